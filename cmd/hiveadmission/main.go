@@ -24,12 +24,14 @@ func main() {
 	admissionCmd.RunAdmissionServer(
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterDeploymentMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewTenantQuotaValidatingAdmissionHook(decoder),
 	)
 }
 
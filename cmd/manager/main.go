@@ -6,7 +6,10 @@ import (
 	golog "log"
 	"math/rand"
 	"net/http"
+	"net/http/pprof"
 	"os"
+	"runtime"
+	"runtime/debug"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	crv1alpha1 "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
@@ -36,26 +40,33 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
+	"github.com/openshift/hive/pkg/controller/clustercost"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
+	"github.com/openshift/hive/pkg/controller/clusteroperationlog"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
 	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/controller/clusterupgrade"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
 	"github.com/openshift/hive/pkg/controller/hibernation"
+	"github.com/openshift/hive/pkg/controller/hivetenant"
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
+	"github.com/openshift/hive/pkg/controller/syncsetrollout"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/controller/velerobackup"
+	"github.com/openshift/hive/pkg/health"
+	"github.com/openshift/hive/pkg/tracing"
 	utillogrus "github.com/openshift/hive/pkg/util/logrus"
 	"github.com/openshift/hive/pkg/version"
 )
@@ -66,27 +77,56 @@ const (
 	leaderElectionLeaseDuration = "360s"
 	leaderElectionRenewDeadline = "270s"
 	leaderElectionRetryPeriod   = "90s"
+
+	// healthCheckTimeout is the timeout of the API requests of the health checks.
+	healthCheckTimeout = 5 * time.Second
+	// leaderElectionHealthTimeout is how long after the expiry of the leader lease the liveness probe still
+	// succeeds while the lease has not been renewed.
+	leaderElectionHealthTimeout = 30 * time.Second
+	// defaultShutdownTimeout is how long the reconciles in flight are given to end on termination. It must be shorter
+	// than the termination grace period of the pods of the controllers.
+	defaultShutdownTimeout = 60 * time.Second
 )
 
+// requiredKinds are the hive kinds which must be served by the API server for the controllers to be ready.
+var requiredKinds = []string{
+	"ClusterClaim",
+	"ClusterDeployment",
+	"ClusterDeprovision",
+	"ClusterImageSet",
+	"ClusterPool",
+	"ClusterProvision",
+	"DNSZone",
+	"HiveConfig",
+	"MachinePool",
+	"SelectorSyncSet",
+	"SyncSet",
+}
+
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
 	clusterclaim.ControllerName:         clusterclaim.Add,
+	clustercost.ControllerName:          clustercost.Add,
 	clusterdeployment.ControllerName:    clusterdeployment.Add,
 	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
+	clusteroperationlog.ControllerName:  clusteroperationlog.Add,
 	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
 	clusterprovision.ControllerName:     clusterprovision.Add,
 	clusterrelocate.ControllerName:      clusterrelocate.Add,
 	clusterstate.ControllerName:         clusterstate.Add,
 	clustersync.ControllerName:          clustersync.Add,
+	clusterupgrade.ControllerName:       clusterupgrade.Add,
 	clusterversion.ControllerName:       clusterversion.Add,
 	controlplanecerts.ControllerName:    controlplanecerts.Add,
 	dnsendpoint.ControllerName:          dnsendpoint.Add,
 	dnszone.ControllerName:              dnszone.Add,
+	hivetenant.ControllerName:           hivetenant.Add,
 	metrics.ControllerName:              metrics.Add,
 	remoteingress.ControllerName:        remoteingress.Add,
 	remotemachineset.ControllerName:     remotemachineset.Add,
 	syncidentityprovider.ControllerName: syncidentityprovider.Add,
+	syncsetrollout.ControllerName:       syncsetrollout.Add,
 	unreachable.ControllerName:          unreachable.Add,
 	velerobackup.ControllerName:         velerobackup.Add,
 	clusterpool.ControllerName:          clusterpool.Add,
@@ -97,6 +137,10 @@ type controllerManagerOptions struct {
 	LogLevel            string
 	Controllers         []string
 	DisabledControllers []string
+	PprofBindAddress    string
+	GCPercent           int
+	MemoryBallastMB     int
+	ShutdownTimeout     time.Duration
 }
 
 func newRootCommand() *cobra.Command {
@@ -121,6 +165,20 @@ func newRootCommand() *cobra.Command {
 			log.Infof("Version: %s", version.String())
 			log.Debug("debug logging enabled")
 
+			if cmd.Flags().Changed("gc-percent") {
+				log.WithField("gcPercent", opts.GCPercent).Info("setting garbage collection target percentage")
+				debug.SetGCPercent(opts.GCPercent)
+			}
+			if opts.MemoryBallastMB > 0 {
+				log.WithField("sizeMB", opts.MemoryBallastMB).Info("allocating memory ballast")
+				ballast := make([]byte, opts.MemoryBallastMB<<20)
+				defer runtime.KeepAlive(ballast)
+			}
+			if opts.PprofBindAddress != "" {
+				log.WithField("address", opts.PprofBindAddress).Info("Starting pprof endpoints")
+				go http.ListenAndServe(opts.PprofBindAddress, newPprofHandler())
+			}
+
 			// Parse leader election options
 			leaseDuration, err := time.ParseDuration(leaderElectionLeaseDuration)
 			if err != nil {
@@ -148,26 +206,67 @@ func newRootCommand() *cobra.Command {
 			hiveNSName := utils.GetHiveNamespace()
 			log.Infof("hive namespace: %s", hiveNSName)
 
-			// Create and start liveness and readiness probe endpoints
-			http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusOK)
-			})
-			http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusOK)
-			})
+			// Create and start liveness and readiness probe endpoints. The liveness probe only fails when this
+			// process holds the leader lease but cannot renew it, the readiness probe also reports the state of
+			// the dependencies of the controllers.
+			healthCfg := rest.CopyConfig(cfg)
+			healthCfg.Timeout = healthCheckTimeout
+			healthClient := kubernetes.NewForConfigOrDie(healthCfg)
+			leaderElectionHealth := leaderelection.NewLeaderHealthzAdaptor(leaderElectionHealthTimeout)
+			healthMux := http.NewServeMux()
+			healthMux.Handle("/healthz", health.NewHandler(leaderElectionHealth))
+			healthMux.Handle("/readyz", health.NewHandler(
+				leaderElectionHealth,
+				health.NewAPIServerCheck(healthClient.Discovery()),
+				health.NewCRDCheck(healthClient.Discovery(), hivev1.SchemeGroupVersion, requiredKinds...),
+				health.NewCertificateSecretCheck("hiveAdmissionServingCert", healthClient.CoreV1(), hiveNSName,
+					constants.HiveAdmissionServingCertSecretName),
+			))
 			log.Info("Starting /healthz and /readyz endpoints")
-			go http.ListenAndServe(":8080", nil)
+			go http.ListenAndServe(":8080", healthMux)
+
+			tracing.Init("hive-controllers")
+			defer tracing.Shutdown()
 
 			// use a Go context so we can tell the leaderelection code when we want to step down
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			// On termination, stop starting reconciles and drain the ones in flight before stopping the manager, so
+			// that resources are not left partially applied to clusters. The leader lease is released once the
+			// manager has stopped. A second signal exits immediately.
+			managerStop := make(chan struct{})
+			leading := make(chan struct{})
+			signalStop := signals.SetupSignalHandler()
+			go func() {
+				<-signalStop
+				log.WithField("timeout", opts.ShutdownTimeout).Info("received termination signal, draining reconciles in flight")
+				if err := utils.DefaultReconcileDrainer().Drain(opts.ShutdownTimeout); err != nil {
+					log.WithError(err).Warn("stopping before all reconciles in flight have ended")
+				} else {
+					log.Info("drained reconciles in flight")
+				}
+				close(managerStop)
+				select {
+				case <-leading:
+					// run stops leading once the manager has stopped.
+				default:
+					cancel()
+				}
+			}()
+
 			run := func(ctx context.Context) {
+				close(leading)
 				// Create a new Cmd to provide shared dependencies and start components
-				mgr, err := manager.New(cfg, manager.Options{
+				mgrOpts := manager.Options{
 					MetricsBindAddress: ":2112",
 					Logger:             utillogrus.NewLogr(log.StandardLogger()),
-				})
+				}
+				if namespaces := utils.GetWatchedNamespaces(); namespaces != nil {
+					log.WithField("namespaces", namespaces).Info("restricting the watches of namespaced objects")
+					mgrOpts.NewCache = utils.NewNamespacedCacheBuilder(namespaces)
+				}
+				mgr, err := manager.New(cfg, mgrOpts)
 				if err != nil {
 					log.Fatal(err)
 				}
@@ -218,7 +317,7 @@ func newRootCommand() *cobra.Command {
 				log.Info("Starting the Cmd.")
 
 				// Start the Cmd
-				err = mgr.Start(signals.SetupSignalHandler())
+				err = mgr.Start(managerStop)
 				if err != nil {
 					log.WithError(err).Error("error running manager")
 				}
@@ -252,6 +351,7 @@ func newRootCommand() *cobra.Command {
 				leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
 					Lock:            lock,
 					ReleaseOnCancel: true,
+					WatchDog:        leaderElectionHealth,
 					LeaseDuration:   leaseDuration,
 					RenewDeadline:   renewDeadline,
 					RetryPeriod:     retryPeriod,
@@ -283,6 +383,14 @@ func newRootCommand() *cobra.Command {
 	cmd.PersistentFlags().StringSliceVar(&opts.Controllers, "controllers", opts.Controllers, "Comma-separated list of controllers to run")
 	cmd.PersistentFlags().StringSliceVar(&opts.DisabledControllers, "disabled-controllers", []string{},
 		"Comma-separated list of controllers to disable (overrides anything enabled with the --controllers param)")
+	cmd.PersistentFlags().StringVar(&opts.PprofBindAddress, "pprof-bind-address", "",
+		"Address on which the pprof endpoints are served, for example 127.0.0.1:6060. The endpoints are not authenticated, so bind them to the loopback interface. They are disabled when empty")
+	cmd.PersistentFlags().IntVar(&opts.GCPercent, "gc-percent", 100,
+		"Garbage collection target percentage, overriding the GOGC environment variable")
+	cmd.PersistentFlags().IntVar(&opts.MemoryBallastMB, "memory-ballast-mb", 0,
+		"Size in MiB of a memory ballast allocated to reduce the frequency of garbage collections")
+	cmd.PersistentFlags().DurationVar(&opts.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout,
+		"Time given to the reconciles in flight to end when the controller manager is terminated, before it stops and releases the leader lease")
 	initializeKlog(cmd.PersistentFlags())
 	flag.CommandLine.Parse([]string{})
 
@@ -300,6 +408,18 @@ func newControllerManagerOptions() *controllerManagerOptions {
 	}
 }
 
+// newPprofHandler returns a handler serving the pprof endpoints. The default mux, on which net/http/pprof registers
+// them, is not served, so that they are only served on the pprof bind address.
+func newPprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
 func initializeKlog(flags *pflag.FlagSet) {
 	golog.SetOutput(klogWriter{}) // Redirect all regular go log output to klog
 	golog.SetFlags(0)
@@ -8,12 +8,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/hive/contrib/pkg/adm"
+	"github.com/openshift/hive/contrib/pkg/awssetup"
 	"github.com/openshift/hive/contrib/pkg/certificate"
+	"github.com/openshift/hive/contrib/pkg/clusterdeployment"
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
+	"github.com/openshift/hive/contrib/pkg/completion"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/fleet"
+	"github.com/openshift/hive/contrib/pkg/machinepool"
 	"github.com/openshift/hive/contrib/pkg/report"
 	"github.com/openshift/hive/contrib/pkg/testresource"
+	"github.com/openshift/hive/contrib/pkg/utils"
 	"github.com/openshift/hive/contrib/pkg/verification"
 	"github.com/openshift/hive/contrib/pkg/version"
 	"github.com/openshift/hive/pkg/imageset"
@@ -54,6 +60,13 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(adm.NewAdmCommand())
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
+	cmd.AddCommand(clusterdeployment.NewClusterDeploymentCommand())
+	cmd.AddCommand(fleet.NewFleetCommand())
+	cmd.AddCommand(machinepool.NewMachinePoolCommand())
+	cmd.AddCommand(awssetup.NewAWSSetupCommand())
+	cmd.AddCommand(completion.NewCompletionCommand())
+
+	cmd.PersistentFlags().Bool(utils.NonInteractiveFlag, false, "Never prompt for the options which were not given, such as in CI")
 
 	return cmd
 }
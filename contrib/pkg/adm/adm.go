@@ -1,7 +1,11 @@
 package adm
 
 import (
+	"github.com/openshift/hive/contrib/pkg/adm/finalizers"
 	"github.com/openshift/hive/contrib/pkg/adm/managedns"
+	"github.com/openshift/hive/contrib/pkg/adm/pprof"
+	"github.com/openshift/hive/contrib/pkg/adm/status"
+	"github.com/openshift/hive/contrib/pkg/adm/sweepsecrets"
 	"github.com/spf13/cobra"
 )
 
@@ -15,5 +19,9 @@ func NewAdmCommand() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(managedns.NewManageDNSCommand())
+	cmd.AddCommand(status.NewStatusCommand())
+	cmd.AddCommand(pprof.NewPprofCommand())
+	cmd.AddCommand(finalizers.NewRemoveFinalizersCommand())
+	cmd.AddCommand(sweepsecrets.NewSweepSecretsCommand())
 	return cmd
 }
@@ -0,0 +1,234 @@
+package finalizers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	kindClusterDeployment = "clusterdeployment"
+	kindDNSZone           = "dnszone"
+)
+
+// Options is the set of options for the remove-finalizers command.
+type Options struct {
+	Namespace string
+	// SkipCloudCheck skips confirming that the cloud resources guarded by the finalizers are gone. It is required for
+	// the objects whose cloud resources cannot be checked by the command.
+	SkipCloudCheck bool
+	// Reason is recorded in the event of the removal of the finalizers.
+	Reason string
+
+	kind string
+	name string
+}
+
+// NewRemoveFinalizersCommand creates a command that force-removes the Hive finalizers of a ClusterDeployment or
+// DNSZone which is stuck being deleted.
+func NewRemoveFinalizersCommand() *cobra.Command {
+	opt := &Options{}
+	cmd := &cobra.Command{
+		Use:   "remove-finalizers (clusterdeployment|dnszone) NAME",
+		Short: "Force-removes the Hive finalizers of a ClusterDeployment or DNSZone stuck being deleted",
+		Long: "Force-removes the Hive finalizers of a ClusterDeployment or DNSZone which is being deleted, once its " +
+			"cloud resources are confirmed gone. On AWS, the command confirms that no resources are tagged for the " +
+			"cluster of the ClusterDeployment, or that the hosted zone of the DNSZone no longer exists. The removal " +
+			"is recorded in a warning event of the object.",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := apis.AddToScheme(scheme.Scheme); err != nil {
+				log.WithError(err).Fatal("error adding hive types to scheme")
+			}
+			c, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube client")
+			}
+			if err := opt.Run(c); err != nil {
+				log.WithError(err).Fatal("error removing finalizers")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the object. Defaults to the namespace of the current context")
+	flags.BoolVar(&opt.SkipCloudCheck, "skip-cloud-check", false, "Remove the finalizers without confirming that the cloud resources are gone")
+	flags.StringVar(&opt.Reason, "reason", "", "Reason for removing the finalizers, recorded in the event of the removal")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *Options) Complete(cmd *cobra.Command, args []string) error {
+	o.kind = strings.ToLower(args[0])
+	o.name = args[1]
+	if o.Namespace == "" {
+		ns, err := contributils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine the default namespace")
+		}
+		o.Namespace = ns
+	}
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *Options) Validate(cmd *cobra.Command) error {
+	switch o.kind {
+	case kindClusterDeployment, kindDNSZone:
+	default:
+		return fmt.Errorf("unsupported kind %q, must be %s or %s", o.kind, kindClusterDeployment, kindDNSZone)
+	}
+	if o.Reason == "" {
+		return errors.New("--reason is required")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *Options) Run(c client.Client) error {
+	logger := log.WithFields(log.Fields{"kind": o.kind, "namespace": o.Namespace, "name": o.name})
+	key := types.NamespacedName{Namespace: o.Namespace, Name: o.name}
+
+	var obj hivev1.MetaRuntimeObject
+	var checkCloud func() error
+	switch o.kind {
+	case kindClusterDeployment:
+		cd := &hivev1.ClusterDeployment{}
+		obj = cd
+		checkCloud = func() error { return checkClusterDeploymentResources(c, cd, logger) }
+	case kindDNSZone:
+		dnsZone := &hivev1.DNSZone{}
+		obj = dnsZone
+		checkCloud = func() error { return checkDNSZoneResources(c, dnsZone, logger) }
+	}
+	if err := c.Get(context.TODO(), key, obj); err != nil {
+		return err
+	}
+	if obj.GetDeletionTimestamp() == nil {
+		return errors.New("object is not being deleted, delete it first")
+	}
+	if len(controllerutils.HiveFinalizers(obj)) == 0 {
+		logger.Info("object has no Hive finalizers")
+		return nil
+	}
+
+	message := fmt.Sprintf("cloud resources confirmed gone by hiveutil, reason: %s", o.Reason)
+	if o.SkipCloudCheck {
+		logger.Warn("skipping the check of the cloud resources")
+		message = fmt.Sprintf("cloud resources check skipped, reason: %s", o.Reason)
+	} else if err := checkCloud(); err != nil {
+		return err
+	}
+
+	removed, err := controllerutils.ForceRemoveFinalizers(c, obj, message, logger)
+	if err != nil {
+		return err
+	}
+	logger.WithField("finalizers", removed).Info("removed finalizers")
+	return nil
+}
+
+// checkClusterDeploymentResources returns an error unless the AWS resources tagged for the cluster of the
+// ClusterDeployment are confirmed gone.
+func checkClusterDeploymentResources(c client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if cd.Spec.Platform.AWS == nil {
+		return errors.New("cloud resources can only be checked on AWS, use --skip-cloud-check after confirming they are gone")
+	}
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		return errors.New("cluster deployment has no infra ID to check cloud resources with, use --skip-cloud-check after confirming they are gone")
+	}
+	awsClient, err := awsclient.NewClient(
+		c,
+		cd.Spec.Platform.AWS.CredentialsSecretRef.Name,
+		cd.Namespace,
+		controllerutils.InstallRegion(cd),
+		cd.Spec.Platform.AWS.ServiceEndpoints,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create AWS client")
+	}
+	tagKey := fmt.Sprintf("kubernetes.io/cluster/%s", cd.Spec.ClusterMetadata.InfraID)
+	var resources []string
+	err = awsClient.GetResourcesPages(
+		&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+				Key:    aws.String(tagKey),
+				Values: []*string{aws.String("owned")},
+			}},
+		},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, r := range page.ResourceTagMappingList {
+				resources = append(resources, aws.StringValue(r.ResourceARN))
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not list the cloud resources of the cluster")
+	}
+	if len(resources) > 0 {
+		logger.WithField("resources", resources).Error("cloud resources of the cluster remain")
+		return fmt.Errorf("%d cloud resources tagged %s=owned remain", len(resources), tagKey)
+	}
+	logger.WithField("tag", tagKey).Info("no cloud resources of the cluster remain")
+	return nil
+}
+
+// checkDNSZoneResources returns an error unless the AWS hosted zone of the DNSZone is confirmed gone.
+func checkDNSZoneResources(c client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if dnsZone.Spec.AWS == nil {
+		return errors.New("cloud resources can only be checked on AWS, use --skip-cloud-check after confirming they are gone")
+	}
+	if dnsZone.Status.AWS == nil || dnsZone.Status.AWS.ZoneID == nil {
+		return errors.New("DNS zone has no hosted zone ID to check, use --skip-cloud-check after confirming it is gone")
+	}
+	region := dnsZone.Spec.AWS.Region
+	if region == "" {
+		region = constants.AWSRoute53Region
+	}
+	awsClient, err := awsclient.NewClient(
+		c,
+		dnsZone.Spec.AWS.CredentialsSecretRef.Name,
+		dnsZone.Namespace,
+		region,
+		dnsZone.Spec.AWS.ServiceEndpoints,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create AWS client")
+	}
+	zoneID := *dnsZone.Status.AWS.ZoneID
+	_, err = awsClient.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+		logger.WithField("zoneID", zoneID).Info("hosted zone no longer exists")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get the hosted zone")
+	}
+	return fmt.Errorf("hosted zone %s still exists", zoneID)
+}
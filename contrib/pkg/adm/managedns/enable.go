@@ -184,7 +184,7 @@ func (o *Options) Run(args []string) error {
 
 	log.Infof("created cloud credentials secret: %s", credsSecret.Name)
 	credsSecret.Namespace = hiveNSName
-	if _, err := rh.ApplyRuntimeObject(credsSecret, scheme.Scheme); err != nil {
+	if _, err := rh.ApplyRuntimeObject(context.Background(), credsSecret, scheme.Scheme); err != nil {
 		log.WithError(err).Fatal("failed to save generated secret")
 	}
 
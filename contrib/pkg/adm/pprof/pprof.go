@@ -0,0 +1,204 @@
+package pprof
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	controllersDeployment = "hive-controllers"
+	pprofPort             = "6060"
+
+	// portForwardTimeout is how long the port forwarding may take to start.
+	portForwardTimeout = 30 * time.Second
+
+	pprofLongDesc = `
+Fetches a profile from the pprof endpoints of the hive-controllers process, which
+are only served on the loopback interface of the pod, by forwarding their port
+with "oc port-forward". The endpoints must be enabled with
+spec.profiling.enablePprof in HiveConfig.
+
+The profile is fetched from a pod of the hive-controllers deployment, or from the
+given pod with --pod. Only the leader runs the controllers, so target the leader
+pod when more than one replica is running.
+
+The profile is written to PROFILE.pprof, or to the file given with --output-file,
+and can be read with "go tool pprof".
+`
+)
+
+// profiles are the profiles served by net/http/pprof. The profile and trace profiles are recorded for a number of
+// seconds.
+var (
+	profiles      = sets.NewString("allocs", "block", "goroutine", "heap", "mutex", "profile", "threadcreate", "trace")
+	timedProfiles = sets.NewString("profile", "trace")
+)
+
+// Options are the options of the pprof command.
+type Options struct {
+	Profile    string
+	Namespace  string
+	Pod        string
+	Seconds    int
+	OutputFile string
+
+	log log.FieldLogger
+}
+
+// NewPprofCommand creates a command that fetches a profile of the hive-controllers process.
+func NewPprofCommand() *cobra.Command {
+	opt := &Options{log: log.WithField("command", "adm pprof")}
+	cmd := &cobra.Command{
+		Use:   "pprof [PROFILE]",
+		Short: "Fetches a profile of the hive-controllers process",
+		Long:  pprofLongDesc,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if len(args) > 0 {
+				opt.Profile = args[0]
+			}
+			if err := opt.validate(); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	opt.Profile = "heap"
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", constants.DefaultHiveNamespace, "Namespace of the hive-controllers deployment")
+	flags.StringVar(&opt.Pod, "pod", "", "Name of the hive-controllers pod to profile. Defaults to a pod of the hive-controllers deployment")
+	flags.IntVar(&opt.Seconds, "seconds", 30, "Duration in seconds of the CPU profile and of the trace")
+	flags.StringVarP(&opt.OutputFile, "output-file", "f", "", "File the profile is written to. Defaults to PROFILE.pprof")
+	return cmd
+}
+
+// validate ensures that option values make sense
+func (o *Options) validate() error {
+	if !profiles.Has(o.Profile) {
+		return fmt.Errorf("unsupported profile %q, must be one of %v", o.Profile, profiles.List())
+	}
+	if timedProfiles.Has(o.Profile) && o.Seconds < 1 {
+		return fmt.Errorf("seconds must be at least 1")
+	}
+	return nil
+}
+
+// run fetches the profile and writes it to the output file.
+func (o *Options) run() error {
+	target := "deployment/" + controllersDeployment
+	if o.Pod != "" {
+		target = "pod/" + o.Pod
+	}
+	localPort, stop, err := o.portForward(target)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	params := url.Values{}
+	timeout := time.Minute
+	if timedProfiles.Has(o.Profile) {
+		params.Set("seconds", strconv.Itoa(o.Seconds))
+		timeout += time.Duration(o.Seconds) * time.Second
+		o.log.WithField("seconds", o.Seconds).Infof("recording %s", o.Profile)
+	}
+	profileURL := fmt.Sprintf("http://127.0.0.1:%d/debug/pprof/%s?%s", localPort, o.Profile, params.Encode())
+	resp, err := (&http.Client{Timeout: timeout}).Get(profileURL)
+	if err != nil {
+		return errors.Wrapf(err, "cannot fetch %s profile, check that spec.profiling.enablePprof is set in HiveConfig", o.Profile)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot fetch %s profile: %s", o.Profile, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read %s profile", o.Profile)
+	}
+
+	outputFile := o.OutputFile
+	if outputFile == "" {
+		outputFile = o.Profile + ".pprof"
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		return errors.Wrap(err, "cannot write profile")
+	}
+	o.log.WithField("file", outputFile).Infof("wrote %s profile", o.Profile)
+	return nil
+}
+
+// portForward forwards a free local port to the pprof port of the target with "oc port-forward". It returns the local
+// port, and a function stopping the port forwarding.
+func (o *Options) portForward(target string) (int, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot find a free local port")
+	}
+	localPort := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	cmd := exec.Command("oc", "port-forward", "--namespace", o.Namespace, "--address", "127.0.0.1", target, fmt.Sprintf("%d:%s", localPort, pprofPort))
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot forward port")
+	}
+	stderr := &strings.Builder{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return 0, nil, errors.Wrap(err, "cannot run oc port-forward")
+	}
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+
+	// oc prints a line for each address it forwards from once it is ready.
+	ready := make(chan bool, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		forwarding := false
+		for scanner.Scan() {
+			if !forwarding && strings.HasPrefix(scanner.Text(), "Forwarding from") {
+				forwarding = true
+				ready <- true
+			}
+		}
+		if !forwarding {
+			ready <- false
+		}
+		io.Copy(ioutil.Discard, stdout)
+	}()
+	select {
+	case ok := <-ready:
+		if !ok {
+			stop()
+			return 0, nil, fmt.Errorf("cannot forward port of %s: %s", target, strings.TrimSpace(stderr.String()))
+		}
+	case <-time.After(portForwardTimeout):
+		stop()
+		return 0, nil, fmt.Errorf("timed out forwarding port of %s", target)
+	}
+	o.log.WithField("target", target).Debug("forwarding the pprof port")
+	return localPort, stop, nil
+}
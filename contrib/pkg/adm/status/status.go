@@ -0,0 +1,330 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	hiveConfigName          = "hive"
+	hiveAdmissionAPIService = "v1.admission.hive.openshift.io"
+)
+
+// hiveAPIGroups are the API groups of the CRDs reported by the status command.
+var hiveAPIGroups = []string{hivev1.SchemeGroupVersion.Group, hiveintv1alpha1.SchemeGroupVersion.Group}
+
+// Options is the set of options for the status command.
+type Options struct {
+	// StuckThreshold is the duration after which provisions and deprovisions which have not completed are reported
+	// as stuck.
+	StuckThreshold time.Duration
+	// MaxItems is the maximum number of stuck provisions, stuck deprovisions and failing syncsets listed.
+	MaxItems int
+
+	out io.Writer
+}
+
+// NewStatusCommand creates a command that prints an overview of the health of the Hive hub cluster.
+func NewStatusCommand() *cobra.Command {
+	opt := &Options{out: os.Stdout}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Prints an overview of the health of Hive in the current cluster",
+		Long: "Prints the readiness of the Hive deployments and webhooks, the versions of the Hive CRDs, the number " +
+			"of clusters by state, and the provisions, deprovisions and syncsets which need attention.",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := apis.AddToScheme(scheme.Scheme); err != nil {
+				log.WithError(err).Fatal("error adding hive types to scheme")
+			}
+			if err := apiextv1beta1.AddToScheme(scheme.Scheme); err != nil {
+				log.WithError(err).Fatal("error adding apiextensions types to scheme")
+			}
+			if err := apiregistrationv1.AddToScheme(scheme.Scheme); err != nil {
+				log.WithError(err).Fatal("error adding apiregistration types to scheme")
+			}
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+			if err := opt.Run(dynClient); err != nil {
+				log.WithError(err).Fatal("error getting hive status")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.DurationVar(&opt.StuckThreshold, "stuck-threshold", 2*time.Hour, "Duration after which provisions and deprovisions which have not completed are reported as stuck")
+	flags.IntVar(&opt.MaxItems, "max-items", 10, "Maximum number of stuck provisions, stuck deprovisions and failing syncsets to list")
+	return cmd
+}
+
+// Run prints the status of Hive.
+func (o *Options) Run(c client.Client) error {
+	w := tabwriter.NewWriter(o.out, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	hiveNSName := constants.DefaultHiveNamespace
+	hiveConfig := &hivev1.HiveConfig{}
+	switch err := c.Get(context.Background(), types.NamespacedName{Name: hiveConfigName}, hiveConfig); {
+	case apierrors.IsNotFound(err):
+		fmt.Fprintf(w, "HiveConfig %q not found, Hive is not deployed\n", hiveConfigName)
+	case err != nil:
+		return err
+	case hiveConfig.Spec.TargetNamespace != "":
+		hiveNSName = hiveConfig.Spec.TargetNamespace
+	}
+
+	sections := []func(client.Client, io.Writer, string) error{
+		o.printComponents,
+		o.printCRDs,
+		o.printClusters,
+		o.printProvisions,
+		o.printDeprovisions,
+		o.printSyncSets,
+	}
+	for _, section := range sections {
+		if err := section(c, w, hiveNSName); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func (o *Options) printComponents(c client.Client, w io.Writer, hiveNSName string) error {
+	fmt.Fprintf(w, "COMPONENT\tNAMESPACE\tSTATUS\n")
+	for _, name := range []string{"hive-controllers", "hiveadmission"} {
+		deployment := &appsv1.Deployment{}
+		err := c.Get(context.Background(), types.NamespacedName{Namespace: hiveNSName, Name: name}, deployment)
+		status, err := readiness(err, deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "deployment/%s\t%s\t%s\n", name, hiveNSName, status)
+	}
+	statefulSet := &appsv1.StatefulSet{}
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: hiveNSName, Name: "hive-clustersync"}, statefulSet)
+	status, err := readiness(err, statefulSet.Status.ReadyReplicas, statefulSet.Status.Replicas)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "statefulset/hive-clustersync\t%s\t%s\n", hiveNSName, status)
+
+	apiService := &apiregistrationv1.APIService{}
+	switch err := c.Get(context.Background(), types.NamespacedName{Name: hiveAdmissionAPIService}, apiService); {
+	case apierrors.IsNotFound(err):
+		status = "not found"
+	case err != nil:
+		return err
+	default:
+		status = "not available"
+		for _, cond := range apiService.Status.Conditions {
+			if cond.Type != apiregistrationv1.Available {
+				continue
+			}
+			if cond.Status == apiregistrationv1.ConditionTrue {
+				status = "available"
+			} else {
+				status = fmt.Sprintf("not available: %s", cond.Message)
+			}
+		}
+	}
+	fmt.Fprintf(w, "apiservice/%s\t\t%s\n", hiveAdmissionAPIService, status)
+	return nil
+}
+
+// readiness returns a description of the readiness of a deployment or statefulset given the error from getting it.
+func readiness(err error, ready, replicas int32) (string, error) {
+	switch {
+	case apierrors.IsNotFound(err):
+		return "not found", nil
+	case err != nil:
+		return "", err
+	case replicas == 0:
+		return "scaled down", nil
+	case ready < replicas:
+		return fmt.Sprintf("not ready (%d/%d ready)", ready, replicas), nil
+	default:
+		return fmt.Sprintf("ready (%d/%d ready)", ready, replicas), nil
+	}
+}
+
+func (o *Options) printCRDs(c client.Client, w io.Writer, _ string) error {
+	crdList := &apiextv1beta1.CustomResourceDefinitionList{}
+	if err := c.List(context.Background(), crdList); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "CRD\tSERVED VERSIONS\tSTORED VERSIONS\n")
+	var crds []apiextv1beta1.CustomResourceDefinition
+	for _, crd := range crdList.Items {
+		for _, group := range hiveAPIGroups {
+			if crd.Spec.Group == group {
+				crds = append(crds, crd)
+			}
+		}
+	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
+	for _, crd := range crds {
+		var served []string
+		for _, version := range crd.Spec.Versions {
+			if version.Served {
+				served = append(served, version.Name)
+			}
+		}
+		if len(served) == 0 && crd.Spec.Version != "" {
+			served = append(served, crd.Spec.Version)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", crd.Name, strings.Join(served, ","), strings.Join(crd.Status.StoredVersions, ","))
+	}
+	return nil
+}
+
+func (o *Options) printClusters(c client.Client, w io.Writer, _ string) error {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return err
+	}
+	var installed, provisioning, deprovisioning, hibernating, unreachable int
+	for _, cd := range cdList.Items {
+		switch {
+		case cd.DeletionTimestamp != nil:
+			deprovisioning++
+		case !cd.Spec.Installed:
+			provisioning++
+		case cd.Spec.PowerState == hivev1.HibernatingClusterPowerState:
+			installed++
+			hibernating++
+		default:
+			installed++
+		}
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); cond != nil && cond.Status == "True" {
+			unreachable++
+		}
+	}
+	fmt.Fprintf(w, "CLUSTERS\tCOUNT\n")
+	fmt.Fprintf(w, "total\t%d\n", len(cdList.Items))
+	fmt.Fprintf(w, "installed\t%d\n", installed)
+	fmt.Fprintf(w, "hibernating\t%d\n", hibernating)
+	fmt.Fprintf(w, "provisioning\t%d\n", provisioning)
+	fmt.Fprintf(w, "deprovisioning\t%d\n", deprovisioning)
+	fmt.Fprintf(w, "unreachable\t%d\n", unreachable)
+	return nil
+}
+
+func (o *Options) printProvisions(c client.Client, w io.Writer, _ string) error {
+	provisionList := &hivev1.ClusterProvisionList{}
+	if err := c.List(context.Background(), provisionList); err != nil {
+		return err
+	}
+	var stuck []hivev1.ClusterProvision
+	for _, provision := range provisionList.Items {
+		switch provision.Spec.Stage {
+		case hivev1.ClusterProvisionStageComplete, hivev1.ClusterProvisionStageFailed:
+			continue
+		}
+		if time.Since(provision.CreationTimestamp.Time) > o.StuckThreshold {
+			stuck = append(stuck, provision)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].CreationTimestamp.Before(&stuck[j].CreationTimestamp) })
+	fmt.Fprintf(w, "STUCK PROVISIONS (%d)\tSTAGE\tAGE\n", len(stuck))
+	for i, provision := range stuck {
+		if i == o.MaxItems {
+			fmt.Fprintf(w, "...\t\t\n")
+			break
+		}
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\n", provision.Namespace, provision.Name, provision.Spec.Stage, age(provision.CreationTimestamp.Time))
+	}
+	return nil
+}
+
+func (o *Options) printDeprovisions(c client.Client, w io.Writer, _ string) error {
+	deprovisionList := &hivev1.ClusterDeprovisionList{}
+	if err := c.List(context.Background(), deprovisionList); err != nil {
+		return err
+	}
+	var stuck []hivev1.ClusterDeprovision
+	for _, deprovision := range deprovisionList.Items {
+		if !deprovision.Status.Completed && time.Since(deprovision.CreationTimestamp.Time) > o.StuckThreshold {
+			stuck = append(stuck, deprovision)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].CreationTimestamp.Before(&stuck[j].CreationTimestamp) })
+	fmt.Fprintf(w, "STUCK DEPROVISIONS (%d)\tAGE\n", len(stuck))
+	for i, deprovision := range stuck {
+		if i == o.MaxItems {
+			fmt.Fprintf(w, "...\t\n")
+			break
+		}
+		fmt.Fprintf(w, "%s/%s\t%s\n", deprovision.Namespace, deprovision.Name, age(deprovision.CreationTimestamp.Time))
+	}
+	return nil
+}
+
+func (o *Options) printSyncSets(c client.Client, w io.Writer, _ string) error {
+	clusterSyncList := &hiveintv1alpha1.ClusterSyncList{}
+	if err := c.List(context.Background(), clusterSyncList); err != nil {
+		return err
+	}
+	// failingClusters counts the clusters each SyncSet or SelectorSyncSet fails to apply to.
+	failingClusters := map[string]int{}
+	for _, clusterSync := range clusterSyncList.Items {
+		for _, status := range clusterSync.Status.SyncSets {
+			if status.Result == hiveintv1alpha1.FailureSyncSetResult {
+				failingClusters[fmt.Sprintf("syncset/%s/%s", clusterSync.Namespace, status.Name)]++
+			}
+		}
+		for _, status := range clusterSync.Status.SelectorSyncSets {
+			if status.Result == hiveintv1alpha1.FailureSyncSetResult {
+				failingClusters["selectorsyncset/"+status.Name]++
+			}
+		}
+	}
+	names := make([]string, 0, len(failingClusters))
+	for name := range failingClusters {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if failingClusters[names[i]] != failingClusters[names[j]] {
+			return failingClusters[names[i]] > failingClusters[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	fmt.Fprintf(w, "FAILING SYNCSETS (%d)\tFAILING CLUSTERS\n", len(names))
+	for i, name := range names {
+		if i == o.MaxItems {
+			fmt.Fprintf(w, "...\t\n")
+			break
+		}
+		fmt.Fprintf(w, "%s\t%d\n", name, failingClusters[name])
+	}
+	return nil
+}
+
+func age(t time.Time) string {
+	return time.Since(t).Round(time.Minute).String()
+}
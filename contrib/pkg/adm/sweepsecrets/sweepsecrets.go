@@ -0,0 +1,155 @@
+package sweepsecrets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// sweptSecretTypes are the values of the secret type label of the secrets generated by Hive for cluster deployments.
+var sweptSecretTypes = sets.NewString(
+	constants.SecretTypeKubeConfig,
+	constants.SecretTypeKubeAdminCreds,
+	constants.SecretTypeMergedPullSecret,
+)
+
+// Options is the set of options for the sweep-secrets command.
+type Options struct {
+	// Namespace is the namespace swept. All namespaces are swept when empty.
+	Namespace string
+	// DryRun lists the orphaned secrets without deleting them.
+	DryRun bool
+
+	out io.Writer
+}
+
+// NewSweepSecretsCommand creates a command that deletes the secrets generated by Hive for cluster deployments which
+// no longer exist.
+func NewSweepSecretsCommand() *cobra.Command {
+	opt := &Options{out: os.Stdout}
+	cmd := &cobra.Command{
+		Use:   "sweep-secrets",
+		Short: "Deletes the secrets generated by Hive for cluster deployments and provisions which no longer exist",
+		Long: "Deletes the admin kubeconfig, admin password and merged pull secrets generated by Hive whose " +
+			"ClusterDeployment and ClusterProvision no longer exist. Such secrets are left behind by clusters " +
+			"deleted before Hive made the ClusterDeployment an owner of the secrets of every provision.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := apis.AddToScheme(scheme.Scheme); err != nil {
+				log.WithError(err).Fatal("error adding hive types to scheme")
+			}
+			c, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube client")
+			}
+			if err := opt.Run(c); err != nil {
+				log.WithError(err).Fatal("error sweeping secrets")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace to sweep. Defaults to all namespaces")
+	flags.BoolVar(&opt.DryRun, "dry-run", false, "List the orphaned secrets without deleting them")
+	return cmd
+}
+
+// Run executes the command
+func (o *Options) Run(c client.Client) error {
+	secrets := &corev1.SecretList{}
+	if err := c.List(context.TODO(), secrets, client.InNamespace(o.Namespace), client.HasLabels{constants.SecretTypeLabel}); err != nil {
+		return err
+	}
+	owners := map[string]*namespaceOwners{}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !sweptSecretTypes.Has(secret.Labels[constants.SecretTypeLabel]) {
+			continue
+		}
+		nsOwners, ok := owners[secret.Namespace]
+		if !ok {
+			var err error
+			if nsOwners, err = listNamespaceOwners(c, secret.Namespace); err != nil {
+				return err
+			}
+			owners[secret.Namespace] = nsOwners
+		}
+		if !nsOwners.isOrphaned(secret) {
+			continue
+		}
+		if o.DryRun {
+			fmt.Fprintf(o.out, "secret %s/%s is orphaned\n", secret.Namespace, secret.Name)
+			continue
+		}
+		if err := c.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(o.out, "secret %s/%s deleted\n", secret.Namespace, secret.Name)
+	}
+	return nil
+}
+
+// namespaceOwners are the possible owners of the secrets of a namespace.
+type namespaceOwners struct {
+	clusterDeployments sets.String
+	clusterProvisions  sets.String
+	referencedSecrets  sets.String
+}
+
+func listNamespaceOwners(c client.Client, namespace string) (*namespaceOwners, error) {
+	owners := &namespaceOwners{
+		clusterDeployments: sets.NewString(),
+		clusterProvisions:  sets.NewString(),
+		referencedSecrets:  sets.NewString(),
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.TODO(), cds, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, cd := range cds.Items {
+		owners.clusterDeployments.Insert(cd.Name)
+		if md := cd.Spec.ClusterMetadata; md != nil {
+			owners.referencedSecrets.Insert(md.AdminKubeconfigSecretRef.Name, md.AdminPasswordSecretRef.Name)
+		}
+	}
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := c.List(context.TODO(), provisions, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, provision := range provisions.Items {
+		owners.clusterProvisions.Insert(provision.Name)
+	}
+	return owners, nil
+}
+
+// isOrphaned returns true if neither the cluster deployment nor the cluster provision of the secret exist, and the
+// secret is not referenced by a cluster deployment.
+func (o *namespaceOwners) isOrphaned(secret *corev1.Secret) bool {
+	cdName, hasCD := secret.Labels[constants.ClusterDeploymentNameLabel]
+	provisionName, hasProvision := secret.Labels[constants.ClusterProvisionNameLabel]
+	if !hasCD && !hasProvision {
+		return false
+	}
+	if hasCD && o.clusterDeployments.Has(cdName) {
+		return false
+	}
+	if hasProvision && o.clusterProvisions.Has(provisionName) {
+		return false
+	}
+	return !o.referencedSecrets.Has(secret.Name)
+}
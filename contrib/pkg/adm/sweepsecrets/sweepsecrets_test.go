@@ -0,0 +1,215 @@
+package sweepsecrets
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testNamespace = "test-namespace"
+
+func testSecret(namespace, name, secretType string, labels map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{},
+		},
+	}
+	if secretType != "" {
+		secret.Labels[constants.SecretTypeLabel] = secretType
+	}
+	for k, v := range labels {
+		secret.Labels[k] = v
+	}
+	return secret
+}
+
+func cdLabel(name string) map[string]string {
+	return map[string]string{constants.ClusterDeploymentNameLabel: name}
+}
+
+func provisionLabel(name string) map[string]string {
+	return map[string]string{constants.ClusterProvisionNameLabel: name}
+}
+
+func TestIsOrphaned(t *testing.T) {
+	owners := &namespaceOwners{
+		clusterDeployments: sets.NewString("existing-cd"),
+		clusterProvisions:  sets.NewString("existing-provision"),
+		referencedSecrets:  sets.NewString("referenced-secret"),
+	}
+	cases := []struct {
+		name           string
+		secret         *corev1.Secret
+		expectOrphaned bool
+	}{
+		{
+			name:   "no owner labels",
+			secret: testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, nil),
+		},
+		{
+			name:   "existing cluster deployment",
+			secret: testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, cdLabel("existing-cd")),
+		},
+		{
+			name:           "missing cluster deployment",
+			secret:         testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, cdLabel("missing-cd")),
+			expectOrphaned: true,
+		},
+		{
+			name:   "existing provision",
+			secret: testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, provisionLabel("existing-provision")),
+		},
+		{
+			name:           "missing provision",
+			secret:         testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, provisionLabel("missing-provision")),
+			expectOrphaned: true,
+		},
+		{
+			name: "missing cluster deployment with existing provision",
+			secret: testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, map[string]string{
+				constants.ClusterDeploymentNameLabel: "missing-cd",
+				constants.ClusterProvisionNameLabel:  "existing-provision",
+			}),
+		},
+		{
+			name: "missing cluster deployment and provision",
+			secret: testSecret(testNamespace, "secret", constants.SecretTypeKubeConfig, map[string]string{
+				constants.ClusterDeploymentNameLabel: "missing-cd",
+				constants.ClusterProvisionNameLabel:  "missing-provision",
+			}),
+			expectOrphaned: true,
+		},
+		{
+			name:   "referenced by a cluster deployment",
+			secret: testSecret(testNamespace, "referenced-secret", constants.SecretTypeKubeConfig, cdLabel("missing-cd")),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectOrphaned, owners.isOrphaned(tc.secret), "unexpected orphaned result")
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme), "cannot add core types to scheme")
+	require.NoError(t, apis.AddToScheme(scheme), "cannot add hive types to scheme")
+
+	existing := []runtime.Object{
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "existing-cd"},
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterMetadata: &hivev1.ClusterMetadata{
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "referenced-kubeconfig"},
+					AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: "referenced-password"},
+				},
+			},
+		},
+		&hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "existing-provision"},
+		},
+		testSecret(testNamespace, "cd-kubeconfig", constants.SecretTypeKubeConfig, cdLabel("existing-cd")),
+		testSecret(testNamespace, "orphaned-kubeconfig", constants.SecretTypeKubeConfig, cdLabel("missing-cd")),
+		testSecret(testNamespace, "orphaned-password", constants.SecretTypeKubeAdminCreds, provisionLabel("missing-provision")),
+		testSecret(testNamespace, "orphaned-pull-secret", constants.SecretTypeMergedPullSecret, cdLabel("missing-cd")),
+		testSecret(testNamespace, "provision-kubeconfig", constants.SecretTypeKubeConfig, map[string]string{
+			constants.ClusterDeploymentNameLabel: "missing-cd",
+			constants.ClusterProvisionNameLabel:  "existing-provision",
+		}),
+		testSecret(testNamespace, "referenced-kubeconfig", constants.SecretTypeKubeConfig, cdLabel("missing-cd")),
+		testSecret(testNamespace, "referenced-password", constants.SecretTypeKubeAdminCreds, provisionLabel("missing-provision")),
+		testSecret(testNamespace, "other-type", "other", cdLabel("missing-cd")),
+		testSecret(testNamespace, "no-type", "", cdLabel("missing-cd")),
+		testSecret("other-namespace", "orphaned-kubeconfig", constants.SecretTypeKubeConfig, cdLabel("missing-cd")),
+	}
+	remainingInTestNamespace := []string{
+		"cd-kubeconfig",
+		"no-type",
+		"other-type",
+		"provision-kubeconfig",
+		"referenced-kubeconfig",
+		"referenced-password",
+	}
+	orphaned := []string{"orphaned-kubeconfig", "orphaned-password", "orphaned-pull-secret"}
+
+	cases := []struct {
+		name             string
+		namespace        string
+		dryRun           bool
+		expectedOutput   string
+		expectDeleted    bool
+		expectOtherSwept bool
+	}{
+		{
+			name:      "namespace",
+			namespace: testNamespace,
+			expectedOutput: "secret test-namespace/orphaned-kubeconfig deleted\n" +
+				"secret test-namespace/orphaned-password deleted\n" +
+				"secret test-namespace/orphaned-pull-secret deleted\n",
+			expectDeleted: true,
+		},
+		{
+			name:      "dry run",
+			namespace: testNamespace,
+			dryRun:    true,
+			expectedOutput: "secret test-namespace/orphaned-kubeconfig is orphaned\n" +
+				"secret test-namespace/orphaned-password is orphaned\n" +
+				"secret test-namespace/orphaned-pull-secret is orphaned\n",
+		},
+		{
+			name: "all namespaces",
+			expectedOutput: "secret other-namespace/orphaned-kubeconfig deleted\n" +
+				"secret test-namespace/orphaned-kubeconfig deleted\n" +
+				"secret test-namespace/orphaned-password deleted\n" +
+				"secret test-namespace/orphaned-pull-secret deleted\n",
+			expectDeleted:    true,
+			expectOtherSwept: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, existing...)
+			out := &bytes.Buffer{}
+			o := &Options{Namespace: tc.namespace, DryRun: tc.dryRun, out: out}
+			require.NoError(t, o.Run(c), "unexpected error")
+			assert.Equal(t, tc.expectedOutput, out.String(), "unexpected output")
+
+			secrets := &corev1.SecretList{}
+			require.NoError(t, c.List(context.TODO(), secrets, client.InNamespace(testNamespace)), "cannot list secrets")
+			var names []string
+			for _, s := range secrets.Items {
+				names = append(names, s.Name)
+			}
+			expected := sets.NewString(remainingInTestNamespace...)
+			if !tc.expectDeleted {
+				expected.Insert(orphaned...)
+			}
+			assert.Equal(t, expected.List(), sets.NewString(names...).List(), "unexpected remaining secrets")
+
+			otherSecrets := &corev1.SecretList{}
+			require.NoError(t, c.List(context.TODO(), otherSecrets, client.InNamespace("other-namespace")), "cannot list secrets")
+			if tc.expectOtherSwept {
+				assert.Empty(t, otherSecrets.Items, "expected the secret of the other namespace to be deleted")
+			} else {
+				assert.Len(t, otherSecrets.Items, 1, "expected the secret of the other namespace to remain")
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package awssetup
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewAWSSetupCommand returns a command with sub-commands that create the prerequisites in AWS that Hive needs to
+// manage clusters.
+func NewAWSSetupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "aws-setup",
+		Short: "Utilities to create the AWS prerequisites for Hive",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewManagedDNSCommand())
+	cmd.AddCommand(NewIAMUserCommand())
+	cmd.AddCommand(NewSTSCredentialsCommand())
+	return cmd
+}
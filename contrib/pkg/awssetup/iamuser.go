@@ -0,0 +1,421 @@
+package awssetup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const iamUserLongDesc = `
+OVERVIEW
+The iam-user command creates an IAM user with the permissions that Hive needs,
+creates an access key for the user, and prints a secret with the access key
+that can be applied to the Hive cluster.
+
+The dns scope grants the permissions needed to manage the DNS of the domains
+managed by Hive. The cluster scope grants the permissions needed to install,
+manage, and deprovision clusters.
+
+When the user already has an access key, only its permissions are updated and
+no secret is printed, as the secret access key of an existing key cannot be
+retrieved. Use --rotate-access-key to create a new access key, print its
+secret, and delete the previous access keys of the user.
+`
+
+const (
+	iamScopeDNS     = "dns"
+	iamScopeCluster = "cluster"
+
+	// maxAccessKeys is the number of access keys an IAM user can have.
+	maxAccessKeys = 2
+)
+
+// iamScopeActions are the actions allowed to the user of each scope. The dns actions are those of the dnszone
+// controller. The cluster actions are those the installer requires to create and destroy the cluster and its network,
+// along with those of the controllers managing the clusters, such as hibernation and the remote machine sets, and of
+// the cloud credential operator of the clusters, which mints the credentials of the cluster components from the
+// credentials of the install.
+var iamScopeActions = map[string][]string{
+	iamScopeDNS: {
+		"route53:AssociateVPCWithHostedZone",
+		"route53:ChangeResourceRecordSets",
+		"route53:ChangeTagsForResource",
+		"route53:CreateHostedZone",
+		"route53:CreateVPCAssociationAuthorization",
+		"route53:DeleteHostedZone",
+		"route53:DeleteVPCAssociationAuthorization",
+		"route53:DisassociateVPCFromHostedZone",
+		"route53:GetChange",
+		"route53:GetHostedZone",
+		"route53:ListHostedZones",
+		"route53:ListHostedZonesByName",
+		"route53:ListResourceRecordSets",
+		"route53:ListTagsForResource",
+		"tag:GetResources",
+	},
+	iamScopeCluster: {
+		"autoscaling:DescribeAutoScalingGroups",
+
+		"ec2:AllocateAddress",
+		"ec2:AssociateAddress",
+		"ec2:AssociateDhcpOptions",
+		"ec2:AssociateRouteTable",
+		"ec2:AttachInternetGateway",
+		"ec2:AttachNetworkInterface",
+		"ec2:AuthorizeSecurityGroupEgress",
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:CopyImage",
+		"ec2:CreateDhcpOptions",
+		"ec2:CreateInternetGateway",
+		"ec2:CreateNatGateway",
+		"ec2:CreateNetworkInterface",
+		"ec2:CreateRoute",
+		"ec2:CreateRouteTable",
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateSubnet",
+		"ec2:CreateTags",
+		"ec2:CreateVolume",
+		"ec2:CreateVpc",
+		"ec2:CreateVpcEndpoint",
+		"ec2:DeleteDhcpOptions",
+		"ec2:DeleteInternetGateway",
+		"ec2:DeleteNatGateway",
+		"ec2:DeleteNetworkInterface",
+		"ec2:DeleteRoute",
+		"ec2:DeleteRouteTable",
+		"ec2:DeleteSecurityGroup",
+		"ec2:DeleteSnapshot",
+		"ec2:DeleteSubnet",
+		"ec2:DeleteVolume",
+		"ec2:DeleteVpc",
+		"ec2:DeleteVpcEndpoints",
+		"ec2:DeregisterImage",
+		"ec2:DescribeAccountAttributes",
+		"ec2:DescribeAddresses",
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeDhcpOptions",
+		"ec2:DescribeImages",
+		"ec2:DescribeInstanceAttribute",
+		"ec2:DescribeInstanceCreditSpecifications",
+		"ec2:DescribeInstances",
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeKeyPairs",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeNetworkAcls",
+		"ec2:DescribeNetworkInterfaces",
+		"ec2:DescribePrefixLists",
+		"ec2:DescribeRegions",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeTags",
+		"ec2:DescribeVolumes",
+		"ec2:DescribeVpcAttribute",
+		"ec2:DescribeVpcClassicLink",
+		"ec2:DescribeVpcClassicLinkDnsSupport",
+		"ec2:DescribeVpcEndpoints",
+		"ec2:DescribeVpcs",
+		"ec2:DetachInternetGateway",
+		"ec2:DisassociateRouteTable",
+		"ec2:GetEbsDefaultKmsKeyId",
+		"ec2:ModifyInstanceAttribute",
+		"ec2:ModifyNetworkInterfaceAttribute",
+		"ec2:ModifySubnetAttribute",
+		"ec2:ModifyVpcAttribute",
+		"ec2:ReleaseAddress",
+		"ec2:ReplaceRouteTableAssociation",
+		"ec2:RevokeSecurityGroupEgress",
+		"ec2:RevokeSecurityGroupIngress",
+		"ec2:RunInstances",
+		"ec2:StartInstances",
+		"ec2:StopInstances",
+		"ec2:TerminateInstances",
+
+		"elasticloadbalancing:AddTags",
+		"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+		"elasticloadbalancing:AttachLoadBalancerToSubnets",
+		"elasticloadbalancing:ConfigureHealthCheck",
+		"elasticloadbalancing:CreateListener",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"elasticloadbalancing:CreateLoadBalancerListeners",
+		"elasticloadbalancing:CreateTargetGroup",
+		"elasticloadbalancing:DeleteLoadBalancer",
+		"elasticloadbalancing:DeleteTargetGroup",
+		"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+		"elasticloadbalancing:DeregisterTargets",
+		"elasticloadbalancing:DescribeInstanceHealth",
+		"elasticloadbalancing:DescribeListeners",
+		"elasticloadbalancing:DescribeLoadBalancerAttributes",
+		"elasticloadbalancing:DescribeLoadBalancers",
+		"elasticloadbalancing:DescribeTags",
+		"elasticloadbalancing:DescribeTargetGroupAttributes",
+		"elasticloadbalancing:DescribeTargetGroups",
+		"elasticloadbalancing:DescribeTargetHealth",
+		"elasticloadbalancing:ModifyLoadBalancerAttributes",
+		"elasticloadbalancing:ModifyTargetGroup",
+		"elasticloadbalancing:ModifyTargetGroupAttributes",
+		"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+		"elasticloadbalancing:RegisterTargets",
+		"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
+
+		"iam:AddRoleToInstanceProfile",
+		"iam:CreateAccessKey",
+		"iam:CreateInstanceProfile",
+		"iam:CreateRole",
+		"iam:CreateUser",
+		"iam:DeleteAccessKey",
+		"iam:DeleteInstanceProfile",
+		"iam:DeleteRole",
+		"iam:DeleteRolePolicy",
+		"iam:DeleteUser",
+		"iam:DeleteUserPolicy",
+		"iam:GetInstanceProfile",
+		"iam:GetRole",
+		"iam:GetRolePolicy",
+		"iam:GetUser",
+		"iam:GetUserPolicy",
+		"iam:ListAccessKeys",
+		"iam:ListInstanceProfiles",
+		"iam:ListInstanceProfilesForRole",
+		"iam:ListRolePolicies",
+		"iam:ListRoles",
+		"iam:ListUserPolicies",
+		"iam:ListUsers",
+		"iam:PassRole",
+		"iam:PutRolePolicy",
+		"iam:PutUserPolicy",
+		"iam:RemoveRoleFromInstanceProfile",
+		"iam:SimulatePrincipalPolicy",
+		"iam:TagRole",
+		"iam:TagUser",
+
+		"route53:ChangeResourceRecordSets",
+		"route53:ChangeTagsForResource",
+		"route53:CreateHostedZone",
+		"route53:DeleteHostedZone",
+		"route53:GetChange",
+		"route53:GetHostedZone",
+		"route53:ListHostedZones",
+		"route53:ListHostedZonesByName",
+		"route53:ListResourceRecordSets",
+		"route53:ListTagsForResource",
+		"route53:UpdateHostedZoneComment",
+
+		"s3:CreateBucket",
+		"s3:DeleteBucket",
+		"s3:DeleteObject",
+		"s3:GetAccelerateConfiguration",
+		"s3:GetBucketAcl",
+		"s3:GetBucketCors",
+		"s3:GetBucketLocation",
+		"s3:GetBucketLogging",
+		"s3:GetBucketObjectLockConfiguration",
+		"s3:GetBucketReplication",
+		"s3:GetBucketRequestPayment",
+		"s3:GetBucketTagging",
+		"s3:GetBucketVersioning",
+		"s3:GetBucketWebsite",
+		"s3:GetEncryptionConfiguration",
+		"s3:GetLifecycleConfiguration",
+		"s3:GetObject",
+		"s3:GetObjectAcl",
+		"s3:GetObjectTagging",
+		"s3:GetObjectVersion",
+		"s3:GetReplicationConfiguration",
+		"s3:ListBucket",
+		"s3:ListBucketVersions",
+		"s3:PutBucketAcl",
+		"s3:PutBucketTagging",
+		"s3:PutEncryptionConfiguration",
+		"s3:PutObject",
+		"s3:PutObjectAcl",
+		"s3:PutObjectTagging",
+
+		"servicequotas:ListAWSDefaultServiceQuotas",
+		"sts:GetCallerIdentity",
+		"tag:GetResources",
+	},
+}
+
+// IAMUserOptions is the set of options to create an IAM user for Hive
+type IAMUserOptions struct {
+	UserName   string
+	Scope      string
+	SecretName string
+	Namespace  string
+	Region     string
+	// RotateAccessKey creates a new access key even when the user already has one, and deletes the previous ones.
+	RotateAccessKey bool
+
+	client awsclient.Client
+	out    io.Writer
+}
+
+// NewIAMUserCommand returns a command that will create an IAM user with the permissions that Hive needs
+func NewIAMUserCommand() *cobra.Command {
+	opt := &IAMUserOptions{}
+	cmd := &cobra.Command{
+		Use:   "iam-user",
+		Short: "Creates an AWS IAM user and credentials secret for Hive",
+		Long:  iamUserLongDesc,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Error("Error")
+				os.Exit(1)
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.UserName, "user-name", "hive", "Name of the IAM user")
+	flags.StringVar(&opt.Scope, "scope", iamScopeCluster, "Permissions to grant to the user: dns|cluster")
+	flags.StringVar(&opt.SecretName, "secret-name", "", "Name of the generated secret (defaults to <user-name>-aws-creds)")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", constants.DefaultHiveNamespace, "Namespace of the generated secret")
+	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use for API calls")
+	flags.BoolVar(&opt.RotateAccessKey, "rotate-access-key", false, "Create a new access key when the user already has one, and delete the previous access keys")
+	return cmd
+}
+
+// Complete finalizes options by setting defaults
+func (o *IAMUserOptions) Complete(cmd *cobra.Command, args []string) error {
+	if o.SecretName == "" {
+		o.SecretName = fmt.Sprintf("%s-aws-creds", o.UserName)
+	}
+	client, err := awsclient.NewClient(nil, "", "", o.Region, nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot create AWS client")
+	}
+	o.client = client
+	o.out = os.Stdout
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *IAMUserOptions) Validate(cmd *cobra.Command) error {
+	if _, ok := iamScopeActions[o.Scope]; !ok {
+		return fmt.Errorf("unsupported scope %q", o.Scope)
+	}
+	return nil
+}
+
+// Run creates the IAM user and its policy, and prints the credentials secret of a new access key when the user has
+// none or the access key is rotated
+func (o *IAMUserOptions) Run() error {
+	logger := log.WithField("user", o.UserName)
+
+	if _, err := o.client.GetUser(&iam.GetUserInput{UserName: aws.String(o.UserName)}); err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != iam.ErrCodeNoSuchEntityException {
+			return errors.Wrap(err, "cannot get IAM user")
+		}
+		logger.Info("creating IAM user")
+		if _, err := o.client.CreateUser(&iam.CreateUserInput{UserName: aws.String(o.UserName)}); err != nil {
+			return errors.Wrap(err, "cannot create IAM user")
+		}
+	} else {
+		logger.Info("using existing IAM user")
+	}
+
+	policy, err := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{{
+			"Effect":   "Allow",
+			"Action":   iamScopeActions[o.Scope],
+			"Resource": "*",
+		}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal IAM policy")
+	}
+	policyName := fmt.Sprintf("hive-%s", o.Scope)
+	if _, err := o.client.PutUserPolicy(&iam.PutUserPolicyInput{
+		UserName:       aws.String(o.UserName),
+		PolicyName:     aws.String(policyName),
+		PolicyDocument: aws.String(string(policy)),
+	}); err != nil {
+		return errors.Wrap(err, "cannot put IAM user policy")
+	}
+	logger.WithField("policy", policyName).Info("granted permissions to IAM user")
+
+	keys, err := o.client.ListAccessKeys(&iam.ListAccessKeysInput{UserName: aws.String(o.UserName)})
+	if err != nil {
+		return errors.Wrap(err, "cannot list access keys")
+	}
+	previousKeys := keys.AccessKeyMetadata
+	if len(previousKeys) > 0 && !o.RotateAccessKey {
+		logger.WithField("accessKeyID", aws.StringValue(previousKeys[0].AccessKeyId)).
+			Info("keeping the existing access key of the IAM user, use --rotate-access-key to replace it")
+		return nil
+	}
+	sort.Slice(previousKeys, func(i, j int) bool {
+		return aws.TimeValue(previousKeys[i].CreateDate).Before(aws.TimeValue(previousKeys[j].CreateDate))
+	})
+	// Make room for the new access key by deleting the oldest one, the others are deleted once the new one exists.
+	if len(previousKeys) >= maxAccessKeys {
+		if err := o.deleteAccessKey(previousKeys[0], logger); err != nil {
+			return err
+		}
+		previousKeys = previousKeys[1:]
+	}
+
+	resp, err := o.client.CreateAccessKey(&iam.CreateAccessKeyInput{UserName: aws.String(o.UserName)})
+	if err != nil {
+		return errors.Wrap(err, "cannot create access key")
+	}
+	logger.WithField("accessKeyID", aws.StringValue(resp.AccessKey.AccessKeyId)).Info("created access key")
+	for _, key := range previousKeys {
+		if err := o.deleteAccessKey(key, logger); err != nil {
+			return err
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.SecretName,
+			Namespace: o.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			"aws_access_key_id":     aws.StringValue(resp.AccessKey.AccessKeyId),
+			"aws_secret_access_key": aws.StringValue(resp.AccessKey.SecretAccessKey),
+		},
+	}
+	printer := printers.NewTypeSetter(scheme.Scheme).ToPrinter(&printers.YAMLPrinter{})
+	return printer.PrintObj(secret, o.out)
+}
+
+// deleteAccessKey deletes a previous access key of the user.
+func (o *IAMUserOptions) deleteAccessKey(key *iam.AccessKeyMetadata, logger log.FieldLogger) error {
+	if _, err := o.client.DeleteAccessKey(&iam.DeleteAccessKeyInput{
+		UserName:    aws.String(o.UserName),
+		AccessKeyId: key.AccessKeyId,
+	}); err != nil {
+		return errors.Wrapf(err, "cannot delete access key %s", aws.StringValue(key.AccessKeyId))
+	}
+	logger.WithField("accessKeyID", aws.StringValue(key.AccessKeyId)).Info("deleted previous access key")
+	return nil
+}
@@ -0,0 +1,112 @@
+package awssetup
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/hive/pkg/awsclient/mock"
+)
+
+func TestIAMScopeActionsAreEnumerated(t *testing.T) {
+	for scope, actions := range iamScopeActions {
+		for _, action := range actions {
+			assert.NotContains(t, action, "*", "expected the actions of the %s scope to be enumerated", scope)
+		}
+	}
+}
+
+func testAccessKey(id string, age time.Duration) *iam.AccessKeyMetadata {
+	return &iam.AccessKeyMetadata{
+		AccessKeyId: aws.String(id),
+		CreateDate:  aws.Time(time.Now().Add(-age)),
+	}
+}
+
+func TestIAMUserRun(t *testing.T) {
+	cases := []struct {
+		name              string
+		existingKeys      []*iam.AccessKeyMetadata
+		rotate            bool
+		expectCreatedKey  bool
+		expectDeletedKeys []string
+	}{
+		{
+			name:             "no access key",
+			expectCreatedKey: true,
+		},
+		{
+			name:         "existing access key",
+			existingKeys: []*iam.AccessKeyMetadata{testAccessKey("existing", time.Hour)},
+		},
+		{
+			name:              "rotated access key",
+			existingKeys:      []*iam.AccessKeyMetadata{testAccessKey("existing", time.Hour)},
+			rotate:            true,
+			expectCreatedKey:  true,
+			expectDeletedKeys: []string{"existing"},
+		},
+		{
+			name: "rotated access keys at the limit",
+			existingKeys: []*iam.AccessKeyMetadata{
+				testAccessKey("newer", time.Hour),
+				testAccessKey("older", 2*time.Hour),
+			},
+			rotate:            true,
+			expectCreatedKey:  true,
+			expectDeletedKeys: []string{"older", "newer"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mock.NewMockClient(mockCtrl)
+
+			client.EXPECT().GetUser(gomock.Any()).Return(&iam.GetUserOutput{}, nil)
+			client.EXPECT().PutUserPolicy(gomock.Any()).Do(func(input *iam.PutUserPolicyInput) {
+				assert.Equal(t, "hive-dns", aws.StringValue(input.PolicyName), "unexpected policy name")
+			}).Return(&iam.PutUserPolicyOutput{}, nil)
+			client.EXPECT().ListAccessKeys(gomock.Any()).Return(&iam.ListAccessKeysOutput{AccessKeyMetadata: tc.existingKeys}, nil)
+			var deletedKeys []string
+			client.EXPECT().DeleteAccessKey(gomock.Any()).Do(func(input *iam.DeleteAccessKeyInput) {
+				deletedKeys = append(deletedKeys, aws.StringValue(input.AccessKeyId))
+			}).Return(&iam.DeleteAccessKeyOutput{}, nil).Times(len(tc.expectDeletedKeys))
+			if tc.expectCreatedKey {
+				client.EXPECT().CreateAccessKey(gomock.Any()).Return(&iam.CreateAccessKeyOutput{
+					AccessKey: &iam.AccessKey{AccessKeyId: aws.String("created"), SecretAccessKey: aws.String("secret")},
+				}, nil)
+			}
+
+			out := &bytes.Buffer{}
+			o := &IAMUserOptions{
+				UserName:        "hive-dns",
+				Scope:           iamScopeDNS,
+				SecretName:      "hive-dns-aws-creds",
+				Namespace:       "hive",
+				RotateAccessKey: tc.rotate,
+				client:          client,
+				out:             out,
+			}
+			require.NoError(t, o.Run(), "unexpected error")
+			assert.Equal(t, tc.expectDeletedKeys, deletedKeys, "unexpected deleted access keys")
+			if !tc.expectCreatedKey {
+				assert.Empty(t, out.String(), "expected no secret to be printed")
+				return
+			}
+			secret := &corev1.Secret{}
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), secret), "cannot unmarshal secret")
+			assert.Equal(t, "created", secret.StringData["aws_access_key_id"], "unexpected access key ID")
+			assert.Equal(t, "secret", secret.StringData["aws_secret_access_key"], "unexpected secret access key")
+		})
+	}
+}
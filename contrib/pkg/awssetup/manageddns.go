@@ -0,0 +1,191 @@
+package awssetup
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	awsclient "github.com/openshift/hive/pkg/awsclient"
+)
+
+const managedDNSLongDesc = `
+OVERVIEW
+The managed-dns command creates a public Route53 hosted zone for a domain that
+Hive will manage DNS for, and delegates the domain from the hosted zone of its
+parent domain by adding an NS record to the parent hosted zone.
+
+The entry to add to spec.managedDomains of the HiveConfig is printed once the
+hosted zone is ready. The credentials secret referenced by the entry can be
+created with the iam-user command.
+`
+
+// ManagedDNSOptions is the set of options to set up a domain managed by Hive
+type ManagedDNSOptions struct {
+	Domain                string
+	ParentHostedZoneID    string
+	CredentialsSecretName string
+	Region                string
+
+	client awsclient.Client
+}
+
+// NewManagedDNSCommand returns a command that will create and delegate the hosted zone for a domain managed by Hive
+func NewManagedDNSCommand() *cobra.Command {
+	opt := &ManagedDNSOptions{}
+	cmd := &cobra.Command{
+		Use:   "managed-dns DOMAIN",
+		Short: "Creates and delegates the AWS hosted zone for a domain managed by Hive",
+		Long:  managedDNSLongDesc,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Error("Error")
+				os.Exit(1)
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.ParentHostedZoneID, "parent-hosted-zone-id", "", "ID of the hosted zone of the parent domain. When omitted, the hosted zone is looked up by the name of the parent domain.")
+	flags.StringVar(&opt.CredentialsSecretName, "credentials-secret-name", "aws-dns-creds", "Name of the secret with the AWS credentials that Hive uses to manage the domain")
+	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use for API calls")
+	return cmd
+}
+
+// Complete finalizes options by using arguments
+func (o *ManagedDNSOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.Domain = strings.TrimSuffix(args[0], ".")
+	client, err := awsclient.NewClient(nil, "", "", o.Region, nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot create AWS client")
+	}
+	o.client = client
+	return nil
+}
+
+// Run creates the hosted zone for the domain, delegates the domain from the parent hosted zone, and prints the
+// HiveConfig entry for the domain.
+func (o *ManagedDNSOptions) Run() error {
+	logger := log.WithField("domain", o.Domain)
+
+	zoneID, err := o.findPublicHostedZone(o.Domain)
+	if err != nil {
+		return err
+	}
+	if zoneID == "" {
+		logger.Info("creating hosted zone")
+		resp, err := o.client.CreateHostedZone(&route53.CreateHostedZoneInput{
+			Name:            aws.String(o.Domain),
+			CallerReference: aws.String(fmt.Sprintf("hiveutil-%s-%d", o.Domain, time.Now().Unix())),
+			HostedZoneConfig: &route53.HostedZoneConfig{
+				Comment: aws.String("Domain managed by Hive"),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "cannot create hosted zone")
+		}
+		zoneID = aws.StringValue(resp.HostedZone.Id)
+	} else {
+		logger.WithField("hostedZoneID", zoneID).Info("using existing hosted zone")
+	}
+
+	zone, err := o.client.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return errors.Wrap(err, "cannot get hosted zone")
+	}
+	if zone.DelegationSet == nil || len(zone.DelegationSet.NameServers) == 0 {
+		return errors.Errorf("hosted zone %s has no name servers", zoneID)
+	}
+	nameServers := aws.StringValueSlice(zone.DelegationSet.NameServers)
+
+	parentZoneID := o.ParentHostedZoneID
+	if parentZoneID == "" {
+		parentDomain := o.Domain[strings.Index(o.Domain, ".")+1:]
+		parentZoneID, err = o.findPublicHostedZone(parentDomain)
+		if err != nil {
+			return err
+		}
+		if parentZoneID == "" {
+			logger.WithField("parentDomain", parentDomain).Warnf("no hosted zone found for the parent domain, delegate the domain to these name servers manually: %s", strings.Join(nameServers, ", "))
+		}
+	}
+	if parentZoneID != "" {
+		if err := o.delegate(parentZoneID, nameServers); err != nil {
+			return err
+		}
+		logger.WithField("parentHostedZoneID", parentZoneID).Info("delegated domain from parent hosted zone")
+	}
+
+	entry := hivev1.ManageDNSConfig{
+		Domains: []string{o.Domain},
+		AWS: &hivev1.ManageDNSAWSConfig{
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: o.CredentialsSecretName},
+		},
+	}
+	out, err := yaml.Marshal([]hivev1.ManageDNSConfig{entry})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal HiveConfig entry")
+	}
+	logger.Info("add the following entry to spec.managedDomains of the HiveConfig")
+	fmt.Print(string(out))
+	return nil
+}
+
+// findPublicHostedZone returns the ID of the public hosted zone for the domain, or an empty string if there is none.
+func (o *ManagedDNSOptions) findPublicHostedZone(domain string) (string, error) {
+	fqdn := domain + "."
+	resp, err := o.client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(fqdn),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot list hosted zones for %s", domain)
+	}
+	for _, zone := range resp.HostedZones {
+		if aws.StringValue(zone.Name) != fqdn {
+			// Zones are listed in order by name, so there are no more zones for the domain.
+			break
+		}
+		if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+			continue
+		}
+		return aws.StringValue(zone.Id), nil
+	}
+	return "", nil
+}
+
+func (o *ManagedDNSOptions) delegate(parentZoneID string, nameServers []string) error {
+	records := make([]*route53.ResourceRecord, len(nameServers))
+	for i, ns := range nameServers {
+		records[i] = &route53.ResourceRecord{Value: aws.String(ns)}
+	}
+	_, err := o.client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(parentZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String(o.Domain + "."),
+					Type:            aws.String(route53.RRTypeNs),
+					TTL:             aws.Int64(60),
+					ResourceRecords: records,
+				},
+			}},
+		},
+	})
+	return errors.Wrap(err, "cannot create NS record in parent hosted zone")
+}
@@ -0,0 +1,383 @@
+package awssetup
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const stsCredentialsLongDesc = `
+OVERVIEW
+The sts-credentials command renders the AWS credentials of the components of a
+cluster installed in the Manual credentials mode with short-lived tokens (AWS
+STS).
+
+The CredentialsRequests of the components are extracted from the release image
+with "oc adm release extract", or read from --credentials-requests-dir. For
+each CredentialsRequest, the command writes to the iam directory of the output
+directory the trust and permissions policies of the IAM role to create for the
+component, and to the manifests directory the secret through which the
+component assumes the role.
+
+Once the IAM roles are created, install the cluster with:
+
+  hiveutil create-cluster --credentials-mode=Manual \
+    --credentials-manifests=<output-dir>/manifests ...
+`
+
+const (
+	// stsTokenFile is the path of the projected service account token the components exchange for the credentials of
+	// their role.
+	stsTokenFile = "/var/run/secrets/openshift/serviceaccount/token"
+
+	// stsTokenAudience is the audience of the projected service account tokens of the components, which the trust
+	// policies require.
+	stsTokenAudience = "openshift"
+
+	// maxRoleNameLength is the maximum length of the name of an IAM role.
+	maxRoleNameLength = 64
+
+	// roleNameHashLength is the length of the hash suffixing the truncated names of IAM roles.
+	roleNameHashLength = 8
+)
+
+// STSCredentialsOptions is the set of options to render the STS credentials of the components of a cluster
+type STSCredentialsOptions struct {
+	ReleaseImage           string
+	PullSecretFile         string
+	CredentialsRequestsDir string
+	OIDCProviderARN        string
+	ServiceAccountIssuer   string
+	RolePrefix             string
+	SigningKeyFile         string
+	OutputDir              string
+}
+
+// credentialsRequest is the part of a CredentialsRequest which is needed to render its STS credentials.
+type credentialsRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		SecretRef           corev1.ObjectReference `json:"secretRef"`
+		ProviderSpec        *runtime.RawExtension  `json:"providerSpec,omitempty"`
+		ServiceAccountNames []string               `json:"serviceAccountNames,omitempty"`
+	} `json:"spec"`
+}
+
+// awsProviderSpec is the AWS provider spec of a CredentialsRequest, listing the permissions of the component.
+type awsProviderSpec struct {
+	metav1.TypeMeta  `json:",inline"`
+	StatementEntries []statementEntry `json:"statementEntries"`
+}
+
+// statementEntry is a statement of the IAM policy of a component.
+type statementEntry struct {
+	Effect   string   `json:"effect"`
+	Action   []string `json:"action"`
+	Resource string   `json:"resource"`
+}
+
+// NewSTSCredentialsCommand returns a command that will render the STS credentials of the components of a cluster
+func NewSTSCredentialsCommand() *cobra.Command {
+	opt := &STSCredentialsOptions{}
+	cmd := &cobra.Command{
+		Use:   "sts-credentials",
+		Short: "Renders the IAM roles and credentials manifests of a cluster using AWS STS",
+		Long:  stsCredentialsLongDesc,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Error("Error")
+				os.Exit(1)
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.ReleaseImage, "release-image", "", "Release image to extract the CredentialsRequests from")
+	flags.StringVar(&opt.PullSecretFile, "pull-secret-file", "", "Pull secret used to extract the CredentialsRequests from the release image")
+	flags.StringVar(&opt.CredentialsRequestsDir, "credentials-requests-dir", "", "Directory containing the CredentialsRequests, instead of extracting them from the release image")
+	flags.StringVar(&opt.OIDCProviderARN, "oidc-provider-arn", "", "ARN of the IAM OIDC identity provider of the service account issuer of the cluster")
+	flags.StringVar(&opt.ServiceAccountIssuer, "service-account-issuer", "", "URL of the service account issuer of the cluster (defaults to the URL of the OIDC provider)")
+	flags.StringVar(&opt.RolePrefix, "role-prefix", "", "Prefix of the names of the IAM roles, typically the name of the cluster")
+	flags.StringVar(&opt.SigningKeyFile, "signing-key-file", "", "Private key signing the tokens of the service accounts of the cluster, added to the credentials manifests")
+	flags.StringVar(&opt.OutputDir, "output-dir", "", "Directory to write the IAM policies and the credentials manifests to")
+	return cmd
+}
+
+// Complete finalizes options by setting defaults
+func (o *STSCredentialsOptions) Complete(cmd *cobra.Command, args []string) error {
+	if o.ServiceAccountIssuer == "" && o.OIDCProviderARN != "" {
+		o.ServiceAccountIssuer = "https://" + oidcProviderHost(o.OIDCProviderARN)
+	}
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *STSCredentialsOptions) Validate(cmd *cobra.Command) error {
+	if (o.ReleaseImage == "") == (o.CredentialsRequestsDir == "") {
+		cmd.Usage()
+		return fmt.Errorf("must specify exactly one of --release-image and --credentials-requests-dir")
+	}
+	if o.OIDCProviderARN == "" || o.RolePrefix == "" || o.OutputDir == "" {
+		cmd.Usage()
+		return fmt.Errorf("must specify --oidc-provider-arn, --role-prefix and --output-dir")
+	}
+	if accountID(o.OIDCProviderARN) == "" || oidcProviderHost(o.OIDCProviderARN) == "" {
+		return fmt.Errorf("invalid OIDC provider ARN %q, expected arn:aws:iam::<account-id>:oidc-provider/<host>", o.OIDCProviderARN)
+	}
+	return nil
+}
+
+// Run renders the IAM policies and the credentials manifests of the CredentialsRequests
+func (o *STSCredentialsOptions) Run() error {
+	requestsDir := o.CredentialsRequestsDir
+	if o.ReleaseImage != "" {
+		tmpDir, err := ioutil.TempDir("", "credentials-requests")
+		if err != nil {
+			return errors.Wrap(err, "cannot create temporary directory")
+		}
+		defer os.RemoveAll(tmpDir)
+		if err := o.extractCredentialsRequests(tmpDir); err != nil {
+			return err
+		}
+		requestsDir = tmpDir
+	}
+	requests, err := readCredentialsRequests(requestsDir)
+	if err != nil {
+		return err
+	}
+
+	iamDir := filepath.Join(o.OutputDir, "iam")
+	manifestsDir := filepath.Join(o.OutputDir, "manifests")
+	for _, dir := range []string{iamDir, manifestsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Wrapf(err, "cannot create directory %s", dir)
+		}
+	}
+
+	for _, cr := range requests {
+		logger := log.WithField("credentialsRequest", cr.Name)
+		providerSpec := &awsProviderSpec{}
+		if err := json.Unmarshal(cr.Spec.ProviderSpec.Raw, providerSpec); err != nil {
+			return errors.Wrapf(err, "cannot unmarshal the provider spec of CredentialsRequest %s", cr.Name)
+		}
+		roleName := o.roleName(cr)
+		trustPolicy, err := o.trustPolicy(cr)
+		if err != nil {
+			return err
+		}
+		permissionsPolicy, err := json.MarshalIndent(map[string]interface{}{
+			"Version":   "2012-10-17",
+			"Statement": policyStatements(providerSpec.StatementEntries),
+		}, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "cannot marshal permissions policy")
+		}
+		if err := ioutil.WriteFile(filepath.Join(iamDir, roleName+"-trust-policy.json"), trustPolicy, 0644); err != nil {
+			return errors.Wrap(err, "cannot write trust policy")
+		}
+		if err := ioutil.WriteFile(filepath.Join(iamDir, roleName+"-permissions-policy.json"), permissionsPolicy, 0644); err != nil {
+			return errors.Wrap(err, "cannot write permissions policy")
+		}
+
+		secret, err := yaml.Marshal(&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cr.Spec.SecretRef.Name,
+				Namespace: cr.Spec.SecretRef.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			StringData: map[string]string{
+				"credentials": fmt.Sprintf("[default]\nrole_arn = %s\nweb_identity_token_file = %s\n", o.roleARN(roleName), stsTokenFile),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "cannot marshal credentials secret")
+		}
+		secretFile := fmt.Sprintf("%s-%s-credentials.yaml", cr.Spec.SecretRef.Namespace, cr.Spec.SecretRef.Name)
+		if err := ioutil.WriteFile(filepath.Join(manifestsDir, secretFile), secret, 0644); err != nil {
+			return errors.Wrap(err, "cannot write credentials secret")
+		}
+		logger.WithField("role", roleName).Info("rendered IAM role and credentials secret")
+	}
+
+	authentication, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "Authentication",
+		"metadata":   map[string]interface{}{"name": "cluster"},
+		"spec":       map[string]interface{}{"serviceAccountIssuer": o.ServiceAccountIssuer},
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal authentication config")
+	}
+	if err := ioutil.WriteFile(filepath.Join(manifestsDir, "cluster-authentication-02-config.yaml"), authentication, 0644); err != nil {
+		return errors.Wrap(err, "cannot write authentication config")
+	}
+
+	if o.SigningKeyFile != "" {
+		key, err := ioutil.ReadFile(o.SigningKeyFile)
+		if err != nil {
+			return errors.Wrap(err, "cannot read signing key")
+		}
+		if err := ioutil.WriteFile(filepath.Join(manifestsDir, hivev1.BoundServiceAccountSigningKeyKey), key, 0600); err != nil {
+			return errors.Wrap(err, "cannot write signing key")
+		}
+	}
+
+	log.WithField("outputDir", o.OutputDir).Infof("rendered the credentials of %d CredentialsRequests, create the IAM roles of %s before installing the cluster", len(requests), iamDir)
+	return nil
+}
+
+// extractCredentialsRequests extracts the AWS CredentialsRequests of the release image to the directory.
+func (o *STSCredentialsOptions) extractCredentialsRequests(dir string) error {
+	args := []string{"adm", "release", "extract", "--credentials-requests", "--cloud=aws", "--to=" + dir}
+	if o.PullSecretFile != "" {
+		args = append(args, "--registry-config="+o.PullSecretFile)
+	}
+	args = append(args, o.ReleaseImage)
+	log.WithField("releaseImage", o.ReleaseImage).Info("extracting CredentialsRequests from the release image")
+	if out, err := exec.Command("oc", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "cannot extract CredentialsRequests from the release image: %s", out)
+	}
+	return nil
+}
+
+// readCredentialsRequests reads the AWS CredentialsRequests of the directory. A file may contain several documents.
+func readCredentialsRequests(dir string) ([]credentialsRequest, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read CredentialsRequests directory")
+	}
+	var requests []credentialsRequest
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read file %s", file.Name())
+		}
+		for _, doc := range strings.Split(string(data), "\n---") {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+			cr := credentialsRequest{}
+			if err := yaml.Unmarshal([]byte(doc), &cr); err != nil {
+				return nil, errors.Wrapf(err, "cannot unmarshal file %s", file.Name())
+			}
+			if cr.Kind != "CredentialsRequest" || cr.Spec.ProviderSpec == nil {
+				continue
+			}
+			providerSpec := &metav1.TypeMeta{}
+			if err := json.Unmarshal(cr.Spec.ProviderSpec.Raw, providerSpec); err != nil || providerSpec.Kind != "AWSProviderSpec" {
+				continue
+			}
+			requests = append(requests, cr)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].Name < requests[j].Name })
+	return requests, nil
+}
+
+// roleName returns the name of the IAM role of the CredentialsRequest, named after the secret of its credentials. Names
+// too long for IAM are truncated and suffixed with a hash of the full name, so that they remain unique.
+func (o *STSCredentialsOptions) roleName(cr credentialsRequest) string {
+	name := fmt.Sprintf("%s-%s-%s", o.RolePrefix, cr.Spec.SecretRef.Namespace, cr.Spec.SecretRef.Name)
+	if len(name) > maxRoleNameLength {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:roleNameHashLength]
+		name = name[:maxRoleNameLength-roleNameHashLength-1] + "-" + hash
+	}
+	return name
+}
+
+func (o *STSCredentialsOptions) roleARN(roleName string) string {
+	return fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID(o.OIDCProviderARN), roleName)
+}
+
+// trustPolicy returns the policy allowing the service accounts of the CredentialsRequest to assume its role with the
+// tokens of the service account issuer. CredentialsRequests without service accounts are rejected, as their role could
+// be assumed by any service account of the cluster.
+func (o *STSCredentialsOptions) trustPolicy(cr credentialsRequest) ([]byte, error) {
+	if len(cr.Spec.ServiceAccountNames) == 0 {
+		return nil, errors.Errorf("CredentialsRequest %s lists no service accounts to allow to assume its role", cr.Name)
+	}
+	host := oidcProviderHost(o.OIDCProviderARN)
+	subjects := make([]string, len(cr.Spec.ServiceAccountNames))
+	for i, sa := range cr.Spec.ServiceAccountNames {
+		subjects[i] = fmt.Sprintf("system:serviceaccount:%s:%s", cr.Spec.SecretRef.Namespace, sa)
+	}
+	statement := map[string]interface{}{
+		"Effect":    "Allow",
+		"Principal": map[string]interface{}{"Federated": o.OIDCProviderARN},
+		"Action":    "sts:AssumeRoleWithWebIdentity",
+		"Condition": map[string]interface{}{
+			"StringEquals": map[string]interface{}{
+				host + ":sub": subjects,
+				host + ":aud": stsTokenAudience,
+			},
+		},
+	}
+	policy, err := json.MarshalIndent(map[string]interface{}{
+		"Version":   "2012-10-17",
+		"Statement": []interface{}{statement},
+	}, "", "  ")
+	return policy, errors.Wrap(err, "cannot marshal trust policy")
+}
+
+// policyStatements returns the IAM policy statements of the statement entries of a CredentialsRequest.
+func policyStatements(entries []statementEntry) []map[string]interface{} {
+	statements := make([]map[string]interface{}, len(entries))
+	for i, e := range entries {
+		statements[i] = map[string]interface{}{
+			"Effect":   e.Effect,
+			"Action":   e.Action,
+			"Resource": e.Resource,
+		}
+	}
+	return statements
+}
+
+// accountID returns the AWS account of the ARN.
+func accountID(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return ""
+	}
+	return parts[4]
+}
+
+// oidcProviderHost returns the host and path of the issuer of the ARN of an IAM OIDC identity provider.
+func oidcProviderHost(arn string) string {
+	parts := strings.SplitN(arn, ":oidc-provider/", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1]
+}
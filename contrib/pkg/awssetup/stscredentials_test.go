@@ -0,0 +1,184 @@
+package awssetup
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testOIDCProviderARN    = "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/mycluster"
+	testCredentialsRequest = `apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-image-registry
+spec:
+  secretRef:
+    namespace: openshift-image-registry
+    name: installer-cloud-credentials
+  serviceAccountNames:
+  - cluster-image-registry-operator
+  - registry
+  providerSpec:
+    apiVersion: cloudcredential.openshift.io/v1
+    kind: AWSProviderSpec
+    statementEntries:
+    - effect: Allow
+      action:
+      - s3:CreateBucket
+      resource: "*"
+---
+apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-gcp
+spec:
+  secretRef:
+    namespace: openshift-gcp
+    name: gcp-credentials
+  providerSpec:
+    apiVersion: cloudcredential.openshift.io/v1
+    kind: GCPProviderSpec
+`
+)
+
+func testCredentialsRequestFor(namespace, name string, serviceAccounts ...string) credentialsRequest {
+	cr := credentialsRequest{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	cr.Spec.SecretRef = corev1.ObjectReference{Namespace: namespace, Name: name}
+	cr.Spec.ServiceAccountNames = serviceAccounts
+	return cr
+}
+
+func TestRoleName(t *testing.T) {
+	longNamespace := strings.Repeat("n", 40)
+	cases := []struct {
+		name         string
+		cr           credentialsRequest
+		expectedName string
+	}{
+		{
+			name:         "short name",
+			cr:           testCredentialsRequestFor("openshift-image-registry", "installer-cloud-credentials"),
+			expectedName: "mycluster-openshift-image-registry-installer-cloud-credentials",
+		},
+		{
+			name:         "truncated name",
+			cr:           testCredentialsRequestFor(longNamespace, "first-credentials"),
+			expectedName: "mycluster-" + longNamespace + "-firs-7ca30bfb",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &STSCredentialsOptions{RolePrefix: "mycluster"}
+			name := o.roleName(tc.cr)
+			assert.Equal(t, tc.expectedName, name, "unexpected role name")
+			assert.LessOrEqual(t, len(name), maxRoleNameLength, "role name too long")
+		})
+	}
+}
+
+func TestRoleNameTruncatedNamesAreUnique(t *testing.T) {
+	o := &STSCredentialsOptions{RolePrefix: "mycluster"}
+	namespace := strings.Repeat("n", 60)
+	first := o.roleName(testCredentialsRequestFor(namespace, "first-credentials"))
+	second := o.roleName(testCredentialsRequestFor(namespace, "second-credentials"))
+	assert.Len(t, first, maxRoleNameLength, "unexpected role name length")
+	assert.Len(t, second, maxRoleNameLength, "unexpected role name length")
+	assert.NotEqual(t, first, second, "expected the truncated role names to differ")
+	assert.Equal(t, first, o.roleName(testCredentialsRequestFor(namespace, "first-credentials")), "expected the role name to be stable")
+}
+
+func TestTrustPolicy(t *testing.T) {
+	cases := []struct {
+		name             string
+		cr               credentialsRequest
+		expectError      bool
+		expectedSubjects []interface{}
+	}{
+		{
+			name: "service accounts",
+			cr:   testCredentialsRequestFor("openshift-image-registry", "installer-cloud-credentials", "cluster-image-registry-operator", "registry"),
+			expectedSubjects: []interface{}{
+				"system:serviceaccount:openshift-image-registry:cluster-image-registry-operator",
+				"system:serviceaccount:openshift-image-registry:registry",
+			},
+		},
+		{
+			name:        "no service accounts",
+			cr:          testCredentialsRequestFor("openshift-image-registry", "installer-cloud-credentials"),
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &STSCredentialsOptions{OIDCProviderARN: testOIDCProviderARN}
+			data, err := o.trustPolicy(tc.cr)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			policy := struct {
+				Statement []struct {
+					Principal map[string]string
+					Condition map[string]map[string]interface{}
+				}
+			}{}
+			require.NoError(t, json.Unmarshal(data, &policy), "cannot unmarshal trust policy")
+			require.Len(t, policy.Statement, 1, "unexpected number of statements")
+			statement := policy.Statement[0]
+			assert.Equal(t, testOIDCProviderARN, statement.Principal["Federated"], "unexpected principal")
+			conditions := statement.Condition["StringEquals"]
+			assert.Equal(t, tc.expectedSubjects, conditions["oidc.example.com/mycluster:sub"], "unexpected subjects")
+			assert.Equal(t, stsTokenAudience, conditions["oidc.example.com/mycluster:aud"], "unexpected audience")
+		})
+	}
+}
+
+func TestSTSCredentialsRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sts-credentials")
+	require.NoError(t, err, "cannot create directory")
+	defer os.RemoveAll(dir)
+	requestsDir := filepath.Join(dir, "requests")
+	require.NoError(t, os.Mkdir(requestsDir, 0755), "cannot create directory")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(requestsDir, "requests.yaml"), []byte(testCredentialsRequest), 0644), "cannot write CredentialsRequests")
+
+	o := &STSCredentialsOptions{
+		CredentialsRequestsDir: requestsDir,
+		OIDCProviderARN:        testOIDCProviderARN,
+		RolePrefix:             "mycluster",
+		OutputDir:              filepath.Join(dir, "output"),
+	}
+	require.NoError(t, o.Complete(nil, nil), "unexpected error completing options")
+	require.NoError(t, o.Run(), "unexpected error")
+
+	roleName := "mycluster-openshift-image-registry-installer-cloud-credentials"
+	for _, file := range []string{roleName + "-trust-policy.json", roleName + "-permissions-policy.json"} {
+		_, err := os.Stat(filepath.Join(o.OutputDir, "iam", file))
+		assert.NoError(t, err, "expected %s to be written", file)
+	}
+	iamFiles, err := ioutil.ReadDir(filepath.Join(o.OutputDir, "iam"))
+	require.NoError(t, err, "cannot read iam directory")
+	assert.Len(t, iamFiles, 2, "expected only the policies of the AWS CredentialsRequest")
+
+	data, err := ioutil.ReadFile(filepath.Join(o.OutputDir, "manifests", "openshift-image-registry-installer-cloud-credentials-credentials.yaml"))
+	require.NoError(t, err, "cannot read credentials secret")
+	secret := &corev1.Secret{}
+	require.NoError(t, yaml.Unmarshal(data, secret), "cannot unmarshal credentials secret")
+	assert.Contains(t, secret.StringData["credentials"], "role_arn = arn:aws:iam::123456789012:role/"+roleName, "unexpected credentials")
+	assert.Contains(t, secret.StringData["credentials"], "web_identity_token_file = "+stsTokenFile, "unexpected credentials")
+
+	data, err = ioutil.ReadFile(filepath.Join(o.OutputDir, "manifests", "cluster-authentication-02-config.yaml"))
+	require.NoError(t, err, "cannot read authentication config")
+	assert.Contains(t, string(data), "serviceAccountIssuer: https://oidc.example.com/mycluster", "unexpected service account issuer")
+}
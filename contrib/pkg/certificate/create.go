@@ -139,7 +139,7 @@ func (o *Options) Run() error {
 }
 
 func (o *Options) getBaseDomainID() (string, error) {
-	client, err := awsclient.NewClient(nil, "", "", o.Region)
+	client, err := awsclient.NewClient(nil, "", "", o.Region, nil)
 	if err != nil {
 		return "", errors.Wrap(err, "cannot create AWS client; make sure your environment is setup to communicate with AWS")
 	}
@@ -102,7 +102,7 @@ func (o *HookOptions) Delete() error {
 }
 
 func (o *HookOptions) modifyDNSRecord(remove bool) error {
-	client, err := awsclient.NewClient(nil, "", "", o.Region)
+	client, err := awsclient.NewClient(nil, "", "", o.Region, nil)
 	if err != nil {
 		return errors.Wrap(err, "cannot create AWS client")
 	}
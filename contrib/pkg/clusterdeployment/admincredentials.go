@@ -0,0 +1,133 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	hiveConfigName = "hive"
+
+	adminCredentialsLongDesc = `
+OVERVIEW
+The hiveutil clusterdeployment admin-credentials command prints the admin
+kubeconfig, or the admin password, of an installed cluster.
+
+When Hive encrypts the admin secrets of the clusters, the secrets are decrypted
+with the secret encryption configuration of the HiveConfig. This requires read
+access to the HiveConfig and to the secret encryption credentials in the Hive
+namespace.
+`
+)
+
+// AdminCredentialsOptions are the options of the clusterdeployment admin-credentials command.
+type AdminCredentialsOptions struct {
+	Name      string
+	Namespace string
+	Password  bool
+
+	log log.FieldLogger
+}
+
+// NewAdminCredentialsCommand creates the 'clusterdeployment admin-credentials' subcommand.
+func NewAdminCredentialsCommand() *cobra.Command {
+	opt := &AdminCredentialsOptions{log: log.WithField("command", "clusterdeployment admin-credentials")}
+
+	cmd := &cobra.Command{
+		Use:   "admin-credentials CLUSTER_DEPLOYMENT_NAME",
+		Short: "Prints the admin kubeconfig or password of a cluster, decrypting them if needed",
+		Long:  adminCredentialsLongDesc,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	flags.BoolVar(&opt.Password, "password", false, "Print the admin password instead of the admin kubeconfig")
+
+	return cmd
+}
+
+// run executes the command
+func (o *AdminCredentialsOptions) run() error {
+	var err error
+	if o.Namespace == "" {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "cannot create client")
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, cd); err != nil {
+		return errors.Wrap(err, "cannot get ClusterDeployment")
+	}
+	if cd.Spec.ClusterMetadata == nil {
+		return fmt.Errorf("ClusterDeployment %s has not been installed", o.Name)
+	}
+	secretName, key := cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, constants.KubeconfigSecretKey
+	if o.Password {
+		secretName, key = cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name, constants.PasswordSecretKey
+	}
+	if secretName == "" {
+		return fmt.Errorf("ClusterDeployment %s has no admin credentials secret", o.Name)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: secretName}, secret); err != nil {
+		return errors.Wrapf(err, "cannot get secret %s", secretName)
+	}
+
+	data := secret.Data
+	if secretencryption.IsEncrypted(secret) {
+		data, err = decrypt(c, secret)
+		if err != nil {
+			return errors.Wrapf(err, "cannot decrypt secret %s", secretName)
+		}
+	}
+	_, err = os.Stdout.Write(data[key])
+	return err
+}
+
+// decrypt decrypts the data of the secret with the secret encryption configuration of the HiveConfig.
+func decrypt(c client.Client, secret *corev1.Secret) (map[string][]byte, error) {
+	hiveConfig := &hivev1.HiveConfig{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: hiveConfigName}, hiveConfig); err != nil {
+		return nil, errors.Wrap(err, "cannot get HiveConfig")
+	}
+	if hiveConfig.Spec.SecretEncryption == nil {
+		return nil, errors.New("secret encryption is not configured in the HiveConfig")
+	}
+	hiveNamespace := hiveConfig.Spec.TargetNamespace
+	if hiveNamespace == "" {
+		hiveNamespace = constants.DefaultHiveNamespace
+	}
+	p, err := secretencryption.NewProvider(c, hiveNamespace, hiveConfig.Spec.SecretEncryption)
+	if err != nil {
+		return nil, err
+	}
+	return secretencryption.Decrypt(p, secret)
+}
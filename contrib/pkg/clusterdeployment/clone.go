@@ -0,0 +1,378 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	installConfigKey = "install-config.yaml"
+
+	cloneLongDesc = `
+OVERVIEW
+The hiveutil clusterdeployment clone command copies a ClusterDeployment, its
+MachinePools, and the secrets and configmaps it references, to provision a new
+cluster with the same configuration.
+
+The copies are named after the new ClusterDeployment: references whose names
+start with the name of the source ClusterDeployment are renamed accordingly,
+while the other secrets and configmaps keep their name and are copied only when
+the new ClusterDeployment is in another namespace. The state of the source
+cluster (its metadata, status, cluster pool, and annotations) is not copied,
+and the cluster name in the InstallConfig is replaced.
+
+DRY RUN
+With the --output flag, the objects are printed instead of being created.
+`
+)
+
+// CloneOptions are the options of the clusterdeployment clone command.
+type CloneOptions struct {
+	From        string
+	Namespace   string
+	Name        string
+	ToNamespace string
+	ClusterName string
+	BaseDomain  string
+	Output      string
+
+	log log.FieldLogger
+}
+
+// NewCloneCommand creates the 'clusterdeployment clone' subcommand.
+func NewCloneCommand() *cobra.Command {
+	opt := &CloneOptions{log: log.WithField("command", "clusterdeployment clone")}
+
+	cmd := &cobra.Command{
+		Use: `clone --from=CLUSTER_DEPLOYMENT_NAME --name=NEW_CLUSTER_DEPLOYMENT_NAME
+clone --from=CLUSTER_DEPLOYMENT_NAME --name=NEW_CLUSTER_DEPLOYMENT_NAME --to-namespace=NEW_NAMESPACE`,
+		Short: "Copies a ClusterDeployment and its dependent objects to create a new cluster",
+		Long:  cloneLongDesc,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.validate(); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opt.From, "from", "", "Name of the ClusterDeployment to copy")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment to copy")
+	flags.StringVar(&opt.Name, "name", "", "Name of the new ClusterDeployment")
+	flags.StringVar(&opt.ToNamespace, "to-namespace", "", "Namespace of the new ClusterDeployment. Defaults to the namespace of the copied ClusterDeployment")
+	flags.StringVar(&opt.ClusterName, "cluster-name", "", "Cluster name of the new ClusterDeployment. Defaults to the name of the new ClusterDeployment")
+	flags.StringVar(&opt.BaseDomain, "base-domain", "", "Base domain of the new ClusterDeployment. Defaults to the base domain of the copied ClusterDeployment")
+	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
+
+	return cmd
+}
+
+// validate ensures that option values make sense
+func (o *CloneOptions) validate() error {
+	if o.From == "" {
+		return fmt.Errorf("the ClusterDeployment to copy is required")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("the name of the new ClusterDeployment is required")
+	}
+	if o.Output != "" && o.Output != "yaml" && o.Output != "json" {
+		return fmt.Errorf("invalid output %q, valid values are: yaml, json", o.Output)
+	}
+	return nil
+}
+
+// run executes the command
+func (o *CloneOptions) run() error {
+	var err error
+	if o.Namespace == "" {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	if o.ToNamespace == "" {
+		o.ToNamespace = o.Namespace
+	}
+	if o.ClusterName == "" {
+		o.ClusterName = o.Name
+	}
+	if o.ToNamespace == o.Namespace && o.Name == o.From {
+		return fmt.Errorf("the new ClusterDeployment must have another name or namespace")
+	}
+
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "cannot create client")
+	}
+	objs, err := o.generateObjects(c)
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if o.Output != "" {
+		var printer printers.ResourcePrinter = &printers.JSONPrinter{}
+		if o.Output == "yaml" {
+			printer = &printers.YAMLPrinter{}
+		}
+		list := &metav1.List{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "List",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+		}
+		meta.SetList(list, objs)
+		return printers.NewTypeSetter(scheme).ToPrinter(printer).PrintObj(list, os.Stdout)
+	}
+
+	rh, err := utils.GetResourceHelper(o.log)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if _, err := rh.ApplyRuntimeObject(context.Background(), obj, scheme); err != nil {
+			return err
+		}
+	}
+	o.log.WithField("clusterDeployment", fmt.Sprintf("%s/%s", o.ToNamespace, o.Name)).Info("created ClusterDeployment")
+	return nil
+}
+
+// generateObjects reads the source ClusterDeployment and its dependent objects, and returns their copies.
+func (o *CloneOptions) generateObjects(c client.Client) ([]runtime.Object, error) {
+	src := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.From}, src); err != nil {
+		return nil, errors.Wrap(err, "cannot get ClusterDeployment")
+	}
+	if src.Spec.Provisioning == nil {
+		return nil, fmt.Errorf("ClusterDeployment %s was adopted and has no provisioning settings to copy", o.From)
+	}
+
+	cd := o.cloneClusterDeployment(src)
+	var objs []runtime.Object
+
+	// Secrets and configmaps which are renamed, or which must be copied to another namespace.
+	secretRefs, configMapRefs := dependentRefs(cd)
+	for _, ref := range secretRefs {
+		isInstallConfig := ref == &cd.Spec.Provisioning.InstallConfigSecretRef
+		newName := o.renamed(ref.Name)
+		if newName == ref.Name && o.ToNamespace == o.Namespace {
+			if !isInstallConfig {
+				continue
+			}
+			// The InstallConfig is always copied, as the cluster name it holds is replaced.
+			newName = fmt.Sprintf("%s-install-config", o.Name)
+		}
+		secret := &corev1.Secret{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, errors.Wrapf(err, "cannot get secret %s", ref.Name)
+		}
+		data := secret.Data
+		if isInstallConfig {
+			installConfig, err := o.cloneInstallConfig(secret.Data[installConfigKey])
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot update the InstallConfig of secret %s", ref.Name)
+			}
+			data = copyData(secret.Data)
+			data[installConfigKey] = installConfig
+		}
+		objs = append(objs, &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      newName,
+				Namespace: o.ToNamespace,
+				Labels:    secret.Labels,
+			},
+			Type: secret.Type,
+			Data: data,
+		})
+		ref.Name = newName
+	}
+	for _, ref := range configMapRefs {
+		newName := o.renamed(ref.Name)
+		if newName == ref.Name && o.ToNamespace == o.Namespace {
+			continue
+		}
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, "cannot get configmap %s", ref.Name)
+		}
+		objs = append(objs, &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      newName,
+				Namespace: o.ToNamespace,
+				Labels:    cm.Labels,
+			},
+			Data:       cm.Data,
+			BinaryData: cm.BinaryData,
+		})
+		ref.Name = newName
+	}
+
+	objs = append([]runtime.Object{cd}, objs...)
+
+	pools := &hivev1.MachinePoolList{}
+	if err := c.List(context.Background(), pools, client.InNamespace(o.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "cannot list MachinePools")
+	}
+	for _, pool := range pools.Items {
+		if pool.Spec.ClusterDeploymentRef.Name != o.From {
+			continue
+		}
+		objs = append(objs, &hivev1.MachinePool{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "MachinePool",
+				APIVersion: hivev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", o.Name, pool.Spec.Name),
+				Namespace: o.ToNamespace,
+				Labels:    pool.Labels,
+			},
+			Spec: func() hivev1.MachinePoolSpec {
+				spec := *pool.Spec.DeepCopy()
+				spec.ClusterDeploymentRef.Name = o.Name
+				return spec
+			}(),
+		})
+	}
+
+	return objs, nil
+}
+
+// cloneClusterDeployment copies the spec of the ClusterDeployment, without the state of the installed cluster.
+func (o *CloneOptions) cloneClusterDeployment(src *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterDeployment",
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.ToNamespace,
+			Labels:    src.Labels,
+		},
+		Spec: *src.Spec.DeepCopy(),
+	}
+	cd.Spec.ClusterName = o.ClusterName
+	if o.BaseDomain != "" {
+		cd.Spec.BaseDomain = o.BaseDomain
+	}
+	cd.Spec.Installed = false
+	cd.Spec.ClusterMetadata = nil
+	cd.Spec.ClusterPoolRef = nil
+	cd.Spec.PowerState = ""
+	return cd
+}
+
+// cloneInstallConfig replaces the cluster name, and the base domain if set, in the InstallConfig.
+func (o *CloneOptions) cloneInstallConfig(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no %s key", installConfigKey)
+	}
+	installConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &installConfig); err != nil {
+		return nil, err
+	}
+	metadata, _ := installConfig["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+		installConfig["metadata"] = metadata
+	}
+	metadata["name"] = o.ClusterName
+	if o.BaseDomain != "" {
+		installConfig["baseDomain"] = o.BaseDomain
+	}
+	return yaml.Marshal(installConfig)
+}
+
+// renamed returns the name of the copy of an object referenced by the source ClusterDeployment.
+func (o *CloneOptions) renamed(name string) string {
+	if strings.HasPrefix(name, o.From) {
+		return o.Name + strings.TrimPrefix(name, o.From)
+	}
+	return name
+}
+
+// dependentRefs returns the references to the secrets and configmaps in the namespace of the ClusterDeployment.
+func dependentRefs(cd *hivev1.ClusterDeployment) (secretRefs, configMapRefs []*corev1.LocalObjectReference) {
+	addSecret := func(ref *corev1.LocalObjectReference) {
+		if ref != nil && ref.Name != "" {
+			secretRefs = append(secretRefs, ref)
+		}
+	}
+	addSecret(cd.Spec.PullSecretRef)
+	addSecret(cd.Spec.DeprovisionCredentialsSecretRef)
+	if p := cd.Spec.Provisioning; p != nil {
+		addSecret(&p.InstallConfigSecretRef)
+		addSecret(p.SSHPrivateKeySecretRef)
+		addSecret(p.CredentialsManifestsSecretRef)
+		if p.ManifestsConfigMapRef != nil && p.ManifestsConfigMapRef.Name != "" {
+			configMapRefs = append(configMapRefs, p.ManifestsConfigMapRef)
+		}
+	}
+	switch p := &cd.Spec.Platform; {
+	case p.AWS != nil:
+		addSecret(&p.AWS.CredentialsSecretRef)
+	case p.Azure != nil:
+		addSecret(&p.Azure.CredentialsSecretRef)
+	case p.GCP != nil:
+		addSecret(&p.GCP.CredentialsSecretRef)
+	case p.OpenStack != nil:
+		addSecret(&p.OpenStack.CredentialsSecretRef)
+		addSecret(p.OpenStack.CertificatesSecretRef)
+	case p.Ovirt != nil:
+		addSecret(&p.Ovirt.CredentialsSecretRef)
+		addSecret(&p.Ovirt.CertificatesSecretRef)
+	case p.VSphere != nil:
+		addSecret(&p.VSphere.CredentialsSecretRef)
+		addSecret(&p.VSphere.CertificatesSecretRef)
+	}
+	for i := range cd.Spec.CertificateBundles {
+		addSecret(&cd.Spec.CertificateBundles[i].CertificateSecretRef)
+	}
+	return secretRefs, configMapRefs
+}
+
+func copyData(data map[string][]byte) map[string][]byte {
+	copied := make(map[string][]byte, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,17 @@
+package clusterdeployment
+
+import "github.com/spf13/cobra"
+
+// NewClusterDeploymentCommand is the entrypoint to create the 'clusterdeployment' subcommand
+func NewClusterDeploymentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clusterdeployment",
+		Short: "Utility to manage ClusterDeployments",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewCloneCommand())
+	cmd.AddCommand(NewAdminCredentialsCommand())
+	return cmd
+}
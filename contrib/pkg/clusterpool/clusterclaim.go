@@ -1,6 +1,7 @@
 package clusterpool
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"time"
 
@@ -68,7 +69,7 @@ func (o ClusterClaimOptions) run() error {
 		}
 	}
 	claim.Namespace = o.Namespace
-	if _, err := rh.ApplyRuntimeObject(claim, scheme); err != nil {
+	if _, err := rh.ApplyRuntimeObject(context.Background(), claim, scheme); err != nil {
 		return err
 	}
 
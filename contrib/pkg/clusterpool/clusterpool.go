@@ -1,6 +1,7 @@
 package clusterpool
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -67,6 +68,7 @@ type ClusterPoolOptions struct {
 	Name               string
 	Namespace          string
 	BaseDomain         string
+	ManageDNS          bool
 	PullSecret         string
 	PullSecretFile     string
 	Cloud              string
@@ -127,6 +129,7 @@ create-pool CLUSTER_POOL_NAME --cloud=gcp`,
 	flags.StringVar(&opt.Cloud, "cloud", cloudAWS, "Cloud provider: aws(default)|azure|gcp)")
 	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace to create cluster pool in")
 	flags.StringVar(&opt.BaseDomain, "base-domain", "new-installer.openshift.com", "Base domain for the cluster pool")
+	flags.BoolVar(&opt.ManageDNS, "manage-dns", false, "Manage the DNS of each cluster in a subdomain of the base domain named after the cluster. The base domain must be a managed domain.")
 	flags.StringVar(&opt.PullSecret, "pull-secret", "", "Pull secret for cluster pool. Takes precedence over pull-secret-file.")
 	flags.StringVar(&opt.PullSecretFile, "pull-secret-file", defaultPullSecretFile, fmt.Sprintf("Pull secret file for cluster pool. %s", pullSecretFileDesc))
 	flags.StringVar(&opt.CredsFile, "creds-file", "", "Cloud credentials file (defaults vary depending on cloud)")
@@ -140,6 +143,10 @@ create-pool CLUSTER_POOL_NAME --cloud=gcp`,
 	flags.StringVar(&opt.HibernateAfter, "hibernate-after", "", "Automatically hibernate clusterpool clusters when they have been running for the given duration")
 	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
 
+	cmd.RegisterFlagCompletionFunc("cloud", utils.FixedCompletion(cloudAWS, cloudAzure, cloudGCP))
+	cmd.RegisterFlagCompletionFunc("output", utils.FixedCompletion("yaml", "json"))
+	cmd.RegisterFlagCompletionFunc("image-set", utils.ClusterImageSetCompletion)
+
 	return cmd
 }
 
@@ -250,7 +257,7 @@ func (o *ClusterPoolOptions) run() error {
 			return errors.Wrapf(err, "cannot create accessor for object of type %T", obj)
 		}
 		accessor.SetNamespace(o.Namespace)
-		if _, err := rh.ApplyRuntimeObject(obj, scheme); err != nil {
+		if _, err := rh.ApplyRuntimeObject(context.Background(), obj, scheme); err != nil {
 			return err
 		}
 
@@ -384,6 +391,7 @@ func (o *ClusterPoolOptions) generateClusterPool(builder *clusterresource.Builde
 		},
 		Spec: hivev1.ClusterPoolSpec{
 			BaseDomain: o.BaseDomain,
+			ManageDNS:  o.ManageDNS,
 			Size:       o.Size,
 		},
 	}
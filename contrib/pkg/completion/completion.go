@@ -0,0 +1,55 @@
+package completion
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const longDesc = `
+OVERVIEW
+Generates the shell completion script of hiveutil for the given shell.
+
+To load the completions of hiveutil in the current bash shell:
+
+  source <(hiveutil completion bash)
+
+To load them in every new zsh shell, write the script to a directory of the
+fpath of zsh:
+
+  hiveutil completion zsh > "${fpath[1]}/_hiveutil"
+`
+
+// NewCompletionCommand creates a command that generates the shell completion script of the root command.
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "completion bash|zsh|fish|powershell",
+		Short:     "Generates the shell completion script of hiveutil",
+		Long:      longDesc,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.ExactValidArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := generate(cmd.Root(), args[0]); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	return cmd
+}
+
+func generate(root *cobra.Command, shell string) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(os.Stdout)
+	case "zsh":
+		return root.GenZshCompletion(os.Stdout)
+	case "fish":
+		return root.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return root.GenPowerShellCompletion(os.Stdout)
+	}
+	return fmt.Errorf("unsupported shell: %s", shell)
+}
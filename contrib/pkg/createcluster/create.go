@@ -2,6 +2,7 @@ package createcluster
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -28,6 +29,7 @@ import (
 	ovirtutils "github.com/openshift/hive/contrib/pkg/utils/ovirt"
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/clusterresource"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/gcpclient"
@@ -92,6 +94,9 @@ const (
 	cloudVSphere         = "vsphere"
 	cloudOVirt           = "ovirt"
 
+	// newClusterImageSetOption is the option of the cluster image set prompt creating a new ClusterImageSet.
+	newClusterImageSetOption = "<create a new image set for the latest release>"
+
 	testFailureManifest = `apiVersion: v1
 kind: NotARealSecret
 metadata:
@@ -116,6 +121,7 @@ var (
 type Options struct {
 	Name                     string
 	Namespace                string
+	CreateNamespace          bool
 	SSHPublicKeyFile         string
 	SSHPublicKey             string
 	SSHPrivateKeyFile        string
@@ -134,14 +140,18 @@ type Options struct {
 	ServingCertKey           string
 	UseClusterImageSet       bool
 	ManageDNS                bool
+	ManagedSubdomain         bool
 	Output                   string
 	IncludeSecrets           bool
 	InstallOnce              bool
 	UninstallOnce            bool
 	SimulateBootstrapFailure bool
 	WorkerNodesCount         int64
+	ControlPlaneNodesCount   int64
 	CreateSampleSyncsets     bool
 	ManifestsDir             string
+	CredentialsMode          string
+	CredentialsManifestsDir  string
 	Adopt                    bool
 	AdoptAdminKubeConfig     string
 	AdoptInfraID             string
@@ -156,7 +166,8 @@ type Options struct {
 	AdditionalTrustBundle    string
 
 	// AWS
-	AWSUserTags []string
+	AWSUserTags         []string
+	AWSServiceEndpoints []string
 
 	// Azure
 	AzureBaseDomainResourceGroupName string
@@ -238,6 +249,7 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags := cmd.Flags()
 	flags.StringVar(&opt.Cloud, "cloud", cloudAWS, "Cloud provider: aws(default)|azure|gcp|openstack)")
 	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace to create cluster deployment in")
+	flags.BoolVar(&opt.CreateNamespace, "create-namespace", false, "Create a dedicated namespace for the cluster deployment, deleted by Hive once the cluster is deprovisioned. The namespace defaults to the name of the cluster")
 	flags.StringVar(&opt.SSHPrivateKeyFile, "ssh-private-key-file", "", "file name containing private key contents")
 	flags.StringVar(&opt.SSHPublicKeyFile, "ssh-public-key-file", defaultSSHPublicKeyFile, "file name of SSH public key for cluster")
 	flags.StringVar(&opt.SSHPublicKey, "ssh-public-key", "", "SSH public key for cluster")
@@ -253,6 +265,7 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.StringVar(&opt.ServingCert, "serving-cert", "", "Serving certificate for control plane and routes")
 	flags.StringVar(&opt.ServingCertKey, "serving-cert-key", "", "Serving certificate key for control plane and routes")
 	flags.BoolVar(&opt.ManageDNS, "manage-dns", false, "Manage this cluster's DNS. This is only available for AWS and GCP.")
+	flags.BoolVar(&opt.ManagedSubdomain, "managed-subdomain", false, "Use the subdomain of the base domain named after the cluster as the base domain of the cluster, giving each cluster its own managed DNS zone. The base domain must be a managed domain. Requires --manage-dns.")
 	flags.BoolVar(&opt.UseClusterImageSet, "use-image-set", true, "If true(default), use a cluster image set for this cluster")
 	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
 	flags.BoolVar(&opt.IncludeSecrets, "include-secrets", true, "Include secrets along with ClusterDeployment")
@@ -260,10 +273,18 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.BoolVar(&opt.UninstallOnce, "uninstall-once", false, "Run the uninstall only one time and fail if not successful")
 	flags.BoolVar(&opt.SimulateBootstrapFailure, "simulate-bootstrap-failure", false, "Simulate an install bootstrap failure by injecting an invalid manifest.")
 	flags.Int64Var(&opt.WorkerNodesCount, "workers", 3, "Number of worker nodes to create.")
+	flags.Int64Var(&opt.ControlPlaneNodesCount, "control-plane-nodes", 3, "Number of control plane nodes to create. Use 1 with --workers=0 for a single-node cluster.")
 	flags.BoolVar(&opt.CreateSampleSyncsets, "create-sample-syncsets", false, "Create a set of sample syncsets for testing")
 	flags.StringVar(&opt.ManifestsDir, "manifests", "", "Directory containing manifests to add during installation")
+	flags.StringVar(&opt.CredentialsMode, "credentials-mode", "", "Mode in which the cloud credential operator provides the credentials of the cluster components. Valid values: Mint,Passthrough,Manual")
+	flags.StringVar(&opt.CredentialsManifestsDir, "credentials-manifests", "", "Directory containing the pre-created credentials manifests to add during installation in the Manual credentials mode")
 	flags.StringVar(&opt.MachineNetwork, "machine-network", "10.0.0.0/16", "Cluster's MachineNetwork to pass to the installer")
 	flags.StringVar(&opt.Region, "region", "", "Region to which to install the cluster. This is only relevant to AWS, Azure, and GCP.")
+
+	cmd.RegisterFlagCompletionFunc("cloud", utils.FixedCompletion(cloudAWS, cloudAzure, cloudGCP, cloudOpenStack, cloudOVirt, cloudVSphere))
+	cmd.RegisterFlagCompletionFunc("output", utils.FixedCompletion("yaml", "json"))
+	cmd.RegisterFlagCompletionFunc("credentials-mode", utils.FixedCompletion(string(hivev1.MintCredentialsMode), string(hivev1.PassthroughCredentialsMode), string(hivev1.ManualCredentialsMode)))
+	cmd.RegisterFlagCompletionFunc("image-set", utils.ClusterImageSetCompletion)
 	flags.StringSliceVarP(&opt.Labels, "labels", "l", nil, "Label to apply to the ClusterDeployment (key=val)")
 	flags.StringSliceVarP(&opt.Annotations, "annotations", "a", nil, "Annotation to apply to the ClusterDeployment (key=val)")
 	flags.BoolVar(&opt.SkipMachinePools, "skip-machine-pools", false, "Skip generation of Hive MachinePools for day 2 MachineSet management")
@@ -278,6 +299,7 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 
 	// AWS flags
 	flags.StringSliceVar(&opt.AWSUserTags, "aws-user-tags", nil, "Additional tags to add to resources. Must be in the form \"key=value\"")
+	flags.StringSliceVar(&opt.AWSServiceEndpoints, "aws-service-endpoints", nil, "Custom endpoints for AWS services. Must be in the form \"name=url\"")
 
 	// Azure flags
 	flags.StringVar(&opt.AzureBaseDomainResourceGroupName, "azure-base-domain-resource-group-name", "os4-common", "Resource group where the azure DNS zone for the base domain is found")
@@ -318,15 +340,45 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 	o.Name = args[0]
 
+	if o.CreateNamespace && o.Namespace == "" {
+		o.Namespace = o.Name
+	}
+
+	prompter := utils.NewPrompter(cmd)
+
 	if o.Region == "" {
+		defaultRegion := ""
 		switch o.Cloud {
 		case cloudAWS:
-			o.Region = "us-east-1"
+			defaultRegion = "us-east-1"
 		case cloudAzure:
-			o.Region = "centralus"
+			defaultRegion = "centralus"
 		case cloudGCP:
-			o.Region = "us-east1"
+			defaultRegion = "us-east1"
+		}
+		if defaultRegion != "" {
+			region, err := prompter.Input("Region", defaultRegion)
+			if err != nil {
+				return err
+			}
+			o.Region = region
+		}
+	}
+
+	if os.Getenv("PULL_SECRET") == "" && o.PullSecret == "" && o.PullSecretFile == "" {
+		pullSecretFile, err := prompter.Input("Pull secret file", "")
+		if err != nil {
+			return err
 		}
+		o.PullSecretFile = pullSecretFile
+	}
+
+	if o.UseClusterImageSet && o.ClusterImageSet == "" && o.ReleaseImage == "" && prompter.Enabled() {
+		imageSet, err := o.promptClusterImageSet(prompter)
+		if err != nil {
+			return err
+		}
+		o.ClusterImageSet = imageSet
 	}
 
 	if o.HibernateAfter != "" {
@@ -340,6 +392,24 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// promptClusterImageSet prompts for one of the ClusterImageSets of the cluster, returning an empty name to create a new
+// ClusterImageSet for the latest release.
+func (o *Options) promptClusterImageSet(prompter *utils.Prompter) (string, error) {
+	names, err := utils.ListClusterImageSetNames()
+	if err != nil {
+		o.log.WithError(err).Debug("cannot list cluster image sets, creating a new cluster image set")
+		return "", nil
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	imageSet, err := prompter.Select("Cluster image set", append(names, newClusterImageSetOption), newClusterImageSetOption)
+	if err != nil || imageSet == newClusterImageSetOption {
+		return "", err
+	}
+	return imageSet, nil
+}
+
 // Validate ensures that option values make sense
 func (o *Options) Validate(cmd *cobra.Command) error {
 	if len(o.Output) > 0 && o.Output != "yaml" && o.Output != "json" {
@@ -357,6 +427,11 @@ func (o *Options) Validate(cmd *cobra.Command) error {
 		o.log.Info("If specifying a serving certificate, specify a valid serving certificate key")
 		return fmt.Errorf("invalid serving cert")
 	}
+	if o.ManagedSubdomain && !o.ManageDNS {
+		cmd.Usage()
+		o.log.Info("A managed subdomain requires managing the DNS of the cluster")
+		return fmt.Errorf("--managed-subdomain requires --manage-dns")
+	}
 	if !validClouds[o.Cloud] {
 		cmd.Usage()
 		o.log.Infof("Unsupported cloud: %s", o.Cloud)
@@ -392,6 +467,19 @@ func (o *Options) Validate(cmd *cobra.Command) error {
 		}
 	}
 
+	switch hivev1.CredentialsMode(o.CredentialsMode) {
+	case "", hivev1.MintCredentialsMode, hivev1.PassthroughCredentialsMode:
+		if o.CredentialsManifestsDir != "" {
+			return fmt.Errorf("--credentials-manifests can only be used with --credentials-mode=Manual")
+		}
+	case hivev1.ManualCredentialsMode:
+		if o.CredentialsManifestsDir == "" {
+			return fmt.Errorf("must specify --credentials-manifests when using --credentials-mode=Manual")
+		}
+	default:
+		return fmt.Errorf("unsupported credentials mode: %s", o.CredentialsMode)
+	}
+
 	if o.Region != "" {
 		switch c := o.Cloud; c {
 		case cloudAWS, cloudAzure, cloudGCP:
@@ -452,8 +540,10 @@ func (o *Options) Run() error {
 			o.log.WithError(err).Errorf("Cannot create accessor for object of type %T", obj)
 			return err
 		}
-		accessor.SetNamespace(o.Namespace)
-		if _, err := rh.ApplyRuntimeObject(obj, scheme.Scheme); err != nil {
+		if _, ok := obj.(*corev1.Namespace); !ok {
+			accessor.SetNamespace(o.Namespace)
+		}
+		if _, err := rh.ApplyRuntimeObject(context.Background(), obj, scheme.Scheme); err != nil {
 			return err
 		}
 
@@ -490,6 +580,12 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		return nil, err
 	}
 
+	// Load credentials manifest files:
+	credentialsManifestFileData, err := o.getCredentialsManifestFileBytes()
+	if err != nil {
+		return nil, err
+	}
+
 	labels := map[string]string{
 		hiveutilCreatedLabel: "true", // implied
 	}
@@ -505,23 +601,28 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 	}
 
 	builder := &clusterresource.Builder{
-		Name:                  o.Name,
-		Namespace:             o.Namespace,
-		WorkerNodesCount:      o.WorkerNodesCount,
-		PullSecret:            pullSecret,
-		SSHPrivateKey:         sshPrivateKey,
-		SSHPublicKey:          sshPublicKey,
-		InstallOnce:           o.InstallOnce,
-		BaseDomain:            o.BaseDomain,
-		ManageDNS:             o.ManageDNS,
-		DeleteAfter:           o.DeleteAfter,
-		HibernateAfter:        o.HibernateAfterDur,
-		Labels:                labels,
-		Annotations:           annotations,
-		InstallerManifests:    manifestFileData,
-		MachineNetwork:        o.MachineNetwork,
-		SkipMachinePools:      o.SkipMachinePools,
-		AdditionalTrustBundle: additionalTrustBundle,
+		Name:                   o.Name,
+		Namespace:              o.Namespace,
+		CreateNamespace:        o.CreateNamespace,
+		WorkerNodesCount:       o.WorkerNodesCount,
+		ControlPlaneNodesCount: o.ControlPlaneNodesCount,
+		PullSecret:             pullSecret,
+		SSHPrivateKey:          sshPrivateKey,
+		SSHPublicKey:           sshPublicKey,
+		InstallOnce:            o.InstallOnce,
+		BaseDomain:             o.BaseDomain,
+		ManageDNS:              o.ManageDNS,
+		ManagedSubdomain:       o.ManagedSubdomain,
+		DeleteAfter:            o.DeleteAfter,
+		HibernateAfter:         o.HibernateAfterDur,
+		Labels:                 labels,
+		Annotations:            annotations,
+		InstallerManifests:     manifestFileData,
+		CredentialsMode:        hivev1.CredentialsMode(o.CredentialsMode),
+		CredentialsManifests:   credentialsManifestFileData,
+		MachineNetwork:         o.MachineNetwork,
+		SkipMachinePools:       o.SkipMachinePools,
+		AdditionalTrustBundle:  additionalTrustBundle,
 	}
 	if o.Adopt {
 		kubeconfigBytes, err := ioutil.ReadFile(o.AdoptAdminKubeConfig)
@@ -554,11 +655,23 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 				userTags[tagParts[0]] = tagParts[1]
 			}
 		}
+		serviceEndpoints := make([]hivev1aws.ServiceEndpoint, 0, len(o.AWSServiceEndpoints))
+		for _, e := range o.AWSServiceEndpoints {
+			endpointParts := strings.SplitN(e, "=", 2)
+			if len(endpointParts) != 2 {
+				return nil, fmt.Errorf("AWS service endpoint %q must be in the form name=url", e)
+			}
+			serviceEndpoints = append(serviceEndpoints, hivev1aws.ServiceEndpoint{
+				Name: endpointParts[0],
+				URL:  endpointParts[1],
+			})
+		}
 		awsProvider := &clusterresource.AWSCloudBuilder{
-			AccessKeyID:     accessKeyID,
-			SecretAccessKey: secretAccessKey,
-			UserTags:        userTags,
-			Region:          o.Region,
+			AccessKeyID:      accessKeyID,
+			SecretAccessKey:  secretAccessKey,
+			UserTags:         userTags,
+			Region:           o.Region,
+			ServiceEndpoints: serviceEndpoints,
 		}
 		builder.CloudBuilder = awsProvider
 	case cloudAzure:
@@ -819,6 +932,28 @@ func (o *Options) getManifestFileBytes() (map[string][]byte, error) {
 	return fileData, nil
 }
 
+func (o *Options) getCredentialsManifestFileBytes() (map[string][]byte, error) {
+	if o.CredentialsManifestsDir == "" {
+		return nil, nil
+	}
+	files, err := ioutil.ReadDir(o.CredentialsManifestsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read credentials manifests directory")
+	}
+	fileData := map[string][]byte{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(o.CredentialsManifestsDir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read credentials manifest file %q", file.Name())
+		}
+		fileData[file.Name()] = data
+	}
+	return fileData, nil
+}
+
 func (o *Options) configureImages(generator *clusterresource.Builder) (*hivev1.ClusterImageSet, error) {
 	if len(o.ClusterImageSet) > 0 {
 		generator.ImageSet = o.ClusterImageSet
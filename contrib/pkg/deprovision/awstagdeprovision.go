@@ -1,17 +1,26 @@
 package deprovision
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
+
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/destroy/aws"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/library-go/pkg/controller/fileobserver"
 
 	"github.com/openshift/hive/pkg/constants"
@@ -22,6 +31,8 @@ import (
 func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	opt := &aws.ClusterUninstaller{}
 	var logLevel string
+	var serviceEndpoints []string
+	var maxThrottledRequests int
 	cmd := &cobra.Command{
 		Use:   "aws-tag-deprovision KEY=VALUE ...",
 		Short: "Deprovision AWS assets (as created by openshift-installer) with the given tag(s)",
@@ -79,7 +90,30 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 				}()
 			}
 
-			if err := opt.Run(); err != nil {
+			if len(serviceEndpoints) > 0 {
+				if err := configureAWSServiceEndpoints(opt, serviceEndpoints); err != nil {
+					log.WithError(err).Fatal("Cannot configure AWS service endpoints")
+				}
+			}
+
+			if opt.Session == nil {
+				s, err := session.NewSession(awssdk.NewConfig().WithRegion(opt.Region))
+				if err != nil {
+					log.WithError(err).Fatal("Cannot create AWS session")
+				}
+				opt.Session = s
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			throttled := watchThrottledRequests(opt.Session, maxThrottledRequests, cancel)
+
+			if _, err := opt.RunWithContext(ctx); err != nil {
+				if message := throttled.terminationMessage(); message != "" {
+					// Exit so that the controller can back off before starting another uninstall job, instead of
+					// adding to the throttling of the account.
+					writeTerminationMessage(message)
+					log.Fatal(message)
+				}
 				log.WithError(err).Fatal("Runtime error")
 			}
 		},
@@ -87,9 +121,61 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use")
+	flags.StringArrayVar(&serviceEndpoints, "service-endpoint", nil, "Custom endpoint for an AWS service, in the form NAME=URL. May be specified multiple times.")
+	flags.IntVar(&maxThrottledRequests, "max-throttled-requests", 50, "Number of throttled AWS API requests after which to give up, so that the deprovision can be retried later. 0 to never give up.")
 	return cmd
 }
 
+// throttledRequests counts the AWS API requests that failed because of throttling, after the retries of the SDK.
+type throttledRequests struct {
+	sync.Mutex
+	count   int
+	lastErr error
+	max     int
+	cancel  context.CancelFunc
+}
+
+// watchThrottledRequests counts the throttled requests of the session and calls cancel once there are more than
+// max of them.
+func watchThrottledRequests(s *session.Session, max int, cancel context.CancelFunc) *throttledRequests {
+	t := &throttledRequests{max: max, cancel: cancel}
+	s.Handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "openshift.io/hive/throttledRequests",
+		Fn:   t.observe,
+	})
+	return t
+}
+
+func (t *throttledRequests) observe(r *request.Request) {
+	if r.Error == nil || !request.IsErrorThrottle(r.Error) {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.count++
+	t.lastErr = r.Error
+	if t.max > 0 && t.count >= t.max {
+		t.cancel()
+	}
+}
+
+// terminationMessage returns the reason to give up, or an empty string if there were not enough throttled requests.
+func (t *throttledRequests) terminationMessage() string {
+	t.Lock()
+	defer t.Unlock()
+	if t.max == 0 || t.count < t.max {
+		return ""
+	}
+	return fmt.Sprintf("AWS API requests were throttled %d times, last error: %v", t.count, t.lastErr)
+}
+
+// writeTerminationMessage writes the message where Kubernetes reports it in the status of the pod.
+func writeTerminationMessage(message string) {
+	if err := ioutil.WriteFile(corev1.TerminationMessagePathDefault, []byte(message), 0644); err != nil {
+		log.WithError(err).Warn("could not write termination message")
+	}
+}
+
 func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string) error {
 
 	for _, arg := range args {
@@ -120,6 +206,27 @@ func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []s
 	return nil
 }
 
+// configureAWSServiceEndpoints sets up the uninstaller session to use the given NAME=URL service endpoint overrides.
+func configureAWSServiceEndpoints(o *aws.ClusterUninstaller, serviceEndpoints []string) error {
+	endpoints := make([]awstypes.ServiceEndpoint, 0, len(serviceEndpoints))
+	for _, e := range serviceEndpoints {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("incorrectly formatted service endpoint %q", e)
+		}
+		endpoints = append(endpoints, awstypes.ServiceEndpoint{Name: parts[0], URL: parts[1]})
+	}
+	session, err := awssession.GetSessionWithOptions(
+		awssession.WithRegion(o.Region),
+		awssession.WithServiceEndpoints(o.Region, endpoints),
+	)
+	if err != nil {
+		return err
+	}
+	o.Session = session
+	return nil
+}
+
 func parseFilter(filterMap aws.Filter, str string) error {
 	parts := strings.SplitN(str, "=", 2)
 	if len(parts) != 2 {
@@ -0,0 +1,334 @@
+package fleet
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// action applies a change to a ClusterDeployment. It returns false when the ClusterDeployment already had the
+// change.
+type action func(c client.Client, cd *hivev1.ClusterDeployment) (bool, error)
+
+// Options are the options shared by the fleet commands.
+type Options struct {
+	Selector      string
+	All           bool
+	Namespace     string
+	AllNamespaces bool
+	Concurrency   int
+	Yes           bool
+	DryRun        bool
+
+	description string
+	action      action
+	in          io.Reader
+	out         io.Writer
+	log         log.FieldLogger
+}
+
+// result is the outcome of the action for one ClusterDeployment.
+type result struct {
+	cd      string
+	changed bool
+	err     error
+}
+
+// NewFleetCommand is the entrypoint to create the 'fleet' subcommand
+func NewFleetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Apply an action to all the ClusterDeployments matching a selector",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(newActionCommand("hibernate", "Hibernates the matching clusters", "hibernate", 0,
+		func([]string) (action, error) { return setPowerState(hivev1.HibernatingClusterPowerState), nil }))
+	cmd.AddCommand(newActionCommand("resume", "Resumes the matching hibernating clusters", "resume", 0,
+		func([]string) (action, error) { return setPowerState(hivev1.RunningClusterPowerState), nil }))
+	cmd.AddCommand(newActionCommand("delete", "Deletes the matching ClusterDeployments, deprovisioning their clusters", "delete", 0,
+		func([]string) (action, error) { return deleteClusterDeployment, nil }))
+	cmd.AddCommand(newActionCommand("label KEY=VALUE|KEY-", "Sets or removes a label on the matching ClusterDeployments", "label", 1,
+		func(args []string) (action, error) { return setLabel(args[0]) }))
+	cmd.AddCommand(newActionCommand("pause", "Pauses the syncing of SyncSets to the matching clusters", "pause syncing of", 0,
+		func([]string) (action, error) { return setSyncSetPause(true), nil }))
+	cmd.AddCommand(newActionCommand("unpause", "Resumes the syncing of SyncSets to the matching clusters", "unpause syncing of", 0,
+		func([]string) (action, error) { return setSyncSetPause(false), nil }))
+	return cmd
+}
+
+func newActionCommand(use, short, description string, nargs int, newAction func(args []string) (action, error)) *cobra.Command {
+	opt := &Options{
+		description: description,
+		in:          os.Stdin,
+		out:         os.Stdout,
+		log:         log.WithField("command", "fleet "+strings.Fields(use)[0]),
+	}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(nargs),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			var err error
+			if opt.action, err = newAction(args); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.validate(); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			c, err := utils.GetClient()
+			if err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.run(c); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Selector, "selector", "l", "", "Label selector of the ClusterDeployments")
+	flags.BoolVar(&opt.All, "all", false, "Select all of the ClusterDeployments. Required when no selector is specified")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployments")
+	flags.BoolVarP(&opt.AllNamespaces, "all-namespaces", "A", false, "Select ClusterDeployments in all namespaces")
+	flags.IntVar(&opt.Concurrency, "concurrency", 5, "Maximum number of ClusterDeployments changed at the same time")
+	flags.BoolVarP(&opt.Yes, "yes", "y", false, "Do not ask for confirmation")
+	flags.BoolVar(&opt.DryRun, "dry-run", false, "Only list the matching ClusterDeployments")
+	return cmd
+}
+
+// validate ensures that option values make sense
+func (o *Options) validate() error {
+	if o.Selector == "" && !o.All {
+		return fmt.Errorf("a selector is required, or --all to select all of the ClusterDeployments")
+	}
+	if o.Selector != "" && o.All {
+		return fmt.Errorf("a selector cannot be used together with --all")
+	}
+	if _, err := labels.Parse(o.Selector); err != nil {
+		return errors.Wrap(err, "invalid selector")
+	}
+	if o.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	return nil
+}
+
+// run applies the action to the matching ClusterDeployments and reports the results.
+func (o *Options) run(c client.Client) error {
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return errors.Wrap(err, "invalid selector")
+	}
+	listOpts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if !o.AllNamespaces {
+		if o.Namespace == "" {
+			if o.Namespace, err = utils.DefaultNamespace(); err != nil {
+				return errors.Wrap(err, "cannot determine default namespace")
+			}
+		}
+		listOpts = append(listOpts, client.InNamespace(o.Namespace))
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList, listOpts...); err != nil {
+		return errors.Wrap(err, "cannot list ClusterDeployments")
+	}
+	cds := cdList.Items
+	sort.Slice(cds, func(i, j int) bool {
+		return cds[i].Namespace+"/"+cds[i].Name < cds[j].Namespace+"/"+cds[j].Name
+	})
+	if len(cds) == 0 {
+		fmt.Fprintln(o.out, "No ClusterDeployments found")
+		return nil
+	}
+
+	for _, cd := range cds {
+		fmt.Fprintf(o.out, "%s/%s\n", cd.Namespace, cd.Name)
+	}
+	if o.DryRun {
+		fmt.Fprintf(o.out, "Would %s %d ClusterDeployments\n", o.description, len(cds))
+		return nil
+	}
+	if !o.Yes {
+		fmt.Fprintf(o.out, "Proceed to %s %d ClusterDeployments? [y/N]: ", o.description, len(cds))
+		answer, _ := bufio.NewReader(o.in).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Fprintln(o.out, "Aborted")
+			return nil
+		}
+	}
+
+	results := o.apply(c, cds)
+
+	var changed, unchanged int
+	var failed []result
+	for _, r := range results {
+		switch {
+		case r.err != nil:
+			failed = append(failed, r)
+		case r.changed:
+			changed++
+		default:
+			unchanged++
+		}
+	}
+	fmt.Fprintf(o.out, "Changed: %d, unchanged: %d, failed: %d\n", changed, unchanged, len(failed))
+	for _, r := range failed {
+		fmt.Fprintf(o.out, "  %s: %v\n", r.cd, r.err)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to %s %d ClusterDeployments", o.description, len(failed))
+	}
+	return nil
+}
+
+// apply runs the action on the ClusterDeployments, at most Concurrency at a time.
+func (o *Options) apply(c client.Client, cds []hivev1.ClusterDeployment) []result {
+	results := make([]result, len(cds))
+	sem := make(chan struct{}, o.Concurrency)
+	var wg sync.WaitGroup
+	for i := range cds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			cd := &cds[i]
+			key := fmt.Sprintf("%s/%s", cd.Namespace, cd.Name)
+			changed, err := o.action(c, cd)
+			results[i] = result{cd: key, changed: changed, err: err}
+			logger := o.log.WithField("clusterDeployment", key)
+			switch {
+			case err != nil:
+				logger.WithError(err).Error("failed")
+			case changed:
+				logger.Info("changed")
+			default:
+				logger.Debug("unchanged")
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// patch applies the mutation to the ClusterDeployment with a merge patch, so that concurrent changes to other fields
+// are not overwritten.
+func patch(c client.Client, cd *hivev1.ClusterDeployment, mutate func(cd *hivev1.ClusterDeployment) bool) (bool, error) {
+	if cd.DeletionTimestamp != nil {
+		return false, fmt.Errorf("ClusterDeployment is being deleted")
+	}
+	orig := cd.DeepCopy()
+	if !mutate(cd) {
+		return false, nil
+	}
+	if err := c.Patch(context.Background(), cd, client.MergeFrom(orig)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func setPowerState(state hivev1.ClusterPowerState) action {
+	return func(c client.Client, cd *hivev1.ClusterDeployment) (bool, error) {
+		return patch(c, cd, func(cd *hivev1.ClusterDeployment) bool {
+			current := cd.Spec.PowerState
+			if current == "" {
+				current = hivev1.RunningClusterPowerState
+			}
+			if current == state {
+				return false
+			}
+			cd.Spec.PowerState = state
+			return true
+		})
+	}
+}
+
+func deleteClusterDeployment(c client.Client, cd *hivev1.ClusterDeployment) (bool, error) {
+	if cd.DeletionTimestamp != nil {
+		return false, nil
+	}
+	if err := c.Delete(context.Background(), cd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setLabel returns the action setting a label for KEY=VALUE, or removing it for KEY-.
+func setLabel(arg string) (action, error) {
+	var key, value string
+	remove := false
+	switch {
+	case strings.HasSuffix(arg, "-") && !strings.Contains(arg, "="):
+		key = strings.TrimSuffix(arg, "-")
+		remove = true
+	case strings.Contains(arg, "="):
+		kv := strings.SplitN(arg, "=", 2)
+		key, value = kv[0], kv[1]
+	}
+	if key == "" {
+		return nil, fmt.Errorf("invalid label %q, must be KEY=VALUE or KEY-", arg)
+	}
+	return func(c client.Client, cd *hivev1.ClusterDeployment) (bool, error) {
+		return patch(c, cd, func(cd *hivev1.ClusterDeployment) bool {
+			current, ok := cd.Labels[key]
+			if remove {
+				if !ok {
+					return false
+				}
+				delete(cd.Labels, key)
+				return true
+			}
+			if ok && current == value {
+				return false
+			}
+			if cd.Labels == nil {
+				cd.Labels = map[string]string{}
+			}
+			cd.Labels[key] = value
+			return true
+		})
+	}, nil
+}
+
+func setSyncSetPause(pause bool) action {
+	return func(c client.Client, cd *hivev1.ClusterDeployment) (bool, error) {
+		return patch(c, cd, func(cd *hivev1.ClusterDeployment) bool {
+			paused, _ := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation])
+			if paused == pause {
+				return false
+			}
+			if pause {
+				if cd.Annotations == nil {
+					cd.Annotations = map[string]string{}
+				}
+				cd.Annotations[constants.SyncsetPauseAnnotation] = "true"
+			} else {
+				delete(cd.Annotations, constants.SyncsetPauseAnnotation)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package machinepool
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/openshift/hive/pkg/apis"
+)
+
+// NewMachinePoolCommand is the entrypoint to create the 'machinepool' subcommand
+func NewMachinePoolCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "machinepool",
+		Short: "Utility to manage MachinePools",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewCreateMachinePoolCommand())
+	cmd.AddCommand(NewScaleMachinePoolCommand())
+	return cmd
+}
+
+// validateOutput checks that the output format, if any, is supported.
+func validateOutput(output string) error {
+	if output != "" && output != "yaml" && output != "json" {
+		return fmt.Errorf("invalid output %q, valid values are: yaml, json", output)
+	}
+	return nil
+}
+
+// printObject prints the object in the output format instead of applying it to the cluster.
+func printObject(obj runtime.Object, output string) error {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return err
+	}
+	var printer printers.ResourcePrinter = &printers.JSONPrinter{}
+	if output == "yaml" {
+		printer = &printers.YAMLPrinter{}
+	}
+	return printers.NewTypeSetter(scheme).ToPrinter(printer).PrintObj(obj, os.Stdout)
+}
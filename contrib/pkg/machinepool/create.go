@@ -0,0 +1,267 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+)
+
+const (
+	cloudAWS   = "aws"
+	cloudAzure = "azure"
+	cloudGCP   = "gcp"
+
+	defaultAWSInstanceType    = "m4.xlarge"
+	defaultAWSVolumeIOPS      = 100
+	defaultAWSVolumeSize      = 120
+	defaultAWSVolumeType      = "gp2"
+	defaultAzureInstanceType  = "Standard_D2s_v3"
+	defaultAzureOSDiskSizeGB  = 128
+	defaultGCPInstanceType    = "n1-standard-4"
+	createMachinePoolLongDesc = `
+OVERVIEW
+The hiveutil machinepool create command generates and applies a MachinePool
+for an existing ClusterDeployment. The cloud of the MachinePool is taken from
+the ClusterDeployment, unless it is specified with the --cloud flag.
+Currently machine pools can be created for AWS, Azure and GCP clusters.
+
+DRY RUN
+With the --output flag, the MachinePool is printed instead of being created.
+When --cloud is specified as well, the cluster is not accessed.
+`
+)
+
+// CreateMachinePoolOptions are the options of the machinepool create command.
+type CreateMachinePoolOptions struct {
+	Name              string
+	Namespace         string
+	ClusterDeployment string
+	Cloud             string
+	Replicas          int64
+	MinReplicas       int32
+	MaxReplicas       int32
+	InstanceType      string
+	Zones             []string
+	Labels            []string
+	AWSVolumeSize     int
+	AWSVolumeType     string
+	AWSVolumeIOPS     int
+	AzureOSDiskSizeGB int32
+	Output            string
+
+	log log.FieldLogger
+}
+
+// NewCreateMachinePoolCommand creates the 'machinepool create' subcommand.
+func NewCreateMachinePoolCommand() *cobra.Command {
+	opt := &CreateMachinePoolOptions{log: log.WithField("command", "machinepool create")}
+
+	cmd := &cobra.Command{
+		Use: `create POOL_NAME --cluster-deployment=CLUSTER_DEPLOYMENT_NAME
+create POOL_NAME --cluster-deployment=CLUSTER_DEPLOYMENT_NAME --replicas=3 --instance-type=m5.2xlarge
+create POOL_NAME --cluster-deployment=CLUSTER_DEPLOYMENT_NAME --min-replicas=1 --max-replicas=5`,
+		Short: "Creates a MachinePool for a ClusterDeployment",
+		Long:  createMachinePoolLongDesc,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.validate(); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	flags.StringVar(&opt.ClusterDeployment, "cluster-deployment", "", "Name of the ClusterDeployment to which the MachinePool belongs")
+	flags.StringVar(&opt.Cloud, "cloud", "", "Cloud provider: aws|azure|gcp. Defaults to the cloud of the ClusterDeployment")
+	flags.Int64Var(&opt.Replicas, "replicas", 1, "Number of machines in the pool")
+	flags.Int32Var(&opt.MinReplicas, "min-replicas", 0, "Minimum number of machines in the pool. Enables autoscaling along with --max-replicas")
+	flags.Int32Var(&opt.MaxReplicas, "max-replicas", 0, "Maximum number of machines in the pool. Enables autoscaling along with --min-replicas")
+	flags.StringVar(&opt.InstanceType, "instance-type", "", "Instance type of the machines. Defaults vary depending on cloud")
+	flags.StringSliceVar(&opt.Zones, "zones", nil, "Availability zones of the machines. Defaults to all of the zones of the region")
+	flags.StringSliceVar(&opt.Labels, "labels", nil, "Labels to apply to the nodes of the pool, as key=value pairs")
+	flags.IntVar(&opt.AWSVolumeSize, "aws-root-volume-size", defaultAWSVolumeSize, "Size in GiB of the root volume of the machines on AWS")
+	flags.StringVar(&opt.AWSVolumeType, "aws-root-volume-type", defaultAWSVolumeType, "Type of the root volume of the machines on AWS")
+	flags.IntVar(&opt.AWSVolumeIOPS, "aws-root-volume-iops", defaultAWSVolumeIOPS, "IOPS of the root volume of the machines on AWS")
+	flags.Int32Var(&opt.AzureOSDiskSizeGB, "azure-os-disk-size", defaultAzureOSDiskSizeGB, "Size in GB of the OS disk of the machines on Azure")
+	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be created on cluster). Valid values: yaml,json")
+
+	return cmd
+}
+
+// validate ensures that option values make sense
+func (o *CreateMachinePoolOptions) validate() error {
+	if o.ClusterDeployment == "" {
+		return fmt.Errorf("cluster deployment is required")
+	}
+	if o.Cloud != "" && o.Cloud != cloudAWS && o.Cloud != cloudAzure && o.Cloud != cloudGCP {
+		return fmt.Errorf("unsupported cloud: %s", o.Cloud)
+	}
+	if (o.MinReplicas != 0 || o.MaxReplicas != 0) && o.MinReplicas > o.MaxReplicas {
+		return fmt.Errorf("min replicas must not be greater than max replicas")
+	}
+	if _, err := parseLabels(o.Labels); err != nil {
+		return err
+	}
+	return validateOutput(o.Output)
+}
+
+// run executes the command
+func (o *CreateMachinePoolOptions) run() error {
+	var err error
+	if o.Namespace == "" {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+
+	if o.Cloud == "" {
+		c, err := utils.GetClient()
+		if err != nil {
+			return errors.Wrap(err, "cannot create client")
+		}
+		cd := &hivev1.ClusterDeployment{}
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.ClusterDeployment}, cd); err != nil {
+			return errors.Wrap(err, "cannot get ClusterDeployment")
+		}
+		switch p := cd.Spec.Platform; {
+		case p.AWS != nil:
+			o.Cloud = cloudAWS
+		case p.Azure != nil:
+			o.Cloud = cloudAzure
+		case p.GCP != nil:
+			o.Cloud = cloudGCP
+		default:
+			return fmt.Errorf("machine pools cannot be created by this command for the platform of the ClusterDeployment")
+		}
+	}
+
+	mp, err := o.generateMachinePool()
+	if err != nil {
+		return err
+	}
+
+	if o.Output != "" {
+		return printObject(mp, o.Output)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return err
+	}
+	rh, err := utils.GetResourceHelper(o.log)
+	if err != nil {
+		return err
+	}
+	if _, err := rh.ApplyRuntimeObject(context.Background(), mp, scheme); err != nil {
+		return err
+	}
+	o.log.WithField("machinePool", mp.Name).Info("created MachinePool")
+	return nil
+}
+
+func (o *CreateMachinePoolOptions) generateMachinePool() (*hivev1.MachinePool, error) {
+	labels, err := parseLabels(o.Labels)
+	if err != nil {
+		return nil, err
+	}
+	mp := &hivev1.MachinePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachinePool",
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", o.ClusterDeployment, o.Name),
+			Namespace: o.Namespace,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: o.ClusterDeployment},
+			Name:                 o.Name,
+			Labels:               labels,
+		},
+	}
+	if o.MinReplicas != 0 || o.MaxReplicas != 0 {
+		mp.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{
+			MinReplicas: o.MinReplicas,
+			MaxReplicas: o.MaxReplicas,
+		}
+	} else {
+		replicas := o.Replicas
+		mp.Spec.Replicas = &replicas
+	}
+
+	switch o.Cloud {
+	case cloudAWS:
+		mp.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{
+			Zones:        o.Zones,
+			InstanceType: instanceTypeOrDefault(o.InstanceType, defaultAWSInstanceType),
+			EC2RootVolume: hivev1aws.EC2RootVolume{
+				IOPS: o.AWSVolumeIOPS,
+				Size: o.AWSVolumeSize,
+				Type: o.AWSVolumeType,
+			},
+		}
+	case cloudAzure:
+		mp.Spec.Platform.Azure = &hivev1azure.MachinePool{
+			Zones:        o.Zones,
+			InstanceType: instanceTypeOrDefault(o.InstanceType, defaultAzureInstanceType),
+			OSDisk: hivev1azure.OSDisk{
+				DiskSizeGB: o.AzureOSDiskSizeGB,
+			},
+		}
+	case cloudGCP:
+		mp.Spec.Platform.GCP = &hivev1gcp.MachinePool{
+			Zones:        o.Zones,
+			InstanceType: instanceTypeOrDefault(o.InstanceType, defaultGCPInstanceType),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported cloud: %s", o.Cloud)
+	}
+	return mp, nil
+}
+
+func instanceTypeOrDefault(instanceType, defaultInstanceType string) string {
+	if instanceType == "" {
+		return defaultInstanceType
+	}
+	return instanceType
+}
+
+// parseLabels parses a list of key=value pairs into a map.
+func parseLabels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid label %q, must be a key=value pair", pair)
+		}
+		labels[kv[0]] = kv[1]
+	}
+	return labels, nil
+}
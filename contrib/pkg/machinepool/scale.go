@@ -0,0 +1,155 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// ScaleMachinePoolOptions are the options of the machinepool scale command.
+type ScaleMachinePoolOptions struct {
+	Name         string
+	Namespace    string
+	Replicas     int64
+	MinReplicas  int32
+	MaxReplicas  int32
+	InstanceType string
+	Output       string
+
+	replicasSet    bool
+	autoscalingSet bool
+	log            log.FieldLogger
+}
+
+// NewScaleMachinePoolCommand creates the 'machinepool scale' subcommand.
+func NewScaleMachinePoolCommand() *cobra.Command {
+	opt := &ScaleMachinePoolOptions{log: log.WithField("command", "machinepool scale")}
+
+	cmd := &cobra.Command{
+		Use: `scale MACHINE_POOL_NAME --replicas=3
+scale MACHINE_POOL_NAME --min-replicas=1 --max-replicas=5
+scale MACHINE_POOL_NAME --instance-type=m5.2xlarge`,
+		Short: "Scales or resizes an existing MachinePool",
+		Long: `Sets the number of machines of an existing MachinePool, switching it between fixed
+replicas and autoscaling as needed, or changes the instance type of its machines.
+With the --output flag, the updated MachinePool is printed instead of being saved.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			opt.replicasSet = cmd.Flags().Changed("replicas")
+			opt.autoscalingSet = cmd.Flags().Changed("min-replicas") || cmd.Flags().Changed("max-replicas")
+			if err := opt.validate(); err != nil {
+				cmd.Usage()
+				opt.log.WithError(err).Fatal("Error")
+			}
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the MachinePool")
+	flags.Int64Var(&opt.Replicas, "replicas", 0, "Number of machines in the pool. Disables autoscaling")
+	flags.Int32Var(&opt.MinReplicas, "min-replicas", 0, "Minimum number of machines in the pool. Enables autoscaling")
+	flags.Int32Var(&opt.MaxReplicas, "max-replicas", 0, "Maximum number of machines in the pool. Enables autoscaling")
+	flags.StringVar(&opt.InstanceType, "instance-type", "", "Instance type of the machines")
+	flags.StringVarP(&opt.Output, "output", "o", "", "Output of this command (nothing will be changed on cluster). Valid values: yaml,json")
+
+	return cmd
+}
+
+// validate ensures that option values make sense
+func (o *ScaleMachinePoolOptions) validate() error {
+	if o.replicasSet && o.autoscalingSet {
+		return fmt.Errorf("replicas cannot be used together with min or max replicas")
+	}
+	if !o.replicasSet && !o.autoscalingSet && o.InstanceType == "" {
+		return fmt.Errorf("must specify replicas, min and max replicas, or instance type")
+	}
+	if o.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	return validateOutput(o.Output)
+}
+
+// run executes the command
+func (o *ScaleMachinePoolOptions) run() error {
+	var err error
+	if o.Namespace == "" {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "cannot create client")
+	}
+	mp := &hivev1.MachinePool{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, mp); err != nil {
+		return errors.Wrap(err, "cannot get MachinePool")
+	}
+
+	if err := o.scale(mp); err != nil {
+		return err
+	}
+
+	if o.Output != "" {
+		return printObject(mp, o.Output)
+	}
+	if err := c.Update(context.Background(), mp); err != nil {
+		return errors.Wrap(err, "cannot update MachinePool")
+	}
+	o.log.WithField("machinePool", mp.Name).Info("updated MachinePool")
+	return nil
+}
+
+// scale applies the requested size and instance type to the MachinePool.
+func (o *ScaleMachinePoolOptions) scale(mp *hivev1.MachinePool) error {
+	switch {
+	case o.replicasSet:
+		replicas := o.Replicas
+		mp.Spec.Replicas = &replicas
+		mp.Spec.Autoscaling = nil
+	case o.autoscalingSet:
+		autoscaling := &hivev1.MachinePoolAutoscaling{}
+		if mp.Spec.Autoscaling != nil {
+			*autoscaling = *mp.Spec.Autoscaling
+		}
+		if o.MinReplicas != 0 {
+			autoscaling.MinReplicas = o.MinReplicas
+		}
+		if o.MaxReplicas != 0 {
+			autoscaling.MaxReplicas = o.MaxReplicas
+		}
+		if autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			return fmt.Errorf("min replicas must not be greater than max replicas")
+		}
+		mp.Spec.Autoscaling = autoscaling
+		mp.Spec.Replicas = nil
+	}
+
+	if o.InstanceType != "" {
+		switch p := mp.Spec.Platform; {
+		case p.AWS != nil:
+			p.AWS.InstanceType = o.InstanceType
+		case p.Azure != nil:
+			p.Azure.InstanceType = o.InstanceType
+		case p.GCP != nil:
+			p.GCP.InstanceType = o.InstanceType
+		default:
+			return fmt.Errorf("the instance type cannot be changed by this command for the platform of the MachinePool")
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package testresource
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -61,19 +62,19 @@ func newApplyCommand() *cobra.Command {
 				fmt.Printf("Error creating resource helper: %v\n", err)
 				return
 			}
-			info, err := helper.Info(content)
+			info, err := helper.Info(context.Background(), content)
 			if err != nil {
 				fmt.Printf("Error obtaining info: %v\n", err)
 				return
 			}
 			name := types.NamespacedName{Namespace: info.Namespace, Name: info.Name}
 			fmt.Printf("The resource is %s (Kind: %s, APIVersion: %s)", name.String(), info.Kind, info.APIVersion)
-			applyResult, err := helper.Apply(content)
+			applyResult, err := helper.Apply(context.Background(), content)
 			if err != nil {
 				fmt.Printf("Error applying: %v\n", err)
 				return
 			}
-			fmt.Printf("The resource was applied successfully: %s\n", applyResult)
+			fmt.Printf("The resource was applied successfully: %s\n", applyResult.Result)
 		},
 	}
 	cmd.Flags().StringVarP(&kubeconfigPath, "kubeconfig", "k", os.Getenv("KUBECONFIG"), "Kubeconfig file to connect to target server")
@@ -133,7 +134,7 @@ func newPatchCommand() *cobra.Command {
 				fmt.Printf("Error creating resource helper: %v\n", err)
 				return
 			}
-			err = helper.Patch(types.NamespacedName{Name: name, Namespace: namespace}, kind, apiVersion, content, patchTypeStr)
+			err = helper.Patch(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, kind, apiVersion, content, patchTypeStr)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				return
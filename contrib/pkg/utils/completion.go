@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// FixedCompletion returns a completion function completing a flag with the given values.
+func FixedCompletion(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ClusterImageSetCompletion completes a flag with the names of the ClusterImageSets of the cluster of the current
+// kubeconfig.
+func ClusterImageSetCompletion(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	names, err := ListClusterImageSetNames()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// ListClusterImageSetNames returns the sorted names of the ClusterImageSets of the cluster of the current kubeconfig.
+func ListClusterImageSetNames() ([]string, error) {
+	c, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	imageSets := &hivev1.ClusterImageSetList{}
+	if err := c.List(context.Background(), imageSets); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(imageSets.Items))
+	for _, imageSet := range imageSets.Items {
+		names = append(names, imageSet.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+// NonInteractiveFlag is the flag of hiveutil disabling the prompts for missing options, such as in CI.
+const NonInteractiveFlag = "non-interactive"
+
+// Prompter asks the user for the values of the options which were not given on the command line. It only prompts
+// when the standard input is a terminal and the --non-interactive flag is not set, the callers otherwise falling back
+// to the defaults of the options.
+type Prompter struct {
+	enabled bool
+	in      *bufio.Reader
+	out     io.Writer
+}
+
+// NewPrompter returns a prompter for the command, disabled when the command runs with the --non-interactive flag.
+func NewPrompter(cmd *cobra.Command) *Prompter {
+	// The flag is not defined when the command does not run under hiveutil, which is then interactive.
+	nonInteractive, _ := cmd.Flags().GetBool(NonInteractiveFlag)
+	return &Prompter{
+		enabled: !nonInteractive && term.IsTerminal(os.Stdin),
+		in:      bufio.NewReader(os.Stdin),
+		out:     os.Stderr,
+	}
+}
+
+// Enabled returns true if the prompter prompts the user.
+func (p *Prompter) Enabled() bool {
+	return p != nil && p.enabled
+}
+
+// Input prompts for a value, returning the default value when prompting is disabled or the answer is empty.
+func (p *Prompter) Input(message, defaultValue string) (string, error) {
+	if !p.Enabled() {
+		return defaultValue, nil
+	}
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", message, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", message)
+	}
+	answer, err := p.readLine()
+	if err != nil || answer == "" {
+		return defaultValue, err
+	}
+	return answer, nil
+}
+
+// Select prompts for one of the options, returning the default value when prompting is disabled or the answer is
+// empty.
+func (p *Prompter) Select(message string, options []string, defaultValue string) (string, error) {
+	if !p.Enabled() {
+		return defaultValue, nil
+	}
+	fmt.Fprintf(p.out, "%s:\n", message)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, option)
+	}
+	for {
+		fmt.Fprintf(p.out, "Choose an option [%s]: ", defaultValue)
+		answer, err := p.readLine()
+		if err != nil || answer == "" {
+			return defaultValue, err
+		}
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(options) {
+			return options[i-1], nil
+		}
+		for _, option := range options {
+			if answer == option {
+				return option, nil
+			}
+		}
+		fmt.Fprintf(p.out, "%q is not one of the options\n", answer)
+	}
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
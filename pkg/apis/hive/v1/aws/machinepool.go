@@ -4,6 +4,7 @@ package aws
 // installed on AWS.
 type MachinePoolPlatform struct {
 	// Zones is list of availability zones that can be used.
+	// When omitted and subnets are specified, the zones of the subnets are used.
 	Zones []string `json:"zones,omitempty"`
 
 	// Subnets is the list of subnets to which to attach the machines.
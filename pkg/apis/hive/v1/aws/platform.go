@@ -14,7 +14,40 @@ type Platform struct {
 	// Region specifies the AWS region where the cluster will be created.
 	Region string `json:"region"`
 
-	// UserTags specifies additional tags for AWS resources created for the cluster.
+	// FallbackRegions is an ordered list of regions where the install is retried when it fails for lack of
+	// capacity in Region. The region where the cluster is installed is reported in the installRegion status field
+	// of the ClusterDeployment.
+	// +optional
+	FallbackRegions []string `json:"fallbackRegions,omitempty"`
+
+	// AMIID is the AMI used to boot the bootstrap and control plane machines of the cluster, overriding the RHCOS AMI
+	// of the release. The AMI must belong to the region of the cluster.
+	// +optional
+	AMIID string `json:"amiID,omitempty"`
+
+	// UserTags specifies additional tags for AWS resources created for the cluster. Hive merges them into the user tags
+	// of the install config, replacing the tags of the same keys, so that the installer tags the resources of the
+	// cluster, and sets them on the resources it creates itself, such as the hosted zone of a managed DNSZone.
 	// +optional
 	UserTags map[string]string `json:"userTags,omitempty"`
+
+	// ServiceEndpoints list contains custom endpoints which will override the default
+	// service endpoints of AWS Services.
+	// There must be only one ServiceEndpoint for a service.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+}
+
+// ServiceEndpoint stores the configuration for services to
+// override existing defaults of AWS Services.
+type ServiceEndpoint struct {
+	// Name is the name of the AWS service.
+	// This must be provided and cannot be empty.
+	Name string `json:"name"`
+
+	// URL is fully qualified URI with scheme https, that overrides the default generated
+	// endpoint for a client.
+	// This must be provided and cannot be empty.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
 }
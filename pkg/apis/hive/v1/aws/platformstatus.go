@@ -0,0 +1,16 @@
+package aws
+
+// PlatformStatus contains the identifiers of the AWS resources of an installed cluster.
+type PlatformStatus struct {
+	// VPCID is the ID of the VPC of the cluster, whether created by the installer or pre-existing.
+	// +optional
+	VPCID string `json:"vpcID,omitempty"`
+
+	// PrivateHostedZoneID is the ID of the private Route53 hosted zone of the cluster.
+	// +optional
+	PrivateHostedZoneID string `json:"privateHostedZoneID,omitempty"`
+
+	// PublicHostedZoneID is the ID of the public Route53 hosted zone of the base domain of the cluster.
+	// +optional
+	PublicHostedZoneID string `json:"publicHostedZoneID,omitempty"`
+}
@@ -56,6 +56,11 @@ func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.FallbackRegions != nil {
+		in, out := &in.FallbackRegions, &out.FallbackRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.UserTags != nil {
 		in, out := &in.UserTags, &out.UserTags
 		*out = make(map[string]string, len(*in))
@@ -63,6 +68,11 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 			(*out)[key] = val
 		}
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -76,6 +86,38 @@ func (in *Platform) DeepCopy() *Platform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceEndpoint) DeepCopyInto(out *ServiceEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpoint.
+func (in *ServiceEndpoint) DeepCopy() *ServiceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotMarketOptions) DeepCopyInto(out *SpotMarketOptions) {
 	*out = *in
@@ -13,6 +13,11 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// Subnet is the name of an existing subnet in which to create the machines. The subnet must belong to the
+	// virtual network used by the control plane of the cluster. When omitted, the compute subnet of the cluster is used.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
 }
 
 // OSDisk defines the disk for machines on Azure.
@@ -38,4 +43,8 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.Subnet != "" {
+		a.Subnet = required.Subnet
+	}
 }
@@ -0,0 +1,17 @@
+package azure
+
+// PlatformStatus contains the identifiers of the Azure resources of an installed cluster.
+type PlatformStatus struct {
+	// ResourceGroupName is the name of the resource group of the cluster.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// VirtualNetwork is the name of the virtual network of the cluster, whether created by the installer or
+	// pre-existing.
+	// +optional
+	VirtualNetwork string `json:"virtualNetwork,omitempty"`
+
+	// NetworkResourceGroupName is the name of the resource group of the virtual network of the cluster.
+	// +optional
+	NetworkResourceGroupName string `json:"networkResourceGroupName,omitempty"`
+}
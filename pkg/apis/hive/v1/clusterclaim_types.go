@@ -69,6 +69,9 @@ const (
 	ClusterClaimClusterDeletedCondition ClusterClaimConditionType = "ClusterDeleted"
 	// ClusterRunningCondition is true when a claimed cluster is running and ready for use.
 	ClusterRunningCondition ClusterClaimConditionType = "ClusterRunning"
+	// ClusterClaimReadyCondition is true when a cluster has been assigned to the claim and is running and ready for
+	// use.
+	ClusterClaimReadyCondition ClusterClaimConditionType = "Ready"
 )
 
 // +genclient
@@ -86,6 +86,13 @@ type ClusterDeploymentSpec struct {
 	// PreserveOnDelete allows the user to disconnect a cluster from Hive without deprovisioning it
 	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
 
+	// DeprovisionCredentialsSecretRef is the reference to a secret holding credentials used only to deprovision the
+	// cluster, instead of the credentials of the platform. The secret has the same format as the credentials secret
+	// of the platform. Hive validates the credentials before provisioning the cluster, so that the cluster can be
+	// deprovisioned once the credentials of the platform have been rotated or revoked.
+	// +optional
+	DeprovisionCredentialsSecretRef *corev1.LocalObjectReference `json:"deprovisionCredentialsSecretRef,omitempty"`
+
 	// ControlPlaneConfig contains additional configuration for the target cluster's control plane
 	// +optional
 	ControlPlaneConfig ControlPlaneConfigSpec `json:"controlPlaneConfig,omitempty"`
@@ -132,6 +139,152 @@ type ClusterDeploymentSpec struct {
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
+
+	// ClusterAutoscaler is the configuration for the default ClusterAutoscaler in the cluster. The configuration is
+	// synced to the cluster while any MachinePool for the cluster uses autoscaling. Settings of the ClusterAutoscaler
+	// that are not specified here are left untouched.
+	// +optional
+	ClusterAutoscaler *ClusterAutoscalerConfig `json:"clusterAutoscaler,omitempty"`
+
+	// PostInstallChecks are the checks which must pass after the installer completes before the cluster is marked
+	// as installed. When omitted, the default checks from HiveConfig are used.
+	// +optional
+	PostInstallChecks []PostInstallCheck `json:"postInstallChecks,omitempty"`
+
+	// Hooks are Jobs which Hive runs at stages of the lifecycle of the cluster, such as to register the cluster in
+	// an inventory once it is installed, or to drain workloads before it is deprovisioned.
+	// +optional
+	Hooks []ClusterHook `json:"hooks,omitempty"`
+}
+
+// ClusterHookStage is a stage of the lifecycle of a cluster at which a hook runs.
+// +kubebuilder:validation:Enum=PreInstall;PostInstall;PreDeprovision
+type ClusterHookStage string
+
+const (
+	// PreInstallClusterHookStage runs the hook before the first provision of the cluster is started.
+	PreInstallClusterHookStage ClusterHookStage = "PreInstall"
+	// PostInstallClusterHookStage runs the hook after the installer completes, before the cluster is marked as
+	// installed.
+	PostInstallClusterHookStage ClusterHookStage = "PostInstall"
+	// PreDeprovisionClusterHookStage runs the hook when the ClusterDeployment is deleted, before the cluster is
+	// deprovisioned.
+	PreDeprovisionClusterHookStage ClusterHookStage = "PreDeprovision"
+)
+
+// ClusterHook is a Job which Hive runs at a stage of the lifecycle of the cluster. The Job runs once in the
+// namespace of the ClusterDeployment. When the cluster has been installed, the admin kubeconfig of the cluster is
+// mounted in the containers of the Job at /etc/kubeconfig/kubeconfig, and the KUBECONFIG environment variable points
+// to it.
+type ClusterHook struct {
+	// Name identifies the hook. It must be unique among the hooks of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Stage is the stage of the lifecycle of the cluster at which the hook runs.
+	Stage ClusterHookStage `json:"stage"`
+
+	// PodTemplateRef is a reference to a PodTemplate in the namespace of the ClusterDeployment, used as the template
+	// of the pod of the Job.
+	PodTemplateRef corev1.LocalObjectReference `json:"podTemplateRef"`
+
+	// Blocking indicates whether the stage waits for the Job to complete successfully before proceeding. A failed
+	// blocking hook holds the stage until its Job is deleted, at which point it runs again. The failure of a
+	// non-blocking hook is only reported.
+	// +optional
+	Blocking bool `json:"blocking,omitempty"`
+}
+
+// PostInstallCheckType is a type of check run after the installer completes.
+// +kubebuilder:validation:Enum=APIReachable;ConsoleAvailable;ClusterOperatorsAvailable;NodesReady;Job
+type PostInstallCheckType string
+
+const (
+	// APIReachablePostInstallCheck checks that the API server of the cluster can be reached.
+	APIReachablePostInstallCheck PostInstallCheckType = "APIReachable"
+	// ConsoleAvailablePostInstallCheck checks that the console route of the cluster is serving.
+	ConsoleAvailablePostInstallCheck PostInstallCheckType = "ConsoleAvailable"
+	// ClusterOperatorsAvailablePostInstallCheck checks that all the ClusterOperators of the cluster are available.
+	ClusterOperatorsAvailablePostInstallCheck PostInstallCheckType = "ClusterOperatorsAvailable"
+	// NodesReadyPostInstallCheck checks that all the nodes of the cluster are ready, and that the cluster has as many
+	// control plane and compute nodes as its install-config asks for.
+	NodesReadyPostInstallCheck PostInstallCheckType = "NodesReady"
+	// JobPostInstallCheck runs a user-supplied Job which must complete successfully.
+	JobPostInstallCheck PostInstallCheckType = "Job"
+)
+
+// PostInstallCheck is a check run after the installer completes.
+type PostInstallCheck struct {
+	// Type is the type of the check.
+	Type PostInstallCheckType `json:"type"`
+
+	// Job is the Job to run for a check of type Job.
+	// +optional
+	Job *PostInstallCheckJob `json:"job,omitempty"`
+}
+
+// PostInstallCheckJob is a user-supplied Job run as a post-install check. The Job runs in the namespace of the
+// ClusterDeployment with the admin kubeconfig of the cluster mounted at /etc/kubeconfig/kubeconfig, and the
+// KUBECONFIG environment variable pointing to it.
+type PostInstallCheckJob struct {
+	// Image is the image of the container of the Job.
+	Image string `json:"image"`
+
+	// Command is the entrypoint of the container of the Job.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint of the container of the Job.
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// ClusterAutoscalerConfig is the configuration for the ClusterAutoscaler in a cluster.
+type ClusterAutoscalerConfig struct {
+	// MaxNodesTotal is the maximum number of nodes in the cluster, including the control plane nodes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNodesTotal *int32 `json:"maxNodesTotal,omitempty"`
+
+	// ScaleDown is the configuration for scaling down the nodes of the cluster.
+	// +optional
+	ScaleDown *ClusterAutoscalerScaleDownConfig `json:"scaleDown,omitempty"`
+
+	// MaxPodGracePeriod is the number of seconds to wait for the pods on a node to terminate gracefully before
+	// scaling down the node.
+	// +optional
+	MaxPodGracePeriod *int32 `json:"maxPodGracePeriod,omitempty"`
+
+	// PodPriorityThreshold is the priority below which pods are not expected to cause a scale up of the cluster, nor
+	// to prevent a scale down of the cluster.
+	// +optional
+	PodPriorityThreshold *int32 `json:"podPriorityThreshold,omitempty"`
+}
+
+// ClusterAutoscalerScaleDownConfig is the configuration for scaling down the nodes of a cluster.
+type ClusterAutoscalerScaleDownConfig struct {
+	// Enabled indicates whether the autoscaler should scale down the cluster. When omitted, scaling down is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DelayAfterAdd is how long after a scale up that the evaluation of scaling down resumes.
+	// +kubebuilder:validation:Pattern=([0-9]*(\.[0-9]*)?[a-z]+)+
+	// +optional
+	DelayAfterAdd *string `json:"delayAfterAdd,omitempty"`
+
+	// DelayAfterDelete is how long after the deletion of a node that the evaluation of scaling down resumes.
+	// +kubebuilder:validation:Pattern=([0-9]*(\.[0-9]*)?[a-z]+)+
+	// +optional
+	DelayAfterDelete *string `json:"delayAfterDelete,omitempty"`
+
+	// DelayAfterFailure is how long after a failed scale down that the evaluation of scaling down resumes.
+	// +kubebuilder:validation:Pattern=([0-9]*(\.[0-9]*)?[a-z]+)+
+	// +optional
+	DelayAfterFailure *string `json:"delayAfterFailure,omitempty"`
+
+	// UnneededTime is how long a node must be unneeded before it is eligible to be scaled down.
+	// +kubebuilder:validation:Pattern=([0-9]*(\.[0-9]*)?[a-z]+)+
+	// +optional
+	UnneededTime *string `json:"unneededTime,omitempty"`
 }
 
 // Provisioning contains settings used only for initial cluster provisioning.
@@ -150,10 +303,40 @@ type Provisioning struct {
 	// that will take precedence over the one from the ClusterImageSet.
 	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
 
+	// Architecture is the instruction set architecture of the control plane machines, and of the compute machine
+	// pools of the InstallConfig which do not set one. Defaults to the architecture of the ClusterImageSet when it
+	// is not a multi-architecture release image, and to amd64 otherwise.
+	// +kubebuilder:validation:Enum=amd64;arm64;ppc64le;s390x
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// ControlPlaneReplicas is the number of control plane nodes of the cluster, 1 for a single-node cluster or at
+	// least 3 otherwise. It is set in the InstallConfig when the InstallConfig does not set controlPlane.replicas,
+	// and is ignored when it does. Defaults to 3, the default of the installer, when the ClusterDeployment is created.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ControlPlaneReplicas *int64 `json:"controlPlaneReplicas,omitempty"`
+
 	// ManifestsConfigMapRef is a reference to user-provided manifests to
 	// add to or replace manifests that are generated by the installer.
 	ManifestsConfigMapRef *corev1.LocalObjectReference `json:"manifestsConfigMapRef,omitempty"`
 
+	// CredentialsMode is the mode in which the cloud credential operator of the installed cluster provides the
+	// credentials of its components. Mint creates scoped credentials from the credentials of the cluster,
+	// Passthrough gives the components the credentials of the cluster, and Manual uses the credentials of
+	// CredentialsManifestsSecretRef, such as the secrets or role manifests of short-lived tokens. Defaults to the
+	// mode of the InstallConfig, or to the mode chosen by the cloud credential operator.
+	// +kubebuilder:validation:Enum=Mint;Passthrough;Manual
+	// +optional
+	CredentialsMode CredentialsMode `json:"credentialsMode,omitempty"`
+
+	// CredentialsManifestsSecretRef is a reference to a secret containing the pre-created credentials manifests of
+	// the components of the cluster, which are added to the manifests generated by the installer. The keys of the
+	// secret are the names of the manifest files, except for the bound-service-account-signing-key.key key, which is
+	// the private key signing the tokens of the service accounts. Required when CredentialsMode is Manual.
+	// +optional
+	CredentialsManifestsSecretRef *corev1.LocalObjectReference `json:"credentialsManifestsSecretRef,omitempty"`
+
 	// SSHPrivateKeySecretRef is the reference to the secret that contains the private SSH key to use
 	// for access to compute instances. This private key should correspond to the public key included
 	// in the InstallConfig. The private key is used by Hive to gather logs on the target cluster if
@@ -171,8 +354,49 @@ type Provisioning struct {
 	// additional features of the installer.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// StageTimeouts are the longest times the stages of the install may take before the install attempt is aborted
+	// and fails with the StageTimeout reason. Stages without a timeout are not limited.
+	// +optional
+	StageTimeouts *ProvisionStageTimeouts `json:"stageTimeouts,omitempty"`
 }
 
+// ProvisionStageTimeouts are the timeouts of the stages of an install.
+type ProvisionStageTimeouts struct {
+	// Infrastructure is the longest time the installer may take to create the cloud infrastructure of the cluster,
+	// from the start of the install to the InfrastructureProvisioned stage.
+	// +optional
+	Infrastructure *metav1.Duration `json:"infrastructure,omitempty"`
+
+	// Bootstrap is the longest time the control plane may take to bootstrap, from the InfrastructureProvisioned
+	// stage to the BootstrapComplete stage.
+	// +optional
+	Bootstrap *metav1.Duration `json:"bootstrap,omitempty"`
+
+	// ClusterOperators is the longest time the cluster operators may take to settle once the control plane is
+	// bootstrapped, from the BootstrapComplete stage to the InstallComplete stage.
+	// +optional
+	ClusterOperators *metav1.Duration `json:"clusterOperators,omitempty"`
+}
+
+// CredentialsMode is the mode in which the cloud credential operator of a cluster provides the credentials of its
+// components.
+type CredentialsMode string
+
+const (
+	// MintCredentialsMode creates scoped credentials for the components from the credentials of the cluster.
+	MintCredentialsMode CredentialsMode = "Mint"
+	// PassthroughCredentialsMode gives the components the credentials of the cluster.
+	PassthroughCredentialsMode CredentialsMode = "Passthrough"
+	// ManualCredentialsMode gives the components the credentials created beforehand, without the credentials of
+	// the cluster.
+	ManualCredentialsMode CredentialsMode = "Manual"
+
+	// BoundServiceAccountSigningKeyKey is the key of the credentials manifests secret holding the private key signing
+	// the tokens of the service accounts of the cluster.
+	BoundServiceAccountSigningKeyKey = "bound-service-account-signing-key.key"
+)
+
 // ClusterImageSetReference is a reference to a ClusterImageSet
 type ClusterImageSetReference struct {
 	// Name is the name of the ClusterImageSet that this refers to
@@ -204,10 +428,25 @@ type ClusterMetadata struct {
 
 	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to login to this cluster.
 	AdminPasswordSecretRef corev1.LocalObjectReference `json:"adminPasswordSecretRef"`
+
+	// MetadataJSONSecretRef references the secret containing the metadata.json generated by the installer. Hive
+	// deprovisions clusters by infra ID without it; it is kept to destroy the cluster by hand with the installer when
+	// Hive cannot deprovision it.
+	// +optional
+	MetadataJSONSecretRef *corev1.LocalObjectReference `json:"metadataJSONSecretRef,omitempty"`
+
+	// TerraformStateSecretRef references the secret containing the terraform state of the installer. It is only set
+	// when the terraform state policy of Hive is StoreEncrypted.
+	// +optional
+	TerraformStateSecretRef *corev1.LocalObjectReference `json:"terraformStateSecretRef,omitempty"`
 }
 
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
 type ClusterDeploymentStatus struct {
+	// ObservedGeneration is the generation of the ClusterDeployment last reconciled without error by the
+	// clusterdeployment controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// InstallRestarts is the total count of container restarts on the clusters install job.
 	InstallRestarts int `json:"installRestarts,omitempty"`
@@ -237,9 +476,135 @@ type ClusterDeploymentStatus struct {
 	// InstalledTimestamp is the time we first detected that the cluster has been successfully installed.
 	InstalledTimestamp *metav1.Time `json:"installedTimestamp,omitempty"`
 
+	// InstallRegion is the region where the cluster is being installed, or was installed once the install has
+	// completed. It is only set when the platform has fallback regions.
+	// +optional
+	InstallRegion string `json:"installRegion,omitempty"`
+
+	// Architecture is the instruction set architecture of the control plane machines of the cluster. It is set when
+	// the install starts.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// MultiArchitecture is true when the cluster is installed from a multi-architecture release image, so that its
+	// machine pools can use other architectures than the control plane.
+	// +optional
+	MultiArchitecture bool `json:"multiArchitecture,omitempty"`
+
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
+
+	// CostEstimate is the estimated cost of running the machines of the cluster. It is set by the clustercost
+	// controller once the cluster is installed.
+	// +optional
+	CostEstimate *ClusterCostEstimate `json:"costEstimate,omitempty"`
+
+	// PlatformStatus contains the identifiers of the cloud resources of the cluster, such as its VPC and hosted zone.
+	// It is set from the provision once the cluster is installed.
+	// +optional
+	PlatformStatus *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// InstallerStages are the stages of the install reached by the installer of the current provision, in the order
+	// they were reached.
+	// +optional
+	InstallerStages []InstallerStageTransition `json:"installerStages,omitempty"`
+
+	// InstallAttempts is the history of the install attempts of the cluster, oldest first. It is kept after the
+	// ClusterProvisions of the attempts are deleted, and bounded by the maxInstallAttempts of the job history of
+	// HiveConfig.
+	// +optional
+	InstallAttempts []InstallAttempt `json:"installAttempts,omitempty"`
+}
+
+// InstallAttempt records an attempt to install the cluster.
+type InstallAttempt struct {
+	// Attempt is the number of the attempt, starting from 0.
+	Attempt int `json:"attempt"`
+
+	// ProvisionName is the name of the ClusterProvision of the attempt, which holds the install log of the attempt
+	// until it is deleted.
+	ProvisionName string `json:"provisionName"`
+
+	// StartTime is when the attempt started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the attempt was seen to complete. It is not set while the attempt is running.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Result is the result of the attempt. It is not set while the attempt is running.
+	// +optional
+	Result InstallAttemptResult `json:"result,omitempty"`
+
+	// FailureReason is the reason the attempt failed.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// FailureMessage is a human-readable message explaining why the attempt failed.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// LogLocation is where the logs of the failed attempt are uploaded, when Hive uploads the logs of failed installs
+	// to an object store.
+	// +optional
+	LogLocation string `json:"logLocation,omitempty"`
+}
+
+// InstallAttemptResult is the result of an install attempt.
+type InstallAttemptResult string
+
+const (
+	// InstallAttemptSucceeded is the result of an attempt which installed the cluster.
+	InstallAttemptSucceeded InstallAttemptResult = "Succeeded"
+	// InstallAttemptFailed is the result of an attempt which failed to install the cluster.
+	InstallAttemptFailed InstallAttemptResult = "Failed"
+)
+
+// PlatformStatus contains the identifiers of the cloud resources of an installed cluster, harvested from the
+// metadata and terraform state of the installer. Only the field of the platform of the cluster is set.
+type PlatformStatus struct {
+	// AWS contains the identifiers of the AWS resources of the cluster.
+	// +optional
+	AWS *aws.PlatformStatus `json:"aws,omitempty"`
+
+	// Azure contains the identifiers of the Azure resources of the cluster.
+	// +optional
+	Azure *azure.PlatformStatus `json:"azure,omitempty"`
+
+	// GCP contains the identifiers of the GCP resources of the cluster.
+	// +optional
+	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
+}
+
+// ClusterCostEstimate is the estimated cost of running the machines of a cluster, computed from the instance types
+// of its machines and the prices of the Hive pricing source in the region of the cluster.
+type ClusterCostEstimate struct {
+	// HourlyCost is the estimated cost of running the machines of the cluster for an hour, as a decimal number. It
+	// is zero while the cluster is hibernating.
+	HourlyCost string `json:"hourlyCost"`
+
+	// Currency is the currency of the hourly cost.
+	// +optional
+	Currency string `json:"currency,omitempty"`
+
+	// Instances are the instance types of the machines of the cluster, with their counts.
+	// +optional
+	Instances []InstanceCount `json:"instances,omitempty"`
+
+	// UnpricedInstanceTypes are the instance types of the machines of the cluster which have no price in the pricing
+	// source. Their machines are not included in the hourly cost.
+	// +optional
+	UnpricedInstanceTypes []string `json:"unpricedInstanceTypes,omitempty"`
+}
+
+// InstanceCount is the number of machines of a cluster of an instance type.
+type InstanceCount struct {
+	// InstanceType is the instance type of the machines.
+	InstanceType string `json:"instanceType"`
+
+	// Count is the number of machines of the instance type.
+	Count int64 `json:"count"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -323,6 +688,49 @@ const (
 
 	// AuthenticationFailureCondition is true when platform credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeploymentCondition ClusterDeploymentConditionType = "AuthenticationFailure"
+
+	// PostInstallChecksFailedCondition is true when a post-install check has not passed after the installer
+	// completed. The cluster is not marked as installed until all the post-install checks pass.
+	PostInstallChecksFailedCondition ClusterDeploymentConditionType = "PostInstallChecksFailed"
+
+	// HookFailedCondition is true when the Job of a hook of the ClusterDeployment has failed.
+	HookFailedCondition ClusterDeploymentConditionType = "HookFailed"
+
+	// DeprovisionBlockedCondition is true when the deprovision of a deleted ClusterDeployment is blocked because the
+	// cluster runs protected workloads, or because the cluster cannot be checked for protected workloads.
+	DeprovisionBlockedCondition ClusterDeploymentConditionType = "DeprovisionBlocked"
+
+	// DeprovisionStuckCondition is true when a deleted ClusterDeployment has been waiting for its cluster to be
+	// deprovisioned for more than an hour. It is set to false once the cluster has been deprovisioned.
+	DeprovisionStuckCondition ClusterDeploymentConditionType = "DeprovisionStuck"
+
+	// ClusterReadyCondition is true when the cluster is installed, running and reachable. It is false while the
+	// cluster is provisioning, hibernating, unreachable or deprovisioning.
+	ClusterReadyCondition ClusterDeploymentConditionType = "Ready"
+
+	// InstallConfigValidationFailedCondition is true when the install-config of the ClusterDeployment is invalid, or
+	// does not match the cluster name, base domain or platform of the ClusterDeployment. No provision is started while
+	// the condition is true.
+	InstallConfigValidationFailedCondition ClusterDeploymentConditionType = "InstallConfigValidationFailed"
+
+	// ReleaseImageVerificationFailedCondition is true when the verification of release images is turned on in
+	// HiveConfig and the release image of the ClusterDeployment cannot be verified. No provision is started while the
+	// condition is true.
+	ReleaseImageVerificationFailedCondition ClusterDeploymentConditionType = "ReleaseImageVerificationFailed"
+
+	// DeprovisionCredentialsInvalidCondition is true when the secret of DeprovisionCredentialsSecretRef does not exist
+	// or its credentials fail the authentication check of the platform. No provision is started while the condition
+	// is true.
+	DeprovisionCredentialsInvalidCondition ClusterDeploymentConditionType = "DeprovisionCredentialsInvalid"
+
+	// BootstrapCompleteCondition is true once the installer of the current provision has bootstrapped the control
+	// plane of the cluster. It is set to false when a provision which has not reached that stage replaces it.
+	BootstrapCompleteCondition ClusterDeploymentConditionType = "BootstrapComplete"
+
+	// TenantQuotaExceededCondition is true when starting a provision would exceed the concurrent installs or vCPUs
+	// quota of a HiveTenant in the namespace of the ClusterDeployment. No provision is started while the condition is
+	// true.
+	TenantQuotaExceededCondition ClusterDeploymentConditionType = "TenantQuotaExceeded"
 )
 
 // AllClusterDeploymentConditions is a slice containing all condition types. This can be used for dealing with
@@ -340,8 +748,27 @@ var AllClusterDeploymentConditions = []ClusterDeploymentConditionType{
 	RelocationFailedCondition,
 	ClusterHibernatingCondition,
 	InstallLaunchErrorCondition,
+	PostInstallChecksFailedCondition,
+	HookFailedCondition,
+	DeprovisionBlockedCondition,
+	DeprovisionStuckCondition,
+	ClusterReadyCondition,
+	InstallConfigValidationFailedCondition,
+	ReleaseImageVerificationFailedCondition,
+	DeprovisionCredentialsInvalidCondition,
+	BootstrapCompleteCondition,
+	TenantQuotaExceededCondition,
 }
 
+// Control plane certificate reasons
+const (
+	// ControlPlaneCertificatesNotServedReason is used as the reason for the ControlPlaneCertificateNotFound condition
+	// when the serving certificates have been synced to the cluster but the API server is not yet serving them.
+	// SyncSets other than the one carrying the control plane certificates are not applied while the condition has
+	// this reason.
+	ControlPlaneCertificatesNotServedReason = "ControlPlaneCertificatesNotServed"
+)
+
 // Cluster hibernating reasons
 const (
 	// ResumingHibernationReason is used as the reason when the cluster is transitioning
@@ -3,6 +3,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
 )
 
 // ClusterDeprovisionSpec defines the desired state of ClusterDeprovision
@@ -22,6 +24,11 @@ type ClusterDeprovisionStatus struct {
 	// Completed is true when the uninstall has completed successfully
 	Completed bool `json:"completed,omitempty"`
 
+	// FailedAttempts is the number of uninstall jobs that have failed. The controller backs off for longer after
+	// each failed attempt before starting another uninstall job.
+	// +optional
+	FailedAttempts int `json:"failedAttempts,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
@@ -51,6 +58,11 @@ type AWSClusterDeprovision struct {
 
 	// CredentialsSecretRef is the AWS account credentials to use for deprovisioning the cluster
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// ServiceEndpoints list contains custom endpoints which will override the default
+	// service endpoints of AWS Services used during deprovisioning.
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 }
 
 // AzureClusterDeprovision contains Azure-specific configuration for a ClusterDeprovision
@@ -148,6 +160,14 @@ type ClusterDeprovisionConditionType string
 const (
 	// AuthenticationFailureClusterDeprovisionCondition is true when credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeprovisionCondition ClusterDeprovisionConditionType = "AuthenticationFailure"
+
+	// DeprovisionFailedClusterDeprovisionCondition is true when the last uninstall job failed and the controller is
+	// backing off before starting another one
+	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
+
+	// ReadyClusterDeprovisionCondition is true when the cluster has been deprovisioned. It is false while the
+	// uninstall job is failing.
+	ReadyClusterDeprovisionCondition ClusterDeprovisionConditionType = "Ready"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,8 +9,32 @@ type ClusterImageSetSpec struct {
 	// ReleaseImage is the image that contains the payload to use when installing
 	// a cluster.
 	ReleaseImage string `json:"releaseImage"`
+
+	// Architecture is the instruction set architecture of the release image, or Multi when the release image is a
+	// multi-architecture payload from which clusters mixing architectures can be installed. When not set, the
+	// architecture of the release image is not checked against the architecture of the clusters.
+	// +kubebuilder:validation:Enum=amd64;arm64;ppc64le;s390x;multi
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
 }
 
+// Architecture is an instruction set architecture of the machines of a cluster.
+type Architecture string
+
+const (
+	// ArchitectureAMD64 is the AMD64 (x86_64) architecture.
+	ArchitectureAMD64 Architecture = "amd64"
+	// ArchitectureARM64 is the ARM64 (aarch64) architecture.
+	ArchitectureARM64 Architecture = "arm64"
+	// ArchitecturePPC64LE is the little endian Power PC architecture.
+	ArchitecturePPC64LE Architecture = "ppc64le"
+	// ArchitectureS390X is the IBM System Z architecture.
+	ArchitectureS390X Architecture = "s390x"
+	// ArchitectureMulti is the architecture of multi-architecture release images, which contain the images of all
+	// the other architectures. Machines cannot use it.
+	ArchitectureMulti Architecture = "multi"
+)
+
 // ClusterImageSetStatus defines the observed state of ClusterImageSet
 type ClusterImageSetStatus struct{}
 
@@ -23,6 +47,7 @@ type ClusterImageSetStatus struct{}
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".spec.releaseImage"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".spec.architecture"
 // +kubebuilder:resource:path=clusterimagesets,shortName=imgset,scope=Cluster
 type ClusterImageSet struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterOperation is a destructive operation performed on a ClusterDeployment.
+// +kubebuilder:validation:Enum=Delete;Deprovision;Hibernate
+type ClusterOperation string
+
+const (
+	// ClusterOperationDelete is the deletion of a ClusterDeployment.
+	ClusterOperationDelete ClusterOperation = "Delete"
+	// ClusterOperationDeprovision is the start of the deprovision of the cluster of a ClusterDeployment.
+	ClusterOperationDeprovision ClusterOperation = "Deprovision"
+	// ClusterOperationHibernate is the change of the power state of a ClusterDeployment to Hibernating.
+	ClusterOperationHibernate ClusterOperation = "Hibernate"
+)
+
+// ClusterOperationLogSpec records which user and client performed a destructive operation on a ClusterDeployment and
+// when.
+type ClusterOperationLogSpec struct {
+	// ClusterDeploymentRef is the ClusterDeployment that the operation was performed on.
+	ClusterDeploymentRef ClusterOperationLogClusterDeploymentReference `json:"clusterDeploymentRef"`
+
+	// Operation is the operation that was performed.
+	Operation ClusterOperation `json:"operation"`
+
+	// User is the name of the user who requested the operation, as authenticated by the API server in the admission
+	// request. It is empty for the operations requested while the Hive admission webhooks were not running, and for
+	// deprovisions, which are started by Hive when the ClusterDeployment is deleted.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Manager is the field manager of the request that performed the operation, taken from the managed fields of the
+	// resource. It identifies the client, such as kubectl or the Hive controllers, rather than the user. It is empty
+	// for deletions, which are not recorded in the managed fields.
+	// +optional
+	Manager string `json:"manager,omitempty"`
+
+	// Time is when the operation was requested.
+	Time metav1.Time `json:"time"`
+
+	// Details is a human-readable description of the operation.
+	// +optional
+	Details string `json:"details,omitempty"`
+}
+
+// ClusterOperationLogClusterDeploymentReference identifies the ClusterDeployment of a ClusterOperationLog.
+type ClusterOperationLogClusterDeploymentReference struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+	// InfraID is the infra ID of the cluster of the ClusterDeployment, if it was installed.
+	// +optional
+	InfraID string `json:"infraID,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterOperationLog is an audit record of a destructive operation performed on a ClusterDeployment. The records are
+// created by the clusteroperationlog controller from the changes it observes, and by the admission webhook for the
+// deletions, and are deleted once they are older than the operation log retention period of the HiveConfig. They are cluster-scoped so that they outlive the namespace of the ClusterDeployment.
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.clusterDeploymentRef.namespace"
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="Operation",type="string",JSONPath=".spec.operation"
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.user"
+// +kubebuilder:printcolumn:name="Manager",type="string",JSONPath=".spec.manager"
+// +kubebuilder:printcolumn:name="Time",type="date",JSONPath=".spec.time"
+// +kubebuilder:resource:path=clusteroperationlogs,scope=Cluster
+type ClusterOperationLog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterOperationLogSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterOperationLogList contains a list of ClusterOperationLogs
+type ClusterOperationLogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterOperationLog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterOperationLog{}, &ClusterOperationLogList{})
+}
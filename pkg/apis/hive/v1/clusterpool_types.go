@@ -37,6 +37,12 @@ type ClusterPoolSpec struct {
 	// +required
 	BaseDomain string `json:"baseDomain"`
 
+	// ManageDNS specifies whether a DNSZone should be created and managed automatically for each cluster of the pool.
+	// Each cluster gets its own zone for the subdomain of the base domain named after the cluster, so the base domain
+	// must be one of the managed domains configured in HiveConfig.
+	// +optional
+	ManageDNS bool `json:"manageDNS,omitempty"`
+
 	// ImageSetRef is a reference to a ClusterImageSet. The release image specified in the ClusterImageSet will be used
 	// by clusters created for this cluster pool.
 	ImageSetRef ClusterImageSetReference `json:"imageSetRef"`
@@ -66,6 +72,12 @@ type ClusterPoolSpec struct {
 	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// ConsumerGroups are the groups whose members may claim clusters from the pool. The groups are bound to the
+	// hive-cluster-pool-consumer ClusterRole in the namespace of the pool, which allows them to create ClusterClaims
+	// for any of the pools in the namespace.
+	// +optional
+	ConsumerGroups []string `json:"consumerGroups,omitempty"`
 }
 
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
@@ -124,6 +136,8 @@ const (
 	// ClusterPoolCapacityAvailableCondition is set to provide information on whether the cluster pool has capacity
 	// available to create more clusters for the pool.
 	ClusterPoolCapacityAvailableCondition ClusterPoolConditionType = "CapacityAvailable"
+	// ClusterPoolReadyCondition is true when the pool has as many clusters ready to be claimed as its size.
+	ClusterPoolReadyCondition ClusterPoolConditionType = "Ready"
 )
 
 // +genclient
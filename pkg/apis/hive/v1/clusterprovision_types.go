@@ -39,11 +39,63 @@ type ClusterProvisionSpec struct {
 	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to login to this cluster.
 	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
 
+	// MetadataJSONSecretRef references the secret containing the metadata.json generated by the installer.
+	MetadataJSONSecretRef *corev1.LocalObjectReference `json:"metadataJSONSecretRef,omitempty"`
+
+	// TerraformStateSecretRef references the secret containing the terraform state of the installer, stored once the
+	// install completed when the terraform state policy is StoreEncrypted.
+	TerraformStateSecretRef *corev1.LocalObjectReference `json:"terraformStateSecretRef,omitempty"`
+
 	// PrevClusterID is the cluster ID of the previous failed provision attempt.
 	PrevClusterID *string `json:"prevClusterID,omitempty"`
 
 	// PrevInfraID is the infra ID of the previous failed provision attempt.
 	PrevInfraID *string `json:"prevInfraID,omitempty"`
+
+	// Region is the region where the cluster is installed, overriding the region of the install config. It is only
+	// set when the platform of the cluster deployment has fallback regions.
+	Region string `json:"region,omitempty"`
+
+	// PrevRegion is the region of the previous failed provision attempt, when it differs from Region.
+	PrevRegion *string `json:"prevRegion,omitempty"`
+
+	// PlatformStatus contains the identifiers of the cloud resources of the cluster, set once the install completed.
+	PlatformStatus *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// InstallerStages are the stages of the install reached by the installer, in the order they were reached.
+	InstallerStages []InstallerStageTransition `json:"installerStages,omitempty"`
+
+	// InfrastructureCreated is set by the install pod when the install fails. It is false when the installer failed
+	// before it started creating the cloud infrastructure of the cluster, so that the install is retried without
+	// waiting for the backoff of failed installs or cleaning up after the failed install.
+	InfrastructureCreated *bool `json:"infrastructureCreated,omitempty"`
+
+	// InfrastructureDestroyed is set by the install pod once it destroyed the cloud infrastructure created by the
+	// failed install, so that the next install does not need to clean up after it.
+	InfrastructureDestroyed bool `json:"infrastructureDestroyed,omitempty"`
+}
+
+// InstallerStage is a stage of the install reached by the installer.
+type InstallerStage string
+
+const (
+	// InstallerStageInfrastructureProvisioned is reached once the installer created the cloud infrastructure of the
+	// cluster, including the bootstrap machine, and waits for the Kubernetes API.
+	InstallerStageInfrastructureProvisioned InstallerStage = "InfrastructureProvisioned"
+	// InstallerStageBootstrapComplete is reached once the control plane has been bootstrapped.
+	InstallerStageBootstrapComplete InstallerStage = "BootstrapComplete"
+	// InstallerStageBootstrapDestroyed is reached once the bootstrap resources have been destroyed.
+	InstallerStageBootstrapDestroyed InstallerStage = "BootstrapDestroyed"
+	// InstallerStageInstallComplete is reached once the cluster has initialized.
+	InstallerStageInstallComplete InstallerStage = "InstallComplete"
+)
+
+// InstallerStageTransition records when the installer reached a stage of the install.
+type InstallerStageTransition struct {
+	// Stage is the stage reached.
+	Stage InstallerStage `json:"stage"`
+	// Time is when the stage was reached.
+	Time metav1.Time `json:"time"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -108,6 +160,10 @@ const (
 
 	// InstallPodStuckCondition is set when the install pod is stuck
 	InstallPodStuckCondition ClusterProvisionConditionType = "InstallPodStuck"
+
+	// ClusterProvisionReadyCondition is true when the provision has completed successfully. It is false while the
+	// provision is running, and once it has failed.
+	ClusterProvisionReadyCondition ClusterProvisionConditionType = "Ready"
 )
 
 // +genclient
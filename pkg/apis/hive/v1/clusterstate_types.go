@@ -18,6 +18,11 @@ type ClusterStateStatus struct {
 	// ClusterOperators contains the state for every cluster operator in the
 	// target cluster
 	ClusterOperators []ClusterOperatorState `json:"clusterOperators,omitempty"`
+
+	// ReadyTimestamp is the time at which all cluster operators in the target
+	// cluster were first observed to be available
+	// +optional
+	ReadyTimestamp *metav1.Time `json:"readyTimestamp,omitempty"`
 }
 
 // ClusterOperatorState summarizes the status of a single cluster operator
@@ -0,0 +1,213 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterUpgradeSpec defines the desired upgrade of a fleet of clusters.
+type ClusterUpgradeSpec struct {
+	// ClusterDeploymentSelector selects the ClusterDeployments for the clusters to upgrade.
+	// +required
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// DesiredUpdate is the release to which the clusters will be upgraded. It is set as the desired update in the
+	// ClusterVersion of each cluster.
+	// +required
+	DesiredUpdate ClusterUpgradeRelease `json:"desiredUpdate"`
+
+	// CanarySelector selects the clusters, from the clusters selected by the ClusterDeploymentSelector, that are
+	// upgraded first. The remaining clusters are not upgraded until all of the canary clusters have completed the
+	// upgrade.
+	// By default there are no canary clusters.
+	// +optional
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+
+	// MaxConcurrent is the maximum number of clusters that will be upgraded at a time.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+
+	// MaintenanceWindows are the windows of time during which the upgrade of a cluster may be started. Upgrades that
+	// have already started are allowed to complete outside of the windows.
+	// By default upgrades may be started at any time.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Paused stops the upgrade of any more clusters from being started. Upgrades that have already started are
+	// allowed to complete.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ClusterUpgradeRelease identifies the release to which clusters are upgraded.
+type ClusterUpgradeRelease struct {
+	// Version is the version of the release. Version is required if Image is not specified.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image is the pull spec of the release image. Image is required if Version is not specified.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Force allows the upgrade to proceed even when the release fails verification or is not one of the available
+	// updates for the cluster.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// MaintenanceWindow is a recurring window of time during which the upgrade of a cluster may be started.
+type MaintenanceWindow struct {
+	// Days are the days of the week, such as Monday, on which the window starts.
+	// By default the window starts every day.
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// StartTime is the time, in the form HH:MM in UTC, at which the window starts.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +required
+	StartTime string `json:"startTime"`
+
+	// Duration is the length of the window.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// ClusterUpgradeStatus defines the observed state of the ClusterUpgrade.
+type ClusterUpgradeStatus struct {
+	// Clusters is the status of the upgrade of each of the selected clusters.
+	// +optional
+	Clusters []ClusterUpgradeClusterStatus `json:"clusters,omitempty"`
+
+	// Completed is the number of clusters that have completed the upgrade.
+	// +optional
+	Completed int32 `json:"completed,omitempty"`
+
+	// Total is the number of clusters selected for the upgrade.
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// Conditions includes more detailed status for the upgrade.
+	// +optional
+	Conditions []ClusterUpgradeCondition `json:"conditions,omitempty"`
+}
+
+// ClusterUpgradeClusterStatus is the status of the upgrade of a single cluster.
+type ClusterUpgradeClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Canary is true if the cluster is one of the canary clusters.
+	// +optional
+	Canary bool `json:"canary,omitempty"`
+
+	// State is the state of the upgrade of the cluster.
+	State ClusterUpgradeState `json:"state"`
+
+	// DesiredUpdate is the release to which the state of the upgrade of the cluster applies. The state is reset when
+	// the desired update of the ClusterUpgrade changes.
+	// +optional
+	DesiredUpdate ClusterUpgradeRelease `json:"desiredUpdate,omitempty"`
+
+	// Version is the most recently observed version of the cluster.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// StartTime is the time at which Hive started the upgrade of the cluster.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the upgrade of the cluster was observed to be complete.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human-readable message with details about the state of the upgrade of the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeState is the state of the upgrade of a single cluster.
+type ClusterUpgradeState string
+
+const (
+	// ClusterUpgradeStatePending indicates that the upgrade of the cluster has not been started.
+	ClusterUpgradeStatePending ClusterUpgradeState = "Pending"
+	// ClusterUpgradeStateUpgrading indicates that the upgrade of the cluster has been started but is not complete.
+	ClusterUpgradeStateUpgrading ClusterUpgradeState = "Upgrading"
+	// ClusterUpgradeStateCompleted indicates that the cluster is running the desired release.
+	ClusterUpgradeStateCompleted ClusterUpgradeState = "Completed"
+	// ClusterUpgradeStateFailed indicates that the cluster is reporting a failure while upgrading.
+	ClusterUpgradeStateFailed ClusterUpgradeState = "Failed"
+)
+
+// ClusterUpgradeCondition contains details for the current condition of a cluster upgrade.
+type ClusterUpgradeCondition struct {
+	// Type is the type of the condition.
+	Type ClusterUpgradeConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeConditionType is a valid value for ClusterUpgradeCondition.Type
+type ClusterUpgradeConditionType string
+
+const (
+	// ClusterUpgradeCompletedCondition is set to true when all of the selected clusters have completed the upgrade.
+	// When false, the reason explains why the upgrade is not progressing, such as waiting for a maintenance window.
+	ClusterUpgradeCompletedCondition ClusterUpgradeConditionType = "Completed"
+	// ClusterUpgradeFailedCondition is set to true when one or more clusters have failed to upgrade or the
+	// ClusterUpgrade is invalid. No further cluster upgrades are started while the condition is true.
+	ClusterUpgradeFailedCondition ClusterUpgradeConditionType = "Failed"
+	// ClusterUpgradeReadyCondition is true when the upgrade has completed on all of the selected clusters.
+	ClusterUpgradeReadyCondition ClusterUpgradeConditionType = "Ready"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgrade upgrades the clusters selected from a fleet of ClusterDeployments to a desired release. Clusters are
+// upgraded a few at a time, canary clusters first, and only during the maintenance windows.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.desiredUpdate.version"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Total",type="string",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterupgrades,shortName=cu,scope=Cluster
+type ClusterUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradeSpec   `json:"spec,omitempty"`
+	Status ClusterUpgradeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgradeList contains a list of ClusterUpgrades
+type ClusterUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgrade{}, &ClusterUpgradeList{})
+}
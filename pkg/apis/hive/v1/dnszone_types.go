@@ -3,6 +3,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
 )
 
 const (
@@ -37,6 +39,43 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// Records are additional records maintained by Hive in the zone, such as wildcard records or CNAMEs to external
+	// load balancers. Records removed from the list are deleted from the zone. They must not collide with the records
+	// created in the zone by the installer or the cluster, so the names "api", "api-int" and "*.apps" are rejected.
+	// +optional
+	Records []DNSRecord `json:"records,omitempty"`
+}
+
+// DNSRecordType is the type of a record maintained by Hive in a DNSZone.
+// +kubebuilder:validation:Enum=A;AAAA;CNAME
+type DNSRecordType string
+
+const (
+	// DNSRecordTypeA is a record of IPv4 addresses.
+	DNSRecordTypeA DNSRecordType = "A"
+	// DNSRecordTypeAAAA is a record of IPv6 addresses.
+	DNSRecordTypeAAAA DNSRecordType = "AAAA"
+	// DNSRecordTypeCNAME is an alias of another domain name.
+	DNSRecordTypeCNAME DNSRecordType = "CNAME"
+)
+
+// DNSRecord is a record maintained by Hive in a DNSZone.
+type DNSRecord struct {
+	// Name is the name of the record relative to the zone, such as "*.apps-external" for a wildcard record. "@" is the apex
+	// of the zone.
+	Name string `json:"name"`
+
+	// Type is the type of the record.
+	Type DNSRecordType `json:"type"`
+
+	// TTL is the time to live of the record in seconds. Defaults to 60.
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
+
+	// Values are the addresses of A and AAAA records, or the single domain name aliased by a CNAME record.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
 }
 
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
@@ -55,6 +94,40 @@ type AWSDNSZoneSpec struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// ServiceEndpoints list contains custom endpoints which will override the default
+	// service endpoints of AWS Services used for route53 operations.
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// PrivateZone makes the hosted zone a Route53 private hosted zone, which resolves only from the VPCs associated
+	// with it. Whether the zone is private cannot be changed once the DNSZone is created.
+	// +optional
+	PrivateZone *AWSPrivateDNSZone `json:"privateZone,omitempty"`
+}
+
+// AWSPrivateDNSZone contains the configuration of a Route53 private hosted zone.
+type AWSPrivateDNSZone struct {
+	// VPCs are the VPCs associated with the private hosted zone. The zone is created associated with the first VPC
+	// of the account of the credentials of the DNSZone, which must be listed. VPCs later removed from the list are
+	// disassociated from the zone.
+	// +kubebuilder:validation:MinItems=1
+	VPCs []AWSDNSZoneVPC `json:"vpcs"`
+}
+
+// AWSDNSZoneVPC is a VPC associated with a private hosted zone.
+type AWSDNSZoneVPC struct {
+	// VPCID is the ID of the VPC.
+	VPCID string `json:"vpcID"`
+
+	// Region is the region of the VPC.
+	Region string `json:"region"`
+
+	// AssumeRoleARN is the ARN of a role of the account owning the VPC, when the VPC belongs to another account than
+	// the hosted zone, as in shared-VPC setups. The zone's account authorizes the association, then the role, assumed
+	// with the credentials of the DNSZone, associates the VPC with the zone.
+	// +optional
+	AssumeRoleARN string `json:"assumeRoleARN,omitempty"`
 }
 
 // AWSResourceTag represents a tag that is applied to an AWS cloud resource
@@ -88,6 +161,10 @@ type AzureDNSZoneSpec struct {
 
 // DNSZoneStatus defines the observed state of DNSZone
 type DNSZoneStatus struct {
+	// ObservedGeneration is the generation of the DNSZone last reconciled without error by the dnszone controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// LastSyncTimestamp is the time that the zone was last sync'd.
 	// +optional
 	LastSyncTimestamp *metav1.Time `json:"lastSyncTimestamp,omitempty"`
@@ -101,6 +178,15 @@ type DNSZoneStatus struct {
 	// +optional
 	NameServers []string `json:"nameServers,omitempty"`
 
+	// DelegatedNameServers are the nameservers returned for the zone by public DNS resolvers when the delegation of
+	// the zone was last verified.
+	// +optional
+	DelegatedNameServers []string `json:"delegatedNameServers,omitempty"`
+
+	// LastDelegationCheckTimestamp is the time that the delegation of the zone was last verified.
+	// +optional
+	LastDelegationCheckTimestamp *metav1.Time `json:"lastDelegationCheckTimestamp,omitempty"`
+
 	// AWSDNSZoneStatus contains status information specific to AWS
 	// +optional
 	AWS *AWSDNSZoneStatus `json:"aws,omitempty"`
@@ -115,6 +201,11 @@ type DNSZoneStatus struct {
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
+
+	// SyncedRecords are the records of the spec last synced to the zone. The records no longer in the spec are
+	// deleted from the zone.
+	// +optional
+	SyncedRecords []DNSRecord `json:"syncedRecords,omitempty"`
 }
 
 // AWSDNSZoneStatus contains status information specific to AWS DNS zones
@@ -122,6 +213,10 @@ type AWSDNSZoneStatus struct {
 	// ZoneID is the ID of the zone in AWS
 	// +optional
 	ZoneID *string `json:"zoneID,omitempty"`
+
+	// AssociatedVPCs are the IDs of the VPCs associated with the private hosted zone.
+	// +optional
+	AssociatedVPCs []string `json:"associatedVPCs,omitempty"`
 }
 
 // AzureDNSZoneStatus contains status information specific to Azure DNS zones
@@ -172,6 +267,13 @@ const (
 	// AuthenticationFailureCondition is true when credentials cannot be used to create a
 	// DNS zone because they fail authentication
 	AuthenticationFailureCondition DNSZoneConditionType = "AuthenticationFailure"
+	// ReadyDNSZoneCondition is true when the DNS zone has been synced with the DNS provider and is
+	// responding to DNS queries
+	ReadyDNSZoneCondition DNSZoneConditionType = "Ready"
+	// DelegationBrokenDNSZoneCondition is true when the nameservers returned for the zone by public DNS
+	// resolvers are not nameservers of the zone, meaning that the zone is not properly delegated from its
+	// parent domain
+	DelegationBrokenDNSZoneCondition DNSZoneConditionType = "DelegationBroken"
 )
 
 // +genclient
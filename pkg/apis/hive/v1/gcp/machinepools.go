@@ -8,6 +8,11 @@ type MachinePool struct {
 	// InstanceType defines the GCP instance type.
 	// eg. n1-standard-4
 	InstanceType string `json:"type"`
+
+	// Subnet is the name of an existing subnet in which to create the machines. The subnet must belong to the
+	// network used by the control plane of the cluster. When omitted, the compute subnet of the cluster is used.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -23,4 +28,8 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.InstanceType != "" {
 		a.InstanceType = required.InstanceType
 	}
+
+	if required.Subnet != "" {
+		a.Subnet = required.Subnet
+	}
 }
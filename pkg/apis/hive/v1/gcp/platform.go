@@ -13,4 +13,10 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// FallbackRegions is an ordered list of regions where the install is retried when it fails for lack of
+	// capacity in Region. The region where the cluster is installed is reported in the installRegion status field
+	// of the ClusterDeployment.
+	// +optional
+	FallbackRegions []string `json:"fallbackRegions,omitempty"`
 }
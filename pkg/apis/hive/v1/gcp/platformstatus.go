@@ -0,0 +1,17 @@
+package gcp
+
+// PlatformStatus contains the identifiers of the GCP resources of an installed cluster.
+type PlatformStatus struct {
+	// ProjectID is the ID of the project of the cluster.
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// Network is the name of the VPC network of the cluster, whether created by the installer or pre-existing.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// NetworkProjectID is the ID of the project of the VPC network of the cluster. It differs from the project of
+	// the cluster when the cluster is installed into a shared VPC.
+	// +optional
+	NetworkProjectID string `json:"networkProjectID,omitempty"`
+}
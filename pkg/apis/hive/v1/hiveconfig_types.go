@@ -17,7 +17,8 @@ type HiveConfigSpec struct {
 	// ManagedDomains is the list of DNS domains that are managed by the Hive cluster
 	// When specifying 'manageDNS: true' in a ClusterDeployment, the ClusterDeployment's
 	// baseDomain should be a direct child of one of these domains, otherwise the
-	// ClusterDeployment creation will result in a validation error.
+	// ClusterDeployment creation will result in a validation error. The same applies to the
+	// zone of DNSZones with linkToParentDomain set to true.
 	// +optional
 	ManagedDomains []ManageDNSConfig `json:"managedDomains,omitempty"`
 
@@ -55,6 +56,64 @@ type HiveConfigSpec struct {
 	// The default reapply interval is two hours.
 	SyncSetReapplyInterval string `json:"syncSetReapplyInterval,omitempty"`
 
+	// SyncSetStaleThreshold is a string duration indicating how much time may pass since the SyncSets and
+	// SelectorSyncSets were last all applied successfully to a cluster before its ClusterSync reports the
+	// SyncStale condition.
+	// The default threshold is three times the reapply interval.
+	// +optional
+	SyncSetStaleThreshold string `json:"syncSetStaleThreshold,omitempty"`
+
+	// SyncSetApplyWorkers is the number of workers of each clustersync pod that apply the resources of SyncSets and
+	// SelectorSyncSets to the clusters. The workers are shared by all the clusters synced by the pod, and apply the
+	// resources of a syncset that do not depend on each other concurrently.
+	// The default is 10 workers.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SyncSetApplyWorkers *int32 `json:"syncSetApplyWorkers,omitempty"`
+
+	// SyncSetResourcesFromAllowedURLs is the list of the URL prefixes from which the ResourcesFrom of SyncSets and
+	// SelectorSyncSets may download resources, such as https://my-bucket.s3.amazonaws.com/hive/. The scheme and host
+	// of a URL, and of the URLs it redirects to, must match those of a prefix exactly. URL sources are rejected when
+	// the list is empty, so that the clustersync pods cannot be made to request arbitrary URLs.
+	// +optional
+	SyncSetResourcesFromAllowedURLs []string `json:"syncSetResourcesFromAllowedURLs,omitempty"`
+
+	// OperationLogRetention is a string duration indicating how long ClusterOperationLogs are kept before they
+	// are deleted.
+	// The default retention is 90 days (2160h).
+	// +optional
+	OperationLogRetention string `json:"operationLogRetention,omitempty"`
+
+	// DeprovisionStuckThreshold is a string duration indicating how long a deleted ClusterDeployment may wait for its
+	// cluster to be deprovisioned before its DeprovisionStuck condition is set.
+	// The default threshold is one hour.
+	// +optional
+	DeprovisionStuckThreshold string `json:"deprovisionStuckThreshold,omitempty"`
+
+	// SecretEncryption configures envelope encryption of the admin kubeconfig and admin password secrets of the
+	// installed clusters with a key management service. When absent, the secrets are stored in plain text.
+	// +optional
+	SecretEncryption *SecretEncryptionConfig `json:"secretEncryption,omitempty"`
+
+	// TerraformState configures what becomes of the terraform state of the installer once a cluster is installed.
+	// When absent, the terraform state is discarded.
+	// +optional
+	TerraformState *TerraformStateConfig `json:"terraformState,omitempty"`
+
+	// SpokeServiceAccountTokens makes the clustersync and clusterstate controllers connect to the clusters with
+	// short-lived ServiceAccount tokens instead of the admin kubeconfig. Hive creates a ServiceAccount for each of
+	// these controllers on each cluster, and uses the admin kubeconfig only to set up the ServiceAccounts and to
+	// request their tokens.
+	// When absent, the admin kubeconfig is used.
+	// +optional
+	SpokeServiceAccountTokens *SpokeServiceAccountTokensConfig `json:"spokeServiceAccountTokens,omitempty"`
+
+	// JobHistory configures how many install attempts of each ClusterDeployment are retained, and for how long
+	// completed install and uninstall jobs, with their pods and logs, are retained. When absent, the defaults of
+	// each setting apply.
+	// +optional
+	JobHistory *JobHistoryConfig `json:"jobHistory,omitempty"`
+
 	// MaintenanceMode can be set to true to disable the hive controllers in situations where we need to ensure
 	// nothing is running that will add or act upon finalizers on Hive types. This should rarely be needed.
 	// Sets replicas to 0 for the hive-controllers deployment to accomplish this.
@@ -71,6 +130,33 @@ type HiveConfigSpec struct {
 	// +optional
 	DeleteProtection DeleteProtectionType `json:"deleteProtection,omitempty"`
 
+	// ProtectedWorkloads turns on a check before each cluster is deprovisioned which refuses to deprovision the
+	// cluster while it runs protected workloads.
+	// +optional
+	ProtectedWorkloads *ProtectedWorkloadsConfig `json:"protectedWorkloads,omitempty"`
+
+	// ReleaseImageVerification turns on the verification of the release images used to install clusters and of the
+	// release images that ClusterUpgrades upgrade clusters to.
+	// +optional
+	ReleaseImageVerification *ReleaseImageVerificationConfig `json:"releaseImageVerification,omitempty"`
+
+	// Proxy configures the proxy which Hive uses to connect to cloud APIs, to the API servers of clusters and to the
+	// stores of release image signatures, along with the install and uninstall jobs.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// PodScheduling constrains the nodes which the pods of Hive run on: the pods of the controllers, and the install,
+	// uninstall, imageset, hook and post-install check jobs. Use it to run Hive on a dedicated, tainted pool of nodes.
+	// +optional
+	PodScheduling *PodSchedulingConfig `json:"podScheduling,omitempty"`
+
+	// PropagatedLabels are the keys of the labels of ClusterDeployments which Hive copies to the objects it creates for
+	// the clusters: the provisions, jobs and their pods, secrets, DNSZones and deprovisions. They are also added as
+	// tags to the cloud resources of the clusters where the platform supports it, currently AWS. Labels already set on
+	// an object, and tags already set in the install config, are not overridden.
+	// +optional
+	PropagatedLabels []string `json:"propagatedLabels,omitempty"`
+
 	// DisabledControllers allows selectively disabling Hive controllers by name.
 	// The name of an individual controller matches the name of the controller as seen in the Hive logging output.
 	DisabledControllers []string `json:"disabledControllers,omitempty"`
@@ -80,6 +166,42 @@ type HiveConfigSpec struct {
 	ControllersConfig *ControllersConfig `json:"controllersConfig,omitempty"`
 
 	FeatureGates *FeatureGateSelection `json:"featureGates,omitempty"`
+
+	// Monitoring configures the monitoring resources deployed for Hive.
+	// +optional
+	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
+
+	// MetricsConfig configures the metrics published by the Hive controllers.
+	// +optional
+	MetricsConfig *MetricsConfig `json:"metricsConfig,omitempty"`
+
+	// Tracing configures the export of traces of the reconciles of the Hive controllers and of the install jobs.
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// Profiling configures the profiling endpoints and the garbage collection of the hive-controllers process, to
+	// help diagnose and reduce its memory usage on large hubs.
+	// +optional
+	Profiling *ProfilingConfig `json:"profiling,omitempty"`
+
+	// PostInstallChecks are the default checks which must pass after the installer completes before a cluster is
+	// marked as installed. They apply to the ClusterDeployments which do not specify their own post-install checks.
+	// +optional
+	PostInstallChecks []PostInstallCheck `json:"postInstallChecks,omitempty"`
+
+	// WatchedNamespaces restricts the namespaced objects watched and cached by the Hive controllers to the
+	// TargetNamespace and these namespaces, reducing the memory used by the controllers on clusters which host many
+	// unrelated workloads. ClusterDeployments and the other namespaced Hive resources outside of these namespaces are
+	// ignored. The namespaces created by Hive for the clusters of ClusterPools cannot be listed, so this cannot be set
+	// while there are ClusterPools, and ClusterPools cannot be created while it is set.
+	// When empty, all namespaces are watched.
+	// +optional
+	WatchedNamespaces []string `json:"watchedNamespaces,omitempty"`
+
+	// DefaultClusterImageSet is the name of the ClusterImageSet used by new ClusterDeployments which specify neither a
+	// release image nor a ClusterImageSet for provisioning.
+	// +optional
+	DefaultClusterImageSet string `json:"defaultClusterImageSet,omitempty"`
 }
 
 // FeatureSet defines the set of feature gates that should be used.
@@ -141,8 +263,38 @@ type HiveConfigStatus struct {
 	// ConfigApplied will be set by the hive operator to indicate whether or not the LastGenerationObserved
 	// was successfully reconciled.
 	ConfigApplied bool `json:"configApplied,omitempty"`
+
+	// Conditions includes more detailed status for the HiveConfig.
+	// +optional
+	Conditions []HiveConfigCondition `json:"conditions,omitempty"`
+}
+
+// HiveConfigCondition contains details for the current condition of the HiveConfig.
+type HiveConfigCondition struct {
+	// Type is the type of the condition.
+	Type HiveConfigConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// HiveConfigConditionType is a valid value for HiveConfigCondition.Type
+type HiveConfigConditionType string
+
+const (
+	// HiveConfigReadyCondition is true when the current generation of the HiveConfig has been applied by the hive
+	// operator.
+	HiveConfigReadyCondition HiveConfigConditionType = "Ready"
+)
+
 // BackupConfig contains settings for the Velero backup integration.
 type BackupConfig struct {
 	// Velero specifies configuration for the Velero backup integration.
@@ -157,6 +309,58 @@ type BackupConfig struct {
 	MinBackupPeriodSeconds *int `json:"minBackupPeriodSeconds,omitempty"`
 }
 
+// MonitoringConfig contains settings for the monitoring resources deployed for Hive.
+type MonitoringConfig struct {
+	// Enabled dictates if the alerting rules and dashboards for Hive are deployed to the Hive namespace. A
+	// ServiceMonitor for the Hive controllers and a PrometheusRule with alerts are deployed when the Prometheus
+	// operator API is available, and a ConfigMap with a Grafana dashboard is always deployed.
+	// If not specified, the default is disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// MetricsConfig contains settings for the metrics published by the Hive controllers.
+type MetricsConfig struct {
+	// AdditionalClusterDeploymentLabels maps the names of additional labels to add to the ClusterDeployment count
+	// metrics, such as hive_cluster_deployments, to the keys of the ClusterDeployment labels providing their values.
+	// This allows slicing the metrics by labels already applied to the ClusterDeployments, such as a team or an
+	// environment. Clusters without the ClusterDeployment label get the "unspecified" value. To limit the
+	// cardinality of the metrics, at most 5 additional labels are supported and the values beyond the first 20
+	// distinct values of a label are reported as "other".
+	// +kubebuilder:validation:MaxProperties=5
+	// +optional
+	AdditionalClusterDeploymentLabels map[string]string `json:"additionalClusterDeploymentLabels,omitempty"`
+}
+
+// TracingConfig contains settings for the tracing of the Hive controllers and install jobs.
+type TracingConfig struct {
+	// OTLPEndpoint is the URL of an OpenTelemetry collector accepting traces using the OTLP/HTTP protocol,
+	// for example http://otel-collector.observability.svc:4318. Spans are exported to the /v1/traces path of
+	// the endpoint. Tracing is disabled when not set.
+	// +optional
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+}
+
+// ProfilingConfig contains settings for the profiling of the hive-controllers process.
+type ProfilingConfig struct {
+	// EnablePprof serves the net/http/pprof endpoints of the hive-controllers process on port 6060 of the loopback
+	// interface of its pod, which is reachable by port forwarding. The profiles can be fetched with "hiveutil adm pprof".
+	// +optional
+	EnablePprof bool `json:"enablePprof,omitempty"`
+
+	// GCPercent sets the garbage collection target percentage of the hive-controllers process, as the GOGC
+	// environment variable would. Lower values reduce the memory used at the cost of more CPU spent collecting
+	// garbage. The Go default of 100 is used when not set.
+	// +optional
+	GCPercent *int32 `json:"gcPercent,omitempty"`
+
+	// MemoryBallastMB is the size in MiB of a memory ballast allocated by the hive-controllers process. The ballast
+	// is never written to, so it uses little physical memory, but it raises the heap size which triggers garbage
+	// collection, reducing the CPU spent collecting garbage when the live heap is small.
+	// +optional
+	MemoryBallastMB int32 `json:"memoryBallastMB,omitempty"`
+}
+
 // VeleroBackupConfig contains settings for the Velero backup integration.
 type VeleroBackupConfig struct {
 	// Enabled dictates if Velero backup integration is enabled.
@@ -178,6 +382,28 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+
+	// FatalFailures is the list of install failures that are not worth retrying. When the install log of a failed
+	// provision matches one of these failures, the ProvisionStopped condition is set on the ClusterDeployment instead
+	// of starting a new provision.
+	// When omitted, failures caused by invalid credentials, unsupported regions, and invalid base domains are fatal.
+	// +optional
+	FatalFailures []FatalProvisionFailure `json:"fatalFailures,omitempty"`
+}
+
+// FatalProvisionFailure describes an install failure that Hive will not retry.
+type FatalProvisionFailure struct {
+	// Name is the name of the failure.
+	Name string `json:"name"`
+
+	// SearchRegexStrings are the regular expressions searched for in the install log.
+	SearchRegexStrings []string `json:"searchRegexStrings"`
+
+	// InstallFailingReason is the single word CamelCase reason reported for this failure in conditions.
+	InstallFailingReason string `json:"installFailingReason"`
+
+	// InstallFailingMessage is the user friendly sentence reported for this failure in conditions.
+	InstallFailingMessage string `json:"installFailingMessage"`
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -254,12 +480,198 @@ type ManageDNSGCPConfig struct {
 	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
 }
 
+// SpokeServiceAccountTokensConfig configures the ServiceAccount tokens used to connect to the clusters.
+type SpokeServiceAccountTokensConfig struct {
+	// Expiration is a string duration indicating how long the tokens are valid. Tokens are renewed when a fifth
+	// of their lifetime remains.
+	// The minimum expiration is ten minutes. The default expiration is one hour.
+	// +optional
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// JobHistoryConfig configures the garbage collection of the install and uninstall attempts of clusters.
+type JobHistoryConfig struct {
+	// MaxProvisions is the maximum number of ClusterProvisions, each with its install job, pods, and logs, retained
+	// for a ClusterDeployment while it is being installed. The first provision is always retained as it records
+	// when the install started.
+	// The default is 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxProvisions *int32 `json:"maxProvisions,omitempty"`
+
+	// MaxInstallAttempts is the maximum number of install attempts recorded in the installAttempts status of a
+	// ClusterDeployment. The oldest attempts are dropped first.
+	// The default is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInstallAttempts *int32 `json:"maxInstallAttempts,omitempty"`
+
+	// FailedProvisionRetention is a string duration indicating how long failed ClusterProvisions, with their
+	// install jobs, pods, and logs, are retained once the ClusterDeployment is installed.
+	// The default retention is 7 days (168h).
+	// +optional
+	FailedProvisionRetention string `json:"failedProvisionRetention,omitempty"`
+
+	// CompletedInstallJobRetention is a string duration indicating how long the install job and pods of a
+	// successful ClusterProvision are retained after the provision was created. The ClusterProvision itself is
+	// kept.
+	// The default retention is 24h.
+	// +optional
+	CompletedInstallJobRetention string `json:"completedInstallJobRetention,omitempty"`
+
+	// CompletedUninstallJobRetention is a string duration indicating how long the uninstall job and pods of a
+	// completed ClusterDeprovision are retained after the job completed.
+	// When empty, the job is retained until the ClusterDeprovision is deleted.
+	// +optional
+	CompletedUninstallJobRetention string `json:"completedUninstallJobRetention,omitempty"`
+}
+
+// TerraformStatePolicy is what becomes of the terraform state of the installer once a cluster is installed.
+type TerraformStatePolicy string
+
+const (
+	// TerraformStatePolicyDiscard discards the terraform state with the install pod.
+	TerraformStatePolicyDiscard TerraformStatePolicy = "Discard"
+	// TerraformStatePolicyStoreEncrypted stores the terraform state in a secret of the ClusterDeployment, encrypted
+	// with the key management service of SecretEncryption.
+	TerraformStatePolicyStoreEncrypted TerraformStatePolicy = "StoreEncrypted"
+	// TerraformStatePolicyUpload uploads the terraform state to the object store that the logs of failed installs are
+	// uploaded to, configured by FailedProvisionConfig.
+	TerraformStatePolicyUpload TerraformStatePolicy = "Upload"
+)
+
+// TerraformStateConfig configures the handling of the terraform state of the installer.
+type TerraformStateConfig struct {
+	// Policy is what becomes of the terraform state once a cluster is installed. StoreEncrypted requires
+	// SecretEncryption, and Upload requires the AWS settings of FailedProvisionConfig.
+	// The default policy is Discard.
+	// +kubebuilder:validation:Enum=Discard;StoreEncrypted;Upload
+	// +optional
+	Policy TerraformStatePolicy `json:"policy,omitempty"`
+}
+
+// SecretEncryptionConfig configures the key management service used to encrypt the admin secrets of clusters.
+// Exactly one provider must be set.
+type SecretEncryptionConfig struct {
+	// AWS uses an AWS KMS key to encrypt the secrets.
+	// +optional
+	AWS *SecretEncryptionAWSConfig `json:"aws,omitempty"`
+
+	// GCP uses a GCP Cloud KMS key to encrypt the secrets.
+	// +optional
+	GCP *SecretEncryptionGCPConfig `json:"gcp,omitempty"`
+}
+
+// SecretEncryptionAWSConfig contains AWS-specific info to encrypt secrets with AWS KMS.
+type SecretEncryptionAWSConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// AWS KMS. It will need permission to encrypt and decrypt with the key.
+	// Secret should have AWS keys named 'aws_access_key_id' and 'aws_secret_access_key'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region is the AWS region of the key.
+	Region string `json:"region"`
+
+	// KeyID is the ID, ARN, or alias of the KMS key.
+	KeyID string `json:"keyID"`
+}
+
+// SecretEncryptionGCPConfig contains GCP-specific info to encrypt secrets with GCP Cloud KMS.
+type SecretEncryptionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// GCP Cloud KMS. It will need permission to encrypt and decrypt with the key.
+	// Secret should have a key named 'osServiceAccount.json'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// KeyName is the resource name of the Cloud KMS key, in the form
+	// projects/{project}/locations/{location}/keyRings/{keyRing}/cryptoKeys/{key}.
+	KeyName string `json:"keyName"`
+}
+
 type DeleteProtectionType string
 
 const (
 	DeleteProtectionEnabled DeleteProtectionType = "enabled"
 )
 
+// ProtectedWorkloadsConfig configures the check for protected workloads before clusters are deprovisioned.
+type ProtectedWorkloadsConfig struct {
+	// NamespaceSelector selects the namespaces of a cluster which hold protected workloads. A cluster with any
+	// namespace matching the selector is not deprovisioned, unless its ClusterDeployment has the
+	// "hive.openshift.io/force-deprovision" annotation set to "true".
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+}
+
+// ReleaseImageVerificationConfig configures the verification of release images against signature stores.
+type ReleaseImageVerificationConfig struct {
+	// SignatureStores are the HTTPS base URLs of the stores which publish the signatures of release images, such as
+	// https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release. A release image is verified when it
+	// is referenced by digest and one of the stores publishes a signature of the digest made with one of the keys.
+	// +kubebuilder:validation:MinItems=1
+	SignatureStores []string `json:"signatureStores"`
+
+	// KeysConfigMapRef references a ConfigMap in the TargetNamespace holding the ASCII-armored GPG public keys which
+	// the signatures are verified with, one or more keys per data entry, such as the keys trusted by the cluster
+	// version operator.
+	KeysConfigMapRef corev1.LocalObjectReference `json:"keysConfigMapRef"`
+}
+
+// PodSchedulingConfig constrains the nodes which the pods of Hive are scheduled on.
+type PodSchedulingConfig struct {
+	// NodeSelector is added to the node selector of the pods. The labels already selected by a pod, such as the
+	// labels set in the pod templates of hooks, are kept.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the tolerations of the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ProxyConfig configures the proxy of the outbound connections of Hive.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domains and CIDRs which are reached without the proxy. The
+	// addresses of the API server of the hub and of its services are always reached without the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// TrustedCASecretRef references a secret in the TargetNamespace with the certificate authorities to trust when
+	// connecting through the proxy, in PEM format in its ca.crt key.
+	// +optional
+	TrustedCASecretRef *corev1.LocalObjectReference `json:"trustedCASecretRef,omitempty"`
+
+	// Platforms overrides the proxy of the install and uninstall jobs of the clusters of a platform.
+	// +optional
+	Platforms []PlatformProxyConfig `json:"platforms,omitempty"`
+}
+
+// PlatformProxyConfig configures the proxy of the install and uninstall jobs of the clusters of a platform.
+type PlatformProxyConfig struct {
+	// Platform is the platform of the clusters.
+	// +kubebuilder:validation:Enum=aws;azure;gcp;openstack;vsphere;baremetal
+	Platform string `json:"platform"`
+
+	// HTTPProxy is the URL of the proxy for HTTP requests. HTTP requests are not proxied when it is empty.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests. HTTPS requests are not proxied when it is empty.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domains and CIDRs which are reached without the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
 // ManageDNSAzureConfig contains Azure-specific info to manage a given domain
 type ManageDNSAzureConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -294,9 +706,14 @@ type ControllerConfig struct {
 	// This is ONLY for controllers that have been split out into their own pods.
 	// This is ignored for all others.
 	Replicas *int32 `json:"replicas,omitempty"`
+	// WatchRemoteCluster specifies whether a controller managing objects in the remote clusters watches those objects,
+	// so that changes made to them in the remote clusters are reconciled immediately rather than at the next periodic
+	// reconcile. This is only used by the remotemachineset and clustersync controllers.
+	// +optional
+	WatchRemoteCluster *bool `json:"watchRemoteCluster,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;clusterupgrade;syncsetrollout;hivetenant;clusteroperationlog;clustercost
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -339,6 +756,11 @@ const (
 	VeleroBackupControllerName         ControllerName = "velerobackup"
 	MetricsControllerName              ControllerName = "metrics"
 	ClustersyncControllerName          ControllerName = "clustersync"
+	ClusterUpgradeControllerName       ControllerName = "clusterupgrade"
+	SyncSetRolloutControllerName       ControllerName = "syncsetrollout"
+	HiveTenantControllerName           ControllerName = "hivetenant"
+	ClusterOperationLogControllerName  ControllerName = "clusteroperationlog"
+	ClusterCostControllerName          ControllerName = "clustercost"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
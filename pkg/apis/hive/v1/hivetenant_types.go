@@ -0,0 +1,97 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HiveTenantSpec defines the quotas for the Hive resources in a namespace.
+type HiveTenantSpec struct {
+	// MaxClusterDeployments is the maximum number of ClusterDeployments that may exist in the namespace.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusterDeployments *int32 `json:"maxClusterDeployments,omitempty"`
+
+	// MaxConcurrentInstalls is the maximum number of ClusterDeployments in the namespace that may be installing at a
+	// time. A ClusterDeployment is installing from when it is created until the cluster is installed. A provision is
+	// not started while the maximum number of provisions are running in the namespace.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentInstalls *int32 `json:"maxConcurrentInstalls,omitempty"`
+
+	// MaxVCPUs is the maximum total number of vCPUs of the machines of the MachinePools and of the control plane
+	// machines of the ClusterDeployments in the namespace. The number of machines in a MachinePool is its replicas, or
+	// its maximum replicas when autoscaling. The control plane machines are taken from the install-config.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxVCPUs *int32 `json:"maxVCPUs,omitempty"`
+
+	// InstanceTypeVCPUs is the number of vCPUs of each of the AWS, Azure, and GCP instance types and OpenStack
+	// flavors that the machines in the namespace may use. When MaxVCPUs is set, MachinePools using an instance type
+	// that is not listed are rejected. The vCPUs of vSphere and oVirt machines are taken from the MachinePool or the
+	// install-config.
+	// +optional
+	InstanceTypeVCPUs map[string]int32 `json:"instanceTypeVCPUs,omitempty"`
+}
+
+// HiveTenantStatus defines the observed usage of the quotas for the Hive resources in a namespace.
+type HiveTenantStatus struct {
+	// ClusterDeployments is the number of ClusterDeployments in the namespace.
+	// +optional
+	ClusterDeployments int32 `json:"clusterDeployments,omitempty"`
+
+	// ConcurrentInstalls is the number of ClusterDeployments in the namespace that are installing.
+	// +optional
+	ConcurrentInstalls int32 `json:"concurrentInstalls,omitempty"`
+
+	// VCPUs is the total number of vCPUs of the machines of the MachinePools and of the control plane machines of
+	// the ClusterDeployments in the namespace.
+	// +optional
+	VCPUs int32 `json:"vcpus,omitempty"`
+
+	// UnknownInstanceTypes are the instance types used in the namespace that are not listed in InstanceTypeVCPUs.
+	// The vCPUs of the machines with these instance types are not included in VCPUs.
+	// +optional
+	UnknownInstanceTypes []string `json:"unknownInstanceTypes,omitempty"`
+
+	// ObservedGeneration is the generation of the HiveTenant for which the usage was last counted. The admission
+	// webhook rejects requests until the usage has been counted for the current generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HiveTenant limits the Hive resources that may be created in its namespace. The quotas are enforced when
+// ClusterDeployments and MachinePools are created or updated, and the concurrent installs and vCPUs again before a
+// provision is started. Resources that already exist are not affected by lowering a quota.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployments",type="integer",JSONPath=".status.clusterDeployments"
+// +kubebuilder:printcolumn:name="Installing",type="integer",JSONPath=".status.concurrentInstalls"
+// +kubebuilder:printcolumn:name="VCPUs",type="integer",JSONPath=".status.vcpus"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=hivetenants,scope=Namespaced
+type HiveTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HiveTenantSpec   `json:"spec,omitempty"`
+	Status HiveTenantStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HiveTenantList contains a list of HiveTenants
+type HiveTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HiveTenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HiveTenant{}, &HiveTenantList{})
+}
@@ -44,6 +44,13 @@ type MachinePoolSpec struct {
 	// Platform is configuration for machine pool specific to the platform.
 	Platform MachinePoolPlatform `json:"platform"`
 
+	// Architecture is the instruction set architecture of the machines of the pool. Defaults to the architecture of
+	// the control plane of the cluster. Another architecture can only be used when the cluster was installed from a
+	// multi-architecture release image.
+	// +kubebuilder:validation:Enum=amd64;arm64;ppc64le;s390x
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
 	// Map of label string keys and values that will be applied to the created MachineSet's
 	// MachineSpec. This list will overwrite any modifications made to Node labels on an
 	// ongoing basis.
@@ -84,6 +91,10 @@ type MachinePoolPlatform struct {
 
 // MachinePoolStatus defines the observed state of MachinePool
 type MachinePoolStatus struct {
+	// ObservedGeneration is the generation of the MachinePool last synced to the remote cluster.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Replicas is the current number of replicas for the machine pool.
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
@@ -146,9 +157,20 @@ const (
 	// InvalidSubnetsMachinePoolCondition is true when there are missing or invalid entries in the subnet field
 	InvalidSubnetsMachinePoolCondition MachinePoolConditionType = "InvalidSubnets"
 
+	// InvalidZonesMachinePoolCondition is true when the zones specified in the MachinePool are not available in the
+	// region of the cluster.
+	InvalidZonesMachinePoolCondition MachinePoolConditionType = "InvalidZones"
+
 	// UnsupportedConfigurationMachinePoolCondition is true when the configuration of the MachinePool is unsupported
 	// by the cluster.
 	UnsupportedConfigurationMachinePoolCondition MachinePoolConditionType = "UnsupportedConfiguration"
+
+	// InvalidArchitectureMachinePoolCondition is true when the architecture of the MachinePool cannot be used in the
+	// cluster.
+	InvalidArchitectureMachinePoolCondition MachinePoolConditionType = "InvalidArchitecture"
+
+	// ReadyMachinePoolCondition is true when the MachineSets of the MachinePool have been synced to the cluster.
+	ReadyMachinePoolCondition MachinePoolConditionType = "Ready"
 )
 
 // +genclient
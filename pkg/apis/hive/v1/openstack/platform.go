@@ -36,6 +36,36 @@ type Platform struct {
 	// from the clouds.yaml in the CredentialsSecretRef.
 	Cloud string `json:"cloud"`
 
+	// ExternalNetwork is the name of the external network of the cloud. When set, the install-config of the cluster
+	// must use the same external network.
+	// +optional
+	ExternalNetwork string `json:"externalNetwork,omitempty"`
+
+	// ComputeFlavor is the name of the Nova flavor of the machines which do not set one. When set, the
+	// install-config of the cluster must use the same flavor by default.
+	// +optional
+	ComputeFlavor string `json:"computeFlavor,omitempty"`
+
+	// ControlPlaneFlavor is the name of the Nova flavor of the control plane machines. When set, the install-config
+	// of the cluster must use the same flavor for its control plane.
+	// +optional
+	ControlPlaneFlavor string `json:"controlPlaneFlavor,omitempty"`
+
+	// APIFloatingIP is the floating IP of the external network attached to the API load balancer of the cluster.
+	// When set, the install-config of the cluster must use the same floating IP.
+	// +optional
+	APIFloatingIP string `json:"apiFloatingIP,omitempty"`
+
+	// IngressFloatingIP is the floating IP of the external network attached to the ingress port of the cluster.
+	// When set, the install-config of the cluster must use the same floating IP.
+	// +optional
+	IngressFloatingIP string `json:"ingressFloatingIP,omitempty"`
+
+	// ClusterOSImage is the URL of the image used to boot the machines of the cluster, or the name of an existing
+	// Glance image, overriding the RHCOS image of the release.
+	// +optional
+	ClusterOSImage string `json:"clusterOSImage,omitempty"`
+
 	// TrunkSupport indicates whether or not to use trunk ports in your OpenShift cluster.
 	// +optional
 	TrunkSupport bool `json:"trunkSupport,omitempty"`
@@ -48,6 +48,7 @@ type IdentityProviderStatus struct {
 // SelectorSyncIdentityProvider is the Schema for the SelectorSyncSet API
 // +k8s:openapi-gen=true
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
 type SelectorSyncIdentityProvider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -62,6 +63,7 @@ type SelectorSyncIdentityProvider struct {
 // SyncIdentityProvider is the Schema for the SyncIdentityProvider API
 // +k8s:openapi-gen=true
 // +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
 type SyncIdentityProvider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
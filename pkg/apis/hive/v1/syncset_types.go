@@ -44,6 +44,24 @@ const (
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
 )
 
+// SyncSetEnforcementMode is a string representing whether the state declared
+// in a syncset is enforced in the target cluster.
+// +kubebuilder:validation:Enum="";Enforce;Report
+type SyncSetEnforcementMode string
+
+const (
+	// EnforceSyncSetEnforcementMode is the default enforcement mode. Resources,
+	// secrets, and patches are applied to the target cluster, reverting any
+	// changes made to them directly in the cluster.
+	EnforceSyncSetEnforcementMode SyncSetEnforcementMode = "Enforce"
+
+	// ReportSyncSetEnforcementMode results in nothing being applied to the
+	// target cluster. Instead, the resources and secrets in the target cluster
+	// are compared with the syncset, and any differences are reported in the
+	// ClusterSync status.
+	ReportSyncSetEnforcementMode SyncSetEnforcementMode = "Report"
+)
+
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
 // SyncSet Patches.
 type SyncSetPatchApplyMode string
@@ -93,6 +111,34 @@ type SecretReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// SyncSetResourcesSource is a source of objects to sync. Exactly one of ConfigMapRef and URL must be set.
+type SyncSetResourcesSource struct {
+	// ConfigMapRef references a ConfigMap whose data entries each hold a YAML or JSON stream of objects to sync, in
+	// the order of their keys. The syncset is applied again when the ConfigMap changes.
+	// +optional
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
+
+	// URL is the HTTP or HTTPS URL of a YAML or JSON stream of objects to sync, for example an object in an object
+	// store. The content is only downloaded again when SHA256 changes.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the content at URL, which is required with URL. Content that does
+	// not match the checksum is not synced.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// ConfigMapReference is a reference to a ConfigMap
+type ConfigMapReference struct {
+	// Name is the name of the ConfigMap
+	Name string `json:"name"`
+	// Namespace is the namespace where the ConfigMap lives. It must be the namespace of the syncset for a SyncSet,
+	// which is assumed when it is not present, and is required for a SelectorSyncSet.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // SecretMapping defines a source and destination for a secret to be synced by a SyncSet
 type SecretMapping struct {
 
@@ -208,6 +254,18 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Resources []runtime.RawExtension `json:"resources,omitempty"`
 
+	// ResourcesFrom is the list of sources of objects to sync which are kept outside of the syncset, for example
+	// because they would make the syncset larger than the maximum size of an object. The objects are synced after
+	// the objects in Resources, in order.
+	// +optional
+	ResourcesFrom []SyncSetResourcesSource `json:"resourcesFrom,omitempty"`
+
+	// EnableResourceTemplates enables the resolution of Go templates in the string values of the objects to sync
+	// with the values of each target cluster, such as {{ .InfraID }} or {{ index .Labels "region" }}. The values
+	// available are Name, Namespace, ClusterName, BaseDomain, InfraID, ClusterID, Region, and Labels.
+	// +optional
+	EnableResourceTemplates bool `json:"enableResourceTemplates,omitempty"`
+
 	// ResourceApplyMode indicates if the Resource apply mode is "Upsert" (default) or "Sync".
 	// ApplyMode "Upsert" indicates create and update.
 	// ApplyMode "Sync" indicates create, update and delete.
@@ -233,6 +291,14 @@ type SyncSetCommonSpec struct {
 	// labels, and other map entries in general.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// EnforcementMode indicates whether the state declared in this syncset is enforced in the target
+	// cluster. The default value of "Enforce" indicates that resources, secrets, and patches are applied
+	// to the target cluster. A value of "Report" indicates that nothing is applied to the target cluster.
+	// Instead, resources and secrets that have drifted from the state declared in the syncset are reported
+	// in the ClusterSync status for the cluster. Patches are not checked for drift.
+	// +optional
+	EnforcementMode SyncSetEnforcementMode `json:"enforcementMode,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -242,9 +308,60 @@ type SelectorSyncSetSpec struct {
 	SyncSetCommonSpec `json:",inline"`
 
 	// ClusterDeploymentSelector is a LabelSelector indicating which clusters the SelectorSyncSet
-	// applies to in any namespace.
+	// applies to in any namespace. Clusters can be left out with matchExpressions using the NotIn
+	// and DoesNotExist operators.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// ExcludeClusterDeployments is the list of ClusterDeployments that the SelectorSyncSet does not
+	// apply to even though they match the ClusterDeploymentSelector.
+	// +optional
+	ExcludeClusterDeployments []SelectorSyncSetClusterDeploymentReference `json:"excludeClusterDeployments,omitempty"`
+
+	// Rollout controls how changes to the SelectorSyncSet are rolled out to the selected clusters. When set, a new
+	// generation of the SelectorSyncSet is applied to a few clusters at a time, wave by wave, instead of to every
+	// selected cluster at once.
+	// +optional
+	Rollout *SelectorSyncSetRollout `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetClusterDeploymentReference is a reference to a ClusterDeployment in any namespace.
+type SelectorSyncSetClusterDeploymentReference struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+}
+
+// SelectorSyncSetRollout is the strategy for rolling out changes to a SelectorSyncSet.
+type SelectorSyncSetRollout struct {
+	// Waves are selectors for the groups of clusters to which a change is rolled out in order. A cluster is in the
+	// first wave whose selector matches the labels of its ClusterDeployment. Clusters that do not match any of the
+	// waves are in a final wave. The change is not applied to the clusters in a wave until it has been applied to all
+	// of the clusters in the earlier waves.
+	// +optional
+	Waves []metav1.LabelSelector `json:"waves,omitempty"`
+
+	// MaxConcurrent is the maximum number of clusters in a wave to which the change is being applied at a time.
+	// By default the change is applied to all of the clusters in a wave at once.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+
+	// MaxFailures is the number of clusters that may fail to apply the change before the rollout is paused. While the
+	// rollout is paused, the change is not applied to any more clusters. Clusters that do not apply the change within
+	// the progress deadline count as failed.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures *int32 `json:"maxFailures,omitempty"`
+
+	// ProgressDeadline is how long the rollout waits for the admitted clusters to apply the change. When none of them
+	// has applied the change for longer than the deadline, those that have not applied it yet are counted as failed so
+	// that a cluster which never syncs does not hold up its wave.
+	// Defaults to 1h.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
@@ -265,6 +382,52 @@ type SyncSetStatus struct {
 
 // SelectorSyncSetStatus defines the observed state of a SelectorSyncSet
 type SelectorSyncSetStatus struct {
+	// Rollout is the status of the rollout of the current generation of the SelectorSyncSet. It is only set when the
+	// SelectorSyncSet has a rollout strategy.
+	// +optional
+	Rollout *SelectorSyncSetRolloutStatus `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetRolloutStatus is the status of the rollout of a generation of a SelectorSyncSet.
+type SelectorSyncSetRolloutStatus struct {
+	// ObservedGeneration is the generation of the SelectorSyncSet that is being rolled out.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// CurrentWave is the index of the wave that is being rolled out. The final wave, for the clusters that do not
+	// match any of the waves, has an index equal to the number of waves. Once the rollout is complete, CurrentWave
+	// is past the final wave and the change is applied to any cluster selected by the SelectorSyncSet.
+	CurrentWave int32 `json:"currentWave"`
+
+	// AdmittedClusters are the clusters in the current wave to which the change may be applied, in the form
+	// namespace/name. The change may be applied to all of the clusters in the earlier waves.
+	// +optional
+	AdmittedClusters []string `json:"admittedClusters,omitempty"`
+
+	// UpdatedClusters is the number of clusters to which the change has been applied successfully.
+	// +optional
+	UpdatedClusters int32 `json:"updatedClusters,omitempty"`
+
+	// FailedClusters is the number of clusters that failed to apply the change.
+	// +optional
+	FailedClusters int32 `json:"failedClusters,omitempty"`
+
+	// TotalClusters is the number of clusters selected by the SelectorSyncSet.
+	// +optional
+	TotalClusters int32 `json:"totalClusters,omitempty"`
+
+	// Paused is true when more clusters failed to apply the change than are allowed by the rollout strategy.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// LastProgressTime is the last time that the change was applied to a cluster, that more clusters were admitted, or
+	// that the rollout moved to another wave.
+	// +optional
+	LastProgressTime *metav1.Time `json:"lastProgressTime,omitempty"`
+
+	// TimedOutClusters are the clusters, in the form namespace/name, that did not apply the change within the progress
+	// deadline. They count as failed until the change is applied to them.
+	// +optional
+	TimedOutClusters []string `json:"timedOutClusters,omitempty"`
 }
 
 // +genclient
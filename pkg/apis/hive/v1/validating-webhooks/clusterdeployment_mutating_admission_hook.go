@@ -0,0 +1,250 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// defaultControlPlaneReplicas is the number of control plane nodes of a cluster whose InstallConfig does not set one,
+// as defaulted by the installer.
+const defaultControlPlaneReplicas int64 = 3
+
+// ClusterDeploymentMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server
+// to default ClusterDeployments.
+type ClusterDeploymentMutatingAdmissionHook struct {
+	decoder                *admission.Decoder
+	defaultClusterImageSet string
+}
+
+// NewClusterDeploymentMutatingAdmissionHook constructs a new ClusterDeploymentMutatingAdmissionHook
+func NewClusterDeploymentMutatingAdmissionHook(decoder *admission.Decoder) *ClusterDeploymentMutatingAdmissionHook {
+	defaultClusterImageSet := os.Getenv(constants.DefaultClusterImageSetEnvVar)
+	log.WithField("mutating_webhook", "clusterdeployment").WithField("defaultClusterImageSet", defaultClusterImageSet).Info("Read default ClusterImageSet")
+	return &ClusterDeploymentMutatingAdmissionHook{
+		decoder:                decoder,
+		defaultClusterImageSet: defaultClusterImageSet,
+	}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterdeploymentmutators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterDeploymentMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    clusterDeploymentAdmissionGroup,
+		"version":  clusterDeploymentAdmissionVersion,
+		"resource": "clusterdeploymentmutator",
+	}).Info("Registering mutating REST resource")
+
+	// NOTE: This GVR is meant to be different than the ClusterDeployment CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    clusterDeploymentAdmissionGroup,
+			Version:  clusterDeploymentAdmissionVersion,
+			Resource: "clusterdeploymentmutators",
+		},
+		"clusterdeploymentmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterDeploymentMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    clusterDeploymentAdmissionGroup,
+		"version":  clusterDeploymentAdmissionVersion,
+		"resource": "clusterdeploymentmutator",
+	}).Info("Initializing mutating REST resource")
+	return nil // No initialization needed right now.
+}
+
+// patchOperation is a JSON patch operation returned to the kube apiserver to mutate the admitted object.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// It fills in the fields of new ClusterDeployments which were left unset so that the stored object is fully specified:
+// the cluster type label, the cluster name, and the ClusterImageSet and number of control plane nodes used for
+// provisioning. On updates, it records the user who changed the power state of the ClusterDeployment.
+func (a *ClusterDeploymentMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !a.shouldAdmit(admissionSpec) {
+		contextLogger.Info("Skipping defaulting for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, cd); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = cd.Name
+
+	var patch []patchOperation
+	switch admissionSpec.Operation {
+	case admissionv1beta1.Create:
+		patch = a.defaultingPatch(cd)
+	case admissionv1beta1.Update:
+		oldCD := &hivev1.ClusterDeployment{}
+		if err := a.decoder.DecodeRaw(admissionSpec.OldObject, oldCD); err != nil {
+			contextLogger.Errorf("Failed unmarshaling OldObject: %v", err.Error())
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: err.Error(),
+				},
+			}
+		}
+		patch = powerStateChangedByPatch(oldCD, cd, admissionSpec.UserInfo.Username)
+	}
+	if len(patch) == 0 {
+		contextLogger.Info("No changes to apply")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	contextLogger.WithField("patch", string(patchBytes)).Info("Applying changes")
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patchBytes,
+		PatchType: &patchType,
+	}
+}
+
+// shouldAdmit explicitly checks if the request should be mutated. Only the creations and updates of
+// ClusterDeployments are mutated.
+func (a *ClusterDeploymentMutatingAdmissionHook) shouldAdmit(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	return admissionSpec.Resource.Group == clusterDeploymentGroup &&
+		admissionSpec.Resource.Version == clusterDeploymentVersion &&
+		admissionSpec.Resource.Resource == clusterDeploymentResource &&
+		(admissionSpec.Operation == admissionv1beta1.Create || admissionSpec.Operation == admissionv1beta1.Update)
+}
+
+// defaultingPatch returns the JSON patch operations setting the defaults for the unset fields of the ClusterDeployment.
+func (a *ClusterDeploymentMutatingAdmissionHook) defaultingPatch(cd *hivev1.ClusterDeployment) []patchOperation {
+	var patch []patchOperation
+
+	if _, ok := cd.Labels[hivev1.HiveClusterTypeLabel]; !ok {
+		if cd.Labels == nil {
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/metadata/labels",
+				Value: map[string]string{hivev1.HiveClusterTypeLabel: hivev1.DefaultClusterType},
+			})
+		} else {
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/metadata/labels/" + escapeJSONPointer(hivev1.HiveClusterTypeLabel),
+				Value: hivev1.DefaultClusterType,
+			})
+		}
+	}
+
+	// The name of the ClusterDeployment is not yet known when it is generated. The cluster name is then set to the
+	// generated name by the clusterdeployment controller.
+	if cd.Spec.ClusterName == "" && cd.Name != "" {
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  "/spec/clusterName",
+			Value: cd.Name,
+		})
+	}
+
+	// Adopted clusters are not provisioned and have no use for a ClusterImageSet.
+	if p := cd.Spec.Provisioning; p != nil && !cd.Spec.Installed && p.ReleaseImage == "" && p.ImageSetRef == nil && a.defaultClusterImageSet != "" {
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  "/spec/provisioning/imageSetRef",
+			Value: hivev1.ClusterImageSetReference{Name: a.defaultClusterImageSet},
+		})
+	}
+
+	if p := cd.Spec.Provisioning; p != nil && !cd.Spec.Installed && p.ControlPlaneReplicas == nil {
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  "/spec/provisioning/controlPlaneReplicas",
+			Value: defaultControlPlaneReplicas,
+		})
+	}
+
+	return patch
+}
+
+// powerStateChangedByPatch returns the JSON patch operations setting the annotation recording the user who changed the
+// power state of the ClusterDeployment. The annotation cannot be changed by the users themselves: it is restored when
+// an update which does not change the power state changes it.
+func powerStateChangedByPatch(oldCD, cd *hivev1.ClusterDeployment, username string) []patchOperation {
+	oldValue, oldOK := oldCD.Annotations[constants.PowerStateChangedByAnnotation]
+	value, ok := cd.Annotations[constants.PowerStateChangedByAnnotation]
+	if oldCD.Spec.PowerState != cd.Spec.PowerState {
+		oldValue, oldOK = username, true
+	}
+	if value == oldValue && ok == oldOK {
+		return nil
+	}
+	path := "/metadata/annotations/" + escapeJSONPointer(constants.PowerStateChangedByAnnotation)
+	switch {
+	case !oldOK:
+		return []patchOperation{{Op: "remove", Path: path}}
+	case cd.Annotations == nil:
+		return []patchOperation{{
+			Op:    "add",
+			Path:  "/metadata/annotations",
+			Value: map[string]string{constants.PowerStateChangedByAnnotation: oldValue},
+		}}
+	default:
+		return []patchOperation{{Op: "add", Path: path, Value: oldValue}}
+	}
+}
+
+// escapeJSONPointer escapes a key for use as a reference token in a JSON pointer.
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,253 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterDeploymentMutatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterdeploymentmutators",
+	}
+	expectedSingular := "clusterdeploymentmutator"
+
+	// Act
+	plural, singular := data.MutatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterDeploymentAdmit(t *testing.T) {
+	cases := []struct {
+		name                   string
+		cd                     *hivev1.ClusterDeployment
+		oldCD                  *hivev1.ClusterDeployment
+		operation              admissionv1beta1.Operation
+		defaultClusterImageSet string
+		expectNoPatch          bool
+		validate               func(t *testing.T, cd *hivev1.ClusterDeployment)
+	}{
+		{
+			name:                   "defaults applied",
+			cd:                     clusterDeploymentTemplate(),
+			defaultClusterImageSet: "default-imageset",
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, hivev1.DefaultClusterType, cd.Labels[hivev1.HiveClusterTypeLabel], "unexpected cluster type label")
+				assert.Equal(t, "SameClusterName", cd.Spec.ClusterName, "unexpected cluster name")
+				if assert.NotNil(t, cd.Spec.Provisioning.ImageSetRef, "expected imageset ref") {
+					assert.Equal(t, "default-imageset", cd.Spec.Provisioning.ImageSetRef.Name, "unexpected imageset")
+				}
+				if assert.NotNil(t, cd.Spec.Provisioning.ControlPlaneReplicas, "expected control plane replicas") {
+					assert.Equal(t, int64(3), *cd.Spec.Provisioning.ControlPlaneReplicas, "unexpected control plane replicas")
+				}
+			},
+		},
+		{
+			name: "control plane replicas kept",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				replicas := int64(1)
+				cd.Spec.Provisioning.ControlPlaneReplicas = &replicas
+				return cd
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				if assert.NotNil(t, cd.Spec.Provisioning.ControlPlaneReplicas, "expected control plane replicas") {
+					assert.Equal(t, int64(1), *cd.Spec.Provisioning.ControlPlaneReplicas, "unexpected control plane replicas")
+				}
+			},
+		},
+		{
+			name: "control plane replicas of installed cluster not defaulted",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Spec.Installed = true
+				return cd
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Nil(t, cd.Spec.Provisioning.ControlPlaneReplicas, "expected no control plane replicas")
+			},
+		},
+		{
+			name: "existing labels kept",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Labels = map[string]string{"other": "value"}
+				return cd
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, map[string]string{"other": "value", hivev1.HiveClusterTypeLabel: hivev1.DefaultClusterType}, cd.Labels, "unexpected labels")
+				assert.Nil(t, cd.Spec.Provisioning.ImageSetRef, "expected no imageset ref without a default imageset")
+			},
+		},
+		{
+			name: "nothing to default",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Labels = map[string]string{hivev1.HiveClusterTypeLabel: "managed"}
+				cd.Spec.Provisioning.ReleaseImage = "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64"
+				replicas := int64(3)
+				cd.Spec.Provisioning.ControlPlaneReplicas = &replicas
+				return cd
+			}(),
+			defaultClusterImageSet: "default-imageset",
+			expectNoPatch:          true,
+		},
+		{
+			name: "cluster name from name",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Name = "test-cluster"
+				cd.Spec.ClusterName = ""
+				return cd
+			}(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, "test-cluster", cd.Spec.ClusterName, "unexpected cluster name")
+			},
+		},
+		{
+			name: "cluster name not defaulted for generated name",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Labels = map[string]string{hivev1.HiveClusterTypeLabel: "managed"}
+				cd.GenerateName = "test-cluster-"
+				cd.Spec.ClusterName = ""
+				replicas := int64(3)
+				cd.Spec.Provisioning.ControlPlaneReplicas = &replicas
+				return cd
+			}(),
+			expectNoPatch: true,
+		},
+		{
+			name:                   "update not defaulted",
+			cd:                     clusterDeploymentTemplate(),
+			oldCD:                  clusterDeploymentTemplate(),
+			operation:              admissionv1beta1.Update,
+			defaultClusterImageSet: "default-imageset",
+			expectNoPatch:          true,
+		},
+		{
+			name: "power state changer recorded",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				return cd
+			}(),
+			oldCD:     clusterDeploymentTemplate(),
+			operation: admissionv1beta1.Update,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, "test-user", cd.Annotations[constants.PowerStateChangedByAnnotation], "unexpected power state changer")
+			},
+		},
+		{
+			name: "power state changer replaced",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "other-user"}
+				cd.Spec.PowerState = hivev1.RunningClusterPowerState
+				return cd
+			}(),
+			oldCD: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "other-user"}
+				cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				return cd
+			}(),
+			operation: admissionv1beta1.Update,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, "test-user", cd.Annotations[constants.PowerStateChangedByAnnotation], "unexpected power state changer")
+			},
+		},
+		{
+			name: "forged power state changer restored",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "forged-user"}
+				return cd
+			}(),
+			oldCD: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "other-user"}
+				return cd
+			}(),
+			operation: admissionv1beta1.Update,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, "other-user", cd.Annotations[constants.PowerStateChangedByAnnotation], "unexpected power state changer")
+			},
+		},
+		{
+			name: "forged power state changer removed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentTemplate()
+				cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "forged-user"}
+				return cd
+			}(),
+			oldCD:     clusterDeploymentTemplate(),
+			operation: admissionv1beta1.Update,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				_, ok := cd.Annotations[constants.PowerStateChangedByAnnotation]
+				assert.False(t, ok, "expected no power state changer")
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+			data.defaultClusterImageSet = tc.defaultClusterImageSet
+			raw, err := json.Marshal(tc.cd)
+			require.NoError(t, err, "unexpected error marshaling ClusterDeployment")
+			operation := tc.operation
+			if operation == "" {
+				operation = admissionv1beta1.Create
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeployments",
+				},
+				Operation: operation,
+				UserInfo:  authenticationv1.UserInfo{Username: "test-user"},
+			}
+			request.Object.Raw = raw
+			if tc.oldCD != nil {
+				request.OldObject.Raw, err = json.Marshal(tc.oldCD)
+				require.NoError(t, err, "unexpected error marshaling old ClusterDeployment")
+			}
+
+			response := data.Admit(request)
+
+			assert.True(t, response.Allowed, "expected request to be allowed")
+			if tc.expectNoPatch {
+				assert.Nil(t, response.Patch, "expected no patch")
+				return
+			}
+			require.NotNil(t, response.PatchType, "expected patch type")
+			assert.Equal(t, admissionv1beta1.PatchTypeJSONPatch, *response.PatchType, "unexpected patch type")
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err, "unexpected error decoding patch")
+			patched, err := patch.Apply(raw)
+			require.NoError(t, err, "unexpected error applying patch")
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, json.Unmarshal(patched, cd), "unexpected error unmarshaling patched ClusterDeployment")
+			tc.validate(t, cd)
+		})
+	}
+}
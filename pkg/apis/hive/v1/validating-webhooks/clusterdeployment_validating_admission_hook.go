@@ -1,8 +1,10 @@
 package validatingwebhooks
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -11,21 +13,28 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/manageddns"
+	"github.com/openshift/hive/pkg/operationlog"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 const (
@@ -35,21 +44,34 @@ const (
 
 	clusterDeploymentAdmissionGroup   = "admission.hive.openshift.io"
 	clusterDeploymentAdmissionVersion = "v1"
+
+	// clusterAppsWildcardPrefix is the prefix of the wildcard DNS record of the default ingress of the cluster.
+	clusterAppsWildcardPrefix = "*.apps"
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "DeprovisionCredentialsSecretRef"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder             *admission.Decoder
 	validManagedDomains []string
+	// client records the deletions of ClusterDeployments in ClusterOperationLogs.
+	client client.Client
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
 func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *ClusterDeploymentValidatingAdmissionHook {
-	logger := log.WithField("validating_webhook", "clusterdeployment")
+	return &ClusterDeploymentValidatingAdmissionHook{
+		decoder:             decoder,
+		validManagedDomains: readManagedDomains(log.WithField("validating_webhook", "clusterdeployment")),
+	}
+}
+
+// readManagedDomains returns the domains under which Hive may manage DNS, as configured in the managed domains of
+// HiveConfig.
+func readManagedDomains(logger log.FieldLogger) []string {
 	managedDomains, err := manageddns.ReadManagedDomainsFile()
 	if err != nil {
 		logger.WithError(err).Fatal("Unable to read managedDomains file")
@@ -59,10 +81,7 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 		domains = append(domains, md.Domains...)
 	}
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
-	return &ClusterDeploymentValidatingAdmissionHook{
-		decoder:             decoder,
-		validManagedDomains: domains,
-	}
+	return domains
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
@@ -86,13 +105,24 @@ func (a *ClusterDeploymentValidatingAdmissionHook) ValidatingResource() (plural
 }
 
 // Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+// The webhook needs a client to record the deletions of ClusterDeployments.
 func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
 	log.WithFields(log.Fields{
 		"group":    clusterDeploymentAdmissionGroup,
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(kubeClientConfig, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return err
+	}
+	a.client, err = client.New(kubeClientConfig, client.Options{Scheme: scheme, Mapper: mapper})
+	return err
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -231,7 +261,7 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 
 	if newObject.Spec.ManageDNS {
 		if !validateDomain(newObject.Spec.BaseDomain, a.validManagedDomains) {
-			message := "The base domain must be a child of one of the managed domains for ClusterDeployments with manageDNS set to true"
+			message := fmt.Sprintf("The base domain must be a child of one of the managed domains for ClusterDeployments with manageDNS set to true (managed domains: %s)", strings.Join(a.validManagedDomains, ", "))
 			return &admissionv1beta1.AdmissionResponse{
 				Allowed: false,
 				Result: &metav1.Status{
@@ -254,13 +284,20 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateClusterDomain(specPath, newObject.Spec)...)
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), newObject.Spec.Platform)...)
 	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, newObject.Spec)...)
+	allErrs = append(allErrs, validateAPIURLOverride(specPath.Child("controlPlaneConfig", "apiURLOverride"), newObject.Spec.ControlPlaneConfig.APIURLOverride)...)
+	allErrs = append(allErrs, validateHooks(specPath.Child("hooks"), newObject.Spec.Hooks)...)
+	allErrs = append(allErrs, validateDeprovisionCredentials(specPath.Child("deprovisionCredentialsSecretRef"), newObject.Spec.DeprovisionCredentialsSecretRef)...)
 
 	if newObject.Spec.Provisioning != nil {
 		if newObject.Spec.Provisioning.SSHPrivateKeySecretRef != nil && newObject.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		allErrs = append(allErrs, validateArchitecture(specPath.Child("provisioning", "architecture"), newObject.Spec.Provisioning.Architecture, false)...)
+		allErrs = append(allErrs, validateCredentialsMode(specPath.Child("provisioning"), newObject.Spec.Provisioning)...)
+		allErrs = append(allErrs, validateStageTimeouts(specPath.Child("provisioning", "stageTimeouts"), newObject.Spec.Provisioning.StageTimeouts)...)
 	}
 
 	if poolRef := newObject.Spec.ClusterPoolRef; poolRef != nil {
@@ -328,6 +365,8 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if aws.Region == "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "must specify AWS region"))
 		}
+		allErrs = append(allErrs, validateFallbackRegions(awsPath.Child("fallbackRegions"), aws.Region, aws.FallbackRegions)...)
+		allErrs = append(allErrs, validateAWSServiceEndpoints(awsPath.Child("serviceEndpoints"), aws.ServiceEndpoints)...)
 	}
 	if azure := platform.Azure; azure != nil {
 		numberOfPlatforms++
@@ -338,6 +377,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if azure.Region == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("region"), "must specify Azure region"))
 		}
+		allErrs = append(allErrs, validateFallbackRegions(azurePath.Child("fallbackRegions"), azure.Region, azure.FallbackRegions)...)
 		if azure.BaseDomainResourceGroupName == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("baseDomainResourceGroupName"), "must specify the Azure resource group for the base domain"))
 		}
@@ -351,6 +391,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if gcp.Region == "" {
 			allErrs = append(allErrs, field.Required(gcpPath.Child("region"), "must specify GCP region"))
 		}
+		allErrs = append(allErrs, validateFallbackRegions(gcpPath.Child("fallbackRegions"), gcp.Region, gcp.FallbackRegions)...)
 	}
 	if openstack := platform.OpenStack; openstack != nil {
 		numberOfPlatforms++
@@ -397,7 +438,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 			allErrs = append(allErrs, field.Required(ovirtPath.Child("ovirt_cluster_id"), "must specify ovirt_cluster_id"))
 		}
 		if ovirt.StorageDomainID == "" {
-			allErrs = append(allErrs, field.Required(ovirtPath.Child("ovirt_storage_domain_id"), "must specify ovirt_storage_domain_id"))
+			allErrs = append(allErrs, field.Required(ovirtPath.Child("storage_domain_id"), "must specify storage_domain_id"))
 		}
 	}
 	if baremetal := platform.BareMetal; baremetal != nil {
@@ -412,19 +453,195 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	return allErrs
 }
 
-func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+func validateAWSServiceEndpoints(path *field.Path, serviceEndpoints []hivev1aws.ServiceEndpoint) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, e := range serviceEndpoints {
+		endpointPath := path.Index(i)
+		if e.Name == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("name"), "must specify the AWS service name"))
+		} else if seen[e.Name] {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("name"), e.Name))
+		}
+		seen[e.Name] = true
+		if e.URL == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("url"), "must specify the AWS service endpoint URL"))
+			continue
+		}
+		if u, err := url.Parse(e.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("url"), e.URL, "must be a valid https URL"))
+		}
+	}
+	return allErrs
+}
+
+// validateFallbackRegions validates the regions where an install is retried when the region of the platform lacks
+// capacity. Each fallback region must be tried at most once.
+func validateFallbackRegions(path *field.Path, region string, fallbackRegions []string) field.ErrorList {
 	allErrs := field.ErrorList{}
-	canManageDNS := false
-	if spec.Platform.AWS != nil {
-		canManageDNS = true
+	seen := map[string]bool{region: true}
+	for i, r := range fallbackRegions {
+		switch {
+		case r == "":
+			allErrs = append(allErrs, field.Required(path.Index(i), "must specify the fallback region"))
+		case seen[r]:
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), r))
+		}
+		seen[r] = true
 	}
-	if spec.Platform.Azure != nil {
-		canManageDNS = true
+	return allErrs
+}
+
+// validateArchitecture validates an instruction set architecture. Only release images can be multi-architecture.
+func validateArchitecture(path *field.Path, arch hivev1.Architecture, allowMulti bool) field.ErrorList {
+	supported := []string{
+		string(hivev1.ArchitectureAMD64),
+		string(hivev1.ArchitectureARM64),
+		string(hivev1.ArchitecturePPC64LE),
+		string(hivev1.ArchitectureS390X),
 	}
-	if spec.Platform.GCP != nil {
-		canManageDNS = true
+	if allowMulti {
+		supported = append(supported, string(hivev1.ArchitectureMulti))
 	}
-	if !canManageDNS && spec.ManageDNS {
+	if arch == "" {
+		return nil
+	}
+	for _, s := range supported {
+		if string(arch) == s {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(path, arch, supported)}
+}
+
+// validateStageTimeouts validates that the timeouts of the stages of the install are positive.
+func validateStageTimeouts(path *field.Path, timeouts *hivev1.ProvisionStageTimeouts) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if timeouts == nil {
+		return allErrs
+	}
+	for _, t := range []struct {
+		name    string
+		timeout *metav1.Duration
+	}{
+		{name: "infrastructure", timeout: timeouts.Infrastructure},
+		{name: "bootstrap", timeout: timeouts.Bootstrap},
+		{name: "clusterOperators", timeout: timeouts.ClusterOperators},
+	} {
+		if t.timeout != nil && t.timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(t.name), t.timeout.Duration.String(), "must be positive"))
+		}
+	}
+	return allErrs
+}
+
+// validateCredentialsMode validates the credentials mode of the provisioning. The credentials manifests are required
+// by, and only used in, the manual mode.
+func validateCredentialsMode(path *field.Path, provisioning *hivev1.Provisioning) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supported := []string{
+		string(hivev1.MintCredentialsMode),
+		string(hivev1.PassthroughCredentialsMode),
+		string(hivev1.ManualCredentialsMode),
+	}
+	mode := provisioning.CredentialsMode
+	if mode != "" {
+		isSupported := false
+		for _, s := range supported {
+			if string(mode) == s {
+				isSupported = true
+			}
+		}
+		if !isSupported {
+			allErrs = append(allErrs, field.NotSupported(path.Child("credentialsMode"), mode, supported))
+		}
+	}
+	manifestsRef := provisioning.CredentialsManifestsSecretRef
+	switch {
+	case mode == hivev1.ManualCredentialsMode && (manifestsRef == nil || manifestsRef.Name == ""):
+		allErrs = append(allErrs, field.Required(path.Child("credentialsManifestsSecretRef", "name"), "must specify the credentials manifests in the Manual credentials mode"))
+	case mode != hivev1.ManualCredentialsMode && manifestsRef != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("credentialsManifestsSecretRef"), "credentials manifests are only used in the Manual credentials mode"))
+	}
+	return allErrs
+}
+
+// validateDeprovisionCredentials validates the reference to the deprovision credentials secret.
+func validateDeprovisionCredentials(path *field.Path, ref *corev1.LocalObjectReference) field.ErrorList {
+	if ref != nil && ref.Name == "" {
+		return field.ErrorList{field.Required(path.Child("name"), "must specify a name for the deprovision credentials secret if the deprovision credentials secret is specified")}
+	}
+	return nil
+}
+
+// validateHooks validates the lifecycle hooks of a ClusterDeployment. The names of the hooks must be unique, as the
+// names of their jobs are derived from them.
+func validateHooks(path *field.Path, hooks []hivev1.ClusterHook) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, hook := range hooks {
+		hookPath := path.Index(i)
+		if hook.Name == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("name"), "must specify the name of the hook"))
+		} else if seen[hook.Name] {
+			allErrs = append(allErrs, field.Duplicate(hookPath.Child("name"), hook.Name))
+		}
+		seen[hook.Name] = true
+		if hook.PodTemplateRef.Name == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("podTemplateRef", "name"), "must specify the pod template of the hook"))
+		}
+	}
+	return allErrs
+}
+
+// validateAPIURLOverride validates the URL that Hive uses in place of the API URL from the admin kubeconfig. The URL
+// may omit the scheme, in which case https is assumed.
+func validateAPIURLOverride(path *field.Path, override string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if override == "" {
+		return allErrs
+	}
+	rawURL := override
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(path, override, "must be a valid https URL"))
+	} else if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+		allErrs = append(allErrs, field.Invalid(path, override, "must not contain a path or query"))
+	}
+	return allErrs
+}
+
+// validateClusterDomain validates that the base domain is a valid DNS name, and that the names of the DNS records
+// created for the cluster under its base domain do not exceed the length limits of DNS, which the installer and the
+// cloud DNS services would otherwise only reject once the install is underway.
+func validateClusterDomain(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	baseDomainPath := specPath.Child("baseDomain")
+	if spec.BaseDomain == "" {
+		return append(allErrs, field.Required(baseDomainPath, "must specify a base domain"))
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(spec.BaseDomain)); len(errs) > 0 {
+		return append(allErrs, field.Invalid(baseDomainPath, spec.BaseDomain, strings.Join(errs, ", ")))
+	}
+	// The wildcard record of the default ingress is the longest of the DNS records created for the cluster.
+	appsDomain := fmt.Sprintf("%s.%s.%s", clusterAppsWildcardPrefix, spec.ClusterName, spec.BaseDomain)
+	if len(appsDomain) > validation.DNS1123SubdomainMaxLength {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("clusterName"),
+			spec.ClusterName,
+			fmt.Sprintf("the cluster name and base domain must be no more than %d characters combined",
+				validation.DNS1123SubdomainMaxLength-len(clusterAppsWildcardPrefix)-2),
+		))
+	}
+	return allErrs
+}
+
+func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.ManageDNS && !platform.ManagesDNS(&hivev1.ClusterDeployment{Spec: spec}) {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
 	}
 	return allErrs
@@ -475,7 +692,12 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(&oldObject.Spec, &newObject.Spec)
+	// The cluster name of a ClusterDeployment created with a generated name is set to the name once it is known.
+	oldSpec := oldObject.Spec
+	if oldSpec.ClusterName == "" && newObject.Spec.ClusterName == newObject.Name {
+		oldSpec.ClusterName = newObject.Spec.ClusterName
+	}
+	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(&oldSpec, &newObject.Spec)
 	if hasChangedImmutableField {
 		message := fmt.Sprintf("Attempted to change ClusterDeployment.Spec.%v. ClusterDeployment.Spec is immutable except for %v", changedFieldName, mutableFields)
 		contextLogger.Infof("Failed validation: %v", message)
@@ -512,6 +734,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
+	allErrs = append(allErrs, validateDeprovisionCredentials(specPath.Child("deprovisionCredentialsSecretRef"), newObject.Spec.DeprovisionCredentialsSecretRef)...)
+
 	if newObject.Spec.Installed {
 		if newObject.Spec.ClusterMetadata != nil {
 			if oldObject.Spec.Installed {
@@ -526,6 +750,12 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	// An override stored before it was validated is left alone, so that the other fields can still be updated.
+	if newObject.Spec.ControlPlaneConfig.APIURLOverride != oldObject.Spec.ControlPlaneConfig.APIURLOverride {
+		allErrs = append(allErrs, validateAPIURLOverride(specPath.Child("controlPlaneConfig", "apiURLOverride"), newObject.Spec.ControlPlaneConfig.APIURLOverride)...)
+	}
+	allErrs = append(allErrs, validateHooks(specPath.Child("hooks"), newObject.Spec.Hooks)...)
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, newObject.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
@@ -611,12 +841,35 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 		}
 	}
 
+	if request.DryRun == nil || !*request.DryRun {
+		a.recordDelete(oldObject, request.UserInfo.Username, logger)
+	}
+
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
 		Allowed: true,
 	}
 }
 
+// recordDelete records the deletion of the ClusterDeployment by the user in a ClusterOperationLog. The deletion is not
+// rejected when it cannot be recorded, as the clusteroperationlog controller also records it, without the user.
+func (a *ClusterDeploymentValidatingAdmissionHook) recordDelete(cd *hivev1.ClusterDeployment, username string, logger log.FieldLogger) {
+	if a.client == nil || cd.DeletionTimestamp != nil {
+		// The deletion of a ClusterDeployment which is already being deleted has already been recorded.
+		return
+	}
+	opLog := operationlog.Delete(cd, metav1.Now())
+	opLog.Spec.User = username
+	switch err := a.client.Create(context.Background(), opLog); {
+	case errors.IsAlreadyExists(err):
+		logger.Debug("deletion already recorded")
+	case err != nil:
+		logger.WithError(err).Warn("could not record the deletion in a cluster operation log")
+	default:
+		logger.WithField("clusterOperationLog", opLog.Name).WithField("user", username).Info("recorded deletion")
+	}
+}
+
 // isFieldMutable says whether the ClusterDeployment.spec field is meant to be mutable or not.
 func isFieldMutable(value string) bool {
 	for _, mutableField := range mutableFields {
@@ -1,18 +1,26 @@
 package validatingwebhooks
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
@@ -22,6 +30,7 @@ import (
 	hivev1ovirt "github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operationlog"
 )
 
 var validTestManagedDomains = []string{
@@ -179,10 +188,87 @@ func TestClusterDeploymentInitialize(t *testing.T) {
 	data := NewClusterDeploymentValidatingAdmissionHook(createDecoder(t))
 
 	// Act
-	err := data.Initialize(nil, nil)
+	err := data.Initialize(&rest.Config{Host: "https://example.com"}, nil)
 
 	// Assert
 	assert.Nil(t, err)
+	assert.NotNil(t, data.client, "expected a client to record deletions")
+}
+
+func TestClusterDeploymentRecordDelete(t *testing.T) {
+	deleting := metav1.Now()
+	cases := []struct {
+		name              string
+		dryRun            bool
+		deletionTimestamp *metav1.Time
+		existing          []runtime.Object
+		expectUser        string
+	}{
+		{
+			name:       "deletion recorded",
+			expectUser: "test-user",
+		},
+		{
+			name:   "dry run not recorded",
+			dryRun: true,
+		},
+		{
+			name:              "deletion of deleting cluster not recorded",
+			deletionTimestamp: &deleting,
+		},
+		{
+			name: "deletion already recorded",
+			existing: func() []runtime.Object {
+				cd := validAWSClusterDeployment()
+				cd.Namespace = "test-namespace"
+				cd.Name = "test-cluster"
+				return []runtime.Object{operationlog.Delete(cd, metav1.Now())}
+			}(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, hivev1.AddToScheme(scheme), "unexpected error adding to scheme")
+			c := fake.NewFakeClientWithScheme(scheme, tc.existing...)
+			data := &ClusterDeploymentValidatingAdmissionHook{
+				decoder:             createDecoder(t),
+				validManagedDomains: validTestManagedDomains,
+				client:              c,
+			}
+			cd := validAWSClusterDeployment()
+			cd.Namespace = "test-namespace"
+			cd.Name = "test-cluster"
+			cd.DeletionTimestamp = tc.deletionTimestamp
+			raw, err := json.Marshal(cd)
+			require.NoError(t, err, "unexpected error marshaling ClusterDeployment")
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeployments",
+				},
+				Operation: admissionv1beta1.Delete,
+				UserInfo:  authenticationv1.UserInfo{Username: "test-user"},
+				DryRun:    &tc.dryRun,
+			}
+			request.OldObject.Raw = raw
+
+			response := data.Validate(request)
+
+			assert.True(t, response.Allowed, "expected deletion to be allowed")
+			opLogs := &hivev1.ClusterOperationLogList{}
+			require.NoError(t, c.List(context.TODO(), opLogs), "unexpected error listing cluster operation logs")
+			if tc.expectUser == "" && len(tc.existing) == 0 {
+				assert.Empty(t, opLogs.Items, "expected no cluster operation log")
+				return
+			}
+			if assert.Len(t, opLogs.Items, 1, "expected one cluster operation log") {
+				assert.Equal(t, hivev1.ClusterOperationDelete, opLogs.Items[0].Spec.Operation, "unexpected operation")
+				assert.Equal(t, tc.expectUser, opLogs.Items[0].Spec.User, "unexpected user")
+			}
+		})
+	}
 }
 
 func TestClusterDeploymentValidate(t *testing.T) {
@@ -350,6 +436,39 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test Update Operation is allowed setting the cluster name to the generated name",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "test-cluster-abcde"
+				cd.Spec.ClusterName = ""
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "test-cluster-abcde"
+				cd.Spec.ClusterName = "test-cluster-abcde"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test Update Operation is NOT allowed setting the cluster name to another name",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "test-cluster-abcde"
+				cd.Spec.ClusterName = ""
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentDifferentImmutableValue()
+				cd.Name = "test-cluster-abcde"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
@@ -473,6 +592,47 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test base domain is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test base domain is not a valid DNS name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "example_com."
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test base domain with uppercase letters",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "Example.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test cluster name and base domain too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = strings.Repeat(strings.Repeat("a", 60)+".", 3) + "example.com"
+				cd.Spec.ClusterName = strings.Repeat("b", 55)
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "Test managed DNS is valid on GCP",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -586,6 +746,331 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "AWS create with service endpoints",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://vpce-ec2.example.com"},
+					{Name: "route53", URL: "https://vpce-route53.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS create with non-https service endpoint",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "http://vpce-ec2.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with service endpoint missing name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{URL: "https://vpce-ec2.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with duplicate service endpoints",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://vpce-ec2.example.com"},
+					{Name: "ec2", URL: "https://other-ec2.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with fallback regions",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.FallbackRegions = []string{"us-east-2", "us-west-2"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS create with fallback region matching region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.FallbackRegions = []string{"us-east-2", cd.Spec.Platform.AWS.Region}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP create with empty fallback region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.FallbackRegions = []string{""}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with arm64 architecture",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Architecture = hivev1.ArchitectureARM64
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with multi architecture",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Architecture = hivev1.ArchitectureMulti
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with stage timeouts",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.StageTimeouts = &hivev1.ProvisionStageTimeouts{
+					Infrastructure: &metav1.Duration{Duration: 30 * time.Minute},
+					Bootstrap:      &metav1.Duration{Duration: 45 * time.Minute},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with non-positive stage timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.StageTimeouts = &hivev1.ProvisionStageTimeouts{
+					ClusterOperators: &metav1.Duration{},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with deprovision credentials",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with deprovision credentials without name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update deprovision credentials",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update deprovision credentials without name",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with Manual credentials mode",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.CredentialsMode = hivev1.ManualCredentialsMode
+				cd.Spec.Provisioning.CredentialsManifestsSecretRef = &corev1.LocalObjectReference{Name: "credentials-manifests"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with Manual credentials mode without credentials manifests",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.CredentialsMode = hivev1.ManualCredentialsMode
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with credentials manifests in Mint credentials mode",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.CredentialsMode = hivev1.MintCredentialsMode
+				cd.Spec.Provisioning.CredentialsManifestsSecretRef = &corev1.LocalObjectReference{Name: "credentials-manifests"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with unsupported credentials mode",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.CredentialsMode = "Borrow"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with hooks",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Hooks = []hivev1.ClusterHook{
+					{Name: "register", Stage: hivev1.PostInstallClusterHookStage, PodTemplateRef: corev1.LocalObjectReference{Name: "register"}},
+					{Name: "drain", Stage: hivev1.PreDeprovisionClusterHookStage, PodTemplateRef: corev1.LocalObjectReference{Name: "drain"}, Blocking: true},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with duplicate hook names",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Hooks = []hivev1.ClusterHook{
+					{Name: "register", Stage: hivev1.PreInstallClusterHookStage, PodTemplateRef: corev1.LocalObjectReference{Name: "register"}},
+					{Name: "register", Stage: hivev1.PostInstallClusterHookStage, PodTemplateRef: corev1.LocalObjectReference{Name: "register"}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with hook missing pod template",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Hooks = []hivev1.ClusterHook{
+					{Name: "register", Stage: hivev1.PostInstallClusterHookStage},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with API URL override",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://api-int.test-cluster.example.com:6443"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with API URL override without scheme",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "api-int.test-cluster.example.com:6443"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with non-https API URL override",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "http://api-int.test-cluster.example.com:6443"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with API URL override with path",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://api-int.test-cluster.example.com:6443/api"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update to set API URL override",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://api-int.test-cluster.example.com:6443"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update to set invalid API URL override",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "update with unchanged invalid API URL override",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "http://api-int.test-cluster.example.com:6443"
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "http://api-int.test-cluster.example.com:6443"
+				cd.Labels = map[string]string{"new-label": "value"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
 		{
 			name:            "Azure create valid",
 			newObject:       validAzureClusterDeployment(),
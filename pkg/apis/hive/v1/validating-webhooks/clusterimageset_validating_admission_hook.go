@@ -1,6 +1,7 @@
 package validatingwebhooks
 
 import (
+	"fmt"
 	"net/http"
 	"reflect"
 
@@ -9,6 +10,7 @@ import (
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -165,6 +167,18 @@ func (a *ClusterImageSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 		}
 	}
 
+	if errs := validateArchitecture(field.NewPath("spec", "architecture"), newObject.Spec.Architecture, true); len(errs) > 0 {
+		message := fmt.Sprintf("Failed validation: %v", errs.ToAggregate())
+		contextLogger.Info(message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
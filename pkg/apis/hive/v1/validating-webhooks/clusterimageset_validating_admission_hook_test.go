@@ -60,6 +60,24 @@ func TestClusterImageSetValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test valid ClusterImageSet.Spec with multi-architecture release image",
+			newSpec: hivev1.ClusterImageSetSpec{
+				ReleaseImage: "image:tag",
+				Architecture: hivev1.ArchitectureMulti,
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test invalid ClusterImageSet.Spec architecture",
+			newSpec: hivev1.ClusterImageSetSpec{
+				ReleaseImage: "image:tag",
+				Architecture: "x86",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test empty ClusterImageSet.Spec value",
 			newSpec:         hivev1.ClusterImageSetSpec{},
@@ -3,6 +3,8 @@ package validatingwebhooks
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -16,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 const (
@@ -29,13 +32,19 @@ const (
 
 // ClusterPoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterPoolValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder             *admission.Decoder
+	validManagedDomains []string
+	// namespacesRestricted is true when the Hive controllers only watch the namespaces listed in the HiveConfig,
+	// which cannot include the namespaces created for the clusters of the pools.
+	namespacesRestricted bool
 }
 
 // NewClusterPoolValidatingAdmissionHook constructs a new ClusterPoolValidatingAdmissionHook
 func NewClusterPoolValidatingAdmissionHook(decoder *admission.Decoder) *ClusterPoolValidatingAdmissionHook {
 	return &ClusterPoolValidatingAdmissionHook{
-		decoder: decoder,
+		decoder:              decoder,
+		validManagedDomains:  readManagedDomains(log.WithField("validating_webhook", "clusterpool")),
+		namespacesRestricted: os.Getenv(constants.WatchedNamespacesEnvVar) != "",
 	}
 }
 
@@ -174,10 +183,23 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 		}
 	}
 
+	if a.namespacesRestricted {
+		message := "ClusterPools cannot be created while the Hive controllers are restricted to the watchedNamespaces of the HiveConfig"
+		contextLogger.Info(message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusForbidden, Reason: metav1.StatusReasonForbidden,
+				Message: message,
+			},
+		}
+	}
+
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateClusterPoolManageDNS(specPath, newObject.Spec, a.validManagedDomains)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -238,6 +260,7 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateClusterPoolManageDNS(specPath, newObject.Spec, a.validManagedDomains)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +277,22 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+// validateClusterPoolManageDNS checks that a pool managing DNS uses one of the managed domains as its base domain, so
+// that the subdomains named after its clusters are direct children of the managed domain.
+func validateClusterPoolManageDNS(specPath *field.Path, spec hivev1.ClusterPoolSpec, validManagedDomains []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !spec.ManageDNS {
+		return allErrs
+	}
+	if spec.Platform.AWS == nil && spec.Platform.Azure == nil && spec.Platform.GCP == nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
+	}
+	for _, domain := range validManagedDomains {
+		if spec.BaseDomain == domain {
+			return allErrs
+		}
+	}
+	return append(allErrs, field.Invalid(specPath.Child("baseDomain"), spec.BaseDomain,
+		fmt.Sprintf("must be one of the managed domains for ClusterPools with manageDNS set to true (managed domains: %s)", strings.Join(validManagedDomains, ", "))))
+}
@@ -77,6 +77,8 @@ func TestClusterPoolValidate(t *testing.T) {
 		operation       admissionv1beta1.Operation
 		expectedAllowed bool
 		gvr             *metav1.GroupVersionResource
+		// namespacesRestricted restricts the Hive controllers to the watched namespaces of the HiveConfig
+		namespacesRestricted bool
 	}{
 		{
 			name:            "Test valid create",
@@ -102,6 +104,39 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test managed DNS with managed base domain",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.BaseDomain = "aaa.com"
+				pool.Spec.ManageDNS = true
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS with unmanaged base domain",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.BaseDomain = "pools.aaa.com"
+				pool.Spec.ManageDNS = true
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test managed DNS enabled on update with unmanaged base domain",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ManageDNS = true
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
@@ -216,6 +251,21 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name:                 "create with watched namespaces",
+			newObject:            validAWSClusterPool(),
+			operation:            admissionv1beta1.Create,
+			namespacesRestricted: true,
+			expectedAllowed:      false,
+		},
+		{
+			name:                 "update with watched namespaces",
+			newObject:            validAWSClusterPool(),
+			oldObject:            validAWSClusterPool(),
+			operation:            admissionv1beta1.Update,
+			namespacesRestricted: true,
+			expectedAllowed:      true,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
@@ -228,7 +278,9 @@ func TestClusterPoolValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := ClusterPoolValidatingAdmissionHook{
-				decoder: createDecoder(t),
+				decoder:              createDecoder(t),
+				validManagedDomains:  validTestManagedDomains,
+				namespacesRestricted: tc.namespacesRestricted,
 			}
 
 			if tc.gvr == nil {
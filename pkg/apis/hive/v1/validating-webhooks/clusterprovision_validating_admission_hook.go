@@ -257,8 +257,16 @@ func validateClusterProvisionUpdate(old, new *hivev1.ClusterProvision) field.Err
 	if old.Spec.AdminPasswordSecretRef != nil {
 		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.AdminPasswordSecretRef, old.Spec.AdminPasswordSecretRef, specPath.Child("adminPasswordSecretRef"))...)
 	}
+	if old.Spec.MetadataJSONSecretRef != nil {
+		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.MetadataJSONSecretRef, old.Spec.MetadataJSONSecretRef, specPath.Child("metadataJSONSecretRef"))...)
+	}
+	if old.Spec.TerraformStateSecretRef != nil {
+		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.TerraformStateSecretRef, old.Spec.TerraformStateSecretRef, specPath.Child("terraformStateSecretRef"))...)
+	}
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.PrevClusterID, old.Spec.PrevClusterID, specPath.Child("prevClusterID"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.PrevInfraID, old.Spec.PrevInfraID, specPath.Child("prevInfraID"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Region, old.Spec.Region, specPath.Child("region"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.PrevRegion, old.Spec.PrevRegion, specPath.Child("prevRegion"))...)
 	return allErrs
 }
 
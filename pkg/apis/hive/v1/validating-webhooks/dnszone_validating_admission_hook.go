@@ -2,15 +2,19 @@ package validatingwebhooks
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	dnsvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -25,12 +29,16 @@ const (
 
 // DNSZoneValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type DNSZoneValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder             *admission.Decoder
+	validManagedDomains []string
 }
 
 // NewDNSZoneValidatingAdmissionHook constructs a new DNSZoneValidatingAdmissionHook
 func NewDNSZoneValidatingAdmissionHook(decoder *admission.Decoder) *DNSZoneValidatingAdmissionHook {
-	return &DNSZoneValidatingAdmissionHook{decoder: decoder}
+	return &DNSZoneValidatingAdmissionHook{
+		decoder:             decoder,
+		validManagedDomains: readManagedDomains(log.WithField("validating_webhook", "dnszone")),
+	}
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
@@ -168,6 +176,31 @@ func (a *DNSZoneValidatingAdmissionHook) validateCreate(admissionSpec *admission
 		}
 	}
 
+	// Hive can only delegate a zone from a parent zone for which it was given credentials.
+	if newObject.Spec.LinkToParentDomain && !validateDomain(newObject.Spec.Zone, a.validManagedDomains) {
+		message := fmt.Sprintf("The zone must be a child of one of the managed domains for DNSZones with linkToParentDomain set to true (managed domains: %s)", strings.Join(a.validManagedDomains, ", "))
+		contextLogger.Info(message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
+	if errs := validateDNSZoneSpec(&newObject.Spec); len(errs) > 0 {
+		message := errs.ToAggregate().Error()
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -228,9 +261,163 @@ func (a *DNSZoneValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 		}
 	}
 
+	// A hosted zone cannot be made private or public once created.
+	if isAWSPrivateZone(oldObject) != isAWSPrivateZone(newObject) {
+		message := "DNSZone.Spec.AWS.PrivateZone cannot be added or removed"
+		contextLogger.Infof("Failed validation: %v", message)
+
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
+	if errs := validateDNSZoneSpec(&newObject.Spec); len(errs) > 0 {
+		message := errs.ToAggregate().Error()
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
 		Allowed: true,
 	}
 }
+
+// validateDNSZoneSpec validates the parts of the spec which may change after the DNSZone is created.
+func validateDNSZoneSpec(spec *hivev1.DNSZoneSpec) field.ErrorList {
+	allErrs := validateAWSPrivateZone(spec)
+	allErrs = append(allErrs, validateDNSRecords(spec.Records, field.NewPath("spec", "records"))...)
+	return allErrs
+}
+
+// validateDNSRecords validates the records maintained by Hive in the zone.
+func validateDNSRecords(records []hivev1.DNSRecord, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	types := map[string][]hivev1.DNSRecordType{}
+	for i, record := range records {
+		recordPath := fldPath.Index(i)
+		allErrs = append(allErrs, validateDNSRecordName(record.Name, recordPath.Child("name"))...)
+		for _, t := range types[record.Name] {
+			switch {
+			case t == record.Type:
+				allErrs = append(allErrs, field.Duplicate(recordPath, fmt.Sprintf("%s %s", record.Name, record.Type)))
+			case t == hivev1.DNSRecordTypeCNAME || record.Type == hivev1.DNSRecordTypeCNAME:
+				allErrs = append(allErrs, field.Invalid(recordPath.Child("name"), record.Name, "a CNAME record cannot share its name with other records"))
+			}
+		}
+		types[record.Name] = append(types[record.Name], record.Type)
+		if record.TTL < 0 {
+			allErrs = append(allErrs, field.Invalid(recordPath.Child("ttl"), record.TTL, "must not be negative"))
+		}
+		valuesPath := recordPath.Child("values")
+		if len(record.Values) == 0 {
+			allErrs = append(allErrs, field.Required(valuesPath, "must specify the values of the record"))
+		}
+		switch record.Type {
+		case hivev1.DNSRecordTypeA:
+			for j, value := range record.Values {
+				if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+					allErrs = append(allErrs, field.Invalid(valuesPath.Index(j), value, "must be an IPv4 address"))
+				}
+			}
+		case hivev1.DNSRecordTypeAAAA:
+			for j, value := range record.Values {
+				if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+					allErrs = append(allErrs, field.Invalid(valuesPath.Index(j), value, "must be an IPv6 address"))
+				}
+			}
+		case hivev1.DNSRecordTypeCNAME:
+			if record.Name == "@" {
+				allErrs = append(allErrs, field.Invalid(recordPath.Child("name"), record.Name, "the apex of the zone cannot be a CNAME record"))
+			}
+			if len(record.Values) > 1 {
+				allErrs = append(allErrs, field.TooMany(valuesPath, len(record.Values), 1))
+			}
+			for j, value := range record.Values {
+				for _, msg := range dnsvalidation.IsDNS1123Subdomain(strings.TrimSuffix(value, ".")) {
+					allErrs = append(allErrs, field.Invalid(valuesPath.Index(j), value, msg))
+				}
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(recordPath.Child("type"), record.Type, []string{string(hivev1.DNSRecordTypeA), string(hivev1.DNSRecordTypeAAAA), string(hivev1.DNSRecordTypeCNAME)}))
+		}
+	}
+	return allErrs
+}
+
+// installerRecordNames are the names, relative to the zone of the cluster, of the records created by the installer and
+// the cluster, which the records maintained by Hive would overwrite.
+var installerRecordNames = sets.NewString("api", "api-int", "*.apps")
+
+// validateDNSRecordName validates the name of a record relative to the zone, which is "@" for the apex of the zone and
+// may start with a wildcard label.
+func validateDNSRecordName(name string, fldPath *field.Path) field.ErrorList {
+	if name == "@" || name == "*" {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	if installerRecordNames.Has(strings.ToLower(name)) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "collides with a record created by the installer"))
+	}
+	for _, msg := range dnsvalidation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*.")) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	return allErrs
+}
+
+func isAWSPrivateZone(dnsZone *hivev1.DNSZone) bool {
+	return dnsZone.Spec.AWS != nil && dnsZone.Spec.AWS.PrivateZone != nil
+}
+
+// validateAWSPrivateZone validates the VPCs of an AWS private hosted zone.
+func validateAWSPrivateZone(spec *hivev1.DNSZoneSpec) field.ErrorList {
+	if spec.AWS == nil || spec.AWS.PrivateZone == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	privateZonePath := field.NewPath("spec", "aws", "privateZone")
+	// The parent domain cannot delegate to a zone which only resolves from its VPCs.
+	if spec.LinkToParentDomain {
+		allErrs = append(allErrs, field.Forbidden(privateZonePath, "private zones cannot be linked to the parent domain"))
+	}
+	vpcsPath := privateZonePath.Child("vpcs")
+	if len(spec.AWS.PrivateZone.VPCs) == 0 {
+		return append(allErrs, field.Required(vpcsPath, "private zones must be associated with at least one VPC"))
+	}
+	sameAccountVPC := false
+	vpcIDs := map[string]bool{}
+	for i, vpc := range spec.AWS.PrivateZone.VPCs {
+		vpcPath := vpcsPath.Index(i)
+		switch {
+		case vpc.VPCID == "":
+			allErrs = append(allErrs, field.Required(vpcPath.Child("vpcID"), "must specify the ID of the VPC"))
+		case vpcIDs[vpc.VPCID]:
+			allErrs = append(allErrs, field.Duplicate(vpcPath.Child("vpcID"), vpc.VPCID))
+		}
+		vpcIDs[vpc.VPCID] = true
+		if vpc.Region == "" {
+			allErrs = append(allErrs, field.Required(vpcPath.Child("region"), "must specify the region of the VPC"))
+		}
+		if vpc.AssumeRoleARN == "" {
+			sameAccountVPC = true
+		} else if _, err := arn.Parse(vpc.AssumeRoleARN); err != nil {
+			allErrs = append(allErrs, field.Invalid(vpcPath.Child("assumeRoleARN"), vpc.AssumeRoleARN, err.Error()))
+		}
+	}
+	// The zone is created with a VPC of the account of the credentials of the DNSZone.
+	if !sameAccountVPC {
+		allErrs = append(allErrs, field.Required(vpcsPath, "at least one VPC must belong to the account of the credentials, without assumeRoleARN"))
+	}
+	return allErrs
+}
@@ -46,6 +46,10 @@ func TestDNSZoneValidate(t *testing.T) {
 		name            string
 		newZoneStr      string
 		oldZoneStr      string
+		linkToParent    bool
+		newPrivateZone  *hivev1.AWSPrivateDNSZone
+		oldPrivateZone  *hivev1.AWSPrivateDNSZone
+		newRecords      []hivev1.DNSRecord
 		newObjectRaw    []byte
 		oldObjectRaw    []byte
 		operation       admissionv1beta1.Operation
@@ -70,6 +74,27 @@ func TestDNSZoneValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name:            "Test linked DNSZone.Spec.Zone under managed domain",
+			newZoneStr:      "bar.aaa.com",
+			linkToParent:    true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test linked DNSZone.Spec.Zone not under managed domain",
+			newZoneStr:      "this.is.a.valid.zone",
+			linkToParent:    true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test linked DNSZone.Spec.Zone same as managed domain",
+			newZoneStr:      "aaa.com",
+			linkToParent:    true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
@@ -104,6 +129,201 @@ func TestDNSZoneValidate(t *testing.T) {
 
 			expectedAllowed: true,
 		},
+		{
+			name:            "Test valid private zone",
+			newZoneStr:      "this.is.a.valid.zone",
+			newPrivateZone:  testPrivateZone(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test private zone without VPCs",
+			newZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs = nil
+				return pz
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private zone VPC without region",
+			newZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs[0].Region = ""
+				return pz
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private zone with duplicate VPCs",
+			newZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs[1].VPCID = pz.VPCs[0].VPCID
+				return pz
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private zone with invalid role ARN",
+			newZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs[1].AssumeRoleARN = "vpc-owner"
+				return pz
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private zone with only VPCs of other accounts",
+			newZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs = pz.VPCs[1:]
+				return pz
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test private zone linked to parent domain",
+			newZoneStr:      "bar.aaa.com",
+			linkToParent:    true,
+			newPrivateZone:  testPrivateZone(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private zone VPCs can be updated",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newPrivateZone: func() *hivev1.AWSPrivateDNSZone {
+				pz := testPrivateZone()
+				pz.VPCs = pz.VPCs[:1]
+				return pz
+			}(),
+			oldPrivateZone:  testPrivateZone(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test zone cannot be made private",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newPrivateZone:  testPrivateZone(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test private zone cannot be made public",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			oldPrivateZone:  testPrivateZone(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid records",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      testDNSRecords(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test records can be updated",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      testDNSRecords()[:1],
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test record with invalid name",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "my_app", Type: hivev1.DNSRecordTypeA, Values: []string{"10.0.0.2"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test A record with IPv6 address",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "app", Type: hivev1.DNSRecordTypeA, Values: []string{"fd00::1"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test AAAA record with IPv4 address",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "app", Type: hivev1.DNSRecordTypeAAAA, Values: []string{"10.0.0.2"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test record without values",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "app", Type: hivev1.DNSRecordTypeA}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test CNAME record with several values",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "app", Type: hivev1.DNSRecordTypeCNAME, Values: []string{"a.example.net", "b.example.net"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test CNAME record at zone apex",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "@", Type: hivev1.DNSRecordTypeCNAME, Values: []string{"lb.example.net"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test record colliding with the API record of the installer",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "api", Type: hivev1.DNSRecordTypeA, Values: []string{"10.0.0.1"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test record colliding with the ingress record of the cluster",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "*.Apps", Type: hivev1.DNSRecordTypeA, Values: []string{"10.0.0.1"}}),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test CNAME record sharing its name",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(hivev1.DNSRecord{Name: "*.apps-external", Type: hivev1.DNSRecordTypeCNAME, Values: []string{"lb.example.net"}}),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test duplicate records",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newRecords:      withTestRecord(testDNSRecords()[0]),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test that we don't validate deletes",
 			operation:       admissionv1beta1.Delete,
@@ -142,9 +362,12 @@ func TestDNSZoneValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := NewDNSZoneValidatingAdmissionHook(createDecoder(t))
+			data.validManagedDomains = validTestManagedDomains
 			newObject := &hivev1.DNSZone{
 				Spec: hivev1.DNSZoneSpec{
-					Zone: tc.newZoneStr,
+					Zone:               tc.newZoneStr,
+					LinkToParentDomain: tc.linkToParent,
+					Records:            tc.newRecords,
 				},
 			}
 			oldObject := &hivev1.DNSZone{
@@ -152,6 +375,12 @@ func TestDNSZoneValidate(t *testing.T) {
 					Zone: tc.oldZoneStr,
 				},
 			}
+			if tc.newPrivateZone != nil {
+				newObject.Spec.AWS = &hivev1.AWSDNSZoneSpec{PrivateZone: tc.newPrivateZone}
+			}
+			if tc.oldPrivateZone != nil {
+				oldObject.Spec.AWS = &hivev1.AWSDNSZoneSpec{PrivateZone: tc.oldPrivateZone}
+			}
 
 			if tc.newObjectRaw == nil {
 				tc.newObjectRaw, _ = json.Marshal(newObject)
@@ -188,3 +417,49 @@ func TestDNSZoneValidate(t *testing.T) {
 		})
 	}
 }
+
+func testPrivateZone() *hivev1.AWSPrivateDNSZone {
+	return &hivev1.AWSPrivateDNSZone{
+		VPCs: []hivev1.AWSDNSZoneVPC{
+			{
+				VPCID:  "vpc-1",
+				Region: "us-east-1",
+			},
+			{
+				VPCID:         "vpc-2",
+				Region:        "us-east-2",
+				AssumeRoleARN: "arn:aws:iam::123456789012:role/vpc-owner",
+			},
+		},
+	}
+}
+
+func testDNSRecords() []hivev1.DNSRecord {
+	return []hivev1.DNSRecord{
+		{
+			Name:   "*.apps-external",
+			Type:   hivev1.DNSRecordTypeA,
+			Values: []string{"10.0.0.1"},
+		},
+		{
+			Name:   "*.apps-external",
+			Type:   hivev1.DNSRecordTypeAAAA,
+			Values: []string{"fd00::1"},
+		},
+		{
+			Name:   "console",
+			Type:   hivev1.DNSRecordTypeCNAME,
+			TTL:    300,
+			Values: []string{"lb.example.net."},
+		},
+		{
+			Name:   "@",
+			Type:   hivev1.DNSRecordTypeA,
+			Values: []string{"10.0.0.1", "10.0.0.2"},
+		},
+	}
+}
+
+func withTestRecord(record hivev1.DNSRecord) []hivev1.DNSRecord {
+	return append(testDNSRecords(), record)
+}
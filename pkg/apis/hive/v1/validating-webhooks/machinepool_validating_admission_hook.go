@@ -225,6 +225,7 @@ func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Architecture, old.Spec.Architecture, specPath.Child("architecture"))...)
 	return allErrs
 }
 
@@ -318,6 +319,7 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 			allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("minReplicas"), spec.Autoscaling.MinReplicas, "minimum replicas must not be greater than maximum replicas"))
 		}
 	}
+	allErrs = append(allErrs, validateArchitecture(fldPath.Child("architecture"), spec.Architecture, false)...)
 	allErrs = append(allErrs, metavalidation.ValidateLabels(spec.Labels, fldPath.Child("labels"))...)
 	return allErrs
 }
@@ -165,6 +165,23 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "arm64 architecture",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "multi architecture",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureMulti
+				return pool
+			}(),
+		},
 		{
 			name: "zero replicas",
 			provision: func() *hivev1.MachinePool {
@@ -535,6 +552,15 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 			}(),
 			expectAllowed: true,
 		},
+		{
+			name: "architecture changed",
+			old:  testMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				return pool
+			}(),
+		},
 		{
 			name: "platform changed",
 			old:  testMachinePool(),
@@ -8,6 +8,7 @@ import (
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
@@ -158,6 +159,8 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec").Child("resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesFrom(newObject.Spec.ResourcesFrom, "", field.NewPath("spec", "resourcesFrom"))...)
+	allErrs = append(allErrs, validateClusterDeploymentSelection(&newObject.Spec, field.NewPath("spec"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
 
 	if len(allErrs) > 0 {
@@ -205,6 +208,8 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec", "resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesFrom(newObject.Spec.ResourcesFrom, "", field.NewPath("spec", "resourcesFrom"))...)
+	allErrs = append(allErrs, validateClusterDeploymentSelection(&newObject.Spec, field.NewPath("spec"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
 
 	if len(allErrs) > 0 {
@@ -222,3 +227,19 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+// validateClusterDeploymentSelection validates the fields of the SelectorSyncSet which select the ClusterDeployments
+// it applies to.
+func validateClusterDeploymentSelection(spec *hivev1.SelectorSyncSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := metav1validation.ValidateLabelSelector(&spec.ClusterDeploymentSelector, fldPath.Child("clusterDeploymentSelector"))
+	for i, ref := range spec.ExcludeClusterDeployments {
+		path := fldPath.Child("excludeClusterDeployments").Index(i)
+		if ref.Namespace == "" {
+			allErrs = append(allErrs, field.Required(path.Child("namespace"), "Namespace is required"))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "Name is required"))
+		}
+	}
+	return allErrs
+}
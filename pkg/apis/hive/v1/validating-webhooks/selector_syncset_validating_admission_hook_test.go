@@ -355,6 +355,65 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			selectorSyncSet: testSelectorSyncSetWithResources(`{"apiVersion": "authorization.openshift.io/v1", "kind": "SubjectAccessReview"}`),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid configmap resources source",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: testSelectorSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{
+				ConfigMapRef: &hivev1.ConfigMapReference{Name: "foo", Namespace: "anotherns"},
+			}),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test invalid configmap resources source no namespace",
+			operation:       admissionv1beta1.Update,
+			selectorSyncSet: testSelectorSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{ConfigMapRef: &hivev1.ConfigMapReference{Name: "foo"}}),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid negative ClusterDeploymentSelector",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ClusterDeploymentSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+					{Key: "environment", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"production"}},
+					{Key: "opt-out", Operator: metav1.LabelSelectorOpDoesNotExist},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid ClusterDeploymentSelector",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ClusterDeploymentSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+					{Key: "environment", Operator: metav1.LabelSelectorOpNotIn},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid excluded ClusterDeployment",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ExcludeClusterDeployments = []hivev1.SelectorSyncSetClusterDeploymentReference{{Namespace: "foo", Name: "bar"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid excluded ClusterDeployment no namespace",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ExcludeClusterDeployments = []hivev1.SelectorSyncSetClusterDeploymentReference{{Name: "bar"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -451,3 +510,9 @@ func testSelectorSyncSetWithResources(resources ...string) *hivev1.SelectorSyncS
 	}
 	return ss
 }
+
+func testSelectorSyncSetWithResourcesFrom(sources ...hivev1.SyncSetResourcesSource) *hivev1.SelectorSyncSet {
+	ss := testSelectorSyncSet()
+	ss.Spec.ResourcesFrom = sources
+	return ss
+}
@@ -3,6 +3,8 @@ package validatingwebhooks
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"regexp"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -43,6 +45,8 @@ var validPatchTypes = map[string]bool{
 
 var validPatchTypeSlice = []string{"json", "merge", "strategic"}
 
+var sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 var (
 	validResourceApplyModes = map[hivev1.SyncSetResourceApplyMode]bool{
 		hivev1.UpsertResourceApplyMode: true,
@@ -195,6 +199,7 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesFrom(newObject.Spec.ResourcesFrom, newObject.Namespace, field.NewPath("spec", "resourcesFrom"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
 
 	if len(allErrs) > 0 {
@@ -243,6 +248,7 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesFrom(newObject.Spec.ResourcesFrom, newObject.Namespace, field.NewPath("spec", "resourcesFrom"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
 
 	if len(allErrs) > 0 {
@@ -327,6 +333,45 @@ func validateSourceSecretInSyncSetNamespace(secrets []hivev1.SecretMapping, sync
 	return allErrs
 }
 
+// validateResourcesFrom validates the sources of the resources of a syncset. syncSetNS is the namespace of a SyncSet,
+// whose ConfigMaps must be in the same namespace, or empty for a SelectorSyncSet, whose ConfigMaps must have a
+// namespace.
+func validateResourcesFrom(sources []hivev1.SyncSetResourcesSource, syncSetNS string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, source := range sources {
+		path := fldPath.Index(i)
+		switch {
+		case source.ConfigMapRef != nil && source.URL != "":
+			allErrs = append(allErrs, field.Invalid(path, source, "only one of configMapRef and url may be set"))
+		case source.ConfigMapRef != nil:
+			ref := source.ConfigMapRef
+			if ref.Name == "" {
+				allErrs = append(allErrs, field.Required(path.Child("configMapRef", "name"), "Name is required"))
+			}
+			switch {
+			case syncSetNS == "" && ref.Namespace == "":
+				allErrs = append(allErrs, field.Required(path.Child("configMapRef", "namespace"), "Namespace is required"))
+			case syncSetNS != "" && ref.Namespace != "" && ref.Namespace != syncSetNS:
+				allErrs = append(allErrs, field.Invalid(path.Child("configMapRef", "namespace"), ref.Namespace,
+					"configmap reference must be in same namespace as SyncSet"))
+			}
+			if source.SHA256 != "" {
+				allErrs = append(allErrs, field.Forbidden(path.Child("sha256"), "sha256 is only allowed with url"))
+			}
+		case source.URL != "":
+			if u, err := url.Parse(source.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(path.Child("url"), source.URL, "must be an http or https URL"))
+			}
+			if !sha256Regexp.MatchString(source.SHA256) {
+				allErrs = append(allErrs, field.Invalid(path.Child("sha256"), source.SHA256, "must be a hex-encoded SHA-256 checksum"))
+			}
+		default:
+			allErrs = append(allErrs, field.Required(path, "one of configMapRef and url must be set"))
+		}
+	}
+	return allErrs
+}
+
 func validateSecretRef(ref hivev1.SecretReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(ref.Name) == 0 {
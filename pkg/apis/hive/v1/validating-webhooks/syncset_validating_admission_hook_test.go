@@ -15,7 +15,8 @@ import (
 )
 
 const (
-	syncSetNS = "test-namespace"
+	syncSetNS  = "test-namespace"
+	testSHA256 = "6d0a2b8b4d4e5d8e8c1a0b3f0a9e2c4d7f6b5a4c3d2e1f0a9b8c7d6e5f4a3b2c"
 )
 
 func TestSyncSetValidatingResource(t *testing.T) {
@@ -375,6 +376,60 @@ func TestSyncSetValidate(t *testing.T) {
 			syncSet:         testSyncSetWithResources(`{"apiVersion": "authorization.openshift.io/v1", "kind": "SubjectAccessReview"}`),
 			expectedAllowed: false,
 		},
+		{
+			name:            "Test valid configmap resources source",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{ConfigMapRef: &hivev1.ConfigMapReference{Name: "foo"}}),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid configmap resources source not in SyncSet namespace",
+			operation: admissionv1beta1.Create,
+			syncSet: testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{
+				ConfigMapRef: &hivev1.ConfigMapReference{Name: "foo", Namespace: "anotherns"},
+			}),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test invalid configmap resources source no name",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{ConfigMapRef: &hivev1.ConfigMapReference{}}),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid url resources source",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{URL: "https://bucket.example.com/resources.yaml", SHA256: testSHA256}),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test invalid url resources source no sha256",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{URL: "https://bucket.example.com/resources.yaml"}),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test invalid url resources source not http",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{URL: "s3://bucket/resources.yaml", SHA256: testSHA256}),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resources source with configmap and url",
+			operation: admissionv1beta1.Create,
+			syncSet: testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{
+				ConfigMapRef: &hivev1.ConfigMapReference{Name: "foo"},
+				URL:          "https://bucket.example.com/resources.yaml",
+				SHA256:       testSHA256,
+			}),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test invalid empty resources source",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testSyncSetWithResourcesFrom(hivev1.SyncSetResourcesSource{}),
+			expectedAllowed: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -471,3 +526,9 @@ func testSyncSetWithResources(resources ...string) *hivev1.SyncSet {
 	}
 	return ss
 }
+
+func testSyncSetWithResourcesFrom(sources ...hivev1.SyncSetResourcesSource) *hivev1.SyncSet {
+	ss := testSyncSet()
+	ss.Spec.ResourcesFrom = sources
+	return ss
+}
@@ -0,0 +1,304 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/tenancy"
+)
+
+const (
+	tenantQuotaGroup   = "hive.openshift.io"
+	tenantQuotaVersion = "v1"
+)
+
+// TenantQuotaValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+// It enforces the quotas of the HiveTenants in the namespaces of new and updated ClusterDeployments and MachinePools.
+type TenantQuotaValidatingAdmissionHook struct {
+	decoder *admission.Decoder
+	// tenants reads the HiveTenants from an informer cache, so that requests in namespaces without a HiveTenant do
+	// not make any request to the API server.
+	tenants client.Reader
+	// client reads and charges the usage in the status of the HiveTenants of the namespace of a request.
+	client client.Client
+}
+
+// NewTenantQuotaValidatingAdmissionHook constructs a new TenantQuotaValidatingAdmissionHook
+func NewTenantQuotaValidatingAdmissionHook(decoder *admission.Decoder) *TenantQuotaValidatingAdmissionHook {
+	return &TenantQuotaValidatingAdmissionHook{decoder: decoder}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/tenantquotavalidators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *TenantQuotaValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "tenantquotavalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the HiveTenant CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "tenantquotavalidators",
+		},
+		"tenantquotavalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+// The webhook needs a cache of the HiveTenants and a client to charge the requests to their usage.
+func (a *TenantQuotaValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "tenantquotavalidator",
+	}).Info("Initializing validation REST resource")
+
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(kubeClientConfig, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return err
+	}
+	c, err := client.New(kubeClientConfig, client.Options{Scheme: scheme, Mapper: mapper})
+	if err != nil {
+		return err
+	}
+	tenantCache, err := cache.New(kubeClientConfig, cache.Options{Scheme: scheme, Mapper: mapper})
+	if err != nil {
+		return err
+	}
+	if _, err := tenantCache.GetInformer(context.Background(), &hivev1.HiveTenant{}); err != nil {
+		return err
+	}
+	go func() {
+		if err := tenantCache.Start(stopCh); err != nil {
+			log.WithError(err).Error("could not start HiveTenant cache")
+		}
+	}()
+	if !tenantCache.WaitForCacheSync(stopCh) {
+		return errors.New("could not sync HiveTenant cache")
+	}
+	a.tenants = tenantCache
+	a.client = c
+	return nil
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *TenantQuotaValidatingAdmissionHook) Validate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"namespace": request.Namespace,
+		"method":    "Validate",
+	})
+
+	if request.Resource.Group != tenantQuotaGroup || request.Resource.Version != tenantQuotaVersion {
+		logger.Info("Skipping validation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	switch {
+	case request.Resource.Resource == "clusterdeployments" && request.Operation == admissionv1beta1.Create:
+		return a.validateClusterDeploymentCreate(request, logger)
+	case request.Resource.Resource == "machinepools" &&
+		(request.Operation == admissionv1beta1.Create || request.Operation == admissionv1beta1.Update):
+		return a.validateMachinePool(request, logger)
+	default:
+		logger.Info("Skipping validation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+}
+
+func (a *TenantQuotaValidatingAdmissionHook) validateClusterDeploymentCreate(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	cd := &hivev1.ClusterDeployment{}
+	if err := a.decoder.DecodeRaw(request.Object, cd); err != nil {
+		logger.WithError(err).Error("Failed unmarshaling Object")
+		return badRequestResponse(err.Error())
+	}
+
+	installing := tenancy.IsInstalling(cd)
+	applies := func(tenant *hivev1.HiveTenant) bool {
+		return tenant.Spec.MaxClusterDeployments != nil || tenant.Spec.MaxConcurrentInstalls != nil
+	}
+	return a.charge(request, logger, applies, func(tenant *hivev1.HiveTenant) string {
+		usage := &tenant.Status
+		if max := tenant.Spec.MaxClusterDeployments; max != nil && usage.ClusterDeployments+1 > *max {
+			return fmt.Sprintf("the namespace already has %d of a maximum of %d ClusterDeployments", usage.ClusterDeployments, *max)
+		}
+		if max := tenant.Spec.MaxConcurrentInstalls; max != nil && installing && usage.ConcurrentInstalls+1 > *max {
+			return fmt.Sprintf("the namespace already has %d of a maximum of %d concurrent installs", usage.ConcurrentInstalls, *max)
+		}
+		usage.ClusterDeployments++
+		if installing {
+			usage.ConcurrentInstalls++
+		}
+		return ""
+	})
+}
+
+func (a *TenantQuotaValidatingAdmissionHook) validateMachinePool(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	pool := &hivev1.MachinePool{}
+	if err := a.decoder.DecodeRaw(request.Object, pool); err != nil {
+		logger.WithError(err).Error("Failed unmarshaling Object")
+		return badRequestResponse(err.Error())
+	}
+	var oldPool *hivev1.MachinePool
+	if request.Operation == admissionv1beta1.Update {
+		oldPool = &hivev1.MachinePool{}
+		if err := a.decoder.DecodeRaw(request.OldObject, oldPool); err != nil {
+			logger.WithError(err).Error("Failed unmarshaling OldObject")
+			return badRequestResponse(err.Error())
+		}
+	}
+
+	applies := func(tenant *hivev1.HiveTenant) bool {
+		if tenant.Spec.MaxVCPUs == nil {
+			return false
+		}
+		if oldPool == nil {
+			return true
+		}
+		// Updates that do not add vCPUs, such as adding a finalizer, are always allowed so that MachinePools
+		// are not stuck when the quota is lowered.
+		vcpus, unknownInstanceType := tenancy.MachinePoolVCPUs(pool, tenant.Spec.InstanceTypeVCPUs)
+		oldVCPUs, oldUnknownInstanceType := tenancy.MachinePoolVCPUs(oldPool, tenant.Spec.InstanceTypeVCPUs)
+		return unknownInstanceType != oldUnknownInstanceType ||
+			vcpus > oldVCPUs ||
+			tenancy.MachinePoolMachines(pool) > tenancy.MachinePoolMachines(oldPool)
+	}
+	return a.charge(request, logger, applies, func(tenant *hivev1.HiveTenant) string {
+		max := tenant.Spec.MaxVCPUs
+		vcpus, unknownInstanceType := tenancy.MachinePoolVCPUs(pool, tenant.Spec.InstanceTypeVCPUs)
+		if unknownInstanceType != "" {
+			return fmt.Sprintf("the number of vCPUs of instance type %q is not known; it must be added to the instanceTypeVCPUs of the HiveTenant", unknownInstanceType)
+		}
+		var oldVCPUs int32
+		if oldPool != nil {
+			oldVCPUs, _ = tenancy.MachinePoolVCPUs(oldPool, tenant.Spec.InstanceTypeVCPUs)
+		}
+		// The usage includes the MachinePool being updated, so only the vCPUs being added count against the quota.
+		usage := &tenant.Status
+		if usage.VCPUs-oldVCPUs+vcpus > *max {
+			return fmt.Sprintf("the MachinePool needs %d vCPUs but the namespace already has %d of a maximum of %d vCPUs", vcpus, usage.VCPUs-oldVCPUs, *max)
+		}
+		usage.VCPUs += vcpus - oldVCPUs
+		return ""
+	})
+}
+
+// charge checks the request against the quotas of each of the HiveTenants in the namespace whose quotas apply to the
+// request, and adds the resources of the request to the usage in the status of the HiveTenants. The check function
+// returns the reason a quota would be exceeded, or adds the resources to the usage.
+// The usage is updated with the resource version of the HiveTenant, so that concurrent requests, including those
+// admitted by other replicas of the webhook, are each checked against the usage charged by the others. Requests for
+// different HiveTenants never wait for each other. The HiveTenant controller recounts the usage from the resources in
+// the namespace, which releases the usage charged for requests that were admitted but then not persisted.
+// Dry run requests are checked against the usage without charging it, as the webhook declares that it has no side
+// effects on dry runs.
+func (a *TenantQuotaValidatingAdmissionHook) charge(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger, applies func(tenant *hivev1.HiveTenant) bool, check func(tenant *hivev1.HiveTenant) string) *admissionv1beta1.AdmissionResponse {
+	namespace := request.Namespace
+	dryRun := request.DryRun != nil && *request.DryRun
+	tenants := &hivev1.HiveTenantList{}
+	if err := a.tenants.List(context.Background(), tenants, client.InNamespace(namespace)); err != nil {
+		logger.WithError(err).Error("could not list HiveTenants")
+		return internalErrorResponse(err.Error())
+	}
+	for i := range tenants.Items {
+		if !applies(&tenants.Items[i]) {
+			continue
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: tenants.Items[i].Name}
+		var reason string
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			tenant := &hivev1.HiveTenant{}
+			if err := a.client.Get(context.Background(), key, tenant); err != nil {
+				return err
+			}
+			if tenant.Status.ObservedGeneration != tenant.Generation {
+				return fmt.Errorf("the usage of HiveTenant %s has not been counted yet", tenant.Name)
+			}
+			origStatus := tenant.Status.DeepCopy()
+			if reason = check(tenant); reason != "" || dryRun || reflect.DeepEqual(origStatus, &tenant.Status) {
+				return nil
+			}
+			return a.client.Status().Update(context.Background(), tenant)
+		})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			logger.WithError(err).WithField("tenant", key.Name).Error("could not charge the usage of the HiveTenant")
+			return internalErrorResponse(err.Error())
+		case reason != "":
+			return quotaExceededResponse(logger, key.Name, reason)
+		}
+	}
+
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+func quotaExceededResponse(logger log.FieldLogger, tenantName, reason string) *admissionv1beta1.AdmissionResponse {
+	message := fmt.Sprintf("quota of HiveTenant %s exceeded: %s", tenantName, reason)
+	logger.Info(message)
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusForbidden, Reason: metav1.StatusReasonForbidden,
+			Message: message,
+		},
+	}
+}
+
+func badRequestResponse(message string) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+			Message: message,
+		},
+	}
+}
+
+func internalErrorResponse(message string) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+			Message: message,
+		},
+	}
+}
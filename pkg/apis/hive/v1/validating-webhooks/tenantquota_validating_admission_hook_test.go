@@ -0,0 +1,334 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+)
+
+const tenantQuotaTestNamespace = "tenant-namespace"
+
+func testTenant(opts ...func(*hivev1.HiveTenant)) *hivev1.HiveTenant {
+	tenant := &hivev1.HiveTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tenantQuotaTestNamespace,
+			Name:      "test-tenant",
+		},
+		Spec: hivev1.HiveTenantSpec{
+			InstanceTypeVCPUs: map[string]int32{"m5.xlarge": 4},
+		},
+	}
+	for _, opt := range opts {
+		opt(tenant)
+	}
+	return tenant
+}
+
+func testTenantQuotaClusterDeployment(name string, installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tenantQuotaTestNamespace,
+			Name:      name,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: installed,
+		},
+	}
+}
+
+func testTenantQuotaMachinePool(name, instanceType string, replicas int64) *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tenantQuotaTestNamespace,
+			Name:      name,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			Replicas: pointer.Int64Ptr(replicas),
+			Platform: hivev1.MachinePoolPlatform{
+				AWS: &hivev1aws.MachinePoolPlatform{InstanceType: instanceType},
+			},
+		},
+	}
+}
+
+func TestTenantQuotaValidatingAdmissionHook(t *testing.T) {
+	withMaxClusterDeployments := func(max int32) func(*hivev1.HiveTenant) {
+		return func(tenant *hivev1.HiveTenant) { tenant.Spec.MaxClusterDeployments = pointer.Int32Ptr(max) }
+	}
+	withMaxConcurrentInstalls := func(max int32) func(*hivev1.HiveTenant) {
+		return func(tenant *hivev1.HiveTenant) { tenant.Spec.MaxConcurrentInstalls = pointer.Int32Ptr(max) }
+	}
+	withMaxVCPUs := func(max int32) func(*hivev1.HiveTenant) {
+		return func(tenant *hivev1.HiveTenant) { tenant.Spec.MaxVCPUs = pointer.Int32Ptr(max) }
+	}
+	withUsage := func(usage hivev1.HiveTenantStatus) func(*hivev1.HiveTenant) {
+		return func(tenant *hivev1.HiveTenant) { tenant.Status = usage }
+	}
+	cases := []struct {
+		name           string
+		tenant         *hivev1.HiveTenant
+		resource       string
+		operation      admissionv1beta1.Operation
+		object         runtime.Object
+		oldObject      runtime.Object
+		dryRun         bool
+		expectAllowed  bool
+		expectedStatus *hivev1.HiveTenantStatus
+	}{
+		{
+			name:          "no tenant",
+			resource:      "clusterdeployments",
+			operation:     admissionv1beta1.Create,
+			object:        testTenantQuotaClusterDeployment("new", false),
+			expectAllowed: true,
+		},
+		{
+			name:           "cluster deployments under quota",
+			tenant:         testTenant(withMaxClusterDeployments(2), withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", false),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 2, ConcurrentInstalls: 1},
+		},
+		{
+			name:           "dry run not charged",
+			tenant:         testTenant(withMaxClusterDeployments(2), withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", false),
+			dryRun:         true,
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 1},
+		},
+		{
+			name:           "dry run over quota",
+			tenant:         testTenant(withMaxClusterDeployments(1), withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", false),
+			dryRun:         true,
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 1},
+		},
+		{
+			name:           "cluster deployments over quota",
+			tenant:         testTenant(withMaxClusterDeployments(1), withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", false),
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 1},
+		},
+		{
+			name: "concurrent installs over quota",
+			tenant: testTenant(withMaxConcurrentInstalls(1),
+				withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1, ConcurrentInstalls: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", false),
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 1, ConcurrentInstalls: 1},
+		},
+		{
+			name: "concurrent installs not used by adopted cluster",
+			tenant: testTenant(withMaxConcurrentInstalls(1),
+				withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 1, ConcurrentInstalls: 1})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaClusterDeployment("new", true),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 2, ConcurrentInstalls: 1},
+		},
+		{
+			name:           "cluster deployment update",
+			tenant:         testTenant(withMaxClusterDeployments(1), withUsage(hivev1.HiveTenantStatus{ClusterDeployments: 2})),
+			resource:       "clusterdeployments",
+			operation:      admissionv1beta1.Update,
+			object:         testTenantQuotaClusterDeployment("existing", true),
+			oldObject:      testTenantQuotaClusterDeployment("existing", true),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{ClusterDeployments: 2},
+		},
+		{
+			name: "usage not counted yet",
+			tenant: testTenant(withMaxClusterDeployments(2), func(tenant *hivev1.HiveTenant) {
+				tenant.Generation = 2
+				tenant.Status.ObservedGeneration = 1
+			}),
+			resource:  "clusterdeployments",
+			operation: admissionv1beta1.Create,
+			object:    testTenantQuotaClusterDeployment("new", false),
+		},
+		{
+			name:           "vcpus under quota",
+			tenant:         testTenant(withMaxVCPUs(24), withUsage(hivev1.HiveTenantStatus{VCPUs: 12})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaMachinePool("new", "m5.xlarge", 3),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{VCPUs: 24},
+		},
+		{
+			name:           "vcpus over quota",
+			tenant:         testTenant(withMaxVCPUs(20), withUsage(hivev1.HiveTenantStatus{VCPUs: 12})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaMachinePool("new", "m5.xlarge", 3),
+			expectedStatus: &hivev1.HiveTenantStatus{VCPUs: 12},
+		},
+		{
+			name:      "unknown instance type",
+			tenant:    testTenant(withMaxVCPUs(100)),
+			resource:  "machinepools",
+			operation: admissionv1beta1.Create,
+			object:    testTenantQuotaMachinePool("new", "m5.metal", 1),
+		},
+		{
+			name:           "unknown instance type without vcpu quota",
+			tenant:         testTenant(withMaxClusterDeployments(1)),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Create,
+			object:         testTenantQuotaMachinePool("new", "m5.metal", 1),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{},
+		},
+		{
+			name:           "scale up under quota",
+			tenant:         testTenant(withMaxVCPUs(20), withUsage(hivev1.HiveTenantStatus{VCPUs: 12})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Update,
+			object:         testTenantQuotaMachinePool("existing", "m5.xlarge", 5),
+			oldObject:      testTenantQuotaMachinePool("existing", "m5.xlarge", 3),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{VCPUs: 20},
+		},
+		{
+			name:           "scale up over quota",
+			tenant:         testTenant(withMaxVCPUs(20), withUsage(hivev1.HiveTenantStatus{VCPUs: 12})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Update,
+			object:         testTenantQuotaMachinePool("existing", "m5.xlarge", 6),
+			oldObject:      testTenantQuotaMachinePool("existing", "m5.xlarge", 3),
+			expectedStatus: &hivev1.HiveTenantStatus{VCPUs: 12},
+		},
+		{
+			name:           "update without added vcpus when over quota",
+			tenant:         testTenant(withMaxVCPUs(4), withUsage(hivev1.HiveTenantStatus{VCPUs: 12})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Update,
+			object:         testTenantQuotaMachinePool("existing", "m5.xlarge", 3),
+			oldObject:      testTenantQuotaMachinePool("existing", "m5.xlarge", 3),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{VCPUs: 12},
+		},
+		{
+			name: "update with unchanged unknown instance type",
+			tenant: testTenant(withMaxVCPUs(4),
+				withUsage(hivev1.HiveTenantStatus{UnknownInstanceTypes: []string{"m5.metal"}})),
+			resource:       "machinepools",
+			operation:      admissionv1beta1.Update,
+			object:         testTenantQuotaMachinePool("existing", "m5.metal", 3),
+			oldObject:      testTenantQuotaMachinePool("existing", "m5.metal", 3),
+			expectAllowed:  true,
+			expectedStatus: &hivev1.HiveTenantStatus{UnknownInstanceTypes: []string{"m5.metal"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			var existing []runtime.Object
+			if tc.tenant != nil {
+				existing = append(existing, tc.tenant)
+			}
+			fakeClient := fake.NewFakeClientWithScheme(scheme, existing...)
+			hook := NewTenantQuotaValidatingAdmissionHook(createDecoder(t))
+			hook.tenants = fakeClient
+			hook.client = fakeClient
+			objectAsJSON, err := json.Marshal(tc.object)
+			require.NoError(t, err, "unexpected error marshalling object")
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    tenantQuotaGroup,
+					Version:  tenantQuotaVersion,
+					Resource: tc.resource,
+				},
+				Operation: tc.operation,
+				Namespace: tenantQuotaTestNamespace,
+				Object:    runtime.RawExtension{Raw: objectAsJSON},
+				DryRun:    &tc.dryRun,
+			}
+			if tc.oldObject != nil {
+				oldObjectAsJSON, err := json.Marshal(tc.oldObject)
+				require.NoError(t, err, "unexpected error marshalling old object")
+				request.OldObject = runtime.RawExtension{Raw: oldObjectAsJSON}
+			}
+			response := hook.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %v", response.Result)
+			if tc.expectedStatus != nil {
+				tenant := &hivev1.HiveTenant{}
+				require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: tenantQuotaTestNamespace, Name: tc.tenant.Name}, tenant))
+				assert.Equal(t, *tc.expectedStatus, tenant.Status, "unexpected usage charged to the tenant")
+			}
+		})
+	}
+}
+
+func TestTenantQuotaConcurrentCharges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	fakeClient := fake.NewFakeClientWithScheme(scheme, testTenant(func(tenant *hivev1.HiveTenant) {
+		tenant.Spec.MaxClusterDeployments = pointer.Int32Ptr(1)
+	}))
+	hook := NewTenantQuotaValidatingAdmissionHook(createDecoder(t))
+	hook.tenants = fakeClient
+	// Another replica of the webhook charges a request between the read and the update of the usage, so the update
+	// conflicts and the request is checked again against the usage charged by the other replica.
+	hook.client = &chargeOnFirstGetClient{Client: fakeClient}
+
+	objectAsJSON, err := json.Marshal(testTenantQuotaClusterDeployment("new", true))
+	require.NoError(t, err, "unexpected error marshalling object")
+	response := hook.Validate(&admissionv1beta1.AdmissionRequest{
+		Resource:  metav1.GroupVersionResource{Group: tenantQuotaGroup, Version: tenantQuotaVersion, Resource: "clusterdeployments"},
+		Operation: admissionv1beta1.Create,
+		Namespace: tenantQuotaTestNamespace,
+		Object:    runtime.RawExtension{Raw: objectAsJSON},
+	})
+	assert.False(t, response.Allowed, "expected the request to be rejected")
+
+	tenant := &hivev1.HiveTenant{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: tenantQuotaTestNamespace, Name: "test-tenant"}, tenant))
+	assert.Equal(t, int32(1), tenant.Status.ClusterDeployments, "unexpected usage charged to the tenant")
+}
+
+// chargeOnFirstGetClient charges a ClusterDeployment to the usage of the HiveTenant right after the first Get of the
+// HiveTenant, as another replica of the webhook would.
+type chargeOnFirstGetClient struct {
+	client.Client
+	charged bool
+}
+
+func (c *chargeOnFirstGetClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if err := c.Client.Get(ctx, key, obj); err != nil || c.charged {
+		return err
+	}
+	c.charged = true
+	other := &hivev1.HiveTenant{}
+	if err := c.Client.Get(ctx, key, other); err != nil {
+		return err
+	}
+	other.Status.ClusterDeployments++
+	return c.Client.Status().Update(ctx, other)
+}
@@ -30,6 +30,11 @@ type Platform struct {
 	// Cluster is the name of the cluster virtual machines will be cloned into.
 	Cluster string `json:"cluster,omitempty"`
 
+	// ClusterOSImage is the URL of the OVA used to boot the machines of the cluster, overriding the RHCOS OVA of
+	// the release.
+	// +optional
+	ClusterOSImage string `json:"clusterOSImage,omitempty"`
+
 	// Network specifies the name of the network to be used by the cluster.
 	Network string `json:"network,omitempty"`
 }
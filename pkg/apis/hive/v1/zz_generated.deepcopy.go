@@ -27,6 +27,11 @@ func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -49,6 +54,16 @@ func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 		*out = make([]AWSResourceTag, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateZone != nil {
+		in, out := &in.PrivateZone, &out.PrivateZone
+		*out = new(AWSPrivateDNSZone)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -70,6 +85,11 @@ func (in *AWSDNSZoneStatus) DeepCopyInto(out *AWSDNSZoneStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AssociatedVPCs != nil {
+		in, out := &in.AssociatedVPCs, &out.AssociatedVPCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -83,6 +103,43 @@ func (in *AWSDNSZoneStatus) DeepCopy() *AWSDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDNSZoneVPC) DeepCopyInto(out *AWSDNSZoneVPC) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDNSZoneVPC.
+func (in *AWSDNSZoneVPC) DeepCopy() *AWSDNSZoneVPC {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDNSZoneVPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateDNSZone) DeepCopyInto(out *AWSPrivateDNSZone) {
+	*out = *in
+	if in.VPCs != nil {
+		in, out := &in.VPCs, &out.VPCs
+		*out = make([]AWSDNSZoneVPC, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateDNSZone.
+func (in *AWSPrivateDNSZone) DeepCopy() *AWSPrivateDNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateDNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSResourceTag) DeepCopyInto(out *AWSResourceTag) {
 	*out = *in
@@ -319,6 +376,83 @@ func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerConfig) DeepCopyInto(out *ClusterAutoscalerConfig) {
+	*out = *in
+	if in.MaxNodesTotal != nil {
+		in, out := &in.MaxNodesTotal, &out.MaxNodesTotal
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(ClusterAutoscalerScaleDownConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPodGracePeriod != nil {
+		in, out := &in.MaxPodGracePeriod, &out.MaxPodGracePeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodPriorityThreshold != nil {
+		in, out := &in.PodPriorityThreshold, &out.PodPriorityThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerConfig.
+func (in *ClusterAutoscalerConfig) DeepCopy() *ClusterAutoscalerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerScaleDownConfig) DeepCopyInto(out *ClusterAutoscalerScaleDownConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DelayAfterAdd != nil {
+		in, out := &in.DelayAfterAdd, &out.DelayAfterAdd
+		*out = new(string)
+		**out = **in
+	}
+	if in.DelayAfterDelete != nil {
+		in, out := &in.DelayAfterDelete, &out.DelayAfterDelete
+		*out = new(string)
+		**out = **in
+	}
+	if in.DelayAfterFailure != nil {
+		in, out := &in.DelayAfterFailure, &out.DelayAfterFailure
+		*out = new(string)
+		**out = **in
+	}
+	if in.UnneededTime != nil {
+		in, out := &in.UnneededTime, &out.UnneededTime
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerScaleDownConfig.
+func (in *ClusterAutoscalerScaleDownConfig) DeepCopy() *ClusterAutoscalerScaleDownConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerScaleDownConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
@@ -452,6 +586,32 @@ func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCostEstimate) DeepCopyInto(out *ClusterCostEstimate) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnpricedInstanceTypes != nil {
+		in, out := &in.UnpricedInstanceTypes, &out.UnpricedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCostEstimate.
+func (in *ClusterCostEstimate) DeepCopy() *ClusterCostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
@@ -540,6 +700,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.DeprovisionCredentialsSecretRef != nil {
+		in, out := &in.DeprovisionCredentialsSecretRef, &out.DeprovisionCredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
@@ -556,7 +721,7 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	if in.ClusterMetadata != nil {
 		in, out := &in.ClusterMetadata, &out.ClusterMetadata
 		*out = new(ClusterMetadata)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Provisioning != nil {
 		in, out := &in.Provisioning, &out.Provisioning
@@ -578,6 +743,23 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ClusterAutoscaler != nil {
+		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
+		*out = new(ClusterAutoscalerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostInstallChecks != nil {
+		in, out := &in.PostInstallChecks, &out.PostInstallChecks
+		*out = make([]PostInstallCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]ClusterHook, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -625,6 +807,30 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(ClusterCostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlatformStatus != nil {
+		in, out := &in.PlatformStatus, &out.PlatformStatus
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallerStages != nil {
+		in, out := &in.InstallerStages, &out.InstallerStages
+		*out = make([]InstallerStageTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstallAttempts != nil {
+		in, out := &in.InstallAttempts, &out.InstallAttempts
+		*out = make([]InstallAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -803,6 +1009,23 @@ func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHook) DeepCopyInto(out *ClusterHook) {
+	*out = *in
+	out.PodTemplateRef = in.PodTemplateRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHook.
+func (in *ClusterHook) DeepCopy() *ClusterHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
 	*out = *in
@@ -943,6 +1166,16 @@ func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
 	*out = *in
 	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
 	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
+	if in.MetadataJSONSecretRef != nil {
+		in, out := &in.MetadataJSONSecretRef, &out.MetadataJSONSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TerraformStateSecretRef != nil {
+		in, out := &in.TerraformStateSecretRef, &out.TerraformStateSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -956,6 +1189,100 @@ func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperationLog) DeepCopyInto(out *ClusterOperationLog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationLog.
+func (in *ClusterOperationLog) DeepCopy() *ClusterOperationLog {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperationLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperationLog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperationLogClusterDeploymentReference) DeepCopyInto(out *ClusterOperationLogClusterDeploymentReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationLogClusterDeploymentReference.
+func (in *ClusterOperationLogClusterDeploymentReference) DeepCopy() *ClusterOperationLogClusterDeploymentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperationLogClusterDeploymentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperationLogList) DeepCopyInto(out *ClusterOperationLogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOperationLog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationLogList.
+func (in *ClusterOperationLogList) DeepCopy() *ClusterOperationLogList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperationLogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperationLogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperationLogSpec) DeepCopyInto(out *ClusterOperationLogSpec) {
+	*out = *in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationLogSpec.
+func (in *ClusterOperationLogSpec) DeepCopy() *ClusterOperationLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperationLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
 	*out = *in
@@ -1142,6 +1469,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(ClusterPoolClaimLifetime)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConsumerGroups != nil {
+		in, out := &in.ConsumerGroups, &out.ConsumerGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1292,6 +1624,16 @@ func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.MetadataJSONSecretRef != nil {
+		in, out := &in.MetadataJSONSecretRef, &out.MetadataJSONSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TerraformStateSecretRef != nil {
+		in, out := &in.TerraformStateSecretRef, &out.TerraformStateSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.PrevClusterID != nil {
 		in, out := &in.PrevClusterID, &out.PrevClusterID
 		*out = new(string)
@@ -1302,6 +1644,28 @@ func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrevRegion != nil {
+		in, out := &in.PrevRegion, &out.PrevRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.PlatformStatus != nil {
+		in, out := &in.PlatformStatus, &out.PlatformStatus
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallerStages != nil {
+		in, out := &in.InstallerStages, &out.InstallerStages
+		*out = make([]InstallerStageTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InfrastructureCreated != nil {
+		in, out := &in.InfrastructureCreated, &out.InfrastructureCreated
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1529,6 +1893,10 @@ func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadyTimestamp != nil {
+		in, out := &in.ReadyTimestamp, &out.ReadyTimestamp
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1543,25 +1911,225 @@ func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
-func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgrade.
+func (in *ClusterUpgrade) DeepCopy() *ClusterUpgrade {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneAdditionalCertificate)
+	out := new(ClusterUpgrade)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
-	*out = *in
-	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeClusterStatus) DeepCopyInto(out *ClusterUpgradeClusterStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeClusterStatus.
+func (in *ClusterUpgradeClusterStatus) DeepCopy() *ClusterUpgradeClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCondition) DeepCopyInto(out *ClusterUpgradeCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCondition.
+func (in *ClusterUpgradeCondition) DeepCopy() *ClusterUpgradeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeList) DeepCopyInto(out *ClusterUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeList.
+func (in *ClusterUpgradeList) DeepCopy() *ClusterUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeRelease) DeepCopyInto(out *ClusterUpgradeRelease) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeRelease.
+func (in *ClusterUpgradeRelease) DeepCopy() *ClusterUpgradeRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeSpec) DeepCopyInto(out *ClusterUpgradeSpec) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	out.DesiredUpdate = in.DesiredUpdate
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeSpec.
+func (in *ClusterUpgradeSpec) DeepCopy() *ClusterUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterUpgradeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
+func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneAdditionalCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+	*out = *in
+	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
 	return
 }
 
@@ -1629,6 +2197,11 @@ func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.WatchRemoteCluster != nil {
+		in, out := &in.WatchRemoteCluster, &out.WatchRemoteCluster
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1690,6 +2263,27 @@ func (in *ControllersConfig) DeepCopy() *ControllersConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecord.
+func (in *DNSRecord) DeepCopy() *DNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSZone) DeepCopyInto(out *DNSZone) {
 	*out = *in
@@ -1787,6 +2381,13 @@ func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
 		*out = new(AzureDNSZoneSpec)
 		**out = **in
 	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]DNSRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1812,6 +2413,15 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DelegatedNameServers != nil {
+		in, out := &in.DelegatedNameServers, &out.DelegatedNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDelegationCheckTimestamp != nil {
+		in, out := &in.LastDelegationCheckTimestamp, &out.LastDelegationCheckTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(AWSDNSZoneStatus)
@@ -1834,6 +2444,13 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SyncedRecords != nil {
+		in, out := &in.SyncedRecords, &out.SyncedRecords
+		*out = make([]DNSRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1872,6 +2489,13 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.FatalFailures != nil {
+		in, out := &in.FatalFailures, &out.FatalFailures
+		*out = make([]FatalProvisionFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1885,6 +2509,27 @@ func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FatalProvisionFailure) DeepCopyInto(out *FatalProvisionFailure) {
+	*out = *in
+	if in.SearchRegexStrings != nil {
+		in, out := &in.SearchRegexStrings, &out.SearchRegexStrings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FatalProvisionFailure.
+func (in *FatalProvisionFailure) DeepCopy() *FatalProvisionFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(FatalProvisionFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
@@ -1992,7 +2637,7 @@ func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -2029,6 +2674,23 @@ func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigCondition) DeepCopyInto(out *HiveConfigCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigCondition.
+func (in *HiveConfigCondition) DeepCopy() *HiveConfigCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
 func (in *HiveConfigList) DeepCopy() *HiveConfigList {
 	if in == nil {
@@ -2069,6 +2731,36 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 	}
 	in.Backup.DeepCopyInto(&out.Backup)
 	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
+	if in.SyncSetApplyWorkers != nil {
+		in, out := &in.SyncSetApplyWorkers, &out.SyncSetApplyWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SyncSetResourcesFromAllowedURLs != nil {
+		in, out := &in.SyncSetResourcesFromAllowedURLs, &out.SyncSetResourcesFromAllowedURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretEncryption != nil {
+		in, out := &in.SecretEncryption, &out.SecretEncryption
+		*out = new(SecretEncryptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerraformState != nil {
+		in, out := &in.TerraformState, &out.TerraformState
+		*out = new(TerraformStateConfig)
+		**out = **in
+	}
+	if in.SpokeServiceAccountTokens != nil {
+		in, out := &in.SpokeServiceAccountTokens, &out.SpokeServiceAccountTokens
+		*out = new(SpokeServiceAccountTokensConfig)
+		**out = **in
+	}
+	if in.JobHistory != nil {
+		in, out := &in.JobHistory, &out.JobHistory
+		*out = new(JobHistoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MaintenanceMode != nil {
 		in, out := &in.MaintenanceMode, &out.MaintenanceMode
 		*out = new(bool)
@@ -2079,6 +2771,31 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ProtectedWorkloads != nil {
+		in, out := &in.ProtectedWorkloads, &out.ProtectedWorkloads
+		*out = new(ProtectedWorkloadsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseImageVerification != nil {
+		in, out := &in.ReleaseImageVerification, &out.ReleaseImageVerification
+		*out = new(ReleaseImageVerificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodScheduling != nil {
+		in, out := &in.PodScheduling, &out.PodScheduling
+		*out = new(PodSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagatedLabels != nil {
+		in, out := &in.PropagatedLabels, &out.PropagatedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DisabledControllers != nil {
 		in, out := &in.DisabledControllers, &out.DisabledControllers
 		*out = make([]string, len(*in))
@@ -2094,22 +2811,57 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(FeatureGateSelection)
 		(*in).DeepCopyInto(*out)
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
-func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
-	if in == nil {
-		return nil
+	out.Monitoring = in.Monitoring
+	if in.MetricsConfig != nil {
+		in, out := &in.MetricsConfig, &out.MetricsConfig
+		*out = new(MetricsConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(HiveConfigSpec)
-	in.DeepCopyInto(out)
-	return out
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfig)
+		**out = **in
+	}
+	if in.Profiling != nil {
+		in, out := &in.Profiling, &out.Profiling
+		*out = new(ProfilingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostInstallChecks != nil {
+		in, out := &in.PostInstallChecks, &out.PostInstallChecks
+		*out = make([]PostInstallCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WatchedNamespaces != nil {
+		in, out := &in.WatchedNamespaces, &out.WatchedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
+func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HiveConfigCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2123,6 +2875,126 @@ func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveTenant) DeepCopyInto(out *HiveTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveTenant.
+func (in *HiveTenant) DeepCopy() *HiveTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveTenantList) DeepCopyInto(out *HiveTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HiveTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveTenantList.
+func (in *HiveTenantList) DeepCopy() *HiveTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveTenantSpec) DeepCopyInto(out *HiveTenantSpec) {
+	*out = *in
+	if in.MaxClusterDeployments != nil {
+		in, out := &in.MaxClusterDeployments, &out.MaxClusterDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentInstalls != nil {
+		in, out := &in.MaxConcurrentInstalls, &out.MaxConcurrentInstalls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxVCPUs != nil {
+		in, out := &in.MaxVCPUs, &out.MaxVCPUs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InstanceTypeVCPUs != nil {
+		in, out := &in.InstanceTypeVCPUs, &out.InstanceTypeVCPUs
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveTenantSpec.
+func (in *HiveTenantSpec) DeepCopy() *HiveTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveTenantStatus) DeepCopyInto(out *HiveTenantStatus) {
+	*out = *in
+	if in.UnknownInstanceTypes != nil {
+		in, out := &in.UnknownInstanceTypes, &out.UnknownInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveTenantStatus.
+func (in *HiveTenantStatus) DeepCopy() *HiveTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
 	*out = *in
@@ -2139,6 +3011,86 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallAttempt) DeepCopyInto(out *InstallAttempt) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallAttempt.
+func (in *InstallAttempt) DeepCopy() *InstallAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallerStageTransition) DeepCopyInto(out *InstallerStageTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallerStageTransition.
+func (in *InstallerStageTransition) DeepCopy() *InstallerStageTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallerStageTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCount) DeepCopyInto(out *InstanceCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCount.
+func (in *InstanceCount) DeepCopy() *InstanceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobHistoryConfig) DeepCopyInto(out *JobHistoryConfig) {
+	*out = *in
+	if in.MaxProvisions != nil {
+		in, out := &in.MaxProvisions, &out.MaxProvisions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxInstallAttempts != nil {
+		in, out := &in.MaxInstallAttempts, &out.MaxInstallAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobHistoryConfig.
+func (in *JobHistoryConfig) DeepCopy() *JobHistoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobHistoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
 	*out = *in
@@ -2475,6 +3427,28 @@ func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
 	*out = *in
@@ -2517,186 +3491,541 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(ManageDNSAWSConfig)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(ManageDNSAWSConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(ManageDNSGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(ManageDNSAzureConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
+func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
+func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfig) DeepCopyInto(out *MetricsConfig) {
+	*out = *in
+	if in.AdditionalClusterDeploymentLabels != nil {
+		in, out := &in.AdditionalClusterDeploymentLabels, &out.AdditionalClusterDeploymentLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfig.
+func (in *MetricsConfig) DeepCopy() *MetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CertificatesSecretRef != nil {
+		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
+func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	out.CertificatesSecretRef = in.CertificatesSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
+func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(OvirtClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Platform) DeepCopyInto(out *Platform) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BareMetal != nil {
+		in, out := &in.BareMetal, &out.BareMetal
+		*out = new(baremetal.Platform)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(openstack.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(vsphere.Platform)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(ovirt.Platform)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
+func (in *Platform) DeepCopy() *Platform {
+	if in == nil {
+		return nil
+	}
+	out := new(Platform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.PlatformStatus)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.PlatformStatus)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.PlatformStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformProxyConfig) DeepCopyInto(out *PlatformProxyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformProxyConfig.
+func (in *PlatformProxyConfig) DeepCopy() *PlatformProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSchedulingConfig) DeepCopyInto(out *PodSchedulingConfig) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSchedulingConfig.
+func (in *PodSchedulingConfig) DeepCopy() *PodSchedulingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSchedulingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostInstallCheck) DeepCopyInto(out *PostInstallCheck) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(PostInstallCheckJob)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostInstallCheck.
+func (in *PostInstallCheck) DeepCopy() *PostInstallCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PostInstallCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostInstallCheckJob) DeepCopyInto(out *PostInstallCheckJob) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostInstallCheckJob.
+func (in *PostInstallCheckJob) DeepCopy() *PostInstallCheckJob {
+	if in == nil {
+		return nil
+	}
+	out := new(PostInstallCheckJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfilingConfig) DeepCopyInto(out *ProfilingConfig) {
+	*out = *in
+	if in.GCPercent != nil {
+		in, out := &in.GCPercent, &out.GCPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfilingConfig.
+func (in *ProfilingConfig) DeepCopy() *ProfilingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfilingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedWorkloadsConfig) DeepCopyInto(out *ProtectedWorkloadsConfig) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedWorkloadsConfig.
+func (in *ProtectedWorkloadsConfig) DeepCopy() *ProtectedWorkloadsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedWorkloadsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionStageTimeouts) DeepCopyInto(out *ProvisionStageTimeouts) {
+	*out = *in
+	if in.Infrastructure != nil {
+		in, out := &in.Infrastructure, &out.Infrastructure
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionStageTimeouts.
+func (in *ProvisionStageTimeouts) DeepCopy() *ProvisionStageTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionStageTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provisioning) DeepCopyInto(out *Provisioning) {
+	*out = *in
+	out.InstallConfigSecretRef = in.InstallConfigSecretRef
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	if in.ControlPlaneReplicas != nil {
+		in, out := &in.ControlPlaneReplicas, &out.ControlPlaneReplicas
+		*out = new(int64)
 		**out = **in
 	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(ManageDNSGCPConfig)
+	if in.ManifestsConfigMapRef != nil {
+		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(ManageDNSAzureConfig)
+	if in.CredentialsManifestsSecretRef != nil {
+		in, out := &in.CredentialsManifestsSecretRef, &out.CredentialsManifestsSecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SSHPrivateKeySecretRef != nil {
+		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SSHKnownHosts != nil {
+		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallerEnv != nil {
+		in, out := &in.InstallerEnv, &out.InstallerEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StageTimeouts != nil {
+		in, out := &in.StageTimeouts, &out.StageTimeouts
+		*out = new(ProvisionStageTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
-func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
+func (in *Provisioning) DeepCopy() *Provisioning {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSConfig)
+	out := new(Provisioning)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.TrustedCASecretRef != nil {
+		in, out := &in.TrustedCASecretRef, &out.TrustedCASecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformProxyConfig, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
-func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSGCPConfig)
+	out := new(ProxyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
+func (in *ReleaseImageVerificationConfig) DeepCopyInto(out *ReleaseImageVerificationConfig) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.CertificatesSecretRef != nil {
-		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.SignatureStores != nil {
+		in, out := &in.SignatureStores, &out.SignatureStores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	out.KeysConfigMapRef = in.KeysConfigMapRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
-func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseImageVerificationConfig.
+func (in *ReleaseImageVerificationConfig) DeepCopy() *ReleaseImageVerificationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenStackClusterDeprovision)
+	out := new(ReleaseImageVerificationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
+func (in *SecretEncryptionAWSConfig) DeepCopyInto(out *SecretEncryptionAWSConfig) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
-	out.CertificatesSecretRef = in.CertificatesSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
-func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEncryptionAWSConfig.
+func (in *SecretEncryptionAWSConfig) DeepCopy() *SecretEncryptionAWSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OvirtClusterDeprovision)
+	out := new(SecretEncryptionAWSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Platform) DeepCopyInto(out *Platform) {
+func (in *SecretEncryptionConfig) DeepCopyInto(out *SecretEncryptionConfig) {
 	*out = *in
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
-		*out = new(aws.Platform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(azure.Platform)
-		**out = **in
-	}
-	if in.BareMetal != nil {
-		in, out := &in.BareMetal, &out.BareMetal
-		*out = new(baremetal.Platform)
+		*out = new(SecretEncryptionAWSConfig)
 		**out = **in
 	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
-		*out = new(gcp.Platform)
-		**out = **in
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(openstack.Platform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(vsphere.Platform)
-		**out = **in
-	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(ovirt.Platform)
+		*out = new(SecretEncryptionGCPConfig)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
-func (in *Platform) DeepCopy() *Platform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEncryptionConfig.
+func (in *SecretEncryptionConfig) DeepCopy() *SecretEncryptionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Platform)
+	out := new(SecretEncryptionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provisioning) DeepCopyInto(out *Provisioning) {
+func (in *SecretEncryptionGCPConfig) DeepCopyInto(out *SecretEncryptionGCPConfig) {
 	*out = *in
-	out.InstallConfigSecretRef = in.InstallConfigSecretRef
-	if in.ImageSetRef != nil {
-		in, out := &in.ImageSetRef, &out.ImageSetRef
-		*out = new(ClusterImageSetReference)
-		**out = **in
-	}
-	if in.ManifestsConfigMapRef != nil {
-		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHPrivateKeySecretRef != nil {
-		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHKnownHosts != nil {
-		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstallerEnv != nil {
-		in, out := &in.InstallerEnv, &out.InstallerEnv
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
-func (in *Provisioning) DeepCopy() *Provisioning {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEncryptionGCPConfig.
+func (in *SecretEncryptionGCPConfig) DeepCopy() *SecretEncryptionGCPConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Provisioning)
+	out := new(SecretEncryptionGCPConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2820,7 +4149,7 @@ func (in *SelectorSyncSet) DeepCopyInto(out *SelectorSyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -2842,6 +4171,22 @@ func (in *SelectorSyncSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetClusterDeploymentReference) DeepCopyInto(out *SelectorSyncSetClusterDeploymentReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetClusterDeploymentReference.
+func (in *SelectorSyncSetClusterDeploymentReference) DeepCopy() *SelectorSyncSetClusterDeploymentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetClusterDeploymentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetList) DeepCopyInto(out *SelectorSyncSetList) {
 	*out = *in
@@ -2875,11 +4220,89 @@ func (in *SelectorSyncSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRollout) DeepCopyInto(out *SelectorSyncSetRollout) {
+	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]metav1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFailures != nil {
+		in, out := &in.MaxFailures, &out.MaxFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRollout.
+func (in *SelectorSyncSetRollout) DeepCopy() *SelectorSyncSetRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStatus) DeepCopyInto(out *SelectorSyncSetRolloutStatus) {
+	*out = *in
+	if in.AdmittedClusters != nil {
+		in, out := &in.AdmittedClusters, &out.AdmittedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastProgressTime != nil {
+		in, out := &in.LastProgressTime, &out.LastProgressTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TimedOutClusters != nil {
+		in, out := &in.TimedOutClusters, &out.TimedOutClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStatus.
+func (in *SelectorSyncSetRolloutStatus) DeepCopy() *SelectorSyncSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.ExcludeClusterDeployments != nil {
+		in, out := &in.ExcludeClusterDeployments, &out.ExcludeClusterDeployments
+		*out = make([]SelectorSyncSetClusterDeploymentReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2896,6 +4319,11 @@ func (in *SelectorSyncSetSpec) DeepCopy() *SelectorSyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetStatus) DeepCopyInto(out *SelectorSyncSetStatus) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2926,6 +4354,22 @@ func (in *SpecificControllerConfig) DeepCopy() *SpecificControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpokeServiceAccountTokensConfig) DeepCopyInto(out *SpokeServiceAccountTokensConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpokeServiceAccountTokensConfig.
+func (in *SpokeServiceAccountTokensConfig) DeepCopy() *SpokeServiceAccountTokensConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpokeServiceAccountTokensConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncCondition) DeepCopyInto(out *SyncCondition) {
 	*out = *in
@@ -3104,6 +4548,13 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourcesFrom != nil {
+		in, out := &in.ResourcesFrom, &out.ResourcesFrom
+		*out = make([]SyncSetResourcesSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]SyncObjectPatch, len(*in))
@@ -3204,6 +4655,27 @@ func (in *SyncSetObjectStatus) DeepCopy() *SyncSetObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetResourcesSource) DeepCopyInto(out *SyncSetResourcesSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetResourcesSource.
+func (in *SyncSetResourcesSource) DeepCopy() *SyncSetResourcesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetResourcesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 	*out = *in
@@ -3265,6 +4737,38 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformStateConfig) DeepCopyInto(out *TerraformStateConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformStateConfig.
+func (in *TerraformStateConfig) DeepCopy() *TerraformStateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformStateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterDeprovision) DeepCopyInto(out *VSphereClusterDeprovision) {
 	*out = *in
@@ -50,11 +50,30 @@ type SyncStatus struct {
 	// ObservedGeneration is the generation of the SyncSet or SelectorSyncSet that was last observed.
 	ObservedGeneration int64 `json:"observedGeneration"`
 
+	// ResourcesChecksum is a checksum of the resources referenced by the ResourcesFrom of the SyncSet or
+	// SelectorSyncSet and of the values of its resource templates that were last observed. The SyncSet or
+	// SelectorSyncSet is applied again when it changes.
+	// +optional
+	ResourcesChecksum string `json:"resourcesChecksum,omitempty"`
+
 	// ResourcesToDelete is the list of resources in the cluster that should be deleted when the SyncSet or SelectorSyncSet
 	// is deleted or is no longer matched to the cluster.
 	// +optional
 	ResourcesToDelete []SyncResourceReference `json:"resourcesToDelete,omitempty"`
 
+	// AppliedResources is the list of resources and secrets that were last applied successfully to the cluster along
+	// with a hash of their content and their version in the cluster. Resources whose content has not changed and which
+	// have not changed in the cluster are not applied again until the next full re-apply of the SyncSet or
+	// SelectorSyncSet.
+	// +optional
+	AppliedResources []AppliedResource `json:"appliedResources,omitempty"`
+
+	// DriftedResources is the list of resources and secrets in the cluster that differ from the state declared in the
+	// SyncSet or SelectorSyncSet. Drift is only reported when the SyncSet or SelectorSyncSet is not enforced in the
+	// cluster.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+
 	// Result is the result of the last attempt to apply the SyncSet or SelectorSyncSet to the cluster.
 	Result SyncSetResult `json:"result"`
 
@@ -88,6 +107,31 @@ type SyncResourceReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// AppliedResource is a resource that was applied to a cluster via a SyncSet or SelectorSyncSet.
+type AppliedResource struct {
+	SyncResourceReference `json:",inline"`
+
+	// Hash is the hash of the content of the resource that was applied to the cluster.
+	Hash string `json:"hash"`
+
+	// Generation is the generation of the resource in the cluster once it was applied. It is zero for resources
+	// that do not track the generation of their spec.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// ResourceVersion is the resource version of the resource in the cluster once it was applied.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// DriftedResource is a resource in the cluster that differs from the state declared in a SyncSet or SelectorSyncSet.
+type DriftedResource struct {
+	SyncResourceReference `json:",inline"`
+
+	// Differences describe the fields of the resource in the cluster that differ from the declared state.
+	Differences []string `json:"differences"`
+}
+
 // SyncSetResult is the result of a sync attempt.
 // +kubebuilder:validation:Enum=Success;Failure
 type SyncSetResult string
@@ -128,6 +172,14 @@ const (
 	// ClusterSyncFailed is the type of condition used to indicate whether there are SyncSets or SelectorSyncSets which
 	// have not been applied due to an error.
 	ClusterSyncFailed ClusterSyncConditionType = "Failed"
+
+	// ClusterSyncStale is the type of condition used to indicate whether the SyncSets and SelectorSyncSets have not
+	// all been applied successfully to the cluster for longer than the sync stale threshold.
+	ClusterSyncStale ClusterSyncConditionType = "SyncStale"
+
+	// ClusterSyncReady is the type of condition used to indicate whether all of the SyncSets and SelectorSyncSets
+	// have been applied successfully to the cluster.
+	ClusterSyncReady ClusterSyncConditionType = "Ready"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
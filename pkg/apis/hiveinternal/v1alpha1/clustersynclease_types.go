@@ -21,6 +21,11 @@ type ClusterSyncLease struct {
 type ClusterSyncLeaseSpec struct {
 	// RenewTime is the time when SyncSets and SelectorSyncSets were last applied to the cluster.
 	RenewTime metav1.MicroTime `json:"renewTime"`
+
+	// LastSuccessTime is the time when all of the SyncSets and SelectorSyncSets were last applied to the cluster
+	// successfully, either during a full re-apply or once the failures of the last full re-apply were resolved.
+	// +optional
+	LastSuccessTime *metav1.MicroTime `json:"lastSuccessTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -14,6 +14,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -32,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 )
 
@@ -102,6 +104,10 @@ type Client interface {
 	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
 	ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
 	ChangeResourceRecordSets(*route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	AssociateVPCWithHostedZone(*route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
+	DisassociateVPCFromHostedZone(*route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
+	CreateVPCAssociationAuthorization(*route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error)
+	DeleteVPCAssociationAuthorization(*route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
 
 	// ResourceTagging
 	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
@@ -302,6 +308,26 @@ func (c *awsClient) ChangeResourceRecordSets(input *route53.ChangeResourceRecord
 	return c.route53Client.ChangeResourceRecordSets(input)
 }
 
+func (c *awsClient) AssociateVPCWithHostedZone(input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	metricAWSAPICalls.WithLabelValues("AssociateVPCWithHostedZone").Inc()
+	return c.route53Client.AssociateVPCWithHostedZone(input)
+}
+
+func (c *awsClient) DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DisassociateVPCFromHostedZone").Inc()
+	return c.route53Client.DisassociateVPCFromHostedZone(input)
+}
+
+func (c *awsClient) CreateVPCAssociationAuthorization(input *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateVPCAssociationAuthorization").Inc()
+	return c.route53Client.CreateVPCAssociationAuthorization(input)
+}
+
+func (c *awsClient) DeleteVPCAssociationAuthorization(input *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteVPCAssociationAuthorization").Inc()
+	return c.route53Client.DeleteVPCAssociationAuthorization(input)
+}
+
 func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetCallerIdentity").Inc()
 	return c.stsClient.GetCallerIdentity(input)
@@ -314,11 +340,13 @@ func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.G
 //
 // Pass a nil client, and empty secret name and namespace to load credentials from the standard
 // AWS environment variables.
-func NewClient(kubeClient client.Client, secretName, namespace, region string) (Client, error) {
+//
+// The serviceEndpoints, if any, override the default endpoints of the matching AWS services.
+func NewClient(kubeClient client.Client, secretName, namespace, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (Client, error) {
 
 	// Special case to not use a secret to gather credentials.
 	if secretName == "" {
-		return NewClientFromSecret(nil, region)
+		return NewClientFromSecret(nil, region, serviceEndpoints)
 	}
 
 	secret := &corev1.Secret{}
@@ -332,7 +360,7 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 		return nil, err
 	}
 
-	return NewClientFromSecret(secret, region)
+	return NewClientFromSecret(secret, region, serviceEndpoints)
 }
 
 // NewClientFromSecret creates our client wrapper object for the actual AWS clients we use.
@@ -341,10 +369,31 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 // otherwise the IAM profile of the master where the actuator will run. (target clusters)
 //
 // Pass a nil secret to load credentials from the standard AWS environment variables.
-func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
+//
+// The serviceEndpoints, if any, override the default endpoints of the matching AWS services.
+func NewClientFromSecret(secret *corev1.Secret, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (Client, error) {
+	s, err := newSession(secret, region, serviceEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromSession(s), nil
+}
+
+// NewClientFromSecretAssumingRole creates our client wrapper object for the actual AWS clients we use, authenticated
+// as the role with the given ARN. The role is assumed with the credentials of the secret, or of the standard AWS
+// environment variables when the secret is nil.
+func NewClientFromSecretAssumingRole(secret *corev1.Secret, roleARN, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (Client, error) {
+	s, err := newSession(secret, region, serviceEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromSession(s.Copy(&aws.Config{Credentials: stscreds.NewCredentials(s, roleARN)})), nil
+}
+
+func newSession(secret *corev1.Secret, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (*session.Session, error) {
 	awsConfig := &aws.Config{
 		Region:           aws.String(region),
-		EndpointResolver: endpoints.ResolverFunc(awsChinaEndpointResolver),
+		EndpointResolver: newEndpointResolver(serviceEndpoints),
 	}
 
 	// Special case to not use a secret to gather credentials.
@@ -375,17 +424,52 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 		Name: "openshift.io/hive",
 		Fn:   request.MakeAddToUserAgentHandler("openshift.io hive", "v1"),
 	})
+	s.Handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: "openshift.io/hive/route53-throttle",
+		Fn:   throttleRoute53,
+	})
+	return s, nil
+}
 
+func newClientFromSession(s *session.Session) Client {
 	return &awsClient{
 		ec2Client:     ec2.New(s),
 		elbClient:     elb.New(s),
 		iamClient:     iam.New(s),
 		s3Client:      s3.New(s),
 		s3Uploader:    s3manager.NewUploader(s),
-		route53Client: route53.New(s),
+		route53Client: route53.New(s, request.WithRetryer(aws.NewConfig(), newRoute53Retryer())),
 		stsClient:     sts.New(s),
 		tagClient:     resourcegroupstaggingapi.New(s),
-	}, nil
+	}
+}
+
+// newEndpointResolver returns a resolver that uses the given service endpoint overrides, falling back
+// to the default endpoints for any service without an override.
+func newEndpointResolver(serviceEndpoints []hivev1aws.ServiceEndpoint) endpoints.ResolverFunc {
+	if len(serviceEndpoints) == 0 {
+		return awsChinaEndpointResolver
+	}
+	overrides := make(map[string]string, len(serviceEndpoints))
+	for _, e := range serviceEndpoints {
+		overrides[e.Name] = e.URL
+	}
+	return func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		url, ok := overrides[service]
+		if !ok {
+			return awsChinaEndpointResolver(service, region, optFns...)
+		}
+		// Keep signing requests for the region the service would normally be signed for. Global
+		// services like route53 and iam are signed for a single region regardless of the client's.
+		signingRegion := region
+		if def, err := endpoints.DefaultResolver().EndpointFor(service, region); err == nil && def.SigningRegion != "" {
+			signingRegion = def.SigningRegion
+		}
+		return endpoints.ResolvedEndpoint{
+			URL:           url,
+			SigningRegion: signingRegion,
+		}, nil
+	}
 }
 
 func awsChinaEndpointResolver(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
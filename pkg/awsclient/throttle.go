@@ -0,0 +1,51 @@
+package awsclient
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// route53QPS and route53Burst keep the Route53 requests of the process under the limit of 5 requests per second
+	// of an AWS account.
+	route53QPS   = 4
+	route53Burst = 5
+
+	// route53MaxRetries is the number of times a Route53 request is retried. Throttled requests are retried with a
+	// jittered exponential backoff, waiting at least for the delay asked by the Retry-After header of the response.
+	route53MaxRetries       = 8
+	route53MinThrottleDelay = time.Second
+	route53MaxThrottleDelay = time.Minute
+)
+
+// route53RateLimiter is shared by all the Route53 clients of the process, so that reconciling many DNSZones at once,
+// such as when creating clusters in bulk, does not exceed the request limit of the account and starve the other
+// controllers calling Route53.
+var route53RateLimiter = flowcontrol.NewTokenBucketRateLimiter(route53QPS, route53Burst)
+
+// throttleRoute53 waits for the shared Route53 rate limiter before each attempt of a Route53 request. It runs before
+// the request is signed, so that the signature does not expire while waiting.
+func throttleRoute53(r *request.Request) {
+	if r.ClientInfo.ServiceName != route53.ServiceName {
+		return
+	}
+	if err := route53RateLimiter.Wait(r.Context()); err != nil {
+		r.Error = awserr.New(request.CanceledErrorCode, "request canceled while waiting for the Route53 rate limiter", err)
+	}
+}
+
+// newRoute53Retryer returns the retryer of the Route53 clients, which retries throttled requests more patiently than
+// the default retryer of the SDK.
+func newRoute53Retryer() request.Retryer {
+	return client.DefaultRetryer{
+		NumMaxRetries:    route53MaxRetries,
+		MinThrottleDelay: route53MinThrottleDelay,
+		MaxThrottleDelay: route53MaxThrottleDelay,
+	}
+}
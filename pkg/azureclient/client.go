@@ -167,9 +167,13 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 
 	recordSetsClient := dns.NewRecordSetsClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	recordSetsClient.Authorizer = authorizer
+	recordSetsClient.RequestInspector = throttleDNSRequests
+	recordSetsClient.RetryAttempts = dnsRetryAttempts
 
 	zonesClient := dns.NewZonesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	zonesClient.Authorizer = authorizer
+	zonesClient.RequestInspector = throttleDNSRequests
+	zonesClient.RetryAttempts = dnsRetryAttempts
 
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
@@ -0,0 +1,35 @@
+package azureclient
+
+import (
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// dnsQPS and dnsBurst limit the Azure DNS requests of the process, which share the request limits of the
+	// subscription.
+	dnsQPS   = 5
+	dnsBurst = 10
+
+	// dnsRetryAttempts is the number of attempts of a failed Azure DNS request. Autorest waits for the delay asked by
+	// the Retry-After header of throttled responses before retrying them.
+	dnsRetryAttempts = 6
+)
+
+// dnsRateLimiter is shared by all the Azure DNS clients of the process, so that reconciling many DNSZones at once
+// does not exceed the request limits of the subscription.
+var dnsRateLimiter = flowcontrol.NewTokenBucketRateLimiter(dnsQPS, dnsBurst)
+
+// throttleDNSRequests is the request inspector of the Azure DNS clients, which waits for the shared rate limiter
+// before each request.
+func throttleDNSRequests(p autorest.Preparer) autorest.Preparer {
+	return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+		if err := dnsRateLimiter.Wait(r.Context()); err != nil {
+			return r, err
+		}
+		return p.Prepare(r)
+	})
+}
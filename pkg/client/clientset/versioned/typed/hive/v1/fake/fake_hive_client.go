@@ -32,6 +32,10 @@ func (c *FakeHiveV1) ClusterImageSets() v1.ClusterImageSetInterface {
 	return &FakeClusterImageSets{c}
 }
 
+func (c *FakeHiveV1) ClusterOperationLogs() v1.ClusterOperationLogInterface {
+	return &FakeClusterOperationLogs{c}
+}
+
 func (c *FakeHiveV1) ClusterPools(namespace string) v1.ClusterPoolInterface {
 	return &FakeClusterPools{c, namespace}
 }
@@ -48,6 +52,10 @@ func (c *FakeHiveV1) ClusterStates(namespace string) v1.ClusterStateInterface {
 	return &FakeClusterStates{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterUpgrades() v1.ClusterUpgradeInterface {
+	return &FakeClusterUpgrades{c}
+}
+
 func (c *FakeHiveV1) DNSZones(namespace string) v1.DNSZoneInterface {
 	return &FakeDNSZones{c, namespace}
 }
@@ -56,6 +64,10 @@ func (c *FakeHiveV1) HiveConfigs() v1.HiveConfigInterface {
 	return &FakeHiveConfigs{c}
 }
 
+func (c *FakeHiveV1) HiveTenants(namespace string) v1.HiveTenantInterface {
+	return &FakeHiveTenants{c, namespace}
+}
+
 func (c *FakeHiveV1) MachinePools(namespace string) v1.MachinePoolInterface {
 	return &FakeMachinePools{c, namespace}
 }
@@ -12,6 +12,8 @@ type ClusterDeprovisionExpansion interface{}
 
 type ClusterImageSetExpansion interface{}
 
+type ClusterOperationLogExpansion interface{}
+
 type ClusterPoolExpansion interface{}
 
 type ClusterProvisionExpansion interface{}
@@ -20,10 +22,14 @@ type ClusterRelocateExpansion interface{}
 
 type ClusterStateExpansion interface{}
 
+type ClusterUpgradeExpansion interface{}
+
 type DNSZoneExpansion interface{}
 
 type HiveConfigExpansion interface{}
 
+type HiveTenantExpansion interface{}
+
 type MachinePoolExpansion interface{}
 
 type MachinePoolNameLeaseExpansion interface{}
@@ -15,12 +15,15 @@ type HiveV1Interface interface {
 	ClusterDeploymentsGetter
 	ClusterDeprovisionsGetter
 	ClusterImageSetsGetter
+	ClusterOperationLogsGetter
 	ClusterPoolsGetter
 	ClusterProvisionsGetter
 	ClusterRelocatesGetter
 	ClusterStatesGetter
+	ClusterUpgradesGetter
 	DNSZonesGetter
 	HiveConfigsGetter
+	HiveTenantsGetter
 	MachinePoolsGetter
 	MachinePoolNameLeasesGetter
 	SelectorSyncIdentityProvidersGetter
@@ -54,6 +57,10 @@ func (c *HiveV1Client) ClusterImageSets() ClusterImageSetInterface {
 	return newClusterImageSets(c)
 }
 
+func (c *HiveV1Client) ClusterOperationLogs() ClusterOperationLogInterface {
+	return newClusterOperationLogs(c)
+}
+
 func (c *HiveV1Client) ClusterPools(namespace string) ClusterPoolInterface {
 	return newClusterPools(c, namespace)
 }
@@ -70,6 +77,10 @@ func (c *HiveV1Client) ClusterStates(namespace string) ClusterStateInterface {
 	return newClusterStates(c, namespace)
 }
 
+func (c *HiveV1Client) ClusterUpgrades() ClusterUpgradeInterface {
+	return newClusterUpgrades(c)
+}
+
 func (c *HiveV1Client) DNSZones(namespace string) DNSZoneInterface {
 	return newDNSZones(c, namespace)
 }
@@ -78,6 +89,10 @@ func (c *HiveV1Client) HiveConfigs() HiveConfigInterface {
 	return newHiveConfigs(c)
 }
 
+func (c *HiveV1Client) HiveTenants(namespace string) HiveTenantInterface {
+	return newHiveTenants(c, namespace)
+}
+
 func (c *HiveV1Client) MachinePools(namespace string) MachinePoolInterface {
 	return newMachinePools(c, namespace)
 }
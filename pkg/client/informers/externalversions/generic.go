@@ -48,6 +48,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeprovisions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterimagesets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterImageSets().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusteroperationlogs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterOperationLogs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterpools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterPools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterprovisions"):
@@ -56,10 +58,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterRelocates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterstates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterStates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterupgrades"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterUpgrades().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("dnszones"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSZones().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("hiveconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HiveConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("hivetenants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HiveTenants().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepoolnameleases"):
@@ -18,6 +18,8 @@ type Interface interface {
 	ClusterDeprovisions() ClusterDeprovisionInformer
 	// ClusterImageSets returns a ClusterImageSetInformer.
 	ClusterImageSets() ClusterImageSetInformer
+	// ClusterOperationLogs returns a ClusterOperationLogInformer.
+	ClusterOperationLogs() ClusterOperationLogInformer
 	// ClusterPools returns a ClusterPoolInformer.
 	ClusterPools() ClusterPoolInformer
 	// ClusterProvisions returns a ClusterProvisionInformer.
@@ -26,10 +28,14 @@ type Interface interface {
 	ClusterRelocates() ClusterRelocateInformer
 	// ClusterStates returns a ClusterStateInformer.
 	ClusterStates() ClusterStateInformer
+	// ClusterUpgrades returns a ClusterUpgradeInformer.
+	ClusterUpgrades() ClusterUpgradeInformer
 	// DNSZones returns a DNSZoneInformer.
 	DNSZones() DNSZoneInformer
 	// HiveConfigs returns a HiveConfigInformer.
 	HiveConfigs() HiveConfigInformer
+	// HiveTenants returns a HiveTenantInformer.
+	HiveTenants() HiveTenantInformer
 	// MachinePools returns a MachinePoolInformer.
 	MachinePools() MachinePoolInformer
 	// MachinePoolNameLeases returns a MachinePoolNameLeaseInformer.
@@ -80,6 +86,11 @@ func (v *version) ClusterImageSets() ClusterImageSetInformer {
 	return &clusterImageSetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterOperationLogs returns a ClusterOperationLogInformer.
+func (v *version) ClusterOperationLogs() ClusterOperationLogInformer {
+	return &clusterOperationLogInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterPools returns a ClusterPoolInformer.
 func (v *version) ClusterPools() ClusterPoolInformer {
 	return &clusterPoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -100,6 +111,11 @@ func (v *version) ClusterStates() ClusterStateInformer {
 	return &clusterStateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterUpgrades returns a ClusterUpgradeInformer.
+func (v *version) ClusterUpgrades() ClusterUpgradeInformer {
+	return &clusterUpgradeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSZones returns a DNSZoneInformer.
 func (v *version) DNSZones() DNSZoneInformer {
 	return &dNSZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -110,6 +126,11 @@ func (v *version) HiveConfigs() HiveConfigInformer {
 	return &hiveConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// HiveTenants returns a HiveTenantInformer.
+func (v *version) HiveTenants() HiveTenantInformer {
+	return &hiveTenantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // MachinePools returns a MachinePoolInformer.
 func (v *version) MachinePools() MachinePoolInformer {
 	return &machinePoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
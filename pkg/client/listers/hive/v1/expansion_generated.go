@@ -38,6 +38,10 @@ type ClusterDeprovisionNamespaceListerExpansion interface{}
 // ClusterImageSetLister.
 type ClusterImageSetListerExpansion interface{}
 
+// ClusterOperationLogListerExpansion allows custom methods to be added to
+// ClusterOperationLogLister.
+type ClusterOperationLogListerExpansion interface{}
+
 // ClusterPoolListerExpansion allows custom methods to be added to
 // ClusterPoolLister.
 type ClusterPoolListerExpansion interface{}
@@ -66,6 +70,10 @@ type ClusterStateListerExpansion interface{}
 // ClusterStateNamespaceLister.
 type ClusterStateNamespaceListerExpansion interface{}
 
+// ClusterUpgradeListerExpansion allows custom methods to be added to
+// ClusterUpgradeLister.
+type ClusterUpgradeListerExpansion interface{}
+
 // DNSZoneListerExpansion allows custom methods to be added to
 // DNSZoneLister.
 type DNSZoneListerExpansion interface{}
@@ -78,6 +86,14 @@ type DNSZoneNamespaceListerExpansion interface{}
 // HiveConfigLister.
 type HiveConfigListerExpansion interface{}
 
+// HiveTenantListerExpansion allows custom methods to be added to
+// HiveTenantLister.
+type HiveTenantListerExpansion interface{}
+
+// HiveTenantNamespaceListerExpansion allows custom methods to be added to
+// HiveTenantNamespaceLister.
+type HiveTenantNamespaceListerExpansion interface{}
+
 // MachinePoolListerExpansion allows custom methods to be added to
 // MachinePoolLister.
 type MachinePoolListerExpansion interface{}
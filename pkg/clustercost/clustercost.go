@@ -0,0 +1,180 @@
+// Package clustercost estimates the cost of running the machines of clusters from the hourly prices of their instance
+// types.
+package clustercost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// pricesDataEntryName is the data entry of the pricing ConfigMap which lists the prices.
+	pricesDataEntryName = "prices"
+
+	// defaultCurrency is the currency of the prices which do not specify one.
+	defaultCurrency = "USD"
+
+	// costDecimals is the number of decimals the estimated costs are rounded to.
+	costDecimals = 4
+)
+
+// RegionPrices are the hourly prices of the instance types of a platform in a region.
+type RegionPrices struct {
+	// Platform is the platform of the instance types, for example aws, gcp or azure.
+	Platform string `json:"platform"`
+
+	// Region is the region the prices apply to. The prices without a region apply to the regions of the platform
+	// which are not listed.
+	Region string `json:"region,omitempty"`
+
+	// Currency is the currency of the prices. Defaults to USD.
+	Currency string `json:"currency,omitempty"`
+
+	// HourlyPrices maps the instance types to their hourly prices.
+	HourlyPrices map[string]float64 `json:"hourlyPrices"`
+}
+
+// Price is the hourly price of an instance type.
+type Price struct {
+	// Amount is the price of running an instance for an hour.
+	Amount float64
+	// Currency is the currency of the amount.
+	Currency string
+}
+
+// PricingSource provides the hourly prices of instance types. Sources other than the pricing ConfigMap, such as the
+// pricing APIs of the cloud providers, can be plugged into the clustercost controller by implementing it.
+type PricingSource interface {
+	// HourlyPrice returns the hourly price of the instance type in the region of the platform, or nil when the price
+	// is unknown.
+	HourlyPrice(platform, region, instanceType string) (*Price, error)
+}
+
+// NewConfigMapPricingSource returns a pricing source which reads the prices from the optional pricing ConfigMap of
+// the Hive namespace. The prices are cached until the ConfigMap changes. All prices are unknown when the ConfigMap
+// does not exist.
+func NewConfigMapPricingSource(c client.Client) PricingSource {
+	return &configMapPricingSource{client: c}
+}
+
+type configMapPricingSource struct {
+	client client.Client
+
+	mutex sync.Mutex
+	// resourceVersion is the resource version of the ConfigMap the prices were cached from.
+	resourceVersion string
+	prices          map[regionKey]*RegionPrices
+}
+
+type regionKey struct {
+	platform string
+	region   string
+}
+
+func (s *configMapPricingSource) HourlyPrice(platform, region, instanceType string) (*Price, error) {
+	cm := &corev1.ConfigMap{}
+	switch err := s.client.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: constants.ClusterPricingConfigMapName},
+		cm,
+	); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get pricing configmap")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.prices == nil || s.resourceVersion != cm.ResourceVersion {
+		prices, err := parsePrices(cm)
+		if err != nil {
+			return nil, err
+		}
+		s.prices = prices
+		s.resourceVersion = cm.ResourceVersion
+	}
+	for _, key := range []regionKey{{platform: platform, region: region}, {platform: platform}} {
+		if regionPrices, ok := s.prices[key]; ok {
+			if amount, ok := regionPrices.HourlyPrices[instanceType]; ok {
+				return &Price{Amount: amount, Currency: regionPrices.Currency}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func parsePrices(cm *corev1.ConfigMap) (map[regionKey]*RegionPrices, error) {
+	raw, ok := cm.Data[pricesDataEntryName]
+	if !ok {
+		return nil, fmt.Errorf("%s configmap does not have a %q data entry", cm.Name, pricesDataEntryName)
+	}
+	var list []RegionPrices
+	if err := yaml.Unmarshal([]byte(raw), &list); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal data from %s configmap", cm.Name)
+	}
+	prices := make(map[regionKey]*RegionPrices, len(list))
+	for i := range list {
+		if list[i].Currency == "" {
+			list[i].Currency = defaultCurrency
+		}
+		prices[regionKey{platform: list[i].Platform, region: list[i].Region}] = &list[i]
+	}
+	return prices, nil
+}
+
+// Estimate returns the estimated cost of running the machines of a cluster of the platform in the region. The
+// instances map the instance types of the machines to their counts. The machines whose instance type has no price are
+// listed in the estimate, but not included in the cost. An error is returned when the prices of the instance types
+// are in different currencies.
+func Estimate(source PricingSource, platform, region string, instances map[string]int64) (*hivev1.ClusterCostEstimate, error) {
+	estimate := &hivev1.ClusterCostEstimate{}
+	instanceTypes := make([]string, 0, len(instances))
+	for instanceType := range instances {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Strings(instanceTypes)
+
+	var cost float64
+	for _, instanceType := range instanceTypes {
+		count := instances[instanceType]
+		estimate.Instances = append(estimate.Instances, hivev1.InstanceCount{InstanceType: instanceType, Count: count})
+		price, err := source.HourlyPrice(platform, region, instanceType)
+		if err != nil {
+			return nil, err
+		}
+		if price == nil {
+			estimate.UnpricedInstanceTypes = append(estimate.UnpricedInstanceTypes, instanceType)
+			continue
+		}
+		if estimate.Currency != "" && estimate.Currency != price.Currency {
+			return nil, fmt.Errorf("price of instance type %s is in %s, other instance types are priced in %s", instanceType, price.Currency, estimate.Currency)
+		}
+		estimate.Currency = price.Currency
+		cost += price.Amount * float64(count)
+	}
+	estimate.HourlyCost = FormatCost(cost)
+	return estimate, nil
+}
+
+// FormatCost formats a cost as a decimal number, rounded to four decimals.
+func FormatCost(cost float64) string {
+	scale := math.Pow10(costDecimals)
+	return strconv.FormatFloat(math.Round(cost*scale)/scale, 'f', -1, 64)
+}
@@ -0,0 +1,227 @@
+package clustercost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testPrices = `
+- platform: aws
+  region: us-east-1
+  hourlyPrices:
+    m5.xlarge: 0.192
+    m5.2xlarge: 0.384
+- platform: aws
+  hourlyPrices:
+    m5.xlarge: 0.2
+    m5.4xlarge: 0.85
+- platform: gcp
+  region: europe-west1
+  currency: EUR
+  hourlyPrices:
+    n1-standard-4: 0.17
+`
+
+func TestHourlyPrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []runtime.Object
+		platform      string
+		region        string
+		instanceType  string
+		expectedPrice *Price
+		expectError   bool
+	}{
+		{
+			name:          "region price",
+			existing:      []runtime.Object{testConfigMap(testPrices)},
+			platform:      "aws",
+			region:        "us-east-1",
+			instanceType:  "m5.xlarge",
+			expectedPrice: &Price{Amount: 0.192, Currency: "USD"},
+		},
+		{
+			name:          "platform price",
+			existing:      []runtime.Object{testConfigMap(testPrices)},
+			platform:      "aws",
+			region:        "us-east-1",
+			instanceType:  "m5.4xlarge",
+			expectedPrice: &Price{Amount: 0.85, Currency: "USD"},
+		},
+		{
+			name:          "platform price in unlisted region",
+			existing:      []runtime.Object{testConfigMap(testPrices)},
+			platform:      "aws",
+			region:        "eu-west-1",
+			instanceType:  "m5.xlarge",
+			expectedPrice: &Price{Amount: 0.2, Currency: "USD"},
+		},
+		{
+			name:          "currency",
+			existing:      []runtime.Object{testConfigMap(testPrices)},
+			platform:      "gcp",
+			region:        "europe-west1",
+			instanceType:  "n1-standard-4",
+			expectedPrice: &Price{Amount: 0.17, Currency: "EUR"},
+		},
+		{
+			name:         "unknown instance type",
+			existing:     []runtime.Object{testConfigMap(testPrices)},
+			platform:     "aws",
+			region:       "eu-west-1",
+			instanceType: "m5.2xlarge",
+		},
+		{
+			name:         "unknown platform",
+			existing:     []runtime.Object{testConfigMap(testPrices)},
+			platform:     "azure",
+			region:       "eastus",
+			instanceType: "Standard_D8s_v3",
+		},
+		{
+			name:         "no configmap",
+			platform:     "aws",
+			region:       "us-east-1",
+			instanceType: "m5.xlarge",
+		},
+		{
+			name: "configmap without prices",
+			existing: []runtime.Object{func() *corev1.ConfigMap {
+				cm := testConfigMap("")
+				cm.Data = nil
+				return cm
+			}()},
+			platform:     "aws",
+			region:       "us-east-1",
+			instanceType: "m5.xlarge",
+			expectError:  true,
+		},
+		{
+			name:         "invalid prices",
+			existing:     []runtime.Object{testConfigMap("not: [a list")},
+			platform:     "aws",
+			region:       "us-east-1",
+			instanceType: "m5.xlarge",
+			expectError:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := NewConfigMapPricingSource(fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...))
+			price, err := source.HourlyPrice(test.platform, test.region, test.instanceType)
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedPrice, price, "unexpected price")
+		})
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name             string
+		region           string
+		instances        map[string]int64
+		expectedEstimate *hivev1.ClusterCostEstimate
+		expectError      bool
+	}{
+		{
+			name:      "priced instances",
+			region:    "us-east-1",
+			instances: map[string]int64{"m5.xlarge": 3, "m5.2xlarge": 2},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "1.344",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.2xlarge", Count: 2},
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+			},
+		},
+		{
+			name:      "unpriced instances",
+			region:    "eu-west-1",
+			instances: map[string]int64{"m5.xlarge": 3, "m5.2xlarge": 2},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.6",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.2xlarge", Count: 2},
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+				UnpricedInstanceTypes: []string{"m5.2xlarge"},
+			},
+		},
+		{
+			name:      "no instances",
+			region:    "us-east-1",
+			instances: map[string]int64{},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0",
+			},
+		},
+		{
+			name:        "mixed currencies",
+			region:      "us-east-1",
+			instances:   map[string]int64{"m5.xlarge": 3, "m5.2xlarge": 2},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var source PricingSource = testPricingSource{
+				"us-east-1/m5.xlarge":  {Amount: 0.192, Currency: "USD"},
+				"us-east-1/m5.2xlarge": {Amount: 0.384, Currency: "USD"},
+				"eu-west-1/m5.xlarge":  {Amount: 0.2, Currency: "USD"},
+			}
+			if test.expectError {
+				source = testPricingSource{
+					"us-east-1/m5.xlarge":  {Amount: 0.192, Currency: "USD"},
+					"us-east-1/m5.2xlarge": {Amount: 0.35, Currency: "EUR"},
+				}
+			}
+			estimate, err := Estimate(source, "aws", test.region, test.instances)
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedEstimate, estimate, "unexpected estimate")
+		})
+	}
+}
+
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "0.576", FormatCost(0.192*3))
+	assert.Equal(t, "1.2346", FormatCost(1.23456))
+	assert.Equal(t, "0", FormatCost(0))
+}
+
+// testPricingSource maps region/instanceType keys to prices.
+type testPricingSource map[string]*Price
+
+func (s testPricingSource) HourlyPrice(platform, region, instanceType string) (*Price, error) {
+	return s[region+"/"+instanceType], nil
+}
+
+func testConfigMap(prices string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.DefaultHiveNamespace,
+			Name:      constants.ClusterPricingConfigMapName,
+		},
+		Data: map[string]string{pricesDataEntryName: prices},
+	}
+}
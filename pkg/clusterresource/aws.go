@@ -33,6 +33,10 @@ type AWSCloudBuilder struct {
 	UserTags map[string]string
 	// Region is the AWS region to which to install the cluster
 	Region string
+	// FallbackRegions are the regions where the install is retried when Region lacks capacity.
+	FallbackRegions []string
+	// ServiceEndpoints are custom endpoints which override the default AWS service endpoints.
+	ServiceEndpoints []hivev1aws.ServiceEndpoint
 }
 
 func NewAWSCloudBuilderFromSecret(credsSecret *corev1.Secret) *AWSCloudBuilder {
@@ -72,8 +76,10 @@ func (p *AWSCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
 			},
-			Region:   p.Region,
-			UserTags: p.UserTags,
+			Region:           p.Region,
+			FallbackRegions:  p.FallbackRegions,
+			UserTags:         p.UserTags,
+			ServiceEndpoints: p.ServiceEndpoints,
 		},
 	}
 }
@@ -97,6 +103,12 @@ func (p *AWSCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 			Region: p.Region,
 		},
 	}
+	for _, e := range p.ServiceEndpoints {
+		ic.Platform.AWS.ServiceEndpoints = append(ic.Platform.AWS.ServiceEndpoints, awsinstallertypes.ServiceEndpoint{
+			Name: e.Name,
+			URL:  e.URL,
+		})
+	}
 
 	// Used for both control plane and workers.
 	mpp := &awsinstallertypes.MachinePool{
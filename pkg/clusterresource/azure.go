@@ -31,6 +31,9 @@ type AzureCloudBuilder struct {
 
 	// Region is the Azure region to which to install the cluster.
 	Region string
+
+	// FallbackRegions are the regions where the install is retried when Region lacks capacity.
+	FallbackRegions []string
 }
 
 func NewAzureCloudBuilderFromSecret(credsSecret *corev1.Secret) *AzureCloudBuilder {
@@ -68,6 +71,7 @@ func (p *AzureCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 				Name: p.CredsSecretName(o),
 			},
 			Region:                      p.Region,
+			FallbackRegions:             p.FallbackRegions,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
 		},
 	}
@@ -32,6 +32,10 @@ type Builder struct {
 	// Namespace where the ClusterDeployment and all associated artifacts will be created.
 	Namespace string
 
+	// CreateNamespace generates the Namespace, labeled so that Hive deletes it once the ClusterDeployment has been
+	// deleted and deprovisioned.
+	CreateNamespace bool
+
 	// Labels are labels to be added to the ClusterDeployment.
 	Labels map[string]string
 
@@ -61,10 +65,19 @@ type Builder struct {
 	// WorkerNodesCount is the number of worker nodes to create in the cluster initially.
 	WorkerNodesCount int64
 
+	// ControlPlaneNodesCount is the number of control plane nodes of the cluster, 3 if unset. A single-node cluster
+	// has 1 control plane node, which runs the workloads of the cluster, and no worker nodes.
+	ControlPlaneNodesCount int64
+
 	// ManageDNS can be set to true to enable Hive's automatic DNS zone creation and forwarding. (assuming
 	// this is properly configured in HiveConfig)
 	ManageDNS bool
 
+	// ManagedSubdomain can be set to true along with ManageDNS to use the subdomain of BaseDomain named after the
+	// cluster as the base domain of the cluster, so that each cluster gets its own managed DNS zone without planning
+	// a base domain per cluster.
+	ManagedSubdomain bool
+
 	// DeleteAfter is the duration after which the cluster should be automatically destroyed, relative to
 	// creationTimestamp. Stored as an annotation on the ClusterDeployment.
 	DeleteAfter string
@@ -105,6 +118,14 @@ type Builder struct {
 	// manifests dir before launching create-cluster.
 	InstallerManifests map[string][]byte
 
+	// CredentialsMode is the mode in which the cloud credential operator of the cluster provides the credentials of
+	// its components.
+	CredentialsMode hivev1.CredentialsMode
+
+	// CredentialsManifests is a map of filename strings to bytes for the pre-created credentials manifests to inject
+	// into the installers manifests dir in the Manual credentials mode.
+	CredentialsManifests map[string][]byte
+
 	// ImageSet is the ClusterImageSet to use for this cluster.
 	ImageSet string
 
@@ -137,6 +158,12 @@ func (o *Builder) Validate() error {
 	if o.CloudBuilder == nil {
 		return fmt.Errorf("no CloudBuilder configured for this Builder")
 	}
+	if o.ManagedSubdomain && !o.ManageDNS {
+		return fmt.Errorf("ManagedSubdomain requires ManageDNS")
+	}
+	if o.CreateNamespace && len(o.Namespace) == 0 {
+		return fmt.Errorf("namespace is required to create the namespace")
+	}
 	if len(o.ImageSet) > 0 && len(o.ReleaseImage) > 0 {
 		return fmt.Errorf("cannot set both ImageSet and ReleaseImage")
 	}
@@ -162,6 +189,13 @@ func (o *Builder) Validate() error {
 		}
 	}
 
+	switch controlPlaneNodes := o.controlPlaneNodesCount(); {
+	case controlPlaneNodes != 1 && controlPlaneNodes < 3:
+		return fmt.Errorf("ControlPlaneNodesCount must be 1 or at least 3")
+	case controlPlaneNodes == 1 && o.WorkerNodesCount != 0:
+		return fmt.Errorf("WorkerNodesCount must be 0 for a single-node cluster")
+	}
+
 	if len(o.AdditionalTrustBundle) > 0 {
 		if err := validate.CABundle(o.AdditionalTrustBundle); err != nil {
 			return fmt.Errorf("AdditionalTrustBundle is not valid: %s", err.Error())
@@ -179,9 +213,14 @@ func (o *Builder) Build() ([]runtime.Object, error) {
 	}
 
 	var allObjects []runtime.Object
+	if o.CreateNamespace {
+		allObjects = append(allObjects, o.generateNamespace())
+	}
 	allObjects = append(allObjects, o.generateClusterDeployment())
 
-	if mp := o.generateMachinePool(); mp != nil && !o.SkipMachinePools {
+	// A cluster without worker nodes, such as a single-node or a compact cluster, runs its workloads on its control
+	// plane nodes and has no worker machines to manage.
+	if !o.SkipMachinePools && o.hasWorkers() {
 		allObjects = append(allObjects, o.generateMachinePool())
 	}
 
@@ -223,6 +262,10 @@ func (o *Builder) Build() ([]runtime.Object, error) {
 		allObjects = append(allObjects, o.generateInstallerManifestsConfigMap())
 	}
 
+	if o.CredentialsManifests != nil {
+		allObjects = append(allObjects, o.generateCredentialsManifestsSecret())
+	}
+
 	if o.Adopt {
 		allObjects = append(allObjects, o.generateAdminKubeconfigSecret())
 		if o.AdoptAdminUsername != "" {
@@ -233,6 +276,22 @@ func (o *Builder) Build() ([]runtime.Object, error) {
 	return allObjects, nil
 }
 
+// generateNamespace returns the Namespace of the cluster, labeled so that it is deleted with the cluster.
+func (o *Builder) generateNamespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: o.Namespace,
+			Labels: map[string]string{
+				constants.ClusterNamespaceLabel: o.Name,
+			},
+		},
+	}
+}
+
 func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 	cd := &hivev1.ClusterDeployment{
 		TypeMeta: metav1.TypeMeta{
@@ -247,7 +306,7 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		},
 		Spec: hivev1.ClusterDeploymentSpec{
 			ClusterName:  o.Name,
-			BaseDomain:   o.BaseDomain,
+			BaseDomain:   o.clusterBaseDomain(),
 			ManageDNS:    o.ManageDNS,
 			Provisioning: &hivev1.Provisioning{},
 		},
@@ -278,7 +337,7 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.Ingress = []hivev1.ClusterIngress{
 			{
 				Name:               "default",
-				Domain:             fmt.Sprintf("apps.%s.%s", o.Name, o.BaseDomain),
+				Domain:             fmt.Sprintf("apps.%s.%s", o.Name, o.clusterBaseDomain()),
 				ServingCertificate: "serving-cert",
 			},
 		}
@@ -312,6 +371,13 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		}
 	}
 
+	cd.Spec.Provisioning.CredentialsMode = o.CredentialsMode
+	if o.CredentialsManifests != nil {
+		cd.Spec.Provisioning.CredentialsManifestsSecretRef = &corev1.LocalObjectReference{
+			Name: o.getCredentialsManifestsSecretName(),
+		}
+	}
+
 	if o.ReleaseImage != "" {
 		cd.Spec.Provisioning.ReleaseImage = o.ReleaseImage
 	} else if o.ImageSet != "" {
@@ -333,7 +399,7 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 			APIVersion: installertypes.InstallConfigVersion,
 		},
 		SSHKey:     o.SSHPublicKey,
-		BaseDomain: o.BaseDomain,
+		BaseDomain: o.clusterBaseDomain(),
 		Networking: &installertypes.Networking{
 			NetworkType:    "OpenShiftSDN",
 			ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
@@ -351,7 +417,7 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 		},
 		ControlPlane: &installertypes.MachinePool{
 			Name:     "master",
-			Replicas: pointer.Int64Ptr(3),
+			Replicas: pointer.Int64Ptr(o.controlPlaneNodesCount()),
 		},
 		Compute: []installertypes.MachinePool{
 			{
@@ -390,7 +456,7 @@ func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing installconfigtemplate: %s", err.Error())
 	}
-	ic.BaseDomain = o.BaseDomain
+	ic.BaseDomain = o.clusterBaseDomain()
 	ic.MetaData.Name = o.Name
 
 	d, err := yaml.Marshal(ic)
@@ -436,6 +502,44 @@ func (o *Builder) generateMachinePool() *hivev1.MachinePool {
 	return mp
 }
 
+// clusterBaseDomain returns the base domain of the cluster, the subdomain of BaseDomain named after the cluster when
+// ManagedSubdomain is set.
+func (o *Builder) clusterBaseDomain() string {
+	if o.ManagedSubdomain {
+		return fmt.Sprintf("%s.%s", o.Name, o.BaseDomain)
+	}
+	return o.BaseDomain
+}
+
+func (o *Builder) controlPlaneNodesCount() int64 {
+	if o.ControlPlaneNodesCount == 0 {
+		return 3
+	}
+	return o.ControlPlaneNodesCount
+}
+
+// hasWorkers returns true if the cluster has worker nodes. The worker nodes of a cluster generated from an
+// InstallConfigTemplate are those of the compute pools of the template.
+func (o *Builder) hasWorkers() bool {
+	if o.InstallConfigTemplate == "" {
+		return o.WorkerNodesCount > 0
+	}
+	template := struct {
+		Compute []struct {
+			Replicas *int64 `json:"replicas"`
+		} `json:"compute"`
+	}{}
+	if err := yaml.Unmarshal([]byte(o.InstallConfigTemplate), &template); err != nil || len(template.Compute) == 0 {
+		return true
+	}
+	for _, pool := range template.Compute {
+		if pool.Replicas == nil || *pool.Replicas > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *Builder) getInstallConfigSecretName() string {
 	return fmt.Sprintf("%s-install-config", o.Name)
 }
@@ -523,6 +627,21 @@ func (o *Builder) generateInstallerManifestsConfigMap() *corev1.ConfigMap {
 	}
 }
 
+func (o *Builder) generateCredentialsManifestsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.getCredentialsManifestsSecretName(),
+			Namespace: o.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: o.CredentialsManifests,
+	}
+}
+
 func (o *Builder) generateAdoptedAdminPasswordSecret() *corev1.Secret {
 	if o.AdoptAdminUsername == "" {
 		return nil
@@ -543,6 +662,10 @@ func (o *Builder) generateAdoptedAdminPasswordSecret() *corev1.Secret {
 func (o *Builder) getManifestsConfigMapName() string {
 	return fmt.Sprintf("%s-manifests", o.Name)
 }
+
+func (o *Builder) getCredentialsManifestsSecretName() string {
+	return fmt.Sprintf("%s-credentials-manifests", o.Name)
+}
 func (o *Builder) getAdoptAdminPasswordSecretName() string {
 	return fmt.Sprintf("%s-adopted-admin-password", o.Name)
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	installertypes "github.com/openshift/installer/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -166,6 +168,41 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, awsInstanceType, workerPool.Spec.Platform.AWS.InstanceType)
 			},
 		},
+		{
+			name: "AWS cluster in Manual credentials mode",
+			builder: func() *Builder {
+				awsBuilder := createAWSClusterBuilder()
+				awsBuilder.CredentialsMode = hivev1.ManualCredentialsMode
+				awsBuilder.CredentialsManifests = map[string][]byte{
+					"openshift-ingress-cloud-credentials.yaml": []byte("fake-credentials"),
+				}
+				return awsBuilder
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, hivev1.ManualCredentialsMode, cd.Spec.Provisioning.CredentialsMode)
+
+				credentialsManifestsSecret := findSecret(allObjects, fmt.Sprintf("%s-credentials-manifests", clusterName))
+				require.NotNil(t, credentialsManifestsSecret)
+				require.NotNil(t, cd.Spec.Provisioning.CredentialsManifestsSecretRef)
+				assert.Equal(t, credentialsManifestsSecret.Name, cd.Spec.Provisioning.CredentialsManifestsSecretRef.Name)
+				assert.Equal(t, "fake-credentials", string(credentialsManifestsSecret.Data["openshift-ingress-cloud-credentials.yaml"]))
+			},
+		},
+		{
+			name: "AWS cluster with its namespace",
+			builder: func() *Builder {
+				awsBuilder := createAWSClusterBuilder()
+				awsBuilder.CreateNamespace = true
+				return awsBuilder
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				ns, ok := allObjects[0].(*corev1.Namespace)
+				require.True(t, ok, "expected the namespace to be the first object")
+				assert.Equal(t, namespace, ns.Name)
+				assert.Equal(t, clusterName, ns.Labels[constants.ClusterNamespaceLabel])
+			},
+		},
 		{
 			name: "adopt AWS cluster",
 			builder: func() *Builder {
@@ -314,6 +351,155 @@ func TestBuildClusterResources(t *testing.T) {
 
 }
 
+func TestBuildSingleNodeClusterResources(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	b := createAWSClusterBuilder()
+	b.ControlPlaneNodesCount = 1
+	b.WorkerNodesCount = 0
+	allObjects, err := b.Build()
+	require.NoError(t, err)
+
+	assert.Nil(t, findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker")), "expected no worker machine pool")
+
+	installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+	require.NotNil(t, installConfigSecret)
+	installConfig := &installertypes.InstallConfig{}
+	require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+	if assert.NotNil(t, installConfig.ControlPlane.Replicas) {
+		assert.Equal(t, int64(1), *installConfig.ControlPlane.Replicas, "unexpected control plane replicas")
+	}
+	if assert.Len(t, installConfig.Compute, 1) && assert.NotNil(t, installConfig.Compute[0].Replicas) {
+		assert.Equal(t, int64(0), *installConfig.Compute[0].Replicas, "unexpected compute replicas")
+	}
+}
+
+func TestBuildManagedSubdomainClusterResources(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	b := createAWSClusterBuilder()
+	b.ManagedSubdomain = true
+	assert.Error(t, b.Validate(), "expected managed subdomain to require managed DNS")
+	b.ManageDNS = true
+	require.NoError(t, b.Validate())
+	allObjects, err := b.Build()
+	require.NoError(t, err)
+
+	expectedBaseDomain := fmt.Sprintf("%s.%s", clusterName, baseDomain)
+	cd := findClusterDeployment(allObjects, clusterName)
+	require.NotNil(t, cd)
+	assert.True(t, cd.Spec.ManageDNS, "expected managed DNS")
+	assert.Equal(t, expectedBaseDomain, cd.Spec.BaseDomain, "unexpected base domain")
+
+	installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+	require.NotNil(t, installConfigSecret)
+	installConfig := &installertypes.InstallConfig{}
+	require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+	assert.Equal(t, expectedBaseDomain, installConfig.BaseDomain, "unexpected install config base domain")
+}
+
+func TestBuildWorkerMachinePool(t *testing.T) {
+	tests := []struct {
+		name                 string
+		workers              int64
+		installConfig        string
+		expectWorkerPool     bool
+		expectedPoolReplicas int64
+	}{
+		{
+			name:                 "workers",
+			workers:              3,
+			expectWorkerPool:     true,
+			expectedPoolReplicas: 3,
+		},
+		{
+			name: "compact cluster",
+		},
+		{
+			name:                 "template with compute replicas",
+			workers:              3,
+			installConfig:        strings.Replace(fakeInstallConfigYaml, "  name: worker\n", "  name: worker\n  replicas: 2\n", 1),
+			expectWorkerPool:     true,
+			expectedPoolReplicas: 3,
+		},
+		{
+			name:                 "template with default compute replicas",
+			workers:              3,
+			installConfig:        fakeInstallConfigYaml,
+			expectWorkerPool:     true,
+			expectedPoolReplicas: 3,
+		},
+		{
+			name:          "compact cluster template",
+			workers:       3,
+			installConfig: strings.Replace(fakeInstallConfigYaml, "  name: worker\n", "  name: worker\n  replicas: 0\n", 1),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apis.AddToScheme(scheme.Scheme)
+			b := createAWSClusterBuilder()
+			b.WorkerNodesCount = test.workers
+			b.InstallConfigTemplate = test.installConfig
+			allObjects, err := b.Build()
+			require.NoError(t, err)
+			workerPool := findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker"))
+			if !test.expectWorkerPool {
+				assert.Nil(t, workerPool, "expected no worker machine pool")
+				return
+			}
+			if assert.NotNil(t, workerPool, "expected a worker machine pool") {
+				assert.Equal(t, &test.expectedPoolReplicas, workerPool.Spec.Replicas, "unexpected worker machine pool replicas")
+			}
+		})
+	}
+}
+
+func TestValidateNodesCounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		controlPlane  int64
+		workers       int64
+		expectedError string
+	}{
+		{
+			name:    "default control plane",
+			workers: 3,
+		},
+		{
+			name:         "compact cluster",
+			controlPlane: 3,
+		},
+		{
+			name:         "single-node cluster",
+			controlPlane: 1,
+		},
+		{
+			name:          "two control plane nodes",
+			controlPlane:  2,
+			workers:       3,
+			expectedError: "ControlPlaneNodesCount must be 1 or at least 3",
+		},
+		{
+			name:          "single-node cluster with workers",
+			controlPlane:  1,
+			workers:       1,
+			expectedError: "WorkerNodesCount must be 0 for a single-node cluster",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := createAWSClusterBuilder()
+			b.ControlPlaneNodesCount = test.controlPlane
+			b.WorkerNodesCount = test.workers
+			err := b.Validate()
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
 func findSecret(allObjects []runtime.Object, name string) *corev1.Secret {
 	for _, ro := range allObjects {
 		obj, ok := ro.(*corev1.Secret)
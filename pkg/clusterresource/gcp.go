@@ -32,6 +32,9 @@ type GCPCloudBuilder struct {
 
 	// Region is the GCP region to which to install the cluster.
 	Region string
+
+	// FallbackRegions are the regions where the install is retried when Region lacks capacity.
+	FallbackRegions []string
 }
 
 func NewGCPCloudBuilderFromSecret(credsSecret *corev1.Secret) (*GCPCloudBuilder, error) {
@@ -73,7 +76,8 @@ func (p *GCPCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
 			},
-			Region: p.Region,
+			Region:          p.Region,
+			FallbackRegions: p.FallbackRegions,
 		},
 	}
 }
@@ -63,7 +63,11 @@ func (p *OpenStackCloudBuilder) generateCloudCertificatesSecret(o *Builder) *cor
 func (p *OpenStackCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 	return hivev1.Platform{
 		OpenStack: &hivev1openstack.Platform{
-			Cloud: p.Cloud,
+			Cloud:              p.Cloud,
+			ExternalNetwork:    p.ExternalNetwork,
+			ComputeFlavor:      p.ComputeFlavor,
+			ControlPlaneFlavor: p.MasterFlavor,
+			APIFloatingIP:      p.APIFloatingIP,
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
 			},
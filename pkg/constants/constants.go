@@ -11,6 +11,7 @@ const (
 	PlatformBaremetal = "baremetal"
 	PlatformGCP       = "gcp"
 	PlatformOpenStack = "openstack"
+	PlatformOvirt     = "ovirt"
 	PlatformUnknown   = "unknown"
 	PlatformVSphere   = "vsphere"
 
@@ -41,6 +42,13 @@ const (
 	// ClusterDeploymentNameLabel is the label that is used to identify a relationship to a given cluster deployment object.
 	ClusterDeploymentNameLabel = "hive.openshift.io/cluster-deployment-name"
 
+	// ClusterDeploymentNamespaceLabel is the label that is used to identify the namespace of the cluster deployment
+	// object that a cluster-scoped object relates to.
+	ClusterDeploymentNamespaceLabel = "hive.openshift.io/cluster-deployment-namespace"
+
+	// ClusterOperationLabel is the label that is used to identify the operation recorded by a cluster operation log.
+	ClusterOperationLabel = "hive.openshift.io/cluster-operation"
+
 	// ClusterDeprovisionNameLabel is the label that is used to identify a relationship to a given cluster deprovision object.
 	ClusterDeprovisionNameLabel = "hive.openshift.io/cluster-deprovision-name"
 
@@ -49,9 +57,20 @@ const (
 
 	// ClusterPoolNameLabel is the label that is used to signal that a namespace was created to house a
 	// ClusterDeployment created for a ClusterPool. The label is used to reap namespaces after the ClusterDeployment
-	// has been deleted.
+	// has been deleted. The label is also set on the ClusterDeployments created for a ClusterPool, so that they can be
+	// selected by SelectorSyncSets.
 	ClusterPoolNameLabel = "hive.openshift.io/cluster-pool-name"
 
+	// ClusterPoolNamespaceLabel is the label that is set on the ClusterDeployments created for a ClusterPool to the
+	// namespace of the ClusterPool.
+	ClusterPoolNamespaceLabel = "hive.openshift.io/cluster-pool-namespace"
+
+	// ClusterNamespaceLabel is the label that is used to signal that a namespace was created to house a single
+	// ClusterDeployment, such as by hiveutil create-cluster --create-namespace. The value is the name of the
+	// ClusterDeployment. Like namespaces labeled with ClusterPoolNameLabel, the namespace is reaped after the
+	// ClusterDeployment has been deleted.
+	ClusterNamespaceLabel = "hive.openshift.io/cluster-namespace"
+
 	// SyncSetNameLabel is the label that is used to identify a relationship to a given syncset object.
 	SyncSetNameLabel = "hive.openshift.io/syncset-name"
 
@@ -76,6 +95,15 @@ const (
 	// JobTypeProvision is used as a value of JobTypeLabel that says the Job is specifically running the provisioner.
 	JobTypeProvision = "provision"
 
+	// JobTypePostInstallCheck is used as a value of JobTypeLabel that says the Job is running a post-install check.
+	JobTypePostInstallCheck = "post-install-check"
+
+	// JobTypeHook is used as a value of JobTypeLabel that says the Job is running a lifecycle hook of a ClusterDeployment.
+	JobTypeHook = "hook"
+
+	// HookNameLabel is the label on the Job of a lifecycle hook with the name of the hook.
+	HookNameLabel = "hive.openshift.io/hook-name"
+
 	// DNSZoneTypeLabel is the label that is used to identify what a DNSZone is being used for.
 	DNSZoneTypeLabel = "hive.openshift.io/dnszone-type"
 
@@ -94,6 +122,15 @@ const (
 	// SecretTypeKubeAdminCreds is used as a value of SecretTypeLabel that says the secret is specifically used for storing kubeadmin credentials.
 	SecretTypeKubeAdminCreds = "kubeadmincreds"
 
+	// SecretTypeMetadataJSON is used as a value of SecretTypeLabel that says the secret is specifically used for storing the metadata.json of the installer.
+	SecretTypeMetadataJSON = "metadata-json"
+
+	// SecretTypeTerraformState is used as a value of SecretTypeLabel that says the secret is specifically used for storing the terraform state of the installer.
+	SecretTypeTerraformState = "terraform-state"
+
+	// MetadataJSONSecretKey is the key of the metadata.json of the installer in the secrets of type SecretTypeMetadataJSON.
+	MetadataJSONSecretKey = "metadata.json"
+
 	// SyncSetTypeLabel is the label that is used to identify what a SyncSet is being used for.
 	SyncSetTypeLabel = "hive.openshift.io/syncset-type"
 
@@ -117,12 +154,20 @@ const (
 	// The default is defined above.
 	HiveNamespaceEnvVar = "HIVE_NS"
 
+	// HiveAdmissionServingCertSecretName is the name of the secret in the hive namespace containing the serving
+	// certificate of hiveadmission.
+	HiveAdmissionServingCertSecretName = "hiveadmission-serving-cert"
+
 	// CheckpointName is the name of the object in each namespace in which the namespace's backup information is stored.
 	CheckpointName = "hive"
 
 	// SyncsetPauseAnnotation is a annotation used by clusterDeployment, if it's true, then we will disable syncing to a specific cluster
 	SyncsetPauseAnnotation = "hive.openshift.io/syncset-pause"
 
+	// SyncsetReportOnlyAnnotation is an annotation used by clusterDeployment, if it's true, then the syncsets for the
+	// cluster are not applied and any drift of the cluster from the syncsets is reported instead.
+	SyncsetReportOnlyAnnotation = "hive.openshift.io/syncset-report-only"
+
 	// HiveManagedLabel is a label added to any resources we sync to the remote cluster to help identify that they are
 	// managed by Hive, and any manual changes may be undone the next time the resource is reconciled.
 	HiveManagedLabel = "hive.openshift.io/managed"
@@ -141,10 +186,67 @@ const (
 	// for the cluster provision to complete by running `openshift-install wait-for install-complete` command.
 	WaitForInstallCompleteExecutionsAnnotation = "hive.openshift.io/wait-for-install-complete-executions"
 
+	// GatherLogsAnnotation is an annotation used on ClusterDeployments to request the install pod to gather the logs
+	// of the bootstrap and master nodes while the cluster is provisioning, and upload them to the configured log
+	// store. The value identifies the request, set a new value to request the logs again. Example: a timestamp.
+	GatherLogsAnnotation = "hive.openshift.io/gather-logs"
+
+	// GatherLogsCompletedAnnotation is an annotation set on ClusterProvisions by the install pod once it has handled
+	// the request of the GatherLogsAnnotation of the ClusterDeployment. The value is the value of that annotation.
+	GatherLogsCompletedAnnotation = "hive.openshift.io/gather-logs-completed"
+
+	// InstallStageTimeoutLogMessage starts the line the install pod appends to the install log when it aborts an
+	// install whose stage exceeded its timeout. The rest of the line tells which stage timed out.
+	InstallStageTimeoutLogMessage = "Hive aborted the install: "
+
 	// ProtectedDeleteAnnotation is an annotation used on ClusterDeployments to indicate that the ClusterDeployment
 	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment.
 	ProtectedDeleteAnnotation = "hive.openshift.io/protected-delete"
 
+	// PowerStateChangedByAnnotation is set by the admission webhook on ClusterDeployments to the user who last changed
+	// their power state, so that the user is recorded in the ClusterOperationLogs of hibernations.
+	PowerStateChangedByAnnotation = "hive.openshift.io/power-state-changed-by"
+
+	// ForceDeprovisionAnnotation is an annotation used on ClusterDeployments to deprovision the cluster even though it
+	// runs protected workloads.
+	ForceDeprovisionAnnotation = "hive.openshift.io/force-deprovision"
+
+	// ProtectedWorkloadsEnvVar is the name of the environment variable used to tell the controller manager which
+	// namespaces of a cluster hold protected workloads. The value is a JSON ProtectedWorkloadsConfig.
+	ProtectedWorkloadsEnvVar = "HIVE_PROTECTED_WORKLOADS"
+
+	// ReleaseImageVerificationEnvVar is the name of the environment variable used to tell the controller manager
+	// which signature stores release images are verified against. The value is a JSON ReleaseImageVerificationConfig.
+	ReleaseImageVerificationEnvVar = "HIVE_RELEASE_IMAGE_VERIFICATION"
+
+	// ReleaseImageVerificationKeysDir is the directory where the operator mounts the ConfigMap holding the public keys
+	// which the signatures of release images are verified with.
+	ReleaseImageVerificationKeysDir = "/etc/hive/release-verification-keys"
+
+	// PlatformProxiesEnvVar is the name of the environment variable used to tell the controller manager which proxy
+	// the install and uninstall jobs of the clusters of a platform use. The value is a JSON list of
+	// PlatformProxyConfigs.
+	PlatformProxiesEnvVar = "HIVE_PLATFORM_PROXIES"
+
+	// ProxyTrustedCASecretEnvVar is the name of the environment variable used to tell the controller manager the name
+	// of the secret in the hive namespace with the certificate authorities to trust when connecting through the proxy,
+	// so that it passes them to the install and uninstall jobs.
+	ProxyTrustedCASecretEnvVar = "HIVE_PROXY_TRUSTED_CA_SECRET"
+
+	// ProxyTrustedCAKey is the key of the certificate authorities in the secret referenced by the TrustedCASecretRef of
+	// the proxy.
+	ProxyTrustedCAKey = "ca.crt"
+
+	// ProxyTrustedCAMountPath is the directory where the certificate authorities of the proxy are mounted in the pods
+	// of Hive and its jobs.
+	ProxyTrustedCAMountPath = "/etc/hive/proxy-ca"
+
+	// HTTPProxyEnvVar, HTTPSProxyEnvVar and NoProxyEnvVar are the names of the standard environment variables which
+	// configure the proxy of outbound connections.
+	HTTPProxyEnvVar  = "HTTP_PROXY"
+	HTTPSProxyEnvVar = "HTTPS_PROXY"
+	NoProxyEnvVar    = "NO_PROXY"
+
 	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager whether
 	// protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
@@ -159,6 +261,85 @@ const (
 	// An incoming status indicates that the resource is on the destination side of an in-progress relocate.
 	RelocateAnnotation = "hive.openshift.io/relocate"
 
+	// FatalProvisionFailuresEnvVar is the name of the environment variable used to tell the controller manager which
+	// install failures are fatal and must not be retried. The value is a JSON list of FatalProvisionFailures.
+	FatalProvisionFailuresEnvVar = "FATAL_PROVISION_FAILURES"
+
+	// OperationLogRetentionEnvVar is the name of the environment variable used to tell the controller manager how
+	// long cluster operation logs are kept. The value is a duration string.
+	OperationLogRetentionEnvVar = "OPERATION_LOG_RETENTION"
+
+	// DeprovisionStuckThresholdEnvVar is the name of the environment variable used to tell the controller manager how
+	// long a deleted cluster deployment can wait for its cluster to be deprovisioned before it is reported as stuck.
+	// The value is a duration string.
+	DeprovisionStuckThresholdEnvVar = "DEPROVISION_STUCK_THRESHOLD"
+
+	// WatchedNamespacesEnvVar is the name of the environment variable used to tell the controller manager which
+	// namespaces to watch in addition to the Hive namespace. The value is a comma-separated list of namespaces. All
+	// namespaces are watched when it is not set.
+	WatchedNamespacesEnvVar = "HIVE_WATCHED_NAMESPACES"
+
+	// DefaultClusterImageSetEnvVar is the name of the environment variable used to tell the admission webhooks which
+	// ClusterImageSet to use for ClusterDeployments which do not specify a release image.
+	DefaultClusterImageSetEnvVar = "HIVE_DEFAULT_CLUSTER_IMAGE_SET"
+
+	// SecretEncryptionEnvVar is the name of the environment variable used to tell the controllers how to encrypt and
+	// decrypt the admin secrets of clusters. The value is a JSON SecretEncryptionConfig.
+	SecretEncryptionEnvVar = "HIVE_SECRET_ENCRYPTION"
+
+	// TerraformStatePolicyEnvVar is the name of the environment variable used to tell the controllers and the install
+	// jobs what becomes of the terraform state of the installer. The value is a TerraformStatePolicy.
+	TerraformStatePolicyEnvVar = "HIVE_TERRAFORM_STATE_POLICY"
+
+	// TerraformStatePublicKeyEnvVar is the name of the environment variable used to pass the PEM encoded RSA public
+	// key which the install jobs encrypt the terraform state with, when the terraform state policy is
+	// StoreEncrypted.
+	TerraformStatePublicKeyEnvVar = "HIVE_TERRAFORM_STATE_PUBLIC_KEY"
+
+	// TerraformStateKeySecretName is the name of the secret of the hive namespace holding the RSA private key which
+	// decrypts the data keys of the terraform states encrypted by the install jobs. The secret is itself encrypted
+	// with the key management service of SecretEncryption.
+	TerraformStateKeySecretName = "hive-terraform-state-key"
+
+	// JobHistoryEnvVar is the name of the environment variable used to tell the controllers how many install
+	// attempts to retain and for how long to retain completed jobs. The value is a JSON JobHistoryConfig.
+	JobHistoryEnvVar = "HIVE_JOB_HISTORY"
+
+	// MetricsConfigEnvVar is the name of the environment variable used to tell the controllers which additional
+	// labels to add to the metrics they publish. The value is a JSON MetricsConfig.
+	MetricsConfigEnvVar = "HIVE_METRICS_CONFIG"
+
+	// PodSchedulingEnvVar is the name of the environment variable used to tell the controllers on which nodes to
+	// schedule the pods of the jobs they create. The value is a JSON PodSchedulingConfig.
+	PodSchedulingEnvVar = "HIVE_POD_SCHEDULING"
+
+	// PropagatedLabelsEnvVar is the name of the environment variable used to tell the controllers and install jobs which
+	// labels of ClusterDeployments to copy to the objects and cloud resources created for the clusters. The value is a
+	// JSON list of label keys.
+	PropagatedLabelsEnvVar = "HIVE_PROPAGATED_LABELS"
+
+	// OTLPEndpointEnvVar is the name of the standard OpenTelemetry environment variable used to tell the controllers
+	// and install jobs where to export their traces. Tracing is disabled when it is not set.
+	OTLPEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// PostInstallChecksEnvVar is the name of the environment variable used to tell the controllers which checks to
+	// run after the installer completes for the clusters which do not specify their own. The value is a JSON list of
+	// PostInstallChecks.
+	PostInstallChecksEnvVar = "HIVE_POST_INSTALL_CHECKS"
+
+	// SpokeTokenExpirationEnvVar is the name of the environment variable used to tell the controllers to connect to
+	// the clusters with ServiceAccount tokens. The value is the expiration of the tokens as a duration string, or
+	// empty for the default expiration.
+	SpokeTokenExpirationEnvVar = "SPOKE_TOKEN_EXPIRATION"
+
+	// EncryptionProviderAnnotation is the annotation set on secrets whose data is encrypted. The value is the name
+	// of the key management service that encrypted the data key of the secret.
+	EncryptionProviderAnnotation = "hive.openshift.io/encryption-provider"
+
+	// EncryptedDataKeyAnnotation is the annotation holding the encrypted data key of a secret whose data is
+	// encrypted, encoded in base64.
+	EncryptedDataKeyAnnotation = "hive.openshift.io/encrypted-data-key"
+
 	// ManagedDomainsFileEnvVar if present, points to a simple text
 	// file that includes a valid managed domain per line. Cluster deployments
 	// requesting that their domains be managed must have a base domain
@@ -194,6 +375,18 @@ const (
 	// a fake install.
 	FakeClusterInstallEnvVar = "FAKE_INSTALL"
 
+	// MachineImageEnvVar is the environment variable Hive will set for the installmanager pod with the machine image
+	// to boot the machines of the cluster from, when it overrides the image of the release.
+	MachineImageEnvVar = "HIVE_MACHINE_IMAGE"
+
+	// MachineImagesConfigMapName is the name of the optional ConfigMap in the Hive namespace which lists the machine
+	// images to use for the releases.
+	MachineImagesConfigMapName = "machine-images"
+
+	// ClusterPricingConfigMapName is the name of the optional ConfigMap in the Hive namespace which lists the hourly
+	// prices of the instance types used to estimate the cost of the clusters.
+	ClusterPricingConfigMapName = "cluster-pricing"
+
 	// ControlPlaneCertificateSuffix is the suffix used when naming objects having to do control plane certificates.
 	ControlPlaneCertificateSuffix = "cp-certs"
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -35,6 +37,21 @@ const (
 	hiveClaimOwnerRoleBindingName = "hive-claim-owner"
 )
 
+var (
+	metricClaimAssignmentDelaySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_clusterclaim_assignment_delay_seconds",
+			Help:    "Time between cluster claim creation and the assignment of a cluster from the pool to the claim.",
+			Buckets: []float64{10, 30, 60, 300, 600, 1200, 1800, 3600},
+		},
+		[]string{"namespace", "clusterpool"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricClaimAssignmentDelaySeconds)
+}
+
 // Add creates a new ClusterClaim Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -60,7 +77,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterclaim-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -390,7 +407,15 @@ func (r *ReconcileClusterClaim) reconcileForDeletedCluster(claim *hivev1.Cluster
 		"Assigned cluster has been deleted",
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
-	if changed {
+	conds, readyChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		conds,
+		hivev1.ClusterClaimReadyCondition,
+		corev1.ConditionFalse,
+		"ClusterDeleted",
+		"Assigned cluster has been deleted",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed || readyChanged {
 		claim.Status.Conditions = conds
 		if err := r.Status().Update(context.Background(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status")
@@ -408,6 +433,8 @@ func (r *ReconcileClusterClaim) reconcileForNewAssignment(claim *hivev1.ClusterC
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set claim for ClusterDeployment")
 		return reconcile.Result{}, err
 	}
+	metricClaimAssignmentDelaySeconds.WithLabelValues(claim.Namespace, claim.Spec.ClusterPoolName).
+		Observe(time.Since(claim.CreationTimestamp.Time).Seconds())
 	return r.reconcileForExistingAssignment(claim, cd, logger)
 }
 
@@ -431,24 +458,19 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 	statusChanged = statusChanged || changed
 
 	hc := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
-	if hc == nil || hc.Status == corev1.ConditionFalse {
-		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
-			conds,
-			hivev1.ClusterRunningCondition,
-			corev1.ConditionTrue,
-			"Running",
-			"Cluster is running",
-			controllerutils.UpdateConditionIfReasonOrMessageChange,
-		)
-		statusChanged = statusChanged || changed
-	} else {
+	runningStatus, runningReason, runningMessage := corev1.ConditionTrue, "Running", "Cluster is running"
+	if hc != nil && hc.Status != corev1.ConditionFalse {
 		log.Debug("waiting for cluster to be running")
+		runningStatus, runningReason, runningMessage = corev1.ConditionFalse, "Resuming", "Waiting for cluster to be running"
+	}
+	// Ready is the ClusterRunning condition, under the name that kubectl wait expects.
+	for _, conditionType := range []hivev1.ClusterClaimConditionType{hivev1.ClusterRunningCondition, hivev1.ClusterClaimReadyCondition} {
 		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
 			conds,
-			hivev1.ClusterRunningCondition,
-			corev1.ConditionFalse,
-			"Resuming",
-			"Waiting for cluster to be running",
+			conditionType,
+			runningStatus,
+			runningReason,
+			runningMessage,
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		)
 		statusChanged = statusChanged || changed
@@ -466,8 +488,7 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 
 func (r *ReconcileClusterClaim) reconcileForAssignmentConflict(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
 	logger.Info("claim assigned a cluster that has already been claimed by another ClusterClaim")
-	claim.Spec.Namespace = ""
-	claim.Status.Conditions = controllerutils.SetClusterClaimCondition(
+	conds := controllerutils.SetClusterClaimCondition(
 		claim.Status.Conditions,
 		hivev1.ClusterClaimPendingCondition,
 		corev1.ConditionTrue,
@@ -475,7 +496,14 @@ func (r *ReconcileClusterClaim) reconcileForAssignmentConflict(claim *hivev1.Clu
 		"Assigned cluster was claimed by a different ClusterClaim",
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
+	claim.Spec.Namespace = ""
 	if err := r.Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not clear the assignment of ClusterClaim")
+		return reconcile.Result{}, err
+	}
+	// The status is a subresource, so it is not changed by the update of the spec.
+	claim.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), claim); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
 		return reconcile.Result{}, err
 	}
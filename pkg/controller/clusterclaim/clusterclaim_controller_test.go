@@ -120,6 +120,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -147,6 +153,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -164,12 +176,20 @@ func TestReconcileClusterClaim(t *testing.T) {
 		{
 			name:  "deleted cluster",
 			claim: claimBuilder.Build(testclaim.WithCluster(clusterName)),
-			expectedConditions: []hivev1.ClusterClaimCondition{{
-				Type:    hivev1.ClusterClaimClusterDeletedCondition,
-				Status:  corev1.ConditionTrue,
-				Reason:  "ClusterDeleted",
-				Message: "Assigned cluster has been deleted",
-			}},
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimClusterDeletedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "ClusterDeleted",
+					Message: "Assigned cluster has been deleted",
+				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterDeleted",
+					Message: "Assigned cluster has been deleted",
+				},
+			},
 			expectAssignedClusterDeploymentDeleted: true,
 		},
 		{
@@ -204,6 +224,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 			expectRBAC: true,
 		},
@@ -302,6 +328,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -336,6 +368,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -371,6 +409,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -400,6 +444,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -429,6 +479,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -463,6 +519,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -500,6 +562,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -537,6 +605,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -575,6 +649,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -612,6 +692,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -650,6 +736,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 		},
 		{
@@ -690,6 +782,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:    hivev1.ClusterClaimReadyCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Resuming",
+					Message: "Waiting for cluster to be running",
+				},
 			},
 			expectRBAC:           true,
 			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
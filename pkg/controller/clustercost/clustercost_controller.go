@@ -0,0 +1,309 @@
+package clustercost
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/clustercost"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterCostControllerName
+
+	// estimateInterval is how often the cost of a cluster is estimated again, so that changes of the prices are
+	// picked up.
+	estimateInterval = time.Hour
+
+	installConfigSecretKey = "install-config.yaml"
+
+	// defaultControlPlaneReplicas is the number of control plane machines of the installer.
+	defaultControlPlaneReplicas = 3
+)
+
+// defaultControlPlaneInstanceTypes are the instance types of the control plane machines used by the installer when
+// the install-config does not set one.
+var defaultControlPlaneInstanceTypes = map[string]string{
+	constants.PlatformAWS:   "m5.xlarge",
+	constants.PlatformAzure: "Standard_D8s_v3",
+	constants.PlatformGCP:   "n1-standard-4",
+}
+
+// Add creates a new ClusterCost controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterCost which prices the instance types with the pricing ConfigMap of the
+// Hive namespace.
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterCost {
+	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
+	return &ReconcileClusterCost{
+		Client:        c,
+		logger:        log.WithField("controller", ControllerName),
+		pricingSource: clustercost.NewConfigMapPricingSource(c),
+	}
+}
+
+// AddToManager adds a new Controller to the controller manager
+func AddToManager(mgr manager.Manager, r *ReconcileClusterCost, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clustercost-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the MachinePools of the ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.MachinePool{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			pool, ok := a.Object.(*hivev1.MachinePool)
+			if !ok {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{
+				Namespace: pool.Namespace,
+				Name:      pool.Spec.ClusterDeploymentRef.Name,
+			}}}
+		}),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterCost{}
+
+// ReconcileClusterCost reconciles a ClusterDeployment to estimate the cost of running its machines
+type ReconcileClusterCost struct {
+	client.Client
+	logger        log.FieldLogger
+	pricingSource clustercost.PricingSource
+}
+
+// Reconcile estimates the hourly cost of the machines of an installed ClusterDeployment from the instance types of its
+// control plane and machine pools, and records it in the status of the ClusterDeployment. The ClusterDeployment is
+// requeued so that the estimate follows the changes of the prices.
+func (r *ReconcileClusterCost) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if cd.DeletionTimestamp != nil {
+		logger.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	}
+	if !cd.Spec.Installed {
+		logger.Debug("cluster deployment is not installed")
+		return reconcile.Result{}, nil
+	}
+	platform := clusterPlatform(cd)
+	if platform == "" {
+		logger.Debug("cost estimation is not supported on the platform of the cluster deployment")
+		return reconcile.Result{}, nil
+	}
+
+	instances, err := r.clusterInstances(cd, platform, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	estimate, err := clustercost.Estimate(r.pricingSource, platform, controllerutils.InstallRegion(cd), instances)
+	if err != nil {
+		logger.WithError(err).Error("could not estimate the cost of the cluster")
+		return reconcile.Result{}, err
+	}
+	if isHibernating(cd) {
+		estimate.HourlyCost = clustercost.FormatCost(0)
+	}
+
+	if !reflect.DeepEqual(cd.Status.CostEstimate, estimate) {
+		logger.WithField("hourlyCost", estimate.HourlyCost).WithField("currency", estimate.Currency).Info("updating cost estimate")
+		cd.Status.CostEstimate = estimate
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cost estimate")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: estimateInterval}, nil
+}
+
+// clusterInstances returns the counts of the instance types of the machines of the cluster: the control plane
+// machines of the install-config, and the machines of the machine pools of the cluster deployment.
+func (r *ReconcileClusterCost) clusterInstances(cd *hivev1.ClusterDeployment, platform string, logger log.FieldLogger) (map[string]int64, error) {
+	instances := map[string]int64{}
+
+	controlPlaneType, controlPlaneReplicas, err := r.controlPlaneInstances(cd, platform)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not determine the control plane machines")
+		return nil, err
+	}
+	instances[controlPlaneType] += controlPlaneReplicas
+
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list machine pools")
+		return nil, err
+	}
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name || pool.DeletionTimestamp != nil {
+			continue
+		}
+		instanceType := poolInstanceType(pool)
+		if instanceType == "" {
+			continue
+		}
+		if replicas := poolReplicas(pool); replicas > 0 {
+			instances[instanceType] += replicas
+		}
+	}
+	return instances, nil
+}
+
+// controlPlaneInstances returns the instance type and count of the control plane machines set in the install-config
+// of the cluster deployment, falling back to the control plane replicas of the cluster deployment and to the defaults
+// of the installer. The defaults are used for adopted clusters, which have no install-config.
+func (r *ReconcileClusterCost) controlPlaneInstances(cd *hivev1.ClusterDeployment, platform string) (string, int64, error) {
+	instanceType, replicas := defaultControlPlaneInstanceTypes[platform], int64(defaultControlPlaneReplicas)
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ControlPlaneReplicas != nil {
+		replicas = *cd.Spec.Provisioning.ControlPlaneReplicas
+	}
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef.Name == "" {
+		return instanceType, replicas, nil
+	}
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); {
+	case apierrors.IsNotFound(err):
+		return instanceType, replicas, nil
+	case err != nil:
+		return "", 0, errors.Wrap(err, "could not get install-config secret")
+	}
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretKey], installConfig); err != nil {
+		return "", 0, errors.Wrap(err, "could not unmarshal install-config")
+	}
+	if defaultType := installConfigDefaultInstanceType(installConfig); defaultType != "" {
+		instanceType = defaultType
+	}
+	if cp := installConfig.ControlPlane; cp != nil {
+		if cp.Replicas != nil {
+			replicas = *cp.Replicas
+		}
+		switch p := cp.Platform; {
+		case p.AWS != nil && p.AWS.InstanceType != "":
+			instanceType = p.AWS.InstanceType
+		case p.Azure != nil && p.Azure.InstanceType != "":
+			instanceType = p.Azure.InstanceType
+		case p.GCP != nil && p.GCP.InstanceType != "":
+			instanceType = p.GCP.InstanceType
+		}
+	}
+	return instanceType, replicas, nil
+}
+
+// installConfigDefaultInstanceType returns the instance type of the default machine platform of the install-config.
+func installConfigDefaultInstanceType(installConfig *installertypes.InstallConfig) string {
+	switch p := installConfig.Platform; {
+	case p.AWS != nil && p.AWS.DefaultMachinePlatform != nil:
+		return p.AWS.DefaultMachinePlatform.InstanceType
+	case p.Azure != nil && p.Azure.DefaultMachinePlatform != nil:
+		return p.Azure.DefaultMachinePlatform.InstanceType
+	case p.GCP != nil && p.GCP.DefaultMachinePlatform != nil:
+		return p.GCP.DefaultMachinePlatform.InstanceType
+	}
+	return ""
+}
+
+// poolInstanceType returns the instance type of the machines of the machine pool.
+func poolInstanceType(pool *hivev1.MachinePool) string {
+	switch p := pool.Spec.Platform; {
+	case p.AWS != nil:
+		return p.AWS.InstanceType
+	case p.Azure != nil:
+		return p.Azure.InstanceType
+	case p.GCP != nil:
+		return p.GCP.InstanceType
+	}
+	return ""
+}
+
+// poolReplicas returns the number of machines of the machine pool. The replicas reported in the status of the pool
+// are used once they are known, otherwise the replicas of the spec or the minimum replicas of the autoscaler.
+func poolReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Status.Replicas > 0:
+		return int64(pool.Status.Replicas)
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Spec.Autoscaling.MinReplicas)
+	}
+	return 0
+}
+
+// clusterPlatform returns the platform of the cluster deployment, or an empty string when its cost cannot be
+// estimated.
+func clusterPlatform(cd *hivev1.ClusterDeployment) string {
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	}
+	return ""
+}
+
+// isHibernating returns true if the machines of the cluster are stopped.
+func isHibernating(cd *hivev1.ClusterDeployment) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == hivev1.HibernatingHibernationReason
+}
@@ -0,0 +1,280 @@
+package clustercost
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+	"github.com/openshift/hive/pkg/clustercost"
+)
+
+const (
+	testName              = "test-cluster"
+	testNamespace         = "test-namespace"
+	testInstallConfigName = "test-install-config"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileClusterCost(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name             string
+		cd               *hivev1.ClusterDeployment
+		existing         []runtime.Object
+		expectedEstimate *hivev1.ClusterCostEstimate
+	}{
+		{
+			name: "default control plane",
+			cd:   testClusterDeployment(),
+			existing: []runtime.Object{
+				testMachinePool("worker", "m5.large", 2),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.768",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.large", Count: 2},
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+			},
+		},
+		{
+			name: "install-config control plane",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Provisioning = &hivev1.Provisioning{
+					InstallConfigSecretRef: corev1.LocalObjectReference{Name: testInstallConfigName},
+				}
+				return cd
+			}(),
+			existing: []runtime.Object{
+				testInstallConfigSecret(`
+controlPlane:
+  name: master
+  replicas: 1
+  platform:
+    aws:
+      type: m5.2xlarge
+`),
+				testMachinePool("worker", "m5.large", 2),
+				testMachinePool("infra", "m5.large", 1),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.672",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.2xlarge", Count: 1},
+					{InstanceType: "m5.large", Count: 3},
+				},
+			},
+		},
+		{
+			name: "cluster deployment control plane replicas",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Provisioning = &hivev1.Provisioning{
+					InstallConfigSecretRef: corev1.LocalObjectReference{Name: testInstallConfigName},
+					ControlPlaneReplicas:   pointer.Int64Ptr(1),
+				}
+				return cd
+			}(),
+			existing: []runtime.Object{
+				testInstallConfigSecret(`
+controlPlane:
+  name: master
+`),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.192",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.xlarge", Count: 1},
+				},
+			},
+		},
+		{
+			name: "pools of other clusters",
+			cd:   testClusterDeployment(),
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool("worker", "m5.large", 2)
+					pool.Spec.ClusterDeploymentRef.Name = "other-cluster"
+					return pool
+				}(),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.576",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+			},
+		},
+		{
+			name: "autoscaling pool",
+			cd:   testClusterDeployment(),
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool("worker", "m5.large", 0)
+					pool.Spec.Replicas = nil
+					pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 6}
+					return pool
+				}(),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.768",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.large", Count: 2},
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+			},
+		},
+		{
+			name: "unpriced instance type",
+			cd:   testClusterDeployment(),
+			existing: []runtime.Object{
+				testMachinePool("worker", "m5.metal", 2),
+			},
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0.576",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.metal", Count: 2},
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+				UnpricedInstanceTypes: []string{"m5.metal"},
+			},
+		},
+		{
+			name: "hibernating",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+					Reason: hivev1.HibernatingHibernationReason,
+				}}
+				return cd
+			}(),
+			expectedEstimate: &hivev1.ClusterCostEstimate{
+				HourlyCost: "0",
+				Currency:   "USD",
+				Instances: []hivev1.InstanceCount{
+					{InstanceType: "m5.xlarge", Count: 3},
+				},
+			},
+		},
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Installed = false
+				return cd
+			}(),
+		},
+		{
+			name: "unsupported platform",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Platform = hivev1.Platform{VSphere: &hivev1vsphere.Platform{}}
+				return cd
+			}(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(tc.existing, tc.cd)...)
+			r := &ReconcileClusterCost{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+				pricingSource: testPricingSource{
+					"m5.large":   0.096,
+					"m5.xlarge":  0.192,
+					"m5.2xlarge": 0.384,
+				},
+			}
+			key := types.NamespacedName{Namespace: testNamespace, Name: testName}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), key, cd))
+			assert.Equal(t, tc.expectedEstimate, cd.Status.CostEstimate, "unexpected cost estimate")
+		})
+	}
+}
+
+// testPricingSource maps the instance types to their hourly prices in USD.
+type testPricingSource map[string]float64
+
+func (s testPricingSource) HourlyPrice(platform, region, instanceType string) (*clustercost.Price, error) {
+	amount, ok := s[instanceType]
+	if !ok {
+		return nil, nil
+	}
+	return &clustercost.Price{Amount: amount, Currency: "USD"}, nil
+}
+
+func testClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: true,
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{Region: "us-east-1"},
+			},
+		},
+	}
+}
+
+func testMachinePool(name, instanceType string, replicas int64) *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName + "-" + name,
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: testName},
+			Name:                 name,
+			Replicas:             pointer.Int64Ptr(replicas),
+			Platform: hivev1.MachinePoolPlatform{
+				AWS: &hivev1aws.MachinePoolPlatform{InstanceType: instanceType},
+			},
+		},
+	}
+}
+
+func testInstallConfigSecret(installConfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstallConfigName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{installConfigSecretKey: []byte(installConfig)},
+	}
+}
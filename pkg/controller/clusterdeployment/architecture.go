@@ -0,0 +1,66 @@
+package clusterdeployment
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	unsupportedArchitectureReason = "UnsupportedArchitecture"
+)
+
+// getArchitecture returns the architecture of the control plane of the cluster, and whether the cluster is installed
+// from a multi-architecture release image. The architecture of the release image is only known when it comes from the
+// ClusterImageSet.
+func getArchitecture(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet) (hivev1.Architecture, bool, error) {
+	var releaseArch hivev1.Architecture
+	if imageSet != nil && cd.Spec.Provisioning.ReleaseImage == "" {
+		releaseArch = imageSet.Spec.Architecture
+	}
+	multiArch := releaseArch == hivev1.ArchitectureMulti
+	arch := cd.Spec.Provisioning.Architecture
+	switch {
+	case arch == "" && releaseArch != "" && !multiArch:
+		arch = releaseArch
+	case arch == "":
+		arch = hivev1.ArchitectureAMD64
+	case releaseArch != "" && !multiArch && arch != releaseArch:
+		return "", false, fmt.Errorf("the release image of ClusterImageSet %s only supports the %s architecture", imageSet.Name, releaseArch)
+	}
+	return arch, multiArch, nil
+}
+
+// setArchitecture records the architecture of the cluster in the status of the cluster deployment before the install
+// starts. It returns a non-nil result when the release image does not support the architecture of the cluster, which
+// stops the provisioning.
+func (r *ReconcileClusterDeployment) setArchitecture(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	arch, multiArch, err := getArchitecture(cd, imageSet)
+	if err != nil {
+		cdLog.WithError(err).Error("not creating new provision since the architecture is not supported")
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionStoppedCondition,
+			corev1.ConditionTrue,
+			unsupportedArchitectureReason,
+			err.Error(),
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+		if changed {
+			cd.Status.Conditions = conditions
+			return &reconcile.Result{}, r.statusUpdate(cd, cdLog)
+		}
+		return &reconcile.Result{}, nil
+	}
+	if cd.Status.Architecture == arch && cd.Status.MultiArchitecture == multiArch {
+		return nil, nil
+	}
+	cd.Status.Architecture = arch
+	cd.Status.MultiArchitecture = multiArch
+	return nil, r.statusUpdate(cd, cdLog)
+}
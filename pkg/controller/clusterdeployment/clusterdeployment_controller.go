@@ -7,7 +7,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,6 +19,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
@@ -42,9 +43,14 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imagemirror"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/machineimage"
+	"github.com/openshift/hive/pkg/platform"
+	"github.com/openshift/hive/pkg/releaseverification"
 	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/secretencryption"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -54,7 +60,6 @@ var controllerKind = hivev1.SchemeGroupVersion.WithKind("ClusterDeployment")
 const (
 	ControllerName     = hivev1.ClusterDeploymentControllerName
 	defaultRequeueTime = 10 * time.Second
-	maxProvisions      = 3
 
 	platformAuthFailureReason = "PlatformAuthError"
 	platformAuthSuccessReason = "PlatformAuthSuccess"
@@ -69,6 +74,7 @@ const (
 	installAttemptsLimitReachedReason = "InstallAttemptsLimitReached"
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
 	provisionNotStoppedReason         = "ProvisionNotStopped"
+	fatalProvisionFailureReason       = "FatalProvisionFailure"
 
 	deleteAfterAnnotation    = "hive.openshift.io/delete-after" // contains a duration after which the cluster should be cleaned up.
 	tryInstallOnceAnnotation = "hive.openshift.io/try-install-once"
@@ -131,6 +137,14 @@ var (
 			Buckets: []float64{10, 30, 60, 300, 600, 1200, 1800},
 		},
 	)
+	metricInstallerStageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_deployment_installer_stage_duration_seconds",
+			Help:    "Time taken by the installer to reach a stage of the install from the previous stage, or from the creation of the provision for the first stage.",
+			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
+		},
+		[]string{"stage"},
+	)
 )
 
 func init() {
@@ -142,6 +156,7 @@ func init() {
 	metrics.Registry.MustRegister(metricClustersInstalled)
 	metrics.Registry.MustRegister(metricClustersDeleted)
 	metrics.Registry.MustRegister(metricDNSDelaySeconds)
+	metrics.Registry.MustRegister(metricInstallerStageDuration)
 }
 
 // Add creates a new ClusterDeployment controller and adds it to the manager with default RBAC.
@@ -174,6 +189,14 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	r.deprovisionStuckThreshold = getDeprovisionStuckThreshold(logger)
+	r.defaultPostInstallChecks = getDefaultPostInstallChecks(logger)
+	r.protectedWorkloadsSelector = getProtectedWorkloadsSelector(logger)
+	r.machineImageResolver = machineimage.NewResolver(r.Client)
+	r.imageMirrorLister = imagemirror.NewLister(r.Client)
+	r.releaseImageVerifier = releaseverification.NewVerifierFromEnv(logger)
+	r.secretEncryptionProvider = secretencryption.ProviderFromEnv
+
 	return r
 }
 
@@ -185,7 +208,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 	}
 
 	c, err := controller.New("clusterdeployment-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -253,6 +276,24 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return errors.Wrap(err, "cannot start watch on ClusterSyncs")
 	}
 
+	// Watch for changes to the secrets and ClusterImageSets referenced by ClusterDeployments, using field indexes so
+	// that only the ClusterDeployments referencing them are enqueued.
+	if err := addFieldIndexes(mgr.GetFieldIndexer()); err != nil {
+		return errors.Wrap(err, "cannot add ClusterDeployment field indexes")
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: requestsForSecret(cdReconciler.Client, cdReconciler.logger)},
+	); err != nil {
+		return errors.Wrap(err, "cannot start watch on secrets")
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterImageSet{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: requestsForClusterImageSet(cdReconciler.Client, cdReconciler.logger)},
+	); err != nil {
+		return errors.Wrap(err, "cannot start watch on ClusterImageSets")
+	}
+
 	return nil
 }
 
@@ -267,6 +308,11 @@ type ReconcileClusterDeployment struct {
 	// A TTLCache of clusterprovision creates each clusterdeployment expects to see
 	expectations controllerutils.ExpectationsInterface
 
+	// tenantQuotaLocks holds a *sync.Mutex per namespace, which serializes the checks of the quotas of the HiveTenants
+	// of the namespace with the creation of the provisions, so that provisions started concurrently are counted
+	// against the quotas. Provisions in different namespaces do not wait for each other.
+	tenantQuotaLocks sync.Map
+
 	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
@@ -276,6 +322,32 @@ type ReconcileClusterDeployment struct {
 	validateCredentialsForClusterDeployment func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 
 	protectedDelete bool
+
+	// deprovisionStuckThreshold is how long a deleted cluster deployment can wait for its cluster to be deprovisioned
+	// before the DeprovisionStuck condition is set. defaultDeprovisionStuckThreshold is used when it is zero.
+	deprovisionStuckThreshold time.Duration
+
+	// defaultPostInstallChecks are the post-install checks run for the cluster deployments which do not specify
+	// their own.
+	defaultPostInstallChecks []hivev1.PostInstallCheck
+
+	// protectedWorkloadsSelector selects the namespaces of a cluster which hold protected workloads. It is nil when
+	// clusters are not checked for protected workloads before they are deprovisioned.
+	protectedWorkloadsSelector labels.Selector
+
+	// machineImageResolver resolves the machine images overriding the RHCOS images of the releases.
+	machineImageResolver machineimage.Resolver
+
+	// imageMirrorLister lists the mirrors of the hub which the images of the install jobs are pulled from.
+	imageMirrorLister imagemirror.Lister
+
+	// releaseImageVerifier verifies the release images before they are used to install clusters. It is nil when the
+	// verification of release images is not turned on.
+	releaseImageVerifier releaseverification.Verifier
+
+	// secretEncryptionProvider returns the provider which encrypts the admin secrets of the clusters, or nil when
+	// secret encryption is not configured.
+	secretEncryptionProvider func(client.Client) (secretencryption.Provider, error)
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -312,7 +384,11 @@ func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (resul
 		return reconcile.Result{}, err
 	}
 
-	return r.reconcile(request, cd, cdLog)
+	result, err = r.reconcile(request, cd, cdLog)
+	if err != nil {
+		return result, err
+	}
+	return result, r.updateReadyStatus(cd, cdLog)
 }
 
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
@@ -377,6 +453,22 @@ func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerut
 			},
 			Controlled: false,
 		},
+		{
+			TypeToList: &corev1.SecretList{},
+			LabelSelector: map[string]string{
+				constants.ClusterDeploymentNameLabel: owner.GetName(),
+				constants.SecretTypeLabel:            constants.SecretTypeMetadataJSON,
+			},
+			Controlled: false,
+		},
+		{
+			TypeToList: &corev1.SecretList{},
+			LabelSelector: map[string]string{
+				constants.ClusterDeploymentNameLabel: owner.GetName(),
+				constants.SecretTypeLabel:            constants.SecretTypeTerraformState,
+			},
+			Controlled: false,
+		},
 	}
 }
 
@@ -389,6 +481,17 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		return err
 	}
 
+	// The data of an encrypted secret is decrypted to add the CAs, and encrypted again if it changes.
+	encrypted := secretencryption.IsEncrypted(adminKubeconfigSecret)
+	if encrypted {
+		data, err := r.decryptedSecretData(adminKubeconfigSecret)
+		if err != nil {
+			cdLog.WithError(err).Error("failed to decrypt admin kubeconfig secret")
+			return err
+		}
+		adminKubeconfigSecret.Data = data
+	}
+
 	originalSecret := adminKubeconfigSecret.DeepCopy()
 
 	rawData, hasRawData := adminKubeconfigSecret.Data[constants.RawKubeconfigSecretKey]
@@ -409,6 +512,20 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		return nil
 	}
 
+	if encrypted {
+		provider, err := r.secretEncryptionProvider(r)
+		if err != nil {
+			cdLog.WithError(err).Error("failed to get secret encryption provider")
+			return err
+		}
+		delete(adminKubeconfigSecret.Annotations, constants.EncryptionProviderAnnotation)
+		delete(adminKubeconfigSecret.Annotations, constants.EncryptedDataKeyAnnotation)
+		if err := secretencryption.Encrypt(provider, adminKubeconfigSecret); err != nil {
+			cdLog.WithError(err).Error("failed to encrypt admin kubeconfig secret")
+			return err
+		}
+	}
+
 	cdLog.Info("admin kubeconfig has been modified, updating")
 	err = r.Update(context.TODO(), adminKubeconfigSecret)
 	if err != nil {
@@ -421,15 +538,15 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 
 func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
 	// Set platform label on the ClusterDeployment
-	if platform := getClusterPlatform(cd); cd.Labels[hivev1.HiveClusterPlatformLabel] != platform {
+	if clusterPlatform := platform.Name(cd); cd.Labels[hivev1.HiveClusterPlatformLabel] != clusterPlatform {
 		if cd.Labels == nil {
 			cd.Labels = make(map[string]string)
 		}
 		if cd.Labels[hivev1.HiveClusterPlatformLabel] != "" {
 			cdLog.Warnf("changing the value of %s from %s to %s", hivev1.HiveClusterPlatformLabel,
-				cd.Labels[hivev1.HiveClusterPlatformLabel], platform)
+				cd.Labels[hivev1.HiveClusterPlatformLabel], clusterPlatform)
 		}
-		cd.Labels[hivev1.HiveClusterPlatformLabel] = platform
+		cd.Labels[hivev1.HiveClusterPlatformLabel] = clusterPlatform
 		err := r.Update(context.TODO(), cd)
 		if err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set cluster platform label")
@@ -454,6 +571,18 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, err
 	}
 
+	// Set the cluster name of a ClusterDeployment created with a generated name, which the defaulting webhook could
+	// not know.
+	if cd.Spec.ClusterName == "" && cd.DeletionTimestamp == nil {
+		cdLog.WithField("clusterName", cd.Name).Info("setting cluster name to the name of the ClusterDeployment")
+		cd.Spec.ClusterName = cd.Name
+		err := r.Update(context.TODO(), cd)
+		if err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set cluster name")
+		}
+		return reconcile.Result{}, err
+	}
+
 	if cd.DeletionTimestamp != nil {
 		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
 			// Make sure we have no deprovision underway metric even though this was probably cleared when we
@@ -534,7 +663,12 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, err
 		}
 
-		// delete failed provisions which are more than 7 days old
+		// report the failures of the jobs of non-blocking hooks
+		if err := r.setHookFailedCondition(cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		// delete failed provisions which are older than the failed provision retention
 		existingProvisions, err := r.existingProvisions(cd, cdLog)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -551,6 +685,10 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 				return reconcile.Result{}, err
 			}
 
+			if err := r.reconcileSecretEncryption(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+
 			// Add cluster deployment as additional owner reference to admin secrets
 			if err := r.addOwnershipToSecret(cd, cdLog, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name); err != nil {
 				return reconcile.Result{}, err
@@ -560,6 +698,19 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 					return reconcile.Result{}, err
 				}
 			}
+			// The metadata.json and terraform state are kept for as long as the cluster deployment, to destroy the
+			// cluster.
+			for _, ref := range []*corev1.LocalObjectReference{
+				cd.Spec.ClusterMetadata.MetadataJSONSecretRef,
+				cd.Spec.ClusterMetadata.TerraformStateSecretRef,
+			} {
+				if ref == nil {
+					continue
+				}
+				if err := r.addOwnershipToSecret(cd, cdLog, ref.Name); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 
 			if cd.Status.WebConsoleURL == "" || cd.Status.APIURL == "" {
 				return r.setClusterStatusURLs(cd, cdLog)
@@ -603,6 +754,10 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, authError
 	}
 
+	if err := r.validateDeprovisionCreds(cd, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	imageSet, err := r.getClusterImageSet(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -610,6 +765,10 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 
 	releaseImage := r.getReleaseImage(cd, imageSet, cdLog)
 
+	if err := r.verifyReleaseImage(cd, releaseImage, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	cdLog.Debug("loading pull secrets")
 	pullSecret, err := r.mergePullSecrets(cd, cdLog)
 	if err != nil {
@@ -641,7 +800,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 	}
 
 	if cd.Status.ProvisionRef == nil {
-		return r.startNewProvision(cd, releaseImage, cdLog)
+		return r.startNewProvision(cd, imageSet, releaseImage, cdLog)
 	}
 
 	return r.reconcileExistingProvision(cd, cdLog)
@@ -649,6 +808,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 
 func (r *ReconcileClusterDeployment) startNewProvision(
 	cd *hivev1.ClusterDeployment,
+	imageSet *hivev1.ClusterImageSet,
 	releaseImage string,
 	cdLog log.FieldLogger,
 ) (result reconcile.Result, returnedErr error) {
@@ -665,6 +825,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 
 	r.deleteStaleProvisions(existingProvisions, cdLog)
 
+	if err := r.validateInstallConfig(cd, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if cd.Spec.ManageDNS {
 		dnsZone, err := r.ensureManagedDNSZone(cd, cdLog)
 		if err != nil {
@@ -719,6 +883,13 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, nil
 	}
 
+	switch result, err := r.setArchitecture(cd, imageSet, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionStoppedCondition,
@@ -736,9 +907,19 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, nil
 	}
 
-	if err := controllerutils.SetupClusterInstallServiceAccount(r, cd.Namespace, cdLog); err != nil {
-		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
+	switch done, err := r.runHooks(cd, hivev1.PreInstallClusterHookStage, cdLog); {
+	case err != nil:
 		return reconcile.Result{}, err
+	case !done:
+		cdLog.Debug("waiting for pre-install hooks to complete")
+		return reconcile.Result{}, nil
+	}
+
+	if controllerutils.HasFallbackRegions(cd) && cd.Status.InstallRegion == "" {
+		cd.Status.InstallRegion = controllerutils.InstallRegion(cd)
+		if err := r.statusUpdate(cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	provisionName := apihelpers.GetResourceName(cd.Name, fmt.Sprintf("%d-%s", cd.Status.InstallRestarts, utilrand.String(5)))
@@ -750,18 +931,66 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	labels[constants.ClusterDeploymentNameLabel] = cd.Name
 
 	extraEnvVars := getInstallLogEnvVars(cd.Name)
+	// Export the spans of the install job to the same collector as the controllers.
+	extraEnvVars = addEnvVarIfFound(constants.OTLPEndpointEnvVar, extraEnvVars)
+	extraEnvVars = addEnvVarIfFound(constants.TerraformStatePolicyEnvVar, extraEnvVars)
+	extraEnvVars = addEnvVarIfFound(constants.PropagatedLabelsEnvVar, extraEnvVars)
+	extraEnvVars = append(extraEnvVars, controllerutils.JobProxyEnvVars(platform.Name(cd), cdLog)...)
+	terraformStateKeyEnvVar, err := r.terraformStatePublicKeyEnvVar(cdLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if terraformStateKeyEnvVar != nil {
+		extraEnvVars = append(extraEnvVars, *terraformStateKeyEnvVar)
+	}
+
+	machineImage, err := r.machineImageResolver.Resolve(cd, releaseImage, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("could not resolve machine image")
+		return reconcile.Result{}, err
+	}
+	if machineImage != "" {
+		extraEnvVars = append(extraEnvVars, corev1.EnvVar{Name: constants.MachineImageEnvVar, Value: machineImage})
+	}
+
+	// The installer reads the install log credentials secret to upload the logs.
+	var installerSecrets []string
+	for _, envVar := range extraEnvVars {
+		if envVar.Name == constants.InstallLogsCredentialsSecretRefEnvVar {
+			installerSecrets = append(installerSecrets, envVar.Value)
+		}
+	}
+	if err := controllerutils.SetupClusterInstallServiceAccount(r, cd, provisionName, installerSecrets, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
+		return reconcile.Result{}, err
+	}
+
+	imageMirrors, err := r.imageMirrorLister.List(cdLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	podSpec, err := install.InstallerPodSpec(
 		cd,
 		provisionName,
 		releaseImage,
-		controllerutils.ServiceAccountName,
+		controllerutils.InstallServiceAccountName(cd.Name),
 		extraEnvVars,
+		imageMirrors,
 	)
 	if err != nil {
 		cdLog.WithError(err).Error("could not generate installer pod spec")
 		return reconcile.Result{}, err
 	}
+	trustedCASecret, err := controllerutils.SyncJobProxyTrustedCA(r, cd, r.scheme, cdLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if trustedCASecret != "" {
+		for i := range podSpec.Containers {
+			controllerutils.AddProxyTrustedCA(podSpec, &podSpec.Containers[i], trustedCASecret)
+		}
+	}
 
 	provision := &hivev1.ClusterProvision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -784,6 +1013,8 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		provision.Spec.PrevClusterID = &cd.Spec.ClusterMetadata.ClusterID
 		provision.Spec.PrevInfraID = &cd.Spec.ClusterMetadata.InfraID
 	}
+	skipUnneededCleanup(provision, existingProvisions, cdLog)
+	setProvisionRegion(cd, provision, existingProvisions)
 
 	cdLog.WithField("derivedObject", provision.Name).Debug("Setting label on derived object")
 	provision.Labels = k8slabels.AddLabel(provision.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
@@ -801,6 +1032,16 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		}
 	}
 
+	tenantQuotaLock := r.tenantQuotaLock(cd.Namespace)
+	tenantQuotaLock.Lock()
+	defer tenantQuotaLock.Unlock()
+	switch result, err := r.checkTenantQuotas(cd, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
 	r.expectations.ExpectCreations(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String(), 1)
 	if err := r.Create(context.TODO(), provision); err != nil {
 		cdLog.WithError(err).Error("could not create provision")
@@ -919,6 +1160,12 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 		if provision.Spec.AdminPasswordSecretRef != nil {
 			clusterMetadata.AdminPasswordSecretRef = *provision.Spec.AdminPasswordSecretRef
 		}
+		if provision.Spec.MetadataJSONSecretRef != nil {
+			clusterMetadata.MetadataJSONSecretRef = provision.Spec.MetadataJSONSecretRef.DeepCopy()
+		}
+		if provision.Spec.TerraformStateSecretRef != nil {
+			clusterMetadata.TerraformStateSecretRef = provision.Spec.TerraformStateSecretRef.DeepCopy()
+		}
 		if !reflect.DeepEqual(clusterMetadata, cd.Spec.ClusterMetadata) {
 			cd.Spec.ClusterMetadata = clusterMetadata
 			cdLog.Infof("Saving infra ID %q for cluster", cd.Spec.ClusterMetadata.InfraID)
@@ -930,6 +1177,12 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 		}
 	}
 
+	if syncInstallAttempt(cd, provision, cdLog) {
+		if err := r.statusUpdate(cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	switch provision.Spec.Stage {
 	case hivev1.ClusterProvisionStageInitializing:
 		return r.reconcileInitializingProvision(cd, provision, cdLog)
@@ -960,6 +1213,11 @@ func (r *ReconcileClusterDeployment) reconcileInitializingProvision(cd *hivev1.C
 
 func (r *ReconcileClusterDeployment) reconcileProvisioningProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
 	cdLog.Debug("still provisioning")
+	if syncInstallerStages(cd, provision, cdLog) {
+		if err := r.statusUpdate(cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	if err := r.setInstallLaunchErrorCondition(cd, corev1.ConditionFalse, "InstallLaunchSuccessful", "Successfully launched install pod", cdLog); err != nil {
 		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update InstallLaunchErrorCondition")
 		return reconcile.Result{}, err
@@ -974,11 +1232,37 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
 		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
+		if failedBeforeInfrastructure(provision) {
+			existingProvisions, err := r.existingProvisions(cd, cdLog)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if failures := consecutiveEarlyFailures(provision, existingProvisions); failures <= maxEarlyInstallFailureRetries {
+				cdLog.Info("provision failed before creating infrastructure, retrying without backoff")
+				nextProvisionTime = failedCond.LastTransitionTime.Add(earlyInstallFailureRetryDelay)
+			} else {
+				cdLog.WithField("failures", failures).Info("provisions keep failing before creating infrastructure, retrying with backoff")
+			}
+		}
 		reason = failedCond.Reason
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
 
+	if controllerutils.IsFatalProvisionFailureReason(reason, cdLog) {
+		return r.stopProvisionAfterFatalFailure(cd, provision, reason, failedCond.Message, cdLog)
+	}
+
+	if controllerutils.IsRegionCapacityFailureReason(reason) {
+		failedRegion := provision.Spec.Region
+		if failedRegion == "" {
+			failedRegion = controllerutils.InstallRegion(cd)
+		}
+		if nextRegion, ok := controllerutils.NextFallbackRegion(cd, failedRegion); ok {
+			return r.failOverToRegion(cd, provision, reason, failedRegion, nextRegion, cdLog)
+		}
+	}
+
 	newConditions, condChange := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionFailedCondition,
@@ -988,11 +1272,12 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
 	cd.Status.Conditions = newConditions
+	stagesChange := syncInstallerStages(cd, provision, cdLog)
 
 	timeUntilNextProvision := time.Until(nextProvisionTime)
 	if timeUntilNextProvision.Seconds() > 0 {
 		cdLog.WithField("nextProvision", nextProvisionTime).Info("waiting to start a new provision after failure")
-		if condChange {
+		if condChange || stagesChange {
 			if err := r.statusUpdate(cd, cdLog); err != nil {
 				return reconcile.Result{}, err
 			}
@@ -1004,6 +1289,38 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	return r.clearOutCurrentProvision(cd, cdLog)
 }
 
+// stopProvisionAfterFatalFailure sets the ProvisionStopped condition for a provision that failed with an install
+// failure that is not worth retrying. The failed provision is kept as the current provision so that no new provision
+// is started.
+func (r *ReconcileClusterDeployment) stopProvisionAfterFatalFailure(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, reason, message string, cdLog log.FieldLogger) (reconcile.Result, error) {
+	cdLog.WithField("reason", reason).WithField("message", message).Info("not creating new provision since the provision failed with a fatal error")
+	conditions, failedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ProvisionFailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		fmt.Sprintf("Provision %s failed with a fatal error: %s", provision.Name, message),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	conditions, stoppedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		conditions,
+		hivev1.ProvisionStoppedCondition,
+		corev1.ConditionTrue,
+		fatalProvisionFailureReason,
+		fmt.Sprintf("Provision %s failed with a fatal error that will not be retried", provision.Name),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if failedChanged || stoppedChanged {
+		cd.Status.Conditions = conditions
+		cdLog.Debugf("setting ProvisionStoppedCondition to %v", corev1.ConditionTrue)
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
 func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
 	cdLog.Info("provision completed successfully")
 
@@ -1013,6 +1330,13 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 		now := metav1.Now()
 		cd.Status.InstalledTimestamp = &now
 	}
+	if provision.Spec.PlatformStatus != nil && !reflect.DeepEqual(provision.Spec.PlatformStatus, cd.Status.PlatformStatus) {
+		statusChange = true
+		cd.Status.PlatformStatus = provision.Spec.PlatformStatus.DeepCopy()
+	}
+	if syncInstallerStages(cd, provision, cdLog) {
+		statusChange = true
+	}
 	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionFailedCondition,
@@ -1036,6 +1360,21 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 		return reconcile.Result{}, nil
 	}
 
+	switch result, err := r.runPostInstallChecks(cd, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
+	switch done, err := r.runHooks(cd, hivev1.PostInstallClusterHookStage, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case !done:
+		cdLog.Debug("waiting for post-install hooks to complete")
+		return reconcile.Result{}, nil
+	}
+
 	cd.Spec.Installed = true
 
 	if r.protectedDelete {
@@ -1144,18 +1483,23 @@ func (r *ReconcileClusterDeployment) resolveInstallerImage(cd *hivev1.ClusterDep
 			return nil, r.setInstallImagesNotResolvedCondition(cd, corev1.ConditionFalse, imagesResolvedReason, imagesResolvedMsg, cdLog)
 		}
 
-		job := imageset.GenerateImageSetJob(cd, releaseImage, controllerutils.ServiceAccountName)
+		imageMirrors, err := r.imageMirrorLister.List(cdLog)
+		if err != nil {
+			return nil, err
+		}
+		job := imageset.GenerateImageSetJob(cd, releaseImage, controllerutils.InstallServiceAccountName(cd.Name), imageMirrors)
 
 		cdLog.WithField("derivedObject", job.Name).Debug("Setting labels on derived object")
 		job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
 		job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypeImageSet)
+		controllerutils.AddJobPropagatedLabels(cd, job, cdLog)
 		if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
 			cdLog.WithError(err).Error("error setting controller reference on job")
 			return nil, err
 		}
 
 		jobLog.WithField("releaseImage", releaseImage).Info("creating imageset job")
-		err = controllerutils.SetupClusterInstallServiceAccount(r, cd.Namespace, cdLog)
+		err = controllerutils.SetupClusterInstallServiceAccount(r, cd, "", nil, cdLog)
 		if err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account and role")
 			return nil, err
@@ -1448,6 +1792,7 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 
 	cdLog.WithField("derivedObject", request.Name).Debug("Setting label on derived object")
 	request.Labels = k8slabels.AddLabel(request.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	controllerutils.AddPropagatedLabels(cd, request, cdLog)
 	err = controllerutil.SetControllerReference(cd, request, r.scheme)
 	if err != nil {
 		cdLog.Errorf("error setting controller reference on deprovision request: %v", err)
@@ -1486,12 +1831,18 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 		return false, err
 	}
 
-	authenticationFailureCondition := controllerutils.FindClusterDeprovisionCondition(existingRequest.Status.Conditions, hivev1.AuthenticationFailureClusterDeprovisionCondition)
-	if authenticationFailureCondition != nil {
+	// A failed uninstall job takes precedence over the credentials check, which passed for the job to be started.
+	launchErrorCondition := controllerutils.FindClusterDeprovisionCondition(existingRequest.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+	if launchErrorCondition == nil || launchErrorCondition.Status != corev1.ConditionTrue {
+		if authenticationFailureCondition := controllerutils.FindClusterDeprovisionCondition(existingRequest.Status.Conditions, hivev1.AuthenticationFailureClusterDeprovisionCondition); authenticationFailureCondition != nil {
+			launchErrorCondition = authenticationFailureCondition
+		}
+	}
+	if launchErrorCondition != nil {
 		err := r.setDeprovisionLaunchErrorCondition(cd,
-			authenticationFailureCondition.Status,
-			authenticationFailureCondition.Reason,
-			authenticationFailureCondition.Message,
+			launchErrorCondition.Status,
+			launchErrorCondition.Reason,
+			launchErrorCondition.Message,
 			cdLog)
 
 		if err != nil {
@@ -1508,7 +1859,7 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 	return true, nil
 }
 
-func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
 	switch _, relocateStatus, err := controllerutils.IsRelocating(cd); {
 	case err != nil:
 		cdLog.WithError(err).Error("could not determine relocate status")
@@ -1525,6 +1876,21 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, nil
 	}
 
+	stuckAfter, err := r.checkDeprovisionStuck(cd, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update DeprovisionStuck condition")
+		return reconcile.Result{}, err
+	}
+	finalized := false
+	if stuckAfter > 0 {
+		// Come back when the deprovision is considered stuck, in case nothing else triggers a reconcile by then.
+		defer func() {
+			if !finalized && returnErr == nil && !result.Requeue && (result.RequeueAfter == 0 || result.RequeueAfter > stuckAfter) {
+				result.RequeueAfter = stuckAfter
+			}
+		}()
+	}
+
 	if controllerutils.IsDeleteProtected(cd) {
 		cdLog.Error("deprovision blocked for ClusterDeployment with protected delete on")
 		return reconcile.Result{}, nil
@@ -1543,10 +1909,31 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, err
 	}
 
+	switch done, err := r.runPreDeprovisionHooks(cd, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case !done:
+		cdLog.Debug("waiting for pre-deprovision hooks to complete")
+		return reconcile.Result{}, nil
+	}
+
+	switch result, err := r.checkProtectedWorkloads(cd, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
 	deprovisioned, err := r.ensureClusterDeprovisioned(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if deprovisioned {
+		if err := r.clearDeprovisionStuck(cd, cdLog); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not clear DeprovisionStuck condition")
+			return reconcile.Result{}, err
+		}
+	}
 
 	switch {
 	case !deprovisioned:
@@ -1559,6 +1946,7 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error removing finalizer")
 			return reconcile.Result{}, err
 		}
+		finalized = true
 		return reconcile.Result{}, nil
 	}
 }
@@ -1727,35 +2115,15 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		},
 	}
 
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		additionalTags := make([]hivev1.AWSResourceTag, 0, len(cd.Spec.Platform.AWS.UserTags))
-		for k, v := range cd.Spec.Platform.AWS.UserTags {
-			additionalTags = append(additionalTags, hivev1.AWSResourceTag{Key: k, Value: v})
-		}
-		region := ""
-		if strings.HasPrefix(cd.Spec.Platform.AWS.Region, constants.AWSChinaRegionPrefix) {
-			region = constants.AWSChinaRoute53Region
-		}
-		dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
-			CredentialsSecretRef: cd.Spec.Platform.AWS.CredentialsSecretRef,
-			AdditionalTags:       additionalTags,
-			Region:               region,
-		}
-	case cd.Spec.Platform.GCP != nil:
-		dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
-			CredentialsSecretRef: cd.Spec.Platform.GCP.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.Azure != nil:
-		dnsZone.Spec.Azure = &hivev1.AzureDNSZoneSpec{
-			CredentialsSecretRef: cd.Spec.Platform.Azure.CredentialsSecretRef,
-			ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
-		}
+	if actuator := platform.ForClusterDeployment(cd); actuator != nil {
+		actuator.SetDNSZonePlatform(cd, &dnsZone.Spec)
 	}
+	addDNSZonePropagatedTags(cd, dnsZone, logger)
 
 	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
 	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
 	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.DNSZoneTypeLabel, constants.DNSZoneTypeChild)
+	controllerutils.AddPropagatedLabels(cd, dnsZone, logger)
 	if err := controllerutil.SetControllerReference(cd, dnsZone, r.scheme); err != nil {
 		logger.WithError(err).Error("error setting controller reference on dnszone")
 		return err
@@ -1845,7 +2213,28 @@ func (r *ReconcileClusterDeployment) cleanupInstallLogPVC(cd *hivev1.ClusterDepl
 	return nil
 }
 
+// addDNSZonePropagatedTags tags the hosted zone of an AWS DNSZone with the propagated labels of the cluster
+// deployment, as the installer tags the other cloud resources of the cluster. The user tags of the cluster deployment
+// take precedence.
+func addDNSZonePropagatedTags(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) {
+	if dnsZone.Spec.AWS == nil {
+		return
+	}
+	labels := controllerutils.PropagatedLabels(cd, logger)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		if _, ok := cd.Spec.Platform.AWS.UserTags[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		dnsZone.Spec.AWS.AdditionalTags = append(dnsZone.Spec.AWS.AdditionalTags, hivev1.AWSResourceTag{Key: key, Value: labels[key]})
+	}
+}
+
 func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovision, error) {
+	cd = withDeprovisionCreds(cd)
 	req := &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cd.Name,
@@ -1857,42 +2246,14 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 		},
 	}
 
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
-			Region:               cd.Spec.Platform.AWS.Region,
-			CredentialsSecretRef: &cd.Spec.Platform.AWS.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.Azure != nil:
-		req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
-			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.GCP != nil:
-		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
-			Region:               cd.Spec.Platform.GCP.Region,
-			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
-		}
-	case cd.Spec.Platform.OpenStack != nil:
-		req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
-			Cloud:                 cd.Spec.Platform.OpenStack.Cloud,
-			CredentialsSecretRef:  &cd.Spec.Platform.OpenStack.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.OpenStack.CertificatesSecretRef,
-		}
-	case cd.Spec.Platform.VSphere != nil:
-		req.Spec.Platform.VSphere = &hivev1.VSphereClusterDeprovision{
-			CredentialsSecretRef:  cd.Spec.Platform.VSphere.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.VSphere.CertificatesSecretRef,
-			VCenter:               cd.Spec.Platform.VSphere.VCenter,
-		}
-	case cd.Spec.Platform.Ovirt != nil:
-		req.Spec.Platform.Ovirt = &hivev1.OvirtClusterDeprovision{
-			CredentialsSecretRef:  cd.Spec.Platform.Ovirt.CredentialsSecretRef,
-			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
-			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
-		}
-	default:
+	var deprovisionPlatform *hivev1.ClusterDeprovisionPlatform
+	if actuator := platform.ForClusterDeployment(cd); actuator != nil {
+		deprovisionPlatform = actuator.DeprovisionPlatform(cd, controllerutils.InstallRegion(cd))
+	}
+	if deprovisionPlatform == nil {
 		return nil, errors.New("unsupported cloud provider for deprovision")
 	}
+	req.Spec.Platform = *deprovisionPlatform
 
 	return req, nil
 }
@@ -2033,6 +2394,7 @@ func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd
 		cdLog.WithField("derivedObject", newPullSecretObj.Name).Debug("Setting labels on derived object")
 		newPullSecretObj.Labels = k8slabels.AddLabel(newPullSecretObj.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
 		newPullSecretObj.Labels = k8slabels.AddLabel(newPullSecretObj.Labels, constants.SecretTypeLabel, constants.SecretTypeMergedPullSecret)
+		controllerutils.AddPropagatedLabels(cd, newPullSecretObj, cdLog)
 		err = controllerutil.SetControllerReference(cd, newPullSecretObj, r.scheme)
 		if err != nil {
 			cdLog.Errorf("error setting controller reference on new merged pull secret: %v", err)
@@ -2105,6 +2467,11 @@ func (r *ReconcileClusterDeployment) deleteStaleProvisions(provs []*hivev1.Clust
 	// Cap the number of existing provisions. Always keep the earliest provision as
 	// it is used to determine the total time that it took to install. Take off
 	// one extra to make room for the new provision being started.
+	maxProvisions := controllerutils.GetJobHistory(cdLog).MaxProvisions
+	if maxProvisions < 2 {
+		// The earliest provision is kept, so at least one more is needed to make progress.
+		maxProvisions = 2
+	}
 	amountToDelete := len(provs) - maxProvisions
 	if amountToDelete <= 0 {
 		return
@@ -2120,11 +2487,13 @@ func (r *ReconcileClusterDeployment) deleteStaleProvisions(provs []*hivev1.Clust
 	}
 }
 
-// deleteOldFailedProvisions deletes the failed provisions which are more than 7 days old
+// deleteOldFailedProvisions deletes the failed provisions which are older than the failed provision retention
+// (7 days by default)
 func (r *ReconcileClusterDeployment) deleteOldFailedProvisions(provs []*hivev1.ClusterProvision, cdLog log.FieldLogger) {
-	cdLog.Debugf("Deleting failed provisions which are more than 7 days old")
+	retention := controllerutils.GetJobHistory(cdLog).FailedProvisionRetention
+	cdLog.Debugf("Deleting failed provisions which are more than %v old", retention)
 	for _, provision := range provs {
-		if provision.Spec.Stage == hivev1.ClusterProvisionStageFailed && time.Since(provision.CreationTimestamp.Time) > (7*24*time.Hour) {
+		if provision.Spec.Stage == hivev1.ClusterProvisionStageFailed && time.Since(provision.CreationTimestamp.Time) > retention {
 			pLog := cdLog.WithField("provision", provision.Name)
 			pLog.Info("Deleting failed provision")
 			if err := r.Delete(context.TODO(), provision); err != nil {
@@ -2194,6 +2563,102 @@ func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.Cluste
 	return nil
 }
 
+// reconcileSecretEncryption encrypts the admin kubeconfig, admin password and terraform state secrets of the cluster
+// deployment when secret encryption is configured. Secrets that are already encrypted are left as they are. The owners
+// of the claim of a cluster from a pool read its admin secrets directly, so those secrets are decrypted once the
+// cluster is claimed.
+func (r *ReconcileClusterDeployment) reconcileSecretEncryption(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	provider, err := r.secretEncryptionProvider(r)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to get secret encryption provider")
+		return err
+	}
+	if provider == nil {
+		return nil
+	}
+	claimed := cd.Spec.ClusterPoolRef != nil && cd.Spec.ClusterPoolRef.ClaimName != ""
+	encrypted := map[string]bool{
+		cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name: !claimed,
+		cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name:   !claimed,
+	}
+	if ref := cd.Spec.ClusterMetadata.TerraformStateSecretRef; ref != nil {
+		encrypted[ref.Name] = true
+	}
+	for name, encrypt := range encrypted {
+		if name == "" {
+			continue
+		}
+		secretLog := cdLog.WithField("secret", name)
+		secret := &corev1.Secret{}
+		if err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, secret); err != nil {
+			secretLog.WithError(err).Error("failed to get secret")
+			return err
+		}
+		if secret.Annotations[constants.EncryptionProviderAnnotation] == secretencryption.RSAProviderName {
+			// The install job encrypted the terraform state with the terraform state key. Its data key is encrypted
+			// again with the key management service, like the data keys of the other secrets.
+			keyProvider, err := r.terraformStateKeyProvider(secretLog)
+			if err != nil {
+				return err
+			}
+			if keyProvider == nil {
+				secretLog.Warn("secret encrypted with the terraform state key while the terraform state is not stored encrypted")
+				continue
+			}
+			if err := secretencryption.Rewrap(keyProvider, provider, secret); err != nil {
+				secretLog.WithError(err).Error("failed to encrypt the data key of the secret")
+				return err
+			}
+			secretLog.Info("encrypting the data key of the secret")
+			if err := r.Update(context.Background(), secret); err != nil {
+				secretLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating secret")
+				return err
+			}
+			continue
+		}
+		if secretencryption.IsEncrypted(secret) == encrypt {
+			continue
+		}
+		if encrypt {
+			if err := secretencryption.Encrypt(provider, secret); err != nil {
+				secretLog.WithError(err).Error("failed to encrypt secret")
+				return err
+			}
+			secretLog.Info("encrypting secret")
+		} else {
+			data, err := secretencryption.Decrypt(provider, secret)
+			if err != nil {
+				secretLog.WithError(err).Error("failed to decrypt secret")
+				return err
+			}
+			secret.Data = data
+			delete(secret.Annotations, constants.EncryptionProviderAnnotation)
+			delete(secret.Annotations, constants.EncryptedDataKeyAnnotation)
+			secretLog.Info("decrypting secret of claimed cluster")
+		}
+		if err := r.Update(context.Background(), secret); err != nil {
+			secretLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating secret")
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptedSecretData returns the data of the secret, decrypting it if the secret is encrypted.
+func (r *ReconcileClusterDeployment) decryptedSecretData(secret *corev1.Secret) (map[string][]byte, error) {
+	if !secretencryption.IsEncrypted(secret) {
+		return secret.Data, nil
+	}
+	provider, err := r.secretEncryptionProvider(r)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, errors.Errorf("secret %s is encrypted but secret encryption is not configured", secret.Name)
+	}
+	return secretencryption.Decrypt(provider, secret)
+}
+
 // addOwnershipToSecret adds cluster deployment as an additional non-controlling owner to secret
 func (r *ReconcileClusterDeployment) addOwnershipToSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger, name string) error {
 	cdLog = cdLog.WithField("secret", name)
@@ -2212,6 +2677,10 @@ func (r *ReconcileClusterDeployment) addOwnershipToSecret(cd *hivev1.ClusterDepl
 		secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
 		labelAdded = true
 	}
+	if controllerutils.AddPropagatedLabels(cd, secret, cdLog) {
+		cdLog.Debug("Setting propagated labels on derived object")
+		labelAdded = true
+	}
 
 	cdRef := metav1.OwnerReference{
 		APIVersion:         cd.APIVersion,
@@ -2235,34 +2704,10 @@ func (r *ReconcileClusterDeployment) addOwnershipToSecret(cd *hivev1.ClusterDepl
 	return nil
 }
 
-// getClusterPlatform returns the platform of a given ClusterDeployment
-func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		return constants.PlatformAWS
-	case cd.Spec.Platform.Azure != nil:
-		return constants.PlatformAzure
-	case cd.Spec.Platform.GCP != nil:
-		return constants.PlatformGCP
-	case cd.Spec.Platform.OpenStack != nil:
-		return constants.PlatformOpenStack
-	case cd.Spec.Platform.VSphere != nil:
-		return constants.PlatformVSphere
-	case cd.Spec.Platform.BareMetal != nil:
-		return constants.PlatformBaremetal
-	}
-	return constants.PlatformUnknown
-}
-
 // getClusterRegion returns the region of a given ClusterDeployment
 func getClusterRegion(cd *hivev1.ClusterDeployment) string {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		return cd.Spec.Platform.AWS.Region
-	case cd.Spec.Platform.Azure != nil:
-		return cd.Spec.Platform.Azure.Region
-	case cd.Spec.Platform.GCP != nil:
-		return cd.Spec.Platform.GCP.Region
+	if region := controllerutils.InstallRegion(cd); region != "" {
+		return region
 	}
 	return regionUnknown
 }
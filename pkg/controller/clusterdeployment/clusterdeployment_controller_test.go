@@ -2,8 +2,12 @@ package clusterdeployment
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,17 +41,23 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
+	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imagemirror"
+	"github.com/openshift/hive/pkg/machineimage"
+	"github.com/openshift/hive/pkg/platform"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	"github.com/openshift/hive/pkg/secretencryption"
 	testclusterdeprovision "github.com/openshift/hive/pkg/test/clusterdeprovision"
 )
 
 const (
 	testName                = "foo-lqmsh"
 	testClusterName         = "bar"
+	testBaseDomain          = "example.com"
 	testClusterID           = "testFooClusterUUID"
 	testInfraID             = "testFooInfraID"
 	provisionName           = "foo-lqmsh-random"
@@ -55,6 +66,7 @@ const (
 	testSyncsetInstanceName = "testSSI"
 	metadataName            = "foo-lqmsh-metadata"
 	pullSecretSecret        = "pull-secret"
+	installConfigSecret     = "install-config-secret"
 	installLogSecret        = "install-log-secret"
 	globalPullSecret        = "global-pull-secret"
 	adminKubeconfigSecret   = "foo-lqmsh-admin-kubeconfig"
@@ -71,10 +83,19 @@ current-context: admin
 `
 	adminPasswordSecret = "foo-lqmsh-admin-password"
 	adminPassword       = "foo"
+	testInstallConfig   = `apiVersion: v1
+metadata:
+  name: bar
+baseDomain: example.com
+platform:
+  aws:
+    region: us-east-1
+`
 
 	remoteClusterRouteObjectName      = "console"
 	remoteClusterRouteObjectNamespace = "openshift-console"
 	testClusterImageSetName           = "test-image-set"
+	postInstallCheckJobName           = "foo-lqmsh-post-install-check-0"
 )
 
 func init() {
@@ -130,29 +151,619 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 	}{
 		{
-			name: "Add finalizer",
+			name: "Add finalizer",
+			existing: []runtime.Object{
+				testClusterDeploymentWithoutFinalizer(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if cd == nil || !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
+					t.Errorf("did not get expected clusterdeployment finalizer")
+				}
+			},
+		},
+		{
+			name: "Create provision",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Do not create provision without install-config secret",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, installConfigSecretNotFoundReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with invalid install-config",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, "apiVersion: v1\nmetadata:\n  name: bar\nbaseDomain: example.com\n"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, invalidInstallConfigReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with single-node install-config with compute nodes",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, testInstallConfig+"controlPlane:\n  name: master\n  replicas: 1\n"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, invalidInstallConfigReason, cond.Reason, "unexpected condition reason")
+					assert.Contains(t, cond.Message, "a single-node cluster has no compute nodes, found 3", "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Create provision with single-node install-config",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, testInstallConfig+"controlPlane:\n  name: master\n  replicas: 1\ncompute:\n- name: worker\n  replicas: 0\n"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Do not create provision with single-node cluster deployment with compute nodes",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ControlPlaneReplicas = pointer.Int64Ptr(1)
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, invalidInstallConfigReason, cond.Reason, "unexpected condition reason")
+					assert.Contains(t, cond.Message, "a single-node cluster has no compute nodes, found 3", "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Do not create provision when tenant concurrent installs are exceeded",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testProvisioningClusterDeployment("other"),
+				testHiveTenant(func(tenant *hivev1.HiveTenant) { tenant.Spec.MaxConcurrentInstalls = pointer.Int32Ptr(1) }),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: tenantQuotaCheckInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.TenantQuotaExceededCondition)
+				if assert.NotNil(t, cond, "missing TenantQuotaExceeded condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Contains(t, cond.Message, "1 of a maximum of 1 running provisions", "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Do not create provision when tenant vcpus are exceeded by the control plane",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testHiveTenant(func(tenant *hivev1.HiveTenant) { tenant.Spec.MaxVCPUs = pointer.Int32Ptr(8) }),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: tenantQuotaCheckInterval,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.TenantQuotaExceededCondition)
+				if assert.NotNil(t, cond, "missing TenantQuotaExceeded condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Contains(t, cond.Message, "needs 12 vCPUs", "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Create provision within tenant quotas",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.TenantQuotaExceededCondition,
+						Status: corev1.ConditionTrue,
+						Reason: tenantQuotaExceededReason,
+					})
+					return cd
+				}(),
+				testProvisioningClusterDeployment("other"),
+				testHiveTenant(func(tenant *hivev1.HiveTenant) {
+					tenant.Spec.MaxConcurrentInstalls = pointer.Int32Ptr(2)
+					// The control planes of both cluster deployments.
+					tenant.Spec.MaxVCPUs = pointer.Int32Ptr(24)
+				}),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.TenantQuotaExceededCondition)
+				if assert.NotNil(t, cond, "missing TenantQuotaExceeded condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with mismatched install-config",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ClusterName = "other"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, installConfigMismatchReason, cond.Reason, "unexpected condition reason")
+					assert.Contains(t, cond.Message, `metadata.name "bar" is not the cluster name "other"`, "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with mismatched OpenStack install-config",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{
+						OpenStack: &hivev1openstack.Platform{
+							Cloud:                "shiftstack",
+							ExternalNetwork:      "public",
+							ComputeFlavor:        "m1.large",
+							ControlPlaneFlavor:   "m1.xlarge",
+							APIFloatingIP:        "10.0.0.1",
+							IngressFloatingIP:    "10.0.0.2",
+							CredentialsSecretRef: corev1.LocalObjectReference{Name: "openstack-creds"},
+						},
+					}
+					cd.Labels[hivev1.HiveClusterPlatformLabel] = constants.PlatformOpenStack
+					cd.Labels[hivev1.HiveClusterRegionLabel] = regionUnknown
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, "apiVersion: v1\nmetadata:\n  name: bar\nbaseDomain: example.com\ncontrolPlane:\n  name: master\n  platform:\n    openstack:\n      type: m1.large\nplatform:\n  openstack:\n    cloud: shiftstack\n    externalNetwork: private\n    computeFlavor: m1.large\n    lbFloatingIP: 10.0.0.3\n    ingressFloatingIP: 10.0.0.2\n"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, installConfigMismatchReason, cond.Reason, "unexpected condition reason")
+					assert.Contains(t, cond.Message, `platform.openstack.externalNetwork "private" is not the external network "public"`, "unexpected condition message")
+					assert.Contains(t, cond.Message, `controlPlane.platform.openstack.type "m1.large" is not the control plane flavor "m1.xlarge"`, "unexpected condition message")
+					assert.Contains(t, cond.Message, `platform.openstack.apiFloatingIP "10.0.0.3" is not the API floating IP "10.0.0.1"`, "unexpected condition message")
+					assert.NotContains(t, cond.Message, "platform.openstack.cloud", "unexpected condition message")
+					assert.NotContains(t, cond.Message, "platform.openstack.computeFlavor", "unexpected condition message")
+					assert.NotContains(t, cond.Message, "platform.openstack.ingressFloatingIP", "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Clear InstallConfigValidationFailed condition",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.InstallConfigValidationFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: installConfigMismatchReason,
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallConfigValidationFailedCondition)
+				if assert.NotNil(t, cond, "missing InstallConfigValidationFailed condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, installConfigValidReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with unverified release image",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.releaseImageVerifier = fakeReleaseImageVerifier(func(string) error {
+					return errors.New("no signature is published")
+				})
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReleaseImageVerificationFailedCondition)
+				if assert.NotNil(t, cond, "missing ReleaseImageVerificationFailed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, releaseImageUnverifiedReason, cond.Reason, "unexpected condition reason")
+					assert.Equal(t, "no signature is published", cond.Message, "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Create provision with verified release image",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.ReleaseImageVerificationFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: releaseImageUnverifiedReason,
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.releaseImageVerifier = fakeReleaseImageVerifier(func(string) error { return nil })
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReleaseImageVerificationFailedCondition)
+				if assert.NotNil(t, cond, "missing ReleaseImageVerificationFailed condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, releaseImageVerifiedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with missing deprovision credentials",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionCredentialsInvalidCondition)
+				if assert.NotNil(t, cond, "missing DeprovisionCredentialsInvalid condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, deprovisionCredentialsNotFoundReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Do not create provision with failing deprovision credentials",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, "deprovision-creds", "aws_access_key_id", "revoked"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			platformCredentialsValidation: func(_ client.Client, cd *hivev1.ClusterDeployment, _ log.FieldLogger) (bool, error) {
+				return cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "deprovision-creds", nil
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionCredentialsInvalidCondition)
+				if assert.NotNil(t, cond, "missing DeprovisionCredentialsInvalid condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, deprovisionCredentialsAuthFailReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Create provision with valid deprovision credentials",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.DeprovisionCredentialsInvalidCondition,
+						Status: corev1.ConditionTrue,
+						Reason: deprovisionCredentialsNotFoundReason,
+					}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, "deprovision-creds", "aws_access_key_id", "deprovisioner"),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionCredentialsInvalidCondition)
+				if assert.NotNil(t, cond, "missing DeprovisionCredentialsInvalid condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected condition status")
+					assert.Equal(t, deprovisionCredentialsValidReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "Create provision with machine image override",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Platform.AWS.AMIID = "ami-golden"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					var machineImage string
+					for _, container := range provisions[0].Spec.PodSpec.Containers {
+						for _, envVar := range container.Env {
+							if envVar.Name == constants.MachineImageEnvVar {
+								machineImage = envVar.Value
+							}
+						}
+					}
+					assert.Equal(t, "ami-golden", machineImage, "unexpected machine image")
+				}
+			},
+		},
+		{
+			name: "Create provision with images pulled from mirrors",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = pointer.StringPtr("quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:installer")
+					cd.Status.CLIImage = pointer.StringPtr("quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:cli")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.imageMirrorLister = fakeImageMirrorLister{{
+					Source:  "quay.io/openshift-release-dev",
+					Mirrors: []string{"mirror.example.com/openshift"},
+				}}
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					images := map[string]string{}
+					for _, container := range provisions[0].Spec.PodSpec.Containers {
+						images[container.Name] = container.Image
+					}
+					assert.Equal(t, "mirror.example.com/openshift/ocp-v4.0-art-dev@sha256:installer", images["installer"], "unexpected installer image")
+					assert.Equal(t, "mirror.example.com/openshift/ocp-v4.0-art-dev@sha256:cli", images["cli"], "unexpected cli image")
+				}
+			},
+		},
+		{
+			name: "Create provision with architecture of clusterimageset",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					return cd
+				}(),
+				func() *hivev1.ClusterImageSet {
+					cis := testClusterImageSet()
+					cis.Spec.Architecture = hivev1.ArchitectureARM64
+					return cis
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				assert.Equal(t, hivev1.ArchitectureARM64, cd.Status.Architecture, "unexpected architecture")
+				assert.False(t, cd.Status.MultiArchitecture, "unexpected multi-architecture")
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Create provision with multi-architecture clusterimageset",
 			existing: []runtime.Object{
-				testClusterDeploymentWithoutFinalizer(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					return cd
+				}(),
+				func() *hivev1.ClusterImageSet {
+					cis := testClusterImageSet()
+					cis.Spec.Architecture = hivev1.ArchitectureMulti
+					return cis
+				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
+			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				if cd == nil || !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
-					t.Errorf("did not get expected clusterdeployment finalizer")
+				assert.Equal(t, hivev1.ArchitectureAMD64, cd.Status.Architecture, "unexpected architecture")
+				assert.True(t, cd.Status.MultiArchitecture, "expected multi-architecture")
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Stop provision with architecture unsupported by clusterimageset",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					cd.Spec.Provisioning.Architecture = hivev1.ArchitectureARM64
+					return cd
+				}(),
+				func() *hivev1.ClusterImageSet {
+					cis := testClusterImageSet()
+					cis.Spec.Architecture = hivev1.ArchitectureAMD64
+					return cis
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, unsupportedArchitectureReason)
+			},
+		},
+		{
+			name: "Blocking pre-install hook delays provision",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("register", hivev1.PreInstallClusterHookStage, true)}
+					return cd
+				}(),
+				testHookPodTemplate(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				job := getJob(c, testName+"-hook-register")
+				if assert.NotNil(t, job, "missing hook job") {
+					assert.Equal(t, constants.JobTypeHook, job.Labels[constants.JobTypeLabel], "unexpected job type")
+					assert.Equal(t, "register", job.Labels[constants.HookNameLabel], "unexpected hook name")
+					assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy, "unexpected restart policy")
+					assert.Equal(t, "hook-image", job.Spec.Template.Spec.Containers[0].Image, "unexpected job image")
 				}
 			},
 		},
 		{
-			name: "Create provision",
+			name: "Create provision after blocking pre-install hook completes",
 			existing: []runtime.Object{
-				testClusterDeployment(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("register", hivev1.PreInstallClusterHookStage, true)}
+					return cd
+				}(),
+				testHookPodTemplate(),
+				testHookJob("register", batchv1.JobComplete),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
-				provisions := getProvisions(c)
-				assert.Len(t, provisions, 1, "expected provision to exist")
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Create provision with non-blocking pre-install hook",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("register", hivev1.PreInstallClusterHookStage, false)}
+					return cd
+				}(),
+				testHookPodTemplate(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+				assert.NotNil(t, getJob(c, testName+"-hook-register"), "missing hook job")
 			},
 		},
 		{
@@ -189,7 +800,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		{
 			name: "No-op Running provision",
 			existing: []runtime.Object{
-				testClusterDeploymentWithProvision(),
+				testProvisioningClusterDeploymentWithProvision(),
 				testProvision(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
@@ -197,7 +808,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "no clusterdeployment found") {
-					if e, a := testClusterDeploymentWithProvision(), cd; !assert.True(t, apiequality.Semantic.DeepEqual(e, a), "unexpected change in clusterdeployment") {
+					if e, a := testProvisioningClusterDeploymentWithProvision(), cd; !assert.True(t, apiequality.Semantic.DeepEqual(e, a), "unexpected change in clusterdeployment") {
 						t.Logf("diff = %s", diff.ObjectReflectDiff(e, a))
 					}
 				}
@@ -288,6 +899,83 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Completed provision sets platform status",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				func() *hivev1.ClusterProvision {
+					provision := testSuccessfulProvision()
+					provision.Spec.PlatformStatus = &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{
+						VPCID:               "vpc-0123",
+						PrivateHostedZoneID: "Z2PRIVATE",
+					}}
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+					assert.Equal(t, &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{
+						VPCID:               "vpc-0123",
+						PrivateHostedZoneID: "Z2PRIVATE",
+					}}, cd.Status.PlatformStatus, "unexpected platform status")
+				}
+			},
+		},
+		{
+			name: "Provisioning provision sets installer stages",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				func() *hivev1.ClusterProvision {
+					provision := testProvision()
+					provision.Spec.Stage = hivev1.ClusterProvisionStageProvisioning
+					provision.Spec.InstallerStages = []hivev1.InstallerStageTransition{
+						{Stage: hivev1.InstallerStageInfrastructureProvisioned, Time: metav1.NewTime(time.Now().Add(-20 * time.Minute).Truncate(time.Second))},
+						{Stage: hivev1.InstallerStageBootstrapComplete, Time: metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Second))},
+					}
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				if assert.Len(t, cd.Status.InstallerStages, 2, "unexpected installer stages") {
+					assert.Equal(t, hivev1.InstallerStageInfrastructureProvisioned, cd.Status.InstallerStages[0].Stage, "unexpected first installer stage")
+					assert.Equal(t, hivev1.InstallerStageBootstrapComplete, cd.Status.InstallerStages[1].Stage, "unexpected second installer stage")
+				}
+				assertConditionStatus(t, cd, hivev1.BootstrapCompleteCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.BootstrapCompleteCondition, bootstrapCompleteReason)
+			},
+		},
+		{
+			name: "Provisioning provision before bootstrap",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				func() *hivev1.ClusterProvision {
+					provision := testProvision()
+					provision.Spec.Stage = hivev1.ClusterProvisionStageProvisioning
+					provision.Spec.InstallerStages = []hivev1.InstallerStageTransition{
+						{Stage: hivev1.InstallerStageInfrastructureProvisioned, Time: metav1.NewTime(time.Now().Truncate(time.Second))},
+					}
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assert.Len(t, cd.Status.InstallerStages, 1, "unexpected installer stages")
+				assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.BootstrapCompleteCondition), "unexpected BootstrapComplete condition")
+			},
+		},
 		{
 			name: "Completed provision with protected delete",
 			existing: []runtime.Object{
@@ -309,6 +997,152 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Completed provision creates post-install check job",
+			existing: []runtime.Object{
+				testClusterDeploymentWithPostInstallJobCheck(),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+				}
+				job := getJob(c, postInstallCheckJobName)
+				if assert.NotNil(t, job, "missing post-install check job") {
+					assert.Equal(t, constants.JobTypePostInstallCheck, job.Labels[constants.JobTypeLabel], "unexpected job type")
+					container := job.Spec.Template.Spec.Containers[0]
+					assert.Equal(t, "check-image", container.Image, "unexpected job image")
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/etc/kubeconfig/kubeconfig"}, "missing KUBECONFIG env var")
+					assert.Equal(t, adminKubeconfigSecret, job.Spec.Template.Spec.Volumes[0].Secret.SecretName, "unexpected kubeconfig volume")
+				}
+			},
+		},
+		{
+			name: "Completed provision with failed post-install check job",
+			existing: []runtime.Object{
+				testClusterDeploymentWithPostInstallJobCheck(),
+				testSuccessfulProvision(),
+				testPostInstallCheckJob(batchv1.JobFailed),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: postInstallCheckRequeueAfter,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+					assertConditionStatus(t, cd, hivev1.PostInstallChecksFailedCondition, corev1.ConditionTrue)
+					assertConditionReason(t, cd, hivev1.PostInstallChecksFailedCondition, "JobCheckFailed")
+				}
+			},
+		},
+		{
+			name: "Completed provision with passed post-install check job",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithPostInstallJobCheck()
+					cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+						Type:   hivev1.PostInstallChecksFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "JobCheckFailed",
+					}}
+					return cd
+				}(),
+				testSuccessfulProvision(),
+				testPostInstallCheckJob(batchv1.JobComplete),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+					assertConditionStatus(t, cd, hivev1.PostInstallChecksFailedCondition, corev1.ConditionFalse)
+				}
+			},
+		},
+		{
+			name: "Completed provision with default post-install checks",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.defaultPostInstallChecks = []hivev1.PostInstallCheck{{
+					Type: hivev1.JobPostInstallCheck,
+					Job:  &hivev1.PostInstallCheckJob{Image: "check-image"},
+				}}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+				}
+				assert.NotNil(t, getJob(c, postInstallCheckJobName), "missing post-install check job")
+			},
+		},
+		{
+			name: "Completed provision with failed non-blocking post-install hook",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("register", hivev1.PostInstallClusterHookStage, false)}
+					return cd
+				}(),
+				testSuccessfulProvision(),
+				testHookJob("register", batchv1.JobFailed),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+					assertConditionStatus(t, cd, hivev1.HookFailedCondition, corev1.ConditionTrue)
+				}
+			},
+		},
+		{
+			name: "Completed provision with blocking post-install hook",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("register", hivev1.PostInstallClusterHookStage, true)}
+					return cd
+				}(),
+				testSuccessfulProvision(),
+				testHookPodTemplate(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+				}
+				job := getJob(c, testName+"-hook-register")
+				if assert.NotNil(t, job, "missing hook job") {
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/etc/kubeconfig/kubeconfig"}, "missing KUBECONFIG env var")
+				}
+			},
+		},
 		{
 			name: "clusterdeployment must specify pull secret when there is no global pull secret ",
 			existing: []runtime.Object{
@@ -331,6 +1165,45 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 		},
+		{
+			name: "Record observed generation",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithProvision()
+					cd.Generation = 3
+					cd.Status.ObservedGeneration = 2
+					return cd
+				}(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "no clusterdeployment found") {
+					assert.Equal(t, int64(3), cd.Status.ObservedGeneration, "unexpected observed generation")
+				}
+			},
+		},
+		{
+			name: "Set Ready condition while provisioning",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "no clusterdeployment found") {
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterReadyCondition)
+					if assert.NotNil(t, cond, "missing Ready condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected Ready condition status")
+						assert.Equal(t, provisioningReason, cond.Reason, "unexpected Ready condition reason")
+					}
+				}
+			},
+		},
 		{
 			name: "No-op deleted cluster without finalizer",
 			existing: []runtime.Object{
@@ -361,6 +1234,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				deprovision := getDeprovision(c)
 				assert.Nil(t, deprovision, "expected no deprovision request")
@@ -437,12 +1311,77 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				deprovision := getDeprovision(c)
 				require.NotNil(t, deprovision, "expected deprovision request")
 				assert.Equal(t, testClusterDeployment().Name, deprovision.Labels[constants.ClusterDeploymentNameLabel], "incorrect cluster deployment name label")
 			},
 		},
+		{
+			name: "Failed blocking pre-deprovision hook blocks deprovision",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeployment()
+					cd.Spec.Installed = true
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("drain", hivev1.PreDeprovisionClusterHookStage, true)}
+					return cd
+				}(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+				testHookJob("drain", batchv1.JobFailed),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request")
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assertConditionStatus(t, cd, hivev1.HookFailedCondition, corev1.ConditionTrue)
+				}
+			},
+		},
+		{
+			name: "Create deprovision after blocking pre-deprovision hook completes",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeployment()
+					cd.Spec.Installed = true
+					cd.Spec.Hooks = []hivev1.ClusterHook{testHook("drain", hivev1.PreDeprovisionClusterHookStage, true)}
+					return cd
+				}(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+				testHookJob("drain", batchv1.JobComplete),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request")
+			},
+		},
+		{
+			name: "Create deprovision with deprovision credentials",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedClusterDeployment()
+					cd.Spec.Installed = true
+					cd.Spec.DeprovisionCredentialsSecretRef = &corev1.LocalObjectReference{Name: "deprovision-creds"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
+			validate: func(c client.Client, t *testing.T) {
+				deprovision := getDeprovision(c)
+				if assert.NotNil(t, deprovision, "expected deprovision request") && assert.NotNil(t, deprovision.Spec.Platform.AWS, "expected AWS deprovision") {
+					assert.Equal(t, "deprovision-creds", deprovision.Spec.Platform.AWS.CredentialsSecretRef.Name, "unexpected deprovision credentials")
+				}
+				cd := getCD(c)
+				assert.Equal(t, "aws-credentials", cd.Spec.Platform.AWS.CredentialsSecretRef.Name, "platform credentials of the cluster deployment should not change")
+			},
+		},
 		{
 			name: "Create job to resolve installer image",
 			existing: []runtime.Object{
@@ -518,6 +1457,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testClusterImageSet(),
 				testCompletedImageSetJob(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
@@ -539,6 +1479,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testClusterImageSet(),
 				testCompletedImageSetJob(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
@@ -592,6 +1533,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cis
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
@@ -627,6 +1569,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			validate: func(c client.Client, t *testing.T) {
@@ -645,6 +1588,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testDNSZone(),
 			},
@@ -662,6 +1606,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testDNSZone(),
 			},
@@ -679,6 +1624,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testDNSZoneWithInvalidCredentialsCondition(),
 			},
@@ -697,6 +1643,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testDNSZoneWithAuthenticationFailureCondition(),
 			},
@@ -719,6 +1666,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testAvailableDNSZone(),
 			},
@@ -736,6 +1684,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				func() *hivev1.DNSZone {
 					zone := testDNSZone()
@@ -766,6 +1715,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				func() *hivev1.DNSZone {
 					zone := testDNSZone()
@@ -797,6 +1747,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testAvailableDNSZone(),
 			},
@@ -815,6 +1766,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testAvailableDNSZone(),
 			},
@@ -836,6 +1788,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testDNSZone(),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				dnsZone := getDNSZone(c)
 				assert.Nil(t, dnsZone, "dnsZone should not exist")
@@ -852,6 +1805,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				deprovision := getDeprovision(c)
 				assert.NotNil(t, deprovision, "expected deprovision request to be created")
@@ -866,6 +1820,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 				testFailedProvisionAttempt(0),
 				testFailedProvisionAttempt(1),
@@ -878,94 +1833,400 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				for _, p := range getProvisions(c) {
 					actualAttempts = append(actualAttempts, p.Spec.Attempt)
 				}
-				expectedAttempts := []int{0, 2, 3, 4}
-				assert.ElementsMatch(t, expectedAttempts, actualAttempts, "unexpected provisions kept")
+				expectedAttempts := []int{0, 2, 3, 4}
+				assert.ElementsMatch(t, expectedAttempts, actualAttempts, "unexpected provisions kept")
+			},
+		},
+		{
+			name: "Adopt provision",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testProvision(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "provision reference not set") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision referenced")
+					}
+				}
+			},
+		},
+		{
+			name: "Do not adopt failed provision",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testFailedProvisionAttempt(0),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected provision reference to not be set")
+				}
+			},
+		},
+		{
+			name: "Delete-after requeue",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.CreationTimestamp = metav1.Now()
+					if cd.Annotations == nil {
+						cd.Annotations = make(map[string]string, 1)
+					}
+					cd.Annotations[deleteAfterAnnotation] = "8h"
+					return cd
+				}(),
+				testProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 8 * time.Hour,
+		},
+		{
+			name: "Wait after failed provision",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.CreationTimestamp = metav1.Now()
+					if cd.Annotations == nil {
+						cd.Annotations = make(map[string]string, 1)
+					}
+					cd.Annotations[deleteAfterAnnotation] = "8h"
+					return cd
+				}(),
+				testFailedProvisionTime(time.Now()),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 1 * time.Minute,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+				}
+			},
+		},
+		{
+			name: "Clear out provision after wait time",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				testFailedProvisionTime(time.Now().Add(-2 * time.Minute)),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 1, cd.Status.InstallRestarts, "expected incremented install restart count")
+				}
+			},
+		},
+		{
+			name: "Clear out provision which failed before creating infrastructure without backoff",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Status.InstallRestarts = 4
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(false)
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 5, cd.Status.InstallRestarts, "expected incremented install restart count")
+				}
+			},
+		},
+		{
+			name: "Back off after consecutive provisions failed before creating infrastructure",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Status.InstallRestarts = 4
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Spec.Attempt = 2
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(false)
+					return provision
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(0)
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(false)
+					return provision
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(1)
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(false)
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: 14 * time.Minute,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.NotNil(t, cd.Status.ProvisionRef, "expected provision ref")
+					assert.Equal(t, 4, cd.Status.InstallRestarts, "unexpected install restart count")
+				}
+			},
+		},
+		{
+			name: "Create provision without cleanup after failure before creating infrastructure",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(0)
+					provision.Spec.InfraID = pointer.StringPtr(testInfraID)
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(false)
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				var provision *hivev1.ClusterProvision
+				for _, p := range getProvisions(c) {
+					if p.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
+						provision = p
+					}
+				}
+				if assert.NotNil(t, provision, "expected new provision") {
+					assert.Nil(t, provision.Spec.PrevInfraID, "unexpected previous infra ID")
+					assert.Nil(t, provision.Spec.PrevClusterID, "unexpected previous cluster ID")
+				}
+			},
+		},
+		{
+			name: "Create provision without cleanup after failed install destroyed its infrastructure",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(0)
+					provision.Spec.InfraID = pointer.StringPtr(testInfraID)
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(true)
+					provision.Spec.InfrastructureDestroyed = true
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				var provision *hivev1.ClusterProvision
+				for _, p := range getProvisions(c) {
+					if p.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
+						provision = p
+					}
+				}
+				if assert.NotNil(t, provision, "expected new provision") {
+					assert.Nil(t, provision.Spec.PrevInfraID, "unexpected previous infra ID")
+					assert.Nil(t, provision.Spec.PrevClusterID, "unexpected previous cluster ID")
+				}
+			},
+		},
+		{
+			name: "Create provision with cleanup after failure which created infrastructure",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(0)
+					provision.Spec.InfraID = pointer.StringPtr(testInfraID)
+					provision.Spec.InfrastructureCreated = pointer.BoolPtr(true)
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				var provision *hivev1.ClusterProvision
+				for _, p := range getProvisions(c) {
+					if p.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
+						provision = p
+					}
+				}
+				if assert.NotNil(t, provision, "expected new provision") && assert.NotNil(t, provision.Spec.PrevInfraID, "missing previous infra ID") {
+					assert.Equal(t, testInfraID, *provision.Spec.PrevInfraID, "unexpected previous infra ID")
+				}
 			},
 		},
 		{
-			name: "Adopt provision",
+			name: "Fail over to fallback region after capacity failure",
 			existing: []runtime.Object{
-				testClusterDeployment(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Platform.AWS.FallbackRegions = []string{"us-east-2", "us-west-2"}
+					cd.Status.InstallRegion = "us-east-1"
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now())
+					provision.Spec.Region = "us-east-1"
+					provision.Status.Conditions[0].Reason = "AWSInsufficientCapacity"
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
-				testProvision(),
 			},
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				if assert.NotNil(t, cd, "missing cluster deployment") {
-					if assert.NotNil(t, cd.Status.ProvisionRef, "provision reference not set") {
-						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision referenced")
-					}
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 1, cd.Status.InstallRestarts, "expected incremented install restart count")
+					assert.Equal(t, "us-east-2", cd.Status.InstallRegion, "unexpected install region")
+					assertConditionReason(t, cd, hivev1.ProvisionFailedCondition, "AWSInsufficientCapacity")
 				}
 			},
 		},
 		{
-			name: "Do not adopt failed provision",
+			name: "Wait after capacity failure in last fallback region",
 			existing: []runtime.Object{
-				testClusterDeployment(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Platform.AWS.FallbackRegions = []string{"us-west-2"}
+					cd.Status.InstallRegion = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now())
+					provision.Spec.Region = "us-west-2"
+					provision.Status.Conditions[0].Reason = "AWSInsufficientCapacity"
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
-				testFailedProvisionAttempt(0),
 			},
-			expectPendingCreation: true,
+			expectedRequeueAfter: 1 * time.Minute,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				if assert.NotNil(t, cd, "missing cluster deployment") {
-					assert.Nil(t, cd.Status.ProvisionRef, "expected provision reference to not be set")
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref")
+					assert.Equal(t, "us-west-2", cd.Status.InstallRegion, "unexpected install region")
 				}
 			},
 		},
 		{
-			name: "Delete-after requeue",
+			name: "Create provision in fallback region",
 			existing: []runtime.Object{
 				func() runtime.Object {
-					cd := testClusterDeploymentWithProvision()
-					cd.CreationTimestamp = metav1.Now()
-					if cd.Annotations == nil {
-						cd.Annotations = make(map[string]string, 1)
-					}
-					cd.Annotations[deleteAfterAnnotation] = "8h"
+					cd := testClusterDeployment()
+					cd.Spec.Platform.AWS.FallbackRegions = []string{"us-west-2"}
+					cd.Status.InstallRegion = "us-west-2"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-west-2"
+					cd.Status.InstallRestarts = 1
 					return cd
 				}(),
-				testProvision(),
+				func() runtime.Object {
+					provision := testFailedProvisionAttempt(0)
+					provision.Spec.InfraID = pointer.StringPtr(testInfraID)
+					provision.Spec.Region = "us-east-1"
+					return provision
+				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
-			expectedRequeueAfter: 8 * time.Hour,
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				var provision *hivev1.ClusterProvision
+				for _, p := range getProvisions(c) {
+					if p.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
+						provision = p
+					}
+				}
+				if assert.NotNil(t, provision, "expected new provision") {
+					assert.Equal(t, "us-west-2", provision.Spec.Region, "unexpected provision region")
+					if assert.NotNil(t, provision.Spec.PrevRegion, "missing previous region") {
+						assert.Equal(t, "us-east-1", *provision.Spec.PrevRegion, "unexpected previous region")
+					}
+				}
+			},
 		},
 		{
-			name: "Wait after failed provision",
+			name: "Create provision with fallback regions sets install region",
 			existing: []runtime.Object{
 				func() runtime.Object {
-					cd := testClusterDeploymentWithProvision()
-					cd.CreationTimestamp = metav1.Now()
-					if cd.Annotations == nil {
-						cd.Annotations = make(map[string]string, 1)
-					}
-					cd.Annotations[deleteAfterAnnotation] = "8h"
+					cd := testClusterDeployment()
+					cd.Spec.Platform.AWS.FallbackRegions = []string{"us-west-2"}
 					return cd
 				}(),
-				testFailedProvisionTime(time.Now()),
-				testMetadataConfigMap(),
-				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
-			expectedRequeueAfter: 1 * time.Minute,
+			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "missing clusterdeployment") {
-					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
-						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
-					}
+					assert.Equal(t, "us-east-1", cd.Status.InstallRegion, "unexpected install region")
+				}
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					assert.Equal(t, "us-east-1", provisions[0].Spec.Region, "unexpected provision region")
+					assert.Nil(t, provisions[0].Spec.PrevRegion, "unexpected previous region")
 				}
 			},
 		},
 		{
-			name: "Clear out provision after wait time",
+			name: "Stop provisioning after fatal provision failure",
 			existing: []runtime.Object{
 				testClusterDeploymentWithProvision(),
-				testFailedProvisionTime(time.Now().Add(-2 * time.Minute)),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Status.Conditions[0].Reason = "InvalidCredentials"
+					provision.Status.Conditions[0].Message = "Credentials are invalid"
+					return provision
+				}(),
 				testMetadataConfigMap(),
 				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
@@ -974,8 +2235,14 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "missing clusterdeployment") {
-					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
-					assert.Equal(t, 1, cd.Status.InstallRestarts, "expected incremented install restart count")
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+					assert.Equal(t, 0, cd.Status.InstallRestarts, "unexpected install restart count")
+					assertConditionStatus(t, cd, hivev1.ProvisionFailedCondition, corev1.ConditionTrue)
+					assertConditionReason(t, cd, hivev1.ProvisionFailedCondition, "InvalidCredentials")
+					assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+					assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, fatalProvisionFailureReason)
 				}
 			},
 		},
@@ -1035,6 +2302,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "missing clusterdeployment") {
@@ -1122,7 +2390,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "missing clusterdeployment") {
-					assert.Equal(t, getClusterPlatform(cd), cd.Labels[hivev1.HiveClusterPlatformLabel], "incorrect cluster platform label")
+					assert.Equal(t, platform.Name(cd), cd.Labels[hivev1.HiveClusterPlatformLabel], "incorrect cluster platform label")
 				}
 			},
 		},
@@ -1139,6 +2407,22 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Set cluster name of generated cluster deployment",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Spec.ClusterName = ""
+					return cd
+				}(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Equal(t, cd.Name, cd.Spec.ClusterName, "unexpected cluster name")
+				}
+			},
+		},
 		{
 			name: "Ensure cluster metadata set from provision",
 			existing: []runtime.Object{
@@ -1163,6 +2447,33 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Ensure metadata.json and terraform state references set from provision",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.ClusterMetadata = nil
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testSuccessfulProvision()
+					provision.Spec.MetadataJSONSecretRef = &corev1.LocalObjectReference{Name: "metadata-json-secret"}
+					provision.Spec.TerraformStateSecretRef = &corev1.LocalObjectReference{Name: "terraform-state-secret"}
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Spec.ClusterMetadata, "expected cluster metadata to be set") {
+						assert.Equal(t, &corev1.LocalObjectReference{Name: "metadata-json-secret"}, cd.Spec.ClusterMetadata.MetadataJSONSecretRef, "unexpected metadata.json secret")
+						assert.Equal(t, &corev1.LocalObjectReference{Name: "terraform-state-secret"}, cd.Spec.ClusterMetadata.TerraformStateSecretRef, "unexpected terraform state secret")
+					}
+				}
+			},
+		},
 		{
 			name: "Ensure cluster metadata overwrites from provision",
 			existing: []runtime.Object{
@@ -1227,14 +2538,16 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}(),
 				testClusterImageSet(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				require.Equal(t, 1, len(cd.Status.Conditions))
-				require.Equal(t, corev1.ConditionFalse, cd.Status.Conditions[0].Status)
-				require.Equal(t, clusterImageSetFoundReason, cd.Status.Conditions[0].Reason)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterImageSetNotFoundCondition)
+				require.NotNil(t, cond)
+				require.Equal(t, corev1.ConditionFalse, cond.Status)
+				require.Equal(t, clusterImageSetFoundReason, cond.Reason)
 			},
 		},
 		{
@@ -1280,6 +2593,49 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Add ownership to admin secrets of failed provisions",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(
+					testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				func() *corev1.Secret {
+					s := testSecret(corev1.SecretTypeOpaque, "failed-provision-admin-kubeconfig", "kubeconfig", adminKubeconfig)
+					s.Labels = map[string]string{
+						constants.ClusterDeploymentNameLabel: testName,
+						constants.ClusterProvisionNameLabel:  "failed-provision",
+						constants.SecretTypeLabel:            constants.SecretTypeKubeConfig,
+					}
+					return s
+				}(),
+				func() *corev1.Secret {
+					s := testSecret(corev1.SecretTypeOpaque, "failed-provision-admin-password", "password", adminPassword)
+					s.Labels = map[string]string{
+						constants.ClusterDeploymentNameLabel: testName,
+						constants.ClusterProvisionNameLabel:  "failed-provision",
+						constants.SecretTypeLabel:            constants.SecretTypeKubeAdminCreds,
+					}
+					return s
+				}(),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				for _, secretName := range []string{"failed-provision-admin-kubeconfig", "failed-provision-admin-password"} {
+					secret := &corev1.Secret{}
+					err := c.Get(context.TODO(), client.ObjectKey{Name: secretName, Namespace: testNamespace}, secret)
+					require.NoErrorf(t, err, "not found secret %s", secretName)
+					cdAsOwnerRef := false
+					for _, ref := range secret.GetOwnerReferences() {
+						if ref.Name == testName {
+							cdAsOwnerRef = true
+						}
+					}
+					assert.Truef(t, cdAsOwnerRef, "cluster deployment not owner of %s", secretName)
+				}
+			},
+		},
 		{
 			name: "delete finalizer when deprovision complete and dnszone gone",
 			existing: []runtime.Object{
@@ -1319,6 +2675,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					testclusterdeprovision.WithName(testName),
 				),
 			},
+			expectedRequeueAfter: defaultDeprovisionStuckThreshold,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				require.NotNil(t, cd, "could not get ClusterDeployment")
@@ -1355,6 +2712,66 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			},
 			expectedRequeueAfter: defaultRequeueTime,
 		},
+		{
+			name: "set DeprovisionStuck when deprovision takes too long",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Installed = true
+					deleted := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+					cd.DeletionTimestamp = &deleted
+					return cd
+				}(),
+				testclusterdeprovision.Build(
+					testclusterdeprovision.WithNamespace(testNamespace),
+					testclusterdeprovision.WithName(testName),
+				),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.DeprovisionStuckCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.DeprovisionStuckCondition, deprovisionTakingTooLongReason)
+			},
+		},
+		{
+			name: "clear DeprovisionStuck when deprovision completes",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.Spec.Installed = true
+					deleted := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+					cd.DeletionTimestamp = &deleted
+					cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+						cd.Status.Conditions,
+						hivev1.DeprovisionStuckCondition,
+						corev1.ConditionTrue,
+						deprovisionTakingTooLongReason,
+						"",
+						controllerutils.UpdateConditionNever)
+					return cd
+				}(),
+				testclusterdeprovision.Build(
+					testclusterdeprovision.WithNamespace(testNamespace),
+					testclusterdeprovision.WithName(testName),
+					testclusterdeprovision.Completed(),
+				),
+				func() *hivev1.DNSZone {
+					dnsZone := testDNSZone()
+					now := metav1.Now()
+					dnsZone.DeletionTimestamp = &now
+					return dnsZone
+				}(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.DeprovisionStuckCondition, corev1.ConditionFalse)
+				assertConditionReason(t, cd, hivev1.DeprovisionStuckCondition, deprovisionCompletedReason)
+			},
+			expectedRequeueAfter: defaultRequeueTime,
+		},
 		{
 			name: "do not wait for dnszone to be gone when not using managed dns",
 			existing: []runtime.Object{
@@ -1433,6 +2850,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			expectPendingCreation: true,
@@ -1452,6 +2870,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			validate: func(c client.Client, t *testing.T) {
@@ -1471,6 +2890,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					return cd
 				}(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
 			validate: func(c client.Client, t *testing.T) {
@@ -1551,6 +2971,9 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				expectations:                            controllerExpectations,
 				remoteClusterAPIClientBuilder:           func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
 				validateCredentialsForClusterDeployment: test.platformCredentialsValidation,
+				machineImageResolver:                    machineimage.NewResolver(fakeClient),
+				imageMirrorLister:                       fakeImageMirrorLister(nil),
+				secretEncryptionProvider:                secretencryption.ProviderFromEnv,
 			}
 
 			if test.reconcilerSetup != nil {
@@ -1628,6 +3051,7 @@ func TestClusterDeploymentReconcileResults(t *testing.T) {
 				logger:                        logger,
 				expectations:                  controllerExpectations,
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				secretEncryptionProvider:      secretencryption.ProviderFromEnv,
 			}
 
 			reconcileResult, err := rcd.Reconcile(reconcile.Request{
@@ -1644,6 +3068,94 @@ func TestClusterDeploymentReconcileResults(t *testing.T) {
 	}
 }
 
+func TestReadyCondition(t *testing.T) {
+	installed := func(conditions ...hivev1.ClusterDeploymentCondition) *hivev1.ClusterDeployment {
+		cd := testClusterDeployment()
+		cd.Spec.Installed = true
+		cd.Status.Conditions = conditions
+		return cd
+	}
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "provisioning",
+			cd:             testClusterDeployment(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: provisioningReason,
+		},
+		{
+			name: "provision stopped",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.ProvisionStoppedCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return cd
+			}(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: provisionStoppedReason,
+		},
+		{
+			name:           "installed",
+			cd:             installed(),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: clusterReadyReason,
+		},
+		{
+			name: "installed and running",
+			cd: installed(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionFalse,
+				Reason: hivev1.RunningHibernationReason,
+			}),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: clusterReadyReason,
+		},
+		{
+			name: "hibernating",
+			cd: installed(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionTrue,
+				Reason: hivev1.HibernatingHibernationReason,
+			}),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.HibernatingHibernationReason,
+		},
+		{
+			name: "unreachable",
+			cd: installed(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionTrue,
+			}),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: unreachableReason,
+		},
+		{
+			name: "deleted",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := installed()
+				now := metav1.Now()
+				cd.DeletionTimestamp = &now
+				return cd
+			}(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: deprovisioningReason,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason, _ := readyCondition(tc.cd)
+			assert.Equal(t, tc.expectedStatus, status, "unexpected status")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+		})
+	}
+}
+
 func TestCalculateNextProvisionTime(t *testing.T) {
 	cases := []struct {
 		name             string
@@ -1708,6 +3220,7 @@ func TestDeleteStaleProvisions(t *testing.T) {
 		name             string
 		existingAttempts []int
 		expectedAttempts []int
+		jobHistory       string
 	}{
 		{
 			name: "none",
@@ -1737,9 +3250,25 @@ func TestDeleteStaleProvisions(t *testing.T) {
 			existingAttempts: []int{10, 3, 7, 8, 1},
 			expectedAttempts: []int{1, 8, 10},
 		},
+		{
+			name:             "five with configured max",
+			existingAttempts: []int{0, 1, 2, 3, 4},
+			expectedAttempts: []int{0, 1, 2, 3, 4},
+			jobHistory:       `{"maxProvisions": 5}`,
+		},
+		{
+			name:             "five with max below minimum",
+			existingAttempts: []int{0, 1, 2, 3, 4},
+			expectedAttempts: []int{0, 4},
+			jobHistory:       `{"maxProvisions": 1}`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
+			if tc.jobHistory != "" {
+				os.Setenv(constants.JobHistoryEnvVar, tc.jobHistory)
+				defer os.Unsetenv(constants.JobHistoryEnvVar)
+			}
 			provisions := make([]runtime.Object, len(tc.existingAttempts))
 			for i, a := range tc.existingAttempts {
 				provisions[i] = testFailedProvisionAttempt(a)
@@ -1822,6 +3351,7 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 
 	cd.Spec = hivev1.ClusterDeploymentSpec{
 		ClusterName: testClusterName,
+		BaseDomain:  testBaseDomain,
 		PullSecretRef: &corev1.LocalObjectReference{
 			Name: pullSecretSecret,
 		},
@@ -1834,7 +3364,7 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 			},
 		},
 		Provisioning: &hivev1.Provisioning{
-			InstallConfigSecretRef: corev1.LocalObjectReference{Name: "install-config-secret"},
+			InstallConfigSecretRef: corev1.LocalObjectReference{Name: installConfigSecret},
 		},
 		ClusterMetadata: &hivev1.ClusterMetadata{
 			ClusterID:                testClusterID,
@@ -1910,10 +3440,90 @@ func testExpiredClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
-func testClusterDeploymentWithProvision() *hivev1.ClusterDeployment {
-	cd := testClusterDeployment()
-	cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
-	return cd
+func testClusterDeploymentWithProvision() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
+	return cd
+}
+
+// testProvisioningClusterDeploymentWithProvision returns a cluster deployment with a provision which already reports
+// that it is not ready because it is provisioning.
+func testProvisioningClusterDeploymentWithProvision() *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithProvision()
+	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+		Type:    hivev1.ClusterReadyCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  provisioningReason,
+		Message: "The cluster is being provisioned",
+	})
+	return cd
+}
+
+func testClusterDeploymentWithPostInstallJobCheck() *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithProvision()
+	cd.Spec.PostInstallChecks = []hivev1.PostInstallCheck{{
+		Type: hivev1.JobPostInstallCheck,
+		Job:  &hivev1.PostInstallCheckJob{Image: "check-image"},
+	}}
+	return cd
+}
+
+func testPostInstallCheckJob(conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      postInstallCheckJobName,
+			Namespace: testNamespace,
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{
+				Type:   conditionType,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	}
+}
+
+func testHook(name string, stage hivev1.ClusterHookStage, blocking bool) hivev1.ClusterHook {
+	return hivev1.ClusterHook{
+		Name:           name,
+		Stage:          stage,
+		PodTemplateRef: corev1.LocalObjectReference{Name: "hook-template"},
+		Blocking:       blocking,
+	}
+}
+
+func testHookPodTemplate() *corev1.PodTemplate {
+	return &corev1.PodTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hook-template",
+			Namespace: testNamespace,
+		},
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+			},
+		},
+	}
+}
+
+func testHookJob(hookName string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName + "-hook-" + hookName,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: testName,
+				constants.JobTypeLabel:               constants.JobTypeHook,
+				constants.HookNameLabel:              hookName,
+			},
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{
+				Type:   conditionType,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	}
 }
 
 func testProvision() *hivev1.ClusterProvision {
@@ -2008,6 +3618,48 @@ func testSecret(secretType corev1.SecretType, name, key, value string) *corev1.S
 	return testSecretWithNamespace(secretType, name, testNamespace, key, value)
 }
 
+// fakeImageMirrorLister is an image mirror lister which lists the mirrors.
+type fakeImageMirrorLister imagemirror.Mirrors
+
+func (f fakeImageMirrorLister) List(log.FieldLogger) (imagemirror.Mirrors, error) {
+	return imagemirror.Mirrors(f), nil
+}
+
+// fakeReleaseImageVerifier is a release image verifier which verifies release images with the function.
+type fakeReleaseImageVerifier func(releaseImage string) error
+
+func (f fakeReleaseImageVerifier) Verify(releaseImage string) error {
+	return f(releaseImage)
+}
+
+// testProvisioningClusterDeployment returns another cluster deployment in the namespace with a running provision.
+func testProvisioningClusterDeployment(name string) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Name = name
+	cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: name + "-provision"}
+	return cd
+}
+
+func testHiveTenant(opts ...func(*hivev1.HiveTenant)) *hivev1.HiveTenant {
+	tenant := &hivev1.HiveTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "test-tenant",
+		},
+		Spec: hivev1.HiveTenantSpec{
+			InstanceTypeVCPUs: map[string]int32{"m5.xlarge": 4},
+		},
+	}
+	for _, opt := range opts {
+		opt(tenant)
+	}
+	return tenant
+}
+
+func testInstallConfigSecret() *corev1.Secret {
+	return testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, testInstallConfig)
+}
+
 func testSecretWithNamespace(secretType corev1.SecretType, name, namespace, key, value string) *corev1.Secret {
 	s := &corev1.Secret{
 		Type: secretType,
@@ -2131,6 +3783,15 @@ func getJob(c client.Client, name string) *batchv1.Job {
 	return nil
 }
 
+func getSecret(c client.Client, name string) *corev1.Secret {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: testNamespace}, secret)
+	if err == nil {
+		return secret
+	}
+	return nil
+}
+
 func TestUpdatePullSecretInfo(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	testPullSecret1 := `{"auths": {"registry.svc.ci.okd.org": {"auth": "dXNljlfjldsfSDD"}}}`
@@ -2493,3 +4154,633 @@ func testCompletedFailedImageSetJob() *batchv1.Job {
 		},
 	}
 }
+
+func TestRunPostInstallChecks(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	openshiftapiv1.Install(scheme.Scheme)
+	routev1.Install(scheme.Scheme)
+
+	console := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer console.Close()
+	consoleRoute := func(host string) *routev1.Route {
+		return &routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{Name: remoteClusterRouteObjectName, Namespace: remoteClusterRouteObjectNamespace},
+			Spec:       routev1.RouteSpec{Host: host},
+		}
+	}
+	clusterOperator := func(name string, available openshiftapiv1.ConditionStatus) *openshiftapiv1.ClusterOperator {
+		return &openshiftapiv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: openshiftapiv1.ClusterOperatorStatus{
+				Conditions: []openshiftapiv1.ClusterOperatorStatusCondition{{
+					Type:   openshiftapiv1.OperatorAvailable,
+					Status: available,
+				}},
+			},
+		}
+	}
+	clusterVersion := &openshiftapiv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}}
+	node := func(name, role string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"node-role.kubernetes.io/" + role: ""},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{
+					Type:   corev1.NodeReady,
+					Status: ready,
+				}},
+			},
+		}
+	}
+	singleNodeInstallConfig := testInstallConfig + "controlPlane:\n  name: master\n  replicas: 1\ncompute:\n- name: worker\n  replicas: 0\n"
+	compactInstallConfig := testInstallConfig + "controlPlane:\n  name: master\n  replicas: 3\ncompute:\n- name: worker\n  replicas: 0\n"
+
+	tests := []struct {
+		name           string
+		checks         []hivev1.PostInstallCheck
+		installConfig  string
+		remote         []runtime.Object
+		expectPassed   bool
+		expectedReason string
+	}{
+		{
+			name:         "no checks",
+			expectPassed: true,
+		},
+		{
+			name:         "api reachable",
+			checks:       []hivev1.PostInstallCheck{{Type: hivev1.APIReachablePostInstallCheck}},
+			remote:       []runtime.Object{clusterVersion},
+			expectPassed: true,
+		},
+		{
+			name:           "api not reachable",
+			checks:         []hivev1.PostInstallCheck{{Type: hivev1.APIReachablePostInstallCheck}},
+			expectedReason: "APIReachableCheckFailed",
+		},
+		{
+			name:         "console serving",
+			checks:       []hivev1.PostInstallCheck{{Type: hivev1.ConsoleAvailablePostInstallCheck}},
+			remote:       []runtime.Object{consoleRoute(strings.TrimPrefix(console.URL, "https://"))},
+			expectPassed: true,
+		},
+		{
+			name:           "console not serving",
+			checks:         []hivev1.PostInstallCheck{{Type: hivev1.ConsoleAvailablePostInstallCheck}},
+			remote:         []runtime.Object{consoleRoute("127.0.0.1:1")},
+			expectedReason: "ConsoleAvailableCheckFailed",
+		},
+		{
+			name:   "cluster operators available",
+			checks: []hivev1.PostInstallCheck{{Type: hivev1.ClusterOperatorsAvailablePostInstallCheck}},
+			remote: []runtime.Object{
+				clusterOperator("console", openshiftapiv1.ConditionTrue),
+				clusterOperator("ingress", openshiftapiv1.ConditionTrue),
+			},
+			expectPassed: true,
+		},
+		{
+			name: "cluster operator not available",
+			checks: []hivev1.PostInstallCheck{
+				{Type: hivev1.APIReachablePostInstallCheck},
+				{Type: hivev1.ClusterOperatorsAvailablePostInstallCheck},
+			},
+			remote: []runtime.Object{
+				clusterVersion,
+				clusterOperator("console", openshiftapiv1.ConditionTrue),
+				clusterOperator("ingress", openshiftapiv1.ConditionFalse),
+			},
+			expectedReason: "ClusterOperatorsAvailableCheckFailed",
+		},
+		{
+			name:          "nodes ready",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: compactInstallConfig,
+			remote: []runtime.Object{
+				node("master-0", "master", corev1.ConditionTrue),
+				node("master-1", "master", corev1.ConditionTrue),
+				node("master-2", "master", corev1.ConditionTrue),
+			},
+			expectPassed: true,
+		},
+		{
+			name:          "node not ready",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: compactInstallConfig,
+			remote: []runtime.Object{
+				node("master-0", "master", corev1.ConditionTrue),
+				node("master-1", "master", corev1.ConditionFalse),
+				node("master-2", "master", corev1.ConditionTrue),
+			},
+			expectedReason: "NodesReadyCheckFailed",
+		},
+		{
+			name:          "compute nodes missing",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: testInstallConfig,
+			remote: []runtime.Object{
+				node("master-0", "master", corev1.ConditionTrue),
+				node("master-1", "master", corev1.ConditionTrue),
+				node("master-2", "master", corev1.ConditionTrue),
+				node("worker-0", "worker", corev1.ConditionTrue),
+			},
+			expectedReason: "NodesReadyCheckFailed",
+		},
+		{
+			name:          "compact cluster with unschedulable control plane",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: compactInstallConfig,
+			remote: []runtime.Object{
+				node("master-0", "master", corev1.ConditionTrue),
+				func() *corev1.Node {
+					n := node("master-1", "master", corev1.ConditionTrue)
+					n.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}}
+					return n
+				}(),
+				node("master-2", "master", corev1.ConditionTrue),
+			},
+			expectedReason: "NodesReadyCheckFailed",
+		},
+		{
+			name:          "control plane tainted in cluster with compute nodes",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: testInstallConfig,
+			remote: func() []runtime.Object {
+				var nodes []runtime.Object
+				for i := 0; i < 3; i++ {
+					n := node(fmt.Sprintf("master-%d", i), "master", corev1.ConditionTrue)
+					n.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}}
+					nodes = append(nodes, n, node(fmt.Sprintf("worker-%d", i), "worker", corev1.ConditionTrue))
+				}
+				return nodes
+			}(),
+			expectPassed: true,
+		},
+		{
+			name:          "single-node cluster ready",
+			checks:        []hivev1.PostInstallCheck{{Type: hivev1.NodesReadyPostInstallCheck}},
+			installConfig: singleNodeInstallConfig,
+			remote: []runtime.Object{
+				func() *corev1.Node {
+					n := node("master-0", "master", corev1.ConditionTrue)
+					n.Labels["node-role.kubernetes.io/worker"] = ""
+					return n
+				}(),
+			},
+			expectPassed: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeploymentWithProvision()
+			cd.Spec.PostInstallChecks = test.checks
+			existing := []runtime.Object{cd}
+			if test.installConfig != "" {
+				existing = append(existing, testSecret(corev1.SecretTypeOpaque, installConfigSecret, installConfigSecretKey, test.installConfig))
+			}
+			fakeClient := fake.NewFakeClient(existing...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().UsePrimaryAPIURL().Return(mockRemoteClientBuilder).AnyTimes()
+			mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClient(test.remote...), nil).AnyTimes()
+			rcd := &ReconcileClusterDeployment{
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				logger:                        log.WithField("controller", "clusterDeployment"),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			result, err := rcd.runPostInstallChecks(cd, rcd.logger)
+			require.NoError(t, err, "unexpected error")
+			if test.expectPassed {
+				assert.Nil(t, result, "expected the checks to pass")
+				return
+			}
+			if assert.NotNil(t, result, "expected the checks to fail") {
+				assert.Equal(t, postInstallCheckRequeueAfter, result.RequeueAfter, "unexpected requeue after")
+			}
+			cd = getCDFromClient(fakeClient)
+			assertConditionStatus(t, cd, hivev1.PostInstallChecksFailedCondition, corev1.ConditionTrue)
+			assertConditionReason(t, cd, hivev1.PostInstallChecksFailedCondition, test.expectedReason)
+		})
+	}
+}
+
+func TestCheckProtectedWorkloads(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	protectedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "payments",
+			Labels: map[string]string{"example.com/protected": "true"},
+		},
+	}
+	otherNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "scratch"}}
+	installedCD := func() *hivev1.ClusterDeployment {
+		cd := testDeletedClusterDeployment()
+		cd.Spec.Installed = true
+		return cd
+	}
+
+	tests := []struct {
+		name              string
+		cd                *hivev1.ClusterDeployment
+		existing          []runtime.Object
+		remote            []runtime.Object
+		remoteErr         error
+		disabled          bool
+		expectBlocked     bool
+		expectedReason    string
+		expectRemoteBuild bool
+	}{
+		{
+			name:     "check disabled",
+			cd:       installedCD(),
+			remote:   []runtime.Object{protectedNamespace},
+			disabled: true,
+		},
+		{
+			name:              "no protected workloads",
+			cd:                installedCD(),
+			remote:            []runtime.Object{otherNamespace},
+			expectRemoteBuild: true,
+		},
+		{
+			name:              "protected workloads",
+			cd:                installedCD(),
+			remote:            []runtime.Object{protectedNamespace, otherNamespace},
+			expectRemoteBuild: true,
+			expectBlocked:     true,
+			expectedReason:    protectedWorkloadsFoundReason,
+		},
+		{
+			name:              "cluster unreachable",
+			cd:                installedCD(),
+			remoteErr:         errors.New("unreachable"),
+			expectRemoteBuild: true,
+			expectBlocked:     true,
+			expectedReason:    protectedWorkloadsCheckFailedReason,
+		},
+		{
+			name: "force deprovision",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := installedCD()
+				cd.Annotations = map[string]string{constants.ForceDeprovisionAnnotation: "true"}
+				return cd
+			}(),
+			remote: []runtime.Object{protectedNamespace},
+		},
+		{
+			name: "unclaimed pool cluster",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := installedCD()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{Namespace: testNamespace, PoolName: "pool"}
+				return cd
+			}(),
+			remote: []runtime.Object{protectedNamespace},
+		},
+		{
+			name: "cluster not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Spec.Installed = false
+				return cd
+			}(),
+			remote: []runtime.Object{protectedNamespace},
+		},
+		{
+			name:     "deprovision already started",
+			cd:       installedCD(),
+			existing: []runtime.Object{&hivev1.ClusterDeprovision{ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace}}},
+			remote:   []runtime.Object{protectedNamespace},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClient(append(test.existing, test.cd)...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectRemoteBuild {
+				if test.remoteErr != nil {
+					mockRemoteClientBuilder.EXPECT().Build().Return(nil, test.remoteErr)
+				} else {
+					mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClient(test.remote...), nil)
+				}
+			}
+			rcd := &ReconcileClusterDeployment{
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				logger:                        log.WithField("controller", "clusterDeployment"),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+			if !test.disabled {
+				rcd.protectedWorkloadsSelector = k8slabels.SelectorFromSet(map[string]string{"example.com/protected": "true"})
+			}
+
+			result, err := rcd.checkProtectedWorkloads(test.cd, rcd.logger)
+			require.NoError(t, err, "unexpected error")
+			if !test.expectBlocked {
+				assert.Nil(t, result, "expected deprovision to proceed")
+				return
+			}
+			if assert.NotNil(t, result, "expected deprovision to be blocked") {
+				assert.Equal(t, protectedWorkloadsRequeueAfter, result.RequeueAfter, "unexpected requeue after")
+			}
+			cd := getCDFromClient(fakeClient)
+			assertConditionStatus(t, cd, hivev1.DeprovisionBlockedCondition, corev1.ConditionTrue)
+			assertConditionReason(t, cd, hivev1.DeprovisionBlockedCondition, test.expectedReason)
+		})
+	}
+}
+
+func TestAddDNSZonePropagatedTags(t *testing.T) {
+	os.Setenv(constants.PropagatedLabelsEnvVar, `["team","cost-center"]`)
+	defer os.Unsetenv(constants.PropagatedLabelsEnvVar)
+	cd := testClusterDeployment()
+	cd.Labels = map[string]string{"team": "hive", "cost-center": "1234", "other": "value"}
+	cd.Spec.Platform.AWS.UserTags = map[string]string{"team": "other"}
+	dnsZone := &hivev1.DNSZone{
+		Spec: hivev1.DNSZoneSpec{
+			AWS: &hivev1.AWSDNSZoneSpec{
+				AdditionalTags: []hivev1.AWSResourceTag{{Key: "team", Value: "other"}},
+			},
+		},
+	}
+	addDNSZonePropagatedTags(cd, dnsZone, log.WithField("test", t.Name()))
+	assert.Equal(t, []hivev1.AWSResourceTag{
+		{Key: "team", Value: "other"},
+		{Key: "cost-center", Value: "1234"},
+	}, dnsZone.Spec.AWS.AdditionalTags, "unexpected additional tags")
+}
+
+func TestSyncInstallAttempt(t *testing.T) {
+	failedTime := metav1.NewTime(time.Date(2020, 10, 16, 10, 0, 0, 0, time.UTC))
+	failedProvision := testFailedProvisionAttempt(2)
+	failedProvision.Status.Conditions = []hivev1.ClusterProvisionCondition{{
+		Type:               hivev1.ClusterProvisionFailedCondition,
+		Status:             corev1.ConditionTrue,
+		Reason:             "AWSInsufficientCapacity",
+		Message:            "insufficient capacity",
+		LastTransitionTime: failedTime,
+	}}
+	existingAttempts := func(attempts ...int) []hivev1.InstallAttempt {
+		var history []hivev1.InstallAttempt
+		for _, a := range attempts {
+			history = append(history, hivev1.InstallAttempt{
+				Attempt:        a,
+				ProvisionName:  testFailedProvisionAttempt(a).Name,
+				CompletionTime: &failedTime,
+				Result:         hivev1.InstallAttemptFailed,
+			})
+		}
+		return history
+	}
+	cases := []struct {
+		name             string
+		provision        *hivev1.ClusterProvision
+		existing         []hivev1.InstallAttempt
+		jobHistory       string
+		expectedChanged  bool
+		expectedAttempts []int
+	}{
+		{
+			name:      "running provision",
+			provision: testProvision(),
+		},
+		{
+			name:             "failed provision",
+			provision:        failedProvision,
+			existing:         existingAttempts(0, 1),
+			expectedChanged:  true,
+			expectedAttempts: []int{0, 1, 2},
+		},
+		{
+			name:             "oldest attempts dropped",
+			provision:        failedProvision,
+			existing:         existingAttempts(0, 1),
+			jobHistory:       `{"maxInstallAttempts": 2}`,
+			expectedChanged:  true,
+			expectedAttempts: []int{1, 2},
+		},
+		{
+			name:             "successful provision",
+			provision:        testSuccessfulProvision(),
+			expectedChanged:  true,
+			expectedAttempts: []int{0},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.jobHistory != "" {
+				os.Setenv(constants.JobHistoryEnvVar, tc.jobHistory)
+				defer os.Unsetenv(constants.JobHistoryEnvVar)
+			}
+			os.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAWS)
+			defer os.Unsetenv(constants.InstallLogsUploadProviderEnvVar)
+			os.Setenv(constants.InstallLogsAWSS3BucketEnvVar, "test-bucket")
+			defer os.Unsetenv(constants.InstallLogsAWSS3BucketEnvVar)
+			cd := testClusterDeployment()
+			cd.Status.InstallAttempts = tc.existing
+			logger := log.WithField("test", t.Name())
+
+			changed := syncInstallAttempt(cd, tc.provision, logger)
+			assert.Equal(t, tc.expectedChanged, changed, "unexpected change")
+			var attempts []int
+			for _, a := range cd.Status.InstallAttempts {
+				attempts = append(attempts, a.Attempt)
+			}
+			assert.Equal(t, tc.expectedAttempts, attempts, "unexpected install attempts")
+			if !changed {
+				return
+			}
+			last := cd.Status.InstallAttempts[len(cd.Status.InstallAttempts)-1]
+			assert.Equal(t, tc.provision.Name, last.ProvisionName, "unexpected provision name")
+			if assert.NotNil(t, last.CompletionTime, "expected completion time") && tc.provision.Spec.Stage == hivev1.ClusterProvisionStageFailed {
+				assert.Equal(t, hivev1.InstallAttemptFailed, last.Result, "unexpected result")
+				assert.True(t, failedTime.Equal(last.CompletionTime), "unexpected completion time")
+				assert.Equal(t, "AWSInsufficientCapacity", last.FailureReason, "unexpected failure reason")
+				assert.Equal(t, "s3://test-bucket/bar-"+testNamespace+"/"+tc.provision.Name+"-", last.LogLocation, "unexpected log location")
+			} else {
+				assert.Equal(t, hivev1.InstallAttemptSucceeded, last.Result, "unexpected result")
+				assert.Empty(t, last.LogLocation, "unexpected log location")
+			}
+
+			assert.False(t, syncInstallAttempt(cd, tc.provision, logger), "expected attempt to be recorded once")
+		})
+	}
+}
+
+// testKMSProvider "encrypts" data keys by prefixing them.
+type testKMSProvider struct{}
+
+func (testKMSProvider) Name() string { return "test-kms" }
+func (testKMSProvider) EncryptKey(key []byte) ([]byte, error) {
+	return append([]byte("test-"), key...), nil
+}
+func (testKMSProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	return encryptedKey[len("test-"):], nil
+}
+
+func TestSecretEncryption(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	provider := func(client.Client) (secretencryption.Provider, error) { return testKMSProvider{}, nil }
+	encrypted := func(secret *corev1.Secret) *corev1.Secret {
+		require.NoError(t, secretencryption.Encrypt(testKMSProvider{}, secret), "unexpected error encrypting secret")
+		return secret
+	}
+
+	cases := []struct {
+		name      string
+		claimed   bool
+		existing  []runtime.Object
+		encrypted bool
+	}{
+		{
+			name: "encrypt admin secrets",
+			existing: []runtime.Object{
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", "test-password"),
+			},
+			encrypted: true,
+		},
+		{
+			name:    "decrypt admin secrets of claimed cluster",
+			claimed: true,
+			existing: []runtime.Object{
+				encrypted(testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig)),
+				encrypted(testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", "test-password")),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testInstalledClusterDeployment(time.Now())
+			if tc.claimed {
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{Namespace: testNamespace, PoolName: "test-pool", ClaimName: "test-claim"}
+			}
+			fakeClient := fake.NewFakeClient(tc.existing...)
+			r := &ReconcileClusterDeployment{Client: fakeClient, scheme: scheme.Scheme, secretEncryptionProvider: provider}
+			require.NoError(t, r.reconcileSecretEncryption(cd, log.WithField("test", tc.name)), "unexpected error")
+
+			for name, data := range map[string]string{adminKubeconfigSecret: adminKubeconfig, adminPasswordSecret: "test-password"} {
+				secret := getSecret(fakeClient, name)
+				require.NotNil(t, secret, "missing secret %s", name)
+				assert.Equal(t, tc.encrypted, secretencryption.IsEncrypted(secret), "unexpected encryption of secret %s", name)
+				decrypted, err := r.decryptedSecretData(secret)
+				require.NoError(t, err, "unexpected error decrypting secret %s", name)
+				for _, value := range decrypted {
+					assert.Equal(t, data, string(value), "unexpected data of secret %s", name)
+				}
+			}
+		})
+	}
+
+	t.Run("data key of terraform state encrypted by install job encrypted again", func(t *testing.T) {
+		os.Setenv(constants.TerraformStatePolicyEnvVar, string(hivev1.TerraformStatePolicyStoreEncrypted))
+		defer os.Unsetenv(constants.TerraformStatePolicyEnvVar)
+		os.Setenv(constants.HiveNamespaceEnvVar, testNamespace)
+		defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+		fakeClient := fake.NewFakeClient(
+			encrypted(testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig)),
+			encrypted(testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", "test-password")),
+		)
+		r := &ReconcileClusterDeployment{Client: fakeClient, scheme: scheme.Scheme, secretEncryptionProvider: provider}
+		publicKeyEnvVar, err := r.terraformStatePublicKeyEnvVar(log.WithField("test", "terraform state"))
+		require.NoError(t, err, "unexpected error getting terraform state key")
+		require.NotNil(t, publicKeyEnvVar, "expected terraform state key")
+		keySecret := getSecret(fakeClient, constants.TerraformStateKeySecretName)
+		if assert.NotNil(t, keySecret, "missing terraform state key secret") {
+			assert.True(t, secretencryption.IsEncrypted(keySecret), "expected terraform state key to be encrypted")
+		}
+
+		// The install job encrypts the terraform state with the public key.
+		jobProvider, err := secretencryption.NewRSAPublicKeyProvider([]byte(publicKeyEnvVar.Value))
+		require.NoError(t, err, "unexpected error parsing public key")
+		stateSecret := testSecret(corev1.SecretTypeOpaque, "test-terraform-state", "terraform.tfstate.gz", "state")
+		require.NoError(t, secretencryption.Encrypt(jobProvider, stateSecret), "unexpected error encrypting terraform state")
+		require.NoError(t, fakeClient.Create(context.TODO(), stateSecret), "unexpected error creating terraform state secret")
+
+		cd := testInstalledClusterDeployment(time.Now())
+		cd.Spec.ClusterMetadata.TerraformStateSecretRef = &corev1.LocalObjectReference{Name: stateSecret.Name}
+		require.NoError(t, r.reconcileSecretEncryption(cd, log.WithField("test", "terraform state")), "unexpected error")
+		stored := getSecret(fakeClient, stateSecret.Name)
+		require.NotNil(t, stored, "missing terraform state secret")
+		assert.Equal(t, "test-kms", stored.Annotations[constants.EncryptionProviderAnnotation], "expected data key encrypted with the key management service")
+		data, err := secretencryption.Decrypt(testKMSProvider{}, stored)
+		require.NoError(t, err, "unexpected error decrypting terraform state")
+		assert.Equal(t, "state", string(data["terraform.tfstate.gz"]), "unexpected terraform state")
+	})
+
+	t.Run("job mounts decrypted copy of admin kubeconfig", func(t *testing.T) {
+		kubeconfigSecret := encrypted(testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig))
+		fakeClient := fake.NewFakeClient(kubeconfigSecret)
+		r := &ReconcileClusterDeployment{Client: fakeClient, scheme: scheme.Scheme, secretEncryptionProvider: provider}
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-job"}}
+		mountAdminKubeconfig(&job.Spec.Template.Spec, jobAdminKubeconfigSecretName(job.Name, kubeconfigSecret))
+		require.NoError(t, r.createJob(job, kubeconfigSecret, log.WithField("test", "job")), "unexpected error creating job")
+
+		mounted := job.Spec.Template.Spec.Volumes[0].Secret.SecretName
+		assert.NotEqual(t, adminKubeconfigSecret, mounted, "expected job to mount a copy of the admin kubeconfig secret")
+		jobSecret := getSecret(fakeClient, mounted)
+		if assert.NotNil(t, jobSecret, "missing decrypted copy of admin kubeconfig secret") {
+			assert.False(t, secretencryption.IsEncrypted(jobSecret), "expected copy to be decrypted")
+			assert.Equal(t, adminKubeconfig, string(jobSecret.Data["kubeconfig"]), "unexpected kubeconfig in copy")
+			assert.True(t, metav1.IsControlledBy(jobSecret, getJob(fakeClient, job.Name)), "expected copy to be owned by the job")
+		}
+	})
+}
+
+func TestGetDeprovisionStuckThreshold(t *testing.T) {
+	cases := []struct {
+		name              string
+		threshold         string
+		expectedThreshold time.Duration
+	}{
+		{
+			name:              "not configured",
+			expectedThreshold: defaultDeprovisionStuckThreshold,
+		},
+		{
+			name:              "configured",
+			threshold:         "3h",
+			expectedThreshold: 3 * time.Hour,
+		},
+		{
+			name:              "invalid",
+			threshold:         "three hours",
+			expectedThreshold: defaultDeprovisionStuckThreshold,
+		},
+		{
+			name:              "negative",
+			threshold:         "-1h",
+			expectedThreshold: defaultDeprovisionStuckThreshold,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.threshold != "" {
+				os.Setenv(constants.DeprovisionStuckThresholdEnvVar, tc.threshold)
+				defer os.Unsetenv(constants.DeprovisionStuckThresholdEnvVar)
+			}
+			assert.Equal(t, tc.expectedThreshold, getDeprovisionStuckThreshold(log.WithField("test", tc.name)), "unexpected threshold")
+		})
+	}
+}
+
+func TestCheckDeprovisionStuckConfiguredThreshold(t *testing.T) {
+	cd := testClusterDeployment()
+	cd.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	r := &ReconcileClusterDeployment{
+		Client:                    fake.NewFakeClient(cd),
+		scheme:                    scheme.Scheme,
+		deprovisionStuckThreshold: 3 * time.Hour,
+	}
+	remaining, err := r.checkDeprovisionStuck(cd, log.WithField("test", "configured threshold"))
+	require.NoError(t, err, "unexpected error")
+	assert.InDelta(t, float64(time.Hour), float64(remaining), float64(time.Minute), "expected the configured threshold to be used")
+	assert.Nil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionStuckCondition), "expected no DeprovisionStuck condition")
+}
@@ -0,0 +1,99 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	deprovisionCredentialsValidReason    = "DeprovisionCredentialsValid"
+	deprovisionCredentialsNotFoundReason = "DeprovisionCredentialsNotFound"
+	deprovisionCredentialsAuthFailReason = "DeprovisionCredentialsAuthenticationFailed"
+)
+
+// validateDeprovisionCreds validates the deprovision credentials of the cluster deployment, if any, before the cluster
+// is provisioned. It sets the DeprovisionCredentialsInvalid condition, and returns an error while the credentials are
+// invalid so that the cluster deployment is requeued with a backoff.
+func (r *ReconcileClusterDeployment) validateDeprovisionCreds(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	ref := cd.Spec.DeprovisionCredentialsSecretRef
+	if ref == nil {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: ref.Name}, secret); {
+	case apierrors.IsNotFound(err):
+		invalidErr := fmt.Errorf("deprovision credentials secret %s not found", ref.Name)
+		return r.setDeprovisionCredentialsInvalid(cd, deprovisionCredentialsNotFoundReason, invalidErr, cdLog)
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get deprovision credentials secret")
+		return err
+	}
+	valid, err := r.validateCredentialsForClusterDeployment(r.Client, withDeprovisionCreds(cd), cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("unable to validate deprovision credentials")
+		return err
+	}
+	if !valid {
+		invalidErr := fmt.Errorf("deprovision credentials secret %s failed authentication check", ref.Name)
+		return r.setDeprovisionCredentialsInvalid(cd, deprovisionCredentialsAuthFailReason, invalidErr, cdLog)
+	}
+	return r.setDeprovisionCredentialsInvalidCondition(cd, corev1.ConditionFalse, deprovisionCredentialsValidReason, "Deprovision credentials passed authentication check", cdLog)
+}
+
+func (r *ReconcileClusterDeployment) setDeprovisionCredentialsInvalid(cd *hivev1.ClusterDeployment, reason string, invalidErr error, cdLog log.FieldLogger) error {
+	cdLog.WithError(invalidErr).Warn("deprovision credentials are invalid, not starting a provision")
+	if err := r.setDeprovisionCredentialsInvalidCondition(cd, corev1.ConditionTrue, reason, invalidErr.Error(), cdLog); err != nil {
+		return err
+	}
+	return invalidErr
+}
+
+func (r *ReconcileClusterDeployment) setDeprovisionCredentialsInvalidCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.DeprovisionCredentialsInvalidCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
+
+// withDeprovisionCreds returns a copy of the cluster deployment whose platform uses the deprovision credentials, or
+// the cluster deployment itself when it has none.
+func withDeprovisionCreds(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	ref := cd.Spec.DeprovisionCredentialsSecretRef
+	if ref == nil {
+		return cd
+	}
+	cd = cd.DeepCopy()
+	switch p := &cd.Spec.Platform; {
+	case p.AWS != nil:
+		p.AWS.CredentialsSecretRef = *ref
+	case p.Azure != nil:
+		p.Azure.CredentialsSecretRef = *ref
+	case p.GCP != nil:
+		p.GCP.CredentialsSecretRef = *ref
+	case p.OpenStack != nil:
+		p.OpenStack.CredentialsSecretRef = *ref
+	case p.VSphere != nil:
+		p.VSphere.CredentialsSecretRef = *ref
+	case p.Ovirt != nil:
+		p.Ovirt.CredentialsSecretRef = *ref
+	}
+	return cd
+}
@@ -0,0 +1,90 @@
+package clusterdeployment
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	deprovisionTakingTooLongReason = "DeprovisionTakingTooLong"
+	deprovisionCompletedReason     = "DeprovisionCompleted"
+
+	// defaultDeprovisionStuckThreshold is how long a deleted cluster deployment can wait for its cluster to be
+	// deprovisioned before the DeprovisionStuck condition is set, unless configured in HiveConfig.
+	defaultDeprovisionStuckThreshold = time.Hour
+)
+
+// getDeprovisionStuckThreshold returns the threshold configured in the environment of the controller manager, or the
+// default threshold when none is configured or the configured one is invalid.
+func getDeprovisionStuckThreshold(logger log.FieldLogger) time.Duration {
+	value, ok := os.LookupEnv(constants.DeprovisionStuckThresholdEnvVar)
+	if !ok || value == "" {
+		return defaultDeprovisionStuckThreshold
+	}
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold <= 0 {
+		logger.WithField("deprovisionStuckThreshold", value).Errorf("invalid %s, using the default threshold", constants.DeprovisionStuckThresholdEnvVar)
+		return defaultDeprovisionStuckThreshold
+	}
+	logger.WithField("deprovisionStuckThreshold", threshold).Info("Deprovision stuck threshold set")
+	return threshold
+}
+
+// checkDeprovisionStuck sets the DeprovisionStuck condition when the cluster deployment has been deleted for longer
+// than the deprovision stuck threshold. It returns how long is left before the threshold is reached, or zero when the
+// threshold has been reached or the cluster has already been deprovisioned.
+func (r *ReconcileClusterDeployment) checkDeprovisionStuck(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (time.Duration, error) {
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionStuckCondition); cond != nil && cond.Reason == deprovisionCompletedReason {
+		return 0, nil
+	}
+	threshold := r.deprovisionStuckThreshold
+	if threshold == 0 {
+		threshold = defaultDeprovisionStuckThreshold
+	}
+	if remaining := threshold - time.Since(cd.DeletionTimestamp.Time); remaining > 0 {
+		return remaining, nil
+	}
+	return 0, r.setDeprovisionStuckCondition(cd,
+		corev1.ConditionTrue,
+		deprovisionTakingTooLongReason,
+		fmt.Sprintf("The ClusterDeployment was deleted more than %s ago and its cluster has not been deprovisioned yet", threshold),
+		cdLog)
+}
+
+// clearDeprovisionStuck clears the DeprovisionStuck condition once the cluster has been deprovisioned.
+func (r *ReconcileClusterDeployment) clearDeprovisionStuck(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DeprovisionStuckCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+	return r.setDeprovisionStuckCondition(cd,
+		corev1.ConditionFalse,
+		deprovisionCompletedReason,
+		"The cluster has been deprovisioned",
+		cdLog)
+}
+
+func (r *ReconcileClusterDeployment) setDeprovisionStuckCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.DeprovisionStuckCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	cdLog.WithField("status", status).Info("setting DeprovisionStuck condition")
+	return r.statusUpdate(cd, cdLog)
+}
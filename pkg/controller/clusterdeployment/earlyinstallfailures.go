@@ -0,0 +1,72 @@
+package clusterdeployment
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// earlyInstallFailureRetryDelay is how long after an install which failed before creating any infrastructure a
+	// new install is started, instead of the backoff of failed installs.
+	earlyInstallFailureRetryDelay = time.Minute
+
+	// maxEarlyInstallFailureRetries is the number of consecutive installs failing before creating any infrastructure
+	// which are retried after earlyInstallFailureRetryDelay. Past it, the failure is not transient, and the installs
+	// are retried with the backoff of failed installs.
+	maxEarlyInstallFailureRetries = 2
+)
+
+// failedBeforeInfrastructure returns true if the installer of the failed provision failed before it started creating
+// the cloud infrastructure of the cluster, as classified by the install pod.
+func failedBeforeInfrastructure(provision *hivev1.ClusterProvision) bool {
+	return provision.Spec.Stage == hivev1.ClusterProvisionStageFailed &&
+		provision.Spec.InfrastructureCreated != nil && !*provision.Spec.InfrastructureCreated
+}
+
+// consecutiveEarlyFailures returns the number of consecutive attempts, up to and including the failed provision, which
+// failed before creating any infrastructure. The count stops at the first attempt whose provision is missing.
+func consecutiveEarlyFailures(provision *hivev1.ClusterProvision, existingProvisions []*hivev1.ClusterProvision) int {
+	byAttempt := make(map[int]*hivev1.ClusterProvision, len(existingProvisions))
+	for _, p := range existingProvisions {
+		byAttempt[p.Spec.Attempt] = p
+	}
+	byAttempt[provision.Spec.Attempt] = provision
+	count := 0
+	for attempt := provision.Spec.Attempt; attempt >= 0; attempt-- {
+		p, ok := byAttempt[attempt]
+		if !ok || !failedBeforeInfrastructure(p) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// skipUnneededCleanup clears the infra ID of the previous provision from the provision when the previous provision
+// failed before creating any infrastructure, or destroyed the infrastructure it created, so that the new install does
+// not try to clean up after it. The previous provision set its infra ID after cleaning up after the provision before
+// it, which is then not needed either.
+func skipUnneededCleanup(provision *hivev1.ClusterProvision, existingProvisions []*hivev1.ClusterProvision, cdLog log.FieldLogger) {
+	if provision.Spec.PrevInfraID == nil {
+		return
+	}
+	for _, prev := range existingProvisions {
+		if prev.Spec.InfraID == nil || *prev.Spec.InfraID != *provision.Spec.PrevInfraID {
+			continue
+		}
+		switch {
+		case failedBeforeInfrastructure(prev):
+			cdLog.WithField("prevProvision", prev.Name).Info("previous provision failed before creating infrastructure, skipping cleanup")
+		case prev.Spec.InfrastructureDestroyed:
+			cdLog.WithField("prevProvision", prev.Name).Info("previous provision destroyed its infrastructure, skipping cleanup")
+		default:
+			return
+		}
+		provision.Spec.PrevClusterID = nil
+		provision.Spec.PrevInfraID = nil
+		return
+	}
+}
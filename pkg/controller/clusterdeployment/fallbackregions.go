@@ -0,0 +1,53 @@
+package clusterdeployment
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// failOverToRegion clears out a provision which failed for lack of capacity in its region so that the next provision
+// installs the cluster in the next fallback region. The next provision starts right away, without the backoff
+// between install attempts, as it does not hit the same capacity shortage.
+func (r *ReconcileClusterDeployment) failOverToRegion(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, reason, failedRegion, nextRegion string, cdLog log.FieldLogger) (reconcile.Result, error) {
+	cdLog.WithField("failedRegion", failedRegion).WithField("nextRegion", nextRegion).Info("failing over to the next region after provision failed for lack of capacity")
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.ProvisionFailedCondition,
+		corev1.ConditionTrue,
+		reason,
+		fmt.Sprintf("Provision %s failed for lack of capacity in region %s. Failing over to region %s.", provision.Name, failedRegion, nextRegion),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	cd.Status.InstallRegion = nextRegion
+	return r.clearOutCurrentProvision(cd, cdLog)
+}
+
+// setProvisionRegion sets the region where the provision installs the cluster when the platform has fallback regions.
+// When the previous failed provision was in another region, its region is set too so that the resources it left
+// behind are cleaned up in the right region.
+func setProvisionRegion(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, existingProvisions []*hivev1.ClusterProvision) {
+	if !controllerutils.HasFallbackRegions(cd) {
+		return
+	}
+	provision.Spec.Region = controllerutils.InstallRegion(cd)
+	if provision.Spec.PrevInfraID == nil {
+		return
+	}
+	for _, prev := range existingProvisions {
+		if prev.Spec.InfraID == nil || *prev.Spec.InfraID != *provision.Spec.PrevInfraID {
+			continue
+		}
+		if prev.Spec.Region != "" && prev.Spec.Region != provision.Spec.Region {
+			prevRegion := prev.Spec.Region
+			provision.Spec.PrevRegion = &prevRegion
+		}
+		return
+	}
+}
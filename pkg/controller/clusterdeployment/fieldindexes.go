@@ -0,0 +1,125 @@
+package clusterdeployment
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
+)
+
+const (
+	// pullSecretIndex indexes the ClusterDeployments by the name of their pull secret.
+	pullSecretIndex = "spec.pullSecretRef.name"
+	// credentialsSecretIndex indexes the ClusterDeployments by the name of the secret holding their platform
+	// credentials.
+	credentialsSecretIndex = "spec.platform.credentialsSecretRef.name"
+	// imageSetIndex indexes the ClusterDeployments by the name of their ClusterImageSet.
+	imageSetIndex = "spec.provisioning.imageSetRef.name"
+)
+
+// addFieldIndexes adds the field indexes used to find the ClusterDeployments referencing secrets and ClusterImageSets
+// without listing all of the ClusterDeployments.
+func addFieldIndexes(indexer client.FieldIndexer) error {
+	for field, extract := range map[string]client.IndexerFunc{
+		pullSecretIndex:        indexPullSecret,
+		credentialsSecretIndex: indexCredentialsSecret,
+		imageSetIndex:          indexImageSet,
+	} {
+		if err := indexer.IndexField(context.Background(), &hivev1.ClusterDeployment{}, field, extract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexPullSecret(o runtime.Object) []string {
+	cd, ok := o.(*hivev1.ClusterDeployment)
+	if !ok || cd.Spec.PullSecretRef == nil || cd.Spec.PullSecretRef.Name == "" {
+		return nil
+	}
+	return []string{cd.Spec.PullSecretRef.Name}
+}
+
+func indexCredentialsSecret(o runtime.Object) []string {
+	cd, ok := o.(*hivev1.ClusterDeployment)
+	if !ok {
+		return nil
+	}
+	name := platform.CredentialsSecretName(cd)
+	if name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+func indexImageSet(o runtime.Object) []string {
+	cd, ok := o.(*hivev1.ClusterDeployment)
+	if !ok || cd.Spec.Provisioning == nil || cd.Spec.Provisioning.ImageSetRef == nil || cd.Spec.Provisioning.ImageSetRef.Name == "" {
+		return nil
+	}
+	return []string{cd.Spec.Provisioning.ImageSetRef.Name}
+}
+
+// requestsForSecret returns the requests for the ClusterDeployments using the secret as their pull secret or for their
+// platform credentials.
+func requestsForSecret(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		secret, ok := o.Object.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+		var requests []reconcile.Request
+		seen := map[types.NamespacedName]bool{}
+		for _, index := range []string{pullSecretIndex, credentialsSecretIndex} {
+			cds := &hivev1.ClusterDeploymentList{}
+			if err := c.List(
+				context.Background(),
+				cds,
+				client.InNamespace(secret.Namespace),
+				client.MatchingFields{index: secret.Name},
+			); err != nil {
+				logger.WithError(err).WithField("secret", secret.Name).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments referencing secret")
+				continue
+			}
+			for _, cd := range cds.Items {
+				key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+				if !seen[key] {
+					seen[key] = true
+					requests = append(requests, reconcile.Request{NamespacedName: key})
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// requestsForClusterImageSet returns the requests for the ClusterDeployments using the ClusterImageSet.
+func requestsForClusterImageSet(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		imageSet, ok := o.Object.(*hivev1.ClusterImageSet)
+		if !ok {
+			return nil
+		}
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cds, client.MatchingFields{imageSetIndex: imageSet.Name}); err != nil {
+			logger.WithError(err).WithField("clusterImageSet", imageSet.Name).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments using ClusterImageSet")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(cds.Items))
+		for i, cd := range cds.Items {
+			requests[i].Namespace = cd.Namespace
+			requests[i].Name = cd.Name
+		}
+		return requests
+	}
+}
@@ -0,0 +1,64 @@
+package clusterdeployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+)
+
+func TestFieldIndexes(t *testing.T) {
+	cases := []struct {
+		name                      string
+		cd                        *hivev1.ClusterDeployment
+		expectedPullSecret        []string
+		expectedCredentialsSecret []string
+		expectedImageSet          []string
+	}{
+		{
+			name: "no references",
+			cd:   &hivev1.ClusterDeployment{},
+		},
+		{
+			name: "aws",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := &hivev1.ClusterDeployment{}
+				cd.Spec.PullSecretRef = &corev1.LocalObjectReference{Name: "pull-secret"}
+				cd.Spec.Platform.AWS = &hivev1aws.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+				}
+				cd.Spec.Provisioning = &hivev1.Provisioning{
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-v4.6.1"},
+				}
+				return cd
+			}(),
+			expectedPullSecret:        []string{"pull-secret"},
+			expectedCredentialsSecret: []string{"aws-creds"},
+			expectedImageSet:          []string{"openshift-v4.6.1"},
+		},
+		{
+			name: "vsphere with release image",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := &hivev1.ClusterDeployment{}
+				cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "vsphere-creds"},
+				}
+				cd.Spec.Provisioning = &hivev1.Provisioning{ReleaseImage: "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64"}
+				return cd
+			}(),
+			expectedCredentialsSecret: []string{"vsphere-creds"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPullSecret, indexPullSecret(tc.cd), "unexpected pull secret index")
+			assert.Equal(t, tc.expectedCredentialsSecret, indexCredentialsSecret(tc.cd), "unexpected credentials secret index")
+			assert.Equal(t, tc.expectedImageSet, indexImageSet(tc.cd), "unexpected imageset index")
+		})
+	}
+}
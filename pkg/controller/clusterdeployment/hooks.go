@@ -0,0 +1,290 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	hooksSucceededReason = "HooksSucceeded"
+	hookFailedReason     = "HookFailed"
+
+	// jobKubeconfigDir is where the admin kubeconfig of the cluster is mounted in the jobs of post-install checks
+	// and hooks.
+	jobKubeconfigDir = "/etc/kubeconfig"
+)
+
+func hookJobName(cd *hivev1.ClusterDeployment, hook *hivev1.ClusterHook) string {
+	return apihelpers.GetResourceName(cd.Name, "hook-"+hook.Name)
+}
+
+// runHooks runs the hooks of the cluster deployment for the given stage. The job of each hook which has not run yet
+// is created. It returns true when the jobs of all the blocking hooks of the stage have completed successfully.
+func (r *ReconcileClusterDeployment) runHooks(cd *hivev1.ClusterDeployment, stage hivev1.ClusterHookStage, cdLog log.FieldLogger) (bool, error) {
+	done := true
+	for i := range cd.Spec.Hooks {
+		hook := &cd.Spec.Hooks[i]
+		if hook.Stage != stage {
+			continue
+		}
+		hookLog := cdLog.WithField("hook", hook.Name).WithField("stage", stage)
+		job := &batchv1.Job{}
+		switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: hookJobName(cd, hook)}, job); {
+		case apierrors.IsNotFound(err):
+			if err := r.createHookJob(cd, hook, hookLog); err != nil {
+				return false, err
+			}
+			if hook.Blocking {
+				done = false
+			}
+			continue
+		case err != nil:
+			hookLog.WithError(err).Error("cannot get hook job")
+			return false, err
+		}
+		if hook.Blocking && !controllerutils.IsSuccessful(job) {
+			hookLog.Debug("waiting for blocking hook job to complete successfully")
+			done = false
+		}
+	}
+	if err := r.setHookFailedCondition(cd, cdLog); err != nil {
+		return false, err
+	}
+	return done, nil
+}
+
+// runPreDeprovisionHooks runs the pre-deprovision hooks of the cluster deployment. The hooks only run for installed
+// clusters which are going to be deprovisioned. They are skipped when the namespace is being deleted, as their jobs
+// cannot be created anymore.
+func (r *ReconcileClusterDeployment) runPreDeprovisionHooks(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	if !cd.Spec.Installed || cd.Spec.PreserveOnDelete || !hasHooks(cd, hivev1.PreDeprovisionClusterHookStage) {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Name: cd.Namespace}, ns); err != nil {
+		cdLog.WithError(err).Error("error checking for deletionTimestamp on namespace")
+		return false, err
+	}
+	if ns.DeletionTimestamp != nil {
+		cdLog.Warn("namespace is being deleted, skipping pre-deprovision hooks")
+		return true, nil
+	}
+	return r.runHooks(cd, hivev1.PreDeprovisionClusterHookStage, cdLog)
+}
+
+func hasHooks(cd *hivev1.ClusterDeployment, stage hivev1.ClusterHookStage) bool {
+	for _, hook := range cd.Spec.Hooks {
+		if hook.Stage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// setHookFailedCondition reports the hooks of the cluster deployment whose jobs have failed.
+func (r *ReconcileClusterDeployment) setHookFailedCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if len(cd.Spec.Hooks) == 0 && controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.HookFailedCondition) == nil {
+		return nil
+	}
+	jobs := &batchv1.JobList{}
+	if err := r.List(
+		context.TODO(),
+		jobs,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{
+			constants.ClusterDeploymentNameLabel: cd.Name,
+			constants.JobTypeLabel:               constants.JobTypeHook,
+		},
+	); err != nil {
+		cdLog.WithError(err).Error("cannot list hook jobs")
+		return err
+	}
+	var failed []string
+	for i := range jobs.Items {
+		if controllerutils.IsFailed(&jobs.Items[i]) {
+			failed = append(failed, jobs.Items[i].Labels[constants.HookNameLabel])
+		}
+	}
+	status, reason, message := corev1.ConditionFalse, hooksSucceededReason, "No hook has failed"
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		status, reason = corev1.ConditionTrue, hookFailedReason
+		message = fmt.Sprintf("Hooks failed: %s. Delete the job of a hook to run it again.", strings.Join(failed, ", "))
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.HookFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
+
+func (r *ReconcileClusterDeployment) createHookJob(cd *hivev1.ClusterDeployment, hook *hivev1.ClusterHook, hookLog log.FieldLogger) error {
+	podTemplate := &corev1.PodTemplate{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: hook.PodTemplateRef.Name}, podTemplate); err != nil {
+		hookLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot get pod template of hook")
+		return err
+	}
+	// The cluster only exists after the installer completes.
+	var kubeconfigSecret *corev1.Secret
+	if hook.Stage != hivev1.PreInstallClusterHookStage {
+		var err error
+		kubeconfigSecret, err = r.getJobAdminKubeconfigSecret(cd)
+		if err != nil {
+			hookLog.WithError(err).Error("cannot get admin kubeconfig to mount in hook job")
+			return err
+		}
+	}
+
+	template := *podTemplate.Template.DeepCopy()
+	// Jobs only support the Never and OnFailure restart policies.
+	if template.Spec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	if kubeconfigSecret != nil {
+		mountAdminKubeconfig(&template.Spec, jobAdminKubeconfigSecretName(hookJobName(cd, hook), kubeconfigSecret))
+	}
+	controllerutils.AddJobPodScheduling(&template.Spec, hookLog)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hookJobName(cd, hook),
+			Namespace: cd.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Template: template,
+		},
+	}
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypeHook)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.HookNameLabel, hook.Name)
+	controllerutils.AddJobPropagatedLabels(cd, job, hookLog)
+	if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
+		hookLog.WithError(err).Error("error setting controller reference on job")
+		return err
+	}
+
+	hookLog.WithField("job", job.Name).Info("creating hook job")
+	return r.createJob(job, kubeconfigSecret, hookLog.WithField("job", job.Name))
+}
+
+// getJobAdminKubeconfigSecret returns the admin kubeconfig secret of the cluster to mount in a job, or nil when the
+// cluster has not been installed.
+func (r *ReconcileClusterDeployment) getJobAdminKubeconfigSecret(cd *hivev1.ClusterDeployment) (*corev1.Secret, error) {
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(
+		context.TODO(),
+		client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name},
+		secret,
+	); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// jobAdminKubeconfigSecretName returns the name of the secret with the admin kubeconfig to mount in the job. An
+// encrypted secret cannot be mounted, so the job mounts a decrypted copy of it instead.
+func jobAdminKubeconfigSecretName(jobName string, kubeconfigSecret *corev1.Secret) string {
+	if secretencryption.IsEncrypted(kubeconfigSecret) {
+		return apihelpers.GetResourceName(jobName, "admin-kubeconfig")
+	}
+	return kubeconfigSecret.Name
+}
+
+// createJob creates the job and, when the admin kubeconfig secret mounted by the job is encrypted, the decrypted copy
+// of the secret that the job mounts instead. If the copy cannot be created, the job is deleted so that both are created
+// again by a later reconcile.
+func (r *ReconcileClusterDeployment) createJob(job *batchv1.Job, kubeconfigSecret *corev1.Secret, jobLog log.FieldLogger) error {
+	if err := r.Create(context.TODO(), job); err != nil {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating job")
+		return err
+	}
+	if kubeconfigSecret == nil || !secretencryption.IsEncrypted(kubeconfigSecret) {
+		return nil
+	}
+	if err := r.createJobAdminKubeconfigSecret(job, kubeconfigSecret); err != nil {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating decrypted admin kubeconfig secret for job, deleting job")
+		if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			jobLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting job")
+		}
+		return err
+	}
+	return nil
+}
+
+// createJobAdminKubeconfigSecret creates the decrypted copy of the encrypted admin kubeconfig secret for the job. The
+// copy is owned by the job so that it is deleted with the job.
+func (r *ReconcileClusterDeployment) createJobAdminKubeconfigSecret(job *batchv1.Job, kubeconfigSecret *corev1.Secret) error {
+	data, err := r.decryptedSecretData(kubeconfigSecret)
+	if err != nil {
+		return err
+	}
+	jobSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: job.Namespace,
+			Name:      jobAdminKubeconfigSecretName(job.Name, kubeconfigSecret),
+		},
+		Type: kubeconfigSecret.Type,
+		Data: map[string][]byte{constants.KubeconfigSecretKey: data[constants.KubeconfigSecretKey]},
+	}
+	if err := controllerutil.SetControllerReference(job, jobSecret, r.scheme); err != nil {
+		return err
+	}
+	if err := r.Create(context.TODO(), jobSecret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// mountAdminKubeconfig mounts the admin kubeconfig secret in all the containers of the pod, and points the KUBECONFIG
+// environment variable to it.
+func mountAdminKubeconfig(podSpec *corev1.PodSpec, secretName string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "admin-kubeconfig",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items:      []corev1.KeyToPath{{Key: constants.KubeconfigSecretKey, Path: constants.KubeconfigSecretKey}},
+			},
+		},
+	})
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "admin-kubeconfig",
+			MountPath: jobKubeconfigDir,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "KUBECONFIG",
+			Value: jobKubeconfigDir + "/" + constants.KubeconfigSecretKey,
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package clusterdeployment
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// syncInstallAttempt records the install attempt of the provision in the install attempt history of the status of the
+// cluster deployment once the provision failed or completed. The oldest attempts are dropped beyond the maximum number
+// of attempts of the job history. It returns true if the status of the cluster deployment changed.
+func syncInstallAttempt(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) bool {
+	if provision.Spec.Stage != hivev1.ClusterProvisionStageComplete && provision.Spec.Stage != hivev1.ClusterProvisionStageFailed {
+		return false
+	}
+	index := -1
+	for i, attempt := range cd.Status.InstallAttempts {
+		if attempt.ProvisionName == provision.Name {
+			index = i
+			break
+		}
+	}
+	var existing *hivev1.InstallAttempt
+	if index >= 0 {
+		existing = &cd.Status.InstallAttempts[index]
+	}
+	attempt := installAttemptForProvision(cd, provision, existing)
+	if existing != nil {
+		if equality.Semantic.DeepEqual(existing, attempt) {
+			return false
+		}
+		cd.Status.InstallAttempts[index] = *attempt
+	} else {
+		cd.Status.InstallAttempts = append(cd.Status.InstallAttempts, *attempt)
+	}
+	cdLog.WithField("attempt", attempt.Attempt).WithField("result", attempt.Result).Info("recording install attempt")
+	if max := controllerutils.GetJobHistory(cdLog).MaxInstallAttempts; len(cd.Status.InstallAttempts) > max {
+		cd.Status.InstallAttempts = cd.Status.InstallAttempts[len(cd.Status.InstallAttempts)-max:]
+	}
+	return true
+}
+
+func installAttemptForProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, existing *hivev1.InstallAttempt) *hivev1.InstallAttempt {
+	attempt := &hivev1.InstallAttempt{
+		Attempt:       provision.Spec.Attempt,
+		ProvisionName: provision.Name,
+		StartTime:     provision.CreationTimestamp,
+	}
+	// The provision does not record when it completed, which is then when the completion is first seen.
+	completionTime := metav1.NewTime(time.Now())
+	if provision.Spec.Stage == hivev1.ClusterProvisionStageComplete {
+		attempt.Result = hivev1.InstallAttemptSucceeded
+	} else {
+		attempt.Result = hivev1.InstallAttemptFailed
+		failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+		if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
+			attempt.FailureReason = failedCond.Reason
+			attempt.FailureMessage = failedCond.Message
+			completionTime = failedCond.LastTransitionTime
+		}
+		attempt.LogLocation = controllerutils.InstallLogsLocation(cd.Spec.ClusterName, provision)
+	}
+	if existing != nil && existing.CompletionTime != nil {
+		completionTime = *existing.CompletionTime
+	}
+	attempt.CompletionTime = &completionTime
+	return attempt
+}
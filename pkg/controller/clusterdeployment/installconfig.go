@@ -0,0 +1,212 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/validate"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
+)
+
+const (
+	installConfigSecretKey = "install-config.yaml"
+
+	installConfigValidReason          = "InstallConfigValid"
+	installConfigSecretNotFoundReason = "InstallConfigSecretNotFound"
+	invalidInstallConfigReason        = "InvalidInstallConfig"
+	installConfigMismatchReason       = "InstallConfigMismatch"
+)
+
+// validateInstallConfig checks the install-config of the cluster deployment before a provision is started, so that no
+// install job is launched for an install-config which the installer would reject or which does not match the cluster
+// deployment. It sets the InstallConfigValidationFailed condition, and returns an error while the install-config is
+// not valid, so that the cluster deployment is requeued until the install-config secret is fixed.
+func (r *ReconcileClusterDeployment) validateInstallConfig(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	reason, message, err := r.checkInstallConfig(cd)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not check install-config")
+		return err
+	}
+	if reason == "" {
+		return r.setInstallConfigValidationFailedCondition(cd, corev1.ConditionFalse, installConfigValidReason, "The install-config is valid", cdLog)
+	}
+	cdLog.WithField("reason", reason).WithField("message", message).Warn("install-config is not valid, not starting a provision")
+	if err := r.setInstallConfigValidationFailedCondition(cd, corev1.ConditionTrue, reason, message, cdLog); err != nil {
+		return err
+	}
+	return errors.New(message)
+}
+
+// checkInstallConfig returns the reason and message of the problem found in the install-config of the cluster
+// deployment, or an empty reason when the install-config is valid.
+func (r *ReconcileClusterDeployment) checkInstallConfig(cd *hivev1.ClusterDeployment) (reason, message string, returnErr error) {
+	secretName := cd.Spec.Provisioning.InstallConfigSecretRef.Name
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		return installConfigSecretNotFoundReason, fmt.Sprintf("The install-config secret %s does not exist", secretName), nil
+	case err != nil:
+		return "", "", errors.Wrap(err, "could not get install-config secret")
+	}
+	data, ok := secret.Data[installConfigSecretKey]
+	if !ok {
+		return installConfigSecretNotFoundReason, fmt.Sprintf("The install-config secret %s does not contain the %s key", secretName, installConfigSecretKey), nil
+	}
+
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(data, installConfig); err != nil {
+		return invalidInstallConfigReason, fmt.Sprintf("The install-config cannot be parsed: %v", err), nil
+	}
+	setControlPlaneReplicas(installConfig, cd)
+	if problems := validateInstallConfigFields(installConfig); len(problems) > 0 {
+		return invalidInstallConfigReason, "The install-config is invalid: " + strings.Join(problems, "; "), nil
+	}
+	if mismatches := installConfigMismatches(installConfig, cd); len(mismatches) > 0 {
+		return installConfigMismatchReason, "The install-config does not match the ClusterDeployment: " + strings.Join(mismatches, "; "), nil
+	}
+	return "", "", nil
+}
+
+// validateInstallConfigFields runs the checks of the installer which do not depend on the cloud of the cluster.
+func validateInstallConfigFields(installConfig *installertypes.InstallConfig) []string {
+	var problems []string
+	if installConfig.APIVersion != installertypes.InstallConfigVersion {
+		problems = append(problems, fmt.Sprintf("apiVersion must be %q", installertypes.InstallConfigVersion))
+	}
+	if err := validate.ClusterName(installConfig.ObjectMeta.Name); err != nil {
+		problems = append(problems, fmt.Sprintf("metadata.name: %v", err))
+	}
+	if err := validate.DomainName(installConfig.BaseDomain, true); err != nil {
+		problems = append(problems, fmt.Sprintf("baseDomain: %v", err))
+	}
+	if installConfig.SSHKey != "" {
+		if err := validate.SSHPublicKey(installConfig.SSHKey); err != nil {
+			problems = append(problems, fmt.Sprintf("sshKey: %v", err))
+		}
+	}
+	if installConfig.Platform.Name() == "" {
+		problems = append(problems, "platform: a platform must be specified")
+	}
+	return append(problems, validateInstallConfigTopology(installConfig)...)
+}
+
+// validateInstallConfigTopology checks the number of nodes of the install-config. A cluster either has a highly
+// available control plane of at least three nodes, or is a single-node cluster whose one node runs both the control
+// plane and the workloads, and so has no compute nodes.
+func validateInstallConfigTopology(installConfig *installertypes.InstallConfig) []string {
+	var problems []string
+	controlPlaneReplicas := installConfigControlPlaneReplicas(installConfig)
+	if controlPlaneReplicas != 1 && controlPlaneReplicas < 3 {
+		problems = append(problems, fmt.Sprintf("controlPlane.replicas: %d control plane nodes are not supported, there must be 1 or at least 3", controlPlaneReplicas))
+	}
+	if computeReplicas := installConfigComputeReplicas(installConfig); controlPlaneReplicas == 1 && computeReplicas > 0 {
+		problems = append(problems, fmt.Sprintf("compute.replicas: a single-node cluster has no compute nodes, found %d", computeReplicas))
+	}
+	return problems
+}
+
+// installConfigControlPlaneReplicas returns the number of control plane nodes of the install-config, which the
+// installer defaults to 3. The install-config should have been updated by setControlPlaneReplicas.
+func installConfigControlPlaneReplicas(installConfig *installertypes.InstallConfig) int64 {
+	if installConfig.ControlPlane != nil && installConfig.ControlPlane.Replicas != nil {
+		return *installConfig.ControlPlane.Replicas
+	}
+	return 3
+}
+
+// installConfigComputeReplicas returns the number of compute nodes of the install-config. The installer defaults to a
+// pool of 3 compute nodes, and defaults the replicas of each pool to 3.
+func installConfigComputeReplicas(installConfig *installertypes.InstallConfig) int64 {
+	if len(installConfig.Compute) == 0 {
+		return 3
+	}
+	var replicas int64
+	for _, pool := range installConfig.Compute {
+		if pool.Replicas == nil {
+			replicas += 3
+			continue
+		}
+		replicas += *pool.Replicas
+	}
+	return replicas
+}
+
+// installConfigMismatches returns the fields of the install-config which do not match the cluster deployment.
+func installConfigMismatches(installConfig *installertypes.InstallConfig, cd *hivev1.ClusterDeployment) []string {
+	var mismatches []string
+	if installConfig.ObjectMeta.Name != cd.Spec.ClusterName {
+		mismatches = append(mismatches, fmt.Sprintf("metadata.name %q is not the cluster name %q", installConfig.ObjectMeta.Name, cd.Spec.ClusterName))
+	}
+	if installConfig.BaseDomain != cd.Spec.BaseDomain {
+		mismatches = append(mismatches, fmt.Sprintf("baseDomain %q is not the base domain %q", installConfig.BaseDomain, cd.Spec.BaseDomain))
+	}
+	if clusterPlatform := platform.Name(cd); clusterPlatform != constants.PlatformUnknown && installConfig.Platform.Name() != clusterPlatform {
+		mismatches = append(mismatches, fmt.Sprintf("platform %q is not the platform %q", installConfig.Platform.Name(), clusterPlatform))
+	}
+	if actuator := platform.ForClusterDeployment(cd); actuator != nil {
+		mismatches = append(mismatches, actuator.InstallConfigMismatches(cd, installConfig)...)
+	}
+	return mismatches
+}
+
+// getInstallConfig returns the install-config of the cluster deployment.
+func (r *ReconcileClusterDeployment) getInstallConfig(cd *hivev1.ClusterDeployment) (*installertypes.InstallConfig, error) {
+	if cd.Spec.Provisioning == nil {
+		return nil, errors.New("the cluster deployment has no install-config")
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get install-config secret")
+	}
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretKey], installConfig); err != nil {
+		return nil, errors.Wrap(err, "could not parse install-config")
+	}
+	setControlPlaneReplicas(installConfig, cd)
+	return installConfig, nil
+}
+
+// setControlPlaneReplicas sets the control plane replicas of the cluster deployment in the install-config when the
+// install-config does not set them, as the install manager does before running the installer.
+func setControlPlaneReplicas(installConfig *installertypes.InstallConfig, cd *hivev1.ClusterDeployment) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.ControlPlaneReplicas == nil {
+		return
+	}
+	if installConfig.ControlPlane == nil {
+		installConfig.ControlPlane = &installertypes.MachinePool{Name: "master"}
+	}
+	if installConfig.ControlPlane.Replicas == nil {
+		replicas := *cd.Spec.Provisioning.ControlPlaneReplicas
+		installConfig.ControlPlane.Replicas = &replicas
+	}
+}
+
+func (r *ReconcileClusterDeployment) setInstallConfigValidationFailedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.InstallConfigValidationFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
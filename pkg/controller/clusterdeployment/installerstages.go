@@ -0,0 +1,79 @@
+package clusterdeployment
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	bootstrapCompleteReason    = "BootstrapComplete"
+	bootstrapNotCompleteReason = "BootstrapNotComplete"
+)
+
+// syncInstallerStages copies the stages of the install reached by the installer of the provision to the status of
+// the cluster deployment, and sets the BootstrapComplete condition accordingly. The time taken to reach each newly
+// reached stage is observed. It returns true if the status of the cluster deployment changed.
+func syncInstallerStages(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) bool {
+	changed := len(provision.Spec.InstallerStages) != len(cd.Status.InstallerStages)
+	previous := provision.CreationTimestamp.Time
+	for _, transition := range provision.Spec.InstallerStages {
+		if !hasInstallerStageTransition(cd.Status.InstallerStages, transition) {
+			changed = true
+			cdLog.WithField("installerStage", transition.Stage).Info("installer reached stage")
+			metricInstallerStageDuration.WithLabelValues(string(transition.Stage)).Observe(transition.Time.Sub(previous).Seconds())
+		}
+		previous = transition.Time.Time
+	}
+	if changed {
+		cd.Status.InstallerStages = nil
+		for _, transition := range provision.Spec.InstallerStages {
+			cd.Status.InstallerStages = append(cd.Status.InstallerStages, *transition.DeepCopy())
+		}
+	}
+
+	// A completed provision has bootstrapped the cluster, even when its installer did not report the stage.
+	status := corev1.ConditionFalse
+	reason := bootstrapNotCompleteReason
+	message := fmt.Sprintf("Provision %s has not bootstrapped the cluster", provision.Name)
+	if provision.Spec.Stage == hivev1.ClusterProvisionStageComplete || hasInstallerStage(provision.Spec.InstallerStages, hivev1.InstallerStageBootstrapComplete) {
+		status = corev1.ConditionTrue
+		reason = bootstrapCompleteReason
+		message = fmt.Sprintf("Provision %s bootstrapped the cluster", provision.Name)
+	}
+	conds, condChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.BootstrapCompleteCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if condChanged {
+		cd.Status.Conditions = conds
+	}
+	return changed || condChanged
+}
+
+func hasInstallerStage(transitions []hivev1.InstallerStageTransition, stage hivev1.InstallerStage) bool {
+	for _, t := range transitions {
+		if t.Stage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInstallerStageTransition(transitions []hivev1.InstallerStageTransition, transition hivev1.InstallerStageTransition) bool {
+	for _, t := range transitions {
+		if t.Stage == transition.Stage && t.Time.Equal(&transition.Time) {
+			return true
+		}
+	}
+	return false
+}
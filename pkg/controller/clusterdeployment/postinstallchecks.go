@@ -0,0 +1,368 @@
+package clusterdeployment
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	postInstallChecksPassedReason = "PostInstallChecksPassed"
+
+	// postInstallCheckRequeueAfter is how long to wait before running the post-install checks again after one fails.
+	postInstallCheckRequeueAfter = time.Minute
+
+	postInstallCheckConsoleTimeout = 10 * time.Second
+
+	controlPlaneNodeRoleLabel = "node-role.kubernetes.io/master"
+	computeNodeRoleLabel      = "node-role.kubernetes.io/worker"
+)
+
+// getDefaultPostInstallChecks returns the default post-install checks configured in HiveConfig.
+func getDefaultPostInstallChecks(logger log.FieldLogger) []hivev1.PostInstallCheck {
+	value, ok := os.LookupEnv(constants.PostInstallChecksEnvVar)
+	if !ok {
+		return nil
+	}
+	var checks []hivev1.PostInstallCheck
+	if err := json.Unmarshal([]byte(value), &checks); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, no default post-install checks will run", constants.PostInstallChecksEnvVar)
+		return nil
+	}
+	return checks
+}
+
+func (r *ReconcileClusterDeployment) getPostInstallChecks(cd *hivev1.ClusterDeployment) []hivev1.PostInstallCheck {
+	if len(cd.Spec.PostInstallChecks) > 0 {
+		return cd.Spec.PostInstallChecks
+	}
+	return r.defaultPostInstallChecks
+}
+
+// runPostInstallChecks runs the post-install checks of the cluster deployment in order, stopping at the first check
+// which does not pass. It returns a nil result when all the checks have passed and the cluster can be marked as
+// installed.
+func (r *ReconcileClusterDeployment) runPostInstallChecks(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	checks := r.getPostInstallChecks(cd)
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	var remoteClient client.Client
+	getRemoteClient := func() (client.Client, error) {
+		if remoteClient != nil {
+			return remoteClient, nil
+		}
+		c, err := r.remoteClusterAPIClientBuilder(cd).UsePrimaryAPIURL().Build()
+		if err != nil {
+			return nil, err
+		}
+		remoteClient = c
+		return remoteClient, nil
+	}
+
+	for i, check := range checks {
+		checkLog := cdLog.WithField("postInstallCheck", fmt.Sprintf("%d-%s", i, check.Type))
+		var err error
+		switch check.Type {
+		case hivev1.APIReachablePostInstallCheck:
+			err = checkAPIReachable(getRemoteClient)
+		case hivev1.ConsoleAvailablePostInstallCheck:
+			err = checkConsoleAvailable(getRemoteClient, controllerutils.IsFakeCluster(cd))
+		case hivev1.ClusterOperatorsAvailablePostInstallCheck:
+			err = checkClusterOperatorsAvailable(getRemoteClient)
+		case hivev1.NodesReadyPostInstallCheck:
+			err = r.checkNodesReady(cd, getRemoteClient)
+		case hivev1.JobPostInstallCheck:
+			var finished bool
+			finished, err = r.checkPostInstallJob(cd, i, check.Job, checkLog)
+			if err == nil && !finished {
+				// The controller is requeued by the watch on the jobs when the job finishes.
+				checkLog.Debug("waiting for post-install check job to finish")
+				return &reconcile.Result{}, nil
+			}
+		default:
+			err = fmt.Errorf("unknown post-install check type %q", check.Type)
+		}
+		if err != nil {
+			checkLog.WithError(err).Info("post-install check failed")
+			return &reconcile.Result{RequeueAfter: postInstallCheckRequeueAfter}, r.setPostInstallChecksFailedCondition(
+				cd,
+				corev1.ConditionTrue,
+				fmt.Sprintf("%sCheckFailed", check.Type),
+				fmt.Sprintf("Post-install check %d (%s) failed: %v", i, check.Type, err),
+				cdLog,
+			)
+		}
+		checkLog.Debug("post-install check passed")
+	}
+
+	return nil, r.setPostInstallChecksFailedCondition(
+		cd,
+		corev1.ConditionFalse,
+		postInstallChecksPassedReason,
+		"All post-install checks passed",
+		cdLog,
+	)
+}
+
+func (r *ReconcileClusterDeployment) setPostInstallChecksFailedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PostInstallChecksFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
+
+func checkAPIReachable(getRemoteClient func() (client.Client, error)) error {
+	remoteClient, err := getRemoteClient()
+	if err != nil {
+		return err
+	}
+	return remoteClient.Get(context.TODO(), client.ObjectKey{Name: "version"}, &configv1.ClusterVersion{})
+}
+
+func checkConsoleAvailable(getRemoteClient func() (client.Client, error), fakeCluster bool) error {
+	remoteClient, err := getRemoteClient()
+	if err != nil {
+		return err
+	}
+	route := &routev1.Route{}
+	if err := remoteClient.Get(
+		context.TODO(),
+		client.ObjectKey{Namespace: "openshift-console", Name: "console"},
+		route,
+	); err != nil {
+		return err
+	}
+	// There is no console to reach for a fake cluster.
+	if fakeCluster {
+		return nil
+	}
+	httpClient := &http.Client{
+		Timeout: postInstallCheckConsoleTimeout,
+		Transport: &http.Transport{
+			// The console is typically served with a certificate signed by the ingress CA of the cluster, which
+			// Hive does not trust. The check is only about whether the console is serving.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	resp, err := httpClient.Get("https://" + route.Spec.Host)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("console responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func checkClusterOperatorsAvailable(getRemoteClient func() (client.Client, error)) error {
+	remoteClient, err := getRemoteClient()
+	if err != nil {
+		return err
+	}
+	clusterOperators := &configv1.ClusterOperatorList{}
+	if err := remoteClient.List(context.TODO(), clusterOperators); err != nil {
+		return err
+	}
+	var unavailable []string
+	for _, co := range clusterOperators.Items {
+		available := false
+		for _, cond := range co.Status.Conditions {
+			if cond.Type == configv1.OperatorAvailable {
+				available = cond.Status == configv1.ConditionTrue
+				break
+			}
+		}
+		if !available {
+			unavailable = append(unavailable, co.Name)
+		}
+	}
+	if len(unavailable) > 0 {
+		sort.Strings(unavailable)
+		return fmt.Errorf("cluster operators not available: %s", strings.Join(unavailable, ", "))
+	}
+	return nil
+}
+
+// checkNodesReady checks that all the nodes of the cluster are ready, and that the cluster has as many control plane
+// and compute nodes as its install-config asks for. A cluster without compute nodes, such as a single-node or a
+// compact cluster, runs its workloads on its control plane nodes, which must then be schedulable.
+func (r *ReconcileClusterDeployment) checkNodesReady(cd *hivev1.ClusterDeployment, getRemoteClient func() (client.Client, error)) error {
+	// There are no nodes in a fake cluster.
+	if controllerutils.IsFakeCluster(cd) {
+		return nil
+	}
+	installConfig, err := r.getInstallConfig(cd)
+	if err != nil {
+		return err
+	}
+	remoteClient, err := getRemoteClient()
+	if err != nil {
+		return err
+	}
+	nodes := &corev1.NodeList{}
+	if err := remoteClient.List(context.TODO(), nodes); err != nil {
+		return err
+	}
+	var notReady, unschedulable []string
+	var controlPlaneNodes, computeNodes int64
+	for i, node := range nodes.Items {
+		if !isNodeReady(&nodes.Items[i]) {
+			notReady = append(notReady, node.Name)
+		}
+		if _, ok := node.Labels[controlPlaneNodeRoleLabel]; ok {
+			controlPlaneNodes++
+			if !isNodeSchedulable(&nodes.Items[i]) {
+				unschedulable = append(unschedulable, node.Name)
+			}
+		} else if _, ok := node.Labels[computeNodeRoleLabel]; ok {
+			computeNodes++
+		}
+	}
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		return fmt.Errorf("nodes not ready: %s", strings.Join(notReady, ", "))
+	}
+	if expected := installConfigControlPlaneReplicas(installConfig); controlPlaneNodes < expected {
+		return fmt.Errorf("%d of %d control plane nodes have joined the cluster", controlPlaneNodes, expected)
+	}
+	switch expected := installConfigComputeReplicas(installConfig); {
+	case computeNodes < expected:
+		return fmt.Errorf("%d of %d compute nodes have joined the cluster", computeNodes, expected)
+	case expected == 0 && len(unschedulable) > 0:
+		sort.Strings(unschedulable)
+		return fmt.Errorf("control plane nodes not schedulable in a cluster without compute nodes: %s", strings.Join(unschedulable, ", "))
+	}
+	return nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isNodeSchedulable returns true if workloads can be scheduled on the node, which is not the case of the control plane
+// nodes of a cluster with compute nodes, tainted so that only the control plane runs on them.
+func isNodeSchedulable(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == controlPlaneNodeRoleLabel && taint.Effect == corev1.TaintEffectNoSchedule {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPostInstallJob runs the job of a post-install check. It returns true when the job completed successfully. A
+// failed job is retained so that its logs can be inspected, and fails the check until it is deleted, at which point
+// it is created again.
+func (r *ReconcileClusterDeployment) checkPostInstallJob(cd *hivev1.ClusterDeployment, index int, spec *hivev1.PostInstallCheckJob, checkLog log.FieldLogger) (bool, error) {
+	if spec == nil {
+		return false, fmt.Errorf("no job specified")
+	}
+
+	jobName := apihelpers.GetResourceName(cd.Name, fmt.Sprintf("post-install-check-%d", index))
+	job := &batchv1.Job{}
+	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: jobName}, job); {
+	case apierrors.IsNotFound(err):
+		return false, r.createPostInstallJob(cd, jobName, spec, checkLog)
+	case err != nil:
+		checkLog.WithError(err).Error("cannot get post-install check job")
+		return false, err
+	}
+
+	switch {
+	case controllerutils.IsSuccessful(job):
+		return true, nil
+	case controllerutils.IsFailed(job):
+		return false, fmt.Errorf("job %s failed, delete the job to run the check again", jobName)
+	default:
+		return false, nil
+	}
+}
+
+func (r *ReconcileClusterDeployment) createPostInstallJob(cd *hivev1.ClusterDeployment, jobName string, spec *hivev1.PostInstallCheckJob, checkLog log.FieldLogger) error {
+	kubeconfigSecret, err := r.getJobAdminKubeconfigSecret(cd)
+	if err != nil {
+		return err
+	}
+	if kubeconfigSecret == nil {
+		return fmt.Errorf("cluster metadata is not set")
+	}
+
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: cd.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "check",
+						Image:   spec.Image,
+						Command: spec.Command,
+						Args:    spec.Args,
+					}},
+				},
+			},
+		},
+	}
+	mountAdminKubeconfig(&job.Spec.Template.Spec, jobAdminKubeconfigSecretName(jobName, kubeconfigSecret))
+	controllerutils.AddJobPodScheduling(&job.Spec.Template.Spec, checkLog)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	job.Labels = k8slabels.AddLabel(job.Labels, constants.JobTypeLabel, constants.JobTypePostInstallCheck)
+	controllerutils.AddJobPropagatedLabels(cd, job, checkLog)
+	if err := controllerutil.SetControllerReference(cd, job, r.scheme); err != nil {
+		checkLog.WithError(err).Error("error setting controller reference on job")
+		return err
+	}
+
+	checkLog.WithField("job", jobName).Info("creating post-install check job")
+	return r.createJob(job, kubeconfigSecret, checkLog.WithField("job", jobName))
+}
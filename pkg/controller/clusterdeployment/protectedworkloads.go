@@ -0,0 +1,150 @@
+package clusterdeployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	protectedWorkloadsFoundReason       = "ProtectedWorkloadsFound"
+	protectedWorkloadsCheckFailedReason = "ProtectedWorkloadsCheckFailed"
+	noProtectedWorkloadsReason          = "NoProtectedWorkloads"
+
+	// protectedWorkloadsRequeueAfter is how long to wait before checking a cluster for protected workloads again.
+	protectedWorkloadsRequeueAfter = time.Minute
+
+	// maxReportedProtectedNamespaces is the maximum number of protected namespaces listed in the DeprovisionBlocked
+	// condition.
+	maxReportedProtectedNamespaces = 10
+)
+
+// getProtectedWorkloadsSelector returns the selector of the namespaces holding protected workloads configured in
+// HiveConfig, or nil when the check for protected workloads is disabled.
+func getProtectedWorkloadsSelector(logger log.FieldLogger) labels.Selector {
+	value, ok := os.LookupEnv(constants.ProtectedWorkloadsEnvVar)
+	if !ok {
+		return nil
+	}
+	config := &hivev1.ProtectedWorkloadsConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, protected workloads check disabled", constants.ProtectedWorkloadsEnvVar)
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&config.NamespaceSelector)
+	if err != nil {
+		logger.WithError(err).Error("invalid protected workloads namespace selector, protected workloads check disabled")
+		return nil
+	}
+	return selector
+}
+
+// checkProtectedWorkloads checks that a deleted cluster deployment runs no protected workloads before its cluster is
+// deprovisioned. It returns a nil result when the deprovision can proceed.
+func (r *ReconcileClusterDeployment) checkProtectedWorkloads(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	if r.protectedWorkloadsSelector == nil || !cd.Spec.Installed || cd.Spec.PreserveOnDelete {
+		return nil, nil
+	}
+	if cd.Annotations[constants.ForceDeprovisionAnnotation] == "true" {
+		cdLog.Warn("skipping protected workloads check for cluster with force deprovision annotation")
+		return nil, nil
+	}
+	// Nobody can have put workloads on a cluster of a pool which has not been claimed.
+	if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil && poolRef.ClaimName == "" {
+		return nil, nil
+	}
+	// Once the deprovision has started, there is nothing left to protect.
+	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name}, &hivev1.ClusterDeprovision{}); {
+	case err == nil:
+		return nil, nil
+	case !apierrors.IsNotFound(err):
+		cdLog.WithError(err).Error("error getting deprovision request")
+		return nil, err
+	}
+
+	forceMsg := fmt.Sprintf("Set the %s annotation to \"true\" to deprovision the cluster anyway.", constants.ForceDeprovisionAnnotation)
+	protected, err := r.listProtectedNamespaces(cd)
+	if err != nil {
+		cdLog.WithError(err).Warn("cannot check cluster for protected workloads")
+		return &reconcile.Result{RequeueAfter: protectedWorkloadsRequeueAfter}, r.setDeprovisionBlockedCondition(
+			cd,
+			corev1.ConditionTrue,
+			protectedWorkloadsCheckFailedReason,
+			fmt.Sprintf("Cannot check the cluster for protected workloads: %v. %s", err, forceMsg),
+			cdLog,
+		)
+	}
+	if len(protected) > 0 {
+		cdLog.WithField("namespaces", protected).Warn("deprovision blocked for cluster with protected workloads")
+		if len(protected) > maxReportedProtectedNamespaces {
+			protected = append(protected[:maxReportedProtectedNamespaces], "...")
+		}
+		return &reconcile.Result{RequeueAfter: protectedWorkloadsRequeueAfter}, r.setDeprovisionBlockedCondition(
+			cd,
+			corev1.ConditionTrue,
+			protectedWorkloadsFoundReason,
+			fmt.Sprintf("The cluster runs protected workloads in namespaces %s. %s", strings.Join(protected, ", "), forceMsg),
+			cdLog,
+		)
+	}
+	return nil, r.setDeprovisionBlockedCondition(
+		cd,
+		corev1.ConditionFalse,
+		noProtectedWorkloadsReason,
+		"The cluster runs no protected workloads",
+		cdLog,
+	)
+}
+
+// listProtectedNamespaces returns the names of the namespaces of the cluster which hold protected workloads.
+func (r *ReconcileClusterDeployment) listProtectedNamespaces(cd *hivev1.ClusterDeployment) ([]string, error) {
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := &corev1.NamespaceList{}
+	if err := remoteClient.List(
+		context.TODO(),
+		namespaces,
+		client.MatchingLabelsSelector{Selector: r.protectedWorkloadsSelector},
+	); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(namespaces.Items))
+	for i, ns := range namespaces.Items {
+		names[i] = ns.Name
+	}
+	return names, nil
+}
+
+func (r *ReconcileClusterDeployment) setDeprovisionBlockedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.DeprovisionBlockedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
@@ -0,0 +1,110 @@
+package clusterdeployment
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	clusterReadyReason     = "ClusterReady"
+	provisioningReason     = "Provisioning"
+	provisionStoppedReason = "ProvisionStopped"
+	unreachableReason      = "Unreachable"
+	deprovisioningReason   = "Deprovisioning"
+)
+
+// updateReadyStatus records the generation of the cluster deployment once it has been reconciled without error, and
+// sets the Ready condition from the current state of the cluster deployment. The cluster deployment is read again from
+// the cache, as it may have been updated from a copy during the reconcile, and its conditions are also set by other
+// controllers. The status is patched, so that the update does not conflict with those of the other controllers.
+func (r *ReconcileClusterDeployment) updateReadyStatus(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	current := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, current); {
+	case apierrors.IsNotFound(err):
+		// The cluster deployment is gone once its finalizer has been removed.
+		return nil
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster deployment")
+		return err
+	}
+
+	orig := current.DeepCopy()
+	changed := false
+	if current.Status.ObservedGeneration < cd.Generation {
+		current.Status.ObservedGeneration = cd.Generation
+		changed = true
+	}
+	status, reason, message := readyCondition(current)
+	if controllerutils.FindClusterDeploymentCondition(current.Status.Conditions, hivev1.ClusterReadyCondition) == nil {
+		// Ready is always reported, so that clients can wait for it without having to handle a missing condition.
+		now := metav1.Now()
+		current.Status.Conditions = append(current.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterReadyCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+		})
+		changed = true
+	} else {
+		var readyChanged bool
+		current.Status.Conditions, readyChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			current.Status.Conditions,
+			hivev1.ClusterReadyCondition,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		changed = changed || readyChanged
+	}
+	if !changed {
+		return nil
+	}
+
+	switch err := r.Status().Patch(context.TODO(), current, client.MergeFrom(orig)); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update ready status")
+		return err
+	}
+	return nil
+}
+
+// readyCondition returns the status, reason and message of the Ready condition of the cluster deployment.
+func readyCondition(cd *hivev1.ClusterDeployment) (corev1.ConditionStatus, string, string) {
+	isTrue := func(conditionType hivev1.ClusterDeploymentConditionType) *hivev1.ClusterDeploymentCondition {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			return nil
+		}
+		return cond
+	}
+	switch {
+	case cd.DeletionTimestamp != nil:
+		return corev1.ConditionFalse, deprovisioningReason, "The cluster is being deprovisioned"
+	case !cd.Spec.Installed && isTrue(hivev1.ProvisionStoppedCondition) != nil:
+		return corev1.ConditionFalse, provisionStoppedReason, "The cluster failed to provision and will not be retried"
+	case !cd.Spec.Installed:
+		return corev1.ConditionFalse, provisioningReason, "The cluster is being provisioned"
+	}
+	if cond := isTrue(hivev1.ClusterHibernatingCondition); cond != nil {
+		return corev1.ConditionFalse, cond.Reason, cond.Message
+	}
+	if isTrue(hivev1.UnreachableCondition) != nil {
+		return corev1.ConditionFalse, unreachableReason, "The cluster is unreachable"
+	}
+	return corev1.ConditionTrue, clusterReadyReason, "The cluster is installed and reachable"
+}
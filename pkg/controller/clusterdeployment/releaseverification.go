@@ -0,0 +1,49 @@
+package clusterdeployment
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	releaseImageVerifiedReason   = "ReleaseImageVerified"
+	releaseImageUnverifiedReason = "ReleaseImageUnverified"
+)
+
+// verifyReleaseImage verifies the release image of the cluster deployment when the verification of release images is
+// turned on. It sets the ReleaseImageVerificationFailed condition, and returns an error while the release image cannot
+// be verified so that the cluster deployment is requeued with a backoff.
+func (r *ReconcileClusterDeployment) verifyReleaseImage(cd *hivev1.ClusterDeployment, releaseImage string, cdLog log.FieldLogger) error {
+	if r.releaseImageVerifier == nil {
+		return nil
+	}
+	verifyErr := r.releaseImageVerifier.Verify(releaseImage)
+	if verifyErr == nil {
+		return r.setReleaseImageVerificationFailedCondition(cd, corev1.ConditionFalse, releaseImageVerifiedReason, "The release image has been verified", cdLog)
+	}
+	cdLog.WithError(verifyErr).WithField("releaseImage", releaseImage).Warn("release image cannot be verified, not starting a provision")
+	if err := r.setReleaseImageVerificationFailedCondition(cd, corev1.ConditionTrue, releaseImageUnverifiedReason, verifyErr.Error(), cdLog); err != nil {
+		return err
+	}
+	return verifyErr
+}
+
+func (r *ReconcileClusterDeployment) setReleaseImageVerificationFailedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ReleaseImageVerificationFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	return r.statusUpdate(cd, cdLog)
+}
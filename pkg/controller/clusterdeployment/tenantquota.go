@@ -0,0 +1,144 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/tenancy"
+)
+
+const (
+	tenantQuotaExceededReason    = "TenantQuotaExceeded"
+	tenantQuotaNotExceededReason = "TenantQuotaNotExceeded"
+
+	// tenantQuotaCheckInterval is how often the quotas are checked again for a cluster deployment whose provision is
+	// held back by a quota.
+	tenantQuotaCheckInterval = time.Minute
+)
+
+// checkTenantQuotas checks the concurrent installs and vCPUs quotas of the HiveTenants in the namespace of the cluster
+// deployment before a provision is started. The admission webhook only checks the quotas when resources are created
+// or updated, so the provision is held back here when other provisions were started in the meantime, or when the
+// control plane of the cluster would exceed the vCPUs of the namespace. It sets the TenantQuotaExceeded condition,
+// and returns a non-nil result while a quota would be exceeded, so that the cluster deployment is requeued until the
+// provision can be started.
+// The caller must hold the tenantQuotaLock of the namespace until the provision has been created.
+func (r *ReconcileClusterDeployment) checkTenantQuotas(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	tenants := &hivev1.HiveTenantList{}
+	if err := r.List(context.TODO(), tenants, client.InNamespace(cd.Namespace)); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not list hive tenants")
+		return nil, err
+	}
+	var message string
+	for i := range tenants.Items {
+		tenant := &tenants.Items[i]
+		reason, err := r.tenantQuotaExceeded(cd, tenant)
+		if err != nil {
+			cdLog.WithError(err).WithField("hiveTenant", tenant.Name).Log(controllerutils.LogLevel(err), "could not check hive tenant quotas")
+			return nil, err
+		}
+		if reason != "" {
+			message = fmt.Sprintf("The provision cannot be started without exceeding the quota of HiveTenant %s: %s", tenant.Name, reason)
+			break
+		}
+	}
+
+	if message == "" {
+		// The condition is only added to the cluster deployments which were held back.
+		if controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.TenantQuotaExceededCondition) == nil {
+			return nil, nil
+		}
+		return nil, r.setTenantQuotaExceededCondition(cd, corev1.ConditionFalse, tenantQuotaNotExceededReason, "No tenant quota is exceeded", cdLog)
+	}
+	cdLog.WithField("message", message).Info("not creating new provision since a tenant quota would be exceeded")
+	if err := r.setTenantQuotaExceededCondition(cd, corev1.ConditionTrue, tenantQuotaExceededReason, message, cdLog); err != nil {
+		return nil, err
+	}
+	return &reconcile.Result{RequeueAfter: tenantQuotaCheckInterval}, nil
+}
+
+// tenantQuotaLock returns the lock of the quotas of the HiveTenants of the namespace.
+func (r *ReconcileClusterDeployment) tenantQuotaLock(namespace string) *sync.Mutex {
+	lock, _ := r.tenantQuotaLocks.LoadOrStore(namespace, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// tenantQuotaExceeded returns the reason that starting a provision of the cluster deployment would exceed a quota of
+// the HiveTenant, or an empty string when it would not.
+func (r *ReconcileClusterDeployment) tenantQuotaExceeded(cd *hivev1.ClusterDeployment, tenant *hivev1.HiveTenant) (string, error) {
+	if max := tenant.Spec.MaxConcurrentInstalls; max != nil {
+		running, err := r.runningProvisions(cd)
+		if err != nil {
+			return "", err
+		}
+		if running >= *max {
+			return fmt.Sprintf("the namespace already has %d of a maximum of %d running provisions", running, *max), nil
+		}
+	}
+	if max := tenant.Spec.MaxVCPUs; max != nil {
+		installConfig, err := r.getInstallConfig(cd)
+		if err != nil {
+			return "", err
+		}
+		if _, unknownInstanceType := tenancy.ControlPlaneVCPUs(installConfig, tenant.Spec.InstanceTypeVCPUs); unknownInstanceType != "" {
+			return fmt.Sprintf("the number of vCPUs of the control plane instance type %q is not known", unknownInstanceType), nil
+		}
+		// The usage includes the control plane of the cluster deployment itself.
+		usage, err := tenancy.GetUsage(r, cd.Namespace, tenant.Spec.InstanceTypeVCPUs)
+		if err != nil {
+			return "", err
+		}
+		if usage.VCPUs > *max {
+			return fmt.Sprintf("the namespace needs %d vCPUs, including the control plane of the cluster, of a maximum of %d vCPUs", usage.VCPUs, *max), nil
+		}
+	}
+	return "", nil
+}
+
+// runningProvisions returns the number of the other cluster deployments in the namespace of the cluster deployment
+// which have a provision running, or are creating one.
+func (r *ReconcileClusterDeployment) runningProvisions(cd *hivev1.ClusterDeployment) (int32, error) {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cds, client.InNamespace(cd.Namespace)); err != nil {
+		return 0, err
+	}
+	var running int32
+	for _, other := range cds.Items {
+		if other.Name == cd.Name || other.Spec.Installed || other.DeletionTimestamp != nil {
+			continue
+		}
+		// A provision which has been created but not yet observed is not yet referenced by the cluster deployment.
+		creating := !r.expectations.SatisfiedExpectations(types.NamespacedName{Namespace: other.Namespace, Name: other.Name}.String())
+		if other.Status.ProvisionRef != nil || creating {
+			running++
+		}
+	}
+	return running, nil
+}
+
+func (r *ReconcileClusterDeployment) setTenantQuotaExceededCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.TenantQuotaExceededCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	return r.statusUpdate(cd, cdLog)
+}
@@ -0,0 +1,87 @@
+package clusterdeployment
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+// terraformStatePrivateKey is the key of the RSA private key in the terraform state key secret.
+const terraformStatePrivateKey = "private.pem"
+
+// terraformStateKeyProvider returns the provider holding the RSA key which the install jobs encrypt the terraform
+// state with, as they have no access to the key management service. The key is generated the first time it is needed,
+// and stored in the hive namespace, encrypted with the key management service. It returns nil when the terraform
+// state policy is not StoreEncrypted or secret encryption is not configured.
+func (r *ReconcileClusterDeployment) terraformStateKeyProvider(cdLog log.FieldLogger) (secretencryption.Provider, error) {
+	if hivev1.TerraformStatePolicy(os.Getenv(constants.TerraformStatePolicyEnvVar)) != hivev1.TerraformStatePolicyStoreEncrypted {
+		return nil, nil
+	}
+	kms, err := r.secretEncryptionProvider(r)
+	if err != nil || kms == nil {
+		return nil, err
+	}
+	name := types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: constants.TerraformStateKeySecretName}
+	secret := &corev1.Secret{}
+	err = r.Get(context.TODO(), name, secret)
+	if apierrors.IsNotFound(err) {
+		err = r.createTerraformStateKey(kms, name, cdLog)
+		if err == nil || apierrors.IsAlreadyExists(err) {
+			err = r.Get(context.TODO(), name, secret)
+		}
+	}
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get the terraform state key")
+		return nil, err
+	}
+	data, err := secretencryption.Decrypt(kms, secret)
+	if err != nil {
+		cdLog.WithError(err).Error("could not decrypt the terraform state key")
+		return nil, err
+	}
+	return secretencryption.NewRSAProvider(data[terraformStatePrivateKey])
+}
+
+func (r *ReconcileClusterDeployment) createTerraformStateKey(kms secretencryption.Provider, name types.NamespacedName, cdLog log.FieldLogger) error {
+	privateKey, err := secretencryption.GenerateRSAKey()
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Data: map[string][]byte{terraformStatePrivateKey: privateKey},
+	}
+	if err := secretencryption.Encrypt(kms, secret); err != nil {
+		return err
+	}
+	cdLog.Info("creating the terraform state key")
+	return r.Create(context.TODO(), secret)
+}
+
+// terraformStatePublicKeyEnvVar returns the environment variable passing the public key of the terraform state key to
+// the install job, or nil when the install job does not encrypt the terraform state.
+func (r *ReconcileClusterDeployment) terraformStatePublicKeyEnvVar(cdLog log.FieldLogger) (*corev1.EnvVar, error) {
+	provider, err := r.terraformStateKeyProvider(cdLog)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+	publicKey, err := secretencryption.RSAPublicKeyPEM(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.EnvVar{Name: constants.TerraformStatePublicKeyEnvVar, Value: string(publicKey)}, nil
+}
@@ -47,7 +47,7 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 }
 
 func getAWSClient(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, clusterDeprovision.Spec.Platform.AWS.CredentialsSecretRef.Name, clusterDeprovision.Namespace, clusterDeprovision.Spec.Platform.AWS.Region)
+	awsClient, err := awsclient.NewClient(c, clusterDeprovision.Spec.Platform.AWS.CredentialsSecretRef.Name, clusterDeprovision.Namespace, clusterDeprovision.Spec.Platform.AWS.Region, clusterDeprovision.Spec.Platform.AWS.ServiceEndpoints)
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
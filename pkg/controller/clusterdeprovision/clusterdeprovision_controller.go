@@ -3,9 +3,12 @@ package clusterdeprovision
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -32,6 +35,7 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/platform"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -40,9 +44,22 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+	throttledReason               = "Throttled"
+	cloudAPIErrorReason           = "CloudAPIError"
+	uninstallJobFailedReason      = "UninstallJobFailed"
+	uninstallSucceededReason      = "UninstallSucceeded"
+	unknownFailureMessage         = "unknown failure"
+
+	minDeprovisionBackoff = time.Minute
+	maxDeprovisionBackoff = 30 * time.Minute
 )
 
 var (
+	// throttledRegex matches the errors of the cloud APIs that rate limit requests.
+	throttledRegex = regexp.MustCompile(`(?i)throttl|RequestLimitExceeded|Rate exceeded|TooManyRequests|SlowDown|PriorRequestNotComplete`)
+	// cloudAPIErrorRegex matches the errors of the cloud APIs that may go away when retried later.
+	cloudAPIErrorRegex = regexp.MustCompile(`status code: 5\d\d|RequestError|ServiceUnavailable|InternalError|InternalFailure`)
+
 	metricUninstallJobDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "hive_cluster_deployment_uninstall_job_duration_seconds",
@@ -106,7 +123,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterdeprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -181,8 +198,8 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 	}
 
 	if instance.Status.Completed {
-		rLog.Debug("clusterdeprovision is complete, skipping")
-		return reconcile.Result{}, nil
+		rLog.Debug("clusterdeprovision is complete")
+		return r.deleteCompletedUninstallJob(instance, rLog)
 	}
 
 	// Check if there is a ClusterDeployment owning this Deprovision, if so look it up and
@@ -272,11 +289,28 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		rLog.Errorf("error generating uninstaller job: %v", err)
 		return reconcile.Result{}, err
 	}
+	if proxyEnvVars := controllerutils.JobProxyEnvVars(platform.DeprovisionName(instance), rLog); len(proxyEnvVars) > 0 {
+		for i := range uninstallJob.Spec.Template.Spec.Containers {
+			container := &uninstallJob.Spec.Template.Spec.Containers[i]
+			container.Env = append(container.Env, proxyEnvVars...)
+		}
+	}
+	trustedCASecret, err := controllerutils.SyncJobProxyTrustedCA(r, instance, r.scheme, rLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if trustedCASecret != "" {
+		podSpec := &uninstallJob.Spec.Template.Spec
+		for i := range podSpec.Containers {
+			controllerutils.AddProxyTrustedCA(podSpec, &podSpec.Containers[i], trustedCASecret)
+		}
+	}
 
 	rLog.Debug("setting uninstall job controller reference")
 	rLog.WithField("derivedObject", uninstallJob.Name).Debug("Setting labels on derived object")
 	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.ClusterDeprovisionNameLabel, instance.Name)
 	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.JobTypeLabel, constants.JobTypeDeprovision)
+	controllerutils.AddJobPropagatedLabels(instance, uninstallJob, rLog)
 	err = controllerutil.SetControllerReference(instance, uninstallJob, r.scheme)
 	if err != nil {
 		rLog.Errorf("error setting controller reference on job: %v", err)
@@ -293,6 +327,11 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 	}
 	uninstallJob.Annotations[jobHashAnnotation] = jobHash
 
+	if err := controllerutils.SetupClusterUninstallServiceAccount(r, instance, rLog); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account")
+		return reconcile.Result{}, err
+	}
+
 	// Check if uninstall job already exists:
 	existingJob := &batchv1.Job{}
 	err = r.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
@@ -317,6 +356,22 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		jobDuration := existingJob.Status.CompletionTime.Time.Sub(existingJob.Status.StartTime.Time)
 		rLog.WithField("duration", jobDuration.Seconds()).Debug("uninstall job completed")
 		instance.Status.Completed = true
+		if cond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition); cond != nil {
+			instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+				instance.Status.Conditions,
+				hivev1.DeprovisionFailedClusterDeprovisionCondition,
+				corev1.ConditionFalse,
+				uninstallSucceededReason,
+				"Uninstall job succeeded",
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		}
+		instance.Status.Conditions, _ = setReadyCondition(
+			instance.Status.Conditions,
+			corev1.ConditionTrue,
+			uninstallSucceededReason,
+			"Uninstall job succeeded",
+		)
 		err = r.Status().Update(context.TODO(), instance)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
@@ -349,10 +404,144 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsFailed(existingJob) {
+		return r.reconcileFailedJob(instance, existingJob, rLog)
+	}
+
 	rLog.Infof("uninstall job not yet successful")
 	return reconcile.Result{}, nil
 }
 
+// setReadyCondition sets the Ready condition, which is added whatever its status so that it can be waited on.
+func setReadyCondition(conditions []hivev1.ClusterDeprovisionCondition, status corev1.ConditionStatus, reason, message string) ([]hivev1.ClusterDeprovisionCondition, bool) {
+	if controllerutils.FindClusterDeprovisionCondition(conditions, hivev1.ReadyClusterDeprovisionCondition) == nil {
+		now := metav1.Now()
+		return append(conditions, hivev1.ClusterDeprovisionCondition{
+			Type:               hivev1.ReadyClusterDeprovisionCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		}), true
+	}
+	return controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		conditions,
+		hivev1.ReadyClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+// reconcileFailedJob records why the uninstall job failed and deletes it once the backoff for the number of failed
+// attempts has passed, so that a new uninstall job is started.
+func (r *ReconcileClusterDeprovision) reconcileFailedJob(instance *hivev1.ClusterDeprovision, job *batchv1.Job, rLog log.FieldLogger) (reconcile.Result, error) {
+	if job.DeletionTimestamp != nil {
+		rLog.Debug("failed uninstall job is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	message := r.uninstallFailureMessage(job, rLog)
+	reason := uninstallFailureReason(message)
+	failedAt := job.CreationTimestamp.Time
+	if cond := controllerutils.FindJobCondition(job, batchv1.JobFailed); cond != nil {
+		failedAt = cond.LastTransitionTime.Time
+	}
+	retryAt := failedAt.Add(deprovisionBackoff(instance, instance.Status.FailedAttempts))
+
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.DeprovisionFailedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		reason,
+		fmt.Sprintf("Uninstall job failed, retrying after %s: %s", retryAt.UTC().Format(time.RFC3339), message),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	conditions, readyChanged := setReadyCondition(conditions, corev1.ConditionFalse, reason, "Uninstall job failed: "+message)
+	if changed || readyChanged {
+		rLog.WithField("reason", reason).Warn("uninstall job failed")
+		instance.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if wait := time.Until(retryAt); wait > 0 {
+		rLog.WithField("retryAt", retryAt).Debug("backing off before retrying failed uninstall job")
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	instance.Status.FailedAttempts++
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+		return reconcile.Result{}, err
+	}
+	rLog.WithField("failedAttempts", instance.Status.FailedAttempts).Info("deleting failed uninstall job to retry")
+	// deleting uninstall job with background propagation policy to cascade delete the failed pod
+	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting failed uninstall job")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// uninstallFailureMessage returns the termination message of the failed uninstall pod of the job.
+func (r *ReconcileClusterDeprovision) uninstallFailureMessage(job *batchv1.Job, rLog log.FieldLogger) string {
+	podLabelSelector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		rLog.WithError(err).Warn("could not create pod selector from job")
+		return unknownFailureMessage
+	}
+	podList := &corev1.PodList{}
+	if err := r.List(
+		context.TODO(),
+		podList,
+		client.MatchingLabelsSelector{Selector: podLabelSelector},
+		client.InNamespace(job.Namespace),
+	); err != nil {
+		rLog.WithError(err).Warn("could not list uninstall pods")
+		return unknownFailureMessage
+	}
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 && terminated.Message != "" {
+				return strings.TrimSpace(terminated.Message)
+			}
+		}
+	}
+	return unknownFailureMessage
+}
+
+// uninstallFailureReason classifies the termination message of a failed uninstall pod.
+func uninstallFailureReason(message string) string {
+	switch {
+	case throttledRegex.MatchString(message):
+		return throttledReason
+	case cloudAPIErrorRegex.MatchString(message):
+		return cloudAPIErrorReason
+	default:
+		return uninstallJobFailedReason
+	}
+}
+
+// deprovisionBackoff returns how long to wait after an uninstall job failed before starting another one. The backoff
+// doubles with each failed attempt, and is spread by up to a quarter so that the retries of many deprovisions that
+// failed together do not all hit the cloud API at the same time.
+func deprovisionBackoff(instance *hivev1.ClusterDeprovision, failedAttempts int) time.Duration {
+	backoff := maxDeprovisionBackoff
+	if failedAttempts < 10 {
+		if d := minDeprovisionBackoff << uint(failedAttempts); d < maxDeprovisionBackoff {
+			backoff = d
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(instance.UID))
+	return backoff + time.Duration(h.Sum32()%100)*backoff/400
+}
+
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
 	return []*controllerutils.OwnershipUniqueKey{
 		{
@@ -374,3 +563,37 @@ func (r *ReconcileClusterDeprovision) getActuator(cd *hivev1.ClusterDeprovision)
 	}
 	return nil
 }
+
+// deleteCompletedUninstallJob deletes the uninstall job of a completed deprovision once it is older than the
+// completed uninstall job retention. The job is retained when there is no retention configured.
+func (r *ReconcileClusterDeprovision) deleteCompletedUninstallJob(instance *hivev1.ClusterDeprovision, rLog log.FieldLogger) (reconcile.Result, error) {
+	retention := controllerutils.GetJobHistory(rLog).CompletedUninstallJobRetention
+	if retention == 0 {
+		return reconcile.Result{}, nil
+	}
+	job := &batchv1.Job{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Name: install.GetUninstallJobName(instance.Name), Namespace: instance.Namespace}, job); {
+	case errors.IsNotFound(err):
+		rLog.Debug("uninstall job has already been deleted")
+		return reconcile.Result{}, nil
+	case err != nil:
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get uninstall job")
+		return reconcile.Result{}, err
+	case job.DeletionTimestamp != nil:
+		return reconcile.Result{}, nil
+	}
+	completionTime := job.CreationTimestamp.Time
+	if job.Status.CompletionTime != nil {
+		completionTime = job.Status.CompletionTime.Time
+	}
+	if remaining := time.Until(completionTime.Add(retention)); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+	rLog.WithField("job", job.Name).Info("deleting completed uninstall job")
+	// deleting uninstall job with background propagation policy to cascade delete uninstall pods
+	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		rLog.WithField("job", job.Name).WithError(err).Log(controllerutils.LogLevel(err), "error deleting completed uninstall job")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
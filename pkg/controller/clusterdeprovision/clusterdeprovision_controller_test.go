@@ -3,7 +3,9 @@ package clusterdeprovision
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
@@ -53,6 +55,8 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		jobHistory                     string
+		platformProxies                string
 	}{
 		{
 			name: "no-op deleting",
@@ -79,6 +83,83 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateNoJobExists(t, c)
 			},
 		},
+		{
+			name: "completed uninstall job retained by default",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Completed = true
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testCompletedUninstallJob(time.Now().Add(-30 * 24 * time.Hour)),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+			},
+		},
+		{
+			name: "completed uninstall job retained within retention",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Completed = true
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testCompletedUninstallJob(time.Now().Add(-30 * time.Minute)),
+			},
+			jobHistory: `{"completedUninstallJobRetention": "1h"}`,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+			},
+		},
+		{
+			name: "completed uninstall job deleted after retention",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Completed = true
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testCompletedUninstallJob(time.Now().Add(-2 * time.Hour)),
+			},
+			jobHistory: `{"completedUninstallJobRetention": "1h"}`,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+			},
+		},
+		{
+			name:        "back off after failed job",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testFailedUninstallJob(time.Now()),
+				testFailedUninstallPod("AWS API requests were throttled 50 times, last error: Throttling: Rate exceeded"),
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				req := validateDeprovisionFailed(t, c, throttledReason)
+				assert.Zero(t, req.Status.FailedAttempts, "unexpected failed attempts")
+			},
+		},
+		{
+			name:        "retry failed job after backoff",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testFailedUninstallJob(time.Now().Add(-2 * minDeprovisionBackoff)),
+				testFailedUninstallPod("level=fatal msg=\"Runtime error\" error=\"some failure\""),
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				req := validateDeprovisionFailed(t, c, uninstallJobFailedReason)
+				assert.Equal(t, 1, req.Status.FailedAttempts, "unexpected failed attempts")
+			},
+		},
 		{
 			name:        "no-op if cluster deployment not deleted",
 			deprovision: testClusterDeprovision(),
@@ -113,6 +194,20 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateJobExists(t, c)
 			},
 		},
+		{
+			name:                  "create uninstall job with proxy of platform",
+			deprovision:           testClusterDeprovision(),
+			deployment:            testDeletedClusterDeployment(),
+			mockGetCallerIdentity: true,
+			platformProxies:       `[{"platform":"aws","httpsProxy":"http://proxy.example.com:3128","noProxy":".svc"}]`,
+			validate: func(t *testing.T, c client.Client) {
+				job := validateJobExists(t, c)
+				for _, container := range job.Spec.Template.Spec.Containers {
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.HTTPSProxyEnvVar, Value: "http://proxy.example.com:3128"}, "missing proxy")
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.NoProxyEnvVar, Value: ".svc"}, "missing no proxy")
+				}
+			},
+		},
 		{
 			name:                 "do not create uninstall job when deprovisions are disabled",
 			deprovision:          testClusterDeprovision(),
@@ -235,6 +330,14 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					return
 				}
 			}
+			if test.platformProxies != "" {
+				os.Setenv(constants.PlatformProxiesEnvVar, test.platformProxies)
+				defer os.Unsetenv(constants.PlatformProxiesEnvVar)
+			}
+			if test.jobHistory != "" {
+				os.Setenv(constants.JobHistoryEnvVar, test.jobHistory)
+				defer os.Unsetenv(constants.JobHistoryEnvVar)
+			}
 			existing := append(test.existing, test.deprovision, test.deployment)
 
 			mocks := setupDefaultMocks(t, existing...)
@@ -332,6 +435,101 @@ func testUninstallJob() *batchv1.Job {
 	return uninstallJob
 }
 
+func testCompletedUninstallJob(completionTime time.Time) *batchv1.Job {
+	uninstallJob := testUninstallJob()
+	uninstallJob.Labels[constants.ClusterDeprovisionNameLabel] = testName
+	uninstallJob.Labels[constants.JobTypeLabel] = constants.JobTypeDeprovision
+	completed := metav1.NewTime(completionTime)
+	uninstallJob.Status.CompletionTime = &completed
+	uninstallJob.Status.Conditions = []batchv1.JobCondition{{
+		Type:   batchv1.JobComplete,
+		Status: corev1.ConditionTrue,
+	}}
+	return uninstallJob
+}
+
+func testFailedUninstallJob(failureTime time.Time) *batchv1.Job {
+	uninstallJob := testUninstallJob()
+	uninstallJob.Labels[constants.ClusterDeprovisionNameLabel] = testName
+	uninstallJob.Labels[constants.JobTypeLabel] = constants.JobTypeDeprovision
+	hash, err := controllerutils.CalculateJobSpecHash(uninstallJob)
+	if err != nil {
+		panic("should never get error calculating job spec hash")
+	}
+	uninstallJob.Annotations[jobHashAnnotation] = hash
+	// The selector is set by the API server, after the job spec hash is calculated.
+	uninstallJob.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": uninstallJob.Name}}
+	uninstallJob.Status.Conditions = []batchv1.JobCondition{{
+		Type:               batchv1.JobFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(failureTime),
+	}}
+	return uninstallJob
+}
+
+func testFailedUninstallPod(message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName + "-uninstall-abcde",
+			Labels:    map[string]string{"job-name": testName + "-uninstall"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: message},
+				},
+			}},
+		},
+	}
+}
+
+func validateDeprovisionFailed(t *testing.T, c client.Client, expectedReason string) *hivev1.ClusterDeprovision {
+	req := &hivev1.ClusterDeprovision{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req)
+	require.NoError(t, err, "unexpected error getting ClusterDeprovision")
+	cond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+	if assert.NotNil(t, cond, "expected DeprovisionFailed condition") {
+		assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+		assert.Equal(t, expectedReason, cond.Reason, "unexpected condition reason")
+	}
+	readyCond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.ReadyClusterDeprovisionCondition)
+	if assert.NotNil(t, readyCond, "expected Ready condition") {
+		assert.Equal(t, corev1.ConditionFalse, readyCond.Status, "unexpected Ready condition status")
+		assert.Equal(t, expectedReason, readyCond.Reason, "unexpected Ready condition reason")
+	}
+	return req
+}
+
+func TestUninstallFailureReason(t *testing.T) {
+	cases := []struct {
+		message  string
+		expected string
+	}{
+		{message: "AWS API requests were throttled 50 times, last error: RequestLimitExceeded: Request limit exceeded.", expected: throttledReason},
+		{message: "Throttling: Rate exceeded\n\tstatus code: 400", expected: throttledReason},
+		{message: "ServiceUnavailable: Service is unavailable\n\tstatus code: 503", expected: cloudAPIErrorReason},
+		{message: "error reading /etc/aws-creds/aws_access_key_id file", expected: uninstallJobFailedReason},
+		{message: unknownFailureMessage, expected: uninstallJobFailedReason},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, uninstallFailureReason(tc.message), "unexpected reason for %q", tc.message)
+	}
+}
+
+func TestDeprovisionBackoff(t *testing.T) {
+	req := testClusterDeprovision()
+	req.UID = "test-uid"
+	previous := time.Duration(0)
+	for attempts := 0; attempts < 20; attempts++ {
+		backoff := deprovisionBackoff(req, attempts)
+		assert.True(t, backoff >= previous, "expected backoff to not decrease")
+		assert.True(t, backoff <= maxDeprovisionBackoff*5/4, "expected backoff to be capped")
+		previous = backoff
+	}
+	assert.True(t, deprovisionBackoff(req, 0) >= minDeprovisionBackoff, "expected minimum backoff")
+}
+
 func validateNoJobExists(t *testing.T, c client.Client) {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
@@ -344,7 +542,7 @@ func validateNoJobExists(t *testing.T, c client.Client) {
 	}
 }
 
-func validateJobExists(t *testing.T, c client.Client) {
+func validateJobExists(t *testing.T, c client.Client) *batchv1.Job {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
 	if err != nil {
@@ -354,6 +552,7 @@ func validateJobExists(t *testing.T, c client.Client) {
 	require.NotNil(t, job, "expected job")
 	assert.Equal(t, testClusterDeprovision().Name, job.Labels[constants.ClusterDeprovisionNameLabel], "incorrect cluster deprovision name label")
 	assert.Equal(t, constants.JobTypeDeprovision, job.Labels[constants.JobTypeLabel], "incorrect job type label")
+	return job
 }
 
 func validateNotCompleted(t *testing.T, c client.Client) {
@@ -396,4 +595,8 @@ func validateCompleted(t *testing.T, c client.Client) {
 	if !req.Status.Completed {
 		t.Errorf("request is expected to be in completed state")
 	}
+	cond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.ReadyClusterDeprovisionCondition)
+	if assert.NotNil(t, cond, "expected Ready condition") {
+		assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected Ready condition status")
+	}
 }
@@ -0,0 +1,138 @@
+package clusteroperationlog
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterOperationLogControllerName
+
+	defaultRetention = 90 * 24 * time.Hour
+)
+
+// Add creates a new ClusterOperationLog controller, and the recorder which creates the ClusterOperationLogs, and adds
+// them to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(newRecorder(r.Client, mgr.GetCache(), queueRateLimiter, logger)); err != nil {
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterOperationLog
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileClusterOperationLog, error) {
+	logger := log.WithField("controller", ControllerName)
+	retention := defaultRetention
+	if envRetention := os.Getenv(constants.OperationLogRetentionEnvVar); len(envRetention) > 0 {
+		var err error
+		retention, err = time.ParseDuration(envRetention)
+		if err != nil {
+			logger.WithError(err).WithField("retention", envRetention).Errorf("unable to parse %s", constants.OperationLogRetentionEnvVar)
+			return nil, err
+		}
+	}
+	logger.WithField("retention", retention).Info("Operation log retention set")
+	return &ReconcileClusterOperationLog{
+		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:    logger,
+		retention: retention,
+	}, nil
+}
+
+// AddToManager adds a new Controller to the controller manager
+func AddToManager(mgr manager.Manager, r *ReconcileClusterOperationLog, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusteroperationlog-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterOperationLogs
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterOperationLog{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterOperationLog{}
+
+// ReconcileClusterOperationLog reconciles a ClusterOperationLog object to delete it once it has expired
+type ReconcileClusterOperationLog struct {
+	client.Client
+	logger    log.FieldLogger
+	retention time.Duration
+}
+
+// Reconcile deletes a ClusterOperationLog once the operation it records is older than the retention period. Until
+// then, the ClusterOperationLog is requeued for when it expires.
+func (r *ReconcileClusterOperationLog) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterOperationLog", request.NamespacedName)
+	logger.Debug("reconciling cluster operation log")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	opLog := &hivev1.ClusterOperationLog{}
+	if err := r.Get(context.Background(), request.NamespacedName, opLog); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster operation log not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster operation log")
+		return reconcile.Result{}, err
+	}
+	if opLog.DeletionTimestamp != nil {
+		logger.Debug("cluster operation log is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	// Fall back to the creation time for logs without an operation time so that they do not expire immediately.
+	operationTime := opLog.Spec.Time.Time
+	if operationTime.IsZero() {
+		operationTime = opLog.CreationTimestamp.Time
+	}
+	if remaining := r.retention - time.Since(operationTime); remaining > 0 {
+		logger.WithField("remaining", remaining).Debug("cluster operation log has not expired")
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	logger.Info("deleting expired cluster operation log")
+	if err := r.Delete(context.Background(), opLog); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster operation log")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
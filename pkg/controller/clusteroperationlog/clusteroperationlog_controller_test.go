@@ -0,0 +1,86 @@
+package clusteroperationlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const testLogName = "test-log"
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileClusterOperationLog(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name          string
+		age           time.Duration
+		expectDeleted bool
+	}{
+		{
+			name: "recent",
+			age:  time.Hour,
+		},
+		{
+			name:          "expired",
+			age:           48 * time.Hour,
+			expectDeleted: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opLog := &hivev1.ClusterOperationLog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testLogName,
+				},
+				Spec: hivev1.ClusterOperationLogSpec{
+					ClusterDeploymentRef: hivev1.ClusterOperationLogClusterDeploymentReference{
+						Namespace: "test-namespace",
+						Name:      "test-cluster-deployment",
+					},
+					Operation: hivev1.ClusterOperationDelete,
+					Manager:   "kubectl",
+					Time:      metav1.NewTime(time.Now().Add(-tc.age)),
+				},
+			}
+			fakeClient := fake.NewFakeClient(opLog)
+			r := &ReconcileClusterOperationLog{
+				Client:    fakeClient,
+				logger:    log.WithField("controller", ControllerName),
+				retention: 24 * time.Hour,
+			}
+
+			key := types.NamespacedName{Name: testLogName}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			err = fakeClient.Get(context.Background(), key, &hivev1.ClusterOperationLog{})
+			if tc.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected cluster operation log to be deleted")
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+				return
+			}
+			require.NoError(t, err, "expected cluster operation log to exist")
+			remaining := 24*time.Hour - tc.age
+			assert.InDelta(t, remaining.Seconds(), result.RequeueAfter.Seconds(), 60, "unexpected requeue after")
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package clusteroperationlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kcache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/operationlog"
+)
+
+// recorder records the destructive operations performed on ClusterDeployments in ClusterOperationLogs. The operations
+// are found by diffing the objects in the watch events of the ClusterDeployments and ClusterDeprovisions, so only the
+// changes that have been persisted by the API server are recorded. The field manager and time of an operation are
+// taken from the managed fields of the object, and the user who changed the power state from the annotation set by the
+// mutating admission webhook. The deletions are also recorded by the validating admission webhook with the user who
+// requested them.
+//
+// The names of the ClusterOperationLogs are derived from the objects they record, so that an operation that is
+// observed more than once, such as after a restart, is only recorded once. A hibernation is only observed as the change
+// of the power state in an update event, so hibernations requested while the controller is not running are not
+// recorded.
+type recorder struct {
+	client client.Client
+	cache  cache.Cache
+	queue  workqueue.RateLimitingInterface
+	logger log.FieldLogger
+}
+
+func newRecorder(c client.Client, informers cache.Cache, rateLimiter workqueue.RateLimiter, logger log.FieldLogger) *recorder {
+	return &recorder{
+		client: c,
+		cache:  informers,
+		queue:  workqueue.NewNamedRateLimitingQueue(rateLimiter, "clusteroperationlog-recorder"),
+		logger: logger,
+	}
+}
+
+// Start adds the event handlers to the informers of the ClusterDeployments and ClusterDeprovisions and creates the
+// ClusterOperationLogs for the operations they find until the stop channel is closed.
+func (r *recorder) Start(stop <-chan struct{}) error {
+	defer r.queue.ShutDown()
+
+	cdInformer, err := r.cache.GetInformer(context.Background(), &hivev1.ClusterDeployment{})
+	if err != nil {
+		return err
+	}
+	cdInformer.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+		AddFunc:    r.clusterDeploymentAdded,
+		UpdateFunc: r.clusterDeploymentUpdated,
+	})
+	deprovisionInformer, err := r.cache.GetInformer(context.Background(), &hivev1.ClusterDeprovision{})
+	if err != nil {
+		return err
+	}
+	deprovisionInformer.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+		AddFunc: r.clusterDeprovisionAdded,
+	})
+
+	go func() {
+		for r.processNextItem() {
+		}
+	}()
+	<-stop
+	return nil
+}
+
+// clusterDeploymentAdded records the deletion of ClusterDeployments which were deleted before they were first observed.
+func (r *recorder) clusterDeploymentAdded(obj interface{}) {
+	cd, ok := obj.(*hivev1.ClusterDeployment)
+	if !ok || cd.DeletionTimestamp == nil {
+		return
+	}
+	r.queue.Add(deleteLog(cd))
+}
+
+func (r *recorder) clusterDeploymentUpdated(oldObj, newObj interface{}) {
+	oldCD, ok := oldObj.(*hivev1.ClusterDeployment)
+	if !ok {
+		return
+	}
+	cd, ok := newObj.(*hivev1.ClusterDeployment)
+	if !ok {
+		return
+	}
+	if oldCD.DeletionTimestamp == nil && cd.DeletionTimestamp != nil {
+		r.queue.Add(deleteLog(cd))
+	}
+	if oldCD.Spec.PowerState != hivev1.HibernatingClusterPowerState && cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		r.queue.Add(hibernateLog(oldCD, cd))
+	}
+}
+
+func (r *recorder) clusterDeprovisionAdded(obj interface{}) {
+	deprovision, ok := obj.(*hivev1.ClusterDeprovision)
+	if !ok {
+		return
+	}
+	r.queue.Add(deprovisionLog(deprovision))
+}
+
+// processNextItem creates the next queued ClusterOperationLog. It returns false once the queue has been shut down.
+func (r *recorder) processNextItem() bool {
+	obj, shutdown := r.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.queue.Done(obj)
+	opLog, ok := obj.(*hivev1.ClusterOperationLog)
+	if !ok {
+		r.logger.WithField("obj", obj).Error("queued object is not a cluster operation log")
+		r.queue.Forget(obj)
+		return true
+	}
+	logger := r.logger.WithField("clusterOperationLog", opLog.Name)
+	switch err := r.client.Create(context.Background(), opLog.DeepCopy()); {
+	case apierrors.IsAlreadyExists(err):
+		logger.Debug("cluster operation already recorded")
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create cluster operation log")
+		r.queue.AddRateLimited(obj)
+		return true
+	default:
+		logger.WithField("clusterOperation", opLog.Spec.Operation).Info("recorded cluster operation")
+	}
+	r.queue.Forget(obj)
+	return true
+}
+
+func deleteLog(cd *hivev1.ClusterDeployment) *hivev1.ClusterOperationLog {
+	// The user who deleted the ClusterDeployment is recorded by the admission webhook, which creates the same
+	// ClusterOperationLog when it admits the deletion.
+	return operationlog.Delete(cd, *cd.DeletionTimestamp)
+}
+
+func hibernateLog(oldCD, cd *hivev1.ClusterDeployment) *hivev1.ClusterOperationLog {
+	// The resource version identifies the update which changed the power state.
+	opLog := operationlog.New(cd.Namespace, cd.Name, hivev1.ClusterOperationHibernate, string(cd.UID)+"/"+cd.ResourceVersion)
+	opLog.Spec.Manager, opLog.Spec.Time = fieldManager(cd, "spec", "powerState")
+	opLog.Spec.User = cd.Annotations[constants.PowerStateChangedByAnnotation]
+	if cd.Spec.ClusterMetadata != nil {
+		opLog.Spec.ClusterDeploymentRef.InfraID = cd.Spec.ClusterMetadata.InfraID
+	}
+	oldPowerState := oldCD.Spec.PowerState
+	if oldPowerState == "" {
+		oldPowerState = hivev1.RunningClusterPowerState
+	}
+	opLog.Spec.Details = fmt.Sprintf("power state changed from %s to %s", oldPowerState, cd.Spec.PowerState)
+	return opLog
+}
+
+func deprovisionLog(deprovision *hivev1.ClusterDeprovision) *hivev1.ClusterOperationLog {
+	// The ClusterDeprovision of a ClusterDeployment has the name of the ClusterDeployment.
+	opLog := operationlog.New(deprovision.Namespace, deprovision.Name, hivev1.ClusterOperationDeprovision, string(deprovision.UID))
+	opLog.Spec.Manager, _ = fieldManager(deprovision, "spec", "infraID")
+	opLog.Spec.Time = deprovision.CreationTimestamp
+	opLog.Spec.ClusterDeploymentRef.InfraID = deprovision.Spec.InfraID
+	opLog.Spec.Details = fmt.Sprintf("deprovision of cluster with infra ID %s started", deprovision.Spec.InfraID)
+	return opLog
+}
+
+// fieldManager returns the manager and time of the most recent managed fields entry of the object which owns the field
+// at the path. The current time is returned when no entry owns the field.
+func fieldManager(obj metav1.Object, path ...string) (string, metav1.Time) {
+	var manager string
+	var latest *metav1.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.FieldsV1 == nil || !ownsField(entry.FieldsV1.Raw, path) {
+			continue
+		}
+		if latest == nil || (entry.Time != nil && latest.Before(entry.Time)) {
+			manager, latest = entry.Manager, entry.Time
+		}
+	}
+	if latest == nil {
+		return manager, metav1.Now()
+	}
+	return manager, *latest
+}
+
+// ownsField returns true if the serialized field set contains the field at the path.
+func ownsField(fields []byte, path []string) bool {
+	var set map[string]interface{}
+	if err := json.Unmarshal(fields, &set); err != nil {
+		return false
+	}
+	for _, name := range path {
+		next, ok := set["f:"+name].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		set = next
+	}
+	return true
+}
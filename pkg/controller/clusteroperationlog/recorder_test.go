@@ -0,0 +1,213 @@
+package clusteroperationlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testCDName    = "test-cluster"
+)
+
+func testClusterDeployment(opts ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            testCDName,
+			UID:             "test-uid",
+			ResourceVersion: "1",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "test-infra-id"},
+		},
+	}
+	for _, opt := range opts {
+		opt(cd)
+	}
+	return cd
+}
+
+func withPowerState(powerState hivev1.ClusterPowerState, manager string, managerTime metav1.Time) func(*hivev1.ClusterDeployment) {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.PowerState = powerState
+		cd.Annotations = map[string]string{constants.PowerStateChangedByAnnotation: "test-user"}
+		cd.ResourceVersion = "2"
+		cd.ManagedFields = []metav1.ManagedFieldsEntry{
+			{
+				Manager:  "manager",
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:clusterMetadata":{}}}`)},
+				Time:     &managerTime,
+			},
+			{
+				Manager:  manager,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:powerState":{}}}`)},
+				Time:     &managerTime,
+			},
+		}
+	}
+}
+
+func withDeletionTimestamp(deletionTime metav1.Time) func(*hivev1.ClusterDeployment) {
+	return func(cd *hivev1.ClusterDeployment) { cd.DeletionTimestamp = &deletionTime }
+}
+
+func TestRecorder(t *testing.T) {
+	operationTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	deprovision := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         testNamespace,
+			Name:              testCDName,
+			UID:               "test-deprovision-uid",
+			CreationTimestamp: operationTime,
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:  "manager",
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{".":{},"f:infraID":{}}}`)},
+				Time:     &operationTime,
+			}},
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{InfraID: "test-infra-id"},
+	}
+
+	cases := []struct {
+		name              string
+		record            func(r *recorder)
+		expectedOperation hivev1.ClusterOperation
+		expectedManager   string
+		expectedUser      string
+		expectedDetails   string
+	}{
+		{
+			name: "delete",
+			record: func(r *recorder) {
+				r.clusterDeploymentUpdated(testClusterDeployment(), testClusterDeployment(withDeletionTimestamp(operationTime)))
+			},
+			expectedOperation: hivev1.ClusterOperationDelete,
+			expectedDetails:   "ClusterDeployment deleted",
+		},
+		{
+			name: "delete with preserve on delete",
+			record: func(r *recorder) {
+				r.clusterDeploymentUpdated(testClusterDeployment(), testClusterDeployment(withDeletionTimestamp(operationTime), func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.PreserveOnDelete = true
+				}))
+			},
+			expectedOperation: hivev1.ClusterOperationDelete,
+			expectedDetails:   "ClusterDeployment deleted; the cluster is not deprovisioned because preserveOnDelete is set",
+		},
+		{
+			name: "deleted before observed",
+			record: func(r *recorder) {
+				r.clusterDeploymentAdded(testClusterDeployment(withDeletionTimestamp(operationTime)))
+			},
+			expectedOperation: hivev1.ClusterOperationDelete,
+			expectedDetails:   "ClusterDeployment deleted",
+		},
+		{
+			name: "update when already deleting",
+			record: func(r *recorder) {
+				r.clusterDeploymentUpdated(testClusterDeployment(withDeletionTimestamp(operationTime)), testClusterDeployment(withDeletionTimestamp(operationTime)))
+			},
+		},
+		{
+			name: "not deleted",
+			record: func(r *recorder) {
+				r.clusterDeploymentAdded(testClusterDeployment())
+			},
+		},
+		{
+			name: "hibernate",
+			record: func(r *recorder) {
+				r.clusterDeploymentUpdated(testClusterDeployment(), testClusterDeployment(withPowerState(hivev1.HibernatingClusterPowerState, "kubectl-patch", operationTime)))
+			},
+			expectedOperation: hivev1.ClusterOperationHibernate,
+			expectedManager:   "kubectl-patch",
+			expectedUser:      "test-user",
+			expectedDetails:   "power state changed from Running to Hibernating",
+		},
+		{
+			name: "hibernate observed twice",
+			record: func(r *recorder) {
+				cd := testClusterDeployment(withPowerState(hivev1.HibernatingClusterPowerState, "kubectl-patch", operationTime))
+				r.clusterDeploymentUpdated(testClusterDeployment(), cd)
+				r.clusterDeploymentUpdated(testClusterDeployment(), cd)
+			},
+			expectedOperation: hivev1.ClusterOperationHibernate,
+			expectedManager:   "kubectl-patch",
+			expectedUser:      "test-user",
+			expectedDetails:   "power state changed from Running to Hibernating",
+		},
+		{
+			name: "already hibernating",
+			record: func(r *recorder) {
+				cd := testClusterDeployment(withPowerState(hivev1.HibernatingClusterPowerState, "kubectl-patch", operationTime))
+				r.clusterDeploymentUpdated(cd, cd)
+			},
+		},
+		{
+			name: "resume",
+			record: func(r *recorder) {
+				r.clusterDeploymentUpdated(
+					testClusterDeployment(withPowerState(hivev1.HibernatingClusterPowerState, "kubectl-patch", operationTime)),
+					testClusterDeployment(withPowerState(hivev1.RunningClusterPowerState, "kubectl-patch", operationTime)),
+				)
+			},
+		},
+		{
+			name: "deprovision",
+			record: func(r *recorder) {
+				r.clusterDeprovisionAdded(deprovision)
+			},
+			expectedOperation: hivev1.ClusterOperationDeprovision,
+			expectedManager:   "manager",
+			expectedDetails:   "deprovision of cluster with infra ID test-infra-id started",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			fakeClient := fake.NewFakeClientWithScheme(scheme)
+			r := newRecorder(fakeClient, nil, workqueue.DefaultControllerRateLimiter(), log.WithField("controller", ControllerName))
+			defer r.queue.ShutDown()
+
+			tc.record(r)
+			for r.queue.Len() > 0 {
+				require.True(t, r.processNextItem(), "unexpected queue shutdown")
+			}
+
+			opLogs := &hivev1.ClusterOperationLogList{}
+			require.NoError(t, fakeClient.List(context.Background(), opLogs), "could not list cluster operation logs")
+			if tc.expectedOperation == "" {
+				assert.Empty(t, opLogs.Items, "expected no cluster operation logs")
+				return
+			}
+			require.Len(t, opLogs.Items, 1, "expected a single cluster operation log")
+			opLog := opLogs.Items[0]
+			assert.Equal(t, tc.expectedOperation, opLog.Spec.Operation, "unexpected operation")
+			assert.Equal(t, tc.expectedManager, opLog.Spec.Manager, "unexpected manager")
+			assert.Equal(t, tc.expectedUser, opLog.Spec.User, "unexpected user")
+			assert.Equal(t, tc.expectedDetails, opLog.Spec.Details, "unexpected details")
+			assert.Equal(t, hivev1.ClusterOperationLogClusterDeploymentReference{
+				Namespace: testNamespace,
+				Name:      testCDName,
+				InfraID:   "test-infra-id",
+			}, opLog.Spec.ClusterDeploymentRef, "unexpected cluster deployment reference")
+			assert.True(t, operationTime.Equal(&opLog.Spec.Time), "unexpected operation time %v", opLog.Spec.Time)
+		})
+	}
+}
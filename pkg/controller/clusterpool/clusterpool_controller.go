@@ -42,6 +42,7 @@ const (
 	credentialsSecretDependent      = "credentials secret"
 	clusterPoolAdminRoleName        = "hive-cluster-pool-admin"
 	clusterPoolAdminRoleBindingName = "hive-cluster-pool-admin-binding"
+	clusterPoolConsumerRoleName     = "hive-cluster-pool-consumer"
 	icSecretDependent               = "install config template secret"
 )
 
@@ -76,7 +77,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterpool-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -126,6 +127,17 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the consumer RoleBindings owned by the ClusterPool
+	if err := c.Watch(
+		&source.Kind{Type: &rbacv1.RoleBinding{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterPool{},
+		},
+	); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive-cluster-pool-admin-binding RoleBinding
 	if err := c.Watch(
 		&source.Kind{Type: &rbacv1.RoleBinding{}},
@@ -280,6 +292,15 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 	origStatus := clp.Status.DeepCopy()
 	clp.Status.Size = int32(len(installingCDs) + len(readyCDs))
 	clp.Status.Ready = int32(len(readyCDs))
+	readyStatus, readyReason, readyMessage := poolReadyCondition(clp)
+	clp.Status.Conditions, _ = controllerutils.SetClusterPoolConditionWithChangeCheck(
+		clp.Status.Conditions,
+		hivev1.ClusterPoolReadyCondition,
+		readyStatus,
+		readyReason,
+		readyMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
@@ -367,6 +388,11 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if err := r.reconcileConsumerRBAC(clp, logger); err != nil {
+		log.WithError(err).Error("error reconciling consumer RBAC")
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -441,6 +467,54 @@ func (r *ReconcileClusterPool) reconcileRBAC(
 	return nil
 }
 
+// reconcileConsumerRBAC binds the consumer groups of the pool to the hive-cluster-pool-consumer ClusterRole in the
+// namespace of the pool. The RoleBinding is owned by the pool so that it is removed along with the pool.
+func (r *ReconcileClusterPool) reconcileConsumerRBAC(clp *hivev1.ClusterPool, logger log.FieldLogger) error {
+	name := consumerRoleBindingName(clp)
+	if len(clp.Spec.ConsumerGroups) == 0 {
+		rb := &rbacv1.RoleBinding{}
+		switch err := r.Get(context.Background(), client.ObjectKey{Namespace: clp.Namespace, Name: name}, rb); {
+		case apierrors.IsNotFound(err):
+			return nil
+		case err != nil:
+			return errors.Wrap(err, "could not get consumer rolebinding")
+		case !metav1.IsControlledBy(rb, clp):
+			return nil
+		}
+		logger.WithField("rolebinding", name).Info("deleting consumer rolebinding")
+		if err := r.Delete(context.Background(), rb); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "could not delete consumer rolebinding")
+		}
+		return nil
+	}
+	subjects := make([]rbacv1.Subject, len(clp.Spec.ConsumerGroups))
+	for i, group := range clp.Spec.ConsumerGroups {
+		subjects[i] = rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.GroupKind,
+			Name:     group,
+		}
+	}
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       clp.Namespace,
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(clp, controllerKind)},
+		},
+		Subjects: subjects,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterPoolConsumerRoleName,
+		},
+	}
+	return r.applyRoleBinding(rb, &rbacv1.RoleBinding{}, logger)
+}
+
+func consumerRoleBindingName(clp *hivev1.ClusterPool) string {
+	return apihelpers.GetResourceName(clp.Name, "consumers")
+}
+
 func (r *ReconcileClusterPool) applyRoleBinding(desired, observed *rbacv1.RoleBinding, logger log.FieldLogger) error {
 	key := client.ObjectKey{Namespace: desired.GetNamespace(), Name: desired.GetName()}
 	logger = logger.WithField("rolebinding", key)
@@ -541,17 +615,28 @@ func (r *ReconcileClusterPool) createCluster(
 	}
 	logger.WithField("cluster", ns.Name).Info("Creating new cluster")
 
+	// Label the cluster with its pool so that SelectorSyncSets and SelectorSyncIdentityProviders can select the
+	// clusters of the pool, and be applied before the clusters are claimed.
+	labels := map[string]string{}
+	for k, v := range clp.Spec.Labels {
+		labels[k] = v
+	}
+	labels[constants.ClusterPoolNameLabel] = clp.Name
+	labels[constants.ClusterPoolNamespaceLabel] = clp.Namespace
+
 	// We will use this unique random namespace name for our cluster name.
 	builder := &clusterresource.Builder{
 		Name:                  ns.Name,
 		Namespace:             ns.Name,
 		BaseDomain:            clp.Spec.BaseDomain,
+		ManageDNS:             clp.Spec.ManageDNS,
+		ManagedSubdomain:      clp.Spec.ManageDNS,
 		ImageSet:              clp.Spec.ImageSetRef.Name,
 		WorkerNodesCount:      int64(3),
 		MachineNetwork:        "10.0.0.0/16",
 		PullSecret:            pullSecret,
 		CloudBuilder:          cloudBuilder,
-		Labels:                clp.Spec.Labels,
+		Labels:                labels,
 		InstallConfigTemplate: installConfigTemplate,
 		SkipMachinePools:      clp.Spec.SkipMachinePools,
 	}
@@ -711,6 +796,18 @@ func (r *ReconcileClusterPool) getCredentialsSecret(pool *hivev1.ClusterPool, se
 	return credsSecret, nil
 }
 
+// poolReadyCondition returns the status, reason and message of the Ready condition of the pool, which is true when the
+// pool has as many clusters ready to be claimed as its size.
+func poolReadyCondition(pool *hivev1.ClusterPool) (corev1.ConditionStatus, string, string) {
+	if cond := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return corev1.ConditionFalse, "MissingDependencies", cond.Message
+	}
+	if pool.Status.Ready < pool.Spec.Size {
+		return corev1.ConditionFalse, "Filling", fmt.Sprintf("%d of %d clusters are ready to be claimed", pool.Status.Ready, pool.Spec.Size)
+	}
+	return corev1.ConditionTrue, "Full", fmt.Sprintf("%d clusters are ready to be claimed", pool.Status.Ready)
+}
+
 func (r *ReconcileClusterPool) setMissingDependenciesCondition(pool *hivev1.ClusterPool, err error, logger log.FieldLogger) error {
 	status := corev1.ConditionFalse
 	reason := "Verified"
@@ -831,6 +928,7 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 		}
 		cloudBuilder := clusterresource.NewAWSCloudBuilderFromSecret(credsSecret)
 		cloudBuilder.Region = platform.AWS.Region
+		cloudBuilder.FallbackRegions = platform.AWS.FallbackRegions
 		return cloudBuilder, nil
 	case platform.GCP != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.GCP.CredentialsSecretRef.Name, logger)
@@ -843,6 +941,7 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 			return nil, err
 		}
 		cloudBuilder.Region = platform.GCP.Region
+		cloudBuilder.FallbackRegions = platform.GCP.FallbackRegions
 		return cloudBuilder, nil
 	case platform.Azure != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.Azure.CredentialsSecretRef.Name, logger)
@@ -852,6 +951,7 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 		cloudBuilder := clusterresource.NewAzureCloudBuilderFromSecret(credsSecret)
 		cloudBuilder.BaseDomainResourceGroupName = platform.Azure.BaseDomainResourceGroupName
 		cloudBuilder.Region = platform.Azure.Region
+		cloudBuilder.FallbackRegions = platform.Azure.FallbackRegions
 		return cloudBuilder, nil
 	// TODO: OpenStack, VMware, and Ovirt.
 	default:
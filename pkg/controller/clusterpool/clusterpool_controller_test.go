@@ -16,6 +16,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -83,6 +84,7 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectManagedSubdomains            bool              // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
 	}{
 		{
 			name: "create all clusters",
@@ -92,7 +94,19 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedTotalClusters: 5,
 			expectedObservedSize:  0,
 			expectedObservedReady: 0,
-			expectedLabels:        map[string]string{"foo": "bar"},
+			expectedLabels: map[string]string{
+				"foo":                               "bar",
+				constants.ClusterPoolNameLabel:      testLeasePoolName,
+				constants.ClusterPoolNamespaceLabel: testNamespace,
+			},
+		},
+		{
+			name: "create clusters with managed DNS",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithManageDNS()),
+			},
+			expectedTotalClusters:   2,
+			expectManagedSubdomains: true,
 		},
 		{
 			name: "scale up",
@@ -930,6 +944,10 @@ func TestReconcileClusterPool(t *testing.T) {
 						assert.Equal(t, v, cd.Labels[k])
 					}
 				}
+				if test.expectManagedSubdomains {
+					assert.True(t, cd.Spec.ManageDNS, "expected cluster to have managed DNS")
+					assert.Equal(t, cd.Spec.ClusterName+".test-domain", cd.Spec.BaseDomain, "expected base domain to be a subdomain named after the cluster")
+				}
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -1734,3 +1752,143 @@ func TestReconcileRBAC(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileConsumerRBAC(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+
+	pool := &hivev1.ClusterPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testLeasePoolName,
+			Namespace: testNamespace,
+			UID:       types.UID("test-pool-uid"),
+		},
+	}
+	consumerBinding := func(groups ...string) *rbacv1.RoleBinding {
+		rb := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       testNamespace,
+				Name:            consumerRoleBindingName(pool),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(pool, controllerKind)},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     clusterPoolConsumerRoleName,
+			},
+		}
+		for _, group := range groups {
+			rb.Subjects = append(rb.Subjects, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: group})
+		}
+		return rb
+	}
+	unownedBinding := consumerBinding("other-group")
+	unownedBinding.OwnerReferences = nil
+
+	tests := []struct {
+		name            string
+		consumerGroups  []string
+		existing        []runtime.Object
+		expectedBinding *rbacv1.RoleBinding
+	}{{
+		name: "no consumer groups",
+	}, {
+		name:            "create binding",
+		consumerGroups:  []string{"group-1", "group-2"},
+		expectedBinding: consumerBinding("group-1", "group-2"),
+	}, {
+		name:            "update binding",
+		consumerGroups:  []string{"group-1", "group-2"},
+		existing:        []runtime.Object{consumerBinding("group-1")},
+		expectedBinding: consumerBinding("group-1", "group-2"),
+	}, {
+		name:     "delete binding",
+		existing: []runtime.Object{consumerBinding("group-1")},
+	}, {
+		name:            "keep binding not owned by pool",
+		existing:        []runtime.Object{unownedBinding},
+		expectedBinding: unownedBinding,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			logger := log.New()
+			logger.SetLevel(log.DebugLevel)
+			rcp := &ReconcileClusterPool{
+				Client:       fakeClient,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+
+			clp := pool.DeepCopy()
+			clp.Spec.ConsumerGroups = test.consumerGroups
+			require.NoError(t, rcp.reconcileConsumerRBAC(clp, logger))
+
+			rb := &rbacv1.RoleBinding{}
+			err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: consumerRoleBindingName(pool)}, rb)
+			if test.expectedBinding == nil {
+				assert.True(t, apierrors.IsNotFound(err), "expected no consumer rolebinding")
+				return
+			}
+			require.NoError(t, err)
+			rb.TypeMeta = metav1.TypeMeta{}
+			rb.ResourceVersion = ""
+			assert.Equal(t, test.expectedBinding, rb)
+		})
+	}
+}
+
+func TestPoolReadyCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int32
+		ready          int32
+		missingDeps    bool
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{{
+		name:           "full",
+		size:           2,
+		ready:          2,
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: "Full",
+	}, {
+		name:           "filling",
+		size:           2,
+		ready:          1,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: "Filling",
+	}, {
+		name:           "empty pool",
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: "Full",
+	}, {
+		name:           "missing dependencies",
+		size:           2,
+		ready:          2,
+		missingDeps:    true,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: "MissingDependencies",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.ClusterPool{
+				Spec:   hivev1.ClusterPoolSpec{Size: test.size},
+				Status: hivev1.ClusterPoolStatus{Ready: test.ready},
+			}
+			if test.missingDeps {
+				pool.Status.Conditions = []hivev1.ClusterPoolCondition{{
+					Type:    hivev1.ClusterPoolMissingDependenciesCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Missing",
+					Message: "missing image set",
+				}}
+			}
+			status, reason, _ := poolReadyCondition(pool)
+			assert.Equal(t, test.expectedStatus, status, "unexpected Ready status")
+			assert.Equal(t, test.expectedReason, reason, "unexpected Ready reason")
+		})
+	}
+}
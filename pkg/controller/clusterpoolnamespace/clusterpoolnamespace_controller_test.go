@@ -42,6 +42,10 @@ func TestReconcileClusterPoolNamespace_Reconcile_Movement(t *testing.T) {
 		testgeneric.WithLabel(constants.ClusterPoolNameLabel, "test-cluster-pool"),
 	)
 
+	clusterNamespaceBuilder := namespaceWithoutLabelBuilder.GenericOptions(
+		testgeneric.WithLabel(constants.ClusterNamespaceLabel, "test-cd"),
+	)
+
 	poolBuilder := testcp.FullBuilder("test-pool-namespace", "test-cluster-pool", scheme).
 		Options(
 			testcp.ForAWS("aws-creds", "us-east-1"),
@@ -165,6 +169,32 @@ func TestReconcileClusterPoolNamespace_Reconcile_Movement(t *testing.T) {
 			expectDeleted:        false,
 			validateRequeueAfter: validateNoRequeueAfter,
 		},
+		{
+			name:                 "cluster namespace without clusterdeployments",
+			namespaceBuilder:     clusterNamespaceBuilder,
+			expectDeleted:        true,
+			validateRequeueAfter: validateNoRequeueAfter,
+		},
+		{
+			name:             "cluster namespace with non-deleted clusterdeployment",
+			namespaceBuilder: clusterNamespaceBuilder,
+			resources: []runtime.Object{
+				testcd.FullBuilder(namespaceName, "test-cd", scheme).Build(),
+			},
+			expectDeleted:        false,
+			validateRequeueAfter: validateNoRequeueAfter,
+		},
+		{
+			name:             "cluster namespace with deleted clusterdeployment",
+			namespaceBuilder: clusterNamespaceBuilder,
+			resources: []runtime.Object{
+				testcd.FullBuilder(namespaceName, "test-cd", scheme).
+					GenericOptions(testgeneric.Deleted()).
+					Build(),
+			},
+			expectDeleted:        false,
+			validateRequeueAfter: validateWaitForCDGoneRequeueAfter,
+		},
 		{
 			name:                 "deleted namespace",
 			namespaceBuilder:     namespaceBuilder.GenericOptions(testgeneric.Deleted()),
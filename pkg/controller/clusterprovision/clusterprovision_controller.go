@@ -102,7 +102,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int,
 
 	// Create a new controller
 	c, err := controller.New("clusterprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -193,11 +193,12 @@ func (r *ReconcileClusterProvision) Reconcile(request reconcile.Request) (reconc
 		return r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, "NoJobReference", "Missing reference to install job", pLog)
 	case hivev1.ClusterProvisionStageComplete:
 		pLog.Debugf("ClusterProvision is %s", instance.Spec.Stage)
-		if instance.Status.JobRef != nil && time.Since(instance.CreationTimestamp.Time) > (24*time.Hour) {
+		retention := controllerutils.GetJobHistory(pLog).CompletedInstallJobRetention
+		if instance.Status.JobRef != nil && time.Since(instance.CreationTimestamp.Time) > retention {
 			return r.deleteInstallJob(instance, pLog)
 		}
-		// installJobDeletionRecheckDelay will be duration between current time and expected install job deletion time (provision creation time + 24 hours)
-		installJobDeletionRecheckDelay := instance.CreationTimestamp.Time.Add(24 * time.Hour).Sub(time.Now())
+		// installJobDeletionRecheckDelay will be duration between current time and expected install job deletion time (provision creation time + retention)
+		installJobDeletionRecheckDelay := instance.CreationTimestamp.Time.Add(retention).Sub(time.Now())
 		return reconcile.Result{RequeueAfter: installJobDeletionRecheckDelay}, nil
 	case hivev1.ClusterProvisionStageFailed:
 		pLog.Debugf("ClusterProvision is %s. Nothing more to do", instance.Spec.Stage)
@@ -470,6 +471,9 @@ func (r *ReconcileClusterProvision) setCondition(
 		message,
 		updateConditionCheck,
 	)
+	if readyStatus, ok := provisionReadyStatus(conditionType, status); ok {
+		instance.Status.Conditions = setReadyCondition(instance.Status.Conditions, readyStatus, reason, message)
+	}
 	if err := r.Status().Update(context.TODO(), instance); err != nil {
 		pLog.WithError(err).Error("cannot update status conditions")
 		return err
@@ -477,6 +481,45 @@ func (r *ReconcileClusterProvision) setCondition(
 	return nil
 }
 
+// provisionReadyStatus returns the status of the Ready condition when the condition is set to the status, and whether
+// the condition determines the Ready condition. The provision is ready once it has completed.
+func provisionReadyStatus(conditionType hivev1.ClusterProvisionConditionType, status corev1.ConditionStatus) (corev1.ConditionStatus, bool) {
+	if status != corev1.ConditionTrue {
+		return "", false
+	}
+	switch conditionType {
+	case hivev1.ClusterProvisionInitializedCondition, hivev1.ClusterProvisionFailedCondition:
+		return corev1.ConditionFalse, true
+	case hivev1.ClusterProvisionCompletedCondition:
+		return corev1.ConditionTrue, true
+	default:
+		return "", false
+	}
+}
+
+// setReadyCondition sets the Ready condition, which is added whatever its status so that it can be waited on.
+func setReadyCondition(conditions []hivev1.ClusterProvisionCondition, status corev1.ConditionStatus, reason, message string) []hivev1.ClusterProvisionCondition {
+	if controllerutils.FindClusterProvisionCondition(conditions, hivev1.ClusterProvisionReadyCondition) == nil {
+		now := metav1.Now()
+		return append(conditions, hivev1.ClusterProvisionCondition{
+			Type:               hivev1.ClusterProvisionReadyCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+	}
+	return controllerutils.SetClusterProvisionCondition(
+		conditions,
+		hivev1.ClusterProvisionReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
 func (r *ReconcileClusterProvision) setStage(instance *hivev1.ClusterProvision, stage hivev1.ClusterProvisionStage, pLog log.FieldLogger) error {
 	instance.Spec.Stage = stage
 	if err := r.Update(context.TODO(), instance); err != nil {
@@ -552,6 +595,22 @@ func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerut
 			},
 			Controlled: false,
 		},
+		{
+			TypeToList: &corev1.SecretList{},
+			LabelSelector: map[string]string{
+				constants.ClusterProvisionNameLabel: owner.GetName(),
+				constants.SecretTypeLabel:           constants.SecretTypeMetadataJSON,
+			},
+			Controlled: false,
+		},
+		{
+			TypeToList: &corev1.SecretList{},
+			LabelSelector: map[string]string{
+				constants.ClusterProvisionNameLabel: owner.GetName(),
+				constants.SecretTypeLabel:           constants.SecretTypeTerraformState,
+			},
+			Controlled: false,
+		},
 	}
 }
 
@@ -289,6 +289,10 @@ func TestClusterProvisionReconcile(t *testing.T) {
 					if assert.NotNil(t, failedCond, "expected to find a Failed condition") {
 						assert.Equal(t, test.expectedFailReason, failedCond.Reason, "unexpected fail reason")
 					}
+					readyCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionReadyCondition)
+					if assert.NotNil(t, readyCond, "expected to find a Ready condition") {
+						assert.Equal(t, corev1.ConditionFalse, readyCond.Status, "unexpected Ready condition status")
+					}
 				} else {
 					assert.Nil(t, failedCond, "expected not to find a Failed condition")
 				}
@@ -385,6 +389,11 @@ func withFailedCondition(reason string) provisionOption {
 				Status: corev1.ConditionTrue,
 				Reason: reason,
 			},
+			hivev1.ClusterProvisionCondition{
+				Type:   hivev1.ClusterProvisionReadyCondition,
+				Status: corev1.ConditionFalse,
+				Reason: reason,
+			},
 		)
 	}
 }
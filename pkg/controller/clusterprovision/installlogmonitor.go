@@ -2,55 +2,75 @@ package clusterprovision
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
-	regexConfigMapName = "install-log-regexes"
-	regexDataEntryName = "regexes"
-	unknownReason      = "UnknownError"
-	logMissingMessage  = "Cluster install failed but installer log was not captured"
-	regexBadMessage    = "Cluster install failed but regex configmap to parse for known reasons could not be used"
-	unknownMessage     = "Cluster install failed but no known errors found in logs"
+	regexConfigMapName           = "install-log-regexes"
+	additionalRegexConfigMapName = "additional-install-log-regexes"
+	regexDataEntryName           = "regexes"
+	unknownReason                = "UnknownError"
+	logMissingMessage            = "Cluster install failed but installer log was not captured"
+	regexBadMessage              = "Cluster install failed but regex configmap to parse for known reasons could not be used"
+	unknownMessage               = "Cluster install failed but no known errors found in logs"
+	stageTimeoutReason           = "StageTimeout"
 )
 
+// stageTimeoutRegex matches the line the install pod appends to the install log when it aborts an install whose stage
+// exceeded its timeout, capturing which stage timed out.
+var stageTimeoutRegex = regexp.MustCompile(regexp.QuoteMeta(constants.InstallStageTimeoutLogMessage) + `([^"]*)`)
+
 // parseInstallLog parses install log to monitor for known issues.
 func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
 	if log == nil {
 		return unknownReason, logMissingMessage
 	}
 
-	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
-	regexCM := &corev1.ConfigMap{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: regexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, regexCM); err != nil {
-		pLog.WithError(err).Errorf("error loading %s configmap", regexConfigMapName)
-		// Even if the error was a transient error in fetching the configmap, we should not block
-		// the continuation of deploying the cluster just so that we can potentially get a
-		// better failure message.
-		return unknownReason, regexBadMessage
+	// Installs aborted by the install pod failed because of the timeout, whatever else is in the log.
+	if match := stageTimeoutRegex.FindStringSubmatch(*log); match != nil {
+		pLog.WithField("reason", stageTimeoutReason).Info("found stage timeout")
+		return stageTimeoutReason, fmt.Sprintf("Install aborted: %s", match[1])
 	}
 
-	regexesRaw, ok := regexCM.Data[regexDataEntryName]
-	if !ok {
-		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+	// Fatal failures take precedence over the regex configmap since they stop further provisions.
+	if fatal := controllerutils.MatchFatalProvisionFailure(*log, pLog); fatal != nil {
+		pLog.WithField("reason", fatal.InstallFailingReason).Info("found fatal install failure string")
+		return fatal.InstallFailingReason, fatal.InstallFailingMessage
 	}
 
-	regexes := []installLogRegex{}
-	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
-		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+	// Rules from the additional regex configmap are owned by the administrators of Hive rather than the operator,
+	// and are scanned before the rules shipped with Hive so that they can refine the known reasons.
+	regexes, err := r.loadInstallLogRegexes(additionalRegexConfigMapName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		pLog.WithError(err).Errorf("error loading %s configmap", additionalRegexConfigMapName)
 	}
 
+	// Load the regex configmap, if we don't have any rules, there's not much point proceeding here.
+	defaultRegexes, err := r.loadInstallLogRegexes(regexConfigMapName)
+	if err != nil {
+		pLog.WithError(err).Errorf("error loading %s configmap", regexConfigMapName)
+		// Even if the error was a transient error in fetching the configmap, we should not block
+		// the continuation of deploying the cluster just so that we can potentially get a
+		// better failure message.
+		if len(regexes) == 0 {
+			return unknownReason, regexBadMessage
+		}
+	}
+	regexes = append(regexes, defaultRegexes...)
+
 	pLog.Info("processing new install log")
 
 	// Log each line separately, this brings all our install logs from many namespaces into
@@ -78,3 +98,23 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 
 	return unknownReason, unknownMessage
 }
+
+// loadInstallLogRegexes loads the install log regexes from the regexes data entry of the named configmap in the
+// hive namespace.
+func (r *ReconcileClusterProvision) loadInstallLogRegexes(name string) ([]installLogRegex, error) {
+	regexCM := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: controllerutils.GetHiveNamespace()}, regexCM); err != nil {
+		return nil, err
+	}
+
+	regexesRaw, ok := regexCM.Data[regexDataEntryName]
+	if !ok {
+		return nil, fmt.Errorf("%s configmap does not have a %q data entry", name, regexDataEntryName)
+	}
+
+	regexes := []installLogRegex{}
+	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal data from %s configmap", name)
+	}
+	return regexes, nil
+}
@@ -1,6 +1,7 @@
 package clusterprovision
 
 import (
+	"os"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,15 +29,19 @@ const (
 	pendingVerificationLog = "blahblah\naws_instance.master.2: Error launching source instance: PendingVerification: Your request for accessing resources in this region is being validated, and you will not be able to launch additional resources in this region until the validation is complete. We will notify you by email once your request has been validated. While normally resolved within minutes, please allow up to 4 hours for this process to complete. If the issue still persists, please let us know by writing to awsa\n\nblahblah"
 	gcpInvalidProjectIDLog = "blahblah\ntime=\"2020-11-13T16:05:07Z\" level=fatal msg=\"failed to fetch Master Machines: failed to load asset \"Install Config\": platform.gcp.project: Invalid value: \"o-6b20f250\": invalid project ID\nblahblah"
 	gcpSSDQUotaLog         = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=error msg=\"Error: Error waiting for instance to create: Quota 'SSD_TOTAL_GB' exceeded. Limit: 500.0 in region asia-northeast2.\nblahblah"
+	invalidCredentialsLog  = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=fatal msg=\"failed to fetch Cluster: failed to fetch dependency of \"Cluster\": failed to generate asset \"Platform Credentials Check\": validate AWS credentials: checking install permissions: error gathering user policy: InvalidClientTokenId: The security token included in the request is invalid.\nblahblah"
+	stageTimeoutLog        = "blahblah\nlevel=error msg=\"Hive aborted the install: the bootstrap stage of the install did not complete within 45m0s\"\nblahblah"
+	unsupportedRegionLog   = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=fatal msg=\"failed to fetch Master Machines: failed to load asset \"Install Config\": platform.aws.region: Unsupported value: \"us-nowhere-1\"\nblahblah"
 )
 
 func TestParseInstallLog(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	tests := []struct {
-		name           string
-		log            *string
-		existing       []runtime.Object
-		expectedReason string
+		name                   string
+		log                    *string
+		existing               []runtime.Object
+		fatalProvisionFailures string
+		expectedReason         string
 	}{
 		{
 			name:           "DNS already exists",
@@ -62,6 +67,37 @@ func TestParseInstallLog(t *testing.T) {
 			existing:       []runtime.Object{buildRegexConfigMap()},
 			expectedReason: "GCPQuotaSSDTotalGBExceeded",
 		},
+		{
+			name:           "fatal failure takes precedence",
+			log:            pointer.StringPtr(dnsAlreadyExistsLog + "\n" + invalidCredentialsLog),
+			existing:       []runtime.Object{buildRegexConfigMap()},
+			expectedReason: "InvalidCredentials",
+		},
+		{
+			name:           "fatal failure without regex configmap",
+			log:            pointer.StringPtr(unsupportedRegionLog),
+			expectedReason: "UnsupportedRegion",
+		},
+		{
+			name:                   "configured fatal failures",
+			log:                    pointer.StringPtr(pendingVerificationLog),
+			existing:               []runtime.Object{buildRegexConfigMap()},
+			fatalProvisionFailures: `[{"name":"PendingVerification","searchRegexStrings":["PendingVerification"],"installFailingReason":"FatalPendingVerification","installFailingMessage":"Account pending verification"}]`,
+			expectedReason:         "FatalPendingVerification",
+		},
+		{
+			name:                   "configured fatal failures replace defaults",
+			log:                    pointer.StringPtr(invalidCredentialsLog),
+			existing:               []runtime.Object{buildRegexConfigMap()},
+			fatalProvisionFailures: `[]`,
+			expectedReason:         unknownReason,
+		},
+		{
+			name:           "stage timeout takes precedence",
+			log:            pointer.StringPtr(dnsAlreadyExistsLog + stageTimeoutLog),
+			existing:       []runtime.Object{buildRegexConfigMap()},
+			expectedReason: stageTimeoutReason,
+		},
 		{
 			name:           "no log",
 			existing:       []runtime.Object{buildRegexConfigMap()},
@@ -143,10 +179,46 @@ func TestParseInstallLog(t *testing.T) {
 			}},
 			expectedReason: "DNSAlreadyExists",
 		},
+		{
+			name:           "additional regexes refine known reasons",
+			log:            pointer.StringPtr(dnsAlreadyExistsLog),
+			existing:       []runtime.Object{buildRegexConfigMap(), buildAdditionalRegexConfigMap()},
+			expectedReason: "APIRecordAlreadyExists",
+		},
+		{
+			name:           "additional regexes without regex configmap",
+			log:            pointer.StringPtr(dnsAlreadyExistsLog),
+			existing:       []runtime.Object{buildAdditionalRegexConfigMap()},
+			expectedReason: "APIRecordAlreadyExists",
+		},
+		{
+			name:           "fall back to regex configmap",
+			log:            pointer.StringPtr(pendingVerificationLog),
+			existing:       []runtime.Object{buildRegexConfigMap(), buildAdditionalRegexConfigMap()},
+			expectedReason: "PendingVerification",
+		},
+		{
+			name: "malformed additional regexes",
+			log:  pointer.StringPtr(dnsAlreadyExistsLog),
+			existing: []runtime.Object{buildRegexConfigMap(), &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      additionalRegexConfigMapName,
+					Namespace: constants.DefaultHiveNamespace,
+				},
+				Data: map[string]string{
+					"regexes": "malformed",
+				},
+			}},
+			expectedReason: "DNSAlreadyExists",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			if test.fatalProvisionFailures != "" {
+				os.Setenv(constants.FatalProvisionFailuresEnvVar, test.fatalProvisionFailures)
+				defer os.Unsetenv(constants.FatalProvisionFailuresEnvVar)
+			}
 			fakeClient := fake.NewFakeClient(test.existing...)
 			r := &ReconcileClusterProvision{
 				Client: fakeClient,
@@ -192,3 +264,21 @@ func buildRegexConfigMap() *corev1.ConfigMap {
 	}
 	return cm
 }
+
+func buildAdditionalRegexConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      additionalRegexConfigMapName,
+			Namespace: constants.DefaultHiveNamespace,
+		},
+		Data: map[string]string{
+			"regexes": `
+- name: APIRecordAlreadyExists
+  searchRegexStrings:
+  - "aws_route53_record.api_external: .*but it already exists"
+  installFailingReason: APIRecordAlreadyExists
+  installFailingMessage: DNS record for the API already exists
+`,
+		},
+	}
+}
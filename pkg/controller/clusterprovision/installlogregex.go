@@ -2,7 +2,7 @@ package clusterprovision
 
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
-// the install-log-regexes ConfigMap.
+// the install-log-regexes ConfigMap, and the optional additional-install-log-regexes ConfigMap.
 type installLogRegex struct {
 	// Name is the name of the regex.
 	Name string `json:"name"`
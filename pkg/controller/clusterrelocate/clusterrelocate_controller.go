@@ -81,7 +81,7 @@ func Add(mgr manager.Manager) error {
 	}
 
 	c, err := controller.New("clusterrelocate-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
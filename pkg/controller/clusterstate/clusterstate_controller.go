@@ -66,7 +66,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	c, err := controller.New("clusterstate-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -114,6 +114,7 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
 			logger.Debug("cluster deployment not found")
+			remoteclient.ForgetServiceAccountTokens(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -130,6 +131,7 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 
 	if !cd.DeletionTimestamp.IsZero() {
 		logger.Debug("ClusterDeployment resource has been deleted")
+		remoteclient.ForgetServiceAccountTokens(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 	if !cd.Spec.Installed {
@@ -158,6 +160,7 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 
 		logger.WithField("derivedObject", st.Name).Debug("Setting label on derived object")
 		st.Labels = k8slabels.AddLabel(st.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+		controllerutils.AddPropagatedLabels(cd, st, logger)
 		if err = controllerutil.SetControllerReference(cd, st, r.scheme); err != nil {
 			logger.WithError(err).Error("error setting controller reference on cluster state")
 			return reconcile.Result{}, err
@@ -205,10 +208,10 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 		logger.WithError(err).Error("failed to list target cluster operators")
 		return reconcile.Result{}, err
 	}
-	return r.syncOperatorStates(clusterOperators.Items, st, logger)
+	return r.syncOperatorStates(clusterOperators.Items, st, cd, logger)
 }
 
-func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterOperator, st *hivev1.ClusterState, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterOperator, st *hivev1.ClusterState, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	operatorStates := make([]hivev1.ClusterOperatorState, len(operators))
 	for i, clusterOperator := range operators {
 		operatorStates[i] = hivev1.ClusterOperatorState{
@@ -216,9 +219,21 @@ func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterO
 			Conditions: clusterOperator.Status.Conditions,
 		}
 	}
-	if operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates) {
-		st.Status.ClusterOperators = operatorStates
+	becameReady := st.Status.ReadyTimestamp == nil && allOperatorsAvailable(operatorStates)
+	if operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates) || becameReady {
 		now := metav1.Now()
+		if becameReady {
+			st.Status.ReadyTimestamp = &now
+			// ClusterStates of clusters which were already ready before the ready timestamp was tracked
+			// are not observed, as the time they took to become ready is not known.
+			if !allOperatorsAvailable(st.Status.ClusterOperators) {
+				provisionToReady := now.Sub(cd.CreationTimestamp.Time)
+				logger.WithField("provisionToReady", provisionToReady).Info("all cluster operators are available")
+				hivemetrics.MetricClusterDeploymentProvisionToReadySeconds.WithLabelValues(
+					hivemetrics.GetClusterDeploymentType(cd)).Observe(provisionToReady.Seconds())
+			}
+		}
+		st.Status.ClusterOperators = operatorStates
 		st.Status.LastUpdated = &now
 		if err := r.updateStatus(r, st); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster operator state")
@@ -232,6 +247,21 @@ func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterO
 	}, nil
 }
 
+// allOperatorsAvailable returns true if there is at least one cluster operator and all of the cluster
+// operators are available.
+func allOperatorsAvailable(operatorStates []hivev1.ClusterOperatorState) bool {
+	if len(operatorStates) == 0 {
+		return false
+	}
+	for _, state := range operatorStates {
+		i := indexOfCondition(state.Conditions, string(configv1.OperatorAvailable))
+		if i < 0 || state.Conditions[i].Status != configv1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
 func operatorStatesChanged(logger log.FieldLogger, existing, updated []hivev1.ClusterOperatorState) bool {
 	changed := false
 	existingNames := sets.NewString()
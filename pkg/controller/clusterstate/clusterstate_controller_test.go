@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +26,7 @@ import (
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -62,6 +64,8 @@ func TestClusterStateReconcile(t *testing.T) {
 		noRemoteCall bool
 		validate     func(*testing.T, client.Client, reconcile.Result)
 		noUpdate     bool
+		// expectReadyObserved is true if the time for the cluster to become ready is expected to be observed
+		expectReadyObserved bool
 	}{
 		{
 			name: "create cluster state",
@@ -87,11 +91,12 @@ func TestClusterStateReconcile(t *testing.T) {
 				st := cs(t, c)
 				validateStatus(t, st.Status, co("a"), co("b"), co("c"))
 			},
+			expectReadyObserved: true,
 		},
 		{
 			name: "steady state",
 			existing: []runtime.Object{
-				testClusterStateWithStatus(co("d"), co("e")),
+				withReadyTimestamp(testClusterStateWithStatus(co("d"), co("e"))),
 				testClusterDeployment(),
 				testKubeconfigSecret(),
 			},
@@ -106,7 +111,7 @@ func TestClusterStateReconcile(t *testing.T) {
 		{
 			name: "changed state",
 			existing: []runtime.Object{
-				testClusterStateWithStatus(co("a"), co("b"), co("c")),
+				withReadyTimestamp(testClusterStateWithStatus(co("a"), co("b"), co("c"))),
 				testClusterDeployment(),
 				testKubeconfigSecret(),
 			},
@@ -116,6 +121,49 @@ func TestClusterStateReconcile(t *testing.T) {
 				validateStatus(t, st.Status, co("a"), co("b"), uco("c"))
 			},
 		},
+		{
+			name: "cluster became ready",
+			existing: []runtime.Object{
+				testClusterStateWithStatus(co("a"), co("b"), uco("c")),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), co("b"), co("c")},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				validateStatus(t, st.Status, co("a"), co("b"), co("c"))
+				assert.NotNil(t, st.Status.ReadyTimestamp, "expected ready timestamp to be set")
+			},
+			expectReadyObserved: true,
+		},
+		{
+			name: "cluster not ready",
+			existing: []runtime.Object{
+				testClusterState(),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), uco("b")},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				validateStatus(t, st.Status, co("a"), uco("b"))
+				assert.Nil(t, st.Status.ReadyTimestamp, "expected ready timestamp to not be set")
+			},
+		},
+		{
+			name: "cluster already ready without ready timestamp",
+			existing: []runtime.Object{
+				testClusterStateWithStatus(co("a"), co("b")),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), co("b")},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				validateStatus(t, st.Status, co("a"), co("b"))
+				assert.NotNil(t, st.Status.ReadyTimestamp, "expected ready timestamp to be set")
+			},
+		},
 		{
 			name: "removed remote co",
 			existing: []runtime.Object{
@@ -159,6 +207,7 @@ func TestClusterStateReconcile(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			hivemetrics.MetricClusterDeploymentProvisionToReadySeconds.Reset()
 			fakeClient := fake.NewFakeClient(test.existing...)
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
@@ -195,6 +244,12 @@ func TestClusterStateReconcile(t *testing.T) {
 			if test.validate != nil {
 				test.validate(t, fakeClient, result)
 			}
+			expectedObservations := 0
+			if test.expectReadyObserved {
+				expectedObservations = 1
+			}
+			assert.Equal(t, expectedObservations, testutil.CollectAndCount(hivemetrics.MetricClusterDeploymentProvisionToReadySeconds),
+				"unexpected provision to ready observations")
 		})
 	}
 }
@@ -219,6 +274,12 @@ func testClusterStateWithStatus(operators ...*configv1.ClusterOperator) *hivev1.
 	return cs
 }
 
+func withReadyTimestamp(cs *hivev1.ClusterState) *hivev1.ClusterState {
+	now := metav1.Now()
+	cs.Status.ReadyTimestamp = &now
+	return cs
+}
+
 func testClusterDeployment() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2,14 +2,17 @@ package clustersync
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"os"
+	"path"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -50,6 +54,9 @@ const (
 	defaultReapplyInterval = 2 * time.Hour
 	reapplyIntervalEnvKey  = "SYNCSET_REAPPLY_INTERVAL"
 	reapplyIntervalJitter  = 0.1
+	staleThresholdEnvKey   = "SYNCSET_STALE_THRESHOLD"
+	applyWorkersEnvKey     = "SYNCSET_APPLY_WORKERS"
+	defaultApplyWorkers    = 10
 	secretAPIVersion       = "v1"
 	secretKind             = "Secret"
 	labelApply             = "apply"
@@ -57,9 +64,17 @@ const (
 	labelCreateOnly        = "createOnly"
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
+	metricResultSkipped    = "skipped"
 	stsName                = "hive-clustersync"
 )
 
+// allowedResourcesFromURLsEnvKey is the comma-separated list of the URL prefixes from which the ResourcesFrom of
+// syncsets may download resources.
+const allowedResourcesFromURLsEnvKey = "SYNCSET_RESOURCES_FROM_ALLOWED_URLS"
+
+// defaultStaleThresholdReapplyIntervals is the default stale threshold, in reapply intervals.
+const defaultStaleThresholdReapplyIntervals = 3
+
 var (
 	metricTimeToApplySyncSet = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -94,6 +109,13 @@ var (
 		[]string{"type", "result"},
 	)
 
+	metricResourcesDrifted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_syncset_resources_drifted_total",
+		Help: "Counter incremented for each resource found to have drifted from a syncset that is not enforced and that had not drifted when last checked, labeled by type of syncset and by SelectorSyncSet name or SyncSet metrics group.",
+	},
+		[]string{"type", "name"},
+	)
+
 	metricTimeToApplySyncSets = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "hive_clustersync_first_success_duration_seconds",
@@ -108,6 +130,7 @@ func init() {
 	metrics.Registry.MustRegister(metricTimeToApplySelectorSyncSet)
 	metrics.Registry.MustRegister(metricResourcesApplied)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSetResource)
+	metrics.Registry.MustRegister(metricResourcesDrifted)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSets)
 }
 
@@ -126,6 +149,19 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	watchRemoteCluster, err := controllerutils.GetWatchRemoteCluster(ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get whether to watch remote clusters")
+		return err
+	}
+	if watchRemoteCluster {
+		watcher := remoteclient.NewWatcher(ControllerName)
+		if err := mgr.Add(watcher); err != nil {
+			return err
+		}
+		r.remoteWatcher = watcher
+	}
+
 	logger.Debug("Getting HIVE_CLUSTERSYNC_POD_NAME")
 	podname, found := os.LookupEnv("HIVE_CLUSTERSYNC_POD_NAME")
 
@@ -160,12 +196,43 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		}
 	}
 	log.WithField("reapplyInterval", reapplyInterval).Info("Reapply interval set")
+	staleThreshold := defaultStaleThresholdReapplyIntervals * reapplyInterval
+	if envStaleThreshold := os.Getenv(staleThresholdEnvKey); len(envStaleThreshold) > 0 {
+		var err error
+		staleThreshold, err = time.ParseDuration(envStaleThreshold)
+		if err != nil {
+			log.WithError(err).WithField("staleThreshold", envStaleThreshold).Errorf("unable to parse %s", staleThresholdEnvKey)
+			return nil, err
+		}
+	}
+	log.WithField("staleThreshold", staleThreshold).Info("Stale threshold set")
+	applyWorkers := defaultApplyWorkers
+	if envApplyWorkers := os.Getenv(applyWorkersEnvKey); len(envApplyWorkers) > 0 {
+		var err error
+		applyWorkers, err = strconv.Atoi(envApplyWorkers)
+		if err != nil || applyWorkers < 1 {
+			if err == nil {
+				err = errors.New("the number of apply workers must be positive")
+			}
+			log.WithError(err).WithField("applyWorkers", envApplyWorkers).Errorf("unable to parse %s", applyWorkersEnvKey)
+			return nil, err
+		}
+	}
+	log.WithField("applyWorkers", applyWorkers).Info("Apply workers set")
+	var allowedResourcesFromURLs []string
+	if envAllowedURLs := os.Getenv(allowedResourcesFromURLsEnvKey); len(envAllowedURLs) > 0 {
+		allowedResourcesFromURLs = strings.Split(envAllowedURLs, ",")
+	}
+	log.WithField("allowedResourcesFromURLs", allowedResourcesFromURLs).Info("Allowed resources from URLs set")
 	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
 	return &ReconcileClusterSync{
-		Client:                c,
-		logger:                logger,
-		reapplyInterval:       reapplyInterval,
-		resourceHelperBuilder: resourceHelperBuilderFunc,
+		Client:                   c,
+		logger:                   logger,
+		reapplyInterval:          reapplyInterval,
+		staleThreshold:           staleThreshold,
+		applyWorkers:             newWorkerPool(applyWorkers),
+		allowedResourcesFromURLs: allowedResourcesFromURLs,
+		resourceHelperBuilder:    resourceHelperBuilderFunc,
 		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 			return remoteclient.NewBuilder(c, cd, ControllerName)
 		},
@@ -183,7 +250,7 @@ func resourceHelperBuilderFunc(restConfig *rest.Config, fakeCluster bool, logger
 func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterSync-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -216,6 +283,23 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the ConfigMaps referenced by the ResourcesFrom of SyncSets and SelectorSyncSets
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForConfigMap(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
+	// Watch for changes to the resources applied to the remote clusters
+	if r.remoteWatcher != nil {
+		if err := c.Watch(r.remoteWatcher.Source(), &handler.EnqueueRequestForObject{}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -258,6 +342,60 @@ func requestsForSelectorSyncSet(c client.Client, logger log.FieldLogger) handler
 	}
 }
 
+// requestsForConfigMap returns the requests for the clusters targeted by the SyncSets and SelectorSyncSets whose
+// ResourcesFrom reference the ConfigMap.
+func requestsForConfigMap(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	forSelectorSyncSet := requestsForSelectorSyncSet(c, logger)
+	return func(o handler.MapObject) []reconcile.Request {
+		cm, ok := o.Object.(*corev1.ConfigMap)
+		if !ok {
+			return nil
+		}
+		logger := logger.WithField("configMap", cm.Namespace+"/"+cm.Name)
+		references := func(spec *hivev1.SyncSetCommonSpec, defaultNamespace string) bool {
+			for _, source := range spec.ResourcesFrom {
+				if ref := source.ConfigMapRef; ref != nil && ref.Name == cm.Name {
+					if namespace := ref.Namespace; namespace == cm.Namespace || namespace == "" && defaultNamespace == cm.Namespace {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		var requests []reconcile.Request
+		seen := map[reconcile.Request]bool{}
+		add := func(newRequests []reconcile.Request) {
+			for _, request := range newRequests {
+				if !seen[request] {
+					seen[request] = true
+					requests = append(requests, request)
+				}
+			}
+		}
+		syncSets := &hivev1.SyncSetList{}
+		if err := c.List(context.Background(), syncSets, client.InNamespace(cm.Namespace)); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
+			return nil
+		}
+		for i := range syncSets.Items {
+			if references(&syncSets.Items[i].Spec.SyncSetCommonSpec, syncSets.Items[i].Namespace) {
+				add(requestsForSyncSet(handler.MapObject{Meta: &syncSets.Items[i], Object: &syncSets.Items[i]}))
+			}
+		}
+		selectorSyncSets := &hivev1.SelectorSyncSetList{}
+		if err := c.List(context.Background(), selectorSyncSets); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SelectorSyncSets")
+			return nil
+		}
+		for i := range selectorSyncSets.Items {
+			if references(&selectorSyncSets.Items[i].Spec.SyncSetCommonSpec, "") {
+				add(forSelectorSyncSet(handler.MapObject{Meta: &selectorSyncSets.Items[i], Object: &selectorSyncSets.Items[i]}))
+			}
+		}
+		return requests
+	}
+}
+
 var _ reconcile.Reconciler = &ReconcileClusterSync{}
 
 // ReconcileClusterSync reconciles a ClusterDeployment object to apply its SyncSets and SelectorSyncSets
@@ -265,6 +403,13 @@ type ReconcileClusterSync struct {
 	client.Client
 	logger          log.FieldLogger
 	reapplyInterval time.Duration
+	// staleThreshold is how long the syncsets may go without all being applied successfully before the ClusterSync
+	// reports the SyncStale condition.
+	staleThreshold time.Duration
+	// applyWorkers applies the resources of the syncsets to the remote clusters. The workers are shared by all the
+	// reconciles, which bounds the number of resources being applied at once. When nil, the resources are applied one
+	// at a time.
+	applyWorkers *workerPool
 
 	resourceHelperBuilder func(*rest.Config, bool, log.FieldLogger) (resource.Helper, error)
 
@@ -272,6 +417,16 @@ type ReconcileClusterSync struct {
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
+	// urlResources caches the resources downloaded from the URLs referenced by the ResourcesFrom of syncsets.
+	urlResources urlResourcesCache
+	// allowedResourcesFromURLs is the list of the URL prefixes from which the ResourcesFrom of syncsets may download
+	// resources. No URL is allowed when it is empty.
+	allowedResourcesFromURLs []string
+
+	// remoteWatcher watches the resources applied to the remote clusters. It is nil when remote clusters are not
+	// watched.
+	remoteWatcher remoteWatcher
+
 	ordinalID int64
 }
 
@@ -349,6 +504,8 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("ClusterDeployment not found")
+			r.stopWatchingRemoteCluster(request.NamespacedName)
+			remoteclient.ForgetServiceAccountTokens(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		log.WithError(err).Error("failed to get ClusterDeployment")
@@ -369,20 +526,25 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 
 		logger.Debug("not syncing because isSyncAssignedToMe returned false")
 		recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeSkippedSync)
+		r.stopWatchingRemoteCluster(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
 	if controllerutils.IsClusterPausedOrRelocating(cd, logger) {
+		r.stopWatchingRemoteCluster(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
 	if cd.DeletionTimestamp != nil {
 		logger.Debug("cluster is being deleted")
+		r.stopWatchingRemoteCluster(request.NamespacedName)
+		remoteclient.ForgetServiceAccountTokens(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
 	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
 		logger.Debug("cluster is unreachable")
+		r.stopWatchingRemoteCluster(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
@@ -449,8 +611,19 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	if needToDoFullReapply {
 		logger.Info("need to reapply all syncsets")
 	}
+
+	waitingForControlPlaneCerts := isWaitingForControlPlaneCertificates(cd)
+	if waitingForControlPlaneCerts {
+		logger.Info("only applying the control plane certificates until they are served by the cluster")
+	}
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
+	// Resources changed or deleted in the cluster are applied again even if the syncsets applying them are up-to-date.
+	changedResources := r.takeChangedResources(request.NamespacedName)
+	if len(changedResources) > 0 {
+		logger.WithField("changedResources", len(changedResources)).Info("applied resources were changed in the cluster")
+	}
+
 	// Apply SyncSets
 	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
 		cd,
@@ -458,6 +631,8 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		syncSets,
 		clusterSync.Status.SyncSets,
 		needToDoFullReapply,
+		changedResources,
+		waitingForControlPlaneCerts,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
 		resourceHelper,
 		logger,
@@ -471,6 +646,8 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		selectorSyncSets,
 		clusterSync.Status.SelectorSyncSets,
 		needToDoFullReapply,
+		changedResources,
+		waitingForControlPlaneCerts,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
 		resourceHelper,
 		logger,
@@ -481,10 +658,28 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 
 	// Set clusterSync.Status.FirstSyncSetsSuccessTime
 	syncStatuses := append(syncStatusesForSyncSets, syncStatusesForSelectorSyncSets...)
-	if clusterSync.Status.FirstSuccessTime == nil {
+	if clusterSync.Status.FirstSuccessTime == nil && !waitingForControlPlaneCerts {
 		r.setFirstSuccessTime(syncStatuses, cd, clusterSync, logger)
 	}
 
+	// The full re-apply is not complete when syncsets were held back waiting for the control plane certificates.
+	needToUpdateLease := false
+	now := metav1.NowMicro()
+	if needToDoFullReapply && !waitingForControlPlaneCerts {
+		logger.Info("setting last full apply time")
+		lease.Spec.RenewTime = now
+		needToUpdateLease = true
+	}
+	// Record the success once all of the syncsets have been applied successfully since the last full re-apply.
+	if !waitingForControlPlaneCerts && len(getFailingSyncSets(syncStatuses)) == 0 &&
+		(lease.Spec.LastSuccessTime == nil || lease.Spec.LastSuccessTime.Before(&lease.Spec.RenewTime)) {
+		logger.Info("setting last successful sync time")
+		lease.Spec.LastSuccessTime = &now
+		needToUpdateLease = true
+	}
+
+	timeUntilStale := r.setStaleCondition(cd, clusterSync, lease, logger)
+
 	// Update the ClusterSync
 	if !reflect.DeepEqual(origStatus, &clusterSync.Status) {
 		logger.Info("updating ClusterSync")
@@ -494,9 +689,11 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		}
 	}
 
-	if needToDoFullReapply {
-		logger.Info("setting last full apply time")
-		lease.Spec.RenewTime = metav1.NowMicro()
+	if !fakeCluster {
+		r.watchRemoteCluster(cd, clusterSync, logger)
+	}
+
+	if needToUpdateLease {
 		if needToCreateLease {
 			logger.Info("creating lease for ClusterSync")
 			lease.Namespace = cd.Namespace
@@ -518,6 +715,9 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	if timeUntilStale > 0 && timeUntilStale < result.RequeueAfter {
+		result.RequeueAfter = timeUntilStale
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
@@ -530,6 +730,8 @@ func (r *ReconcileClusterSync) applySyncSets(
 	syncSets []CommonSyncSet,
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
+	changedResources []hiveintv1alpha1.SyncResourceReference,
+	onlyControlPlaneCerts bool,
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
@@ -540,6 +742,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 		return syncSets[i].AsMetaObject().GetName() < syncSets[j].AsMetaObject().GetName()
 	})
 
+	targetVersions := newTargetClusterVersions(resourceHelper)
 	for _, syncSet := range syncSets {
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
@@ -551,8 +754,31 @@ func (r *ReconcileClusterSync) applySyncSets(
 			syncStatuses = syncStatuses[:last]
 		}
 
+		// Add the resources referenced by the syncset to the resources in the syncset and render their templates.
+		syncSet, resourcesChecksum, resolveErr := r.resolveResources(syncSet, cd, logger)
+
 		// Determine if the syncset needs to be applied
 		switch {
+		case onlyControlPlaneCerts && !isControlPlaneCertsSyncSet(syncSet):
+			logger.Debug("skipping apply of syncset until the control plane certificates are served")
+			if indexOfOldStatus >= 0 {
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			}
+			continue
+		case !isRolledOutToCluster(syncSet, cd) &&
+			(indexOfOldStatus < 0 || oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration()):
+			// The previous generation cannot be re-applied, so the syncset is left alone entirely until the rollout
+			// of the current generation reaches the cluster.
+			logger.Debug("skipping apply of syncset until its rollout reaches the cluster")
+			if indexOfOldStatus >= 0 {
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			}
+			continue
+		case resolveErr != nil:
+			logger.WithError(resolveErr).Warn("cannot resolve the resources of the syncset")
+			requeue = true
+			newSyncStatuses = append(newSyncStatuses, resolveFailureSyncStatus(syncSet, oldSyncStatus, resolveErr))
+			continue
 		case needToDoFullReapply:
 			logger.Debug("applying syncset because it is time to do a full re-apply")
 		case indexOfOldStatus < 0:
@@ -561,17 +787,39 @@ func (r *ReconcileClusterSync) applySyncSets(
 			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case oldSyncStatus.ResourcesChecksum != resourcesChecksum:
+			logger.Debug("applying syncset because the resources it references or the values of its templates have changed")
+		case appliedResourcesChanged(oldSyncStatus.AppliedResources, changedResources):
+			logger.Debug("applying syncset because resources it applied were changed in the cluster")
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
 			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
 			continue
 		}
 
+		if isReportOnly(syncSet, cd, logger) {
+			newSyncStatuses = append(newSyncStatuses, r.reportDrift(syncSetType, syncSet, resourcesChecksum, oldSyncStatus, indexOfOldStatus >= 0, resourceHelper, logger))
+			continue
+		}
+
+		// Resources that have not changed since they were last applied are skipped unless it is time to do a full
+		// re-apply or they were changed in the cluster. The changes in the cluster are reported by the remote watcher
+		// when the cluster is watched, and are otherwise found by listing the applied resources in the cluster.
+		var lastAppliedResources []hiveintv1alpha1.AppliedResource
+		if !needToDoFullReapply {
+			lastAppliedResources = withoutChangedResources(oldSyncStatus.AppliedResources, changedResources)
+			if r.remoteWatcher == nil {
+				lastAppliedResources = targetVersions.withoutChangedResources(lastAppliedResources, logger)
+			}
+		}
+
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, appliedResources, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, lastAppliedResources, resourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+			ResourcesChecksum:  resourcesChecksum,
+			AppliedResources:   appliedResources,
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
 		}
 		if syncSet.GetSpec().ResourceApplyMode == hivev1.SyncResourceApplyMode {
@@ -613,8 +861,9 @@ func (r *ReconcileClusterSync) applySyncSets(
 			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
 		}
 
-		// Update the last transition time if there were any changes to the sync status.
-		if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+		// Update the last transition time if there were any changes to the sync status. The hashes of the applied
+		// resources are bookkeeping for skipping unchanged resources and are not considered a change in status.
+		if !isSyncStatusEqualIgnoringAppliedResources(oldSyncStatus, newSyncStatus) {
 			newSyncStatus.LastTransitionTime = metav1.Now()
 		}
 
@@ -686,6 +935,33 @@ func (r *ReconcileClusterSync) applySyncSets(
 	return
 }
 
+// isWaitingForControlPlaneCertificates returns true if the control plane certificates have been synced to the cluster
+// but the API server is not yet serving them.
+func isWaitingForControlPlaneCertificates(cd *hivev1.ClusterDeployment) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ControlPlaneCertificateNotFoundCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == hivev1.ControlPlaneCertificatesNotServedReason
+}
+
+func isControlPlaneCertsSyncSet(syncSet CommonSyncSet) bool {
+	return syncSet.AsMetaObject().GetLabels()[constants.SyncSetTypeLabel] == constants.SyncSetTypeControlPlaneCerts
+}
+
+// isRolledOutToCluster returns true if the rollout of the current generation of the syncset has reached the cluster.
+// Only SelectorSyncSets have a rollout strategy.
+func isRolledOutToCluster(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment) bool {
+	sss, ok := syncSet.(*SelectorSyncSetAsCommon)
+	if !ok {
+		return true
+	}
+	return controllerutils.IsSelectorSyncSetRolledOutToCluster((*hivev1.SelectorSyncSet)(sss), cd)
+}
+
+func isSyncStatusEqualIgnoringAppliedResources(a, b hiveintv1alpha1.SyncStatus) bool {
+	a.AppliedResources = nil
+	b.AppliedResources = nil
+	return reflect.DeepEqual(a, b)
+}
+
 func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.SyncStatus) (hiveintv1alpha1.SyncStatus, int) {
 	for i, status := range syncSetStatuses {
 		if status.Name == syncSet.AsMetaObject().GetName() {
@@ -695,13 +971,155 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 	return hiveintv1alpha1.SyncStatus{}, -1
 }
 
+// isReportOnly returns true if the syncset is not to be applied to the cluster and drift is to be reported instead.
+func isReportOnly(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	if syncSet.GetSpec().EnforcementMode == hivev1.ReportSyncSetEnforcementMode {
+		return true
+	}
+	reportOnly, err := strconv.ParseBool(cd.Annotations[constants.SyncsetReportOnlyAnnotation])
+	if err == nil && reportOnly {
+		logger.WithField("annotation", constants.SyncsetReportOnlyAnnotation).Debug("syncsets are not enforced in the cluster")
+		return true
+	}
+	return false
+}
+
+// resolveFailureSyncStatus returns the sync status of a syncset whose resources cannot be resolved. The resources of
+// the last apply are kept so that they are still deleted if the syncset is removed.
+func resolveFailureSyncStatus(syncSet CommonSyncSet, oldSyncStatus hiveintv1alpha1.SyncStatus, err error) hiveintv1alpha1.SyncStatus {
+	newSyncStatus := hiveintv1alpha1.SyncStatus{
+		Name:               syncSet.AsMetaObject().GetName(),
+		ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+		ResourcesChecksum:  oldSyncStatus.ResourcesChecksum,
+		ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+		AppliedResources:   oldSyncStatus.AppliedResources,
+		DriftedResources:   oldSyncStatus.DriftedResources,
+		Result:             hiveintv1alpha1.FailureSyncSetResult,
+		FailureMessage:     err.Error(),
+		LastTransitionTime: oldSyncStatus.LastTransitionTime,
+		FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+	}
+	if !isSyncStatusEqualIgnoringAppliedResources(oldSyncStatus, newSyncStatus) {
+		newSyncStatus.LastTransitionTime = metav1.Now()
+	}
+	return newSyncStatus
+}
+
+// reportDrift compares the resources and secrets in the cluster with the syncset and returns the sync status reporting
+// the resources that have drifted. Nothing is applied to or deleted from the cluster.
+func (r *ReconcileClusterSync) reportDrift(
+	syncSetType string,
+	syncSet CommonSyncSet,
+	resourcesChecksum string,
+	oldSyncStatus hiveintv1alpha1.SyncStatus,
+	hasOldSyncStatus bool,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) hiveintv1alpha1.SyncStatus {
+	logger.Debug("checking syncset for drift")
+	newSyncStatus := hiveintv1alpha1.SyncStatus{
+		Name:               syncSet.AsMetaObject().GetName(),
+		ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+		ResourcesChecksum:  resourcesChecksum,
+		// Keep track of the resources applied before the syncset stopped being enforced so that they are still
+		// deleted if the syncset is removed.
+		ResourcesToDelete: oldSyncStatus.ResourcesToDelete,
+		Result:            hiveintv1alpha1.SuccessSyncSetResult,
+		FirstSuccessTime:  oldSyncStatus.FirstSuccessTime,
+	}
+	if hasOldSyncStatus {
+		newSyncStatus.LastTransitionTime = oldSyncStatus.LastTransitionTime
+	}
+	driftedResources, err := r.findDriftedResources(syncSet, resourceHelper, logger)
+	if err != nil {
+		newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		newSyncStatus.FailureMessage = err.Error()
+	}
+	newSyncStatus.DriftedResources = driftedResources
+	if len(driftedResources) > 0 {
+		logger.WithField("driftedResources", len(driftedResources)).Info("resources in the cluster have drifted from the syncset")
+	}
+	// Drift is checked at each re-apply, so only the resources which were not already reported as drifted are counted.
+	if newlyDrifted := countNewlyDrifted(oldSyncStatus.DriftedResources, driftedResources); newlyDrifted > 0 {
+		metricResourcesDrifted.WithLabelValues(syncSetType, syncSetMetricsName(syncSet)).Add(float64(newlyDrifted))
+	}
+	if !isSyncStatusEqualIgnoringAppliedResources(oldSyncStatus, newSyncStatus) {
+		newSyncStatus.LastTransitionTime = metav1.Now()
+	}
+	return newSyncStatus
+}
+
+// findDriftedResources returns the resources and secrets in the cluster that differ from the syncset. With the
+// CreateOnly apply behavior, only the resources and secrets missing from the cluster have drifted.
+func (r *ReconcileClusterSync) findDriftedResources(syncSet CommonSyncSet, resourceHelper resource.Helper, logger log.FieldLogger) ([]hiveintv1alpha1.DriftedResource, error) {
+	resources, references, err := decodeResources(syncSet, logger)
+	if err != nil {
+		return nil, err
+	}
+	desired := make([]map[string]interface{}, len(resources))
+	for i, u := range resources {
+		desired[i] = u.Object
+	}
+	for i, secretMapping := range syncSet.GetSpec().Secrets {
+		secret, err, _ := r.getSecretToSync(syncSet, i, secretMapping, logger)
+		if err != nil {
+			return nil, err
+		}
+		secret.APIVersion = secretAPIVersion
+		secret.Kind = secretKind
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert secret %d", i)
+		}
+		desired = append(desired, obj)
+	}
+	references = append(references, referencesToSecrets(syncSet)...)
+
+	var driftedResources []hiveintv1alpha1.DriftedResource
+	for i, reference := range references {
+		actual, err := resourceHelper.Get(context.Background(), reference.APIVersion, reference.Kind, reference.Namespace, reference.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s %s", reference.Kind, path.Join(reference.Namespace, reference.Name))
+		}
+		// Resources which are only created are not updated to match the syncset once they exist.
+		if actual != nil && syncSet.GetSpec().ApplyBehavior == hivev1.CreateOnlySyncSetApplyBehavior {
+			continue
+		}
+		var actualObj map[string]interface{}
+		if actual != nil {
+			actualObj = actual.Object
+		}
+		if differences := findDifferences(desired[i], actualObj, isRedacted(reference.Kind)); len(differences) > 0 {
+			driftedResources = append(driftedResources, hiveintv1alpha1.DriftedResource{
+				SyncResourceReference: reference,
+				Differences:           differences,
+			})
+		}
+	}
+	return driftedResources, nil
+}
+
+// syncSetMetricsName returns the name used for the syncset in metrics. SyncSets are grouped by their metrics group
+// annotation to bound the cardinality of the metrics.
+func syncSetMetricsName(syncSet CommonSyncSet) string {
+	if syncSet.AsMetaObject().GetNamespace() == "" {
+		return syncSet.AsMetaObject().GetName()
+	}
+	if syncSetGroup, ok := syncSet.AsMetaObject().GetAnnotations()[constants.SyncSetMetricsGroupAnnotation]; ok && syncSetGroup != "" {
+		return syncSetGroup
+	}
+	return "none"
+}
+
 func (r *ReconcileClusterSync) applySyncSet(
 	syncSet CommonSyncSet,
+	lastAppliedResources []hiveintv1alpha1.AppliedResource,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
 	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	appliedResources []hiveintv1alpha1.AppliedResource,
 	requeue bool,
 	returnErr error,
 ) {
@@ -724,23 +1142,47 @@ func (r *ReconcileClusterSync) applySyncSet(
 		applyFnMetricsLabel = labelCreateOnly
 	}
 
-	// Apply Resources
-	for i, resource := range resources {
-		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
+	// Apply Resources. The resources of each stage of the apply order are applied concurrently by the apply workers.
+	// Once a resource fails to apply, the resources not yet being applied are skipped. The resources that were applied
+	// are recorded even when others in the stage failed, so that they are deleted from the cluster once removed from
+	// the syncset.
+	for _, stage := range applyStages(resources) {
+		applied := make([]hiveintv1alpha1.AppliedResource, len(stage))
+		errs := make([]error, len(stage))
+		var failed int32
+		r.applyWorkers.run(len(stage), func(j int) {
+			if atomic.LoadInt32(&failed) != 0 {
+				return
+			}
+			i := stage[j]
+			applied[j], errs[j], _ = r.applyResource(i, resources[i], referencesToResources[i], lastAppliedResource(lastAppliedResources, referencesToResources[i]), applyFn, applyFnMetricsLabel, logger)
+			if errs[j] != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		})
+		for j, i := range stage {
+			if errs[j] != nil && returnErr == nil {
+				returnErr, requeue = errs[j], true
+			}
+			if applied[j].Hash != "" {
+				resourcesApplied = append(resourcesApplied, referencesToResources[i])
+				appliedResources = append(appliedResources, applied[j])
+			}
+		}
 		if returnErr != nil {
-			resourcesApplied = referencesToResources[:i]
 			return
 		}
 	}
-	resourcesApplied = referencesToResources
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
-		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		var applied hiveintv1alpha1.AppliedResource
+		applied, returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], lastAppliedResource(lastAppliedResources, referencesToSecrets[i]), applyFn, applyFnMetricsLabel, logger)
 		if returnErr != nil {
 			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
 			return
 		}
+		appliedResources = append(appliedResources, applied)
 	}
 	resourcesApplied = append(resourcesApplied, referencesToSecrets...)
 
@@ -779,6 +1221,44 @@ func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
 	return
 }
 
+// applyOrder returns the indices of the resources in the order in which they are applied. Resources that other
+// resources commonly depend on, such as namespaces and CustomResourceDefinitions, are applied first. The order of
+// the other resources in the syncset is kept.
+func applyOrder(resources []*unstructured.Unstructured) []int {
+	order := make([]int, len(resources))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return applyPriority(resources[order[i]]) < applyPriority(resources[order[j]])
+	})
+	return order
+}
+
+// applyStages splits the indices of the resources in apply order into the stages in which they are applied. The
+// resources of a stage do not depend on each other, and only depend on the resources of the previous stages.
+func applyStages(resources []*unstructured.Unstructured) [][]int {
+	var stages [][]int
+	for _, i := range applyOrder(resources) {
+		if n := len(stages); n == 0 || applyPriority(resources[stages[n-1][0]]) != applyPriority(resources[i]) {
+			stages = append(stages, nil)
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], i)
+	}
+	return stages
+}
+
+func applyPriority(resource *unstructured.Unstructured) int {
+	switch resource.GetKind() {
+	case "Namespace":
+		return 0
+	case "CustomResourceDefinition":
+		return 1
+	default:
+		return 2
+	}
+}
+
 func referencesToSecrets(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceReference {
 	var references []hiveintv1alpha1.SyncResourceReference
 	for _, secretMapping := range syncSet.GetSpec().Secrets {
@@ -792,24 +1272,37 @@ func referencesToSecrets(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceRe
 	return references
 }
 
+// lastAppliedResource returns the resource as it was last applied to the cluster, or nil if the resource has not been
+// applied.
+func lastAppliedResource(lastAppliedResources []hiveintv1alpha1.AppliedResource, reference hiveintv1alpha1.SyncResourceReference) *hiveintv1alpha1.AppliedResource {
+	for i, r := range lastAppliedResources {
+		if r.SyncResourceReference == reference {
+			return &lastAppliedResources[i]
+		}
+	}
+	return nil
+}
+
 func (r *ReconcileClusterSync) applyResource(
 	resourceIndex int,
 	resource *unstructured.Unstructured,
 	reference hiveintv1alpha1.SyncResourceReference,
-	applyFn func(obj []byte) (resource.ApplyResult, error),
+	lastApplied *hiveintv1alpha1.AppliedResource,
+	applyFn func(ctx context.Context, obj []byte) (*resource.AppliedObject, error),
 	applyFnMetricsLabel string,
 	logger log.FieldLogger,
-) (returnErr error, requeue bool) {
+) (applied hiveintv1alpha1.AppliedResource, returnErr error, requeue bool) {
 	logger = logger.WithField("resourceIndex", resourceIndex).
 		WithField("resourceNamespace", reference.Namespace).
 		WithField("resourceName", reference.Name).
 		WithField("resourceAPIVersion", reference.APIVersion).
 		WithField("resourceKind", reference.Kind)
 	logger.Debug("applying resource")
-	if err := applyToTargetCluster(resource, applyFnMetricsLabel, applyFn, logger); err != nil {
-		return errors.Wrapf(err, "failed to apply resource %d", resourceIndex), true
+	applied, err := applyToTargetCluster(resource, reference, lastApplied, applyFnMetricsLabel, applyFn, logger)
+	if err != nil {
+		return applied, errors.Wrapf(err, "failed to apply resource %d", resourceIndex), true
 	}
-	return nil, false
+	return applied, nil, false
 }
 
 func (r *ReconcileClusterSync) applySecret(
@@ -817,20 +1310,41 @@ func (r *ReconcileClusterSync) applySecret(
 	secretIndex int,
 	secretMapping hivev1.SecretMapping,
 	reference hiveintv1alpha1.SyncResourceReference,
-	applyFn func(obj []byte) (resource.ApplyResult, error),
+	lastApplied *hiveintv1alpha1.AppliedResource,
+	applyFn func(ctx context.Context, obj []byte) (*resource.AppliedObject, error),
 	applyFnMetricsLabel string,
 	logger log.FieldLogger,
-) (returnErr error, requeue bool) {
+) (applied hiveintv1alpha1.AppliedResource, returnErr error, requeue bool) {
 	logger = logger.WithField("secretIndex", secretIndex).
 		WithField("secretNamespace", reference.Namespace).
 		WithField("secretName", reference.Name)
+	secret, err, requeue := r.getSecretToSync(syncSet, secretIndex, secretMapping, logger)
+	if err != nil {
+		return applied, err, requeue
+	}
+	logger.Debug("applying secret")
+	applied, err = applyToTargetCluster(secret, reference, lastApplied, applyFnMetricsLabel, applyFn, logger)
+	if err != nil {
+		return applied, errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
+	}
+	return applied, nil, false
+}
+
+// getSecretToSync reads the source secret of the secret mapping and returns the secret as it is to be synced to the
+// target cluster.
+func (r *ReconcileClusterSync) getSecretToSync(
+	syncSet CommonSyncSet,
+	secretIndex int,
+	secretMapping hivev1.SecretMapping,
+	logger log.FieldLogger,
+) (secret *corev1.Secret, returnErr error, requeue bool) {
 	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
 	srcNamespace := secretMapping.SourceRef.Namespace
 	if srcNamespace == "" {
 		// The namespace of the source secret is required for SelectorSyncSets.
 		if syncSetNamespace == "" {
 			logger.Warn("namespace must be specified for source secret")
-			return fmt.Errorf("source namespace missing for secret %d", secretIndex), false
+			return nil, fmt.Errorf("source namespace missing for secret %d", secretIndex), false
 		}
 		// Use the namespace of the SyncSet if the namespace of the source secret is omitted.
 		srcNamespace = syncSetNamespace
@@ -838,13 +1352,13 @@ func (r *ReconcileClusterSync) applySecret(
 		// If the namespace of the source secret is specified, then it must match the namespace of the SyncSet.
 		if syncSetNamespace != "" && syncSetNamespace != srcNamespace {
 			logger.Warn("source secret must be in same namespace as SyncSet")
-			return fmt.Errorf("source in wrong namespace for secret %d", secretIndex), false
+			return nil, fmt.Errorf("source in wrong namespace for secret %d", secretIndex), false
 		}
 	}
-	secret := &corev1.Secret{}
+	secret = &corev1.Secret{}
 	if err := r.Get(context.Background(), types.NamespacedName{Namespace: srcNamespace, Name: secretMapping.SourceRef.Name}, secret); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read secret")
-		return errors.Wrapf(err, "failed to read secret %d", secretIndex), true
+		return nil, errors.Wrapf(err, "failed to read secret %d", secretIndex), true
 	}
 	// Clear out the fields of the metadata which are specific to the cluster to which the secret belongs.
 	secret.ObjectMeta = metav1.ObjectMeta{
@@ -853,11 +1367,7 @@ func (r *ReconcileClusterSync) applySecret(
 		Annotations: secret.Annotations,
 		Labels:      secret.Labels,
 	}
-	logger.Debug("applying secret")
-	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
-		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
-	}
-	return nil, false
+	return secret, nil, false
 }
 
 func (r *ReconcileClusterSync) applyPatch(
@@ -873,6 +1383,7 @@ func (r *ReconcileClusterSync) applyPatch(
 		WithField("patchKind", patch.Kind)
 	logger.Debug("applying patch")
 	if err := resourceHelper.Patch(
+		context.Background(),
 		types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
 		patch.Kind,
 		patch.APIVersion,
@@ -884,28 +1395,31 @@ func (r *ReconcileClusterSync) applyPatch(
 	return nil, false
 }
 
+// applyToTargetCluster applies the object to the target cluster and returns the resource as it was applied, including
+// the hash of the applied content. The apply is skipped when the hash matches the hash of the content last applied.
 func applyToTargetCluster(
 	obj hivev1.MetaRuntimeObject,
+	reference hiveintv1alpha1.SyncResourceReference,
+	lastApplied *hiveintv1alpha1.AppliedResource,
 	applyFnMetricLabel string,
-	applyFn func(obj []byte) (resource.ApplyResult, error),
+	applyFn func(ctx context.Context, obj []byte) (*resource.AppliedObject, error),
 	logger log.FieldLogger,
-) error {
+) (hiveintv1alpha1.AppliedResource, error) {
 	startTime := time.Now()
-	labels := obj.GetLabels()
-	if labels == nil {
-		labels = make(map[string]string, 1)
-	}
-	// Inject the hive managed annotation to help end-users see that a resource is managed by hive:
-	labels[constants.HiveManagedLabel] = "true"
-	obj.SetLabels(labels)
-
-	bytes, err := json.Marshal(obj)
+	bytes, err := prepareForApply(obj)
 	if err != nil {
 		logger.WithError(err).Error("error marshalling unstructured object to json bytes")
-		return err
+		return hiveintv1alpha1.AppliedResource{}, err
+	}
+
+	hash := appliedContentHash(bytes)
+	if lastApplied != nil && hash == lastApplied.Hash {
+		logger.Debug("skipping apply of resource since it has not changed since it was last applied")
+		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultSkipped).Inc()
+		return *lastApplied, nil
 	}
 
-	applyResult, err := applyFn(bytes)
+	applyResult, err := applyFn(context.Background(), bytes)
 	// Record the amount of time we took to apply this specific resource. When combined with the metric for duration of
 	// our kube client requests, we can get an idea how much time we're spending cpu bound vs network bound.
 	applyTime := metav1.Now().Sub(startTime).Seconds()
@@ -913,12 +1427,108 @@ func applyToTargetCluster(
 		logger.WithError(err).Warn("error applying resource")
 		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultError).Inc()
 		metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultError).Observe(applyTime)
-	} else {
-		logger.WithField("applyResult", applyResult).Debug("resource applied")
-		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Inc()
-		metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Observe(applyTime)
+		return hiveintv1alpha1.AppliedResource{}, err
+	}
+	logger.WithField("applyResult", applyResult.Result).Debug("resource applied")
+	metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Inc()
+	metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Observe(applyTime)
+	return hiveintv1alpha1.AppliedResource{
+		SyncResourceReference: reference,
+		Hash:                  hash,
+		Generation:            applyResult.Generation,
+		ResourceVersion:       applyResult.ResourceVersion,
+	}, nil
+}
+
+// resourceKindInNamespace identifies the resources of a kind in a namespace of the target cluster.
+type resourceKindInNamespace struct {
+	apiVersion, kind, namespace string
+}
+
+// targetClusterVersions holds the generation and resource version of the resources applied to the target cluster. The
+// resources are listed one kind and namespace at a time, when the first of their resources is looked up, so that the
+// resources which have not changed since they were last applied are checked with one request per kind and namespace
+// rather than one per resource.
+type targetClusterVersions struct {
+	resourceHelper resource.Helper
+	// resources holds the resources of each kind and namespace by name, or nil when they could not be listed.
+	resources map[resourceKindInNamespace]map[string]*unstructured.Unstructured
+}
+
+func newTargetClusterVersions(resourceHelper resource.Helper) *targetClusterVersions {
+	return &targetClusterVersions{
+		resourceHelper: resourceHelper,
+		resources:      map[resourceKindInNamespace]map[string]*unstructured.Unstructured{},
 	}
-	return err
+}
+
+// withoutChangedResources returns the applied resources which have not changed in the target cluster since they were
+// last applied. The generation of the resources that track the generation of their spec is compared, so that updates
+// of their status are not mistaken for changes. The resource version is compared for the other resources.
+func (v *targetClusterVersions) withoutChangedResources(appliedResources []hiveintv1alpha1.AppliedResource, logger log.FieldLogger) []hiveintv1alpha1.AppliedResource {
+	var unchanged []hiveintv1alpha1.AppliedResource
+	for _, applied := range appliedResources {
+		logger := logger.WithField("resource", fmt.Sprintf("%s/%s", applied.Kind, applied.Name))
+		if applied.Generation == 0 && applied.ResourceVersion == "" {
+			// The version of the resource in the target cluster was not recorded when it was last applied.
+			continue
+		}
+		resources, ok := v.list(resourceKindInNamespace{apiVersion: applied.APIVersion, kind: applied.Kind, namespace: applied.Namespace}, logger)
+		if !ok {
+			continue
+		}
+		current := resources[applied.Name]
+		switch {
+		case current == nil:
+			logger.Debug("applying resource since it was deleted from the target cluster")
+			continue
+		case applied.Generation != 0:
+			if current.GetGeneration() != applied.Generation {
+				logger.Debug("applying resource since its generation has changed in the target cluster")
+				continue
+			}
+		case current.GetResourceVersion() != applied.ResourceVersion:
+			logger.Debug("applying resource since it has changed in the target cluster")
+			continue
+		}
+		unchanged = append(unchanged, applied)
+	}
+	return unchanged
+}
+
+// list returns the resources of the kind in the namespace applied by Hive, by name, and whether they could be listed.
+func (v *targetClusterVersions) list(k resourceKindInNamespace, logger log.FieldLogger) (map[string]*unstructured.Unstructured, bool) {
+	if resources, ok := v.resources[k]; ok {
+		return resources, resources != nil
+	}
+	items, err := v.resourceHelper.List(context.Background(), k.apiVersion, k.kind, k.namespace, constants.HiveManagedLabel+"=true")
+	if err != nil {
+		logger.WithError(err).Warn("could not list resources in the target cluster")
+		v.resources[k] = nil
+		return nil, false
+	}
+	resources := make(map[string]*unstructured.Unstructured, len(items))
+	for i := range items {
+		resources[items[i].GetName()] = &items[i]
+	}
+	v.resources[k] = resources
+	return resources, true
+}
+
+// prepareForApply injects the hive managed label into the object and returns the JSON to apply to the target cluster.
+func prepareForApply(obj hivev1.MetaRuntimeObject) ([]byte, error) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	// Inject the hive managed annotation to help end-users see that a resource is managed by hive:
+	labels[constants.HiveManagedLabel] = "true"
+	obj.SetLabels(labels)
+	return json.Marshal(obj)
+}
+
+func appliedContentHash(content []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(content))
 }
 
 func deleteFromTargetCluster(
@@ -938,7 +1548,16 @@ func deleteFromTargetCluster(
 			WithField("resourceAPIVersion", r.APIVersion).
 			WithField("resourceKind", r.Kind)
 		logger.Info("deleting resource")
-		if err := resourceHelper.Delete(r.APIVersion, r.Kind, r.Namespace, r.Name); err != nil {
+		// Garbage collect the dependents of the resource rather than orphaning them, as the older API versions of some
+		// kinds do by default.
+		if err := resourceHelper.Delete(
+			context.Background(),
+			r.APIVersion,
+			r.Kind,
+			r.Namespace,
+			r.Name,
+			resource.WithPropagationPolicy(metav1.DeletePropagationBackground),
+		); err != nil {
 			logger.WithError(err).Warn("could not delete resource")
 			allErrs = append(allErrs, fmt.Errorf("Failed to delete %s, Kind=%s %s/%s: %w", r.APIVersion, r.Kind, r.Namespace, r.Name, err))
 			remainingResources = append(remainingResources, r)
@@ -994,17 +1613,19 @@ func doesSelectorSyncSetApplyToClusterDeployment(selectorSyncSet *hivev1.Selecto
 		logger.WithError(err).Error("unable to convert selector")
 		return false
 	}
-	return labelSelector.Matches(labels.Set(cd.Labels))
+	return labelSelector.Matches(labels.Set(cd.Labels)) && !controllerutils.IsExcludedFromSelectorSyncSet(selectorSyncSet, cd)
 }
 
+// setFailedCondition sets the Failed condition of the ClusterSync, along with the Ready condition which has the opposite
+// status.
 func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
-	status := corev1.ConditionFalse
+	status, readyStatus := corev1.ConditionFalse, corev1.ConditionTrue
 	reason := "Success"
 	message := "All SyncSets and SelectorSyncSets have been applied to the cluster"
 	failingSyncSets := getFailingSyncSets(clusterSync.Status.SyncSets)
 	failingSelectorSyncSets := getFailingSyncSets(clusterSync.Status.SelectorSyncSets)
 	if len(failingSyncSets)+len(failingSelectorSyncSets) != 0 {
-		status = corev1.ConditionTrue
+		status, readyStatus = corev1.ConditionTrue, corev1.ConditionFalse
 		reason = "Failure"
 		var failureNames []string
 		if len(failingSyncSets) != 0 {
@@ -1019,22 +1640,86 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 		}
 		message = fmt.Sprintf("%s %s failing", strings.Join(failureNames, " and "), verb)
 	}
-	if len(clusterSync.Status.Conditions) > 0 {
-		cond := clusterSync.Status.Conditions[0]
-		if status == cond.Status &&
-			reason == cond.Reason &&
-			message == cond.Message {
-			return
+	setCondition(clusterSync, hiveintv1alpha1.ClusterSyncFailed, status, reason, message)
+	setCondition(clusterSync, hiveintv1alpha1.ClusterSyncReady, readyStatus, reason, message)
+}
+
+// setStaleCondition sets the SyncStale condition of the ClusterSync when the syncsets have not all been applied
+// successfully to the cluster for longer than the stale threshold. The condition is only added once the cluster goes
+// stale. It returns the time until the cluster goes stale, or zero when it is stale already.
+func (r *ReconcileClusterSync) setStaleCondition(
+	cd *hivev1.ClusterDeployment,
+	clusterSync *hiveintv1alpha1.ClusterSync,
+	lease *hiveintv1alpha1.ClusterSyncLease,
+	logger log.FieldLogger,
+) time.Duration {
+	var since time.Time
+	switch {
+	case lease.Spec.LastSuccessTime != nil:
+		since = lease.Spec.LastSuccessTime.Time
+	case cd.Status.InstalledTimestamp != nil:
+		since = cd.Status.InstalledTimestamp.Time
+	default:
+		since = clusterSync.CreationTimestamp.Time
+	}
+	timeUntilStale := r.staleThreshold - time.Since(since)
+	if timeUntilStale > 0 {
+		if getCondition(clusterSync, hiveintv1alpha1.ClusterSyncStale) != nil {
+			setCondition(
+				clusterSync,
+				hiveintv1alpha1.ClusterSyncStale,
+				corev1.ConditionFalse,
+				"SyncCurrent",
+				fmt.Sprintf("All SyncSets and SelectorSyncSets have been applied successfully within the last %s", r.staleThreshold),
+			)
 		}
+		return timeUntilStale
+	}
+	if cond := getCondition(clusterSync, hiveintv1alpha1.ClusterSyncStale); cond == nil || cond.Status != corev1.ConditionTrue {
+		logger.WithField("staleThreshold", r.staleThreshold).Warn("syncsets have not all been applied successfully within the stale threshold")
 	}
-	clusterSync.Status.Conditions = []hiveintv1alpha1.ClusterSyncCondition{{
-		Type:               hiveintv1alpha1.ClusterSyncFailed,
+	setCondition(
+		clusterSync,
+		hiveintv1alpha1.ClusterSyncStale,
+		corev1.ConditionTrue,
+		"SyncStale",
+		fmt.Sprintf("SyncSets and SelectorSyncSets have not all been applied successfully for more than %s", r.staleThreshold),
+	)
+	return 0
+}
+
+func getCondition(clusterSync *hiveintv1alpha1.ClusterSync, condType hiveintv1alpha1.ClusterSyncConditionType) *hiveintv1alpha1.ClusterSyncCondition {
+	for i, cond := range clusterSync.Status.Conditions {
+		if cond.Type == condType {
+			return &clusterSync.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// setCondition sets the condition of the given type, leaving the condition untouched when its status, reason, and
+// message are unchanged.
+func setCondition(
+	clusterSync *hiveintv1alpha1.ClusterSync,
+	condType hiveintv1alpha1.ClusterSyncConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	newCond := hiveintv1alpha1.ClusterSyncCondition{
+		Type:               condType,
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
 		LastProbeTime:      metav1.Now(),
 		LastTransitionTime: metav1.Now(),
-	}}
+	}
+	cond := getCondition(clusterSync, condType)
+	switch {
+	case cond == nil:
+		clusterSync.Status.Conditions = append(clusterSync.Status.Conditions, newCond)
+	case status != cond.Status || reason != cond.Reason || message != cond.Message:
+		*cond = newCond
+	}
 }
 
 func getFailingSyncSets(syncStatuses []hiveintv1alpha1.SyncStatus) []string {
@@ -2,8 +2,12 @@ package clustersync
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
@@ -91,6 +96,7 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		Client:          c,
 		logger:          logger,
 		reapplyInterval: defaultReapplyInterval,
+		staleThreshold:  defaultStaleThresholdReapplyIntervals * defaultReapplyInterval,
 		resourceHelperBuilder: func(rc *rest.Config, fakeCluster bool, _ log.FieldLogger) (resource.Helper, error) {
 			return mockResourceHelper, nil
 		},
@@ -196,22 +202,26 @@ func (rt *reconcileTest) run(t *testing.T) {
 	}
 	assert.Contains(t, lease.OwnerReferences, expectedOwnerReferenceFromLease, "expected owner reference from ClusterSyncLease to ClusterSync")
 
-	var syncFailedCond *hiveintv1alpha1.ClusterSyncCondition
+	var syncFailedCond, readyCond *hiveintv1alpha1.ClusterSyncCondition
 	for i, cond := range clusterSync.Status.Conditions {
-		if cond.Type == hiveintv1alpha1.ClusterSyncFailed {
+		switch cond.Type {
+		case hiveintv1alpha1.ClusterSyncFailed:
 			syncFailedCond = &clusterSync.Status.Conditions[i]
-			break
+		case hiveintv1alpha1.ClusterSyncReady:
+			readyCond = &clusterSync.Status.Conditions[i]
 		}
 	}
 	assert.NotNil(t, syncFailedCond, "expected a sync failed condition")
-	expectedConditionStatus := corev1.ConditionTrue
+	assert.NotNil(t, readyCond, "expected a ready condition")
+	expectedConditionStatus, expectedReadyStatus := corev1.ConditionTrue, corev1.ConditionFalse
 	expectedConditionMessage := rt.expectedFailedMessage
 	if expectedConditionMessage == "" {
-		expectedConditionStatus = corev1.ConditionFalse
+		expectedConditionStatus, expectedReadyStatus = corev1.ConditionFalse, corev1.ConditionTrue
 		expectedConditionMessage = "All SyncSets and SelectorSyncSets have been applied to the cluster"
 	}
 	assert.Equal(t, string(expectedConditionStatus), string(syncFailedCond.Status), "unexpected sync failed status")
 	assert.Equal(t, expectedConditionMessage, syncFailedCond.Message, "unexpected sync failed message")
+	assert.Equal(t, string(expectedReadyStatus), string(readyCond.Status), "unexpected ready status")
 
 	areSyncStatusesEqual(t, "syncset", rt.expectedSyncSetStatuses, clusterSync.Status.SyncSets, startTime, endTime)
 	areSyncStatusesEqual(t, "selectorsyncset", rt.expectedSelectorSyncSetStatuses, clusterSync.Status.SelectorSyncSets, startTime, endTime)
@@ -234,6 +244,14 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 				*expectedStatuses[i].FirstSuccessTime = *actualStatuses[i].FirstSuccessTime
 			}
 		}
+		// Tests that do not care about the hashes of the applied resources leave AppliedResources unset.
+		if expectedStatus.AppliedResources == nil {
+			expectedStatuses[i].AppliedResources = actualStatuses[i].AppliedResources
+		}
+		// Tests that do not care about the checksum of the referenced resources leave ResourcesChecksum unset.
+		if expectedStatus.ResourcesChecksum == "" {
+			expectedStatuses[i].ResourcesChecksum = actualStatuses[i].ResourcesChecksum
+		}
 	}
 	assert.Equalf(t, expectedStatuses, actualStatuses, "unexpected %s statuses", syncSetType)
 }
@@ -307,8 +325,11 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 					))
 			}
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			watcher := &fakeRemoteWatcher{}
+			rt.r.remoteWatcher = watcher
 			rt.expectNoWorkDone = true
 			rt.run(t)
+			assert.True(t, watcher.stopped, "expected remote cluster not to be watched")
 		})
 	}
 }
@@ -347,7 +368,7 @@ func TestReconcileClusterSync_ApplyResource(t *testing.T) {
 					teststatefulset.WithReplicas(3),
 				),
 				syncSet)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			expectedSyncStatusBuilder := newSyncStatusBuilder("test-syncset")
 			if tc.includeResourcesToDelete {
 				expectedSyncStatusBuilder = expectedSyncStatusBuilder.Options(
@@ -581,7 +602,7 @@ func TestReconcileClusterSync_ApplySecret(t *testing.T) {
 			).Build(
 				testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 			)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			expectedSyncStatusBuilder := newSyncStatusBuilder("test-syncset")
 			if tc.includeResourcesToDelete {
 				expectedSyncStatusBuilder = expectedSyncStatusBuilder.Options(
@@ -627,7 +648,7 @@ func TestReconcileClusterSync_ApplyPatch(t *testing.T) {
 					teststatefulset.WithReplicas(3),
 				),
 				syncSet)
-			rt.mockResourceHelper.EXPECT().Patch(
+			rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 				types.NamespacedName{Namespace: "dest-namespace", Name: "dest-name"},
 				"ConfigMap",
 				"v1",
@@ -696,9 +717,9 @@ func TestReconcileClusterSync_ApplyAllTypes(t *testing.T) {
 			).Build(
 				testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 			)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
-			rt.mockResourceHelper.EXPECT().Patch(
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+			rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
 				"PatchKind",
 				"patch-api/v1",
@@ -780,7 +801,7 @@ func TestReconcileClusterSync_Reapply(t *testing.T) {
 				buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
 			}
 			if tc.expectApply {
-				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			} else {
 				rt.expectUnchangedLeaseRenewTime = true
 			}
@@ -789,6 +810,210 @@ func TestReconcileClusterSync_Reapply(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_SkipUnchangedResources(t *testing.T) {
+	cases := []struct {
+		name                string
+		renewTime           time.Time
+		lastGeneration      int64
+		lastResourceVersion string
+		targetResource      *unstructured.Unstructured
+		listError           bool
+		expectApplyOfAll    bool
+		expectUnchangedTime bool
+	}{
+		{
+			name:                "not time for reapply",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastResourceVersion: "1",
+			targetResource:      testTargetResource(0, "1"),
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "generation unchanged in cluster",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastGeneration:      1,
+			lastResourceVersion: "1",
+			targetResource:      testTargetResource(1, "2"),
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "generation changed in cluster",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastGeneration:      1,
+			lastResourceVersion: "1",
+			targetResource:      testTargetResource(2, "2"),
+			expectApplyOfAll:    true,
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "resource version changed in cluster",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastResourceVersion: "1",
+			targetResource:      testTargetResource(0, "2"),
+			expectApplyOfAll:    true,
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "deleted from cluster",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastResourceVersion: "1",
+			expectApplyOfAll:    true,
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "list failed",
+			renewTime:           time.Now().Add(-time.Hour),
+			lastResourceVersion: "1",
+			listError:           true,
+			expectApplyOfAll:    true,
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "version in cluster not recorded",
+			renewTime:           time.Now().Add(-time.Hour),
+			expectApplyOfAll:    true,
+			expectUnchangedTime: true,
+		},
+		{
+			name:                "time for reapply",
+			renewTime:           time.Now().Add(-3 * time.Hour),
+			lastResourceVersion: "1",
+			expectApplyOfAll:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			unchangedResource := testConfigMap("dest-namespace", "unchanged")
+			changedResource := testConfigMap("dest-namespace", "changed")
+			changedResource.Data = map[string]string{"key": "new-value"}
+			oldChangedResource := testConfigMap("dest-namespace", "changed")
+			oldChangedResource.Data = map[string]string{"key": "old-value"}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithResources(unchangedResource, changedResource),
+			)
+			lastAppliedUnchangedResource := buildAppliedResource(t, unchangedResource)
+			lastAppliedUnchangedResource.Generation = tc.lastGeneration
+			lastAppliedUnchangedResource.ResourceVersion = tc.lastResourceVersion
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+						withAppliedResources(
+							lastAppliedUnchangedResource,
+							buildAppliedResource(t, oldChangedResource),
+						),
+					)),
+				),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				buildSyncLease(tc.renewTime),
+			)
+			if tc.expectUnchangedTime && tc.lastResourceVersion != "" {
+				var targetResources []unstructured.Unstructured
+				if tc.targetResource != nil {
+					targetResources = append(targetResources, *tc.targetResource)
+				}
+				var listErr error
+				if tc.listError {
+					listErr = errors.New("list failed")
+				}
+				rt.mockResourceHelper.EXPECT().List(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "hive.openshift.io/managed=true").Return(targetResources, listErr)
+			}
+			expectedUnchangedResource := lastAppliedUnchangedResource
+			if tc.expectApplyOfAll {
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(unchangedResource)).Return(&resource.AppliedObject{Result: resource.UnchangedApplyResult, Generation: 3, ResourceVersion: "3"}, nil)
+				expectedUnchangedResource.Generation = 3
+				expectedUnchangedResource.ResourceVersion = "3"
+			}
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(changedResource)).Return(&resource.AppliedObject{Result: resource.ConfiguredApplyResult}, nil)
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-syncset",
+					withObservedGeneration(2),
+					withFirstSuccessTimeInThePast(),
+					withAppliedResources(
+						expectedUnchangedResource,
+						buildAppliedResource(t, changedResource),
+					),
+				),
+			}
+			rt.expectUnchangedLeaseRenewTime = tc.expectUnchangedTime
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_SkipUnchangedResourcesListedOnce(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	firstResource := testConfigMap("dest-namespace", "first")
+	secondResource := testConfigMap("dest-namespace", "second")
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithResources(firstResource, secondResource),
+	)
+	var appliedResources []hiveintv1alpha1.AppliedResource
+	var targetResources []unstructured.Unstructured
+	for _, r := range []*corev1.ConfigMap{firstResource, secondResource} {
+		applied := buildAppliedResource(t, r)
+		applied.ResourceVersion = "1"
+		appliedResources = append(appliedResources, applied)
+		target := testTargetResource(0, "1")
+		target.SetName(r.Name)
+		targetResources = append(targetResources, *target)
+	}
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(
+			testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withAppliedResources(appliedResources...),
+			)),
+		),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		buildSyncLease(time.Now().Add(-time.Hour)),
+	)
+	rt.mockResourceHelper.EXPECT().List(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "hive.openshift.io/managed=true").Return(targetResources, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		buildSyncStatus("test-syncset",
+			withObservedGeneration(2),
+			withFirstSuccessTimeInThePast(),
+			withAppliedResources(appliedResources...),
+		),
+	}
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.run(t)
+}
+
+// testTargetResource returns the unchanged resource of TestReconcileClusterSync_SkipUnchangedResources as it is in the
+// target cluster.
+func testTargetResource(generation int64, resourceVersion string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace("dest-namespace")
+	u.SetName("unchanged")
+	u.SetGeneration(generation)
+	u.SetResourceVersion(resourceVersion)
+	return u
+}
+
 func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -819,7 +1044,7 @@ func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 		newSyncSet,
 		clusterSync,
 		lease)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(newResource)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(newResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
 		buildSyncStatus("existing-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
 		buildSyncStatus("new-syncset"),
@@ -828,6 +1053,274 @@ func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_WaitForControlPlaneCertificates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	existingResource := testConfigMap("dest-namespace", "dest-name")
+	existingSyncSet := testsyncset.FullBuilder(testNamespace, "existing-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithResources(existingResource),
+	)
+	newSyncSet := testsyncset.FullBuilder(testNamespace, "new-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(testConfigMap("other-namespace", "other-name")),
+	)
+	certsResource := testConfigMap("certs-namespace", "certs-name")
+	certsSyncSet := testsyncset.FullBuilder(testNamespace, "certs-syncset", scheme).Build(
+		testsyncset.Generic(testgeneric.WithLabel(constants.SyncSetTypeLabel, constants.SyncSetTypeControlPlaneCerts)),
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(certsResource),
+	)
+	clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(
+		buildSyncStatus("existing-syncset", withObservedGeneration(1), withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+	))
+	lease := buildSyncLease(time.Now().Add(-1 * time.Hour))
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.ControlPlaneCertificateNotFoundCondition,
+			Status: corev1.ConditionTrue,
+			Reason: hivev1.ControlPlaneCertificatesNotServedReason,
+		})),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		existingSyncSet,
+		newSyncSet,
+		certsSyncSet,
+		clusterSync,
+		lease)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(certsResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		buildSyncStatus("certs-syncset"),
+		buildSyncStatus("existing-syncset", withObservedGeneration(1), withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+	}
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_SelectorSyncSetRollout(t *testing.T) {
+	cdKey := testNamespace + "/" + testCDName
+	cases := []struct {
+		name          string
+		rolloutStatus *hivev1.SelectorSyncSetRolloutStatus
+		existingSync  bool
+		expectApply   bool
+	}{
+		{
+			name:         "rollout not started",
+			existingSync: true,
+		},
+		{
+			name:          "rollout of older generation",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 1, CurrentWave: 2},
+			existingSync:  true,
+		},
+		{
+			name:          "cluster in later wave",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 2, CurrentWave: 0, AdmittedClusters: []string{"other-namespace/other-cd"}},
+			existingSync:  true,
+		},
+		{
+			name:          "cluster not admitted",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 2, CurrentWave: 1},
+			existingSync:  true,
+		},
+		{
+			name:          "new to cluster and not admitted",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 2, CurrentWave: 1},
+		},
+		{
+			name:          "cluster admitted",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 2, CurrentWave: 1, AdmittedClusters: []string{cdKey}},
+			existingSync:  true,
+			expectApply:   true,
+		},
+		{
+			name:          "cluster in earlier wave",
+			rolloutStatus: &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 2, CurrentWave: 2},
+			existingSync:  true,
+			expectApply:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(2),
+				testselectorsyncset.WithResources(resourceToApply),
+				testselectorsyncset.WithRollout(&hivev1.SelectorSyncSetRollout{
+					Waves: []metav1.LabelSelector{{MatchLabels: map[string]string{"canary": "true"}}},
+				}),
+				testselectorsyncset.WithRolloutStatus(tc.rolloutStatus),
+			)
+			var existingStatuses []hiveintv1alpha1.SyncStatus
+			if tc.existingSync {
+				existingStatuses = append(existingStatuses,
+					buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+				)
+			}
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			clusterSync.Status.SelectorSyncSets = existingStatuses
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				selectorSyncSet,
+				clusterSync,
+				buildSyncLease(time.Now()),
+			)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.ConfiguredApplyResult}, nil)
+				rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+					buildSyncStatus("test-selectorsyncset", withObservedGeneration(2), withFirstSuccessTimeInThePast()),
+				}
+			} else {
+				rt.expectedSelectorSyncSetStatuses = existingStatuses
+			}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ReportDrift(t *testing.T) {
+	driftedConfigMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"namespace":       "dest-namespace",
+			"name":            "dest-name",
+			"resourceVersion": "1234",
+		},
+		"data": map[string]interface{}{
+			"test-key": "other-value",
+		},
+	}}
+	syncedConfigMap := driftedConfigMap.DeepCopy()
+	syncedConfigMap.Object["data"] = map[string]interface{}{"test-key": "test-value"}
+	cases := []struct {
+		name                  string
+		enforcementMode       hivev1.SyncSetEnforcementMode
+		applyBehavior         hivev1.SyncSetApplyBehavior
+		reportOnlyAnnotation  bool
+		actualConfigMap       *unstructured.Unstructured
+		getErr                error
+		expectedStatusOptions []syncStatusOption
+		expectedFailedMessage string
+	}{
+		{
+			name:            "report mode",
+			enforcementMode: hivev1.ReportSyncSetEnforcementMode,
+			actualConfigMap: driftedConfigMap,
+			expectedStatusOptions: []syncStatusOption{
+				withDriftedResources(
+					hiveintv1alpha1.DriftedResource{
+						SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name"),
+						Differences:           []string{`data.test-key: expected "test-value", found "other-value"`},
+					},
+					hiveintv1alpha1.DriftedResource{
+						SyncResourceReference: testSecretRef("dest-namespace", "dest-secret"),
+						Differences:           []string{"resource does not exist"},
+					},
+				),
+			},
+		},
+		{
+			name:            "create only",
+			enforcementMode: hivev1.ReportSyncSetEnforcementMode,
+			applyBehavior:   hivev1.CreateOnlySyncSetApplyBehavior,
+			actualConfigMap: driftedConfigMap,
+			expectedStatusOptions: []syncStatusOption{
+				withDriftedResources(
+					hiveintv1alpha1.DriftedResource{
+						SyncResourceReference: testSecretRef("dest-namespace", "dest-secret"),
+						Differences:           []string{"resource does not exist"},
+					},
+				),
+			},
+		},
+		{
+			name:                 "report-only annotation",
+			reportOnlyAnnotation: true,
+			actualConfigMap:      syncedConfigMap,
+			expectedStatusOptions: []syncStatusOption{
+				withDriftedResources(
+					hiveintv1alpha1.DriftedResource{
+						SyncResourceReference: testSecretRef("dest-namespace", "dest-secret"),
+						Differences:           []string{"resource does not exist"},
+					},
+				),
+			},
+		},
+		{
+			name:            "get failure",
+			enforcementMode: hivev1.ReportSyncSetEnforcementMode,
+			getErr:          errors.New("get failed"),
+			expectedStatusOptions: []syncStatusOption{
+				withFailureResult("failed to get ConfigMap dest-namespace/dest-name: get failed"),
+			},
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			configMap := testConfigMap("dest-namespace", "dest-name")
+			configMap.Data = map[string]string{"test-key": "test-value"}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithEnforcementMode(tc.enforcementMode),
+				testsyncset.WithApplyBehavior(tc.applyBehavior),
+				testsyncset.WithResources(configMap),
+				testsyncset.WithSecrets(
+					testSecretMapping("test-secret", "dest-namespace", "dest-secret"),
+				),
+			)
+			srcSecret := testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+				testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+			)
+			cd := cdBuilder(scheme).Build()
+			if tc.reportOnlyAnnotation {
+				cd.Annotations = map[string]string{constants.SyncsetReportOnlyAnnotation: "true"}
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				srcSecret)
+			rt.mockResourceHelper.EXPECT().Get(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "dest-name").
+				Return(tc.actualConfigMap, tc.getErr)
+			if tc.getErr == nil {
+				rt.mockResourceHelper.EXPECT().Get(gomock.Any(), "v1", "Secret", "dest-namespace", "dest-secret").
+					Return(nil, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-syncset", append([]syncStatusOption{withNoFirstSuccessTime()}, tc.expectedStatusOptions...)...),
+			}
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_SyncSetDeleted(t *testing.T) {
 	cases := []struct {
 		name                     string
@@ -871,7 +1364,7 @@ func TestReconcileClusterSync_SyncSetDeleted(t *testing.T) {
 				lease)
 			if tc.expectDelete {
 				rt.mockResourceHelper.EXPECT().
-					Delete("v1", "ConfigMap", "dest-namespace", "dest-name").
+					Delete(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "dest-name", gomock.Any()).
 					Return(nil)
 			}
 			rt.expectUnchangedLeaseRenewTime = true
@@ -929,10 +1422,10 @@ func TestReconcileClusterSync_ResourceRemovedFromSyncSet(t *testing.T) {
 				syncSet,
 				clusterSync,
 				lease)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			if tc.expectDelete {
 				rt.mockResourceHelper.EXPECT().
-					Delete("v1", "ConfigMap", "dest-namespace", "deleted-resource").
+					Delete(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "deleted-resource", gomock.Any()).
 					Return(nil)
 			}
 			expectedSyncStatusBuilder := newSyncStatusBuilder("test-syncset").Options(
@@ -969,8 +1462,8 @@ func TestReconcileClusterSync_ErrorApplyingResource(t *testing.T) {
 			teststatefulset.WithReplicas(3),
 		),
 		syncSet)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).
-		Return(resource.ApplyResult(""), errors.New("test apply error"))
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).
+		Return(nil, errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
 		withFailureResult("failed to apply resource 0: test apply error"),
@@ -1035,8 +1528,8 @@ func TestReconcileClusterSync_ErrorApplyingSecret(t *testing.T) {
 	).Build(
 		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 	)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).
-		Return(resource.ApplyResult(""), errors.New("test apply error"))
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).
+		Return(nil, errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
 		withFailureResult("failed to apply secret 0: test apply error"),
@@ -1070,7 +1563,7 @@ func TestReconcileClusterSync_ErrorApplyingPatch(t *testing.T) {
 			teststatefulset.WithReplicas(3),
 		),
 		syncSet)
-	rt.mockResourceHelper.EXPECT().Patch(
+	rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 		types.NamespacedName{Namespace: "dest-namespace", Name: "dest-name"},
 		"ConfigMap",
 		"v1",
@@ -1220,28 +1713,28 @@ func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
 			var resourceHelperCalls []*gomock.Call
 			for i := 0; i < tc.successfulResources; i++ {
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[i])).
-						Return(resource.CreatedApplyResult, nil))
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourcesToApply[i])).
+						Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil))
 			}
 			if tc.successfulResources < len(resourcesToApply) {
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[tc.successfulResources])).
-						Return(resource.ApplyResult(""), errors.New("test apply error")))
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourcesToApply[tc.successfulResources])).
+						Return(nil, errors.New("test apply error")))
 			}
 			for i := 0; i < tc.successfulSecrets; i++ {
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretsToApply[i])).
-						Return(resource.CreatedApplyResult, nil))
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretsToApply[i])).
+						Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil))
 			}
 			if tc.successfulResources == len(resourcesToApply) && tc.successfulSecrets < len(srcSecrets) {
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretsToApply[tc.successfulSecrets])).
-						Return(resource.ApplyResult(""), errors.New("test apply error")))
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretsToApply[tc.successfulSecrets])).
+						Return(nil, errors.New("test apply error")))
 			}
 			for i := 0; i < tc.successfulPatches; i++ {
 				patch := patchesToApply[i]
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Patch(
+					rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 						types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
 						patch.Kind,
 						patch.APIVersion,
@@ -1252,7 +1745,7 @@ func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
 			if tc.successfulResources == len(resourcesToApply) && tc.successfulSecrets == len(secretsToApply) && tc.successfulPatches < len(patchesToApply) {
 				patch := patchesToApply[tc.successfulPatches]
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Patch(
+					rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 						types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
 						patch.Kind,
 						patch.APIVersion,
@@ -1359,8 +1852,8 @@ func TestReconcileClusterSync_ResourcesToDeleteAreOrdered(t *testing.T) {
 				var resourceHelperCalls []*gomock.Call
 				for _, r := range resourcesToApply {
 					resourceHelperCalls = append(resourceHelperCalls,
-						rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(r)).
-							Return(resource.CreatedApplyResult, nil))
+						rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(r)).
+							Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil))
 				}
 				for _, s := range secretMappings {
 					secretToApply := testsecret.BasicBuilder().GenericOptions(
@@ -1371,15 +1864,15 @@ func TestReconcileClusterSync_ResourcesToDeleteAreOrdered(t *testing.T) {
 						testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 					)
 					resourceHelperCalls = append(resourceHelperCalls,
-						rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).
-							Return(resource.CreatedApplyResult, nil))
+						rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).
+							Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil))
 				}
 				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "namespace-A", "resource-failing-to-delete-A").
+					rt.mockResourceHelper.EXPECT().Delete(gomock.Any(), "v1", "ConfigMap", "namespace-A", "resource-failing-to-delete-A", gomock.Any()).
 						Return(errors.New("error deleting resource")),
-					rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "namespace-A", "resource-failing-to-delete-B").
+					rt.mockResourceHelper.EXPECT().Delete(gomock.Any(), "v1", "ConfigMap", "namespace-A", "resource-failing-to-delete-B", gomock.Any()).
 						Return(errors.New("error deleting resource")),
-					rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "namespace-B", "resource-failing-to-delete-A").
+					rt.mockResourceHelper.EXPECT().Delete(gomock.Any(), "v1", "ConfigMap", "namespace-B", "resource-failing-to-delete-A", gomock.Any()).
 						Return(errors.New("error deleting resource")),
 				)
 				gomock.InOrder(resourceHelperCalls...)
@@ -1475,11 +1968,11 @@ func TestReconcileClusterSync_FailingSyncSetDoesNotBlockOtherSyncSets(t *testing
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
 			for i, r := range resourcesToApply {
 				if i == tc.failingSyncSet {
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(r)).
-						Return(resource.ApplyResult(""), errors.New("test apply error"))
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(r)).
+						Return(nil, errors.New("test apply error"))
 				} else {
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(r)).
-						Return(resource.CreatedApplyResult, nil)
+					rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(r)).
+						Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 				}
 			}
 			rt.expectedFailedMessage = fmt.Sprintf("SyncSet test-syncset-%d is failing", tc.failingSyncSet)
@@ -1565,8 +2058,8 @@ func TestReconcileClusterSync_FailureMessage(t *testing.T) {
 			existing = append(existing, syncSets...)
 			existing = append(existing, selectorSyncSets...)
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
-			rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).
-				Return(resource.ApplyResult(""), errors.New("test apply error")).
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("test apply error")).
 				Times(tc.failingSyncSets + tc.failingSelectorSyncSets)
 			rt.expectedFailedMessage = tc.expectedFailedMessage
 			if tc.failingSyncSets > 0 {
@@ -1593,33 +2086,112 @@ func TestReconcileClusterSync_FailureMessage(t *testing.T) {
 	}
 }
 
-func TestReconcileClusterSync_PartialApply(t *testing.T) {
-	cases := []struct {
-		name               string
-		existingSyncStatus hiveintv1alpha1.SyncStatus
-		expectedSyncStatus hiveintv1alpha1.SyncStatus
-	}{
-		{
-			name: "last apply failed",
-			existingSyncStatus: buildSyncStatus("test-syncset",
-				withObservedGeneration(2),
-				withFailureResult("existing failure"),
-				withTransitionInThePast(),
-			),
-			expectedSyncStatus: buildSyncStatus("test-syncset",
-				withObservedGeneration(2),
-			),
+func TestReconcileClusterSync_ApplyResourcesInDependencyOrder(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	configMap := testConfigMap("dest-namespace", "dest-name")
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
 		},
-		{
-			name: "syncset generation changed",
-			existingSyncStatus: buildSyncStatus("test-syncset",
-				withObservedGeneration(1),
-				withTransitionInThePast(),
-				withFirstSuccessTimeInThePast(),
-			),
-			expectedSyncStatus: buildSyncStatus("test-syncset",
-				withObservedGeneration(2),
-				withFirstSuccessTimeInThePast(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dest-namespace",
+		},
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(configMap, namespace),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	gomock.InOrder(
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(namespace)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(configMap)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+	)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		newSyncStatusBuilder("test-syncset").Build(),
+	}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ApplyResourcesWithWorkers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	configMaps := []*corev1.ConfigMap{
+		testConfigMap("dest-namespace", "dest-name-0"),
+		testConfigMap("dest-namespace", "dest-name-1"),
+		testConfigMap("dest-namespace", "dest-name-2"),
+	}
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dest-namespace",
+		},
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(configMaps[0], configMaps[1], configMaps[2], namespace),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.r.applyWorkers = newWorkerPool(2)
+	namespaceApply := rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(namespace)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+	for _, cm := range configMaps {
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(cm)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil).After(namespaceApply)
+	}
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		newSyncStatusBuilder("test-syncset").Build(),
+	}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_PartialApply(t *testing.T) {
+	cases := []struct {
+		name               string
+		existingSyncStatus hiveintv1alpha1.SyncStatus
+		expectedSyncStatus hiveintv1alpha1.SyncStatus
+	}{
+		{
+			name: "last apply failed",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFailureResult("existing failure"),
+				withTransitionInThePast(),
+			),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+			),
+		},
+		{
+			name: "syncset generation changed",
+			existingSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(1),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
 			),
 		},
 	}
@@ -1644,7 +2216,7 @@ func TestReconcileClusterSync_PartialApply(t *testing.T) {
 				), syncSet,
 				clusterSync,
 				syncLease)
-			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
 			rt.expectUnchangedLeaseRenewTime = true
 			rt.run(t)
@@ -1672,7 +2244,7 @@ func TestReconcileClusterSync_ErrorDeleting(t *testing.T) {
 		clusterSync,
 		lease)
 	rt.mockResourceHelper.EXPECT().
-		Delete("v1", "ConfigMap", "dest-namespace", "dest-name").
+		Delete(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "dest-name", gomock.Any()).
 		Return(errors.New("error deleting resource"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
@@ -1733,10 +2305,10 @@ func TestReconcileClusterSync_DeleteErrorDoesNotBlockOtherDeletes(t *testing.T)
 			}
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
 			rt.mockResourceHelper.EXPECT().
-				Delete("v1", "ConfigMap", "dest-namespace", "failing-resource").
+				Delete(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "failing-resource", gomock.Any()).
 				Return(errors.New("error deleting resource"))
 			rt.mockResourceHelper.EXPECT().
-				Delete("v1", "ConfigMap", "dest-namespace", "successful-resource").
+				Delete(gomock.Any(), "v1", "ConfigMap", "dest-namespace", "successful-resource", gomock.Any()).
 				Return(nil)
 			rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 			expectedSyncSetStatusBuilder := newSyncStatusBuilder("test-syncset").Options(
@@ -1817,16 +2389,16 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 			)
 			switch tc.applyBehavior {
 			case hivev1.ApplySyncSetApplyBehavior:
-				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
-				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			case hivev1.CreateOnlySyncSetApplyBehavior:
-				rt.mockResourceHelper.EXPECT().Create(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
-				rt.mockResourceHelper.EXPECT().Create(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Create(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+				rt.mockResourceHelper.EXPECT().Create(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
-				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
-				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().CreateOrUpdate(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+				rt.mockResourceHelper.EXPECT().CreateOrUpdate(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 			}
-			rt.mockResourceHelper.EXPECT().Patch(
+			rt.mockResourceHelper.EXPECT().Patch(gomock.Any(),
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
 				"PatchKind",
 				"patch-api/v1",
@@ -1869,6 +2441,24 @@ func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 			testConfigMap("dest-namespace", "resource-from-non-applicable-selectorsyncset"),
 		),
 	)
+	negativeSelectorSyncSet := testselectorsyncset.FullBuilder("negative-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelectorRequirement("test-label-key", metav1.LabelSelectorOpNotIn, "test-label-value"),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResources(
+			testConfigMap("dest-namespace", "resource-from-negative-selectorsyncset"),
+		),
+	)
+	excludingSelectorSyncSet := testselectorsyncset.FullBuilder("excluding-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithExcludedClusterDeployments(hivev1.SelectorSyncSetClusterDeploymentReference{
+			Namespace: testNamespace,
+			Name:      testCDName,
+		}),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResources(
+			testConfigMap("dest-namespace", "resource-from-excluding-selectorsyncset"),
+		),
+	)
 	rt := newReconcileTest(t, mockCtrl, scheme,
 		cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
 		clusterSyncBuilder(scheme).Build(),
@@ -1880,9 +2470,11 @@ func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 		nonApplicableSyncSet,
 		applicableSelectorSyncSet,
 		nonApplicableSelectorSyncSet,
+		negativeSelectorSyncSet,
+		excludingSelectorSyncSet,
 	)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(syncSetResourceToApply)).Return(resource.CreatedApplyResult, nil)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(selectorSyncSetResourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(syncSetResourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(selectorSyncSetResourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("applicable-syncset")}
 	rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("applicable-selectorsyncset")}
 	rt.run(t)
@@ -1922,7 +2514,7 @@ func TestReconcileClusterSync_ApplySecretForSelectorSyncSet(t *testing.T) {
 	).Build(
 		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 	)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-selectorsyncset")}
 	rt.run(t)
 }
@@ -1992,7 +2584,7 @@ func TestReconcileClusterSync_ValidSecretNamespaceForSyncSet(t *testing.T) {
 	).Build(
 		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 	)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secretToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
 	rt.run(t)
 }
@@ -2059,6 +2651,318 @@ func TestReconcileClusterSync_MissingSourceSecret(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_ApplyResourcesFromConfigMap(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	inlineResource := testConfigMap("dest-namespace", "inline")
+	firstResource := testConfigMap("dest-namespace", "first")
+	secondResource := testConfigMap("dest-namespace", "second")
+	thirdResource := testConfigMap("dest-namespace", "third")
+	resourcesConfigMap := testConfigMap(testNamespace, "test-resources")
+	resourcesConfigMap.Data = map[string]string{
+		"b": testResourcesYAML(t, thirdResource),
+		"a": testResourcesYAML(t, firstResource, secondResource),
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(inlineResource),
+		testsyncset.WithResourcesFrom(hivev1.SyncSetResourcesSource{
+			ConfigMapRef: &hivev1.ConfigMapReference{Name: "test-resources"},
+		}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		resourcesConfigMap)
+	gomock.InOrder(
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(inlineResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(firstResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(secondResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+		rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(thirdResource)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil),
+	)
+	_, checksum, err := rt.r.resolveResources((*SyncSetAsCommon)(syncSet), cdBuilder(scheme).Build(), rt.logger)
+	require.NoError(t, err, "unexpected error resolving resources")
+	require.NotEmpty(t, checksum, "expected checksum of referenced resources")
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withResourcesChecksum(checksum),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ResourcesFromConfigMapChanged(t *testing.T) {
+	cases := []struct {
+		name    string
+		changed bool
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name:    "changed",
+			changed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			oldResource := testConfigMap("dest-namespace", "dest-name")
+			oldResource.Data = map[string]string{"key": "old-value"}
+			newResource := testConfigMap("dest-namespace", "dest-name")
+			newResource.Data = map[string]string{"key": "new-value"}
+			resourcesConfigMap := testConfigMap(testNamespace, "test-resources")
+			resourcesConfigMap.Data = map[string]string{"resources": testResourcesYAML(t, oldResource)}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResourcesFrom(hivev1.SyncSetResourcesSource{
+					ConfigMapRef: &hivev1.ConfigMapReference{Name: "test-resources"},
+				}),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme, resourcesConfigMap.DeepCopy())
+			_, oldChecksum, err := rt.r.resolveResources((*SyncSetAsCommon)(syncSet), cdBuilder(scheme).Build(), rt.logger)
+			require.NoError(t, err, "unexpected error resolving resources")
+			if tc.changed {
+				resourcesConfigMap.Data = map[string]string{"resources": testResourcesYAML(t, newResource)}
+			}
+			rt = newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+						withResourcesChecksum(oldChecksum),
+						withAppliedResources(buildAppliedResource(t, oldResource)),
+					)),
+				),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				resourcesConfigMap,
+				buildSyncLease(time.Now().Add(-time.Hour)),
+			)
+			expectedStatus := buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withResourcesChecksum(oldChecksum),
+				withAppliedResources(buildAppliedResource(t, oldResource)),
+			)
+			if tc.changed {
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(newResource)).Return(&resource.AppliedObject{Result: resource.ConfiguredApplyResult}, nil)
+				_, newChecksum, err := rt.r.resolveResources((*SyncSetAsCommon)(syncSet), cdBuilder(scheme).Build(), rt.logger)
+				require.NoError(t, err, "unexpected error resolving resources")
+				assert.NotEqual(t, oldChecksum, newChecksum, "expected checksum to change with the configmap")
+				expectedStatus = buildSyncStatus("test-syncset",
+					withFirstSuccessTimeInThePast(),
+					withResourcesChecksum(newChecksum),
+					withAppliedResources(buildAppliedResource(t, newResource)),
+				)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedStatus}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_MissingResourcesFromConfigMap(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResourcesFrom(hivev1.SyncSetResourcesSource{
+			ConfigMapRef: &hivev1.ConfigMapReference{Name: "test-resources"},
+		}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult(`failed to get resources from source 0: failed to read configmap: configmaps "test-resources" not found`),
+		withNoFirstSuccessTime(),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ApplyResourceTemplates(t *testing.T) {
+	cases := []struct {
+		name                  string
+		value                 string
+		expectedValue         string
+		expectedFailureResult string
+	}{
+		{
+			name:          "rendered",
+			value:         `{{ .Name }}-{{ index .Labels "env" }}`,
+			expectedValue: testCDName + "-prod",
+		},
+		{
+			name:                  "unknown value",
+			value:                 "{{ .Unknown }}",
+			expectedFailureResult: "failed to render resource 0: template: resource:1:3: executing \"resource\" at <.Unknown>: can't evaluate field Unknown in type *clustersync.resourceTemplateValues",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			templatedResource := testConfigMap("dest-namespace", "dest-name")
+			templatedResource.Data = map[string]string{"key": tc.value}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(templatedResource),
+				testsyncset.WithResourceTemplates(),
+			)
+			cd := cdBuilder(scheme).Build(testcd.WithLabel("env", "prod"))
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			if tc.expectedFailureResult != "" {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult(tc.expectedFailureResult),
+					withNoFirstSuccessTime(),
+				)}
+				rt.expectRequeue = true
+				rt.run(t)
+				return
+			}
+			renderedResource := testConfigMap("dest-namespace", "dest-name")
+			renderedResource.Data = map[string]string{"key": tc.expectedValue}
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(renderedResource)).
+				Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+			_, checksum, err := rt.r.resolveResources((*SyncSetAsCommon)(syncSet), cd, rt.logger)
+			require.NoError(t, err, "unexpected error resolving resources")
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesChecksum(checksum),
+			)}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ApplyResourcesFromURL(t *testing.T) {
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	content := testResourcesYAML(t, resourceToApply)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/resources.yaml":
+			fmt.Fprint(w, content)
+		case "/redirect":
+			http.Redirect(w, req, "https://example.com/resources.yaml", http.StatusFound)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+	contentSHA256 := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	wrongSHA256 := fmt.Sprintf("%x", sha256.Sum256([]byte("wrong")))
+
+	cases := []struct {
+		name                   string
+		path                   string
+		sha256                 string
+		allowedURLs            []string
+		expectedFailureMessage string
+	}{
+		{
+			name:   "valid",
+			path:   "/resources.yaml",
+			sha256: contentSHA256,
+		},
+		{
+			name:                   "not allowed",
+			path:                   "/resources.yaml",
+			sha256:                 contentSHA256,
+			allowedURLs:            []string{server.URL + "/other/"},
+			expectedFailureMessage: fmt.Sprintf("failed to get resources from source 0: url %s/resources.yaml is not allowed by the syncSetResourcesFromAllowedURLs of the HiveConfig", server.URL),
+		},
+		{
+			name:                   "redirect not allowed",
+			path:                   "/redirect",
+			sha256:                 contentSHA256,
+			expectedFailureMessage: `failed to get resources from source 0: failed to download resources: Get "https://example.com/resources.yaml": redirect to https://example.com/resources.yaml is not allowed by the syncSetResourcesFromAllowedURLs of the HiveConfig`,
+		},
+		{
+			name:                   "checksum mismatch",
+			path:                   "/resources.yaml",
+			sha256:                 wrongSHA256,
+			expectedFailureMessage: fmt.Sprintf("failed to get resources from source 0: checksum of downloaded resources %s does not match %s", contentSHA256, wrongSHA256),
+		},
+		{
+			name:                   "not found",
+			path:                   "/missing.yaml",
+			sha256:                 contentSHA256,
+			expectedFailureMessage: "failed to get resources from source 0: failed to download resources: 404 Not Found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResourcesFrom(hivev1.SyncSetResourcesSource{
+					URL:    server.URL + tc.path,
+					SHA256: tc.sha256,
+				}),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			rt.r.allowedResourcesFromURLs = tc.allowedURLs
+			if rt.r.allowedResourcesFromURLs == nil {
+				rt.r.allowedResourcesFromURLs = []string{server.URL + "/"}
+			}
+			if tc.expectedFailureMessage == "" {
+				rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+			} else {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult(tc.expectedFailureMessage),
+					withNoFirstSuccessTime(),
+				)}
+				rt.expectRequeue = true
+			}
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -2082,6 +2986,14 @@ func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testin
 			LastTransitionTime: timeInThePast,
 			LastProbeTime:      timeInThePast,
 		}),
+		testcs.WithCondition(hiveintv1alpha1.ClusterSyncCondition{
+			Type:               hiveintv1alpha1.ClusterSyncReady,
+			Status:             corev1.ConditionFalse,
+			Reason:             "Failure",
+			Message:            "SyncSet test-syncset is failing",
+			LastTransitionTime: timeInThePast,
+			LastProbeTime:      timeInThePast,
+		}),
 	)
 	rt := newReconcileTest(t, mockCtrl, scheme,
 		cdBuilder(scheme).Build(),
@@ -2091,8 +3003,8 @@ func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testin
 		),
 		existingClusterSync,
 		syncSet)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).
-		Return(resource.ApplyResult(""), errors.New("test apply error"))
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).
+		Return(nil, errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
 		withFailureResult("failed to apply resource 0: test apply error"),
@@ -2103,12 +3015,141 @@ func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testin
 	actualClusterSync := &hiveintv1alpha1.ClusterSync{}
 	err := rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, actualClusterSync)
 	require.NoError(t, err, "unexpected error getting ClusterSync")
-	require.Len(t, actualClusterSync.Status.Conditions, 1, "expected exactly 1 condition")
+	require.Len(t, actualClusterSync.Status.Conditions, 2, "expected exactly 2 conditions")
 	cond := actualClusterSync.Status.Conditions[0]
 	require.Equal(t, hiveintv1alpha1.ClusterSyncFailed, cond.Type, "expected Failed condition")
 	require.Equal(t, string(corev1.ConditionTrue), string(cond.Status), "expected Failed condition to be true")
 	assert.Equal(t, timeInThePast, cond.LastTransitionTime, "expected no change in last transition time")
 	assert.Equal(t, timeInThePast, cond.LastProbeTime, "expected no change in last probe time")
+	cond = actualClusterSync.Status.Conditions[1]
+	require.Equal(t, hiveintv1alpha1.ClusterSyncReady, cond.Type, "expected Ready condition")
+	assert.Equal(t, timeInThePast, cond.LastTransitionTime, "expected no change in last transition time of Ready condition")
+}
+
+func TestReconcileClusterSync_SyncStale(t *testing.T) {
+	staleTime := metav1.NewMicroTime(time.Now().Add(-7 * time.Hour).Truncate(time.Microsecond))
+	cases := []struct {
+		name                    string
+		renewTime               time.Time
+		lastSuccessTime         *metav1.MicroTime
+		existingStatus          hiveintv1alpha1.SyncStatus
+		existingStaleCondition  bool
+		applyErr                error
+		expectLastSuccessUpdate bool
+		expectStaleCondition    corev1.ConditionStatus
+	}{
+		{
+			name:                    "success",
+			renewTime:               time.Now().Add(-3 * time.Hour),
+			lastSuccessTime:         &staleTime,
+			existingStatus:          buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			expectLastSuccessUpdate: true,
+		},
+		{
+			name:                 "failure",
+			renewTime:            time.Now().Add(-3 * time.Hour),
+			lastSuccessTime:      &staleTime,
+			existingStatus:       buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			applyErr:             errors.New("test apply error"),
+			expectStaleCondition: corev1.ConditionTrue,
+		},
+		{
+			name:      "failure before stale threshold",
+			renewTime: time.Now().Add(-3 * time.Hour),
+			lastSuccessTime: func() *metav1.MicroTime {
+				t := metav1.NewMicroTime(time.Now().Add(-time.Hour).Truncate(time.Microsecond))
+				return &t
+			}(),
+			existingStatus: buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			applyErr:       errors.New("test apply error"),
+		},
+		{
+			name:            "recovered after failed reapply",
+			renewTime:       time.Now().Add(-time.Hour),
+			lastSuccessTime: &staleTime,
+			existingStatus: buildSyncStatus("test-syncset",
+				withFailureResult("failed to apply"),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			),
+			existingStaleCondition:  true,
+			expectLastSuccessUpdate: true,
+			expectStaleCondition:    corev1.ConditionFalse,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(resourceToApply),
+			)
+			csOpts := []testcs.Option{testcs.WithSyncSetStatus(tc.existingStatus)}
+			if tc.existingStaleCondition {
+				csOpts = append(csOpts, testcs.WithCondition(hiveintv1alpha1.ClusterSyncCondition{
+					Type:               hiveintv1alpha1.ClusterSyncStale,
+					Status:             corev1.ConditionTrue,
+					Reason:             "SyncStale",
+					LastTransitionTime: timeInThePast,
+					LastProbeTime:      timeInThePast,
+				}))
+			}
+			lease := buildSyncLease(tc.renewTime)
+			lease.Spec.LastSuccessTime = tc.lastSuccessTime
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(csOpts...),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				lease,
+				syncSet)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourceToApply)).
+				Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, tc.applyErr)
+			if tc.applyErr != nil {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult("failed to apply resource 0: test apply error"),
+					withFirstSuccessTimeInThePast(),
+				)}
+				rt.expectRequeue = true
+			} else {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.existingStatus}
+				if tc.existingStatus.Result != hiveintv1alpha1.SuccessSyncSetResult {
+					rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+						withFirstSuccessTimeInThePast(),
+					)}
+				}
+			}
+			rt.expectUnchangedLeaseRenewTime = tc.renewTime.After(time.Now().Add(-defaultReapplyInterval))
+			startTime := time.Now()
+			rt.run(t)
+
+			actualLease := &hiveintv1alpha1.ClusterSyncLease{}
+			err := rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testLeaseName}, actualLease)
+			require.NoError(t, err, "unexpected error getting lease")
+			require.NotNil(t, actualLease.Spec.LastSuccessTime, "expected last success time")
+			if tc.expectLastSuccessUpdate {
+				assert.False(t, actualLease.Spec.LastSuccessTime.Time.Before(startTime.Truncate(time.Microsecond)), "expected last success time to be updated")
+			} else {
+				assert.True(t, actualLease.Spec.LastSuccessTime.Equal(tc.lastSuccessTime), "expected last success time to be unchanged")
+			}
+			actualClusterSync := &hiveintv1alpha1.ClusterSync{}
+			err = rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, actualClusterSync)
+			require.NoError(t, err, "unexpected error getting ClusterSync")
+			cond := getCondition(actualClusterSync, hiveintv1alpha1.ClusterSyncStale)
+			if tc.expectStaleCondition == "" {
+				assert.Nil(t, cond, "expected no SyncStale condition")
+			} else if assert.NotNil(t, cond, "expected SyncStale condition") {
+				assert.Equal(t, string(tc.expectStaleCondition), string(cond.Status), "unexpected SyncStale condition status")
+			}
+		})
+	}
 }
 
 func TestReconcileClusterSync_FirstSuccessTime(t *testing.T) {
@@ -2200,8 +3241,8 @@ func TestReconcileClusterSync_NoFirstSuccessTimeSet(t *testing.T) {
 			withNoFirstSuccessTime(),
 			withFailureResult("failed to apply resource 0: test apply error")),
 	}
-	rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).
-		Return(resource.ApplyResult(""), errors.New("test apply error")).Times(1)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("test apply error")).Times(1)
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectUnchangedLeaseRenewTime = true
 	rt.expectRequeue = true
@@ -2280,7 +3321,7 @@ func TestReconcileClusterSync_SyncToUpsertResourceApplyMode(t *testing.T) {
 		syncSet,
 		clusterSync,
 		syncLease)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourcesToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
 		buildSyncStatus("test-syncset",
 			withFirstSuccessTimeInThePast(),
@@ -2330,7 +3371,7 @@ func TestReconcileClusterSync_UpsertToSyncResourceApplyMode(t *testing.T) {
 		syncSet,
 		clusterSync,
 		syncLease)
-	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(resourcesToApply)).Return(&resource.AppliedObject{Result: resource.CreatedApplyResult}, nil)
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
 		buildSyncStatus("test-syncset",
 			withFirstSuccessTimeInThePast(),
@@ -2415,6 +3456,17 @@ func testConfigMap(namespace, name string) *corev1.ConfigMap {
 	}
 }
 
+// testResourcesYAML returns the objects as a YAML stream, with an empty document between each object.
+func testResourcesYAML(t *testing.T, objs ...hivev1.MetaRuntimeObject) string {
+	var docs []string
+	for _, obj := range objs {
+		doc, err := yaml.Marshal(obj)
+		require.NoError(t, err, "could not marshal resource to YAML")
+		docs = append(docs, string(doc))
+	}
+	return strings.Join(docs, "---\n---\n")
+}
+
 func testConfigMapRef(namespace, name string) hiveintv1alpha1.SyncResourceReference {
 	return hiveintv1alpha1.SyncResourceReference{
 		APIVersion: "v1",
@@ -2567,6 +3619,44 @@ func withResourcesToDelete(resourcesToDelete ...hiveintv1alpha1.SyncResourceRefe
 	}
 }
 
+func withAppliedResources(appliedResources ...hiveintv1alpha1.AppliedResource) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.AppliedResources = appliedResources
+	}
+}
+
+func withResourcesChecksum(checksum string) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.ResourcesChecksum = checksum
+	}
+}
+
+func withDriftedResources(driftedResources ...hiveintv1alpha1.DriftedResource) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.DriftedResources = driftedResources
+	}
+}
+
+func buildAppliedResource(t *testing.T, obj hivev1.MetaRuntimeObject) hiveintv1alpha1.AppliedResource {
+	// Hash the resource as the controller does, after decoding the resource from the syncset as unstructured.
+	objAsJSON, err := json.Marshal(obj)
+	require.NoError(t, err, "unexpected error marshalling resource to JSON")
+	u := &unstructured.Unstructured{}
+	require.NoError(t, json.Unmarshal(objAsJSON, u), "unexpected error unmarshalling resource as unstructured")
+	content, err := prepareForApply(u)
+	require.NoError(t, err, "unexpected error preparing resource for apply")
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return hiveintv1alpha1.AppliedResource{
+		SyncResourceReference: hiveintv1alpha1.SyncResourceReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		},
+		Hash: appliedContentHash(content),
+	}
+}
+
 func withTransitionInThePast() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.LastTransitionTime = timeInThePast
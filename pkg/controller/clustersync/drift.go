@@ -0,0 +1,130 @@
+package clustersync
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	// maxDifferencesPerResource is the maximum number of differences reported for a single resource, to keep the
+	// ClusterSync status from growing too large when a resource has drifted substantially.
+	maxDifferencesPerResource = 10
+
+	differenceResourceMissing = "resource does not exist"
+)
+
+// findDifferences returns descriptions of the fields of the actual object that differ from the desired object. Only the
+// fields that are set in the desired object are compared, so fields that are defaulted or set by the cluster are not
+// reported. The status and the metadata other than the labels and annotations are ignored. When redact is true, the
+// values of the fields are left out of the descriptions.
+func findDifferences(desired, actual map[string]interface{}, redact bool) []string {
+	if actual == nil {
+		return []string{differenceResourceMissing}
+	}
+	var differences []string
+	for _, key := range sortedKeys(desired) {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			desiredMetadata, _ := desired[key].(map[string]interface{})
+			actualMetadata, _ := actual[key].(map[string]interface{})
+			for _, metadataKey := range []string{"labels", "annotations"} {
+				differences = append(differences,
+					compareValues("metadata."+metadataKey, desiredMetadata[metadataKey], actualMetadata[metadataKey], redact)...)
+			}
+			continue
+		}
+		differences = append(differences, compareValues(key, desired[key], actual[key], redact)...)
+	}
+	if len(differences) > maxDifferencesPerResource {
+		more := len(differences) - maxDifferencesPerResource
+		differences = append(differences[:maxDifferencesPerResource], fmt.Sprintf("and %d more", more))
+	}
+	return differences
+}
+
+func compareValues(path string, desired, actual interface{}, redact bool) []string {
+	if desired == nil {
+		return nil
+	}
+	if actual == nil {
+		return []string{fmt.Sprintf("%s: missing", path)}
+	}
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{describeDifference(path, desired, actual, redact)}
+		}
+		var differences []string
+		for _, key := range sortedKeys(d) {
+			differences = append(differences, compareValues(path+"."+key, d[key], a[key], redact)...)
+		}
+		return differences
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(d) {
+			return []string{describeDifference(path, desired, actual, redact)}
+		}
+		var differences []string
+		for i := range d {
+			differences = append(differences, compareValues(fmt.Sprintf("%s[%d]", path, i), d[i], a[i], redact)...)
+		}
+		return differences
+	default:
+		if reflect.DeepEqual(desired, actual) {
+			return nil
+		}
+		return []string{describeDifference(path, desired, actual, redact)}
+	}
+}
+
+func describeDifference(path string, desired, actual interface{}, redact bool) string {
+	if redact {
+		return fmt.Sprintf("%s: differs", path)
+	}
+	return fmt.Sprintf("%s: expected %s, found %s", path, formatValue(desired), formatValue(actual))
+}
+
+func formatValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countNewlyDrifted returns the number of drifted resources which are not among the previously drifted resources.
+func countNewlyDrifted(previous, current []hiveintv1alpha1.DriftedResource) int {
+	drifted := make(map[hiveintv1alpha1.SyncResourceReference]bool, len(previous))
+	for _, resource := range previous {
+		drifted[resource.SyncResourceReference] = true
+	}
+	count := 0
+	for _, resource := range current {
+		if !drifted[resource.SyncResourceReference] {
+			count++
+		}
+	}
+	return count
+}
+
+// isRedacted returns true if the values of the fields of the resource must not be reported.
+func isRedacted(kind string) bool {
+	return strings.EqualFold(kind, secretKind)
+}
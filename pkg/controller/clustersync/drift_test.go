@@ -0,0 +1,193 @@
+package clustersync
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+)
+
+func TestFindDifferences(t *testing.T) {
+	manyKeys := map[string]interface{}{}
+	for i := 0; i < 12; i++ {
+		manyKeys[fmt.Sprintf("key-%02d", i)] = "value"
+	}
+	cases := []struct {
+		name     string
+		desired  map[string]interface{}
+		actual   map[string]interface{}
+		redact   bool
+		expected []string
+	}{
+		{
+			name:     "missing resource",
+			desired:  map[string]interface{}{"data": map[string]interface{}{"key": "value"}},
+			expected: []string{"resource does not exist"},
+		},
+		{
+			name: "no differences",
+			desired: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"data":       map[string]interface{}{"key": "value"},
+			},
+			actual: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"data":       map[string]interface{}{"key": "value"},
+			},
+		},
+		{
+			name: "nested differences",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"template": map[string]interface{}{"image": "image:v2"},
+					"ports":    []interface{}{int64(80), int64(443)},
+					"extra":    "value",
+				},
+			},
+			actual: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"template": map[string]interface{}{"image": "image:v1"},
+					"ports":    []interface{}{int64(80), int64(8443)},
+				},
+			},
+			expected: []string{
+				"spec.extra: missing",
+				"spec.ports[1]: expected 443, found 8443",
+				"spec.replicas: expected 3, found 1",
+				`spec.template.image: expected "image:v2", found "image:v1"`,
+			},
+		},
+		{
+			name: "slices of different lengths",
+			desired: map[string]interface{}{
+				"items": []interface{}{"a", "b"},
+			},
+			actual: map[string]interface{}{
+				"items": []interface{}{"a"},
+			},
+			expected: []string{`items: expected ["a","b"], found ["a"]`},
+		},
+		{
+			name: "ignored fields",
+			desired: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Thing",
+				"metadata": map[string]interface{}{
+					"name":        "test",
+					"labels":      map[string]interface{}{"label": "value"},
+					"annotations": map[string]interface{}{"annotation": "value"},
+				},
+				"status": map[string]interface{}{"phase": "Ready"},
+			},
+			actual: map[string]interface{}{
+				"apiVersion": "v2",
+				"kind":       "Thing",
+				"metadata": map[string]interface{}{
+					"name":            "test",
+					"resourceVersion": "1234",
+					"labels":          map[string]interface{}{"label": "value", "other-label": "value"},
+				},
+				"status": map[string]interface{}{"phase": "NotReady"},
+			},
+			expected: []string{"metadata.annotations: missing"},
+		},
+		{
+			name: "fields set only in the cluster",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(3)},
+			},
+			actual: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(3), "strategy": "RollingUpdate"},
+			},
+		},
+		{
+			name: "redacted",
+			desired: map[string]interface{}{
+				"data": map[string]interface{}{"password": "c2VjcmV0"},
+			},
+			actual: map[string]interface{}{
+				"data": map[string]interface{}{"password": "b3RoZXI="},
+			},
+			redact:   true,
+			expected: []string{"data.password: differs"},
+		},
+		{
+			name:    "truncated",
+			desired: map[string]interface{}{"data": manyKeys},
+			actual:  map[string]interface{}{"data": map[string]interface{}{}},
+			expected: []string{
+				"data.key-00: missing",
+				"data.key-01: missing",
+				"data.key-02: missing",
+				"data.key-03: missing",
+				"data.key-04: missing",
+				"data.key-05: missing",
+				"data.key-06: missing",
+				"data.key-07: missing",
+				"data.key-08: missing",
+				"data.key-09: missing",
+				"and 2 more",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := findDifferences(tc.desired, tc.actual, tc.redact)
+			assert.Equal(t, tc.expected, actual, "unexpected differences")
+		})
+	}
+}
+
+func TestCountNewlyDrifted(t *testing.T) {
+	configMap := hiveintv1alpha1.DriftedResource{
+		SyncResourceReference: hiveintv1alpha1.SyncResourceReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns", Name: "cm"},
+		Differences:           []string{`data.key: expected "a", found "b"`},
+	}
+	configMapOtherDifference := configMap
+	configMapOtherDifference.Differences = []string{`data.key: expected "a", found "c"`}
+	secret := hiveintv1alpha1.DriftedResource{
+		SyncResourceReference: hiveintv1alpha1.SyncResourceReference{APIVersion: "v1", Kind: "Secret", Namespace: "ns", Name: "secret"},
+		Differences:           []string{differenceResourceMissing},
+	}
+	cases := []struct {
+		name     string
+		previous []hiveintv1alpha1.DriftedResource
+		current  []hiveintv1alpha1.DriftedResource
+		expected int
+	}{
+		{
+			name: "no drift",
+		},
+		{
+			name:     "new drift",
+			current:  []hiveintv1alpha1.DriftedResource{configMap, secret},
+			expected: 2,
+		},
+		{
+			name:     "drift already reported",
+			previous: []hiveintv1alpha1.DriftedResource{configMap, secret},
+			current:  []hiveintv1alpha1.DriftedResource{configMapOtherDifference, secret},
+		},
+		{
+			name:     "additional drift",
+			previous: []hiveintv1alpha1.DriftedResource{configMap},
+			current:  []hiveintv1alpha1.DriftedResource{configMap, secret},
+			expected: 1,
+		},
+		{
+			name:     "drift resolved",
+			previous: []hiveintv1alpha1.DriftedResource{configMap, secret},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, countNewlyDrifted(tc.previous, tc.current))
+		})
+	}
+}
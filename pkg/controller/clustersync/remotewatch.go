@@ -0,0 +1,120 @@
+package clustersync
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+// remoteWatcher watches the resources applied to the remote clusters, so that the resources changed or deleted in a
+// remote cluster are re-applied immediately rather than at the next full re-apply. It is implemented by
+// remoteclient.Watcher.
+type remoteWatcher interface {
+	Watch(cd *hivev1.ClusterDeployment, builder remoteclient.Builder, resources []remoteclient.WatchedResource) error
+	StopWatching(key types.NamespacedName)
+	TakeChanges(key types.NamespacedName) []remoteclient.ObjectReference
+	Source() source.Source
+}
+
+// watchRemoteCluster ensures that the resources applied to the cluster are watched.
+func (r *ReconcileClusterSync) watchRemoteCluster(cd *hivev1.ClusterDeployment, clusterSync *hiveintv1alpha1.ClusterSync, logger log.FieldLogger) {
+	if r.remoteWatcher == nil {
+		return
+	}
+	// A failure to watch the remote cluster only delays the re-apply of the resources changed in the remote cluster
+	// until the next full re-apply.
+	if err := r.remoteWatcher.Watch(cd, r.remoteClusterAPIClientBuilder(cd), watchedResources(clusterSync)); err != nil {
+		logger.WithError(err).Warn("could not watch the resources applied to the remote cluster")
+	}
+}
+
+// stopWatchingRemoteCluster stops watching the remote cluster of the ClusterDeployment.
+func (r *ReconcileClusterSync) stopWatchingRemoteCluster(key types.NamespacedName) {
+	if r.remoteWatcher != nil {
+		r.remoteWatcher.StopWatching(key)
+	}
+}
+
+// takeChangedResources returns the resources changed or deleted in the remote cluster since the last reconcile.
+func (r *ReconcileClusterSync) takeChangedResources(key types.NamespacedName) []hiveintv1alpha1.SyncResourceReference {
+	if r.remoteWatcher == nil {
+		return nil
+	}
+	changes := r.remoteWatcher.TakeChanges(key)
+	if len(changes) == 0 {
+		return nil
+	}
+	changedResources := make([]hiveintv1alpha1.SyncResourceReference, len(changes))
+	for i, change := range changes {
+		changedResources[i] = hiveintv1alpha1.SyncResourceReference{
+			APIVersion: change.APIVersion,
+			Kind:       change.Kind,
+			Namespace:  change.Namespace,
+			Name:       change.Name,
+		}
+	}
+	return changedResources
+}
+
+// watchedResources returns the resources applied to the cluster by the syncsets, grouped by kind and namespace so that
+// each is watched by a single informer, and restricted to the names of the applied objects, so that the changes of the
+// other objects of the kinds are ignored. The resources of syncsets which are only checked for drift are not applied,
+// so they are not watched.
+func watchedResources(clusterSync *hiveintv1alpha1.ClusterSync) []remoteclient.WatchedResource {
+	type kindInNamespace struct {
+		apiVersion, kind, namespace string
+	}
+	var kinds []kindInNamespace
+	names := map[kindInNamespace][]string{}
+	for _, syncStatuses := range [][]hiveintv1alpha1.SyncStatus{clusterSync.Status.SyncSets, clusterSync.Status.SelectorSyncSets} {
+		for _, syncStatus := range syncStatuses {
+			for _, applied := range syncStatus.AppliedResources {
+				k := kindInNamespace{apiVersion: applied.APIVersion, kind: applied.Kind, namespace: applied.Namespace}
+				if _, ok := names[k]; !ok {
+					kinds = append(kinds, k)
+				}
+				names[k] = append(names[k], applied.Name)
+			}
+		}
+	}
+	resources := make([]remoteclient.WatchedResource, len(kinds))
+	for i, k := range kinds {
+		resources[i] = remoteclient.WatchedResource{
+			GroupVersionKind: schema.FromAPIVersionAndKind(k.apiVersion, k.kind),
+			Namespace:        k.namespace,
+			Names:            names[k],
+		}
+	}
+	return resources
+}
+
+// appliedResourcesChanged returns true if any of the applied resources was changed in the cluster.
+func appliedResourcesChanged(appliedResources []hiveintv1alpha1.AppliedResource, changedResources []hiveintv1alpha1.SyncResourceReference) bool {
+	for _, applied := range appliedResources {
+		if containsResource(changedResources, applied.SyncResourceReference) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutChangedResources returns the applied resources which were not changed in the cluster, so that the changed
+// resources are applied again even though their content has not changed since they were last applied.
+func withoutChangedResources(appliedResources []hiveintv1alpha1.AppliedResource, changedResources []hiveintv1alpha1.SyncResourceReference) []hiveintv1alpha1.AppliedResource {
+	if len(changedResources) == 0 {
+		return appliedResources
+	}
+	var unchanged []hiveintv1alpha1.AppliedResource
+	for _, applied := range appliedResources {
+		if !containsResource(changedResources, applied.SyncResourceReference) {
+			unchanged = append(unchanged, applied)
+		}
+	}
+	return unchanged
+}
@@ -0,0 +1,126 @@
+package clustersync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/resource"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	teststatefulset "github.com/openshift/hive/pkg/test/statefulset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+type fakeRemoteWatcher struct {
+	changes   []remoteclient.ObjectReference
+	resources []remoteclient.WatchedResource
+	stopped   bool
+}
+
+func (w *fakeRemoteWatcher) Watch(_ *hivev1.ClusterDeployment, _ remoteclient.Builder, resources []remoteclient.WatchedResource) error {
+	w.resources = resources
+	return nil
+}
+
+func (w *fakeRemoteWatcher) StopWatching(types.NamespacedName) {
+	w.stopped = true
+}
+
+func (w *fakeRemoteWatcher) TakeChanges(types.NamespacedName) []remoteclient.ObjectReference {
+	changes := w.changes
+	w.changes = nil
+	return changes
+}
+
+func (w *fakeRemoteWatcher) Source() source.Source {
+	return nil
+}
+
+func TestReconcileClusterSync_ReapplyChangedResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	unchangedResource := testConfigMap("dest-namespace", "unchanged")
+	changedResource := testConfigMap("dest-namespace", "changed")
+	lastAppliedUnchangedResource := buildAppliedResource(t, unchangedResource)
+	lastAppliedUnchangedResource.ResourceVersion = "1"
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(unchangedResource, changedResource),
+	)
+	syncStatus := buildSyncStatus("test-syncset",
+		withTransitionInThePast(),
+		withFirstSuccessTimeInThePast(),
+		withAppliedResources(
+			lastAppliedUnchangedResource,
+			buildAppliedResource(t, changedResource),
+		),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(syncStatus)),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		buildSyncLease(time.Now().Add(-time.Hour)),
+	)
+	watcher := &fakeRemoteWatcher{
+		changes: []remoteclient.ObjectReference{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "dest-namespace", Name: "changed"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "dest-namespace", Name: "not-applied"},
+		},
+	}
+	rt.r.remoteWatcher = watcher
+	rt.mockResourceHelper.EXPECT().Apply(gomock.Any(), newApplyMatcher(changedResource)).Return(&resource.AppliedObject{Result: resource.ConfiguredApplyResult}, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{syncStatus}
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.run(t)
+
+	assert.Equal(t,
+		[]remoteclient.WatchedResource{{
+			GroupVersionKind: changedResource.GroupVersionKind(),
+			Namespace:        "dest-namespace",
+			Names:            []string{"unchanged", "changed"},
+		}},
+		watcher.resources,
+		"unexpected watched resources",
+	)
+}
+
+func TestWatchedResources(t *testing.T) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{
+		Status: hiveintv1alpha1.ClusterSyncStatus{
+			SyncSets: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("report-only"),
+				buildSyncStatus("syncset", withAppliedResources(
+					hiveintv1alpha1.AppliedResource{SyncResourceReference: testConfigMapRef("namespace-1", "a")},
+					hiveintv1alpha1.AppliedResource{SyncResourceReference: testSecretRef("namespace-1", "b")},
+				)),
+			},
+			SelectorSyncSets: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("selectorsyncset", withAppliedResources(
+					hiveintv1alpha1.AppliedResource{SyncResourceReference: testConfigMapRef("namespace-1", "c")},
+					hiveintv1alpha1.AppliedResource{SyncResourceReference: testConfigMapRef("namespace-2", "a")},
+				)),
+			},
+		},
+	}
+	configMaps := remoteclient.WatchedResource{GroupVersionKind: testConfigMap("", "").GroupVersionKind()}
+	secrets := remoteclient.WatchedResource{GroupVersionKind: configMaps.GroupVersion().WithKind("Secret")}
+	expected := []remoteclient.WatchedResource{configMaps, secrets, configMaps}
+	expected[0].Namespace, expected[0].Names = "namespace-1", []string{"a", "c"}
+	expected[1].Namespace, expected[1].Names = "namespace-1", []string{"b"}
+	expected[2].Namespace, expected[2].Names = "namespace-2", []string{"a"}
+	assert.Equal(t, expected, watchedResources(clusterSync))
+}
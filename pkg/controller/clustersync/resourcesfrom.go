@@ -0,0 +1,283 @@
+package clustersync
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// maxResourcesFromURLSize is the maximum size of the content downloaded from the URL of a syncset.
+	maxResourcesFromURLSize = 128 * 1024 * 1024
+
+	// maxCachedResourcesFromURLsSize is the maximum total size of the resources cached for the URLs of syncsets. The
+	// resources of the least recently used URLs are evicted to make room, so that the content of URLs no longer
+	// referenced does not stay around.
+	maxCachedResourcesFromURLsSize = 256 * 1024 * 1024
+
+	// maxResourcesFromURLRedirects is the maximum number of redirects followed when downloading the resources of a URL.
+	maxResourcesFromURLRedirects = 10
+)
+
+// urlResourcesCache caches the resources downloaded from the URLs of syncsets. As the content of a URL is verified
+// against its checksum, the resources are keyed by URL and checksum and never go stale.
+type urlResourcesCache struct {
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the cached entries, from the most to the least recently used.
+	lru  list.List
+	size int
+}
+
+type urlResourcesCacheEntry struct {
+	key       string
+	resources []runtime.RawExtension
+	size      int
+}
+
+func (c *urlResourcesCache) get(key string) ([]runtime.RawExtension, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*urlResourcesCacheEntry).resources, true
+}
+
+// add caches the resources, whose size is the size of the content they were decoded from. Resources larger than the
+// cache are not cached.
+func (c *urlResourcesCache) add(key string, resources []runtime.RawExtension, size int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if size > maxCachedResourcesFromURLsSize {
+		return
+	}
+	if c.entries == nil {
+		c.entries = map[string]*list.Element{}
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	for c.size+size > maxCachedResourcesFromURLsSize {
+		c.remove(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(&urlResourcesCacheEntry{key: key, resources: resources, size: size})
+	c.size += size
+}
+
+func (c *urlResourcesCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*urlResourcesCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// urlAllowed returns whether the URL starts with one of the allowed URL prefixes. The scheme and host of the URL must
+// match those of the prefix exactly, so that a prefix cannot be extended into the name of another host.
+func urlAllowed(rawURL string, allowedPrefixes []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User != nil {
+		return false
+	}
+	for _, allowedPrefix := range allowedPrefixes {
+		prefix, err := url.Parse(allowedPrefix)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(u.Scheme, prefix.Scheme) && strings.EqualFold(u.Host, prefix.Host) && strings.HasPrefix(u.Path, prefix.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveResources returns the syncset with the resources referenced by its ResourcesFrom appended to its Resources
+// and with the templates in the resources rendered for the cluster, along with a checksum of the referenced resources
+// and template values. The syncset is returned as is when it has neither ResourcesFrom nor resource templates, or when
+// its resources cannot be resolved.
+func (r *ReconcileClusterSync) resolveResources(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (CommonSyncSet, string, error) {
+	spec := syncSet.GetSpec()
+	if len(spec.ResourcesFrom) == 0 && !spec.EnableResourceTemplates {
+		return syncSet, "", nil
+	}
+	checksum := sha256.New()
+	resources := append([]runtime.RawExtension{}, spec.Resources...)
+	for i, source := range spec.ResourcesFrom {
+		logger := logger.WithField("resourcesFromIndex", i)
+		var sourceResources []runtime.RawExtension
+		var err error
+		switch {
+		case source.ConfigMapRef != nil:
+			sourceResources, err = r.getConfigMapResources(syncSet, source.ConfigMapRef, checksum, logger)
+		case source.URL != "":
+			fmt.Fprintf(checksum, "url %s %s\n", source.URL, source.SHA256)
+			sourceResources, err = r.getURLResources(source.URL, source.SHA256, logger)
+		default:
+			err = errors.New("neither configMapRef nor url is set")
+		}
+		if err != nil {
+			return syncSet, "", errors.Wrapf(err, "failed to get resources from source %d", i)
+		}
+		resources = append(resources, sourceResources...)
+	}
+	if spec.EnableResourceTemplates {
+		values := newResourceTemplateValues(cd)
+		if err := json.NewEncoder(checksum).Encode(values); err != nil {
+			return syncSet, "", errors.Wrap(err, "failed to encode resource template values")
+		}
+		var err error
+		if resources, err = renderResourceTemplates(resources, values); err != nil {
+			return syncSet, "", err
+		}
+	}
+	return withResources(syncSet, resources), hex.EncodeToString(checksum.Sum(nil)), nil
+}
+
+// getConfigMapResources returns the resources in the data entries of the ConfigMap, in the order of their keys, and
+// adds the data entries to the checksum.
+func (r *ReconcileClusterSync) getConfigMapResources(
+	syncSet CommonSyncSet,
+	ref *hivev1.ConfigMapReference,
+	checksum hash.Hash,
+	logger log.FieldLogger,
+) ([]runtime.RawExtension, error) {
+	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
+	namespace := ref.Namespace
+	switch {
+	case namespace == "" && syncSetNamespace == "":
+		// The namespace of the ConfigMap is required for SelectorSyncSets.
+		return nil, errors.New("configmap namespace missing")
+	case namespace == "":
+		namespace = syncSetNamespace
+	case syncSetNamespace != "" && syncSetNamespace != namespace:
+		return nil, errors.New("configmap must be in same namespace as SyncSet")
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, cm); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read configmap")
+		return nil, errors.Wrap(err, "failed to read configmap")
+	}
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var resources []runtime.RawExtension
+	for _, key := range keys {
+		fmt.Fprintf(checksum, "configmap %s/%s %s %d\n", namespace, ref.Name, key, len(cm.Data[key]))
+		io.WriteString(checksum, cm.Data[key])
+		keyResources, err := splitResources([]byte(cm.Data[key]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode configmap key %s", key)
+		}
+		resources = append(resources, keyResources...)
+	}
+	return resources, nil
+}
+
+// getURLResources returns the resources downloaded from the URL, whose content must match the checksum. The URL, and
+// the URLs it redirects to, must be allowed by the HiveConfig.
+func (r *ReconcileClusterSync) getURLResources(resourcesURL, sha256Sum string, logger log.FieldLogger) ([]runtime.RawExtension, error) {
+	if !urlAllowed(resourcesURL, r.allowedResourcesFromURLs) {
+		return nil, fmt.Errorf("url %s is not allowed by the syncSetResourcesFromAllowedURLs of the HiveConfig", resourcesURL)
+	}
+	key := resourcesURL + "@" + sha256Sum
+	if resources, ok := r.urlResources.get(key); ok {
+		return resources, nil
+	}
+	httpClient := &http.Client{
+		Timeout: time.Minute,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxResourcesFromURLRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxResourcesFromURLRedirects)
+			}
+			if !urlAllowed(req.URL.String(), r.allowedResourcesFromURLs) {
+				return fmt.Errorf("redirect to %s is not allowed by the syncSetResourcesFromAllowedURLs of the HiveConfig", req.URL.Redacted())
+			}
+			return nil
+		},
+	}
+	logger.WithField("url", resourcesURL).Info("downloading syncset resources")
+	resp, err := httpClient.Get(resourcesURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download resources")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download resources: %s", resp.Status)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResourcesFromURLSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download resources")
+	}
+	if len(content) > maxResourcesFromURLSize {
+		return nil, fmt.Errorf("resources are larger than %d bytes", maxResourcesFromURLSize)
+	}
+	if actual := sha256.Sum256(content); hex.EncodeToString(actual[:]) != sha256Sum {
+		return nil, fmt.Errorf("checksum of downloaded resources %x does not match %s", actual, sha256Sum)
+	}
+	resources, err := splitResources(content)
+	if err != nil {
+		return nil, err
+	}
+	r.urlResources.add(key, resources, len(content))
+	return resources, nil
+}
+
+// splitResources splits a YAML or JSON stream into its objects. Empty documents are skipped.
+func splitResources(data []byte) ([]runtime.RawExtension, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var resources []runtime.RawExtension
+	for {
+		resource := runtime.RawExtension{}
+		if err := decoder.Decode(&resource); err == io.EOF {
+			return resources, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode resource %d", len(resources))
+		}
+		if len(resource.Raw) > 0 {
+			resources = append(resources, resource)
+		}
+	}
+}
+
+// withResources returns a copy of the syncset with the resources in place of its Resources.
+func withResources(syncSet CommonSyncSet, resources []runtime.RawExtension) CommonSyncSet {
+	switch ss := syncSet.(type) {
+	case *SyncSetAsCommon:
+		c := (*hivev1.SyncSet)(ss).DeepCopy()
+		c.Spec.Resources = resources
+		return (*SyncSetAsCommon)(c)
+	case *SelectorSyncSetAsCommon:
+		c := (*hivev1.SelectorSyncSet)(ss).DeepCopy()
+		c.Spec.Resources = resources
+		return (*SelectorSyncSetAsCommon)(c)
+	default:
+		panic(fmt.Sprintf("unexpected syncset type %T", syncSet))
+	}
+}
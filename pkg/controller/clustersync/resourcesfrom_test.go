@@ -0,0 +1,111 @@
+package clustersync
+
+import (
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestURLAllowed(t *testing.T) {
+	allowedPrefixes := []string{"https://bucket.example.com/hive/", "https://other.example.com"}
+	cases := []struct {
+		url    string
+		expect bool
+	}{
+		{url: "https://bucket.example.com/hive/resources.yaml", expect: true},
+		{url: "https://BUCKET.example.com/hive/resources.yaml", expect: true},
+		{url: "https://other.example.com/any/resources.yaml", expect: true},
+		{url: "https://bucket.example.com/other/resources.yaml"},
+		{url: "http://bucket.example.com/hive/resources.yaml"},
+		{url: "https://bucket.example.com.evil.com/hive/resources.yaml"},
+		{url: "https://other.example.com:8443/resources.yaml"},
+		{url: "https://user@bucket.example.com/hive/resources.yaml"},
+		{url: "http://169.254.169.254/latest/meta-data/"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.url, func(t *testing.T) {
+			assert.Equal(t, tc.expect, urlAllowed(tc.url, allowedPrefixes))
+		})
+	}
+	assert.False(t, urlAllowed("https://bucket.example.com/hive/resources.yaml", nil), "expected no URL to be allowed without prefixes")
+}
+
+func TestURLResourcesCache(t *testing.T) {
+	resources := []runtime.RawExtension{{Raw: []byte("{}")}}
+	cache := &urlResourcesCache{}
+	third := maxCachedResourcesFromURLsSize / 3
+	for i := 0; i < 3; i++ {
+		cache.add(fmt.Sprint(i), resources, third)
+	}
+	// Using the first entry makes the second one the least recently used.
+	_, ok := cache.get("0")
+	assert.True(t, ok, "expected first entry to be cached")
+	cache.add("3", resources, third)
+	for key, expectCached := range map[string]bool{"0": true, "1": false, "2": true, "3": true} {
+		_, ok := cache.get(key)
+		assert.Equal(t, expectCached, ok, "unexpected caching of entry %s", key)
+	}
+	assert.LessOrEqual(t, cache.size, maxCachedResourcesFromURLsSize, "cache larger than its maximum size")
+
+	cache.add("too-large", resources, maxCachedResourcesFromURLsSize+1)
+	_, ok = cache.get("too-large")
+	assert.False(t, ok, "expected resources larger than the cache not to be cached")
+}
+
+func TestRequestsForConfigMap(t *testing.T) {
+	scheme := newScheme()
+	configMapSource := func(namespace, name string) hivev1.SyncSetResourcesSource {
+		return hivev1.SyncSetResourcesSource{ConfigMapRef: &hivev1.ConfigMapReference{Namespace: namespace, Name: name}}
+	}
+	selectorSyncSet := func(name, configMapNamespace, configMapName string) *hivev1.SelectorSyncSet {
+		sss := testselectorsyncset.FullBuilder(name, scheme).Build(testselectorsyncset.WithLabelSelector("env", "prod"))
+		sss.Spec.ResourcesFrom = []hivev1.SyncSetResourcesSource{configMapSource(configMapNamespace, configMapName)}
+		return sss
+	}
+	c := fake.NewFakeClientWithScheme(scheme,
+		testsyncset.FullBuilder(testNamespace, "referencing", scheme).Build(
+			testsyncset.ForClusterDeployments("cd1", "cd2"),
+			testsyncset.WithResourcesFrom(configMapSource("", "resources")),
+		),
+		testsyncset.FullBuilder(testNamespace, "also-referencing", scheme).Build(
+			testsyncset.ForClusterDeployments("cd2"),
+			testsyncset.WithResourcesFrom(configMapSource(testNamespace, "resources")),
+		),
+		testsyncset.FullBuilder(testNamespace, "referencing-other", scheme).Build(
+			testsyncset.ForClusterDeployments("cd3"),
+			testsyncset.WithResourcesFrom(configMapSource("", "other")),
+		),
+		testsyncset.FullBuilder("other-namespace", "referencing-in-other-namespace", scheme).Build(
+			testsyncset.ForClusterDeployments("cd4"),
+			testsyncset.WithResourcesFrom(configMapSource("", "resources")),
+		),
+		selectorSyncSet("selector-referencing", testNamespace, "resources"),
+		selectorSyncSet("selector-referencing-other", "other-namespace", "resources"),
+		testcd.FullBuilder("prod-namespace", "prod-cd", scheme).Build(testcd.WithLabel("env", "prod")),
+		testcd.FullBuilder("dev-namespace", "dev-cd", scheme).Build(testcd.WithLabel("env", "dev")),
+	)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "resources"}}
+
+	requests := requestsForConfigMap(c, log.StandardLogger())(handler.MapObject{Meta: cm, Object: cm})
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "cd1"}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "cd2"}},
+		{NamespacedName: types.NamespacedName{Namespace: "prod-namespace", Name: "prod-cd"}},
+	}, requests, "unexpected requests")
+}
@@ -0,0 +1,125 @@
+package clustersync
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// resourceTemplateValues are the values of a cluster available to the templates in the resources of a syncset.
+type resourceTemplateValues struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+	BaseDomain  string
+	InfraID     string
+	ClusterID   string
+	Region      string
+	Labels      map[string]string
+}
+
+func newResourceTemplateValues(cd *hivev1.ClusterDeployment) *resourceTemplateValues {
+	values := &resourceTemplateValues{
+		Name:        cd.Name,
+		Namespace:   cd.Namespace,
+		ClusterName: cd.Spec.ClusterName,
+		BaseDomain:  cd.Spec.BaseDomain,
+		Region:      controllerutils.InstallRegion(cd),
+		Labels:      cd.Labels,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		values.InfraID = cd.Spec.ClusterMetadata.InfraID
+		values.ClusterID = cd.Spec.ClusterMetadata.ClusterID
+	}
+	if values.Labels == nil {
+		values.Labels = map[string]string{}
+	}
+	return values
+}
+
+// renderResourceTemplates renders the templates in the string values and map keys of the resources. Resources
+// without templates are returned as is.
+func renderResourceTemplates(resources []runtime.RawExtension, values *resourceTemplateValues) ([]runtime.RawExtension, error) {
+	rendered := make([]runtime.RawExtension, len(resources))
+	for i, resource := range resources {
+		if !bytes.Contains(resource.Raw, []byte("{{")) {
+			rendered[i] = resource
+			continue
+		}
+		// The resources are JSON unless they come from a YAML stream of the resources of a syncset.
+		raw, err := yaml.YAMLToJSON(resource.Raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode resource %d", i)
+		}
+		var obj interface{}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode resource %d", i)
+		}
+		obj, err = renderValue(obj, values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render resource %d", i)
+		}
+		raw, err = json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode resource %d", i)
+		}
+		rendered[i] = runtime.RawExtension{Raw: raw}
+	}
+	return rendered, nil
+}
+
+// renderValue renders the templates in a value decoded from JSON. Templates are only rendered in strings, so that the
+// rendered resources are always valid.
+func renderValue(value interface{}, values *resourceTemplateValues) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return renderString(v, values)
+	case []interface{}:
+		for i := range v {
+			rendered, err := renderValue(v[i], values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			renderedKey, err := renderString(key, values)
+			if err != nil {
+				return nil, err
+			}
+			if rendered[renderedKey], err = renderValue(item, values); err != nil {
+				return nil, err
+			}
+		}
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
+
+func renderString(s string, values *resourceTemplateValues) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New("resource").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, values); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
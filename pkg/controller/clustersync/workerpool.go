@@ -0,0 +1,43 @@
+package clustersync
+
+import (
+	"sync"
+)
+
+// workerPool bounds the number of operations run at once against the remote clusters by all the reconciles of the
+// controller, so that applying large syncsets to many clusters at once does not overwhelm the clustersync pod.
+type workerPool struct {
+	workers chan struct{}
+}
+
+func newWorkerPool(size int) *workerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &workerPool{workers: make(chan struct{}, size)}
+}
+
+// run calls fn for each of the n items, and returns once all the calls have returned. The calls are run concurrently
+// by the workers of the pool, which are shared with the other reconciles of the controller. A nil pool runs the calls
+// one after the other.
+func (p *workerPool) run(n int, fn func(i int)) {
+	if p == nil {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		p.workers <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-p.workers
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
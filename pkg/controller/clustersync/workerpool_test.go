@@ -0,0 +1,55 @@
+package clustersync
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	cases := []struct {
+		name              string
+		pool              *workerPool
+		expectedMaxAtOnce int
+		expectedMinAtOnce int
+	}{
+		{
+			name:              "no pool",
+			expectedMaxAtOnce: 1,
+			expectedMinAtOnce: 1,
+		},
+		{
+			name:              "pool",
+			pool:              newWorkerPool(3),
+			expectedMaxAtOnce: 3,
+			expectedMinAtOnce: 2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			running, maxRunning := 0, 0
+			called := make([]bool, 10)
+			tc.pool.run(len(called), func(i int) {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				called[i] = true
+				lock.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				lock.Lock()
+				running--
+				lock.Unlock()
+			})
+			for i, c := range called {
+				assert.True(t, c, "expected call for item %d", i)
+			}
+			assert.LessOrEqual(t, maxRunning, tc.expectedMaxAtOnce, "too many calls run at once")
+			assert.GreaterOrEqual(t, maxRunning, tc.expectedMinAtOnce, "too few calls run at once")
+		})
+	}
+}
@@ -0,0 +1,551 @@
+package clusterupgrade
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/releaseverification"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName           = hivev1.ClusterUpgradeControllerName
+	clusterVersionObjectName = "version"
+	defaultMaxConcurrent     = 1
+
+	// observeWorkers is the number of clusters whose ClusterVersion is read at once, so that the clusters of a large
+	// upgrade are not observed one after the other, nor all at once.
+	observeWorkers = 10
+
+	// clusterVersionFailingCondition is set on the ClusterVersion when the cluster version operator cannot make
+	// progress on the update.
+	clusterVersionFailingCondition configv1.ClusterStatusConditionType = "Failing"
+
+	completedReason                = "AllClustersUpgraded"
+	noClustersReason               = "NoClustersSelected"
+	inProgressReason               = "UpgradeInProgress"
+	canaryInProgressReason         = "CanaryUpgradeInProgress"
+	pausedReason                   = "Paused"
+	outsideMaintenanceWindowReason = "OutsideMaintenanceWindow"
+	haltedReason                   = "UpgradeHalted"
+	invalidSpecReason              = "InvalidSpec"
+	clustersFailedReason           = "ClustersFailed"
+	noFailuresReason               = "NoFailures"
+	releaseImageUnverifiedReason   = "ReleaseImageUnverified"
+)
+
+var (
+	// progressCheckInterval is how often the clusters are checked while the upgrade is not complete.
+	progressCheckInterval = time.Minute
+)
+
+// Add creates a new ClusterUpgrade controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterUpgrade
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterUpgrade {
+	logger := log.WithField("controller", ControllerName)
+	r := &ReconcileClusterUpgrade{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: logger,
+	}
+	r.releaseImageVerifier = releaseverification.NewVerifierFromEnv(logger)
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to the controller manager
+func AddToManager(mgr manager.Manager, r *ReconcileClusterUpgrade, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterupgrade-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterUpgrades
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterUpgrade{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, using field indexes so that only the ClusterUpgrades which may select the
+	// ClusterDeployment are enqueued.
+	if err := addFieldIndexes(mgr.GetFieldIndexer()); err != nil {
+		return errors.Wrap(err, "cannot add ClusterUpgrade field indexes")
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForClusterDeployment(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterUpgrade{}
+
+// ReconcileClusterUpgrade reconciles a ClusterUpgrade object to upgrade the selected clusters
+type ReconcileClusterUpgrade struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// releaseImageVerifier verifies the release image of the upgrade. It is nil when the verification of release
+	// images is not turned on.
+	releaseImageVerifier releaseverification.Verifier
+}
+
+// remoteCluster is the ClusterVersion of a cluster along with the client used to fetch it.
+type remoteCluster struct {
+	client         client.Client
+	clusterVersion *configv1.ClusterVersion
+}
+
+// Reconcile observes the progress of the upgrade of the clusters selected by a ClusterUpgrade and starts the upgrade
+// of more clusters when allowed.
+func (r *ReconcileClusterUpgrade) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterUpgrade", request.NamespacedName)
+	logger.Info("reconciling cluster upgrade")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	upgrade := &hivev1.ClusterUpgrade{}
+	if err := r.Get(context.Background(), request.NamespacedName, upgrade); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster upgrade not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster upgrade")
+		return reconcile.Result{}, err
+	}
+	if upgrade.DeletionTimestamp != nil {
+		logger.Debug("cluster upgrade is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := upgrade.Status.DeepCopy()
+
+	if err := validateClusterUpgrade(upgrade); err != nil {
+		logger.WithError(err).Info("invalid cluster upgrade")
+		r.setConditions(upgrade, corev1.ConditionFalse, invalidSpecReason, "The cluster upgrade is invalid", corev1.ConditionTrue, invalidSpecReason, err.Error())
+		return reconcile.Result{}, r.updateStatus(upgrade, origStatus, logger)
+	}
+	if r.releaseImageVerifier != nil {
+		image := upgrade.Spec.DesiredUpdate.Image
+		var err error
+		if image == "" {
+			// The release image of a version is only resolved by the clusters, so it cannot be verified by Hive.
+			err = fmt.Errorf("the release image must be set in spec.desiredUpdate.image to be verified, upgrades to a version are not allowed when release images are verified")
+		} else {
+			err = r.releaseImageVerifier.Verify(image)
+		}
+		if err != nil {
+			logger.WithError(err).WithField("releaseImage", image).Warn("release image cannot be verified, not upgrading clusters")
+			r.setConditions(upgrade, corev1.ConditionFalse, releaseImageUnverifiedReason, "The release image cannot be verified", corev1.ConditionTrue, releaseImageUnverifiedReason, err.Error())
+			if err := r.updateStatus(upgrade, origStatus, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: progressCheckInterval}, nil
+		}
+	}
+	selector, _ := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+	canarySelector := labels.Nothing()
+	if upgrade.Spec.CanarySelector != nil {
+		canarySelector, _ = metav1.LabelSelectorAsSelector(upgrade.Spec.CanarySelector)
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster deployments")
+		return reconcile.Result{}, err
+	}
+	var cds []*hivev1.ClusterDeployment
+	for i, cd := range cdList.Items {
+		// Clusters that are not installed will be installed with whatever release they were created with.
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		cds = append(cds, &cdList.Items[i])
+	}
+	// Upgrade the canary clusters first, and otherwise in a consistent order.
+	sort.Slice(cds, func(i, j int) bool {
+		iCanary, jCanary := canarySelector.Matches(labels.Set(cds[i].Labels)), canarySelector.Matches(labels.Set(cds[j].Labels))
+		if iCanary != jCanary {
+			return iCanary
+		}
+		if cds[i].Namespace != cds[j].Namespace {
+			return cds[i].Namespace < cds[j].Namespace
+		}
+		return cds[i].Name < cds[j].Name
+	})
+
+	oldStatuses := map[types.NamespacedName]hivev1.ClusterUpgradeClusterStatus{}
+	for _, status := range upgrade.Status.Clusters {
+		oldStatuses[types.NamespacedName{Namespace: status.Namespace, Name: status.Name}] = status
+	}
+	now := metav1.Now()
+	statuses := make([]hivev1.ClusterUpgradeClusterStatus, len(cds))
+	for i, cd := range cds {
+		// The state of the upgrade of a cluster to a previous desired update does not apply to the current one.
+		status, ok := oldStatuses[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		if !ok || status.DesiredUpdate != upgrade.Spec.DesiredUpdate {
+			status = hivev1.ClusterUpgradeClusterStatus{
+				Namespace:     cd.Namespace,
+				Name:          cd.Name,
+				State:         hivev1.ClusterUpgradeStatePending,
+				DesiredUpdate: upgrade.Spec.DesiredUpdate,
+			}
+		}
+		status.Canary = canarySelector.Matches(labels.Set(cd.Labels))
+		statuses[i] = status
+	}
+	// The clusters are observed concurrently, each filling in its own status.
+	remoteClusters := make([]*remoteCluster, len(cds))
+	requeueClusters := make([]bool, len(cds))
+	workqueue.ParallelizeUntil(context.Background(), observeWorkers, len(cds), func(i int) {
+		if statuses[i].State != hivev1.ClusterUpgradeStateCompleted {
+			remoteClusters[i], requeueClusters[i] = r.observeCluster(cds[i], upgrade.Spec.DesiredUpdate, &statuses[i], now, logger)
+		}
+	})
+	var requeue bool
+	for _, requeueCluster := range requeueClusters {
+		requeue = requeue || requeueCluster
+	}
+
+	var completed, upgrading int
+	var failed []string
+	canariesCompleted := true
+	for _, status := range statuses {
+		switch status.State {
+		case hivev1.ClusterUpgradeStateCompleted:
+			completed++
+		case hivev1.ClusterUpgradeStateUpgrading:
+			upgrading++
+		case hivev1.ClusterUpgradeStateFailed:
+			failed = append(failed, status.Namespace+"/"+status.Name)
+		}
+		if status.Canary && status.State != hivev1.ClusterUpgradeStateCompleted {
+			canariesCompleted = false
+		}
+	}
+
+	// Determine whether the upgrade of more clusters can be started.
+	var reason, message string
+	switch {
+	case len(statuses) == 0:
+		reason, message = noClustersReason, "No installed clusters are selected for the upgrade"
+	case completed == len(statuses):
+		reason, message = completedReason, "All of the selected clusters have been upgraded"
+	case len(failed) > 0:
+		reason, message = haltedReason, "No more clusters will be upgraded while clusters are failing to upgrade"
+	case upgrade.Spec.Paused:
+		reason, message = pausedReason, "The upgrade is paused"
+	case !inMaintenanceWindow(upgrade.Spec.MaintenanceWindows, now.Time):
+		reason, message = outsideMaintenanceWindowReason, "Waiting for a maintenance window to upgrade more clusters"
+	default:
+		maxConcurrent := defaultMaxConcurrent
+		if upgrade.Spec.MaxConcurrent != nil {
+			maxConcurrent = int(*upgrade.Spec.MaxConcurrent)
+		}
+		for i := range statuses {
+			if upgrading >= maxConcurrent {
+				break
+			}
+			status := &statuses[i]
+			if status.State != hivev1.ClusterUpgradeStatePending || remoteClusters[i] == nil {
+				continue
+			}
+			if !status.Canary && !canariesCompleted {
+				break
+			}
+			cdLog := logger.WithField("clusterDeployment", types.NamespacedName{Namespace: status.Namespace, Name: status.Name})
+			if err := startUpgrade(remoteClusters[i], upgrade.Spec.DesiredUpdate); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not start the upgrade of the cluster")
+				status.Message = fmt.Sprintf("Could not start the upgrade: %v", err)
+				continue
+			}
+			cdLog.Info("started the upgrade of the cluster")
+			status.State = hivev1.ClusterUpgradeStateUpgrading
+			status.StartTime = &now
+			status.Message = ""
+			upgrading++
+		}
+		reason, message = inProgressReason, "Clusters are being upgraded"
+		if !canariesCompleted {
+			reason, message = canaryInProgressReason, "The canary clusters are being upgraded"
+		}
+	}
+
+	upgrade.Status.Clusters = statuses
+	upgrade.Status.Total = int32(len(statuses))
+	upgrade.Status.Completed = int32(completed)
+	completedStatus := corev1.ConditionFalse
+	if reason == completedReason {
+		completedStatus = corev1.ConditionTrue
+	}
+	failedStatus, failedReason, failedMessage := corev1.ConditionFalse, noFailuresReason, "No clusters have failed to upgrade"
+	if len(failed) > 0 {
+		failedStatus, failedReason = corev1.ConditionTrue, clustersFailedReason
+		failedMessage = fmt.Sprintf("Clusters failed to upgrade: %s", strings.Join(failed, ", "))
+	}
+	r.setConditions(upgrade, completedStatus, reason, message, failedStatus, failedReason, failedMessage)
+
+	if err := r.updateStatus(upgrade, origStatus, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	if completedStatus == corev1.ConditionTrue || len(statuses) == 0 {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: progressCheckInterval}, nil
+}
+
+// observeCluster updates the status with the progress of the upgrade of the cluster. The ClusterVersion of the cluster
+// is returned when the cluster is reachable. The reconcile needs to be requeued when the unreachable condition of the
+// cluster could not be recorded.
+func (r *ReconcileClusterUpgrade) observeCluster(
+	cd *hivev1.ClusterDeployment,
+	desired hivev1.ClusterUpgradeRelease,
+	status *hivev1.ClusterUpgradeClusterStatus,
+	now metav1.Time,
+	logger log.FieldLogger,
+) (*remoteCluster, bool) {
+	cdLog := logger.WithField("clusterDeployment", types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name})
+	remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(cd, r.remoteClusterAPIClientBuilder(cd), r.Client, cdLog)
+	if unreachable {
+		status.Message = "The cluster is unreachable"
+		// The error connecting to the cluster is recorded in the unreachable condition.
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); cond != nil &&
+			cond.Status == corev1.ConditionTrue && cond.Message != "" {
+			status.Message = fmt.Sprintf("The cluster is unreachable: %s", cond.Message)
+		}
+		return nil, requeue
+	}
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := remoteClient.Get(context.Background(), types.NamespacedName{Name: clusterVersionObjectName}, clusterVersion); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get the cluster version")
+		status.Message = fmt.Sprintf("Could not get the cluster version: %v", err)
+		return nil, false
+	}
+
+	status.Version = clusterVersion.Status.Desired.Version
+	status.Message = ""
+	switch {
+	case isReleaseCompleted(desired, clusterVersion):
+		cdLog.Debug("cluster is running the desired release")
+		status.State = hivev1.ClusterUpgradeStateCompleted
+		status.CompletionTime = &now
+	case clusterVersion.Spec.DesiredUpdate != nil && releaseMatches(desired, *clusterVersion.Spec.DesiredUpdate):
+		status.State = hivev1.ClusterUpgradeStateUpgrading
+		if status.StartTime == nil {
+			status.StartTime = &now
+		}
+		for _, cond := range clusterVersion.Status.Conditions {
+			if cond.Type == clusterVersionFailingCondition && cond.Status == configv1.ConditionTrue {
+				status.State = hivev1.ClusterUpgradeStateFailed
+				status.Message = cond.Message
+			}
+		}
+	default:
+		status.State = hivev1.ClusterUpgradeStatePending
+	}
+	return &remoteCluster{client: remoteClient, clusterVersion: clusterVersion}, false
+}
+
+// startUpgrade sets the desired update in the ClusterVersion of the cluster.
+func startUpgrade(cluster *remoteCluster, desired hivev1.ClusterUpgradeRelease) error {
+	cluster.clusterVersion.Spec.DesiredUpdate = &configv1.Update{
+		Version: desired.Version,
+		Image:   desired.Image,
+		Force:   desired.Force,
+	}
+	return cluster.client.Update(context.Background(), cluster.clusterVersion)
+}
+
+// isReleaseCompleted returns true if the cluster has completed the update to the desired release.
+func isReleaseCompleted(desired hivev1.ClusterUpgradeRelease, clusterVersion *configv1.ClusterVersion) bool {
+	if !releaseMatches(desired, clusterVersion.Status.Desired) {
+		return false
+	}
+	history := clusterVersion.Status.History
+	return len(history) > 0 && history[0].State == configv1.CompletedUpdate
+}
+
+// releaseMatches returns true if the update is for the desired release. Only the fields set in the desired release
+// are compared.
+func releaseMatches(desired hivev1.ClusterUpgradeRelease, update configv1.Update) bool {
+	if desired.Version != "" && desired.Version != update.Version {
+		return false
+	}
+	if desired.Image != "" && desired.Image != update.Image {
+		return false
+	}
+	return true
+}
+
+// inMaintenanceWindow returns true if the time is within one of the maintenance windows. When there are no maintenance
+// windows, any time is within a window.
+func inMaintenanceWindow(windows []hivev1.MaintenanceWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	now = now.UTC()
+	for _, window := range windows {
+		startTime, err := time.Parse("15:04", window.StartTime)
+		if err != nil {
+			continue
+		}
+		days := map[time.Weekday]bool{}
+		for _, day := range window.Days {
+			weekday, _ := parseWeekday(day)
+			days[weekday] = true
+		}
+		// Look back far enough to find a window that started on an earlier day and is still open.
+		daysBack := int(window.Duration.Duration/(24*time.Hour)) + 1
+		if daysBack > 7 {
+			daysBack = 7
+		}
+		for d := 0; d <= daysBack; d++ {
+			day := now.AddDate(0, 0, -d)
+			start := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
+			if len(days) > 0 && !days[start.Weekday()] {
+				continue
+			}
+			if !now.Before(start) && now.Before(start.Add(window.Duration.Duration)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), day) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q", day)
+}
+
+func validateClusterUpgrade(upgrade *hivev1.ClusterUpgrade) error {
+	if _, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector); err != nil {
+		return errors.Wrap(err, "invalid clusterDeploymentSelector")
+	}
+	if upgrade.Spec.CanarySelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(upgrade.Spec.CanarySelector); err != nil {
+			return errors.Wrap(err, "invalid canarySelector")
+		}
+	}
+	if upgrade.Spec.DesiredUpdate.Version == "" && upgrade.Spec.DesiredUpdate.Image == "" {
+		return errors.New("desiredUpdate must specify a version or an image")
+	}
+	if upgrade.Spec.MaxConcurrent != nil && *upgrade.Spec.MaxConcurrent < 1 {
+		return errors.New("maxConcurrent must be at least 1")
+	}
+	for i, window := range upgrade.Spec.MaintenanceWindows {
+		if _, err := time.Parse("15:04", window.StartTime); err != nil {
+			return errors.Errorf("maintenanceWindows[%d]: invalid startTime %q", i, window.StartTime)
+		}
+		if window.Duration.Duration <= 0 {
+			return errors.Errorf("maintenanceWindows[%d]: duration must be positive", i)
+		}
+		for _, day := range window.Days {
+			if _, err := parseWeekday(day); err != nil {
+				return errors.Wrapf(err, "maintenanceWindows[%d]", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileClusterUpgrade) setConditions(
+	upgrade *hivev1.ClusterUpgrade,
+	completedStatus corev1.ConditionStatus, completedReason, completedMessage string,
+	failedStatus corev1.ConditionStatus, failedReason, failedMessage string,
+) {
+	upgrade.Status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		upgrade.Status.Conditions,
+		hivev1.ClusterUpgradeCompletedCondition,
+		completedStatus,
+		completedReason,
+		completedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	upgrade.Status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		upgrade.Status.Conditions,
+		hivev1.ClusterUpgradeFailedCondition,
+		failedStatus,
+		failedReason,
+		failedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	// Ready is the Completed condition, under the name that kubectl wait expects.
+	upgrade.Status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		upgrade.Status.Conditions,
+		hivev1.ClusterUpgradeReadyCondition,
+		completedStatus,
+		completedReason,
+		completedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+func (r *ReconcileClusterUpgrade) updateStatus(upgrade *hivev1.ClusterUpgrade, origStatus *hivev1.ClusterUpgradeStatus, logger log.FieldLogger) error {
+	if reflect.DeepEqual(origStatus, &upgrade.Status) {
+		return nil
+	}
+	logger.Debug("updating cluster upgrade status")
+	if err := r.Status().Update(context.Background(), upgrade); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster upgrade status")
+		return err
+	}
+	return nil
+}
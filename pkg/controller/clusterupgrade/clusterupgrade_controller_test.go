@@ -0,0 +1,692 @@
+package clusterupgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/releaseverification"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testUpgradeName    = "test-upgrade"
+	testNamespace      = "test-namespace"
+	testCurrentVersion = "4.5.1"
+	testDesiredVersion = "4.5.2"
+	testFleetLabel     = "fleet"
+	testCanaryLabel    = "canary"
+	testDesiredImage   = "quay.io/openshift-release-dev/ocp-release@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+type testCluster struct {
+	name        string
+	canary      bool
+	unreachable bool
+	// connectionError is the error returned when connecting to the cluster.
+	connectionError string
+	notSelected     bool
+	// desiredUpdate is the version set as the desired update in the ClusterVersion of the cluster.
+	desiredUpdate string
+	// currentVersion is the version the cluster is running. The cluster is still updating if it differs from the
+	// desiredUpdate.
+	currentVersion string
+	failing        bool
+}
+
+func TestReconcileClusterUpgrade(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	configv1.Install(scheme.Scheme)
+
+	outsideWindow := time.Now().UTC().Add(2 * time.Hour).Format("15:04")
+
+	cases := []struct {
+		name                    string
+		upgrade                 *hivev1.ClusterUpgrade
+		clusters                []testCluster
+		existingStatuses        []hivev1.ClusterUpgradeClusterStatus
+		expectedStates          map[string]hivev1.ClusterUpgradeState
+		expectedMessages        map[string]string
+		expectedStarted         []string
+		expectedCompletedStatus corev1.ConditionStatus
+		expectedCompletedReason string
+		expectedFailedStatus    corev1.ConditionStatus
+		expectNoRequeue         bool
+		releaseImageVerifier    releaseverification.Verifier
+	}{
+		{
+			name:    "no clusters",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", notSelected: true},
+			},
+			expectedStates:          map[string]hivev1.ClusterUpgradeState{},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: noClustersReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+			expectNoRequeue:         true,
+		},
+		{
+			name:    "invalid desired update",
+			upgrade: testClusterUpgrade(withDesiredUpdate("")),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			expectedStates:          map[string]hivev1.ClusterUpgradeState{},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: invalidSpecReason,
+			expectedFailedStatus:    corev1.ConditionTrue,
+			expectNoRequeue:         true,
+		},
+		{
+			name:    "invalid maintenance window",
+			upgrade: testClusterUpgrade(withMaintenanceWindow(hivev1.MaintenanceWindow{Days: []string{"Someday"}, StartTime: "01:00", Duration: metav1.Duration{Duration: time.Hour}})),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			expectedStates:          map[string]hivev1.ClusterUpgradeState{},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: invalidSpecReason,
+			expectedFailedStatus:    corev1.ConditionTrue,
+			expectNoRequeue:         true,
+		},
+		{
+			name:    "unverified release image",
+			upgrade: testClusterUpgrade(withDesiredImage(testDesiredImage)),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			releaseImageVerifier: fakeReleaseImageVerifier(func(string) error {
+				return fmt.Errorf("no signature is published")
+			}),
+			expectedStates:          map[string]hivev1.ClusterUpgradeState{},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: releaseImageUnverifiedReason,
+			expectedFailedStatus:    corev1.ConditionTrue,
+		},
+		{
+			name:    "version without release image when verifying",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			releaseImageVerifier: fakeReleaseImageVerifier(func(string) error {
+				t.Error("unexpected verification of a version")
+				return nil
+			}),
+			expectedStates:          map[string]hivev1.ClusterUpgradeState{},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: releaseImageUnverifiedReason,
+			expectedFailedStatus:    corev1.ConditionTrue,
+		},
+		{
+			name:    "verified release image",
+			upgrade: testClusterUpgrade(withDesiredImage(testDesiredImage)),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			releaseImageVerifier: fakeReleaseImageVerifier(func(string) error { return nil }),
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedStarted:         []string{"cluster1"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "start upgrade",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+				"cluster2": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedStarted:         []string{"cluster1"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "max concurrent",
+			upgrade: testClusterUpgrade(withMaxConcurrent(2)),
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2"},
+				{name: "cluster3"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+				"cluster2": hivev1.ClusterUpgradeStateUpgrading,
+				"cluster3": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedStarted:         []string{"cluster1", "cluster2"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "wait for upgrading cluster",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", desiredUpdate: testDesiredVersion},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+				"cluster2": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "start next after completion",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", desiredUpdate: testDesiredVersion, currentVersion: testDesiredVersion},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateCompleted,
+				"cluster2": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedStarted:         []string{"cluster2"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "canary first",
+			upgrade: testClusterUpgrade(withCanarySelector(), withMaxConcurrent(2)),
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2", canary: true},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStatePending,
+				"cluster2": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedStarted:         []string{"cluster2"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: canaryInProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "canary completed",
+			upgrade: testClusterUpgrade(withCanarySelector(), withMaxConcurrent(2)),
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2", canary: true, desiredUpdate: testDesiredVersion, currentVersion: testDesiredVersion},
+				{name: "cluster3"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+				"cluster2": hivev1.ClusterUpgradeStateCompleted,
+				"cluster3": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedStarted:         []string{"cluster1", "cluster3"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "paused",
+			upgrade: testClusterUpgrade(withPaused()),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: pausedReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "outside maintenance window",
+			upgrade: testClusterUpgrade(withMaintenanceWindow(hivev1.MaintenanceWindow{StartTime: outsideWindow, Duration: metav1.Duration{Duration: time.Hour}})),
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: outsideMaintenanceWindowReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "failed cluster halts upgrade",
+			upgrade: testClusterUpgrade(withMaxConcurrent(2)),
+			clusters: []testCluster{
+				{name: "cluster1", desiredUpdate: testDesiredVersion, failing: true},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateFailed,
+				"cluster2": hivev1.ClusterUpgradeStatePending,
+			},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: haltedReason,
+			expectedFailedStatus:    corev1.ConditionTrue,
+		},
+		{
+			name:    "unreachable cluster",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", unreachable: true},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStatePending,
+				"cluster2": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedMessages: map[string]string{
+				"cluster1": "The cluster is unreachable: connection refused",
+			},
+			expectedStarted:         []string{"cluster2"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "cluster fails to connect",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", connectionError: "x509: certificate signed by unknown authority"},
+				{name: "cluster2"},
+			},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStatePending,
+				"cluster2": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedMessages: map[string]string{
+				"cluster1": "The cluster is unreachable: x509: certificate signed by unknown authority",
+			},
+			expectedStarted:         []string{"cluster2"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "desired update changed",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", desiredUpdate: testCurrentVersion, currentVersion: testCurrentVersion},
+			},
+			existingStatuses: []hivev1.ClusterUpgradeClusterStatus{{
+				Namespace:     testNamespace,
+				Name:          "cluster1",
+				State:         hivev1.ClusterUpgradeStateCompleted,
+				DesiredUpdate: hivev1.ClusterUpgradeRelease{Version: testCurrentVersion},
+			}},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateUpgrading,
+			},
+			expectedStarted:         []string{"cluster1"},
+			expectedCompletedStatus: corev1.ConditionFalse,
+			expectedCompletedReason: inProgressReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name:    "all completed",
+			upgrade: testClusterUpgrade(),
+			clusters: []testCluster{
+				{name: "cluster1", unreachable: true},
+				{name: "cluster2", desiredUpdate: testDesiredVersion, currentVersion: testDesiredVersion},
+			},
+			existingStatuses: []hivev1.ClusterUpgradeClusterStatus{{
+				Namespace:     testNamespace,
+				Name:          "cluster1",
+				State:         hivev1.ClusterUpgradeStateCompleted,
+				DesiredUpdate: hivev1.ClusterUpgradeRelease{Version: testDesiredVersion},
+			}},
+			expectedStates: map[string]hivev1.ClusterUpgradeState{
+				"cluster1": hivev1.ClusterUpgradeStateCompleted,
+				"cluster2": hivev1.ClusterUpgradeStateCompleted,
+			},
+			expectedCompletedStatus: corev1.ConditionTrue,
+			expectedCompletedReason: completedReason,
+			expectedFailedStatus:    corev1.ConditionFalse,
+			expectNoRequeue:         true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			tc.upgrade.Status.Clusters = tc.existingStatuses
+			existing := []runtime.Object{tc.upgrade}
+			remoteClients := map[string]client.Client{}
+			connectionErrors := map[string]error{}
+			for _, cluster := range tc.clusters {
+				existing = append(existing, testClusterDeployment(cluster))
+				remoteClients[cluster.name] = fake.NewFakeClient(testClusterVersion(cluster))
+				if cluster.connectionError != "" {
+					connectionErrors[cluster.name] = errors.New(cluster.connectionError)
+				}
+			}
+			fakeClient := fake.NewFakeClient(existing...)
+			rcu := &ReconcileClusterUpgrade{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+				remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					builder := remoteclientmock.NewMockBuilder(mockCtrl)
+					if err := connectionErrors[cd.Name]; err != nil {
+						builder.EXPECT().Build().Return(nil, err).AnyTimes()
+					} else {
+						builder.EXPECT().Build().Return(remoteClients[cd.Name], nil).AnyTimes()
+					}
+					return builder
+				},
+				releaseImageVerifier: tc.releaseImageVerifier,
+			}
+
+			result, err := rcu.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testUpgradeName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			if tc.expectNoRequeue {
+				assert.Zero(t, result.RequeueAfter, "expected no requeue")
+			} else {
+				assert.Equal(t, progressCheckInterval, result.RequeueAfter, "unexpected requeue after")
+			}
+
+			upgrade := &hivev1.ClusterUpgrade{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: testUpgradeName}, upgrade), "could not get cluster upgrade")
+			states := map[string]hivev1.ClusterUpgradeState{}
+			for _, status := range upgrade.Status.Clusters {
+				states[status.Name] = status.State
+				assert.Equal(t, tc.upgrade.Spec.DesiredUpdate, status.DesiredUpdate, "unexpected desired update in status of %s", status.Name)
+				if message, ok := tc.expectedMessages[status.Name]; ok {
+					assert.Equal(t, message, status.Message, "unexpected message for %s", status.Name)
+				}
+			}
+			assert.Equal(t, tc.expectedStates, states, "unexpected cluster states")
+			assert.Equal(t, int32(len(tc.expectedStates)), upgrade.Status.Total, "unexpected total")
+
+			completedCond := controllerutils.FindClusterUpgradeCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeCompletedCondition)
+			if assert.NotNil(t, completedCond, "missing completed condition") {
+				assert.Equal(t, tc.expectedCompletedStatus, completedCond.Status, "unexpected completed condition status")
+				assert.Equal(t, tc.expectedCompletedReason, completedCond.Reason, "unexpected completed condition reason")
+			}
+			readyCond := controllerutils.FindClusterUpgradeCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeReadyCondition)
+			if assert.NotNil(t, readyCond, "missing ready condition") {
+				assert.Equal(t, tc.expectedCompletedStatus, readyCond.Status, "unexpected ready condition status")
+			}
+			failedCond := controllerutils.FindClusterUpgradeCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeFailedCondition)
+			if assert.NotNil(t, failedCond, "missing failed condition") {
+				assert.Equal(t, tc.expectedFailedStatus, failedCond.Status, "unexpected failed condition status")
+			}
+
+			started := map[string]bool{}
+			for _, name := range tc.expectedStarted {
+				started[name] = true
+			}
+			for _, cluster := range tc.clusters {
+				if cluster.unreachable {
+					continue
+				}
+				cv := &configv1.ClusterVersion{}
+				require.NoError(t, remoteClients[cluster.name].Get(context.Background(), types.NamespacedName{Name: clusterVersionObjectName}, cv), "could not get cluster version")
+				if started[cluster.name] {
+					if assert.NotNil(t, cv.Spec.DesiredUpdate, "expected desired update for %s", cluster.name) {
+						assert.Equal(t, testDesiredVersion, cv.Spec.DesiredUpdate.Version, "unexpected desired update for %s", cluster.name)
+					}
+				} else if cluster.desiredUpdate == "" {
+					assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update for %s", cluster.name)
+				}
+			}
+		})
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// 2020-06-03 is a Wednesday
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2020, 6, 3, hour, minute, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		name     string
+		windows  []hivev1.MaintenanceWindow
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "no windows",
+			now:      wednesday(12, 0),
+			expected: true,
+		},
+		{
+			name:     "within daily window",
+			windows:  []hivev1.MaintenanceWindow{testMaintenanceWindow("11:30", time.Hour)},
+			now:      wednesday(12, 0),
+			expected: true,
+		},
+		{
+			name:    "before daily window",
+			windows: []hivev1.MaintenanceWindow{testMaintenanceWindow("12:30", time.Hour)},
+			now:     wednesday(12, 0),
+		},
+		{
+			name:    "after daily window",
+			windows: []hivev1.MaintenanceWindow{testMaintenanceWindow("10:00", time.Hour)},
+			now:     wednesday(12, 0),
+		},
+		{
+			name:    "end of window is excluded",
+			windows: []hivev1.MaintenanceWindow{testMaintenanceWindow("11:00", time.Hour)},
+			now:     wednesday(12, 0),
+		},
+		{
+			name:     "window from previous day",
+			windows:  []hivev1.MaintenanceWindow{testMaintenanceWindow("22:00", 4*time.Hour)},
+			now:      wednesday(1, 0),
+			expected: true,
+		},
+		{
+			name:     "matching day",
+			windows:  []hivev1.MaintenanceWindow{testMaintenanceWindow("11:00", 2*time.Hour, "Monday", "Wednesday")},
+			now:      wednesday(12, 0),
+			expected: true,
+		},
+		{
+			name:    "other day",
+			windows: []hivev1.MaintenanceWindow{testMaintenanceWindow("11:00", 2*time.Hour, "Tuesday")},
+			now:     wednesday(12, 0),
+		},
+		{
+			name:     "multi-day window from earlier day",
+			windows:  []hivev1.MaintenanceWindow{testMaintenanceWindow("20:00", 48*time.Hour, "Monday")},
+			now:      wednesday(12, 0),
+			expected: true,
+		},
+		{
+			name: "second window matches",
+			windows: []hivev1.MaintenanceWindow{
+				testMaintenanceWindow("01:00", time.Hour),
+				testMaintenanceWindow("11:00", 2*time.Hour, "wednesday"),
+			},
+			now:      wednesday(12, 0),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, inMaintenanceWindow(tc.windows, tc.now))
+		})
+	}
+}
+
+type clusterUpgradeOption func(*hivev1.ClusterUpgrade)
+
+func testClusterUpgrade(opts ...clusterUpgradeOption) *hivev1.ClusterUpgrade {
+	upgrade := &hivev1.ClusterUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testUpgradeName,
+		},
+		Spec: hivev1.ClusterUpgradeSpec{
+			ClusterDeploymentSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{testFleetLabel: "true"},
+			},
+			DesiredUpdate: hivev1.ClusterUpgradeRelease{Version: testDesiredVersion},
+		},
+	}
+	for _, o := range opts {
+		o(upgrade)
+	}
+	return upgrade
+}
+
+func withDesiredUpdate(version string) clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.DesiredUpdate.Version = version
+	}
+}
+
+func withDesiredImage(image string) clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.DesiredUpdate.Image = image
+	}
+}
+
+func withCanarySelector() clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.CanarySelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{testCanaryLabel: "true"},
+		}
+	}
+}
+
+func withMaxConcurrent(maxConcurrent int32) clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.MaxConcurrent = pointer.Int32Ptr(maxConcurrent)
+	}
+}
+
+func withPaused() clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.Paused = true
+	}
+}
+
+func withMaintenanceWindow(window hivev1.MaintenanceWindow) clusterUpgradeOption {
+	return func(upgrade *hivev1.ClusterUpgrade) {
+		upgrade.Spec.MaintenanceWindows = append(upgrade.Spec.MaintenanceWindows, window)
+	}
+}
+
+func testMaintenanceWindow(startTime string, duration time.Duration, days ...string) hivev1.MaintenanceWindow {
+	return hivev1.MaintenanceWindow{
+		Days:      days,
+		StartTime: startTime,
+		Duration:  metav1.Duration{Duration: duration},
+	}
+}
+
+func testClusterDeployment(cluster testCluster) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      cluster.name,
+			Labels:    map[string]string{},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: cluster.name,
+			Installed:   true,
+		},
+	}
+	if !cluster.notSelected {
+		cd.Labels[testFleetLabel] = "true"
+	}
+	if cluster.canary {
+		cd.Labels[testCanaryLabel] = "true"
+	}
+	unreachableStatus, unreachableMessage := corev1.ConditionFalse, ""
+	if cluster.unreachable {
+		unreachableStatus, unreachableMessage = corev1.ConditionTrue, "connection refused"
+	}
+	cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+		Type:    hivev1.UnreachableCondition,
+		Status:  unreachableStatus,
+		Message: unreachableMessage,
+	}}
+	return cd
+}
+
+func testClusterVersion(cluster testCluster) *configv1.ClusterVersion {
+	currentVersion := cluster.currentVersion
+	if currentVersion == "" {
+		currentVersion = testCurrentVersion
+	}
+	cv := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterVersionObjectName,
+		},
+		Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Update{Version: currentVersion},
+			History: []configv1.UpdateHistory{{
+				State:   configv1.CompletedUpdate,
+				Version: currentVersion,
+				Image:   fmt.Sprintf("example.com/release:%s", currentVersion),
+			}},
+		},
+	}
+	if cluster.desiredUpdate != "" {
+		cv.Spec.DesiredUpdate = &configv1.Update{Version: cluster.desiredUpdate}
+		if cluster.currentVersion != cluster.desiredUpdate {
+			// The cluster version operator has accepted the update but not completed it.
+			cv.Status.Desired = configv1.Update{Version: cluster.desiredUpdate}
+			cv.Status.History = append([]configv1.UpdateHistory{{
+				State:   configv1.PartialUpdate,
+				Version: cluster.desiredUpdate,
+			}}, cv.Status.History...)
+		}
+	}
+	if cluster.failing {
+		cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+			Type:    clusterVersionFailingCondition,
+			Status:  configv1.ConditionTrue,
+			Message: "Cluster operator foo is degraded",
+		}}
+	}
+	return cv
+}
+
+// fakeReleaseImageVerifier is a release image verifier which verifies release images with the function.
+type fakeReleaseImageVerifier func(releaseImage string) error
+
+func (f fakeReleaseImageVerifier) Verify(releaseImage string) error {
+	return f(releaseImage)
+}
@@ -0,0 +1,146 @@
+package clusterupgrade
+
+import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// selectorIndex indexes the ClusterUpgrades by a label, or label key, that a ClusterDeployment must have to be
+	// selected by their ClusterDeployment selector.
+	selectorIndex = "spec.clusterDeploymentSelector"
+	// clustersIndex indexes the ClusterUpgrades by the namespace and name of the clusters in their status.
+	clustersIndex = "status.clusters"
+
+	// matchAnyIndexValue is the selector index value of the ClusterUpgrades whose selector does not require any
+	// particular label. It is not a valid label key, so it cannot collide with the other values.
+	matchAnyIndexValue = "*"
+)
+
+// addFieldIndexes adds the field indexes used to find the ClusterUpgrades which select a ClusterDeployment without
+// listing all of the ClusterUpgrades.
+func addFieldIndexes(indexer client.FieldIndexer) error {
+	for field, extract := range map[string]client.IndexerFunc{
+		selectorIndex: indexSelector,
+		clustersIndex: indexClusters,
+	} {
+		if err := indexer.IndexField(context.Background(), &hivev1.ClusterUpgrade{}, field, extract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexSelector(o runtime.Object) []string {
+	upgrade, ok := o.(*hivev1.ClusterUpgrade)
+	if !ok {
+		return nil
+	}
+	return selectorIndexValues(upgrade.Spec.ClusterDeploymentSelector)
+}
+
+// selectorIndexValues returns the values under which a selector is indexed. A ClusterDeployment can only be selected
+// when it has one of the labels, or label keys, of the values. A selector which can select ClusterDeployments without
+// any particular label is indexed under matchAnyIndexValue.
+func selectorIndexValues(selector metav1.LabelSelector) []string {
+	if len(selector.MatchLabels) > 0 {
+		keys := make([]string, 0, len(selector.MatchLabels))
+		for key := range selector.MatchLabels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return []string{labelIndexValue(keys[0], selector.MatchLabels[keys[0]])}
+	}
+	for _, requirement := range selector.MatchExpressions {
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn:
+			values := make([]string, len(requirement.Values))
+			for i, value := range requirement.Values {
+				values[i] = labelIndexValue(requirement.Key, value)
+			}
+			return values
+		case metav1.LabelSelectorOpExists:
+			return []string{requirement.Key}
+		}
+	}
+	return []string{matchAnyIndexValue}
+}
+
+func labelIndexValue(key, value string) string {
+	return key + "=" + value
+}
+
+// clusterDeploymentIndexValues returns the selector index values of the ClusterUpgrades which may select the
+// ClusterDeployment.
+func clusterDeploymentIndexValues(cd *hivev1.ClusterDeployment) []string {
+	values := []string{matchAnyIndexValue}
+	for key, value := range cd.Labels {
+		values = append(values, key, labelIndexValue(key, value))
+	}
+	return values
+}
+
+func indexClusters(o runtime.Object) []string {
+	upgrade, ok := o.(*hivev1.ClusterUpgrade)
+	if !ok {
+		return nil
+	}
+	values := make([]string, len(upgrade.Status.Clusters))
+	for i, cluster := range upgrade.Status.Clusters {
+		values[i] = cluster.Namespace + "/" + cluster.Name
+	}
+	return values
+}
+
+// requestsForClusterDeployment returns a request for each ClusterUpgrade that selects the ClusterDeployment, and for
+// each ClusterUpgrade with the ClusterDeployment in its status so that it is removed once no longer selected.
+func requestsForClusterDeployment(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cd, ok := o.Object.(*hivev1.ClusterDeployment)
+		if !ok {
+			return nil
+		}
+		var requests []reconcile.Request
+		seen := map[string]bool{}
+		list := func(index, value string, filter func(upgrade *hivev1.ClusterUpgrade) bool) {
+			upgrades := &hivev1.ClusterUpgradeList{}
+			if err := c.List(context.Background(), upgrades, client.MatchingFields{index: value}); err != nil {
+				logger.WithError(err).WithField(index, value).Log(controllerutils.LogLevel(err), "could not list ClusterUpgrades")
+				return
+			}
+			for i, upgrade := range upgrades.Items {
+				if seen[upgrade.Name] || !filter(&upgrades.Items[i]) {
+					continue
+				}
+				seen[upgrade.Name] = true
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: upgrade.Name}})
+			}
+		}
+
+		selects := func(upgrade *hivev1.ClusterUpgrade) bool {
+			labelSelector, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+			if err != nil {
+				logger.WithField("clusterUpgrade", upgrade.Name).WithError(err).Warn("cannot parse ClusterDeployment selector")
+				return false
+			}
+			return labelSelector.Matches(labels.Set(cd.Labels))
+		}
+		for _, value := range clusterDeploymentIndexValues(cd) {
+			list(selectorIndex, value, selects)
+		}
+		list(clustersIndex, cd.Namespace+"/"+cd.Name, func(*hivev1.ClusterUpgrade) bool { return true })
+		return requests
+	}
+}
@@ -0,0 +1,94 @@
+package clusterupgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestSelectorIndex(t *testing.T) {
+	cdLabels := []map[string]string{
+		nil,
+		{"fleet": "true"},
+		{"fleet": "false"},
+		{"fleet": "true", "region": "us-east-1"},
+		{"region": "eu-west-1"},
+	}
+	cases := []struct {
+		name           string
+		selector       metav1.LabelSelector
+		expectedValues []string
+	}{
+		{
+			name:           "empty selector",
+			expectedValues: []string{matchAnyIndexValue},
+		},
+		{
+			name: "match labels",
+			selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"region": "us-east-1", "fleet": "true"},
+			},
+			expectedValues: []string{"fleet=true"},
+		},
+		{
+			name: "in",
+			selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "region",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"us-east-1", "eu-west-1"},
+				}},
+			},
+			expectedValues: []string{"region=us-east-1", "region=eu-west-1"},
+		},
+		{
+			name: "exists",
+			selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "fleet", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"false"}},
+					{Key: "fleet", Operator: metav1.LabelSelectorOpExists},
+				},
+			},
+			expectedValues: []string{"fleet"},
+		},
+		{
+			name: "not in",
+			selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "fleet", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"false"}},
+				},
+			},
+			expectedValues: []string{matchAnyIndexValue},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values := selectorIndexValues(tc.selector)
+			assert.Equal(t, tc.expectedValues, values, "unexpected index values")
+
+			// Every ClusterDeployment selected by the selector must be found through the index.
+			selector, err := metav1.LabelSelectorAsSelector(&tc.selector)
+			require.NoError(t, err, "invalid selector")
+			indexed := map[string]bool{}
+			for _, value := range values {
+				indexed[value] = true
+			}
+			for _, l := range cdLabels {
+				cd := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Labels: l}}
+				found := false
+				for _, value := range clusterDeploymentIndexValues(cd) {
+					found = found || indexed[value]
+				}
+				if selector.Matches(labels.Set(l)) {
+					assert.True(t, found, "selected ClusterDeployment with labels %v not found through the index", l)
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package controlplanecerts
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/tls"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"sort"
 	"strings"
@@ -48,16 +52,22 @@ const (
 	certsNotFoundMessage = "One or more serving certificates for the cluster control plane are missing"
 	certsFoundReason     = "ControlPlaneCertificatesFound"
 	certsFoundMessage    = "Control plane certificates are present"
+	certsNotServedReason = hivev1.ControlPlaneCertificatesNotServedReason
 
 	kubeAPIServerPatchTemplate = `[ {"op": "replace", "path": "/spec/forceRedeploymentReason", "value": %q } ]`
 )
 
 var (
-	secretCheckInterval = 2 * time.Minute
+	secretCheckInterval             = 2 * time.Minute
+	servingCertificateCheckInterval = 30 * time.Second
+	servingCertificateDialTimeout   = 10 * time.Second
 )
 
+// servingCertificateGetter returns the DER encoded leaf certificate served at the address for the server name.
+type servingCertificateGetter func(address, serverName string) ([]byte, error)
+
 type applier interface {
-	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+	ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error)
 }
 
 // Add creates a new ControlPlaneCerts Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -81,9 +91,10 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 		logger.WithError(err).Fatal("unable to create resource helper")
 	}
 	r := &ReconcileControlPlaneCerts{
-		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:  mgr.GetScheme(),
-		applier: helper,
+		Client:                   controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:                   mgr.GetScheme(),
+		applier:                  helper,
+		servingCertificateGetter: getServingCertificate,
 	}
 
 	return r
@@ -93,7 +104,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("controlplanecerts-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -117,6 +128,9 @@ type ReconcileControlPlaneCerts struct {
 	client.Client
 	scheme  *runtime.Scheme
 	applier applier
+
+	// servingCertificateGetter is used to check which certificates the API server of the remote cluster is serving.
+	servingCertificateGetter servingCertificateGetter
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -170,24 +184,18 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, err
 	}
 
-	// clear condition if certs were found
-	updated, err := r.setCertsNotFoundCondition(cd, !secretsAvailable, cdLog)
-	if err != nil {
-		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment secrets not found condition")
-		return reconcile.Result{}, err
-	}
-	if updated {
-		return reconcile.Result{}, nil
-	}
-
 	if !secretsAvailable {
+		if err := r.setCertsNotFoundCondition(cd, corev1.ConditionTrue, certsNotFoundReason, certsNotFoundMessage, cdLog); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment secrets not found condition")
+			return reconcile.Result{}, err
+		}
 		cdLog.Debugf("cert secrets are not available yet, requeueing clusterdeployment for %s", secretCheckInterval)
 		return reconcile.Result{RequeueAfter: secretCheckInterval}, nil
 	}
 
 	if len(secrets) == 0 && existingSyncSet == nil {
 		cdLog.Debug("no control plane certs needed, and no syncset exists, nothing to do")
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.clearCertsNotFoundCondition(cd, cdLog)
 	}
 
 	desiredSyncSet, err := r.generateControlPlaneCertsSyncSet(cd, secrets, cdLog)
@@ -196,12 +204,29 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, err
 	}
 
-	if _, err = r.applier.ApplyRuntimeObject(desiredSyncSet, r.scheme); err != nil {
+	if _, err = r.applier.ApplyRuntimeObject(context.TODO(), desiredSyncSet, r.scheme); err != nil {
 		cdLog.WithError(err).Error("failed to apply control plane certificates syncset")
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	// Hold off on clearing the condition until the API server is serving the certificates. Until then the
+	// clustersync controller only applies the control plane certs syncset to the cluster.
+	domainsNotServed, err := r.getDomainsNotServed(cd, secrets, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to check the certificates served by the control plane")
+		return reconcile.Result{}, err
+	}
+	if len(domainsNotServed) > 0 {
+		message := fmt.Sprintf("The API server is not yet serving the certificates for %s", strings.Join(domainsNotServed, ", "))
+		if err := r.setCertsNotFoundCondition(cd, corev1.ConditionTrue, certsNotServedReason, message, cdLog); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment secrets not found condition")
+			return reconcile.Result{}, err
+		}
+		cdLog.WithField("domains", domainsNotServed).Infof("control plane certificates are not served yet, requeueing clusterdeployment for %s", servingCertificateCheckInterval)
+		return reconcile.Result{RequeueAfter: servingCertificateCheckInterval}, nil
+	}
+
+	return reconcile.Result{}, r.clearCertsNotFoundCondition(cd, cdLog)
 }
 
 func (r *ReconcileControlPlaneCerts) getControlPlaneSecrets(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*corev1.Secret, bool, error) {
@@ -370,31 +395,145 @@ func (r *ReconcileControlPlaneCerts) getServingCertificatesJSONPatch(cd *hivev1.
 
 }
 
-func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.ClusterDeployment, notFound bool, cdLog log.FieldLogger) (bool, error) {
-	status := corev1.ConditionFalse
-	reason := certsFoundReason
-	message := certsFoundMessage
-	if notFound {
-		status = corev1.ConditionTrue
-		reason = certsNotFoundReason
-		message = certsNotFoundMessage
+// getDomainsNotServed returns the control plane domains for which the API server is not serving the certificate from
+// the corresponding certificate bundle.
+func (r *ReconcileControlPlaneCerts) getDomainsNotServed(cd *hivev1.ClusterDeployment, secrets []*corev1.Secret, cdLog log.FieldLogger) ([]string, error) {
+	if len(secrets) == 0 || controllerutils.IsFakeCluster(cd) {
+		return nil, nil
+	}
+	address, err := r.controlPlaneAddress(cd)
+	if err != nil {
+		return nil, err
+	}
+
+	// map of domain to the name of the certificate bundle that should be served for the domain
+	domainCerts := map[string]string{}
+	if defaultCert := cd.Spec.ControlPlaneConfig.ServingCertificates.Default; defaultCert != "" {
+		apidomain, err := r.defaultControlPlaneDomain(cd)
+		if err != nil {
+			return nil, err
+		}
+		domainCerts[apidomain] = defaultCert
+	}
+	for _, additional := range cd.Spec.ControlPlaneConfig.ServingCertificates.Additional {
+		domainCerts[additional.Domain] = additional.Name
+	}
+	domains := make([]string, 0, len(domainCerts))
+	for domain := range domainCerts {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	var notServed []string
+	for _, domain := range domains {
+		logger := cdLog.WithField("domain", domain)
+		expected, err := expectedServingCertificate(cd, domainCerts[domain], secrets)
+		if err != nil {
+			return nil, err
+		}
+		served, err := r.servingCertificateGetter(address, domain)
+		if err != nil {
+			logger.WithError(err).Info("could not get the certificate served by the control plane")
+			notServed = append(notServed, domain)
+			continue
+		}
+		if !bytes.Equal(expected, served) {
+			logger.Debug("control plane is not serving the expected certificate")
+			notServed = append(notServed, domain)
+		}
+	}
+	return notServed, nil
+}
+
+// controlPlaneAddress returns the host and port on which Hive is currently communicating with the API server of the
+// remote cluster.
+func (r *ReconcileControlPlaneCerts) controlPlaneAddress(cd *hivev1.ClusterDeployment) (string, error) {
+	apiURL := cd.Spec.ControlPlaneConfig.APIURLOverride
+	if apiURL == "" || !remoteclient.IsPrimaryURLActive(cd) {
+		var err error
+		if apiURL, err = remoteclient.InitialURL(r.Client, cd); err != nil {
+			return "", errors.Wrap(err, "failed to fetch initial API URL")
+		}
 	}
+	if !strings.Contains(apiURL, "://") {
+		apiURL = "https://" + apiURL
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse cluster's API URL")
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// expectedServingCertificate returns the DER encoded leaf certificate from the secret of the named certificate bundle.
+func expectedServingCertificate(cd *hivev1.ClusterDeployment, bundleName string, secrets []*corev1.Secret) ([]byte, error) {
+	bundle := certificateBundle(cd, bundleName)
+	if bundle == nil {
+		return nil, fmt.Errorf("no certificate bundle was found for %s", bundleName)
+	}
+	for _, secret := range secrets {
+		if secret.Name != bundle.CertificateSecretRef.Name {
+			continue
+		}
+		block, _ := pem.Decode(secret.Data[constants.TLSCrtSecretKey])
+		if block == nil {
+			return nil, fmt.Errorf("secret %s does not contain a PEM encoded certificate", secret.Name)
+		}
+		return block.Bytes, nil
+	}
+	return nil, fmt.Errorf("secret %s for certificate bundle %s was not found", bundle.CertificateSecretRef.Name, bundleName)
+}
+
+// getServingCertificate connects to the address using the server name for SNI and returns the leaf certificate
+// presented by the server.
+func getServingCertificate(address, serverName string) ([]byte, error) {
+	dialer := &net.Dialer{Timeout: servingCertificateDialTimeout}
+	// The served certificate is compared byte for byte with the expected certificate, so there is no need to verify
+	// the certificate chain here.
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New("server did not present a certificate")
+	}
+	return certs[0].Raw, nil
+}
+
+func (r *ReconcileControlPlaneCerts) clearCertsNotFoundCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if err := r.setCertsNotFoundCondition(cd, corev1.ConditionFalse, certsFoundReason, certsFoundMessage, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment secrets not found condition")
+		return err
+	}
+	return nil
+}
 
+func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
 	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ControlPlaneCertificateNotFoundCondition,
 		status,
 		reason,
 		message,
-		controllerutils.UpdateConditionNever,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
 
 	if !changed {
-		return false, nil
+		return nil
 	}
 
+	cdLog.WithField("reason", reason).Debug("updating control plane certificate not found condition")
 	cd.Status.Conditions = conds
-	return true, r.Status().Update(context.TODO(), cd)
+	return r.Status().Update(context.TODO(), cd)
 }
 
 // defaultControlPlaneDomain will attempt to return the domain/hostname for the secondary API URL
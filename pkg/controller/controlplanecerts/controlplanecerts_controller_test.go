@@ -3,8 +3,10 @@ package controlplanecerts
 import (
 	"context"
 	"crypto/rand"
+	"encoding/pem"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -38,6 +40,7 @@ const (
 	fakeDomain           = "example.com"
 	fakeAPIURL           = "https://test-api-url:6443"
 	fakeAPIURLDomain     = "test-api-url"
+	fakeAPIAddress       = "test-api-url:6443"
 	kubeconfigSecretName = "test-kubeconfig"
 	adminKubeconfig      = `clusters:
 - cluster:
@@ -63,10 +66,17 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 		name     string
 		existing []runtime.Object
 
+		// map of domain to the name of the secret with the certificate served for the domain
+		servedCerts map[string]string
+		// address at which the served certificates are expected to be checked, defaults to fakeAPIAddress
+		expectedAddress string
+
 		expectNoSyncSet        bool
 		expectedPatch          string
 		expectedSecrets        []string
 		expectedNotFoundStatus corev1.ConditionStatus
+		expectedNotFoundReason string
+		expectedRequeueAfter   time.Duration
 	}{
 		{
 			name: "no control plane certs",
@@ -81,6 +91,31 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				fakeClusterDeployment().defaultCert("default-cert", "default-secret").obj(),
 				fakeCertSecret("default-secret"),
 			},
+			servedCerts:     map[string]string{fakeAPIURLDomain: "default-secret"},
+			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets: []string{"default-secret"},
+		},
+		{
+			name: "default control plane certs not served yet",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").obj(),
+				fakeCertSecret("default-secret"),
+			},
+			expectedPatch:          `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:        []string{"default-secret"},
+			expectedNotFoundStatus: corev1.ConditionTrue,
+			expectedNotFoundReason: hivev1.ControlPlaneCertificatesNotServedReason,
+			expectedRequeueAfter:   servingCertificateCheckInterval,
+		},
+		{
+			name: "default control plane certs served on API URL override",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").
+					apiURLOverride("https://test-api-url-override:6443").obj(),
+				fakeCertSecret("default-secret"),
+			},
+			servedCerts:     map[string]string{fakeAPIURLDomain: "default-secret"},
+			expectedAddress: "test-api-url-override:6443",
 			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
 			expectedSecrets: []string{"default-secret"},
 		},
@@ -93,9 +128,26 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				fakeCertSecret("secret1"),
 				fakeCertSecret("secret2"),
 			},
+			servedCerts:     map[string]string{"foo.com": "secret1", "bar.com": "secret2"},
 			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "foo.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } }, { "names": [ "bar.com" ], "servingCertificate": { "name": "fake-cluster-secret2" } } ] } ]`,
 			expectedSecrets: []string{"secret1", "secret2"},
 		},
+		{
+			name: "additional cert served with wrong certificate",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					namedCert("cert1", "foo.com", "secret1").
+					namedCert("cert2", "bar.com", "secret2").obj(),
+				fakeCertSecret("secret1"),
+				fakeCertSecret("secret2"),
+			},
+			servedCerts:            map[string]string{"foo.com": "secret1", "bar.com": "secret1"},
+			expectedPatch:          `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "foo.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } }, { "names": [ "bar.com" ], "servingCertificate": { "name": "fake-cluster-secret2" } } ] } ]`,
+			expectedSecrets:        []string{"secret1", "secret2"},
+			expectedNotFoundStatus: corev1.ConditionTrue,
+			expectedNotFoundReason: hivev1.ControlPlaneCertificatesNotServedReason,
+			expectedRequeueAfter:   servingCertificateCheckInterval,
+		},
 		{
 			name: "default and additional certs",
 			existing: []runtime.Object{
@@ -108,6 +160,7 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				fakeCertSecret("secret1"),
 				fakeCertSecret("secret2"),
 			},
+			servedCerts:     map[string]string{fakeAPIURLDomain: "secret0", "foo.com": "secret1", "bar.com": "secret2"},
 			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-secret0" } }, { "names": [ "foo.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } }, { "names": [ "bar.com" ], "servingCertificate": { "name": "fake-cluster-secret2" } } ] } ]`,
 			expectedSecrets: []string{"secret0", "secret1", "secret2"},
 		},
@@ -123,6 +176,8 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			},
 			expectNoSyncSet:        true,
 			expectedNotFoundStatus: corev1.ConditionTrue,
+			expectedNotFoundReason: certsNotFoundReason,
+			expectedRequeueAfter:   secretCheckInterval,
 		},
 		{
 			name: "existing syncset remove certs",
@@ -135,12 +190,26 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 		{
 			name: "existing not found condition changed to false",
 			existing: []runtime.Object{
-				fakeClusterDeployment().defaultCert("default", "test-secret").withNotFoundCondition().obj(),
+				fakeClusterDeployment().defaultCert("default", "test-secret").withNotFoundCondition(certsNotFoundReason).obj(),
 				fakeCertSecret("test-secret"),
 			},
-			// no apply expected because we update the condition and return immediately, next reconcile would apply
-			expectNoSyncSet:        true,
+			servedCerts:            map[string]string{fakeAPIURLDomain: "test-secret"},
+			expectedPatch:          `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-test-secret" } } ] } ]`,
+			expectedSecrets:        []string{"test-secret"},
+			expectedNotFoundStatus: corev1.ConditionFalse,
+			expectedNotFoundReason: certsFoundReason,
+		},
+		{
+			name: "existing not served condition changed to false",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default", "test-secret").withNotFoundCondition(certsNotServedReason).obj(),
+				fakeCertSecret("test-secret"),
+			},
+			servedCerts:            map[string]string{fakeAPIURLDomain: "test-secret"},
+			expectedPatch:          `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-test-secret" } } ] } ]`,
+			expectedSecrets:        []string{"test-secret"},
 			expectedNotFoundStatus: corev1.ConditionFalse,
+			expectedNotFoundReason: certsFoundReason,
 		},
 	}
 
@@ -159,13 +228,25 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			defer mockController.Finish()
 
 			applier := &fakeApplier{}
+			expectedAddress := test.expectedAddress
+			if expectedAddress == "" {
+				expectedAddress = fakeAPIAddress
+			}
 			r := &ReconcileControlPlaneCerts{
 				Client:  fakeClient,
 				scheme:  scheme.Scheme,
 				applier: applier,
+				servingCertificateGetter: func(address, serverName string) ([]byte, error) {
+					assert.Equal(t, expectedAddress, address, "unexpected address for control plane")
+					secretName, ok := test.servedCerts[serverName]
+					if !ok {
+						return nil, fmt.Errorf("connection refused")
+					}
+					return fakeCertificate(secretName), nil
+				},
 			}
 
-			_, err := r.Reconcile(reconcile.Request{
+			result, err := r.Reconcile(reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      fakeName,
 					Namespace: fakeNamespace,
@@ -173,6 +254,7 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			})
 
 			assert.Nil(t, err)
+			assert.Equal(t, test.expectedRequeueAfter, result.RequeueAfter, "unexpected requeue after")
 
 			cd := getFakeClusterDeployment(t, fakeClient)
 
@@ -210,6 +292,9 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			if test.expectedNotFoundStatus != "" {
 				assert.NotNil(t, notFoundCondition, "expected a NotFound condition")
 				assert.Equal(t, test.expectedNotFoundStatus, notFoundCondition.Status, "unexpected NotFound status")
+				if test.expectedNotFoundReason != "" {
+					assert.Equal(t, test.expectedNotFoundReason, notFoundCondition.Reason, "unexpected NotFound reason")
+				}
 			} else {
 				assert.Nil(t, notFoundCondition, "test did not specify an expectedNotFoundStatus but condition was present")
 			}
@@ -305,9 +390,9 @@ type fakeApplier struct {
 	appliedObjects []runtime.Object
 }
 
-func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+func (a *fakeApplier) ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error) {
 	a.appliedObjects = append(a.appliedObjects, obj)
-	return "", nil
+	return &resource.AppliedObject{}, nil
 }
 
 type fakeClusterDeploymentWrapper struct {
@@ -368,12 +453,21 @@ func (f *fakeClusterDeploymentWrapper) namedCert(name, domain, secretName string
 	return f
 }
 
-func (f *fakeClusterDeploymentWrapper) withNotFoundCondition() *fakeClusterDeploymentWrapper {
+func (f *fakeClusterDeploymentWrapper) apiURLOverride(override string) *fakeClusterDeploymentWrapper {
+	f.cd.Spec.ControlPlaneConfig.APIURLOverride = override
+	f.cd.Status.Conditions = append(f.cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+		Type:   hivev1.ActiveAPIURLOverrideCondition,
+		Status: corev1.ConditionTrue,
+	})
+	return f
+}
+
+func (f *fakeClusterDeploymentWrapper) withNotFoundCondition(reason string) *fakeClusterDeploymentWrapper {
 	f.cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
 		f.cd.Status.Conditions,
 		hivev1.ControlPlaneCertificateNotFoundCondition,
 		corev1.ConditionTrue,
-		"",
+		reason,
 		"",
 		controllerutils.UpdateConditionNever,
 	)
@@ -386,12 +480,16 @@ func fakeCertSecret(name string) *corev1.Secret {
 	s.Namespace = fakeNamespace
 	s.Data = map[string][]byte{
 		constants.TLSKeySecretKey: []byte("blah"),
-		constants.TLSCrtSecretKey: []byte("blah"),
+		constants.TLSCrtSecretKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fakeCertificate(name)}),
 	}
 	s.Type = corev1.SecretTypeTLS
 	return s
 }
 
+func fakeCertificate(secretName string) []byte {
+	return []byte("certificate-" + secretName)
+}
+
 type additionalCertSpec struct {
 	domain string
 	secret string
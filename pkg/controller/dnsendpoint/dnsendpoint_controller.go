@@ -57,7 +57,7 @@ func Add(mgr manager.Manager) error {
 		ControllerName.String(),
 		mgr,
 		controller.Options{
-			Reconciler:              reconciler,
+			Reconciler:              hivemetrics.NewReconcilerWithMetrics(reconciler, ControllerName),
 			MaxConcurrentReconciles: concurrentReconciles,
 			RateLimiter:             queueRateLimiter,
 		},
@@ -18,7 +18,7 @@ import (
 func NewAWSQuery(c client.Client, credsSecretName string, region string) Query {
 	return &awsQuery{
 		getAWSClient: func() (awsclient.Client, error) {
-			awsClient, err := awsclient.NewClient(c, credsSecretName, controllerutils.GetHiveNamespace(), region)
+			awsClient, err := awsclient.NewClient(c, credsSecretName, controllerutils.GetHiveNamespace(), region, nil)
 			return awsClient, errors.Wrap(err, "error creating AWS client")
 		},
 	}
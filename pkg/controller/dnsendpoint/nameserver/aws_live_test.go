@@ -122,7 +122,7 @@ func (s *LiveAWSTestSuite) TestDeleteOfNonExistentNS() {
 func (s *LiveAWSTestSuite) getCUT() *awsQuery {
 	return &awsQuery{
 		getAWSClient: func() (awsclient.Client, error) {
-			return awsclient.NewClient(nil, "", "", "us-east-1")
+			return awsclient.NewClient(nil, "", "", "us-east-1", nil)
 		},
 	}
 }
@@ -1,5 +1,9 @@
 package dnszone
 
+import (
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
 // Actuator interface is the interface that is used to add dns provider support to the dnszone controller.
 type Actuator interface {
 	// Create tells the actuator to make a zone in the dns provider.
@@ -15,6 +19,10 @@ type Actuator interface {
 	// UpdateMetadata tells the actuator to update the zone's metadata in the dns provider.
 	UpdateMetadata() error
 
+	// SyncRecords creates or updates the records of the DNSZone spec in the zone, and deletes the given records which
+	// are no longer in the spec.
+	SyncRecords(toDelete []hivev1.DNSRecord) error
+
 	// GetNameServers returns a list of nameservers that service the zone in the dns provider.
 	GetNameServers() ([]string, error)
 
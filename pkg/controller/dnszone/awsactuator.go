@@ -3,6 +3,7 @@ package dnszone
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -14,11 +15,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 const (
@@ -42,11 +46,18 @@ type AWSActuator struct {
 	// currentTags are the list of tags associated with the currentHostedZone
 	currentHostedZoneTags []*route53.Tag
 
+	// currentHostedZoneVPCs are the VPCs associated with the hostedZone, when it is a private hosted zone
+	currentHostedZoneVPCs []*route53.VPC
+
+	// assumeRoleClient creates a client authenticated as a role of the account owning a VPC, to associate VPCs of
+	// other accounts with a private hosted zone
+	assumeRoleClient func(roleARN string) (awsclient.Client, error)
+
 	// The DNSZone that represents the desired state.
 	dnsZone *hivev1.DNSZone
 }
 
-type awsClientBuilderType func(secret *corev1.Secret, region string) (awsclient.Client, error)
+type awsClientBuilderType func(secret *corev1.Secret, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (awsclient.Client, error)
 
 // NewAWSActuator creates a new AWSActuator object. A new AWSActuator is expected to be created for each controller sync.
 func NewAWSActuator(
@@ -59,7 +70,7 @@ func NewAWSActuator(
 	if region == "" {
 		region = constants.AWSRoute53Region
 	}
-	awsClient, err := awsClientBuilder(secret, region)
+	awsClient, err := awsClientBuilder(secret, region, dnsZone.Spec.AWS.ServiceEndpoints)
 	if err != nil {
 		logger.WithError(err).Error("Error creating AWSClient")
 		return nil, err
@@ -68,7 +79,10 @@ func NewAWSActuator(
 	awsActuator := &AWSActuator{
 		logger:    logger,
 		awsClient: awsClient,
-		dnsZone:   dnsZone,
+		assumeRoleClient: func(roleARN string) (awsclient.Client, error) {
+			return awsclient.NewClientFromSecretAssumingRole(secret, roleARN, region, dnsZone.Spec.AWS.ServiceEndpoints)
+		},
+		dnsZone: dnsZone,
 	}
 
 	return awsActuator, nil
@@ -80,8 +94,10 @@ func (a *AWSActuator) UpdateMetadata() error {
 		return errors.New("hostedZone is unpopulated")
 	}
 
-	// For now, tags are the only things we can sync with existing zones.
-	return a.syncTags()
+	if err := a.syncTags(); err != nil {
+		return err
+	}
+	return a.syncVPCAssociations()
 }
 
 // syncTags determines if there are changes that need to happen to match tags in the spec
@@ -163,14 +179,124 @@ func (a *AWSActuator) syncTags() error {
 	return nil
 }
 
+// syncVPCAssociations associates the VPCs in the spec with the private hosted zone, and disassociates the VPCs no longer
+// in the spec.
+func (a *AWSActuator) syncVPCAssociations() error {
+	privateZone := a.dnsZone.Spec.AWS.PrivateZone
+	if privateZone == nil {
+		return nil
+	}
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+
+	associated := sets.NewString()
+	for _, vpc := range a.currentHostedZoneVPCs {
+		associated.Insert(aws.StringValue(vpc.VPCId))
+	}
+	expected := sets.NewString()
+	for _, vpc := range privateZone.VPCs {
+		expected.Insert(vpc.VPCID)
+		if associated.Has(vpc.VPCID) {
+			continue
+		}
+		if err := a.associateVPC(vpc, logger.WithField("vpc", vpc.VPCID)); err != nil {
+			return err
+		}
+		a.currentHostedZoneVPCs = append(a.currentHostedZoneVPCs, &route53.VPC{
+			VPCId:     aws.String(vpc.VPCID),
+			VPCRegion: aws.String(vpc.Region),
+		})
+	}
+
+	// The VPCs in the spec are associated first, as the last VPC of a private hosted zone cannot be disassociated.
+	var remaining []*route53.VPC
+	for _, vpc := range a.currentHostedZoneVPCs {
+		if expected.Has(aws.StringValue(vpc.VPCId)) {
+			remaining = append(remaining, vpc)
+			continue
+		}
+		vpcLogger := logger.WithField("vpc", aws.StringValue(vpc.VPCId))
+		vpcLogger.Info("disassociating VPC from hosted zone")
+		if _, err := a.awsClient.DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC:          vpc,
+		}); err != nil {
+			vpcLogger.WithError(err).Error("cannot disassociate VPC from hosted zone")
+			return err
+		}
+	}
+	a.currentHostedZoneVPCs = remaining
+
+	return a.modifyStatus()
+}
+
+// associateVPC associates the VPC with the private hosted zone. A VPC of another account is associated by the role of
+// that account, after the association is authorized by the account of the hosted zone.
+func (a *AWSActuator) associateVPC(vpc hivev1.AWSDNSZoneVPC, logger log.FieldLogger) error {
+	route53VPC := &route53.VPC{
+		VPCId:     aws.String(vpc.VPCID),
+		VPCRegion: aws.String(vpc.Region),
+	}
+	if vpc.AssumeRoleARN == "" {
+		logger.Info("associating VPC with hosted zone")
+		if _, err := a.awsClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC:          route53VPC,
+		}); err != nil {
+			logger.WithError(err).Error("cannot associate VPC with hosted zone")
+			return err
+		}
+		return nil
+	}
+
+	logger = logger.WithField("role", vpc.AssumeRoleARN)
+	logger.Info("authorizing association of VPC of another account with hosted zone")
+	if _, err := a.awsClient.CreateVPCAssociationAuthorization(&route53.CreateVPCAssociationAuthorizationInput{
+		HostedZoneId: a.hostedZone.Id,
+		VPC:          route53VPC,
+	}); err != nil {
+		logger.WithError(err).Error("cannot authorize association of VPC with hosted zone")
+		return err
+	}
+	vpcAccountClient, err := a.assumeRoleClient(vpc.AssumeRoleARN)
+	if err != nil {
+		logger.WithError(err).Error("cannot create AWS client assuming role of VPC account")
+		return err
+	}
+	logger.Info("associating VPC of another account with hosted zone")
+	if _, err := vpcAccountClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: a.hostedZone.Id,
+		VPC:          route53VPC,
+	}); err != nil {
+		logger.WithError(err).Error("cannot associate VPC of another account with hosted zone")
+		return err
+	}
+	// The authorization is no longer needed once the VPC is associated. Keeping it would let the account of the VPC
+	// associate the VPC again after it is removed from the spec.
+	if _, err := a.awsClient.DeleteVPCAssociationAuthorization(&route53.DeleteVPCAssociationAuthorizationInput{
+		HostedZoneId: a.hostedZone.Id,
+		VPC:          route53VPC,
+	}); err != nil {
+		logger.WithError(err).Error("cannot delete authorization of association of VPC with hosted zone")
+		return err
+	}
+	return nil
+}
+
 // modifyStatus updates the DnsZone's status with AWS specific information.
 func (a *AWSActuator) modifyStatus() error {
 	if a.hostedZone == nil {
 		return errors.New("zoneID is unpopulated")
 	}
 
+	var vpcIDs []string
+	for _, vpc := range a.currentHostedZoneVPCs {
+		vpcIDs = append(vpcIDs, aws.StringValue(vpc.VPCId))
+	}
+	sort.Strings(vpcIDs)
+
 	a.dnsZone.Status.AWS = &hivev1.AWSDNSZoneStatus{
-		ZoneID: a.hostedZone.Id,
+		ZoneID:         a.hostedZone.Id,
+		AssociatedVPCs: vpcIDs,
 	}
 
 	return nil
@@ -227,6 +353,7 @@ func (a *AWSActuator) Refresh() error {
 		}
 		logger.Debug("Found hosted zone")
 		a.hostedZone = resp.HostedZone
+		a.currentHostedZoneVPCs = resp.VPCs
 
 		// Update dnsZone status now that we have the zoneID
 		if err := a.modifyStatus(); err != nil {
@@ -326,14 +453,30 @@ func (a *AWSActuator) Create() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
 	logger.Info("Creating route53 hostedzone")
 	var hostedZone *route53.HostedZone
-	resp, err := a.awsClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+	var vpcs []*route53.VPC
+	input := &route53.CreateHostedZoneInput{
 		Name: aws.String(a.dnsZone.Spec.Zone),
 		// We use the UID of the HostedZone resource as the caller reference so that if
 		// we fail to update the status of the HostedZone with the ID of the recently
 		// created zone, we don't attempt to recreate it. Same if communication fails on
 		// the response from AWS.
 		CallerReference: aws.String(string(a.dnsZone.UID)),
-	})
+	}
+	if privateZone := a.dnsZone.Spec.AWS.PrivateZone; privateZone != nil {
+		// A private hosted zone is created associated with a VPC of its own account. The other VPCs are associated
+		// once the zone exists.
+		vpc := firstSameAccountVPC(privateZone)
+		if vpc == nil {
+			return errors.New("private zone has no VPC in the account of the credentials")
+		}
+		logger = logger.WithField("vpc", vpc.VPCID)
+		input.HostedZoneConfig = &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}
+		input.VPC = &route53.VPC{
+			VPCId:     aws.String(vpc.VPCID),
+			VPCRegion: aws.String(vpc.Region),
+		}
+	}
+	resp, err := a.awsClient.CreateHostedZone(input)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeHostedZoneAlreadyExists {
 			// If the zone was already created, we need to find its ID
@@ -343,6 +486,15 @@ func (a *AWSActuator) Create() error {
 				logger.Error("Failed to find zone by caller reference")
 				return err
 			}
+			if input.VPC != nil {
+				// The VPCs of the zone are not listed by name, they are fetched with the zone.
+				zoneResp, err := a.awsClient.GetHostedZone(&route53.GetHostedZoneInput{Id: hostedZone.Id})
+				if err != nil {
+					logger.WithError(err).Error("Cannot get hosted zone")
+					return err
+				}
+				vpcs = zoneResp.VPCs
+			}
 		} else {
 			logger.WithError(err).Error("Error creating hosted zone")
 			return err
@@ -350,6 +502,9 @@ func (a *AWSActuator) Create() error {
 	} else {
 		logger.Debug("Hosted zone successfully created")
 		hostedZone = resp.HostedZone
+		if resp.VPC != nil {
+			vpcs = []*route53.VPC{resp.VPC}
+		}
 	}
 
 	logger = logger.WithField("id", aws.StringValue(hostedZone.Id))
@@ -361,6 +516,7 @@ func (a *AWSActuator) Create() error {
 	}
 
 	a.hostedZone = hostedZone
+	a.currentHostedZoneVPCs = vpcs
 	if err := a.modifyStatus(); err != nil {
 		logger.WithError(err).Error("failed to populate DNSZone status")
 		return err
@@ -377,7 +533,25 @@ func (a *AWSActuator) Create() error {
 		return err
 	}
 
-	return err
+	logger.Debug("Syncing zone VPC associations")
+	if err := a.syncVPCAssociations(); err != nil {
+		// As for the tags, the associations are retried with the create call.
+		logger.WithError(err).Error("Failed to associate VPCs with newly created zone")
+		return err
+	}
+
+	return nil
+}
+
+// firstSameAccountVPC returns the first VPC of the private zone which belongs to the account of the credentials of the
+// DNSZone, or nil if there is none.
+func firstSameAccountVPC(privateZone *hivev1.AWSPrivateDNSZone) *hivev1.AWSDNSZoneVPC {
+	for i, vpc := range privateZone.VPCs {
+		if vpc.AssumeRoleARN == "" {
+			return &privateZone.VPCs[i]
+		}
+	}
+	return nil
 }
 
 func (a *AWSActuator) findZoneByCallerReference(domain, callerRef string) (*route53.HostedZone, error) {
@@ -424,7 +598,7 @@ func (a *AWSActuator) Delete() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("id", aws.StringValue(a.hostedZone.Id))
 
 	logger.Info("Deleting route53 recordsets in hostedzone")
-	if err := DeleteAWSRecordSets(a.awsClient, a.dnsZone, logger); err != nil {
+	if err := platform.DeleteAWSRecordSets(a.awsClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
@@ -442,50 +616,106 @@ func (a *AWSActuator) Delete() error {
 	return err
 }
 
-// DeleteAWSRecordSets will clean up a DNS zone down to the minimum required record entries
-func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
-
-	maxItems := "100"
-	listInput := &route53.ListResourceRecordSetsInput{
-		HostedZoneId: dnsZone.Status.AWS.ZoneID,
-		MaxItems:     &maxItems,
+// SyncRecords upserts the records of the DNSZone spec which differ from the record sets of the route53 hosted zone,
+// and deletes the given records.
+func (a *AWSActuator) SyncRecords(toDelete []hivev1.DNSRecord) error {
+	if len(a.dnsZone.Spec.Records) == 0 && len(toDelete) == 0 {
+		return nil
 	}
-	for {
-		listOutput, err := awsClient.ListResourceRecordSets(listInput)
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+
+	var changes []*route53.Change
+	for _, record := range a.dnsZone.Spec.Records {
+		recordSet := &route53.ResourceRecordSet{
+			Name: aws.String(recordFQDN(a.dnsZone.Spec.Zone, record)),
+			Type: aws.String(string(record.Type)),
+			TTL:  aws.Int64(recordTTL(record)),
+		}
+		for _, value := range record.Values {
+			recordSet.ResourceRecords = append(recordSet.ResourceRecords, &route53.ResourceRecord{Value: aws.String(value)})
+		}
+		current, err := a.findRecordSet(record)
 		if err != nil {
 			return err
 		}
-		var changes []*route53.Change
-		for _, recordSet := range listOutput.ResourceRecordSets {
-			// Ignore the 2 recordsets that are created with the hosted zone and that cannot be deleted
-			if n, t := aws.StringValue(recordSet.Name), aws.StringValue(recordSet.Type); n == controllerutils.Dotted(dnsZone.Spec.Zone) && (t == route53.RRTypeNs || t == route53.RRTypeSoa) {
-				continue
-			}
-
-			logger.WithField("name", aws.StringValue(recordSet.Name)).WithField("type", aws.StringValue(recordSet.Type)).Info("recordset set for deletion")
-			changes = append(changes, &route53.Change{
-				Action:            aws.String(route53.ChangeActionDelete),
-				ResourceRecordSet: recordSet,
-			})
+		if current != nil && awsRecordSetInSync(current, recordSet) {
+			logger.WithField("name", record.Name).WithField("type", record.Type).Debug("record is in sync")
+			continue
 		}
-		if len(changes) > 0 {
-			logger.WithField("count", len(changes)).Info("deleting recordsets")
-			if _, err := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
-				ChangeBatch:  &route53.ChangeBatch{Changes: changes},
-				HostedZoneId: dnsZone.Status.AWS.ZoneID,
-			}); err != nil {
-				return err
-			}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: recordSet,
+		})
+	}
+	for _, record := range toDelete {
+		// A record set is deleted with its current values, which may differ from the values last synced.
+		recordSet, err := a.findRecordSet(record)
+		if err != nil {
+			return err
 		}
-		if listOutput.IsTruncated == nil || !*listOutput.IsTruncated {
-			break
+		if recordSet == nil {
+			logger.WithField("name", record.Name).WithField("type", record.Type).Debug("record already deleted")
+			continue
 		}
-		listInput.StartRecordIdentifier = listOutput.NextRecordIdentifier
-		listInput.StartRecordName = listOutput.NextRecordName
-		listInput.StartRecordType = listOutput.NextRecordType
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: recordSet,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	logger.WithField("count", len(changes)).Info("syncing records")
+	if _, err := a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		HostedZoneId: a.hostedZone.Id,
+	}); err != nil {
+		logger.WithError(err).Error("Cannot sync records")
+		return err
 	}
 	return nil
+}
 
+// awsRecordSetInSync returns true if the current record set has the TTL and the values of the desired one.
+func awsRecordSetInSync(current, desired *route53.ResourceRecordSet) bool {
+	if aws.Int64Value(current.TTL) != aws.Int64Value(desired.TTL) {
+		return false
+	}
+	currentValues := make([]string, len(current.ResourceRecords))
+	for i, r := range current.ResourceRecords {
+		currentValues[i] = aws.StringValue(r.Value)
+	}
+	desiredValues := make([]string, len(desired.ResourceRecords))
+	for i, r := range desired.ResourceRecords {
+		desiredValues[i] = aws.StringValue(r.Value)
+	}
+	return len(currentValues) == len(desiredValues) && sets.NewString(currentValues...).Equal(sets.NewString(desiredValues...))
+}
+
+// findRecordSet returns the record set of the hosted zone with the name and type of the record, or nil if there is
+// none.
+func (a *AWSActuator) findRecordSet(record hivev1.DNSRecord) (*route53.ResourceRecordSet, error) {
+	fqdn := recordFQDN(a.dnsZone.Spec.Zone, record)
+	resp, err := a.awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    a.hostedZone.Id,
+		StartRecordName: aws.String(fqdn),
+		StartRecordType: aws.String(string(record.Type)),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		a.logger.WithError(err).WithField("name", fqdn).Error("Error listing recordsets for zone")
+		return nil, err
+	}
+	for _, recordSet := range resp.ResourceRecordSets {
+		if recordNameEquals(aws.StringValue(recordSet.Name), fqdn) && aws.StringValue(recordSet.Type) == string(record.Type) {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
 }
 
 // GetNameServers returns the nameservers listed in the route53 hosted zone NS record.
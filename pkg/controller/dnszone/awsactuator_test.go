@@ -59,6 +59,8 @@ func TestNewAWSActuator(t *testing.T) {
 			// Assert
 			assert.Nil(t, err)
 			assert.NotNil(t, zr.awsClient)
+			assert.NotNil(t, zr.assumeRoleClient)
+			zr.assumeRoleClient = nil
 			assert.Equal(t, expectedAWSActuator, zr)
 		})
 	}
@@ -108,6 +110,137 @@ func mockCreateAWSZoneDuplicateFailure(expect *mock.MockClientMockRecorder) {
 	expect.CreateHostedZone(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneAlreadyExists, "already exists", fmt.Errorf("already exists"))).Times(1)
 }
 
+func mockCreatePrivateAWSZone(expect *mock.MockClientMockRecorder) {
+	expect.CreateHostedZone(vpcInput("vpc-1")).Return(&route53.CreateHostedZoneOutput{
+		HostedZone: &route53.HostedZone{
+			Id:   aws.String("1234"),
+			Name: aws.String("blah.example.com."),
+		},
+		VPC: &route53.VPC{
+			VPCId:     aws.String("vpc-1"),
+			VPCRegion: aws.String("us-east-1"),
+		},
+	}, nil).Times(1)
+}
+
+func mockPrivateAWSZoneExists(expect *mock.MockClientMockRecorder, vpcIDs ...string) {
+	var vpcs []*route53.VPC
+	for _, id := range vpcIDs {
+		vpcs = append(vpcs, &route53.VPC{
+			VPCId:     aws.String(id),
+			VPCRegion: aws.String("us-east-1"),
+		})
+	}
+	expect.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{
+			Id:   aws.String("1234"),
+			Name: aws.String("blah.example.com."),
+		},
+		VPCs: vpcs,
+	}, nil).Times(1)
+}
+
+func mockAssociateAWSVPC(expect *mock.MockClientMockRecorder, vpcID string) {
+	expect.AssociateVPCWithHostedZone(vpcInput(vpcID)).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1)
+}
+
+func mockAssociateCrossAccountAWSVPC(expect *mock.MockClientMockRecorder, vpcID string) {
+	gomock.InOrder(
+		expect.CreateVPCAssociationAuthorization(vpcInput(vpcID)).Return(&route53.CreateVPCAssociationAuthorizationOutput{}, nil).Times(1),
+		expect.AssociateVPCWithHostedZone(vpcInput(vpcID)).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1),
+		expect.DeleteVPCAssociationAuthorization(vpcInput(vpcID)).Return(&route53.DeleteVPCAssociationAuthorizationOutput{}, nil).Times(1),
+	)
+}
+
+func mockDisassociateAWSVPC(expect *mock.MockClientMockRecorder, vpcID string) {
+	expect.DisassociateVPCFromHostedZone(vpcInput(vpcID)).Return(&route53.DisassociateVPCFromHostedZoneOutput{}, nil).Times(1)
+}
+
+// vpcInput matches the input of the route53 calls creating a private hosted zone with the VPC, or associating the VPC
+// with a hosted zone.
+type vpcInput string
+
+func (m vpcInput) Matches(x interface{}) bool {
+	var vpc *route53.VPC
+	switch input := x.(type) {
+	case *route53.CreateHostedZoneInput:
+		if input.HostedZoneConfig == nil || !aws.BoolValue(input.HostedZoneConfig.PrivateZone) {
+			return false
+		}
+		vpc = input.VPC
+	case *route53.AssociateVPCWithHostedZoneInput:
+		vpc = input.VPC
+	case *route53.DisassociateVPCFromHostedZoneInput:
+		vpc = input.VPC
+	case *route53.CreateVPCAssociationAuthorizationInput:
+		vpc = input.VPC
+	case *route53.DeleteVPCAssociationAuthorizationInput:
+		vpc = input.VPC
+	}
+	return vpc != nil && aws.StringValue(vpc.VPCId) == string(m)
+}
+
+func (m vpcInput) String() string {
+	return fmt.Sprintf("is input for VPC %s", string(m))
+}
+
+func mockSyncAWSRecords(expect *mock.MockClientMockRecorder) {
+	oldRecordSet := &route53.ResourceRecordSet{
+		Name:            aws.String("old.blah.example.com."),
+		Type:            aws.String("A"),
+		TTL:             aws.Int64(60),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("10.0.0.2")}},
+	}
+	expect.ListResourceRecordSets(gomock.Eq(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("1234"),
+		StartRecordName: aws.String("old.blah.example.com"),
+		StartRecordType: aws.String("A"),
+		MaxItems:        aws.String("1"),
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{oldRecordSet},
+	}, nil).Times(1)
+	// The wildcard record is in sync, and route53 returns its name escaped.
+	expect.ListResourceRecordSets(gomock.Eq(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("1234"),
+		StartRecordName: aws.String("*.apps-external.blah.example.com"),
+		StartRecordType: aws.String("A"),
+		MaxItems:        aws.String("1"),
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{{
+			Name:            aws.String(`\052.apps-external.blah.example.com.`),
+			Type:            aws.String("A"),
+			TTL:             aws.Int64(60),
+			ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("10.0.0.1")}},
+		}},
+	}, nil).Times(1)
+	expect.ListResourceRecordSets(gomock.Eq(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("1234"),
+		StartRecordName: aws.String("console.blah.example.com"),
+		StartRecordType: aws.String("CNAME"),
+		MaxItems:        aws.String("1"),
+	})).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
+	expect.ChangeResourceRecordSets(gomock.Eq(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("1234"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(route53.ChangeActionUpsert),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String("console.blah.example.com"),
+						Type:            aws.String("CNAME"),
+						TTL:             aws.Int64(300),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("lb.example.net")}},
+					},
+				},
+				{
+					Action:            aws.String(route53.ChangeActionDelete),
+					ResourceRecordSet: oldRecordSet,
+				},
+			},
+		},
+	})).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+}
+
 func mockNoExistingAWSTags(expect *mock.MockClientMockRecorder) {
 	expect.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
 		ResourceTagSet: &route53.ResourceTagSet{
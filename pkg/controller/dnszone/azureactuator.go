@@ -7,12 +7,15 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 // AzureActuator attempts to make the current state reflect the given desired state.
@@ -90,7 +93,7 @@ func (a *AzureActuator) Delete() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
 
 	logger.Info("Deleting recordsets in managedzone")
-	if err := DeleteAzureRecordSets(a.azureClient, a.dnsZone, logger); err != nil {
+	if err := platform.DeleteAzureRecordSets(a.azureClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
@@ -103,41 +106,6 @@ func (a *AzureActuator) Delete() error {
 	return err
 }
 
-// DeleteAzureRecordSets will remove all non-essential records from the DNSZone provided.
-func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
-	resourceGroupName := dnsZone.Spec.Azure.ResourceGroupName
-	zoneName := dnsZone.Spec.Zone
-	recordSetsPage, err := azureClient.ListRecordSetsByZone(context.Background(), resourceGroupName, zoneName, "")
-	if err != nil {
-		return err
-	}
-	for recordSetsPage.NotDone() {
-		for _, recordSet := range recordSetsPage.Values() {
-			if recordSet.Name == nil || recordSet.Type == nil {
-				logger.Warn("found recordset with missing name or type")
-				continue
-			}
-			name := *recordSet.Name
-			// The type comes in as, for example, "Microsoft.Network/dnszones/NS". We need just the last part of that,
-			// in this case "NS".
-			typeParts := strings.Split(*recordSet.Type, "/")
-			recordType := dns.RecordType(typeParts[len(typeParts)-1])
-			// Ignore the 2 recordsets that are created with the managed zone and that cannot be deleted
-			if name == "@" && (recordType == dns.NS || recordType == dns.SOA) {
-				continue
-			}
-			logger.WithField("name", name).WithField("type", recordType).Info("deleting recordset")
-			if err := azureClient.DeleteRecordSet(context.Background(), resourceGroupName, zoneName, name, recordType); err != nil {
-				return err
-			}
-		}
-		if err := recordSetsPage.NextWithContext(context.Background()); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 // Exists implements the Exists call of the actuator interface
 func (a *AzureActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
@@ -194,6 +162,131 @@ func (a *AzureActuator) Refresh() error {
 	return nil
 }
 
+// SyncRecords implements the SyncRecords call of the actuator interface
+func (a *AzureActuator) SyncRecords(toDelete []hivev1.DNSRecord) error {
+	if len(a.dnsZone.Spec.Records) == 0 && len(toDelete) == 0 {
+		return nil
+	}
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := a.dnsZone.Spec.Zone
+	logger := a.logger.WithField("zone", zoneName)
+
+	var current map[string]dns.RecordSet
+	if len(a.dnsZone.Spec.Records) > 0 {
+		var err error
+		if current, err = a.listRecordSets(); err != nil {
+			logger.WithError(err).Error("Cannot list records")
+			return err
+		}
+	}
+	for _, record := range a.dnsZone.Spec.Records {
+		recordLogger := logger.WithField("name", record.Name).WithField("type", record.Type)
+		desired := azureRecordSet(record)
+		if recordSet, ok := current[azureRecordSetKey(record.Name, dns.RecordType(record.Type))]; ok && azureRecordSetInSync(recordSet, desired) {
+			recordLogger.Debug("record is in sync")
+			continue
+		}
+		recordLogger.Info("syncing record")
+		if _, err := a.azureClient.CreateOrUpdateRecordSet(context.TODO(), resourceGroupName, zoneName, record.Name, dns.RecordType(record.Type), desired); err != nil {
+			recordLogger.WithError(err).Error("Cannot sync record")
+			return err
+		}
+	}
+	for _, record := range toDelete {
+		recordLogger := logger.WithField("name", record.Name).WithField("type", record.Type)
+		recordLogger.Info("deleting record")
+		// Deleting a record set which does not exist succeeds.
+		if err := a.azureClient.DeleteRecordSet(context.TODO(), resourceGroupName, zoneName, record.Name, dns.RecordType(record.Type)); err != nil {
+			recordLogger.WithError(err).Error("Cannot delete record")
+			return err
+		}
+	}
+	return nil
+}
+
+// listRecordSets returns the record sets of the managed zone, keyed by their name and type.
+func (a *AzureActuator) listRecordSets() (map[string]dns.RecordSet, error) {
+	recordSets := map[string]dns.RecordSet{}
+	page, err := a.azureClient.ListRecordSetsByZone(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, "")
+	if err != nil {
+		return nil, err
+	}
+	for page.NotDone() {
+		for _, recordSet := range page.Values() {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				continue
+			}
+			// The type comes in as, for example, "Microsoft.Network/dnszones/A".
+			typeParts := strings.Split(*recordSet.Type, "/")
+			recordSets[azureRecordSetKey(*recordSet.Name, dns.RecordType(typeParts[len(typeParts)-1]))] = recordSet
+		}
+		if err := page.NextWithContext(context.TODO()); err != nil {
+			return nil, err
+		}
+	}
+	return recordSets, nil
+}
+
+func azureRecordSetKey(name string, recordType dns.RecordType) string {
+	return strings.ToLower(name) + "/" + string(recordType)
+}
+
+// azureRecordSetInSync returns true if the current record set has the TTL and the values of the desired one.
+func azureRecordSetInSync(current, desired dns.RecordSet) bool {
+	if current.RecordSetProperties == nil {
+		return false
+	}
+	if to.Int64(current.TTL) != to.Int64(desired.TTL) {
+		return false
+	}
+	currentValues, desiredValues := azureRecordSetValues(current), azureRecordSetValues(desired)
+	return len(currentValues) == len(desiredValues) && sets.NewString(currentValues...).Equal(sets.NewString(desiredValues...))
+}
+
+func azureRecordSetValues(recordSet dns.RecordSet) []string {
+	var values []string
+	if recordSet.ARecords != nil {
+		for _, r := range *recordSet.ARecords {
+			values = append(values, to.String(r.Ipv4Address))
+		}
+	}
+	if recordSet.AaaaRecords != nil {
+		for _, r := range *recordSet.AaaaRecords {
+			values = append(values, to.String(r.Ipv6Address))
+		}
+	}
+	if recordSet.CnameRecord != nil {
+		values = append(values, to.String(recordSet.CnameRecord.Cname))
+	}
+	return values
+}
+
+func azureRecordSet(record hivev1.DNSRecord) dns.RecordSet {
+	properties := &dns.RecordSetProperties{
+		TTL: to.Int64Ptr(recordTTL(record)),
+	}
+	switch record.Type {
+	case hivev1.DNSRecordTypeA:
+		aRecords := make([]dns.ARecord, len(record.Values))
+		for i, value := range record.Values {
+			aRecords[i] = dns.ARecord{Ipv4Address: to.StringPtr(value)}
+		}
+		properties.ARecords = &aRecords
+	case hivev1.DNSRecordTypeAAAA:
+		aaaaRecords := make([]dns.AaaaRecord, len(record.Values))
+		for i, value := range record.Values {
+			aaaaRecords[i] = dns.AaaaRecord{Ipv6Address: to.StringPtr(value)}
+		}
+		properties.AaaaRecords = &aaaaRecords
+	case hivev1.DNSRecordTypeCNAME:
+		properties.CnameRecord = &dns.CnameRecord{Cname: to.StringPtr(record.Values[0])}
+	}
+	return dns.RecordSet{RecordSetProperties: properties}
+}
+
 // UpdateMetadata implements the UpdateMetadata call of the actuator interface
 func (a *AzureActuator) UpdateMetadata() error {
 	return nil
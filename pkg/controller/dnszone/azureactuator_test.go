@@ -87,6 +87,32 @@ func mockCreateAzureZone(expect *mock.MockClientMockRecorder) {
 	}, nil).Times(1)
 }
 
+func mockSyncAzureRecords(mockCtrl *gomock.Controller, expect *mock.MockClientMockRecorder) {
+	// The wildcard record is in sync.
+	recordSetPage := mock.NewMockRecordSetPage(mockCtrl)
+	gomock.InOrder(
+		recordSetPage.EXPECT().NotDone().Return(true).Times(1),
+		recordSetPage.EXPECT().NotDone().Return(false).Times(1),
+	)
+	recordSetPage.EXPECT().Values().Return([]dns.RecordSet{{
+		Name: to.StringPtr("*.apps-external"),
+		Type: to.StringPtr("Microsoft.Network/dnszones/A"),
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:      to.Int64Ptr(60),
+			ARecords: &[]dns.ARecord{{Ipv4Address: to.StringPtr("10.0.0.1")}},
+		},
+	}}).Times(1)
+	recordSetPage.EXPECT().NextWithContext(gomock.Any()).Return(nil).Times(1)
+	expect.ListRecordSetsByZone(gomock.Any(), "default", "blah.example.com", "").Return(recordSetPage, nil).Times(1)
+	expect.CreateOrUpdateRecordSet(gomock.Any(), "default", "blah.example.com", "console", dns.CNAME, dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:         to.Int64Ptr(300),
+			CnameRecord: &dns.CnameRecord{Cname: to.StringPtr("lb.example.net")},
+		},
+	}).Return(dns.RecordSet{}, nil).Times(1)
+	expect.DeleteRecordSet(gomock.Any(), "default", "blah.example.com", "old", dns.A).Return(nil).Times(1)
+}
+
 func mockDeleteAzureZone(mockCtrl *gomock.Controller, expect *mock.MockClientMockRecorder) {
 	recordSetPage := mock.NewMockRecordSetPage(mockCtrl)
 	recordSetPage.EXPECT().NotDone().Return(false).Times(1)
@@ -0,0 +1,204 @@
+package dnszone
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// delegationCheckInterval is how often the delegation of an available zone is verified.
+	delegationCheckInterval = 10 * time.Minute
+	// delegationCheckDNSServersEnvVar overrides the DNS resolvers queried to verify the delegation of zones, as a
+	// comma-separated list of servers.
+	delegationCheckDNSServersEnvVar = "DELEGATION_CHECK_DNS_SERVERS"
+
+	delegationVerifiedReason       = "DelegationVerified"
+	delegationMismatchReason       = "DelegationMismatch"
+	delegationNotResolvableReason  = "DelegationNotResolvable"
+	delegationCheckFailedReason    = "DelegationCheckFailed"
+	delegationNotCheckedReason     = "DelegationNotChecked"
+	delegationNotCheckedMessage    = "delegation is verified once the SOA record of the zone is reachable"
+	delegationVerifiedMessage      = "public DNS resolvers return the nameservers of the zone"
+	delegationNotResolvableMessage = "public DNS resolvers return no nameservers for the zone"
+)
+
+// defaultDelegationCheckDNSServers are the public DNS resolvers queried to verify the delegation of zones. Unlike the
+// resolvers of the cluster used to look up the SOA record, they see the zone as the rest of the internet does.
+var defaultDelegationCheckDNSServers = []string{"8.8.8.8:53", "1.1.1.1:53"}
+
+var (
+	metricDNSZoneDelegationBroken = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_dnszone_delegation_broken",
+		Help: "Set to 1 when public DNS resolvers do not return the nameservers of the dnszone, meaning the zone is not properly delegated from its parent domain.",
+	},
+		[]string{"namespace", "name"},
+	)
+)
+
+// isPrivateZone returns true if the zone only resolves from the networks associated with it.
+func isPrivateZone(dnsZone *hivev1.DNSZone) bool {
+	return dnsZone.Spec.AWS != nil && dnsZone.Spec.AWS.PrivateZone != nil
+}
+
+// delegationCheckDelay returns how long until the delegation of the zone is next verified, and false if the
+// delegation of the zone is not verified. The delegation of a zone is only verified once its SOA record is reachable.
+func delegationCheckDelay(dnsZone *hivev1.DNSZone) (time.Duration, bool) {
+	if dnsZone.DeletionTimestamp != nil || isPrivateZone(dnsZone) {
+		return 0, false
+	}
+	availableCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+	if availableCondition == nil || availableCondition.Status != corev1.ConditionTrue {
+		return 0, false
+	}
+	if dnsZone.Status.LastDelegationCheckTimestamp == nil {
+		return 0, true
+	}
+	delay := delegationCheckInterval - time.Since(dnsZone.Status.LastDelegationCheckTimestamp.Time)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// checkDelegation verifies that public DNS resolvers return the nameservers of the zone, and records the result in the
+// status of the DNSZone. The status is not persisted.
+func (r *ReconcileDNSZone) checkDelegation(dnsZone *hivev1.DNSZone, nameServers []string) {
+	logger := r.logger.WithField("zone", dnsZone.Spec.Zone)
+	delegated, err := r.nameServerLookup(dnsZone.Spec.Zone, logger)
+	now := metav1.Now()
+	dnsZone.Status.LastDelegationCheckTimestamp = &now
+
+	var status corev1.ConditionStatus
+	var reason, message string
+	switch {
+	case err != nil:
+		// The resolvers could not be queried, which does not tell whether the delegation is broken.
+		logger.WithError(err).Warn("could not verify delegation of zone")
+		status = corev1.ConditionUnknown
+		reason = delegationCheckFailedReason
+		message = fmt.Sprintf("could not query public DNS resolvers: %v", err)
+	case len(delegated) == 0:
+		logger.Warn("zone is not delegated")
+		status = corev1.ConditionTrue
+		reason = delegationNotResolvableReason
+		message = delegationNotResolvableMessage
+	default:
+		unexpected := sets.NewString(normalizeNameServers(delegated)...).Difference(sets.NewString(normalizeNameServers(nameServers)...))
+		if unexpected.Len() > 0 {
+			logger.WithField("nameServers", unexpected.List()).Warn("zone is delegated to nameservers which are not nameservers of the zone")
+			status = corev1.ConditionTrue
+			reason = delegationMismatchReason
+			message = fmt.Sprintf("public DNS resolvers return nameservers which are not nameservers of the zone: %s", strings.Join(unexpected.List(), ", "))
+		} else {
+			logger.Debug("delegation of zone verified")
+			status = corev1.ConditionFalse
+			reason = delegationVerifiedReason
+			message = delegationVerifiedMessage
+		}
+	}
+	if err == nil {
+		dnsZone.Status.DelegatedNameServers = delegated
+	}
+
+	switch status {
+	case corev1.ConditionTrue:
+		metricDNSZoneDelegationBroken.WithLabelValues(dnsZone.Namespace, dnsZone.Name).Set(1)
+	case corev1.ConditionFalse:
+		metricDNSZoneDelegationBroken.WithLabelValues(dnsZone.Namespace, dnsZone.Name).Set(0)
+	}
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.DelegationBrokenDNSZoneCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+}
+
+// setDelegationNotChecked records that the delegation of the zone is not verified while its SOA record is not
+// reachable, rather than keeping the result of a previous verification.
+func setDelegationNotChecked(dnsZone *hivev1.DNSZone) {
+	if controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.DelegationBrokenDNSZoneCondition) == nil {
+		return
+	}
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.DelegationBrokenDNSZoneCondition,
+		corev1.ConditionUnknown,
+		delegationNotCheckedReason,
+		delegationNotCheckedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+}
+
+// clearDelegationMetric stops reporting the delegation of a deleted zone.
+func clearDelegationMetric(dnsZone *hivev1.DNSZone) {
+	metricDNSZoneDelegationBroken.DeleteLabelValues(dnsZone.Namespace, dnsZone.Name)
+}
+
+// normalizeNameServers returns the nameservers in lower case without the trailing dot, as the DNS providers and
+// resolvers do not agree on either.
+func normalizeNameServers(nameServers []string) []string {
+	normalized := make([]string, len(nameServers))
+	for i, ns := range nameServers {
+		normalized[i] = strings.ToLower(strings.TrimSuffix(ns, "."))
+	}
+	return normalized
+}
+
+// lookupNameServers returns the nameservers returned for the zone by the first public DNS resolver answering the
+// query. No nameservers are returned when the zone does not resolve. An error is returned when no resolver answers.
+func lookupNameServers(zone string, logger log.FieldLogger) ([]string, error) {
+	dnsServers := defaultDelegationCheckDNSServers
+	if serversFromEnv := os.Getenv(delegationCheckDNSServersEnvVar); len(serversFromEnv) > 0 {
+		dnsServers = strings.Split(serversFromEnv, ",")
+		// Add port to servers with unspecified port
+		for i := range dnsServers {
+			if !strings.Contains(dnsServers[i], ":") {
+				dnsServers[i] = dnsServers[i] + ":53"
+			}
+		}
+	}
+	logger.WithField("servers", dnsServers).Debug("looking up domain NS records")
+
+	client := dns.Client{Timeout: dnsClientTimeout}
+	m := &dns.Msg{}
+	m.SetQuestion(controllerutils.Dotted(zone), dns.TypeNS)
+	var lastErr error
+	for _, s := range dnsServers {
+		in, _, err := client.Exchange(m, s)
+		if err != nil {
+			logger.WithError(err).WithField("server", s).Info("query for NS records failed")
+			lastErr = err
+			continue
+		}
+		if in.Rcode != dns.RcodeSuccess {
+			logger.WithField("server", s).WithField("rcode", dns.RcodeToString[in.Rcode]).Info("query for NS records not successful")
+			return nil, nil
+		}
+		var nameServers []string
+		for _, rr := range in.Answer {
+			ns, ok := rr.(*dns.NS)
+			if !ok || !strings.EqualFold(ns.Hdr.Name, controllerutils.Dotted(zone)) {
+				continue
+			}
+			nameServers = append(nameServers, ns.Ns)
+		}
+		sort.Strings(nameServers)
+		return nameServers, nil
+	}
+	return nil, fmt.Errorf("no DNS server answered the query for NS records: %v", lastErr)
+}
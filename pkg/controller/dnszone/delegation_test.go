@@ -0,0 +1,177 @@
+package dnszone
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func availableDNSZone(lastDelegationCheck *time.Time) *hivev1.DNSZone {
+	zone := validDNSZone()
+	zone.Status.NameServers = []string{"ns1.example.com", "ns2.example.com"}
+	zone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		zone.Status.Conditions,
+		hivev1.ZoneAvailableDNSZoneCondition,
+		corev1.ConditionTrue,
+		"ZoneAvailable",
+		"DNS SOA record for zone is reachable",
+		controllerutils.UpdateConditionNever)
+	if lastDelegationCheck != nil {
+		t := metav1.NewTime(*lastDelegationCheck)
+		zone.Status.LastDelegationCheckTimestamp = &t
+	}
+	return zone
+}
+
+func TestDelegationCheckDelay(t *testing.T) {
+	recently := time.Now().Add(-time.Minute)
+	longAgo := time.Now().Add(-time.Hour)
+	cases := []struct {
+		name            string
+		dnsZone         *hivev1.DNSZone
+		expectedChecked bool
+		expectDelay     bool
+	}{
+		{
+			name:    "zone not available",
+			dnsZone: validDNSZone(),
+		},
+		{
+			name: "private zone",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := availableDNSZone(nil)
+				zone.Spec.AWS.PrivateZone = validPrivateDNSZone().Spec.AWS.PrivateZone
+				return zone
+			}(),
+		},
+		{
+			name:            "never checked",
+			dnsZone:         availableDNSZone(nil),
+			expectedChecked: true,
+		},
+		{
+			name:            "checked recently",
+			dnsZone:         availableDNSZone(&recently),
+			expectedChecked: true,
+			expectDelay:     true,
+		},
+		{
+			name:            "checked long ago",
+			dnsZone:         availableDNSZone(&longAgo),
+			expectedChecked: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, checked := delegationCheckDelay(tc.dnsZone)
+			assert.Equal(t, tc.expectedChecked, checked, "unexpected checked")
+			if tc.expectDelay {
+				assert.True(t, delay > 0 && delay <= delegationCheckInterval, "unexpected delay %v", delay)
+			} else {
+				assert.Zero(t, delay, "unexpected delay")
+			}
+		})
+	}
+}
+
+func TestCheckDelegation(t *testing.T) {
+	cases := []struct {
+		name                         string
+		delegatedNameServers         []string
+		lookupErr                    error
+		expectedStatus               corev1.ConditionStatus
+		expectedReason               string
+		expectedDelegatedNameServers []string
+	}{
+		{
+			name:                         "verified",
+			delegatedNameServers:         []string{"NS1.example.com.", "ns2.example.com."},
+			expectedStatus:               corev1.ConditionFalse,
+			expectedReason:               delegationVerifiedReason,
+			expectedDelegatedNameServers: []string{"NS1.example.com.", "ns2.example.com."},
+		},
+		{
+			name:           "not resolvable",
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: delegationNotResolvableReason,
+		},
+		{
+			name:                         "mismatch",
+			delegatedNameServers:         []string{"ns1.example.com.", "ns-stale.example.net."},
+			expectedStatus:               corev1.ConditionTrue,
+			expectedReason:               delegationMismatchReason,
+			expectedDelegatedNameServers: []string{"ns1.example.com.", "ns-stale.example.net."},
+		},
+		{
+			name:                         "lookup failure",
+			lookupErr:                    errors.New("i/o timeout"),
+			expectedStatus:               corev1.ConditionUnknown,
+			expectedReason:               delegationCheckFailedReason,
+			expectedDelegatedNameServers: []string{"ns1.example.com."},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			zone := availableDNSZone(nil)
+			zone.Status.DelegatedNameServers = []string{"ns1.example.com."}
+			zone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+				zone.Status.Conditions,
+				hivev1.DelegationBrokenDNSZoneCondition,
+				corev1.ConditionTrue,
+				delegationNotResolvableReason,
+				delegationNotResolvableMessage,
+				controllerutils.UpdateConditionNever)
+			r := &ReconcileDNSZone{
+				logger:           log.WithField("controller", ControllerName),
+				nameServerLookup: fakeNameServerLookup(tc.delegatedNameServers, tc.lookupErr),
+			}
+			r.checkDelegation(zone, zone.Status.NameServers)
+			condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.DelegationBrokenDNSZoneCondition)
+			if assert.NotNil(t, condition, "delegation broken condition should be set on dnszone") {
+				assert.Equal(t, tc.expectedStatus, condition.Status, "unexpected delegation broken condition status")
+				assert.Equal(t, tc.expectedReason, condition.Reason, "unexpected delegation broken condition reason")
+			}
+			assert.Equal(t, tc.expectedDelegatedNameServers, zone.Status.DelegatedNameServers, "unexpected delegated nameservers")
+			assert.NotNil(t, zone.Status.LastDelegationCheckTimestamp, "expected delegation check timestamp to be set")
+		})
+	}
+}
+
+func TestReconcileDelegation(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+	zone := availableDNSZone(nil)
+	require.NoError(t, setFakeDNSZoneInKube(mocks, zone), "unexpected error creating dnszone")
+	r := &ReconcileDNSZone{
+		Client:           mocks.fakeKubeClient,
+		logger:           log.WithField("controller", ControllerName),
+		nameServerLookup: fakeNameServerLookup([]string{"ns-stale.example.net."}, nil),
+	}
+
+	result, err := r.reconcileDelegation(zone)
+	require.NoError(t, err, "unexpected error reconciling delegation")
+	assert.Equal(t, delegationCheckInterval, result.RequeueAfter, "unexpected requeue")
+
+	stored := &hivev1.DNSZone{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}, stored), "unexpected error getting dnszone")
+	condition := controllerutils.FindDNSZoneCondition(stored.Status.Conditions, hivev1.DelegationBrokenDNSZoneCondition)
+	if assert.NotNil(t, condition, "delegation broken condition should be set on dnszone") {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status, "unexpected delegation broken condition status")
+	}
+
+	result, err = r.reconcileDelegation(stored)
+	require.NoError(t, err, "unexpected error reconciling delegation")
+	assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= delegationCheckInterval, "expected requeue for the next check")
+}
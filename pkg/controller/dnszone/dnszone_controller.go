@@ -59,6 +59,7 @@ var (
 
 func init() {
 	metrics.Registry.MustRegister(metricDNSZonesDeleted)
+	metrics.Registry.MustRegister(metricDNSZoneDelegationBroken)
 }
 
 // Add creates a new DNSZone Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -76,10 +77,11 @@ func Add(mgr manager.Manager) error {
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSZone {
 	return &ReconcileDNSZone{
-		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:    mgr.GetScheme(),
-		logger:    log.WithField("controller", ControllerName),
-		soaLookup: lookupSOARecord,
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:           mgr.GetScheme(),
+		logger:           log.WithField("controller", ControllerName),
+		soaLookup:        lookupSOARecord,
+		nameServerLookup: lookupNameServers,
 	}
 }
 
@@ -87,7 +89,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New(ControllerName.String(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -122,6 +124,9 @@ type ReconcileDNSZone struct {
 
 	// soaLookup is a function that looks up a zone's SOA record
 	soaLookup func(string, log.FieldLogger) (bool, error)
+
+	// nameServerLookup is a function that looks up the nameservers returned for a zone by public DNS resolvers
+	nameServerLookup func(string, log.FieldLogger) ([]string, error)
 }
 
 // Reconcile reads that state of the cluster for a DNSZone object and makes changes based on the state read
@@ -162,7 +167,7 @@ func (r *ReconcileDNSZone) Reconcile(request reconcile.Request) (reconcile.Resul
 			"lastSyncedGeneration": desiredState.Status.LastSyncGeneration,
 		}).Debug("Sync not needed")
 
-		return reconcile.Result{}, nil
+		return r.reconcileDelegation(desiredState)
 	}
 
 	actuator, err := r.getActuator(desiredState, dnsLog)
@@ -189,6 +194,7 @@ func (r *ReconcileDNSZone) Reconcile(request reconcile.Request) (reconcile.Resul
 					dnsLog.WithError(err).Log(controllerutils.LogLevel(err), "Failed to remove DNSZone finalizer")
 				} else {
 					metricDNSZonesDeleted.WithLabelValues("true").Inc()
+					clearDelegationMetric(desiredState)
 				}
 
 				// This returns whether there was an error or not.
@@ -254,6 +260,7 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 				r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to remove DNSZone finalizer")
 			}
 			metricDNSZonesDeleted.WithLabelValues("false").Inc()
+			clearDelegationMetric(dnsZone)
 		}
 		return reconcile.Result{}, err
 	}
@@ -282,26 +289,62 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 		}
 	}
 
+	r.logger.Debug("Syncing records of hosted zone")
+	if err := actuator.SyncRecords(removedRecords(dnsZone)); err != nil {
+		r.logger.WithError(err).Error("failed to sync records of hosted zone")
+		return reconcile.Result{}, err
+	}
+
 	nameServers, err := actuator.GetNameServers()
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to get hosted zone name servers")
 		return reconcile.Result{}, err
 	}
 
-	isZoneSOAAvailable, err := r.soaLookup(dnsZone.Spec.Zone, r.logger)
-	if err != nil {
-		r.logger.WithError(err).Error("error looking up SOA record for zone")
+	// The SOA record of a private zone only resolves from the VPCs associated with it, so the zone is available as
+	// soon as it exists.
+	isZoneSOAAvailable := true
+	if !isPrivateZone(dnsZone) {
+		isZoneSOAAvailable, err = r.soaLookup(dnsZone.Spec.Zone, r.logger)
+		if err != nil {
+			r.logger.WithError(err).Error("error looking up SOA record for zone")
+		}
 	}
 
 	reconcileResult := reconcile.Result{}
-	if !isZoneSOAAvailable {
+	switch {
+	case !isZoneSOAAvailable:
 		r.logger.Info("SOA record for DNS zone not available")
 		reconcileResult.RequeueAfter = domainAvailabilityCheckInterval
+	case !isPrivateZone(dnsZone):
+		// Requeue for the next verification of the delegation of the zone.
+		reconcileResult.RequeueAfter = delegationCheckInterval
 	}
 
 	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone)
 }
 
+// reconcileDelegation verifies the delegation of a zone which does not need to be synced with the DNS provider when
+// the verification is due, and requeues the zone for the next verification.
+func (r *ReconcileDNSZone) reconcileDelegation(dnsZone *hivev1.DNSZone) (reconcile.Result, error) {
+	delay, checked := delegationCheckDelay(dnsZone)
+	if !checked {
+		return reconcile.Result{}, nil
+	}
+	if delay > 0 {
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+	orig := dnsZone.DeepCopy()
+	r.checkDelegation(dnsZone, dnsZone.Status.NameServers)
+	if !reflect.DeepEqual(orig.Status, dnsZone.Status) {
+		if err := r.Client.Status().Update(context.TODO(), dnsZone); err != nil {
+			r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Cannot update DNSZone status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: delegationCheckInterval}, nil
+}
+
 func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 	if desiredState.DeletionTimestamp != nil && !controllerutils.HasFinalizer(desiredState, hivev1.FinalizerDNSZone) {
 		return false, 0 // No finalizer means our cleanup has been completed. There's nothing left to do.
@@ -390,6 +433,7 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 	r.logger.Debug("Updating DNSZone status")
 
 	dnsZone.Status.NameServers = nameServers
+	dnsZone.Status.SyncedRecords = dnsZone.Spec.Records
 
 	var availableStatus corev1.ConditionStatus
 	var availableReason, availableMessage string
@@ -407,6 +451,7 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableMessage = "DNS SOA record for zone is not reachable"
 	}
 	dnsZone.Status.LastSyncGeneration = dnsZone.ObjectMeta.Generation
+	dnsZone.Status.ObservedGeneration = dnsZone.ObjectMeta.Generation
 	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
 		dnsZone.Status.Conditions,
 		hivev1.ZoneAvailableDNSZoneCondition,
@@ -414,6 +459,20 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableReason,
 		availableMessage,
 		controllerutils.UpdateConditionNever)
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.ReadyDNSZoneCondition,
+		availableStatus,
+		availableReason,
+		availableMessage,
+		controllerutils.UpdateConditionNever)
+
+	switch {
+	case !isSOAAvailable:
+		setDelegationNotChecked(dnsZone)
+	case !isPrivateZone(dnsZone):
+		r.checkDelegation(dnsZone, nameServers)
+	}
 
 	if !reflect.DeepEqual(orig.Status, dnsZone.Status) {
 		err := r.Client.Status().Update(context.TODO(), dnsZone)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/awsclient/mock"
 	awsmock "github.com/openshift/hive/pkg/awsclient/mock"
 	azuremock "github.com/openshift/hive/pkg/azureclient/mock"
@@ -31,12 +33,14 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 
 	cases := []struct {
-		name            string
-		dnsZone         *hivev1.DNSZone
-		setupAWSMock    func(*awsmock.MockClientMockRecorder)
-		validateZone    func(*testing.T, *hivev1.DNSZone)
-		errorExpected   bool
-		soaLookupResult bool
+		name                 string
+		dnsZone              *hivev1.DNSZone
+		setupAWSMock         func(*awsmock.MockClientMockRecorder)
+		validateZone         func(*testing.T, *hivev1.DNSZone)
+		errorExpected        bool
+		soaLookupResult      bool
+		delegatedNameServers []string
+		assumedRoles         []string
 	}{
 		{
 			name:    "DNSZone without finalizer",
@@ -110,6 +114,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.Equal(t, zone.Status.LastSyncGeneration, int64(6))
+				assert.Equal(t, int64(6), zone.Status.ObservedGeneration)
 			},
 		},
 		{
@@ -152,9 +157,10 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			},
 		},
 		{
-			name:            "Existing zone, link to parent, reachable SOA",
-			dnsZone:         validDNSZoneWithLinkToParent(),
-			soaLookupResult: true,
+			name:                 "Existing zone, link to parent, reachable SOA",
+			dnsZone:              validDNSZoneWithLinkToParent(),
+			soaLookupResult:      true,
+			delegatedNameServers: []string{"ns1.example.com.", "ns2.example.com."},
 			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
 				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
 				mockExistingAWSTags(expect)
@@ -163,6 +169,89 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
+				condition = controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ReadyDNSZoneCondition)
+				if assert.NotNil(t, condition, "ready condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "unexpected ready condition status")
+				}
+				condition = controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.DelegationBrokenDNSZoneCondition)
+				assert.Nil(t, condition, "delegation broken condition should not be set on dnszone")
+				assert.NotNil(t, zone.Status.LastDelegationCheckTimestamp, "expected delegation check timestamp to be set")
+			},
+		},
+		{
+			name:                 "Existing zone, link to parent, broken delegation",
+			dnsZone:              validDNSZoneWithLinkToParent(),
+			soaLookupResult:      true,
+			delegatedNameServers: []string{"ns1.example.com.", "ns-stale.example.net."},
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.DelegationBrokenDNSZoneCondition)
+				if assert.NotNil(t, condition, "delegation broken condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "unexpected delegation broken condition status")
+					assert.Equal(t, delegationMismatchReason, condition.Reason, "unexpected delegation broken condition reason")
+				}
+				assert.Equal(t, []string{"ns1.example.com.", "ns-stale.example.net."}, zone.Status.DelegatedNameServers, "unexpected delegated nameservers")
+			},
+		},
+		{
+			name:    "Existing zone, sync records",
+			dnsZone: withRecords(validDNSZone()),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockSyncAWSRecords(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, withRecords(validDNSZone()).Spec.Records, zone.Status.SyncedRecords, "unexpected synced records")
+			},
+		},
+		{
+			name:    "Create private hosted zone",
+			dnsZone: validPrivateDNSZoneWithoutID(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneDoesntExist(expect, validPrivateDNSZoneWithoutID())
+				mockCreatePrivateAWSZone(expect)
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockAssociateCrossAccountAWSVPC(expect, "vpc-2")
+				mockAWSGetNSRecord(expect)
+			},
+			assumedRoles: []string{"arn:aws:iam::123456789012:role/vpc-owner"},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.AWS) {
+					assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID), "unexpected zone ID")
+					assert.Equal(t, []string{"vpc-1", "vpc-2"}, zone.Status.AWS.AssociatedVPCs, "unexpected associated VPCs")
+				}
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ReadyDNSZoneCondition)
+				if assert.NotNil(t, condition, "ready condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "private zone should be ready without SOA lookup")
+				}
+			},
+		},
+		{
+			name: "Existing private zone, sync VPC associations",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validPrivateDNSZone()
+				dz.Spec.AWS.PrivateZone.VPCs[1] = hivev1.AWSDNSZoneVPC{VPCID: "vpc-3", Region: "us-east-1"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockPrivateAWSZoneExists(expect, "vpc-1", "vpc-2")
+				mockExistingAWSTags(expect)
+				mockAssociateAWSVPC(expect, "vpc-3")
+				mockDisassociateAWSVPC(expect, "vpc-2")
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.AWS) {
+					assert.Equal(t, []string{"vpc-1", "vpc-3"}, zone.Status.AWS.AssociatedVPCs, "unexpected associated VPCs")
+				}
 			},
 		},
 	}
@@ -178,6 +267,11 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				tc.dnsZone,
 				fakeAWSClientBuilder(mocks.mockAWSClient),
 			)
+			var assumedRoles []string
+			zr.assumeRoleClient = func(roleARN string) (awsclient.Client, error) {
+				assumedRoles = append(assumedRoles, roleARN)
+				return mocks.mockAWSClient, nil
+			}
 
 			r := ReconcileDNSZone{
 				Client: mocks.fakeKubeClient,
@@ -188,6 +282,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nameServerLookup = fakeNameServerLookup(tc.delegatedNameServers, nil)
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -217,6 +312,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			if tc.validateZone != nil {
 				tc.validateZone(t, zone)
 			}
+			assert.Equal(t, tc.assumedRoles, assumedRoles, "unexpected assumed roles")
 		})
 	}
 }
@@ -301,6 +397,17 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Existing zone, sync records",
+			dnsZone: withRecords(validDNSZone()),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				mockSyncGCPRecords(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, withRecords(validDNSZone()).Spec.Records, zone.Status.SyncedRecords, "unexpected synced records")
+			},
+		},
 		{
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validDNSZoneWithLinkToParent(),
@@ -311,6 +418,10 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
+				condition = controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ReadyDNSZoneCondition)
+				if assert.NotNil(t, condition, "ready condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "unexpected ready condition status")
+				}
 			},
 		},
 	}
@@ -336,6 +447,7 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nameServerLookup = fakeNameServerLookup(nil, nil)
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -435,6 +547,17 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Existing zone, sync records",
+			dnsZone: withRecords(validAzureDNSZone()),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzureZoneExists(expect)
+				mockSyncAzureRecords(mockCtrl, expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, withRecords(validAzureDNSZone()).Spec.Records, zone.Status.SyncedRecords, "unexpected synced records")
+			},
+		},
 		{
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validAzureDNSZoneWithLinkToParent(),
@@ -445,6 +568,10 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
+				condition = controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ReadyDNSZoneCondition)
+				if assert.NotNil(t, condition, "ready condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "unexpected ready condition status")
+				}
 			},
 		},
 	}
@@ -470,6 +597,7 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
 				return tc.soaLookupResult, nil
 			}
+			r.nameServerLookup = fakeNameServerLookup(nil, nil)
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
@@ -2,6 +2,7 @@ package dnszone
 
 import (
 	"net/http"
+	"reflect"
 	"strings"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -14,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 const (
@@ -106,7 +108,7 @@ func (a *GCPActuator) Delete() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("zoneName", zoneName)
 
 	logger.Info("Deleting recordsets in managedzone")
-	if err := DeleteGCPRecordSets(a.gcpClient, a.dnsZone, logger); err != nil {
+	if err := platform.DeleteGCPRecordSets(a.gcpClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
@@ -127,46 +129,93 @@ func (a *GCPActuator) Delete() error {
 	return err
 }
 
-// DeleteGCPRecordSets will delete all non-essential DNS records in the DNSZone provided
-func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
-	listOpts := gcpclient.ListResourceRecordSetsOptions{}
-	for {
-		listOutput, err := gcpClient.ListResourceRecordSets(*dnsZone.Status.GCP.ZoneName, listOpts)
+// Exists implements the Exists call of the actuator interface
+func (a *GCPActuator) Exists() (bool, error) {
+	return a.managedZone != nil, nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *GCPActuator) UpdateMetadata() error {
+	// Nothing to do here since GCP CloudDNS doesn't support tags.
+	return nil
+}
+
+// SyncRecords implements the SyncRecords call of the actuator interface
+func (a *GCPActuator) SyncRecords(toDelete []hivev1.DNSRecord) error {
+	if len(a.dnsZone.Spec.Records) == 0 && len(toDelete) == 0 {
+		return nil
+	}
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	logger := a.logger.WithField("zoneName", a.managedZone.Name)
+
+	for _, record := range a.dnsZone.Spec.Records {
+		recordLogger := logger.WithField("name", record.Name).WithField("type", record.Type)
+		current, err := a.findRecordSet(record)
 		if err != nil {
 			return err
 		}
-		var recordSetsToDelete []*dns.ResourceRecordSet
-		for _, recordSet := range listOutput.Rrsets {
-			// Ignore the 2 recordsets that are created with the managed zone and that cannot be deleted
-			if n, t := recordSet.Name, recordSet.Type; n == controllerutils.Dotted(dnsZone.Spec.Zone) && (t == "NS" || t == "SOA") {
-				continue
-			}
-			logger.WithField("name", recordSet.Name).WithField("type", recordSet.Type).Info("recordset set for deletion")
-			recordSetsToDelete = append(recordSetsToDelete, recordSet)
+		desired := &dns.ResourceRecordSet{
+			Name: controllerutils.Dotted(recordFQDN(a.dnsZone.Spec.Zone, record)),
+			Type: string(record.Type),
+			Ttl:  recordTTL(record),
 		}
-		if len(recordSetsToDelete) > 0 {
-			logger.WithField("count", len(recordSetsToDelete)).Info("deleting recordsets")
-			if err := gcpClient.DeleteResourceRecordSets(*dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
-				return err
+		for _, value := range record.Values {
+			// Cloud DNS requires the domain names of CNAME records to be fully qualified.
+			if record.Type == hivev1.DNSRecordTypeCNAME {
+				value = controllerutils.Dotted(value)
 			}
+			desired.Rrdatas = append(desired.Rrdatas, value)
+		}
+		if current != nil && current.Ttl == desired.Ttl && reflect.DeepEqual(current.Rrdatas, desired.Rrdatas) {
+			recordLogger.Debug("record is in sync")
+			continue
+		}
+		recordLogger.Info("syncing record")
+		// Cloud DNS replaces a record set by deleting the current one in the same change.
+		if err := a.gcpClient.UpdateResourceRecordSet(a.managedZone.Name, desired, current); err != nil {
+			recordLogger.WithError(err).Error("Cannot sync record")
+			return err
 		}
-		if listOutput.NextPageToken == "" {
-			break
+	}
+	for _, record := range toDelete {
+		recordLogger := logger.WithField("name", record.Name).WithField("type", record.Type)
+		current, err := a.findRecordSet(record)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			recordLogger.Debug("record already deleted")
+			continue
+		}
+		recordLogger.Info("deleting record")
+		if err := a.gcpClient.DeleteResourceRecordSet(a.managedZone.Name, current); err != nil {
+			recordLogger.WithError(err).Error("Cannot delete record")
+			return err
 		}
-		listOpts.PageToken = listOutput.NextPageToken
 	}
 	return nil
 }
 
-// Exists implements the Exists call of the actuator interface
-func (a *GCPActuator) Exists() (bool, error) {
-	return a.managedZone != nil, nil
-}
-
-// UpdateMetadata implements the UpdateMetadata call of the actuator interface
-func (a *GCPActuator) UpdateMetadata() error {
-	// Nothing to do here since GCP CloudDNS doesn't support tags.
-	return nil
+// findRecordSet returns the record set of the managed zone with the name and type of the record, or nil if there is
+// none.
+func (a *GCPActuator) findRecordSet(record hivev1.DNSRecord) (*dns.ResourceRecordSet, error) {
+	fqdn := controllerutils.Dotted(recordFQDN(a.dnsZone.Spec.Zone, record))
+	resp, err := a.gcpClient.ListResourceRecordSets(a.managedZone.Name, gcpclient.ListResourceRecordSetsOptions{
+		Name: fqdn,
+		Type: string(record.Type),
+	})
+	if err != nil {
+		a.logger.WithError(err).WithField("name", fqdn).Error("Error listing recordsets for zone")
+		return nil, err
+	}
+	for _, recordSet := range resp.Rrsets {
+		if recordNameEquals(recordSet.Name, fqdn) && recordSet.Type == string(record.Type) {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
 }
 
 // modifyStatus updates the DnsZone's status with GCP specific information.
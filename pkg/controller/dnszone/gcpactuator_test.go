@@ -5,6 +5,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/gcpclient/mock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +76,32 @@ func mockCreateGCPZone(expect *mock.MockClientMockRecorder) {
 	}, nil).Times(1)
 }
 
+func mockSyncGCPRecords(expect *mock.MockClientMockRecorder) {
+	oldRecordSet := &dns.ResourceRecordSet{
+		Name:    "old.blah.example.com.",
+		Type:    "A",
+		Ttl:     60,
+		Rrdatas: []string{"10.0.0.2"},
+	}
+	expect.ListResourceRecordSets("hive-blah-example-com", gcpclient.ListResourceRecordSetsOptions{Name: "old.blah.example.com.", Type: "A"}).
+		Return(&dns.ResourceRecordSetsListResponse{Rrsets: []*dns.ResourceRecordSet{oldRecordSet}}, nil).Times(1)
+	expect.ListResourceRecordSets("hive-blah-example-com", gomock.Any()).
+		Return(&dns.ResourceRecordSetsListResponse{}, nil).Times(2)
+	expect.UpdateResourceRecordSet("hive-blah-example-com", gomock.Eq(&dns.ResourceRecordSet{
+		Name:    "*.apps-external.blah.example.com.",
+		Type:    "A",
+		Ttl:     60,
+		Rrdatas: []string{"10.0.0.1"},
+	}), gomock.Nil()).Return(nil).Times(1)
+	expect.UpdateResourceRecordSet("hive-blah-example-com", gomock.Eq(&dns.ResourceRecordSet{
+		Name:    "console.blah.example.com.",
+		Type:    "CNAME",
+		Ttl:     300,
+		Rrdatas: []string{"lb.example.net."},
+	}), gomock.Nil()).Return(nil).Times(1)
+	expect.DeleteResourceRecordSet("hive-blah-example-com", oldRecordSet).Return(nil).Times(1)
+}
+
 func mockDeleteGCPZone(expect *mock.MockClientMockRecorder) {
 	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
 	expect.DeleteManagedZone(gomock.Any()).Return(nil).Times(1)
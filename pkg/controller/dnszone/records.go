@@ -0,0 +1,50 @@
+package dnszone
+
+import (
+	"strings"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// defaultRecordTTL is the time to live in seconds of the records of a DNSZone which do not set one.
+const defaultRecordTTL = 60
+
+// removedRecords returns the records last synced to the zone which are no longer in the spec of the DNSZone.
+func removedRecords(dnsZone *hivev1.DNSZone) []hivev1.DNSRecord {
+	var removed []hivev1.DNSRecord
+	for _, synced := range dnsZone.Status.SyncedRecords {
+		found := false
+		for _, record := range dnsZone.Spec.Records {
+			if record.Name == synced.Name && record.Type == synced.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			removed = append(removed, synced)
+		}
+	}
+	return removed
+}
+
+// recordFQDN returns the fully qualified name of the record in the zone, without the trailing dot.
+func recordFQDN(zone string, record hivev1.DNSRecord) string {
+	if record.Name == "@" {
+		return zone
+	}
+	return record.Name + "." + zone
+}
+
+func recordTTL(record hivev1.DNSRecord) int64 {
+	if record.TTL == 0 {
+		return defaultRecordTTL
+	}
+	return record.TTL
+}
+
+// recordNameEquals compares the name of a record as returned by the DNS provider with a fully qualified name, ignoring
+// the trailing dot and the octal escaping of the wildcard label.
+func recordNameEquals(name, fqdn string) bool {
+	name = strings.TrimSuffix(strings.Replace(name, `\052`, "*", 1), ".")
+	return strings.EqualFold(name, strings.TrimSuffix(fqdn, "."))
+}
@@ -0,0 +1,61 @@
+package dnszone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestRemovedRecords(t *testing.T) {
+	zone := withRecords(validDNSZone())
+	zone.Status.SyncedRecords = append(zone.Status.SyncedRecords, hivev1.DNSRecord{
+		Name:   "*.apps-external",
+		Type:   hivev1.DNSRecordTypeA,
+		Values: []string{"10.0.0.3"},
+	}, hivev1.DNSRecord{
+		Name:   "console",
+		Type:   hivev1.DNSRecordTypeA,
+		Values: []string{"10.0.0.4"},
+	})
+	removed := removedRecords(zone)
+	if assert.Len(t, removed, 2, "unexpected number of removed records") {
+		assert.Equal(t, "old", removed[0].Name, "unexpected removed record")
+		assert.Equal(t, "console", removed[1].Name, "unexpected removed record")
+		assert.Equal(t, hivev1.DNSRecordTypeA, removed[1].Type, "record of another type should be removed")
+	}
+}
+
+func TestRecordNameEquals(t *testing.T) {
+	cases := []struct {
+		name     string
+		fqdn     string
+		expected bool
+	}{
+		{
+			name:     "api.blah.example.com.",
+			fqdn:     "api.blah.example.com",
+			expected: true,
+		},
+		{
+			name:     `\052.apps.blah.example.com.`,
+			fqdn:     "*.apps.blah.example.com",
+			expected: true,
+		},
+		{
+			name:     "API.blah.example.com",
+			fqdn:     "api.blah.example.com.",
+			expected: true,
+		},
+		{
+			name: "apps.blah.example.com.",
+			fqdn: "*.apps.blah.example.com",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, recordNameEquals(tc.name, tc.fqdn))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
@@ -22,6 +23,7 @@ import (
 	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
 	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	log "github.com/sirupsen/logrus"
 )
 
 var (
@@ -164,6 +166,55 @@ var (
 		return zone
 	}
 
+	validPrivateDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.AWS.PrivateZone = &hivev1.AWSPrivateDNSZone{
+			VPCs: []hivev1.AWSDNSZoneVPC{
+				{
+					VPCID:  "vpc-1",
+					Region: "us-east-1",
+				},
+				{
+					VPCID:         "vpc-2",
+					Region:        "us-east-2",
+					AssumeRoleARN: "arn:aws:iam::123456789012:role/vpc-owner",
+				},
+			},
+		}
+		return zone
+	}
+
+	validPrivateDNSZoneWithoutID = func() *hivev1.DNSZone {
+		zone := validPrivateDNSZone()
+		zone.Status.AWS = nil
+		return zone
+	}
+
+	// withRecords adds records to the spec of the zone, and a record removed from the spec to its synced records.
+	withRecords = func(zone *hivev1.DNSZone) *hivev1.DNSZone {
+		zone.Spec.Records = []hivev1.DNSRecord{
+			{
+				Name:   "*.apps-external",
+				Type:   hivev1.DNSRecordTypeA,
+				Values: []string{"10.0.0.1"},
+			},
+			{
+				Name:   "console",
+				Type:   hivev1.DNSRecordTypeCNAME,
+				TTL:    300,
+				Values: []string{"lb.example.net"},
+			},
+		}
+		zone.Status.SyncedRecords = []hivev1.DNSRecord{
+			{
+				Name:   "old",
+				Type:   hivev1.DNSRecordTypeA,
+				Values: []string{"10.0.0.2"},
+			},
+		}
+		return zone
+	}
+
 	validDNSZoneBeingDeleted = func() *hivev1.DNSZone {
 		// Take a copy of the default validDNSZone object
 		zone := validDNSZone()
@@ -206,7 +257,7 @@ func setupDefaultMocks(t *testing.T) *mocks {
 }
 
 func fakeAWSClientBuilder(mockAWSClient *mockaws.MockClient) awsClientBuilderType {
-	return func(secret *corev1.Secret, region string) (awsclient.Client, error) {
+	return func(secret *corev1.Secret, region string, serviceEndpoints []hivev1aws.ServiceEndpoint) (awsclient.Client, error) {
 		return mockAWSClient, nil
 	}
 }
@@ -224,6 +275,12 @@ func fakeAzureClientBuilder(mockAzureClient *mockazure.MockClient) azureClientBu
 }
 
 // setFakeDNSZoneInKube is an easy way to register a dns zone object with kube.
+func fakeNameServerLookup(nameServers []string, err error) func(string, log.FieldLogger) ([]string, error) {
+	return func(string, log.FieldLogger) ([]string, error) {
+		return nameServers, err
+	}
+}
+
 func setFakeDNSZoneInKube(mocks *mocks, dnsZone *hivev1.DNSZone) error {
 	return mocks.fakeKubeClient.Create(context.TODO(), dnsZone)
 }
@@ -3,6 +3,7 @@ package hibernation
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -30,6 +32,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -54,16 +57,19 @@ const (
 	// hibernateAfterSyncSetsNotApplied is the amount of time to wait
 	// before hibernating when SyncSets have not been applied
 	hibernateAfterSyncSetsNotApplied = 10 * time.Minute
+
+	// clusterOperatorsSettleWaitTime is the maximum time to wait for the
+	// cluster operators to settle after a cluster started resuming. Once
+	// elapsed, the cluster is reported as running even if some cluster
+	// operators have not settled, as they may not have been settled
+	// before the cluster was hibernated either.
+	clusterOperatorsSettleWaitTime = 20 * time.Minute
 )
 
 var (
 	// minimumClusterVersion is the minimum supported version for
 	// hibernation
 	minimumClusterVersion = semver.MustParse("4.4.8")
-
-	// actuators is a list of available actuators for this controller
-	// It is populated via the RegisterActuator function
-	actuators []HibernationActuator
 )
 
 // Add creates a new Hibernation controller and adds it to the manager with default RBAC.
@@ -77,13 +83,6 @@ func Add(mgr manager.Manager) error {
 	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
 }
 
-// RegisterActuator register an actuator with this controller. The actuator
-// determines whether it can handle a particular cluster deployment via the CanHandle
-// function.
-func RegisterActuator(a HibernationActuator) {
-	actuators = append(actuators, a)
-}
-
 // hibernationReconciler is the reconciler type for this controller
 type hibernationReconciler struct {
 	client.Client
@@ -110,7 +109,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 // AddToManager adds a new Controller to the controller manager
 func AddToManager(mgr manager.Manager, r *hibernationReconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	c, err := controller.New("hibernation-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -347,8 +346,52 @@ func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment
 		logger.Info("Nodes are not ready, checking for CSRs to approve")
 		return r.checkCSRs(cd, remoteClient, logger)
 	}
+	unsettled, err := r.unsettledClusterOperators(remoteClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether cluster operators have settled")
+		return reconcile.Result{}, err
+	}
+	message := "All machines are started and nodes are ready"
+	if len(unsettled) > 0 {
+		logger = logger.WithField("clusterOperators", unsettled)
+		// The hibernating condition is not updated while waiting, as its last probe time is when the cluster started
+		// resuming.
+		hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+		if hibernatingCondition != nil && time.Since(hibernatingCondition.LastProbeTime.Time) < clusterOperatorsSettleWaitTime {
+			logger.Info("Cluster operators have not settled, waiting")
+			return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
+		}
+		logger.Warn("Cluster operators have not settled in time")
+		message = fmt.Sprintf("All machines are started and nodes are ready, but cluster operators have not settled: %s", strings.Join(unsettled, ", "))
+	}
 	logger.Info("Cluster has started and is in Running state")
-	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All machines are started and nodes are ready", corev1.ConditionFalse, logger)
+	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, message, corev1.ConditionFalse, logger)
+}
+
+// unsettledClusterOperators returns the names of the cluster operators which are not available, or are progressing or
+// degraded.
+func (r *hibernationReconciler) unsettledClusterOperators(remoteClient client.Client, logger log.FieldLogger) ([]string, error) {
+	clusterOperators := &configv1.ClusterOperatorList{}
+	if err := remoteClient.List(context.TODO(), clusterOperators); err != nil {
+		return nil, errors.Wrap(err, "failed to list cluster operators")
+	}
+	var unsettled []string
+	for _, co := range clusterOperators.Items {
+		settled := true
+		for _, cond := range co.Status.Conditions {
+			switch cond.Type {
+			case configv1.OperatorAvailable:
+				settled = settled && cond.Status == configv1.ConditionTrue
+			case configv1.OperatorProgressing, configv1.OperatorDegraded:
+				settled = settled && cond.Status == configv1.ConditionFalse
+			}
+		}
+		if !settled {
+			unsettled = append(unsettled, co.Name)
+		}
+	}
+	logger.WithField("count", len(clusterOperators.Items)).WithField("unsettled", len(unsettled)).Debug("checked cluster operators")
+	return unsettled, nil
 }
 
 func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeployment, reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) (result reconcile.Result, returnErr error) {
@@ -396,13 +439,10 @@ func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeploy
 	return reconcile.Result{}, nil
 }
 
-func (r *hibernationReconciler) getActuator(cd *hivev1.ClusterDeployment) HibernationActuator {
-	for _, a := range actuators {
-		if a.CanHandle(cd) {
-			return a
-		}
-	}
-	return nil
+// getActuator returns the hibernator of the platform of the cluster, or nil if the platform does not support
+// hibernation.
+func (r *hibernationReconciler) getActuator(cd *hivev1.ClusterDeployment) platform.Hibernator {
+	return platform.HibernatorForClusterDeployment(cd)
 }
 
 func (r *hibernationReconciler) hibernationSupported(cd *hivev1.ClusterDeployment) (bool, string) {
@@ -22,11 +22,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	fakeplatform "github.com/openshift/hive/pkg/platform/fake"
+	platformmock "github.com/openshift/hive/pkg/platform/mock"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -49,6 +52,7 @@ func TestReconcile(t *testing.T) {
 	hivev1.AddToScheme(scheme)
 	hiveintv1alpha1.AddToScheme(scheme)
 	machineapi.AddToScheme(scheme)
+	configv1.AddToScheme(scheme)
 
 	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
 		testcd.Installed(),
@@ -63,7 +67,7 @@ func TestReconcile(t *testing.T) {
 		name           string
 		cd             *hivev1.ClusterDeployment
 		cs             *hiveintv1alpha1.ClusterSync
-		setupActuator  func(actuator *mock.MockHibernationActuator)
+		setupActuator  func(actuator *platformmock.MockHibernator)
 		setupCSRHelper func(helper *mock.MockcsrHelper)
 		setupRemote    func(builder *remoteclientmock.MockBuilder)
 		validate       func(t *testing.T, cd *hivev1.ClusterDeployment)
@@ -114,7 +118,7 @@ func TestReconcile(t *testing.T) {
 			name: "start hibernating, syncsets not applied but 10 minutes have passed since cd install",
 			cd:   cdBuilder.Options(o.shouldHibernate, testcd.InstalledTimestamp(time.Now().Add(-15*time.Minute))).Build(),
 			cs:   csBuilder.Options(testcs.WithNoFirstSuccessTime()).Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -128,7 +132,7 @@ func TestReconcile(t *testing.T) {
 			name: "start hibernating",
 			cd:   cdBuilder.Options(o.shouldHibernate).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -142,7 +146,7 @@ func TestReconcile(t *testing.T) {
 			name: "fail to stop machines",
 			cd:   cdBuilder.Options(o.shouldHibernate).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -156,7 +160,7 @@ func TestReconcile(t *testing.T) {
 			name: "stopping, machines have stopped",
 			cd:   cdBuilder.Options(o.shouldHibernate, o.stopping).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -170,7 +174,7 @@ func TestReconcile(t *testing.T) {
 			name: "stopping, machines have not stopped",
 			cd:   cdBuilder.Options(o.shouldHibernate, o.stopping).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -190,7 +194,7 @@ func TestReconcile(t *testing.T) {
 				},
 				)),
 			cs: csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				// Ensure we try to stop machines in this state (bugfix)
 				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
@@ -205,7 +209,7 @@ func TestReconcile(t *testing.T) {
 			name: "start resuming",
 			cd:   cdBuilder.Options(o.hibernating).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -219,7 +223,7 @@ func TestReconcile(t *testing.T) {
 			name: "fail to start machines",
 			cd:   cdBuilder.Options(o.hibernating).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
 			},
 			expectError: true,
@@ -240,7 +244,7 @@ func TestReconcile(t *testing.T) {
 				},
 				)),
 			cs: csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				// Call will succeed which should clear the FailedToStart reason:
 				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
@@ -255,7 +259,7 @@ func TestReconcile(t *testing.T) {
 			name: "starting, machines have not started",
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -269,7 +273,7 @@ func TestReconcile(t *testing.T) {
 			name: "starting, machines running, nodes ready",
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
@@ -283,11 +287,50 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name: "starting, machines running, nodes ready, cluster operators not settled",
+			cd:   cdBuilder.Options(o.resumingSince(5 * time.Minute)).Build(),
+			cs:   csBuilder.Build(),
+			setupActuator: func(actuator *platformmock.MockHibernator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				objs := append(readyNodes(), clusterOperator("settled", true, false, false), clusterOperator("progressing", true, true, false))
+				c := fake.NewFakeClientWithScheme(scheme, objs...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "starting, machines running, nodes ready, cluster operators not settled in time",
+			cd:   cdBuilder.Options(o.resumingSince(30 * time.Minute)).Build(),
+			cs:   csBuilder.Build(),
+			setupActuator: func(actuator *platformmock.MockHibernator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				objs := append(readyNodes(), clusterOperator("degraded", true, false, true))
+				c := fake.NewFakeClientWithScheme(scheme, objs...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+				assert.Contains(t, cond.Message, "degraded")
+			},
+		},
 		{
 			name: "starting, machines running, unready node",
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
@@ -307,7 +350,7 @@ func TestReconcile(t *testing.T) {
 			name: "starting, machines running, unready node, csrs to approve",
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
@@ -346,8 +389,7 @@ func TestReconcile(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			mockActuator := mock.NewMockHibernationActuator(ctrl)
-			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			mockActuator := platformmock.NewMockHibernator(ctrl)
 			if test.setupActuator != nil {
 				test.setupActuator(mockActuator)
 			}
@@ -359,7 +401,10 @@ func TestReconcile(t *testing.T) {
 			if test.setupCSRHelper != nil {
 				test.setupCSRHelper(mockCSRHelper)
 			}
-			actuators = []HibernationActuator{mockActuator}
+			defer fakeplatform.Override(&fakeplatform.Actuator{
+				Handles:     func(*hivev1.ClusterDeployment) bool { return true },
+				Hibernation: mockActuator,
+			})()
 			c := fake.NewFakeClientWithScheme(scheme, test.cd, test.cs)
 
 			reconciler := hibernationReconciler{
@@ -411,7 +456,7 @@ func TestHibernateAfter(t *testing.T) {
 
 	tests := []struct {
 		name          string
-		setupActuator func(actuator *mock.MockHibernationActuator)
+		setupActuator func(actuator *platformmock.MockHibernator)
 		cd            *hivev1.ClusterDeployment
 		cs            *hiveintv1alpha1.ClusterSync
 
@@ -480,7 +525,7 @@ func TestHibernateAfter(t *testing.T) {
 		},
 		{
 			name: "cluster waking from hibernate",
-			setupActuator: func(actuator *mock.MockHibernationActuator) {
+			setupActuator: func(actuator *platformmock.MockHibernator) {
 				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			cd: cdBuilder.Build(
@@ -533,14 +578,16 @@ func TestHibernateAfter(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			mockActuator := mock.NewMockHibernationActuator(ctrl)
-			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			mockActuator := platformmock.NewMockHibernator(ctrl)
 			if test.setupActuator != nil {
 				test.setupActuator(mockActuator)
 			}
 			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
 			mockCSRHelper := mock.NewMockcsrHelper(ctrl)
-			actuators = []HibernationActuator{mockActuator}
+			defer fakeplatform.Override(&fakeplatform.Actuator{
+				Handles:     func(*hivev1.ClusterDeployment) bool { return true },
+				Hibernation: mockActuator,
+			})()
 			c := fake.NewFakeClientWithScheme(scheme, test.cd, test.cs)
 
 			reconciler := hibernationReconciler{
@@ -620,6 +667,16 @@ func (*clusterDeploymentOptions) resuming(cd *hivev1.ClusterDeployment) {
 		Status: corev1.ConditionTrue,
 	})
 }
+func (*clusterDeploymentOptions) resumingSince(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.ClusterHibernatingCondition,
+			Reason:        hivev1.ResumingHibernationReason,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-d)),
+		})
+	}
+}
 func (*clusterDeploymentOptions) unsupported(cd *hivev1.ClusterDeployment) {
 	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
 		Type:   hivev1.ClusterHibernatingCondition,
@@ -653,6 +710,23 @@ func readyNodes() []runtime.Object {
 	return nodes
 }
 
+func clusterOperator(name string, available, progressing, degraded bool) runtime.Object {
+	status := func(b bool) configv1.ConditionStatus {
+		if b {
+			return configv1.ConditionTrue
+		}
+		return configv1.ConditionFalse
+	}
+	co := &configv1.ClusterOperator{}
+	co.Name = name
+	co.Status.Conditions = []configv1.ClusterOperatorStatusCondition{
+		{Type: configv1.OperatorAvailable, Status: status(available)},
+		{Type: configv1.OperatorProgressing, Status: status(progressing)},
+		{Type: configv1.OperatorDegraded, Status: status(degraded)},
+	}
+	return co
+}
+
 func unreadyNode() []runtime.Object {
 	node := &corev1.Node{}
 	node.Name = "unready"
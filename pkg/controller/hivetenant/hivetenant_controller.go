@@ -0,0 +1,208 @@
+package hivetenant
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/tenancy"
+)
+
+const (
+	ControllerName = hivev1.HiveTenantControllerName
+
+	clusterDeploymentsQuota = "clusterdeployments"
+	concurrentInstallsQuota = "concurrentinstalls"
+	vcpusQuota              = "vcpus"
+
+	// recountInterval is how often the usage is counted again, so that the usage charged by the admission webhook
+	// for requests which were not persisted is released, and changes of the install-configs are picked up.
+	recountInterval = 10 * time.Minute
+)
+
+var (
+	metricTenantUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_tenant_usage",
+		Help: "Usage of the quotas of a HiveTenant by the resources in its namespace.",
+	}, []string{"namespace", "tenant", "quota"})
+	metricTenantLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_tenant_limit",
+		Help: "Limits set by the quotas of a HiveTenant. Quotas without a limit are not reported.",
+	}, []string{"namespace", "tenant", "quota"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricTenantUsage)
+	metrics.Registry.MustRegister(metricTenantLimit)
+}
+
+// Add creates a new HiveTenant controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileHiveTenant
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileHiveTenant {
+	return &ReconcileHiveTenant{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to the controller manager
+func AddToManager(mgr manager.Manager, r *ReconcileHiveTenant, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("hivetenant-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the spec of HiveTenants. The updates of the status by the admission webhook, which charges
+	// admitted requests to the usage, are ignored so that the usage is not counted again before the admitted resources
+	// are in the cache.
+	if err := c.Watch(&source.Kind{Type: &hivev1.HiveTenant{}}, &handler.EnqueueRequestForObject{}, predicate.GenerationChangedPredicate{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the resources counted against the quotas
+	mapToTenants := &handler.EnqueueRequestsFromMapFunc{ToRequests: requestsForNamespace(r.Client, r.logger)}
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, mapToTenants); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &hivev1.MachinePool{}}, mapToTenants); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForNamespace returns a request for each HiveTenant in the namespace of the object.
+func requestsForNamespace(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		tenants := &hivev1.HiveTenantList{}
+		if err := c.List(context.Background(), tenants, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list HiveTenants")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(tenants.Items))
+		for i, tenant := range tenants.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: tenant.Namespace, Name: tenant.Name}}
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileHiveTenant{}
+
+// ReconcileHiveTenant reconciles a HiveTenant object to report the usage of its quotas
+type ReconcileHiveTenant struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile counts the resources in the namespace of a HiveTenant and records the usage of its quotas in the status
+// of the HiveTenant and in metrics. The quotas are enforced by the admission webhook, which checks requests against
+// the usage in the status, and by the clusterdeployment controller before starting a provision.
+func (r *ReconcileHiveTenant) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "hiveTenant", request.NamespacedName)
+	logger.Info("reconciling hive tenant")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	tenant := &hivev1.HiveTenant{}
+	if err := r.Get(context.Background(), request.NamespacedName, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("hive tenant not found")
+			clearMetrics(request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get hive tenant")
+		return reconcile.Result{}, err
+	}
+	if tenant.DeletionTimestamp != nil {
+		logger.Debug("hive tenant is being deleted")
+		clearMetrics(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	usage, err := tenancy.GetUsage(r.Client, tenant.Namespace, tenant.Spec.InstanceTypeVCPUs)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get quota usage")
+		return reconcile.Result{}, err
+	}
+
+	setMetrics(tenant, usage)
+
+	origStatus := tenant.Status.DeepCopy()
+	tenant.Status.ClusterDeployments = usage.ClusterDeployments
+	tenant.Status.ConcurrentInstalls = usage.ConcurrentInstalls
+	tenant.Status.VCPUs = usage.VCPUs
+	tenant.Status.UnknownInstanceTypes = usage.UnknownInstanceTypes
+	tenant.Status.ObservedGeneration = tenant.Generation
+	if reflect.DeepEqual(origStatus, &tenant.Status) {
+		return reconcile.Result{RequeueAfter: recountInterval}, nil
+	}
+	logger.Debug("updating hive tenant status")
+	if err := r.Status().Update(context.Background(), tenant); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update hive tenant status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: recountInterval}, nil
+}
+
+func setMetrics(tenant *hivev1.HiveTenant, usage *tenancy.Usage) {
+	for quota, value := range map[string]int32{
+		clusterDeploymentsQuota: usage.ClusterDeployments,
+		concurrentInstallsQuota: usage.ConcurrentInstalls,
+		vcpusQuota:              usage.VCPUs,
+	} {
+		metricTenantUsage.WithLabelValues(tenant.Namespace, tenant.Name, quota).Set(float64(value))
+	}
+	for quota, limit := range map[string]*int32{
+		clusterDeploymentsQuota: tenant.Spec.MaxClusterDeployments,
+		concurrentInstallsQuota: tenant.Spec.MaxConcurrentInstalls,
+		vcpusQuota:              tenant.Spec.MaxVCPUs,
+	} {
+		if limit == nil {
+			metricTenantLimit.DeleteLabelValues(tenant.Namespace, tenant.Name, quota)
+			continue
+		}
+		metricTenantLimit.WithLabelValues(tenant.Namespace, tenant.Name, quota).Set(float64(*limit))
+	}
+}
+
+func clearMetrics(tenant types.NamespacedName) {
+	for _, quota := range []string{clusterDeploymentsQuota, concurrentInstallsQuota, vcpusQuota} {
+		metricTenantUsage.DeleteLabelValues(tenant.Namespace, tenant.Name, quota)
+		metricTenantLimit.DeleteLabelValues(tenant.Namespace, tenant.Name, quota)
+	}
+}
@@ -0,0 +1,181 @@
+package hivetenant
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testTenantName = "test-tenant"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func testClusterDeployment(namespace, name string, installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: installed,
+		},
+	}
+}
+
+func testClusterDeploymentWithInstallConfig(name, installConfig string) []runtime.Object {
+	cd := testClusterDeployment(testNamespace, name, false)
+	cd.Spec.Provisioning = &hivev1.Provisioning{
+		InstallConfigSecretRef: corev1.LocalObjectReference{Name: name + "-install-config"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name + "-install-config",
+		},
+		Data: map[string][]byte{"install-config.yaml": []byte(installConfig)},
+	}
+	return []runtime.Object{cd, secret}
+}
+
+func testMachinePool(namespace, name string, platform hivev1.MachinePoolPlatform) *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			Replicas: pointer.Int64Ptr(3),
+			Platform: platform,
+		},
+	}
+}
+
+func TestReconcileHiveTenant(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	awsPlatform := func(instanceType string) hivev1.MachinePoolPlatform {
+		return hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{InstanceType: instanceType}}
+	}
+	cases := []struct {
+		name           string
+		existing       []runtime.Object
+		expectedStatus hivev1.HiveTenantStatus
+	}{
+		{
+			name: "empty namespace",
+		},
+		{
+			name: "cluster deployments",
+			existing: []runtime.Object{
+				testClusterDeployment(testNamespace, "installed", true),
+				testClusterDeployment(testNamespace, "installing", false),
+				testClusterDeployment("other-namespace", "other", false),
+			},
+			expectedStatus: hivev1.HiveTenantStatus{
+				ClusterDeployments: 2,
+				ConcurrentInstalls: 1,
+			},
+		},
+		{
+			name: "machine pools",
+			existing: []runtime.Object{
+				testMachinePool(testNamespace, "aws", awsPlatform("m5.xlarge")),
+				testMachinePool(testNamespace, "vsphere", hivev1.MachinePoolPlatform{VSphere: &hivev1vsphere.MachinePool{NumCPUs: 2}}),
+				testMachinePool(testNamespace, "unknown", awsPlatform("m5.metal")),
+				testMachinePool("other-namespace", "other", awsPlatform("m5.xlarge")),
+			},
+			expectedStatus: hivev1.HiveTenantStatus{
+				VCPUs:                18,
+				UnknownInstanceTypes: []string{"m5.metal"},
+			},
+		},
+		{
+			name: "control plane machines",
+			existing: append(append(append(
+				testClusterDeploymentWithInstallConfig("default", `
+platform:
+  aws:
+    region: us-east-1
+`),
+				testClusterDeploymentWithInstallConfig("single-node", `
+controlPlane:
+  replicas: 1
+platform:
+  aws:
+    region: us-east-1
+    defaultMachinePlatform:
+      type: m5.2xlarge
+`)...),
+				testClusterDeploymentWithInstallConfig("vsphere", `
+controlPlane:
+  platform:
+    vsphere:
+      cpus: 8
+platform:
+  vsphere:
+    vCenter: vcenter.example.com
+`)...),
+				testClusterDeployment(testNamespace, "adopted", true),
+			),
+			expectedStatus: hivev1.HiveTenantStatus{
+				ClusterDeployments:   4,
+				ConcurrentInstalls:   3,
+				VCPUs:                36,
+				UnknownInstanceTypes: []string{"m5.2xlarge"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tenant := &hivev1.HiveTenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  testNamespace,
+					Name:       testTenantName,
+					Generation: 2,
+				},
+				Spec: hivev1.HiveTenantSpec{
+					MaxVCPUs:          pointer.Int32Ptr(100),
+					InstanceTypeVCPUs: map[string]int32{"m5.xlarge": 4},
+				},
+			}
+			fakeClient := fake.NewFakeClient(append(tc.existing, tenant)...)
+			r := &ReconcileHiveTenant{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+			}
+
+			key := types.NamespacedName{Namespace: testNamespace, Name: testTenantName}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			actual := &hivev1.HiveTenant{}
+			require.NoError(t, fakeClient.Get(context.Background(), key, actual), "could not get hive tenant")
+			assert.Equal(t, int64(2), actual.Status.ObservedGeneration, "unexpected observed generation")
+			actual.Status.ObservedGeneration = 0
+			assert.Equal(t, tc.expectedStatus, actual.Status, "unexpected hive tenant status")
+		})
+	}
+}
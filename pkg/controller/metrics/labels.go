@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// maxAdditionalLabels is the maximum number of additional labels added to the ClusterDeployment metrics.
+	maxAdditionalLabels = 5
+	// maxAdditionalLabelValues is the maximum number of distinct values reported for each additional label. Any
+	// further values are reported as additionalLabelOtherValue.
+	maxAdditionalLabelValues = 20
+
+	additionalLabelOtherValue       = "other"
+	additionalLabelUnspecifiedValue = "unspecified"
+
+	// labelValuesSeparator separates the label values in the keys of the clusterAccumulator maps. Label values
+	// taken from ClusterDeployment labels cannot contain it.
+	labelValuesSeparator = "/"
+)
+
+// labelNameRegexp matches the valid prometheus label names.
+var labelNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// reservedLabelNames are the names of the labels of the ClusterDeployment metrics which cannot be used for
+// additional labels.
+var reservedLabelNames = sets.NewString("cluster_type", "age_lt", "uninstalled_gt", "deprovisioning_gt", "condition")
+
+// additionalLabel is a label added to the ClusterDeployment metrics whose value is taken from a label of the
+// ClusterDeployments.
+type additionalLabel struct {
+	// name is the name of the label in the metrics.
+	name string
+	// clusterDeploymentLabel is the key of the ClusterDeployment label providing the value of the label.
+	clusterDeploymentLabel string
+}
+
+// getAdditionalLabels returns the additional labels configured in HiveConfig. They are read from the environment
+// variable set by the operator. Invalid labels are logged and skipped.
+func getAdditionalLabels(logger log.FieldLogger) []additionalLabel {
+	value, ok := os.LookupEnv(constants.MetricsConfigEnvVar)
+	if !ok {
+		return nil
+	}
+	config := &hivev1.MetricsConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, not adding any labels to metrics", constants.MetricsConfigEnvVar)
+		return nil
+	}
+	return parseAdditionalLabels(config.AdditionalClusterDeploymentLabels, logger)
+}
+
+func parseAdditionalLabels(labels map[string]string, logger log.FieldLogger) []additionalLabel {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var additionalLabels []additionalLabel
+	for _, name := range names {
+		labelLogger := logger.WithField("label", name)
+		switch {
+		case len(additionalLabels) == maxAdditionalLabels:
+			labelLogger.Errorf("at most %d additional labels are supported, skipping label", maxAdditionalLabels)
+			continue
+		case !labelNameRegexp.MatchString(name) || strings.HasPrefix(name, "__"):
+			labelLogger.Error("invalid metric label name, skipping label")
+			continue
+		case reservedLabelNames.Has(name):
+			labelLogger.Error("metric label name is already used by hive, skipping label")
+			continue
+		case labels[name] == "":
+			labelLogger.Error("no ClusterDeployment label specified, skipping label")
+			continue
+		}
+		additionalLabels = append(additionalLabels, additionalLabel{
+			name:                   name,
+			clusterDeploymentLabel: labels[name],
+		})
+	}
+	return additionalLabels
+}
+
+// additionalLabelNames returns the names of the additional labels.
+func additionalLabelNames(additionalLabels []additionalLabel) []string {
+	names := make([]string, len(additionalLabels))
+	for i, label := range additionalLabels {
+		names[i] = label.name
+	}
+	return names
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestParseAdditionalLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected []additionalLabel
+	}{
+		{
+			name: "no labels",
+		},
+		{
+			name: "valid labels",
+			labels: map[string]string{
+				"team":        "example.com/team",
+				"environment": "example.com/environment",
+			},
+			expected: []additionalLabel{
+				{name: "environment", clusterDeploymentLabel: "example.com/environment"},
+				{name: "team", clusterDeploymentLabel: "example.com/team"},
+			},
+		},
+		{
+			name: "invalid labels",
+			labels: map[string]string{
+				"team":         "example.com/team",
+				"invalid-name": "example.com/invalid",
+				"__reserved":   "example.com/reserved",
+				"cluster_type": "example.com/cluster-type",
+				"empty":        "",
+			},
+			expected: []additionalLabel{
+				{name: "team", clusterDeploymentLabel: "example.com/team"},
+			},
+		},
+		{
+			name: "too many labels",
+			labels: map[string]string{
+				"a": "example.com/a",
+				"b": "example.com/b",
+				"c": "example.com/c",
+				"d": "example.com/d",
+				"e": "example.com/e",
+				"f": "example.com/f",
+			},
+			expected: []additionalLabel{
+				{name: "a", clusterDeploymentLabel: "example.com/a"},
+				{name: "b", clusterDeploymentLabel: "example.com/b"},
+				{name: "c", clusterDeploymentLabel: "example.com/c"},
+				{name: "d", clusterDeploymentLabel: "example.com/d"},
+				{name: "e", clusterDeploymentLabel: "example.com/e"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := parseAdditionalLabels(test.labels, log.WithField("controller", "metrics"))
+			assert.Equal(t, test.expected, actual, "unexpected additional labels")
+		})
+	}
+}
+
+func TestClusterAccumulatorAdditionalLabels(t *testing.T) {
+	fiveMinsAgo := metav1.Time{Time: time.Now().Add(-5 * time.Minute)}
+	withTeam := func(cd hivev1.ClusterDeployment, team string) hivev1.ClusterDeployment {
+		cd.Labels["example.com/team"] = team
+		return cd
+	}
+	clusters := []hivev1.ClusterDeployment{
+		withTeam(testClusterDeployment("a", "managed", fiveMinsAgo, true), "team-a"),
+		withTeam(testClusterDeployment("b", "managed", fiveMinsAgo, false), "team-a"),
+		withTeam(testClusterDeployment("c", "managed", fiveMinsAgo, true), "team-b"),
+		testClusterDeployment("d", "managed", fiveMinsAgo, true),
+	}
+	// Clusters of more teams than the maximum number of values of a label:
+	for i := 0; i < maxAdditionalLabelValues; i++ {
+		clusters = append(clusters, withTeam(testClusterDeployment(fmt.Sprintf("e%d", i), "unmanaged", fiveMinsAgo, true), fmt.Sprintf("team-e%d", i)))
+	}
+
+	additionalLabels := []additionalLabel{{name: "team", clusterDeploymentLabel: "example.com/team"}}
+	accumulator, err := newClusterAccumulator(infinity, []string{"0h"}, additionalLabels)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, cd := range clusters {
+		accumulator.processCluster(&cd)
+	}
+
+	total := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "total"}, []string{"cluster_type", "age_lt", "team"})
+	installed := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "installed"}, []string{"cluster_type", "age_lt", "team"})
+	uninstalled := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "uninstalled"}, []string{"cluster_type", "age_lt", "uninstalled_gt", "team"})
+	deprovisioning := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "deprovisioning"}, []string{"cluster_type", "age_lt", "deprovisioning_gt", "team"})
+	conditions := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "conditions"}, []string{"cluster_type", "age_lt", "condition", "team"})
+	accumulator.setMetrics(total, installed, uninstalled, deprovisioning, conditions, log.WithField("controller", "metrics"))
+
+	assert.Equal(t, 2., testutil.ToFloat64(total.WithLabelValues("managed", infinity, "team-a")))
+	assert.Equal(t, 1., testutil.ToFloat64(installed.WithLabelValues("managed", infinity, "team-a")))
+	assert.Equal(t, 1., testutil.ToFloat64(uninstalled.WithLabelValues("managed", infinity, "0h", "team-a")))
+	assert.Equal(t, 1., testutil.ToFloat64(total.WithLabelValues("managed", infinity, "team-b")))
+	assert.Equal(t, 1., testutil.ToFloat64(total.WithLabelValues("managed", infinity, additionalLabelUnspecifiedValue)))
+	// The first values of the label were used by the managed clusters, the remaining clusters are reported as other.
+	assert.Equal(t, 3., testutil.ToFloat64(total.WithLabelValues("unmanaged", infinity, additionalLabelOtherValue)))
+	assert.Equal(t, maxAdditionalLabelValues, accumulator.additionalLabelValues[0].Len(), "unexpected number of label values")
+}
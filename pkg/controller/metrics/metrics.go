@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,6 +12,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -27,28 +29,14 @@ const (
 )
 
 var (
-	metricClusterDeploymentsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "hive_cluster_deployments",
-		Help: "Total number of cluster deployments.",
-	}, []string{"cluster_type", "age_lt"})
-	metricClusterDeploymentsInstalledTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "hive_cluster_deployments_installed",
-		Help: "Total number of cluster deployments that are successfully installed.",
-	}, []string{"cluster_type", "age_lt"})
-	metricClusterDeploymentsUninstalledTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "hive_cluster_deployments_uninstalled",
-		Help: "Total number of cluster deployments that are not yet installed by type and bucket for length of time in this state.",
-	},
-		[]string{"cluster_type", "age_lt", "uninstalled_gt"},
-	)
-	metricClusterDeploymentsDeprovisioningTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "hive_cluster_deployments_deprovisioning",
-		Help: "Total number of cluster deployments in process of being deprovisioned.",
-	}, []string{"cluster_type", "age_lt", "deprovisioning_gt"})
-	metricClusterDeploymentsWithConditionTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "hive_cluster_deployments_conditions",
-		Help: "Total number of cluster deployments by type with conditions.",
-	}, []string{"cluster_type", "age_lt", "condition"})
+	// The cluster deployment count metrics are created by registerClusterDeploymentMetrics, as their labels
+	// include the additional labels configured in HiveConfig.
+	metricClusterDeploymentsTotal               *prometheus.GaugeVec
+	metricClusterDeploymentsInstalledTotal      *prometheus.GaugeVec
+	metricClusterDeploymentsUninstalledTotal    *prometheus.GaugeVec
+	metricClusterDeploymentsDeprovisioningTotal *prometheus.GaugeVec
+	metricClusterDeploymentsWithConditionTotal  *prometheus.GaugeVec
+
 	metricInstallJobsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "hive_install_jobs",
 		Help: "Total number of install jobs running by cluster type and state.",
@@ -77,9 +65,25 @@ var (
 		Name: "hive_syncsets_unapplied_total",
 		Help: "Total number of SyncSetsInstances referencing non-selector SyncSets that have not successfully applied all resources/patches/secrets.",
 	})
+	metricClusterPoolSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_size",
+		Help: "Number of unclaimed clusters each ClusterPool is configured to keep provisioned.",
+	}, []string{"namespace", "clusterpool"})
+	metricClusterPoolClustersUnclaimed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_unclaimed",
+		Help: "Number of unclaimed clusters that have been created for each ClusterPool.",
+	}, []string{"namespace", "clusterpool"})
+	metricClusterPoolClustersReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_ready",
+		Help: "Number of unclaimed clusters of each ClusterPool that are installed and ready to be claimed.",
+	}, []string{"namespace", "clusterpool"})
+	metricClusterPoolClustersStandby = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_standby",
+		Help: "Number of unclaimed clusters of each ClusterPool that are not yet ready to be claimed.",
+	}, []string{"namespace", "clusterpool"})
 
 	// MetricClusterDeploymentDeprovisioningUnderwaySeconds is a prometheus metric for the number of seconds
-	// between when a still deprovisioning cluster was created and now.
+	// between when a still deprovisioning cluster was deleted and now.
 	MetricClusterDeploymentDeprovisioningUnderwaySeconds = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "hive_cluster_deployment_deprovision_underway_seconds",
@@ -88,6 +92,17 @@ var (
 		},
 		[]string{"cluster_deployment", "namespace", "cluster_type"},
 	)
+	// MetricClusterDeploymentProvisionToReadySeconds is a prometheus metric for the number of seconds between
+	// the creation of a cluster and all of its cluster operators first being available. It is observed by the
+	// clusterstate controller, which tracks the cluster operators of installed clusters.
+	MetricClusterDeploymentProvisionToReadySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_deployment_provision_to_ready_seconds",
+			Help:    "Time between cluster deployment creation and all cluster operators of the cluster being available.",
+			Buckets: []float64{600, 1200, 1800, 2400, 3000, 3600, 5400, 7200, 10800},
+		},
+		[]string{"cluster_type"},
+	)
 	// metricControllerReconcileTime tracks the length of time our reconcile loops take. controller-runtime
 	// technically tracks this for us, but due to bugs currently also includes time in the queue, which leads to
 	// extremely strange results. For now, track our own metric.
@@ -110,6 +125,25 @@ var (
 		},
 		[]string{"cluster_deployment", "namespace", "cluster_type"},
 	)
+	// metricClusterSyncLastSuccessAgeSeconds tracks how long ago the SyncSets and SelectorSyncSets were last all
+	// applied successfully to each cluster, as recorded in its ClusterSyncLease. Clusters which have never been
+	// synced successfully report the age of their lease.
+	metricClusterSyncLastSuccessAgeSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hive_clustersync_last_success_age_seconds",
+			Help: "Time since the SyncSets and SelectorSyncSets were last all applied successfully to the cluster",
+		},
+		[]string{"cluster_deployment", "namespace"},
+	)
+	// metricClusterDeploymentEstimatedHourlyCost reports the estimated hourly cost of each cluster recorded in the
+	// status of its ClusterDeployment by the clustercost controller.
+	metricClusterDeploymentEstimatedHourlyCost = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hive_cluster_deployment_estimated_hourly_cost",
+			Help: "Estimated cost of running the machines of the cluster for an hour",
+		},
+		[]string{"cluster_deployment", "namespace", "currency"},
+	)
 )
 
 // ReconcileOutcome is used in controller "reconcile complete" log entries, and the metricControllerReconcileTime
@@ -127,11 +161,6 @@ const (
 )
 
 func init() {
-	metrics.Registry.MustRegister(metricClusterDeploymentsTotal)
-	metrics.Registry.MustRegister(metricClusterDeploymentsInstalledTotal)
-	metrics.Registry.MustRegister(metricClusterDeploymentsUninstalledTotal)
-	metrics.Registry.MustRegister(metricClusterDeploymentsDeprovisioningTotal)
-	metrics.Registry.MustRegister(metricClusterDeploymentsWithConditionTotal)
 	metrics.Registry.MustRegister(metricInstallJobsTotal)
 	metrics.Registry.MustRegister(metricUninstallJobsTotal)
 	metrics.Registry.MustRegister(metricImagesetJobsTotal)
@@ -139,17 +168,63 @@ func init() {
 	metrics.Registry.MustRegister(metricSelectorSyncSetClustersUnappliedTotal)
 	metrics.Registry.MustRegister(metricSyncSetsTotal)
 	metrics.Registry.MustRegister(metricSyncSetsUnappliedTotal)
+	metrics.Registry.MustRegister(metricClusterPoolSize)
+	metrics.Registry.MustRegister(metricClusterPoolClustersUnclaimed)
+	metrics.Registry.MustRegister(metricClusterPoolClustersReady)
+	metrics.Registry.MustRegister(metricClusterPoolClustersStandby)
 	metrics.Registry.MustRegister(metricControllerReconcileTime)
 
 	metrics.Registry.MustRegister(MetricClusterDeploymentDeprovisioningUnderwaySeconds)
+	metrics.Registry.MustRegister(MetricClusterDeploymentProvisionToReadySeconds)
 	metrics.Registry.MustRegister(metricClusterDeploymentSyncsetPaused)
+	metrics.Registry.MustRegister(metricClusterSyncLastSuccessAgeSeconds)
+	metrics.Registry.MustRegister(metricClusterDeploymentEstimatedHourlyCost)
+}
+
+// registerClusterDeploymentMetrics creates and registers the cluster deployment count metrics, with the additional
+// labels appended to their labels.
+func registerClusterDeploymentMetrics(additionalLabels []additionalLabel) {
+	labels := func(names ...string) []string {
+		return append(names, additionalLabelNames(additionalLabels)...)
+	}
+	metricClusterDeploymentsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments",
+		Help: "Total number of cluster deployments.",
+	}, labels("cluster_type", "age_lt"))
+	metricClusterDeploymentsInstalledTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_installed",
+		Help: "Total number of cluster deployments that are successfully installed.",
+	}, labels("cluster_type", "age_lt"))
+	metricClusterDeploymentsUninstalledTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_uninstalled",
+		Help: "Total number of cluster deployments that are not yet installed by type and bucket for length of time in this state.",
+	},
+		labels("cluster_type", "age_lt", "uninstalled_gt"),
+	)
+	metricClusterDeploymentsDeprovisioningTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_deprovisioning",
+		Help: "Total number of cluster deployments in process of being deprovisioned.",
+	}, labels("cluster_type", "age_lt", "deprovisioning_gt"))
+	metricClusterDeploymentsWithConditionTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_conditions",
+		Help: "Total number of cluster deployments by type with conditions.",
+	}, labels("cluster_type", "age_lt", "condition"))
+
+	metrics.Registry.MustRegister(metricClusterDeploymentsTotal)
+	metrics.Registry.MustRegister(metricClusterDeploymentsInstalledTotal)
+	metrics.Registry.MustRegister(metricClusterDeploymentsUninstalledTotal)
+	metrics.Registry.MustRegister(metricClusterDeploymentsDeprovisioningTotal)
+	metrics.Registry.MustRegister(metricClusterDeploymentsWithConditionTotal)
 }
 
 // Add creates a new metrics Calculator and adds it to the Manager.
 func Add(mgr manager.Manager) error {
+	additionalLabels := getAdditionalLabels(log.WithField("controller", ControllerName))
+	registerClusterDeploymentMetrics(additionalLabels)
 	mc := &Calculator{
-		Client:   mgr.GetClient(),
-		Interval: 2 * time.Minute,
+		Client:           mgr.GetClient(),
+		Interval:         2 * time.Minute,
+		additionalLabels: additionalLabels,
 	}
 	metrics.Registry.MustRegister(newProvisioningUnderwayCollector(mgr.GetClient()))
 	err := mgr.Add(mc)
@@ -170,6 +245,10 @@ type Calculator struct {
 
 	// Interval is the length of time we sleep between metrics calculations.
 	Interval time.Duration
+
+	// additionalLabels are the labels added to the cluster deployment count metrics from the labels of the
+	// cluster deployments.
+	additionalLabels []additionalLabel
 }
 
 // Start begins the metrics calculation loop.
@@ -189,15 +268,26 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 		if err != nil {
 			log.WithError(err).Error("error listing cluster deployments")
 		} else {
-			accumulator, err := newClusterAccumulator(infinity, []string{"0h", "1h", "2h", "8h", "24h", "72h"})
+			accumulator, err := newClusterAccumulator(infinity, []string{"0h", "1h", "2h", "8h", "24h", "72h"}, mc.additionalLabels)
 			if err != nil {
 				mcLog.WithError(err).Error("unable to calculate metrics")
 				return
 			}
+			// Reset the gauge so that the metrics of deleted clusters are cleared.
+			metricClusterDeploymentEstimatedHourlyCost.Reset()
 			for _, cd := range clusterDeployments.Items {
 				clusterType := GetClusterDeploymentType(&cd)
 				accumulator.processCluster(&cd)
 
+				if estimate := cd.Status.CostEstimate; estimate != nil {
+					if cost, err := strconv.ParseFloat(estimate.HourlyCost, 64); err == nil {
+						metricClusterDeploymentEstimatedHourlyCost.WithLabelValues(
+							cd.Name,
+							cd.Namespace,
+							estimate.Currency).Set(cost)
+					}
+				}
+
 				if cd.DeletionTimestamp != nil {
 
 					// For deprovisioning clusters we report the seconds since
@@ -207,7 +297,7 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 						cd.Name,
 						cd.Namespace,
 						clusterType).Set(
-						time.Since(cd.CreationTimestamp.Time).Seconds())
+						time.Since(cd.DeletionTimestamp.Time).Seconds())
 				}
 
 				if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); err == nil && paused {
@@ -235,7 +325,7 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 				mcLog)
 
 			// Also add metrics only for clusters created in last 48h
-			accumulator, err = newClusterAccumulator("48h", []string{"0h", "1h", "2h", "8h", "24h"})
+			accumulator, err = newClusterAccumulator("48h", []string{"0h", "1h", "2h", "8h", "24h"}, mc.additionalLabels)
 			if err != nil {
 				mcLog.WithError(err).Error("unable to calculate metrics")
 				return
@@ -313,6 +403,8 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 		}
 
 		mc.calculateSelectorSyncSetMetrics(mcLog)
+		mc.calculateClusterSyncLeaseMetrics(mcLog)
+		mc.calculateClusterPoolMetrics(mcLog)
 	}, mc.Interval, stopCh)
 
 	return nil
@@ -365,6 +457,48 @@ func (mc *Calculator) calculateSelectorSyncSetMetrics(mcLog log.FieldLogger) {
 	metricSyncSetsUnappliedTotal.Set(float64(ssInstancesUnappliedTotal))
 }
 
+func (mc *Calculator) calculateClusterSyncLeaseMetrics(mcLog log.FieldLogger) {
+	mcLog.Debug("calculating metrics across all ClusterSyncLeases")
+	leaseList := &hiveintv1alpha1.ClusterSyncLeaseList{}
+	err := mc.Client.List(context.Background(), leaseList)
+	if err != nil {
+		mcLog.WithError(err).Error("error listing all ClusterSyncLeases")
+		return
+	}
+
+	// Reset the gauge so that the metrics of deleted clusters are cleared.
+	metricClusterSyncLastSuccessAgeSeconds.Reset()
+	for _, lease := range leaseList.Items {
+		lastSuccessTime := lease.CreationTimestamp.Time
+		if lease.Spec.LastSuccessTime != nil {
+			lastSuccessTime = lease.Spec.LastSuccessTime.Time
+		}
+		metricClusterSyncLastSuccessAgeSeconds.WithLabelValues(lease.Name, lease.Namespace).Set(time.Since(lastSuccessTime).Seconds())
+	}
+}
+
+func (mc *Calculator) calculateClusterPoolMetrics(mcLog log.FieldLogger) {
+	mcLog.Debug("calculating metrics across all ClusterPools")
+	clusterPoolList := &hivev1.ClusterPoolList{}
+	err := mc.Client.List(context.Background(), clusterPoolList)
+	if err != nil {
+		mcLog.WithError(err).Error("error listing all ClusterPools")
+		return
+	}
+
+	// Reset the gauges so that the metrics of deleted pools are cleared.
+	metricClusterPoolSize.Reset()
+	metricClusterPoolClustersUnclaimed.Reset()
+	metricClusterPoolClustersReady.Reset()
+	metricClusterPoolClustersStandby.Reset()
+	for _, pool := range clusterPoolList.Items {
+		metricClusterPoolSize.WithLabelValues(pool.Namespace, pool.Name).Set(float64(pool.Spec.Size))
+		metricClusterPoolClustersUnclaimed.WithLabelValues(pool.Namespace, pool.Name).Set(float64(pool.Status.Size))
+		metricClusterPoolClustersReady.WithLabelValues(pool.Namespace, pool.Name).Set(float64(pool.Status.Ready))
+		metricClusterPoolClustersStandby.WithLabelValues(pool.Namespace, pool.Name).Set(float64(pool.Status.Size - pool.Status.Ready))
+	}
+}
+
 func processJobs(jobs []batchv1.Job) (runningTotal, succeededTotal, failedTotal map[string]int) {
 	running := map[string]int{}
 	failed := map[string]int{}
@@ -414,6 +548,15 @@ type clusterAccumulator struct {
 	// Used to zero out some values which may no longer exist when setting the final metrics.
 	// Maps cluster type to a meaningless bool.
 	clusterTypesSet map[string]bool
+
+	// additionalLabels are the labels added to the metrics from the labels of the cluster deployments. When set,
+	// the cluster types in the maps above are followed by the values of the additional labels, joined with
+	// labelValuesSeparator.
+	additionalLabels []additionalLabel
+
+	// additionalLabelValues contains the distinct values encountered for each additional label, used to limit
+	// the number of values reported.
+	additionalLabelValues []sets.String
 }
 
 const (
@@ -426,15 +569,21 @@ const (
 // newClusterAccumulator initializes a new cluster accumulator.
 // ageFilter can be used to exclude clusters older than a certain duration. Use "0h" to include all clusters.
 // durationBuckets are used to sort uninstalled, or deleted clusters into buckets based on how long they have been in that state.
-func newClusterAccumulator(ageFilter string, durationBuckets []string) (*clusterAccumulator, error) {
+// additionalLabels are added to the metrics from the labels of the cluster deployments.
+func newClusterAccumulator(ageFilter string, durationBuckets []string, additionalLabels []additionalLabel) (*clusterAccumulator, error) {
 	ca := &clusterAccumulator{
-		ageFilter:       ageFilter,
-		total:           map[string]int{},
-		installed:       map[string]int{},
-		deprovisioning:  map[string]map[string]int{},
-		uninstalled:     map[string]map[string]int{},
-		conditions:      map[hivev1.ClusterDeploymentConditionType]map[string]int{},
-		clusterTypesSet: map[string]bool{},
+		ageFilter:             ageFilter,
+		total:                 map[string]int{},
+		installed:             map[string]int{},
+		deprovisioning:        map[string]map[string]int{},
+		uninstalled:           map[string]map[string]int{},
+		conditions:            map[hivev1.ClusterDeploymentConditionType]map[string]int{},
+		clusterTypesSet:       map[string]bool{},
+		additionalLabels:      additionalLabels,
+		additionalLabelValues: make([]sets.String, len(additionalLabels)),
+	}
+	for i := range ca.additionalLabelValues {
+		ca.additionalLabelValues[i] = sets.NewString()
 	}
 	var err error
 	if ageFilter != infinity {
@@ -499,7 +648,7 @@ func (ca *clusterAccumulator) processCluster(cd *hivev1.ClusterDeployment) {
 		return
 	}
 
-	clusterType := GetClusterDeploymentType(cd)
+	clusterType := ca.clusterTypeWithLabelValues(cd)
 	ca.ensureClusterTypeBuckets(clusterType)
 	ca.clusterTypesSet[clusterType] = true
 
@@ -550,41 +699,71 @@ func (ca *clusterAccumulator) processCluster(cd *hivev1.ClusterDeployment) {
 	}
 }
 
+// clusterTypeWithLabelValues returns the cluster type of the cluster deployment followed by the values of the
+// additional labels. Values beyond the maximum number of distinct values of a label are replaced with
+// additionalLabelOtherValue.
+func (ca *clusterAccumulator) clusterTypeWithLabelValues(cd *hivev1.ClusterDeployment) string {
+	values := []string{GetClusterDeploymentType(cd)}
+	for i, label := range ca.additionalLabels {
+		value, ok := cd.Labels[label.clusterDeploymentLabel]
+		if !ok {
+			value = additionalLabelUnspecifiedValue
+		}
+		if seen := ca.additionalLabelValues[i]; !seen.Has(value) {
+			if seen.Len() < maxAdditionalLabelValues {
+				seen.Insert(value)
+			} else {
+				value = additionalLabelOtherValue
+			}
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, labelValuesSeparator)
+}
+
+// labelValues returns the label values of a metric for a cluster type returned by clusterTypeWithLabelValues: the
+// cluster type, followed by the given label values, followed by the values of the additional labels.
+func (ca *clusterAccumulator) labelValues(clusterType string, values ...string) []string {
+	typeAndAdditionalValues := strings.SplitN(clusterType, labelValuesSeparator, len(ca.additionalLabels)+1)
+	labelValues := append([]string{typeAndAdditionalValues[0]}, values...)
+	return append(labelValues, typeAndAdditionalValues[1:]...)
+}
+
 func (ca *clusterAccumulator) setMetrics(total, installed, uninstalled, deprovisioning, conditions *prometheus.GaugeVec, mcLog log.FieldLogger) {
 
 	for k, v := range ca.total {
-		total.WithLabelValues(k, ca.ageFilter).Set(float64(v))
+		total.WithLabelValues(ca.labelValues(k, ca.ageFilter)...).Set(float64(v))
 	}
 	for k, v := range ca.installed {
-		installed.WithLabelValues(k, ca.ageFilter).Set(float64(v))
+		installed.WithLabelValues(ca.labelValues(k, ca.ageFilter)...).Set(float64(v))
 	}
 	for k, v := range ca.uninstalled {
 		for clusterType := range ca.clusterTypesSet {
 			if count, ok := v[clusterType]; ok {
-				uninstalled.WithLabelValues(clusterType, ca.ageFilter, k).Set(float64(count))
+				uninstalled.WithLabelValues(ca.labelValues(clusterType, ca.ageFilter, k)...).Set(float64(count))
 			} else {
 				// We need to potentially clear out old cluster types no longer showing in the list.
 				// This will work so long as there is at least one cluster of that type still remaining
 				// in hive somewhere.
-				uninstalled.WithLabelValues(clusterType, ca.ageFilter, k).Set(float64(0))
+				uninstalled.WithLabelValues(ca.labelValues(clusterType, ca.ageFilter, k)...).Set(float64(0))
 			}
 		}
 	}
 	for k, v := range ca.deprovisioning {
 		for clusterType := range ca.clusterTypesSet {
 			if count, ok := v[clusterType]; ok {
-				deprovisioning.WithLabelValues(clusterType, ca.ageFilter, k).Set(float64(count))
+				deprovisioning.WithLabelValues(ca.labelValues(clusterType, ca.ageFilter, k)...).Set(float64(count))
 			} else {
 				// We need to potentially clear out old cluster types no longer showing in the list.
 				// This will work so long as there is at least one cluster of that type still remaining
 				// in hive somewhere.
-				deprovisioning.WithLabelValues(clusterType, ca.ageFilter, k).Set(float64(0))
+				deprovisioning.WithLabelValues(ca.labelValues(clusterType, ca.ageFilter, k)...).Set(float64(0))
 			}
 		}
 	}
 	for k, v := range ca.conditions {
 		for k1, v1 := range v {
-			conditions.WithLabelValues(k1, ca.ageFilter, string(k)).Set(float64(v1))
+			conditions.WithLabelValues(ca.labelValues(k1, ca.ageFilter, string(k))...).Set(float64(v1))
 		}
 	}
 }
@@ -4,14 +4,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	testclusterpool "github.com/openshift/hive/pkg/test/clusterpool"
 )
 
 func TestClusterAccumulator(t *testing.T) {
@@ -71,7 +78,7 @@ func TestClusterAccumulator(t *testing.T) {
 		testDeletedClusterDeployment("unmanaged1", "unmanaged", tenDaysAgo, threeHoursAgo, true),
 	}
 
-	accumulator, _ := newClusterAccumulator(infinity, []string{"0h", "1h", "2h", "8h", "24h", "72h"})
+	accumulator, _ := newClusterAccumulator(infinity, []string{"0h", "1h", "2h", "8h", "24h", "72h"}, nil)
 	for _, cd := range clusters {
 		accumulator.processCluster(&cd)
 	}
@@ -107,7 +114,7 @@ func TestClusterAccumulator(t *testing.T) {
 	assert.Equal(t, 1, accumulator.conditions[hivev1.IngressCertificateNotFoundCondition]["managed"])
 
 	// Also test with a cluster age filter:
-	accumulator, _ = newClusterAccumulator("8h", []string{"0h", "1h", "2h", "8h", "24h", "72h"})
+	accumulator, _ = newClusterAccumulator("8h", []string{"0h", "1h", "2h", "8h", "24h", "72h"}, nil)
 	for _, cd := range clusters {
 		accumulator.processCluster(&cd)
 	}
@@ -159,6 +166,70 @@ func TestInstallJobs(t *testing.T) {
 	assert.Equal(t, 1, failed[hivev1.DefaultClusterType])
 }
 
+func TestClusterPoolMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	poolBuilder := testclusterpool.FullBuilder("test-namespace", "test-pool", scheme)
+	pool := poolBuilder.Build(testclusterpool.WithSize(5))
+	pool.Status.Size = 4
+	pool.Status.Ready = 1
+
+	// Set the metrics of a pool which no longer exists to check that they are cleared.
+	metricClusterPoolSize.WithLabelValues("test-namespace", "deleted-pool").Set(3)
+	mc := &Calculator{Client: fake.NewFakeClientWithScheme(scheme, pool)}
+	mc.calculateClusterPoolMetrics(log.WithField("controller", "metrics"))
+
+	assert.Equal(t, 5., testutil.ToFloat64(metricClusterPoolSize.WithLabelValues("test-namespace", "test-pool")), "unexpected pool size")
+	assert.Equal(t, 4., testutil.ToFloat64(metricClusterPoolClustersUnclaimed.WithLabelValues("test-namespace", "test-pool")), "unexpected unclaimed clusters")
+	assert.Equal(t, 1., testutil.ToFloat64(metricClusterPoolClustersReady.WithLabelValues("test-namespace", "test-pool")), "unexpected ready clusters")
+	assert.Equal(t, 3., testutil.ToFloat64(metricClusterPoolClustersStandby.WithLabelValues("test-namespace", "test-pool")), "unexpected standby clusters")
+	assert.Equal(t, 1, testutil.CollectAndCount(metricClusterPoolSize), "expected metrics of deleted pool to be cleared")
+}
+
+func TestClusterSyncLeaseMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hiveintv1alpha1.AddToScheme(scheme)
+	lastSuccessTime := metav1.NewMicroTime(time.Now().Add(-time.Hour))
+	leases := []runtime.Object{
+		&hiveintv1alpha1.ClusterSyncLease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "synced"},
+			Spec:       hiveintv1alpha1.ClusterSyncLeaseSpec{RenewTime: lastSuccessTime, LastSuccessTime: &lastSuccessTime},
+		},
+		&hiveintv1alpha1.ClusterSyncLease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test-namespace",
+				Name:              "never-synced",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+			Spec: hiveintv1alpha1.ClusterSyncLeaseSpec{RenewTime: lastSuccessTime},
+		},
+	}
+
+	// Set the metric of a cluster which no longer exists to check that it is cleared.
+	metricClusterSyncLastSuccessAgeSeconds.WithLabelValues("deleted", "test-namespace").Set(3)
+	mc := &Calculator{Client: fake.NewFakeClientWithScheme(scheme, leases...)}
+	mc.calculateClusterSyncLeaseMetrics(log.WithField("controller", "metrics"))
+
+	assert.InDelta(t, time.Hour.Seconds(), testutil.ToFloat64(metricClusterSyncLastSuccessAgeSeconds.WithLabelValues("synced", "test-namespace")), 60, "unexpected age of last success")
+	assert.InDelta(t, 2*time.Hour.Seconds(), testutil.ToFloat64(metricClusterSyncLastSuccessAgeSeconds.WithLabelValues("never-synced", "test-namespace")), 60, "expected age of lease when never synced")
+	assert.Equal(t, 2, testutil.CollectAndCount(metricClusterSyncLastSuccessAgeSeconds), "expected metric of deleted cluster to be cleared")
+}
+
+func TestProvisioningUnderwayCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	threeHoursAgo := metav1.Time{Time: time.Now().Add(-3 * time.Hour)}
+	installed := testClusterDeployment("installed", "managed", threeHoursAgo, true)
+	deleted := testDeletedClusterDeployment("deleted", "managed", threeHoursAgo, metav1.Now(), false)
+	installing := testClusterDeploymentWithConditions("installing", "managed", threeHoursAgo, false,
+		[]hivev1.ClusterDeploymentConditionType{hivev1.ProvisionFailedCondition})
+
+	collector := newProvisioningUnderwayCollector(fake.NewFakeClientWithScheme(scheme, &installed, &deleted, &installing))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(collector), "expected a metric only for the installing cluster")
+	assert.InDelta(t, 3*time.Hour.Seconds(), testutil.ToFloat64(collector), 60, "unexpected provisioning time")
+}
+
 func testClusterDeployment(name, clusterType string, created metav1.Time, installed bool) hivev1.ClusterDeployment {
 	return hivev1.ClusterDeployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/tracing"
+)
+
+// ReconcileResult is the result of a reconcile as reported by the hive_controller_reconcile_total metric.
+type ReconcileResult string
+
+const (
+	ReconcileResultSuccess      ReconcileResult = "success"
+	ReconcileResultError        ReconcileResult = "error"
+	ReconcileResultRequeue      ReconcileResult = "requeue"
+	ReconcileResultRequeueAfter ReconcileResult = "requeue_after"
+)
+
+var (
+	metricControllerReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_controller_reconcile_total",
+		Help: "Counter incremented for each reconcile of each controller by result.",
+	}, []string{"controller", "result"})
+	metricControllerReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_controller_reconcile_errors_total",
+		Help: "Counter incremented for each reconcile of each controller that returned an error.",
+	}, []string{"controller"})
+	// metricControllerReconcileDuration is labeled by the result of the reconciles, unlike
+	// metricControllerReconcileTime which is labeled by the outcome reported by the controllers.
+	metricControllerReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_controller_reconcile_duration_seconds",
+		Help:    "Distribution of the length of time the reconciles of each controller take by result.",
+		Buckets: []float64{0.001, 0.01, 0.1, 1, 10, 30, 60, 120},
+	}, []string{"controller", "outcome"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricControllerReconcileTotal)
+	metrics.Registry.MustRegister(metricControllerReconcileErrors)
+	metrics.Registry.MustRegister(metricControllerReconcileDuration)
+}
+
+// NewReconcilerWithMetrics wraps the given reconciler with one which counts the reconciles of the controller and the
+// errors they return, observes the time taken by the reconciles by result, and records a span for each reconcile when
+// tracing is enabled. This should be used as the Reconciler of all Hive controllers, and of the operator. The
+// reconciles are tracked by the default ReconcileDrainer, so that they are drained when the controller manager shuts
+// down.
+func NewReconcilerWithMetrics(r reconcile.Reconciler, controllerName hivev1.ControllerName) reconcile.Reconciler {
+	// Initialize the counters so that error rates can be computed before the first error.
+	for _, result := range []ReconcileResult{
+		ReconcileResultSuccess,
+		ReconcileResultError,
+		ReconcileResultRequeue,
+		ReconcileResultRequeueAfter,
+	} {
+		metricControllerReconcileTotal.WithLabelValues(controllerName.String(), string(result))
+	}
+	metricControllerReconcileErrors.WithLabelValues(controllerName.String())
+	return &reconcilerWithMetrics{
+		Reconciler:     r,
+		controllerName: controllerName,
+		drainer:        controllerutils.DefaultReconcileDrainer(),
+	}
+}
+
+type reconcilerWithMetrics struct {
+	reconcile.Reconciler
+	controllerName hivev1.ControllerName
+	drainer        *controllerutils.ReconcileDrainer
+}
+
+// Reconcile calls the wrapped reconciler and records the result of the reconcile. Once the controller manager is
+// shutting down, the request is dropped without calling the wrapped reconciler. The objects are reconciled again
+// when the next leader starts.
+func (r *reconcilerWithMetrics) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	if !r.drainer.StartReconcile() {
+		log.WithField("controller", r.controllerName).WithField("request", request.NamespacedName).Debug("shutting down, not starting reconcile")
+		return reconcile.Result{}, nil
+	}
+	defer r.drainer.EndReconcile()
+
+	span := tracing.StartSpan(request.Namespace, request.Name, "reconcile "+r.controllerName.String())
+	span.SetAttribute("controller", r.controllerName.String())
+	span.SetAttribute("namespace", request.Namespace)
+	span.SetAttribute("name", request.Name)
+	start := time.Now()
+	result, err := r.Reconciler.Reconcile(request)
+	outcome := reconcileResult(result, err)
+	span.SetAttribute("result", string(outcome))
+	span.End(err)
+	metricControllerReconcileDuration.WithLabelValues(r.controllerName.String(), string(outcome)).Observe(time.Since(start).Seconds())
+	metricControllerReconcileTotal.WithLabelValues(r.controllerName.String(), string(outcome)).Inc()
+	if err != nil {
+		metricControllerReconcileErrors.WithLabelValues(r.controllerName.String()).Inc()
+	}
+	return result, err
+}
+
+func reconcileResult(result reconcile.Result, err error) ReconcileResult {
+	switch {
+	case err != nil:
+		return ReconcileResultError
+	case result.RequeueAfter > 0:
+		return ReconcileResultRequeueAfter
+	case result.Requeue:
+		return ReconcileResultRequeue
+	default:
+		return ReconcileResultSuccess
+	}
+}
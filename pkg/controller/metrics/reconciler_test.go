@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+type fakeReconciler struct {
+	result reconcile.Result
+	err    error
+}
+
+func (r *fakeReconciler) Reconcile(reconcile.Request) (reconcile.Result, error) {
+	return r.result, r.err
+}
+
+func TestReconcilerWithMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		result         reconcile.Result
+		err            error
+		expectedResult ReconcileResult
+		expectedErrors float64
+	}{
+		{
+			name:           "success",
+			expectedResult: ReconcileResultSuccess,
+		},
+		{
+			name:           "error",
+			err:            errors.New("reconcile failed"),
+			expectedResult: ReconcileResultError,
+			expectedErrors: 1,
+		},
+		{
+			name:           "error with requeue",
+			result:         reconcile.Result{Requeue: true},
+			err:            errors.New("reconcile failed"),
+			expectedResult: ReconcileResultError,
+			expectedErrors: 1,
+		},
+		{
+			name:           "requeue",
+			result:         reconcile.Result{Requeue: true},
+			expectedResult: ReconcileResultRequeue,
+		},
+		{
+			name:           "requeue after",
+			result:         reconcile.Result{RequeueAfter: time.Minute},
+			expectedResult: ReconcileResultRequeueAfter,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			controllerName := hivev1.ControllerName("test-" + test.name)
+			r := NewReconcilerWithMetrics(&fakeReconciler{result: test.result, err: test.err}, controllerName)
+			result, err := r.Reconcile(reconcile.Request{})
+			assert.Equal(t, test.result, result, "unexpected reconcile result")
+			assert.Equal(t, test.err, err, "unexpected reconcile error")
+			for _, res := range []ReconcileResult{
+				ReconcileResultSuccess,
+				ReconcileResultError,
+				ReconcileResultRequeue,
+				ReconcileResultRequeueAfter,
+			} {
+				expected := 0.
+				if res == test.expectedResult {
+					expected = 1
+				}
+				assert.Equal(t, expected,
+					testutil.ToFloat64(metricControllerReconcileTotal.WithLabelValues(controllerName.String(), string(res))),
+					"unexpected reconcile count for result %s", res)
+			}
+			assert.Equal(t, test.expectedErrors,
+				testutil.ToFloat64(metricControllerReconcileErrors.WithLabelValues(controllerName.String())),
+				"unexpected reconcile error count")
+			duration := &dto.Metric{}
+			if assert.NoError(t, metricControllerReconcileDuration.WithLabelValues(controllerName.String(), string(test.expectedResult)).(prometheus.Histogram).Write(duration)) {
+				assert.Equal(t, uint64(1), duration.GetHistogram().GetSampleCount(), "expected the reconcile duration to be observed for the result")
+			}
+		})
+	}
+}
+
+func TestReconcilerWithMetricsDraining(t *testing.T) {
+	controllerName := hivev1.ControllerName("test-draining")
+	r := NewReconcilerWithMetrics(&fakeReconciler{result: reconcile.Result{Requeue: true}}, controllerName).(*reconcilerWithMetrics)
+	r.drainer = &controllerutils.ReconcileDrainer{}
+	assert.NoError(t, r.drainer.Drain(time.Second), "unexpected drain error")
+
+	result, err := r.Reconcile(reconcile.Request{})
+	assert.NoError(t, err, "unexpected reconcile error")
+	assert.Equal(t, reconcile.Result{}, result, "expected the request to be dropped")
+	assert.Equal(t, 0., testutil.ToFloat64(metricControllerReconcileTotal.WithLabelValues(controllerName.String(), string(ReconcileResultRequeue))),
+		"expected no reconcile to be counted")
+}
@@ -58,7 +58,7 @@ const (
 
 // kubeCLIApplier knows how to ApplyRuntimeObject.
 type kubeCLIApplier interface {
-	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+	ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error)
 }
 
 // Add creates a new RemoteMachineSet Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
@@ -93,7 +93,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("remoteingress-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -299,7 +299,7 @@ func (r *ReconcileRemoteClusterIngress) syncSyncSet(rContext *reconcileContext,
 		return err
 	}
 
-	if _, err := r.kubeCLI.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+	if _, err := r.kubeCLI.ApplyRuntimeObject(context.TODO(), syncSet, r.scheme); err != nil {
 		rContext.logger.WithError(err).Error("failed to apply syncset")
 		return err
 	}
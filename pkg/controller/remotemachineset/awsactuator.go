@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -25,6 +26,7 @@ import (
 	installertypesaws "github.com/openshift/installer/pkg/types/aws"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -57,12 +59,13 @@ func NewAWSActuator(
 	client client.Client,
 	awsCreds *corev1.Secret,
 	region string,
+	serviceEndpoints []hivev1aws.ServiceEndpoint,
 	pool *hivev1.MachinePool,
 	masterMachine *machineapi.Machine,
 	scheme *runtime.Scheme,
 	logger log.FieldLogger,
 ) (*AWSActuator, error) {
-	awsClient, err := awsclient.NewClientFromSecret(awsCreds, region)
+	awsClient, err := awsclient.NewClientFromSecret(awsCreds, region, serviceEndpoints)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create AWS client")
 		return nil, err
@@ -144,17 +147,6 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		Zones: pool.Spec.Platform.AWS.Zones,
 	}
 
-	if len(computePool.Platform.AWS.Zones) == 0 {
-		zones, err := a.fetchAvailabilityZones()
-		if err != nil {
-			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
-		}
-		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.AWS.Region)
-		}
-		computePool.Platform.AWS.Zones = zones
-	}
-
 	subnets := map[string]string{}
 	// Fetching private subnets from the machinepool and then mapping availability zones to subnets
 	if len(pool.Spec.Platform.AWS.Subnets) > 0 {
@@ -164,6 +156,39 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		}
 		subnets = subnetsByAvailabilityZone
 	}
+
+	switch {
+	case len(subnets) > 0:
+		// The zones of the pool are checked against the zones of the subnets when generating the machinesets. When no
+		// zones are specified, use the zones of the subnets so that the machines are only spread across the zones in
+		// which the pool has a subnet.
+		if len(computePool.Platform.AWS.Zones) == 0 {
+			computePool.Platform.AWS.Zones = sets.StringKeySet(subnets).List()
+		}
+	case len(computePool.Platform.AWS.Zones) > 0:
+		zones, err := a.fetchAvailabilityZones()
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to fetch list of zones to validate compute pool zones")
+		}
+		valid, err := validateZones(a.client, pool, computePool.Platform.AWS.Zones, zones)
+		if err != nil {
+			return nil, false, err
+		}
+		if !valid {
+			logger.WithField("zones", computePool.Platform.AWS.Zones).Warn("machine pool specifies zones that are not available")
+			return nil, false, nil
+		}
+	default:
+		zones, err := a.fetchAvailabilityZones()
+		if err != nil {
+			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
+		}
+		if len(zones) == 0 {
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.InstallRegion(cd))
+		}
+		computePool.Platform.AWS.Zones = zones
+	}
+
 	// userTags are settings available in the installconfig that we are choosing
 	// to ignore for the timebeing. These empty settings should be updated to feed
 	// from the machinepool / installconfig in the future.
@@ -171,7 +196,7 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 
 	installerMachineSets, err := installaws.MachineSets(
 		cd.Spec.ClusterMetadata.InfraID,
-		cd.Spec.Platform.AWS.Region,
+		controllerutils.InstallRegion(cd),
 		subnets,
 		computePool,
 		pool.Spec.Name,
@@ -238,7 +263,7 @@ func getAWSAMIID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logg
 	return amiID, nil
 }
 
-// fetchAvailabilityZones fetches availability zones for the AWS region
+// fetchAvailabilityZones fetches the sorted availability zones for the AWS region
 func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
 	zoneFilter := &ec2.Filter{
 		Name:   aws.String("region-name"),
@@ -255,6 +280,8 @@ func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
 	for _, zone := range resp.AvailabilityZones {
 		zones = append(zones, *zone.ZoneName)
 	}
+	// Sort the zones so that replicas are distributed across the zones in a deterministic order.
+	sort.Strings(zones)
 	return zones, nil
 }
 
@@ -83,12 +83,80 @@ func TestAWSActuator(t *testing.T) {
 					return pool
 				}(),
 			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3", "zone4"})
+			},
 			expectedMachineSetReplicas: map[string]int64{
 				generateAWSMachineSetName("zone1"): 1,
 				generateAWSMachineSetName("zone2"): 1,
 				generateAWSMachineSetName("zone3"): 1,
 			},
 		},
+		{
+			name:              "specified zones not available",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone4"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3"})
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidZonesMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZonesNotAvailable",
+			},
+		},
+		{
+			name:              "zones sorted when distributing replicas",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Replicas = pointer.Int64Ptr(4)
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone3", "zone1", "zone2"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone2"): 1,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+		},
+		{
+			name:              "generate machinesets for zones of specified subnets",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.Subnets = []string{"subnet-zone3", "subnet-zone1"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeSubnets(client, []string{"zone3", "zone1"},
+					[]string{"subnet-zone3", "subnet-zone1"}, []string{}, "vpc-1")
+				mockDescribeRouteTables(client, map[string]bool{
+					"subnet-zone3": false,
+					"subnet-zone1": false,
+				}, "vpc-1")
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
 		{
 			name:              "generate machinesets for specified zones and subnets",
 			clusterDeployment: testClusterDeployment(),
@@ -3,6 +3,7 @@ package remotemachineset
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +12,10 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	azureproviderv1beta1 "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	installazure "github.com/openshift/installer/pkg/asset/machines/azure"
 	installertypes "github.com/openshift/installer/pkg/types"
@@ -18,27 +23,51 @@ import (
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 // AzureActuator encapsulates the pieces necessary to be able to generate
 // a list of MachineSets to sync to the remote cluster.
 type AzureActuator struct {
-	client azureclient.Client
-	logger log.FieldLogger
+	client     azureclient.Client
+	kubeClient client.Client
+	logger     log.FieldLogger
+	// networkResourceGroup and virtualNetwork are those used by the master machines, in which machines using a
+	// subnet specified by the pool are created.
+	networkResourceGroup string
+	virtualNetwork       string
 }
 
 var _ Actuator = &AzureActuator{}
 
+func addAzureProviderToScheme(scheme *runtime.Scheme) error {
+	return azureproviderv1beta1.SchemeBuilder.AddToScheme(scheme)
+}
+
 // NewAzureActuator is the constructor for building a AzureActuator
-func NewAzureActuator(azureCreds *corev1.Secret, logger log.FieldLogger) (*AzureActuator, error) {
+func NewAzureActuator(
+	kubeClient client.Client,
+	azureCreds *corev1.Secret,
+	masterMachine *machineapi.Machine,
+	scheme *runtime.Scheme,
+	logger log.FieldLogger,
+) (*AzureActuator, error) {
 	azureClient, err := azureclient.NewClientFromSecret(azureCreds)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create Azure client with creds in clusterDeployment's secret")
 		return nil, err
 	}
+	providerSpec, err := decodeAzureMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value, scheme)
+	if err != nil {
+		logger.WithError(err).Warn("cannot decode AzureMachineProviderSpec from master machine")
+		return nil, errors.Wrap(err, "cannot decode AzureMachineProviderSpec from master machine")
+	}
 	actuator := &AzureActuator{
-		client: azureClient,
-		logger: logger,
+		client:               azureClient,
+		kubeClient:           kubeClient,
+		logger:               logger,
+		networkResourceGroup: providerSpec.NetworkResourceGroup,
+		virtualNetwork:       providerSpec.Vnet,
 	}
 	return actuator, nil
 }
@@ -59,11 +88,20 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			Azure: &installertypesazure.Platform{
-				Region: cd.Spec.Platform.Azure.Region,
+				Region: controllerutils.InstallRegion(cd),
 			},
 		},
 	}
 
+	if pool.Spec.Platform.Azure.Subnet != "" {
+		if a.virtualNetwork == "" {
+			return nil, false, errors.New("cannot use the subnet of the MachinePool since the virtual network of the master machines is unknown")
+		}
+		ic.Platform.Azure.NetworkResourceGroupName = a.networkResourceGroup
+		ic.Platform.Azure.VirtualNetwork = a.virtualNetwork
+		ic.Platform.Azure.ComputeSubnet = pool.Spec.Platform.Azure.Subnet
+	}
+
 	computePool := baseMachinePool(pool)
 	computePool.Platform.Azure = &installertypesazure.MachinePool{
 		Zones:        pool.Spec.Platform.Azure.Zones,
@@ -74,14 +112,27 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	}
 
 	if len(computePool.Platform.Azure.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.Azure.Region, pool.Spec.Platform.Azure.InstanceType)
+		zones, err := a.getZones(controllerutils.InstallRegion(cd), pool.Spec.Platform.Azure.InstanceType)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
 		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.Azure.Region)
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.InstallRegion(cd))
 		}
 		computePool.Platform.Azure.Zones = zones
+	} else {
+		zones, err := a.getZones(controllerutils.InstallRegion(cd), pool.Spec.Platform.Azure.InstanceType)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to fetch list of zones to validate compute pool zones")
+		}
+		valid, err := validateZones(a.kubeClient, pool, computePool.Platform.Azure.Zones, zones)
+		if err != nil {
+			return nil, false, err
+		}
+		if !valid {
+			logger.WithField("zones", computePool.Platform.Azure.Zones).Warn("machine pool specifies zones that are not available")
+			return nil, false, nil
+		}
 	}
 
 	// The imageID parameter is not used. The image is determined by the infraID.
@@ -109,7 +160,10 @@ func (a *AzureActuator) getZones(region string, instanceType string) ([]string,
 			if strings.EqualFold(to.String(resSku.Name), instanceType) {
 				for _, locationInfo := range *resSku.LocationInfo {
 					if strings.EqualFold(to.String(locationInfo.Location), region) {
-						return *locationInfo.Zones, nil
+						// Sort the zones so that replicas are distributed across the zones in a deterministic order.
+						zones := append([]string{}, *locationInfo.Zones...)
+						sort.Strings(zones)
+						return zones, nil
 					}
 				}
 			}
@@ -118,3 +172,20 @@ func (a *AzureActuator) getZones(region string, instanceType string) ([]string,
 
 	return nil, err
 }
+
+func decodeAzureMachineProviderSpec(rawExt *runtime.RawExtension, scheme *runtime.Scheme) (*azureproviderv1beta1.AzureMachineProviderSpec, error) {
+	codecFactory := serializer.NewCodecFactory(scheme)
+	decoder := codecFactory.UniversalDecoder(azureproviderv1beta1.SchemeGroupVersion)
+	if rawExt == nil {
+		return nil, fmt.Errorf("MachineSet has no ProviderSpec")
+	}
+	obj, gvk, err := decoder.Decode([]byte(rawExt.Raw), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode Azure ProviderSpec: %v", err)
+	}
+	spec, ok := obj.(*azureproviderv1beta1.AzureMachineProviderSpec)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected object: %#v", gvk)
+	}
+	return spec, nil
+}
@@ -9,14 +9,18 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
+	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 func TestAzureActuator(t *testing.T) {
@@ -25,8 +29,10 @@ func TestAzureActuator(t *testing.T) {
 		mockAzureClient            func(*gomock.Controller, *mockazure.MockClient)
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
+		virtualNetwork             string
 		expectedMachineSetReplicas map[string]int64
 		expectedErr                bool
+		expectedCondition          *hivev1.MachinePoolCondition
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -60,13 +66,76 @@ func TestAzureActuator(t *testing.T) {
 				pool.Spec.Platform.Azure.Zones = []string{"zone1", "zone2", "zone3"}
 				return pool
 			}(),
-			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1", "zone2", "zone3"})
+			},
 			expectedMachineSetReplicas: map[string]int64{
 				generateAzureMachineSetName("zone1"): 1,
 				generateAzureMachineSetName("zone2"): 1,
 				generateAzureMachineSetName("zone3"): 1,
 			},
 		},
+		{
+			name:              "specified zones not available",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1", "zone4"}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1", "zone2", "zone3"})
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidZonesMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZonesNotAvailable",
+			},
+		},
+		{
+			name:              "zones sorted when distributing replicas",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Replicas = pointer.Int64Ptr(4)
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone3", "zone1", "zone2"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 2,
+				generateAzureMachineSetName("zone2"): 1,
+				generateAzureMachineSetName("zone3"): 1,
+			},
+		},
+		{
+			name:              "generate machinesets for specified subnet",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Subnet = "test-subnet"
+				return p
+			}(),
+			virtualNetwork: "test-vnet",
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+		},
+		{
+			name:              "specified subnet with unknown virtual network",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Subnet = "test-subnet"
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedErr:     true,
+		},
 		{
 			name:              "more replicas than zones",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -111,6 +180,7 @@ func TestAzureActuator(t *testing.T) {
 	}
 
 	for _, test := range tests {
+		apis.AddToScheme(scheme.Scheme)
 		t.Run(test.name, func(t *testing.T) {
 
 			mockCtrl := gomock.NewController(t)
@@ -122,8 +192,11 @@ func TestAzureActuator(t *testing.T) {
 			test.mockAzureClient(mockCtrl, aClient)
 
 			actuator := &AzureActuator{
-				client: aClient,
-				logger: log.WithField("actuator", "azureactuator"),
+				client:               aClient,
+				kubeClient:           fake.NewFakeClient(test.pool),
+				logger:               log.WithField("actuator", "azureactuator"),
+				networkResourceGroup: "test-network-rg",
+				virtualNetwork:       test.virtualNetwork,
 			}
 
 			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
@@ -132,6 +205,21 @@ func TestAzureActuator(t *testing.T) {
 				assert.Error(t, err, "expected error for test case")
 			} else {
 				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				for _, ms := range generatedMachineSets {
+					azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+					if test.pool.Spec.Platform.Azure.Subnet != "" {
+						assert.Equal(t, "test-network-rg", azureProvider.NetworkResourceGroup, "unexpected network resource group")
+						assert.Equal(t, test.virtualNetwork, azureProvider.Vnet, "unexpected virtual network")
+						assert.Equal(t, test.pool.Spec.Platform.Azure.Subnet, azureProvider.Subnet, "unexpected subnet")
+					}
+				}
+			}
+			if test.expectedCondition != nil {
+				cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, test.expectedCondition.Type)
+				if assert.NotNil(t, cond, "missing expected condition") {
+					assert.Equal(t, test.expectedCondition.Status, cond.Status, "unexpected condition status")
+					assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+				}
 			}
 		})
 	}
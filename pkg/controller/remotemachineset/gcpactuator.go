@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -50,6 +51,9 @@ type GCPActuator struct {
 	scheme    *runtime.Scheme
 	projectID string
 	imageID   string
+	// network is the network used by the master machines, in which machines using a subnet specified by the pool
+	// are created.
+	network string
 	// expectations is a reference to the reconciler's TTLCache of machinepoolnamelease creates each machinepool
 	// expects to see.
 	expectations   controllerutils.ExpectationsInterface
@@ -91,6 +95,12 @@ func NewGCPActuator(
 		return nil, err
 	}
 
+	network, err := getGCPNetwork(masterMachine, scheme, logger)
+	if err != nil {
+		logger.WithError(err).Error("error getting network from master machine")
+		return nil, err
+	}
+
 	actuator := &GCPActuator{
 		gcpClient:      gcpClient,
 		client:         client,
@@ -99,6 +109,7 @@ func NewGCPActuator(
 		expectations:   expectations,
 		projectID:      projectID,
 		imageID:        imageID,
+		network:        network,
 		leasesRequired: requireLeases(clusterVersion, remoteMachineSets, logger),
 	}
 	return actuator, nil
@@ -160,12 +171,20 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			GCP: &installertypesgcp.Platform{
-				Region:    cd.Spec.Platform.GCP.Region,
+				Region:    controllerutils.InstallRegion(cd),
 				ProjectID: a.projectID,
 			},
 		},
 	}
 
+	if pool.Spec.Platform.GCP.Subnet != "" {
+		if a.network == "" {
+			return nil, false, errors.New("cannot use the subnet of the MachinePool since the network of the master machines is unknown")
+		}
+		ic.Platform.GCP.Network = a.network
+		ic.Platform.GCP.ComputeSubnet = pool.Spec.Platform.GCP.Subnet
+	}
+
 	computePool := baseMachinePool(pool)
 	computePool.Name = poolName
 	computePool.Platform.GCP = &installertypesgcp.MachinePool{
@@ -178,14 +197,27 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	}
 
 	if len(computePool.Platform.GCP.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.GCP.Region)
+		zones, err := a.getZones(controllerutils.InstallRegion(cd))
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
 		if len(zones) == 0 {
-			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.GCP.Region)
+			return nil, false, fmt.Errorf("zero zones returned for region %s", controllerutils.InstallRegion(cd))
 		}
 		computePool.Platform.GCP.Zones = zones
+	} else {
+		zones, err := a.getZones(controllerutils.InstallRegion(cd))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to fetch list of zones to validate compute pool zones")
+		}
+		valid, err := validateZones(a.client, pool, computePool.Platform.GCP.Zones, zones)
+		if err != nil {
+			return nil, false, err
+		}
+		if !valid {
+			logger.WithField("zones", computePool.Platform.GCP.Zones).Warn("machine pool specifies zones that are not available")
+			return nil, false, nil
+		}
 	}
 
 	// Assuming all machine pools are workers at this time.
@@ -227,6 +259,8 @@ func (a *GCPActuator) getZones(region string) ([]string, error) {
 		pageToken = zoneList.NextPageToken
 	}
 
+	// Sort the zones so that replicas are distributed across the zones in a deterministic order.
+	sort.Strings(zones)
 	return zones, nil
 }
 
@@ -424,6 +458,22 @@ func getGCPImageID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, lo
 	return imageID, nil
 }
 
+// Get the network from an existing master machine.
+func getGCPNetwork(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (string, error) {
+	providerSpec, err := decodeGCPMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value, scheme)
+	if err != nil {
+		logger.WithError(err).Warn("cannot decode GCPMachineProviderSpec from master machine")
+		return "", errors.Wrap(err, "cannot decode GCPMachineProviderSpec from master machine")
+	}
+	if len(providerSpec.NetworkInterfaces) == 0 {
+		logger.Debug("master machine does not have any network interfaces")
+		return "", nil
+	}
+	network := providerSpec.NetworkInterfaces[0].Network
+	logger.WithField("network", network).Debug("resolved network to use for new machinesets using a pool subnet")
+	return network, nil
+}
+
 func decodeGCPMachineProviderSpec(rawExt *runtime.RawExtension, scheme *runtime.Scheme) (*gcpproviderv1beta1.GCPMachineProviderSpec, error) {
 	codecFactory := serializer.NewCodecFactory(scheme)
 	decoder := codecFactory.UniversalDecoder(gcpproviderv1beta1.SchemeGroupVersion)
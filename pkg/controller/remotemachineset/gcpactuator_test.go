@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	gcpprovider "github.com/openshift/cluster-api-provider-gcp/pkg/apis/gcpprovider/v1beta1"
@@ -42,9 +43,11 @@ func TestGCPActuator(t *testing.T) {
 		existing                        []runtime.Object
 		mockGCPClient                   func(*mockgcp.MockClient)
 		setupPendingCreationExpectation bool
+		network                         string
 
 		expectedMachineSetReplicas map[string]int64
 		expectedErr                bool
+		expectedCondition          *hivev1.MachinePoolCondition
 	}{
 		{
 			name: "generate single machineset for single zone",
@@ -75,12 +78,71 @@ func TestGCPActuator(t *testing.T) {
 				pool.Spec.Platform.GCP.Zones = []string{"zone1", "zone2", "zone3"}
 				return pool
 			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1", "zone2", "zone3", "zone4"}, testRegion)
+			},
 			expectedMachineSetReplicas: map[string]int64{
 				generateGCPMachineSetName("worker", "zone1"): 1,
 				generateGCPMachineSetName("worker", "zone2"): 1,
 				generateGCPMachineSetName("worker", "zone3"): 1,
 			},
 		},
+		{
+			name: "specified zones not available",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Zones = []string{"zone1", "zone4"}
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1", "zone2", "zone3"}, testRegion)
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidZonesMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "ZonesNotAvailable",
+			},
+		},
+		{
+			name: "zones sorted when distributing replicas",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Replicas = pointer.Int64Ptr(4)
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone3", "zone1", "zone2"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 2,
+				generateGCPMachineSetName("worker", "zone2"): 1,
+				generateGCPMachineSetName("worker", "zone3"): 1,
+			},
+		},
+		{
+			name: "generate machinesets for specified subnet",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Subnet = "test-subnet"
+				return pool
+			}(),
+			network: "test-network",
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
+		{
+			name: "specified subnet with unknown network",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Subnet = "test-subnet"
+				return pool
+			}(),
+			expectedErr: true,
+		},
 		{
 			name: "list zones returns zero",
 			pool: testGCPPool(testPoolName),
@@ -112,6 +174,9 @@ func TestGCPActuator(t *testing.T) {
 			existing: []runtime.Object{
 				testPoolLease("additional-compute", testName, testInfraID, "r"),
 			},
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1", "zone2", "zone3"}, testRegion)
+			},
 			expectedMachineSetReplicas: map[string]int64{
 				generateGCPMachineSetName("r", "zone1"): 1,
 				generateGCPMachineSetName("r", "zone2"): 1,
@@ -145,7 +210,7 @@ func TestGCPActuator(t *testing.T) {
 				}.String(), 1)
 			}
 
-			test.existing = append(test.existing, clusterDeployment)
+			test.existing = append(test.existing, clusterDeployment, test.pool)
 			fakeClient := fake.NewFakeClient(test.existing...)
 
 			// set up mock expectations
@@ -160,6 +225,7 @@ func TestGCPActuator(t *testing.T) {
 				scheme:         scheme.Scheme,
 				expectations:   controllerExpectations,
 				projectID:      testProjectID,
+				network:        test.network,
 				leasesRequired: test.requireLeases,
 			}
 
@@ -169,6 +235,20 @@ func TestGCPActuator(t *testing.T) {
 				assert.Error(t, err, "expected error for test case")
 			} else {
 				validateGCPMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				for _, ms := range generatedMachineSets {
+					gcpProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpprovider.GCPMachineProviderSpec)
+					if test.pool.Spec.Platform.GCP.Subnet != "" {
+						assert.Equal(t, test.network, gcpProvider.NetworkInterfaces[0].Network, "unexpected network")
+						assert.Equal(t, test.pool.Spec.Platform.GCP.Subnet, gcpProvider.NetworkInterfaces[0].Subnetwork, "unexpected subnetwork")
+					}
+				}
+			}
+			if test.expectedCondition != nil {
+				cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, test.expectedCondition.Type)
+				if assert.NotNil(t, cond, "missing expected condition") {
+					assert.Equal(t, test.expectedCondition.Status, cond.Status, "unexpected condition status")
+					assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+				}
 			}
 		})
 	}
@@ -14,6 +14,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -31,6 +33,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -39,11 +42,40 @@ const (
 	machinePoolNameLabel       = "hive.openshift.io/machine-pool"
 	finalizer                  = "hive.openshift.io/remotemachineset"
 	masterMachineLabelSelector = "machine.openshift.io/cluster-api-machine-type=master"
+
+	// clusterAutoscalerFieldsAnnotation is set on the ClusterAutoscaler of the remote cluster to the comma-separated
+	// list of the fields that were last set from the ClusterAutoscaler config of the ClusterDeployment, so that the
+	// fields removed from the config are reverted in the remote cluster.
+	clusterAutoscalerFieldsAnnotation = "hive.openshift.io/cluster-autoscaler-fields"
 )
 
 // controllerKind contains the schema.GroupVersionKind for this controller type.
 var controllerKind = hivev1.SchemeGroupVersion.WithKind("MachinePool")
 
+// remoteWatchedResources are the objects generated for the MachinePools which are watched in the remote clusters when
+// the controller watches remote clusters.
+var remoteWatchedResources = []remoteclient.WatchedResource{
+	{
+		GroupVersionKind: machineapi.SchemeGroupVersion.WithKind("MachineSet"),
+		LabelSelector:    machinePoolNameLabel,
+	},
+	{
+		GroupVersionKind: autoscalingv1beta1.SchemeGroupVersion.WithKind("MachineAutoscaler"),
+		LabelSelector:    machinePoolNameLabel,
+	},
+}
+
+// readyBlockingConditions are the conditions which, when true, prevent the MachineSets of a MachinePool from being
+// synced to the cluster.
+var readyBlockingConditions = []hivev1.MachinePoolConditionType{
+	hivev1.InvalidArchitectureMachinePoolCondition,
+	hivev1.UnsupportedConfigurationMachinePoolCondition,
+	hivev1.InvalidSubnetsMachinePoolCondition,
+	hivev1.InvalidZonesMachinePoolCondition,
+	hivev1.NoMachinePoolNameLeasesAvailable,
+	hivev1.NotEnoughReplicasMachinePoolCondition,
+}
+
 // Add creates a new RemoteMachineSet Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -56,6 +88,9 @@ func Add(mgr manager.Manager) error {
 	if err := addGCPProviderToScheme(scheme); err != nil {
 		return errors.Wrap(err, "cannot add GCP provider to scheme")
 	}
+	if err := addAzureProviderToScheme(scheme); err != nil {
+		return errors.Wrap(err, "cannot add Azure provider to scheme")
+	}
 	if err := addOpenStackProviderToScheme(scheme); err != nil {
 		return errors.Wrap(err, "cannot add OpenStack provider to scheme")
 	}
@@ -70,6 +105,11 @@ func Add(mgr manager.Manager) error {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
+	watchRemoteCluster, err := controllerutils.GetWatchRemoteCluster(ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get whether to watch remote clusters")
+		return err
+	}
 
 	r := &ReconcileRemoteMachineSet{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
@@ -86,7 +126,7 @@ func Add(mgr manager.Manager) error {
 
 	// Create a new controller
 	c, err := controller.New("remotemachineset-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
@@ -113,6 +153,20 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	// Watch for changes to the MachineSets and MachineAutoscalers in the remote clusters
+	if watchRemoteCluster {
+		r.remoteWatcher = remoteclient.NewWatcher(ControllerName)
+		if err := mgr.Add(r.remoteWatcher); err != nil {
+			return err
+		}
+		err = c.Watch(r.remoteWatcher.Source(), &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.clusterDeploymentWatchHandler),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -158,6 +212,10 @@ type ReconcileRemoteMachineSet struct {
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
+	// remoteWatcher watches the MachineSets and MachineAutoscalers in the remote clusters. It is nil when remote
+	// clusters are not watched.
+	remoteWatcher *remoteclient.Watcher
+
 	// actuatorBuilder is a function pointer to the function that builds the actuator
 	actuatorBuilder func(
 		cd *hivev1.ClusterDeployment,
@@ -213,6 +271,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 	); {
 	case apierrors.IsNotFound(err):
 		logger.Debug("clusterdeployment does not exist")
+		r.stopWatchingRemoteCluster(client.ObjectKey{Namespace: pool.Namespace, Name: pool.Spec.ClusterDeploymentRef.Name})
 		return r.removeFinalizer(pool, logger)
 	case err != nil:
 		logger.WithError(err).Error("error looking up cluster deploymnet")
@@ -225,6 +284,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
+		r.stopWatchingRemoteCluster(client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name})
 		return r.removeFinalizer(pool, logger)
 	}
 
@@ -253,6 +313,15 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, nil
 	}
 
+	switch valid, err := validateArchitecture(r.Client, pool, cd); {
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not validate architecture")
+		return reconcile.Result{}, err
+	case !valid:
+		logger.WithField("architecture", pool.Spec.Architecture).Info("machine pool architecture cannot be used in the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(pool, logger)
+	}
+
 	remoteClusterAPIClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
 		cd,
 		r.remoteClusterAPIClientBuilder(cd),
@@ -260,9 +329,17 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		logger,
 	)
 	if unreachable {
+		r.stopWatchingRemoteCluster(client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name})
 		return reconcile.Result{Requeue: requeue}, nil
 	}
 
+	if r.remoteWatcher != nil {
+		// A failure to watch the remote cluster only delays the reconcile of changes made in the remote cluster.
+		if err := r.remoteWatcher.Watch(cd, r.remoteClusterAPIClientBuilder(cd), remoteWatchedResources); err != nil {
+			logger.WithError(err).Warn("could not watch the machinesets of the remote cluster")
+		}
+	}
+
 	logger.Info("reconciling machine pool for cluster deployment")
 
 	masterMachine, err := r.getMasterMachine(cd, remoteClusterAPIClient, logger)
@@ -282,7 +359,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	} else if !proceed {
 		logger.Info("machineSets generator indicated not to proceed, returning")
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.setNotReadyCondition(pool, logger)
 	}
 
 	switch result, err := r.ensureEnoughReplicas(pool, generatedMachineSets, logger); {
@@ -290,7 +367,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not ensureEnoughReplicas")
 		return reconcile.Result{}, err
 	case result != nil:
-		return *result, nil
+		return *result, r.setNotReadyCondition(pool, logger)
 	}
 
 	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, logger)
@@ -770,12 +847,11 @@ func (r *ReconcileRemoteMachineSet) syncClusterAutoscaler(
 		}
 	}
 	if defaultClusterAutoscaler != nil {
-		if spec := &defaultClusterAutoscaler.Spec; spec.ScaleDown == nil || !spec.ScaleDown.Enabled {
-			logger.Info("updaing cluster autoscaler")
-			if spec.ScaleDown == nil {
-				spec.ScaleDown = &autoscalingv1.ScaleDownConfig{}
-			}
-			spec.ScaleDown.Enabled = true
+		orig := defaultClusterAutoscaler.DeepCopy()
+		applyClusterAutoscalerConfig(defaultClusterAutoscaler, cd.Spec.ClusterAutoscaler)
+		if !reflect.DeepEqual(orig.Spec, defaultClusterAutoscaler.Spec) ||
+			!reflect.DeepEqual(orig.Annotations, defaultClusterAutoscaler.Annotations) {
+			logger.Info("updating cluster autoscaler")
 			if err := remoteClusterAPIClient.Update(context.Background(), defaultClusterAutoscaler); err != nil {
 				logger.WithError(err).Error("could not update cluster autoscaler")
 				return err
@@ -787,12 +863,8 @@ func (r *ReconcileRemoteMachineSet) syncClusterAutoscaler(
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "default",
 			},
-			Spec: autoscalingv1.ClusterAutoscalerSpec{
-				ScaleDown: &autoscalingv1.ScaleDownConfig{
-					Enabled: true,
-				},
-			},
 		}
+		applyClusterAutoscalerConfig(defaultClusterAutoscaler, cd.Spec.ClusterAutoscaler)
 		if err := remoteClusterAPIClient.Create(context.Background(), defaultClusterAutoscaler); err != nil {
 			logger.WithError(err).Error("could not create cluster autoscaler")
 			return err
@@ -801,6 +873,92 @@ func (r *ReconcileRemoteMachineSet) syncClusterAutoscaler(
 	return nil
 }
 
+// applyClusterAutoscalerConfig sets the fields of the ClusterAutoscaler spec that are specified in the config from the
+// ClusterDeployment. Scaling down is enabled unless it is explicitly disabled in the config. The fields which were set
+// from the config by a previous sync but have since been removed from the config are reverted to their defaults,
+// while the fields which were never set from the config are left as they are in the remote cluster.
+func applyClusterAutoscalerConfig(ca *autoscalingv1.ClusterAutoscaler, config *hivev1.ClusterAutoscalerConfig) {
+	spec := &ca.Spec
+	if spec.ScaleDown == nil {
+		spec.ScaleDown = &autoscalingv1.ScaleDownConfig{}
+	}
+	if config == nil {
+		config = &hivev1.ClusterAutoscalerConfig{}
+	}
+	scaleDown := config.ScaleDown
+	if scaleDown == nil {
+		scaleDown = &hivev1.ClusterAutoscalerScaleDownConfig{}
+	}
+	previousFields := sets.NewString()
+	if value := ca.Annotations[clusterAutoscalerFieldsAnnotation]; value != "" {
+		previousFields.Insert(strings.Split(value, ",")...)
+	}
+	fields := sets.NewString()
+	// apply sets the field when it is specified in the config, and reverts it when it was set from the config by a
+	// previous sync.
+	apply := func(field string, specified bool, set, revert func()) {
+		switch {
+		case specified:
+			fields.Insert(field)
+			set()
+		case previousFields.Has(field):
+			revert()
+		}
+	}
+
+	apply("maxNodesTotal", config.MaxNodesTotal != nil,
+		func() {
+			if spec.ResourceLimits == nil {
+				spec.ResourceLimits = &autoscalingv1.ResourceLimits{}
+			}
+			spec.ResourceLimits.MaxNodesTotal = pointer.Int32Ptr(*config.MaxNodesTotal)
+		},
+		func() {
+			if spec.ResourceLimits != nil {
+				spec.ResourceLimits.MaxNodesTotal = nil
+				if reflect.DeepEqual(spec.ResourceLimits, &autoscalingv1.ResourceLimits{}) {
+					spec.ResourceLimits = nil
+				}
+			}
+		},
+	)
+	apply("maxPodGracePeriod", config.MaxPodGracePeriod != nil,
+		func() { spec.MaxPodGracePeriod = pointer.Int32Ptr(*config.MaxPodGracePeriod) },
+		func() { spec.MaxPodGracePeriod = nil },
+	)
+	apply("podPriorityThreshold", config.PodPriorityThreshold != nil,
+		func() { spec.PodPriorityThreshold = pointer.Int32Ptr(*config.PodPriorityThreshold) },
+		func() { spec.PodPriorityThreshold = nil },
+	)
+	apply("scaleDown.delayAfterAdd", scaleDown.DelayAfterAdd != nil,
+		func() { spec.ScaleDown.DelayAfterAdd = pointer.StringPtr(*scaleDown.DelayAfterAdd) },
+		func() { spec.ScaleDown.DelayAfterAdd = nil },
+	)
+	apply("scaleDown.delayAfterDelete", scaleDown.DelayAfterDelete != nil,
+		func() { spec.ScaleDown.DelayAfterDelete = pointer.StringPtr(*scaleDown.DelayAfterDelete) },
+		func() { spec.ScaleDown.DelayAfterDelete = nil },
+	)
+	apply("scaleDown.delayAfterFailure", scaleDown.DelayAfterFailure != nil,
+		func() { spec.ScaleDown.DelayAfterFailure = pointer.StringPtr(*scaleDown.DelayAfterFailure) },
+		func() { spec.ScaleDown.DelayAfterFailure = nil },
+	)
+	apply("scaleDown.unneededTime", scaleDown.UnneededTime != nil,
+		func() { spec.ScaleDown.UnneededTime = pointer.StringPtr(*scaleDown.UnneededTime) },
+		func() { spec.ScaleDown.UnneededTime = nil },
+	)
+	// Scaling down is always managed by hive, so it is enabled again when it is no longer disabled in the config.
+	spec.ScaleDown.Enabled = scaleDown.Enabled == nil || *scaleDown.Enabled
+
+	if fields.Len() > 0 {
+		if ca.Annotations == nil {
+			ca.Annotations = map[string]string{}
+		}
+		ca.Annotations[clusterAutoscalerFieldsAnnotation] = strings.Join(fields.List(), ",")
+	} else {
+		delete(ca.Annotations, clusterAutoscalerFieldsAnnotation)
+	}
+}
+
 func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 	pool *hivev1.MachinePool,
 	machineSets []*machineapi.MachineSet,
@@ -826,8 +984,19 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 		}
 		pool.Status.Replicas += *ms.Spec.Replicas
 	}
+	pool.Status.ObservedGeneration = pool.Generation
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.ReadyMachinePoolCondition,
+		corev1.ConditionTrue,
+		"MachineSetsSynced",
+		"The MachineSets of the MachinePool have been synced to the cluster",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
 
-	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0) ||
+	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0 &&
+		origPool.Status.ObservedGeneration == pool.Status.ObservedGeneration &&
+		reflect.DeepEqual(origPool.Status.Conditions, pool.Status.Conditions)) ||
 		reflect.DeepEqual(origPool.Status, pool.Status) {
 		return nil
 	}
@@ -835,6 +1004,97 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 	return errors.Wrap(r.Status().Update(context.Background(), pool), "failed to update pool status")
 }
 
+// setNotReadyCondition sets the Ready condition to false when one of the readyBlockingConditions prevents the
+// MachineSets of the pool from being synced, using the reason and message of the blocking condition.
+func (r *ReconcileRemoteMachineSet) setNotReadyCondition(pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	for _, conditionType := range readyBlockingConditions {
+		cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, conditionType)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		var changed bool
+		if controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.ReadyMachinePoolCondition) == nil {
+			now := metav1.Now()
+			pool.Status.Conditions = append(pool.Status.Conditions, hivev1.MachinePoolCondition{
+				Type:               hivev1.ReadyMachinePoolCondition,
+				Status:             corev1.ConditionFalse,
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+			})
+			changed = true
+		} else {
+			pool.Status.Conditions, changed = controllerutils.SetMachinePoolConditionWithChangeCheck(
+				pool.Status.Conditions,
+				hivev1.ReadyMachinePoolCondition,
+				corev1.ConditionFalse,
+				cond.Reason,
+				cond.Message,
+				controllerutils.UpdateConditionIfReasonOrMessageChange,
+			)
+		}
+		if !changed {
+			return nil
+		}
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update MachinePool Ready condition")
+			return err
+		}
+		return nil
+	}
+	return nil
+}
+
+// actuatorFactory creates the machine set actuator of the clusters of a platform. The secret holding the cloud
+// credentials of the cluster is only looked up for the platforms whose actuators need it.
+type actuatorFactory struct {
+	needsCredentials bool
+	new              func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error)
+}
+
+// actuatorFactories are the factories of the machine set actuators, keyed by the name of the platform of the clusters,
+// as returned by the platform actuators.
+var actuatorFactories = map[string]actuatorFactory{
+	constants.PlatformAWS: {
+		needsCredentials: true,
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			return NewAWSActuator(r.Client, creds, controllerutils.InstallRegion(cd), cd.Spec.Platform.AWS.ServiceEndpoints, pool, masterMachine, r.scheme, logger)
+		},
+	},
+	constants.PlatformGCP: {
+		needsCredentials: true,
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			clusterVersion, err := getClusterVersion(cd)
+			if err != nil {
+				return nil, err
+			}
+			return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
+		},
+	},
+	constants.PlatformAzure: {
+		needsCredentials: true,
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			return NewAzureActuator(r.Client, creds, masterMachine, r.scheme, logger)
+		},
+	},
+	constants.PlatformOpenStack: {
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
+		},
+	},
+	constants.PlatformVSphere: {
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			return NewVSphereActuator(masterMachine, r.scheme, logger)
+		},
+	},
+	constants.PlatformOvirt: {
+		new: func(r *ReconcileRemoteMachineSet, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, creds *corev1.Secret, logger log.FieldLogger) (Actuator, error) {
+			return NewOvirtActuator(masterMachine, r.scheme, logger)
+		},
+	},
+}
+
 func (r *ReconcileRemoteMachineSet) createActuator(
 	cd *hivev1.ClusterDeployment,
 	pool *hivev1.MachinePool,
@@ -842,66 +1102,119 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 	remoteMachineSets []machineapi.MachineSet,
 	logger log.FieldLogger,
 ) (Actuator, error) {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		creds := &corev1.Secret{}
-		if err := r.Get(
-			context.TODO(),
-			types.NamespacedName{
-				Name:      cd.Spec.Platform.AWS.CredentialsSecretRef.Name,
-				Namespace: cd.Namespace,
-			},
-			creds,
-		); err != nil {
-			return nil, err
-		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
-	case cd.Spec.Platform.GCP != nil:
-		creds := &corev1.Secret{}
-		if err := r.Get(
-			context.TODO(),
-			types.NamespacedName{
-				Name:      cd.Spec.Platform.GCP.CredentialsSecretRef.Name,
-				Namespace: cd.Namespace,
-			},
-			creds,
-		); err != nil {
-			return nil, err
-		}
-		clusterVersion, err := getClusterVersion(cd)
-		if err != nil {
-			return nil, err
-		}
-		return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
-	case cd.Spec.Platform.Azure != nil:
-		creds := &corev1.Secret{}
+	factory, ok := actuatorFactories[platform.Name(cd)]
+	if !ok {
+		return nil, errors.New("unsupported platform")
+	}
+	var creds *corev1.Secret
+	if factory.needsCredentials {
+		creds = &corev1.Secret{}
 		if err := r.Get(
 			context.TODO(),
 			types.NamespacedName{
-				Name:      cd.Spec.Platform.Azure.CredentialsSecretRef.Name,
+				Name:      platform.CredentialsSecretName(cd),
 				Namespace: cd.Namespace,
 			},
 			creds,
 		); err != nil {
 			return nil, err
 		}
-		return NewAzureActuator(creds, logger)
-	case cd.Spec.Platform.OpenStack != nil:
-		return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
-	case cd.Spec.Platform.VSphere != nil:
-		return NewVSphereActuator(masterMachine, r.scheme, logger)
-	case cd.Spec.Platform.Ovirt != nil:
-		return NewOvirtActuator(masterMachine, r.scheme, logger)
-	default:
-		return nil, errors.New("unsupported platform")
 	}
+	return factory.new(r, cd, pool, masterMachine, remoteMachineSets, creds, logger)
 }
 
 func baseMachinePool(pool *hivev1.MachinePool) *installertypes.MachinePool {
 	return &installertypes.MachinePool{
-		Name:     pool.Spec.Name,
-		Replicas: pool.Spec.Replicas,
+		Name:         pool.Spec.Name,
+		Replicas:     pool.Spec.Replicas,
+		Architecture: installertypes.Architecture(pool.Spec.Architecture),
+	}
+}
+
+// validateArchitecture sets the InvalidArchitecture condition on the pool based on whether its machines can use its
+// architecture. Machines can only use another architecture than the control plane when the cluster was installed from
+// a multi-architecture release image. They cannot boot from the image of the control plane machines then, so the
+// image must be set with an annotation, which is only supported on AWS. Returns true when the architecture is valid.
+func validateArchitecture(c client.Client, pool *hivev1.MachinePool, cd *hivev1.ClusterDeployment) (bool, error) {
+	clusterArch := cd.Status.Architecture
+	if clusterArch == "" {
+		clusterArch = hivev1.ArchitectureAMD64
+	}
+	var reason, message string
+	switch arch := pool.Spec.Architecture; {
+	case arch == "" || arch == clusterArch:
+	case !cd.Status.MultiArchitecture:
+		reason = "ArchitectureNotInRelease"
+		message = fmt.Sprintf("the cluster was not installed from a multi-architecture release image, machines must use the %s architecture of the control plane", clusterArch)
+	case pool.Spec.Platform.AWS == nil:
+		reason = "UnsupportedPlatform"
+		message = fmt.Sprintf("machines can only use another architecture than the %s architecture of the control plane on AWS", clusterArch)
+	case pool.Annotations[hivev1.MachinePoolImageIDOverrideAnnotation] == "":
+		reason = "ImageIDRequired"
+		message = fmt.Sprintf("machines using another architecture than the %s architecture of the control plane require the %s annotation", clusterArch, hivev1.MachinePoolImageIDOverrideAnnotation)
+	}
+	var conds []hivev1.MachinePoolCondition
+	var changed bool
+	if reason != "" {
+		conds, changed = controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.InvalidArchitectureMachinePoolCondition,
+			corev1.ConditionTrue,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else {
+		conds, changed = controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.InvalidArchitectureMachinePoolCondition,
+			corev1.ConditionFalse,
+			"ValidArchitecture",
+			"Architecture is valid",
+			controllerutils.UpdateConditionNever,
+		)
 	}
+	if changed {
+		pool.Status.Conditions = conds
+		if err := c.Status().Update(context.Background(), pool); err != nil {
+			return false, errors.Wrap(err, "could not update MachinePool status")
+		}
+	}
+	return reason == "", nil
+}
+
+// validateZones sets the InvalidZones condition on the pool based on whether all of the zones requested by the pool are
+// in the list of zones available in the region of the cluster. Returns true when all of the requested zones are available.
+func validateZones(c client.Client, pool *hivev1.MachinePool, requestedZones, availableZones []string) (bool, error) {
+	unavailableZones := sets.NewString(requestedZones...).Difference(sets.NewString(availableZones...))
+	var conds []hivev1.MachinePoolCondition
+	var changed bool
+	if len(unavailableZones) > 0 {
+		conds, changed = controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.InvalidZonesMachinePoolCondition,
+			corev1.ConditionTrue,
+			"ZonesNotAvailable",
+			fmt.Sprintf("zones not available in the region of the cluster: %s", strings.Join(unavailableZones.List(), ", ")),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else {
+		conds, changed = controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.InvalidZonesMachinePoolCondition,
+			corev1.ConditionFalse,
+			"ValidZones",
+			"Zones are valid",
+			controllerutils.UpdateConditionNever,
+		)
+	}
+	if changed {
+		pool.Status.Conditions = conds
+		if err := c.Status().Update(context.Background(), pool); err != nil {
+			return false, errors.Wrap(err, "could not update MachinePool status")
+		}
+	}
+	return len(unavailableZones) == 0, nil
 }
 
 func isControlledByMachinePool(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, obj metav1.Object) bool {
@@ -910,16 +1223,15 @@ func isControlledByMachinePool(cd *hivev1.ClusterDeployment, pool *hivev1.Machin
 		obj.GetLabels()[machinePoolNameLabel] == pool.Spec.Name
 }
 
-func (r *ReconcileRemoteMachineSet) removeFinalizer(pool *hivev1.MachinePool, logger log.FieldLogger) (reconcile.Result, error) {
-	if !controllerutils.HasFinalizer(pool, finalizer) {
-		return reconcile.Result{}, nil
-	}
-	controllerutils.DeleteFinalizer(pool, finalizer)
-	err := r.Update(context.Background(), pool)
-	if err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer")
+// stopWatchingRemoteCluster stops watching the remote cluster of the ClusterDeployment, if remote clusters are watched.
+func (r *ReconcileRemoteMachineSet) stopWatchingRemoteCluster(key client.ObjectKey) {
+	if r.remoteWatcher != nil {
+		r.remoteWatcher.StopWatching(key)
 	}
-	return reconcile.Result{}, err
+}
+
+func (r *ReconcileRemoteMachineSet) removeFinalizer(pool *hivev1.MachinePool, logger log.FieldLogger) (reconcile.Result, error) {
+	return reconcile.Result{}, controllerutils.RemoveFinalizer(r, pool, finalizer, logger)
 }
 
 func getMinMaxReplicasForMachineSet(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, machineSetIndex int) (min, max int32) {
@@ -116,6 +116,8 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		expectedRemoteMachineSets        []*machineapi.MachineSet
 		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
 		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
+		expectedObservedGeneration       int64
+		expectedReadyCondition           *hivev1.MachinePoolCondition
 	}{
 		{
 			name: "Cluster not installed yet",
@@ -147,6 +149,54 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
 			},
 		},
+		{
+			name:              "Record observed generation",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Generation = 2
+				pool.Status.ObservedGeneration = 1
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			expectedObservedGeneration: 2,
+			expectedReadyCondition: &hivev1.MachinePoolCondition{
+				Status: corev1.ConditionTrue,
+				Reason: "MachineSetsSynced",
+			},
+		},
+		{
+			name:              "Not ready when min replicas too small",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testAutoscalingMachinePool(2, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedReadyCondition: &hivev1.MachinePoolCondition{
+				Status: corev1.ConditionFalse,
+				Reason: "MinReplicasTooSmall",
+			},
+		},
 		{
 			name:                 "No-op when actuator says not to proceed",
 			clusterDeployment:    testClusterDeployment(),
@@ -495,6 +545,223 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("2"),
 			},
 		},
+		{
+			name: "Create cluster autoscaler with config",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerConfig{
+					MaxNodesTotal: pointer.Int32Ptr(20),
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDownConfig{
+						UnneededTime: pointer.StringPtr("5m"),
+					},
+				}
+				return cd
+			}(),
+			machinePool: testAutoscalingMachinePool(3, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+				testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				*testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				func() autoscalingv1.ClusterAutoscaler {
+					a := testClusterAutoscaler("1")
+					a.Annotations = map[string]string{clusterAutoscalerFieldsAnnotation: "maxNodesTotal,scaleDown.unneededTime"}
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{MaxNodesTotal: pointer.Int32Ptr(20)}
+					a.Spec.ScaleDown.UnneededTime = pointer.StringPtr("5m")
+					return *a
+				}(),
+			},
+		},
+		{
+			name: "Update cluster autoscaler from config",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerConfig{
+					MaxNodesTotal:        pointer.Int32Ptr(20),
+					PodPriorityThreshold: pointer.Int32Ptr(-10),
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDownConfig{
+						Enabled:       pointer.BoolPtr(false),
+						DelayAfterAdd: pointer.StringPtr("10m"),
+					},
+				}
+				return cd
+			}(),
+			machinePool: testAutoscalingMachinePool(3, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+				func() runtime.Object {
+					a := testClusterAutoscaler("1")
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{
+						MaxNodesTotal: pointer.Int32Ptr(10),
+						Cores:         &autoscalingv1.ResourceRange{Min: 8, Max: 128},
+					}
+					return a
+				}(),
+				testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				*testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				func() autoscalingv1.ClusterAutoscaler {
+					a := testClusterAutoscaler("2")
+					a.Annotations = map[string]string{clusterAutoscalerFieldsAnnotation: "maxNodesTotal,podPriorityThreshold,scaleDown.delayAfterAdd"}
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{
+						MaxNodesTotal: pointer.Int32Ptr(20),
+						Cores:         &autoscalingv1.ResourceRange{Min: 8, Max: 128},
+					}
+					a.Spec.PodPriorityThreshold = pointer.Int32Ptr(-10)
+					a.Spec.ScaleDown = &autoscalingv1.ScaleDownConfig{
+						Enabled:       false,
+						DelayAfterAdd: pointer.StringPtr("10m"),
+					}
+					return *a
+				}(),
+			},
+		},
+		{
+			name: "Revert cluster autoscaler fields removed from config",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerConfig{
+					MaxNodesTotal: pointer.Int32Ptr(20),
+				}
+				return cd
+			}(),
+			machinePool: testAutoscalingMachinePool(3, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+				func() runtime.Object {
+					a := testClusterAutoscaler("1")
+					a.Annotations = map[string]string{clusterAutoscalerFieldsAnnotation: "maxNodesTotal,podPriorityThreshold,scaleDown.delayAfterAdd"}
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{
+						MaxNodesTotal: pointer.Int32Ptr(10),
+						Cores:         &autoscalingv1.ResourceRange{Min: 8, Max: 128},
+					}
+					a.Spec.MaxPodGracePeriod = pointer.Int32Ptr(300)
+					a.Spec.PodPriorityThreshold = pointer.Int32Ptr(-10)
+					a.Spec.ScaleDown = &autoscalingv1.ScaleDownConfig{
+						Enabled:       false,
+						DelayAfterAdd: pointer.StringPtr("10m"),
+						UnneededTime:  pointer.StringPtr("5m"),
+					}
+					return a
+				}(),
+				testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				*testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				func() autoscalingv1.ClusterAutoscaler {
+					// The fields which were never set from the config are left as they are in the cluster.
+					a := testClusterAutoscaler("2")
+					a.Annotations = map[string]string{clusterAutoscalerFieldsAnnotation: "maxNodesTotal"}
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{
+						MaxNodesTotal: pointer.Int32Ptr(20),
+						Cores:         &autoscalingv1.ResourceRange{Min: 8, Max: 128},
+					}
+					a.Spec.MaxPodGracePeriod = pointer.Int32Ptr(300)
+					a.Spec.ScaleDown = &autoscalingv1.ScaleDownConfig{
+						Enabled:      true,
+						UnneededTime: pointer.StringPtr("5m"),
+					}
+					return *a
+				}(),
+			},
+		},
+		{
+			name:              "Revert cluster autoscaler when config removed",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testAutoscalingMachinePool(3, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+				func() runtime.Object {
+					a := testClusterAutoscaler("1")
+					a.Annotations = map[string]string{clusterAutoscalerFieldsAnnotation: "maxNodesTotal"}
+					a.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{MaxNodesTotal: pointer.Int32Ptr(20)}
+					return a
+				}(),
+				testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				*testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				*testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				*testClusterAutoscaler("2"),
+			},
+		},
 		{
 			name:              "Create machine autoscalers",
 			clusterDeployment: testClusterDeployment(),
@@ -685,6 +952,16 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				} else {
 					assert.Contains(t, pool.Finalizers, finalizer, "missing finalizer")
 				}
+				if test.expectedObservedGeneration != 0 {
+					assert.Equal(t, test.expectedObservedGeneration, pool.Status.ObservedGeneration, "unexpected observed generation")
+				}
+				if e := test.expectedReadyCondition; e != nil {
+					cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.ReadyMachinePoolCondition)
+					if assert.NotNil(t, cond, "missing Ready condition") {
+						assert.Equal(t, e.Status, cond.Status, "unexpected Ready condition status")
+						assert.Equal(t, e.Reason, cond.Reason, "unexpected Ready condition reason")
+					}
+				}
 			}
 
 			rMSL, err := getRMSL(remoteFakeClient)
@@ -750,6 +1027,89 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 	}
 }
 
+func TestValidateArchitecture(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	tests := []struct {
+		name           string
+		pool           *hivev1.MachinePool
+		clusterArch    hivev1.Architecture
+		multiArch      bool
+		expectValid    bool
+		expectedReason string
+	}{
+		{
+			name:        "default architecture",
+			pool:        testMachinePool(),
+			expectValid: true,
+		},
+		{
+			name: "architecture of the control plane",
+			pool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				return pool
+			}(),
+			clusterArch: hivev1.ArchitectureARM64,
+			expectValid: true,
+		},
+		{
+			name: "other architecture in single architecture cluster",
+			pool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				return pool
+			}(),
+			clusterArch:    hivev1.ArchitectureAMD64,
+			expectedReason: "ArchitectureNotInRelease",
+		},
+		{
+			name: "other architecture without image",
+			pool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				return pool
+			}(),
+			clusterArch:    hivev1.ArchitectureAMD64,
+			multiArch:      true,
+			expectedReason: "ImageIDRequired",
+		},
+		{
+			name: "other architecture with image",
+			pool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Architecture = hivev1.ArchitectureARM64
+				pool.Annotations = map[string]string{hivev1.MachinePoolImageIDOverrideAnnotation: "ami-arm64"}
+				return pool
+			}(),
+			clusterArch: hivev1.ArchitectureAMD64,
+			multiArch:   true,
+			expectValid: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Status.Architecture = test.clusterArch
+			cd.Status.MultiArchitecture = test.multiArch
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, test.pool)
+			valid, err := validateArchitecture(fakeClient, test.pool, cd)
+			if !assert.NoError(t, err, "unexpected error validating architecture") {
+				return
+			}
+			assert.Equal(t, test.expectValid, valid, "unexpected validity of architecture")
+			cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, hivev1.InvalidArchitectureMachinePoolCondition)
+			if test.expectValid {
+				assert.Nil(t, cond, "unexpected InvalidArchitecture condition")
+				return
+			}
+			if assert.NotNil(t, cond, "expected InvalidArchitecture condition") {
+				assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected InvalidArchitecture condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected InvalidArchitecture condition reason")
+			}
+		})
+	}
+}
+
 func testMachinePool() *hivev1.MachinePool {
 	return &hivev1.MachinePool{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,373 @@
+package syncsetrollout
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.SyncSetRolloutControllerName
+)
+
+const (
+	// defaultMaxFailures is the number of clusters that may fail to apply the change before the rollout is paused when
+	// the rollout strategy does not say.
+	defaultMaxFailures = 1
+	// defaultProgressDeadline is how long the rollout waits for the admitted clusters to apply the change when the
+	// rollout strategy does not say.
+	defaultProgressDeadline = time.Hour
+)
+
+var (
+	// rolloutCheckInterval is how often a rollout that is not complete is checked, in addition to when the
+	// ClusterSyncs of the clusters change.
+	rolloutCheckInterval = time.Minute
+)
+
+// clusterState is the state of the rollout of a generation of a SelectorSyncSet to a single cluster.
+type clusterState int
+
+const (
+	// clusterPending indicates that the generation has not been applied to the cluster.
+	clusterPending clusterState = iota
+	// clusterUpdated indicates that the generation has been applied successfully to the cluster.
+	clusterUpdated
+	// clusterFailed indicates that the generation failed to apply to the cluster.
+	clusterFailed
+	// clusterSkipped indicates that the generation cannot be applied to the cluster, such as when the cluster is
+	// unreachable. Skipped clusters do not hold up the rollout. The generation is applied to them once the rollout
+	// has moved past their wave and they can be synced again.
+	clusterSkipped
+)
+
+// rolloutCluster is a cluster selected by a SelectorSyncSet that is being rolled out.
+type rolloutCluster struct {
+	key   string
+	wave  int
+	state clusterState
+}
+
+// Add creates a new SyncSetRollout controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileSyncSetRollout
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileSyncSetRollout {
+	return &ReconcileSyncSetRollout{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to the controller manager
+func AddToManager(mgr manager.Manager, r *ReconcileSyncSetRollout, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("syncsetrollout-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewReconcilerWithMetrics(r, ControllerName),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to SelectorSyncSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.SelectorSyncSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs
+	if err := c.Watch(
+		&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(requestsForClusterSync),
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterSync returns a request for each SelectorSyncSet that has been synced to the cluster.
+func requestsForClusterSync(o handler.MapObject) []reconcile.Request {
+	clusterSync, ok := o.Object.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return nil
+	}
+	requests := make([]reconcile.Request, len(clusterSync.Status.SelectorSyncSets))
+	for i, status := range clusterSync.Status.SelectorSyncSets {
+		requests[i].Name = status.Name
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileSyncSetRollout{}
+
+// ReconcileSyncSetRollout reconciles a SelectorSyncSet to roll out each generation to the selected clusters
+type ReconcileSyncSetRollout struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile observes which of the selected clusters the current generation of a SelectorSyncSet has been applied to and
+// admits more clusters to the rollout when allowed by the rollout strategy.
+func (r *ReconcileSyncSetRollout) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "selectorSyncSet", request.NamespacedName)
+	logger.Info("reconciling SelectorSyncSet rollout")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	sss := &hivev1.SelectorSyncSet{}
+	if err := r.Get(context.Background(), request.NamespacedName, sss); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("SelectorSyncSet not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get SelectorSyncSet")
+		return reconcile.Result{}, err
+	}
+	if sss.DeletionTimestamp != nil {
+		logger.Debug("SelectorSyncSet is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := sss.Status.DeepCopy()
+
+	rollout := sss.Spec.Rollout
+	if rollout == nil {
+		sss.Status.Rollout = nil
+		return reconcile.Result{}, r.updateStatus(sss, origStatus, logger)
+	}
+
+	now := metav1.Now()
+	status := sss.Status.Rollout
+	if status == nil || status.ObservedGeneration != sss.Generation {
+		logger.WithField("generation", sss.Generation).Info("starting rollout of new generation")
+		status = &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: sss.Generation}
+		sss.Status.Rollout = status
+	}
+	if status.LastProgressTime == nil {
+		status.LastProgressTime = &now
+	}
+
+	clusters, err := r.getRolloutClusters(sss, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	maxFailures := int32(defaultMaxFailures)
+	if rollout.MaxFailures != nil {
+		maxFailures = *rollout.MaxFailures
+	}
+	progressDeadline := defaultProgressDeadline
+	if rollout.ProgressDeadline != nil {
+		progressDeadline = rollout.ProgressDeadline.Duration
+	}
+	progressed := false
+	admitted := map[string]bool{}
+	for _, key := range status.AdmittedClusters {
+		admitted[key] = true
+	}
+	timedOut := map[string]bool{}
+	for _, key := range status.TimedOutClusters {
+		timedOut[key] = true
+	}
+	// The admitted clusters that have not applied the change by the deadline are failed, so that they no longer hold
+	// up the wave. They stay failed until the change is applied to them.
+	deadlinePassed := now.Sub(status.LastProgressTime.Time) > progressDeadline
+	status.TimedOutClusters = nil
+	var updated, failed int32
+	for i := range clusters {
+		cluster := &clusters[i]
+		if cluster.state == clusterPending &&
+			(timedOut[cluster.key] || deadlinePassed && cluster.wave == int(status.CurrentWave) && admitted[cluster.key]) {
+			if !timedOut[cluster.key] {
+				logger.WithField("cluster", cluster.key).Warn("cluster did not apply the SelectorSyncSet within the progress deadline")
+				progressed = true
+			}
+			cluster.state = clusterFailed
+			status.TimedOutClusters = append(status.TimedOutClusters, cluster.key)
+		}
+		switch cluster.state {
+		case clusterUpdated:
+			updated++
+		case clusterFailed:
+			failed++
+		}
+	}
+	if updated > status.UpdatedClusters {
+		progressed = true
+	}
+	status.UpdatedClusters = updated
+	status.FailedClusters = failed
+	status.TotalClusters = int32(len(clusters))
+	status.Paused = failed > maxFailures
+	if status.Paused {
+		logger.WithField("failedClusters", failed).Warn("rollout is paused because too many clusters failed to apply the SelectorSyncSet")
+	}
+
+	finalWave := int32(len(rollout.Waves))
+	for !status.Paused && status.CurrentWave <= finalWave && isWaveDone(clusters, int(status.CurrentWave)) {
+		status.CurrentWave++
+		status.AdmittedClusters = nil
+		admitted = map[string]bool{}
+		progressed = true
+		logger.WithField("wave", status.CurrentWave).Info("rollout moved to next wave")
+	}
+
+	if status.CurrentWave <= finalWave {
+		// Keep only the admitted clusters that are still in the current wave.
+		status.AdmittedClusters = nil
+		inProgress := 0
+		for _, cluster := range clusters {
+			if cluster.wave != int(status.CurrentWave) || !admitted[cluster.key] {
+				continue
+			}
+			status.AdmittedClusters = append(status.AdmittedClusters, cluster.key)
+			if cluster.state == clusterPending {
+				inProgress++
+			}
+		}
+		if !status.Paused {
+			for _, cluster := range clusters {
+				if rollout.MaxConcurrent != nil && inProgress >= int(*rollout.MaxConcurrent) {
+					break
+				}
+				if cluster.wave != int(status.CurrentWave) || cluster.state != clusterPending || admitted[cluster.key] {
+					continue
+				}
+				logger.WithField("cluster", cluster.key).Info("admitting cluster to rollout")
+				status.AdmittedClusters = append(status.AdmittedClusters, cluster.key)
+				inProgress++
+				progressed = true
+			}
+		}
+		sort.Strings(status.AdmittedClusters)
+	}
+	if progressed {
+		status.LastProgressTime = &now
+	}
+
+	if err := r.updateStatus(sss, origStatus, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if status.CurrentWave > finalWave {
+		logger.Debug("rollout is complete")
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: rolloutCheckInterval}, nil
+}
+
+// getRolloutClusters gets the clusters selected by the SelectorSyncSet along with the state of the rollout for each
+// cluster, sorted by wave and then by name.
+func (r *ReconcileSyncSetRollout) getRolloutClusters(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) ([]rolloutCluster, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		logger.WithError(err).Error("cannot parse ClusterDeployment selector")
+		return nil, nil
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cds, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return nil, err
+	}
+	var clusters []rolloutCluster
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil || controllerutils.IsExcludedFromSelectorSyncSet(sss, cd) {
+			continue
+		}
+		cluster := rolloutCluster{
+			key:  cd.Namespace + "/" + cd.Name,
+			wave: controllerutils.SelectorSyncSetRolloutWave(sss.Spec.Rollout, cd),
+		}
+		cluster.state, err = r.getClusterState(sss, cd, logger)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].wave != clusters[j].wave {
+			return clusters[i].wave < clusters[j].wave
+		}
+		return clusters[i].key < clusters[j].key
+	})
+	return clusters, nil
+}
+
+func (r *ReconcileSyncSetRollout) getClusterState(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (clusterState, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		logger.WithField("clusterSync", cd.Namespace+"/"+cd.Name).WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return clusterPending, err
+	default:
+		for _, syncStatus := range clusterSync.Status.SelectorSyncSets {
+			if syncStatus.Name != sss.Name || syncStatus.ObservedGeneration != sss.Generation {
+				continue
+			}
+			if syncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult {
+				return clusterUpdated, nil
+			}
+			return clusterFailed, nil
+		}
+	}
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable || controllerutils.IsClusterPausedOrRelocating(cd, logger) {
+		return clusterSkipped, nil
+	}
+	return clusterPending, nil
+}
+
+// isWaveDone returns true if none of the clusters in the wave are waiting for the generation to be applied.
+func isWaveDone(clusters []rolloutCluster, wave int) bool {
+	for _, cluster := range clusters {
+		if cluster.wave == wave && cluster.state == clusterPending {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ReconcileSyncSetRollout) updateStatus(sss *hivev1.SelectorSyncSet, origStatus *hivev1.SelectorSyncSetStatus, logger log.FieldLogger) error {
+	if reflect.DeepEqual(origStatus, &sss.Status) {
+		return nil
+	}
+	logger.Debug("updating SelectorSyncSet status")
+	if err := r.Status().Update(context.Background(), sss); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update SelectorSyncSet status")
+		return err
+	}
+	return nil
+}
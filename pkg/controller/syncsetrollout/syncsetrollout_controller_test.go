@@ -0,0 +1,448 @@
+package syncsetrollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	testSelectorSyncSetName = "test-selectorsyncset"
+	testNamespace           = "test-namespace"
+	testGeneration          = 2
+	testFleetLabel          = "fleet"
+	testCanaryLabel         = "canary"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+type testCluster struct {
+	name        string
+	canary      bool
+	unreachable bool
+	// syncedGeneration is the generation of the SelectorSyncSet last applied to the cluster. Zero when the
+	// SelectorSyncSet has never been applied.
+	syncedGeneration int64
+	failed           bool
+}
+
+func TestReconcileSyncSetRollout(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	canaryWaves := []metav1.LabelSelector{{MatchLabels: map[string]string{testCanaryLabel: "true"}}}
+
+	cases := []struct {
+		name            string
+		rollout         *hivev1.SelectorSyncSetRollout
+		existingStatus  *hivev1.SelectorSyncSetRolloutStatus
+		clusters        []testCluster
+		excluded        []string
+		expectedStatus  *hivev1.SelectorSyncSetRolloutStatus
+		expectNoRequeue bool
+	}{
+		{
+			name:            "no rollout",
+			existingStatus:  &hivev1.SelectorSyncSetRolloutStatus{ObservedGeneration: 1},
+			clusters:        []testCluster{{name: "cluster1"}},
+			expectNoRequeue: true,
+		},
+		{
+			name:    "new generation admits first wave",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: 1,
+				CurrentWave:        2,
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: 1},
+				{name: "cluster3", canary: true, syncedGeneration: 1},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2", "test-namespace/cluster3"},
+				TotalClusters:      3,
+			},
+		},
+		{
+			name:    "max concurrent",
+			rollout: &hivev1.SelectorSyncSetRollout{MaxConcurrent: pointer.Int32Ptr(2)},
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2"},
+				{name: "cluster3"},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1", "test-namespace/cluster2"},
+				TotalClusters:      3,
+			},
+		},
+		{
+			name:     "excluded cluster is not rolled out to",
+			rollout:  &hivev1.SelectorSyncSetRollout{MaxConcurrent: pointer.Int32Ptr(2)},
+			excluded: []string{"cluster1"},
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2"},
+				{name: "cluster3"},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2", "test-namespace/cluster3"},
+				TotalClusters:      2,
+			},
+		},
+		{
+			name:    "max concurrent with updated cluster",
+			rollout: &hivev1.SelectorSyncSetRollout{MaxConcurrent: pointer.Int32Ptr(2)},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1", "test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: testGeneration},
+				{name: "cluster2"},
+				{name: "cluster3"},
+				{name: "cluster4"},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1", "test-namespace/cluster2", "test-namespace/cluster3"},
+				UpdatedClusters:    1,
+				TotalClusters:      4,
+			},
+		},
+		{
+			name:    "next wave",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: testGeneration},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				UpdatedClusters:    1,
+				TotalClusters:      2,
+			},
+		},
+		{
+			name:    "empty wave",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			clusters: []testCluster{
+				{name: "cluster1"},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				TotalClusters:      1,
+			},
+		},
+		{
+			name:    "failure pauses rollout",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves, MaxFailures: pointer.Int32Ptr(0)},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: testGeneration, failed: true},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+				FailedClusters:     1,
+				TotalClusters:      2,
+				Paused:             true,
+			},
+		},
+		{
+			name:    "paused rollout admits no more clusters in wave",
+			rollout: &hivev1.SelectorSyncSetRollout{MaxConcurrent: pointer.Int32Ptr(1), MaxFailures: pointer.Int32Ptr(0)},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: testGeneration, failed: true},
+				{name: "cluster2"},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				FailedClusters:     1,
+				TotalClusters:      2,
+				Paused:             true,
+			},
+		},
+		{
+			name:    "failure within default threshold",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: testGeneration, failed: true},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				FailedClusters:     1,
+				TotalClusters:      2,
+			},
+		},
+		{
+			name:    "failures over threshold pause rollout",
+			rollout: &hivev1.SelectorSyncSetRollout{MaxFailures: pointer.Int32Ptr(1)},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1", "test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: testGeneration, failed: true},
+				{name: "cluster2", syncedGeneration: testGeneration, failed: true},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster1", "test-namespace/cluster2"},
+				FailedClusters:     2,
+				TotalClusters:      2,
+				Paused:             true,
+			},
+		},
+		{
+			name:    "cluster not applying the change by the deadline is failed",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+				LastProgressTime:   &metav1.Time{Time: time.Now().Add(-2 * defaultProgressDeadline)},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: 1},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				FailedClusters:     1,
+				TotalClusters:      2,
+				TimedOutClusters:   []string{"test-namespace/cluster2"},
+			},
+		},
+		{
+			name:    "cluster within the deadline holds up wave",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+				LastProgressTime:   &metav1.Time{Time: time.Now().Add(-defaultProgressDeadline / 2)},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, syncedGeneration: 1},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster2"},
+				TotalClusters:      2,
+			},
+		},
+		{
+			name:    "timed out cluster that applies the change is updated",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				TimedOutClusters:   []string{"test-namespace/cluster2"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1"},
+				{name: "cluster2", canary: true, syncedGeneration: testGeneration},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				UpdatedClusters:    1,
+				TotalClusters:      2,
+			},
+		},
+		{
+			name:    "unreachable cluster does not hold up wave",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				AdmittedClusters:   []string{"test-namespace/cluster3"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: 1},
+				{name: "cluster2", canary: true, unreachable: true, syncedGeneration: 1},
+				{name: "cluster3", canary: true, syncedGeneration: testGeneration},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+				UpdatedClusters:    1,
+				TotalClusters:      3,
+			},
+		},
+		{
+			name:    "complete",
+			rollout: &hivev1.SelectorSyncSetRollout{Waves: canaryWaves},
+			existingStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        1,
+				AdmittedClusters:   []string{"test-namespace/cluster1"},
+			},
+			clusters: []testCluster{
+				{name: "cluster1", syncedGeneration: testGeneration},
+				{name: "cluster2", canary: true, syncedGeneration: testGeneration},
+			},
+			expectedStatus: &hivev1.SelectorSyncSetRolloutStatus{
+				ObservedGeneration: testGeneration,
+				CurrentWave:        2,
+				UpdatedClusters:    2,
+				TotalClusters:      2,
+			},
+			expectNoRequeue: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sss := &hivev1.SelectorSyncSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testSelectorSyncSetName,
+					Generation: testGeneration,
+				},
+				Spec: hivev1.SelectorSyncSetSpec{
+					ClusterDeploymentSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{testFleetLabel: "true"},
+					},
+					Rollout: tc.rollout,
+				},
+				Status: hivev1.SelectorSyncSetStatus{
+					Rollout: tc.existingStatus,
+				},
+			}
+			for _, name := range tc.excluded {
+				sss.Spec.ExcludeClusterDeployments = append(sss.Spec.ExcludeClusterDeployments, hivev1.SelectorSyncSetClusterDeploymentReference{
+					Namespace: testNamespace,
+					Name:      name,
+				})
+			}
+			existing := []runtime.Object{sss}
+			for _, cluster := range tc.clusters {
+				existing = append(existing, testClusterDeployment(cluster))
+				if clusterSync := testClusterSync(cluster); clusterSync != nil {
+					existing = append(existing, clusterSync)
+				}
+			}
+			fakeClient := fake.NewFakeClient(existing...)
+			r := &ReconcileSyncSetRollout{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+			}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testSelectorSyncSetName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			if tc.expectNoRequeue {
+				assert.Zero(t, result.RequeueAfter, "expected no requeue")
+			} else {
+				assert.Equal(t, rolloutCheckInterval, result.RequeueAfter, "unexpected requeue after")
+			}
+
+			actual := &hivev1.SelectorSyncSet{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: testSelectorSyncSetName}, actual), "could not get SelectorSyncSet")
+			if actual.Status.Rollout != nil {
+				assert.NotNil(t, actual.Status.Rollout.LastProgressTime, "expected last progress time")
+				actual.Status.Rollout.LastProgressTime = nil
+			}
+			assert.Equal(t, tc.expectedStatus, actual.Status.Rollout, "unexpected rollout status")
+		})
+	}
+}
+
+func testClusterDeployment(cluster testCluster) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      cluster.name,
+			Labels:    map[string]string{testFleetLabel: "true"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: cluster.name,
+			Installed:   true,
+		},
+	}
+	if cluster.canary {
+		cd.Labels[testCanaryLabel] = "true"
+	}
+	unreachableStatus := corev1.ConditionFalse
+	if cluster.unreachable {
+		unreachableStatus = corev1.ConditionTrue
+	}
+	cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+		Type:   hivev1.UnreachableCondition,
+		Status: unreachableStatus,
+	}}
+	return cd
+}
+
+func testClusterSync(cluster testCluster) *hiveintv1alpha1.ClusterSync {
+	if cluster.syncedGeneration == 0 {
+		return nil
+	}
+	syncStatus := hiveintv1alpha1.SyncStatus{
+		Name:               testSelectorSyncSetName,
+		ObservedGeneration: cluster.syncedGeneration,
+		Result:             hiveintv1alpha1.SuccessSyncSetResult,
+	}
+	if cluster.failed {
+		syncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		syncStatus.FailureMessage = "failed to apply"
+	}
+	return &hiveintv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      cluster.name,
+		},
+		Status: hiveintv1alpha1.ClusterSyncStatus{
+			SelectorSyncSets: []hiveintv1alpha1.SyncStatus{syncStatus},
+		},
+	}
+}
@@ -249,6 +249,52 @@ func SetClusterPoolConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetClusterUpgradeConditionWithChangeCheck sets a condition on a ClusterUpgrade resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterUpgradeConditionWithChangeCheck(
+	conditions []hivev1.ClusterUpgradeCondition,
+	conditionType hivev1.ClusterUpgradeConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterUpgradeCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindClusterUpgradeCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterUpgradeCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 // SetClusterProvisionCondition sets a condition on a ClusterProvision resource's status
 func SetClusterProvisionCondition(
 	conditions []hivev1.ClusterProvisionCondition,
@@ -572,6 +618,17 @@ func FindClusterPoolCondition(conditions []hivev1.ClusterPoolCondition, conditio
 	return nil
 }
 
+// FindClusterUpgradeCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindClusterUpgradeCondition(conditions []hivev1.ClusterUpgradeCondition, conditionType hivev1.ClusterUpgradeConditionType) *hivev1.ClusterUpgradeCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // FindClusterProvisionCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindClusterProvisionCondition(conditions []hivev1.ClusterProvisionCondition, conditionType hivev1.ClusterProvisionConditionType) *hivev1.ClusterProvisionCondition {
@@ -21,6 +21,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 // ValidateCredentialsForClusterDeployment will attempt to verify that the platform/cloud credentials
@@ -30,7 +31,7 @@ import (
 func ValidateCredentialsForClusterDeployment(kubeClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
 	secret := &corev1.Secret{}
 
-	switch getClusterPlatform(cd) {
+	switch platform.Name(cd) {
 	case constants.PlatformVSphere:
 		secretKey := types.NamespacedName{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}
 		if err := kubeClient.Get(context.TODO(), secretKey, secret); err != nil {
@@ -134,22 +135,3 @@ func validateVSphereCredentials(vcenter, username, password string, rootCAFiles
 
 	return err == nil, nil
 }
-
-// getClusterPlatform returns the platform of a given ClusterDeployment
-func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		return constants.PlatformAWS
-	case cd.Spec.Platform.Azure != nil:
-		return constants.PlatformAzure
-	case cd.Spec.Platform.GCP != nil:
-		return constants.PlatformGCP
-	case cd.Spec.Platform.OpenStack != nil:
-		return constants.PlatformOpenStack
-	case cd.Spec.Platform.VSphere != nil:
-		return constants.PlatformVSphere
-	case cd.Spec.Platform.BareMetal != nil:
-		return constants.PlatformBaremetal
-	}
-	return constants.PlatformUnknown
-}
@@ -71,7 +71,7 @@ func ReconcileDNSZoneForRelocation(c client.Client, logger log.FieldLogger, dnsZ
 	case hivev1.RelocateIncoming:
 		logger.Info("reconciling DNSZone is disabled for incoming relocate")
 		if dnsZone.DeletionTimestamp != nil {
-			if err := removeFinalizerIfPresent(c, logger, dnsZone, finalizer); err != nil {
+			if err := RemoveFinalizer(c, dnsZone, finalizer, logger); err != nil {
 				return nil, err
 			}
 		}
@@ -79,23 +79,9 @@ func ReconcileDNSZoneForRelocation(c client.Client, logger log.FieldLogger, dnsZ
 	// Clear finalizer on a DNSZone that has completed relocation out to another cluster.
 	case hivev1.RelocateComplete:
 		logger.Info("reconciling DNSZone is disabled after being relocated")
-		return &reconcile.Result{}, removeFinalizerIfPresent(c, logger, dnsZone, finalizer)
+		return &reconcile.Result{}, RemoveFinalizer(c, dnsZone, finalizer, logger)
 	default:
 		logger.WithField("annotation", constants.RelocateAnnotation).Error("unknown relocate status")
 		return nil, errors.New("unknown relocate status")
 	}
 }
-
-func removeFinalizerIfPresent(c client.Client, logger log.FieldLogger, obj hivev1.MetaRuntimeObject, finalizer string) error {
-	if !HasFinalizer(obj, finalizer) {
-		return nil
-	}
-	logger = logger.WithField("finalizer", finalizer)
-	logger.Debug("Removing finalizer")
-	DeleteFinalizer(obj, finalizer)
-	if err := c.Update(context.TODO(), obj); err != nil {
-		logger.WithError(err).Log(LogLevel(err), "failed to remove finalizer")
-		return errors.Wrap(err, "failed to remove finalizer")
-	}
-	return nil
-}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReconcileDrainer tracks the reconciles in flight in the controller manager, so that they can be drained when the
+// manager shuts down instead of being cut off halfway through, for example while applying resources to a cluster.
+type ReconcileDrainer struct {
+	mutex    sync.Mutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+// defaultReconcileDrainer tracks the reconciles of all the controllers of the process.
+var defaultReconcileDrainer = &ReconcileDrainer{}
+
+// DefaultReconcileDrainer returns the drainer tracking the reconciles of all the controllers of the process.
+func DefaultReconcileDrainer() *ReconcileDrainer {
+	return defaultReconcileDrainer
+}
+
+// StartReconcile records the start of a reconcile. It returns false, without recording the reconcile, once the
+// drainer is draining. Reconciles which were started must be ended with EndReconcile.
+func (d *ReconcileDrainer) StartReconcile() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inFlight.Add(1)
+	return true
+}
+
+// EndReconcile records the end of a reconcile started with StartReconcile.
+func (d *ReconcileDrainer) EndReconcile() {
+	d.inFlight.Done()
+}
+
+// Drain stops new reconciles from starting, and waits for the reconciles in flight to end. An error is returned if
+// they have not ended within the timeout.
+func (d *ReconcileDrainer) Drain(timeout time.Duration) error {
+	d.mutex.Lock()
+	d.draining = true
+	d.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("reconciles still in flight after %s", timeout)
+	}
+}
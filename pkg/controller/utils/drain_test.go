@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileDrainer(t *testing.T) {
+	d := &ReconcileDrainer{}
+	assert.True(t, d.StartReconcile(), "expected reconcile to start")
+
+	ended := make(chan struct{})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		d.EndReconcile()
+		close(ended)
+	}()
+	assert.NoError(t, d.Drain(5*time.Second), "expected the reconcile in flight to be drained")
+	<-ended
+	assert.False(t, d.StartReconcile(), "expected no reconcile to start while draining")
+}
+
+func TestReconcileDrainerTimeout(t *testing.T) {
+	d := &ReconcileDrainer{}
+	assert.True(t, d.StartReconcile(), "expected reconcile to start")
+	assert.Error(t, d.Drain(10*time.Millisecond), "expected drain to time out")
+	d.EndReconcile()
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// hiveFinalizerPrefix is the prefix of the finalizers of Hive.
+	hiveFinalizerPrefix = "hive.openshift.io/"
+
+	// FinalizersForceRemovedEventReason is the reason of the event recorded when finalizers are force-removed from an
+	// object.
+	FinalizersForceRemovedEventReason = "FinalizersForceRemoved"
+)
+
+// HasFinalizer returns true if the given object has the given finalizer
+func HasFinalizer(object metav1.Object, finalizer string) bool {
+	for _, f := range object.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer adds a finalizer to the given object
+func AddFinalizer(object metav1.Object, finalizer string) {
+	finalizers := sets.NewString(object.GetFinalizers()...)
+	finalizers.Insert(finalizer)
+	object.SetFinalizers(finalizers.List())
+}
+
+// DeleteFinalizer removes a finalizer from the given object
+func DeleteFinalizer(object metav1.Object, finalizer string) {
+	finalizers := sets.NewString(object.GetFinalizers()...)
+	finalizers.Delete(finalizer)
+	object.SetFinalizers(finalizers.List())
+}
+
+// HiveFinalizers returns the finalizers of Hive of the given object
+func HiveFinalizers(object metav1.Object) []string {
+	var finalizers []string
+	for _, f := range object.GetFinalizers() {
+		if strings.HasPrefix(f, hiveFinalizerPrefix) {
+			finalizers = append(finalizers, f)
+		}
+	}
+	return finalizers
+}
+
+// RemoveFinalizer removes a finalizer from the given object and updates it, if the object has the finalizer
+func RemoveFinalizer(c client.Client, obj hivev1.MetaRuntimeObject, finalizer string, logger log.FieldLogger) error {
+	if !HasFinalizer(obj, finalizer) {
+		return nil
+	}
+	logger = logger.WithField("finalizer", finalizer)
+	logger.Debug("Removing finalizer")
+	DeleteFinalizer(obj, finalizer)
+	if err := c.Update(context.TODO(), obj); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "failed to remove finalizer")
+		return errors.Wrap(err, "failed to remove finalizer")
+	}
+	return nil
+}
+
+// ForceRemoveFinalizers removes the Hive finalizers of an object being deleted without waiting for the cleanup they
+// guard, so that the object can go away. The override is first recorded in a warning event of the object, with the
+// given message, so that it can be audited. The removed finalizers are returned.
+func ForceRemoveFinalizers(c client.Client, obj hivev1.MetaRuntimeObject, message string, logger log.FieldLogger) ([]string, error) {
+	if obj.GetDeletionTimestamp() == nil {
+		return nil, errors.New("object is not being deleted")
+	}
+	finalizers := HiveFinalizers(obj)
+	if len(finalizers) == 0 {
+		return nil, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine the kind of the object")
+	}
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: obj.GetName() + ".",
+			Namespace:    obj.GetNamespace(),
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      gvk.GroupVersion().String(),
+			Kind:            gvk.Kind,
+			Name:            obj.GetName(),
+			Namespace:       obj.GetNamespace(),
+			UID:             obj.GetUID(),
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+		Reason:         FinalizersForceRemovedEventReason,
+		Message:        fmt.Sprintf("Force-removed finalizers %s: %s", strings.Join(finalizers, ", "), message),
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "hive"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if err := c.Create(context.TODO(), event); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "failed to record the removal of the finalizers")
+		return nil, errors.Wrap(err, "failed to record the removal of the finalizers")
+	}
+	for _, f := range finalizers {
+		DeleteFinalizer(obj, f)
+	}
+	if err := c.Update(context.TODO(), obj); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "failed to remove finalizers")
+		return nil, errors.Wrap(err, "failed to remove finalizers")
+	}
+	logger.WithField("finalizers", finalizers).Warn("force-removed finalizers")
+	return finalizers, nil
+}
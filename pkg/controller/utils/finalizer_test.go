@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestHiveFinalizers(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Finalizers: []string{hivev1.FinalizerDeprovision, "other.io/finalizer", hivev1.FinalizerDNSZone},
+		},
+	}
+	assert.Equal(t, []string{hivev1.FinalizerDeprovision, hivev1.FinalizerDNSZone}, HiveFinalizers(cd))
+}
+
+func TestForceRemoveFinalizers(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name               string
+		deleted            bool
+		finalizers         []string
+		expectErr          bool
+		expectedRemoved    []string
+		expectedFinalizers []string
+		expectEvent        bool
+	}{
+		{
+			name:               "removes hive finalizers",
+			deleted:            true,
+			finalizers:         []string{hivev1.FinalizerDeprovision, "other.io/finalizer"},
+			expectedRemoved:    []string{hivev1.FinalizerDeprovision},
+			expectedFinalizers: []string{"other.io/finalizer"},
+			expectEvent:        true,
+		},
+		{
+			name:               "no hive finalizers",
+			deleted:            true,
+			finalizers:         []string{"other.io/finalizer"},
+			expectedFinalizers: []string{"other.io/finalizer"},
+		},
+		{
+			name:               "not being deleted",
+			finalizers:         []string{hivev1.FinalizerDeprovision},
+			expectErr:          true,
+			expectedFinalizers: []string{hivev1.FinalizerDeprovision},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testName,
+					Namespace:  testNamespace,
+					Finalizers: tc.finalizers,
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cd.DeletionTimestamp = &now
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, cd)
+
+			removed, err := ForceRemoveFinalizers(c, cd.DeepCopy(), "cloud resources confirmed gone", log.WithField("test", tc.name))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+			assert.Equal(t, tc.expectedRemoved, removed, "unexpected removed finalizers")
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, actual))
+			assert.Equal(t, tc.expectedFinalizers, actual.Finalizers, "unexpected finalizers")
+
+			events := &corev1.EventList{}
+			require.NoError(t, c.List(context.TODO(), events, client.InNamespace(testNamespace)))
+			if !tc.expectEvent {
+				assert.Empty(t, events.Items, "expected no events")
+				return
+			}
+			if assert.Len(t, events.Items, 1, "expected one event") {
+				event := events.Items[0]
+				assert.Equal(t, FinalizersForceRemovedEventReason, event.Reason, "unexpected event reason")
+				assert.Equal(t, corev1.EventTypeWarning, event.Type, "unexpected event type")
+				assert.Equal(t, "ClusterDeployment", event.InvolvedObject.Kind, "unexpected involved object kind")
+				assert.Contains(t, event.Message, "cloud resources confirmed gone", "unexpected event message")
+			}
+		})
+	}
+}
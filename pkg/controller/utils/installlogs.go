@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// InstallLogsFolder returns the folder of the object store where the logs of the failed installs of the cluster are
+// uploaded.
+func InstallLogsFolder(clusterName, namespace string) string {
+	return fmt.Sprintf("%v-%v", clusterName, namespace)
+}
+
+// InstallLogsLocation returns the location in the object store of the logs uploaded for the failed provision of the
+// cluster, which prefixes the names of the uploaded log files. It is empty when the upload of the logs of failed
+// installs is not configured.
+func InstallLogsLocation(clusterName string, provision *hivev1.ClusterProvision) string {
+	if os.Getenv(constants.InstallLogsUploadProviderEnvVar) != constants.InstallLogsUploadProviderAWS {
+		return ""
+	}
+	bucket := os.Getenv(constants.InstallLogsAWSS3BucketEnvVar)
+	if bucket == "" {
+		return ""
+	}
+	return fmt.Sprintf("s3://%v/%v/%v-", bucket, InstallLogsFolder(clusterName, provision.Namespace), provision.Name)
+}
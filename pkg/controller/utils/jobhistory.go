@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultMaxProvisions                = 3
+	defaultMaxInstallAttempts           = 10
+	defaultFailedProvisionRetention     = 7 * 24 * time.Hour
+	defaultCompletedInstallJobRetention = 24 * time.Hour
+)
+
+// JobHistory is the job history configuration from HiveConfig with the defaults applied.
+type JobHistory struct {
+	// MaxProvisions is the maximum number of provisions retained for a cluster deployment.
+	MaxProvisions int
+	// MaxInstallAttempts is the maximum number of install attempts recorded in the status of a cluster deployment.
+	MaxInstallAttempts int
+	// FailedProvisionRetention is how long failed provisions of installed clusters are retained.
+	FailedProvisionRetention time.Duration
+	// CompletedInstallJobRetention is how long the install job of a successful provision is retained.
+	CompletedInstallJobRetention time.Duration
+	// CompletedUninstallJobRetention is how long the uninstall job of a completed deprovision is retained. Zero
+	// means the job is retained until the deprovision is deleted.
+	CompletedUninstallJobRetention time.Duration
+}
+
+// GetJobHistory returns the job history configuration. It is read from the environment variable set by the
+// operator from HiveConfig, falling back to the defaults for any setting that is not set or cannot be parsed.
+func GetJobHistory(logger log.FieldLogger) JobHistory {
+	history := JobHistory{
+		MaxProvisions:                defaultMaxProvisions,
+		MaxInstallAttempts:           defaultMaxInstallAttempts,
+		FailedProvisionRetention:     defaultFailedProvisionRetention,
+		CompletedInstallJobRetention: defaultCompletedInstallJobRetention,
+	}
+	value, ok := os.LookupEnv(constants.JobHistoryEnvVar)
+	if !ok {
+		return history
+	}
+	config := &hivev1.JobHistoryConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, using default job history", constants.JobHistoryEnvVar)
+		return history
+	}
+	if config.MaxProvisions != nil && *config.MaxProvisions > 0 {
+		history.MaxProvisions = int(*config.MaxProvisions)
+	}
+	if config.MaxInstallAttempts != nil && *config.MaxInstallAttempts > 0 {
+		history.MaxInstallAttempts = int(*config.MaxInstallAttempts)
+	}
+	parseRetention := func(name, value string, retention *time.Duration) {
+		if value == "" {
+			return
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			logger.WithError(err).WithField(name, value).Errorf("cannot parse %s, using default", name)
+			return
+		}
+		*retention = d
+	}
+	parseRetention("failedProvisionRetention", config.FailedProvisionRetention, &history.FailedProvisionRetention)
+	parseRetention("completedInstallJobRetention", config.CompletedInstallJobRetention, &history.CompletedInstallJobRetention)
+	parseRetention("completedUninstallJobRetention", config.CompletedUninstallJobRetention, &history.CompletedUninstallJobRetention)
+	return history
+}
@@ -11,12 +11,20 @@ import (
 // getJobConditionStatus gets the status of the condition in the job. If the
 // condition is not found in the job, then returns False.
 func getJobConditionStatus(job *batchv1.Job, conditionType batchv1.JobConditionType) corev1.ConditionStatus {
-	for _, condition := range job.Status.Conditions {
+	if condition := FindJobCondition(job, conditionType); condition != nil {
+		return condition.Status
+	}
+	return corev1.ConditionFalse
+}
+
+// FindJobCondition returns the condition of the given type in the job, or nil if the job does not have it.
+func FindJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i, condition := range job.Status.Conditions {
 		if condition.Type == conditionType {
-			return condition.Status
+			return &job.Status.Conditions[i]
 		}
 	}
-	return corev1.ConditionFalse
+	return nil
 }
 
 // IsSuccessful returns true if the job was successful
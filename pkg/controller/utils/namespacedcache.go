@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetWatchedNamespaces returns the namespaces the controllers are restricted to, always including the Hive namespace,
+// or nil when the controllers watch all namespaces.
+func GetWatchedNamespaces() []string {
+	value := os.Getenv(constants.WatchedNamespacesEnvVar)
+	if value == "" {
+		return nil
+	}
+	namespaces := sets.NewString(GetHiveNamespace())
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces.Insert(ns)
+		}
+	}
+	return namespaces.List()
+}
+
+// NewNamespacedCacheBuilder returns a builder of caches whose informers for namespaced objects only watch the given
+// namespaces. Unlike the multi-namespace cache of controller-runtime, which cannot serve cluster-scoped objects, the
+// caches watch cluster-scoped objects across the cluster.
+func NewNamespacedCacheBuilder(namespaces []string) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		if opts.Mapper == nil {
+			mapper, err := apiutil.NewDynamicRESTMapper(config)
+			if err != nil {
+				return nil, err
+			}
+			opts.Mapper = mapper
+		}
+		namespaced, err := cache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		clusterScoped, err := cache.New(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &namespacedCache{
+			namespaced:    namespaced,
+			clusterScoped: clusterScoped,
+			scheme:        opts.Scheme,
+			mapper:        opts.Mapper,
+		}, nil
+	}
+}
+
+// namespacedCache sends the requests for namespaced objects to a cache restricted to the watched namespaces, and the
+// requests for cluster-scoped objects to a cache for the whole cluster.
+type namespacedCache struct {
+	namespaced    cache.Cache
+	clusterScoped cache.Cache
+	scheme        *runtime.Scheme
+	mapper        meta.RESTMapper
+}
+
+var _ cache.Cache = &namespacedCache{}
+
+func (c *namespacedCache) cacheFor(obj runtime.Object) (cache.Cache, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if meta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	return c.cacheForKind(gvk)
+}
+
+func (c *namespacedCache) cacheForKind(gvk schema.GroupVersionKind) (cache.Cache, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return c.clusterScoped, nil
+	}
+	return c.namespaced, nil
+}
+
+func (c *namespacedCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	delegate, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+	return delegate.Get(ctx, key, obj)
+}
+
+func (c *namespacedCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	delegate, err := c.cacheFor(list)
+	if err != nil {
+		return err
+	}
+	return delegate.List(ctx, list, opts...)
+}
+
+func (c *namespacedCache) GetInformer(ctx context.Context, obj runtime.Object) (cache.Informer, error) {
+	delegate, err := c.cacheFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return delegate.GetInformer(ctx, obj)
+}
+
+func (c *namespacedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	delegate, err := c.cacheForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	return delegate.GetInformerForKind(ctx, gvk)
+}
+
+func (c *namespacedCache) IndexField(ctx context.Context, obj runtime.Object, field string, extractValue client.IndexerFunc) error {
+	delegate, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+	return delegate.IndexField(ctx, obj, field, extractValue)
+}
+
+func (c *namespacedCache) Start(stopCh <-chan struct{}) error {
+	errs := make(chan error, 2)
+	go func() { errs <- c.clusterScoped.Start(stopCh) }()
+	go func() { errs <- c.namespaced.Start(stopCh) }()
+	var firstErr error
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *namespacedCache) WaitForCacheSync(stop <-chan struct{}) bool {
+	return c.clusterScoped.WaitForCacheSync(stop) && c.namespaced.WaitForCacheSync(stop)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestGetWatchedNamespaces(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:     "namespaces",
+			value:    "clusters-b, clusters-a,,hive",
+			expected: []string{"clusters-a", "clusters-b", "hive"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(constants.HiveNamespaceEnvVar, "hive")
+			defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+			os.Setenv(constants.WatchedNamespacesEnvVar, tc.value)
+			defer os.Unsetenv(constants.WatchedNamespacesEnvVar)
+			assert.Equal(t, tc.expected, GetWatchedNamespaces())
+		})
+	}
+}
+
+type testCache struct {
+	cache.Cache
+}
+
+func TestNamespacedCacheFor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	mapper.Add(hivev1.SchemeGroupVersion.WithKind("ClusterImageSet"), meta.RESTScopeRoot)
+	c := &namespacedCache{
+		namespaced:    &testCache{},
+		clusterScoped: &testCache{},
+		scheme:        scheme,
+		mapper:        mapper,
+	}
+	cases := []struct {
+		name          string
+		obj           runtime.Object
+		clusterScoped bool
+	}{
+		{
+			name: "namespaced",
+			obj:  &corev1.Secret{},
+		},
+		{
+			name: "namespaced list",
+			obj:  &corev1.SecretList{},
+		},
+		{
+			name:          "cluster-scoped",
+			obj:           &hivev1.ClusterImageSet{},
+			clusterScoped: true,
+		},
+		{
+			name:          "cluster-scoped list",
+			obj:           &hivev1.ClusterImageSetList{},
+			clusterScoped: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delegate, err := c.cacheFor(tc.obj)
+			require.NoError(t, err, "unexpected error getting cache")
+			if tc.clusterScoped {
+				assert.Same(t, c.clusterScoped, delegate, "expected cluster-scoped cache")
+			} else {
+				assert.Same(t, c.namespaced, delegate, "expected namespaced cache")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// AddPodScheduling adds the node selector and tolerations of the pod scheduling configuration to the pod. The labels
+// already selected by the pod are kept, and the tolerations the pod already has are not duplicated.
+func AddPodScheduling(podSpec *corev1.PodSpec, config *hivev1.PodSchedulingConfig) {
+	if config == nil {
+		return
+	}
+	for key, value := range config.NodeSelector {
+		if _, ok := podSpec.NodeSelector[key]; ok {
+			continue
+		}
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		podSpec.NodeSelector[key] = value
+	}
+	for _, toleration := range config.Tolerations {
+		if !hasToleration(podSpec.Tolerations, toleration) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for _, t := range tolerations {
+		if reflect.DeepEqual(t, toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddJobPodScheduling adds the pod scheduling configuration of HiveConfig to the pod of a job created by the
+// controllers. It is read from the environment variable set by the operator. The pod is left unchanged when the
+// configuration is not set or cannot be parsed.
+func AddJobPodScheduling(podSpec *corev1.PodSpec, logger log.FieldLogger) {
+	value, ok := os.LookupEnv(constants.PodSchedulingEnvVar)
+	if !ok {
+		return
+	}
+	config := &hivev1.PodSchedulingConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, using the default scheduling of the job pods", constants.PodSchedulingEnvVar)
+		return
+	}
+	AddPodScheduling(podSpec, config)
+}
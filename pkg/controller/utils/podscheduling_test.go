@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+var testToleration = corev1.Toleration{
+	Key:      "hive.openshift.io/dedicated",
+	Operator: corev1.TolerationOpExists,
+	Effect:   corev1.TaintEffectNoSchedule,
+}
+
+func TestAddPodScheduling(t *testing.T) {
+	cases := []struct {
+		name                 string
+		podSpec              corev1.PodSpec
+		config               *hivev1.PodSchedulingConfig
+		expectedNodeSelector map[string]string
+		expectedTolerations  []corev1.Toleration
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name: "empty pod",
+			config: &hivev1.PodSchedulingConfig{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/hive": ""},
+				Tolerations:  []corev1.Toleration{testToleration},
+			},
+			expectedNodeSelector: map[string]string{"node-role.kubernetes.io/hive": ""},
+			expectedTolerations:  []corev1.Toleration{testToleration},
+		},
+		{
+			name: "pod selectors kept",
+			podSpec: corev1.PodSpec{
+				NodeSelector: map[string]string{"zone": "a", "node-role.kubernetes.io/hive": "hooks"},
+			},
+			config: &hivev1.PodSchedulingConfig{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/hive": ""},
+			},
+			expectedNodeSelector: map[string]string{"zone": "a", "node-role.kubernetes.io/hive": "hooks"},
+		},
+		{
+			name: "tolerations not duplicated",
+			podSpec: corev1.PodSpec{
+				Tolerations: []corev1.Toleration{testToleration},
+			},
+			config: &hivev1.PodSchedulingConfig{
+				Tolerations: []corev1.Toleration{
+					testToleration,
+					{Key: "other", Operator: corev1.TolerationOpExists},
+				},
+			},
+			expectedTolerations: []corev1.Toleration{
+				testToleration,
+				{Key: "other", Operator: corev1.TolerationOpExists},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			podSpec := tc.podSpec.DeepCopy()
+			AddPodScheduling(podSpec, tc.config)
+			assert.Equal(t, tc.expectedNodeSelector, podSpec.NodeSelector, "unexpected node selector")
+			assert.Equal(t, tc.expectedTolerations, podSpec.Tolerations, "unexpected tolerations")
+		})
+	}
+}
+
+func TestAddJobPodScheduling(t *testing.T) {
+	logger := log.WithField("test", "TestAddJobPodScheduling")
+
+	podSpec := &corev1.PodSpec{}
+	AddJobPodScheduling(podSpec, logger)
+	assert.Empty(t, podSpec.NodeSelector, "expected no node selector without configuration")
+
+	os.Setenv(constants.PodSchedulingEnvVar, `{"nodeSelector":{"node-role.kubernetes.io/hive":""},"tolerations":[{"key":"hive.openshift.io/dedicated","operator":"Exists","effect":"NoSchedule"}]}`)
+	defer os.Unsetenv(constants.PodSchedulingEnvVar)
+	AddJobPodScheduling(podSpec, logger)
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/hive": ""}, podSpec.NodeSelector, "unexpected node selector")
+	assert.Equal(t, []corev1.Toleration{testToleration}, podSpec.Tolerations, "unexpected tolerations")
+
+	os.Setenv(constants.PodSchedulingEnvVar, "not json")
+	podSpec = &corev1.PodSpec{}
+	AddJobPodScheduling(podSpec, logger)
+	assert.Empty(t, podSpec.Tolerations, "expected no tolerations with invalid configuration")
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// PropagatedLabelKeys returns the keys of the labels of ClusterDeployments which are copied to the objects created for
+// the clusters. They are read from the environment variable set by the operator. No label is propagated when the
+// variable is not set or cannot be parsed.
+func PropagatedLabelKeys(logger log.FieldLogger) []string {
+	value, ok := os.LookupEnv(constants.PropagatedLabelsEnvVar)
+	if !ok {
+		return nil
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(value), &keys); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, not propagating labels", constants.PropagatedLabelsEnvVar)
+		return nil
+	}
+	return keys
+}
+
+// PropagatedLabels returns the labels of the object which are propagated to the objects created from it.
+func PropagatedLabels(from metav1.Object, logger log.FieldLogger) map[string]string {
+	keys := PropagatedLabelKeys(logger)
+	if len(keys) == 0 {
+		return nil
+	}
+	labels := map[string]string{}
+	for _, key := range keys {
+		if value, ok := from.GetLabels()[key]; ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// AddPropagatedLabels copies the propagated labels of the first object to the second. The labels already set on the
+// second object are kept. It returns true if the labels of the second object changed.
+func AddPropagatedLabels(from, to metav1.Object, logger log.FieldLogger) bool {
+	labels, changed := addLabels(to.GetLabels(), PropagatedLabels(from, logger))
+	if changed {
+		to.SetLabels(labels)
+	}
+	return changed
+}
+
+// AddJobPropagatedLabels copies the propagated labels of the object to the job and to the template of its pods.
+func AddJobPropagatedLabels(from metav1.Object, job *batchv1.Job, logger log.FieldLogger) {
+	propagated := PropagatedLabels(from, logger)
+	job.Labels, _ = addLabels(job.Labels, propagated)
+	job.Spec.Template.Labels, _ = addLabels(job.Spec.Template.Labels, propagated)
+}
+
+func addLabels(labels, added map[string]string) (map[string]string, bool) {
+	changed := false
+	for key, value := range added {
+		if _, ok := labels[key]; ok {
+			continue
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[key] = value
+		changed = true
+	}
+	return labels, changed
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestAddPropagatedLabels(t *testing.T) {
+	from := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			"cost-center": "1234",
+			"team":        "hive",
+			"unrelated":   "value",
+		},
+	}
+	cases := []struct {
+		name            string
+		env             string
+		labels          map[string]string
+		expectedLabels  map[string]string
+		expectedChanged bool
+	}{
+		{
+			name:   "not configured",
+			labels: map[string]string{"app": "test"},
+			expectedLabels: map[string]string{
+				"app": "test",
+			},
+		},
+		{
+			name: "invalid configuration",
+			env:  "not json",
+		},
+		{
+			name: "labels added",
+			env:  `["cost-center","team","missing"]`,
+			expectedLabels: map[string]string{
+				"cost-center": "1234",
+				"team":        "hive",
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "existing labels kept",
+			env:    `["cost-center","team"]`,
+			labels: map[string]string{"team": "other"},
+			expectedLabels: map[string]string{
+				"cost-center": "1234",
+				"team":        "other",
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "already propagated",
+			env:    `["team"]`,
+			labels: map[string]string{"team": "hive"},
+			expectedLabels: map[string]string{
+				"team": "hive",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.env != "" {
+				os.Setenv(constants.PropagatedLabelsEnvVar, tc.env)
+				defer os.Unsetenv(constants.PropagatedLabelsEnvVar)
+			}
+			to := &metav1.ObjectMeta{Labels: tc.labels}
+			changed := AddPropagatedLabels(from, to, log.WithField("test", t.Name()))
+			assert.Equal(t, tc.expectedChanged, changed, "unexpected change")
+			assert.Equal(t, tc.expectedLabels, to.Labels, "unexpected labels")
+		})
+	}
+}
+
+func TestAddJobPropagatedLabels(t *testing.T) {
+	os.Setenv(constants.PropagatedLabelsEnvVar, `["team"]`)
+	defer os.Unsetenv(constants.PropagatedLabelsEnvVar)
+	from := &metav1.ObjectMeta{Labels: map[string]string{"team": "hive", "other": "value"}}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{},
+		},
+	}
+	AddJobPropagatedLabels(from, job, log.WithField("test", t.Name()))
+	assert.Equal(t, map[string]string{"app": "test", "team": "hive"}, job.Labels, "unexpected job labels")
+	assert.Equal(t, map[string]string{"team": "hive"}, job.Spec.Template.Labels, "unexpected pod labels")
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// defaultFatalProvisionFailures are the install failures that are not retried when HiveConfig does not configure any.
+var defaultFatalProvisionFailures = []hivev1.FatalProvisionFailure{
+	{
+		Name: "InvalidCredentials",
+		SearchRegexStrings: []string{
+			"InvalidClientTokenId: The security token included in the request is invalid",
+			"AuthFailure: AWS was not able to validate the provided access credentials",
+			"SignatureDoesNotMatch: The request signature we calculated does not match the signature you provided",
+			"oauth2: cannot fetch token.*invalid_grant",
+			"AADSTS7000215: Invalid client secret",
+		},
+		InstallFailingReason:  "InvalidCredentials",
+		InstallFailingMessage: "Credentials are invalid",
+	},
+	{
+		Name: "UnsupportedRegion",
+		SearchRegexStrings: []string{
+			"platform\\.(aws|gcp|azure)\\.region: (Unsupported|Invalid) value",
+		},
+		InstallFailingReason:  "UnsupportedRegion",
+		InstallFailingMessage: "Region is not supported",
+	},
+	{
+		Name: "InvalidBaseDomain",
+		SearchRegexStrings: []string{
+			"baseDomain: Invalid value",
+		},
+		InstallFailingReason:  "InvalidBaseDomain",
+		InstallFailingMessage: "Base domain is invalid",
+	},
+}
+
+// GetFatalProvisionFailures returns the install failures that must not be retried. They are read from the environment
+// variable set by the operator from HiveConfig, falling back to the defaults when the variable is not set.
+func GetFatalProvisionFailures(logger log.FieldLogger) []hivev1.FatalProvisionFailure {
+	value, ok := os.LookupEnv(constants.FatalProvisionFailuresEnvVar)
+	if !ok {
+		return defaultFatalProvisionFailures
+	}
+	failures := []hivev1.FatalProvisionFailure{}
+	if err := json.Unmarshal([]byte(value), &failures); err != nil {
+		logger.WithError(err).Errorf("cannot unmarshal %s, using default fatal provision failures", constants.FatalProvisionFailuresEnvVar)
+		return defaultFatalProvisionFailures
+	}
+	return failures
+}
+
+// MatchFatalProvisionFailure returns the fatal failure matching the install log, or nil if the log does not match any.
+func MatchFatalProvisionFailure(installLog string, logger log.FieldLogger) *hivev1.FatalProvisionFailure {
+	failures := GetFatalProvisionFailures(logger)
+	for i, failure := range failures {
+		for _, ss := range failure.SearchRegexStrings {
+			switch match, err := regexp.MatchString(ss, installLog); {
+			case err != nil:
+				logger.WithError(err).WithField("searchString", ss).Error("unable to compile regex")
+			case match:
+				return &failures[i]
+			}
+		}
+	}
+	return nil
+}
+
+// IsFatalProvisionFailureReason returns true if the reason is reported for one of the fatal install failures.
+func IsFatalProvisionFailureReason(reason string, logger log.FieldLogger) bool {
+	for _, failure := range GetFatalProvisionFailures(logger) {
+		if failure.InstallFailingReason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// regionCapacityFailureReasons are the reasons reported for install failures caused by the region lacking capacity.
+// They are matched by the install log regexes.
+var regionCapacityFailureReasons = map[string]bool{
+	"AWSInsufficientCapacity":      true,
+	"GCPZoneResourcePoolExhausted": true,
+	"AzureAllocationFailed":        true,
+}
+
+// IsRegionCapacityFailureReason returns true if the reason is reported for an install failure caused by the region
+// lacking capacity, which may succeed in another region.
+func IsRegionCapacityFailureReason(reason string) bool {
+	return regionCapacityFailureReasons[reason]
+}
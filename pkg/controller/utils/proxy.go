@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ProxyEnvVars returns the environment variables which make the outbound connections of a container go through the
+// proxy. No environment variables are returned when neither an HTTP nor an HTTPS proxy is set.
+func ProxyEnvVars(httpProxy, httpsProxy, noProxy string) []corev1.EnvVar {
+	if httpProxy == "" && httpsProxy == "" {
+		return nil
+	}
+	var envVars []corev1.EnvVar
+	for _, envVar := range []corev1.EnvVar{
+		{Name: constants.HTTPProxyEnvVar, Value: httpProxy},
+		{Name: constants.HTTPSProxyEnvVar, Value: httpsProxy},
+		{Name: constants.NoProxyEnvVar, Value: noProxy},
+	} {
+		if envVar.Value != "" {
+			envVars = append(envVars, envVar)
+		}
+	}
+	return envVars
+}
+
+// JobProxyEnvVars returns the proxy environment variables of the install and uninstall jobs of the clusters of the
+// platform. The proxy configured for the platform in HiveConfig is used, or else the proxy of the controller manager.
+func JobProxyEnvVars(platform string, logger log.FieldLogger) []corev1.EnvVar {
+	if value, ok := os.LookupEnv(constants.PlatformProxiesEnvVar); ok {
+		var platforms []hivev1.PlatformProxyConfig
+		if err := json.Unmarshal([]byte(value), &platforms); err != nil {
+			logger.WithError(err).Errorf("cannot unmarshal %s, using the proxy of the controller manager", constants.PlatformProxiesEnvVar)
+		}
+		for _, p := range platforms {
+			if p.Platform == platform {
+				return ProxyEnvVars(p.HTTPProxy, p.HTTPSProxy, p.NoProxy)
+			}
+		}
+	}
+	return ProxyEnvVars(
+		os.Getenv(constants.HTTPProxyEnvVar),
+		os.Getenv(constants.HTTPSProxyEnvVar),
+		os.Getenv(constants.NoProxyEnvVar),
+	)
+}
+
+// proxyTrustedCAVolumeName is the name of the volume of the certificate authorities of the proxy.
+const proxyTrustedCAVolumeName = "proxy-trusted-ca"
+
+// sslCertDirEnvVar makes the Go runtime trust the certificates of the directory along with the ones of the system.
+const sslCertDirEnvVar = "SSL_CERT_DIR"
+
+// AddProxyTrustedCA mounts the certificate authorities of the secret in the container of the pod, and makes the
+// container trust them along with the certificate authorities of the system.
+func AddProxyTrustedCA(podSpec *corev1.PodSpec, container *corev1.Container, secretName string) {
+	if !hasVolume(podSpec, proxyTrustedCAVolumeName) {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: proxyTrustedCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+					Items:      []corev1.KeyToPath{{Key: constants.ProxyTrustedCAKey, Path: constants.ProxyTrustedCAKey}},
+				},
+			},
+		})
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      proxyTrustedCAVolumeName,
+		MountPath: constants.ProxyTrustedCAMountPath,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  sslCertDirEnvVar,
+		Value: constants.ProxyTrustedCAMountPath,
+	})
+}
+
+func hasVolume(podSpec *corev1.PodSpec, name string) bool {
+	for _, v := range podSpec.Volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// JobProxyTrustedCASecretName returns the name of the copy of the certificate authorities of the proxy in the
+// namespace of a job owned by the named resource.
+func JobProxyTrustedCASecretName(ownerName string) string {
+	return apihelpers.GetResourceName(ownerName, "proxy-ca")
+}
+
+// SyncJobProxyTrustedCA copies the certificate authorities of the proxy from the hive namespace to the namespace of
+// the owner of a job, as a secret owned by it, so that the pods of the job can mount them. It returns the name of the
+// copy, or an empty string when HiveConfig configures no certificate authorities for the proxy.
+func SyncJobProxyTrustedCA(c client.Client, owner metav1.Object, scheme *runtime.Scheme, logger log.FieldLogger) (string, error) {
+	srcName := os.Getenv(constants.ProxyTrustedCASecretEnvVar)
+	if srcName == "" {
+		return "", nil
+	}
+	src := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: GetHiveNamespace(), Name: srcName}, src); err != nil {
+		logger.WithError(err).WithField("secret", srcName).Error("could not get the certificate authorities of the proxy")
+		return "", err
+	}
+	name := JobProxyTrustedCASecretName(owner.GetName())
+	dest := &corev1.Secret{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: owner.GetNamespace(), Name: name}, dest); {
+	case apierrors.IsNotFound(err):
+		dest = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: owner.GetNamespace(),
+				Name:      name,
+			},
+			Data: map[string][]byte{constants.ProxyTrustedCAKey: src.Data[constants.ProxyTrustedCAKey]},
+		}
+		if err := controllerutil.SetControllerReference(owner, dest, scheme); err != nil {
+			return "", err
+		}
+		if err := c.Create(context.TODO(), dest); err != nil {
+			logger.WithError(err).Log(LogLevel(err), "could not create the certificate authorities of the proxy")
+			return "", err
+		}
+	case err != nil:
+		return "", err
+	case !bytes.Equal(dest.Data[constants.ProxyTrustedCAKey], src.Data[constants.ProxyTrustedCAKey]):
+		dest.Data = map[string][]byte{constants.ProxyTrustedCAKey: src.Data[constants.ProxyTrustedCAKey]}
+		if err := c.Update(context.TODO(), dest); err != nil {
+			logger.WithError(err).Log(LogLevel(err), "could not update the certificate authorities of the proxy")
+			return "", err
+		}
+	}
+	return name, nil
+}
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestJobProxyEnvVars(t *testing.T) {
+	cases := []struct {
+		name                 string
+		environmentVariables map[string]string
+		platform             string
+		expected             []corev1.EnvVar
+	}{
+		{
+			name:     "no proxy",
+			platform: constants.PlatformAWS,
+		},
+		{
+			name: "proxy of the controller manager",
+			environmentVariables: map[string]string{
+				constants.HTTPSProxyEnvVar: "http://proxy.example.com:3128",
+				constants.NoProxyEnvVar:    ".svc,172.30.0.1",
+			},
+			platform: constants.PlatformAWS,
+			expected: []corev1.EnvVar{
+				{Name: constants.HTTPSProxyEnvVar, Value: "http://proxy.example.com:3128"},
+				{Name: constants.NoProxyEnvVar, Value: ".svc,172.30.0.1"},
+			},
+		},
+		{
+			name: "proxy of the platform",
+			environmentVariables: map[string]string{
+				constants.HTTPSProxyEnvVar:      "http://proxy.example.com:3128",
+				constants.PlatformProxiesEnvVar: `[{"platform":"aws","httpProxy":"http://aws-proxy.example.com:3128","httpsProxy":"http://aws-proxy.example.com:3128"}]`,
+			},
+			platform: constants.PlatformAWS,
+			expected: []corev1.EnvVar{
+				{Name: constants.HTTPProxyEnvVar, Value: "http://aws-proxy.example.com:3128"},
+				{Name: constants.HTTPSProxyEnvVar, Value: "http://aws-proxy.example.com:3128"},
+			},
+		},
+		{
+			name: "platform without proxy",
+			environmentVariables: map[string]string{
+				constants.HTTPSProxyEnvVar:      "http://proxy.example.com:3128",
+				constants.PlatformProxiesEnvVar: `[{"platform":"vsphere"}]`,
+			},
+			platform: constants.PlatformVSphere,
+		},
+		{
+			name: "proxy of another platform",
+			environmentVariables: map[string]string{
+				constants.HTTPSProxyEnvVar:      "http://proxy.example.com:3128",
+				constants.PlatformProxiesEnvVar: `[{"platform":"aws","httpsProxy":"http://aws-proxy.example.com:3128"}]`,
+			},
+			platform: constants.PlatformGCP,
+			expected: []corev1.EnvVar{
+				{Name: constants.HTTPSProxyEnvVar, Value: "http://proxy.example.com:3128"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{constants.HTTPProxyEnvVar, constants.HTTPSProxyEnvVar, constants.NoProxyEnvVar, constants.PlatformProxiesEnvVar} {
+				if v, ok := os.LookupEnv(k); ok {
+					defer os.Setenv(k, v)
+				}
+				os.Unsetenv(k)
+			}
+			for k, v := range tc.environmentVariables {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			assert.Equal(t, tc.expected, JobProxyEnvVars(tc.platform, log.WithField("test", tc.name)), "unexpected proxy environment variables")
+		})
+	}
+}
+
+func TestSyncJobProxyTrustedCA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	const (
+		hiveNamespace = "hive"
+		clusterNS     = "cluster-namespace"
+		caSecretName  = "proxy-ca"
+	)
+	owner := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: "cluster", UID: "uid"}}
+	caSecret := func(namespace, name, ca string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string][]byte{constants.ProxyTrustedCAKey: []byte(ca)},
+		}
+	}
+	cases := []struct {
+		name           string
+		secretEnvVar   string
+		existing       []runtime.Object
+		expectedSecret string
+		expectedCA     string
+		expectError    bool
+	}{
+		{
+			name: "no certificate authorities",
+		},
+		{
+			name:           "copied",
+			secretEnvVar:   caSecretName,
+			existing:       []runtime.Object{caSecret(hiveNamespace, caSecretName, "ca")},
+			expectedSecret: "cluster-proxy-ca",
+			expectedCA:     "ca",
+		},
+		{
+			name:         "updated",
+			secretEnvVar: caSecretName,
+			existing: []runtime.Object{
+				caSecret(hiveNamespace, caSecretName, "new-ca"),
+				caSecret(clusterNS, "cluster-proxy-ca", "old-ca"),
+			},
+			expectedSecret: "cluster-proxy-ca",
+			expectedCA:     "new-ca",
+		},
+		{
+			name:         "missing secret",
+			secretEnvVar: caSecretName,
+			expectError:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(constants.HiveNamespaceEnvVar, hiveNamespace)
+			defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+			if tc.secretEnvVar != "" {
+				os.Setenv(constants.ProxyTrustedCASecretEnvVar, tc.secretEnvVar)
+				defer os.Unsetenv(constants.ProxyTrustedCASecretEnvVar)
+			}
+			c := fake.NewFakeClientWithScheme(scheme, tc.existing...)
+			secretName, err := SyncJobProxyTrustedCA(c, owner, scheme, log.WithField("test", tc.name))
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedSecret, secretName, "unexpected secret name")
+			if tc.expectedSecret == "" {
+				return
+			}
+			secret := &corev1.Secret{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNS, Name: secretName}, secret), "could not get copied secret")
+			assert.Equal(t, tc.expectedCA, string(secret.Data[constants.ProxyTrustedCAKey]), "unexpected certificate authorities")
+		})
+	}
+}
+
+func TestAddProxyTrustedCA(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "first"}, {Name: "second"}}}
+	for i := range podSpec.Containers {
+		AddProxyTrustedCA(podSpec, &podSpec.Containers[i], "cluster-proxy-ca")
+	}
+	if assert.Len(t, podSpec.Volumes, 1, "expected a single volume") {
+		assert.Equal(t, "cluster-proxy-ca", podSpec.Volumes[0].Secret.SecretName, "unexpected secret of the volume")
+	}
+	for _, container := range podSpec.Containers {
+		if assert.Len(t, container.VolumeMounts, 1, "expected mounted certificate authorities") {
+			assert.Equal(t, constants.ProxyTrustedCAMountPath, container.VolumeMounts[0].MountPath, "unexpected mount path")
+		}
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "SSL_CERT_DIR", Value: constants.ProxyTrustedCAMountPath}, "expected trusted certificate directory")
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/platform"
+)
+
+// HasFallbackRegions returns true if the platform of the cluster deployment has regions to fail over to when the
+// install lacks capacity in its region.
+func HasFallbackRegions(cd *hivev1.ClusterDeployment) bool {
+	return len(platform.Regions(cd)) > 1
+}
+
+// InstallRegion returns the region where the cluster is installed. This is the region of the platform, unless the
+// install failed over to one of the fallback regions. An empty string is returned for platforms without regions.
+func InstallRegion(cd *hivev1.ClusterDeployment) string {
+	return platform.InstallRegion(cd)
+}
+
+// NextFallbackRegion returns the region to fail over to after an install in the given region failed for lack of
+// capacity. It returns false when there are no regions left to try.
+func NextFallbackRegion(cd *hivev1.ClusterDeployment, region string) (string, bool) {
+	regions := platform.Regions(cd)
+	for i, r := range regions {
+		if r == region && i+1 < len(regions) {
+			return regions[i+1], true
+		}
+	}
+	return "", false
+}
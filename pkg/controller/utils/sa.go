@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -13,117 +14,211 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
-)
 
-const (
-	// ServiceAccountName will be a service account that can run the installer and then
-	// upload artifacts to the cluster's namespace.
-	ServiceAccountName = "cluster-installer"
-	roleName           = "cluster-installer"
-	roleBindingName    = "cluster-installer"
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 )
 
-var (
-	roleRules = []rbacv1.PolicyRule{
+// InstallServiceAccountName returns the name of the service account, role, and role binding used by the install
+// and imageset jobs of a cluster deployment.
+func InstallServiceAccountName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "installer")
+}
+
+// UninstallServiceAccountName returns the name of the service account used by the uninstall job of a cluster
+// deprovision.
+func UninstallServiceAccountName(name string) string {
+	return apihelpers.GetResourceName(name, "uninstaller")
+}
+
+// AdminKubeconfigSecretName returns the name of the admin kubeconfig secret uploaded by the installer for a
+// cluster provision.
+func AdminKubeconfigSecretName(provisionName string) string {
+	return fmt.Sprintf("%s-admin-kubeconfig", provisionName)
+}
+
+// AdminPasswordSecretName returns the name of the admin password secret uploaded by the installer for a cluster
+// provision.
+func AdminPasswordSecretName(provisionName string) string {
+	return fmt.Sprintf("%s-admin-password", provisionName)
+}
+
+// MetadataJSONSecretName returns the name of the metadata.json secret uploaded by the installer for a cluster
+// provision.
+func MetadataJSONSecretName(provisionName string) string {
+	return fmt.Sprintf("%s-metadata-json", provisionName)
+}
+
+// TerraformStateSecretName returns the name of the terraform state secret uploaded by the installer for a cluster
+// provision.
+func TerraformStateSecretName(provisionName string) string {
+	return fmt.Sprintf("%s-terraform-state", provisionName)
+}
+
+// installRoleRules returns the rules for the install role of the cluster deployment. Everything but the creation
+// of secrets and the listing of provisions is limited to the objects of the cluster deployment, as RBAC cannot
+// restrict creates, or lists without a field selector, by name.
+func installRoleRules(cdName, provisionName string, secretNames []string) []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
 		{
-			APIGroups: []string{""},
-			Resources: []string{"secrets", "configmaps"},
-			Verbs:     []string{"create", "delete", "get", "list", "update"},
+			APIGroups:     []string{"hive.openshift.io"},
+			Resources:     []string{"clusterdeployments"},
+			ResourceNames: []string{cdName},
+			Verbs:         []string{"get"},
 		},
 		{
-			APIGroups: []string{"hive.openshift.io"},
-			Resources: []string{"dnszones"},
-			Verbs:     []string{"get"},
+			APIGroups:     []string{"hive.openshift.io"},
+			Resources:     []string{"clusterdeployments/status"},
+			ResourceNames: []string{cdName},
+			Verbs:         []string{"get", "update"},
 		},
 		{
-			APIGroups: []string{"hive.openshift.io"},
-			Resources: []string{"clusterdeployments", "clusterdeployments/finalizers", "clusterdeployments/status"},
-			Verbs:     []string{"get", "update"},
+			APIGroups:     []string{"hive.openshift.io"},
+			Resources:     []string{"dnszones"},
+			ResourceNames: []string{DNSZoneName(cdName)},
+			Verbs:         []string{"get"},
 		},
-		{
+	}
+	if provisionName == "" {
+		return rules
+	}
+	return append(rules,
+		rbacv1.PolicyRule{
+			APIGroups:     []string{"hive.openshift.io"},
+			Resources:     []string{"clusterprovisions"},
+			ResourceNames: []string{provisionName},
+			Verbs:         []string{"get", "list", "update", "watch"},
+		},
+		// The installer lists the provisions of the namespace to check that the infra ID it generated is not used by
+		// another provision of the cluster deployment.
+		rbacv1.PolicyRule{
 			APIGroups: []string{"hive.openshift.io"},
-			Resources: []string{"clusterprovisions", "clusterprovisions/finalizers", "clusterprovisions/status"},
-			Verbs:     []string{"get", "list", "update", "watch"},
+			Resources: []string{"clusterprovisions"},
+			Verbs:     []string{"list"},
 		},
-	}
-)
+		// The uploaded secrets are owned by the provision with BlockOwnerDeletion set.
+		rbacv1.PolicyRule{
+			APIGroups:     []string{"hive.openshift.io"},
+			Resources:     []string{"clusterprovisions/finalizers"},
+			ResourceNames: []string{provisionName},
+			Verbs:         []string{"update"},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"create"},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			ResourceNames: append(
+				[]string{
+					AdminKubeconfigSecretName(provisionName),
+					AdminPasswordSecretName(provisionName),
+					MetadataJSONSecretName(provisionName),
+					TerraformStateSecretName(provisionName),
+				},
+				secretNames...,
+			),
+			Verbs: []string{"delete", "get"},
+		},
+	)
+}
 
-// SetupClusterInstallServiceAccount ensures a service account exists which can upload
+// SetupClusterInstallServiceAccount ensures a service account exists for the cluster deployment which can upload
 // the required artifacts after running the installer in a pod. (metadata, admin kubeconfig)
-func SetupClusterInstallServiceAccount(c client.Client, namespace string, logger log.FieldLogger) error {
+// The role is scoped to the objects of the provision, so it is updated for each provision. provisionName is empty
+// for the imageset job, which only updates the status of the cluster deployment. secretNames are any additional
+// secrets that the installer reads.
+func SetupClusterInstallServiceAccount(c client.Client, cd *hivev1.ClusterDeployment, provisionName string, secretNames []string, logger log.FieldLogger) error {
+	name := InstallServiceAccountName(cd.Name)
+	ownerRef := *metav1.NewControllerRef(cd, hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"))
+	return setupServiceAccount(c, cd.Namespace, name, ownerRef, installRoleRules(cd.Name, provisionName, secretNames), logger)
+}
+
+// SetupClusterUninstallServiceAccount ensures a service account exists for the uninstall job of the cluster
+// deprovision. The uninstaller only reads the mounted credentials, so the service account is not bound to any role.
+func SetupClusterUninstallServiceAccount(c client.Client, req *hivev1.ClusterDeprovision, logger log.FieldLogger) error {
+	name := UninstallServiceAccountName(req.Name)
+	ownerRef := *metav1.NewControllerRef(req, hivev1.SchemeGroupVersion.WithKind("ClusterDeprovision"))
+	return setupServiceAccount(c, req.Namespace, name, ownerRef, nil, logger)
+}
+
+// setupServiceAccount ensures the service account exists and, when there are rules, a role and role binding of the
+// same name granting them. The objects are owned by the owner so that they are deleted with it.
+func setupServiceAccount(c client.Client, namespace, name string, ownerRef metav1.OwnerReference, rules []rbacv1.PolicyRule, logger log.FieldLogger) error {
+	objectMeta := metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       namespace,
+		OwnerReferences: []metav1.OwnerReference{ownerRef},
+	}
+
 	// create new serviceaccount if it doesn't already exist
-	switch err := c.Get(context.Background(), client.ObjectKey{Name: ServiceAccountName, Namespace: namespace}, &corev1.ServiceAccount{}); {
+	switch err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, &corev1.ServiceAccount{}); {
 	case apierrors.IsNotFound(err):
-		sa := &corev1.ServiceAccount{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      ServiceAccountName,
-				Namespace: namespace,
-			},
-		}
+		sa := &corev1.ServiceAccount{ObjectMeta: objectMeta}
 		if err := c.Create(context.TODO(), sa); err != nil {
 			return errors.Wrap(err, "error creating serviceaccount")
 		}
-		logger.WithField("name", ServiceAccountName).Info("created service account")
+		logger.WithField("name", name).Info("created service account")
 	case err != nil:
 		return errors.Wrap(err, "error checking for existing serviceaccount")
 	default:
-		logger.WithField("name", ServiceAccountName).Debug("service account already exists")
+		logger.WithField("name", name).Debug("service account already exists")
+	}
+
+	if len(rules) == 0 {
+		return nil
 	}
 
 	currentRole := &rbacv1.Role{}
-	switch err := c.Get(context.Background(), client.ObjectKey{Name: roleName, Namespace: namespace}, currentRole); {
+	switch err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, currentRole); {
 	case apierrors.IsNotFound(err):
 		role := &rbacv1.Role{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      roleName,
-				Namespace: namespace,
-			},
-			Rules: roleRules,
+			ObjectMeta: objectMeta,
+			Rules:      rules,
 		}
 		if err := c.Create(context.TODO(), role); err != nil {
 			return errors.Wrap(err, "error creating role")
 		}
-		logger.WithField("name", roleName).Info("created role")
+		logger.WithField("name", name).Info("created role")
 	case err != nil:
 		return errors.Wrap(err, "error checking for existing role")
-	case !reflect.DeepEqual(currentRole.Rules, roleRules):
-		currentRole.Rules = roleRules
+	case !reflect.DeepEqual(currentRole.Rules, rules):
+		currentRole.Rules = rules
 		if err := c.Update(context.TODO(), currentRole); err != nil {
 			return errors.Wrap(err, "error updating role")
 		}
-		logger.WithField("name", roleName).Info("updated role")
+		logger.WithField("name", name).Info("updated role")
 	default:
-		logger.WithField("name", roleName).Debug("role already exists")
+		logger.WithField("name", name).Debug("role already exists")
 	}
 
 	// create rolebinding for the serviceaccount
-	switch err := c.Get(context.Background(), client.ObjectKey{Name: roleBindingName, Namespace: namespace}, &rbacv1.RoleBinding{}); {
+	switch err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, &rbacv1.RoleBinding{}); {
 	case apierrors.IsNotFound(err):
 		rb := &rbacv1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      roleBindingName,
-				Namespace: namespace,
-			},
+			ObjectMeta: objectMeta,
 			Subjects: []rbacv1.Subject{
 				{
 					Kind:      "ServiceAccount",
-					Name:      ServiceAccountName,
+					Name:      name,
 					Namespace: namespace,
 				},
 			},
 			RoleRef: rbacv1.RoleRef{
-				Name: roleName,
+				Name: name,
 				Kind: "Role",
 			},
 		}
 		if err := c.Create(context.Background(), rb); err != nil {
 			return errors.Wrap(err, "error creating rolebinding")
 		}
-		logger.WithField("name", roleBindingName).Info("created rolebinding")
+		logger.WithField("name", name).Info("created rolebinding")
 	case err != nil:
 		return errors.Wrap(err, "error checking for existing rolebinding")
 	default:
-		logger.WithField("name", roleBindingName).Debug("rolebinding already exists")
+		logger.WithField("name", name).Debug("rolebinding already exists")
 	}
 
 	return nil
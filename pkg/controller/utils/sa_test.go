@@ -9,15 +9,24 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 )
 
 const (
-	testNamespace = "test-namespace"
+	testNamespace     = "test-namespace"
+	testCDName        = "test-cd"
+	testProvisionName = "test-cd-0-abcde"
+)
+
+var (
+	testServiceAccountName = InstallServiceAccountName(testCDName)
 )
 
 func TestSetupClusterInstallServiceAccount(t *testing.T) {
@@ -94,32 +103,36 @@ func TestSetupClusterInstallServiceAccount(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeClient := fake.NewFakeClient(tc.existing...)
-			err := SetupClusterInstallServiceAccount(fakeClient, testNamespace, log.StandardLogger())
+			err := SetupClusterInstallServiceAccount(fakeClient, testInstallClusterDeployment(), testProvisionName, nil, log.StandardLogger())
 			if !assert.NoError(t, err, "unexpected error setting up service account") {
 				return
 			}
 
 			sa := &corev1.ServiceAccount{}
-			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: ServiceAccountName, Namespace: testNamespace}, sa)
-			assert.NoError(t, err, "unexpected error fetching service account")
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: testServiceAccountName, Namespace: testNamespace}, sa)
+			if assert.NoError(t, err, "unexpected error fetching service account") && len(tc.existing) == 0 {
+				if assert.Len(t, sa.OwnerReferences, 1, "expected created service account to have an owner") {
+					assert.Equal(t, testCDName, sa.OwnerReferences[0].Name, "unexpected owner")
+				}
+			}
 
 			role := &rbacv1.Role{}
-			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: roleName, Namespace: testNamespace}, role)
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: testServiceAccountName, Namespace: testNamespace}, role)
 			if assert.NoError(t, err, "unexpected error fetching role") {
-				assert.Equal(t, roleRules, role.Rules, "incorrect rules")
+				assert.Equal(t, installRoleRules(testCDName, testProvisionName, nil), role.Rules, "incorrect rules")
 			}
 
 			roleBinding := &rbacv1.RoleBinding{}
-			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: roleBindingName, Namespace: testNamespace}, roleBinding)
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: testServiceAccountName, Namespace: testNamespace}, roleBinding)
 			if assert.NoError(t, err, "unexpected error fetching role binding") {
 				if assert.Len(t, roleBinding.Subjects, 1, "unexpected number of subjects") {
 					subject := roleBinding.Subjects[0]
 					assert.Equal(t, "ServiceAccount", subject.Kind, "unexpected subject kind")
-					assert.Equal(t, ServiceAccountName, subject.Name, "unexpected subject name")
+					assert.Equal(t, testServiceAccountName, subject.Name, "unexpected subject name")
 					assert.Equal(t, testNamespace, subject.Namespace, "unexpected subject namespace")
 				}
 				assert.Equal(t, "Role", roleBinding.RoleRef.Kind, "unexpected role ref kind")
-				assert.Equal(t, roleName, roleBinding.RoleRef.Name, "unexpected roel ref name")
+				assert.Equal(t, testServiceAccountName, roleBinding.RoleRef.Name, "unexpected roel ref name")
 			}
 		})
 	}
@@ -128,7 +141,7 @@ func TestSetupClusterInstallServiceAccount(t *testing.T) {
 func testServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ServiceAccountName,
+			Name:      testServiceAccountName,
 			Namespace: testNamespace,
 		},
 	}
@@ -137,29 +150,122 @@ func testServiceAccount() *corev1.ServiceAccount {
 func testRole() *rbacv1.Role {
 	return &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      roleName,
+			Name:      testServiceAccountName,
 			Namespace: testNamespace,
 		},
-		Rules: roleRules,
+		Rules: installRoleRules(testCDName, testProvisionName, nil),
 	}
 }
 
 func testRoleBinding() *rbacv1.RoleBinding {
 	return &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      roleBindingName,
+			Name:      testServiceAccountName,
 			Namespace: testNamespace,
 		},
 		Subjects: []rbacv1.Subject{
 			{
 				Kind:      "ServiceAccount",
-				Name:      ServiceAccountName,
+				Name:      testServiceAccountName,
 				Namespace: testNamespace,
 			},
 		},
 		RoleRef: rbacv1.RoleRef{
-			Name: roleName,
+			Name: testServiceAccountName,
 			Kind: "Role",
 		},
 	}
 }
+
+func TestInstallRoleRules(t *testing.T) {
+	rules := installRoleRules(testCDName, testProvisionName, []string{"test-log-creds"})
+	for _, rule := range rules {
+		if rule.Resources[0] == "secrets" && rule.Verbs[0] == "create" ||
+			rule.Resources[0] == "clusterprovisions" && rule.Verbs[0] == "list" {
+			assert.Empty(t, rule.ResourceNames, "unexpected resource names for %s rule", rule.Verbs[0])
+			continue
+		}
+		assert.NotEmpty(t, rule.ResourceNames, "expected rule for %v to be limited to named resources", rule.Resources)
+	}
+	assert.Contains(t, rules[len(rules)-1].ResourceNames, "test-log-creds", "expected additional secret to be readable")
+
+	cases := []struct {
+		verb     string
+		resource string
+		name     string
+		allowed  bool
+	}{
+		{verb: "get", resource: "clusterprovisions", name: testProvisionName, allowed: true},
+		{verb: "update", resource: "clusterprovisions", name: testProvisionName, allowed: true},
+		// The infra ID check lists all of the provisions of the namespace.
+		{verb: "list", resource: "clusterprovisions", allowed: true},
+		{verb: "get", resource: "clusterprovisions", name: "other-provision"},
+		{verb: "update", resource: "clusterprovisions", name: "other-provision"},
+		{verb: "get", resource: "clusterdeployments", name: testCDName, allowed: true},
+		{verb: "list", resource: "clusterdeployments"},
+		{verb: "create", resource: "secrets", allowed: true},
+		{verb: "get", resource: "secrets", name: AdminKubeconfigSecretName(testProvisionName), allowed: true},
+		{verb: "get", resource: "secrets", name: "other-secret"},
+		{verb: "list", resource: "secrets"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.allowed, rulesAllow(rules, tc.verb, tc.resource, tc.name), "unexpected authorization to %s %s %q", tc.verb, tc.resource, tc.name)
+	}
+
+	imageSetRules := installRoleRules(testCDName, "", nil)
+	for _, rule := range imageSetRules {
+		assert.NotContains(t, rule.Resources, "secrets", "unexpected secrets rule without a provision")
+	}
+}
+
+// rulesAllow returns true if the rules authorize the verb on the resource, as RBAC would. An empty name is a request for
+// the whole collection, which is only authorized by rules without resource names.
+func rulesAllow(rules []rbacv1.PolicyRule, verb, resource, name string) bool {
+	for _, rule := range rules {
+		if !contains(rule.Verbs, verb) || !contains(rule.Resources, resource) {
+			continue
+		}
+		if len(rule.ResourceNames) == 0 || name != "" && contains(rule.ResourceNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetupClusterUninstallServiceAccount(t *testing.T) {
+	fakeClient := fake.NewFakeClient()
+	req := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testCDName,
+			Namespace: testNamespace,
+		},
+	}
+	err := SetupClusterUninstallServiceAccount(fakeClient, req, log.StandardLogger())
+	if !assert.NoError(t, err, "unexpected error setting up service account") {
+		return
+	}
+
+	key := client.ObjectKey{Name: UninstallServiceAccountName(testCDName), Namespace: testNamespace}
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, &corev1.ServiceAccount{}), "unexpected error fetching service account")
+	assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), key, &rbacv1.Role{})), "expected no role")
+	assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), key, &rbacv1.RoleBinding{})), "expected no role binding")
+}
+
+func testInstallClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testCDName,
+			Namespace: testNamespace,
+			UID:       "test-uid",
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// IsExcludedFromSelectorSyncSet returns true if the ClusterDeployment is listed in the excluded ClusterDeployments of
+// the SelectorSyncSet.
+func IsExcludedFromSelectorSyncSet(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) bool {
+	for _, ref := range sss.Spec.ExcludeClusterDeployments {
+		if ref.Namespace == cd.Namespace && ref.Name == cd.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// SelectorSyncSetRolloutWave returns the index of the wave of the rollout that the ClusterDeployment is in. Clusters
+// that do not match any of the waves are in the final wave, which has an index equal to the number of waves.
+func SelectorSyncSetRolloutWave(rollout *hivev1.SelectorSyncSetRollout, cd *hivev1.ClusterDeployment) int {
+	for i := range rollout.Waves {
+		selector, err := metav1.LabelSelectorAsSelector(&rollout.Waves[i])
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(cd.Labels)) {
+			return i
+		}
+	}
+	return len(rollout.Waves)
+}
+
+// IsSelectorSyncSetRolledOutToCluster returns true if the current generation of the SelectorSyncSet may be applied to
+// the ClusterDeployment. This is always true for SelectorSyncSets without a rollout strategy.
+func IsSelectorSyncSetRolledOutToCluster(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) bool {
+	if sss.Spec.Rollout == nil {
+		return true
+	}
+	status := sss.Status.Rollout
+	if status == nil || status.ObservedGeneration != sss.Generation {
+		// The rollout of the current generation has not started yet.
+		return false
+	}
+	wave := int32(SelectorSyncSetRolloutWave(sss.Spec.Rollout, cd))
+	if wave < status.CurrentWave {
+		return true
+	}
+	if wave > status.CurrentWave {
+		return false
+	}
+	key := cd.Namespace + "/" + cd.Name
+	for _, admitted := range status.AdmittedClusters {
+		if admitted == key {
+			return true
+		}
+	}
+	return false
+}
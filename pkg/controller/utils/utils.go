@@ -20,7 +20,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
@@ -59,31 +58,11 @@ const (
 	// QueueBurstEnvVariableFormat is the format of the environment variable that stores
 	// workqueue burst for a controller
 	QueueBurstEnvVariableFormat = "%s-queue-burst"
-)
-
-// HasFinalizer returns true if the given object has the given finalizer
-func HasFinalizer(object metav1.Object, finalizer string) bool {
-	for _, f := range object.GetFinalizers() {
-		if f == finalizer {
-			return true
-		}
-	}
-	return false
-}
-
-// AddFinalizer adds a finalizer to the given object
-func AddFinalizer(object metav1.Object, finalizer string) {
-	finalizers := sets.NewString(object.GetFinalizers()...)
-	finalizers.Insert(finalizer)
-	object.SetFinalizers(finalizers.List())
-}
 
-// DeleteFinalizer removes a finalizer from the given object
-func DeleteFinalizer(object metav1.Object, finalizer string) {
-	finalizers := sets.NewString(object.GetFinalizers()...)
-	finalizers.Delete(finalizer)
-	object.SetFinalizers(finalizers.List())
-}
+	// WatchRemoteClusterEnvVariableFormat is the format of the environment variable that stores
+	// whether a controller watches the objects it manages in the remote clusters
+	WatchRemoteClusterEnvVariableFormat = "%s-watch-remote-cluster"
+)
 
 // getConcurrentReconciles returns the number of goroutines each controller should
 // use for parallel processing of their queue. Default value, if not set in
@@ -273,6 +252,15 @@ func GetHiveNamespace() string {
 	return constants.DefaultHiveNamespace
 }
 
+// GetWatchRemoteCluster returns whether the controller watches the objects it manages in the remote clusters. Remote
+// clusters are not watched unless it is set in hive-controllers-config.
+func GetWatchRemoteCluster(controllerName hivev1.ControllerName) (bool, error) {
+	if value, ok := getValueFromEnvVariable(controllerName, WatchRemoteClusterEnvVariableFormat); ok {
+		return strconv.ParseBool(value)
+	}
+	return false, nil
+}
+
 // getValueFromEnvVariable gets a configuration value for a controller from the environment variable
 func getValueFromEnvVariable(controllerName hivev1.ControllerName, envVarFormat string) (string, bool) {
 	if value, ok := os.LookupEnv(fmt.Sprintf(envVarFormat, controllerName)); ok {
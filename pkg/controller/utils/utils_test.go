@@ -326,6 +326,58 @@ func TestGetQueueRateLimiter(t *testing.T) {
 	}
 }
 
+func TestGetWatchRemoteCluster(t *testing.T) {
+	cases := []struct {
+		name                 string
+		environmentVariables map[string]string
+		expectedWatch        bool
+		expectedError        bool
+	}{
+		{
+			name:                 "Not set",
+			environmentVariables: map[string]string{},
+		},
+		{
+			name: "Only default is set",
+			environmentVariables: map[string]string{
+				fmt.Sprintf(WatchRemoteClusterEnvVariableFormat, "default"): "true",
+			},
+			expectedWatch: true,
+		},
+		{
+			name: "Controller overrides default",
+			environmentVariables: map[string]string{
+				fmt.Sprintf(WatchRemoteClusterEnvVariableFormat, "default"):          "true",
+				fmt.Sprintf(WatchRemoteClusterEnvVariableFormat, testControllerName): "false",
+			},
+		},
+		{
+			name: "Set incorrectly",
+			environmentVariables: map[string]string{
+				fmt.Sprintf(WatchRemoteClusterEnvVariableFormat, testControllerName): "sometimes",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// set environment variables
+			for k, v := range tc.environmentVariables {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			watch, err := GetWatchRemoteCluster(testControllerName)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedWatch, watch, "unexpected watch remote cluster")
+		})
+	}
+}
+
 func TestEnsureRequeueAtLeastWithin(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -93,7 +93,12 @@ func (c *gcpClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error)
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
 
-	return c.dnsClient.ManagedZones.Get(c.projectName, managedZone).Context(ctx).Do()
+	var zone *dns.ManagedZone
+	err := callDNS(ctx, func() (err error) {
+		zone, err = c.dnsClient.ManagedZones.Get(c.projectName, managedZone).Context(ctx).Do()
+		return err
+	})
+	return zone, err
 }
 
 func (c *gcpClient) ListManagedZones(opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
@@ -110,19 +115,31 @@ func (c *gcpClient) ListManagedZones(opts ListManagedZonesOptions) (*dns.Managed
 	if opts.DNSName != "" {
 		call.DnsName(opts.DNSName)
 	}
-	return call.Do()
+	var resp *dns.ManagedZonesListResponse
+	err := callDNS(ctx, func() (err error) {
+		resp, err = call.Do()
+		return err
+	})
+	return resp, err
 }
 
 func (c *gcpClient) CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
-	return c.dnsClient.ManagedZones.Create(c.projectName, managedZone).Context(ctx).Do()
+	var zone *dns.ManagedZone
+	err := callDNS(ctx, func() (err error) {
+		zone, err = c.dnsClient.ManagedZones.Create(c.projectName, managedZone).Context(ctx).Do()
+		return err
+	})
+	return zone, err
 }
 
 func (c *gcpClient) DeleteManagedZone(managedZone string) error {
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
-	return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
+	return callDNS(ctx, func() error {
+		return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
+	})
 }
 
 func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
@@ -141,7 +158,12 @@ func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResource
 	if opts.Type != "" {
 		call.Type(opts.Type)
 	}
-	return call.Do()
+	var resp *dns.ResourceRecordSetsListResponse
+	err := callDNS(ctx, func() (err error) {
+		resp, err = call.Do()
+		return err
+	})
+	return resp, err
 }
 
 func (c *gcpClient) AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
@@ -183,8 +205,10 @@ func (c *gcpClient) DeleteResourceRecordSets(managedZone string, recordSets []*d
 func (c *gcpClient) changeResourceRecordSet(managedZone string, change *dns.Change) error {
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
-	_, err := c.dnsClient.Changes.Create(c.projectName, managedZone, change).Context(ctx).Do()
-	return err
+	return callDNS(ctx, func() error {
+		_, err := c.dnsClient.Changes.Create(c.projectName, managedZone, change).Context(ctx).Do()
+		return err
+	})
 }
 
 // ListComputeZonesOptions are the options for listing compute zones.
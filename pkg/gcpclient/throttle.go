@@ -0,0 +1,109 @@
+package gcpclient
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// dnsQPS and dnsBurst limit the Cloud DNS calls of the process, which share the quotas of the project.
+	dnsQPS   = 10
+	dnsBurst = 20
+
+	// dnsMaxRetries is the number of times a rate limited Cloud DNS call is retried.
+	dnsMaxRetries    = 6
+	dnsMinRetryDelay = time.Second
+	dnsMaxRetryDelay = 30 * time.Second
+	// dnsRetryJitterRate spreads the backoff between half and all of its duration.
+	dnsRetryJitterRate = 1.0
+)
+
+// dnsRateLimiter is shared by all the Cloud DNS clients of the process, so that reconciling many DNSZones at once
+// does not exhaust the quotas of the project.
+var dnsRateLimiter = flowcontrol.NewTokenBucketRateLimiter(dnsQPS, dnsBurst)
+
+// callDNS makes a Cloud DNS call, waiting for the shared rate limiter before each attempt and retrying the call while
+// it is rate limited.
+func callDNS(ctx context.Context, call func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := dnsRateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+		err := call()
+		if err == nil || !isRateLimitError(err) || attempt == dnsMaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryDelay(err, attempt)):
+		}
+	}
+}
+
+// isRateLimitError returns true if the call failed because a rate limit or quota of the project was exceeded.
+func isRateLimitError(err error) bool {
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	if apiErr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded", "quotaExceeded":
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retrying a rate limited call: the delay asked by the Retry-After header
+// of the response when there is one, otherwise an exponential backoff of at most dnsMaxRetryDelay. The delay is
+// jittered so that the calls throttled together are not all retried at once.
+func retryDelay(err error, attempt int) time.Duration {
+	if apiErr, ok := err.(*googleapi.Error); ok {
+		if delay, ok := retryAfter(apiErr.Header, time.Now()); ok {
+			return delay + time.Duration(rand.Int63n(int64(dnsMinRetryDelay)))
+		}
+	}
+	delay := dnsMinRetryDelay << uint(attempt)
+	if delay > dnsMaxRetryDelay || delay <= 0 {
+		delay = dnsMaxRetryDelay
+	}
+	return wait.Jitter(delay/2, dnsRetryJitterRate)
+}
+
+// retryAfter returns the delay asked by the Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
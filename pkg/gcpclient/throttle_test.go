@@ -0,0 +1,112 @@
+package gcpclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsRateLimitError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "too many requests",
+			err:      &googleapi.Error{Code: http.StatusTooManyRequests},
+			expected: true,
+		},
+		{
+			name: "rate limit exceeded",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
+			},
+			expected: true,
+		},
+		{
+			name: "forbidden",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "forbidden"}},
+			},
+		},
+		{
+			name: "not found",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+		},
+		{
+			name: "other error",
+			err:  errors.New("connection reset"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isRateLimitError(tc.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 10, 16, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name          string
+		value         string
+		expectedDelay time.Duration
+		expectedOK    bool
+	}{
+		{
+			name: "no header",
+		},
+		{
+			name:          "seconds",
+			value:         "7",
+			expectedDelay: 7 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:          "date",
+			value:         now.Add(90 * time.Second).Format(http.TimeFormat),
+			expectedDelay: 90 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:       "past date",
+			value:      now.Add(-time.Minute).Format(http.TimeFormat),
+			expectedOK: true,
+		},
+		{
+			name:  "invalid",
+			value: "soon",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+			delay, ok := retryAfter(header, now)
+			assert.Equal(t, tc.expectedOK, ok, "unexpected ok")
+			assert.Equal(t, tc.expectedDelay, delay, "unexpected delay")
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	throttled := &googleapi.Error{Code: http.StatusTooManyRequests, Header: http.Header{}}
+	for attempt := 0; attempt <= dnsMaxRetries; attempt++ {
+		delay := retryDelay(throttled, attempt)
+		assert.True(t, delay <= dnsMaxRetryDelay, "delay %v of attempt %d exceeds maximum", delay, attempt)
+		assert.True(t, delay >= dnsMinRetryDelay/2, "delay %v of attempt %d below minimum", delay, attempt)
+	}
+
+	throttled.Header.Set("Retry-After", "20")
+	delay := retryDelay(throttled, 0)
+	assert.True(t, delay >= 20*time.Second && delay < 20*time.Second+dnsMinRetryDelay, "unexpected delay %v with Retry-After", delay)
+}
@@ -0,0 +1,139 @@
+// Package health provides the checks served by the /healthz and /readyz endpoints of the Hive controllers.
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Checker is a named health check. It matches the health checks of client-go, such as the leader election
+// HealthzAdaptor.
+type Checker interface {
+	// Name returns the name of the check, reported by the endpoints.
+	Name() string
+	// Check returns an error if the check fails.
+	Check(req *http.Request) error
+}
+
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (c *checkerFunc) Name() string {
+	return c.name
+}
+
+func (c *checkerFunc) Check(req *http.Request) error {
+	return c.check(req.Context())
+}
+
+// NewHandler returns a handler which runs all the given checks. It responds with 200 if all the checks pass, and
+// 500 otherwise. The body of the response lists the result of each check.
+func NewHandler(checks ...Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body bytes.Buffer
+		failed := false
+		for _, check := range checks {
+			if err := check.Check(req); err != nil {
+				failed = true
+				log.WithError(err).WithField("check", check.Name()).WithField("path", req.URL.Path).Warn("health check failed")
+				fmt.Fprintf(&body, "[-]%s failed: %v\n", check.Name(), err)
+				continue
+			}
+			fmt.Fprintf(&body, "[+]%s ok\n", check.Name())
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if failed {
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write(body.Bytes())
+	})
+}
+
+// NewAPIServerCheck returns a check which fails if the API server cannot be reached.
+func NewAPIServerCheck(client discovery.DiscoveryInterface) Checker {
+	return &checkerFunc{
+		name: "apiServer",
+		check: func(ctx context.Context) error {
+			_, err := client.ServerVersion()
+			return err
+		},
+	}
+}
+
+// NewCRDCheck returns a check which fails if any of the given kinds is not served by the API server in the given
+// group version, such as when the CRDs have not been installed or have been deleted.
+func NewCRDCheck(client discovery.DiscoveryInterface, groupVersion schema.GroupVersion, kinds ...string) Checker {
+	return &checkerFunc{
+		name: "crds",
+		check: func(ctx context.Context) error {
+			resources, err := client.ServerResourcesForGroupVersion(groupVersion.String())
+			if err != nil {
+				return err
+			}
+			served := sets.NewString()
+			for _, resource := range resources.APIResources {
+				served.Insert(resource.Kind)
+			}
+			if missing := sets.NewString(kinds...).Difference(served); missing.Len() > 0 {
+				return fmt.Errorf("kinds not served in %s: %v", groupVersion, missing.List())
+			}
+			return nil
+		},
+	}
+}
+
+// NewCertificateSecretCheck returns a check which fails if the certificate in the given TLS secret has expired or
+// is not yet valid. The check passes if the secret does not exist, as the secret is only created when the
+// certificate is issued by the OpenShift service CA.
+func NewCertificateSecretCheck(name string, client corev1client.SecretsGetter, namespace, secretName string) Checker {
+	return &checkerFunc{
+		name: name,
+		check: func(ctx context.Context) error {
+			secret, err := client.Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return checkCertificate(secret.Data[corev1.TLSCertKey], time.Now())
+		},
+	}
+}
+
+func checkCertificate(data []byte, now time.Time) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("certificate is not valid before %s", cert.NotBefore)
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("certificate expired at %s", cert.NotAfter)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package health
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testNamespace  = "hive"
+	testSecretName = "serving-cert"
+)
+
+func TestHandler(t *testing.T) {
+	passing := &checkerFunc{name: "passing", check: func(_ context.Context) error { return nil }}
+	failing := &checkerFunc{name: "failing", check: func(_ context.Context) error { return errors.New("broken") }}
+	tests := []struct {
+		name         string
+		checks       []Checker
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "no checks",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "passing checks",
+			checks:       []Checker{passing},
+			expectedCode: http.StatusOK,
+			expectedBody: "[+]passing ok\n",
+		},
+		{
+			name:         "failing check",
+			checks:       []Checker{passing, failing},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "[+]passing ok\n[-]failing failed: broken\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewHandler(test.checks...).ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+			assert.Equal(t, test.expectedCode, recorder.Code, "unexpected status code")
+			assert.Equal(t, test.expectedBody, recorder.Body.String(), "unexpected body")
+		})
+	}
+}
+
+func TestCRDCheck(t *testing.T) {
+	groupVersion := schema.GroupVersion{Group: "hive.openshift.io", Version: "v1"}
+	tests := []struct {
+		name        string
+		resources   []*metav1.APIResourceList
+		expectError bool
+	}{
+		{
+			name: "all kinds served",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: groupVersion.String(),
+				APIResources: []metav1.APIResource{{Kind: "ClusterDeployment"}, {Kind: "ClusterPool"}},
+			}},
+		},
+		{
+			name: "missing kind",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: groupVersion.String(),
+				APIResources: []metav1.APIResource{{Kind: "ClusterDeployment"}},
+			}},
+			expectError: true,
+		},
+		{
+			name:        "group version not served",
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			client.Discovery().(*fakediscovery.FakeDiscovery).Resources = test.resources
+			err := NewCRDCheck(client.Discovery(), groupVersion, "ClusterDeployment", "ClusterPool").Check(httptest.NewRequest("GET", "/readyz", nil))
+			if test.expectError {
+				assert.Error(t, err, "expected check to fail")
+			} else {
+				assert.NoError(t, err, "expected check to pass")
+			}
+		})
+	}
+}
+
+func TestCertificateSecretCheck(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		existing    []runtime.Object
+		expectError bool
+	}{
+		{
+			name: "no secret",
+		},
+		{
+			name:     "valid certificate",
+			existing: []runtime.Object{testSecret(t, now.Add(-time.Hour), now.Add(time.Hour))},
+		},
+		{
+			name:        "expired certificate",
+			existing:    []runtime.Object{testSecret(t, now.Add(-2*time.Hour), now.Add(-time.Hour))},
+			expectError: true,
+		},
+		{
+			name:        "certificate not yet valid",
+			existing:    []runtime.Object{testSecret(t, now.Add(time.Hour), now.Add(2*time.Hour))},
+			expectError: true,
+		},
+		{
+			name: "invalid certificate",
+			existing: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")},
+			}},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(test.existing...)
+			err := NewCertificateSecretCheck("cert", client.CoreV1(), testNamespace, testSecretName).Check(httptest.NewRequest("GET", "/readyz", nil))
+			if test.expectError {
+				assert.Error(t, err, "expected check to fail")
+			} else {
+				assert.NoError(t, err, "expected check to pass")
+			}
+		})
+	}
+}
+
+func testSecret(t *testing.T, notBefore, notAfter time.Time) *corev1.Secret {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "hiveadmission"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
@@ -0,0 +1,141 @@
+// Package imagemirror resolves the mirrors of the images pulled by the install jobs from the ImageContentSourcePolicies
+// and ImageDigestMirrorSets of the hub, so that clusters can be installed from disconnected hubs without overriding
+// every image.
+package imagemirror
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+var (
+	// imageContentSourcePolicyListGVK is the list of the ImageContentSourcePolicies of OpenShift 4.x hubs.
+	imageContentSourcePolicyListGVK = schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1alpha1", Kind: "ImageContentSourcePolicyList"}
+	// imageDigestMirrorSetListGVK is the list of the ImageDigestMirrorSets replacing the ImageContentSourcePolicies.
+	imageDigestMirrorSetListGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ImageDigestMirrorSetList"}
+)
+
+// RepositoryMirrors are the mirrors of a source repository, in the order they are tried.
+type RepositoryMirrors struct {
+	// Source is the repository, or the registry and namespace of the repositories, which is mirrored.
+	Source string
+	// Mirrors are the repositories the images of the source are mirrored to.
+	Mirrors []string
+}
+
+// Mirrors are the mirrors of the repositories configured on the hub.
+type Mirrors []RepositoryMirrors
+
+// Mirror returns the image pulled from the first mirror of its repository, or the image itself when it is not
+// mirrored. Like the mirrors of the nodes, only the images referenced by digest are mirrored, and the most specific
+// source matching the repository of the image is used.
+func (m Mirrors) Mirror(image string) string {
+	at := strings.Index(image, "@")
+	if at < 0 {
+		return image
+	}
+	repository, digest := image[:at], image[at:]
+	for _, rm := range m.sorted() {
+		if len(rm.Mirrors) == 0 {
+			continue
+		}
+		if repository == rm.Source {
+			return rm.Mirrors[0] + digest
+		}
+		if strings.HasPrefix(repository, rm.Source+"/") {
+			return rm.Mirrors[0] + strings.TrimPrefix(repository, rm.Source) + digest
+		}
+	}
+	return image
+}
+
+// sorted returns the mirrors with the most specific sources first.
+func (m Mirrors) sorted() Mirrors {
+	sorted := make(Mirrors, len(m))
+	copy(sorted, m)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Source) > len(sorted[j].Source)
+	})
+	return sorted
+}
+
+// Lister lists the mirrors configured on the hub.
+type Lister interface {
+	// List returns the mirrors of the ImageContentSourcePolicies and ImageDigestMirrorSets of the hub. Hubs which do
+	// not serve either resource have no mirrors.
+	List(logger log.FieldLogger) (Mirrors, error)
+}
+
+// NewLister returns a lister of the mirrors configured on the hub.
+func NewLister(c client.Client) Lister {
+	return &lister{client: c}
+}
+
+type lister struct {
+	client client.Client
+}
+
+func (l *lister) List(logger log.FieldLogger) (Mirrors, error) {
+	var mirrors Mirrors
+	icsps, err := l.list(imageContentSourcePolicyListGVK, logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, icsp := range icsps {
+		mirrors = append(mirrors, repositoryMirrors(icsp, "repositoryDigestMirrors")...)
+	}
+	idmss, err := l.list(imageDigestMirrorSetListGVK, logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, idms := range idmss {
+		mirrors = append(mirrors, repositoryMirrors(idms, "imageDigestMirrors")...)
+	}
+	return mirrors, nil
+}
+
+// list returns the objects of the list kind, or none when the hub does not serve the kind.
+func (l *lister) list(gvk schema.GroupVersionKind, logger log.FieldLogger) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	switch err := l.client.List(context.Background(), list); {
+	case meta.IsNoMatchError(err), apierrors.IsNotFound(err):
+		logger.WithField("kind", gvk.Kind).Debug("kind is not served by the hub, no mirrors")
+		return nil, nil
+	case err != nil:
+		logger.WithError(err).WithField("kind", gvk.Kind).Log(controllerutils.LogLevel(err), "could not list image mirrors")
+		return nil, errors.Wrapf(err, "could not list %s", gvk.Kind)
+	}
+	return list.Items, nil
+}
+
+// repositoryMirrors returns the mirrors of the field of the spec of the object.
+func repositoryMirrors(obj unstructured.Unstructured, field string) Mirrors {
+	entries, _, _ := unstructured.NestedSlice(obj.Object, "spec", field)
+	var mirrors Mirrors
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		source, _, _ := unstructured.NestedString(entryMap, "source")
+		repos, _, _ := unstructured.NestedStringSlice(entryMap, "mirrors")
+		if source == "" {
+			continue
+		}
+		mirrors = append(mirrors, RepositoryMirrors{Source: source, Mirrors: repos})
+	}
+	return mirrors
+}
@@ -0,0 +1,116 @@
+package imagemirror
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testDigest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+)
+
+func TestMirror(t *testing.T) {
+	mirrors := Mirrors{
+		{
+			Source:  "quay.io/openshift-release-dev",
+			Mirrors: []string{"mirror.example.com/openshift"},
+		},
+		{
+			Source:  "quay.io/openshift-release-dev/ocp-v4.0-art-dev",
+			Mirrors: []string{"mirror.example.com/ocp/release", "backup.example.com/ocp/release"},
+		},
+		{
+			Source: "registry.example.com/unmirrored",
+		},
+	}
+	tests := []struct {
+		name          string
+		image         string
+		expectedImage string
+	}{
+		{
+			name:          "repository mirrored",
+			image:         "quay.io/openshift-release-dev/ocp-v4.0-art-dev" + testDigest,
+			expectedImage: "mirror.example.com/ocp/release" + testDigest,
+		},
+		{
+			name:          "namespace mirrored",
+			image:         "quay.io/openshift-release-dev/ocp-release" + testDigest,
+			expectedImage: "mirror.example.com/openshift/ocp-release" + testDigest,
+		},
+		{
+			name:          "referenced by tag",
+			image:         "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64",
+			expectedImage: "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64",
+		},
+		{
+			name:          "repository not mirrored",
+			image:         "quay.io/openshift/hive" + testDigest,
+			expectedImage: "quay.io/openshift/hive" + testDigest,
+		},
+		{
+			name:          "repository prefix not matching",
+			image:         "quay.io/openshift-release-dev-extra/ocp-release" + testDigest,
+			expectedImage: "quay.io/openshift-release-dev-extra/ocp-release" + testDigest,
+		},
+		{
+			name:          "source without mirrors",
+			image:         "registry.example.com/unmirrored/image" + testDigest,
+			expectedImage: "registry.example.com/unmirrored/image" + testDigest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedImage, mirrors.Mirror(test.image), "unexpected image")
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{imageContentSourcePolicyListGVK, imageDigestMirrorSetListGVK} {
+		s.AddKnownTypeWithName(gvk, &unstructured.UnstructuredList{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind[:len(gvk.Kind)-len("List")]), &unstructured.Unstructured{})
+	}
+	icsp := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "operator.openshift.io/v1alpha1",
+		"kind":       "ImageContentSourcePolicy",
+		"metadata":   map[string]interface{}{"name": "release"},
+		"spec": map[string]interface{}{
+			"repositoryDigestMirrors": []interface{}{
+				map[string]interface{}{
+					"source":  "quay.io/openshift-release-dev/ocp-release",
+					"mirrors": []interface{}{"mirror.example.com/ocp/release"},
+				},
+			},
+		},
+	}}
+	idms := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "ImageDigestMirrorSet",
+		"metadata":   map[string]interface{}{"name": "art"},
+		"spec": map[string]interface{}{
+			"imageDigestMirrors": []interface{}{
+				map[string]interface{}{
+					"source":  "quay.io/openshift-release-dev/ocp-v4.0-art-dev",
+					"mirrors": []interface{}{"mirror.example.com/ocp/art", "backup.example.com/ocp/art"},
+				},
+			},
+		},
+	}}
+	c := fake.NewFakeClientWithScheme(s, icsp, idms)
+	mirrors, err := NewLister(c).List(log.WithField("test", "TestList"))
+	require.NoError(t, err, "unexpected error listing mirrors")
+	assert.Equal(t, Mirrors{
+		{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release"}},
+		{Source: "quay.io/openshift-release-dev/ocp-v4.0-art-dev", Mirrors: []string{"mirror.example.com/ocp/art", "backup.example.com/ocp/art"}},
+	}, mirrors, "unexpected mirrors")
+}
@@ -14,6 +14,8 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imagemirror"
 )
 
 const (
@@ -22,8 +24,9 @@ const (
 )
 
 // GenerateImageSetJob creates a job to determine the installer image for a ClusterImageSet
-// given a release image
-func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAccountName string) *batchv1.Job {
+// given a release image. The release and hive images are pulled from their mirrors when they
+// are mirrored on the hub.
+func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAccountName string, imageMirrors imagemirror.Mirrors) *batchv1.Job {
 	logger := log.WithFields(log.Fields{
 		"clusterdeployment": types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String(),
 	})
@@ -42,7 +45,7 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 		InitContainers: []corev1.Container{
 			{
 				Name:            "release",
-				Image:           releaseImage,
+				Image:           imageMirrors.Mirror(releaseImage),
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				Command:         []string{"/bin/sh", "-c"},
 				Args:            []string{"cp -v /release-manifests/image-references /common/image-references"},
@@ -52,7 +55,7 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 		Containers: []corev1.Container{
 			{
 				Name:            "hiveutil",
-				Image:           images.GetHiveImage(),
+				Image:           imageMirrors.Mirror(images.GetHiveImage()),
 				ImagePullPolicy: images.GetHiveImagePullPolicy(),
 				Command:         []string{"/usr/bin/hiveutil"},
 				Args: []string{
@@ -80,6 +83,7 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 		ServiceAccountName: serviceAccountName,
 		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: constants.GetMergedPullSecretName(cd)}},
 	}
+	controllerutils.AddJobPodScheduling(&podSpec, logger)
 
 	completions := int32(1)
 	// make sure the deadline is small enough so that the controller can
@@ -6,11 +6,28 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/imagemirror"
+)
+
+const (
+	testDigest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
 )
 
 func TestGenerateImageSetJob(t *testing.T) {
-	job := GenerateImageSetJob(testClusterDeployment(), testImageSet().Spec.ReleaseImage, "test-service-account")
+	job := GenerateImageSetJob(testClusterDeployment(), testImageSet().Spec.ReleaseImage, "test-service-account", nil)
+	validateJob(t, job)
+}
+
+func TestGenerateImageSetJobWithMirrors(t *testing.T) {
+	mirrors := imagemirror.Mirrors{{
+		Source:  "quay.io/openshift-release-dev/ocp-release",
+		Mirrors: []string{"mirror.example.com/ocp/release"},
+	}}
+	job := GenerateImageSetJob(testClusterDeployment(), "quay.io/openshift-release-dev/ocp-release"+testDigest, "test-service-account", mirrors)
 	validateJob(t, job)
+	if image := job.Spec.Template.Spec.InitContainers[0].Image; image != "mirror.example.com/ocp/release"+testDigest {
+		t.Errorf("unexpected release image: %s", image)
+	}
 }
 
 func testClusterDeployment() *hivev1.ClusterDeployment {
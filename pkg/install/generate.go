@@ -2,7 +2,6 @@ package install
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -18,41 +17,32 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
 	"github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/imagemirror"
+	"github.com/openshift/hive/pkg/platform"
 )
 
 const (
-	azureAuthDir       = "/.azure"
-	azureAuthFile      = azureAuthDir + "/osServicePrincipal.json"
-	gcpAuthDir         = "/.gcp"
-	gcpAuthFile        = gcpAuthDir + "/" + constants.GCPCredentialsName
-	openStackCloudsDir = "/etc/openstack"
-	openStackCADir     = "/etc/openstack-ca"
-	vsphereCloudsDir   = "/vsphere"
-	ovirtCloudsDir     = "/.ovirt"
-	ovirtCADir         = "/.ovirt-ca"
-
 	// SSHPrivateKeyDir is the directory where the generated Job will mount the ssh secret to
 	SSHPrivateKeyDir = "/sshkeys"
 
-	// LibvirtSSHPrivateKeyDir is the directory where the generated Job will mount the libvirt ssh secret to
-	LibvirtSSHPrivateKeyDir = "/libvirtsshkeys"
+	// CredentialsManifestsDir is the directory where the generated Job will mount the credentials manifests secret to
+	CredentialsManifestsDir = "/credentials-manifests"
 )
 
 var (
 	// SSHPrivateKeyFilePath is the path to the private key contents (from the SSH secret)
 	SSHPrivateKeyFilePath = fmt.Sprintf("%s/%s", SSHPrivateKeyDir, constants.SSHPrivateKeySecretKey)
-
-	// LibvirtSSHPrivateKeyFilePath is the path to the private key contents (from the libvirt SSH secret)
-	LibvirtSSHPrivateKeyFilePath = fmt.Sprintf("%s/%s", LibvirtSSHPrivateKeyDir, constants.SSHPrivateKeySecretKey)
 )
 
-// InstallerPodSpec generates a spec for an installer pod.
+// InstallerPodSpec generates a spec for an installer pod. The installer, CLI and install manager images are pulled
+// from their mirrors when they are mirrored on the hub.
 func InstallerPodSpec(
 	cd *hivev1.ClusterDeployment,
 	provisionName string,
 	releaseImage string,
 	serviceAccountName string,
 	extraEnvVars []corev1.EnvVar,
+	imageMirrors imagemirror.Mirrors,
 ) (*corev1.PodSpec, error) {
 
 	if cd.Spec.Provisioning == nil {
@@ -126,134 +116,14 @@ func InstallerPodSpec(
 		},
 	}
 
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		env = append(
-			env,
-			corev1.EnvVar{
-				Name: "AWS_ACCESS_KEY_ID",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: cd.Spec.Platform.AWS.CredentialsSecretRef,
-						Key:                  constants.AWSAccessKeyIDSecretKey,
-					},
-				},
-			},
-			corev1.EnvVar{
-				Name: "AWS_SECRET_ACCESS_KEY",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: cd.Spec.Platform.AWS.CredentialsSecretRef,
-						Key:                  constants.AWSSecretAccessKeySecretKey,
-					},
-				},
-			},
-		)
-	case cd.Spec.Platform.Azure != nil:
-		volumes = append(volumes, corev1.Volume{
-			Name: "azure",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Platform.Azure.CredentialsSecretRef.Name,
-				},
-			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "azure",
-			MountPath: azureAuthDir,
-		})
-		env = append(env, corev1.EnvVar{
-			Name:  "AZURE_AUTH_LOCATION",
-			Value: azureAuthFile,
-		})
-	case cd.Spec.Platform.GCP != nil:
-		volumes = append(volumes, corev1.Volume{
-			Name: "gcp",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Platform.GCP.CredentialsSecretRef.Name,
-				},
-			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "gcp",
-			MountPath: gcpAuthDir,
-		})
-		env = append(env, corev1.EnvVar{
-			Name:  "GOOGLE_CREDENTIALS",
-			Value: gcpAuthFile,
-		})
-	case cd.Spec.Platform.OpenStack != nil:
-		volumes = append(volumes, corev1.Volume{
-			Name: "openstack",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name,
-				},
-			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "openstack",
-			MountPath: openStackCloudsDir,
-		})
-		if cd.Spec.Platform.OpenStack.CertificatesSecretRef != nil && cd.Spec.Platform.OpenStack.CertificatesSecretRef.Name != "" {
-			volumes = append(volumes, corev1.Volume{
-				Name: "openstack-certificates",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: cd.Spec.Platform.OpenStack.CertificatesSecretRef.Name,
-					},
-				},
-			})
-			volumeMounts = append(volumeMounts, corev1.VolumeMount{
-				Name:      "openstack-certificates",
-				MountPath: openStackCADir,
-			})
-		}
-	case cd.Spec.Platform.VSphere != nil:
-		volumes = append(volumes, corev1.Volume{
-			Name: "vsphere-certificates",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Platform.VSphere.CertificatesSecretRef.Name,
-				},
-			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "vsphere-certificates",
-			MountPath: vsphereCloudsDir,
-		})
-		env = append(env, vSphereCredsEnvVars(cd.Spec.Platform.VSphere.CredentialsSecretRef.Name)...)
-	case cd.Spec.Platform.Ovirt != nil:
-		volumes = append(volumes,
-			corev1.Volume{
-				Name: "ovirt-credentials",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name,
-					},
-				},
-			},
-			corev1.Volume{
-				Name: "ovirt-certificates",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: cd.Spec.Platform.Ovirt.CertificatesSecretRef.Name,
-					},
-				},
-			},
-		)
-		volumeMounts = append(volumeMounts,
-			corev1.VolumeMount{
-				Name:      "ovirt-credentials",
-				MountPath: ovirtCloudsDir,
-			},
-			corev1.VolumeMount{
-				Name:      "ovirt-certificates",
-				MountPath: ovirtCADir,
-			},
-		)
-		env = append(env, oVirtCredsEnvVars(cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name)...)
+	var creds *platform.PodCredentials
+	if actuator := platform.ForClusterDeployment(cd); actuator != nil {
+		creds = actuator.InstallerPodCredentials(cd)
+	}
+	if creds != nil {
+		env = append(env, creds.Env...)
+		volumes = append(volumes, creds.Volumes...)
+		volumeMounts = append(volumeMounts, creds.VolumeMounts...)
 	}
 
 	if releaseImage != "" {
@@ -289,41 +159,37 @@ func InstallerPodSpec(
 		)
 	}
 
-	if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil {
+	if cd.Spec.Provisioning.CredentialsManifestsSecretRef != nil {
 		volumes = append(volumes, corev1.Volume{
-			Name: "sshkeys",
+			Name: "credentials-manifests",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name,
+					SecretName: cd.Spec.Provisioning.CredentialsManifestsSecretRef.Name,
 				},
 			},
 		})
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "sshkeys",
-			MountPath: SSHPrivateKeyDir,
-		})
-		env = append(env, corev1.EnvVar{
-			Name:  constants.SSHPrivKeyPathEnvVar,
-			Value: SSHPrivateKeyFilePath,
+			Name:      "credentials-manifests",
+			MountPath: CredentialsManifestsDir,
 		})
 	}
 
-	if cd.Spec.Platform.BareMetal != nil && cd.Spec.Platform.BareMetal.LibvirtSSHPrivateKeySecretRef.Name != "" {
+	if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil {
 		volumes = append(volumes, corev1.Volume{
-			Name: "libvirtsshkeys",
+			Name: "sshkeys",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: cd.Spec.Platform.BareMetal.LibvirtSSHPrivateKeySecretRef.Name,
+					SecretName: cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name,
 				},
 			},
 		})
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "libvirtsshkeys",
-			MountPath: LibvirtSSHPrivateKeyDir,
+			Name:      "sshkeys",
+			MountPath: SSHPrivateKeyDir,
 		})
 		env = append(env, corev1.EnvVar{
-			Name:  constants.LibvirtSSHPrivKeyPathEnvVar,
-			Value: LibvirtSSHPrivateKeyFilePath,
+			Name:  constants.SSHPrivKeyPathEnvVar,
+			Value: SSHPrivateKeyFilePath,
 		})
 	}
 
@@ -338,26 +204,15 @@ func InstallerPodSpec(
 	if cd.Status.InstallerImage == nil {
 		return nil, fmt.Errorf("installer image not resolved")
 	}
-	installerImage := *cd.Status.InstallerImage
+	installerImage := imageMirrors.Mirror(*cd.Status.InstallerImage)
 
 	if cd.Status.CLIImage == nil {
 		return nil, fmt.Errorf("cli image not resolved")
 	}
-	cliImage := *cd.Status.CLIImage
+	cliImage := imageMirrors.Mirror(*cd.Status.CLIImage)
 
-	hiveArg := fmt.Sprintf("/usr/bin/hiveutil install-manager --work-dir /output --log-level debug %s %s", cd.Namespace, provisionName)
-	if cd.Spec.Platform.VSphere != nil {
-		// Add vSphere certificates to CA trust.
-		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", vsphereCloudsDir, hiveArg)
-	}
-	if cd.Spec.Platform.Ovirt != nil {
-		// Add oVirt certificates to CA trust.
-		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", ovirtCADir, hiveArg)
-	}
-	if cd.Spec.Platform.OpenStack != nil && cd.Spec.Platform.OpenStack.CertificatesSecretRef != nil && cd.Spec.Platform.OpenStack.CertificatesSecretRef.Name != "" {
-		// Add OpenStack certificates to CA trust.
-		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", openStackCADir, hiveArg)
-	}
+	// The certificates of the platform are added to the CA trust of the install manager.
+	hiveArg := creds.TrustCA(fmt.Sprintf("/usr/bin/hiveutil install-manager --work-dir /output --log-level debug %s %s", cd.Namespace, provisionName))
 
 	// This is used when scheduling the installer pod. It ensures that installer pods don't overwhelm
 	// a given node's memory.
@@ -390,7 +245,7 @@ func InstallerPodSpec(
 		},
 		{
 			Name:            "hive",
-			Image:           images.GetHiveImage(),
+			Image:           imageMirrors.Mirror(images.GetHiveImage()),
 			ImagePullPolicy: images.GetHiveImagePullPolicy(),
 			Env:             append(env, cd.Spec.Provisioning.InstallerEnv...),
 			Command:         []string{"/bin/sh", "-c"},
@@ -444,10 +299,11 @@ func GenerateInstallerJob(provision *hivev1.ClusterProvision) (*batchv1.Job, err
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
 				},
-				Spec: provision.Spec.PodSpec,
+				Spec: *provision.Spec.PodSpec.DeepCopy(),
 			},
 		},
 	}
+	utils.AddJobPodScheduling(&job.Spec.Template.Spec, pLog)
 
 	return job, nil
 }
@@ -466,15 +322,19 @@ func GetUninstallJobName(name string) string {
 func GenerateUninstallerJobForDeprovision(
 	req *hivev1.ClusterDeprovision) (*batchv1.Job, error) {
 
-	restartPolicy := corev1.RestartPolicyOnFailure
+	// Failed uninstall jobs are retried by the clusterdeprovision controller, which backs off between attempts.
+	restartPolicy := corev1.RestartPolicyNever
 
 	podSpec := corev1.PodSpec{
-		DNSPolicy:     corev1.DNSClusterFirst,
-		RestartPolicy: restartPolicy,
+		DNSPolicy:          corev1.DNSClusterFirst,
+		RestartPolicy:      restartPolicy,
+		ServiceAccountName: utils.UninstallServiceAccountName(req.Name),
+		// The uninstaller does not use the API, so there is no need to mount a token.
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
 	}
 
 	completions := int32(1)
-	backoffLimit := int32(123456) // effectively limitless
+	backoffLimit := int32(0)
 	labels := map[string]string{
 		constants.UninstallJobLabel:          "true",
 		constants.ClusterDeploymentNameLabel: req.Name,
@@ -496,375 +356,17 @@ func GenerateUninstallerJobForDeprovision(
 		},
 	}
 
-	switch {
-	case req.Spec.Platform.AWS != nil:
-		completeAWSDeprovisionJob(req, job)
-	case req.Spec.Platform.Azure != nil:
-		completeAzureDeprovisionJob(req, job)
-	case req.Spec.Platform.GCP != nil:
-		completeGCPDeprovisionJob(req, job)
-	case req.Spec.Platform.OpenStack != nil:
-		completeOpenStackDeprovisionJob(req, job)
-	case req.Spec.Platform.VSphere != nil:
-		completeVSphereDeprovisionJob(req, job)
-	case req.Spec.Platform.Ovirt != nil:
-		completeOvirtDeprovisionJob(req, job)
-	default:
+	actuator := platform.ForClusterDeprovision(req)
+	if actuator == nil {
 		return nil, errors.New("deprovision requests currently not supported for platform")
 	}
+	actuator.CompleteDeprovisionJob(req, job)
 
-	return job, nil
-}
-
-func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	credentialsSecret := ""
-	if len(req.Spec.Platform.AWS.CredentialsSecretRef.Name) > 0 {
-		credentialsSecret = req.Spec.Platform.AWS.CredentialsSecretRef.Name
-	}
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Command:         []string{"/usr/bin/hiveutil"},
-			Args: []string{
-				"aws-tag-deprovision",
-				"--loglevel",
-				"debug",
-				"--region",
-				req.Spec.Platform.AWS.Region,
-				fmt.Sprintf("kubernetes.io/cluster/%s=owned", req.Spec.InfraID),
-			},
-		},
+	// The termination message of a failed uninstaller tells the controller why it failed.
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
 	}
-	if len(req.Spec.ClusterID) > 0 {
-		// Also cleanup anything with the tag for the legacy cluster ID (credentials still using this for example)
-		containers[0].Args = append(containers[0].Args, fmt.Sprintf("openshiftClusterID=%s", req.Spec.ClusterID))
-	}
-	job.Spec.Template.Spec.Containers = containers
-	if len(credentialsSecret) > 0 {
-		containers[0].VolumeMounts = []corev1.VolumeMount{
-			{
-				Name:      "aws-creds",
-				MountPath: constants.AWSCredsMount,
-			},
-		}
-		job.Spec.Template.Spec.Volumes = []corev1.Volume{
-			{
-				Name: "aws-creds",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: credentialsSecret,
-					},
-				},
-			},
-		}
-	}
-}
+	utils.AddJobPodScheduling(&job.Spec.Template.Spec, log.WithField("clusterDeprovision", req.Name))
 
-func completeAzureDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	volumes := []corev1.Volume{}
-	volumeMounts := []corev1.VolumeMount{}
-	env := []corev1.EnvVar{}
-	volumes = append(volumes, corev1.Volume{
-		Name: "azure",
-		VolumeSource: corev1.VolumeSource{
-			Secret: &corev1.SecretVolumeSource{
-				SecretName: req.Spec.Platform.Azure.CredentialsSecretRef.Name,
-			},
-		},
-	})
-	volumeMounts = append(volumeMounts, corev1.VolumeMount{
-		Name:      "azure",
-		MountPath: azureAuthDir,
-	})
-	env = append(env, corev1.EnvVar{
-		Name:  "AZURE_AUTH_LOCATION",
-		Value: azureAuthFile,
-	})
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Env:             env,
-			Command:         []string{"/usr/bin/hiveutil"},
-			Args: []string{
-				"deprovision",
-				"azure",
-				"--loglevel",
-				"debug",
-				"--creds-dir",
-				azureAuthDir,
-				req.Spec.InfraID,
-			},
-			VolumeMounts: volumeMounts,
-		},
-	}
-	job.Spec.Template.Spec.Containers = containers
-	job.Spec.Template.Spec.Volumes = volumes
-
-}
-
-func completeGCPDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	volumes := []corev1.Volume{}
-	volumeMounts := []corev1.VolumeMount{}
-	env := []corev1.EnvVar{}
-	volumes = append(volumes, corev1.Volume{
-		Name: "gcp",
-		VolumeSource: corev1.VolumeSource{
-			Secret: &corev1.SecretVolumeSource{
-				SecretName: req.Spec.Platform.GCP.CredentialsSecretRef.Name,
-			},
-		},
-	})
-	volumeMounts = append(volumeMounts, corev1.VolumeMount{
-		Name:      "gcp",
-		MountPath: gcpAuthDir,
-	})
-	env = append(env, corev1.EnvVar{
-		Name:  "GOOGLE_CREDENTIALS",
-		Value: gcpAuthFile,
-	})
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Env:             env,
-			Command:         []string{"/usr/bin/hiveutil"},
-			Args: []string{
-				"deprovision",
-				"gcp",
-				"--loglevel",
-				"debug",
-				"--creds-dir",
-				gcpAuthDir,
-				"--region",
-				req.Spec.Platform.GCP.Region,
-				req.Spec.InfraID,
-			},
-			VolumeMounts: volumeMounts,
-		},
-	}
-	job.Spec.Template.Spec.Containers = containers
-	job.Spec.Template.Spec.Volumes = volumes
-}
-
-func completeOpenStackDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	volumes := []corev1.Volume{}
-	volumeMounts := []corev1.VolumeMount{}
-	env := []corev1.EnvVar{}
-	volumes = append(volumes, corev1.Volume{
-		Name: "openstack",
-		VolumeSource: corev1.VolumeSource{
-			Secret: &corev1.SecretVolumeSource{
-				SecretName: req.Spec.Platform.OpenStack.CredentialsSecretRef.Name,
-			},
-		},
-	})
-	volumeMounts = append(volumeMounts, corev1.VolumeMount{
-		Name:      "openstack",
-		MountPath: openStackCloudsDir,
-	})
-	if req.Spec.Platform.OpenStack.CertificatesSecretRef != nil && req.Spec.Platform.OpenStack.CertificatesSecretRef.Name != "" {
-		volumes = append(volumes, corev1.Volume{
-			Name: "openstack-certificates",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: req.Spec.Platform.OpenStack.CertificatesSecretRef.Name,
-				},
-			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "openstack-certificates",
-			MountPath: openStackCADir,
-		})
-	}
-	cmd := []string{"/usr/bin/hiveutil"}
-	args := []string{
-		"deprovision",
-		"openstack",
-		"--loglevel",
-		"debug",
-		"--creds-dir",
-		openStackCloudsDir,
-		"--cloud",
-		req.Spec.Platform.OpenStack.Cloud,
-		req.Spec.InfraID,
-	}
-	if req.Spec.Platform.OpenStack.CertificatesSecretRef != nil && req.Spec.Platform.OpenStack.CertificatesSecretRef.Name != "" {
-		args = []string{fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", openStackCADir, strings.Join(append(cmd, args...), " "))}
-		cmd = []string{"/bin/sh", "-c"}
-	}
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Env:             env,
-			Command:         cmd,
-			Args:            args,
-			VolumeMounts:    volumeMounts,
-		},
-	}
-	job.Spec.Template.Spec.Containers = containers
-	job.Spec.Template.Spec.Volumes = volumes
-}
-
-func completeVSphereDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	const vsphereCredsDir = "/vsphere-creds"
-	volumes := []corev1.Volume{}
-	volumeMounts := []corev1.VolumeMount{}
-	volumes = append(volumes,
-		corev1.Volume{
-			Name: "vsphere-creds",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: req.Spec.Platform.VSphere.CredentialsSecretRef.Name,
-				},
-			},
-		},
-		corev1.Volume{
-			Name: "vsphere-certificates",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: req.Spec.Platform.VSphere.CertificatesSecretRef.Name,
-				},
-			},
-		},
-	)
-	volumeMounts = append(volumeMounts,
-		corev1.VolumeMount{
-			Name:      "vsphere-creds",
-			MountPath: vsphereCredsDir,
-		},
-		corev1.VolumeMount{
-			Name:      "vsphere-certificates",
-			MountPath: vsphereCloudsDir,
-		},
-	)
-
-	env := vSphereCredsEnvVars(req.Spec.Platform.VSphere.CredentialsSecretRef.Name)
-
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Env:             env,
-			Command:         []string{"/bin/sh", "-c"},
-			Args: []string{
-				fmt.Sprintf(
-					"cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && "+
-						"update-ca-trust && "+
-						"/usr/bin/hiveutil deprovision vsphere --vsphere-vcenter %s --loglevel debug --creds-dir=%s %s",
-					vsphereCloudsDir,
-					req.Spec.Platform.VSphere.VCenter,
-					vsphereCredsDir,
-					req.Spec.InfraID,
-				),
-			},
-			VolumeMounts: volumeMounts,
-		},
-	}
-	job.Spec.Template.Spec.Containers = containers
-	job.Spec.Template.Spec.Volumes = volumes
-}
-
-func completeOvirtDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
-	volumes := []corev1.Volume{}
-	volumeMounts := []corev1.VolumeMount{}
-	volumes = append(volumes,
-		corev1.Volume{
-			Name: "ovirt-credentials",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: req.Spec.Platform.Ovirt.CredentialsSecretRef.Name,
-				},
-			},
-		},
-		corev1.Volume{
-			Name: "ovirt-certificates",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: req.Spec.Platform.Ovirt.CertificatesSecretRef.Name,
-				},
-			},
-		},
-	)
-	volumeMounts = append(volumeMounts,
-		corev1.VolumeMount{
-			Name:      "ovirt-credentials",
-			MountPath: ovirtCloudsDir,
-		},
-		corev1.VolumeMount{
-			Name:      "ovirt-certificates",
-			MountPath: ovirtCADir,
-		},
-	)
-
-	env := oVirtCredsEnvVars(req.Spec.Platform.Ovirt.CredentialsSecretRef.Name)
-
-	containers := []corev1.Container{
-		{
-			Name:            "deprovision",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveImagePullPolicy(),
-			Env:             env,
-			Command:         []string{"/bin/sh", "-c"},
-			Args: []string{
-				fmt.Sprintf(
-					"cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && "+
-						"update-ca-trust && "+
-						"/usr/bin/hiveutil deprovision ovirt --ovirt-cluster-id %s --loglevel debug --certs-dir=%s %s",
-					ovirtCADir,
-					req.Spec.Platform.Ovirt.ClusterID,
-					ovirtCloudsDir,
-					req.Spec.InfraID,
-				),
-			},
-			VolumeMounts: volumeMounts,
-		},
-	}
-	job.Spec.Template.Spec.Containers = containers
-	job.Spec.Template.Spec.Volumes = volumes
-}
-
-func vSphereCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
-	env := []corev1.EnvVar{}
-	env = append(
-		env,
-		corev1.EnvVar{
-			Name: constants.VSphereUsernameEnvVar,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
-					Key:                  constants.UsernameSecretKey,
-				},
-			},
-		},
-		corev1.EnvVar{
-			Name: constants.VSpherePasswordEnvVar,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
-					Key:                  constants.PasswordSecretKey,
-				},
-			},
-		},
-	)
-	return env
-}
-
-func oVirtCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
-	env := []corev1.EnvVar{}
-	env = append(
-		env,
-		corev1.EnvVar{
-			Name:  constants.OvirtConfigEnvVar,
-			Value: ovirtCloudsDir + "/" + constants.OvirtCredentialsName,
-		},
-	)
-	return env
+	return job, nil
 }
@@ -1,14 +1,22 @@
 package install
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/imagemirror"
 )
 
 var (
@@ -27,6 +35,49 @@ func TestGenerateDeprovision(t *testing.T) {
 	assert.NotNil(t, job)
 }
 
+func TestGenerateDeprovisionWithServiceEndpoints(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+		{Name: "ec2", URL: "https://ec2.example.com"},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr)
+	if assert.NoError(t, err) {
+		args := job.Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, args, "ec2=https://ec2.example.com", "expected service endpoint arg")
+		assert.Equal(t, "kubernetes.io/cluster/test-infra-id=owned", args[len(args)-2], "expected tag filters after flags")
+	}
+}
+
+func TestGenerateOvirtDeprovision(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		Ovirt: &hivev1.OvirtClusterDeprovision{
+			ClusterID:             "test-ovirt-cluster-id",
+			CredentialsSecretRef:  corev1.LocalObjectReference{Name: "ovirt-creds"},
+			CertificatesSecretRef: corev1.LocalObjectReference{Name: "ovirt-certs"},
+		},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr)
+	if assert.NoError(t, err) {
+		container := job.Spec.Template.Spec.Containers[0]
+		if assert.Len(t, container.Args, 1, "expected a single shell command") {
+			assert.Contains(t, container.Args[0], "update-ca-trust", "expected oVirt certificates to be trusted")
+			assert.True(t, strings.HasSuffix(container.Args[0], "hiveutil deprovision ovirt --ovirt-cluster-id test-ovirt-cluster-id --loglevel debug test-infra-id"), "unexpected deprovision command %q", container.Args[0])
+		}
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.OvirtConfigEnvVar, Value: "/.ovirt/" + constants.OvirtCredentialsName}, "expected oVirt config env var")
+		assert.Len(t, job.Spec.Template.Spec.Volumes, 2, "expected credentials and certificates volumes")
+	}
+}
+
+func TestGenerateDeprovisionWithPodScheduling(t *testing.T) {
+	os.Setenv(constants.PodSchedulingEnvVar, `{"nodeSelector":{"node-role.kubernetes.io/hive":""}}`)
+	defer os.Unsetenv(constants.PodSchedulingEnvVar)
+	job, err := GenerateUninstallerJobForDeprovision(testClusterDeprovision())
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"node-role.kubernetes.io/hive": ""}, job.Spec.Template.Spec.NodeSelector, "expected node selector of the pod scheduling configuration")
+	}
+}
+
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	return &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -58,6 +109,7 @@ func TestInstallerPodSpec(t *testing.T) {
 		pvcName            string
 		skipGatherLogs     bool
 		extraEnvVars       []corev1.EnvVar
+		imageMirrors       imagemirror.Mirrors
 		validate           func(*testing.T, *corev1.PodSpec, error)
 	}{
 		{
@@ -91,6 +143,55 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Images Pulled From Mirrors",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: pointer.StringPtr("quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:installer"),
+					CLIImage:       pointer.StringPtr("quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:cli"),
+				},
+			},
+			provisionName: "testprovision",
+			imageMirrors: imagemirror.Mirrors{{
+				Source:  "quay.io/openshift-release-dev",
+				Mirrors: []string{"mirror.example.com/openshift"},
+			}},
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				assert.Equal(t, "mirror.example.com/openshift/ocp-v4.0-art-dev@sha256:installer", actualPodSpec.Containers[0].Image, "Incorrect installer image")
+				assert.Equal(t, "mirror.example.com/openshift/ocp-v4.0-art-dev@sha256:cli", actualPodSpec.Containers[1].Image, "Incorrect cli image")
+			},
+		},
+		{
+			name: "Test Provision Pod OpenStack Certificates",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Platform: hivev1.Platform{
+						OpenStack: &hivev1openstack.Platform{
+							CredentialsSecretRef:  corev1.LocalObjectReference{Name: "openstack-creds"},
+							CertificatesSecretRef: &corev1.LocalObjectReference{Name: "openstack-certs"},
+						},
+					},
+					Provisioning: &hivev1.Provisioning{},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				if !assert.NoError(t, actualError) {
+					return
+				}
+				assert.Contains(t, actualPodSpec.Containers[2].VolumeMounts, corev1.VolumeMount{Name: "openstack", MountPath: "/etc/openstack"}, "expected clouds.yaml to be mounted")
+				assert.Contains(t, actualPodSpec.Containers[2].VolumeMounts, corev1.VolumeMount{Name: "openstack-certificates", MountPath: "/etc/openstack-ca"}, "expected certificates to be mounted")
+				assert.True(t, strings.HasPrefix(actualPodSpec.Containers[2].Args[0], "cp -vr /etc/openstack-ca/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && "), "expected certificates to be trusted")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -102,7 +203,8 @@ func TestInstallerPodSpec(t *testing.T) {
 				test.provisionName,
 				test.releaseImage,
 				test.serviceAccountName,
-				test.extraEnvVars)
+				test.extraEnvVars,
+				test.imageMirrors)
 
 			// Assert
 			test.validate(t, actualPodSpec, actualError)
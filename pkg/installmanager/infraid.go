@@ -0,0 +1,144 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+// maxInfraIDAttempts is the number of times the assets of the install are generated before giving up on getting an
+// infra ID which is not in use. The installer picks a new random suffix for the infra ID each time.
+const maxInfraIDAttempts = 3
+
+// generatedAssets are the files and directories written to the work dir by the installer when generating the assets
+// of the install. They are removed to generate the assets again with a new infra ID.
+var generatedAssets = []string{
+	".openshift_install_state.json",
+	"auth",
+	"bootstrap.ign",
+	"manifests",
+	"master.ign",
+	metadataRelativePath,
+	"openshift",
+	"worker.ign",
+}
+
+// generateAssetsWithUniqueInfraID generates the assets of the install until the infra ID picked by the installer is
+// neither the infra ID of another provision of the cluster deployment nor used by resources left in the cloud, which
+// would make the installer fail on conflicts with those resources or the cleanup destroy the new cluster.
+func (m *InstallManager) generateAssetsWithUniqueInfraID(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, icData []byte) error {
+	if provision.Spec.Region != "" {
+		cd = clusterDeploymentInRegion(cd, provision.Spec.Region)
+	}
+	for attempt := 1; ; attempt++ {
+		if err := m.generateAssets(provision); err != nil {
+			return err
+		}
+		// Failing to read the metadata is reported once the assets are generated.
+		_, metadata, err := m.readClusterMetadata(provision, m)
+		if err != nil {
+			return nil
+		}
+		logger := m.log.WithField("infraID", metadata.InfraID)
+		reason, err := m.infraIDInUse(cd, provision, metadata.InfraID)
+		if err != nil {
+			return err
+		}
+		if reason == "" {
+			logger.Info("infra ID not in use")
+			return nil
+		}
+		logger.WithField("reason", reason).Warn("infra ID in use")
+		if attempt == maxInfraIDAttempts {
+			return fmt.Errorf("infra ID still in use after generating the install assets %d times: %s", attempt, reason)
+		}
+		if err := m.removeGeneratedAssets(icData); err != nil {
+			return err
+		}
+		logger.Info("generating the install assets again for a new infra ID")
+	}
+}
+
+// infraIDInUse returns why the infra ID cannot be used by the provision, or an empty string if it can.
+func (m *InstallManager) infraIDInUse(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, infraID string) (string, error) {
+	if prev := provision.Spec.PrevInfraID; prev != nil && *prev == infraID {
+		return "infra ID of the previous provision", nil
+	}
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := m.DynamicClient.List(context.TODO(), provisions, client.InNamespace(provision.Namespace)); err != nil {
+		m.log.WithError(err).Error("error listing cluster provisions")
+		return "", errors.Wrap(err, "error listing cluster provisions")
+	}
+	for _, p := range provisions.Items {
+		if p.Name == provision.Name || p.Spec.ClusterDeploymentRef.Name != provision.Spec.ClusterDeploymentRef.Name {
+			continue
+		}
+		if p.Spec.InfraID != nil && *p.Spec.InfraID == infraID {
+			return fmt.Sprintf("infra ID of provision %s", p.Name), nil
+		}
+	}
+	inUse, err := m.infraIDInUseInCloud(cd, infraID, m.log)
+	if err != nil {
+		// The check is a safeguard. The install goes on when the cloud cannot be searched, as it did before.
+		m.log.WithError(err).Warn("could not check for cloud resources using the infra ID")
+		return "", nil
+	}
+	if inUse {
+		return "cloud resources tagged with the infra ID exist", nil
+	}
+	return "", nil
+}
+
+// removeGeneratedAssets removes the assets generated by the installer from the work dir and writes the install config
+// again, as the installer consumed it.
+func (m *InstallManager) removeGeneratedAssets(icData []byte) error {
+	for _, asset := range generatedAssets {
+		if err := os.RemoveAll(filepath.Join(m.WorkDir, asset)); err != nil {
+			m.log.WithError(err).WithField("asset", asset).Error("error removing generated asset")
+			return err
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(m.WorkDir, "install-config.yaml"), icData, 0644); err != nil {
+		m.log.WithError(err).Error("error writing install-config.yaml")
+		return err
+	}
+	return nil
+}
+
+// infraIDInUseInCloud returns true if cloud resources are tagged as belonging to a cluster with the infra ID. Only AWS
+// is searched.
+func infraIDInUseInCloud(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (bool, error) {
+	if cd.Spec.Platform.AWS == nil {
+		return false, nil
+	}
+	awsClient, err := awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region, cd.Spec.Platform.AWS.ServiceEndpoints)
+	if err != nil {
+		logger.WithError(err).Error("failed to create AWS client")
+		return false, err
+	}
+	inUse := false
+	err = awsClient.GetResourcesPages(
+		&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+				Key: aws.String(kubernetesKeyPrefix + infraID),
+			}},
+		},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			inUse = len(page.ResourceTagMappingList) > 0
+			return !inUse && !lastPage
+		},
+	)
+	return inUse, err
+}
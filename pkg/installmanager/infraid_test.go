@@ -0,0 +1,124 @@
+package installmanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const testInfraID = "test-cluster-fe953"
+
+func TestInfraIDInUse(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	otherProvision := func(name, cdName, infraID string) runtime.Object {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: hivev1.ClusterProvisionSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				InfraID:              pointer.StringPtr(infraID),
+			},
+		}
+	}
+	cases := []struct {
+		name           string
+		prevInfraID    *string
+		existing       []runtime.Object
+		inUseInCloud   bool
+		cloudErr       error
+		expectedReason string
+	}{
+		{
+			name: "not in use",
+		},
+		{
+			name:           "previous provision",
+			prevInfraID:    pointer.StringPtr(testInfraID),
+			expectedReason: "infra ID of the previous provision",
+		},
+		{
+			name:           "other provision",
+			existing:       []runtime.Object{otherProvision("other-provision", testDeploymentName, testInfraID)},
+			expectedReason: "infra ID of provision other-provision",
+		},
+		{
+			name:     "provision of other cluster deployment",
+			existing: []runtime.Object{otherProvision("other-provision", "other-deployment", testInfraID)},
+		},
+		{
+			name:     "other infra ID",
+			existing: []runtime.Object{otherProvision("other-provision", testDeploymentName, "test-cluster-abcde")},
+		},
+		{
+			name:           "cloud resources",
+			inUseInCloud:   true,
+			expectedReason: "cloud resources tagged with the infra ID exist",
+		},
+		{
+			name:     "cloud search error",
+			cloudErr: fmt.Errorf("access denied"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			provision := testClusterProvision()
+			provision.Spec.PrevInfraID = tc.prevInfraID
+			mocks := setupDefaultMocks(t, append(tc.existing, provision)...)
+			defer mocks.mockCtrl.Finish()
+			m := &InstallManager{
+				log:           log.WithField("test", t.Name()),
+				DynamicClient: mocks.fakeKubeClient,
+				infraIDInUseInCloud: func(_ *hivev1.ClusterDeployment, infraID string, _ log.FieldLogger) (bool, error) {
+					assert.Equal(t, testInfraID, infraID, "unexpected infra ID")
+					return tc.inUseInCloud, tc.cloudErr
+				},
+			}
+			reason, err := m.infraIDInUse(testClusterDeployment(), provision, testInfraID)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+		})
+	}
+}
+
+func TestRemoveGeneratedAssets(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "removegeneratedassets")
+	require.NoError(t, err, "unexpected error creating work dir")
+	defer os.RemoveAll(workDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(workDir, "manifests"), 0755), "unexpected error creating manifests dir")
+	for _, file := range []string{"manifests/cluster-config.yaml", metadataRelativePath, ".openshift_install_state.json", installerBinary} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, file), []byte("{}"), 0644), "unexpected error writing %s", file)
+	}
+
+	m := &InstallManager{
+		log:     log.WithField("test", t.Name()),
+		WorkDir: workDir,
+	}
+	require.NoError(t, m.removeGeneratedAssets([]byte("INSTALL_CONFIG: FAKE")), "unexpected error removing generated assets")
+
+	for _, file := range []string{"manifests", metadataRelativePath, ".openshift_install_state.json"} {
+		_, err := os.Stat(filepath.Join(workDir, file))
+		assert.True(t, os.IsNotExist(err), "expected %s to be removed", file)
+	}
+	_, err = os.Stat(filepath.Join(workDir, installerBinary))
+	assert.NoError(t, err, "expected installer binary to be kept")
+	icData, err := ioutil.ReadFile(filepath.Join(workDir, "install-config.yaml"))
+	require.NoError(t, err, "unexpected error reading install-config.yaml")
+	assert.Equal(t, "INSTALL_CONFIG: FAKE", string(icData), "unexpected install-config.yaml")
+}
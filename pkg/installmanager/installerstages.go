@@ -0,0 +1,182 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// installerStagePatterns match the lines of the installer log written when the installer reaches a stage of the
+// install.
+var installerStagePatterns = []struct {
+	stage   hivev1.InstallerStage
+	pattern *regexp.Regexp
+}{
+	{
+		stage:   hivev1.InstallerStageInfrastructureProvisioned,
+		pattern: regexp.MustCompile(`Waiting up to \S+ for the Kubernetes API at`),
+	},
+	{
+		stage:   hivev1.InstallerStageBootstrapComplete,
+		pattern: regexp.MustCompile(`Destroying the bootstrap resources\.\.\.`),
+	},
+	{
+		stage:   hivev1.InstallerStageBootstrapDestroyed,
+		pattern: regexp.MustCompile(`Waiting up to \S+ for the cluster at \S+ to initialize`),
+	},
+	{
+		stage:   hivev1.InstallerStageInstallComplete,
+		pattern: regexp.MustCompile(`Install complete!`),
+	},
+}
+
+// infrastructureCreationPattern matches the line of the installer log written when the installer starts creating the
+// cloud infrastructure of the cluster.
+var infrastructureCreationPattern = regexp.MustCompile(`Creating infrastructure resources\.\.\.`)
+
+// infrastructureCreated returns false when a failed installer left no sign of having started creating the cloud
+// infrastructure of the cluster: neither the line of the installer log written when it starts, nor terraform state in
+// its working directory.
+func infrastructureCreated(workDir, installLog string) bool {
+	if infrastructureCreationPattern.MatchString(installLog) {
+		return true
+	}
+	stateFiles, _ := filepath.Glob(filepath.Join(workDir, terraformStateGlob))
+	return len(stateFiles) > 0
+}
+
+// installerStageForLogLine returns the stage of the install reached by the installer when it wrote the line of the
+// installer log.
+func installerStageForLogLine(line string) (hivev1.InstallerStage, bool) {
+	for _, p := range installerStagePatterns {
+		if p.pattern.MatchString(line) {
+			return p.stage, true
+		}
+	}
+	return "", false
+}
+
+// recordInstallerStage records on the ClusterProvision that the installer reached the stage, unless it was already
+// recorded.
+func (m *InstallManager) recordInstallerStage(provision *hivev1.ClusterProvision, stage hivev1.InstallerStage) error {
+	m.log.WithField("installerStage", stage).Info("installer reached stage")
+	now := metav1.Now()
+	return m.updateClusterProvision(
+		provision,
+		m,
+		func(provision *hivev1.ClusterProvision) {
+			for _, s := range provision.Spec.InstallerStages {
+				if s.Stage == stage {
+					return
+				}
+			}
+			provision.Spec.InstallerStages = append(provision.Spec.InstallerStages, hivev1.InstallerStageTransition{
+				Stage: stage,
+				Time:  now,
+			})
+		},
+	)
+}
+
+// stageWatchdog aborts the install when the installer does not reach the next stage of the install within the timeout
+// of the stage, by cancelling the context of the installer.
+type stageWatchdog struct {
+	timeouts hivev1.ProvisionStageTimeouts
+	logger   log.FieldLogger
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	mutex sync.Mutex
+	timer *time.Timer
+	// generation identifies the current timer, so that a timer firing while it is replaced is ignored.
+	generation int
+	stopped    bool
+	expired    string
+}
+
+func newStageWatchdog(timeouts *hivev1.ProvisionStageTimeouts, logger log.FieldLogger) *stageWatchdog {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &stageWatchdog{
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if timeouts != nil {
+		w.timeouts = *timeouts
+	}
+	return w
+}
+
+// start starts the timeout of the infrastructure stage, at the start of the install.
+func (w *stageWatchdog) start() {
+	w.restart("infrastructure", w.timeouts.Infrastructure)
+}
+
+// stageReached starts the timeout of the stage following the stage reached by the installer.
+func (w *stageWatchdog) stageReached(stage hivev1.InstallerStage) {
+	switch stage {
+	case hivev1.InstallerStageInfrastructureProvisioned:
+		w.restart("bootstrap", w.timeouts.Bootstrap)
+	case hivev1.InstallerStageBootstrapComplete:
+		w.restart("cluster operators", w.timeouts.ClusterOperators)
+	case hivev1.InstallerStageInstallComplete:
+		w.stop()
+	}
+}
+
+// stop stops the timeout of the current stage, once the install is over. Stages reached afterwards are ignored.
+func (w *stageWatchdog) stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.stopped = true
+	w.stopTimer()
+}
+
+func (w *stageWatchdog) restart(stage string, timeout *metav1.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.stopTimer()
+	if timeout == nil || w.stopped || w.expired != "" {
+		return
+	}
+	generation := w.generation
+	w.timer = time.AfterFunc(timeout.Duration, func() {
+		w.expire(generation, fmt.Sprintf("the %s stage of the install did not complete within %s", stage, timeout.Duration))
+	})
+}
+
+func (w *stageWatchdog) stopTimer() {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	w.generation++
+}
+
+func (w *stageWatchdog) expire(generation int, message string) {
+	w.mutex.Lock()
+	if w.stopped || generation != w.generation {
+		w.mutex.Unlock()
+		return
+	}
+	w.expired = message
+	w.mutex.Unlock()
+	w.logger.WithField("reason", message).Error("aborting install")
+	w.cancel()
+}
+
+// expiredMessage returns which stage exceeded its timeout, or an empty string if none did.
+func (w *stageWatchdog) expiredMessage() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.expired
+}
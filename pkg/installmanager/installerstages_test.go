@@ -0,0 +1,203 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestInstallerStageForLogLine(t *testing.T) {
+	cases := []struct {
+		line          string
+		expectedStage hivev1.InstallerStage
+	}{
+		{
+			line: `time="2020-10-16T10:00:00Z" level=info msg="Creating infrastructure resources..."`,
+		},
+		{
+			line:          `time="2020-10-16T10:05:00Z" level=info msg="Waiting up to 20m0s for the Kubernetes API at https://api.test.example.com:6443..."`,
+			expectedStage: hivev1.InstallerStageInfrastructureProvisioned,
+		},
+		{
+			line: `time="2020-10-16T10:06:00Z" level=info msg="Waiting up to 40m0s for bootstrapping to complete..."`,
+		},
+		{
+			line:          `time="2020-10-16T10:20:00Z" level=info msg="Destroying the bootstrap resources..."`,
+			expectedStage: hivev1.InstallerStageBootstrapComplete,
+		},
+		{
+			line:          `time="2020-10-16T10:22:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."`,
+			expectedStage: hivev1.InstallerStageBootstrapDestroyed,
+		},
+		{
+			line:          `time="2020-10-16T10:40:00Z" level=info msg="Install complete!"`,
+			expectedStage: hivev1.InstallerStageInstallComplete,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.line, func(t *testing.T) {
+			stage, ok := installerStageForLogLine(tc.line)
+			assert.Equal(t, tc.expectedStage != "", ok, "unexpected match")
+			assert.Equal(t, tc.expectedStage, stage, "unexpected stage")
+		})
+	}
+}
+
+func TestInfrastructureCreated(t *testing.T) {
+	cases := []struct {
+		name           string
+		installLog     string
+		terraformState bool
+		expected       bool
+	}{
+		{
+			name:       "failed before infrastructure",
+			installLog: `level=fatal msg="failed to fetch Master Machines: failed to load asset \"Install Config\""`,
+		},
+		{
+			name:       "infrastructure creation started",
+			installLog: `level=info msg="Creating infrastructure resources..."`,
+			expected:   true,
+		},
+		{
+			name:           "terraform state left",
+			terraformState: true,
+			expected:       true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "infrastructurecreated")
+			require.NoError(t, err, "unexpected error creating work dir")
+			defer os.RemoveAll(workDir)
+			if tc.terraformState {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, "terraform.tfstate"), []byte("{}"), 0644), "unexpected error writing terraform state")
+			}
+			assert.Equal(t, tc.expected, infrastructureCreated(workDir, tc.installLog), "unexpected infrastructure created")
+		})
+	}
+}
+
+func TestRecordInstallerStage(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	mocks := setupDefaultMocks(t, testClusterProvision())
+	defer mocks.mockCtrl.Finish()
+	m := &InstallManager{
+		log:                    log.WithField("test", t.Name()),
+		ClusterProvisionName:   testProvisionName,
+		Namespace:              testNamespace,
+		DynamicClient:          mocks.fakeKubeClient,
+		updateClusterProvision: updateClusterProvisionWithRetries,
+	}
+
+	provision := testClusterProvision()
+	for _, stage := range []hivev1.InstallerStage{
+		hivev1.InstallerStageInfrastructureProvisioned,
+		hivev1.InstallerStageBootstrapComplete,
+		hivev1.InstallerStageInfrastructureProvisioned,
+	} {
+		require.NoError(t, m.recordInstallerStage(provision, stage), "unexpected error recording installer stage")
+	}
+
+	stored := &hivev1.ClusterProvision{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, stored), "unexpected error getting provision")
+	if assert.Len(t, stored.Spec.InstallerStages, 2, "expected each stage to be recorded once") {
+		assert.Equal(t, hivev1.InstallerStageInfrastructureProvisioned, stored.Spec.InstallerStages[0].Stage, "unexpected first stage")
+		assert.Equal(t, hivev1.InstallerStageBootstrapComplete, stored.Spec.InstallerStages[1].Stage, "unexpected second stage")
+	}
+}
+
+func TestStageWatchdog(t *testing.T) {
+	short := &metav1.Duration{Duration: 10 * time.Millisecond}
+	brief := &metav1.Duration{Duration: 50 * time.Millisecond}
+	long := &metav1.Duration{Duration: time.Hour}
+	cases := []struct {
+		name            string
+		timeouts        *hivev1.ProvisionStageTimeouts
+		stages          []hivev1.InstallerStage
+		stop            bool
+		expectedMessage string
+	}{
+		{
+			name: "no timeouts",
+		},
+		{
+			name:            "infrastructure timeout",
+			timeouts:        &hivev1.ProvisionStageTimeouts{Infrastructure: short},
+			expectedMessage: "the infrastructure stage of the install did not complete within 10ms",
+		},
+		{
+			name:     "stage reached in time",
+			timeouts: &hivev1.ProvisionStageTimeouts{Infrastructure: brief},
+			stages:   []hivev1.InstallerStage{hivev1.InstallerStageInfrastructureProvisioned},
+		},
+		{
+			name:            "bootstrap timeout",
+			timeouts:        &hivev1.ProvisionStageTimeouts{Infrastructure: long, Bootstrap: short},
+			stages:          []hivev1.InstallerStage{hivev1.InstallerStageInfrastructureProvisioned},
+			expectedMessage: "the bootstrap stage of the install did not complete within 10ms",
+		},
+		{
+			name:     "bootstrap destroyed keeps cluster operators timeout",
+			timeouts: &hivev1.ProvisionStageTimeouts{ClusterOperators: short},
+			stages: []hivev1.InstallerStage{
+				hivev1.InstallerStageInfrastructureProvisioned,
+				hivev1.InstallerStageBootstrapComplete,
+				hivev1.InstallerStageBootstrapDestroyed,
+			},
+			expectedMessage: "the cluster operators stage of the install did not complete within 10ms",
+		},
+		{
+			name:     "install complete",
+			timeouts: &hivev1.ProvisionStageTimeouts{ClusterOperators: brief},
+			stages: []hivev1.InstallerStage{
+				hivev1.InstallerStageInfrastructureProvisioned,
+				hivev1.InstallerStageBootstrapComplete,
+				hivev1.InstallerStageInstallComplete,
+			},
+		},
+		{
+			name:     "stopped",
+			timeouts: &hivev1.ProvisionStageTimeouts{Infrastructure: brief},
+			stop:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newStageWatchdog(tc.timeouts, log.WithField("test", t.Name()))
+			w.start()
+			for _, stage := range tc.stages {
+				w.stageReached(stage)
+			}
+			if tc.stop {
+				w.stop()
+			}
+			if tc.expectedMessage == "" {
+				time.Sleep(100 * time.Millisecond)
+				assert.NoError(t, w.ctx.Err(), "expected install not to be aborted")
+				assert.Empty(t, w.expiredMessage(), "expected no stage timeout")
+				return
+			}
+			select {
+			case <-w.ctx.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected install to be aborted")
+			}
+			assert.Equal(t, tc.expectedMessage, w.expiredMessage(), "unexpected stage timeout")
+		})
+	}
+}
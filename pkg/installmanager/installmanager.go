@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -39,49 +40,43 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
-	"github.com/openshift/installer/pkg/destroy/aws"
-	"github.com/openshift/installer/pkg/destroy/azure"
-	"github.com/openshift/installer/pkg/destroy/gcp"
-	"github.com/openshift/installer/pkg/destroy/openstack"
-	"github.com/openshift/installer/pkg/destroy/ovirt"
-	"github.com/openshift/installer/pkg/destroy/providers"
-	"github.com/openshift/installer/pkg/destroy/vsphere"
 	installertypes "github.com/openshift/installer/pkg/types"
-	installertypesazure "github.com/openshift/installer/pkg/types/azure"
-	installertypesgcp "github.com/openshift/installer/pkg/types/gcp"
-	installertypesopenstack "github.com/openshift/installer/pkg/types/openstack"
-	installertypesovirt "github.com/openshift/installer/pkg/types/ovirt"
-	installertypesvsphere "github.com/openshift/installer/pkg/types/vsphere"
 
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
-	"github.com/openshift/hive/pkg/gcpclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
 	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/tracing"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
 const (
 	// metadataRelativePath is the location of the installers cluster metadata file
 	// relative to our WorkDir.
-	metadataRelativePath                = "metadata.json"
-	adminKubeConfigRelativePath         = "auth/kubeconfig"
-	adminPasswordRelativePath           = "auth/kubeadmin-password"
-	kubernetesKeyPrefix                 = "kubernetes.io/cluster/"
-	kubeadminUsername                   = "kubeadmin"
-	adminKubeConfigSecretStringTemplate = "%s-admin-kubeconfig"
-	adminPasswordSecretStringTemplate   = "%s-admin-password"
-	installerFullLogFile                = ".openshift_install.log"
-	installerConsoleLogFilePath         = "/tmp/openshift-install-console.log"
-	provisioningTransitionTimeout       = 5 * time.Minute
-	sshCopyTempFile                     = "/tmp/ssh-privatekey"
-	defaultInstallConfigMountPath       = "/installconfig/install-config.yaml"
-	defaultPullSecretMountPath          = "/pullsecret/" + corev1.DockerConfigJsonKey
-	defaultManifestsMountPath           = "/manifests"
-	defaultHomeDir                      = "/home/hive" // Used if no HOME env var set.
+	metadataRelativePath          = "metadata.json"
+	adminKubeConfigRelativePath   = "auth/kubeconfig"
+	adminPasswordRelativePath     = "auth/kubeadmin-password"
+	kubernetesKeyPrefix           = "kubernetes.io/cluster/"
+	kubeadminUsername             = "kubeadmin"
+	installerFullLogFile          = ".openshift_install.log"
+	installerConsoleLogFilePath   = "/tmp/openshift-install-console.log"
+	provisioningTransitionTimeout = 5 * time.Minute
+	sshCopyTempFile               = "/tmp/ssh-privatekey"
+	defaultInstallConfigMountPath = "/installconfig/install-config.yaml"
+	defaultPullSecretMountPath    = "/pullsecret/" + corev1.DockerConfigJsonKey
+	defaultManifestsMountPath     = "/manifests"
+	defaultCredsManifestsPath     = "/credentials-manifests"
+	defaultHomeDir                = "/home/hive" // Used if no HOME env var set.
+	gatherLogsRequestPollInterval = 30 * time.Second
 )
 
 var (
+	// installerInterruptGracePeriod is how long the installer is given to exit once interrupted, for terraform to
+	// stop gracefully and write its state, before it is killed.
+	installerInterruptGracePeriod = 2 * time.Minute
+
 	// multi-line mode regex that allows removing/mutating any line containing 'password' case-insensitive
 	multiLineRedactLinesWithPassword = regexp.MustCompile(`(?mi)^.*password.*$`)
 )
@@ -100,8 +95,10 @@ type InstallManager struct {
 	InstallConfigMountPath           string
 	PullSecretMountPath              string
 	ManifestsMountPath               string
+	CredentialsManifestsMountPath    string
 	DynamicClient                    client.Client
 	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
+	infraIDInUseInCloud              func(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (bool, error)
 	updateClusterProvision           func(*hivev1.ClusterProvision, *InstallManager, provisionMutation) error
 	readClusterMetadata              func(*hivev1.ClusterProvision, *InstallManager) ([]byte, *installertypes.ClusterMetadata, error)
 	uploadAdminKubeconfig            func(*hivev1.ClusterProvision, *InstallManager) (*corev1.Secret, error)
@@ -113,18 +110,23 @@ type InstallManager struct {
 	waitForInstallCompleteExecutions int
 	binaryDir                        string
 	actuator                         LogUploaderActuator
+	// gatherLogsMutex prevents gathering logs on request and after a failed install at the same time.
+	gatherLogsMutex sync.Mutex
+	// stageWatchdog aborts the install when a stage of the install exceeds its timeout.
+	stageWatchdog *stageWatchdog
 }
 
 // NewInstallManagerCommand is the entrypoint to create the 'install-manager' subcommand
 func NewInstallManagerCommand() *cobra.Command {
-	im := &InstallManager{
-		actuator: getActuator(),
-	}
+	im := &InstallManager{}
 	cmd := &cobra.Command{
 		Use:   "install-manager NAMESPACE CLUSTER_PROVISION_NAME",
 		Short: "Executes and oversees the openshift-installer.",
 		Long:  "The Hive Install Manager runs the phases of the openshift-installer, edits generated assets before completing install, and monitors for artifacts that need to be uploaded back to Hive.",
 		Run: func(cmd *cobra.Command, args []string) {
+			// The actuator is only looked up when the command runs, so that the other commands of hiveutil, such as
+			// completion, do not log the lookup.
+			im.actuator = getActuator()
 			if err := im.Complete(args); err != nil {
 				log.WithError(err).Error("cannot complete command")
 				return
@@ -139,6 +141,7 @@ func NewInstallManagerCommand() *cobra.Command {
 			im.InstallConfigMountPath = defaultInstallConfigMountPath
 			im.PullSecretMountPath = defaultPullSecretMountPath
 			im.ManifestsMountPath = defaultManifestsMountPath
+			im.CredentialsManifestsMountPath = defaultCredsManifestsPath
 			im.binaryDir = getHomeDir()
 
 			if err := im.Validate(); err != nil {
@@ -152,7 +155,11 @@ func NewInstallManagerCommand() *cobra.Command {
 				im.log.WithError(err).Fatal("error creating kube clients")
 			}
 
-			if err := im.Run(); err != nil {
+			tracing.Init("hive-install-manager")
+			err = im.Run()
+			// Export the spans of the install before exiting.
+			tracing.Shutdown()
+			if err != nil {
 				log.WithError(err).Fatal("runtime error")
 			}
 		},
@@ -174,6 +181,7 @@ func (m *InstallManager) Complete(args []string) error {
 	m.loadAdminPassword = loadAdminPassword
 	m.readInstallerLog = readInstallerLog
 	m.cleanupFailedProvision = cleanupFailedProvision
+	m.infraIDInUseInCloud = infraIDInUseInCloud
 	m.provisionCluster = provisionCluster
 	m.waitForProvisioningStage = waitForProvisioningStage
 
@@ -224,7 +232,7 @@ func (m *InstallManager) Validate() error {
 }
 
 // Run is the entrypoint to start the install process
-func (m *InstallManager) Run() error {
+func (m *InstallManager) Run() (returnErr error) {
 	provision := &hivev1.ClusterProvision{}
 	if err := m.loadClusterProvision(provision); err != nil {
 		m.log.WithError(err).Fatal("error looking up cluster provision")
@@ -250,6 +258,12 @@ func (m *InstallManager) Run() error {
 		os.Exit(0)
 	}
 
+	// The install is traced along with the reconciles of the ClusterDeployment.
+	installSpan := tracing.StartSpan(cd.Namespace, cd.Name, "install")
+	installSpan.SetAttribute("clusterprovision", provision.Name)
+	installSpan.SetAttribute("attempt", strconv.Itoa(provision.Spec.Attempt))
+	defer func() { installSpan.End(returnErr) }()
+
 	// sshKeyPaths will contain paths to all ssh keys in use
 	var sshKeyPaths []string
 
@@ -299,7 +313,12 @@ func (m *InstallManager) Run() error {
 		return err
 	}
 
-	go m.tailFullInstallLog(scrubInstallLog)
+	var stageTimeouts *hivev1.ProvisionStageTimeouts
+	if cd.Spec.Provisioning != nil {
+		stageTimeouts = cd.Spec.Provisioning.StageTimeouts
+	}
+	m.stageWatchdog = newStageWatchdog(stageTimeouts, m.log)
+	go m.tailFullInstallLog(provision.DeepCopy(), scrubInstallLog)
 
 	m.log.Info("copying install-config.yaml")
 	icData, err := ioutil.ReadFile(m.InstallConfigMountPath)
@@ -312,6 +331,65 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	if provision.Spec.Region != "" {
+		m.log.WithField("region", provision.Spec.Region).Info("setting region in install-config.yaml")
+		icData, err = setInstallConfigRegion(icData, provision.Spec.Region)
+		if err != nil {
+			m.log.WithError(err).Error("error setting region in install-config.yaml")
+			return err
+		}
+	}
+	if machineImage := os.Getenv(constants.MachineImageEnvVar); machineImage != "" {
+		m.log.WithField("machineImage", machineImage).Info("setting machine image in install-config.yaml")
+		icData, err = setInstallConfigMachineImage(icData, machineImage)
+		if err != nil {
+			m.log.WithError(err).Error("error setting machine image in install-config.yaml")
+			return err
+		}
+	}
+	// The installer defaults the machine pools to amd64.
+	if arch := cd.Status.Architecture; arch != "" && arch != hivev1.ArchitectureAMD64 {
+		m.log.WithField("architecture", arch).Info("setting architecture in install-config.yaml")
+		icData, err = setInstallConfigArchitecture(icData, arch)
+		if err != nil {
+			m.log.WithError(err).Error("error setting architecture in install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.CredentialsMode != "" {
+		mode := cd.Spec.Provisioning.CredentialsMode
+		m.log.WithField("credentialsMode", mode).Info("setting credentials mode in install-config.yaml")
+		icData, err = setInstallConfigCredentialsMode(icData, mode)
+		if err != nil {
+			m.log.WithError(err).Error("error setting credentials mode in install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ControlPlaneReplicas != nil {
+		replicas := *cd.Spec.Provisioning.ControlPlaneReplicas
+		m.log.WithField("controlPlaneReplicas", replicas).Info("setting default control plane replicas in install-config.yaml")
+		icData, err = setInstallConfigControlPlaneReplicas(icData, replicas)
+		if err != nil {
+			m.log.WithError(err).Error("error setting control plane replicas in install-config.yaml")
+			return err
+		}
+	}
+	if tags := platform.UserTags(cd); len(tags) > 0 {
+		m.log.Info("setting user tags in install-config.yaml")
+		icData, err = setInstallConfigUserTags(icData, tags, true)
+		if err != nil {
+			m.log.WithError(err).Error("error setting user tags in install-config.yaml")
+			return err
+		}
+	}
+	if tags := controllerutils.PropagatedLabels(cd, m.log); len(tags) > 0 {
+		m.log.Info("setting propagated labels as user tags in install-config.yaml")
+		icData, err = setInstallConfigUserTags(icData, tags, false)
+		if err != nil {
+			m.log.WithError(err).Error("error setting user tags in install-config.yaml")
+			return err
+		}
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := ioutil.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
@@ -336,7 +414,10 @@ func (m *InstallManager) Run() error {
 
 	// Generate installer assets we need to modify or upload.
 	m.log.Info("generating assets")
-	if err := m.generateAssets(provision); err != nil {
+	generateAssetsSpan := installSpan.StartChildSpan("generate assets")
+	err = m.generateAssetsWithUniqueInfraID(cd, provision, icData)
+	generateAssetsSpan.End(err)
+	if err != nil {
 		m.log.Info("reading installer log")
 		installLog, readErr := m.readInstallerLog(provision, m, scrubInstallLog)
 		if readErr != nil {
@@ -379,6 +460,13 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error uploading admin password")
 		return errors.Wrap(err, "error trying to save admin password")
 	}
+
+	// The metadata.json is needed to destroy the cluster, so it is kept in a secret which outlives the provision.
+	metadataSecret, err := uploadMetadataJSON(provision, m, metadataBytes)
+	if err != nil {
+		m.log.WithError(err).Error("error uploading metadata.json")
+		return errors.Wrap(err, "error trying to save metadata.json")
+	}
 	if err := m.updateClusterProvision(
 		provision,
 		m,
@@ -393,6 +481,9 @@ func (m *InstallManager) Run() error {
 			provision.Spec.AdminPasswordSecretRef = &corev1.LocalObjectReference{
 				Name: passwordSecret.Name,
 			}
+			provision.Spec.MetadataJSONSecretRef = &corev1.LocalObjectReference{
+				Name: metadataSecret.Name,
+			}
 		},
 	); err != nil {
 		m.log.WithError(err).Error("error updating cluster provision with cluster metadata")
@@ -415,10 +506,28 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
+	// Gather logs when requested by the user while the cluster is provisioning. Requests made before the install
+	// started are ignored, they are for previous install attempts.
+	stopGatherLogsRequests := make(chan struct{})
+	go m.watchGatherLogsRequests(provision.DeepCopy(), cd.Annotations[constants.GatherLogsAnnotation], sshKeyPath, sshAgentSetupErr, stopGatherLogsRequests)
+
+	provisionSpan := installSpan.StartChildSpan("provision cluster")
+	m.stageWatchdog.start()
 	installErr := m.provisionCluster(m)
+	m.stageWatchdog.stop()
+	provisionSpan.End(installErr)
+	close(stopGatherLogsRequests)
 	if installErr != nil {
 		m.log.WithError(installErr).Error("error running openshift-install, running deprovision to clean up")
 
+		// The reason of an install aborted by the watchdog is read from the install log by the clusterprovision
+		// controller.
+		if message := m.stageWatchdog.expiredMessage(); message != "" {
+			if err := appendInstallerConsoleLog(fmt.Sprintf("level=error msg=%q", constants.InstallStageTimeoutLogMessage+message)); err != nil {
+				m.log.WithError(err).Error("error reporting stage timeout in installer log")
+			}
+		}
+
 		if pauseDur, ok := cd.Annotations[constants.PauseOnInstallFailureAnnotation]; ok {
 			m.log.Infof("pausing on failure due to annotation %s=%s", constants.PauseOnInstallFailureAnnotation,
 				pauseDur)
@@ -435,22 +544,43 @@ func (m *InstallManager) Run() error {
 		if m.actuator == nil {
 			m.log.Debug("Unable to find log storage actuator. Disabling gathering logs.")
 		} else {
+			gatherLogsSpan := installSpan.StartChildSpan("gather logs")
 			m.gatherLogs(provision, cd, sshKeyPath, sshAgentSetupErr)
+			gatherLogsSpan.End(nil)
 		}
 	}
 
-	if installLog, err := m.readInstallerLog(provision, m, scrubInstallLog); err == nil {
+	installLog, logErr := m.readInstallerLog(provision, m, scrubInstallLog)
+	if logErr != nil {
+		m.log.WithError(logErr).Error("error reading installer log")
+	}
+	// The infrastructure of a failed install is destroyed right away rather than before the next install, which may
+	// be hours away or never come. Without the installer log, the install is assumed to have created infrastructure.
+	infraCreated := logErr != nil || infrastructureCreated(m.WorkDir, installLog)
+	infraDestroyed := false
+	if installErr != nil && infraCreated {
+		destroySpan := installSpan.StartChildSpan("destroy failed install")
+		infraDestroyed = m.destroyFailedInstall(cd, provision)
+		destroySpan.End(nil)
+	}
+	if logErr == nil || infraDestroyed {
 		if err := m.updateClusterProvision(
 			provision,
 			m,
 			func(provision *hivev1.ClusterProvision) {
-				provision.Spec.InstallLog = pointer.StringPtr(installLog)
+				if logErr == nil {
+					provision.Spec.InstallLog = pointer.StringPtr(installLog)
+					// A failed install which created no infrastructure is retried quickly, without cleaning up after
+					// it.
+					if installErr != nil {
+						provision.Spec.InfrastructureCreated = pointer.BoolPtr(infraCreated)
+					}
+				}
+				provision.Spec.InfrastructureDestroyed = infraDestroyed
 			},
 		); err != nil {
 			m.log.WithError(err).Warning("error updating cluster provision with installer log")
 		}
-	} else {
-		m.log.WithError(err).Error("error reading installer log")
 	}
 
 	if installErr != nil {
@@ -458,6 +588,30 @@ func (m *InstallManager) Run() error {
 		return installErr
 	}
 
+	// Record the identifiers of the cloud resources of the cluster, and apply the terraform state policy. They are
+	// not critical to the install, so failing to record them does not fail it.
+	platformStatus := readPlatformStatus(m.WorkDir, metadata, m.log)
+	terraformStateSecret, err := m.handleTerraformState(cd, provision)
+	if err != nil {
+		m.log.WithError(err).Warning("error handling terraform state")
+	}
+	if platformStatus != nil || terraformStateSecret != nil {
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				provision.Spec.PlatformStatus = platformStatus
+				if terraformStateSecret != nil {
+					provision.Spec.TerraformStateSecretRef = &corev1.LocalObjectReference{
+						Name: terraformStateSecret.Name,
+					}
+				}
+			},
+		); err != nil {
+			m.log.WithError(err).Warning("error updating cluster provision with platform status")
+		}
+	}
+
 	m.log.Info("install completed successfully")
 
 	return nil
@@ -534,12 +688,32 @@ func (m *InstallManager) cleanupFailedInstall(cd *hivev1.ClusterDeployment, prov
 		return err
 	}
 
+	for _, name := range []string{
+		controllerutils.MetadataJSONSecretName(m.ClusterProvisionName),
+		controllerutils.TerraformStateSecretName(m.ClusterProvisionName),
+	} {
+		namespacedName := types.NamespacedName{Name: name, Namespace: m.Namespace}
+		if err := m.deleteAnyExistingObject(namespacedName, &corev1.Secret{}); err != nil {
+			m.log.WithError(err).WithField("secret", name).Error("failed to fetch/delete any pre-existing secret")
+			return err
+		}
+	}
+
 	infraID := provision.Spec.InfraID
+	region := provision.Spec.Region
 	if infraID == nil {
 		infraID = provision.Spec.PrevInfraID
+		if provision.Spec.PrevRegion != nil {
+			region = *provision.Spec.PrevRegion
+		}
 	}
 	if infraID != nil {
 		m.log.Info("InfraID set from failed install, running deprovison")
+		if region != "" {
+			// The failed install may have been in another region than the one of the platform after failing over
+			// to a fallback region.
+			cd = clusterDeploymentInRegion(cd, region)
+		}
 		if err := m.cleanupFailedProvision(m.DynamicClient, cd, *infraID, m.log); err != nil {
 			return err
 		}
@@ -550,112 +724,37 @@ func (m *InstallManager) cleanupFailedInstall(cd *hivev1.ClusterDeployment, prov
 	return nil
 }
 
+// destroyFailedInstall destroys the cloud infrastructure created by the failed install of the provision. It returns true
+// if the infrastructure was destroyed. Otherwise the next install cleans up after the failed install.
+func (m *InstallManager) destroyFailedInstall(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) bool {
+	if provision.Spec.InfraID == nil {
+		m.log.Warn("skipping destroy of the failed install as no infra ID set")
+		return false
+	}
+	if provision.Spec.Region != "" {
+		cd = clusterDeploymentInRegion(cd, provision.Spec.Region)
+	}
+	logger := m.log.WithField("infraID", *provision.Spec.InfraID)
+	logger.Info("destroying the infrastructure of the failed install")
+	if err := m.cleanupFailedProvision(m.DynamicClient, cd, *provision.Spec.InfraID, logger); err != nil {
+		logger.WithError(err).Error("error destroying the infrastructure of the failed install, it will be destroyed before the next install")
+		return false
+	}
+	logger.Info("destroyed the infrastructure of the failed install")
+	return true
+}
+
 func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error {
-	var uninstaller providers.Destroyer
-	switch {
-	case cd.Spec.Platform.AWS != nil:
-		// run the uninstaller to clean up any cloud resources previously created
-		filters := []aws.Filter{
-			{kubernetesKeyPrefix + infraID: "owned"},
-		}
-		uninstaller = &aws.ClusterUninstaller{
-			Filters: filters,
-			Region:  cd.Spec.Platform.AWS.Region,
-			Logger:  logger,
-		}
-	case cd.Spec.Platform.Azure != nil:
-		metadata := &installertypes.ClusterMetadata{
-			InfraID: infraID,
-			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
-				Azure: &installertypesazure.Metadata{
-					CloudName: installertypesazure.PublicCloud,
-				},
-			},
-		}
-		var err error
-		uninstaller, err = azure.New(logger, metadata)
-		if err != nil {
-			return err
-		}
-	case cd.Spec.Platform.GCP != nil:
-		credsFile := os.Getenv("GOOGLE_CREDENTIALS")
-		projectID, err := gcpclient.ProjectIDFromFile(credsFile)
-		if err != nil {
-			return errors.Wrap(err, "could not get GCP project ID")
-		}
-		metadata := &installertypes.ClusterMetadata{
-			InfraID: infraID,
-			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
-				GCP: &installertypesgcp.Metadata{
-					Region:    cd.Spec.Platform.GCP.Region,
-					ProjectID: projectID,
-				},
-			},
-		}
-		uninstaller, err = gcp.New(logger, metadata)
-		if err != nil {
-			return err
-		}
-	case cd.Spec.Platform.OpenStack != nil:
-		metadata := &installertypes.ClusterMetadata{
-			InfraID: infraID,
-			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
-				OpenStack: &installertypesopenstack.Metadata{
-					Cloud: cd.Spec.Platform.OpenStack.Cloud,
-					Identifier: map[string]string{
-						"openshiftClusterID": infraID,
-					},
-				},
-			},
-		}
-		var err error
-		uninstaller, err = openstack.New(logger, metadata)
-		if err != nil {
-			return err
-		}
-	case cd.Spec.Platform.VSphere != nil:
-		vSphereUsername := os.Getenv(constants.VSphereUsernameEnvVar)
-		if vSphereUsername == "" {
-			return fmt.Errorf("No %s env var set, cannot proceed", constants.VSphereUsernameEnvVar)
-		}
-		vSpherePassword := os.Getenv(constants.VSpherePasswordEnvVar)
-		if vSpherePassword == "" {
-			return fmt.Errorf("No %s env var set, cannot proceed", constants.VSpherePasswordEnvVar)
-		}
-		metadata := &installertypes.ClusterMetadata{
-			InfraID: infraID,
-			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
-				VSphere: &installertypesvsphere.Metadata{
-					VCenter:  cd.Spec.Platform.VSphere.VCenter,
-					Username: vSphereUsername,
-					Password: vSpherePassword,
-				},
-			},
-		}
-		var err error
-		uninstaller, err = vsphere.New(logger, metadata)
-		if err != nil {
-			return err
-		}
-	case cd.Spec.Platform.Ovirt != nil:
-		metadata := &installertypes.ClusterMetadata{
-			InfraID: infraID,
-			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
-				Ovirt: &installertypesovirt.Metadata{
-					ClusterID: cd.Spec.Platform.Ovirt.ClusterID,
-				},
-			},
-		}
-		var err error
-		uninstaller, err = ovirt.New(logger, metadata)
-		if err != nil {
-			return err
-		}
-	default:
+	actuator := platform.ForClusterDeployment(cd)
+	if actuator == nil {
 		logger.Warn("unknown platform for re-try cleanup")
 		return errors.New("unknown platform for re-try cleanup")
 	}
-
+	// run the uninstaller to clean up any cloud resources previously created
+	uninstaller, err := actuator.Destroyer(cd, infraID, logger)
+	if err != nil {
+		return err
+	}
 	if err := uninstaller.Run(); err != nil {
 		return err
 	}
@@ -685,6 +784,15 @@ func (m *InstallManager) generateAssets(provision *hivev1.ClusterProvision) erro
 		m.log.Infof("copied %s to %s", src, dest)
 	}
 
+	if src := m.CredentialsManifestsMountPath; isDirNonEmpty(src) {
+		m.log.Info("copying credentials manifests")
+		if err := copyCredentialsManifests(src, m.WorkDir); err != nil {
+			m.log.WithError(err).Errorf("error copying credentials manifests from %s to %s", src, m.WorkDir)
+			return err
+		}
+		m.log.Infof("copied %s to %s", src, m.WorkDir)
+	}
+
 	m.log.Info("running openshift-install create ignition-configs")
 	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
 		m.log.WithError(err).Error("error generating installer assets")
@@ -701,12 +809,17 @@ func provisionCluster(m *InstallManager) error {
 
 	m.log.Info("running openshift-install create cluster")
 
-	if err := m.runOpenShiftInstallCommand("create", "cluster"); err != nil {
-		if (m.waitForInstallCompleteExecutions > 0) && m.isBootstrapComplete() {
-			for i := 0; i < m.waitForInstallCompleteExecutions; i++ {
+	// The installer is interrupted when the watchdog aborts the install.
+	ctx := context.Background()
+	if m.stageWatchdog != nil {
+		ctx = m.stageWatchdog.ctx
+	}
+	if err := m.runOpenShiftInstallCommandContext(ctx, "create", "cluster"); err != nil {
+		if (m.waitForInstallCompleteExecutions > 0) && ctx.Err() == nil && m.isBootstrapComplete() {
+			for i := 0; i < m.waitForInstallCompleteExecutions && ctx.Err() == nil; i++ {
 				m.log.WithField("waitIteration", i).WithError(err).
 					Warn("provisioning cluster failed after completing bootstrapping, waiting longer for install to complete")
-				err = m.runOpenShiftInstallCommand("wait-for", "install-complete")
+				err = m.runOpenShiftInstallCommandContext(ctx, "wait-for", "install-complete")
 			}
 		}
 		if err != nil {
@@ -719,6 +832,13 @@ func provisionCluster(m *InstallManager) error {
 }
 
 func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
+	return m.runOpenShiftInstallCommandContext(context.Background(), args...)
+}
+
+// runOpenShiftInstallCommandContext runs the installer like runOpenShiftInstallCommand, interrupting it when the
+// context is done. The installer is killed if it has not exited within installerInterruptGracePeriod of the
+// interrupt.
+func (m *InstallManager) runOpenShiftInstallCommandContext(ctx context.Context, args ...string) error {
 	m.log.WithField("args", args).Info("running openshift-install binary")
 	cmd := exec.Command(filepath.Join(m.binaryDir, "openshift-install"), args...)
 	cmd.Dir = m.WorkDir
@@ -739,7 +859,27 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 		return err
 	}
 
-	err = cmd.Wait()
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		m.log.Warn("interrupting installer")
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			m.log.WithError(err).Warn("could not interrupt installer")
+		}
+		select {
+		case err = <-done:
+		case <-time.After(installerInterruptGracePeriod):
+			m.log.Warn("installer did not exit after being interrupted, killing it")
+			if err := cmd.Process.Kill(); err != nil {
+				m.log.WithError(err).Warn("could not kill installer")
+			}
+			err = <-done
+		}
+	}
 	// give goroutine above a chance to read through whole buffer
 	time.Sleep(time.Second)
 	if err != nil {
@@ -751,9 +891,21 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 	return nil
 }
 
+// appendInstallerConsoleLog appends a line to the installer console log, which is saved as the install log.
+func appendInstallerConsoleLog(line string) error {
+	f, err := os.OpenFile(installerConsoleLogFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, line)
+	return err
+}
+
 // tailFullInstallLog streams the full install log to standard out so that
-// the log can be seen from the pods logs.
-func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
+// the log can be seen from the pods logs. The stages of the install reached
+// by the installer are recorded on the provision as they show up in the log.
+func (m *InstallManager) tailFullInstallLog(provision *hivev1.ClusterProvision, scrubInstallLog bool) {
 	logfileName := filepath.Join(m.WorkDir, installerFullLogFile)
 	m.waitForFiles([]string{logfileName})
 
@@ -799,6 +951,13 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 		} else {
 			fmt.Println(fullLine)
 		}
+		if stage, ok := installerStageForLogLine(fullLine); ok {
+			m.stageWatchdog.stageReached(stage)
+			if err := m.recordInstallerStage(provision, stage); err != nil {
+				// Not a fatal error.
+				m.log.WithError(err).WithField("installerStage", stage).Warn("error recording installer stage")
+			}
+		}
 		// clear out the line buffer so we can start again
 		fullLine = ""
 	}
@@ -859,6 +1018,9 @@ func (m *InstallManager) loadClusterDeployment(provision *hivev1.ClusterProvisio
 // we're just gathering as much information as we can and then proceeding with cleanup
 // so we can re-try.
 func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment, sshPrivKeyPath string, sshAgentSetupErr error) {
+	m.gatherLogsMutex.Lock()
+	defer m.gatherLogsMutex.Unlock()
+
 	if !m.isBootstrapComplete() {
 		if sshAgentSetupErr != nil {
 			m.log.Warn("unable to fetch logs from bootstrap node as SSH agent was not configured")
@@ -903,6 +1065,49 @@ func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hive
 	}
 }
 
+// watchGatherLogsRequests periodically checks the ClusterDeployment for requests to gather logs until stop is
+// closed. The provision must not be shared with the main install goroutine.
+func (m *InstallManager) watchGatherLogsRequests(provision *hivev1.ClusterProvision, lastRequest string, sshPrivKeyPath string, sshAgentSetupErr error, stop <-chan struct{}) {
+	wait.Until(func() {
+		lastRequest = m.handleGatherLogsRequest(provision, lastRequest, sshPrivKeyPath, sshAgentSetupErr)
+	}, gatherLogsRequestPollInterval, stop)
+}
+
+// handleGatherLogsRequest gathers and uploads the logs if the gather logs annotation of the ClusterDeployment has
+// changed since the last request, and records the request as completed on the provision. Returns the last request
+// handled.
+func (m *InstallManager) handleGatherLogsRequest(provision *hivev1.ClusterProvision, lastRequest string, sshPrivKeyPath string, sshAgentSetupErr error) string {
+	cd := &hivev1.ClusterDeployment{}
+	if err := m.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: provision.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}, cd); err != nil {
+		m.log.WithError(err).Warn("error getting cluster deployment to check for gather logs requests")
+		return lastRequest
+	}
+	request := cd.Annotations[constants.GatherLogsAnnotation]
+	if request == "" || request == lastRequest {
+		return lastRequest
+	}
+	logger := m.log.WithField("request", request)
+	if m.actuator == nil {
+		logger.Warn("gather logs requested but no log storage is configured, not gathering logs")
+	} else {
+		logger.Info("gathering logs on request")
+		m.gatherLogs(provision, cd, sshPrivKeyPath, sshAgentSetupErr)
+	}
+	if err := m.updateClusterProvision(
+		provision,
+		m,
+		func(provision *hivev1.ClusterProvision) {
+			if provision.Annotations == nil {
+				provision.Annotations = map[string]string{}
+			}
+			provision.Annotations[constants.GatherLogsCompletedAnnotation] = request
+		},
+	); err != nil {
+		logger.WithError(err).Warn("error recording completed gather logs request on cluster provision")
+	}
+	return request
+}
+
 func (m *InstallManager) gatherClusterLogs(cd *hivev1.ClusterDeployment) error {
 	m.log.Info("attempting to gather logs with oc adm must-gather")
 	destDir := filepath.Join(m.LogsDir, fmt.Sprintf("%s-must-gather", time.Now().Format("20060102150405")))
@@ -1108,7 +1313,7 @@ func uploadAdminKubeconfig(provision *hivev1.ClusterProvision, m *InstallManager
 
 	kubeconfigSecret = &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf(adminKubeConfigSecretStringTemplate, m.ClusterProvisionName),
+			Name:      controllerutils.AdminKubeconfigSecretName(m.ClusterProvisionName),
 			Namespace: m.Namespace,
 		},
 		Data: map[string][]byte{
@@ -1118,6 +1323,9 @@ func uploadAdminKubeconfig(provision *hivev1.ClusterProvision, m *InstallManager
 
 	m.log.WithField("derivedObject", kubeconfigSecret.Name).Debug("Setting labels on derived object")
 	kubeconfigSecret.Labels = k8slabels.AddLabel(kubeconfigSecret.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+	// The cluster deployment label lets the cluster deployment controller add the cluster deployment as an owner, so that
+	// the secrets of failed attempts are garbage collected with the cluster deployment.
+	kubeconfigSecret.Labels = k8slabels.AddLabel(kubeconfigSecret.Labels, constants.ClusterDeploymentNameLabel, provision.Spec.ClusterDeploymentRef.Name)
 	kubeconfigSecret.Labels = k8slabels.AddLabel(kubeconfigSecret.Labels, constants.SecretTypeLabel, constants.SecretTypeKubeConfig)
 
 	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
@@ -1174,7 +1382,7 @@ func uploadAdminPassword(provision *hivev1.ClusterProvision, m *InstallManager)
 
 	s := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf(adminPasswordSecretStringTemplate, m.ClusterProvisionName),
+			Name:      controllerutils.AdminPasswordSecretName(m.ClusterProvisionName),
 			Namespace: m.Namespace,
 		},
 		Data: map[string][]byte{
@@ -1185,6 +1393,7 @@ func uploadAdminPassword(provision *hivev1.ClusterProvision, m *InstallManager)
 
 	m.log.WithField("derivedObject", s.Name).Debug("Setting labels on derived object")
 	s.Labels = k8slabels.AddLabel(s.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+	s.Labels = k8slabels.AddLabel(s.Labels, constants.ClusterDeploymentNameLabel, provision.Spec.ClusterDeploymentRef.Name)
 	s.Labels = k8slabels.AddLabel(s.Labels, constants.SecretTypeLabel, constants.SecretTypeKubeAdminCreds)
 
 	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
@@ -1208,6 +1417,56 @@ func uploadAdminPassword(provision *hivev1.ClusterProvision, m *InstallManager)
 	return s, nil
 }
 
+func uploadMetadataJSON(provision *hivev1.ClusterProvision, m *InstallManager, metadataBytes []byte) (*corev1.Secret, error) {
+	m.log.Infoln("uploading metadata.json")
+	s, err := newProvisionSecret(
+		provision,
+		controllerutils.MetadataJSONSecretName(m.ClusterProvisionName),
+		constants.SecretTypeMetadataJSON,
+		map[string][]byte{constants.MetadataJSONSecretKey: metadataBytes},
+		m,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := createWithRetries(s, m); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// newProvisionSecret returns a secret of the given type, labeled and owned like the other secrets uploaded for the
+// provision.
+func newProvisionSecret(provision *hivev1.ClusterProvision, name, secretType string, data map[string][]byte, m *InstallManager) (*corev1.Secret, error) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: m.Namespace,
+		},
+		Data: data,
+	}
+
+	m.log.WithField("derivedObject", s.Name).Debug("Setting labels on derived object")
+	s.Labels = k8slabels.AddLabel(s.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+	s.Labels = k8slabels.AddLabel(s.Labels, constants.ClusterDeploymentNameLabel, provision.Spec.ClusterDeploymentRef.Name)
+	s.Labels = k8slabels.AddLabel(s.Labels, constants.SecretTypeLabel, secretType)
+
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		m.log.WithError(err).Errorf("error getting GVK for provision")
+		return nil, err
+	}
+
+	s.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+	return s, nil
+}
+
 func createWithRetries(obj runtime.Object, m *InstallManager) error {
 	logger := m.log.WithField("kind", obj.GetObjectKind().GroupVersionKind().Kind)
 
@@ -1242,7 +1501,7 @@ func createWithRetries(obj runtime.Object, m *InstallManager) error {
 func (m *InstallManager) cleanupAdminKubeconfigSecret() error {
 	// find/delete any previous admin kubeconfig secret
 	namespacedName := types.NamespacedName{
-		Name:      fmt.Sprintf(adminKubeConfigSecretStringTemplate, m.ClusterProvisionName),
+		Name:      controllerutils.AdminKubeconfigSecretName(m.ClusterProvisionName),
 		Namespace: m.Namespace,
 	}
 	if err := m.deleteAnyExistingObject(namespacedName, &corev1.Secret{}); err != nil {
@@ -1256,7 +1515,7 @@ func (m *InstallManager) cleanupAdminKubeconfigSecret() error {
 func (m *InstallManager) cleanupAdminPasswordSecret() error {
 	// find/delete any previous admin password secret
 	namespacedName := types.NamespacedName{
-		Name:      fmt.Sprintf(adminPasswordSecretStringTemplate, m.ClusterProvisionName),
+		Name:      controllerutils.AdminPasswordSecretName(m.ClusterProvisionName),
 		Namespace: m.Namespace,
 	}
 	if err := m.deleteAnyExistingObject(namespacedName, &corev1.Secret{}); err != nil {
@@ -1414,6 +1673,187 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// setInstallConfigRegion sets the region of the platform in the install config. The zones of the machine pools are
+// removed when the region changes, as they are specific to a region.
+func setInstallConfigRegion(icData []byte, region string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	var platformName string
+	for _, name := range []string{"aws", "azure", "gcp"} {
+		if _, ok := platform[name].(map[string]interface{}); ok {
+			platformName = name
+			break
+		}
+	}
+	if platformName == "" {
+		return nil, errors.New("install config has no platform with regions")
+	}
+	platformConfig := platform[platformName].(map[string]interface{})
+	if platformConfig["region"] == region {
+		return icData, nil
+	}
+	platformConfig["region"] = region
+
+	pools := []interface{}{icRaw["controlPlane"]}
+	if compute, ok := icRaw["compute"].([]interface{}); ok {
+		pools = append(pools, compute...)
+	}
+	for _, pool := range pools {
+		poolConfig, _ := pool.(map[string]interface{})
+		poolPlatform, _ := poolConfig["platform"].(map[string]interface{})
+		if poolPlatformConfig, ok := poolPlatform[platformName].(map[string]interface{}); ok {
+			delete(poolPlatformConfig, "zones")
+		}
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// setInstallConfigMachineImage sets the image used to boot the machines of the cluster in the platform of the install
+// config.
+func setInstallConfigMachineImage(icData []byte, image string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	for name, field := range map[string]string{"aws": "amiID", "openstack": "clusterOSImage", "vsphere": "clusterOSImage"} {
+		if platformConfig, ok := platform[name].(map[string]interface{}); ok {
+			platformConfig[field] = image
+			return yaml.Marshal(icRaw)
+		}
+	}
+	return nil, errors.New("install config platform does not support overriding the machine image")
+}
+
+// setInstallConfigArchitecture sets the architecture of the control plane and of the compute machine pools of the
+// install config which do not set one. A compute machine pool is added when there is none, as the installer would.
+func setInstallConfigArchitecture(icData []byte, arch hivev1.Architecture) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	controlPlane, ok := icRaw["controlPlane"].(map[string]interface{})
+	if !ok {
+		controlPlane = map[string]interface{}{"name": "master"}
+		icRaw["controlPlane"] = controlPlane
+	}
+	pools := []map[string]interface{}{controlPlane}
+	compute, _ := icRaw["compute"].([]interface{})
+	if len(compute) == 0 {
+		compute = []interface{}{map[string]interface{}{"name": "worker"}}
+		icRaw["compute"] = compute
+	}
+	for _, pool := range compute {
+		if poolConfig, ok := pool.(map[string]interface{}); ok {
+			pools = append(pools, poolConfig)
+		}
+	}
+	for _, pool := range pools {
+		if _, ok := pool["architecture"]; !ok {
+			pool["architecture"] = string(arch)
+		}
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// setInstallConfigControlPlaneReplicas sets the number of control plane nodes of the install config when it does not
+// set one.
+func setInstallConfigControlPlaneReplicas(icData []byte, replicas int64) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	controlPlane, ok := icRaw["controlPlane"].(map[string]interface{})
+	if !ok {
+		controlPlane = map[string]interface{}{"name": "master"}
+		icRaw["controlPlane"] = controlPlane
+	}
+	if _, ok := controlPlane["replicas"]; !ok {
+		controlPlane["replicas"] = replicas
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// setInstallConfigCredentialsMode sets the mode in which the cloud credential operator of the cluster provides the
+// credentials of its components.
+func setInstallConfigCredentialsMode(icData []byte, mode hivev1.CredentialsMode) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["credentialsMode"] = string(mode)
+	return yaml.Marshal(icRaw)
+}
+
+// setInstallConfigUserTags adds the tags to the user tags of the platform of the install config, which the installer
+// sets on the cloud resources of the cluster. The tags already set in the install config are replaced when override
+// is true, and kept otherwise. Only AWS supports user tags, the install config of the other platforms is left
+// unchanged.
+func setInstallConfigUserTags(icData []byte, tags map[string]string, override bool) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	platformConfig, ok := platform["aws"].(map[string]interface{})
+	if !ok {
+		return icData, nil
+	}
+	userTags, _ := platformConfig["userTags"].(map[string]interface{})
+	if userTags == nil {
+		userTags = map[string]interface{}{}
+	}
+	for key, value := range tags {
+		if _, ok := userTags[key]; override || !ok {
+			userTags[key] = value
+		}
+	}
+	platformConfig["userTags"] = userTags
+	return yaml.Marshal(icRaw)
+}
+
+// copyCredentialsManifests copies the credentials manifests of the directory to the manifests of the installer, and
+// the private key signing the tokens of the service accounts to its tls directory.
+func copyCredentialsManifests(src, workDir string) error {
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return errors.Wrap(err, "could not read the credentials manifests")
+	}
+	for _, f := range files {
+		// Secret volumes hold their data in hidden directories, linked to by the files of the keys.
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(src, f.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "could not read credentials manifest %s", f.Name())
+		}
+		destDir := filepath.Join(workDir, "manifests")
+		if f.Name() == hivev1.BoundServiceAccountSigningKeyKey {
+			destDir = filepath.Join(workDir, "tls")
+		}
+		if err := os.MkdirAll(destDir, 0755); err != nil {
+			return errors.Wrapf(err, "could not create directory %s", destDir)
+		}
+		if err := ioutil.WriteFile(filepath.Join(destDir, f.Name()), content, 0600); err != nil {
+			return errors.Wrapf(err, "could not write credentials manifest %s", f.Name())
+		}
+	}
+	return nil
+}
+
+// clusterDeploymentInRegion returns a copy of the cluster deployment with the given region set on its platform.
+func clusterDeploymentInRegion(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeployment {
+	cd = cd.DeepCopy()
+	if actuator := platform.ForClusterDeployment(cd); actuator != nil {
+		actuator.SetRegion(cd, region)
+	}
+	return cd
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
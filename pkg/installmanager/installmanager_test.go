@@ -8,6 +8,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -27,6 +28,7 @@ import (
 
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -88,6 +90,7 @@ func TestInstallManager(t *testing.T) {
 		failedAdminPasswordSave       bool
 		failedInstallerLogRead        bool
 		failedProvisionUpdate         *int32
+		infraIDInUse                  bool
 		expectKubeconfigSecret        bool
 		expectPasswordSecret          bool
 		expectProvisionMetadataUpdate bool
@@ -145,6 +148,13 @@ func TestInstallManager(t *testing.T) {
 			expectPasswordSecret:          true,
 			expectProvisionMetadataUpdate: true,
 		},
+		{
+			name:                     "infra ID in use", // fatal error
+			existing:                 []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			infraIDInUse:             true,
+			expectProvisionLogUpdate: true,
+			expectError:              true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -240,6 +250,10 @@ func TestInstallManager(t *testing.T) {
 			// We don't want to run the uninstaller, so stub it out
 			im.cleanupFailedProvision = alwaysSucceedCleanupFailedProvision
 
+			im.infraIDInUseInCloud = func(*hivev1.ClusterDeployment, string, log.FieldLogger) (bool, error) {
+				return test.infraIDInUse, nil
+			}
+
 			// Save the list of actuators so that it can be restored at the end of this test
 			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
@@ -268,6 +282,7 @@ func TestInstallManager(t *testing.T) {
 					}
 
 					assert.Equal(t, testClusterProvision().Name, adminKubeconfig.Labels[constants.ClusterProvisionNameLabel], "incorrect cluster provision name label")
+					assert.Equal(t, testDeploymentName, adminKubeconfig.Labels[constants.ClusterDeploymentNameLabel], "incorrect cluster deployment name label")
 					assert.Equal(t, constants.SecretTypeKubeConfig, adminKubeconfig.Labels[constants.SecretTypeLabel], "incorrect secret type label")
 				}
 			} else {
@@ -293,6 +308,7 @@ func TestInstallManager(t *testing.T) {
 					}
 
 					assert.Equal(t, testClusterProvision().Name, adminPassword.Labels[constants.ClusterProvisionNameLabel], "incorrect cluster provision name label")
+					assert.Equal(t, testDeploymentName, adminPassword.Labels[constants.ClusterDeploymentNameLabel], "incorrect cluster deployment name label")
 					assert.Equal(t, constants.SecretTypeKubeAdminCreds, adminPassword.Labels[constants.SecretTypeLabel], "incorrect secret type label")
 				}
 			} else {
@@ -318,10 +334,18 @@ func TestInstallManager(t *testing.T) {
 				if assert.NotNil(t, provision.Spec.AdminPasswordSecretRef, "expected password secret reference to be set") {
 					assert.Equal(t, "test-provision-admin-password", provision.Spec.AdminPasswordSecretRef.Name, "unexpected name for password secret reference")
 				}
+				if assert.NotNil(t, provision.Spec.MetadataJSONSecretRef, "expected metadata.json secret reference to be set") {
+					metadataSecret := &corev1.Secret{}
+					if assert.NoError(t, mocks.fakeKubeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: provision.Spec.MetadataJSONSecretRef.Name}, metadataSecret)) {
+						assert.JSONEq(t, string(provision.Spec.Metadata.Raw), string(metadataSecret.Data[constants.MetadataJSONSecretKey]), "unexpected metadata.json")
+						assert.Equal(t, constants.SecretTypeMetadataJSON, metadataSecret.Labels[constants.SecretTypeLabel], "incorrect secret type label")
+					}
+				}
 			} else {
 				assert.Nil(t, provision.Spec.Metadata, "expected metadata to be empty")
 				assert.Nil(t, provision.Spec.AdminKubeconfigSecretRef, "expected kubeconfig secret reference to be empty")
 				assert.Nil(t, provision.Spec.AdminPasswordSecretRef, "expected password secret reference to be empty")
+				assert.Nil(t, provision.Spec.MetadataJSONSecretRef, "expected metadata.json secret reference to be empty")
 			}
 
 			if test.expectProvisionLogUpdate {
@@ -372,6 +396,65 @@ func testSecret(secretType corev1.SecretType, name, key, value string) *corev1.S
 	return s
 }
 
+func TestDestroyFailedInstall(t *testing.T) {
+	cases := []struct {
+		name            string
+		infraID         *string
+		region          string
+		cleanupErr      error
+		expectCleanup   bool
+		expectDestroyed bool
+	}{
+		{
+			name: "no infra ID",
+		},
+		{
+			name:            "destroyed",
+			infraID:         pointer.StringPtr("test-infra-id"),
+			expectCleanup:   true,
+			expectDestroyed: true,
+		},
+		{
+			name:            "destroyed in provision region",
+			infraID:         pointer.StringPtr("test-infra-id"),
+			region:          "us-west-2",
+			expectCleanup:   true,
+			expectDestroyed: true,
+		},
+		{
+			name:          "destroy error",
+			infraID:       pointer.StringPtr("test-infra-id"),
+			cleanupErr:    fmt.Errorf("failed to destroy"),
+			expectCleanup: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: "us-east-1"}
+			provision := testClusterProvision()
+			provision.Spec.InfraID = tc.infraID
+			provision.Spec.Region = tc.region
+			cleanedUp := false
+			m := &InstallManager{
+				log: log.WithField("test", t.Name()),
+				cleanupFailedProvision: func(_ client.Client, cd *hivev1.ClusterDeployment, infraID string, _ log.FieldLogger) error {
+					cleanedUp = true
+					assert.Equal(t, "test-infra-id", infraID, "unexpected infra ID")
+					expectedRegion := tc.region
+					if expectedRegion == "" {
+						expectedRegion = "us-east-1"
+					}
+					assert.Equal(t, expectedRegion, cd.Spec.Platform.AWS.Region, "unexpected region")
+					return tc.cleanupErr
+				},
+			}
+			assert.Equal(t, tc.expectDestroyed, m.destroyFailedInstall(cd, provision), "unexpected destroyed")
+			assert.Equal(t, tc.expectCleanup, cleanedUp, "unexpected cleanup")
+		})
+	}
+}
+
 func TestCleanupRegex(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -712,3 +795,332 @@ func Test_pasteInPullSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_setInstallConfigRegion(t *testing.T) {
+	icData := []byte(`compute:
+- name: worker
+  platform:
+    aws:
+      type: m4.xlarge
+      zones:
+      - us-east-1a
+controlPlane:
+  name: master
+  platform:
+    aws:
+      zones:
+      - us-east-1b
+platform:
+  aws:
+    region: us-east-1
+`)
+	expected := `compute:
+- name: worker
+  platform:
+    aws:
+      type: m4.xlarge
+controlPlane:
+  name: master
+  platform:
+    aws: {}
+platform:
+  aws:
+    region: us-west-2
+`
+	actual, err := setInstallConfigRegion(icData, "us-west-2")
+	require.NoError(t, err, "unexpected error setting region")
+	assert.Equal(t, expected, string(actual), "unexpected InstallConfig with region")
+
+	actual, err = setInstallConfigRegion(icData, "us-east-1")
+	require.NoError(t, err, "unexpected error setting same region")
+	assert.Equal(t, string(icData), string(actual), "expected InstallConfig to be unchanged")
+}
+
+func Test_setInstallConfigMachineImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		icData      string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "aws",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n",
+			expected: "platform:\n  aws:\n    amiID: test-image\n    region: us-east-1\n",
+		},
+		{
+			name:     "vsphere",
+			icData:   "platform:\n  vsphere:\n    vCenter: vcenter.example.com\n",
+			expected: "platform:\n  vsphere:\n    clusterOSImage: test-image\n    vCenter: vcenter.example.com\n",
+		},
+		{
+			name:        "unsupported platform",
+			icData:      "platform:\n  gcp:\n    region: us-central1\n",
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := setInstallConfigMachineImage([]byte(test.icData), "test-image")
+			if test.expectError {
+				assert.Error(t, err, "expected error setting machine image")
+				return
+			}
+			require.NoError(t, err, "unexpected error setting machine image")
+			assert.Equal(t, test.expected, string(actual), "unexpected InstallConfig with machine image")
+		})
+	}
+}
+
+func Test_setInstallConfigArchitecture(t *testing.T) {
+	tests := []struct {
+		name     string
+		icData   string
+		expected string
+	}{
+		{
+			name:     "no machine pools",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n",
+			expected: "compute:\n- architecture: arm64\n  name: worker\ncontrolPlane:\n  architecture: arm64\n  name: master\nplatform:\n  aws:\n    region: us-east-1\n",
+		},
+		{
+			name:     "machine pools",
+			icData:   "compute:\n- name: worker\n  replicas: 3\ncontrolPlane:\n  name: master\n",
+			expected: "compute:\n- architecture: arm64\n  name: worker\n  replicas: 3\ncontrolPlane:\n  architecture: arm64\n  name: master\n",
+		},
+		{
+			name:     "mixed architecture machine pools",
+			icData:   "compute:\n- name: worker\n- architecture: amd64\n  name: legacy\ncontrolPlane:\n  name: master\n",
+			expected: "compute:\n- architecture: arm64\n  name: worker\n- architecture: amd64\n  name: legacy\ncontrolPlane:\n  architecture: arm64\n  name: master\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := setInstallConfigArchitecture([]byte(test.icData), hivev1.ArchitectureARM64)
+			require.NoError(t, err, "unexpected error setting architecture")
+			assert.Equal(t, test.expected, string(actual), "unexpected InstallConfig with architecture")
+		})
+	}
+}
+
+func Test_setInstallConfigControlPlaneReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		icData   string
+		expected string
+	}{
+		{
+			name:     "no control plane",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n",
+			expected: "controlPlane:\n  name: master\n  replicas: 3\nplatform:\n  aws:\n    region: us-east-1\n",
+		},
+		{
+			name:     "control plane without replicas",
+			icData:   "controlPlane:\n  name: master\n",
+			expected: "controlPlane:\n  name: master\n  replicas: 3\n",
+		},
+		{
+			name:     "control plane with replicas",
+			icData:   "controlPlane:\n  name: master\n  replicas: 1\n",
+			expected: "controlPlane:\n  name: master\n  replicas: 1\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := setInstallConfigControlPlaneReplicas([]byte(test.icData), 3)
+			require.NoError(t, err, "unexpected error setting control plane replicas")
+			assert.Equal(t, test.expected, string(actual), "unexpected InstallConfig with control plane replicas")
+		})
+	}
+}
+
+func Test_setInstallConfigCredentialsMode(t *testing.T) {
+	icData := []byte("credentialsMode: Mint\nplatform:\n  aws:\n    region: us-east-1\n")
+	actual, err := setInstallConfigCredentialsMode(icData, hivev1.ManualCredentialsMode)
+	require.NoError(t, err, "unexpected error setting credentials mode")
+	assert.Equal(t, "credentialsMode: Manual\nplatform:\n  aws:\n    region: us-east-1\n", string(actual), "unexpected InstallConfig with credentials mode")
+}
+
+func Test_setInstallConfigUserTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		icData   string
+		override bool
+		expected string
+	}{
+		{
+			name:     "aws",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n",
+			expected: "platform:\n  aws:\n    region: us-east-1\n    userTags:\n      cost-center: \"1234\"\n      team: hive\n",
+		},
+		{
+			name:     "existing user tags kept",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n    userTags:\n      team: other\n",
+			expected: "platform:\n  aws:\n    region: us-east-1\n    userTags:\n      cost-center: \"1234\"\n      team: other\n",
+		},
+		{
+			name:     "existing user tags overridden",
+			icData:   "platform:\n  aws:\n    region: us-east-1\n    userTags:\n      owner: me\n      team: other\n",
+			override: true,
+			expected: "platform:\n  aws:\n    region: us-east-1\n    userTags:\n      cost-center: \"1234\"\n      owner: me\n      team: hive\n",
+		},
+		{
+			name:     "unsupported platform",
+			icData:   "platform:\n  gcp:\n    region: us-central1\n",
+			expected: "platform:\n  gcp:\n    region: us-central1\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := setInstallConfigUserTags([]byte(test.icData), map[string]string{"team": "hive", "cost-center": "1234"}, test.override)
+			require.NoError(t, err, "unexpected error setting user tags")
+			assert.Equal(t, test.expected, string(actual), "unexpected InstallConfig with user tags")
+		})
+	}
+}
+
+func Test_copyCredentialsManifests(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "installmanagercredentials")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(tempDir)
+	src := filepath.Join(tempDir, "credentials-manifests")
+	workDir := filepath.Join(tempDir, "work")
+	// Lay out the directory like a secret volume, with the files of the keys linking to a hidden data directory.
+	dataDir := filepath.Join(src, "..data")
+	require.NoError(t, os.MkdirAll(dataDir, 0755), "unexpected error creating data dir")
+	require.NoError(t, os.MkdirAll(filepath.Join(workDir, "manifests"), 0755), "unexpected error creating manifests dir")
+	files := map[string]string{
+		"cluster-authentication-02-config.yaml":    "authentication",
+		"openshift-ingress-cloud-credentials.yaml": "ingress",
+		hivev1.BoundServiceAccountSigningKeyKey:    "signing key",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, name), []byte(content), 0644), "unexpected error writing file")
+		require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(src, name)), "unexpected error linking file")
+	}
+
+	require.NoError(t, copyCredentialsManifests(src, workDir), "unexpected error copying credentials manifests")
+
+	for name, dir := range map[string]string{
+		"cluster-authentication-02-config.yaml":    "manifests",
+		"openshift-ingress-cloud-credentials.yaml": "manifests",
+		hivev1.BoundServiceAccountSigningKeyKey:    "tls",
+	} {
+		content, err := ioutil.ReadFile(filepath.Join(workDir, dir, name))
+		if assert.NoError(t, err, "unexpected error reading copied file %s", name) {
+			assert.Equal(t, files[name], string(content), "unexpected content of copied file %s", name)
+		}
+	}
+	_, err = os.Stat(filepath.Join(workDir, "manifests", "..data"))
+	assert.True(t, os.IsNotExist(err), "data dir of the secret volume should not be copied")
+}
+
+func TestHandleGatherLogsRequest(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	tests := []struct {
+		name                      string
+		annotation                string
+		lastRequest               string
+		expectedRequest           string
+		expectedCompletedRequest  string
+		expectCompletedAnnotation bool
+	}{
+		{
+			name: "no request",
+		},
+		{
+			name:                      "new request",
+			annotation:                "request-1",
+			expectedRequest:           "request-1",
+			expectedCompletedRequest:  "request-1",
+			expectCompletedAnnotation: true,
+		},
+		{
+			name:            "request already handled",
+			annotation:      "request-1",
+			lastRequest:     "request-1",
+			expectedRequest: "request-1",
+		},
+		{
+			name:                      "another request",
+			annotation:                "request-2",
+			lastRequest:               "request-1",
+			expectedRequest:           "request-2",
+			expectedCompletedRequest:  "request-2",
+			expectCompletedAnnotation: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			if test.annotation != "" {
+				cd.Annotations = map[string]string{constants.GatherLogsAnnotation: test.annotation}
+			}
+			fakeClient := setupDefaultMocks(t, cd, testClusterProvision()).fakeKubeClient
+			im := &InstallManager{
+				log:                    log.WithField("test", test.name),
+				Namespace:              testNamespace,
+				ClusterProvisionName:   testProvisionName,
+				DynamicClient:          fakeClient,
+				updateClusterProvision: updateClusterProvisionWithRetries,
+			}
+			provision := testClusterProvision()
+
+			actualRequest := im.handleGatherLogsRequest(provision, test.lastRequest, "", nil)
+			assert.Equal(t, test.expectedRequest, actualRequest, "unexpected last request")
+
+			actualProvision := &hivev1.ClusterProvision{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, actualProvision))
+			completedRequest, ok := actualProvision.Annotations[constants.GatherLogsCompletedAnnotation]
+			assert.Equal(t, test.expectCompletedAnnotation, ok, "unexpected completed annotation presence")
+			assert.Equal(t, test.expectedCompletedRequest, completedRequest, "unexpected completed request")
+		})
+	}
+}
+
+func TestRunOpenShiftInstallCommandContextInterrupts(t *testing.T) {
+	tests := []struct {
+		name         string
+		binary       string
+		expectedLine string
+	}{
+		{
+			name: "installer exits when interrupted",
+			binary: `#!/bin/sh
+trap 'echo interrupted; exit 1' INT
+while true; do sleep 0.1; done
+`,
+			expectedLine: "interrupted",
+		},
+		{
+			name: "installer killed when ignoring the interrupt",
+			binary: `#!/bin/sh
+trap 'echo ignored' INT
+while true; do sleep 0.1; done
+`,
+			expectedLine: "ignored",
+		},
+	}
+	defer func(gracePeriod time.Duration) { installerInterruptGracePeriod = gracePeriod }(installerInterruptGracePeriod)
+	installerInterruptGracePeriod = time.Second
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir, err := ioutil.TempDir("", "installmanagertest")
+			require.NoError(t, err)
+			defer os.RemoveAll(tempDir)
+			defer os.Remove(installerConsoleLogFilePath)
+			require.NoError(t, writeFakeBinary(filepath.Join(tempDir, "openshift-install"), test.binary))
+
+			m := &InstallManager{log: log.WithField("test", test.name), WorkDir: tempDir, binaryDir: tempDir}
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(500*time.Millisecond, cancel)
+			start := time.Now()
+			assert.Error(t, m.runOpenShiftInstallCommandContext(ctx, "create", "cluster"), "expected installer to fail")
+			assert.Less(t, int64(time.Since(start)), int64(10*time.Second), "expected installer to be stopped")
+
+			output, err := ioutil.ReadFile(installerConsoleLogFilePath)
+			require.NoError(t, err)
+			assert.Contains(t, string(output), test.expectedLine, "expected installer to be interrupted")
+		})
+	}
+}
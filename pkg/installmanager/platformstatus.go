@@ -0,0 +1,107 @@
+package installmanager
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+)
+
+// terraformStateGlob matches the terraform state files left by the installer in its working directory: a single
+// terraform.tfstate, or one per stage for the installers provisioning in stages.
+const terraformStateGlob = "terraform*.tfstate"
+
+// terraformState is the part of a terraform state file holding the identifiers of the resources.
+type terraformState struct {
+	Resources []terraformResource `json:"resources"`
+}
+
+type terraformResource struct {
+	// Mode is "managed" for the resources created by terraform, and "data" for the pre-existing resources it read.
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Instances []struct {
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"instances"`
+}
+
+// readPlatformStatus harvests the identifiers of the cloud resources of the cluster from the cluster metadata and the
+// terraform state of the installer. It returns nil for platforms without platform status.
+func readPlatformStatus(workDir string, metadata *installertypes.ClusterMetadata, logger log.FieldLogger) *hivev1.PlatformStatus {
+	var resources []terraformResource
+	stateFiles, _ := filepath.Glob(filepath.Join(workDir, terraformStateGlob))
+	for _, stateFile := range stateFiles {
+		stateLogger := logger.WithField("path", stateFile)
+		data, err := ioutil.ReadFile(stateFile)
+		if err != nil {
+			stateLogger.WithError(err).Warn("could not read terraform state")
+			continue
+		}
+		state := &terraformState{}
+		if err := json.Unmarshal(data, state); err != nil {
+			stateLogger.WithError(err).Warn("could not parse terraform state")
+			continue
+		}
+		resources = append(resources, state.Resources...)
+	}
+
+	switch {
+	case metadata.AWS != nil:
+		status := &hivev1aws.PlatformStatus{
+			VPCID: resourceAttribute(resources, "aws_vpc", "id"),
+		}
+		for _, r := range resources {
+			if r.Type != "aws_route53_zone" {
+				continue
+			}
+			for _, instance := range r.Instances {
+				id, _ := instance.Attributes["zone_id"].(string)
+				// The installer creates the private zone of the cluster, and reads the public zone of the base domain.
+				switch private, _ := instance.Attributes["private_zone"].(bool); {
+				case r.Mode == "managed":
+					status.PrivateHostedZoneID = id
+				case !private:
+					status.PublicHostedZoneID = id
+				}
+			}
+		}
+		return &hivev1.PlatformStatus{AWS: status}
+	case metadata.Azure != nil:
+		return &hivev1.PlatformStatus{Azure: &hivev1azure.PlatformStatus{
+			ResourceGroupName:        metadata.Azure.ResourceGroupName,
+			VirtualNetwork:           resourceAttribute(resources, "azurerm_virtual_network", "name"),
+			NetworkResourceGroupName: resourceAttribute(resources, "azurerm_virtual_network", "resource_group_name"),
+		}}
+	case metadata.GCP != nil:
+		return &hivev1.PlatformStatus{GCP: &hivev1gcp.PlatformStatus{
+			ProjectID:        metadata.GCP.ProjectID,
+			Network:          resourceAttribute(resources, "google_compute_network", "name"),
+			NetworkProjectID: resourceAttribute(resources, "google_compute_network", "project"),
+		}}
+	}
+	return nil
+}
+
+// resourceAttribute returns the string attribute of the first instance of the resources of a type, whether created or
+// read by terraform, or an empty string if there is none.
+func resourceAttribute(resources []terraformResource, resourceType, attribute string) string {
+	for _, r := range resources {
+		if r.Type != resourceType {
+			continue
+		}
+		for _, instance := range r.Instances {
+			if value, ok := instance.Attributes[attribute].(string); ok && value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
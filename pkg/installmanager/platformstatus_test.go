@@ -0,0 +1,119 @@
+package installmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesaws "github.com/openshift/installer/pkg/types/aws"
+	installertypesazure "github.com/openshift/installer/pkg/types/azure"
+	installertypesgcp "github.com/openshift/installer/pkg/types/gcp"
+	installertypesvsphere "github.com/openshift/installer/pkg/types/vsphere"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+)
+
+const (
+	testAWSState = `{
+  "version": 4,
+  "resources": [
+    {"mode": "data", "type": "aws_route53_zone", "name": "public", "instances": [{"attributes": {"private_zone": false, "zone_id": "Z1PUBLIC"}}]},
+    {"module": "module.dns", "mode": "managed", "type": "aws_route53_zone", "name": "new_int", "instances": [{"attributes": {"zone_id": "Z2PRIVATE"}}]},
+    {"module": "module.vpc", "mode": "managed", "type": "aws_vpc", "name": "new_vpc", "instances": [{"attributes": {"id": "vpc-0123"}}]}
+  ]
+}`
+	testAzureState = `{
+  "version": 4,
+  "resources": [
+    {"module": "module.vnet", "mode": "data", "type": "azurerm_virtual_network", "name": "preexisting_virtual_network", "instances": [{"attributes": {"name": "shared-vnet", "resource_group_name": "network-rg"}}]}
+  ]
+}`
+	testGCPState = `{
+  "version": 4,
+  "resources": [
+    {"module": "module.network", "mode": "managed", "type": "google_compute_network", "name": "cluster_network", "instances": [{"attributes": {"name": "infra-network", "project": "host-project"}}]}
+  ]
+}`
+)
+
+func TestReadPlatformStatus(t *testing.T) {
+	cases := []struct {
+		name           string
+		metadata       installertypes.ClusterPlatformMetadata
+		stateFiles     map[string]string
+		expectedStatus *hivev1.PlatformStatus
+	}{
+		{
+			name:       "aws",
+			metadata:   installertypes.ClusterPlatformMetadata{AWS: &installertypesaws.Metadata{}},
+			stateFiles: map[string]string{"terraform.tfstate": testAWSState},
+			expectedStatus: &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{
+				VPCID:               "vpc-0123",
+				PrivateHostedZoneID: "Z2PRIVATE",
+				PublicHostedZoneID:  "Z1PUBLIC",
+			}},
+		},
+		{
+			name:           "aws without terraform state",
+			metadata:       installertypes.ClusterPlatformMetadata{AWS: &installertypesaws.Metadata{}},
+			expectedStatus: &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{}},
+		},
+		{
+			name:     "azure",
+			metadata: installertypes.ClusterPlatformMetadata{Azure: &installertypesazure.Metadata{ResourceGroupName: "infra-rg"}},
+			stateFiles: map[string]string{
+				"terraform.bootstrap.tfstate": `{"version": 4, "resources": []}`,
+				"terraform.vnet.tfstate":      testAzureState,
+			},
+			expectedStatus: &hivev1.PlatformStatus{Azure: &hivev1azure.PlatformStatus{
+				ResourceGroupName:        "infra-rg",
+				VirtualNetwork:           "shared-vnet",
+				NetworkResourceGroupName: "network-rg",
+			}},
+		},
+		{
+			name:       "gcp",
+			metadata:   installertypes.ClusterPlatformMetadata{GCP: &installertypesgcp.Metadata{ProjectID: "service-project"}},
+			stateFiles: map[string]string{"terraform.tfstate": testGCPState},
+			expectedStatus: &hivev1.PlatformStatus{GCP: &hivev1gcp.PlatformStatus{
+				ProjectID:        "service-project",
+				Network:          "infra-network",
+				NetworkProjectID: "host-project",
+			}},
+		},
+		{
+			name:       "invalid terraform state",
+			metadata:   installertypes.ClusterPlatformMetadata{GCP: &installertypesgcp.Metadata{ProjectID: "service-project"}},
+			stateFiles: map[string]string{"terraform.tfstate": "not json"},
+			expectedStatus: &hivev1.PlatformStatus{GCP: &hivev1gcp.PlatformStatus{
+				ProjectID: "service-project",
+			}},
+		},
+		{
+			name:     "platform without status",
+			metadata: installertypes.ClusterPlatformMetadata{VSphere: &installertypesvsphere.Metadata{}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "installmanagerplatformstatus")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(workDir)
+			for name, content := range tc.stateFiles {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, name), []byte(content), 0644), "unexpected error writing terraform state")
+			}
+			metadata := &installertypes.ClusterMetadata{InfraID: "infra", ClusterPlatformMetadata: tc.metadata}
+			status := readPlatformStatus(workDir, metadata, log.WithField("test", t.Name()))
+			assert.Equal(t, tc.expectedStatus, status, "unexpected platform status")
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
 	"github.com/pkg/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -61,7 +62,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 
 	retvalErrs := []error{}
 
-	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+	folder := controllerutils.InstallLogsFolder(clusterName, clusterprovision.Namespace)
 
 	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
 
@@ -96,7 +97,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 }
 
 func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, secretName, namespace, region)
+	awsClient, err := awsclient.NewClient(c, secretName, namespace, region, nil)
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
@@ -0,0 +1,110 @@
+package installmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+// maxTerraformStateSecretSize is the size above which the compressed terraform state is not stored in a secret, to
+// stay clear of the size limit of secrets.
+const maxTerraformStateSecretSize = 900 * 1024
+
+// handleTerraformState applies the terraform state policy configured in the environment to the terraform state left
+// by the installer. It returns the secret the state was stored in, or nil if it was not stored in a secret.
+func (m *InstallManager) handleTerraformState(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) (*corev1.Secret, error) {
+	policy := hivev1.TerraformStatePolicy(os.Getenv(constants.TerraformStatePolicyEnvVar))
+	if policy == "" || policy == hivev1.TerraformStatePolicyDiscard {
+		return nil, nil
+	}
+	stateFiles, err := filepath.Glob(filepath.Join(m.WorkDir, terraformStateGlob))
+	if err != nil {
+		return nil, err
+	}
+	if len(stateFiles) == 0 {
+		m.log.Info("installer left no terraform state")
+		return nil, nil
+	}
+
+	switch policy {
+	case hivev1.TerraformStatePolicyStoreEncrypted:
+		m.log.Infoln("uploading terraform state")
+		data := make(map[string][]byte, len(stateFiles))
+		size := 0
+		for _, stateFile := range stateFiles {
+			compressed, err := readCompressed(stateFile)
+			if err != nil {
+				return nil, err
+			}
+			data[filepath.Base(stateFile)+".gz"] = compressed
+			size += len(compressed)
+		}
+		if size > maxTerraformStateSecretSize {
+			return nil, errors.Errorf("terraform state of %d bytes is too large to be stored in a secret", size)
+		}
+		s, err := newProvisionSecret(
+			provision,
+			controllerutils.TerraformStateSecretName(m.ClusterProvisionName),
+			constants.SecretTypeTerraformState,
+			data,
+			m,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// The install job has no access to the key management service. The state is encrypted with the public key of
+		// the terraform state key, and the clusterdeployment controller encrypts its data key again with the key
+		// management service.
+		publicKey := os.Getenv(constants.TerraformStatePublicKeyEnvVar)
+		if publicKey == "" {
+			return nil, errors.New("terraform state policy is StoreEncrypted but no key to encrypt the terraform state with was passed")
+		}
+		provider, err := secretencryption.NewRSAPublicKeyProvider([]byte(publicKey))
+		if err != nil {
+			return nil, err
+		}
+		if err := secretencryption.Encrypt(provider, s); err != nil {
+			return nil, errors.Wrap(err, "could not encrypt terraform state")
+		}
+		if err := createWithRetries(s, m); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case hivev1.TerraformStatePolicyUpload:
+		if m.actuator == nil {
+			return nil, errors.New("terraform state policy is Upload but no object store is configured")
+		}
+		m.log.Infoln("uploading terraform state to object store")
+		return nil, m.actuator.UploadLogs(cd.Name, provision, m.DynamicClient, m.log, stateFiles...)
+	default:
+		return nil, errors.Errorf("unknown terraform state policy %q", policy)
+	}
+}
+
+// readCompressed returns the gzip compressed content of the file.
+func readCompressed(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read %s", path)
+	}
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+package installmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+func TestHandleTerraformState(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	privateKey, err := secretencryption.GenerateRSAKey()
+	require.NoError(t, err, "unexpected error generating key")
+	keyProvider, err := secretencryption.NewRSAProvider(privateKey)
+	require.NoError(t, err, "unexpected error parsing key")
+	publicKey, err := secretencryption.RSAPublicKeyPEM(keyProvider)
+	require.NoError(t, err, "unexpected error encoding public key")
+
+	cases := []struct {
+		name          string
+		policy        hivev1.TerraformStatePolicy
+		noPublicKey   bool
+		stateFiles    map[string]string
+		expectSecret  bool
+		expectedError string
+	}{
+		{
+			name:       "no policy",
+			stateFiles: map[string]string{"terraform.tfstate": "state"},
+		},
+		{
+			name:       "discard",
+			policy:     hivev1.TerraformStatePolicyDiscard,
+			stateFiles: map[string]string{"terraform.tfstate": "state"},
+		},
+		{
+			name:   "store without terraform state",
+			policy: hivev1.TerraformStatePolicyStoreEncrypted,
+		},
+		{
+			name:   "store",
+			policy: hivev1.TerraformStatePolicyStoreEncrypted,
+			stateFiles: map[string]string{
+				"terraform.tfstate":           "state",
+				"terraform.bootstrap.tfstate": "bootstrap state",
+			},
+			expectSecret: true,
+		},
+		{
+			name:          "store without key",
+			policy:        hivev1.TerraformStatePolicyStoreEncrypted,
+			noPublicKey:   true,
+			stateFiles:    map[string]string{"terraform.tfstate": "state"},
+			expectedError: "no key to encrypt the terraform state with",
+		},
+		{
+			name:          "upload without object store",
+			policy:        hivev1.TerraformStatePolicyUpload,
+			stateFiles:    map[string]string{"terraform.tfstate": "state"},
+			expectedError: "no object store is configured",
+		},
+		{
+			name:          "unknown policy",
+			policy:        "Keep",
+			stateFiles:    map[string]string{"terraform.tfstate": "state"},
+			expectedError: "unknown terraform state policy",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "installmanagerterraformstate")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(workDir)
+			for name, content := range tc.stateFiles {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(workDir, name), []byte(content), 0644), "unexpected error writing terraform state")
+			}
+			os.Setenv(constants.TerraformStatePolicyEnvVar, string(tc.policy))
+			defer os.Unsetenv(constants.TerraformStatePolicyEnvVar)
+			if !tc.noPublicKey {
+				os.Setenv(constants.TerraformStatePublicKeyEnvVar, string(publicKey))
+				defer os.Unsetenv(constants.TerraformStatePublicKeyEnvVar)
+			}
+
+			mocks := setupDefaultMocks(t, testClusterProvision())
+			defer mocks.mockCtrl.Finish()
+			m := &InstallManager{
+				log:                  log.WithField("test", t.Name()),
+				WorkDir:              workDir,
+				ClusterProvisionName: testProvisionName,
+				Namespace:            testNamespace,
+				DynamicClient:        mocks.fakeKubeClient,
+			}
+
+			secret, err := m.handleTerraformState(testClusterDeployment(), testClusterProvision())
+			if tc.expectedError != "" {
+				if assert.Error(t, err, "expected error") {
+					assert.Contains(t, err.Error(), tc.expectedError, "unexpected error")
+				}
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+			if !tc.expectSecret {
+				assert.Nil(t, secret, "expected no terraform state secret")
+				return
+			}
+			if !assert.NotNil(t, secret, "expected terraform state secret") {
+				return
+			}
+			stored := &corev1.Secret{}
+			require.NoError(t, mocks.fakeKubeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: secret.Name}, stored), "unexpected error getting terraform state secret")
+			assert.Equal(t, "test-provision-terraform-state", stored.Name, "unexpected secret name")
+			assert.Equal(t, constants.SecretTypeTerraformState, stored.Labels[constants.SecretTypeLabel], "incorrect secret type label")
+			assert.Len(t, stored.Data, len(tc.stateFiles), "unexpected number of terraform state files")
+			assert.Equal(t, secretencryption.RSAProviderName, stored.Annotations[constants.EncryptionProviderAnnotation], "expected terraform state to be encrypted with the terraform state key")
+			data, err := secretencryption.Decrypt(keyProvider, stored)
+			require.NoError(t, err, "unexpected error decrypting terraform state")
+			for name, content := range tc.stateFiles {
+				r, err := gzip.NewReader(bytes.NewReader(data[name+".gz"]))
+				if !assert.NoError(t, err, "unexpected error decompressing %s", name) {
+					continue
+				}
+				decompressed, err := ioutil.ReadAll(r)
+				if assert.NoError(t, err, "unexpected error decompressing %s", name) {
+					assert.Equal(t, content, string(decompressed), "unexpected content of %s", name)
+				}
+			}
+		})
+	}
+}
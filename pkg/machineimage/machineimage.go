@@ -0,0 +1,156 @@
+// Package machineimage resolves the machine image used to boot the machines of a cluster when it overrides the RHCOS
+// image of the release, for example to use golden images or to install in disconnected environments.
+package machineimage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// imagesDataEntryName is the data entry of the machine images ConfigMap which lists the images.
+	imagesDataEntryName = "images"
+)
+
+// ReleaseImages are the machine images to use for a release.
+type ReleaseImages struct {
+	// ReleaseImage is the release image the machine images are used for.
+	ReleaseImage string `json:"releaseImage"`
+
+	// Architecture is the architecture of the machine images. Defaults to amd64. A multi-architecture release image
+	// is listed once for each architecture.
+	Architecture hivev1.Architecture `json:"architecture,omitempty"`
+
+	// AWS maps the regions to the AMIs to use in them.
+	AWS map[string]string `json:"aws,omitempty"`
+
+	// OpenStack is the URL of the image to use on OpenStack, or the name of an existing Glance image.
+	OpenStack string `json:"openstack,omitempty"`
+
+	// VSphere is the URL of the OVA to use on vSphere.
+	VSphere string `json:"vsphere,omitempty"`
+}
+
+// Resolver resolves the machine image of a cluster deployment.
+type Resolver interface {
+	// Resolve returns the machine image to boot the control plane machines of the cluster deployment from for the
+	// release image. An empty string is returned when the image of the release must be used.
+	Resolve(cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (string, error)
+}
+
+// NewResolver returns a resolver which uses the image overrides of the platform of the cluster deployments, falling
+// back to the images listed for the release in the machine images ConfigMap of the Hive namespace. The images listed
+// in the ConfigMap are cached until the ConfigMap changes.
+func NewResolver(c client.Client) Resolver {
+	return &resolver{client: c}
+}
+
+type resolver struct {
+	client client.Client
+
+	mutex sync.Mutex
+	// resourceVersion is the resource version of the ConfigMap the images were cached from.
+	resourceVersion string
+	images          map[releaseImageKey]*ReleaseImages
+}
+
+type releaseImageKey struct {
+	releaseImage string
+	architecture hivev1.Architecture
+}
+
+func (r *resolver) Resolve(cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (string, error) {
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil && p.AWS.AMIID != "":
+		return p.AWS.AMIID, nil
+	case p.OpenStack != nil && p.OpenStack.ClusterOSImage != "":
+		return p.OpenStack.ClusterOSImage, nil
+	case p.VSphere != nil && p.VSphere.ClusterOSImage != "":
+		return p.VSphere.ClusterOSImage, nil
+	case p.AWS == nil && p.OpenStack == nil && p.VSphere == nil:
+		// The installer cannot override the image on the other platforms.
+		return "", nil
+	}
+
+	images, err := r.lookup(releaseImage, cd.Status.Architecture)
+	if err != nil || images == nil {
+		return "", err
+	}
+	var image string
+	switch p := cd.Spec.Platform; {
+	case p.AWS != nil:
+		image = images.AWS[controllerutils.InstallRegion(cd)]
+	case p.OpenStack != nil:
+		image = images.OpenStack
+	case p.VSphere != nil:
+		image = images.VSphere
+	}
+	if image != "" {
+		logger.WithField("releaseImage", releaseImage).WithField("machineImage", image).Debug("resolved machine image from machine images configmap")
+	}
+	return image, nil
+}
+
+// lookup returns the machine images listed for the release image and architecture, or nil if there are none.
+func (r *resolver) lookup(releaseImage string, arch hivev1.Architecture) (*ReleaseImages, error) {
+	cm := &corev1.ConfigMap{}
+	switch err := r.client.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: constants.MachineImagesConfigMapName},
+		cm,
+	); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get machine images configmap")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.images == nil || r.resourceVersion != cm.ResourceVersion {
+		images, err := parseImages(cm)
+		if err != nil {
+			return nil, err
+		}
+		r.images = images
+		r.resourceVersion = cm.ResourceVersion
+	}
+	if arch == "" {
+		arch = hivev1.ArchitectureAMD64
+	}
+	return r.images[releaseImageKey{releaseImage: releaseImage, architecture: arch}], nil
+}
+
+func parseImages(cm *corev1.ConfigMap) (map[releaseImageKey]*ReleaseImages, error) {
+	raw, ok := cm.Data[imagesDataEntryName]
+	if !ok {
+		return nil, fmt.Errorf("%s configmap does not have a %q data entry", cm.Name, imagesDataEntryName)
+	}
+	var list []ReleaseImages
+	if err := yaml.Unmarshal([]byte(raw), &list); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal data from %s configmap", cm.Name)
+	}
+	images := make(map[releaseImageKey]*ReleaseImages, len(list))
+	for i := range list {
+		arch := list[i].Architecture
+		if arch == "" {
+			arch = hivev1.ArchitectureAMD64
+		}
+		images[releaseImageKey{releaseImage: list[i].ReleaseImage, architecture: arch}] = &list[i]
+	}
+	return images, nil
+}
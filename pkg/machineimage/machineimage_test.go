@@ -0,0 +1,194 @@
+package machineimage
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testReleaseImage = "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64"
+
+	testImages = `
+- releaseImage: quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64
+  aws:
+    us-east-1: ami-east
+    us-west-2: ami-west
+  vsphere: https://mirror.example.com/rhcos-4.6.1-vmware.ova
+- releaseImage: quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64
+  architecture: arm64
+  aws:
+    us-east-1: ami-east-arm64
+`
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		releaseImage  string
+		existing      []runtime.Object
+		expectedImage string
+		expectError   bool
+	}{
+		{
+			name: "aws override",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testAWSClusterDeployment("us-east-1")
+				cd.Spec.Platform.AWS.AMIID = "ami-golden"
+				return cd
+			}(),
+			releaseImage:  testReleaseImage,
+			existing:      []runtime.Object{testConfigMap(testImages)},
+			expectedImage: "ami-golden",
+		},
+		{
+			name:          "aws lookup",
+			cd:            testAWSClusterDeployment("us-east-1"),
+			releaseImage:  testReleaseImage,
+			existing:      []runtime.Object{testConfigMap(testImages)},
+			expectedImage: "ami-east",
+		},
+		{
+			name: "aws lookup in fallback region",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testAWSClusterDeployment("us-east-1")
+				cd.Spec.Platform.AWS.FallbackRegions = []string{"us-west-2"}
+				cd.Status.InstallRegion = "us-west-2"
+				return cd
+			}(),
+			releaseImage:  testReleaseImage,
+			existing:      []runtime.Object{testConfigMap(testImages)},
+			expectedImage: "ami-west",
+		},
+		{
+			name: "aws lookup for architecture",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testAWSClusterDeployment("us-east-1")
+				cd.Status.Architecture = hivev1.ArchitectureARM64
+				return cd
+			}(),
+			releaseImage:  testReleaseImage,
+			existing:      []runtime.Object{testConfigMap(testImages)},
+			expectedImage: "ami-east-arm64",
+		},
+		{
+			name: "architecture not listed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testAWSClusterDeployment("us-east-1")
+				cd.Status.Architecture = hivev1.ArchitecturePPC64LE
+				return cd
+			}(),
+			releaseImage: testReleaseImage,
+			existing:     []runtime.Object{testConfigMap(testImages)},
+		},
+		{
+			name:         "aws region not listed",
+			cd:           testAWSClusterDeployment("eu-west-1"),
+			releaseImage: testReleaseImage,
+			existing:     []runtime.Object{testConfigMap(testImages)},
+		},
+		{
+			name: "vsphere lookup",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := &hivev1.ClusterDeployment{}
+				cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+				return cd
+			}(),
+			releaseImage:  testReleaseImage,
+			existing:      []runtime.Object{testConfigMap(testImages)},
+			expectedImage: "https://mirror.example.com/rhcos-4.6.1-vmware.ova",
+		},
+		{
+			name: "unsupported platform",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := &hivev1.ClusterDeployment{}
+				cd.Spec.Platform.GCP = &hivev1gcp.Platform{Region: "us-east-1"}
+				return cd
+			}(),
+			releaseImage: testReleaseImage,
+			existing:     []runtime.Object{testConfigMap(testImages)},
+		},
+		{
+			name:         "release not listed",
+			cd:           testAWSClusterDeployment("us-east-1"),
+			releaseImage: "quay.io/openshift-release-dev/ocp-release:4.5.0-x86_64",
+			existing:     []runtime.Object{testConfigMap(testImages)},
+		},
+		{
+			name:         "no configmap",
+			cd:           testAWSClusterDeployment("us-east-1"),
+			releaseImage: testReleaseImage,
+		},
+		{
+			name:         "invalid configmap",
+			cd:           testAWSClusterDeployment("us-east-1"),
+			releaseImage: testReleaseImage,
+			existing:     []runtime.Object{testConfigMap("not a list")},
+			expectError:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := NewResolver(fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...))
+			image, err := resolver.Resolve(test.cd, test.releaseImage, log.StandardLogger())
+			if test.expectError {
+				assert.Error(t, err, "expected error resolving machine image")
+				return
+			}
+			require.NoError(t, err, "unexpected error resolving machine image")
+			assert.Equal(t, test.expectedImage, image, "unexpected machine image")
+		})
+	}
+}
+
+func TestResolveCacheInvalidation(t *testing.T) {
+	cm := testConfigMap(testImages)
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, cm)
+	resolver := NewResolver(c)
+	cd := testAWSClusterDeployment("us-east-1")
+
+	image, err := resolver.Resolve(cd, testReleaseImage, log.StandardLogger())
+	require.NoError(t, err, "unexpected error resolving machine image")
+	assert.Equal(t, "ami-east", image, "unexpected machine image")
+
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: cm.Namespace, Name: cm.Name}, cm))
+	cm.Data[imagesDataEntryName] = "- releaseImage: " + testReleaseImage + "\n  aws:\n    us-east-1: ami-updated\n"
+	require.NoError(t, c.Update(context.TODO(), cm))
+
+	image, err = resolver.Resolve(cd, testReleaseImage, log.StandardLogger())
+	require.NoError(t, err, "unexpected error resolving machine image")
+	assert.Equal(t, "ami-updated", image, "expected machine image from updated configmap")
+}
+
+func testAWSClusterDeployment(region string) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: region}
+	return cd
+}
+
+func testConfigMap(images string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.DefaultHiveNamespace,
+			Name:      constants.MachineImagesConfigMapName,
+		},
+		Data: map[string]string{imagesDataEntryName: images},
+	}
+}
@@ -0,0 +1,59 @@
+// Package operationlog builds the ClusterOperationLogs recording the destructive operations performed on
+// ClusterDeployments. The names of the records are derived from the operations they record, so that the record of an
+// operation created by the admission webhooks, with the user who requested it, and the one created by the
+// clusteroperationlog controller from the changes it observes are the same object.
+package operationlog
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// New returns a ClusterOperationLog whose name is derived from the key of the recorded operation.
+func New(namespace, cdName string, operation hivev1.ClusterOperation, key string) *hivev1.ClusterOperationLog {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return &hivev1.ClusterOperationLog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: apihelpers.GetName(
+				fmt.Sprintf("%s-%s-%s", namespace, cdName, strings.ToLower(string(operation))),
+				fmt.Sprintf("%08x", hash.Sum32()),
+				validation.DNS1123SubdomainMaxLength,
+			),
+			Labels: map[string]string{
+				constants.ClusterDeploymentNamespaceLabel: namespace,
+				constants.ClusterDeploymentNameLabel:      cdName,
+				constants.ClusterOperationLabel:           string(operation),
+			},
+		},
+		Spec: hivev1.ClusterOperationLogSpec{
+			ClusterDeploymentRef: hivev1.ClusterOperationLogClusterDeploymentReference{
+				Namespace: namespace,
+				Name:      cdName,
+			},
+			Operation: operation,
+		},
+	}
+}
+
+// Delete returns the ClusterOperationLog of the deletion of the ClusterDeployment requested at the time.
+func Delete(cd *hivev1.ClusterDeployment, time metav1.Time) *hivev1.ClusterOperationLog {
+	opLog := New(cd.Namespace, cd.Name, hivev1.ClusterOperationDelete, string(cd.UID))
+	opLog.Spec.Time = time
+	if cd.Spec.ClusterMetadata != nil {
+		opLog.Spec.ClusterDeploymentRef.InfraID = cd.Spec.ClusterMetadata.InfraID
+	}
+	opLog.Spec.Details = "ClusterDeployment deleted"
+	if cd.Spec.PreserveOnDelete {
+		opLog.Spec.Details += "; the cluster is not deprovisioned because preserveOnDelete is set"
+	}
+	return opLog
+}
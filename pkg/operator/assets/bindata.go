@@ -3,6 +3,7 @@
 // config/clustersync/service.yaml
 // config/clustersync/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusterdeployment-mutating-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
@@ -15,6 +16,7 @@
 // config/hiveadmission/service-account.yaml
 // config/hiveadmission/service.yaml
 // config/hiveadmission/syncset-webhook.yaml
+// config/hiveadmission/tenantquota-webhook.yaml
 // config/controllers/deployment.yaml
 // config/controllers/hive_controllers_role.yaml
 // config/controllers/hive_controllers_role_binding.yaml
@@ -23,12 +25,18 @@
 // config/rbac/hive_admin_role.yaml
 // config/rbac/hive_admin_role_binding.yaml
 // config/rbac/hive_clusterpool_admin.yaml
+// config/rbac/hive_clusterpool_consumer.yaml
 // config/rbac/hive_frontend_role.yaml
 // config/rbac/hive_frontend_role_binding.yaml
 // config/rbac/hive_frontend_serviceaccount.yaml
+// config/rbac/hive_namespace_admin_role.yaml
+// config/rbac/hive_namespace_reader_role.yaml
 // config/rbac/hive_reader_role.yaml
 // config/rbac/hive_reader_role_binding.yaml
 // config/configmaps/install-log-regexes-configmap.yaml
+// config/monitoring/grafana-dashboard-configmap.yaml
+// config/monitoring/prometheusrule.yaml
+// config/monitoring/servicemonitor.yaml
 package assets
 
 import (
@@ -149,6 +157,7 @@ spec:
             controller-tools.k8s.io: "1.0"
       serviceAccount: hive-controllers
       serviceAccountName: hive-controllers
+      terminationGracePeriodSeconds: 90
       containers:
       - name: clustersync
         resources:
@@ -176,24 +185,6 @@ spec:
               fieldPath: metadata.name
         - name: HIVE_SKIP_LEADER_ELECTION
           value: "true"
-        livenessProbe:
-          failureThreshold: 3
-          httpGet:
-            path: /healthz
-            port: 8080
-            scheme: HTTP
-          periodSeconds: 10
-          successThreshold: 1
-          timeoutSeconds: 1
-        readinessProbe:
-          failureThreshold: 3
-          httpGet:
-            path: /readyz
-            port: 8080
-            scheme: HTTP
-          periodSeconds: 10
-          successThreshold: 1
-          timeoutSeconds: 1
 `)
 
 func configClustersyncStatefulsetYamlBytes() ([]byte, error) {
@@ -252,6 +243,47 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterdeploymentMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterdeploymentmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterdeploymentmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterdeploymentmutators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeployments
+  failurePolicy: Fail
+`)
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterdeploymentMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterdeployment-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -277,6 +309,8 @@ webhooks:
     resources:
     - clusterdeployments
   failurePolicy: Fail
+  # Deletions are recorded in ClusterOperationLogs, except for dry runs.
+  sideEffects: NoneOnDryRun
 `)
 
 func configHiveadmissionClusterdeploymentWebhookYamlBytes() ([]byte, error) {
@@ -507,6 +541,28 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterdeployments
+  - machinepools
+  - hivetenants
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - hivetenants/status
+  verbs:
+  - update
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusteroperationlogs
+  verbs:
+  - create
 - apiGroups:
   - ""
   resources:
@@ -763,6 +819,57 @@ func configHiveadmissionSyncsetWebhookYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionTenantquotaWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: tenantquotavalidators.admission.hive.openshift.io
+webhooks:
+- name: tenantquotavalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/tenantquotavalidators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeployments
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - machinepools
+  failurePolicy: Fail
+  # The usage of the HiveTenants is charged when requests are admitted, except for dry runs.
+  sideEffects: NoneOnDryRun
+`)
+
+func configHiveadmissionTenantquotaWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionTenantquotaWebhookYaml, nil
+}
+
+func configHiveadmissionTenantquotaWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionTenantquotaWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/tenantquota-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configControllersDeploymentYaml = []byte(`apiVersion: apps/v1
 kind: Deployment
 metadata:
@@ -814,15 +921,7 @@ spec:
           valueFrom:
             fieldRef:
               fieldPath: metadata.namespace
-        readinessProbe:
-          httpGet:
-            path: /readyz
-            port: 8080
-        livenessProbe:
-          httpGet:
-            path: /healthz
-            port: 8080
-      terminationGracePeriodSeconds: 10
+      terminationGracePeriodSeconds: 90
 `)
 
 func configControllersDeploymentYamlBytes() ([]byte, error) {
@@ -900,6 +999,7 @@ rules:
   resources:
   - pods
   - pods/log
+  - podtemplates
   verbs:
   - get
   - list
@@ -923,6 +1023,22 @@ rules:
   - backups
   verbs:
   - create
+- apiGroups:
+  - operator.openshift.io
+  resources:
+  - imagecontentsourcepolicies
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - config.openshift.io
+  resources:
+  - imagedigestmirrorsets
+  verbs:
+  - get
+  - list
+  - watch
 `)
 
 func configControllersHive_controllers_roleYamlBytes() ([]byte, error) {
@@ -1008,11 +1124,6 @@ spec:
   - name: metrics
     port: 2112
     protocol: TCP
-  # Expose 6060 for pprof data. Normally nothing listening here unless a developer has
-  # compiled in pprof support. See Hive developer documentation for how to use.
-  - name: profiling
-    port: 6060
-    protocol: TCP
 `)
 
 func configControllersServiceYamlBytes() ([]byte, error) {
@@ -1070,6 +1181,8 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  # Operation logs are an audit trail and are only deleted by Hive once they expire.
+  - clusteroperationlogs
   verbs:
   - get
   - list
@@ -1078,7 +1191,9 @@ rules:
   - hive.openshift.io
   resources:
   - clusterimagesets
+  - clusterupgrades
   - hiveconfigs
+  - hivetenants
   - selectorsyncsets
   - selectorsyncidentityproviders
   verbs:
@@ -1254,6 +1369,48 @@ func configRbacHive_clusterpool_adminYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configRbacHive_clusterpool_consumerYaml = []byte(`# hive-cluster-pool-consumer is a role intended for users who claim clusters from cluster pools. The cluster
+# pool controller binds the consumer groups of each cluster pool to this role in the namespace of the pool.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: hive-cluster-pool-consumer
+rules:
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterpools
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterclaims
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - delete
+`)
+
+func configRbacHive_clusterpool_consumerYamlBytes() ([]byte, error) {
+	return _configRbacHive_clusterpool_consumerYaml, nil
+}
+
+func configRbacHive_clusterpool_consumerYaml() (*asset, error) {
+	bytes, err := configRbacHive_clusterpool_consumerYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/rbac/hive_clusterpool_consumer.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configRbacHive_frontend_roleYaml = []byte(`# hive-frontend is a role intended for integrating applications acting as a frontend
 # to Hive. These applications will need quite powerful permissions in the Hive cluster
 # to create namespaces to organize clusters, as well as all the required objects in those
@@ -1407,6 +1564,123 @@ func configRbacHive_frontend_serviceaccountYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configRbacHive_namespace_admin_roleYaml = []byte(`# hive-namespace-admin grants the permissions to manage the hive resources of a namespace. It is aggregated
+# into the default admin and edit roles, so users who can edit a namespace can also manage its clusters.
+# HiveTenants are read-only so that namespace admins cannot raise their own quotas.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: hive-namespace-admin
+  labels:
+    rbac.authorization.k8s.io/aggregate-to-admin: "true"
+    rbac.authorization.k8s.io/aggregate-to-edit: "true"
+rules:
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterclaims
+  - clusterdeployments
+  - clusterdeprovisions
+  - clusterpools
+  - machinepools
+  - syncidentityproviders
+  - syncsets
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterprovisions
+  - clusterstates
+  - dnszones
+  - hivetenants
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - hiveinternal.openshift.io
+  resources:
+  - clustersyncs
+  verbs:
+  - get
+  - list
+  - watch
+`)
+
+func configRbacHive_namespace_admin_roleYamlBytes() ([]byte, error) {
+	return _configRbacHive_namespace_admin_roleYaml, nil
+}
+
+func configRbacHive_namespace_admin_roleYaml() (*asset, error) {
+	bytes, err := configRbacHive_namespace_admin_roleYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/rbac/hive_namespace_admin_role.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configRbacHive_namespace_reader_roleYaml = []byte(`# hive-namespace-reader grants the permissions to view the hive resources of a namespace. It is aggregated
+# into the default view role.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: hive-namespace-reader
+  labels:
+    rbac.authorization.k8s.io/aggregate-to-view: "true"
+rules:
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterclaims
+  - clusterdeployments
+  - clusterdeprovisions
+  - clusterpools
+  - clusterprovisions
+  - clusterstates
+  - dnszones
+  - hivetenants
+  - machinepools
+  - syncidentityproviders
+  - syncsets
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - hiveinternal.openshift.io
+  resources:
+  - clustersyncs
+  verbs:
+  - get
+  - list
+  - watch
+`)
+
+func configRbacHive_namespace_reader_roleYamlBytes() ([]byte, error) {
+	return _configRbacHive_namespace_reader_roleYaml, nil
+}
+
+func configRbacHive_namespace_reader_roleYaml() (*asset, error) {
+	bytes, err := configRbacHive_namespace_reader_roleYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/rbac/hive_namespace_reader_role.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configRbacHive_reader_roleYaml = []byte(`# hive-admin is a role intended for hive administrators who need to be able to debug
 # cluster installations, and modify hive configuration.
 apiVersion: rbac.authorization.k8s.io/v1
@@ -1447,6 +1721,7 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - clusteroperationlogs
   verbs:
   - get
   - list
@@ -1455,7 +1730,9 @@ rules:
   - hive.openshift.io
   resources:
   - clusterimagesets
+  - clusterupgrades
   - hiveconfigs
+  - hivetenants
   verbs:
   - get
   - list
@@ -1569,6 +1846,11 @@ data:
       - "Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded
+    - name: AWSInsufficientCapacity
+      searchRegexStrings:
+      - "InsufficientInstanceCapacity"
+      installFailingReason: AWSInsufficientCapacity
+      installFailingMessage: AWS does not have enough capacity for the instance type in the region
     # GCP Specific
     - name: GCPInvalidProjectID
       searchRegexStrings:
@@ -1590,6 +1872,17 @@ data:
       - "Quota \'SSD_TOTAL_GB\' exceeded"
       installFailingReason: GCPQuotaSSDTotalGBExceeded
       installFailingMessage: GCP quota SSD_TOTAL_GB exceeded
+    - name: GCPZoneResourcePoolExhausted
+      searchRegexStrings:
+      - "ZONE_RESOURCE_POOL_EXHAUSTED"
+      installFailingReason: GCPZoneResourcePoolExhausted
+      installFailingMessage: GCP does not have enough resources available in the zone
+    # Azure Specific
+    - name: AzureAllocationFailed
+      searchRegexStrings:
+      - "Code=\"(Zonal)?AllocationFailed\""
+      installFailingReason: AzureAllocationFailed
+      installFailingMessage: Azure does not have enough capacity for the VM size in the region
     # Bare Metal
     - name: LibvirtSSHKeyPermissionDenied
       searchRegexStrings:
@@ -1632,6 +1925,569 @@ func configConfigmapsInstallLogRegexesConfigmapYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configMonitoringGrafanaDashboardConfigmapYaml = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: hive-grafana-dashboard
+  namespace: hive
+  labels:
+    grafana_dashboard: "1"
+data:
+  hive.json: |
+    {
+      "title": "Hive",
+      "uid": "hive",
+      "tags": [
+        "hive"
+      ],
+      "timezone": "utc",
+      "schemaVersion": 22,
+      "refresh": "1m",
+      "time": {
+        "from": "now-24h",
+        "to": "now"
+      },
+      "templating": {
+        "list": [
+          {
+            "name": "datasource",
+            "type": "datasource",
+            "query": "prometheus",
+            "label": "Data Source"
+          }
+        ]
+      },
+      "panels": [
+        {
+          "id": 1,
+          "title": "Install Results",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 0,
+            "y": 0
+          },
+          "targets": [
+            {
+              "expr": "sum by (result) (increase(hive_cluster_provision_results_total[1h]))",
+              "legendFormat": "{{result}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "short",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 2,
+          "title": "Install Failure Rate",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 12,
+            "y": 0
+          },
+          "targets": [
+            {
+              "expr": "sum by (cluster_type) (rate(hive_cluster_provision_results_total{result=\"failure\"}[1h])) / sum by (cluster_type) (rate(hive_cluster_provision_results_total[1h]))",
+              "legendFormat": "{{cluster_type}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "percentunit",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 3,
+          "title": "Install Errors by Reason",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 0,
+            "y": 8
+          },
+          "targets": [
+            {
+              "expr": "sum by (reason) (increase(hive_install_errors[1h]))",
+              "legendFormat": "{{reason}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "short",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 4,
+          "title": "Deprovisions Underway",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 12,
+            "y": 8
+          },
+          "targets": [
+            {
+              "expr": "hive_cluster_deployment_deprovision_underway_seconds",
+              "legendFormat": "{{namespace}}/{{cluster_deployment}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "s",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 5,
+          "title": "Unapplied SyncSets",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 0,
+            "y": 16
+          },
+          "targets": [
+            {
+              "expr": "hive_syncsets_unapplied_total",
+              "legendFormat": "SyncSets",
+              "refId": "A"
+            },
+            {
+              "expr": "sum(hive_selectorsyncset_clusters_unapplied_total)",
+              "legendFormat": "SelectorSyncSet clusters",
+              "refId": "B"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "short",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 6,
+          "title": "Controller Queue Depth",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 12,
+            "y": 16
+          },
+          "targets": [
+            {
+              "expr": "sum by (name) (workqueue_depth{job=~\"hive-controllers|hive-clustersync\"})",
+              "legendFormat": "{{name}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "short",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 7,
+          "title": "Controller Queue Latency (p99)",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 0,
+            "y": 24
+          },
+          "targets": [
+            {
+              "expr": "histogram_quantile(0.99, sum by (name, le) (rate(workqueue_queue_duration_seconds_bucket{job=~\"hive-controllers|hive-clustersync\"}[10m])))",
+              "legendFormat": "{{name}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "s",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 8,
+          "title": "Controller Reconcile Time (p99)",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 12,
+            "y": 24
+          },
+          "targets": [
+            {
+              "expr": "histogram_quantile(0.99, sum by (controller, le) (rate(hive_controller_reconcile_seconds_bucket[10m])))",
+              "legendFormat": "{{controller}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "s",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        },
+        {
+          "id": 9,
+          "title": "Controller Reconcile Errors",
+          "type": "graph",
+          "datasource": "${datasource}",
+          "gridPos": {
+            "h": 8,
+            "w": 12,
+            "x": 0,
+            "y": 32
+          },
+          "targets": [
+            {
+              "expr": "sum by (controller) (rate(hive_controller_reconcile_errors_total[10m])) / sum by (controller) (rate(hive_controller_reconcile_total[10m]))",
+              "legendFormat": "{{controller}}",
+              "refId": "A"
+            }
+          ],
+          "yaxes": [
+            {
+              "format": "percentunit",
+              "min": 0,
+              "show": true
+            },
+            {
+              "format": "short",
+              "show": false
+            }
+          ],
+          "lines": true,
+          "linewidth": 1,
+          "fill": 1,
+          "legend": {
+            "show": true
+          },
+          "xaxis": {
+            "mode": "time",
+            "show": true
+          }
+        }
+      ]
+    }
+`)
+
+func configMonitoringGrafanaDashboardConfigmapYamlBytes() ([]byte, error) {
+	return _configMonitoringGrafanaDashboardConfigmapYaml, nil
+}
+
+func configMonitoringGrafanaDashboardConfigmapYaml() (*asset, error) {
+	bytes, err := configMonitoringGrafanaDashboardConfigmapYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/monitoring/grafana-dashboard-configmap.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configMonitoringPrometheusruleYaml = []byte(`apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: hive-alerts
+  namespace: hive
+spec:
+  groups:
+  - name: hive-provisioning
+    rules:
+    - alert: HiveInstallFailureRateHigh
+      expr: |
+        sum by (cluster_type) (rate(hive_cluster_provision_results_total{result="failure"}[1h]))
+          /
+        sum by (cluster_type) (rate(hive_cluster_provision_results_total[1h]))
+          > 0.5
+      for: 30m
+      labels:
+        severity: warning
+      annotations:
+        summary: More than half of the installs of {{ $labels.cluster_type }} clusters are failing.
+        description: The failure reasons of the installs are reported by the hive_install_errors metric and on the ProvisionFailed condition of the ClusterDeployments.
+    - alert: HiveProvisionStuck
+      expr: hive_cluster_deployment_provision_underway_seconds > 7200
+      labels:
+        severity: warning
+      annotations:
+        summary: ClusterDeployment {{ $labels.namespace }}/{{ $labels.cluster_deployment }} has been provisioning for more than two hours.
+        description: The last condition reported for the install is {{ $labels.condition }} with reason {{ $labels.reason }}. Check the logs of the install pods of the ClusterDeployment.
+  - name: hive-deprovisioning
+    rules:
+    - alert: HiveDeprovisionStuck
+      expr: hive_cluster_deployment_deprovision_underway_seconds > 3600
+      labels:
+        severity: warning
+      annotations:
+        summary: ClusterDeployment {{ $labels.namespace }}/{{ $labels.cluster_deployment }} has been deprovisioning for more than an hour.
+        description: Check the logs of the uninstall job of the ClusterDeployment for the resources that cannot be deleted.
+  - name: hive-clusterpools
+    rules:
+    - alert: HiveClusterPoolEmpty
+      expr: hive_clusterpool_clusters_ready == 0 and hive_clusterpool_size > 0
+      for: 30m
+      labels:
+        severity: warning
+      annotations:
+        summary: ClusterPool {{ $labels.namespace }}/{{ $labels.clusterpool }} has had no clusters ready to be claimed for more than 30 minutes.
+        description: New ClusterClaims for the pool wait until a cluster is installed. Check the ClusterDeployments of the pool for failing installs, or increase the size of the pool.
+  - name: hive-syncsets
+    rules:
+    - alert: HiveSyncSetsUnapplied
+      expr: hive_syncsets_unapplied_total > 0
+      for: 1h
+      labels:
+        severity: warning
+      annotations:
+        summary: "{{ $value }} SyncSets have not been applied to their clusters for more than an hour."
+        description: Check the ClusterSync of the ClusterDeployments for the SyncSets that fail to apply.
+    - alert: HiveSelectorSyncSetsUnapplied
+      expr: hive_selectorsyncset_clusters_unapplied_total > 0
+      for: 1h
+      labels:
+        severity: warning
+      annotations:
+        summary: SelectorSyncSet {{ $labels.name }} has not been applied to {{ $value }} clusters for more than an hour.
+        description: Check the ClusterSync of the ClusterDeployments for the SelectorSyncSets that fail to apply.
+  - name: hive-controllers
+    rules:
+    - alert: HiveControllerQueueLag
+      expr: |
+        histogram_quantile(0.99, sum by (name, le) (rate(workqueue_queue_duration_seconds_bucket{job=~"hive-controllers|hive-clustersync"}[10m]))) > 60
+      for: 30m
+      labels:
+        severity: warning
+      annotations:
+        summary: Items wait more than a minute in the queue of the {{ $labels.name }} controller.
+        description: The controller is not keeping up with the changes of the resources it reconciles. Consider increasing its concurrent reconciles in the controllersConfig of the HiveConfig.
+    - alert: HiveControllerQueueDepthHigh
+      expr: workqueue_depth{job=~"hive-controllers|hive-clustersync"} > 500
+      for: 30m
+      labels:
+        severity: warning
+      annotations:
+        summary: More than 500 items are waiting in the queue of the {{ $labels.name }} controller.
+        description: The controller is not keeping up with the changes of the resources it reconciles. Consider increasing its concurrent reconciles in the controllersConfig of the HiveConfig.
+    - alert: HiveControllerReconcileErrorRateHigh
+      expr: |
+        sum by (controller) (rate(hive_controller_reconcile_errors_total[30m]))
+          /
+        sum by (controller) (rate(hive_controller_reconcile_total[30m]))
+          > 0.5
+      for: 30m
+      labels:
+        severity: warning
+      annotations:
+        summary: More than half of the reconciles of the {{ $labels.controller }} controller are failing.
+        description: Check the logs of the Hive controllers for the errors returned by the {{ $labels.controller }} controller.
+`)
+
+func configMonitoringPrometheusruleYamlBytes() ([]byte, error) {
+	return _configMonitoringPrometheusruleYaml, nil
+}
+
+func configMonitoringPrometheusruleYaml() (*asset, error) {
+	bytes, err := configMonitoringPrometheusruleYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/monitoring/prometheusrule.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configMonitoringServicemonitorYaml = []byte(`apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: hive-controllers
+  namespace: hive
+spec:
+  selector:
+    matchExpressions:
+    - key: control-plane
+      operator: In
+      values:
+      - controller-manager
+      - clustersync
+  endpoints:
+  - port: metrics
+    interval: 30s
+`)
+
+func configMonitoringServicemonitorYamlBytes() ([]byte, error) {
+	return _configMonitoringServicemonitorYaml, nil
+}
+
+func configMonitoringServicemonitorYaml() (*asset, error) {
+	bytes, err := configMonitoringServicemonitorYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/monitoring/servicemonitor.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1684,46 +2540,56 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"config/clustersync/service.yaml":                           configClustersyncServiceYaml,
-	"config/clustersync/statefulset.yaml":                       configClustersyncStatefulsetYaml,
-	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
-	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
-	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
-	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
-	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
-	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
-	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
-	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
-	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
-	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
-	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
-	"config/hiveadmission/service.yaml":                         configHiveadmissionServiceYaml,
-	"config/hiveadmission/syncset-webhook.yaml":                 configHiveadmissionSyncsetWebhookYaml,
-	"config/controllers/deployment.yaml":                        configControllersDeploymentYaml,
-	"config/controllers/hive_controllers_role.yaml":             configControllersHive_controllers_roleYaml,
-	"config/controllers/hive_controllers_role_binding.yaml":     configControllersHive_controllers_role_bindingYaml,
-	"config/controllers/hive_controllers_serviceaccount.yaml":   configControllersHive_controllers_serviceaccountYaml,
-	"config/controllers/service.yaml":                           configControllersServiceYaml,
-	"config/rbac/hive_admin_role.yaml":                          configRbacHive_admin_roleYaml,
-	"config/rbac/hive_admin_role_binding.yaml":                  configRbacHive_admin_role_bindingYaml,
-	"config/rbac/hive_clusterpool_admin.yaml":                   configRbacHive_clusterpool_adminYaml,
-	"config/rbac/hive_frontend_role.yaml":                       configRbacHive_frontend_roleYaml,
-	"config/rbac/hive_frontend_role_binding.yaml":               configRbacHive_frontend_role_bindingYaml,
-	"config/rbac/hive_frontend_serviceaccount.yaml":             configRbacHive_frontend_serviceaccountYaml,
-	"config/rbac/hive_reader_role.yaml":                         configRbacHive_reader_roleYaml,
-	"config/rbac/hive_reader_role_binding.yaml":                 configRbacHive_reader_role_bindingYaml,
-	"config/configmaps/install-log-regexes-configmap.yaml":      configConfigmapsInstallLogRegexesConfigmapYaml,
+	"config/clustersync/service.yaml":                              configClustersyncServiceYaml,
+	"config/clustersync/statefulset.yaml":                          configClustersyncStatefulsetYaml,
+	"config/hiveadmission/apiservice.yaml":                         configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml": configHiveadmissionClusterdeploymentMutatingWebhookYaml,
+	"config/hiveadmission/clusterdeployment-webhook.yaml":          configHiveadmissionClusterdeploymentWebhookYaml,
+	"config/hiveadmission/clusterimageset-webhook.yaml":            configHiveadmissionClusterimagesetWebhookYaml,
+	"config/hiveadmission/clusterprovision-webhook.yaml":           configHiveadmissionClusterprovisionWebhookYaml,
+	"config/hiveadmission/deployment.yaml":                         configHiveadmissionDeploymentYaml,
+	"config/hiveadmission/dnszones-webhook.yaml":                   configHiveadmissionDnszonesWebhookYaml,
+	"config/hiveadmission/hiveadmission_rbac_role.yaml":            configHiveadmissionHiveadmission_rbac_roleYaml,
+	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml":    configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/machinepool-webhook.yaml":                configHiveadmissionMachinepoolWebhookYaml,
+	"config/hiveadmission/selectorsyncset-webhook.yaml":            configHiveadmissionSelectorsyncsetWebhookYaml,
+	"config/hiveadmission/service-account.yaml":                    configHiveadmissionServiceAccountYaml,
+	"config/hiveadmission/service.yaml":                            configHiveadmissionServiceYaml,
+	"config/hiveadmission/syncset-webhook.yaml":                    configHiveadmissionSyncsetWebhookYaml,
+	"config/hiveadmission/tenantquota-webhook.yaml":                configHiveadmissionTenantquotaWebhookYaml,
+	"config/controllers/deployment.yaml":                           configControllersDeploymentYaml,
+	"config/controllers/hive_controllers_role.yaml":                configControllersHive_controllers_roleYaml,
+	"config/controllers/hive_controllers_role_binding.yaml":        configControllersHive_controllers_role_bindingYaml,
+	"config/controllers/hive_controllers_serviceaccount.yaml":      configControllersHive_controllers_serviceaccountYaml,
+	"config/controllers/service.yaml":                              configControllersServiceYaml,
+	"config/rbac/hive_admin_role.yaml":                             configRbacHive_admin_roleYaml,
+	"config/rbac/hive_admin_role_binding.yaml":                     configRbacHive_admin_role_bindingYaml,
+	"config/rbac/hive_clusterpool_admin.yaml":                      configRbacHive_clusterpool_adminYaml,
+	"config/rbac/hive_clusterpool_consumer.yaml":                   configRbacHive_clusterpool_consumerYaml,
+	"config/rbac/hive_frontend_role.yaml":                          configRbacHive_frontend_roleYaml,
+	"config/rbac/hive_frontend_role_binding.yaml":                  configRbacHive_frontend_role_bindingYaml,
+	"config/rbac/hive_frontend_serviceaccount.yaml":                configRbacHive_frontend_serviceaccountYaml,
+	"config/rbac/hive_namespace_admin_role.yaml":                   configRbacHive_namespace_admin_roleYaml,
+	"config/rbac/hive_namespace_reader_role.yaml":                  configRbacHive_namespace_reader_roleYaml,
+	"config/rbac/hive_reader_role.yaml":                            configRbacHive_reader_roleYaml,
+	"config/rbac/hive_reader_role_binding.yaml":                    configRbacHive_reader_role_bindingYaml,
+	"config/configmaps/install-log-regexes-configmap.yaml":         configConfigmapsInstallLogRegexesConfigmapYaml,
+	"config/monitoring/grafana-dashboard-configmap.yaml":           configMonitoringGrafanaDashboardConfigmapYaml,
+	"config/monitoring/prometheusrule.yaml":                        configMonitoringPrometheusruleYaml,
+	"config/monitoring/servicemonitor.yaml":                        configMonitoringServicemonitorYaml,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -1772,27 +2638,37 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"service.yaml":                         {configControllersServiceYaml, map[string]*bintree{}},
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
-			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
-			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
-			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
-			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
-			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
-			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
-			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
-			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
-			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
-			"service.yaml":                         {configHiveadmissionServiceYaml, map[string]*bintree{}},
-			"syncset-webhook.yaml":                 {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
+			"apiservice.yaml":                         {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusterdeployment-mutating-webhook.yaml": {configHiveadmissionClusterdeploymentMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-webhook.yaml":          {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
+			"clusterimageset-webhook.yaml":            {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
+			"clusterprovision-webhook.yaml":           {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
+			"deployment.yaml":                         {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
+			"dnszones-webhook.yaml":                   {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role.yaml":            {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role_binding.yaml":    {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"machinepool-webhook.yaml":                {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
+			"selectorsyncset-webhook.yaml":            {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
+			"service-account.yaml":                    {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
+			"service.yaml":                            {configHiveadmissionServiceYaml, map[string]*bintree{}},
+			"syncset-webhook.yaml":                    {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
+			"tenantquota-webhook.yaml":                {configHiveadmissionTenantquotaWebhookYaml, map[string]*bintree{}},
+		}},
+		"monitoring": {nil, map[string]*bintree{
+			"grafana-dashboard-configmap.yaml": {configMonitoringGrafanaDashboardConfigmapYaml, map[string]*bintree{}},
+			"prometheusrule.yaml":              {configMonitoringPrometheusruleYaml, map[string]*bintree{}},
+			"servicemonitor.yaml":              {configMonitoringServicemonitorYaml, map[string]*bintree{}},
 		}},
 		"rbac": {nil, map[string]*bintree{
 			"hive_admin_role.yaml":              {configRbacHive_admin_roleYaml, map[string]*bintree{}},
 			"hive_admin_role_binding.yaml":      {configRbacHive_admin_role_bindingYaml, map[string]*bintree{}},
 			"hive_clusterpool_admin.yaml":       {configRbacHive_clusterpool_adminYaml, map[string]*bintree{}},
+			"hive_clusterpool_consumer.yaml":    {configRbacHive_clusterpool_consumerYaml, map[string]*bintree{}},
 			"hive_frontend_role.yaml":           {configRbacHive_frontend_roleYaml, map[string]*bintree{}},
 			"hive_frontend_role_binding.yaml":   {configRbacHive_frontend_role_bindingYaml, map[string]*bintree{}},
 			"hive_frontend_serviceaccount.yaml": {configRbacHive_frontend_serviceaccountYaml, map[string]*bintree{}},
+			"hive_namespace_admin_role.yaml":    {configRbacHive_namespace_admin_roleYaml, map[string]*bintree{}},
+			"hive_namespace_reader_role.yaml":   {configRbacHive_namespace_reader_roleYaml, map[string]*bintree{}},
 			"hive_reader_role.yaml":             {configRbacHive_reader_roleYaml, map[string]*bintree{}},
 			"hive_reader_role_binding.yaml":     {configRbacHive_reader_role_bindingYaml, map[string]*bintree{}},
 		}},
@@ -2,6 +2,8 @@ package hive
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -11,6 +13,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/operator/assets"
@@ -64,6 +67,65 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if syncSetStaleThreshold := hiveconfig.Spec.SyncSetStaleThreshold; syncSetStaleThreshold != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  "SYNCSET_STALE_THRESHOLD",
+			Value: syncSetStaleThreshold,
+		})
+	}
+
+	if applyWorkers := hiveconfig.Spec.SyncSetApplyWorkers; applyWorkers != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  "SYNCSET_APPLY_WORKERS",
+			Value: strconv.Itoa(int(*applyWorkers)),
+		})
+	}
+
+	if allowedURLs := hiveconfig.Spec.SyncSetResourcesFromAllowedURLs; len(allowedURLs) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  "SYNCSET_RESOURCES_FROM_ALLOWED_URLS",
+			Value: strings.Join(allowedURLs, ","),
+		})
+	}
+
+	if watchedNamespaces := hiveconfig.Spec.WatchedNamespaces; len(watchedNamespaces) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.WatchedNamespacesEnvVar,
+			Value: strings.Join(watchedNamespaces, ","),
+		})
+	}
+
+	if secretEncryption := hiveconfig.Spec.SecretEncryption; secretEncryption != nil {
+		envVar, err := secretEncryptionEnvVar(secretEncryption)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling secret encryption config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, *envVar)
+	}
+
+	if tracing := hiveconfig.Spec.Tracing; tracing != nil && tracing.OTLPEndpoint != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.OTLPEndpointEnvVar,
+			Value: tracing.OTLPEndpoint,
+		})
+	}
+
+	if spokeTokens := hiveconfig.Spec.SpokeServiceAccountTokens; spokeTokens != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.SpokeTokenExpirationEnvVar,
+			Value: spokeTokens.Expiration,
+		})
+	}
+
+	if proxy := hiveconfig.Spec.Proxy; proxy != nil {
+		addProxy(&newClusterSyncStatefulSet.Spec.Template.Spec, proxy)
+	}
+
+	controllerutils.AddPodScheduling(&newClusterSyncStatefulSet.Spec.Template.Spec, hiveconfig.Spec.PodScheduling)
+
+	setHealthProbes(hiveContainer)
+
 	hiveNSName := getHiveNamespace(hiveconfig)
 
 	if newClusterSyncStatefulSet.Spec.Template.Annotations == nil {
@@ -143,7 +205,7 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hLog.WithError(err).Error("error applying statefulset")
 		return err
 	}
-	hLog.Infof("clustersync statefulset applied (%s)", result)
+	hLog.Infof("clustersync statefulset applied (%s)", result.Result)
 
 	hLog.Info("all clustersync components successfully reconciled")
 	return nil
@@ -46,7 +46,7 @@ func (r *ReconcileHiveConfig) deployHiveControllersConfigMap(hLog log.FieldLogge
 		hLog.WithError(err).Error("error applying hive-controllers-config configmap")
 		return "", err
 	}
-	hLog.WithField("result", result).Info("hive-controllers-config configmap applied")
+	hLog.WithField("result", result.Result).Info("hive-controllers-config configmap applied")
 
 	hLog.Info("Hashing hive-controllers-config data onto a hive deployment annotation")
 	hiveControllersConfigHash := computeHiveControllersConfigHash(hiveControllersConfigMap)
@@ -80,6 +80,9 @@ func setHiveControllersConfig(config *hivev1.ControllerConfig, hiveControllersCo
 	if config.QueueBurst != nil {
 		hiveControllersConfigMap.Data[fmt.Sprintf(utils.QueueBurstEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.QueueBurst))
 	}
+	if config.WatchRemoteCluster != nil {
+		hiveControllersConfigMap.Data[fmt.Sprintf(utils.WatchRemoteClusterEnvVariableFormat, controllerName)] = strconv.FormatBool(*config.WatchRemoteCluster)
+	}
 }
 
 func computeHiveControllersConfigHash(hiveControllersConfigMap *corev1.ConfigMap) string {
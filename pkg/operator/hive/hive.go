@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	oappsv1 "github.com/openshift/api/apps/v1"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -28,13 +30,15 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hiveconstants "github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/operator/assets"
 	"github.com/openshift/hive/pkg/operator/util"
 	"github.com/openshift/hive/pkg/resource"
 )
 
 const (
-	dnsServersEnvVar = "ZONE_CHECK_DNS_SERVERS"
+	dnsServersEnvVar           = "ZONE_CHECK_DNS_SERVERS"
+	delegationDNSServersEnvVar = "DELEGATION_CHECK_DNS_SERVERS"
 
 	// hiveAdditionalCASecret is the name of the secret in the hive namespace
 	// that will contain the aggregate of all AdditionalCertificateAuthorities
@@ -46,12 +50,42 @@ const (
 	hiveConfigHashAnnotation = "hive.openshift.io/hiveconfig-hash"
 
 	hiveClusterSyncStatefulSetSpecHashAnnotation = "hive.openshift.io/clustersync-statefulset-spec-hash"
+
+	// healthProbePort is the port serving the /healthz and /readyz endpoints of the hive controllers.
+	healthProbePort = 8080
+
+	// pprofBindAddress is the address serving the pprof endpoints of the hive controllers when enabled in HiveConfig.
+	// The endpoints are not authenticated, so they are only served on the loopback interface of the pod, reachable by
+	// port forwarding.
+	pprofBindAddress = "127.0.0.1:6060"
+
+	// releaseVerificationKeysVolumeName is the volume of the public keys which the signatures of release images are
+	// verified with.
+	releaseVerificationKeysVolumeName = "release-verification-keys"
 )
 
 var (
 	controllersUsingReplicas = hivev1.ControllerNames{hivev1.ClustersyncControllerName}
 )
 
+// validateWatchedNamespaces rejects a HiveConfig which restricts the Hive controllers to watched namespaces while
+// there are ClusterPools. The namespaces of the clusters of the pools are created by Hive with generated names, so
+// they cannot be listed, and the controllers would not see the ClusterDeployments in them.
+func (r *ReconcileHiveConfig) validateWatchedNamespaces(instance *hivev1.HiveConfig) error {
+	if len(instance.Spec.WatchedNamespaces) == 0 {
+		return nil
+	}
+	pools := &hivev1.ClusterPoolList{}
+	if err := r.List(context.TODO(), pools); err != nil {
+		return errors.Wrap(err, "error listing ClusterPools")
+	}
+	if len(pools.Items) > 0 {
+		return fmt.Errorf("watchedNamespaces cannot be set while there are ClusterPools, found ClusterPool %s/%s",
+			pools.Items[0].Namespace, pools.Items[0].Name)
+	}
+	return nil
+}
+
 func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap, hiveControllersConfigHash string) error {
 
 	asset := assets.MustAsset("config/controllers/deployment.yaml")
@@ -90,6 +124,20 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
 	}
 
+	if profiling := instance.Spec.Profiling; profiling != nil {
+		if profiling.EnablePprof {
+			hiveContainer.Args = append(hiveContainer.Args, "--pprof-bind-address", pprofBindAddress)
+		}
+		if profiling.GCPercent != nil {
+			hiveContainer.Args = append(hiveContainer.Args, "--gc-percent", strconv.Itoa(int(*profiling.GCPercent)))
+		}
+		if profiling.MemoryBallastMB > 0 {
+			hiveContainer.Args = append(hiveContainer.Args, "--memory-ballast-mb", strconv.Itoa(int(profiling.MemoryBallastMB)))
+		}
+	}
+
+	setHealthProbes(hiveContainer)
+
 	if syncSetReapplyInterval := instance.Spec.SyncSetReapplyInterval; syncSetReapplyInterval != "" {
 		syncsetReapplyIntervalEnvVar := corev1.EnvVar{
 			Name:  "SYNCSET_REAPPLY_INTERVAL",
@@ -99,6 +147,103 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if watchedNamespaces := instance.Spec.WatchedNamespaces; len(watchedNamespaces) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.WatchedNamespacesEnvVar,
+			Value: strings.Join(watchedNamespaces, ","),
+		})
+	}
+
+	if operationLogRetention := instance.Spec.OperationLogRetention; operationLogRetention != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.OperationLogRetentionEnvVar,
+			Value: operationLogRetention,
+		})
+	}
+
+	if deprovisionStuckThreshold := instance.Spec.DeprovisionStuckThreshold; deprovisionStuckThreshold != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.DeprovisionStuckThresholdEnvVar,
+			Value: deprovisionStuckThreshold,
+		})
+	}
+
+	if secretEncryption := instance.Spec.SecretEncryption; secretEncryption != nil {
+		envVar, err := secretEncryptionEnvVar(secretEncryption)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling secret encryption config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, *envVar)
+	}
+
+	if terraformState := instance.Spec.TerraformState; terraformState != nil && terraformState.Policy != "" {
+		switch {
+		case terraformState.Policy == hivev1.TerraformStatePolicyStoreEncrypted && instance.Spec.SecretEncryption == nil:
+			err := errors.New("terraform state policy StoreEncrypted requires secretEncryption")
+			hLog.WithError(err).Error("invalid terraform state config")
+			return err
+		case terraformState.Policy == hivev1.TerraformStatePolicyUpload && instance.Spec.FailedProvisionConfig.AWS == nil:
+			err := errors.New("terraform state policy Upload requires failedProvisionConfig.aws")
+			hLog.WithError(err).Error("invalid terraform state config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.TerraformStatePolicyEnvVar,
+			Value: string(terraformState.Policy),
+		})
+	}
+
+	if jobHistory := instance.Spec.JobHistory; jobHistory != nil {
+		jobHistoryJSON, err := json.Marshal(jobHistory)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling job history config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.JobHistoryEnvVar,
+			Value: string(jobHistoryJSON),
+		})
+	}
+
+	if metricsConfig := instance.Spec.MetricsConfig; metricsConfig != nil {
+		metricsConfigJSON, err := json.Marshal(metricsConfig)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling metrics config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.MetricsConfigEnvVar,
+			Value: string(metricsConfigJSON),
+		})
+	}
+
+	if tracing := instance.Spec.Tracing; tracing != nil && tracing.OTLPEndpoint != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.OTLPEndpointEnvVar,
+			Value: tracing.OTLPEndpoint,
+		})
+	}
+
+	if postInstallChecks := instance.Spec.PostInstallChecks; len(postInstallChecks) > 0 {
+		postInstallChecksJSON, err := json.Marshal(postInstallChecks)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling post-install checks")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.PostInstallChecksEnvVar,
+			Value: string(postInstallChecksJSON),
+		})
+	}
+
+	if spokeTokens := instance.Spec.SpokeServiceAccountTokens; spokeTokens != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.SpokeTokenExpirationEnvVar,
+			Value: spokeTokens.Expiration,
+		})
+	}
+
 	addManagedDomainsVolume(&hiveDeployment.Spec.Template.Spec, mdConfigMap.Name)
 
 	hiveNSName := getHiveNamespace(instance)
@@ -132,6 +277,18 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, awsLogsEnvVars...)
 	}
 
+	if fatalFailures := instance.Spec.FailedProvisionConfig.FatalFailures; len(fatalFailures) > 0 {
+		fatalFailuresJSON, err := json.Marshal(fatalFailures)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling fatal provision failures")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.FatalProvisionFailuresEnvVar,
+			Value: string(fatalFailuresJSON),
+		})
+	}
+
 	if zoneCheckDNSServers := os.Getenv(dnsServersEnvVar); len(zoneCheckDNSServers) > 0 {
 		dnsServersEnvVar := corev1.EnvVar{
 			Name:  dnsServersEnvVar,
@@ -140,6 +297,13 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, dnsServersEnvVar)
 	}
 
+	if delegationCheckDNSServers := os.Getenv(delegationDNSServersEnvVar); len(delegationCheckDNSServers) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  delegationDNSServersEnvVar,
+			Value: delegationCheckDNSServers,
+		})
+	}
+
 	if instance.Spec.Backup.Velero.Enabled {
 		hLog.Infof("Velero Backup Enabled.")
 		tmpEnvVar := corev1.EnvVar{
@@ -184,6 +348,86 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		})
 	}
 
+	if protectedWorkloads := instance.Spec.ProtectedWorkloads; protectedWorkloads != nil {
+		protectedWorkloadsJSON, err := json.Marshal(protectedWorkloads)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling protected workloads config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ProtectedWorkloadsEnvVar,
+			Value: string(protectedWorkloadsJSON),
+		})
+	}
+
+	if releaseImageVerification := instance.Spec.ReleaseImageVerification; releaseImageVerification != nil {
+		releaseImageVerificationJSON, err := json.Marshal(releaseImageVerification)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling release image verification config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ReleaseImageVerificationEnvVar,
+			Value: string(releaseImageVerificationJSON),
+		})
+		hiveDeployment.Spec.Template.Spec.Volumes = append(hiveDeployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: releaseVerificationKeysVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: releaseImageVerification.KeysConfigMapRef,
+				},
+			},
+		})
+		hiveContainer.VolumeMounts = append(hiveContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      releaseVerificationKeysVolumeName,
+			MountPath: hiveconstants.ReleaseImageVerificationKeysDir,
+			ReadOnly:  true,
+		})
+	}
+
+	if proxy := instance.Spec.Proxy; proxy != nil {
+		addProxy(&hiveDeployment.Spec.Template.Spec, proxy)
+		if proxy.TrustedCASecretRef != nil && proxy.TrustedCASecretRef.Name != "" {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.ProxyTrustedCASecretEnvVar,
+				Value: proxy.TrustedCASecretRef.Name,
+			})
+		}
+		if len(proxy.Platforms) > 0 {
+			envVar, err := platformProxiesEnvVar(proxy.Platforms)
+			if err != nil {
+				hLog.WithError(err).Error("error marshaling platform proxies")
+				return err
+			}
+			hiveContainer.Env = append(hiveContainer.Env, *envVar)
+		}
+	}
+
+	if podScheduling := instance.Spec.PodScheduling; podScheduling != nil {
+		controllerutils.AddPodScheduling(&hiveDeployment.Spec.Template.Spec, podScheduling)
+		podSchedulingJSON, err := json.Marshal(podScheduling)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling pod scheduling config")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.PodSchedulingEnvVar,
+			Value: string(podSchedulingJSON),
+		})
+	}
+
+	if propagatedLabels := instance.Spec.PropagatedLabels; len(propagatedLabels) > 0 {
+		propagatedLabelsJSON, err := json.Marshal(propagatedLabels)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling propagated labels")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.PropagatedLabelsEnvVar,
+			Value: string(propagatedLabelsJSON),
+		})
+	}
+
 	if err := r.includeAdditionalCAs(hLog, h, instance, hiveDeployment); err != nil {
 		return err
 	}
@@ -220,6 +464,12 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	applyAssets := []string{
 		"config/rbac/hive_frontend_role.yaml",
 		"config/controllers/hive_controllers_role.yaml",
+		"config/rbac/hive_admin_role.yaml",
+		"config/rbac/hive_reader_role.yaml",
+		"config/rbac/hive_clusterpool_admin.yaml",
+		"config/rbac/hive_clusterpool_consumer.yaml",
+		"config/rbac/hive_namespace_admin_role.yaml",
+		"config/rbac/hive_namespace_reader_role.yaml",
 	}
 	for _, a := range applyAssets {
 		if err := util.ApplyAssetWithGC(h, a, instance, hLog); err != nil {
@@ -247,9 +497,6 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	// NOTE: We are configuring two role bindings here, but they do not use namespaced ServiceAccount subjects.
 	// (rather global OpenShift groups), thus they do not need namespace override behavior.
 	openshiftSpecificAssets := []string{
-		"config/rbac/hive_admin_role.yaml",
-		"config/rbac/hive_reader_role.yaml",
-		"config/rbac/hive_clusterpool_admin.yaml",
 		"config/rbac/hive_admin_role_binding.yaml",
 		"config/rbac/hive_reader_role_binding.yaml",
 	}
@@ -275,7 +522,7 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hLog.WithError(err).Error("error applying deployment")
 		return err
 	}
-	hLog.Infof("hive-controllers deployment applied (%s)", result)
+	hLog.Infof("hive-controllers deployment applied (%s)", result.Result)
 
 	hLog.Info("all hive components successfully reconciled")
 	return nil
@@ -325,7 +572,7 @@ func (r *ReconcileHiveConfig) includeAdditionalCAs(hLog log.FieldLogger, h resou
 		hLog.WithError(err).Error("error applying additional cert secret")
 		return err
 	}
-	hLog.Infof("additional cert secret applied (%s)", result)
+	hLog.Infof("additional cert secret applied (%s)", result.Result)
 
 	// Generating a volume name with a hash based on the contents of the additional CA
 	// secret will ensure that when there are changes to the secret, the hive controller
@@ -474,3 +721,49 @@ func (r *ReconcileHiveConfig) deleteAllSyncSetInstances(hLog log.FieldLogger) (n
 	}
 	return
 }
+
+// setHealthProbes configures the liveness and readiness probes of a container running the hive controllers. The
+// readiness checks make requests to the API server, so they are given more time than the liveness check, which
+// only checks the leader election.
+func setHealthProbes(container *corev1.Container) {
+	container.LivenessProbe = &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/healthz",
+				Port:   intstr.FromInt(healthProbePort),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      5,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	container.ReadinessProbe = &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/readyz",
+				Port:   intstr.FromInt(healthProbePort),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		PeriodSeconds:    10,
+		TimeoutSeconds:   20,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+}
+
+// secretEncryptionEnvVar returns the environment variable passing the secret encryption config to the controllers
+// that read the admin secrets of clusters.
+func secretEncryptionEnvVar(secretEncryption *hivev1.SecretEncryptionConfig) (*corev1.EnvVar, error) {
+	secretEncryptionJSON, err := json.Marshal(secretEncryption)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.EnvVar{
+		Name:  constants.SecretEncryptionEnvVar,
+		Value: string(secretEncryptionJSON),
+	}, nil
+}
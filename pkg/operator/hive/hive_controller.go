@@ -12,6 +12,8 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/resource"
 
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -46,6 +48,9 @@ import (
 )
 
 const (
+	// ControllerName is the name of the operator controller in the metrics of its reconciles.
+	ControllerName hivev1.ControllerName = "hive-operator"
+
 	// hiveConfigName is the one and only name for a HiveConfig supported in the cluster. Any others will be ignored.
 	hiveConfigName = "hive"
 
@@ -75,7 +80,7 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("hive-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("hive-controller", mgr, controller.Options{Reconciler: hivemetrics.NewReconcilerWithMetrics(r, ControllerName)})
 	if err != nil {
 		return err
 	}
@@ -353,6 +358,12 @@ func (r *ReconcileHiveConfig) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	if err := r.validateWatchedNamespaces(instance); err != nil {
+		hLog.WithError(err).Error("invalid watched namespaces")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	err = r.deployHive(hLog, h, instance, recorder, managedDomainsConfigMap, confighash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying Hive")
@@ -379,6 +390,12 @@ func (r *ReconcileHiveConfig) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	if err := r.deployMonitoring(hLog, h, instance); err != nil {
+		hLog.WithError(err).Error("error deploying monitoring")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	if err := r.cleanupLegacySyncSetInstances(hLog); err != nil {
 		hLog.WithError(err).Error("error cleaning up legacy SyncSetInstances")
 		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
@@ -420,12 +437,12 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 			},
 		}, predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
-				hLog.WithField("predicateResponse", e.Meta.GetName() == hiveAdmissionServingCertSecretName).Debug("secret CreateEvent")
-				return e.Meta.GetName() == hiveAdmissionServingCertSecretName
+				hLog.WithField("predicateResponse", e.Meta.GetName() == constants.HiveAdmissionServingCertSecretName).Debug("secret CreateEvent")
+				return e.Meta.GetName() == constants.HiveAdmissionServingCertSecretName
 			},
 			UpdateFunc: func(e event.UpdateEvent) bool {
-				hLog.WithField("predicateResponse", e.MetaNew.GetName() == hiveAdmissionServingCertSecretName).Debug("secret UpdateEvent")
-				return e.MetaNew.GetName() == hiveAdmissionServingCertSecretName
+				hLog.WithField("predicateResponse", e.MetaNew.GetName() == constants.HiveAdmissionServingCertSecretName).Debug("secret UpdateEvent")
+				return e.MetaNew.GetName() == constants.HiveAdmissionServingCertSecretName
 			},
 		})
 		if err != nil {
@@ -476,9 +493,37 @@ func computeHash(data map[string]string) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// setHiveConfigReadyCondition sets the Ready condition of the HiveConfig from whether its current generation has been
+// applied.
+func setHiveConfigReadyCondition(hiveConfig *hivev1.HiveConfig, applied bool) {
+	status, reason, message := corev1.ConditionTrue, "ConfigApplied", "The HiveConfig has been applied"
+	if !applied {
+		status, reason, message = corev1.ConditionFalse, "ConfigNotApplied", "The HiveConfig could not be applied, see the logs of the hive operator"
+	}
+	for i := range hiveConfig.Status.Conditions {
+		cond := &hiveConfig.Status.Conditions[i]
+		if cond.Type != hivev1.HiveConfigReadyCondition {
+			continue
+		}
+		if cond.Status != status {
+			cond.LastTransitionTime = metav1.Now()
+		}
+		cond.Status, cond.Reason, cond.Message = status, reason, message
+		return
+	}
+	hiveConfig.Status.Conditions = append(hiveConfig.Status.Conditions, hivev1.HiveConfigCondition{
+		Type:               hivev1.HiveConfigReadyCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
 func (r *ReconcileHiveConfig) updateHiveConfigStatus(origHiveConfig, newHiveConfig *hivev1.HiveConfig, logger log.FieldLogger, succeeded bool) error {
 	newHiveConfig.Status.ObservedGeneration = newHiveConfig.Generation
 	newHiveConfig.Status.ConfigApplied = succeeded
+	setHiveConfigReadyCondition(newHiveConfig, succeeded)
 
 	if reflect.DeepEqual(origHiveConfig, newHiveConfig) {
 		logger.Debug("HiveConfig unchanged, no update required")
@@ -30,8 +30,7 @@ import (
 )
 
 const (
-	clusterVersionCRDName              = "clusterversions.config.openshift.io"
-	hiveAdmissionServingCertSecretName = "hiveadmission-serving-cert"
+	clusterVersionCRDName = "clusterversions.config.openshift.io"
 )
 
 const (
@@ -52,6 +51,11 @@ var webhookAssets = []string{
 	"config/hiveadmission/machinepool-webhook.yaml",
 	"config/hiveadmission/syncset-webhook.yaml",
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
+	"config/hiveadmission/tenantquota-webhook.yaml",
+}
+
+var mutatingWebhookAssets = []string{
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml",
 }
 
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap, featureGateConfigHash string) error {
@@ -113,6 +117,22 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	hiveAdmDeployment.Spec.Template.ObjectMeta.Annotations[featureGateConfigMapNameHashAnnotation] = featureGateConfigHash
 
 	addManagedDomainsVolume(&hiveAdmDeployment.Spec.Template.Spec, mdConfigMap.Name)
+	controllerutils.AddPodScheduling(&hiveAdmDeployment.Spec.Template.Spec, instance.Spec.PodScheduling)
+
+	if instance.Spec.DefaultClusterImageSet != "" {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.DefaultClusterImageSetEnvVar,
+			Value: instance.Spec.DefaultClusterImageSet,
+		})
+	}
+
+	// ClusterPools are rejected while the controllers are restricted to the watched namespaces.
+	if watchedNamespaces := instance.Spec.WatchedNamespaces; len(watchedNamespaces) > 0 {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.WatchedNamespacesEnvVar,
+			Value: strings.Join(watchedNamespaces, ","),
+		})
+	}
 
 	validatingWebhooks := make([]*admregv1.ValidatingWebhookConfiguration, len(webhookAssets))
 	for i, yaml := range webhookAssets {
@@ -121,6 +141,13 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		validatingWebhooks[i] = wh
 	}
 
+	mutatingWebhooks := make([]*admregv1.MutatingWebhookConfiguration, len(mutatingWebhookAssets))
+	for i, yaml := range mutatingWebhookAssets {
+		asset = assets.MustAsset(yaml)
+		wh := util.ReadMutatingWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme)
+		mutatingWebhooks[i] = wh
+	}
+
 	hLog.Debug("reading apiservice")
 	asset = assets.MustAsset("config/hiveadmission/apiservice.yaml")
 	apiService := util.ReadAPIServiceV1Beta1OrDie(asset, scheme.Scheme)
@@ -143,7 +170,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	if !isOpenShift || is311 {
 		hLog.Debug("non-OpenShift 4.x cluster detected, modifying hiveadmission webhooks for CA certs")
-		err = r.injectCerts(apiService, validatingWebhooks, nil, hiveNSName, hLog)
+		err = r.injectCerts(apiService, validatingWebhooks, mutatingWebhooks, hiveNSName, hLog)
 		if err != nil {
 			hLog.WithError(err).Error("error injecting certs")
 			return err
@@ -152,8 +179,8 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 
 	// Set the serving cert CA secret hash as an annotation on the pod template to force a rollout in the event it changes:
 	servingCertSecret := &corev1.Secret{}
-	if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: hiveNSName, Name: hiveAdmissionServingCertSecretName}, servingCertSecret); err != nil {
-		hLog.WithError(err).WithField("secretName", hiveAdmissionServingCertSecretName).Log(
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: hiveNSName, Name: constants.HiveAdmissionServingCertSecretName}, servingCertSecret); err != nil {
+		hLog.WithError(err).WithField("secretName", constants.HiveAdmissionServingCertSecretName).Log(
 			controllerutils.LogLevel(err), "error getting serving cert secret")
 	}
 	hLog.Info("Hashing serving cert secret onto a hiveadmission deployment annotation")
@@ -168,14 +195,14 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithError(err).Error("error applying deployment")
 		return err
 	}
-	hLog.WithField("result", result).Info("hiveadmission deployment applied")
+	hLog.WithField("result", result.Result).Info("hiveadmission deployment applied")
 
 	result, err = util.ApplyRuntimeObjectWithGC(h, apiService, instance)
 	if err != nil {
 		hLog.WithError(err).Error("error applying apiservice")
 		return err
 	}
-	hLog.Infof("apiservice applied (%s)", result)
+	hLog.Infof("apiservice applied (%s)", result.Result)
 
 	for _, webhook := range validatingWebhooks {
 		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
@@ -183,7 +210,16 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying validating webhook")
 			return err
 		}
-		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
+		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result.Result)
+	}
+
+	for _, webhook := range mutatingWebhooks {
+		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
+		if err != nil {
+			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying mutating webhook")
+			return err
+		}
+		hLog.WithField("webhook", webhook.Name).Infof("mutating webhook: %s", result.Result)
 	}
 
 	hLog.Info("hiveadmission components reconciled successfully")
@@ -297,7 +333,7 @@ func (r *ReconcileHiveConfig) deployFeatureGatesConfigMap(hLog log.FieldLogger,
 		hLog.WithError(err).Error("error applying hive-feature-gates configmap")
 		return "", err
 	}
-	hLog.WithField("result", result).Info("hive-feature-gates configmap applied")
+	hLog.WithField("result", result.Result).Info("hive-feature-gates configmap applied")
 
 	return computeConfigHash(cm), nil
 }
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -26,6 +29,10 @@ const (
 // configureManagedDomains will create a new configmap holding the managed domains settings (if necessary), or simply
 // return the current configmap of the current deployment if the settings it contains match the desired settings.
 func (r *ReconcileHiveConfig) configureManagedDomains(logger log.FieldLogger, instance *hivev1.HiveConfig) (*corev1.ConfigMap, error) {
+	if err := validateManagedDomains(instance.Spec.ManagedDomains); err != nil {
+		return nil, errors.Wrap(err, "invalid managed domains")
+	}
+
 	domains, err := json.Marshal(instance.Spec.ManagedDomains)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal managed domains list into the configmap")
@@ -66,6 +73,44 @@ func (r *ReconcileHiveConfig) configureManagedDomains(logger log.FieldLogger, in
 	return mdConfigMap, nil
 }
 
+// validateManagedDomains checks that each managed domain is a valid DNS name listed only once, and that each set of
+// domains has the credentials of exactly one cloud, so that the controllers can find the credentials for the parent
+// zone of each managed DNS zone.
+func validateManagedDomains(managedDomains []hivev1.ManageDNSConfig) error {
+	seen := sets.NewString()
+	for i, md := range managedDomains {
+		if len(md.Domains) == 0 {
+			return fmt.Errorf("managedDomains[%d] must list at least one domain", i)
+		}
+		for _, domain := range md.Domains {
+			if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+				return fmt.Errorf("managed domain %q is not a valid DNS name: %s", domain, strings.Join(errs, ", "))
+			}
+			if seen.Has(domain) {
+				return fmt.Errorf("managed domain %q is listed more than once", domain)
+			}
+			seen.Insert(domain)
+		}
+		var credentialsSecretRefs []corev1.LocalObjectReference
+		if md.AWS != nil {
+			credentialsSecretRefs = append(credentialsSecretRefs, md.AWS.CredentialsSecretRef)
+		}
+		if md.GCP != nil {
+			credentialsSecretRefs = append(credentialsSecretRefs, md.GCP.CredentialsSecretRef)
+		}
+		if md.Azure != nil {
+			credentialsSecretRefs = append(credentialsSecretRefs, md.Azure.CredentialsSecretRef)
+		}
+		if len(credentialsSecretRefs) != 1 {
+			return fmt.Errorf("managedDomains[%d] must configure exactly one cloud", i)
+		}
+		if credentialsSecretRefs[0].Name == "" {
+			return fmt.Errorf("managedDomains[%d] must reference a credentials secret", i)
+		}
+	}
+	return nil
+}
+
 // getCurrentConfigMap will see if any existing configmap (for managed domains) already has the necessary
 // settings. It will also delete any configmaps (for managed domains) that have out-of-date contents
 // (so that the configmaps are not orphaned as config changes happen).
@@ -0,0 +1,104 @@
+package hive
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	grafanaDashboardAsset = "config/monitoring/grafana-dashboard-configmap.yaml"
+	grafanaDashboardName  = "hive-grafana-dashboard"
+)
+
+var (
+	prometheusOperatorGroupVersion = schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}
+
+	// prometheusOperatorAssets are the monitoring assets that are only deployed when the Prometheus operator API is available.
+	prometheusOperatorAssets = []struct {
+		path     string
+		name     string
+		resource string
+	}{
+		{path: "config/monitoring/servicemonitor.yaml", name: "hive-controllers", resource: "servicemonitors"},
+		{path: "config/monitoring/prometheusrule.yaml", name: "hive-alerts", resource: "prometheusrules"},
+	}
+)
+
+// deployMonitoring applies the alerting rules and dashboards for Hive when monitoring is enabled in HiveConfig, and
+// removes them when it is not.
+func (r *ReconcileHiveConfig) deployMonitoring(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) error {
+	hiveNSName := getHiveNamespace(instance)
+
+	hasPrometheusOperator, err := r.prometheusOperatorAvailable(hLog)
+	if err != nil {
+		return err
+	}
+
+	if !instance.Spec.Monitoring.Enabled {
+		return r.removeMonitoring(hLog, hiveNSName, hasPrometheusOperator)
+	}
+
+	if err := util.ApplyAssetWithNSOverrideAndGC(h, grafanaDashboardAsset, hiveNSName, instance); err != nil {
+		hLog.WithError(err).Error("error applying grafana dashboard")
+		return err
+	}
+	hLog.WithField("asset", grafanaDashboardAsset).Info("applied asset with namespace override")
+
+	if !hasPrometheusOperator {
+		hLog.Warn("the Prometheus operator API is not available, alerting rules for hive will not be deployed")
+		return nil
+	}
+	for _, a := range prometheusOperatorAssets {
+		if err := util.ApplyAssetWithNSOverrideAndGC(h, a.path, hiveNSName, instance); err != nil {
+			hLog.WithError(err).Error("error applying object with namespace override")
+			return err
+		}
+		hLog.WithField("asset", a.path).Info("applied asset with namespace override")
+	}
+	return nil
+}
+
+func (r *ReconcileHiveConfig) removeMonitoring(hLog log.FieldLogger, hiveNSName string, hasPrometheusOperator bool) error {
+	dashboard := &corev1.ConfigMap{}
+	dashboard.Name = grafanaDashboardName
+	dashboard.Namespace = hiveNSName
+	if err := r.Delete(context.TODO(), dashboard); err != nil && !apierrors.IsNotFound(err) {
+		hLog.WithError(err).Error("error deleting grafana dashboard")
+		return err
+	}
+
+	if !hasPrometheusOperator {
+		return nil
+	}
+	for _, a := range prometheusOperatorAssets {
+		c := r.dynamicClient.Resource(prometheusOperatorGroupVersion.WithResource(a.resource)).Namespace(hiveNSName)
+		if err := c.Delete(context.TODO(), a.name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			hLog.WithError(err).WithField("resource", a.resource).Error("error deleting monitoring resource")
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileHiveConfig) prometheusOperatorAvailable(hLog log.FieldLogger) (bool, error) {
+	list, err := r.discoveryClient.ServerResourcesForGroupVersion(prometheusOperatorGroupVersion.String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			hLog.WithError(err).Debug("Prometheus operator API not found")
+			return false, nil
+		}
+		hLog.WithError(err).Error("Error determining whether the Prometheus operator API is available")
+		return false, err
+	}
+	return len(list.APIResources) > 0, nil
+}
@@ -0,0 +1,60 @@
+package hive
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// defaultNoProxy are the addresses of the hub which are always reached without the proxy, along with the address
+// of its API server.
+var defaultNoProxy = []string{".cluster.local", ".svc", "localhost", "127.0.0.1"}
+
+// addProxy makes the outbound connections of the first container of the pod go through the proxy, trusting the
+// certificate authorities of the proxy.
+func addProxy(podSpec *corev1.PodSpec, proxy *hivev1.ProxyConfig) {
+	container := &podSpec.Containers[0]
+	container.Env = append(container.Env, controllerutils.ProxyEnvVars(proxy.HTTPProxy, proxy.HTTPSProxy, noProxy(proxy.NoProxy))...)
+
+	if proxy.TrustedCASecretRef == nil || proxy.TrustedCASecretRef.Name == "" {
+		return
+	}
+	controllerutils.AddProxyTrustedCA(podSpec, &podSpec.Containers[0], proxy.TrustedCASecretRef.Name)
+}
+
+// platformProxiesEnvVar returns the environment variable passing the proxies of the platforms to the controller
+// manager. The install and uninstall jobs reach the API server of the hub without the proxy.
+func platformProxiesEnvVar(platforms []hivev1.PlatformProxyConfig) (*corev1.EnvVar, error) {
+	jobPlatforms := make([]hivev1.PlatformProxyConfig, len(platforms))
+	for i, p := range platforms {
+		jobPlatforms[i] = p
+		jobPlatforms[i].NoProxy = noProxy(p.NoProxy)
+	}
+	platformsJSON, err := json.Marshal(jobPlatforms)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.EnvVar{
+		Name:  constants.PlatformProxiesEnvVar,
+		Value: string(platformsJSON),
+	}, nil
+}
+
+// noProxy returns the addresses reached without the proxy, adding the addresses of the hub.
+func noProxy(noProxy string) string {
+	var entries []string
+	if noProxy != "" {
+		entries = append(entries, noProxy)
+	}
+	// The operator runs on the hub, so the API server of the hub is the one of the operator.
+	if host := os.Getenv("KUBERNETES_SERVICE_HOST"); host != "" {
+		entries = append(entries, host)
+	}
+	return strings.Join(append(entries, defaultNoProxy...), ",")
+}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"context"
+
 	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -8,9 +10,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/operator/assets"
@@ -29,12 +33,12 @@ func ApplyAsset(h resource.Helper, assetPath string, hLog log.FieldLogger) error
 	assetLog.Debug("reading asset")
 	asset := assets.MustAsset(assetPath)
 	assetLog.Debug("applying asset")
-	result, err := h.Apply(asset)
+	result, err := h.Apply(context.TODO(), asset)
 	if err != nil {
 		assetLog.WithError(err).Error("error applying asset")
 		return err
 	}
-	assetLog.Infof("asset applied successfully: %v", result)
+	assetLog.Infof("asset applied successfully: %v", result.Result)
 	return nil
 }
 
@@ -53,14 +57,14 @@ func ApplyAssetWithGC(h resource.Helper, assetPath string, hc *hivev1.HiveConfig
 		assetLog.WithError(err).Error("error applying asset")
 		return err
 	}
-	assetLog.Infof("asset applied successfully: %v", result)
+	assetLog.Infof("asset applied successfully: %v", result.Result)
 	return nil
 }
 
 // ApplyAssetWithNSOverrideAndGC loads the given asset, overrides the namespace, adds an owner reference to
 // HiveConfig for uninstall, and applies it to the cluster.
 func ApplyAssetWithNSOverrideAndGC(h resource.Helper, assetPath, namespaceOverride string, hiveConfig *hivev1.HiveConfig) error {
-	requiredObj, _, err := coreDeserializer.Decode(assets.MustAsset(assetPath), nil, nil)
+	requiredObj, err := readRuntimeObject(assetPath)
 	if err != nil {
 		return errors.Wrapf(err, "unable to decode asset: %s", assetPath)
 	}
@@ -91,10 +95,10 @@ func ApplyClusterRoleBindingAssetWithSubjectNSOverrideAndGC(h resource.Helper, r
 }
 
 // ApplyRuntimeObjectWithGC adds an OwnerReference to the HiveConfig on the runtime object, and applies it to the cluster.
-func ApplyRuntimeObjectWithGC(h resource.Helper, runtimeObj runtime.Object, hc *hivev1.HiveConfig) (resource.ApplyResult, error) {
+func ApplyRuntimeObjectWithGC(h resource.Helper, runtimeObj runtime.Object, hc *hivev1.HiveConfig) (*resource.AppliedObject, error) {
 	obj, err := meta.Accessor(runtimeObj)
 	if err != nil {
-		return resource.UnknownApplyResult, err
+		return nil, err
 	}
 	ownerRef := v1.OwnerReference{
 		APIVersion:         hc.APIVersion,
@@ -105,10 +109,20 @@ func ApplyRuntimeObjectWithGC(h resource.Helper, runtimeObj runtime.Object, hc *
 	}
 	// This assumes we have full control of owner references for these resources the operator creates.
 	obj.SetOwnerReferences([]v1.OwnerReference{ownerRef})
-	return h.ApplyRuntimeObject(runtimeObj, scheme.Scheme)
+	return h.ApplyRuntimeObject(context.TODO(), runtimeObj, scheme.Scheme)
 }
 
+// readRuntimeObject decodes an asset. Assets of kinds that are not known to the core scheme, such as the
+// resources of the Prometheus operator, are decoded as unstructured objects.
 func readRuntimeObject(assetPath string) (runtime.Object, error) {
-	obj, _, err := coreDeserializer.Decode(assets.MustAsset(assetPath), nil, nil)
-	return obj, err
+	asset := assets.MustAsset(assetPath)
+	obj, _, err := coreDeserializer.Decode(asset, nil, nil)
+	if !runtime.IsNotRegisteredError(err) {
+		return obj, err
+	}
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(asset, &u.Object); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
@@ -0,0 +1,172 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/destroy/aws"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesaws "github.com/openshift/installer/pkg/types/aws"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&awsActuator{})
+}
+
+// awsActuator handles the ClusterDeployments installed on AWS.
+type awsActuator struct{}
+
+func (a *awsActuator) Name() string {
+	return constants.PlatformAWS
+}
+
+func (a *awsActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.AWS != nil
+}
+
+func (a *awsActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.AWS != nil
+}
+
+func (a *awsActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	p := cd.Spec.Platform.AWS
+	return append([]string{p.Region}, p.FallbackRegions...)
+}
+
+func (a *awsActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.AWS.CredentialsSecretRef.Name
+}
+
+func (a *awsActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+	p := cd.Spec.Platform.AWS
+	additionalTags := make([]hivev1.AWSResourceTag, 0, len(p.UserTags))
+	for k, v := range p.UserTags {
+		additionalTags = append(additionalTags, hivev1.AWSResourceTag{Key: k, Value: v})
+	}
+	region := ""
+	if strings.HasPrefix(p.Region, constants.AWSChinaRegionPrefix) {
+		region = constants.AWSChinaRoute53Region
+	}
+	spec.AWS = &hivev1.AWSDNSZoneSpec{
+		CredentialsSecretRef: p.CredentialsSecretRef,
+		AdditionalTags:       additionalTags,
+		Region:               region,
+		ServiceEndpoints:     p.ServiceEndpoints,
+	}
+}
+
+func (a *awsActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		AWS: &hivev1.AWSClusterDeprovision{
+			Region:               region,
+			CredentialsSecretRef: &cd.Spec.Platform.AWS.CredentialsSecretRef,
+			ServiceEndpoints:     cd.Spec.Platform.AWS.ServiceEndpoints,
+		},
+	}
+}
+
+func (a *awsActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	p := dp.Spec.Platform.AWS
+	args := []string{
+		"aws-tag-deprovision",
+		"--loglevel",
+		"debug",
+		"--region",
+		p.Region,
+	}
+	for _, e := range p.ServiceEndpoints {
+		args = append(args, "--service-endpoint", fmt.Sprintf("%s=%s", e.Name, e.URL))
+	}
+	args = append(args, fmt.Sprintf("kubernetes.io/cluster/%s=owned", dp.Spec.InfraID))
+	if len(dp.Spec.ClusterID) > 0 {
+		// Also cleanup anything with the tag for the legacy cluster ID (credentials still using this for example)
+		args = append(args, fmt.Sprintf("openshiftClusterID=%s", dp.Spec.ClusterID))
+	}
+	creds := &PodCredentials{}
+	if p.CredentialsSecretRef != nil && len(p.CredentialsSecretRef.Name) > 0 {
+		creds.mountSecret("aws-creds", p.CredentialsSecretRef.Name, constants.AWSCredsMount)
+	}
+	setDeprovisionContainer(job, creds, args...)
+}
+
+func (a *awsActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	credentialsSecretRef := cd.Spec.Platform.AWS.CredentialsSecretRef
+	return &PodCredentials{
+		Env: []corev1.EnvVar{
+			{
+				Name: "AWS_ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: credentialsSecretRef,
+						Key:                  constants.AWSAccessKeyIDSecretKey,
+					},
+				},
+			},
+			{
+				Name: "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: credentialsSecretRef,
+						Key:                  constants.AWSSecretAccessKeySecretKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *awsActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+	cd.Spec.Platform.AWS.Region = region
+}
+
+func (a *awsActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return cd.Spec.Platform.AWS.UserTags
+}
+
+func (a *awsActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *awsActuator) Hibernator() Hibernator {
+	return &awsHibernator{awsClientFn: getAWSClient}
+}
+
+func (a *awsActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	p := cd.Spec.Platform.AWS
+	uninstaller := &aws.ClusterUninstaller{
+		Filters: []aws.Filter{
+			{fmt.Sprintf("kubernetes.io/cluster/%s", infraID): "owned"},
+		},
+		Region: p.Region,
+		Logger: logger,
+	}
+	if len(p.ServiceEndpoints) > 0 {
+		serviceEndpoints := make([]installertypesaws.ServiceEndpoint, len(p.ServiceEndpoints))
+		for i, e := range p.ServiceEndpoints {
+			serviceEndpoints[i] = installertypesaws.ServiceEndpoint{Name: e.Name, URL: e.URL}
+		}
+		session, err := awssession.GetSessionWithOptions(
+			awssession.WithRegion(p.Region),
+			awssession.WithServiceEndpoints(p.Region, serviceEndpoints),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create AWS session")
+		}
+		uninstaller.Session = session
+	}
+	return uninstaller, nil
+}
+
+func (a *awsActuator) DNSCleaner() DNSCleaner {
+	return &awsDNSCleaner{}
+}
@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"fmt"
@@ -15,38 +15,30 @@ import (
 )
 
 var (
-	runningStates           = sets.NewString("running")
-	stoppedStates           = sets.NewString("stopped")
-	pendingStates           = sets.NewString("pending")
-	stoppingStates          = sets.NewString("stopping", "shutting-down")
-	runningOrPendingStates  = runningStates.Union(pendingStates)
-	stoppedOrStoppingStates = stoppedStates.Union(stoppingStates)
-	notRunningStates        = stoppedOrStoppingStates.Union(pendingStates)
-	notStoppedStates        = runningOrPendingStates.Union(stoppingStates)
+	awsRunningStates           = sets.NewString("running")
+	awsStoppedStates           = sets.NewString("stopped")
+	awsPendingStates           = sets.NewString("pending")
+	awsStoppingStates          = sets.NewString("stopping", "shutting-down")
+	awsRunningOrPendingStates  = awsRunningStates.Union(awsPendingStates)
+	awsStoppedOrStoppingStates = awsStoppedStates.Union(awsStoppingStates)
+	awsNotRunningStates        = awsStoppedOrStoppingStates.Union(awsPendingStates)
+	awsNotStoppedStates        = awsRunningOrPendingStates.Union(awsStoppingStates)
 )
 
-func init() {
-	RegisterActuator(&awsActuator{awsClientFn: getAWSClient})
-}
-
-type awsActuator struct {
+// awsHibernator stops and starts the EC2 instances of the clusters installed on AWS.
+type awsHibernator struct {
 	// awsClientFn is the function to build an AWS client, here for testing
 	awsClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error)
 }
 
-// CanHandle returns true if the actuator can handle a particular ClusterDeployment
-func (a *awsActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
-	return cd.Spec.Platform.AWS != nil
-}
-
 // StopMachines will stop machines belonging to the given ClusterDeployment
-func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *awsHibernator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, runningOrPendingStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, awsRunningOrPendingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -65,13 +57,13 @@ func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *awsHibernator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, stoppedOrStoppingStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, awsStoppedOrStoppingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -91,14 +83,14 @@ func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Clien
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *awsHibernator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "aws")
 	logger.Infof("checking whether machines are running")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notRunningStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, awsNotRunningStates, logger)
 	if err != nil {
 		return false, err
 	}
@@ -107,14 +99,14 @@ func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Cli
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *awsHibernator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "aws")
 	logger.Infof("checking whether machines are stopped")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notStoppedStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, awsNotStoppedStates, logger)
 	if err != nil {
 		return false, err
 	}
@@ -122,7 +114,7 @@ func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Cli
 }
 
 func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, cd.Spec.Platform.AWS.Region)
+	awsClient, err := awsclient.NewClient(c, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, InstallRegion(cd), cd.Spec.Platform.AWS.ServiceEndpoints)
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
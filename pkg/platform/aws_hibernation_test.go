@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"fmt"
@@ -15,24 +15,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
-	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	mockawsclient "github.com/openshift/hive/pkg/awsclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
 
-func TestCanHandle(t *testing.T) {
-	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
-		cd.Spec.Platform.AWS = &hivev1aws.Platform{}
-	}).Build()
-	actuator := awsActuator{}
-	assert.True(t, actuator.CanHandle(cd))
-
-	cd = testcd.BasicBuilder().Build()
-	assert.False(t, actuator.CanHandle(cd))
-}
-
-func TestStopAndStartMachines(t *testing.T) {
+func TestAWSStopAndStartMachines(t *testing.T) {
 	tests := []struct {
 		name        string
 		testFunc    string
@@ -118,7 +106,7 @@ func TestStopAndStartMachines(t *testing.T) {
 	}
 }
 
-func TestMachinesStoppedAndRunning(t *testing.T) {
+func TestAWSMachinesStoppedAndRunning(t *testing.T) {
 	tests := []struct {
 		name        string
 		testFunc    string
@@ -203,8 +191,8 @@ func matchInstanceIDs(t *testing.T, actual []*string, states map[string]int) {
 	assert.True(t, expected.Equal(actualSet), "Unexpected set of instance IDs: %v", actualSet.List())
 }
 
-func testAWSActuator(awsClient awsclient.Client) *awsActuator {
-	return &awsActuator{
+func testAWSActuator(awsClient awsclient.Client) *awsHibernator {
+	return &awsHibernator{
 		awsClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error) {
 			return awsClient, nil
 		},
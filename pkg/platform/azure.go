@@ -0,0 +1,117 @@
+package platform
+
+import (
+	"github.com/openshift/installer/pkg/destroy/azure"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesazure "github.com/openshift/installer/pkg/types/azure"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&azureActuator{})
+}
+
+// azureActuator handles the ClusterDeployments installed on Azure.
+type azureActuator struct{}
+
+func (a *azureActuator) Name() string {
+	return constants.PlatformAzure
+}
+
+func (a *azureActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Azure != nil
+}
+
+func (a *azureActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.Azure != nil
+}
+
+func (a *azureActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	p := cd.Spec.Platform.Azure
+	return append([]string{p.Region}, p.FallbackRegions...)
+}
+
+func (a *azureActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.Azure.CredentialsSecretRef.Name
+}
+
+func (a *azureActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+	spec.Azure = &hivev1.AzureDNSZoneSpec{
+		CredentialsSecretRef: cd.Spec.Platform.Azure.CredentialsSecretRef,
+		ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
+	}
+}
+
+func (a *azureActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		Azure: &hivev1.AzureClusterDeprovision{
+			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
+		},
+	}
+}
+
+func (a *azureActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	setDeprovisionContainer(job, azureCredentials(dp.Spec.Platform.Azure.CredentialsSecretRef.Name),
+		"deprovision",
+		"azure",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		azureAuthDir,
+		dp.Spec.InfraID,
+	)
+}
+
+func (a *azureActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	return azureCredentials(cd.Spec.Platform.Azure.CredentialsSecretRef.Name)
+}
+
+// azureCredentials mounts the service principal of the credentials secret, and points the Azure SDK to it.
+func azureCredentials(secretName string) *PodCredentials {
+	creds := &PodCredentials{
+		Env: []corev1.EnvVar{{
+			Name:  "AZURE_AUTH_LOCATION",
+			Value: azureAuthFile,
+		}},
+	}
+	creds.mountSecret("azure", secretName, azureAuthDir)
+	return creds
+}
+
+func (a *azureActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+	cd.Spec.Platform.Azure.Region = region
+}
+
+func (a *azureActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *azureActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *azureActuator) Hibernator() Hibernator {
+	return &azureHibernator{azureClientFn: getAzureClient}
+}
+
+func (a *azureActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	return azure.New(logger, &installertypes.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			Azure: &installertypesazure.Metadata{
+				CloudName: installertypesazure.PublicCloud,
+			},
+		},
+	})
+}
+
+func (a *azureActuator) DNSCleaner() DNSCleaner {
+	return &azureDNSCleaner{}
+}
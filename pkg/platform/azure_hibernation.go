@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"context"
@@ -18,12 +18,15 @@ import (
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/azureclient"
-	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
 	azurePowerStatePrefix  = "PowerState/"
 	azureUnknownPowerState = "unknown"
+	// azureClusterTagPrefix is the prefix of the tag the installer sets on the resources of a cluster, followed by the
+	// infra ID of the cluster.
+	azureClusterTagPrefix = "kubernetes.io_cluster."
+	azureOwnedTagValue    = "owned"
 )
 
 var (
@@ -37,22 +40,14 @@ var (
 	azureNotStoppedStates        = azureRunningOrPendingStates.Union(azureStoppingStates)
 )
 
-func init() {
-	RegisterActuator(&azureActuator{azureClientFn: getAzureClient})
-}
-
-type azureActuator struct {
+// azureHibernator stops and starts the virtual machines of the clusters installed on Azure.
+type azureHibernator struct {
 	// azureClientFn is the function to build an Azure client, here for testing
 	azureClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (azureclient.Client, error)
 }
 
-// CanHandle returns true if the actuator can handle a particular ClusterDeployment
-func (a *azureActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
-	return cd.Spec.Platform.Azure != nil
-}
-
 // StopMachines will stop machines belonging to the given ClusterDeployment
-func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *azureHibernator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
@@ -67,19 +62,20 @@ func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Clie
 		return nil
 	}
 	var errs []error
-	for _, machineName := range azureMachineNames(machines) {
-		logger.WithField("machine", machineName).Info("Stopping cluster machine")
-		_, err = azureClient.DeallocateVirtualMachine(context.TODO(), clusterDeploymentResourceGroup(cd), machineName)
+	for _, machine := range machines {
+		logger := logger.WithFields(log.Fields{"machine": machine.name, "resourceGroup": machine.resourceGroup})
+		logger.Info("Stopping cluster machine")
+		_, err = azureClient.DeallocateVirtualMachine(context.TODO(), machine.resourceGroup, machine.name)
 		if err != nil {
 			errs = append(errs, err)
-			logger.WithError(err).WithField("machine", machineName).Error("Failed to stop machine")
+			logger.WithError(err).Error("Failed to stop machine")
 		}
 	}
 	return utilerrors.NewAggregate(errs)
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *azureHibernator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
@@ -94,12 +90,13 @@ func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Cli
 		return nil
 	}
 	var errs []error
-	for _, machineName := range azureMachineNames(machines) {
-		logger.WithField("machine", machineName).Info("Starting cluster machine")
-		_, err = azureClient.StartVirtualMachine(context.TODO(), clusterDeploymentResourceGroup(cd), machineName)
+	for _, machine := range machines {
+		logger := logger.WithFields(log.Fields{"machine": machine.name, "resourceGroup": machine.resourceGroup})
+		logger.Info("Starting cluster machine")
+		_, err = azureClient.StartVirtualMachine(context.TODO(), machine.resourceGroup, machine.name)
 		if err != nil {
 			errs = append(errs, err)
-			logger.WithError(err).WithField("machine", machineName).Error("Failed to start machine")
+			logger.WithError(err).Error("Failed to start machine")
 		}
 	}
 	return utilerrors.NewAggregate(errs)
@@ -107,7 +104,7 @@ func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Cli
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *azureHibernator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
@@ -122,7 +119,7 @@ func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.C
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *azureActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *azureHibernator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
@@ -135,14 +132,20 @@ func (a *azureActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.C
 	return len(machines) == 0, nil
 }
 
-func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states sets.String, logger log.FieldLogger) ([]compute.VirtualMachine, error) {
+// azureMachine is a virtual machine of a cluster.
+type azureMachine struct {
+	name          string
+	resourceGroup string
+}
+
+func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states sets.String, logger log.FieldLogger) ([]azureMachine, error) {
 	page, err := azureClient.ListAllVirtualMachines(context.TODO(), "true")
 	if err != nil {
 		return nil, err
 	}
-	var result []compute.VirtualMachine
+	var result []azureMachine
 	for page.NotDone() {
-		result = append(result, filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger)...)
+		result = append(result, filterClusterMachinesByState(page.Values(), cd, states, logger)...)
 		if err = page.Next(); err != nil {
 			return nil, err
 		}
@@ -150,8 +153,16 @@ func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Cli
 	return result, nil
 }
 
-func filterByResourceGroupAndState(machines []compute.VirtualMachine, resourceGroup string, states sets.String, logger log.FieldLogger) []compute.VirtualMachine {
-	var result []compute.VirtualMachine
+// filterClusterMachinesByState returns the machines of the cluster in the given states. The machines of the cluster
+// are those tagged as owned by the cluster, wherever their resource group, and those in the resource group created for
+// the cluster by the installer.
+func filterClusterMachinesByState(machines []compute.VirtualMachine, cd *hivev1.ClusterDeployment, states sets.String, logger log.FieldLogger) []azureMachine {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil
+	}
+	clusterResourceGroup := fmt.Sprintf("%s-rg", cd.Spec.ClusterMetadata.InfraID)
+	ownedTag := fmt.Sprintf("%s%s", azureClusterTagPrefix, cd.Spec.ClusterMetadata.InfraID)
+	var result []azureMachine
 	for _, vm := range machines {
 		logger := logger.WithField("machine", to.String(vm.Name))
 		resource, err := azure.ParseResourceID(to.String(vm.ID))
@@ -159,7 +170,8 @@ func filterByResourceGroupAndState(machines []compute.VirtualMachine, resourceGr
 			logger.WithError(err).Warning("Failed to parse resource ID")
 			continue
 		}
-		if !strings.EqualFold(resource.ResourceGroup, resourceGroup) {
+		owned := to.String(vm.Tags[ownedTag]) == azureOwnedTagValue
+		if !owned && !strings.EqualFold(resource.ResourceGroup, clusterResourceGroup) {
 			continue
 		}
 		state := azureMachinePowerState(vm)
@@ -169,7 +181,7 @@ func filterByResourceGroupAndState(machines []compute.VirtualMachine, resourceGr
 			continue
 		}
 		logger.Debug("machine included")
-		result = append(result, vm)
+		result = append(result, azureMachine{name: to.String(vm.Name), resourceGroup: resource.ResourceGroup})
 	}
 	return result
 }
@@ -186,23 +198,6 @@ func azureMachinePowerState(vm compute.VirtualMachine) string {
 	return azureUnknownPowerState
 }
 
-func clusterDeploymentResourceGroup(cd *hivev1.ClusterDeployment) string {
-	// TODO: Fix this to use explicit resource group name when we
-	// collect that from the installer.
-	if cd.Spec.ClusterMetadata == nil {
-		return ""
-	}
-	return fmt.Sprintf("%s-rg", cd.Spec.ClusterMetadata.InfraID)
-}
-
-func azureMachineNames(machines []compute.VirtualMachine) []string {
-	result := make([]string, len(machines))
-	for i, m := range machines {
-		result[i] = to.String(m.Name)
-	}
-	return result
-}
-
 func getAzureClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
 	if cd.Spec.Platform.Azure == nil {
 		return nil, errors.New("Azure platform is not set in ClusterDeployment")
@@ -210,7 +205,6 @@ func getAzureClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.Fi
 	secret := &corev1.Secret{}
 	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
 	if err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch Azure credentials secret")
 		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
 	}
 	azureClient, err := azureclient.NewClientFromSecret(secret)
@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"context"
@@ -15,10 +15,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
-	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
 	mockazureclient "github.com/openshift/hive/pkg/azureclient/mock"
-	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
 
 const (
@@ -27,17 +25,6 @@ const (
 	azureTestResourceGroup = "test-infra-id-rg"
 )
 
-func TestAzureCanHandle(t *testing.T) {
-	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
-		cd.Spec.Platform.Azure = &hivev1azure.Platform{}
-	}).Build()
-	actuator := azureActuator{}
-	assert.True(t, actuator.CanHandle(cd))
-
-	cd = testcd.BasicBuilder().Build()
-	assert.False(t, actuator.CanHandle(cd))
-}
-
 func TestAzureStopAndStartMachines(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -183,6 +170,34 @@ func TestAzureMachinesStoppedAndRunning(t *testing.T) {
 	}
 }
 
+func TestAzureFilterClusterMachinesByState(t *testing.T) {
+	vm := func(name, resourceGroup, state string, tags map[string]*string) compute.VirtualMachine {
+		return compute.VirtualMachine{
+			Name: pointer.StringPtr(name),
+			ID:   pointer.StringPtr(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s", azureTestSubscription, resourceGroup, name)),
+			Tags: tags,
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				InstanceView: &compute.VirtualMachineInstanceView{
+					Statuses: &[]compute.InstanceViewStatus{{Code: pointer.StringPtr("PowerState/" + state)}},
+				},
+			},
+		}
+	}
+	ownedTags := map[string]*string{"kubernetes.io_cluster." + azureTestInfraID: pointer.StringPtr("owned")}
+	machines := []compute.VirtualMachine{
+		vm("in-cluster-group", azureTestResourceGroup, "running", nil),
+		vm("owned-in-custom-group", "custom-rg", "running", ownedTags),
+		vm("owned-stopped", "custom-rg", "deallocated", ownedTags),
+		vm("not-owned", "custom-rg", "running", nil),
+		vm("owned-by-other-cluster", "custom-rg", "running", map[string]*string{"kubernetes.io_cluster.other-infra-id": pointer.StringPtr("owned")}),
+	}
+	actual := filterClusterMachinesByState(machines, testAzureClusterDeployment(), azureRunningStates, log.New())
+	assert.Equal(t, []azureMachine{
+		{name: "in-cluster-group", resourceGroup: azureTestResourceGroup},
+		{name: "owned-in-custom-group", resourceGroup: "custom-rg"},
+	}, actual)
+}
+
 func testAzureClusterDeployment() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		Spec: hivev1.ClusterDeploymentSpec{
@@ -193,8 +208,8 @@ func testAzureClusterDeployment() *hivev1.ClusterDeployment {
 	}
 }
 
-func testAzureActuator(azureClient azureclient.Client) *azureActuator {
-	return &azureActuator{
+func testAzureActuator(azureClient azureclient.Client) *azureHibernator {
+	return &azureHibernator{
 		azureClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (azureclient.Client, error) {
 			return azureClient, nil
 		},
@@ -0,0 +1,89 @@
+package platform
+
+import (
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&bareMetalActuator{})
+}
+
+// bareMetalActuator handles the ClusterDeployments installed on bare metal. Their machines are not deprovisioned.
+type bareMetalActuator struct{}
+
+func (a *bareMetalActuator) Name() string {
+	return constants.PlatformBaremetal
+}
+
+func (a *bareMetalActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.BareMetal != nil
+}
+
+func (a *bareMetalActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return false
+}
+
+func (a *bareMetalActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	return nil
+}
+
+func (a *bareMetalActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return ""
+}
+
+func (a *bareMetalActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+}
+
+func (a *bareMetalActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return nil
+}
+
+func (a *bareMetalActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+}
+
+func (a *bareMetalActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	secretName := cd.Spec.Platform.BareMetal.LibvirtSSHPrivateKeySecretRef.Name
+	if secretName == "" {
+		return nil
+	}
+	creds := &PodCredentials{
+		Env: []corev1.EnvVar{{
+			Name:  constants.LibvirtSSHPrivKeyPathEnvVar,
+			Value: LibvirtSSHPrivateKeyFilePath,
+		}},
+	}
+	creds.mountSecret("libvirtsshkeys", secretName, LibvirtSSHPrivateKeyDir)
+	return creds
+}
+
+func (a *bareMetalActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+}
+
+func (a *bareMetalActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *bareMetalActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *bareMetalActuator) Hibernator() Hibernator {
+	return nil
+}
+
+func (a *bareMetalActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	return nil, errors.New("the cloud resources of bare metal installs cannot be destroyed")
+}
+
+func (a *bareMetalActuator) DNSCleaner() DNSCleaner {
+	return nil
+}
@@ -0,0 +1,244 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	azuredns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	log "github.com/sirupsen/logrus"
+	gcpdns "google.golang.org/api/dns/v1"
+
+	azureutils "github.com/openshift/hive/contrib/pkg/utils/azure"
+	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// DNSCleaner returns the managed DNSZone of a cluster to the minimum set of records, removing the stray records left
+// behind by a failed install. It runs in the install pod, with the cloud credentials of the install.
+type DNSCleaner interface {
+	// CleanupDNSZone deletes the records of the DNSZone of the cluster, except for those created with the zone.
+	CleanupDNSZone(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error
+}
+
+// ManagesDNS returns true if the platform of the ClusterDeployment supports managed DNS.
+func ManagesDNS(cd *hivev1.ClusterDeployment) bool {
+	a := ForClusterDeployment(cd)
+	return a != nil && a.DNSCleaner() != nil
+}
+
+// dotted returns the domain with a trailing dot, as the DNS services return the names of the record sets.
+func dotted(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+	return domain + "."
+}
+
+type awsDNSCleaner struct{}
+
+// CleanupDNSZone will return a DNS zone to the minimum set of DNS records
+// May no longer be necessary once https://jira.coreos.com/browse/CORS-1195 is fixed.
+func (c *awsDNSCleaner) CleanupDNSZone(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if dnsZone.Status.AWS == nil {
+		return fmt.Errorf("found non-AWS DNSZone for AWS ClusterDeployment")
+	}
+	if dnsZone.Status.AWS.ZoneID == nil {
+		// Shouldn't really be possible as we block install until DNS is ready:
+		return fmt.Errorf("DNSZone %s has no ZoneID set", dnsZone.Name)
+	}
+
+	zoneLogger := logger.WithField("dnsZoneID", *dnsZone.Status.AWS.ZoneID)
+	zoneLogger.Info("cleaning up DNSZone")
+
+	awsClient, err := awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region, cd.Spec.Platform.AWS.ServiceEndpoints)
+	if err != nil {
+		logger.WithError(err).Error("failed to create AWS client")
+		return err
+	}
+
+	if err := DeleteAWSRecordSets(awsClient, dnsZone, zoneLogger); err != nil {
+		logger.WithError(err).Error("failed to clean up DNS Zone")
+		return err
+	}
+	zoneLogger.Info("DNSZone cleaned")
+	return nil
+}
+
+type azureDNSCleaner struct{}
+
+// CleanupDNSZone will return a DNS zone to the minimum set of DNS records
+func (c *azureDNSCleaner) CleanupDNSZone(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	logger = logger.WithField("dnsZoneID", dnsZone.Spec.Zone)
+	logger.Info("cleaning up DNSZone")
+
+	creds, err := azureutils.GetCreds("")
+	if err != nil {
+		logger.WithError(err).Error("failed to get Azure creds")
+		return err
+	}
+
+	azureClient, err := azureclient.NewClient(creds)
+	if err != nil {
+		logger.WithError(err).Error("failed to create Azure client")
+		return err
+	}
+
+	if err := DeleteAzureRecordSets(azureClient, dnsZone, logger); err != nil {
+		logger.WithError(err).Error("failed to clean up DNS Zone")
+		return err
+	}
+	logger.Info("DNSZone cleaned")
+	return nil
+}
+
+type gcpDNSCleaner struct{}
+
+func (c *gcpDNSCleaner) CleanupDNSZone(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if dnsZone.Status.GCP == nil {
+		return fmt.Errorf("found non-GCP DNSZone for DNS ClusterDeployment")
+	}
+	if dnsZone.Status.GCP.ZoneName == nil {
+		// Shouldn't happen as we block installs until DNS is ready
+		return fmt.Errorf("DNSZone %s has no ZoneName set", dnsZone.Name)
+	}
+
+	logger = logger.WithField("zoneName", *dnsZone.Status.GCP.ZoneName)
+	logger.Info("cleaning up DNSZone")
+
+	creds, err := gcputils.GetCreds("")
+	if err != nil {
+		logger.WithError(err).Error("failed to get GCP creds")
+		return err
+	}
+
+	gcpClient, err := gcpclient.NewClient(creds)
+	if err != nil {
+		logger.WithError(err).Error("failed to create GCP client")
+		return err
+	}
+
+	if err := DeleteGCPRecordSets(gcpClient, dnsZone, logger); err != nil {
+		logger.WithError(err).Error("failed to clean up DNS zone")
+		return err
+	}
+	logger.Info("DNSZone cleaned")
+	return nil
+}
+
+// DeleteAWSRecordSets will clean up a DNS zone down to the minimum required record entries
+func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+
+	maxItems := "100"
+	listInput := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: dnsZone.Status.AWS.ZoneID,
+		MaxItems:     &maxItems,
+	}
+	for {
+		listOutput, err := awsClient.ListResourceRecordSets(listInput)
+		if err != nil {
+			return err
+		}
+		var changes []*route53.Change
+		for _, recordSet := range listOutput.ResourceRecordSets {
+			// Ignore the 2 recordsets that are created with the hosted zone and that cannot be deleted
+			if n, t := aws.StringValue(recordSet.Name), aws.StringValue(recordSet.Type); n == dotted(dnsZone.Spec.Zone) && (t == route53.RRTypeNs || t == route53.RRTypeSoa) {
+				continue
+			}
+
+			logger.WithField("name", aws.StringValue(recordSet.Name)).WithField("type", aws.StringValue(recordSet.Type)).Info("recordset set for deletion")
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: recordSet,
+			})
+		}
+		if len(changes) > 0 {
+			logger.WithField("count", len(changes)).Info("deleting recordsets")
+			if _, err := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+				ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+				HostedZoneId: dnsZone.Status.AWS.ZoneID,
+			}); err != nil {
+				return err
+			}
+		}
+		if listOutput.IsTruncated == nil || !*listOutput.IsTruncated {
+			break
+		}
+		listInput.StartRecordIdentifier = listOutput.NextRecordIdentifier
+		listInput.StartRecordName = listOutput.NextRecordName
+		listInput.StartRecordType = listOutput.NextRecordType
+	}
+	return nil
+
+}
+
+// DeleteAzureRecordSets will remove all non-essential records from the DNSZone provided.
+func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	resourceGroupName := dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := dnsZone.Spec.Zone
+	recordSetsPage, err := azureClient.ListRecordSetsByZone(context.Background(), resourceGroupName, zoneName, "")
+	if err != nil {
+		return err
+	}
+	for recordSetsPage.NotDone() {
+		for _, recordSet := range recordSetsPage.Values() {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				logger.Warn("found recordset with missing name or type")
+				continue
+			}
+			name := *recordSet.Name
+			// The type comes in as, for example, "Microsoft.Network/dnszones/NS". We need just the last part of that,
+			// in this case "NS".
+			typeParts := strings.Split(*recordSet.Type, "/")
+			recordType := azuredns.RecordType(typeParts[len(typeParts)-1])
+			// Ignore the 2 recordsets that are created with the managed zone and that cannot be deleted
+			if name == "@" && (recordType == azuredns.NS || recordType == azuredns.SOA) {
+				continue
+			}
+			logger.WithField("name", name).WithField("type", recordType).Info("deleting recordset")
+			if err := azureClient.DeleteRecordSet(context.Background(), resourceGroupName, zoneName, name, recordType); err != nil {
+				return err
+			}
+		}
+		if err := recordSetsPage.NextWithContext(context.Background()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteGCPRecordSets will delete all non-essential DNS records in the DNSZone provided
+func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	listOpts := gcpclient.ListResourceRecordSetsOptions{}
+	for {
+		listOutput, err := gcpClient.ListResourceRecordSets(*dnsZone.Status.GCP.ZoneName, listOpts)
+		if err != nil {
+			return err
+		}
+		var recordSetsToDelete []*gcpdns.ResourceRecordSet
+		for _, recordSet := range listOutput.Rrsets {
+			// Ignore the 2 recordsets that are created with the managed zone and that cannot be deleted
+			if n, t := recordSet.Name, recordSet.Type; n == dotted(dnsZone.Spec.Zone) && (t == "NS" || t == "SOA") {
+				continue
+			}
+			logger.WithField("name", recordSet.Name).WithField("type", recordSet.Type).Info("recordset set for deletion")
+			recordSetsToDelete = append(recordSetsToDelete, recordSet)
+		}
+		if len(recordSetsToDelete) > 0 {
+			logger.WithField("count", len(recordSetsToDelete)).Info("deleting recordsets")
+			if err := gcpClient.DeleteResourceRecordSets(*dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
+				return err
+			}
+		}
+		if listOutput.NextPageToken == "" {
+			break
+		}
+		listOpts.PageToken = listOutput.NextPageToken
+	}
+	return nil
+}
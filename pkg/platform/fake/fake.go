@@ -0,0 +1,125 @@
+// Package fake provides a fake platform actuator, for testing the controllers without cloud access.
+package fake
+
+import (
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/platform"
+)
+
+// Name is the default name of the platform of the fake actuator.
+const Name = "fake"
+
+// Actuator is a platform actuator returning the values it is configured with. By default it handles the cluster
+// deployments with the hive.openshift.io/fake-cluster annotation, whatever their platform.
+type Actuator struct {
+	// PlatformName is the name of the platform. It defaults to Name.
+	PlatformName string
+	// Handles overrides which cluster deployments the actuator handles.
+	Handles func(cd *hivev1.ClusterDeployment) bool
+	// RegionList is the region of the clusters followed by their fallback regions.
+	RegionList []string
+	// CredentialsSecret is the name of the secret holding the cloud credentials of the clusters.
+	CredentialsSecret string
+	// DNSZonePlatform is copied into the spec of the managed DNSZones of the clusters.
+	DNSZonePlatform *hivev1.DNSZoneSpec
+	// Deprovision is the platform of the ClusterDeprovisions of the clusters. Clusters cannot be deprovisioned when
+	// it is nil.
+	Deprovision *hivev1.ClusterDeprovisionPlatform
+	// InstallerCredentials are the credentials of the installer pods of the clusters.
+	InstallerCredentials *platform.PodCredentials
+	// Hibernation is the hibernator of the clusters. Clusters cannot be hibernated when it is nil.
+	Hibernation platform.Hibernator
+	// Uninstaller is the destroyer of the cloud resources of failed installs. They cannot be destroyed when it is
+	// nil.
+	Uninstaller providers.Destroyer
+	// DNS is the cleaner of the managed DNSZones of the clusters. The clusters have no managed DNS when it is nil.
+	DNS platform.DNSCleaner
+}
+
+var _ platform.Actuator = &Actuator{}
+
+// Override registers the fake actuator ahead of the actuators of the real platforms. The returned function restores
+// the previous actuators.
+func Override(a *Actuator) (restore func()) {
+	return platform.Override(a)
+}
+
+func (a *Actuator) Name() string {
+	if a.PlatformName != "" {
+		return a.PlatformName
+	}
+	return Name
+}
+
+func (a *Actuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	if a.Handles != nil {
+		return a.Handles(cd)
+	}
+	return controllerutils.IsFakeCluster(cd)
+}
+
+func (a *Actuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return false
+}
+
+func (a *Actuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	return a.RegionList
+}
+
+func (a *Actuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return a.CredentialsSecret
+}
+
+func (a *Actuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+	if a.DNSZonePlatform == nil {
+		return
+	}
+	spec.AWS = a.DNSZonePlatform.AWS.DeepCopy()
+	spec.GCP = a.DNSZonePlatform.GCP.DeepCopy()
+	spec.Azure = a.DNSZonePlatform.Azure.DeepCopy()
+}
+
+func (a *Actuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return a.Deprovision.DeepCopy()
+}
+
+func (a *Actuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+}
+
+func (a *Actuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *platform.PodCredentials {
+	return a.InstallerCredentials
+}
+
+func (a *Actuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+}
+
+func (a *Actuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *Actuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *Actuator) Hibernator() platform.Hibernator {
+	return a.Hibernation
+}
+
+func (a *Actuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	if a.Uninstaller == nil {
+		return nil, errors.New("the cloud resources of fake clusters cannot be destroyed")
+	}
+	return a.Uninstaller, nil
+}
+
+func (a *Actuator) DNSCleaner() platform.DNSCleaner {
+	return a.DNS
+}
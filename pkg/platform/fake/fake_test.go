@@ -0,0 +1,42 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/platform"
+)
+
+func TestOverride(t *testing.T) {
+	fakeCD := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.HiveFakeClusterAnnotation: "true"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Platform: hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-east-1"}},
+		},
+	}
+	realCD := &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			Platform: hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-east-1"}},
+		},
+	}
+
+	restore := Override(&Actuator{
+		RegionList:        []string{"fake-region"},
+		CredentialsSecret: "fake-creds",
+	})
+	assert.Equal(t, Name, platform.Name(fakeCD), "expected fake platform for fake cluster")
+	assert.Equal(t, []string{"fake-region"}, platform.Regions(fakeCD), "unexpected fake regions")
+	assert.Equal(t, "fake-creds", platform.CredentialsSecretName(fakeCD), "unexpected fake credentials")
+	assert.Equal(t, constants.PlatformAWS, platform.Name(realCD), "expected real platform for real cluster")
+
+	restore()
+	assert.Equal(t, constants.PlatformAWS, platform.Name(fakeCD), "expected real platform once restored")
+}
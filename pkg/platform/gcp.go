@@ -0,0 +1,128 @@
+package platform
+
+import (
+	"os"
+
+	"github.com/openshift/installer/pkg/destroy/gcp"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesgcp "github.com/openshift/installer/pkg/types/gcp"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func init() {
+	Register(&gcpActuator{})
+}
+
+// gcpActuator handles the ClusterDeployments installed on GCP.
+type gcpActuator struct{}
+
+func (a *gcpActuator) Name() string {
+	return constants.PlatformGCP
+}
+
+func (a *gcpActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.GCP != nil
+}
+
+func (a *gcpActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.GCP != nil
+}
+
+func (a *gcpActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	p := cd.Spec.Platform.GCP
+	return append([]string{p.Region}, p.FallbackRegions...)
+}
+
+func (a *gcpActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.GCP.CredentialsSecretRef.Name
+}
+
+func (a *gcpActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+	spec.GCP = &hivev1.GCPDNSZoneSpec{
+		CredentialsSecretRef: cd.Spec.Platform.GCP.CredentialsSecretRef,
+	}
+}
+
+func (a *gcpActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		GCP: &hivev1.GCPClusterDeprovision{
+			Region:               region,
+			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
+		},
+	}
+}
+
+func (a *gcpActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	setDeprovisionContainer(job, gcpCredentials(dp.Spec.Platform.GCP.CredentialsSecretRef.Name),
+		"deprovision",
+		"gcp",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		gcpAuthDir,
+		"--region",
+		dp.Spec.Platform.GCP.Region,
+		dp.Spec.InfraID,
+	)
+}
+
+func (a *gcpActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	return gcpCredentials(cd.Spec.Platform.GCP.CredentialsSecretRef.Name)
+}
+
+// gcpCredentials mounts the service account key of the credentials secret, and points the GCP SDK to it.
+func gcpCredentials(secretName string) *PodCredentials {
+	creds := &PodCredentials{
+		Env: []corev1.EnvVar{{
+			Name:  "GOOGLE_CREDENTIALS",
+			Value: gcpAuthFile,
+		}},
+	}
+	creds.mountSecret("gcp", secretName, gcpAuthDir)
+	return creds
+}
+
+func (a *gcpActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+	cd.Spec.Platform.GCP.Region = region
+}
+
+func (a *gcpActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *gcpActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *gcpActuator) Hibernator() Hibernator {
+	return &gcpHibernator{getGCPClientFn: getGCPClient}
+}
+
+func (a *gcpActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	projectID, err := gcpclient.ProjectIDFromFile(os.Getenv("GOOGLE_CREDENTIALS"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get GCP project ID")
+	}
+	return gcp.New(logger, &installertypes.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			GCP: &installertypesgcp.Metadata{
+				Region:    cd.Spec.Platform.GCP.Region,
+				ProjectID: projectID,
+			},
+		},
+	})
+}
+
+func (a *gcpActuator) DNSCleaner() DNSCleaner {
+	return &gcpDNSCleaner{}
+}
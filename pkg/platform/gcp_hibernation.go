@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"context"
@@ -14,12 +14,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
-	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
 )
 
 const (
-	instanceFields = "items/*/instances(name,zone,status),nextPageToken"
+	instanceFields = "items/*/instances(name,zone,status,labels),nextPageToken"
+	// gcpClusterLabelPrefix is the prefix of the label the installer sets on the instances of a cluster, followed by
+	// the infra ID of the cluster.
+	gcpClusterLabelPrefix = "kubernetes-io-cluster-"
+	gcpOwnedLabelValue    = "owned"
 )
 
 var (
@@ -33,21 +36,13 @@ var (
 	gcpNotStoppedStatuses        = gcpRunningOrPendingStatuses.Union(gcpStoppingStatuses)
 )
 
-func init() {
-	RegisterActuator(&gcpActuator{getGCPClientFn: getGCPClient})
-}
-
-type gcpActuator struct {
+// gcpHibernator stops and starts the compute instances of the clusters installed on GCP.
+type gcpHibernator struct {
 	getGCPClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (gcpclient.Client, error)
 }
 
-// CanHandle returns true if the actuator can handle a particular ClusterDeployment
-func (a *gcpActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
-	return cd.Spec.Platform.GCP != nil
-}
-
 // StopMachines will start machines belonging to the given ClusterDeployment
-func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+func (a *gcpHibernator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
@@ -69,7 +64,7 @@ func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient clie
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+func (a *gcpHibernator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
@@ -92,7 +87,7 @@ func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient cli
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+func (a *gcpHibernator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
@@ -107,7 +102,7 @@ func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient c
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *gcpActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+func (a *gcpHibernator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
@@ -127,7 +122,6 @@ func getGCPClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.Fiel
 	secret := &corev1.Secret{}
 	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
 	if err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch GCP credentials secret")
 		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
 	}
 	return gcpclient.NewClientFromSecret(secret)
@@ -139,6 +133,7 @@ func instanceFilter(cd *hivev1.ClusterDeployment) string {
 
 func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
 	var instances []*compute.Instance
+	ownedLabel := gcpClusterLabelPrefix + cd.Spec.ClusterMetadata.InfraID
 	logger.Debug("listing client instances")
 	err := gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
 		Filter: instanceFilter(cd),
@@ -146,6 +141,12 @@ func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeplo
 	}, func(list *compute.InstanceAggregatedList) error {
 		for _, scopedList := range list.Items {
 			for _, instance := range scopedList.Instances {
+				// The name filter may match instances which were not created for the cluster, so only the
+				// instances labeled as owned by the cluster are included.
+				if instance.Labels[ownedLabel] != gcpOwnedLabelValue {
+					logger.WithField("instance", instance.Name).Debug("instance filtered out as it is not owned by the cluster")
+					continue
+				}
 				if statuses.Has(instance.Status) {
 					instances = append(instances, instance)
 				}
@@ -154,7 +155,7 @@ func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeplo
 		return nil
 	})
 	if err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch compute instances")
+		logger.WithError(err).Error("Failed to fetch compute instances")
 	} else {
 		logger.WithField("count", len(instances)).WithField("statuses", statuses.List()).Debug("found instances")
 	}
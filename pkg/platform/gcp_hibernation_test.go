@@ -1,4 +1,4 @@
-package hibernation
+package platform
 
 import (
 	"fmt"
@@ -13,23 +13,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
-	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/gcpclient"
 	mockgcpclient "github.com/openshift/hive/pkg/gcpclient/mock"
-	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
 
-func TestGCPCanHandle(t *testing.T) {
-	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
-		cd.Spec.Platform.GCP = &hivev1gcp.Platform{}
-	}).Build()
-	actuator := gcpActuator{}
-	assert.True(t, actuator.CanHandle(cd))
-
-	cd = testcd.BasicBuilder().Build()
-	assert.False(t, actuator.CanHandle(cd))
-}
-
 func TestGCPStopAndStartMachines(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -122,6 +109,31 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 
 }
 
+func TestGCPStopMachinesNotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gcpClient := mockgcpclient.NewMockClient(ctrl)
+	instances := []*compute.Instance{
+		{Name: "abcd1234-master-0", Status: "RUNNING", Labels: map[string]string{"kubernetes-io-cluster-abcd1234": "owned"}},
+		{Name: "abcd1234-bastion", Status: "RUNNING"},
+		{Name: "abcd1234-other", Status: "RUNNING", Labels: map[string]string{"kubernetes-io-cluster-abcd1234": "shared"}},
+	}
+	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Times(1).Do(
+		func(opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
+			f(&compute.InstanceAggregatedList{
+				Items: map[string]compute.InstancesScopedList{"result": {Instances: instances}},
+			})
+		},
+	).Return(nil)
+	gcpClient.EXPECT().StopInstance(gomock.Any()).Times(1).Do(
+		func(instance *compute.Instance) {
+			assert.Equal(t, "abcd1234-master-0", instance.Name)
+		},
+	)
+	err := testGCPActuator(gcpClient).StopMachines(testClusterDeployment(), nil, log.New())
+	assert.NoError(t, err)
+	ctrl.Finish()
+}
+
 func TestGCPMachinesStoppedAndRunning(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -193,8 +205,8 @@ func TestGCPMachinesStoppedAndRunning(t *testing.T) {
 	}
 }
 
-func testGCPActuator(gcpClient gcpclient.Client) *gcpActuator {
-	return &gcpActuator{
+func testGCPActuator(gcpClient gcpclient.Client) *gcpHibernator {
+	return &gcpHibernator{
 		getGCPClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (gcpclient.Client, error) {
 			return gcpClient, nil
 		},
@@ -208,6 +220,7 @@ func setupGCPClientInstances(gcpClient *mockgcpclient.MockClient, statuses map[s
 			instances = append(instances, &compute.Instance{
 				Name:   fmt.Sprintf("%s-%d", status, i),
 				Status: status,
+				Labels: map[string]string{"kubernetes-io-cluster-abcd1234": "owned"},
 			})
 		}
 	}
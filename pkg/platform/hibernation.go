@@ -1,6 +1,6 @@
-package hibernation
+package platform
 
-//go:generate mockgen -source=./hibernation_actuator.go -destination=./mock/hibernation_actuator_generated.go -package=mock
+//go:generate mockgen -source=./hibernation.go -destination=./mock/hibernator_generated.go -package=mock
 
 import (
 	log "github.com/sirupsen/logrus"
@@ -10,14 +10,11 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 )
 
-// HibernationActuator is the interface that the hibernation controller uses to
-// interact with cloud providers.
-type HibernationActuator interface {
-	// CanHandle returns true if the actuator can handle a particular ClusterDeployment
-	CanHandle(cd *hivev1.ClusterDeployment) bool
-	// StopMachines will start machines belonging to the given ClusterDeployment
+// Hibernator stops and starts the machines of the clusters of a platform, to hibernate and resume them.
+type Hibernator interface {
+	// StopMachines will stop machines belonging to the given ClusterDeployment
 	StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
-	// StartMachines will select machines belonging to the given ClusterDeployment
+	// StartMachines will start machines belonging to the given ClusterDeployment
 	StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
 	// MachinesRunning will return true if the machines associated with the given
 	// ClusterDeployment are in a running state.
@@ -26,3 +23,12 @@ type HibernationActuator interface {
 	// ClusterDeployment are in a stopped state.
 	MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
 }
+
+// HibernatorForClusterDeployment returns the hibernator of the platform of the ClusterDeployment, or nil if its
+// platform does not support hibernation.
+func HibernatorForClusterDeployment(cd *hivev1.ClusterDeployment) Hibernator {
+	if a := ForClusterDeployment(cd); a != nil {
+		return a.Hibernator()
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package platform
+
+import (
+	"fmt"
+
+	"github.com/openshift/installer/pkg/destroy/openstack"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesopenstack "github.com/openshift/installer/pkg/types/openstack"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&openStackActuator{})
+}
+
+// openStackActuator handles the ClusterDeployments installed on OpenStack.
+type openStackActuator struct{}
+
+func (a *openStackActuator) Name() string {
+	return constants.PlatformOpenStack
+}
+
+func (a *openStackActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.OpenStack != nil
+}
+
+func (a *openStackActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.OpenStack != nil
+}
+
+func (a *openStackActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	return nil
+}
+
+func (a *openStackActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name
+}
+
+func (a *openStackActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+}
+
+func (a *openStackActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		OpenStack: &hivev1.OpenStackClusterDeprovision{
+			Cloud:                 cd.Spec.Platform.OpenStack.Cloud,
+			CredentialsSecretRef:  &cd.Spec.Platform.OpenStack.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.OpenStack.CertificatesSecretRef,
+		},
+	}
+}
+
+func (a *openStackActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	p := dp.Spec.Platform.OpenStack
+	setDeprovisionContainer(job, openStackCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef),
+		"deprovision",
+		"openstack",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		openStackCloudsDir,
+		"--cloud",
+		p.Cloud,
+		dp.Spec.InfraID,
+	)
+}
+
+func (a *openStackActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	p := cd.Spec.Platform.OpenStack
+	return openStackCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef)
+}
+
+// openStackCredentials mounts the clouds.yaml of the credentials secret, along with the CA certificates of the clouds
+// when there are any.
+func openStackCredentials(secretName string, certificatesSecretRef *corev1.LocalObjectReference) *PodCredentials {
+	creds := &PodCredentials{}
+	creds.mountSecret("openstack", secretName, openStackCloudsDir)
+	if certificatesSecretRef != nil && certificatesSecretRef.Name != "" {
+		creds.mountSecret("openstack-certificates", certificatesSecretRef.Name, openStackCADir)
+		creds.CADir = openStackCADir
+	}
+	return creds
+}
+
+func (a *openStackActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+}
+
+func (a *openStackActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *openStackActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	cdPlatform, icPlatform := cd.Spec.Platform.OpenStack, installConfig.Platform.OpenStack
+	if icPlatform == nil {
+		return nil
+	}
+	var mismatches []string
+	if icPlatform.Cloud != cdPlatform.Cloud {
+		mismatches = append(mismatches, fmt.Sprintf("platform.openstack.cloud %q is not the cloud %q", icPlatform.Cloud, cdPlatform.Cloud))
+	}
+	if cdPlatform.ExternalNetwork != "" && icPlatform.ExternalNetwork != cdPlatform.ExternalNetwork {
+		mismatches = append(mismatches, fmt.Sprintf("platform.openstack.externalNetwork %q is not the external network %q", icPlatform.ExternalNetwork, cdPlatform.ExternalNetwork))
+	}
+	// The flavors are merged from the compute flavor, the default machine platform, and the pool, as the installer
+	// does.
+	computeMachine := installertypesopenstack.MachinePool{FlavorName: icPlatform.FlavorName}
+	computeMachine.Set(icPlatform.DefaultMachinePlatform)
+	if cdPlatform.ComputeFlavor != "" && computeMachine.FlavorName != cdPlatform.ComputeFlavor {
+		mismatches = append(mismatches, fmt.Sprintf("platform.openstack.computeFlavor %q is not the compute flavor %q", computeMachine.FlavorName, cdPlatform.ComputeFlavor))
+	}
+	controlPlaneMachine := installertypesopenstack.MachinePool{FlavorName: icPlatform.FlavorName}
+	controlPlaneMachine.Set(icPlatform.DefaultMachinePlatform)
+	if cp := installConfig.ControlPlane; cp != nil {
+		controlPlaneMachine.Set(cp.Platform.OpenStack)
+	}
+	if cdPlatform.ControlPlaneFlavor != "" && controlPlaneMachine.FlavorName != cdPlatform.ControlPlaneFlavor {
+		mismatches = append(mismatches, fmt.Sprintf("controlPlane.platform.openstack.type %q is not the control plane flavor %q", controlPlaneMachine.FlavorName, cdPlatform.ControlPlaneFlavor))
+	}
+	// The installer still reads the API floating IP from the deprecated lbFloatingIP.
+	apiFloatingIP := icPlatform.APIFloatingIP
+	if apiFloatingIP == "" {
+		apiFloatingIP = icPlatform.DeprecatedLbFloatingIP
+	}
+	if cdPlatform.APIFloatingIP != "" && apiFloatingIP != cdPlatform.APIFloatingIP {
+		mismatches = append(mismatches, fmt.Sprintf("platform.openstack.apiFloatingIP %q is not the API floating IP %q", apiFloatingIP, cdPlatform.APIFloatingIP))
+	}
+	if cdPlatform.IngressFloatingIP != "" && icPlatform.IngressFloatingIP != cdPlatform.IngressFloatingIP {
+		mismatches = append(mismatches, fmt.Sprintf("platform.openstack.ingressFloatingIP %q is not the ingress floating IP %q", icPlatform.IngressFloatingIP, cdPlatform.IngressFloatingIP))
+	}
+	return mismatches
+}
+
+func (a *openStackActuator) Hibernator() Hibernator {
+	return nil
+}
+
+func (a *openStackActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	return openstack.New(logger, &installertypes.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			OpenStack: &installertypesopenstack.Metadata{
+				Cloud: cd.Spec.Platform.OpenStack.Cloud,
+				Identifier: map[string]string{
+					"openshiftClusterID": infraID,
+				},
+			},
+		},
+	})
+}
+
+func (a *openStackActuator) DNSCleaner() DNSCleaner {
+	return nil
+}
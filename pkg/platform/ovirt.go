@@ -0,0 +1,115 @@
+package platform
+
+import (
+	"github.com/openshift/installer/pkg/destroy/ovirt"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesovirt "github.com/openshift/installer/pkg/types/ovirt"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&oVirtActuator{})
+}
+
+// oVirtActuator handles the ClusterDeployments installed on oVirt.
+type oVirtActuator struct{}
+
+func (a *oVirtActuator) Name() string {
+	return constants.PlatformOvirt
+}
+
+func (a *oVirtActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Ovirt != nil
+}
+
+func (a *oVirtActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.Ovirt != nil
+}
+
+func (a *oVirtActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	return nil
+}
+
+func (a *oVirtActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name
+}
+
+func (a *oVirtActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {}
+
+func (a *oVirtActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		Ovirt: &hivev1.OvirtClusterDeprovision{
+			CredentialsSecretRef:  cd.Spec.Platform.Ovirt.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
+			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
+		},
+	}
+}
+
+func (a *oVirtActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	p := dp.Spec.Platform.Ovirt
+	setDeprovisionContainer(job, oVirtCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef.Name),
+		"deprovision",
+		"ovirt",
+		"--ovirt-cluster-id",
+		p.ClusterID,
+		"--loglevel",
+		"debug",
+		dp.Spec.InfraID,
+	)
+}
+
+func (a *oVirtActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	p := cd.Spec.Platform.Ovirt
+	return oVirtCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef.Name)
+}
+
+// oVirtCredentials mounts the oVirt config of the credentials secret and the CA certificates of the oVirt engine.
+func oVirtCredentials(secretName, certificatesSecretName string) *PodCredentials {
+	creds := &PodCredentials{
+		Env: []corev1.EnvVar{{
+			Name:  constants.OvirtConfigEnvVar,
+			Value: ovirtCloudsDir + "/" + constants.OvirtCredentialsName,
+		}},
+		CADir: ovirtCADir,
+	}
+	creds.mountSecret("ovirt-credentials", secretName, ovirtCloudsDir)
+	creds.mountSecret("ovirt-certificates", certificatesSecretName, ovirtCADir)
+	return creds
+}
+
+func (a *oVirtActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {}
+
+func (a *oVirtActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *oVirtActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *oVirtActuator) Hibernator() Hibernator {
+	return nil
+}
+
+func (a *oVirtActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	return ovirt.New(logger, &installertypes.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			Ovirt: &installertypesovirt.Metadata{
+				ClusterID: cd.Spec.Platform.Ovirt.ClusterID,
+			},
+		},
+	})
+}
+
+func (a *oVirtActuator) DNSCleaner() DNSCleaner {
+	return nil
+}
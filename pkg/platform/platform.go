@@ -0,0 +1,154 @@
+// Package platform holds the platform specific parts of ClusterDeployments, behind the Actuator registered for each
+// platform, so that supporting a platform does not require branching on it throughout the controllers.
+package platform
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Actuator handles the platform specific parts of the ClusterDeployments of a platform.
+type Actuator interface {
+	// Name returns the name of the platform, as used in the hive.openshift.io/cluster-platform label.
+	Name() string
+	// CanHandle returns true if the actuator can handle a particular ClusterDeployment.
+	CanHandle(cd *hivev1.ClusterDeployment) bool
+	// CanHandleDeprovision returns true if the actuator can handle a particular ClusterDeprovision.
+	CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool
+	// Regions returns the region of the cluster, followed by the regions to fail over to when the install lacks
+	// capacity. It returns nil for platforms without regions.
+	Regions(cd *hivev1.ClusterDeployment) []string
+	// CredentialsSecretName returns the name of the secret holding the cloud credentials of the cluster, or an
+	// empty string if it has none.
+	CredentialsSecretName(cd *hivev1.ClusterDeployment) string
+	// SetDNSZonePlatform sets the platform of the DNSZone managing the DNS of the cluster. It is left unset for
+	// platforms without managed DNS.
+	SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec)
+	// DeprovisionPlatform returns the platform of the ClusterDeprovision of a cluster installed in the given region,
+	// or nil if the clusters of the platform cannot be deprovisioned.
+	DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform
+	// CompleteDeprovisionJob adds the container destroying the cloud resources of the cluster, and the volumes it
+	// needs, to the uninstall job of the ClusterDeprovision.
+	CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job)
+	// InstallerPodCredentials returns the environment variables and volumes giving the installer pod of the cluster
+	// access to the cloud, or nil if it needs none.
+	InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials
+	// SetRegion sets the region of the platform of the ClusterDeployment. It does nothing for platforms without
+	// regions.
+	SetRegion(cd *hivev1.ClusterDeployment, region string)
+	// UserTags returns the tags that the install-config of the cluster sets on its cloud resources.
+	UserTags(cd *hivev1.ClusterDeployment) map[string]string
+	// InstallConfigMismatches returns a description of each field of the platform of the install-config which does
+	// not match the platform of the ClusterDeployment.
+	InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string
+	// Hibernator returns the hibernator of the clusters, or nil if they cannot be hibernated.
+	Hibernator() Hibernator
+	// Destroyer returns the destroyer of the cloud resources created by an install of the cluster with the given
+	// infra ID. It runs in the install pod, with the credentials given to it by InstallerPodCredentials.
+	Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error)
+	// DNSCleaner returns the cleaner of the managed DNSZones of the clusters, or nil for platforms without managed
+	// DNS.
+	DNSCleaner() DNSCleaner
+}
+
+// actuators is the list of available actuators. It is populated via the Register function.
+var actuators []Actuator
+
+// Register registers the actuator of a platform. The actuator determines whether it can handle a particular cluster
+// deployment via the CanHandle function.
+func Register(a Actuator) {
+	actuators = append(actuators, a)
+}
+
+// Override registers an actuator ahead of the registered actuators, so that it handles the cluster deployments it can
+// handle in their place, until the returned function is called. It is meant for tests, which use it to replace the
+// actuator of a platform with a fake one.
+func Override(a Actuator) (restore func()) {
+	previous := actuators
+	actuators = append([]Actuator{a}, actuators...)
+	return func() {
+		actuators = previous
+	}
+}
+
+// ForClusterDeployment returns the actuator handling the platform of the ClusterDeployment, or nil if no registered
+// actuator can handle it.
+func ForClusterDeployment(cd *hivev1.ClusterDeployment) Actuator {
+	for _, a := range actuators {
+		if a.CanHandle(cd) {
+			return a
+		}
+	}
+	return nil
+}
+
+// Name returns the name of the platform of the ClusterDeployment.
+func Name(cd *hivev1.ClusterDeployment) string {
+	if a := ForClusterDeployment(cd); a != nil {
+		return a.Name()
+	}
+	return constants.PlatformUnknown
+}
+
+// ForClusterDeprovision returns the actuator handling the platform of the ClusterDeprovision, or nil if no registered
+// actuator can handle it.
+func ForClusterDeprovision(dp *hivev1.ClusterDeprovision) Actuator {
+	for _, a := range actuators {
+		if a.CanHandleDeprovision(dp) {
+			return a
+		}
+	}
+	return nil
+}
+
+// DeprovisionName returns the name of the platform of the ClusterDeprovision.
+func DeprovisionName(dp *hivev1.ClusterDeprovision) string {
+	if a := ForClusterDeprovision(dp); a != nil {
+		return a.Name()
+	}
+	return constants.PlatformUnknown
+}
+
+// Regions returns the region of the ClusterDeployment followed by its fallback regions, or nil if its platform has no
+// regions.
+func Regions(cd *hivev1.ClusterDeployment) []string {
+	if a := ForClusterDeployment(cd); a != nil {
+		return a.Regions(cd)
+	}
+	return nil
+}
+
+// InstallRegion returns the region where the cluster is installed. This is the region of the platform, unless the
+// install failed over to one of the fallback regions. An empty string is returned for platforms without regions.
+func InstallRegion(cd *hivev1.ClusterDeployment) string {
+	if cd.Status.InstallRegion != "" {
+		return cd.Status.InstallRegion
+	}
+	if regions := Regions(cd); len(regions) > 0 {
+		return regions[0]
+	}
+	return ""
+}
+
+// CredentialsSecretName returns the name of the secret holding the cloud credentials of the ClusterDeployment.
+func CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	if a := ForClusterDeployment(cd); a != nil {
+		return a.CredentialsSecretName(cd)
+	}
+	return ""
+}
+
+// UserTags returns the tags that the install-config of the ClusterDeployment sets on its cloud resources.
+func UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	if a := ForClusterDeployment(cd); a != nil {
+		return a.UserTags(cd)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package platform
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1baremetal "github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
+	hivev1ovirt "github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestPlatform(t *testing.T) {
+	cases := []struct {
+		name                string
+		platform            hivev1.Platform
+		expectedName        string
+		expectedRegions     []string
+		expectedCredentials string
+		expectDeprovision   bool
+		expectHibernation   bool
+		expectManagedDNS    bool
+	}{
+		{
+			name: "aws",
+			platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               "us-east-1",
+					FallbackRegions:      []string{"us-west-2"},
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+				},
+			},
+			expectedName:        constants.PlatformAWS,
+			expectedRegions:     []string{"us-east-1", "us-west-2"},
+			expectedCredentials: "aws-creds",
+			expectDeprovision:   true,
+			expectHibernation:   true,
+			expectManagedDNS:    true,
+		},
+		{
+			name: "ovirt",
+			platform: hivev1.Platform{
+				Ovirt: &hivev1ovirt.Platform{
+					ClusterID:            "ovirt-cluster",
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "ovirt-creds"},
+				},
+			},
+			expectedName:        constants.PlatformOvirt,
+			expectedCredentials: "ovirt-creds",
+			expectDeprovision:   true,
+		},
+		{
+			name:         "baremetal",
+			platform:     hivev1.Platform{BareMetal: &hivev1baremetal.Platform{}},
+			expectedName: constants.PlatformBaremetal,
+		},
+		{
+			name:         "no platform",
+			expectedName: constants.PlatformUnknown,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{Spec: hivev1.ClusterDeploymentSpec{Platform: tc.platform}}
+			assert.Equal(t, tc.expectedName, Name(cd), "unexpected name")
+			assert.Equal(t, tc.expectedRegions, Regions(cd), "unexpected regions")
+			assert.Equal(t, tc.expectedCredentials, CredentialsSecretName(cd), "unexpected credentials secret name")
+			assert.Equal(t, tc.expectManagedDNS, ManagesDNS(cd), "unexpected managed DNS support")
+
+			actuator := ForClusterDeployment(cd)
+			if actuator == nil {
+				assert.Equal(t, constants.PlatformUnknown, tc.expectedName, "expected an actuator")
+				assert.Nil(t, HibernatorForClusterDeployment(cd), "unexpected hibernator")
+				return
+			}
+			assert.Equal(t, tc.expectHibernation, actuator.Hibernator() != nil, "unexpected hibernation support")
+			deprovisionPlatform := actuator.DeprovisionPlatform(cd, "us-east-1")
+			if !tc.expectDeprovision {
+				assert.Nil(t, deprovisionPlatform, "unexpected deprovision platform")
+				_, err := actuator.Destroyer(cd, "infra-id", log.StandardLogger())
+				assert.Error(t, err, "expected the cloud resources not to be destroyable")
+				return
+			}
+			if assert.NotNil(t, deprovisionPlatform, "expected deprovision platform") {
+				dp := &hivev1.ClusterDeprovision{Spec: hivev1.ClusterDeprovisionSpec{Platform: *deprovisionPlatform}}
+				assert.Equal(t, tc.expectedName, DeprovisionName(dp), "unexpected deprovision platform name")
+			}
+		})
+	}
+}
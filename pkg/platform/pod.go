@@ -0,0 +1,86 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/images"
+)
+
+const (
+	azureAuthDir       = "/.azure"
+	azureAuthFile      = azureAuthDir + "/osServicePrincipal.json"
+	gcpAuthDir         = "/.gcp"
+	gcpAuthFile        = gcpAuthDir + "/" + constants.GCPCredentialsName
+	openStackCloudsDir = "/etc/openstack"
+	openStackCADir     = "/etc/openstack-ca"
+	vsphereCloudsDir   = "/vsphere"
+	vsphereCredsDir    = "/vsphere-creds"
+	ovirtCloudsDir     = "/.ovirt"
+	ovirtCADir         = "/.ovirt-ca"
+
+	// LibvirtSSHPrivateKeyDir is the directory where the installer pod mounts the libvirt ssh secret to
+	LibvirtSSHPrivateKeyDir = "/libvirtsshkeys"
+)
+
+// LibvirtSSHPrivateKeyFilePath is the path to the private key contents (from the libvirt SSH secret)
+var LibvirtSSHPrivateKeyFilePath = fmt.Sprintf("%s/%s", LibvirtSSHPrivateKeyDir, constants.SSHPrivateKeySecretKey)
+
+// PodCredentials are the environment variables and volumes giving the containers of a pod access to the cloud of a
+// platform.
+type PodCredentials struct {
+	Env          []corev1.EnvVar
+	Volumes      []corev1.Volume
+	VolumeMounts []corev1.VolumeMount
+	// CADir is the directory of the mounted certificates which the containers must trust, if any.
+	CADir string
+}
+
+// mountSecret mounts the secret at the path.
+func (c *PodCredentials) mountSecret(volumeName, secretName, path string) {
+	c.Volumes = append(c.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: path,
+	})
+}
+
+// TrustCA prefixes the shell command with the commands adding the certificates of CADir to the trust of the container.
+// The command is returned unchanged when there are no certificates to trust.
+func (c *PodCredentials) TrustCA(command string) string {
+	if c == nil || c.CADir == "" {
+		return command
+	}
+	return fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", c.CADir, command)
+}
+
+// setDeprovisionContainer sets the container running the deprovision command in the uninstall job, with the
+// credentials mounted. The command is run in a shell when certificates must be trusted first.
+func setDeprovisionContainer(job *batchv1.Job, creds *PodCredentials, args ...string) {
+	container := corev1.Container{
+		Name:            "deprovision",
+		Image:           images.GetHiveImage(),
+		ImagePullPolicy: images.GetHiveImagePullPolicy(),
+		Env:             creds.Env,
+		Command:         []string{"/usr/bin/hiveutil"},
+		Args:            args,
+		VolumeMounts:    creds.VolumeMounts,
+	}
+	if creds.CADir != "" {
+		container.Command = []string{"/bin/sh", "-c"}
+		container.Args = []string{creds.TrustCA(strings.Join(append([]string{"/usr/bin/hiveutil"}, args...), " "))}
+	}
+	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+	job.Spec.Template.Spec.Volumes = creds.Volumes
+}
@@ -0,0 +1,149 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/destroy/vsphere"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesvsphere "github.com/openshift/installer/pkg/types/vsphere"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func init() {
+	Register(&vSphereActuator{})
+}
+
+// vSphereActuator handles the ClusterDeployments installed on vSphere.
+type vSphereActuator struct{}
+
+func (a *vSphereActuator) Name() string {
+	return constants.PlatformVSphere
+}
+
+func (a *vSphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+func (a *vSphereActuator) CanHandleDeprovision(dp *hivev1.ClusterDeprovision) bool {
+	return dp.Spec.Platform.VSphere != nil
+}
+
+func (a *vSphereActuator) Regions(cd *hivev1.ClusterDeployment) []string {
+	return nil
+}
+
+func (a *vSphereActuator) CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.Platform.VSphere.CredentialsSecretRef.Name
+}
+
+func (a *vSphereActuator) SetDNSZonePlatform(cd *hivev1.ClusterDeployment, spec *hivev1.DNSZoneSpec) {
+}
+
+func (a *vSphereActuator) DeprovisionPlatform(cd *hivev1.ClusterDeployment, region string) *hivev1.ClusterDeprovisionPlatform {
+	return &hivev1.ClusterDeprovisionPlatform{
+		VSphere: &hivev1.VSphereClusterDeprovision{
+			CredentialsSecretRef:  cd.Spec.Platform.VSphere.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.VSphere.CertificatesSecretRef,
+			VCenter:               cd.Spec.Platform.VSphere.VCenter,
+		},
+	}
+}
+
+func (a *vSphereActuator) CompleteDeprovisionJob(dp *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	p := dp.Spec.Platform.VSphere
+	creds := vSphereCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef.Name)
+	creds.mountSecret("vsphere-creds", p.CredentialsSecretRef.Name, vsphereCredsDir)
+	setDeprovisionContainer(job, creds,
+		"deprovision",
+		"vsphere",
+		"--vsphere-vcenter",
+		p.VCenter,
+		"--loglevel",
+		"debug",
+		fmt.Sprintf("--creds-dir=%s", vsphereCredsDir),
+		dp.Spec.InfraID,
+	)
+}
+
+func (a *vSphereActuator) InstallerPodCredentials(cd *hivev1.ClusterDeployment) *PodCredentials {
+	p := cd.Spec.Platform.VSphere
+	return vSphereCredentials(p.CredentialsSecretRef.Name, p.CertificatesSecretRef.Name)
+}
+
+// vSphereCredentials passes the username and password of the credentials secret in the environment, and mounts the
+// CA certificates of the vCenter.
+func vSphereCredentials(secretName, certificatesSecretName string) *PodCredentials {
+	creds := &PodCredentials{
+		Env: []corev1.EnvVar{
+			{
+				Name: constants.VSphereUsernameEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  constants.UsernameSecretKey,
+					},
+				},
+			},
+			{
+				Name: constants.VSpherePasswordEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  constants.PasswordSecretKey,
+					},
+				},
+			},
+		},
+		CADir: vsphereCloudsDir,
+	}
+	creds.mountSecret("vsphere-certificates", certificatesSecretName, vsphereCloudsDir)
+	return creds
+}
+
+func (a *vSphereActuator) SetRegion(cd *hivev1.ClusterDeployment, region string) {
+}
+
+func (a *vSphereActuator) UserTags(cd *hivev1.ClusterDeployment) map[string]string {
+	return nil
+}
+
+func (a *vSphereActuator) InstallConfigMismatches(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig) []string {
+	return nil
+}
+
+func (a *vSphereActuator) Hibernator() Hibernator {
+	return nil
+}
+
+func (a *vSphereActuator) Destroyer(cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) (providers.Destroyer, error) {
+	username := os.Getenv(constants.VSphereUsernameEnvVar)
+	if username == "" {
+		return nil, fmt.Errorf("No %s env var set, cannot proceed", constants.VSphereUsernameEnvVar)
+	}
+	password := os.Getenv(constants.VSpherePasswordEnvVar)
+	if password == "" {
+		return nil, fmt.Errorf("No %s env var set, cannot proceed", constants.VSpherePasswordEnvVar)
+	}
+	return vsphere.New(logger, &installertypes.ClusterMetadata{
+		InfraID: infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			VSphere: &installertypesvsphere.Metadata{
+				VCenter:  cd.Spec.Platform.VSphere.VCenter,
+				Username: username,
+				Password: password,
+			},
+		},
+	})
+}
+
+func (a *vSphereActuator) DNSCleaner() DNSCleaner {
+	return nil
+}
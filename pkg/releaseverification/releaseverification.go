@@ -0,0 +1,266 @@
+// Package releaseverification verifies the release images used to install and upgrade clusters against the signature
+// stores and the public keys configured in HiveConfig, so that only release images signed with a trusted key are used.
+package releaseverification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/openpgp"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// requestTimeout is how long a request to a signature store may take.
+	requestTimeout = 30 * time.Second
+
+	// maxSignatures is the number of signatures of a digest which are looked up in a store, as the cluster version
+	// operator does. The signatures of a digest are numbered from 1, and the lookup stops at the first missing one.
+	maxSignatures = 10
+
+	// maxSignatureSize is the largest signature which is read from a store.
+	maxSignatureSize = 64 * 1024
+
+	// signatureType is the type of the signatures of release images.
+	signatureType = "atomic container signature"
+)
+
+// digestRegexp matches the sha256 digest of a release image referenced by digest.
+var digestRegexp = regexp.MustCompile(`@sha256:([0-9a-f]{64})$`)
+
+// Verifier verifies release images.
+type Verifier interface {
+	// Verify returns an error when the release image cannot be verified. The error message explains why, and is
+	// suitable for the message of a condition.
+	Verify(releaseImage string) error
+}
+
+// NewVerifierFromEnv returns a verifier for the signature stores configured in the environment of the controller
+// manager, with the public keys mounted by the operator, or nil when the verification of release images is not
+// turned on.
+func NewVerifierFromEnv(logger log.FieldLogger) Verifier {
+	value, ok := os.LookupEnv(constants.ReleaseImageVerificationEnvVar)
+	if !ok {
+		return nil
+	}
+	config := &hivev1.ReleaseImageVerificationConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		// Failing closed: a verifier without stores rejects every release image.
+		logger.WithError(err).Errorf("cannot unmarshal %s, no release image can be verified", constants.ReleaseImageVerificationEnvVar)
+	}
+	keyring, err := loadKeyring(constants.ReleaseImageVerificationKeysDir)
+	if err != nil {
+		// Failing closed: a verifier without keys rejects every release image.
+		logger.WithError(err).Error("cannot load the release image verification keys, no release image can be verified")
+	}
+	return NewVerifier(config.SignatureStores, keyring, &http.Client{Timeout: requestTimeout})
+}
+
+// loadKeyring reads the ASCII-armored public keys of the files of the directory. The files starting with "..", which
+// are the internals of a mounted ConfigMap, are skipped.
+func loadKeyring(dir string) (openpgp.EntityList, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var keyring openpgp.EntityList
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), "..") || file.IsDir() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		keys, err := openpgp.ReadArmoredKeyRing(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid public keys in %s: %v", file.Name(), err)
+		}
+		keyring = append(keyring, keys...)
+	}
+	return keyring, nil
+}
+
+// NewVerifier returns a verifier which looks up the signatures of release images in the given signature stores and
+// verifies them with the keyring. The digests which have been verified are cached.
+func NewVerifier(signatureStores []string, keyring openpgp.EntityList, httpClient *http.Client) Verifier {
+	stores := make([]string, len(signatureStores))
+	for i, store := range signatureStores {
+		stores[i] = strings.TrimSuffix(store, "/")
+	}
+	return &verifier{
+		stores:     stores,
+		keyring:    keyring,
+		httpClient: httpClient,
+		verified:   map[string]bool{},
+	}
+}
+
+type verifier struct {
+	stores     []string
+	keyring    openpgp.EntityList
+	httpClient *http.Client
+
+	mutex    sync.Mutex
+	verified map[string]bool
+}
+
+func (v *verifier) Verify(releaseImage string) error {
+	match := digestRegexp.FindStringSubmatch(releaseImage)
+	if match == nil {
+		return fmt.Errorf("release image %s is not referenced by a sha256 digest, which is required to verify it", releaseImage)
+	}
+	digest := match[1]
+
+	v.mutex.Lock()
+	verified := v.verified[digest]
+	v.mutex.Unlock()
+	if verified {
+		return nil
+	}
+
+	if len(v.stores) == 0 {
+		return fmt.Errorf("release image %s cannot be verified as no signature store is configured", releaseImage)
+	}
+	if len(v.keyring) == 0 {
+		return fmt.Errorf("release image %s cannot be verified as no public key is configured", releaseImage)
+	}
+	var storeErrors []string
+	for _, store := range v.stores {
+		found, err := v.verifyFromStore(store, digest)
+		if err != nil {
+			storeErrors = append(storeErrors, err.Error())
+			continue
+		}
+		if found {
+			v.mutex.Lock()
+			v.verified[digest] = true
+			v.mutex.Unlock()
+			return nil
+		}
+	}
+	if len(storeErrors) > 0 {
+		return fmt.Errorf("release image %s could not be verified: %s", releaseImage, strings.Join(storeErrors, "; "))
+	}
+	return fmt.Errorf("no valid signature is published for release image %s in the signature stores", releaseImage)
+}
+
+// verifyFromStore returns whether the store publishes a signature of the digest made with one of the keys, using the
+// layout of the signature stores of the cluster version operator. Only HTTPS stores are trusted.
+func (v *verifier) verifyFromStore(store, digest string) (bool, error) {
+	storeURL, err := url.Parse(store)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature store %s: %v", store, err)
+	}
+	if storeURL.Scheme != "https" {
+		return false, fmt.Errorf("signature store %s is not served over HTTPS", store)
+	}
+	var invalid []string
+	for i := 1; i <= maxSignatures; i++ {
+		signature, err := v.fetchSignature(fmt.Sprintf("%s/sha256=%s/signature-%d", store, digest, i))
+		if err != nil {
+			return false, fmt.Errorf("signature store %s: %v", store, err)
+		}
+		if signature == nil {
+			break
+		}
+		if err := v.verifySignature(signature, digest); err != nil {
+			invalid = append(invalid, fmt.Sprintf("signature-%d: %v", i, err))
+			continue
+		}
+		return true, nil
+	}
+	if len(invalid) > 0 {
+		return false, fmt.Errorf("signature store %s has no valid signature: %s", store, strings.Join(invalid, ", "))
+	}
+	return false, nil
+}
+
+// fetchSignature returns the signature at the URL, or nil when there is none.
+func (v *verifier) fetchSignature(signatureURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signatureURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot be reached: %v", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("returned %s", resp.Status)
+	}
+	signature, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSignatureSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read signature: %v", err)
+	}
+	if len(signature) > maxSignatureSize {
+		return nil, fmt.Errorf("signature is larger than %d bytes", maxSignatureSize)
+	}
+	return signature, nil
+}
+
+// signatureContent is the signed content of the signatures of release images.
+type signatureContent struct {
+	Critical struct {
+		Type  string `json:"type"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignature checks that the signature is signed with one of the keys and signs the digest.
+func (v *verifier) verifySignature(signature []byte, digest string) error {
+	md, err := openpgp.ReadMessage(bytes.NewReader(signature), v.keyring, nil, nil)
+	if err != nil {
+		return fmt.Errorf("cannot read signed message: %v", err)
+	}
+	if !md.IsSigned || md.SignedBy == nil {
+		return fmt.Errorf("not signed with a trusted key")
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(md.UnverifiedBody, maxSignatureSize+1))
+	if err != nil {
+		return fmt.Errorf("cannot read signed message: %v", err)
+	}
+	// The signature is only checked once the whole body has been read.
+	if md.SignatureError != nil {
+		return fmt.Errorf("invalid signature: %v", md.SignatureError)
+	}
+	if len(body) > maxSignatureSize {
+		return fmt.Errorf("signed content is larger than %d bytes", maxSignatureSize)
+	}
+	content := &signatureContent{}
+	if err := json.Unmarshal(body, content); err != nil {
+		return fmt.Errorf("invalid signed content: %v", err)
+	}
+	if content.Critical.Type != signatureType {
+		return fmt.Errorf("unexpected signature type %q", content.Critical.Type)
+	}
+	if content.Critical.Image.DockerManifestDigest != "sha256:"+digest {
+		return fmt.Errorf("signs digest %s", content.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package releaseverification
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+const (
+	testDigest        = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	otherDigest       = "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+	testReleaseImage  = "quay.io/openshift-release-dev/ocp-release@sha256:" + testDigest
+	testSignaturePath = "/sha256=" + testDigest + "/signature-1"
+)
+
+func testEntity(t *testing.T, name string) *openpgp.Entity {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	require.NoError(t, err, "cannot create key")
+	return entity
+}
+
+// testSignature returns a signature of the digest in the format of the signatures of release images.
+func testSignature(t *testing.T, signer *openpgp.Entity, signatureType, digest string) []byte {
+	// Prefer SHA-256, as RIPEMD-160, the default of keys without preferences, is not compiled in.
+	for _, identity := range signer.Identities {
+		identity.SelfSignature.PreferredHash = []uint8{8}
+	}
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Sign(buf, signer, nil, nil)
+	require.NoError(t, err, "cannot sign")
+	fmt.Fprintf(w, `{"critical":{"type":%q,"image":{"docker-manifest-digest":"sha256:%s"},"identity":{"docker-reference":"quay.io/openshift-release-dev/ocp-release"}},"optional":{}}`, signatureType, digest)
+	require.NoError(t, w.Close(), "cannot sign")
+	return buf.Bytes()
+}
+
+func serveSignatures(signatures map[string][]byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		signature, ok := signatures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(signature)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	trusted := testEntity(t, "trusted")
+	untrusted := testEntity(t, "untrusted")
+	keyring := openpgp.EntityList{trusted}
+
+	tests := []struct {
+		name         string
+		releaseImage string
+		handler      http.HandlerFunc
+		plainHTTP    bool
+		expectError  string
+	}{
+		{
+			name:         "valid signature published",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: testSignature(t, trusted, signatureType, testDigest),
+			}),
+		},
+		{
+			name:         "valid signature after invalid one",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath:                        testSignature(t, untrusted, signatureType, testDigest),
+				"/sha256=" + testDigest + "/signature-2": testSignature(t, trusted, signatureType, testDigest),
+			}),
+		},
+		{
+			name:         "no signature published",
+			releaseImage: testReleaseImage,
+			handler:      http.NotFound,
+			expectError:  "no valid signature is published for release image " + testReleaseImage + " in the signature stores",
+		},
+		{
+			name:         "signature of untrusted key",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: testSignature(t, untrusted, signatureType, testDigest),
+			}),
+			expectError: "has no valid signature: signature-1: ",
+		},
+		{
+			name:         "signature of other digest",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: testSignature(t, trusted, signatureType, otherDigest),
+			}),
+			expectError: "signature-1: signs digest sha256:" + otherDigest,
+		},
+		{
+			name:         "signature of other type",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: testSignature(t, trusted, "other", testDigest),
+			}),
+			expectError: "signature-1: unexpected signature type \"other\"",
+		},
+		{
+			name:         "not a signature",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: []byte("signature"),
+			}),
+			expectError: "signature-1: cannot read signed message",
+		},
+		{
+			name:         "signature store failing",
+			releaseImage: testReleaseImage,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectError: "release image " + testReleaseImage + " could not be verified: signature store",
+		},
+		{
+			name:         "signature store not served over HTTPS",
+			releaseImage: testReleaseImage,
+			handler: serveSignatures(map[string][]byte{
+				testSignaturePath: testSignature(t, trusted, signatureType, testDigest),
+			}),
+			plainHTTP:   true,
+			expectError: "is not served over HTTPS",
+		},
+		{
+			name:         "image referenced by tag",
+			releaseImage: "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64",
+			handler:      http.NotFound,
+			expectError:  "release image quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64 is not referenced by a sha256 digest",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var server *httptest.Server
+			if test.plainHTTP {
+				server = httptest.NewServer(test.handler)
+			} else {
+				server = httptest.NewTLSServer(test.handler)
+			}
+			defer server.Close()
+			verifier := NewVerifier([]string{server.URL + "/"}, keyring, server.Client())
+			err := verifier.Verify(test.releaseImage)
+			if test.expectError == "" {
+				assert.NoError(t, err, "unexpected verification error")
+			} else if assert.Error(t, err, "expected verification error") {
+				assert.Contains(t, err.Error(), test.expectError, "unexpected verification error")
+			}
+		})
+	}
+}
+
+func TestVerifyWithoutKeys(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	verifier := NewVerifier([]string{server.URL}, nil, server.Client())
+	err := verifier.Verify(testReleaseImage)
+	if assert.Error(t, err, "expected verification error") {
+		assert.Contains(t, err.Error(), "no public key is configured", "unexpected verification error")
+	}
+}
+
+func TestVerifyCachesVerifiedDigests(t *testing.T) {
+	trusted := testEntity(t, "trusted")
+	signature := testSignature(t, trusted, signatureType, testDigest)
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(signature)
+	}))
+	defer server.Close()
+	verifier := NewVerifier([]string{server.URL}, openpgp.EntityList{trusted}, server.Client())
+	assert.NoError(t, verifier.Verify(testReleaseImage), "unexpected verification error")
+	assert.NoError(t, verifier.Verify(testReleaseImage), "unexpected verification error")
+	assert.Equal(t, 1, requests, "expected the verified digest to be cached")
+}
+
+func TestLoadKeyring(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err, "cannot create directory")
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"first", "second"} {
+		buf := &bytes.Buffer{}
+		w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+		require.NoError(t, err, "cannot encode key")
+		require.NoError(t, testEntity(t, name).Serialize(w), "cannot serialize key")
+		require.NoError(t, w.Close(), "cannot encode key")
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0600), "cannot write key")
+	}
+	// The internals of a mounted ConfigMap are skipped.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "..data"), []byte("not a key"), 0600), "cannot write file")
+
+	keyring, err := loadKeyring(dir)
+	require.NoError(t, err, "unexpected error loading keys")
+	assert.Len(t, keyring, 2, "unexpected number of keys")
+}
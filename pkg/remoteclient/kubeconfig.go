@@ -75,5 +75,9 @@ func (b *kubeconfigBuilder) UseSecondaryAPIURL() Builder {
 }
 
 func (b *kubeconfigBuilder) RESTConfig() (*rest.Config, error) {
-	return restConfigFromSecret(b.secret)
+	secret, err := decryptedSecret(b.c, b.secret)
+	if err != nil {
+		return nil, err
+	}
+	return restConfigFromSecret(secret, "")
 }
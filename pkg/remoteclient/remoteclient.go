@@ -4,6 +4,8 @@ package remoteclient
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,6 +17,7 @@ import (
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	openshiftapiv1 "github.com/openshift/api/config/v1"
@@ -26,6 +29,7 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
 )
 
 // Builder is used to build API clients to the remote cluster
@@ -289,6 +293,20 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 		}
 	}
 
+	if profile, ok := serviceAccountProfiles[b.controllerName]; ok {
+		expiration, enabled, err := spokeTokenExpiration()
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			cfg, err = serviceAccountRESTConfig(b.cd, profile, cfg, expiration)
+			if err != nil {
+				return nil, err
+			}
+			utils.AddControllerMetricsTransportWrapper(cfg, b.controllerName, true)
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -301,10 +319,30 @@ func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*re
 	); err != nil {
 		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
 	}
-	return restConfigFromSecret(kubeconfigSecret)
+	kubeconfigSecret, err := decryptedSecret(c, kubeconfigSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt admin kubeconfig secret")
+	}
+	return restConfigFromSecret(kubeconfigSecret, cd.Status.APIURL)
+}
+
+// decryptedSecret returns a copy of the secret with its data decrypted, if the secret is encrypted.
+func decryptedSecret(c client.Client, secret *corev1.Secret) (*corev1.Secret, error) {
+	if !secretencryption.IsEncrypted(secret) {
+		return secret, nil
+	}
+	data, err := secretencryption.DecryptedData(c, secret)
+	if err != nil {
+		return nil, err
+	}
+	secret = secret.DeepCopy()
+	secret.Data = data
+	return secret, nil
 }
 
-func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error) {
+// restConfigFromSecret builds a REST config from the kubeconfig in the secret. When the kubeconfig has more than one
+// context, the context for the cluster with the given API URL is used.
+func restConfigFromSecret(kubeconfigSecret *corev1.Secret, apiURL string) (*rest.Config, error) {
 	kubeconfigData, ok := kubeconfigSecret.Data[constants.KubeconfigSecretKey]
 	if !ok {
 		return nil, errors.Errorf("kubeconfig secret does not contain %q data", constants.KubeconfigSecretKey)
@@ -313,6 +351,62 @@ func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error)
 	if err != nil {
 		return nil, err
 	}
-	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+	sanitizeKubeconfig(config)
+	contextName, err := selectContext(config, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfig := clientcmd.NewNonInteractiveClientConfig(*config, contextName, &clientcmd.ConfigOverrides{}, nil)
 	return kubeConfig.ClientConfig()
 }
+
+// sanitizeKubeconfig removes the settings of the kubeconfig that cannot be used from the hive controllers: proxies,
+// authentication plugins, which would run arbitrary commands in the controllers, and references to local files.
+func sanitizeKubeconfig(config *clientcmdapi.Config) {
+	for _, cluster := range config.Clusters {
+		cluster.ProxyURL = ""
+		cluster.CertificateAuthority = ""
+	}
+	for _, authInfo := range config.AuthInfos {
+		authInfo.Exec = nil
+		authInfo.AuthProvider = nil
+		authInfo.TokenFile = ""
+		authInfo.ClientCertificate = ""
+		authInfo.ClientKey = ""
+	}
+}
+
+// selectContext returns the name of the context of the kubeconfig to use. The current context is used unless the
+// kubeconfig has more than one context, in which case the context for the cluster with the given API URL is used.
+func selectContext(config *clientcmdapi.Config, apiURL string) (string, error) {
+	if len(config.Contexts) == 1 {
+		for name := range config.Contexts {
+			return name, nil
+		}
+	}
+	if apiURL != "" {
+		var matches []string
+		for name, kubeContext := range config.Contexts {
+			if cluster, ok := config.Clusters[kubeContext.Cluster]; ok && strings.TrimSuffix(cluster.Server, "/") == strings.TrimSuffix(apiURL, "/") {
+				matches = append(matches, name)
+			}
+		}
+		switch {
+		case len(matches) == 1:
+			return matches[0], nil
+		case len(matches) > 1:
+			// Prefer the current context when several contexts are for the cluster.
+			for _, name := range matches {
+				if name == config.CurrentContext {
+					return name, nil
+				}
+			}
+			sort.Strings(matches)
+			return matches[0], nil
+		}
+	}
+	if _, ok := config.Contexts[config.CurrentContext]; !ok {
+		return "", errors.Errorf("kubeconfig has %d contexts and none of them is for the cluster or is the current context", len(config.Contexts))
+	}
+	return config.CurrentContext, nil
+}
@@ -2,16 +2,20 @@ package remoteclient
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -135,6 +139,77 @@ func Test_builder_RESTConfig(t *testing.T) {
 	}
 }
 
+func Test_restConfigFromSecret(t *testing.T) {
+	cases := []struct {
+		name          string
+		config        *clientcmdapi.Config
+		apiURL        string
+		expectedHost  string
+		expectedError bool
+	}{
+		{
+			name:         "single context without current context",
+			config:       testKubeconfig("", "cluster-1"),
+			expectedHost: "https://cluster-1:6443",
+		},
+		{
+			name:         "multiple contexts, select by API URL",
+			config:       testKubeconfig("cluster-1", "cluster-1", "cluster-2"),
+			apiURL:       "https://cluster-2:6443",
+			expectedHost: "https://cluster-2:6443",
+		},
+		{
+			name:         "multiple contexts, select by API URL with trailing slash",
+			config:       testKubeconfig("cluster-1", "cluster-1", "cluster-2"),
+			apiURL:       "https://cluster-2:6443/",
+			expectedHost: "https://cluster-2:6443",
+		},
+		{
+			name:         "multiple contexts, no API URL",
+			config:       testKubeconfig("cluster-2", "cluster-1", "cluster-2"),
+			expectedHost: "https://cluster-2:6443",
+		},
+		{
+			name:         "multiple contexts, no matching API URL",
+			config:       testKubeconfig("cluster-1", "cluster-1", "cluster-2"),
+			apiURL:       "https://other:6443",
+			expectedHost: "https://cluster-1:6443",
+		},
+		{
+			name:          "multiple contexts, no matching API URL nor current context",
+			config:        testKubeconfig("", "cluster-1", "cluster-2"),
+			apiURL:        "https://other:6443",
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: map[string][]byte{constants.KubeconfigSecretKey: writeTestKubeconfig(t, tc.config)}}
+			cfg, err := restConfigFromSecret(secret, tc.apiURL)
+			if tc.expectedError {
+				assert.Error(t, err, "expected error building REST config")
+				return
+			}
+			require.NoError(t, err, "unexpected error building REST config")
+			assert.Equal(t, tc.expectedHost, cfg.Host, "unexpected host")
+		})
+	}
+}
+
+func Test_restConfigFromSecret_Sanitized(t *testing.T) {
+	config := testKubeconfig("cluster-1", "cluster-1")
+	config.Clusters["cluster-1"].ProxyURL = "http://proxy:3128"
+	config.AuthInfos["cluster-1"].Exec = &clientcmdapi.ExecConfig{Command: "some-plugin"}
+	config.AuthInfos["cluster-1"].TokenFile = "/path/to/token"
+	secret := &corev1.Secret{Data: map[string][]byte{constants.KubeconfigSecretKey: writeTestKubeconfig(t, config)}}
+	cfg, err := restConfigFromSecret(secret, "")
+	require.NoError(t, err, "unexpected error building REST config")
+	assert.Nil(t, cfg.Proxy, "expected proxy to be removed")
+	assert.Nil(t, cfg.ExecProvider, "expected exec plugin to be removed")
+	assert.Empty(t, cfg.BearerTokenFile, "expected token file to be removed")
+	assert.Equal(t, "token-cluster-1", cfg.BearerToken, "unexpected bearer token")
+}
+
 func Test_Unreachable(t *testing.T) {
 	probeTime := time.Unix(123456789, 0)
 	cases := []struct {
@@ -281,3 +356,27 @@ func testKubeconfigSecret(t *testing.T) *corev1.Secret {
 		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
 	}
 }
+
+func testKubeconfig(currentContext string, clusterNames ...string) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+	config.CurrentContext = currentContext
+	for _, name := range clusterNames {
+		cluster := clientcmdapi.NewCluster()
+		cluster.Server = fmt.Sprintf("https://%s:6443", name)
+		config.Clusters[name] = cluster
+		authInfo := clientcmdapi.NewAuthInfo()
+		authInfo.Token = "token-" + name
+		config.AuthInfos[name] = authInfo
+		kubeContext := clientcmdapi.NewContext()
+		kubeContext.Cluster = name
+		kubeContext.AuthInfo = name
+		config.Contexts[name] = kubeContext
+	}
+	return config
+}
+
+func writeTestKubeconfig(t *testing.T, config *clientcmdapi.Config) []byte {
+	kubeconfig, err := clientcmd.Write(*config)
+	require.NoError(t, err, "unexpected error writing kubeconfig")
+	return kubeconfig
+}
@@ -0,0 +1,259 @@
+package remoteclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// spokeServiceAccountNamespace is the namespace on the remote clusters of the ServiceAccounts used by Hive.
+	spokeServiceAccountNamespace = "openshift-hive-managed"
+
+	defaultSpokeTokenExpiration = time.Hour
+	minSpokeTokenExpiration     = 10 * time.Minute
+)
+
+// serviceAccountProfile describes the ServiceAccount used by a controller on the remote clusters.
+type serviceAccountProfile struct {
+	// name is the name of the ServiceAccount, and of its ClusterRole and ClusterRoleBinding.
+	name  string
+	rules []rbacv1.PolicyRule
+}
+
+// serviceAccountProfiles are the controllers that connect to the remote clusters with ServiceAccount tokens when
+// they are enabled.
+var serviceAccountProfiles = map[hivev1.ControllerName]serviceAccountProfile{
+	// SyncSets may contain any resource, so the clustersync controller can read, watch, apply and delete all resources.
+	// It does not get the other verbs of cluster-admin, such as impersonate and proxy, nor the non-resource URLs.
+	// SyncSets may also contain RBAC, which the API server only allows to be created by users who hold the permissions
+	// granted, or who may escalate and bind. The ServiceAccount can therefore grant itself any permission, so the
+	// ClusterRole guards against unintended use of the token rather than being a security boundary.
+	hivev1.ClustersyncControllerName: {
+		name: "hive-clustersync",
+		rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"},
+			},
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"clusterroles", "roles"},
+				Verbs:     []string{"escalate", "bind"},
+			},
+		},
+	},
+	hivev1.ClusterStateControllerName: {
+		name: "hive-clusterstate",
+		rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"config.openshift.io"},
+			Resources: []string{"clusteroperators"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	},
+}
+
+type cachedToken struct {
+	token     string
+	renewTime time.Time
+}
+
+// spokeTokens caches the ServiceAccount tokens by ClusterDeployment and controller.
+var spokeTokens = struct {
+	sync.Mutex
+	tokens map[string]cachedToken
+}{tokens: map[string]cachedToken{}}
+
+// ForgetServiceAccountTokens removes the cached ServiceAccount tokens of a ClusterDeployment. It is called by the
+// controllers that use the tokens when the ClusterDeployment is deleted.
+func ForgetServiceAccountTokens(cdKey types.NamespacedName) {
+	prefix := fmt.Sprintf("%s/%s/", cdKey.Namespace, cdKey.Name)
+	spokeTokens.Lock()
+	defer spokeTokens.Unlock()
+	for key := range spokeTokens.tokens {
+		if strings.HasPrefix(key, prefix) {
+			delete(spokeTokens.tokens, key)
+		}
+	}
+}
+
+// newKubeClient builds the client used to set up the ServiceAccounts. It is replaced in tests.
+var newKubeClient = func(cfg *rest.Config) (kubeclient.Interface, error) {
+	return kubeclient.NewForConfig(cfg)
+}
+
+// spokeTokenExpiration returns the expiration of the ServiceAccount tokens, and whether ServiceAccount tokens are
+// enabled.
+func spokeTokenExpiration() (time.Duration, bool, error) {
+	value, ok := os.LookupEnv(constants.SpokeTokenExpirationEnvVar)
+	if !ok {
+		return 0, false, nil
+	}
+	if value == "" {
+		return defaultSpokeTokenExpiration, true, nil
+	}
+	expiration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "unable to parse %s", constants.SpokeTokenExpirationEnvVar)
+	}
+	if expiration < minSpokeTokenExpiration {
+		expiration = minSpokeTokenExpiration
+	}
+	return expiration, true, nil
+}
+
+// serviceAccountRESTConfig returns a config for the remote cluster that authenticates with a token of the
+// ServiceAccount of the controller instead of the admin credentials. The admin config is used to create the
+// ServiceAccount and to request the token when there is no cached token that is still fresh.
+func serviceAccountRESTConfig(cd *hivev1.ClusterDeployment, profile serviceAccountProfile, adminCfg *rest.Config, expiration time.Duration) (*rest.Config, error) {
+	// The UID distinguishes a ClusterDeployment from a later one with the same name for another cluster.
+	key := fmt.Sprintf("%s/%s/%s/%s", cd.Namespace, cd.Name, cd.UID, profile.name)
+	now := time.Now()
+
+	spokeTokens.Lock()
+	cached, ok := spokeTokens.tokens[key]
+	spokeTokens.Unlock()
+	if !ok || now.After(cached.renewTime) {
+		var err error
+		cached, err = requestServiceAccountToken(profile, adminCfg, expiration)
+		if err != nil {
+			return nil, err
+		}
+		spokeTokens.Lock()
+		spokeTokens.tokens[key] = cached
+		spokeTokens.Unlock()
+	}
+
+	cfg := rest.AnonymousClientConfig(adminCfg)
+	cfg.BearerToken = cached.token
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return &evictRejectedToken{key: key, token: cached.token, delegate: rt}
+	}
+	return cfg, nil
+}
+
+// evictRejectedToken removes the cached token when the remote cluster rejects it, such as when the ServiceAccount
+// was deleted and created again, so that a new token is requested by the next client built for the cluster rather
+// than when the token is due for renewal.
+type evictRejectedToken struct {
+	key      string
+	token    string
+	delegate http.RoundTripper
+}
+
+func (t *evictRejectedToken) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.delegate.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		spokeTokens.Lock()
+		// A token requested since the client was built is kept.
+		if cached, ok := spokeTokens.tokens[t.key]; ok && cached.token == t.token {
+			delete(spokeTokens.tokens, t.key)
+		}
+		spokeTokens.Unlock()
+	}
+	return resp, err
+}
+
+func requestServiceAccountToken(profile serviceAccountProfile, adminCfg *rest.Config, expiration time.Duration) (cachedToken, error) {
+	kubeClient, err := newKubeClient(adminCfg)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	if err := ensureServiceAccount(kubeClient, profile); err != nil {
+		return cachedToken{}, errors.Wrapf(err, "could not set up service account %s", profile.name)
+	}
+	expirationSeconds := int64(expiration.Seconds())
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(spokeServiceAccountNamespace).CreateToken(
+		context.Background(),
+		profile.name,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return cachedToken{}, errors.Wrapf(err, "could not request token for service account %s", profile.name)
+	}
+	// The API server may shorten the requested expiration.
+	expires := tokenRequest.Status.ExpirationTimestamp.Time
+	issued := time.Now()
+	return cachedToken{
+		token:     tokenRequest.Status.Token,
+		renewTime: expires.Add(-expires.Sub(issued) / 5),
+	}, nil
+}
+
+// ensureServiceAccount creates the namespace, ServiceAccount, and RBAC of the profile on the remote cluster if they
+// do not exist.
+func ensureServiceAccount(kubeClient kubeclient.Interface, profile serviceAccountProfile) error {
+	ctx := context.Background()
+	labels := map[string]string{constants.HiveManagedLabel: "true"}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: spokeServiceAccountNamespace, Labels: labels}}
+	if _, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: spokeServiceAccountNamespace, Name: profile.name, Labels: labels}}
+	if _, err := kubeClient.CoreV1().ServiceAccounts(spokeServiceAccountNamespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: profile.name, Labels: labels}, Rules: profile.rules}
+	if _, err := kubeClient.RbacV1().ClusterRoles().Create(ctx, role, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err := kubeClient.RbacV1().ClusterRoles().Update(ctx, role, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: profile.name, Labels: labels},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: spokeServiceAccountNamespace,
+			Name:      profile.name,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     profile.name,
+		},
+	}
+	if _, err := kubeClient.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		// The role ref of a binding cannot be changed, so a binding to another ClusterRole is replaced.
+		existing, err := kubeClient.RbacV1().ClusterRoleBindings().Get(ctx, profile.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if existing.RoleRef != binding.RoleRef {
+			if err := kubeClient.RbacV1().ClusterRoleBindings().Delete(ctx, profile.name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			if _, err := kubeClient.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
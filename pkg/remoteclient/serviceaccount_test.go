@@ -0,0 +1,158 @@
+package remoteclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// resetSpokeTokens empties the token cache for the duration of the test.
+func resetSpokeTokens(t *testing.T) {
+	spokeTokens.Lock()
+	origTokens := spokeTokens.tokens
+	spokeTokens.tokens = map[string]cachedToken{}
+	spokeTokens.Unlock()
+	t.Cleanup(func() {
+		spokeTokens.Lock()
+		spokeTokens.tokens = origTokens
+		spokeTokens.Unlock()
+	})
+}
+
+// fakeTokenClient makes the ServiceAccounts be set up with a fake client for the duration of the test, and returns
+// the client along with the number of tokens it issued.
+func fakeTokenClient(t *testing.T) (*fakekubeclient.Clientset, *int) {
+	kubeClient := fakekubeclient.NewSimpleClientset()
+	tokenRequests := 0
+	kubeClient.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		tokenRequests++
+		request := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		expiration := time.Duration(*request.Spec.ExpirationSeconds) * time.Second
+		request.Status = authenticationv1.TokenRequestStatus{
+			Token:               "test-token",
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(expiration)),
+		}
+		return true, request, nil
+	})
+	origNewKubeClient := newKubeClient
+	newKubeClient = func(*rest.Config) (kubeclient.Interface, error) { return kubeClient, nil }
+	t.Cleanup(func() { newKubeClient = origNewKubeClient })
+	return kubeClient, &tokenRequests
+}
+
+func TestServiceAccountRESTConfig(t *testing.T) {
+	resetSpokeTokens(t)
+	kubeClient, requests := fakeTokenClient(t)
+
+	cd := testClusterDeployment()
+	cd.UID = "test-uid"
+	adminCfg := &rest.Config{
+		Host:            "https://test-cluster-api.example.com:6443",
+		BearerToken:     "admin-token",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("test-ca"), CertData: []byte("admin-cert")},
+	}
+	profile := serviceAccountProfiles[hivev1.ClusterStateControllerName]
+
+	for i := 0; i < 2; i++ {
+		cfg, err := serviceAccountRESTConfig(cd, profile, adminCfg, time.Hour)
+		require.NoError(t, err, "unexpected error getting service account config")
+		assert.Equal(t, adminCfg.Host, cfg.Host, "unexpected host")
+		assert.Equal(t, "test-token", cfg.BearerToken, "unexpected bearer token")
+		assert.Equal(t, []byte("test-ca"), cfg.CAData, "expected CA to be kept")
+		assert.Empty(t, cfg.CertData, "expected admin client certificate to be dropped")
+	}
+	assert.Equal(t, 1, *requests, "expected the token to be cached")
+
+	ctx := context.Background()
+	_, err := kubeClient.CoreV1().ServiceAccounts(spokeServiceAccountNamespace).Get(ctx, profile.name, metav1.GetOptions{})
+	assert.NoError(t, err, "expected service account to be created")
+	role, err := kubeClient.RbacV1().ClusterRoles().Get(ctx, profile.name, metav1.GetOptions{})
+	if assert.NoError(t, err, "expected cluster role to be created") {
+		assert.Equal(t, profile.rules, role.Rules, "unexpected cluster role rules")
+	}
+	binding, err := kubeClient.RbacV1().ClusterRoleBindings().Get(ctx, profile.name, metav1.GetOptions{})
+	if assert.NoError(t, err, "expected cluster role binding to be created") {
+		assert.Equal(t, profile.name, binding.RoleRef.Name, "unexpected role ref")
+	}
+
+	// A token that is due for renewal is requested again.
+	spokeTokens.Lock()
+	for key, cached := range spokeTokens.tokens {
+		cached.renewTime = time.Now().Add(-time.Minute)
+		spokeTokens.tokens[key] = cached
+	}
+	spokeTokens.Unlock()
+	_, err = serviceAccountRESTConfig(cd, profile, adminCfg, time.Hour)
+	require.NoError(t, err, "unexpected error getting service account config")
+	assert.Equal(t, 2, *requests, "expected the token to be renewed")
+
+	// The tokens of a deleted ClusterDeployment are forgotten.
+	ForgetServiceAccountTokens(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name})
+	spokeTokens.Lock()
+	assert.Empty(t, spokeTokens.tokens, "expected the tokens to be forgotten")
+	spokeTokens.Unlock()
+	_, err = serviceAccountRESTConfig(cd, profile, adminCfg, time.Hour)
+	require.NoError(t, err, "unexpected error getting service account config")
+	assert.Equal(t, 3, *requests, "expected a new token after the tokens were forgotten")
+}
+
+func TestServiceAccountRESTConfigEvictsRejectedToken(t *testing.T) {
+	resetSpokeTokens(t)
+	_, requests := fakeTokenClient(t)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cd := testClusterDeployment()
+	adminCfg := &rest.Config{Host: server.URL, BearerToken: "admin-token"}
+	profile := serviceAccountProfiles[hivev1.ClusterStateControllerName]
+	cfg, err := serviceAccountRESTConfig(cd, profile, adminCfg, time.Hour)
+	require.NoError(t, err, "unexpected error getting service account config")
+	client, err := kubeclient.NewForConfig(cfg)
+	require.NoError(t, err, "unexpected error building client")
+	_, err = client.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+	require.True(t, apierrors.IsUnauthorized(err), "expected the token to be rejected")
+
+	spokeTokens.Lock()
+	assert.Empty(t, spokeTokens.tokens, "expected the rejected token to be evicted")
+	spokeTokens.Unlock()
+	_, err = serviceAccountRESTConfig(cd, profile, adminCfg, time.Hour)
+	require.NoError(t, err, "unexpected error getting service account config")
+	assert.Equal(t, 2, *requests, "expected a new token after the token was rejected")
+}
+
+func TestEnsureServiceAccountReplacesBinding(t *testing.T) {
+	profile := serviceAccountProfiles[hivev1.ClustersyncControllerName]
+	kubeClient := fakekubeclient.NewSimpleClientset(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: profile.name},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	})
+
+	require.NoError(t, ensureServiceAccount(kubeClient, profile), "unexpected error setting up service account")
+
+	binding, err := kubeClient.RbacV1().ClusterRoleBindings().Get(context.Background(), profile.name, metav1.GetOptions{})
+	require.NoError(t, err, "expected cluster role binding to exist")
+	assert.Equal(t, profile.name, binding.RoleRef.Name, "expected the binding to cluster-admin to be replaced")
+}
@@ -0,0 +1,530 @@
+package remoteclient
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// maxRejectedWatchBackoff is the longest a remote cluster which keeps rejecting the credentials of its watches
+	// waits to be watched again.
+	maxRejectedWatchBackoff = 5 * time.Minute
+)
+
+// WatchedResource is a kind of objects watched in a remote cluster.
+type WatchedResource struct {
+	schema.GroupVersionKind
+
+	// Namespace restricts the watch to the objects in the namespace. The objects of all the namespaces are watched
+	// when it is empty.
+	Namespace string
+
+	// Names restricts the watch to the objects with the names. The objects of the resource are listed and watched by
+	// a single informer and the other objects are filtered out when their events are received, so restricting the
+	// watch with LabelSelector as well keeps the other objects from being sent by the remote cluster. All the objects
+	// are watched when it is empty.
+	Names []string
+
+	// LabelSelector restricts the watch to the objects matching the selector.
+	LabelSelector string
+}
+
+// ObjectReference identifies an object in a remote cluster.
+type ObjectReference struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// Watcher watches objects in remote clusters on behalf of a controller, so that the controller reconciles the
+// ClusterDeployment of a remote cluster as soon as the objects it manages are changed or deleted in the remote cluster
+// rather than at its next periodic reconcile.
+//
+// Objects created in the remote clusters are not reported, as the objects a controller manages are created by the
+// controller itself.
+//
+// The informers of a remote cluster are built again when its credentials change, such as when a ServiceAccount token
+// is renewed, and when the remote cluster rejects them, such as when a token has expired.
+type Watcher struct {
+	logger log.FieldLogger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan event.GenericEvent
+	// wake is signaled when ClusterDeployments are added to pending.
+	wake chan struct{}
+
+	// newRESTMapper returns the mapper used to find the API resources of the watched kinds in a remote cluster.
+	newRESTMapper func(restConfig *rest.Config) (meta.RESTMapper, error)
+
+	mu      sync.Mutex
+	watches map[types.NamespacedName]*clusterWatch
+	// pending holds the ClusterDeployments whose events have not been sent to the controller yet, so that the changes
+	// of a remote cluster made while the controller is busy are coalesced into one event.
+	pending map[types.NamespacedName]bool
+}
+
+// clusterWatch holds the informers watching a remote cluster.
+type clusterWatch struct {
+	resources []WatchedResource
+	// host is the API server the informers connect to.
+	host string
+	// bearerToken is the token the informers authenticate with, if any.
+	bearerToken string
+	cancel      context.CancelFunc
+	// changed holds the objects changed or deleted since the changes were last taken by the controller.
+	changed map[ObjectReference]bool
+	// rejections is the number of watches of the remote cluster in a row whose credentials were rejected.
+	rejections int
+	// rejected is set once the remote cluster rejected the credentials of the informers, which are stopped then.
+	rejected bool
+}
+
+// NewWatcher creates a Watcher for the controller. The Watcher must be added to the manager of the controller, and
+// its Source watched by the controller.
+func NewWatcher(controllerName hivev1.ControllerName) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Watcher{
+		logger:        log.WithField("controller", controllerName).WithField("component", "remote-watcher"),
+		ctx:           ctx,
+		cancel:        cancel,
+		events:        make(chan event.GenericEvent, 1024),
+		wake:          make(chan struct{}, 1),
+		newRESTMapper: apiutil.NewDiscoveryRESTMapper,
+		watches:       map[types.NamespacedName]*clusterWatch{},
+		pending:       map[types.NamespacedName]bool{},
+	}
+}
+
+// Start implements manager.Runnable. It sends the events of the remote clusters to the controller, and stops watching
+// all the remote clusters when the manager stops.
+func (w *Watcher) Start(stop <-chan struct{}) error {
+	go w.sendEvents(w.ctx)
+	<-stop
+	w.cancel()
+	return nil
+}
+
+// Source returns the source of the events for the ClusterDeployments of the remote clusters in which watched objects
+// were changed or deleted. The events carry a ClusterDeployment with only its namespace and name set.
+func (w *Watcher) Source() source.Source {
+	return &source.Channel{Source: w.events}
+}
+
+// Watch ensures that the resources are watched in the remote cluster of the ClusterDeployment. The informers watching
+// the remote cluster are only restarted when the watched resources, the API server URL or the credentials for the
+// remote cluster change, or when the remote cluster rejected the credentials.
+func (w *Watcher) Watch(cd *hivev1.ClusterDeployment, builder Builder, resources []WatchedResource) error {
+	key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	resources = normalizeWatchedResources(resources)
+	if len(resources) == 0 {
+		w.StopWatching(key)
+		return nil
+	}
+	restConfig, err := builder.RESTConfig()
+	if err != nil {
+		return errors.Wrap(err, "could not get the REST config of the remote cluster")
+	}
+
+	w.mu.Lock()
+	current, ok := w.watches[key]
+	upToDate := ok && current.isUpToDate(resources, restConfig)
+	w.mu.Unlock()
+	if upToDate {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	newWatch := &clusterWatch{
+		resources:   resources,
+		host:        restConfig.Host,
+		bearerToken: restConfig.BearerToken,
+		cancel:      cancel,
+		changed:     map[ObjectReference]bool{},
+	}
+	// Connecting to the remote cluster is done without holding the lock, so that it does not delay the reconciles of
+	// other clusters.
+	informers, targets, err := w.newInformers(restConfig, resources, func(err error) { w.checkCredentials(key, newWatch, err) })
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if current, ok := w.watches[key]; ok {
+		if current.isUpToDate(resources, restConfig) {
+			// The same resources started being watched concurrently.
+			cancel()
+			return nil
+		}
+		current.cancel()
+		for ref := range current.changed {
+			newWatch.changed[ref] = true
+		}
+		if current.rejected {
+			newWatch.rejections = current.rejections
+		}
+	}
+	w.watches[key] = newWatch
+	for i, informer := range informers {
+		informer.AddEventHandler(w.eventHandler(ctx, key, newWatch, targets[i]))
+		informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+			w.watchError(key, err)
+		})
+		go informer.Run(ctx.Done())
+	}
+	w.logger.WithField("clusterDeployment", key).WithField("informers", len(informers)).Info("watching remote cluster")
+	return nil
+}
+
+// isUpToDate returns true if the informers watch the resources through the API server and with the credentials of the
+// REST config, and the remote cluster has not rejected the credentials.
+func (cw *clusterWatch) isUpToDate(resources []WatchedResource, restConfig *rest.Config) bool {
+	return !cw.rejected &&
+		cw.host == restConfig.Host &&
+		cw.bearerToken == restConfig.BearerToken &&
+		reflect.DeepEqual(cw.resources, resources)
+}
+
+// StopWatching stops watching the remote cluster of the ClusterDeployment.
+func (w *Watcher) StopWatching(key types.NamespacedName) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if current, ok := w.watches[key]; ok {
+		current.cancel()
+		delete(w.watches, key)
+		w.logger.WithField("clusterDeployment", key).Info("stopped watching remote cluster")
+	}
+}
+
+// TakeChanges returns the objects changed or deleted in the remote cluster of the ClusterDeployment since the changes
+// were last taken.
+func (w *Watcher) TakeChanges(key types.NamespacedName) []ObjectReference {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	current, ok := w.watches[key]
+	if !ok || len(current.changed) == 0 {
+		return nil
+	}
+	changes := make([]ObjectReference, 0, len(current.changed))
+	for ref := range current.changed {
+		changes = append(changes, ref)
+	}
+	current.changed = map[ObjectReference]bool{}
+	sort.Slice(changes, func(i, j int) bool {
+		return objectReferenceLess(changes[i], changes[j])
+	})
+	return changes
+}
+
+// newInformers builds the informers watching the resources in the remote cluster, one for each kind, namespace and
+// label selector, so that the number of connections to the remote cluster does not grow with the number of watched
+// objects. The objects of the resources which are restricted to names are filtered by the event handlers. It returns
+// the resource watched by each informer. requested is called with the result of each list and watch request of the
+// informers.
+func (w *Watcher) newInformers(restConfig *rest.Config, resources []WatchedResource, requested func(err error)) ([]cache.SharedIndexInformer, []WatchedResource, error) {
+	mapper, err := w.newRESTMapper(restConfig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not discover the API resources of the remote cluster")
+	}
+	gvrs := map[schema.GroupVersionKind]schema.GroupVersionResource{}
+	for _, resource := range resources {
+		mapping, err := mapper.RESTMapping(resource.GroupKind(), resource.Version)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not find the API resource of %s", resource.GroupVersionKind)
+		}
+		gvrs[resource.GroupVersionKind] = mapping.Resource
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not build dynamic client for the remote cluster")
+	}
+	targets := mergeWatchedResources(resources)
+	informers := make([]cache.SharedIndexInformer, len(targets))
+	for i := range targets {
+		target := targets[i]
+		resourceClient := dynamicClient.Resource(gvrs[target.GroupVersionKind]).Namespace(target.Namespace)
+		tweakListOptions := func(options *metav1.ListOptions) {
+			options.LabelSelector = target.LabelSelector
+		}
+		informers[i] = cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					tweakListOptions(&options)
+					list, err := resourceClient.List(context.Background(), options)
+					requested(err)
+					return list, err
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					tweakListOptions(&options)
+					watcher, err := resourceClient.Watch(context.Background(), options)
+					requested(err)
+					return watcher, err
+				},
+			},
+			&unstructured.Unstructured{},
+			0,
+			cache.Indexers{},
+		)
+	}
+	return informers, targets, nil
+}
+
+// mergeWatchedResources merges the resources with the same kind, namespace and label selector, so that they are
+// watched by a single informer. The merged resource is restricted to the names of the resources when all of them are.
+func mergeWatchedResources(resources []WatchedResource) []WatchedResource {
+	type informerKey struct {
+		schema.GroupVersionKind
+		namespace     string
+		labelSelector string
+	}
+	var merged []WatchedResource
+	indexes := map[informerKey]int{}
+	for _, resource := range resources {
+		key := informerKey{GroupVersionKind: resource.GroupVersionKind, namespace: resource.Namespace, labelSelector: resource.LabelSelector}
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged)
+			resource.Names = append([]string(nil), resource.Names...)
+			merged = append(merged, resource)
+			continue
+		}
+		if len(merged[i].Names) == 0 || len(resource.Names) == 0 {
+			merged[i].Names = nil
+			continue
+		}
+		merged[i].Names = sets.NewString(merged[i].Names...).Insert(resource.Names...).List()
+	}
+	return merged
+}
+
+// watchError logs the failures of the informers of a remote cluster, which retry them. The rejections of the
+// credentials are handled by checkCredentials.
+func (w *Watcher) watchError(key types.NamespacedName, err error) {
+	if err == io.EOF || apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		// The watch was closed normally, or must be started again from a new list.
+		return
+	}
+	w.logger.WithField("clusterDeployment", key).WithError(err).Warn("error watching remote cluster")
+}
+
+// checkCredentials handles the result of a request of the informers of a remote cluster, resetting the backoff of the
+// rejected watches once the remote cluster accepts the credentials.
+func (w *Watcher) checkCredentials(key types.NamespacedName, clusterWatch *clusterWatch, err error) {
+	switch {
+	case err == nil:
+		w.mu.Lock()
+		clusterWatch.rejections = 0
+		w.mu.Unlock()
+	case apierrors.IsUnauthorized(err):
+		w.credentialsRejected(key, clusterWatch)
+	}
+}
+
+// credentialsRejected stops the informers of the remote cluster which rejected their credentials, such as an expired
+// ServiceAccount token, as they cannot recover with the same credentials. The cached ServiceAccount tokens of the
+// cluster are forgotten, and the ClusterDeployment is enqueued for its controller to watch the remote cluster again
+// with new credentials. A cluster which keeps rejecting the credentials is enqueued with an exponential backoff.
+func (w *Watcher) credentialsRejected(key types.NamespacedName, clusterWatch *clusterWatch) {
+	w.mu.Lock()
+	if clusterWatch.rejected || w.watches[key] != clusterWatch {
+		// The informers were already stopped.
+		w.mu.Unlock()
+		return
+	}
+	clusterWatch.rejected = true
+	clusterWatch.rejections++
+	clusterWatch.cancel()
+	backoff := rejectedWatchBackoff(clusterWatch.rejections)
+	w.mu.Unlock()
+
+	w.logger.WithField("clusterDeployment", key).WithField("backoff", backoff).
+		Error("remote cluster rejected the credentials of the watch, watching it again with new credentials")
+	ForgetServiceAccountTokens(key)
+	time.AfterFunc(backoff, func() { w.enqueue(key) })
+}
+
+// rejectedWatchBackoff returns how long to wait before watching a remote cluster again after its credentials were
+// rejected the given number of times in a row. The first rejection, such as of an expired token, is retried
+// immediately.
+func rejectedWatchBackoff(rejections int) time.Duration {
+	if rejections <= 1 {
+		return 0
+	}
+	backoff := time.Second << uint(rejections-2)
+	if backoff <= 0 || backoff > maxRejectedWatchBackoff {
+		return maxRejectedWatchBackoff
+	}
+	return backoff
+}
+
+func (w *Watcher) eventHandler(ctx context.Context, key types.NamespacedName, clusterWatch *clusterWatch, resource WatchedResource) cache.ResourceEventHandler {
+	names := sets.NewString(resource.Names...)
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldU, oldOK := oldObj.(*unstructured.Unstructured)
+			newU, newOK := newObj.(*unstructured.Unstructured)
+			if !oldOK || !newOK || !isWatchedObject(newU, names) || !isRelevantUpdate(oldU, newU) {
+				return
+			}
+			w.notify(ctx, key, clusterWatch, resource, newU)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok || !isWatchedObject(u, names) {
+				return
+			}
+			w.notify(ctx, key, clusterWatch, resource, u)
+		},
+	}
+}
+
+// notify records the change of the object and enqueues the ClusterDeployment of the remote cluster. It does not block
+// the informer when the controller is busy.
+func (w *Watcher) notify(ctx context.Context, key types.NamespacedName, clusterWatch *clusterWatch, resource WatchedResource, obj *unstructured.Unstructured) {
+	ref := ObjectReference{
+		APIVersion: resource.GroupVersion().String(),
+		Kind:       resource.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	w.logger.WithField("clusterDeployment", key).WithField("object", ref).Debug("watched object changed in remote cluster")
+	if ctx.Err() != nil {
+		// The resources of the remote cluster are no longer watched.
+		return
+	}
+	w.mu.Lock()
+	clusterWatch.changed[ref] = true
+	w.mu.Unlock()
+	w.enqueue(key)
+}
+
+// enqueue adds the ClusterDeployment to the ClusterDeployments whose events are pending, without blocking.
+func (w *Watcher) enqueue(key types.NamespacedName) {
+	w.mu.Lock()
+	w.pending[key] = true
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+		// The sender is already signaled, and takes all of the pending ClusterDeployments.
+	}
+}
+
+// sendEvents sends an event to the controller for each pending ClusterDeployment until the context is done. The
+// ClusterDeployments enqueued again while the controller is busy are sent once.
+func (w *Watcher) sendEvents(ctx context.Context) {
+	for {
+		select {
+		case <-w.wake:
+		case <-ctx.Done():
+			return
+		}
+		for _, key := range w.takePending() {
+			cd := &hivev1.ClusterDeployment{}
+			cd.Namespace = key.Namespace
+			cd.Name = key.Name
+			select {
+			case w.events <- event.GenericEvent{Meta: cd, Object: cd}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// takePending returns the pending ClusterDeployments and clears them.
+func (w *Watcher) takePending() []types.NamespacedName {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keys := make([]types.NamespacedName, 0, len(w.pending))
+	for key := range w.pending {
+		keys = append(keys, key)
+	}
+	w.pending = map[types.NamespacedName]bool{}
+	return keys
+}
+
+func isWatchedObject(obj *unstructured.Unstructured, names sets.String) bool {
+	return names.Len() == 0 || names.Has(obj.GetName())
+}
+
+// isRelevantUpdate returns false for the updates which do not change what the controllers manage: the resyncs of the
+// informers and the updates of the status of objects. The generation of an object is not changed when only its status
+// or its metadata are changed.
+func isRelevantUpdate(oldObj, newObj *unstructured.Unstructured) bool {
+	if oldObj.GetResourceVersion() == newObj.GetResourceVersion() {
+		return false
+	}
+	if newObj.GetGeneration() == 0 || oldObj.GetGeneration() != newObj.GetGeneration() {
+		return true
+	}
+	return !reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) ||
+		!reflect.DeepEqual(oldObj.GetAnnotations(), newObj.GetAnnotations())
+}
+
+// normalizeWatchedResources returns a sorted copy of the resources with sorted names, so that watched resources can be
+// compared regardless of the order in which the controllers list them.
+func normalizeWatchedResources(resources []WatchedResource) []WatchedResource {
+	normalized := make([]WatchedResource, len(resources))
+	for i, resource := range resources {
+		normalized[i] = resource
+		if len(resource.Names) > 0 {
+			normalized[i].Names = sets.NewString(resource.Names...).List()
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		a, b := normalized[i], normalized[j]
+		if a.GroupVersionKind != b.GroupVersionKind {
+			return a.GroupVersionKind.String() < b.GroupVersionKind.String()
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.LabelSelector < b.LabelSelector
+	})
+	return normalized
+}
+
+func objectReferenceLess(a, b ObjectReference) bool {
+	if a.APIVersion != b.APIVersion {
+		return a.APIVersion < b.APIVersion
+	}
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,323 @@
+package remoteclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+var configMapResource = WatchedResource{
+	GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	Namespace:        testNamespace,
+}
+
+func testRESTMapper(*rest.Config) (meta.RESTMapper, error) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	return mapper, nil
+}
+
+// dynamicBuilder is a Builder building REST configs for a test API server.
+type dynamicBuilder struct {
+	fakeBuilder
+	host  string
+	token string
+}
+
+func (b *dynamicBuilder) RESTConfig() (*rest.Config, error) {
+	return &rest.Config{Host: b.host, BearerToken: b.token}, nil
+}
+
+// receiveEvent returns the next event of the watcher, failing the test if there is none.
+func receiveEvent(t *testing.T, w *Watcher) types.NamespacedName {
+	select {
+	case e := <-w.events:
+		assert.IsType(t, &hivev1.ClusterDeployment{}, e.Object, "unexpected object in event")
+		return types.NamespacedName{Namespace: e.Meta.GetNamespace(), Name: e.Meta.GetName()}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return types.NamespacedName{}
+	}
+}
+
+func testConfigMapObject(name, resourceVersion string, generation int64, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(testNamespace)
+	obj.SetName(name)
+	obj.SetResourceVersion(resourceVersion)
+	obj.SetGeneration(generation)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestIsRelevantUpdate(t *testing.T) {
+	cases := []struct {
+		name     string
+		oldObj   *unstructured.Unstructured
+		newObj   *unstructured.Unstructured
+		expected bool
+	}{
+		{
+			name:   "resync",
+			oldObj: testConfigMapObject("test", "1", 0, nil),
+			newObj: testConfigMapObject("test", "1", 0, nil),
+		},
+		{
+			name:     "object without generation",
+			oldObj:   testConfigMapObject("test", "1", 0, nil),
+			newObj:   testConfigMapObject("test", "2", 0, nil),
+			expected: true,
+		},
+		{
+			name:     "generation changed",
+			oldObj:   testConfigMapObject("test", "1", 1, nil),
+			newObj:   testConfigMapObject("test", "2", 2, nil),
+			expected: true,
+		},
+		{
+			name:   "status changed",
+			oldObj: testConfigMapObject("test", "1", 1, nil),
+			newObj: testConfigMapObject("test", "2", 1, nil),
+		},
+		{
+			name:     "labels changed",
+			oldObj:   testConfigMapObject("test", "1", 1, nil),
+			newObj:   testConfigMapObject("test", "2", 1, map[string]string{"key": "value"}),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isRelevantUpdate(tc.oldObj, tc.newObj))
+		})
+	}
+}
+
+func TestWatcherEventHandler(t *testing.T) {
+	key := types.NamespacedName{Namespace: testNamespace, Name: "test-cluster"}
+	w := NewWatcher(testControllerName)
+	clusterWatch := &clusterWatch{cancel: func() {}, changed: map[ObjectReference]bool{}}
+	w.watches[key] = clusterWatch
+	resource := configMapResource
+	resource.Names = []string{"watched", "other-watched"}
+	handler := w.eventHandler(context.Background(), key, clusterWatch, resource)
+
+	handler.OnAdd(testConfigMapObject("watched", "1", 0, nil))
+	handler.OnUpdate(testConfigMapObject("watched", "1", 0, nil), testConfigMapObject("watched", "1", 0, nil))
+	handler.OnUpdate(testConfigMapObject("not-watched", "1", 0, nil), testConfigMapObject("not-watched", "2", 0, nil))
+	assert.Empty(t, w.pending, "expected no events for creations, resyncs and objects not watched")
+
+	handler.OnUpdate(testConfigMapObject("watched", "1", 0, nil), testConfigMapObject("watched", "2", 0, nil))
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Obj: testConfigMapObject("other-watched", "1", 0, nil)})
+	assert.Equal(t, map[types.NamespacedName]bool{key: true}, w.pending, "expected changes to be coalesced into one pending event")
+
+	go w.sendEvents(w.ctx)
+	defer w.cancel()
+	assert.Equal(t, key, receiveEvent(t, w), "unexpected clusterdeployment in event")
+	assert.Empty(t, w.events, "expected a single event for the changes")
+
+	expected := []ObjectReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "other-watched"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "watched"},
+	}
+	assert.Equal(t, expected, w.TakeChanges(key), "unexpected changes")
+	assert.Empty(t, w.TakeChanges(key), "expected changes to be taken only once")
+}
+
+func TestWatcherWatch(t *testing.T) {
+	cd := testClusterDeployment()
+	key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	deleted := testConfigMapObject("watched", "2", 0, nil)
+	notWatched := testConfigMapObject("not-watched", "2", 0, nil)
+	var mu sync.Mutex
+	fieldSelectors := sets.NewString()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != fmt.Sprintf("/api/v1/namespaces/%s/configmaps", testNamespace) {
+			http.NotFound(rw, req)
+			return
+		}
+		fieldSelector := req.URL.Query().Get("fieldSelector")
+		mu.Lock()
+		requests++
+		fieldSelectors.Insert(fieldSelector)
+		mu.Unlock()
+		rw.Header().Set("Content-Type", "application/json")
+		if req.URL.Query().Get("watch") != "true" {
+			list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMapList"}}
+			list.SetResourceVersion("1")
+			list.Items = []unstructured.Unstructured{
+				*testConfigMapObject("not-watched", "1", 0, nil),
+				*testConfigMapObject("watched", "1", 0, nil),
+			}
+			json.NewEncoder(rw).Encode(list)
+			return
+		}
+		json.NewEncoder(rw).Encode(map[string]interface{}{"type": "DELETED", "object": notWatched.Object})
+		json.NewEncoder(rw).Encode(map[string]interface{}{"type": "DELETED", "object": deleted.Object})
+		rw.(http.Flusher).Flush()
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	w := NewWatcher(testControllerName)
+	defer w.cancel()
+	go w.sendEvents(w.ctx)
+	w.newRESTMapper = testRESTMapper
+	builder := &dynamicBuilder{host: server.URL, token: "token"}
+	resource := configMapResource
+	resource.Names = []string{"watched"}
+	otherResource := configMapResource
+	otherResource.Names = []string{"other-watched"}
+	resources := []WatchedResource{resource, otherResource}
+	require.NoError(t, w.Watch(cd, builder, resources), "unexpected error watching remote cluster")
+
+	assert.Equal(t, key, receiveEvent(t, w), "unexpected clusterdeployment in event")
+	assert.Equal(t,
+		[]ObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "watched"}},
+		w.TakeChanges(key),
+		"unexpected changes",
+	)
+	mu.Lock()
+	assert.Equal(t, 2, requests, "expected the resources to be listed and watched by a single informer")
+	assert.Equal(t, []string{""}, fieldSelectors.List(), "expected the watched objects not to be selected by name")
+	mu.Unlock()
+
+	current := w.watches[key]
+	require.NoError(t, w.Watch(cd, builder, resources), "unexpected error watching remote cluster")
+	assert.Same(t, current, w.watches[key], "expected watch not to be restarted when the resources are unchanged")
+
+	builder.token = "renewed-token"
+	require.NoError(t, w.Watch(cd, builder, resources), "unexpected error watching remote cluster")
+	assert.NotSame(t, current, w.watches[key], "expected watch to be restarted when the token is renewed")
+
+	w.StopWatching(key)
+	assert.NotContains(t, w.watches, key, "expected remote cluster not to be watched")
+}
+
+func TestWatcherCredentialsRejected(t *testing.T) {
+	cd := testClusterDeployment()
+	key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if req.Header.Get("Authorization") != "Bearer valid-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Status",
+				"status":     "Failure",
+				"reason":     "Unauthorized",
+				"code":       http.StatusUnauthorized,
+			})
+			return
+		}
+		if req.URL.Query().Get("watch") != "true" {
+			list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMapList"}}
+			list.SetResourceVersion("1")
+			json.NewEncoder(rw).Encode(list)
+			return
+		}
+		rw.(http.Flusher).Flush()
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	w := NewWatcher(testControllerName)
+	defer w.cancel()
+	go w.sendEvents(w.ctx)
+	w.newRESTMapper = testRESTMapper
+	builder := &dynamicBuilder{host: server.URL, token: "expired-token"}
+	require.NoError(t, w.Watch(cd, builder, []WatchedResource{configMapResource}), "unexpected error watching remote cluster")
+
+	assert.Equal(t, key, receiveEvent(t, w), "expected clusterdeployment to be enqueued when the credentials are rejected")
+	w.mu.Lock()
+	rejected := w.watches[key]
+	assert.True(t, rejected.rejected, "expected watch to be marked as rejected")
+	assert.Equal(t, 1, rejected.rejections, "unexpected number of rejections")
+	w.mu.Unlock()
+
+	builder.token = "valid-token"
+	require.NoError(t, w.Watch(cd, builder, []WatchedResource{configMapResource}), "unexpected error watching remote cluster")
+	w.mu.Lock()
+	assert.NotSame(t, rejected, w.watches[key], "expected watch to be restarted after the credentials were rejected")
+	w.mu.Unlock()
+	assert.Eventually(t, func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return w.watches[key].rejections == 0
+	}, 10*time.Second, 10*time.Millisecond, "expected rejections to be reset once the credentials are accepted")
+}
+
+func TestRejectedWatchBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), rejectedWatchBackoff(1), "expected first rejection to be retried immediately")
+	assert.Equal(t, time.Second, rejectedWatchBackoff(2))
+	assert.Equal(t, 4*time.Second, rejectedWatchBackoff(4))
+	assert.Equal(t, maxRejectedWatchBackoff, rejectedWatchBackoff(100))
+}
+
+func TestWatcherWatchErrors(t *testing.T) {
+	cd := testClusterDeployment()
+	key := client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name}
+	cases := []struct {
+		name     string
+		builder  Builder
+		resource WatchedResource
+	}{
+		{
+			name:     "unknown kind",
+			builder:  &dynamicBuilder{host: "https://example.com"},
+			resource: WatchedResource{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Unknown")},
+		},
+		{
+			name:     "cannot get REST config",
+			builder:  &fakeBuilder{},
+			resource: configMapResource,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewWatcher(testControllerName)
+			w.newRESTMapper = testRESTMapper
+			err := w.Watch(cd, tc.builder, []WatchedResource{tc.resource})
+			assert.Error(t, err, "expected error watching remote cluster")
+			assert.NotContains(t, w.watches, key, "expected remote cluster not to be watched")
+		})
+	}
+}
+
+func TestNormalizeWatchedResources(t *testing.T) {
+	machineSets := WatchedResource{
+		GroupVersionKind: schema.GroupVersionKind{Group: "machine.openshift.io", Version: "v1beta1", Kind: "MachineSet"},
+		Namespace:        "openshift-machine-api",
+	}
+	configMaps := configMapResource
+	configMaps.Names = []string{"b", "a", "b"}
+	expectedConfigMaps := configMapResource
+	expectedConfigMaps.Names = []string{"a", "b"}
+	assert.Equal(t,
+		[]WatchedResource{expectedConfigMaps, machineSets},
+		normalizeWatchedResources([]WatchedResource{machineSets, configMaps}),
+	)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jonboulle/clockwork"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/cli-runtime/pkg/printers"
 	kresource "k8s.io/cli-runtime/pkg/resource"
 	kcmdapply "k8s.io/kubectl/pkg/cmd/apply"
+	"sigs.k8s.io/yaml"
 
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
@@ -39,22 +41,63 @@ const (
 
 const fieldTooLong metav1.CauseType = "FieldValueTooLong"
 
+// AppliedObject describes the object in the target cluster that resulted from applying a resource.
+type AppliedObject struct {
+	APIVersion      string
+	Kind            string
+	Namespace       string
+	Name            string
+	ResourceVersion string
+	// Generation is the generation of the object, which is zero for objects that do not track the generation of their
+	// spec.
+	Generation int64
+	// Result is the type of change that was performed on the object.
+	Result ApplyResult
+}
+
+func newAppliedObject(info *kresource.Info, obj runtime.Object, result ApplyResult) *AppliedObject {
+	gvk := info.ResourceMapping().GroupVersionKind
+	appliedObject := &AppliedObject{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  info.Namespace,
+		Name:       info.Name,
+		Result:     result,
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		appliedObject.ResourceVersion = accessor.GetResourceVersion()
+		appliedObject.Generation = accessor.GetGeneration()
+	}
+	return appliedObject
+}
+
 // Apply applies the given resource bytes to the target cluster specified by kubeconfig
-func (r *helper) Apply(obj []byte) (ApplyResult, error) {
+func (r *helper) Apply(ctx context.Context, obj []byte) (*AppliedObject, error) {
+	var appliedObject *AppliedObject
+	if err := r.runWithTimeout(ctx, "apply", func(ctx context.Context) (err error) {
+		appliedObject, err = r.apply(obj)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return appliedObject, nil
+}
+
+func (r *helper) apply(obj []byte) (*AppliedObject, error) {
 	factory, err := r.getFactory("")
 	if err != nil {
 		r.logger.WithError(err).Error("failed to obtain factory for apply")
-		return "", err
+		return nil, err
 	}
 	ioStreams := genericclioptions.IOStreams{
 		In:     &bytes.Buffer{},
 		Out:    &bytes.Buffer{},
 		ErrOut: &bytes.Buffer{},
 	}
-	applyOptions, changeTracker, err := r.setupApplyCommand(factory, obj, ioStreams)
+	applyOptions, changeTracker, info, err := r.setupApplyCommand(factory, obj, ioStreams)
 	if err != nil {
 		r.logger.WithError(err).Error("failed to setup apply command")
-		return "", err
+		return nil, err
 	}
 
 	err = applyOptions.Run()
@@ -62,118 +105,154 @@ func (r *helper) Apply(obj []byte) (ApplyResult, error) {
 		r.logger.WithError(err).
 			WithField("stdout", ioStreams.Out.(*bytes.Buffer).String()).
 			WithField("stderr", ioStreams.ErrOut.(*bytes.Buffer).String()).Warn("running the apply command failed")
-		return "", err
+		return nil, err
+	}
+	r.resetDiscoveryAfterCRD(obj)
+	// The apply command refreshes the info with the object returned by the server.
+	return newAppliedObject(info, info.Object, changeTracker.GetResult()), nil
+}
+
+// resetDiscoveryAfterCRD discards the discovery information of the session after a CustomResourceDefinition has been
+// applied, so that resources of the new type can be applied in the same session.
+func (r *helper) resetDiscoveryAfterCRD(obj []byte) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(obj, &typeMeta); err == nil && typeMeta.Kind == "CustomResourceDefinition" {
+		r.logger.Debug("resetting discovery after applying a CustomResourceDefinition")
+		r.discovery.reset()
 	}
-	return changeTracker.GetResult(), nil
 }
 
 // ApplyRuntimeObject serializes an object and applies it to the target cluster specified by the kubeconfig.
-func (r *helper) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+func (r *helper) ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
 	data, err := Serialize(obj, scheme)
 	if err != nil {
 		r.logger.WithError(err).Warn("cannot serialize runtime object")
-		return "", err
+		return nil, err
+	}
+	return r.Apply(ctx, data)
+}
+
+func (r *helper) CreateOrUpdate(ctx context.Context, obj []byte) (*AppliedObject, error) {
+	var appliedObject *AppliedObject
+	if err := r.runWithTimeout(ctx, "create or update", func(ctx context.Context) (err error) {
+		appliedObject, err = r.createOrUpdateObject(ctx, obj)
+		return
+	}); err != nil {
+		return nil, err
 	}
-	return r.Apply(data)
+	return appliedObject, nil
 }
 
-func (r *helper) CreateOrUpdate(obj []byte) (ApplyResult, error) {
+func (r *helper) createOrUpdateObject(ctx context.Context, obj []byte) (*AppliedObject, error) {
 	factory, err := r.getFactory("")
 	if err != nil {
 		r.logger.WithError(err).Error("failed to obtain factory for apply")
-		return "", err
+		return nil, err
 	}
 
 	errOut := &bytes.Buffer{}
-	result, err := r.createOrUpdate(factory, obj, errOut)
+	result, err := r.createOrUpdate(ctx, factory, obj, errOut)
 	if err != nil {
 		r.logger.WithError(err).
 			WithField("stderr", errOut.String()).Warn("running the apply command failed")
-		return "", err
+		return nil, err
 	}
+	r.resetDiscoveryAfterCRD(obj)
 	return result, nil
 }
 
-func (r *helper) CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+func (r *helper) CreateOrUpdateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
 	data, err := Serialize(obj, scheme)
 	if err != nil {
 		r.logger.WithError(err).Warn("cannot serialize runtime object")
-		return "", err
+		return nil, err
 	}
-	return r.CreateOrUpdate(data)
+	return r.CreateOrUpdate(ctx, data)
 }
 
-func (r *helper) Create(obj []byte) (ApplyResult, error) {
+func (r *helper) Create(ctx context.Context, obj []byte) (*AppliedObject, error) {
+	var appliedObject *AppliedObject
+	if err := r.runWithTimeout(ctx, "create", func(ctx context.Context) (err error) {
+		appliedObject, err = r.create(ctx, obj)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return appliedObject, nil
+}
+
+func (r *helper) create(ctx context.Context, obj []byte) (*AppliedObject, error) {
 	factory, err := r.getFactory("")
 	if err != nil {
 		r.logger.WithError(err).Error("failed to obtain factory for apply")
-		return "", err
+		return nil, err
 	}
-	result, err := r.createOnly(factory, obj)
+	result, err := r.createOnly(ctx, factory, obj)
 	if err != nil {
 		r.logger.WithError(err).Warn("running the create command failed")
-		return "", err
+		return nil, err
 	}
+	r.resetDiscoveryAfterCRD(obj)
 	return result, nil
 }
 
-func (r *helper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+func (r *helper) CreateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
 	data, err := Serialize(obj, scheme)
 	if err != nil {
 		r.logger.WithError(err).Warn("cannot serialize runtime object")
-		return "", err
+		return nil, err
 	}
-	return r.Create(data)
+	return r.Create(ctx, data)
 }
 
-func (r *helper) createOnly(f cmdutil.Factory, obj []byte) (ApplyResult, error) {
+func (r *helper) createOnly(ctx context.Context, f cmdutil.Factory, obj []byte) (*AppliedObject, error) {
 	info, err := r.getResourceInternalInfo(f, obj)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if info == nil {
 		r.logger.Debug("err getting info")
 	}
 	c, err := f.DynamicClient()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if err = info.Get(); err != nil {
 		if !errors.IsNotFound(err) {
-			return "", err
+			return nil, err
 		}
 		// Object doesn't exist yet, create it
 		gvr := info.ResourceMapping().Resource
-		_, err := c.Resource(gvr).Namespace(info.Namespace).Create(context.TODO(), info.Object.(*unstructured.Unstructured), metav1.CreateOptions{})
+		created, err := c.Resource(gvr).Namespace(info.Namespace).Create(ctx, info.Object.(*unstructured.Unstructured), metav1.CreateOptions{})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		return CreatedApplyResult, nil
+		return newAppliedObject(info, created, CreatedApplyResult), nil
 	}
-	return UnchangedApplyResult, nil
+	return newAppliedObject(info, info.Object, UnchangedApplyResult), nil
 }
 
-func (r *helper) createOrUpdate(f cmdutil.Factory, obj []byte, errOut io.Writer) (ApplyResult, error) {
+func (r *helper) createOrUpdate(ctx context.Context, f cmdutil.Factory, obj []byte, errOut io.Writer) (*AppliedObject, error) {
 	info, err := r.getResourceInternalInfo(f, obj)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	c, err := f.DynamicClient()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	sourceObj := info.Object.DeepCopyObject()
 	if err = info.Get(); err != nil {
 		if !errors.IsNotFound(err) {
-			return "", err
+			return nil, err
 		}
 		// Object doesn't exist yet, create it
 		gvr := info.ResourceMapping().Resource
-		_, err := c.Resource(gvr).Namespace(info.Namespace).Create(context.TODO(), info.Object.(*unstructured.Unstructured), metav1.CreateOptions{})
+		created, err := c.Resource(gvr).Namespace(info.Namespace).Create(ctx, info.Object.(*unstructured.Unstructured), metav1.CreateOptions{})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		return CreatedApplyResult, nil
+		return newAppliedObject(info, created, CreatedApplyResult), nil
 	}
 	openAPISchema, _ := f.OpenAPISchema()
 	patcher := kcmdapply.Patcher{
@@ -185,26 +264,26 @@ func (r *helper) createOrUpdate(f cmdutil.Factory, obj []byte, errOut io.Writer)
 	}
 	sourceBytes, err := runtime.Encode(unstructured.UnstructuredJSONScheme, sourceObj)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	patch, _, err := patcher.Patch(info.Object, sourceBytes, info.Source, info.Namespace, info.Name, errOut)
+	patch, patchedObj, err := patcher.Patch(info.Object, sourceBytes, info.Source, info.Namespace, info.Name, errOut)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	result := ConfiguredApplyResult
 	if string(patch) == "{}" {
 		result = UnchangedApplyResult
 	}
-	return result, nil
+	return newAppliedObject(info, patchedObj, result), nil
 }
 
-func (r *helper) setupApplyCommand(f cmdutil.Factory, obj []byte, ioStreams genericclioptions.IOStreams) (*kcmdapply.ApplyOptions, *changeTracker, error) {
+func (r *helper) setupApplyCommand(f cmdutil.Factory, obj []byte, ioStreams genericclioptions.IOStreams) (*kcmdapply.ApplyOptions, *changeTracker, *kresource.Info, error) {
 	r.logger.Debug("setting up apply command")
 	o := kcmdapply.NewApplyOptions(ioStreams)
 	dynamicClient, err := f.DynamicClient()
 	if err != nil {
 		r.logger.WithError(err).Error("cannot obtain dynamic client from factory")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	o.DeleteOptions = o.DeleteFlags.ToOptions(dynamicClient, o.IOStreams)
 	// Re-use the openAPISchema that should have been initialized in the constructor.
@@ -212,20 +291,20 @@ func (r *helper) setupApplyCommand(f cmdutil.Factory, obj []byte, ioStreams gene
 	o.Validator, err = f.Validator(false)
 	if err != nil {
 		r.logger.WithError(err).Error("cannot obtain schema to validate objects from factory")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	o.Builder = f.NewBuilder()
 	o.Mapper, err = f.ToRESTMapper()
 	if err != nil {
 		r.logger.WithError(err).Error("cannot obtain RESTMapper from factory")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	o.DynamicClient = dynamicClient
 	o.Namespace, o.EnforceNamespace, err = f.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		r.logger.WithError(err).Error("cannot obtain namespace from factory")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	tracker := &changeTracker{
 		internalToPrinter: func(string) (printers.ResourcePrinter, error) { return o.PrintFlags.ToPrinter() },
@@ -233,10 +312,10 @@ func (r *helper) setupApplyCommand(f cmdutil.Factory, obj []byte, ioStreams gene
 	o.ToPrinter = tracker.ToPrinter
 	info, err := r.getResourceInternalInfo(f, obj)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	o.SetObjects([]*kresource.Info{info})
-	return o, tracker, nil
+	return o, tracker, info, nil
 }
 
 type trackerPrinter struct {
@@ -7,8 +7,10 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -37,29 +39,64 @@ func DeleteAnyExistingObject(c client.Client, key client.ObjectKey, obj hivev1.M
 	return nil
 }
 
-func (r *helper) Delete(apiVersion, kind, namespace, name string) error {
+// DeleteOption sets an option on the request to delete resources from the target cluster.
+type DeleteOption func(*metav1.DeleteOptions)
+
+// WithPropagationPolicy sets whether and how garbage collection is performed for the dependents of the deleted
+// resources.
+func WithPropagationPolicy(policy metav1.DeletionPropagation) DeleteOption {
+	return func(o *metav1.DeleteOptions) {
+		o.PropagationPolicy = &policy
+	}
+}
+
+func newDeleteOptions(opts []DeleteOption) metav1.DeleteOptions {
+	deleteOptions := metav1.DeleteOptions{}
+	for _, opt := range opts {
+		opt(&deleteOptions)
+	}
+	return deleteOptions
+}
+
+// Delete deletes the resource with the given type and name from the target cluster. It is not an error if the
+// resource does not exist.
+func (r *helper) Delete(ctx context.Context, apiVersion, kind, namespace, name string, opts ...DeleteOption) error {
+	return r.runWithTimeout(ctx, "delete", func(ctx context.Context) error {
+		resourceClient, err := r.dynamicResource(apiVersion, kind, namespace)
+		if err != nil {
+			return err
+		}
+		switch err := resourceClient.Delete(ctx, name, newDeleteOptions(opts)); {
+		case apierrors.IsNotFound(err):
+			r.logger.Info("resource has already been deleted")
+		case err != nil:
+			return errors.Wrap(err, "could not delete resource")
+		}
+		return nil
+	})
+}
+
+// dynamicResource returns a dynamic client for the resources of the given type in the namespace of the target cluster.
+func (r *helper) dynamicResource(apiVersion, kind, namespace string) (dynamic.ResourceInterface, error) {
 	f, err := r.getFactory(namespace)
 	if err != nil {
-		return errors.Wrap(err, "could not get factory")
+		return nil, errors.Wrap(err, "could not get factory")
 	}
 	mapper, err := f.ToRESTMapper()
 	if err != nil {
-		return errors.Wrap(err, "could not get mapper")
+		return nil, errors.Wrap(err, "could not get mapper")
 	}
 	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return errors.Wrap(err, "could not get mapping")
+		return nil, errors.Wrap(err, "could not get mapping")
 	}
 	dynamicClient, err := f.DynamicClient()
 	if err != nil {
-		return errors.Wrap(err, "could not create dynamic client")
+		return nil, errors.Wrap(err, "could not create dynamic client")
 	}
-	switch err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); {
-	case apierrors.IsNotFound(err):
-		r.logger.Info("resource has already been deleted")
-	case err != nil:
-		return errors.Wrap(err, "could not delete resource")
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return dynamicClient.Resource(mapping.Resource), nil
 	}
-	return nil
+	return dynamicClient.Resource(mapping.Resource).Namespace(namespace), nil
 }
@@ -4,11 +4,14 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 )
 
 func getDiscoveryClient(config *rest.Config, cacheDir string) (discovery.CachedDiscoveryInterface, error) {
@@ -18,6 +21,54 @@ func getDiscoveryClient(config *rest.Config, cacheDir string) (discovery.CachedD
 	return disk.NewCachedDiscoveryClientForConfig(config, discoveryCacheDir, httpCacheDir, time.Duration(10*time.Minute))
 }
 
+// sessionDiscovery shares one discovery client and REST mapper between all the operations of a helper, so that
+// applying many resources to a cluster does not rebuild the discovery information for every resource.
+type sessionDiscovery struct {
+	cacheDir string
+
+	lock            sync.Mutex
+	discoveryClient discovery.CachedDiscoveryInterface
+	mapper          *restmapper.DeferredDiscoveryRESTMapper
+}
+
+func (s *sessionDiscovery) toDiscoveryClient(config *rest.Config) (discovery.CachedDiscoveryInterface, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.discoveryClient == nil {
+		discoveryClient, err := getDiscoveryClient(rest.CopyConfig(config), s.cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		s.discoveryClient = discoveryClient
+	}
+	return s.discoveryClient, nil
+}
+
+func (s *sessionDiscovery) toRESTMapper(config *rest.Config) (meta.RESTMapper, error) {
+	discoveryClient, err := s.toDiscoveryClient(config)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.mapper == nil {
+		s.mapper = restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	}
+	return restmapper.NewShortcutExpander(s.mapper, discoveryClient), nil
+}
+
+// reset discards the discovery information of the session, so that types added to the cluster during the session,
+// such as those of a newly applied CustomResourceDefinition, are discovered by the next operation.
+func (s *sessionDiscovery) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.mapper != nil {
+		s.mapper.Reset()
+	} else if s.discoveryClient != nil {
+		s.discoveryClient.Invalidate()
+	}
+}
+
 // overlyCautiousIllegalFileCharacters matches characters that *might* not be supported.  Windows is really restrictive, so this is really restrictive
 var overlyCautiousIllegalFileCharacters = regexp.MustCompile(`[^(\w/\.)]`)
 
@@ -1,10 +1,12 @@
 package resource
 
 import (
+	"context"
 	"math/rand"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -23,16 +25,16 @@ func NewFakeHelper(logger log.FieldLogger) Helper {
 	return r
 }
 
-func (r *fakeHelper) Apply(obj []byte) (ApplyResult, error) {
+func (r *fakeHelper) Apply(ctx context.Context, obj []byte) (*AppliedObject, error) {
 	// TODO: would be good to simulate some of the serialization here if possible so we hit CPU/RAM nearly as much as
 	// we would in the real world.
 	r.fakeApplySleep()
-	return ConfiguredApplyResult, nil
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
-func (r *fakeHelper) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+func (r *fakeHelper) ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
 	r.fakeApplySleep()
-	return ConfiguredApplyResult, nil
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
 func (r *fakeHelper) fakeApplySleep() {
@@ -50,31 +52,39 @@ func (r *fakeHelper) fakeApplySleep() {
 	time.Sleep(wait)
 }
 
-func (r *fakeHelper) CreateOrUpdate(obj []byte) (ApplyResult, error) {
-	return ConfiguredApplyResult, nil
+func (r *fakeHelper) CreateOrUpdate(ctx context.Context, obj []byte) (*AppliedObject, error) {
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
-func (r *fakeHelper) CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
-	return ConfiguredApplyResult, nil
+func (r *fakeHelper) CreateOrUpdateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
-func (r *fakeHelper) Create(obj []byte) (ApplyResult, error) {
-	return ConfiguredApplyResult, nil
+func (r *fakeHelper) Create(ctx context.Context, obj []byte) (*AppliedObject, error) {
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
-func (r *fakeHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
-	return ConfiguredApplyResult, nil
+func (r *fakeHelper) CreateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error) {
+	return &AppliedObject{Result: ConfiguredApplyResult}, nil
 }
 
-func (r *fakeHelper) Info(obj []byte) (*Info, error) {
+func (r *fakeHelper) Info(ctx context.Context, obj []byte) (*Info, error) {
 	// TODO: Do we need to fake this better?
 	return &Info{}, nil
 }
 
-func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+func (fakeHelper) Patch(ctx context.Context, name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
 	return nil
 }
 
-func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
+func (fakeHelper) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return nil, nil
+}
+
+func (fakeHelper) List(ctx context.Context, apiVersion, kind, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	return nil, nil
+}
+
+func (fakeHelper) Delete(ctx context.Context, apiVersion, kind, namespace, name string, opts ...DeleteOption) error {
 	return nil
 }
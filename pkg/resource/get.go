@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Get gets the resource with the given type and name from the target cluster. A nil resource is returned if the
+// resource does not exist.
+func (r *helper) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	var obj *unstructured.Unstructured
+	if err := r.runWithTimeout(ctx, "get", func(ctx context.Context) error {
+		resourceClient, err := r.dynamicResource(apiVersion, kind, namespace)
+		if err != nil {
+			return err
+		}
+		switch o, err := resourceClient.Get(ctx, name, metav1.GetOptions{}); {
+		case apierrors.IsNotFound(err):
+			r.logger.Debug("resource does not exist")
+		case err != nil:
+			return errors.Wrap(err, "could not get resource")
+		default:
+			obj = o
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// List lists the resources of the given type in the namespace of the target cluster that match the label selector.
+func (r *helper) List(ctx context.Context, apiVersion, kind, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	var items []unstructured.Unstructured
+	if err := r.runWithTimeout(ctx, "list", func(ctx context.Context) error {
+		resourceClient, err := r.dynamicResource(apiVersion, kind, namespace)
+		if err != nil {
+			return err
+		}
+		list, err := resourceClient.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return errors.Wrap(err, "could not list resources")
+		}
+		items = list.Items
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,12 +1,16 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -14,32 +18,44 @@ import (
 	"k8s.io/kubectl/pkg/util/openapi"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
 	defaultCacheDir = "/tmp"
 	cacheDirEnvKey  = "CLI_CACHE_DIR"
+
+	defaultOperationTimeout = 2 * time.Minute
+	operationTimeoutEnvKey  = "RESOURCE_HELPER_OPERATION_TIMEOUT"
 )
 
 //go:generate mockgen -source=./helper.go -destination=./mock/helper_generated.go -package=mock
 
 type Helper interface {
-	// Apply applies the given resource bytes to the target cluster specified by kubeconfig
-	Apply(obj []byte) (ApplyResult, error)
+	// Apply applies the given resource bytes to the target cluster specified by kubeconfig, and returns the object that
+	// resulted from the apply.
+	Apply(ctx context.Context, obj []byte) (*AppliedObject, error)
 	// ApplyRuntimeObject serializes an object and applies it to the target cluster specified by the kubeconfig.
-	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
-	CreateOrUpdate(obj []byte) (ApplyResult, error)
-	CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
-	Create(obj []byte) (ApplyResult, error)
-	CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
+	ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error)
+	CreateOrUpdate(ctx context.Context, obj []byte) (*AppliedObject, error)
+	CreateOrUpdateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error)
+	Create(ctx context.Context, obj []byte) (*AppliedObject, error)
+	CreateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*AppliedObject, error)
 	// Info determines the name/namespace and type of the passed in resource bytes
-	Info(obj []byte) (*Info, error)
+	Info(ctx context.Context, obj []byte) (*Info, error)
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
-	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
-	Delete(apiVersion, kind, namespace, name string) error
+	Patch(ctx context.Context, name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
+	// Get gets the resource with the given type and name from the target cluster. A nil resource is returned if the
+	// resource does not exist.
+	Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
+	// List lists the resources of the given type in the namespace of the target cluster that match the label selector.
+	List(ctx context.Context, apiVersion, kind, namespace, labelSelector string) ([]unstructured.Unstructured, error)
+	// Delete deletes the resource with the given type and name from the target cluster.
+	Delete(ctx context.Context, apiVersion, kind, namespace, name string, opts ...DeleteOption) error
 }
 
-// helper contains configuration for apply and patch operations
+// helper contains configuration for apply and patch operations. All the operations of a helper share one discovery
+// session with the target cluster, so a helper is meant to be used for a batch of operations against one cluster.
 type helper struct {
 	logger         log.FieldLogger
 	cacheDir       string
@@ -50,6 +66,34 @@ type helper struct {
 	restConfig     *rest.Config
 	getFactory     func(namespace string) (cmdutil.Factory, error)
 	openAPISchema  openapi.Resources
+	discovery      *sessionDiscovery
+
+	// operationTimeout bounds the time taken by an operation against the target cluster, and by each of its requests.
+	operationTimeout time.Duration
+
+	factoriesLock sync.Mutex
+	factories     map[string]cmdutil.Factory
+}
+
+// cachedFactory returns a function that builds the factory for a namespace only once, and returns the same factory
+// for the subsequent operations in that namespace.
+func (r *helper) cachedFactory(newFactory func(namespace string) (cmdutil.Factory, error)) func(namespace string) (cmdutil.Factory, error) {
+	return func(namespace string) (cmdutil.Factory, error) {
+		r.factoriesLock.Lock()
+		defer r.factoriesLock.Unlock()
+		if f, ok := r.factories[namespace]; ok {
+			return f, nil
+		}
+		f, err := newFactory(namespace)
+		if err != nil {
+			return nil, err
+		}
+		if r.factories == nil {
+			r.factories = map[string]cmdutil.Factory{}
+		}
+		r.factories[namespace] = f
+		return f, nil
+	}
 }
 
 // cacheOpenAPISchema builds the very expensive OpenAPISchema (>3s commonly) once, and stores
@@ -69,26 +113,35 @@ func (r *helper) cacheOpenAPISchema() error {
 
 // NewHelperFromRESTConfig returns a new object that allows apply and patch operations
 func NewHelperFromRESTConfig(restConfig *rest.Config, logger log.FieldLogger) (Helper, error) {
+	operationTimeout := getOperationTimeout(logger)
 	r := &helper{
-		logger:     logger,
-		cacheDir:   getCacheDir(logger),
-		restConfig: restConfig,
+		logger:           logger,
+		cacheDir:         getCacheDir(logger),
+		restConfig:       withRequestTimeout(rest.CopyConfig(restConfig), operationTimeout),
+		operationTimeout: operationTimeout,
 	}
-	r.getFactory = r.getRESTConfigFactory
+	r.discovery = &sessionDiscovery{cacheDir: r.cacheDir}
+	r.getFactory = r.cachedFactory(r.getRESTConfigFactory)
 	err := r.cacheOpenAPISchema()
 	return r, err
 }
 
 // NewHelperWithMetricsFromRESTConfig returns a new object that allows apply and patch operations, with metrics tracking enabled.
 func NewHelperWithMetricsFromRESTConfig(restConfig *rest.Config, controllerName hivev1.ControllerName, logger log.FieldLogger) (Helper, error) {
+	// Copy the possibly shared restConfig reference and add a metrics wrapper.
+	cfg := rest.CopyConfig(restConfig)
+	controllerutils.AddControllerMetricsTransportWrapper(cfg, controllerName, false)
+	operationTimeout := getOperationTimeout(logger)
 	r := &helper{
-		logger:         logger,
-		metricsEnabled: true,
-		controllerName: controllerName,
-		cacheDir:       getCacheDir(logger),
-		restConfig:     restConfig,
+		logger:           logger,
+		metricsEnabled:   true,
+		controllerName:   controllerName,
+		cacheDir:         getCacheDir(logger),
+		restConfig:       withRequestTimeout(cfg, operationTimeout),
+		operationTimeout: operationTimeout,
 	}
-	r.getFactory = r.getRESTConfigFactory
+	r.discovery = &sessionDiscovery{cacheDir: r.cacheDir}
+	r.getFactory = r.cachedFactory(r.getRESTConfigFactory)
 	err := r.cacheOpenAPISchema()
 	return r, err
 }
@@ -96,11 +149,13 @@ func NewHelperWithMetricsFromRESTConfig(restConfig *rest.Config, controllerName
 // NewHelper returns a new object that allows apply and patch operations
 func NewHelper(kubeconfig []byte, logger log.FieldLogger) (Helper, error) {
 	r := &helper{
-		logger:     logger,
-		cacheDir:   getCacheDir(logger),
-		kubeconfig: kubeconfig,
+		logger:           logger,
+		cacheDir:         getCacheDir(logger),
+		kubeconfig:       kubeconfig,
+		operationTimeout: getOperationTimeout(logger),
 	}
-	r.getFactory = r.getKubeconfigFactory
+	r.discovery = &sessionDiscovery{cacheDir: r.cacheDir}
+	r.getFactory = r.cachedFactory(r.getKubeconfigFactory)
 	err := r.cacheOpenAPISchema()
 	return r, err
 }
@@ -112,6 +167,49 @@ func getCacheDir(logger log.FieldLogger) string {
 	return defaultCacheDir
 }
 
+func getOperationTimeout(logger log.FieldLogger) time.Duration {
+	envOperationTimeout := os.Getenv(operationTimeoutEnvKey)
+	if len(envOperationTimeout) == 0 {
+		return defaultOperationTimeout
+	}
+	operationTimeout, err := time.ParseDuration(envOperationTimeout)
+	if err != nil {
+		logger.WithError(err).WithField("operationTimeout", envOperationTimeout).Errorf("unable to parse %s", operationTimeoutEnvKey)
+		return defaultOperationTimeout
+	}
+	return operationTimeout
+}
+
+// withRequestTimeout bounds the requests made with the REST config by the operation timeout, unless the config already
+// has a timeout.
+func withRequestTimeout(restConfig *rest.Config, operationTimeout time.Duration) *rest.Config {
+	if restConfig.Timeout == 0 {
+		restConfig.Timeout = operationTimeout
+	}
+	return restConfig
+}
+
+// runWithTimeout runs the operation with a context that is done once the operation timeout of the helper has passed.
+// The operations that accept a context pass it down to their requests to the target cluster. The kubectl code used
+// by the other operations does not accept a context, so their requests are bounded by the timeout of the REST config
+// of the helper instead. The operation is not started when the context is already done.
+func (r *helper) runWithTimeout(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	if r.operationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.operationTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return errors.Wrapf(err, "%s not started", operation)
+	}
+	err := fn(ctx)
+	if err != nil && ctx.Err() != nil {
+		r.logger.WithError(err).WithField("operation", operation).Warn("operation against the target cluster did not complete in time")
+		return errors.Wrapf(err, "%s did not complete in time", operation)
+	}
+	return err
+}
+
 func (r *helper) createTempFile(prefix string, content []byte) (string, error) {
 	f, err := ioutil.TempFile(r.cacheDir, prefix)
 	if err != nil {
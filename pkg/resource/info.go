@@ -2,6 +2,7 @@ package resource
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -20,16 +21,19 @@ type Info struct {
 }
 
 // Info determines the name/namespace and type of the passed in resource bytes
-func (r *helper) Info(obj []byte) (*Info, error) {
-	factory, err := r.getFactory("")
-	if err != nil {
-		return nil, err
-	}
-	resourceInfo, err := r.getResourceInfo(factory, obj)
-	if err != nil {
+func (r *helper) Info(ctx context.Context, obj []byte) (*Info, error) {
+	var resourceInfo *Info
+	if err := r.runWithTimeout(ctx, "info", func(ctx context.Context) error {
+		factory, err := r.getFactory("")
+		if err != nil {
+			return err
+		}
+		resourceInfo, err = r.getResourceInfo(factory, obj)
+		return err
+	}); err != nil {
 		return nil, err
 	}
-	return resourceInfo, err
+	return resourceInfo, nil
 }
 
 func (r *helper) getResourceInternalInfo(f cmdutil.Factory, obj []byte) (*resource.Info, error) {
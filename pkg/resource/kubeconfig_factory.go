@@ -6,7 +6,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
@@ -21,6 +20,11 @@ func (r *helper) getKubeconfigFactory(namespace string) (cmdutil.Factory, error)
 	if len(namespace) > 0 {
 		overrides.Context.Namespace = namespace
 	}
+	// The timeout is set on the overrides so that it also bounds the requests of the clients that kubectl builds from
+	// the raw kubeconfig loader.
+	if r.operationTimeout > 0 {
+		overrides.Timeout = r.operationTimeout.String()
+	}
 	clientConfig := clientcmd.NewNonInteractiveClientConfig(*config, "", overrides, nil)
 	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
@@ -33,7 +37,7 @@ func (r *helper) getKubeconfigFactory(namespace string) (cmdutil.Factory, error)
 	r.logger.WithField("cache-dir", r.cacheDir).Debug("creating cmdutil.Factory from client config and cache directory")
 	f := cmdutil.NewFactory(&kubeconfigClientGetter{
 		clientConfig:   clientConfig,
-		cacheDir:       r.cacheDir,
+		discovery:      r.discovery,
 		controllerName: r.controllerName,
 		metricsEnabled: r.metricsEnabled,
 		restConfig:     restConfig,
@@ -43,7 +47,7 @@ func (r *helper) getKubeconfigFactory(namespace string) (cmdutil.Factory, error)
 
 type kubeconfigClientGetter struct {
 	clientConfig   clientcmd.ClientConfig
-	cacheDir       string
+	discovery      *sessionDiscovery
 	controllerName hivev1.ControllerName
 	metricsEnabled bool
 	restConfig     *rest.Config
@@ -56,23 +60,12 @@ func (r *kubeconfigClientGetter) ToRESTConfig() (*rest.Config, error) {
 
 // ToDiscoveryClient returns discovery client
 func (r *kubeconfigClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
-	config, err := r.ToRESTConfig()
-	if err != nil {
-		return nil, err
-	}
-	return getDiscoveryClient(config, r.cacheDir)
+	return r.discovery.toDiscoveryClient(r.restConfig)
 }
 
 // ToRESTMapper returns a restmapper
 func (r *kubeconfigClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
-	discoveryClient, err := r.ToDiscoveryClient()
-	if err != nil {
-		return nil, err
-	}
-
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
-	expander := restmapper.NewShortcutExpander(mapper, discoveryClient)
-	return expander, nil
+	return r.discovery.toRESTMapper(r.restConfig)
 }
 
 // ToRawKubeConfigLoader return kubeconfig loader as-is
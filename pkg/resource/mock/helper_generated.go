@@ -5,8 +5,10 @@
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/openshift/hive/pkg/resource"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 	reflect "reflect"
@@ -36,134 +38,169 @@ func (m *MockHelper) EXPECT() *MockHelperMockRecorder {
 }
 
 // Apply mocks base method
-func (m *MockHelper) Apply(obj []byte) (resource.ApplyResult, error) {
+func (m *MockHelper) Apply(ctx context.Context, obj []byte) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Apply", obj)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "Apply", ctx, obj)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Apply indicates an expected call of Apply
-func (mr *MockHelperMockRecorder) Apply(obj interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) Apply(ctx, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockHelper)(nil).Apply), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockHelper)(nil).Apply), ctx, obj)
 }
 
 // ApplyRuntimeObject mocks base method
-func (m *MockHelper) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+func (m *MockHelper) ApplyRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplyRuntimeObject", obj, scheme)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "ApplyRuntimeObject", ctx, obj, scheme)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ApplyRuntimeObject indicates an expected call of ApplyRuntimeObject
-func (mr *MockHelperMockRecorder) ApplyRuntimeObject(obj, scheme interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) ApplyRuntimeObject(ctx, obj, scheme interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRuntimeObject", reflect.TypeOf((*MockHelper)(nil).ApplyRuntimeObject), obj, scheme)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRuntimeObject", reflect.TypeOf((*MockHelper)(nil).ApplyRuntimeObject), ctx, obj, scheme)
 }
 
 // CreateOrUpdate mocks base method
-func (m *MockHelper) CreateOrUpdate(obj []byte) (resource.ApplyResult, error) {
+func (m *MockHelper) CreateOrUpdate(ctx context.Context, obj []byte) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdate", obj)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "CreateOrUpdate", ctx, obj)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrUpdate indicates an expected call of CreateOrUpdate
-func (mr *MockHelperMockRecorder) CreateOrUpdate(obj interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) CreateOrUpdate(ctx, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockHelper)(nil).CreateOrUpdate), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockHelper)(nil).CreateOrUpdate), ctx, obj)
 }
 
 // CreateOrUpdateRuntimeObject mocks base method
-func (m *MockHelper) CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+func (m *MockHelper) CreateOrUpdateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateRuntimeObject", obj, scheme)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "CreateOrUpdateRuntimeObject", ctx, obj, scheme)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrUpdateRuntimeObject indicates an expected call of CreateOrUpdateRuntimeObject
-func (mr *MockHelperMockRecorder) CreateOrUpdateRuntimeObject(obj, scheme interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) CreateOrUpdateRuntimeObject(ctx, obj, scheme interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRuntimeObject", reflect.TypeOf((*MockHelper)(nil).CreateOrUpdateRuntimeObject), obj, scheme)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRuntimeObject", reflect.TypeOf((*MockHelper)(nil).CreateOrUpdateRuntimeObject), ctx, obj, scheme)
 }
 
 // Create mocks base method
-func (m *MockHelper) Create(obj []byte) (resource.ApplyResult, error) {
+func (m *MockHelper) Create(ctx context.Context, obj []byte) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", obj)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "Create", ctx, obj)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockHelperMockRecorder) Create(obj interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) Create(ctx, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHelper)(nil).Create), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHelper)(nil).Create), ctx, obj)
 }
 
 // CreateRuntimeObject mocks base method
-func (m *MockHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+func (m *MockHelper) CreateRuntimeObject(ctx context.Context, obj runtime.Object, scheme *runtime.Scheme) (*resource.AppliedObject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRuntimeObject", obj, scheme)
-	ret0, _ := ret[0].(resource.ApplyResult)
+	ret := m.ctrl.Call(m, "CreateRuntimeObject", ctx, obj, scheme)
+	ret0, _ := ret[0].(*resource.AppliedObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRuntimeObject indicates an expected call of CreateRuntimeObject
-func (mr *MockHelperMockRecorder) CreateRuntimeObject(obj, scheme interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) CreateRuntimeObject(ctx, obj, scheme interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuntimeObject", reflect.TypeOf((*MockHelper)(nil).CreateRuntimeObject), obj, scheme)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuntimeObject", reflect.TypeOf((*MockHelper)(nil).CreateRuntimeObject), ctx, obj, scheme)
 }
 
 // Info mocks base method
-func (m *MockHelper) Info(obj []byte) (*resource.Info, error) {
+func (m *MockHelper) Info(ctx context.Context, obj []byte) (*resource.Info, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Info", obj)
+	ret := m.ctrl.Call(m, "Info", ctx, obj)
 	ret0, _ := ret[0].(*resource.Info)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Info indicates an expected call of Info
-func (mr *MockHelperMockRecorder) Info(obj interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) Info(ctx, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockHelper)(nil).Info), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockHelper)(nil).Info), ctx, obj)
 }
 
 // Patch mocks base method
-func (m *MockHelper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+func (m *MockHelper) Patch(ctx context.Context, name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Patch", name, kind, apiVersion, patch, patchType)
+	ret := m.ctrl.Call(m, "Patch", ctx, name, kind, apiVersion, patch, patchType)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Patch indicates an expected call of Patch
-func (mr *MockHelperMockRecorder) Patch(name, kind, apiVersion, patch, patchType interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) Patch(ctx, name, kind, apiVersion, patch, patchType interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockHelper)(nil).Patch), name, kind, apiVersion, patch, patchType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockHelper)(nil).Patch), ctx, name, kind, apiVersion, patch, patchType)
+}
+
+// Get mocks base method
+func (m *MockHelper) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, apiVersion, kind, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockHelperMockRecorder) Get(ctx, apiVersion, kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHelper)(nil).Get), ctx, apiVersion, kind, namespace, name)
+}
+
+// List mocks base method
+func (m *MockHelper) List(ctx context.Context, apiVersion, kind, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, apiVersion, kind, namespace, labelSelector)
+	ret0, _ := ret[0].([]unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockHelperMockRecorder) List(ctx, apiVersion, kind, namespace, labelSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHelper)(nil).List), ctx, apiVersion, kind, namespace, labelSelector)
 }
 
 // Delete mocks base method
-func (m *MockHelper) Delete(apiVersion, kind, namespace, name string) error {
+func (m *MockHelper) Delete(ctx context.Context, apiVersion, kind, namespace, name string, opts ...resource.DeleteOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", apiVersion, kind, namespace, name)
+	varargs := []interface{}{ctx, apiVersion, kind, namespace, name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockHelperMockRecorder) Delete(apiVersion, kind, namespace, name interface{}) *gomock.Call {
+func (mr *MockHelperMockRecorder) Delete(ctx, apiVersion, kind, namespace, name interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), apiVersion, kind, namespace, name)
+	varargs := append([]interface{}{ctx, apiVersion, kind, namespace, name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), varargs...)
 }
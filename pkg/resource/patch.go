@@ -2,6 +2,7 @@ package resource
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -20,8 +21,13 @@ var (
 )
 
 // Patch invokes the kubectl patch command with the given resource, patch and patch type
-func (r *helper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+func (r *helper) Patch(ctx context.Context, name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+	return r.runWithTimeout(ctx, "patch", func(context.Context) error {
+		return r.patch(name, kind, apiVersion, patch, patchType)
+	})
+}
 
+func (r *helper) patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
 	ioStreams := genericclioptions.IOStreams{
 		In:     &bytes.Buffer{},
 		Out:    &bytes.Buffer{},
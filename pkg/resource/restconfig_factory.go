@@ -1,30 +1,22 @@
 package resource
 
 import (
-	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
 
 func (r *helper) getRESTConfigFactory(namespace string) (cmdutil.Factory, error) {
-	if r.metricsEnabled {
-		// Copy the possibly shared restConfig reference and add a metrics wrapper.
-		cfg := rest.CopyConfig(r.restConfig)
-		controllerutils.AddControllerMetricsTransportWrapper(cfg, r.controllerName, false)
-		r.restConfig = cfg
-	}
 	r.logger.WithField("cache-dir", r.cacheDir).Debug("creating cmdutil.Factory from REST client config and cache directory")
-	f := cmdutil.NewFactory(&restConfigClientGetter{restConfig: r.restConfig, cacheDir: r.cacheDir, namespace: namespace})
+	f := cmdutil.NewFactory(&restConfigClientGetter{restConfig: r.restConfig, discovery: r.discovery, namespace: namespace})
 	return f, nil
 }
 
 type restConfigClientGetter struct {
 	restConfig *rest.Config
-	cacheDir   string
+	discovery  *sessionDiscovery
 	namespace  string
 }
 
@@ -35,20 +27,12 @@ func (r *restConfigClientGetter) ToRESTConfig() (*rest.Config, error) {
 
 // ToDiscoveryClient returns discovery client
 func (r *restConfigClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
-	config := rest.CopyConfig(r.restConfig)
-	return getDiscoveryClient(config, r.cacheDir)
+	return r.discovery.toDiscoveryClient(r.restConfig)
 }
 
 // ToRESTMapper returns a restmapper
 func (r *restConfigClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
-	discoveryClient, err := r.ToDiscoveryClient()
-	if err != nil {
-		return nil, err
-	}
-
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
-	expander := restmapper.NewShortcutExpander(mapper, discoveryClient)
-	return expander, nil
+	return r.discovery.toRESTMapper(r.restConfig)
 }
 
 // ToRawKubeConfigLoader return kubeconfig loader as-is
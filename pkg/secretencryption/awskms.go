@@ -0,0 +1,140 @@
+package secretencryption
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// AWSKMSProviderName is the name of the AWS KMS provider.
+	AWSKMSProviderName = "aws-kms"
+
+	awsKMSServiceName = "kms"
+)
+
+// awsKMSEncryptInput and the other KMS types mirror the shapes of the AWS KMS API. Only the fields used by Hive
+// are included.
+type awsKMSEncryptInput struct {
+	_         struct{} `type:"structure"`
+	KeyId     *string  `type:"string" required:"true"`
+	Plaintext []byte   `type:"blob" required:"true" sensitive:"true"`
+}
+
+type awsKMSEncryptOutput struct {
+	_              struct{} `type:"structure"`
+	CiphertextBlob []byte   `type:"blob"`
+}
+
+type awsKMSDecryptInput struct {
+	_              struct{} `type:"structure"`
+	CiphertextBlob []byte   `type:"blob" required:"true"`
+	KeyId          *string  `type:"string"`
+}
+
+type awsKMSDecryptOutput struct {
+	_         struct{} `type:"structure"`
+	Plaintext []byte   `type:"blob" sensitive:"true"`
+}
+
+type awsKMSProvider struct {
+	client *client.Client
+	keyID  string
+}
+
+// NewAWSKMSProvider returns a provider that encrypts data keys with the AWS KMS key. The credentials secret must
+// have the aws_access_key_id and aws_secret_access_key keys.
+func NewAWSKMSProvider(credsSecret *corev1.Secret, region, keyID string) (Provider, error) {
+	return newAWSKMSProvider(credsSecret, region, keyID, "")
+}
+
+func newAWSKMSProvider(credsSecret *corev1.Secret, region, keyID, endpoint string) (Provider, error) {
+	accessKeyID, ok := credsSecret.Data[constants.AWSAccessKeyIDSecretKey]
+	if !ok {
+		return nil, errors.Errorf("AWS credentials secret %v did not contain key %v", credsSecret.Name, constants.AWSAccessKeyIDSecretKey)
+	}
+	secretAccessKey, ok := credsSecret.Data[constants.AWSSecretAccessKeySecretKey]
+	if !ok {
+		return nil, errors.Errorf("AWS credentials secret %v did not contain key %v", credsSecret.Name, constants.AWSSecretAccessKeySecretKey)
+	}
+	awsConfig := &aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(string(accessKeyID), string(secretAccessKey), ""),
+	}
+	if endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+	}
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "openshift.io/hive",
+		Fn:   request.MakeAddToUserAgentHandler("openshift.io hive", "v1"),
+	})
+
+	// The KMS client of the AWS SDK is not vendored, so the requests are built with the JSON protocol of the SDK
+	// in the same way as the generated clients.
+	cfg := s.ClientConfig(awsKMSServiceName)
+	c := client.New(
+		*cfg.Config,
+		metadata.ClientInfo{
+			ServiceName:   awsKMSServiceName,
+			ServiceID:     "KMS",
+			SigningName:   cfg.SigningName,
+			SigningRegion: cfg.SigningRegion,
+			PartitionID:   cfg.PartitionID,
+			Endpoint:      cfg.Endpoint,
+			APIVersion:    "2014-11-01",
+			JSONVersion:   "1.1",
+			TargetPrefix:  "TrentService",
+		},
+		cfg.Handlers,
+	)
+	c.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	c.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	c.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	c.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	c.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return &awsKMSProvider{client: c, keyID: keyID}, nil
+}
+
+func (p *awsKMSProvider) Name() string {
+	return AWSKMSProviderName
+}
+
+func (p *awsKMSProvider) EncryptKey(key []byte) ([]byte, error) {
+	output := &awsKMSEncryptOutput{}
+	if err := p.send("Encrypt", &awsKMSEncryptInput{KeyId: aws.String(p.keyID), Plaintext: key}, output); err != nil {
+		return nil, err
+	}
+	return output.CiphertextBlob, nil
+}
+
+func (p *awsKMSProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	output := &awsKMSDecryptOutput{}
+	if err := p.send("Decrypt", &awsKMSDecryptInput{CiphertextBlob: encryptedKey, KeyId: aws.String(p.keyID)}, output); err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
+
+func (p *awsKMSProvider) send(operation string, input, output interface{}) error {
+	op := &request.Operation{
+		Name:       operation,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	return p.client.NewRequest(op, input, output).Send()
+}
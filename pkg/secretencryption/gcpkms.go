@@ -0,0 +1,100 @@
+package secretencryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// GCPKMSProviderName is the name of the GCP Cloud KMS provider.
+	GCPKMSProviderName = "gcp-kms"
+
+	gcpKMSEndpoint = "https://cloudkms.googleapis.com/v1/"
+	gcpKMSScope    = "https://www.googleapis.com/auth/cloudkms"
+)
+
+type gcpKMSProvider struct {
+	httpClient *http.Client
+	endpoint   string
+	keyName    string
+}
+
+// NewGCPKMSProvider returns a provider that encrypts data keys with the GCP Cloud KMS key. The credentials secret
+// must have the osServiceAccount.json key.
+func NewGCPKMSProvider(credsSecret *corev1.Secret, keyName string) (Provider, error) {
+	authJSON, ok := credsSecret.Data[constants.GCPCredentialsName]
+	if !ok {
+		return nil, errors.Errorf("GCP credentials secret %v did not contain key %v", credsSecret.Name, constants.GCPCredentialsName)
+	}
+	creds, err := google.CredentialsFromJSON(context.Background(), authJSON, gcpKMSScope)
+	if err != nil {
+		return nil, err
+	}
+	return &gcpKMSProvider{
+		httpClient: oauth2.NewClient(context.Background(), creds.TokenSource),
+		endpoint:   gcpKMSEndpoint,
+		keyName:    keyName,
+	}, nil
+}
+
+func (p *gcpKMSProvider) Name() string {
+	return GCPKMSProviderName
+}
+
+// The Cloud KMS client library is not vendored, so the REST API is called directly. Binary fields are base64
+// encoded in the JSON bodies.
+func (p *gcpKMSProvider) EncryptKey(key []byte) ([]byte, error) {
+	output := struct {
+		Ciphertext string `json:"ciphertext"`
+	}{}
+	input := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(key)}
+	if err := p.post("encrypt", input, &output); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(output.Ciphertext)
+}
+
+func (p *gcpKMSProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	output := struct {
+		Plaintext string `json:"plaintext"`
+	}{}
+	input := map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(encryptedKey)}
+	if err := p.post("decrypt", input, &output); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(output.Plaintext)
+}
+
+func (p *gcpKMSProvider) post(method string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s%s:%s", p.endpoint, p.keyName, method)
+	resp, err := p.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "could not call Cloud KMS %s", method)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "could not read Cloud KMS %s response", method)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Cloud KMS %s failed with status %d: %s", method, resp.StatusCode, respBody)
+	}
+	return json.Unmarshal(respBody, output)
+}
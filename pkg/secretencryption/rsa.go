@@ -0,0 +1,123 @@
+package secretencryption
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// RSAProviderName identifies the secrets whose data key is encrypted with an RSA public key, which is done by the
+	// pods which have no access to the key management service.
+	RSAProviderName = "rsa-oaep"
+
+	rsaKeySize = 3072
+)
+
+// rsaProvider encrypts data keys with an RSA public key, and decrypts them with the private key when it has it. It
+// lets pods without access to the key management service encrypt secrets that only the controllers can decrypt.
+type rsaProvider struct {
+	publicKey  *rsa.PublicKey
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSAPublicKeyProvider returns a provider which encrypts data keys with the PEM encoded RSA public key. It cannot
+// decrypt them.
+func NewRSAPublicKeyProvider(publicKeyPEM []byte) (Provider, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse public key")
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an RSA key")
+	}
+	return &rsaProvider{publicKey: publicKey}, nil
+}
+
+// NewRSAProvider returns a provider which encrypts and decrypts data keys with the PEM encoded RSA private key.
+func NewRSAProvider(privateKeyPEM []byte) (Provider, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key")
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse private key")
+	}
+	return &rsaProvider{publicKey: &privateKey.PublicKey, privateKey: privateKey}, nil
+}
+
+// GenerateRSAKey returns a new PEM encoded RSA private key.
+func GenerateRSAKey() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate RSA key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), nil
+}
+
+// RSAPublicKeyPEM returns the PEM encoded public key of the provider returned by NewRSAProvider.
+func RSAPublicKeyPEM(p Provider) ([]byte, error) {
+	rp, ok := p.(*rsaProvider)
+	if !ok {
+		return nil, errors.Errorf("%s is not an RSA provider", p.Name())
+	}
+	der, err := x509.MarshalPKIXPublicKey(rp.publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal public key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func (p *rsaProvider) Name() string {
+	return RSAProviderName
+}
+
+func (p *rsaProvider) EncryptKey(key []byte) ([]byte, error) {
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, p.publicKey, key, nil)
+}
+
+func (p *rsaProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	if p.privateKey == nil {
+		return nil, errors.New("no private key to decrypt with")
+	}
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, p.privateKey, encryptedKey, nil)
+}
+
+// Rewrap encrypts the data key of a secret encrypted by one provider with another provider, in place. The data of the
+// secret, which is encrypted with the data key, is left as it is.
+func Rewrap(from, to Provider, secret *corev1.Secret) error {
+	if name := secret.Annotations[constants.EncryptionProviderAnnotation]; name != from.Name() {
+		return errors.Errorf("secret was encrypted with %s, not %s", name, from.Name())
+	}
+	encryptedKey, err := base64.StdEncoding.DecodeString(secret.Annotations[constants.EncryptedDataKeyAnnotation])
+	if err != nil {
+		return errors.Wrap(err, "could not decode encrypted data key")
+	}
+	key, err := from.DecryptKey(encryptedKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not decrypt data key with %s", from.Name())
+	}
+	rewrapped, err := to.EncryptKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "could not encrypt data key with %s", to.Name())
+	}
+	cacheDataKey(rewrapped, key)
+	secret.Annotations[constants.EncryptionProviderAnnotation] = to.Name()
+	secret.Annotations[constants.EncryptedDataKeyAnnotation] = base64.StdEncoding.EncodeToString(rewrapped)
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package secretencryption implements envelope encryption of the data of secrets. The data of a secret is encrypted
+// with a random data key using AES-GCM, and the data key is encrypted with a key held by a key management service.
+// The encrypted data key is stored in an annotation of the secret.
+package secretencryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	dataKeySize = 32
+
+	// maxCachedDataKeys bounds the number of decrypted data keys kept in memory.
+	maxCachedDataKeys = 10000
+)
+
+// Provider encrypts and decrypts data keys with a key management service.
+type Provider interface {
+	// Name identifies the provider in the annotations of the secrets it encrypted.
+	Name() string
+
+	// EncryptKey encrypts a data key.
+	EncryptKey(key []byte) ([]byte, error)
+
+	// DecryptKey decrypts a data key encrypted by EncryptKey.
+	DecryptKey(encryptedKey []byte) ([]byte, error)
+}
+
+// dataKeys caches the decrypted data keys by encrypted data key so that the key management service is not called
+// every time a client for a remote cluster is built.
+var dataKeys = struct {
+	sync.Mutex
+	keys map[string][]byte
+}{keys: map[string][]byte{}}
+
+func cacheDataKey(encryptedKey, key []byte) {
+	dataKeys.Lock()
+	defer dataKeys.Unlock()
+	if len(dataKeys.keys) >= maxCachedDataKeys {
+		dataKeys.keys = map[string][]byte{}
+	}
+	dataKeys.keys[string(encryptedKey)] = key
+}
+
+func cachedDataKey(encryptedKey []byte) ([]byte, bool) {
+	dataKeys.Lock()
+	defer dataKeys.Unlock()
+	key, ok := dataKeys.keys[string(encryptedKey)]
+	return key, ok
+}
+
+// IsEncrypted returns true if the data of the secret is encrypted.
+func IsEncrypted(secret *corev1.Secret) bool {
+	_, ok := secret.Annotations[constants.EncryptionProviderAnnotation]
+	return ok
+}
+
+// Encrypt encrypts the data of the secret in place with a new data key, and records the data key, encrypted by
+// the provider, in the annotations of the secret.
+func Encrypt(p Provider, secret *corev1.Secret) error {
+	if IsEncrypted(secret) {
+		return errors.New("secret is already encrypted")
+	}
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return errors.Wrap(err, "could not generate data key")
+	}
+	encryptedKey, err := p.EncryptKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "could not encrypt data key with %s", p.Name())
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	encryptedData := make(map[string][]byte, len(secret.Data))
+	for name, value := range secret.Data {
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return errors.Wrap(err, "could not generate nonce")
+		}
+		// The name of the data is authenticated so that values cannot be swapped between names.
+		encryptedData[name] = gcm.Seal(nonce, nonce, value, []byte(name))
+	}
+	cacheDataKey(encryptedKey, key)
+	secret.Data = encryptedData
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constants.EncryptionProviderAnnotation] = p.Name()
+	secret.Annotations[constants.EncryptedDataKeyAnnotation] = base64.StdEncoding.EncodeToString(encryptedKey)
+	return nil
+}
+
+// Decrypt returns the decrypted data of an encrypted secret. The secret is not modified.
+func Decrypt(p Provider, secret *corev1.Secret) (map[string][]byte, error) {
+	if name := secret.Annotations[constants.EncryptionProviderAnnotation]; name != p.Name() {
+		return nil, errors.Errorf("secret was encrypted with %s but the configured provider is %s", name, p.Name())
+	}
+	encryptedKey, err := base64.StdEncoding.DecodeString(secret.Annotations[constants.EncryptedDataKeyAnnotation])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode encrypted data key")
+	}
+	key, ok := cachedDataKey(encryptedKey)
+	if !ok {
+		key, err = p.DecryptKey(encryptedKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt data key with %s", p.Name())
+		}
+		cacheDataKey(encryptedKey, key)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(secret.Data))
+	for name, value := range secret.Data {
+		if len(value) < gcm.NonceSize() {
+			return nil, errors.Errorf("encrypted %q data is too short", name)
+		}
+		nonce, ciphertext := value[:gcm.NonceSize()], value[gcm.NonceSize():]
+		data[name], err = gcm.Open(nil, nonce, ciphertext, []byte(name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt %q data", name)
+		}
+	}
+	return data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// DecryptedData returns the data of the secret, decrypting it with the provider configured for the controllers
+// if the secret is encrypted.
+func DecryptedData(c client.Client, secret *corev1.Secret) (map[string][]byte, error) {
+	if !IsEncrypted(secret) {
+		return secret.Data, nil
+	}
+	p, err := ProviderFromEnv(c)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, errors.Errorf("secret %s/%s is encrypted but secret encryption is not configured", secret.Namespace, secret.Name)
+	}
+	return Decrypt(p, secret)
+}
+
+// providers caches the provider configured for the controllers, so that a client for the key management service is
+// only built again when the configuration or its credentials change.
+var providers = struct {
+	sync.Mutex
+	configJSON         string
+	credentialsVersion string
+	provider           Provider
+}{}
+
+// ProviderFromEnv returns the provider configured for the controllers by the operator, or nil if secret
+// encryption is not configured.
+func ProviderFromEnv(c client.Client) (Provider, error) {
+	configJSON, ok := os.LookupEnv(constants.SecretEncryptionEnvVar)
+	if !ok || configJSON == "" {
+		return nil, nil
+	}
+	config := &hivev1.SecretEncryptionConfig{}
+	if err := json.Unmarshal([]byte(configJSON), config); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s", constants.SecretEncryptionEnvVar)
+	}
+	credsSecret, err := credentialsSecret(c, controllerutils.GetHiveNamespace(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	providers.Lock()
+	defer providers.Unlock()
+	if providers.provider != nil && providers.configJSON == configJSON && providers.credentialsVersion == credsSecret.ResourceVersion {
+		return providers.provider, nil
+	}
+	p, err := newProvider(credsSecret, config)
+	if err != nil {
+		return nil, err
+	}
+	providers.configJSON = configJSON
+	providers.credentialsVersion = credsSecret.ResourceVersion
+	providers.provider = p
+	return p, nil
+}
+
+// NewProvider returns the provider for the secret encryption configuration. The credentials of the key management
+// service are read from the Hive namespace.
+func NewProvider(c client.Client, hiveNamespace string, config *hivev1.SecretEncryptionConfig) (Provider, error) {
+	credsSecret, err := credentialsSecret(c, hiveNamespace, config)
+	if err != nil {
+		return nil, err
+	}
+	return newProvider(credsSecret, config)
+}
+
+func newProvider(credsSecret *corev1.Secret, config *hivev1.SecretEncryptionConfig) (Provider, error) {
+	switch {
+	case config.AWS != nil:
+		return NewAWSKMSProvider(credsSecret, config.AWS.Region, config.AWS.KeyID)
+	default:
+		return NewGCPKMSProvider(credsSecret, config.GCP.KeyName)
+	}
+}
+
+// credentialsSecret returns the credentials secret of the key management service of the configuration.
+func credentialsSecret(c client.Client, hiveNamespace string, config *hivev1.SecretEncryptionConfig) (*corev1.Secret, error) {
+	var name string
+	switch {
+	case config.AWS != nil && config.GCP != nil:
+		return nil, errors.New("only one secret encryption provider may be configured")
+	case config.AWS != nil:
+		name = config.AWS.CredentialsSecretRef.Name
+	case config.GCP != nil:
+		name = config.GCP.CredentialsSecretRef.Name
+	default:
+		return nil, errors.New("no secret encryption provider is configured")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: hiveNamespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get secret encryption credentials")
+	}
+	return secret, nil
+}
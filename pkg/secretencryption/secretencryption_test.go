@@ -0,0 +1,250 @@
+package secretencryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// fakeProvider "encrypts" data keys by reversing them and counts the decryptions.
+type fakeProvider struct {
+	name        string
+	decryptions int
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) EncryptKey(key []byte) ([]byte, error) {
+	return reverse(key), nil
+}
+
+func (p *fakeProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	p.decryptions++
+	return reverse(encryptedKey), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func testSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+			Name:      "test-admin-kubeconfig",
+		},
+		Data: map[string][]byte{
+			"kubeconfig":     []byte("test-kubeconfig"),
+			"raw-kubeconfig": []byte("test-raw-kubeconfig"),
+		},
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	p := &fakeProvider{name: "fake"}
+	secret := testSecret()
+	require.NoError(t, Encrypt(p, secret), "unexpected error encrypting secret")
+
+	assert.True(t, IsEncrypted(secret), "expected secret to be encrypted")
+	assert.Equal(t, "fake", secret.Annotations[constants.EncryptionProviderAnnotation], "unexpected provider annotation")
+	for name, value := range secret.Data {
+		assert.NotContains(t, string(value), "kubeconfig", "expected %q data to be encrypted", name)
+	}
+	assert.Error(t, Encrypt(p, secret), "expected error encrypting an encrypted secret")
+
+	data, err := Decrypt(p, secret)
+	require.NoError(t, err, "unexpected error decrypting secret")
+	assert.Equal(t, testSecret().Data, data, "unexpected decrypted data")
+	assert.Zero(t, p.decryptions, "expected the data key of a secret encrypted by this process to be cached")
+
+	// Swapping encrypted values between names must be detected.
+	secret.Data["kubeconfig"], secret.Data["raw-kubeconfig"] = secret.Data["raw-kubeconfig"], secret.Data["kubeconfig"]
+	_, err = Decrypt(p, secret)
+	assert.Error(t, err, "expected error decrypting swapped data")
+}
+
+func TestDecryptUncachedKey(t *testing.T) {
+	p := &fakeProvider{name: "fake"}
+	secret := testSecret()
+	require.NoError(t, Encrypt(p, secret), "unexpected error encrypting secret")
+	dataKeys.Lock()
+	dataKeys.keys = map[string][]byte{}
+	dataKeys.Unlock()
+
+	for i := 0; i < 2; i++ {
+		data, err := Decrypt(p, secret)
+		require.NoError(t, err, "unexpected error decrypting secret")
+		assert.Equal(t, testSecret().Data, data, "unexpected decrypted data")
+	}
+	assert.Equal(t, 1, p.decryptions, "expected the data key to be decrypted once")
+}
+
+func TestDecryptWrongProvider(t *testing.T) {
+	secret := testSecret()
+	require.NoError(t, Encrypt(&fakeProvider{name: "fake"}, secret), "unexpected error encrypting secret")
+	_, err := Decrypt(&fakeProvider{name: "other"}, secret)
+	assert.Error(t, err, "expected error decrypting with another provider")
+}
+
+func TestDecryptedData(t *testing.T) {
+	os.Unsetenv(constants.SecretEncryptionEnvVar)
+	fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme)
+
+	data, err := DecryptedData(fakeClient, testSecret())
+	require.NoError(t, err, "unexpected error for plain text secret")
+	assert.Equal(t, testSecret().Data, data, "unexpected data for plain text secret")
+
+	secret := testSecret()
+	require.NoError(t, Encrypt(&fakeProvider{name: "fake"}, secret), "unexpected error encrypting secret")
+	_, err = DecryptedData(fakeClient, secret)
+	assert.Error(t, err, "expected error for encrypted secret without secret encryption configured")
+}
+
+func TestProviderFromEnvCached(t *testing.T) {
+	os.Setenv(constants.SecretEncryptionEnvVar, `{"aws":{"credentialsSecretRef":{"name":"kms-creds"},"region":"us-east-1","keyID":"test-key"}}`)
+	defer os.Unsetenv(constants.SecretEncryptionEnvVar)
+	credsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.DefaultHiveNamespace,
+			Name:      "kms-creds",
+		},
+		Data: map[string][]byte{
+			constants.AWSAccessKeyIDSecretKey:     []byte("test-access-key-id"),
+			constants.AWSSecretAccessKeySecretKey: []byte("test-secret-access-key"),
+		},
+	}
+	fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, credsSecret)
+
+	p, err := ProviderFromEnv(fakeClient)
+	require.NoError(t, err, "unexpected error getting provider")
+	cached, err := ProviderFromEnv(fakeClient)
+	require.NoError(t, err, "unexpected error getting cached provider")
+	assert.True(t, p == cached, "expected the provider to be cached")
+
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: credsSecret.Namespace, Name: credsSecret.Name}, credsSecret))
+	credsSecret.Data[constants.AWSSecretAccessKeySecretKey] = []byte("rotated-secret-access-key")
+	require.NoError(t, fakeClient.Update(context.Background(), credsSecret))
+	rotated, err := ProviderFromEnv(fakeClient)
+	require.NoError(t, err, "unexpected error getting provider after credentials rotation")
+	assert.False(t, p == rotated, "expected a new provider after credentials rotation")
+}
+
+func TestAWSKMSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err, "could not read request body")
+		input := map[string]string{}
+		require.NoError(t, json.Unmarshal(body, &input), "could not unmarshal request body")
+		assert.Equal(t, "test-key", input["KeyId"], "unexpected key ID")
+		assert.NotEmpty(t, r.Header.Get("Authorization"), "expected request to be signed")
+		var output map[string]string
+		switch target := r.Header.Get("X-Amz-Target"); target {
+		case "TrentService.Encrypt":
+			output = map[string]string{"CiphertextBlob": base64.StdEncoding.EncodeToString(append([]byte("encrypted-"), decode(t, input["Plaintext"])...))}
+		case "TrentService.Decrypt":
+			output = map[string]string{"Plaintext": base64.StdEncoding.EncodeToString(bytes.TrimPrefix(decode(t, input["CiphertextBlob"]), []byte("encrypted-")))}
+		default:
+			t.Errorf("unexpected target %q", target)
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		json.NewEncoder(w).Encode(output)
+	}))
+	defer server.Close()
+
+	credsSecret := &corev1.Secret{Data: map[string][]byte{
+		constants.AWSAccessKeyIDSecretKey:     []byte("test-access-key-id"),
+		constants.AWSSecretAccessKeySecretKey: []byte("test-secret-access-key"),
+	}}
+	p, err := newAWSKMSProvider(credsSecret, "us-east-1", "test-key", server.URL)
+	require.NoError(t, err, "unexpected error creating provider")
+	testProvider(t, p)
+}
+
+func TestGCPKMSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input), "could not decode request body")
+		var output map[string]string
+		switch r.URL.Path {
+		case "/projects/p/locations/l/keyRings/r/cryptoKeys/k:encrypt":
+			output = map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(append([]byte("encrypted-"), decode(t, input["plaintext"])...))}
+		case "/projects/p/locations/l/keyRings/r/cryptoKeys/k:decrypt":
+			output = map[string]string{"plaintext": base64.StdEncoding.EncodeToString(bytes.TrimPrefix(decode(t, input["ciphertext"]), []byte("encrypted-")))}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(output)
+	}))
+	defer server.Close()
+
+	p := &gcpKMSProvider{
+		httpClient: server.Client(),
+		endpoint:   server.URL + "/",
+		keyName:    "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+	}
+	testProvider(t, p)
+}
+
+func testProvider(t *testing.T, p Provider) {
+	key := []byte("test-data-key")
+	encryptedKey, err := p.EncryptKey(key)
+	require.NoError(t, err, "unexpected error encrypting key")
+	assert.Equal(t, "encrypted-test-data-key", string(encryptedKey), "unexpected encrypted key")
+	decryptedKey, err := p.DecryptKey(encryptedKey)
+	require.NoError(t, err, "unexpected error decrypting key")
+	assert.Equal(t, key, decryptedKey, "unexpected decrypted key")
+}
+
+func decode(t *testing.T, s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(t, err, "could not decode base64")
+	return b
+}
+
+func TestRSARewrap(t *testing.T) {
+	privateKey, err := GenerateRSAKey()
+	require.NoError(t, err, "unexpected error generating key")
+	keyProvider, err := NewRSAProvider(privateKey)
+	require.NoError(t, err, "unexpected error parsing key")
+	publicKey, err := RSAPublicKeyPEM(keyProvider)
+	require.NoError(t, err, "unexpected error encoding public key")
+	publicKeyProvider, err := NewRSAPublicKeyProvider(publicKey)
+	require.NoError(t, err, "unexpected error parsing public key")
+
+	secret := testSecret()
+	require.NoError(t, Encrypt(publicKeyProvider, secret), "unexpected error encrypting secret")
+	_, err = publicKeyProvider.DecryptKey([]byte("key"))
+	assert.Error(t, err, "expected public key provider not to decrypt")
+
+	kms := &fakeProvider{name: "fake"}
+	assert.Error(t, Rewrap(kms, keyProvider, secret), "expected error rewrapping with the wrong provider")
+	require.NoError(t, Rewrap(keyProvider, kms, secret), "unexpected error rewrapping secret")
+	assert.Equal(t, "fake", secret.Annotations[constants.EncryptionProviderAnnotation], "unexpected provider annotation")
+	data, err := Decrypt(kms, secret)
+	require.NoError(t, err, "unexpected error decrypting secret")
+	assert.Equal(t, testSecret().Data, data, "unexpected decrypted data")
+}
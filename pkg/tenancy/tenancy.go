@@ -0,0 +1,240 @@
+package tenancy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installeraws "github.com/openshift/installer/pkg/types/aws"
+	installerazure "github.com/openshift/installer/pkg/types/azure"
+	installergcp "github.com/openshift/installer/pkg/types/gcp"
+	installeropenstack "github.com/openshift/installer/pkg/types/openstack"
+	installerovirt "github.com/openshift/installer/pkg/types/ovirt"
+	installervsphere "github.com/openshift/installer/pkg/types/vsphere"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	installConfigSecretKey = "install-config.yaml"
+
+	// defaultControlPlaneReplicas is the number of control plane machines of the installer.
+	defaultControlPlaneReplicas = 3
+)
+
+// defaultControlPlaneMachines are the control plane machines used by the installer when the install-config does not
+// set them.
+var defaultControlPlaneMachines = installertypes.MachinePoolPlatform{
+	AWS:     &installeraws.MachinePool{InstanceType: "m5.xlarge"},
+	Azure:   &installerazure.MachinePool{InstanceType: "Standard_D8s_v3"},
+	GCP:     &installergcp.MachinePool{InstanceType: "n1-standard-4"},
+	VSphere: &installervsphere.MachinePool{NumCPUs: 4},
+	Ovirt:   &installerovirt.MachinePool{CPU: &installerovirt.CPU{Sockets: 1, Cores: 4}},
+}
+
+// Usage is the usage of the quotas of a HiveTenant by the Hive resources in a namespace.
+type Usage struct {
+	// ClusterDeployments is the number of ClusterDeployments in the namespace.
+	ClusterDeployments int32
+	// ConcurrentInstalls is the number of ClusterDeployments in the namespace that are installing.
+	ConcurrentInstalls int32
+	// VCPUs is the total number of vCPUs of the machines of the MachinePools in the namespace and of the control plane
+	// machines of the ClusterDeployments.
+	VCPUs int32
+	// UnknownInstanceTypes are the instance types of the machines whose vCPUs could not be determined.
+	UnknownInstanceTypes []string
+}
+
+// GetUsage returns the usage of the quotas by the ClusterDeployments and MachinePools in the namespace. The
+// instanceTypeVCPUs are the vCPUs of the cloud instance types, as configured in the HiveTenant. The control plane
+// machines of the ClusterDeployments are read from their install-config secrets.
+func GetUsage(c client.Reader, namespace string, instanceTypeVCPUs map[string]int32) (*Usage, error) {
+	usage := &Usage{}
+	unknownInstanceTypes := map[string]bool{}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cds, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		usage.ClusterDeployments++
+		if IsInstalling(cd) {
+			usage.ConcurrentInstalls++
+		}
+		vcpus, unknownInstanceType, err := clusterDeploymentControlPlaneVCPUs(c, cd, instanceTypeVCPUs)
+		if err != nil {
+			return nil, err
+		}
+		if unknownInstanceType != "" {
+			unknownInstanceTypes[unknownInstanceType] = true
+			continue
+		}
+		usage.VCPUs += vcpus
+	}
+	pools := &hivev1.MachinePoolList{}
+	if err := c.List(context.Background(), pools, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "could not list MachinePools")
+	}
+	for i := range pools.Items {
+		vcpus, unknownInstanceType := MachinePoolVCPUs(&pools.Items[i], instanceTypeVCPUs)
+		if unknownInstanceType != "" {
+			unknownInstanceTypes[unknownInstanceType] = true
+			continue
+		}
+		usage.VCPUs += vcpus
+	}
+	for instanceType := range unknownInstanceTypes {
+		usage.UnknownInstanceTypes = append(usage.UnknownInstanceTypes, instanceType)
+	}
+	sort.Strings(usage.UnknownInstanceTypes)
+	return usage, nil
+}
+
+// IsInstalling returns true if the ClusterDeployment counts towards the concurrent installs of its namespace.
+func IsInstalling(cd *hivev1.ClusterDeployment) bool {
+	return !cd.Spec.Installed && cd.DeletionTimestamp == nil
+}
+
+// clusterDeploymentControlPlaneVCPUs returns the vCPUs of the control plane machines of the ClusterDeployment. Only
+// the ClusterDeployments with an install-config are counted, as the control plane of adopted clusters is not known.
+func clusterDeploymentControlPlaneVCPUs(c client.Reader, cd *hivev1.ClusterDeployment, instanceTypeVCPUs map[string]int32) (int32, string, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef.Name == "" {
+		return 0, "", nil
+	}
+	secret := &corev1.Secret{}
+	switch err := c.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); {
+	case apierrors.IsNotFound(err):
+		return 0, "", nil
+	case err != nil:
+		return 0, "", errors.Wrap(err, "could not get install-config secret")
+	}
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretKey], installConfig); err != nil {
+		return 0, "", errors.Wrapf(err, "could not unmarshal install-config of ClusterDeployment %s", cd.Name)
+	}
+	// The control plane replicas of the ClusterDeployment are set in the install-config when it does not set them.
+	if replicas := cd.Spec.Provisioning.ControlPlaneReplicas; replicas != nil {
+		if installConfig.ControlPlane == nil {
+			installConfig.ControlPlane = &installertypes.MachinePool{Name: "master"}
+		}
+		if installConfig.ControlPlane.Replicas == nil {
+			installConfig.ControlPlane.Replicas = replicas
+		}
+	}
+	vcpus, unknownInstanceType := ControlPlaneVCPUs(installConfig, instanceTypeVCPUs)
+	return vcpus, unknownInstanceType, nil
+}
+
+// ControlPlaneVCPUs returns the total number of vCPUs of the control plane machines of the install-config. The
+// machines are merged from the defaults of the installer, the default machine platform of the install-config, and
+// the control plane pool, the same way as the installer does. If the vCPUs of the instance type of the control plane
+// are not known, the instance type is returned instead.
+func ControlPlaneVCPUs(installConfig *installertypes.InstallConfig, instanceTypeVCPUs map[string]int32) (vcpus int32, unknownInstanceType string) {
+	replicas := int32(defaultControlPlaneReplicas)
+	var pool installertypes.MachinePoolPlatform
+	if cp := installConfig.ControlPlane; cp != nil {
+		if cp.Replicas != nil {
+			replicas = int32(*cp.Replicas)
+		}
+		pool = cp.Platform
+	}
+
+	var instanceType string
+	switch p := installConfig.Platform; {
+	case p.AWS != nil:
+		machine := *defaultControlPlaneMachines.AWS
+		machine.Set(p.AWS.DefaultMachinePlatform)
+		machine.Set(pool.AWS)
+		instanceType = machine.InstanceType
+	case p.Azure != nil:
+		machine := *defaultControlPlaneMachines.Azure
+		machine.Set(p.Azure.DefaultMachinePlatform)
+		machine.Set(pool.Azure)
+		instanceType = machine.InstanceType
+	case p.GCP != nil:
+		machine := *defaultControlPlaneMachines.GCP
+		machine.Set(p.GCP.DefaultMachinePlatform)
+		machine.Set(pool.GCP)
+		instanceType = machine.InstanceType
+	case p.OpenStack != nil:
+		machine := installeropenstack.MachinePool{FlavorName: p.OpenStack.FlavorName}
+		machine.Set(p.OpenStack.DefaultMachinePlatform)
+		machine.Set(pool.OpenStack)
+		instanceType = machine.FlavorName
+	case p.VSphere != nil:
+		machine := *defaultControlPlaneMachines.VSphere
+		machine.Set(p.VSphere.DefaultMachinePlatform)
+		machine.Set(pool.VSphere)
+		return machine.NumCPUs * replicas, ""
+	case p.Ovirt != nil:
+		machine := *defaultControlPlaneMachines.Ovirt
+		machine.Set(p.Ovirt.DefaultMachinePlatform)
+		machine.Set(pool.Ovirt)
+		return machine.CPU.Sockets * machine.CPU.Cores * replicas, ""
+	default:
+		return 0, ""
+	}
+	perMachine, ok := instanceTypeVCPUs[instanceType]
+	if !ok {
+		return 0, instanceType
+	}
+	return perMachine * replicas, ""
+}
+
+// MachinePoolVCPUs returns the total number of vCPUs of the machines of the MachinePool. If the vCPUs of the
+// instance type of the MachinePool are not known, the instance type is returned instead.
+func MachinePoolVCPUs(pool *hivev1.MachinePool, instanceTypeVCPUs map[string]int32) (vcpus int32, unknownInstanceType string) {
+	perMachine, unknownInstanceType := machineVCPUs(pool.Spec.Platform, instanceTypeVCPUs)
+	if unknownInstanceType != "" {
+		return 0, unknownInstanceType
+	}
+	return perMachine * MachinePoolMachines(pool), ""
+}
+
+// MachinePoolMachines returns the maximum number of machines in the MachinePool.
+func MachinePoolMachines(pool *hivev1.MachinePool) int32 {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return pool.Spec.Autoscaling.MaxReplicas
+	case pool.Spec.Replicas != nil:
+		return int32(*pool.Spec.Replicas)
+	default:
+		return 0
+	}
+}
+
+func machineVCPUs(platform hivev1.MachinePoolPlatform, instanceTypeVCPUs map[string]int32) (int32, string) {
+	var instanceType string
+	switch {
+	case platform.AWS != nil:
+		instanceType = platform.AWS.InstanceType
+	case platform.Azure != nil:
+		instanceType = platform.Azure.InstanceType
+	case platform.GCP != nil:
+		instanceType = platform.GCP.InstanceType
+	case platform.OpenStack != nil:
+		instanceType = platform.OpenStack.Flavor
+	case platform.VSphere != nil:
+		return platform.VSphere.NumCPUs, ""
+	case platform.Ovirt != nil:
+		if platform.Ovirt.CPU == nil {
+			return 0, ""
+		}
+		return platform.Ovirt.CPU.Sockets * platform.Ovirt.CPU.Cores, ""
+	default:
+		return 0, ""
+	}
+	vcpus, ok := instanceTypeVCPUs[instanceType]
+	if !ok {
+		return 0, instanceType
+	}
+	return vcpus, ""
+}
@@ -110,6 +110,12 @@ func WithBaseDomain(baseDomain string) Option {
 	}
 }
 
+func WithManageDNS() Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ManageDNS = true
+	}
+}
+
 func WithImageSet(clusterImageSetName string) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.ImageSetRef = hivev1.ClusterImageSetReference{Name: clusterImageSetName}
@@ -0,0 +1,261 @@
+// Package lifecycle provides a harness running the Hive controllers against the API server of envtest, so that the
+// lifecycle of ClusterDeployments can be tested without cloud access. There are no job controller, installer,
+// uninstaller or spoke clusters in envtest: the harness plays their part, completing the jobs, provisions and
+// deprovisions of the controllers when asked to.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/imageset"
+)
+
+const (
+	// DefaultTimeout is how long the harness waits for the controllers to reach the next step of the lifecycle.
+	DefaultTimeout = 30 * time.Second
+
+	pollInterval = 250 * time.Millisecond
+)
+
+// Harness runs Hive controllers against the API server of envtest.
+type Harness struct {
+	// Client is a client of the API server, which does not go through the cache of the controllers.
+	Client client.Client
+	// Config is the configuration of the API server.
+	Config *rest.Config
+	// Timeout is how long the harness waits for the controllers to reach the next step of the lifecycle.
+	Timeout time.Duration
+
+	env    *envtest.Environment
+	scheme *runtime.Scheme
+	stop   chan struct{}
+}
+
+// Start starts an API server serving the CRDs of the given directories, typically the config/crds directory of Hive.
+func Start(crdDirectoryPaths ...string) (*Harness, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     crdDirectoryPaths,
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		env.Stop()
+		return nil, err
+	}
+	return &Harness{
+		Client:  c,
+		Config:  cfg,
+		Timeout: DefaultTimeout,
+		env:     env,
+		scheme:  scheme,
+	}, nil
+}
+
+// StartControllers starts a manager running the controllers added by the given functions, such as the Add functions
+// of the controller packages.
+func (h *Harness) StartControllers(adders ...func(manager.Manager) error) error {
+	if h.stop != nil {
+		return fmt.Errorf("controllers already started")
+	}
+	mgr, err := manager.New(h.Config, manager.Options{
+		Scheme:             h.scheme,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		return err
+	}
+	for _, add := range adders {
+		if err := add(mgr); err != nil {
+			return err
+		}
+	}
+	h.stop = make(chan struct{})
+	go mgr.Start(h.stop)
+	return nil
+}
+
+// Stop stops the controllers and the API server.
+func (h *Harness) Stop() error {
+	if h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+	return h.env.Stop()
+}
+
+// ResolveImages plays the part of the imageset job of the ClusterDeployment, resolving the installer and CLI images
+// of its release.
+func (h *Harness) ResolveImages(cdKey types.NamespacedName, installerImage, cliImage string) error {
+	job := &batchv1.Job{}
+	jobKey := types.NamespacedName{Namespace: cdKey.Namespace, Name: imageset.GetImageSetJobName(cdKey.Name)}
+	if err := h.waitFor(fmt.Sprintf("imageset job %s", jobKey), func() (bool, error) {
+		return h.get(jobKey, job)
+	}); err != nil {
+		return err
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := h.Client.Get(context.TODO(), cdKey, cd); err != nil {
+		return err
+	}
+	cd.Status.InstallerImage = pointer.StringPtr(installerImage)
+	cd.Status.CLIImage = pointer.StringPtr(cliImage)
+	if err := h.Client.Status().Update(context.TODO(), cd); err != nil {
+		return err
+	}
+	return h.completeJob(job)
+}
+
+// CompleteProvision plays the part of the installer, completing the current provision of the ClusterDeployment with
+// the given infra and cluster IDs. The admin kubeconfig of the cluster points to the API server of envtest.
+func (h *Harness) CompleteProvision(cdKey types.NamespacedName, infraID, clusterID string) error {
+	kubeconfig, err := h.AdminKubeconfig()
+	if err != nil {
+		return err
+	}
+	return h.CompleteProvisionWithKubeconfig(cdKey, infraID, clusterID, kubeconfig)
+}
+
+// CompleteProvisionWithKubeconfig completes the current provision of the ClusterDeployment like CompleteProvision,
+// with the given admin kubeconfig, such as the one of a simulated spoke cluster.
+func (h *Harness) CompleteProvisionWithKubeconfig(cdKey types.NamespacedName, infraID, clusterID string, kubeconfig []byte) error {
+	cd := &hivev1.ClusterDeployment{}
+	if err := h.waitFor(fmt.Sprintf("provision of ClusterDeployment %s", cdKey), func() (bool, error) {
+		found, err := h.get(cdKey, cd)
+		return found && cd.Status.ProvisionRef != nil, err
+	}); err != nil {
+		return err
+	}
+
+	provision := &hivev1.ClusterProvision{}
+	if err := h.Client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Status.ProvisionRef.Name}, provision); err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      provision.Name + "-admin-kubeconfig",
+		},
+		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+	if err := h.Client.Create(context.TODO(), secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	provision.Spec.InfraID = pointer.StringPtr(infraID)
+	provision.Spec.ClusterID = pointer.StringPtr(clusterID)
+	provision.Spec.AdminKubeconfigSecretRef = &corev1.LocalObjectReference{Name: secret.Name}
+	provision.Spec.Stage = hivev1.ClusterProvisionStageComplete
+	return h.Client.Update(context.TODO(), provision)
+}
+
+// CompleteDeprovision plays the part of the uninstaller, completing the ClusterDeprovision of the ClusterDeployment.
+func (h *Harness) CompleteDeprovision(cdKey types.NamespacedName) error {
+	deprovision := &hivev1.ClusterDeprovision{}
+	if err := h.waitFor(fmt.Sprintf("ClusterDeprovision %s", cdKey), func() (bool, error) {
+		return h.get(cdKey, deprovision)
+	}); err != nil {
+		return err
+	}
+	deprovision.Status.Completed = true
+	return h.Client.Status().Update(context.TODO(), deprovision)
+}
+
+// WaitForClusterDeployment waits until the ClusterDeployment meets the condition. The condition is passed nil once
+// the ClusterDeployment no longer exists.
+func (h *Harness) WaitForClusterDeployment(cdKey types.NamespacedName, description string, condition func(cd *hivev1.ClusterDeployment) bool) error {
+	return h.waitFor(fmt.Sprintf("ClusterDeployment %s %s", cdKey, description), func() (bool, error) {
+		cd := &hivev1.ClusterDeployment{}
+		found, err := h.get(cdKey, cd)
+		if err != nil {
+			return false, err
+		}
+		if !found {
+			return condition(nil), nil
+		}
+		return condition(cd), nil
+	})
+}
+
+// AdminKubeconfig returns a kubeconfig for the API server of envtest.
+func (h *Harness) AdminKubeconfig() ([]byte, error) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["envtest"] = &clientcmdapi.Cluster{
+		Server:                   h.Config.Host,
+		CertificateAuthorityData: h.Config.CAData,
+		InsecureSkipTLSVerify:    h.Config.Insecure,
+	}
+	config.AuthInfos["admin"] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: h.Config.CertData,
+		ClientKeyData:         h.Config.KeyData,
+		Token:                 h.Config.BearerToken,
+	}
+	config.Contexts["admin"] = &clientcmdapi.Context{Cluster: "envtest", AuthInfo: "admin"}
+	config.CurrentContext = "admin"
+	return clientcmd.Write(*config)
+}
+
+// completeJob marks the job as complete, as the job controller would once its pod succeeded.
+func (h *Harness) completeJob(job *batchv1.Job) error {
+	now := metav1.Now()
+	job.Status.Succeeded = 1
+	job.Status.CompletionTime = &now
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:               batchv1.JobComplete,
+		Status:             corev1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
+	return h.Client.Status().Update(context.TODO(), job)
+}
+
+// get gets the object, returning false if it does not exist.
+func (h *Harness) get(key types.NamespacedName, obj runtime.Object) (bool, error) {
+	switch err := h.Client.Get(context.TODO(), key, obj); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *Harness) waitFor(description string, condition wait.ConditionFunc) error {
+	if err := wait.PollImmediate(pollInterval, h.Timeout, condition); err != nil {
+		return fmt.Errorf("waiting for %s: %v", description, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package selectoryncset
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -93,6 +94,21 @@ func WithLabelSelector(labelKey, labelValue string) Option {
 	}
 }
 
+func WithLabelSelectorRequirement(labelKey string, operator metav1.LabelSelectorOperator, labelValues ...string) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ClusterDeploymentSelector.MatchExpressions = append(
+			selectorSyncSet.Spec.ClusterDeploymentSelector.MatchExpressions,
+			metav1.LabelSelectorRequirement{Key: labelKey, Operator: operator, Values: labelValues},
+		)
+	}
+}
+
+func WithExcludedClusterDeployments(refs ...hivev1.SelectorSyncSetClusterDeploymentReference) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ExcludeClusterDeployments = refs
+	}
+}
+
 func WithApplyMode(applyMode hivev1.SyncSetResourceApplyMode) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.ResourceApplyMode = applyMode
@@ -125,3 +141,15 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		selectorSyncSet.Spec.Patches = patches
 	}
 }
+
+func WithRollout(rollout *hivev1.SelectorSyncSetRollout) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.Rollout = rollout
+	}
+}
+
+func WithRolloutStatus(status *hivev1.SelectorSyncSetRolloutStatus) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Status.Rollout = status
+	}
+}
@@ -0,0 +1,176 @@
+// Package spoke provides a simulated spoke cluster for tests: a TLS server proxying the requests of the remote
+// clients of Hive to an upstream API server, such as the one of envtest, while injecting failures into the responses.
+package spoke
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Faults are the failures injected into the responses of the spoke cluster.
+type Faults struct {
+	// Latency delays every response.
+	Latency time.Duration
+	// ThrottledRequests is the number of the next requests answered with 429 Too Many Requests.
+	ThrottledRequests int
+	// RetryAfter is the Retry-After of the throttled requests, in seconds.
+	RetryAfter int
+	// UnavailableRequests is the number of the next requests answered with 503 Service Unavailable, after the
+	// throttled requests.
+	UnavailableRequests int
+}
+
+// Server is a simulated spoke cluster.
+type Server struct {
+	server *httptest.Server
+	proxy  *httputil.ReverseProxy
+
+	mutex    sync.Mutex
+	faults   Faults
+	requests int
+}
+
+// NewServer starts a spoke cluster proxying to the API server of the given configuration. The clients of the spoke
+// cluster are authenticated upstream with the credentials of the configuration.
+func NewServer(upstream *rest.Config) (*Server, error) {
+	upstreamURL, err := url.Parse(upstream.Host)
+	if err != nil {
+		return nil, err
+	}
+	if upstreamURL.Scheme == "" {
+		upstreamURL.Scheme = "https"
+	}
+	transport, err := rest.TransportFor(upstream)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		proxy: httputil.NewSingleHostReverseProxy(upstreamURL),
+	}
+	s.proxy.Transport = transport
+	// Flush the responses of watches as they are streamed.
+	s.proxy.FlushInterval = -1
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s, nil
+}
+
+// Close stops the spoke cluster.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the URL of the API of the spoke cluster.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// SetFaults replaces the failures injected into the responses.
+func (s *Server) SetFaults(faults Faults) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = faults
+}
+
+// Requests returns the number of requests received by the spoke cluster, including the failed ones.
+func (s *Server) Requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+// Kubeconfig returns a kubeconfig for the spoke cluster, trusting its serving certificate.
+func (s *Server) Kubeconfig() ([]byte, error) {
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw})
+	return s.kubeconfig(caData)
+}
+
+// UntrustedKubeconfig returns a kubeconfig for the spoke cluster which does not trust its serving certificate, so that
+// the clients using it fail to verify the certificate.
+func (s *Server) UntrustedKubeconfig() ([]byte, error) {
+	return s.kubeconfig(nil)
+}
+
+func (s *Server) kubeconfig(caData []byte) ([]byte, error) {
+	// The clients are not authenticated by the spoke cluster, the proxy authenticating them upstream.
+	return yaml.Marshal(&clientcmdv1.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: "spoke",
+			Cluster: clientcmdv1.Cluster{
+				Server:                   s.server.URL,
+				CertificateAuthorityData: caData,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{Name: "admin"}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name:    "admin",
+			Context: clientcmdv1.Context{Cluster: "spoke", AuthInfo: "admin"},
+		}},
+		CurrentContext: "admin",
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	s.requests++
+	faults := s.faults
+	var status int
+	switch {
+	case s.faults.ThrottledRequests > 0:
+		s.faults.ThrottledRequests--
+		status = http.StatusTooManyRequests
+	case s.faults.UnavailableRequests > 0:
+		s.faults.UnavailableRequests--
+		status = http.StatusServiceUnavailable
+	}
+	s.mutex.Unlock()
+
+	if faults.Latency > 0 {
+		select {
+		case <-time.After(faults.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	switch status {
+	case http.StatusTooManyRequests:
+		if faults.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(faults.RetryAfter))
+		}
+		writeStatus(w, status, metav1.StatusReasonTooManyRequests, "the spoke cluster is throttling requests")
+	case http.StatusServiceUnavailable:
+		writeStatus(w, status, metav1.StatusReasonServiceUnavailable, "the spoke cluster is unavailable")
+	default:
+		s.proxy.ServeHTTP(w, r)
+	}
+}
+
+// writeStatus writes a failure in the format of the API server, so that the clients can interpret it.
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		fmt.Fprintln(w, message)
+	}
+}
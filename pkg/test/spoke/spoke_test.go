@@ -0,0 +1,110 @@
+package spoke
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestServer(t *testing.T) {
+	cases := []struct {
+		name             string
+		faults           Faults
+		untrusted        bool
+		timeout          time.Duration
+		expectedRequests int
+		validateErr      func(*testing.T, error)
+	}{
+		{
+			name:             "no faults",
+			expectedRequests: 1,
+		},
+		{
+			name:             "throttled and retried",
+			faults:           Faults{ThrottledRequests: 1, RetryAfter: 1},
+			expectedRequests: 2,
+		},
+		{
+			name:             "throttled",
+			faults:           Faults{ThrottledRequests: 1},
+			expectedRequests: 1,
+			validateErr: func(t *testing.T, err error) {
+				assert.True(t, apierrors.IsTooManyRequests(err), "expected too many requests error, got %v", err)
+			},
+		},
+		{
+			name:             "unavailable",
+			faults:           Faults{UnavailableRequests: 1},
+			expectedRequests: 1,
+			validateErr: func(t *testing.T, err error) {
+				assert.True(t, apierrors.IsServiceUnavailable(err), "expected service unavailable error, got %v", err)
+			},
+		},
+		{
+			name:             "slow",
+			faults:           Faults{Latency: time.Second},
+			timeout:          100 * time.Millisecond,
+			expectedRequests: 1,
+			validateErr: func(t *testing.T, err error) {
+				assert.Error(t, err, "expected timeout")
+			},
+		},
+		{
+			name:      "untrusted certificate",
+			untrusted: true,
+			validateErr: func(t *testing.T, err error) {
+				assert.Contains(t, err.Error(), "certificate", "expected certificate error")
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(&corev1.Namespace{
+					TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				})
+			}))
+			defer upstream.Close()
+
+			spoke, err := NewServer(&rest.Config{Host: upstream.URL})
+			require.NoError(t, err, "unexpected error starting spoke")
+			defer spoke.Close()
+			spoke.SetFaults(tc.faults)
+
+			kubeconfig, err := spoke.Kubeconfig()
+			if tc.untrusted {
+				kubeconfig, err = spoke.UntrustedKubeconfig()
+			}
+			require.NoError(t, err, "unexpected error generating kubeconfig")
+			cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+			require.NoError(t, err, "unexpected error loading kubeconfig")
+			cfg.Timeout = tc.timeout
+			client, err := kubernetes.NewForConfig(cfg)
+			require.NoError(t, err, "unexpected error creating client")
+
+			ns, err := client.CoreV1().Namespaces().Get(context.TODO(), "default", metav1.GetOptions{})
+			if tc.validateErr != nil {
+				require.Error(t, err, "expected error")
+				tc.validateErr(t, err)
+			} else if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, "default", ns.Name, "unexpected namespace")
+			}
+			assert.Equal(t, tc.expectedRequests, spoke.Requests(), "unexpected number of requests")
+		})
+	}
+}
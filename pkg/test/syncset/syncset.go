@@ -107,6 +107,12 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithEnforcementMode(enforcementMode hivev1.SyncSetEnforcementMode) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.EnforcementMode = enforcementMode
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -127,3 +133,15 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		syncSet.Spec.Patches = patches
 	}
 }
+
+func WithResourcesFrom(sources ...hivev1.SyncSetResourcesSource) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourcesFrom = sources
+	}
+}
+
+func WithResourceTemplates() Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.EnableResourceTemplates = true
+	}
+}
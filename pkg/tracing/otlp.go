@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"sort"
+	"strconv"
+)
+
+// The types below are the subset of the OTLP/HTTP JSON encoding of ExportTraceServiceRequest used by the exporter.
+// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+
+const (
+	instrumentationScopeName = "github.com/openshift/hive/pkg/tracing"
+
+	// spanKindInternal is the kind of all the spans recorded by hive.
+	spanKindInternal = 1
+	// statusCodeOK and statusCodeError are the status codes of spans which ended without and with an error.
+	statusCodeOK    = 1
+	statusCodeError = 2
+)
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func newExportRequest(serviceName string, spans []*Span) *exportRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		otlpSpans[i] = newOTLPSpan(s)
+	}
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: keyValues(map[string]string{"service.name": serviceName}),
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: instrumentationScopeName},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func newOTLPSpan(s *Span) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.traceID.String(),
+		SpanID:            s.spanID.String(),
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        keyValues(s.attributes),
+		Status:            status{Code: statusCodeOK},
+	}
+	if s.parentID.IsValid() {
+		span.ParentSpanID = s.parentID.String()
+	}
+	if s.err != nil {
+		span.Status = status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return span
+}
+
+// keyValues returns the given attributes sorted by key.
+func keyValues(attributes map[string]string) []keyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	kvs := make([]keyValue, len(keys))
+	for i, key := range keys {
+		kvs[i] = keyValue{Key: key, Value: anyValue{StringValue: attributes[key]}}
+	}
+	return kvs
+}
@@ -0,0 +1,269 @@
+// Package tracing records spans of the work done by the Hive controllers and install jobs and exports them to an
+// OpenTelemetry collector using the OTLP/HTTP protocol with JSON encoding.
+//
+// Tracing is enabled by setting the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, which the operator sets from
+// spec.tracing.otlpEndpoint in HiveConfig. When it is not set, spans are not recorded and all functions of the
+// package are no-ops.
+//
+// The spans of an object share a trace ID derived from its namespace and name, so the reconciles of all
+// controllers for a ClusterDeployment and the phases of its install jobs are found in the same trace.
+package tracing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// tracesPath is the path of the OTLP/HTTP endpoint accepting traces.
+	tracesPath = "/v1/traces"
+
+	// maxQueuedSpans is the maximum number of ended spans waiting to be exported. Spans ended while the queue is
+	// full are dropped.
+	maxQueuedSpans = 2048
+	// maxBatchSize is the maximum number of spans exported in a single request.
+	maxBatchSize = 512
+	// exportInterval is how often the queued spans are exported.
+	exportInterval = 5 * time.Second
+	// exportTimeout is the timeout of the export requests.
+	exportTimeout = 10 * time.Second
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex encoding of the trace ID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// String returns the hex encoding of the span ID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid returns true if the span ID is not all zeros.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// TraceIDForObject returns the ID of the trace of the object with the given namespace and name.
+func TraceIDForObject(namespace, name string) TraceID {
+	sum := sha256.Sum256([]byte(namespace + "/" + name))
+	var traceID TraceID
+	copy(traceID[:], sum[:])
+	return traceID
+}
+
+// Span records the duration and outcome of an operation.
+type Span struct {
+	traceID    TraceID
+	spanID     SpanID
+	parentID   SpanID
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes map[string]string
+	err        error
+}
+
+// StartSpan starts a span with the given name in the trace of the object with the given namespace and name. The
+// span is not recorded if tracing is disabled. End must be called on the returned span.
+func StartSpan(namespace, name, spanName string) *Span {
+	return startSpan(TraceIDForObject(namespace, name), SpanID{}, spanName)
+}
+
+// StartChildSpan starts a span with the given name which is a child of the given span.
+func (s *Span) StartChildSpan(spanName string) *Span {
+	if s == nil {
+		return nil
+	}
+	return startSpan(s.traceID, s.spanID, spanName)
+}
+
+func startSpan(traceID TraceID, parentID SpanID, spanName string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	return &Span{
+		traceID:  traceID,
+		spanID:   newSpanID(),
+		parentID: parentID,
+		name:     spanName,
+		start:    time.Now(),
+	}
+}
+
+// SetAttribute sets an attribute of the span.
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	if s.attributes == nil {
+		s.attributes = map[string]string{}
+	}
+	s.attributes[key] = value
+}
+
+// End ends the span, recording the given error if not nil, and queues the span for export.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+	globalExporter.enqueue(s)
+}
+
+func newSpanID() SpanID {
+	var spanID SpanID
+	for !spanID.IsValid() {
+		if _, err := rand.Read(spanID[:]); err != nil {
+			// Fall back to the time, the IDs only need to be unique within a trace.
+			binary.BigEndian.PutUint64(spanID[:], uint64(time.Now().UnixNano()))
+		}
+	}
+	return spanID
+}
+
+var globalExporter *exporter
+
+// Init enables tracing if the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set. The spans are exported
+// with the given service name until Shutdown is called.
+func Init(serviceName string) {
+	endpoint := os.Getenv(constants.OTLPEndpointEnvVar)
+	if endpoint == "" {
+		return
+	}
+	log.WithField("endpoint", endpoint).Info("exporting traces")
+	globalExporter = newExporter(endpoint, serviceName)
+	go globalExporter.run()
+}
+
+// Enabled returns true if tracing has been enabled with Init.
+func Enabled() bool {
+	return globalExporter != nil
+}
+
+// Shutdown exports the queued spans and stops exporting spans. It should be called before exiting processes
+// which may not run for the export interval, such as the install manager.
+func Shutdown() {
+	if globalExporter == nil {
+		return
+	}
+	globalExporter.shutdown()
+	globalExporter = nil
+}
+
+// exporter exports the ended spans in batches.
+type exporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	queue       chan *Span
+	stop        chan struct{}
+	stopped     chan struct{}
+	stopOnce    sync.Once
+	logger      log.FieldLogger
+}
+
+func newExporter(endpoint, serviceName string) *exporter {
+	return &exporter{
+		url:         strings.TrimSuffix(endpoint, "/") + tracesPath,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: exportTimeout},
+		queue:       make(chan *Span, maxQueuedSpans),
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+		logger:      log.WithField("exporter", "otlp"),
+	}
+}
+
+func (e *exporter) enqueue(s *Span) {
+	if e == nil {
+		return
+	}
+	select {
+	case e.queue <- s:
+	default:
+		e.logger.Debug("span queue is full, dropping span")
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.exportQueued()
+		case <-e.stop:
+			e.exportQueued()
+			return
+		}
+	}
+}
+
+func (e *exporter) shutdown() {
+	e.stopOnce.Do(func() { close(e.stop) })
+	<-e.stopped
+}
+
+// exportQueued exports all the spans currently queued.
+func (e *exporter) exportQueued() {
+	for {
+		batch := make([]*Span, 0, maxBatchSize)
+	fill:
+		for len(batch) < maxBatchSize {
+			select {
+			case s := <-e.queue:
+				batch = append(batch, s)
+			default:
+				break fill
+			}
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			e.logger.WithError(err).WithField("spans", len(batch)).Warn("error exporting spans")
+		}
+		if len(batch) < maxBatchSize {
+			return
+		}
+	}
+}
+
+func (e *exporter) export(spans []*Span) error {
+	body, err := json.Marshal(newExportRequest(e.serviceName, spans))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
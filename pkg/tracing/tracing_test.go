@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestTraceIDForObject(t *testing.T) {
+	assert.Equal(t, TraceIDForObject("ns", "cd"), TraceIDForObject("ns", "cd"), "trace ID should be stable")
+	assert.NotEqual(t, TraceIDForObject("ns", "cd"), TraceIDForObject("ns", "other-cd"), "trace IDs should differ")
+	assert.Len(t, TraceIDForObject("ns", "cd").String(), 32, "unexpected trace ID length")
+}
+
+func TestTracingDisabled(t *testing.T) {
+	os.Unsetenv(constants.OTLPEndpointEnvVar)
+	Init("test")
+	defer Shutdown()
+	assert.False(t, Enabled(), "tracing should be disabled")
+	span := StartSpan("ns", "cd", "test")
+	assert.Nil(t, span, "no span should be started")
+	// Spans are no-ops when tracing is disabled.
+	span.SetAttribute("key", "value")
+	span.StartChildSpan("child").End(nil)
+	span.End(nil)
+}
+
+func TestExport(t *testing.T) {
+	requests := make(chan exportRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tracesPath, r.URL.Path, "unexpected path")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"), "unexpected content type")
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		request := exportRequest{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		requests <- request
+	}))
+	defer server.Close()
+
+	os.Setenv(constants.OTLPEndpointEnvVar, server.URL+"/")
+	defer os.Unsetenv(constants.OTLPEndpointEnvVar)
+	Init("test-service")
+	require.True(t, Enabled(), "tracing should be enabled")
+
+	parent := StartSpan("ns", "cd", "parent")
+	parent.SetAttribute("controller", "test")
+	child := parent.StartChildSpan("child")
+	child.End(errors.New("child failed"))
+	parent.End(nil)
+	Shutdown()
+	assert.False(t, Enabled(), "tracing should be disabled after shutdown")
+
+	var request exportRequest
+	select {
+	case request = <-requests:
+	default:
+		t.Fatal("no spans exported")
+	}
+	require.Len(t, request.ResourceSpans, 1)
+	resourceSpans := request.ResourceSpans[0]
+	assert.Equal(t, []keyValue{{Key: "service.name", Value: anyValue{StringValue: "test-service"}}}, resourceSpans.Resource.Attributes)
+	require.Len(t, resourceSpans.ScopeSpans, 1)
+	spans := resourceSpans.ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	traceID := TraceIDForObject("ns", "cd").String()
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "child", childSpan.Name)
+	assert.Equal(t, traceID, childSpan.TraceID)
+	assert.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	assert.Equal(t, status{Code: statusCodeError, Message: "child failed"}, childSpan.Status)
+	assert.Equal(t, "parent", parentSpan.Name)
+	assert.Equal(t, traceID, parentSpan.TraceID)
+	assert.Empty(t, parentSpan.ParentSpanID, "root span should not have a parent")
+	assert.Equal(t, status{Code: statusCodeOK}, parentSpan.Status)
+	assert.Equal(t, []keyValue{{Key: "controller", Value: anyValue{StringValue: "test"}}}, parentSpan.Attributes)
+	assert.NotEqual(t, "0", parentSpan.StartTimeUnixNano)
+	assert.NotEqual(t, "0", parentSpan.EndTimeUnixNano)
+}
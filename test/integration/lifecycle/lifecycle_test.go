@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clusterdeployment"
+	"github.com/openshift/hive/pkg/platform/fake"
+	"github.com/openshift/hive/pkg/test/lifecycle"
+)
+
+const (
+	testNamespace     = "lifecycle"
+	testName          = "test-cluster"
+	testInstallConfig = `apiVersion: v1
+metadata:
+  name: test-cluster
+baseDomain: example.com
+platform:
+  aws:
+    region: us-east-1
+`
+)
+
+var harness *lifecycle.Harness
+
+func TestMain(m *testing.M) {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetLevel(logrus.DebugLevel)
+
+	var err error
+	if harness, err = lifecycle.Start(filepath.Join("..", "..", "..", "config", "crds")); err != nil {
+		log.Fatal(err)
+	}
+	restore := fake.Override(&fake.Actuator{
+		PlatformName: constants.PlatformAWS,
+		Deprovision: &hivev1.ClusterDeprovisionPlatform{
+			AWS: &hivev1.AWSClusterDeprovision{Region: "us-east-1"},
+		},
+	})
+	if err := harness.StartControllers(clusterdeployment.Add); err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	restore()
+	harness.Stop()
+	os.Exit(code)
+}
+
+func TestClusterDeploymentLifecycle(t *testing.T) {
+	c := harness.Client
+	ctx := context.TODO()
+	key := types.NamespacedName{Namespace: testNamespace, Name: testName}
+
+	require.NoError(t, c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}))
+	require.NoError(t, c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pull-secret"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+	}))
+	require.NoError(t, c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-config"},
+		Data:       map[string][]byte{"install-config.yaml": []byte(testInstallConfig)},
+	}))
+	require.NoError(t, c.Create(ctx, &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        testName,
+			Annotations: map[string]string{constants.HiveFakeClusterAnnotation: "true"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			BaseDomain:  "example.com",
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               "us-east-1",
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+				},
+			},
+			PullSecretRef: &corev1.LocalObjectReference{Name: "pull-secret"},
+			Provisioning: &hivev1.Provisioning{
+				ReleaseImage:           "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64",
+				InstallConfigSecretRef: corev1.LocalObjectReference{Name: "install-config"},
+			},
+		},
+	}))
+
+	require.NoError(t, harness.ResolveImages(key, "example.com/installer:latest", "example.com/cli:latest"))
+	require.NoError(t, harness.CompleteProvision(key, "test-infra-id", "test-cluster-id"))
+	require.NoError(t, harness.WaitForClusterDeployment(key, "to be installed", func(cd *hivev1.ClusterDeployment) bool {
+		return cd != nil && cd.Spec.Installed &&
+			cd.Spec.ClusterMetadata != nil && cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name != ""
+	}))
+
+	cd := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(ctx, key, cd))
+	require.NoError(t, c.Delete(ctx, cd))
+	require.NoError(t, harness.CompleteDeprovision(key))
+	require.NoError(t, harness.WaitForClusterDeployment(key, "to be deleted", func(cd *hivev1.ClusterDeployment) bool {
+		return cd == nil
+	}))
+}
@@ -129,7 +129,7 @@ func TestApply(t *testing.T) {
 				for _, obj := range test.existing {
 					o := obj.DeepCopyObject()
 					accessor.SetNamespace(o, namespace.Name)
-					_, err := h.ApplyRuntimeObject(o, scheme.Scheme)
+					_, err := h.ApplyRuntimeObject(context.TODO(), o, scheme.Scheme)
 					if err != nil {
 						t.Fatalf("unexpected err: %v", err)
 					}
@@ -141,18 +141,21 @@ func TestApply(t *testing.T) {
 					return
 				}
 				t.Logf("The serialized resource:\n%s\n", string(data))
-				info, err := h.Info(data)
+				info, err := h.Info(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling info: %v", err)
 					return
 				}
-				applyResult, err := h.Apply(data)
+				applyResult, err := h.Apply(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling apply: %v", err)
 					return
 				}
-				if applyResult != test.expectedResult {
-					t.Errorf("unexpected apply result: %v", applyResult)
+				if applyResult.Result != test.expectedResult {
+					t.Errorf("unexpected apply result: %v", applyResult.Result)
+				}
+				if applyResult.Name != info.Name || applyResult.Kind != info.Kind || applyResult.ResourceVersion == "" {
+					t.Errorf("unexpected applied object: %+v", applyResult)
 				}
 				test.validate(t, info, namespace.Name)
 			})
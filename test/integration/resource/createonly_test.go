@@ -99,7 +99,7 @@ func TestCreateOnly(t *testing.T) {
 				for _, obj := range test.existing {
 					o := obj.DeepCopyObject()
 					accessor.SetNamespace(o, namespace.Name)
-					_, err := h.CreateRuntimeObject(o, scheme.Scheme)
+					_, err := h.CreateRuntimeObject(context.TODO(), o, scheme.Scheme)
 					if err != nil {
 						t.Fatalf("unexpected err: %v", err)
 					}
@@ -112,18 +112,21 @@ func TestCreateOnly(t *testing.T) {
 				}
 
 				t.Logf("The serialized resource:\n%s\n", string(data))
-				info, err := h.Info(data)
+				info, err := h.Info(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling info: %v", err)
 					return
 				}
-				applyResult, err := h.Create(data)
+				applyResult, err := h.Create(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling apply: %v", err)
 					return
 				}
-				if applyResult != test.expectedResult {
-					t.Errorf("unexpected apply result: %v", applyResult)
+				if applyResult.Result != test.expectedResult {
+					t.Errorf("unexpected apply result: %v", applyResult.Result)
+				}
+				if applyResult.Name != info.Name || applyResult.Kind != info.Kind || applyResult.ResourceVersion == "" {
+					t.Errorf("unexpected applied object: %+v", applyResult)
 				}
 				test.validate(t, info, namespace.Name)
 			})
@@ -114,7 +114,7 @@ func TestCreateOrUpdate(t *testing.T) {
 				for _, obj := range test.existing {
 					o := obj.DeepCopyObject()
 					accessor.SetNamespace(o, namespace.Name)
-					_, err := h.CreateOrUpdateRuntimeObject(o, scheme.Scheme)
+					_, err := h.CreateOrUpdateRuntimeObject(context.TODO(), o, scheme.Scheme)
 					if err != nil {
 						t.Fatalf("unexpected err: %v", err)
 					}
@@ -125,18 +125,21 @@ func TestCreateOrUpdate(t *testing.T) {
 					t.Errorf("unexpected error calling setnamespace: %v", err)
 				}
 				t.Logf("The serialized resource:\n%s\n", string(data))
-				info, err := h.Info(data)
+				info, err := h.Info(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling info: %v", err)
 					return
 				}
-				applyResult, err := h.CreateOrUpdate(data)
+				applyResult, err := h.CreateOrUpdate(context.TODO(), data)
 				if err != nil {
 					t.Errorf("unexpected error calling apply: %v", err)
 					return
 				}
-				if applyResult != test.expectedResult {
-					t.Errorf("unexpected apply result: %v", applyResult)
+				if applyResult.Result != test.expectedResult {
+					t.Errorf("unexpected apply result: %v", applyResult.Result)
+				}
+				if applyResult.Name != info.Name || applyResult.Kind != info.Kind || applyResult.ResourceVersion == "" {
+					t.Errorf("unexpected applied object: %+v", applyResult)
 				}
 				test.validate(t, info, namespace.Name)
 			})
@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/pkg/resource"
+)
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+		opts     []resource.DeleteOption
+	}{
+		{
+			name:     "delete existing resource",
+			existing: true,
+		},
+		{
+			name:     "delete existing resource in the foreground",
+			existing: true,
+			opts:     []resource.DeleteOption{resource.WithPropagationPolicy(metav1.DeletePropagationForeground)},
+		},
+		{
+			name: "delete missing resource",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := log.WithField("test", test.name)
+			namespace := &corev1.Namespace{}
+			namespace.GenerateName = "delete-test-"
+			err := c.Create(context.TODO(), namespace)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			cm := testConfigMap()
+			cm.Namespace = namespace.Name
+			if test.existing {
+				if err := c.Create(context.TODO(), cm); err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+			}
+			h, err := resource.NewHelperFromRESTConfig(cfg, logger)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if err := h.Delete(context.TODO(), "v1", "ConfigMap", namespace.Name, cm.Name, test.opts...); err != nil {
+				t.Errorf("unexpected error calling delete: %v", err)
+				return
+			}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: cm.Name}, &corev1.ConfigMap{})
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("expected configmap to be deleted, got: %v", err)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func TestInfo(t *testing.T) {
 	}
 	h, err := resource.NewHelper(kubeconfig, logger)
 	require.NoError(t, err)
-	i, err := h.Info([]byte(`apiVersion: v1
+	i, err := h.Info(context.TODO(), []byte(`apiVersion: v1
 kind: ConfigMap
 metadata:
   name: test-configmap
@@ -78,7 +78,7 @@ func TestPatch(t *testing.T) {
 				t.Errorf("unexpected err creating resource Helper: %v", err)
 				return
 			}
-			err = h.Patch(types.NamespacedName{Namespace: namespace.Name, Name: cm.Name}, "ConfigMap", "v1", []byte(test.patch), test.patchType)
+			err = h.Patch(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: cm.Name}, "ConfigMap", "v1", []byte(test.patch), test.patchType)
 			if err != nil {
 				t.Errorf("unexpected error calling patch: %v", err)
 				return
@@ -0,0 +1,336 @@
+package spoke
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clusterstate"
+	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/controller/remotemachineset"
+	"github.com/openshift/hive/pkg/test/lifecycle"
+	"github.com/openshift/hive/pkg/test/spoke"
+)
+
+const (
+	machineAPINamespace  = "openshift-machine-api"
+	clusterSyncStsName   = "hive-clustersync"
+	clusterSyncPodName   = "hive-clustersync-0"
+	masterMachineTypeKey = "machine.openshift.io/cluster-api-machine-type"
+	machinePoolNameLabel = "hive.openshift.io/machine-pool"
+	pollInterval         = 250 * time.Millisecond
+)
+
+var (
+	harness *lifecycle.Harness
+	// spokeClient creates the objects of the simulated spoke clusters, which are served by the API server of envtest.
+	spokeClient client.Client
+)
+
+func TestMain(m *testing.M) {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetLevel(logrus.DebugLevel)
+
+	// The clustersync controller only syncs the clusters assigned to its pod of the clustersync statefulset.
+	os.Setenv("HIVE_CLUSTERSYNC_POD_NAME", clusterSyncPodName)
+
+	var err error
+	if harness, err = lifecycle.Start(
+		filepath.Join("..", "..", "..", "config", "crds"),
+		filepath.Join("testdata", "crds"),
+	); err != nil {
+		log.Fatal(err)
+	}
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	configv1.Install(scheme)
+	machineapi.AddToScheme(scheme)
+	if spokeClient, err = client.New(harness.Config, client.Options{Scheme: scheme}); err != nil {
+		log.Fatal(err)
+	}
+	if err := createClusterSyncStatefulSet(); err != nil {
+		log.Fatal(err)
+	}
+	if err := harness.StartControllers(clustersync.Add, clusterstate.Add, remotemachineset.Add); err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	harness.Stop()
+	os.Exit(code)
+}
+
+func TestSyncSetAppliedToSpoke(t *testing.T) {
+	// The clients retry the throttled requests after the Retry-After of the responses.
+	server := startSpoke(t, spoke.Faults{ThrottledRequests: 3, RetryAfter: 1})
+	defer server.Close()
+	key := createInstalledClusterDeployment(t, "spoke-syncset", server, hivev1.Platform{})
+
+	ctx := context.TODO()
+	targetNamespace := "spoke-syncset-target"
+	require.NoError(t, spokeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNamespace}}))
+	require.NoError(t, harness.Client.Create(ctx, &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: "test-syncset"},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				Resources: []runtime.RawExtension{{Object: &corev1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: targetNamespace, Name: "test-configmap"},
+					Data:       map[string]string{"key": "value"},
+				}}},
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: key.Name}},
+		},
+	}))
+
+	configMap := &corev1.ConfigMap{}
+	waitFor(t, "the syncset to be applied to the spoke cluster", func() (bool, error) {
+		return get(spokeClient, types.NamespacedName{Namespace: targetNamespace, Name: "test-configmap"}, configMap)
+	})
+	assert.Equal(t, map[string]string{"key": "value"}, configMap.Data, "unexpected data of the applied configmap")
+
+	waitFor(t, "the ClusterSync to report the syncset applied", func() (bool, error) {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		found, err := get(harness.Client, key, clusterSync)
+		if !found || err != nil {
+			return false, err
+		}
+		return len(clusterSync.Status.SyncSets) == 1 &&
+			clusterSync.Status.SyncSets[0].Result == hiveintv1alpha1.SuccessSyncSetResult, nil
+	})
+	assert.Greater(t, server.Requests(), 3, "expected the throttled requests to be retried")
+}
+
+func TestClusterStateFromSpoke(t *testing.T) {
+	server := startSpoke(t, spoke.Faults{Latency: 100 * time.Millisecond})
+	defer server.Close()
+
+	ctx := context.TODO()
+	operator := &configv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: "test-operator"}}
+	require.NoError(t, spokeClient.Create(ctx, operator))
+	operator.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+		Type:               configv1.OperatorAvailable,
+		Status:             configv1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}}
+	require.NoError(t, spokeClient.Update(ctx, operator))
+
+	key := createInstalledClusterDeployment(t, "spoke-clusterstate", server, hivev1.Platform{})
+	waitForClusterState(t, key)
+
+	waitFor(t, "the ClusterState to report the operators of the spoke cluster", func() (bool, error) {
+		state := &hivev1.ClusterState{}
+		found, err := get(harness.Client, key, state)
+		if !found || err != nil {
+			return false, err
+		}
+		for _, operatorState := range state.Status.ClusterOperators {
+			if operatorState.Name == operator.Name {
+				return len(operatorState.Conditions) == 1 && operatorState.Conditions[0].Type == configv1.OperatorAvailable, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+func TestMachinePoolSyncedToSpoke(t *testing.T) {
+	server := startSpoke(t, spoke.Faults{})
+	defer server.Close()
+
+	ctx := context.TODO()
+	require.NoError(t, spokeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: machineAPINamespace}}))
+	providerSpec, err := json.Marshal(map[string]string{
+		"apiVersion": "vsphereprovider.openshift.io/v1beta1",
+		"kind":       "VSphereMachineProviderSpec",
+		"template":   "test-image",
+	})
+	require.NoError(t, err)
+	require.NoError(t, spokeClient.Create(ctx, &machineapi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: machineAPINamespace,
+			Name:      "test-infra-id-master-0",
+			Labels:    map[string]string{masterMachineTypeKey: "master"},
+		},
+		Spec: machineapi.MachineSpec{
+			ProviderSpec: machineapi.ProviderSpec{Value: &runtime.RawExtension{Raw: providerSpec}},
+		},
+	}))
+
+	key := createInstalledClusterDeployment(t, "spoke-machinepool", server, hivev1.Platform{
+		VSphere: &hivev1vsphere.Platform{
+			VCenter:               "vcenter.example.com",
+			CredentialsSecretRef:  corev1.LocalObjectReference{Name: "vsphere-creds"},
+			CertificatesSecretRef: corev1.LocalObjectReference{Name: "vsphere-certs"},
+			Datacenter:            "test-datacenter",
+			DefaultDatastore:      "test-datastore",
+			Network:               "test-network",
+		},
+	})
+	require.NoError(t, harness.Client.Create(ctx, &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name + "-worker"},
+		Spec: hivev1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: key.Name},
+			Name:                 "worker",
+			Replicas:             pointer.Int64Ptr(2),
+			Platform: hivev1.MachinePoolPlatform{
+				VSphere: &hivev1vsphere.MachinePool{
+					NumCPUs:           4,
+					NumCoresPerSocket: 2,
+					MemoryMiB:         16384,
+					OSDisk:            hivev1vsphere.OSDisk{DiskSizeGB: 120},
+				},
+			},
+		},
+	}))
+
+	machineSet := &machineapi.MachineSet{}
+	waitFor(t, "the MachineSet of the machine pool in the spoke cluster", func() (bool, error) {
+		return get(spokeClient, types.NamespacedName{Namespace: machineAPINamespace, Name: "test-infra-id-worker"}, machineSet)
+	})
+	assert.Equal(t, "worker", machineSet.Labels[machinePoolNameLabel], "unexpected machine pool of the MachineSet")
+	if assert.NotNil(t, machineSet.Spec.Replicas, "expected replicas on the MachineSet") {
+		assert.Equal(t, int32(2), *machineSet.Spec.Replicas, "unexpected replicas of the MachineSet")
+	}
+}
+
+func TestUnavailableSpokeIsUnreachable(t *testing.T) {
+	server := startSpoke(t, spoke.Faults{UnavailableRequests: 1000})
+	defer server.Close()
+	key := createInstalledClusterDeployment(t, "spoke-unreachable", server, hivev1.Platform{})
+	waitForClusterState(t, key)
+
+	require.NoError(t, harness.WaitForClusterDeployment(key, "to be unreachable", func(cd *hivev1.ClusterDeployment) bool {
+		for _, cond := range cd.Status.Conditions {
+			if cond.Type == hivev1.UnreachableCondition {
+				return cond.Status == corev1.ConditionTrue
+			}
+		}
+		return false
+	}))
+	state := &hivev1.ClusterState{}
+	require.NoError(t, harness.Client.Get(context.TODO(), key, state))
+	assert.Empty(t, state.Status.ClusterOperators, "expected no operators from an unavailable spoke cluster")
+}
+
+// createClusterSyncStatefulSet creates the statefulset of the clustersync controllers, with the single replica of
+// the controller run by the test.
+func createClusterSyncStatefulSet() error {
+	ctx := context.TODO()
+	if err := harness.Client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.DefaultHiveNamespace}}); err != nil {
+		return err
+	}
+	labels := map[string]string{"app": clusterSyncStsName}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: clusterSyncStsName},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    pointer.Int32Ptr(1),
+			Selector:    &metav1.LabelSelector{MatchLabels: labels},
+			ServiceName: clusterSyncStsName,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "clustersync", Image: "example.com/hive:latest"}},
+				},
+			},
+		},
+	}
+	if err := harness.Client.Create(ctx, sts); err != nil {
+		return err
+	}
+	sts.Status.Replicas = 1
+	sts.Status.CurrentReplicas = 1
+	return harness.Client.Status().Update(ctx, sts)
+}
+
+// startSpoke starts a spoke cluster served by the API server of envtest, injecting the faults into its responses.
+func startSpoke(t *testing.T, faults spoke.Faults) *spoke.Server {
+	server, err := spoke.NewServer(harness.Config)
+	require.NoError(t, err, "could not start spoke cluster")
+	server.SetFaults(faults)
+	return server
+}
+
+// createInstalledClusterDeployment creates an installed ClusterDeployment in a new namespace, with an admin kubeconfig
+// for the spoke cluster.
+func createInstalledClusterDeployment(t *testing.T, namespace string, server *spoke.Server, platform hivev1.Platform) types.NamespacedName {
+	ctx := context.TODO()
+	kubeconfig, err := server.Kubeconfig()
+	require.NoError(t, err, "could not get kubeconfig of spoke cluster")
+	require.NoError(t, harness.Client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}))
+	require.NoError(t, harness.Client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "admin-kubeconfig"},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}))
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "test-cluster"},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "test-cluster",
+			BaseDomain:  "example.com",
+			Platform:    platform,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                "test-cluster-id",
+				InfraID:                  "test-infra-id",
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "admin-kubeconfig"},
+				AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: "admin-password"},
+			},
+		},
+	}
+	require.NoError(t, harness.Client.Create(ctx, cd))
+	return types.NamespacedName{Namespace: namespace, Name: cd.Name}
+}
+
+// waitForClusterState waits for the clusterstate controller to create the ClusterState of the ClusterDeployment, then
+// updates the ClusterDeployment, as the clusterdeployment controller would, so that the clusterstate controller
+// reconciles it again and fetches the operators of the spoke cluster.
+func waitForClusterState(t *testing.T, key types.NamespacedName) {
+	waitFor(t, "the ClusterState to be created", func() (bool, error) {
+		return get(harness.Client, key, &hivev1.ClusterState{})
+	})
+	cd := &hivev1.ClusterDeployment{}
+	require.NoError(t, harness.Client.Get(context.TODO(), key, cd))
+	cd.Annotations = map[string]string{"hive.openshift.io/test-reconcile": "true"}
+	require.NoError(t, harness.Client.Update(context.TODO(), cd))
+}
+
+// get gets the object, returning false if it does not exist.
+func get(c client.Client, key types.NamespacedName, obj runtime.Object) (bool, error) {
+	switch err := c.Get(context.TODO(), key, obj); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+func waitFor(t *testing.T, description string, condition wait.ConditionFunc) {
+	require.NoError(t, wait.PollImmediate(pollInterval, harness.Timeout, condition), "timed out waiting for %s", description)
+}